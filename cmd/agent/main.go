@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -9,17 +10,43 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/kserve/kserve/pkg/agent"
 	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/agentconfig"
+	"github.com/kserve/kserve/pkg/allowedhosts"
+	"github.com/kserve/kserve/pkg/apikey"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/async"
+	"github.com/kserve/kserve/pkg/auth"
 	"github.com/kserve/kserve/pkg/batcher"
+	"github.com/kserve/kserve/pkg/circuitbreaker"
+	"github.com/kserve/kserve/pkg/concurrency"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/deprecation"
+	"github.com/kserve/kserve/pkg/fips"
+	"github.com/kserve/kserve/pkg/grpcproxy"
 	kfslogger "github.com/kserve/kserve/pkg/logger"
+	"github.com/kserve/kserve/pkg/maintenance"
+	"github.com/kserve/kserve/pkg/modelselect"
+	"github.com/kserve/kserve/pkg/openapi"
+	"github.com/kserve/kserve/pkg/payload"
+	"github.com/kserve/kserve/pkg/predictionsink"
+	"github.com/kserve/kserve/pkg/ratelimit"
+	"github.com/kserve/kserve/pkg/tenant"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+	authv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	"k8s.io/client-go/rest"
 	network "knative.dev/networking/pkg"
 	pkglogging "knative.dev/pkg/logging"
 	pkgnet "knative.dev/pkg/network"
@@ -34,22 +61,106 @@ var (
 	port          = flag.String("port", "9081", "Agent port")
 	componentPort = flag.String("component-port", "8080", "Component port")
 	// model puller flags
-	enablePuller = flag.Bool("enable-puller", false, "Enable model puller")
-	configDir    = flag.String("config-dir", "/mnt/configs", "directory for model config files")
-	modelDir     = flag.String("model-dir", "/mnt/models", "directory for model files")
+	enablePuller       = flag.Bool("enable-puller", false, "Enable model puller")
+	configDir          = flag.String("config-dir", "/mnt/configs", "directory for model config files")
+	modelDir           = flag.String("model-dir", "/mnt/models", "directory for model files")
+	cacheUri           = flag.String("cache-storage-uri", "", "s3://<bucket>/<prefix> location the downloaded models are cached in, shared with the other agents, disabled when empty")
+	cacheMaxAge        = flag.Duration("cache-max-age", 0, "Duration the cached models are kept for since they were last used, kept forever when 0")
+	readinessPolicy    = flag.String("readiness-policy", "", "When the server is ready while the models load, one of always, min-models or initial-models. The agent starts once the initial models are processed when empty")
+	readinessMinModels = flag.Int("readiness-min-models", 1, "Number of models loaded for the server to be ready with the min-models readiness policy")
+	modelStatusPeriod  = flag.Duration("model-status-period", 10*time.Second, "Period the load states of the models are polled from the repository index of the model server")
 	// logger flags
-	logUrl           = flag.String("log-url", "", "The URL to send request/response logs to")
-	workers          = flag.Int("workers", 5, "Number of workers")
-	sourceUri        = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
-	logMode          = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
-	inferenceService = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
-	namespace        = flag.String("namespace", "", "The namespace to add as header to log events")
-	endpoint         = flag.String("endpoint", "", "The endpoint name to add as header to log events")
-	component        = flag.String("component", "", "The component name (predictor, explainer, transformer) to add as header to log events")
+	logUrl             = flag.String("log-url", "", "The URL to send request/response logs to")
+	workers            = flag.Int("workers", 5, "Number of workers")
+	sourceUri          = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
+	logMode            = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
+	logDelivery        = flag.String("log-delivery", string(v1beta1.LogAtMostOnce), "Whether to deliver the log events 'at-most-once' or 'at-least-once'")
+	logAttempts        = flag.Int("log-delivery-attempts", kfslogger.DefaultDeliveryAttempts, "Number of attempts to send a log event delivered at least once")
+	logEncoding        = flag.String("log-encoding", "binary", "Whether to send the log events in the 'binary' or 'structured' CloudEvents mode")
+	logEventType       = flag.String("log-event-type", "", "Template of the type of the log events, the default types are kept when empty")
+	logEventSource     = flag.String("log-event-source", "", "Template of the source of the log events, the source-uri is kept when empty")
+	logSchemaVersion   = flag.String("log-schema-version", "", "The schema version to add as schemaversion attribute to log events")
+	logSamplingPercent = flag.Int("log-sampling-percent", 100, "Percentage of the requests whose payloads are logged, between 0 and 100")
+	logOptOutHeader    = flag.String("log-opt-out-header", "", "Header opting a request out of the payload logging when it is set to true, disabled when empty")
+	logResponseCodes   = flag.String("log-response-codes", "", "Comma separated response status codes or classes, e.g. 5xx, of the requests whose payloads are logged, only the 200 responses are logged when empty")
+	inferenceService   = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
+	namespace          = flag.String("namespace", "", "The namespace to add as header to log events")
+	endpoint           = flag.String("endpoint", "", "The endpoint name to add as header to log events")
+	component          = flag.String("component", "", "The component name (predictor, explainer, transformer) to add as header to log events")
+	// log encryption flags
+	logEncryptionKeyFile = flag.String("log-encryption-key-file", "", "File holding the base64 encoded static key encryption key used to envelope encrypt the logged payloads")
+	logEncryptionKeyId   = flag.String("log-encryption-key-id", "", "The id of the encryption key to add as header to encrypted log events")
+	logEncryptionKMSKey  = flag.String("log-encryption-kms-key", "", "Reference of the KMS key used to envelope encrypt the logged payloads, such as aws-kms://<key arn>")
+	// log transform flags
+	logTransformUrl = flag.String("log-transform-url", "", "URL of the webhook transforming the logged payloads before they are sent, disabled when empty")
+	logHashFields   = flag.String("log-hash-fields", "", "Comma separated names of the JSON fields whose values are hashed before the logged payloads are sent")
+	logHashKeyFile  = flag.String("log-hash-key-file", "", "File holding the base64 encoded key the JSON fields are hashed with, hashed with SHA-256 when empty")
+	logRedact       = flag.String("log-redact", "", "JSON encoded redaction, the JSON paths, patterns and redactors of the values masked before the logged payloads are sent")
+	// Logger retry queue
+	logRetryDir        = flag.String("log-retry-dir", "/mnt/logger-retry", "Directory the log events the logger sink did not accept are queued in until they are delivered")
+	logRetryBufferSize = flag.String("log-retry-buffer-size", "1Gi", "Maximum size of the queued log events, the log events above it are exported to the dead letter store")
+	logRetryMaxAge     = flag.Duration("log-retry-max-age", 0, "Duration the log events the logger sink did not accept are retried for, disabled when 0")
+	logDeadLetterUri   = flag.String("log-dead-letter-uri", "", "s3://<bucket>/<prefix> location the expired log events are exported to, dropped when empty")
+	// Logger blob sink, enabled by a s3://, gs:// or https://<account>.blob.core.windows.net log-url
+	logBlobBatchSize     = flag.Int("log-blob-batch-size", kfslogger.DefaultBlobBatchSize, "Number of log events written together to the blob storage")
+	logBlobFlushInterval = flag.Duration("log-blob-flush-interval", kfslogger.DefaultBlobFlushInterval, "Maximum duration the log events wait before they are written to the blob storage")
+	// prediction sink flags
+	predictionSinkUrl    = flag.String("prediction-sink-url", "", "The URL of the webhook or Knative KafkaSink to publish the predictions to, disabled when empty")
+	predictionBufferDir  = flag.String("prediction-buffer-dir", "/mnt/prediction-buffer", "Directory the predictions are buffered in until the sink acknowledged them")
+	predictionBufferSize = flag.String("prediction-buffer-size", "1Gi", "Maximum size of the buffered predictions, the predictions above it are dropped")
 	// batcher flags
-	enableBatcher = flag.Bool("enable-batcher", false, "Enable request batcher")
-	maxBatchSize  = flag.String("max-batchsize", "32", "Max Batch Size")
-	maxLatency    = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	payloadUriPrefixes = flag.StringSlice("payload-uri-prefixes", nil, "Comma separated s3:// or gs:// prefixes the payloads passed by reference in the X-Kserve-Payload-Uri header are read from, disabled when empty")
+	payloadMaxSize     = flag.String("payload-max-size", "1Gi", "Maximum size of the payloads passed by reference")
+	payloadResponseUri = flag.String("payload-response-uri", "", "s3:// or gs:// prefix the responses to the payloads passed by reference are written under, returned inline when empty")
+
+	enableAsync      = flag.Bool("enable-async", false, "Answer the requests with the Prefer: respond-async header with an operation id and run them in the background")
+	asyncWorkers     = flag.Int("async-workers", 1, "Number of asynchronous requests sent to the component concurrently")
+	asyncQueueSize   = flag.Int("async-queue-size", 100, "Number of asynchronous requests queued, the requests above it are rejected")
+	asyncResultTTL   = flag.Duration("async-result-ttl", time.Hour, "Duration the responses of the asynchronous requests are kept for polling")
+	asyncCallbackUrl = flag.String("async-callback-url", "", "The URL of the webhook or CloudEvents sink the responses of the asynchronous requests are sent to, disabled when empty")
+	asyncBrokerUrl   = flag.String("async-broker-url", "", "The redis:// or rediss:// URL of the Redis server the asynchronous requests are queued in and shared by the replicas, queued in memory when empty")
+
+	enableBatcher       = flag.Bool("enable-batcher", false, "Enable request batcher")
+	maxBatchSize        = flag.String("max-batchsize", "32", "Max Batch Size")
+	maxLatency          = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	targetP99Latency    = flag.String("target-p99-latency", "", "Target p99 latency in milliseconds of the model server the batch size is adapted to, the batch size is fixed to the max batch size when empty")
+	batchPriorityHeader = flag.String("batch-priority-header", "", "Header sending a request to the model server without waiting for a batch when it is set to true, disabled when empty")
+	pipelineOrder       = flag.String("pipeline-order", constants.AgentPipelineLogBatch, "Order of the logger and the batcher, log-batch logs the requests before they are batched and batch-log logs the batched requests")
+	// agent config flags
+	agentConfigFile = flag.String("agent-config-file", "", "File of the logger and batcher configuration reloaded without a restart, overrides their flags")
+	// openapi flags
+	enableOpenAPI = flag.Bool("enable-openapi", false, "Enable serving the OpenAPI specification of the model")
+	modelName     = flag.String("model-name", "", "The model name to generate the OpenAPI specification for")
+	// rate limit flags
+	rateLimitRPS   = flag.Int("rate-limit-rps", 0, "Number of requests per second sent to the component, the requests above the limit are rejected, disabled when 0")
+	rateLimitBurst = flag.Int("rate-limit-burst", 0, "Number of requests allowed above the rate limit, defaults to the rate")
+	// circuit breaker flags
+	failureThreshold = flag.Int("circuit-breaker-failure-threshold", 0, "Number of consecutive model server failures opening the circuit breaker, disabled when 0")
+	openDuration     = flag.Duration("circuit-breaker-open-duration", 30*time.Second, "Duration the circuit breaker stays open before letting a probe request through")
+	retryBudget      = flag.Int("retry-budget-percentage", 0, "Percentage of the requests the transient model server failures are retried for, disabled when 0")
+	// auth flags
+	tokenAudience = flag.String("token-audience", "", "Require a service account token for the given audience on every request")
+	// api key flags
+	apiKeysDir = flag.String("api-keys-dir", "", "Directory of the mounted API key store, requires a valid key in the X-API-Key header on every request")
+	// usage flags
+	tenantHeader = flag.String("tenant-header", "", "Request header identifying the tenant, the requests are counted per tenant")
+	// deprecation flags
+	deprecationDate        = flag.String("deprecation", "", "RFC3339 timestamp the endpoint is deprecated at, enables the Deprecation response header")
+	sunsetDate             = flag.String("sunset", "", "RFC3339 timestamp the endpoint is sunset at, enables the Sunset response header")
+	sunsetRejectPercentage = flag.Int("sunset-reject-percentage", 0, "Percentage of the requests rejected once the sunset has passed")
+	deprecationLink        = flag.String("deprecation-link", "", "URL documenting the deprecation, added as Link response header")
+	// model selection flags
+	modelNames = flag.StringToString("model-names", nil, "Comma separated <external>=<internal> model names, the X-Kserve-Model header selects the internal model or adapter of the model server by its external name")
+	// allowed hosts flags
+	allowedHosts = flag.StringSlice("allowed-hosts", nil, "Comma separated list of hosts the requests are allowed for, requests for other hosts are rejected")
+	// metrics flags
+	metricsPort              = flag.String("metrics-port", "", "Port to serve the agent prometheus metrics on, disabled when empty")
+	enableConcurrencyMetrics = flag.Bool("enable-concurrency-metrics", false, "Report the number of requests in flight for the autoscaler")
+	// grpc flags
+	enableGrpc = flag.Bool("enable-grpc", false, "Serve the gRPC health and reflection services of a gRPC component, the other gRPC calls are proxied to the component port")
+	// tls flags
+	tlsMinVersion   = flag.String("tls-min-version", "", "The minimum TLS version of outgoing connections, e.g. 1.2")
+	tlsCipherSuites = flag.String("tls-cipher-suites", "", "Comma separated list of TLS cipher suites allowed for outgoing connections")
 	// probing flags
 	readinessProbeTimeout = flag.Duration("probe-period", -1, "run readiness probe with given timeout")
 	// This creates an abstract socket instead of an actual file.
@@ -89,11 +200,99 @@ type loggerArgs struct {
 	namespace        string
 	endpoint         string
 	component        string
+	encryptor        *kfslogger.Encryptor
+	format           *kfslogger.EventFormat
+	transformer      kfslogger.Transformer
+	filter           *kfslogger.Filter
+	retries          *kfslogger.RetryQueue
+	blobSink         *kfslogger.BlobSink
+	handler          *kfslogger.LoggerHandler
+}
+
+// newHandler creates the logger handler of the agent, it is kept to reload the logger configuration
+func (args *loggerArgs) newHandler(next http.Handler) http.Handler {
+	args.handler = kfslogger.New(args.logUrl, args.sourceUrl, args.loggerType, args.inferenceService, args.namespace,
+		args.endpoint, args.component, args.encryptor, args.format, args.transformer, args.filter, next)
+	return args.handler
+}
+
+type predictionSinkArgs struct {
+	buffer *predictionsink.Buffer
+}
+
+type payloadArgs struct {
+	store       *storage.PayloadStore
+	prefixes    []string
+	maxSize     int64
+	responseUri string
+}
+
+type asyncArgs struct {
+	workers  int
+	store    async.Store
+	notifier *async.Notifier
 }
 
 type batcherArgs struct {
-	maxBatchSize int
-	maxLatency   int
+	maxBatchSize     int
+	maxLatency       int
+	targetP99Latency int
+	priorityHeader   string
+	logBatched       bool
+	handler          *batcher.BatchHandler
+}
+
+type openAPIArgs struct {
+	modelName string
+}
+
+type rateLimitArgs struct {
+	requestsPerSecond int
+	burst             int
+}
+
+type circuitBreakerArgs struct {
+	breaker *circuitbreaker.Breaker
+	budget  *circuitbreaker.RetryBudget
+}
+
+type authArgs struct {
+	audience string
+	reviews  authv1client.TokenReviewInterface
+}
+
+type apiKeyArgs struct {
+	dir string
+}
+
+type tenantArgs struct {
+	header string
+}
+
+type deprecationArgs struct {
+	deprecation      time.Time
+	sunset           *time.Time
+	rejectPercentage int
+	link             string
+}
+
+type allowedHostsArgs struct {
+	hosts []string
+}
+
+type modelSelectArgs struct {
+	names  map[string]string
+	models modelselect.Models
+}
+
+type maintenanceArgs struct {
+	config  *agentconfig.MaintenanceConfig
+	handler *maintenance.MaintenanceHandler
+}
+
+type grpcArgs struct {
+	conn  *grpc.ClientConn
+	ready func() bool
 }
 
 func main() {
@@ -106,21 +305,66 @@ func main() {
 	}
 
 	logger, _ := pkglogging.NewLogger(env.ServingLoggingConfig, env.ServingLoggingLevel)
+	logger.Infof("Crypto configuration fips: %t", fips.Enabled)
+	tlsSettings, err := fips.NewTLSSettings(*tlsMinVersion, *tlsCipherSuites)
+	if err != nil {
+		logger.Errorw("Invalid TLS configuration", zap.Error(err))
+		os.Exit(1)
+	}
+	// The proxy transport is cloned from http.DefaultTransport and the CloudEvents client of the logger sends
+	// through it, so it is configured before they are created. The TokenReview client is configured in startAuth.
+	if err := tlsSettings.ConfigureTransport(http.DefaultTransport); err != nil {
+		logger.Errorw("Failed to configure TLS", zap.Error(err))
+		os.Exit(1)
+	}
 	// Setup probe to run for checking user container healthiness.
 	probe := func() bool { return true }
 	if env.ServingReadinessProbe != "" {
 		probe = buildProbe(logger, env.ServingReadinessProbe).ProbeContainer
 	}
 
+	ctx := signals.NewContext()
+	var modelStatus *agent.ModelStatus
+	// The agent readiness merged into the gRPC health, it is not probed on every health check
+	agentReady := func() bool { return true }
 	if *enablePuller {
 		logger.Infof("Initializing model agent with config-dir %s, model-dir %s", *configDir, *modelDir)
-		startModelPuller(logger)
+		var readiness *agent.Readiness
+		if readiness, modelStatus = startModelPuller(ctx, logger); readiness != nil {
+			agentReady = readiness.Ready
+			containerProbe := probe
+			probe = func() bool {
+				return readiness.Ready() && containerProbe()
+			}
+		}
+	}
+
+	// The default log url is used when the agent config does not set one
+	defaultLogUrl := *logUrl
+	var agentConfigWatcher *agentconfig.Watcher
+	// The maintenance mode is switched from the agent config
+	var maintenanceArgs *maintenanceArgs
+	if *agentConfigFile != "" {
+		logger.Infof("Loading agent config %s", *agentConfigFile)
+		agentConfigWatcher, maintenanceArgs = startAgentConfig(logger)
 	}
 
 	var loggerArgs *loggerArgs
 	if *logUrl != "" {
 		logger.Info("Starting logger")
-		loggerArgs = startLogger(*workers, logger)
+		loggerArgs = startLogger(ctx, *workers, logger)
+	}
+
+	var predictionSinkArgs *predictionSinkArgs
+	if *predictionSinkUrl != "" {
+		logger.Infof("Starting prediction sink %s", *predictionSinkUrl)
+		predictionSinkArgs = startPredictionSink(ctx, logger)
+	}
+
+	var payloadArgs *payloadArgs
+	if len(*payloadUriPrefixes) != 0 {
+		logger.Infof("Enabling payloads passed by reference from %v", *payloadUriPrefixes)
+		payloadArgs = startPayload(logger)
 	}
 
 	var batcherArgs *batcherArgs
@@ -128,12 +372,87 @@ func main() {
 		logger.Info("Starting batcher")
 		batcherArgs = startBatcher(logger)
 	}
+
+	var asyncArgs *asyncArgs
+	if *enableAsync {
+		logger.Infof("Enabling asynchronous requests with %d workers", *asyncWorkers)
+		asyncArgs = startAsync(logger)
+	}
+
+	var openAPIArgs *openAPIArgs
+	if *enableOpenAPI {
+		logger.Info("Enabling OpenAPI specification")
+		openAPIArgs = startOpenAPI(logger)
+	}
+
+	var circuitBreakerArgs *circuitBreakerArgs
+	if *failureThreshold != 0 || *retryBudget != 0 {
+		logger.Infof("Enabling circuit breaker with failure threshold %d and retry budget %d%%", *failureThreshold, *retryBudget)
+		circuitBreakerArgs = startCircuitBreaker(logger)
+	}
+
+	var rateLimitArgs *rateLimitArgs
+	if *rateLimitRPS != 0 {
+		logger.Infof("Enabling rate limit of %d requests per second", *rateLimitRPS)
+		rateLimitArgs = startRateLimit(logger)
+	}
+
+	var authArgs *authArgs
+	if *tokenAudience != "" {
+		logger.Infof("Enabling token authentication for audience %s", *tokenAudience)
+		authArgs = startAuth(logger, tlsSettings)
+	}
+
+	var apiKeyArgs *apiKeyArgs
+	if *apiKeysDir != "" {
+		logger.Infof("Enabling api keys from %s", *apiKeysDir)
+		apiKeyArgs = startAPIKeys()
+	}
+
+	var tenantArgs *tenantArgs
+	if *tenantHeader != "" {
+		logger.Infof("Counting requests per tenant header %s", *tenantHeader)
+		tenantArgs = startTenants()
+	}
+
+	var deprecationArgs *deprecationArgs
+	if *deprecationDate != "" {
+		logger.Infof("Enabling deprecation headers for deprecation %s and sunset %s", *deprecationDate, *sunsetDate)
+		deprecationArgs = startDeprecation(logger)
+	}
+
+	var allowedHostsArgs *allowedHostsArgs
+	if len(*allowedHosts) != 0 {
+		logger.Infof("Enabling allowed hosts %v", *allowedHosts)
+		allowedHostsArgs = startAllowedHosts()
+	}
+	// The models of the model config are selected by their own name
+	var modelSelectArgs *modelSelectArgs
+	if len(*modelNames) != 0 || modelStatus != nil {
+		logger.Infof("Enabling the model selection with the model names %v", *modelNames)
+		modelSelectArgs = startModelSelect(modelStatus)
+	}
+	var grpcArgs *grpcArgs
+	if *enableGrpc {
+		logger.Info("Enabling the gRPC health and reflection services")
+		grpcArgs = startGrpc(*componentPort, agentReady, logger)
+		defer grpcArgs.conn.Close()
+	}
 	logger.Info("Starting agent http server...")
-	ctx := signals.NewContext()
-	mainServer, drain := buildServer(ctx, *port, *componentPort, loggerArgs, batcherArgs, probe, logger)
+	mainServer, drain := buildServer(ctx, *port, *componentPort, circuitBreakerArgs, loggerArgs, predictionSinkArgs,
+		payloadArgs, batcherArgs, asyncArgs, openAPIArgs, rateLimitArgs, authArgs, apiKeyArgs, tenantArgs, deprecationArgs, maintenanceArgs,
+		allowedHostsArgs, modelSelectArgs, grpcArgs, modelStatus, *enableConcurrencyMetrics, probe, logger)
+	if agentConfigWatcher != nil {
+		agentConfigWatcher.Start(ctx, func(config *agentconfig.Config) {
+			reloadAgentConfig(config, defaultLogUrl, loggerArgs, batcherArgs, maintenanceArgs, logger)
+		})
+	}
 	servers := map[string]*http.Server{
 		"main": mainServer,
 	}
+	if *metricsPort != "" {
+		servers["metrics"] = pkgnet.NewServer(":"+*metricsPort, promhttp.Handler())
+	}
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	for name, server := range servers {
@@ -197,6 +516,14 @@ func main() {
 				logger.Errorw("Failed to shutdown server", zap.String("server", serverName), zap.Error(err))
 			}
 		}
+		if loggerArgs != nil && loggerArgs.retries != nil {
+			logger.Info("Exporting the queued log events")
+			loggerArgs.retries.Flush()
+		}
+		if loggerArgs != nil && loggerArgs.blobSink != nil {
+			logger.Info("Writing the batched log events")
+			loggerArgs.blobSink.Flush(context.Background())
+		}
 		logger.Info("Shutdown complete, exiting...")
 	}
 }
@@ -214,13 +541,226 @@ func startBatcher(logger *zap.SugaredLogger) *batcherArgs {
 		os.Exit(1)
 	}
 
+	targetP99LatencyInt := 0
+	if *targetP99Latency != "" {
+		targetP99LatencyInt, err = strconv.Atoi(*targetP99Latency)
+		if err != nil || targetP99LatencyInt <= 0 {
+			logger.Error(errors.New("Invalid target p99 latency"), *targetP99Latency)
+			os.Exit(1)
+		}
+	}
+
+	if *pipelineOrder != constants.AgentPipelineLogBatch && *pipelineOrder != constants.AgentPipelineBatchLog {
+		logger.Error(errors.New("Invalid pipeline order"), *pipelineOrder)
+		os.Exit(1)
+	}
+
 	return &batcherArgs{
-		maxLatency:   maxLatencyInt,
-		maxBatchSize: maxBatchSizeInt,
+		maxLatency:       maxLatencyInt,
+		maxBatchSize:     maxBatchSizeInt,
+		targetP99Latency: targetP99LatencyInt,
+		priorityHeader:   *batchPriorityHeader,
+		logBatched:       *pipelineOrder == constants.AgentPipelineBatchLog,
+	}
+}
+
+// startAgentConfig loads the agent config, its logger and batcher settings override the flags the agent starts with and
+// its maintenance mode is applied once the server is built
+func startAgentConfig(logger *zap.SugaredLogger) (*agentconfig.Watcher, *maintenanceArgs) {
+	watcher, config, err := agentconfig.NewWatcher(*agentConfigFile, logger)
+	if err != nil {
+		logger.Errorf("Malformed agent config %s: %v", *agentConfigFile, err)
+		os.Exit(-1)
+	}
+	if config.Logger != nil {
+		if config.Logger.URL != "" {
+			*logUrl = config.Logger.URL
+		}
+		if config.Logger.Mode != "" {
+			*logMode = string(config.Logger.Mode)
+		}
+	}
+	if config.Batcher != nil {
+		if config.Batcher.MaxBatchSize > 0 {
+			*maxBatchSize = strconv.Itoa(config.Batcher.MaxBatchSize)
+		}
+		if config.Batcher.MaxLatency > 0 {
+			*maxLatency = strconv.Itoa(config.Batcher.MaxLatency)
+		}
+	}
+	return watcher, &maintenanceArgs{config: config.Maintenance}
+}
+
+// reloadAgentConfig applies the changed logger, batcher and maintenance settings to the running handlers, the requests
+// in flight complete with the previous settings
+func reloadAgentConfig(config *agentconfig.Config, defaultLogUrl string, loggerArgs *loggerArgs, batcherArgs *batcherArgs,
+	maintenanceArgs *maintenanceArgs, logger *zap.SugaredLogger) {
+	if config.Logger != nil && loggerArgs != nil {
+		rawUrl := config.Logger.URL
+		if rawUrl == "" {
+			rawUrl = defaultLogUrl
+		}
+		mode := config.Logger.Mode
+		if mode == "" {
+			mode = v1beta1.LogAll
+		}
+		parsed, err := url.Parse(rawUrl)
+		switch {
+		case err != nil:
+			logger.Errorf("Malformed log url %s in agent config", rawUrl)
+		case mode != v1beta1.LogAll && mode != v1beta1.LogRequest && mode != v1beta1.LogResponse:
+			logger.Errorf("Malformed log mode %s in agent config", mode)
+		case (loggerArgs.blobSink != nil || storage.IsBlobSinkUri(rawUrl)) && rawUrl != *logUrl:
+			// The blob storage and its credentials are set up when the agent starts
+			logger.Errorf("Log url %s in agent config requires a restart to change the blob storage", rawUrl)
+		default:
+			logger.Infof("Logging %s to %s", mode, rawUrl)
+			loggerArgs.handler.Configure(parsed, mode)
+		}
+	}
+	if config.Batcher != nil && batcherArgs != nil {
+		batcherArgs.handler.Configure(config.Batcher.MaxBatchSize, config.Batcher.MaxLatency)
+	}
+	// The maintenance mode is switched off when it is removed from the config
+	if maintenanceArgs != nil {
+		maintenanceArgs.config = config.Maintenance
+		maintenanceArgs.configure(logger)
+	}
+}
+
+// configure switches the maintenance mode of the handler to the one of the agent config
+func (args *maintenanceArgs) configure(logger *zap.SugaredLogger) {
+	if args.config == nil || !args.config.Enabled {
+		args.handler.Configure(false, "", 0)
+		return
+	}
+	logger.Infof("Enabling the maintenance mode, the requests are answered with a 503")
+	args.handler.Configure(true, args.config.Body, time.Duration(args.config.RetryAfterSeconds)*time.Second)
+}
+
+func startOpenAPI(logger *zap.SugaredLogger) *openAPIArgs {
+	if *modelName == "" {
+		logger.Error(errors.New("Model name is required to serve the OpenAPI specification"))
+		os.Exit(1)
+	}
+	return &openAPIArgs{
+		modelName: *modelName,
+	}
+}
+
+func startCircuitBreaker(logger *zap.SugaredLogger) *circuitBreakerArgs {
+	if *failureThreshold < 0 || *openDuration <= 0 || *retryBudget < 0 || *retryBudget > 100 {
+		logger.Errorf("Invalid circuit breaker failure threshold %d, open duration %v or retry budget %d",
+			*failureThreshold, *openDuration, *retryBudget)
+		os.Exit(1)
+	}
+	args := &circuitBreakerArgs{}
+	if *failureThreshold != 0 {
+		args.breaker = circuitbreaker.NewBreaker(*failureThreshold, *openDuration)
+	}
+	if *retryBudget != 0 {
+		args.budget = circuitbreaker.NewRetryBudget(*retryBudget)
+	}
+	return args
+}
+
+func startRateLimit(logger *zap.SugaredLogger) *rateLimitArgs {
+	burst := *rateLimitBurst
+	if burst == 0 {
+		burst = *rateLimitRPS
+	}
+	if *rateLimitRPS < 0 || burst < 0 {
+		logger.Errorf("Invalid rate limit %d with burst %d", *rateLimitRPS, burst)
+		os.Exit(1)
+	}
+	return &rateLimitArgs{
+		requestsPerSecond: *rateLimitRPS,
+		burst:             burst,
+	}
+}
+
+func startAuth(logger *zap.SugaredLogger, tlsSettings *fips.TLSSettings) *authArgs {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Errorw("Failed to load in cluster config", zap.Error(err))
+		os.Exit(1)
+	}
+	cfg.WrapTransport = tlsSettings.WrapTransport
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		logger.Errorw("Failed to create kubernetes client", zap.Error(err))
+		os.Exit(1)
+	}
+	return &authArgs{
+		audience: *tokenAudience,
+		reviews:  clientset.AuthenticationV1().TokenReviews(),
+	}
+}
+
+func startAPIKeys() *apiKeyArgs {
+	return &apiKeyArgs{
+		dir: *apiKeysDir,
+	}
+}
+
+func startTenants() *tenantArgs {
+	return &tenantArgs{
+		header: *tenantHeader,
+	}
+}
+
+func startDeprecation(logger *zap.SugaredLogger) *deprecationArgs {
+	deprecationTime, err := time.Parse(time.RFC3339, *deprecationDate)
+	if err != nil {
+		logger.Errorf("Malformed deprecation %s", *deprecationDate)
+		os.Exit(1)
+	}
+	var sunset *time.Time
+	if *sunsetDate != "" {
+		sunsetTime, err := time.Parse(time.RFC3339, *sunsetDate)
+		if err != nil {
+			logger.Errorf("Malformed sunset %s", *sunsetDate)
+			os.Exit(1)
+		}
+		sunset = &sunsetTime
+	}
+	if *sunsetRejectPercentage < 0 || *sunsetRejectPercentage > 100 {
+		logger.Errorf("Invalid sunset reject percentage %d", *sunsetRejectPercentage)
+		os.Exit(1)
+	}
+	return &deprecationArgs{
+		deprecation:      deprecationTime,
+		sunset:           sunset,
+		rejectPercentage: *sunsetRejectPercentage,
+		link:             *deprecationLink,
 	}
 }
 
-func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
+func startModelSelect(modelStatus *agent.ModelStatus) *modelSelectArgs {
+	args := &modelSelectArgs{names: *modelNames}
+	// A nil model status must not be wrapped in the interface
+	if modelStatus != nil {
+		args.models = modelStatus
+	}
+	return args
+}
+
+func startGrpc(componentPort string, ready func() bool, logger *zap.SugaredLogger) *grpcArgs {
+	// The connection is established lazily, the component may not listen yet
+	conn, err := grpc.Dial(net.JoinHostPort("127.0.0.1", componentPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logger.Fatalw("Failed to create the gRPC connection to the component", zap.Error(err))
+	}
+	return &grpcArgs{conn: conn, ready: ready}
+}
+
+func startAllowedHosts() *allowedHostsArgs {
+	return &allowedHostsArgs{
+		hosts: *allowedHosts,
+	}
+}
+
+func startLogger(ctx context.Context, workers int, logger *zap.SugaredLogger) *loggerArgs {
 	loggingMode := v1beta1.LoggerType(*logMode)
 	switch loggingMode {
 	case v1beta1.LogAll, v1beta1.LogRequest, v1beta1.LogResponse:
@@ -244,8 +784,61 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		logger.Errorf("Malformed source_uri %s", *sourceUri)
 		os.Exit(-1)
 	}
-	logger.Info("Starting the log dispatcher")
-	kfslogger.StartDispatcher(workers, logger)
+	var encryptor *kfslogger.Encryptor
+	if *logEncryptionKMSKey != "" {
+		// KMS mode, the key encryption key never leaves the KMS
+		wrapper, err := kfslogger.NewKMSKeyWrapper(*logEncryptionKMSKey)
+		if err != nil {
+			logger.Errorf("Malformed log encryption KMS key %s: %v", *logEncryptionKMSKey, err)
+			os.Exit(-1)
+		}
+		encryptor = kfslogger.NewEncryptor(wrapper)
+	} else if *logEncryptionKeyFile != "" {
+		// Static key mode, the key encryption key is mounted from a secret of the InferenceService namespace
+		wrapper, err := kfslogger.LoadStaticKeyWrapper(*logEncryptionKeyId, *logEncryptionKeyFile)
+		if err != nil {
+			logger.Errorf("Malformed log encryption key %s: %v", *logEncryptionKeyFile, err)
+			os.Exit(-1)
+		}
+		encryptor = kfslogger.NewEncryptor(wrapper)
+	}
+	format, err := kfslogger.NewEventFormat(*logEncoding, *logEventType, *logEventSource, *logSchemaVersion,
+		*inferenceService, *namespace, *component, *endpoint)
+	if err != nil {
+		logger.Errorf("Malformed log event format: %v", err)
+		os.Exit(-1)
+	}
+	transformer := startLoggerTransformer(logger)
+	filter, err := kfslogger.NewFilter(*logSamplingPercent, *logOptOutHeader, kfslogger.ParseResponseCodes(*logResponseCodes))
+	if err != nil {
+		logger.Errorf("Malformed log filter: %v", err)
+		os.Exit(-1)
+	}
+	var delivery *kfslogger.Delivery
+	switch v1beta1.LoggerDeliveryType(*logDelivery) {
+	case v1beta1.LogAtMostOnce:
+	case v1beta1.LogAtLeastOnce:
+		if *logAttempts <= 0 {
+			logger.Errorf("Invalid log-delivery-attempts %d", *logAttempts)
+			os.Exit(-1)
+		}
+		logger.Infof("Delivering the log events at least once with %d attempts", *logAttempts)
+		delivery = kfslogger.NewAtLeastOnceDelivery(*logAttempts)
+	default:
+		logger.Errorf("Malformed log-delivery %s", *logDelivery)
+		os.Exit(-1)
+	}
+	var retries *kfslogger.RetryQueue
+	var blobSink *kfslogger.BlobSink
+	if storage.IsBlobSinkUri(*logUrl) {
+		blobSink = startLoggerBlobSink(ctx, workers, logger)
+	} else {
+		if *logRetryMaxAge > 0 {
+			retries = startLoggerRetries(ctx, logger)
+		}
+		logger.Info("Starting the log dispatcher")
+		kfslogger.StartDispatcher(workers, delivery, retries, logger)
+	}
 	return &loggerArgs{
 		loggerType:       loggingMode,
 		logUrl:           logUrlParsed,
@@ -254,19 +847,257 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		endpoint:         *endpoint,
 		namespace:        *namespace,
 		component:        *component,
+		encryptor:        encryptor,
+		format:           format,
+		transformer:      transformer,
+		filter:           filter,
+		retries:          retries,
+		blobSink:         blobSink,
+	}
+}
+
+// startLoggerTransformer returns the transformer of the logged payloads, the values are redacted then the fields are
+// hashed before the payload is sent to the webhook. Nil is returned when the payloads are logged as they are.
+func startLoggerTransformer(logger *zap.SugaredLogger) kfslogger.Transformer {
+	var transformers []kfslogger.Transformer
+	if *logRedact != "" {
+		spec := v1beta1.LoggerRedactSpec{}
+		if err := json.Unmarshal([]byte(*logRedact), &spec); err != nil {
+			logger.Errorf("Malformed log-redact %s: %v", *logRedact, err)
+			os.Exit(-1)
+		}
+		redactor, err := kfslogger.NewRedactTransformer(spec)
+		if err != nil {
+			logger.Errorf("Invalid log-redact %s: %v", *logRedact, err)
+			os.Exit(-1)
+		}
+		logger.Infof("Redacting the logged paths %v, patterns %v and redactors %v", spec.Paths, spec.Patterns,
+			spec.Redactors)
+		transformers = append(transformers, redactor)
+	}
+	if *logHashFields != "" {
+		var key []byte
+		if *logHashKeyFile != "" {
+			var err error
+			if key, err = kfslogger.LoadHashKey(*logHashKeyFile); err != nil {
+				logger.Errorf("Malformed log hash key %s: %v", *logHashKeyFile, err)
+				os.Exit(-1)
+			}
+		}
+		var fields []string
+		for _, field := range strings.Split(*logHashFields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		logger.Infof("Hashing the logged fields %v", fields)
+		transformers = append(transformers, kfslogger.NewHashTransformer(fields, key))
+	}
+	if *logTransformUrl != "" {
+		if _, err := url.Parse(*logTransformUrl); err != nil {
+			logger.Errorf("Malformed log-transform-url %s", *logTransformUrl)
+			os.Exit(-1)
+		}
+		logger.Infof("Transforming the logged payloads with %s", *logTransformUrl)
+		transformers = append(transformers, kfslogger.NewWebhookTransformer(*logTransformUrl))
+	}
+	if len(transformers) == 0 {
+		return nil
+	}
+	return kfslogger.Chain(transformers...)
+}
+
+func startLoggerRetries(ctx context.Context, logger *zap.SugaredLogger) *kfslogger.RetryQueue {
+	size, err := resource.ParseQuantity(*logRetryBufferSize)
+	if err != nil || size.Sign() <= 0 {
+		logger.Errorf("Malformed log-retry-buffer-size %s", *logRetryBufferSize)
+		os.Exit(-1)
+	}
+	var deadLetter kfslogger.DeadLetterExporter
+	if *logDeadLetterUri != "" {
+		store, err := storage.NewDeadLetterStore(*logDeadLetterUri)
+		if err != nil {
+			logger.Errorf("Failed to create the log dead letter store %s: %v", *logDeadLetterUri, err)
+			os.Exit(1)
+		}
+		deadLetter = store
+	}
+	// The log events queued by a previous agent container are delivered first
+	retries, err := kfslogger.NewRetryQueue(*logRetryDir, size.Value(), *logRetryMaxAge, deadLetter, logger)
+	if err != nil {
+		logger.Errorf("Failed to load the log retry queue %s: %v", *logRetryDir, err)
+		os.Exit(1)
+	}
+	logger.Infof("Retrying the log events for %v", *logRetryMaxAge)
+	retries.Start(ctx)
+	return retries
+}
+
+// startLoggerBlobSink writes the log events in batches to the blob storage of the log url, with the credentials of
+// the storage secrets of the service account
+func startLoggerBlobSink(ctx context.Context, workers int, logger *zap.SugaredLogger) *kfslogger.BlobSink {
+	if *logBlobBatchSize <= 0 || *logBlobFlushInterval <= 0 {
+		logger.Errorf("Invalid log-blob-batch-size %d or log-blob-flush-interval %v", *logBlobBatchSize,
+			*logBlobFlushInterval)
+		os.Exit(-1)
+	}
+	store, err := storage.NewBlobSinkStore(*logUrl)
+	if err != nil {
+		logger.Errorf("Failed to create the log blob store %s: %v", *logUrl, err)
+		os.Exit(1)
+	}
+	logger.Infof("Writing the log events to %s in batches of %d every %v", *logUrl, *logBlobBatchSize,
+		*logBlobFlushInterval)
+	sink := kfslogger.NewBlobSink(store, *logBlobBatchSize, *logBlobFlushInterval, logger)
+	sink.Start(ctx)
+	kfslogger.StartBlobDispatcher(workers, sink, logger)
+	return sink
+}
+
+func startPredictionSink(ctx context.Context, logger *zap.SugaredLogger) *predictionSinkArgs {
+	sinkUrl, err := url.Parse(*predictionSinkUrl)
+	if err != nil {
+		logger.Errorf("Malformed prediction-sink-url %s", *predictionSinkUrl)
+		os.Exit(-1)
+	}
+	size, err := resource.ParseQuantity(*predictionBufferSize)
+	if err != nil || size.Sign() <= 0 {
+		logger.Errorf("Malformed prediction-buffer-size %s", *predictionBufferSize)
+		os.Exit(-1)
+	}
+	if *sourceUri == "" {
+		*sourceUri = fmt.Sprintf("http://localhost:%s/", *port)
+	}
+	sourceUriParsed, err := url.Parse(*sourceUri)
+	if err != nil {
+		logger.Errorf("Malformed source_uri %s", *sourceUri)
+		os.Exit(-1)
+	}
+	// The predictions buffered by a previous agent container are published first
+	buffer, err := predictionsink.NewBuffer(*predictionBufferDir, size.Value())
+	if err != nil {
+		logger.Errorf("Failed to load the prediction buffer %s: %v", *predictionBufferDir, err)
+		os.Exit(1)
+	}
+	sink, err := predictionsink.NewSink(buffer, sinkUrl, sourceUriParsed, *inferenceService, *namespace, *endpoint,
+		*component, logger)
+	if err != nil {
+		logger.Errorf("Failed to create the prediction sink: %v", err)
+		os.Exit(1)
+	}
+	sink.Start(ctx)
+	return &predictionSinkArgs{
+		buffer: buffer,
+	}
+}
+
+func startAsync(logger *zap.SugaredLogger) *asyncArgs {
+	if *asyncWorkers <= 0 || *asyncQueueSize <= 0 || *asyncResultTTL <= 0 {
+		logger.Errorf("Malformed async-workers %d, async-queue-size %d or async-result-ttl %v", *asyncWorkers,
+			*asyncQueueSize, *asyncResultTTL)
+		os.Exit(-1)
+	}
+	args := &asyncArgs{
+		workers: *asyncWorkers,
+		store:   async.NewMemoryStore(*asyncQueueSize, *asyncResultTTL),
+	}
+	if *asyncBrokerUrl != "" {
+		// The replicas of the component share the queue
+		prefix := fmt.Sprintf("kserve:async:%s/%s/%s", *namespace, *inferenceService, *component)
+		store, err := async.NewRedisStore(*asyncBrokerUrl, prefix, *asyncQueueSize, *asyncResultTTL)
+		if err != nil {
+			logger.Errorf("Malformed async-broker-url: %v", err)
+			os.Exit(-1)
+		}
+		args.store = store
+	}
+	if *asyncCallbackUrl == "" {
+		return args
 	}
+	callbackUrl, err := url.Parse(*asyncCallbackUrl)
+	if err != nil {
+		logger.Errorf("Malformed async-callback-url %s", *asyncCallbackUrl)
+		os.Exit(-1)
+	}
+	if *sourceUri == "" {
+		*sourceUri = fmt.Sprintf("http://localhost:%s/", *port)
+	}
+	sourceUriParsed, err := url.Parse(*sourceUri)
+	if err != nil {
+		logger.Errorf("Malformed source_uri %s", *sourceUri)
+		os.Exit(-1)
+	}
+	// The responses are sent with the same attributes as the logged payloads
+	args.notifier, err = async.NewNotifier(callbackUrl, sourceUriParsed, *inferenceService, *namespace, *endpoint,
+		*component)
+	if err != nil {
+		logger.Errorf("Failed to create the async callback: %v", err)
+		os.Exit(1)
+	}
+	return args
 }
 
-func startModelPuller(logger *zap.SugaredLogger) {
+func startPayload(logger *zap.SugaredLogger) *payloadArgs {
+	size, err := resource.ParseQuantity(*payloadMaxSize)
+	if err != nil || size.Sign() <= 0 {
+		logger.Errorf("Malformed payload-max-size %s", *payloadMaxSize)
+		os.Exit(-1)
+	}
+	uris := *payloadUriPrefixes
+	if *payloadResponseUri != "" {
+		uris = append(uris, *payloadResponseUri)
+	}
+	// The payloads are read and written with the storage credentials of the agent
+	store, err := storage.NewPayloadStore(uris...)
+	if err != nil {
+		logger.Errorf("Failed to create the payload store: %v", err)
+		os.Exit(1)
+	}
+	return &payloadArgs{
+		store:       store,
+		prefixes:    *payloadUriPrefixes,
+		maxSize:     size.Value(),
+		responseUri: *payloadResponseUri,
+	}
+}
+
+// startModelPuller starts the puller, the models are loaded in the background when a readiness policy is set and the
+// returned readiness reports whether they satisfy the policy. The returned model status reports the load states of the
+// models.
+func startModelPuller(ctx context.Context, logger *zap.SugaredLogger) (*agent.Readiness, *agent.ModelStatus) {
 	downloader := agent.Downloader{
 		ModelDir:  *modelDir,
 		Providers: map[storage.Protocol]storage.Provider{},
 		Logger:    logger,
 	}
+	if *cacheUri != "" {
+		cache, err := storage.NewCache(*cacheUri, *cacheMaxAge)
+		if err != nil {
+			logger.Errorf("Failed to create the model cache %s: %v", *cacheUri, err)
+			os.Exit(1)
+		}
+		downloader.Cache = cache
+	}
 	watcher := agent.NewWatcher(*configDir, *modelDir, logger)
+	repository := agent.NewModelRepository("http://localhost:" + *componentPort)
+	status := agent.NewModelStatus("http://localhost:"+*componentPort, logger)
+	go status.Start(ctx, *modelStatusPeriod)
 	logger.Info("Starting puller")
-	agent.StartPullerAndProcessModels(&downloader, watcher.ModelEvents, logger)
-	go watcher.Start()
+	if *readinessPolicy == "" {
+		agent.StartPullerAndProcessModels(&downloader, repository, watcher.ModelEvents, nil, status, logger)
+		go watcher.Start()
+		return nil, status
+	}
+	readiness, err := agent.NewReadiness(agent.ReadinessPolicy(*readinessPolicy), *readinessMinModels)
+	if err != nil {
+		logger.Errorw("Invalid readiness policy", zap.Error(err))
+		os.Exit(1)
+	}
+	go func() {
+		agent.StartPullerAndProcessModels(&downloader, repository, watcher.ModelEvents, readiness, status, logger)
+		watcher.Start()
+	}()
+	return readiness, status
 }
 
 func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
@@ -282,8 +1113,11 @@ func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
 	return newProbe
 }
 
-func buildServer(ctx context.Context, port string, userPort string, loggerArgs *loggerArgs, batcherArgs *batcherArgs,
-	probeContainer func() bool, logging *zap.SugaredLogger) (server *http.Server, drain func()) {
+func buildServer(ctx context.Context, port string, userPort string, circuitBreakerArgs *circuitBreakerArgs,
+	loggerArgs *loggerArgs, predictionSinkArgs *predictionSinkArgs, payloadArgs *payloadArgs, batcherArgs *batcherArgs, asyncArgs *asyncArgs, openAPIArgs *openAPIArgs,
+	rateLimitArgs *rateLimitArgs, authArgs *authArgs, apiKeyArgs *apiKeyArgs, tenantArgs *tenantArgs, deprecationArgs *deprecationArgs,
+	maintenanceArgs *maintenanceArgs, allowedHostsArgs *allowedHostsArgs, modelSelectArgs *modelSelectArgs, grpcArgs *grpcArgs, modelStatus *agent.ModelStatus, reportConcurrency bool, probeContainer func() bool,
+	logging *zap.SugaredLogger) (server *http.Server, drain func()) {
 
 	logging.Infof("Building server user port %s port %s", userPort, port)
 	target := &url.URL{
@@ -294,6 +1128,7 @@ func buildServer(ctx context.Context, port string, userPort string, loggerArgs *
 	maxIdleConns := 1000 // TODO: somewhat arbitrary value for CC=0, needs experimental validation.
 
 	httpProxy := httputil.NewSingleHostReverseProxy(target)
+	// Cloned from http.DefaultTransport, it inherits the TLS settings
 	httpProxy.Transport = pkgnet.NewAutoTransport(maxIdleConns /* max-idle */, maxIdleConns /* max-idle-per-host */)
 	httpProxy.ErrorHandler = pkghandler.Error(logging)
 	httpProxy.BufferPool = network.NewBufferPool()
@@ -303,12 +1138,90 @@ func buildServer(ctx context.Context, port string, userPort string, loggerArgs *
 	// Note: innermost handlers are specified first, ie. the last handler in the chain will be executed first.
 	var composedHandler http.Handler = httpProxy
 
+	// The retries only repeat the model server calls
+	if circuitBreakerArgs != nil {
+		composedHandler = circuitbreaker.New(circuitBreakerArgs.breaker, circuitBreakerArgs.budget, composedHandler, logging)
+	}
+	// The logger logs the batched requests the model server receives in the batch-log order
+	logBatched := batcherArgs != nil && batcherArgs.logBatched
+	if loggerArgs != nil && logBatched {
+		composedHandler = loggerArgs.newHandler(composedHandler)
+	}
 	if batcherArgs != nil {
-		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		batcherArgs.handler = batcher.NewAdaptive(batcherArgs.maxBatchSize, batcherArgs.maxLatency,
+			batcherArgs.targetP99Latency, batcherArgs.priorityHeader, composedHandler, logging)
+		composedHandler = batcherArgs.handler
+	}
+	// The payloads are read before they are batched, the logger and the prediction sink see the references
+	if payloadArgs != nil {
+		composedHandler = payload.New(payloadArgs.store, payloadArgs.prefixes, payloadArgs.maxSize,
+			payloadArgs.responseUri, composedHandler, logging)
+	}
+	// Only the predictions returned to the caller are published
+	if predictionSinkArgs != nil {
+		composedHandler = predictionsink.New(predictionSinkArgs.buffer, composedHandler, logging)
+	}
+	if loggerArgs != nil && !logBatched {
+		composedHandler = loggerArgs.newHandler(composedHandler)
+	}
+	// The queued requests run through the logger and the batcher, only the authenticated requests are queued
+	if asyncArgs != nil {
+		asyncHandler := async.New(asyncArgs.workers, asyncArgs.store, asyncArgs.notifier, composedHandler, logging)
+		asyncHandler.Start(ctx)
+		composedHandler = asyncHandler
+	}
+	// The selected model is mapped before the requests are queued, logged and batched
+	if modelSelectArgs != nil {
+		composedHandler = modelselect.New(modelSelectArgs.names, modelSelectArgs.models, composedHandler, logging)
+	}
+	if openAPIArgs != nil {
+		composedHandler = openapi.New(openAPIArgs.modelName, target, composedHandler, logging)
+	}
+	// Only the authenticated requests consume the tokens of the rate limit
+	if rateLimitArgs != nil {
+		composedHandler = ratelimit.New(rateLimitArgs.requestsPerSecond, rateLimitArgs.burst, composedHandler, logging)
+	}
+	if authArgs != nil {
+		composedHandler = auth.New(authArgs.audience, authArgs.reviews, composedHandler, logging)
+	}
+	// Only the authenticated requests are counted per tenant
+	if tenantArgs != nil {
+		composedHandler = tenant.New(tenantArgs.header, composedHandler, logging)
+	}
+	if apiKeyArgs != nil {
+		apiKeyHandler, err := apikey.New(ctx, apiKeyArgs.dir, composedHandler, logging)
+		if err != nil {
+			logging.Fatalw("Failed to load api keys", zap.Error(err))
+		}
+		composedHandler = apiKeyHandler
+	}
+	if deprecationArgs != nil {
+		composedHandler = deprecation.New(deprecationArgs.deprecation, deprecationArgs.sunset, deprecationArgs.rejectPercentage,
+			deprecationArgs.link, composedHandler, logging)
+	}
+	// The requests are answered during the maintenance before they are authenticated or counted
+	if maintenanceArgs != nil {
+		maintenanceArgs.handler = maintenance.New(composedHandler, logging)
+		maintenanceArgs.configure(logging)
+		composedHandler = maintenanceArgs.handler
+	}
+	// Misdirected requests are rejected before any other handler sees them
+	if allowedHostsArgs != nil {
+		composedHandler = allowedhosts.New(allowedHostsArgs.hosts, composedHandler, logging)
+	}
+
+	// Every request is in flight until answered, whichever handler answers it
+	if reportConcurrency {
+		composedHandler = concurrency.New(composedHandler, logging)
+	}
+
+	// The TrainedModel controller polls the model states without credentials, they are answered before the other handlers
+	if modelStatus != nil {
+		composedHandler = modelStatus.NewHandler(composedHandler)
 	}
-	if loggerArgs != nil {
-		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType,
-			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, composedHandler)
+	// The mesh health checkers call the gRPC health service without credentials, the other gRPC calls pass through
+	if grpcArgs != nil {
+		composedHandler = grpcproxy.New(grpcArgs.conn, grpcArgs.ready, composedHandler, logging)
 	}
 
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/debugcontainer"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+var (
+	namespace       = flag.String("namespace", "default", "Namespace of the InferenceService")
+	component       = flag.String("component", string(constants.Predictor), "Component of the InferenceService, predictor, transformer or explainer")
+	pod             = flag.String("pod", "", "Pod of the component, the first pod which is not ready when empty")
+	container       = flag.String("container", constants.InferenceServiceContainerName, "Container of the pod the debug container targets")
+	image           = flag.String("image", "", "Image of the debug container, defaults to the image of the debugContainer config of the inferenceservice-config ConfigMap")
+	command         = flag.String("command", "", "Comma separated command of the debug container, defaults to the command of the debugContainer config")
+	configNamespace = flag.String("config-namespace", constants.KServeNamespace, "Namespace of the inferenceservice-config ConfigMap")
+)
+
+// isvc-debug attaches an ephemeral debug container to a pod of an InferenceService:
+//
+//	isvc-debug --namespace <namespace> <inferenceservice>
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] <inferenceservice>\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(isvc string) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("unable to set up client config: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	debugConfig := &debugcontainer.Config{Image: *image}
+	if *image == "" {
+		// The users who may not read the ConfigMap of the KServe namespace set the image instead
+		if debugConfig, err = debugcontainer.LoadConfig(ctx, clientset, *configNamespace); err != nil {
+			return fmt.Errorf("unable to read the %s config: %v", debugcontainer.ConfigMapKey, err)
+		}
+	}
+	if *command != "" {
+		debugConfig.Command = strings.Split(*command, ",")
+	}
+	debugPod, name, err := debugcontainer.Attach(ctx, clientset, debugConfig, debugcontainer.Options{
+		Namespace:        *namespace,
+		InferenceService: isvc,
+		Component:        *component,
+		Pod:              *pod,
+		Container:        *container,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Debug container %s added to pod %s, attach to it with:\n\n", name, debugPod.Name)
+	fmt.Printf("  kubectl attach -it -n %s %s -c %s\n", debugPod.Namespace, debugPod.Name, name)
+	return nil
+}
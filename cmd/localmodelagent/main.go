@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/kserve/kserve/pkg/agent"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	localmodelcachecontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/localmodelcache"
+	v1 "k8s.io/api/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+var (
+	metricsAddr = flag.String("metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	cacheDir    = flag.String("cache-dir", constants.LocalModelCachePath, "Directory of the node the models of the LocalModelCaches are downloaded to")
+)
+
+// The local model agent runs on every node of the DaemonSet and downloads the models of the LocalModelCaches selecting
+// its node, there is no leader election as every agent only manages the cache of its own node.
+func main() {
+	flag.Parse()
+	logf.SetLogger(zap.New())
+	log := logf.Log.WithName("entrypoint")
+
+	nodeName := os.Getenv(constants.LocalModelAgentNodeNameEnvVarKey)
+	if nodeName == "" {
+		log.Error(nil, "the node name is required", "env", constants.LocalModelAgentNodeNameEnvVarKey)
+		os.Exit(1)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "unable to set up client config")
+		os.Exit(1)
+	}
+	mgr, err := manager.New(cfg, manager.Options{
+		MetricsBindAddress: *metricsAddr,
+		// Only the node of the agent is read, the nodes of the cluster are not cached
+		ClientDisableCacheFor: []client.Object{&v1.Node{}},
+	})
+	if err != nil {
+		log.Error(err, "unable to set up local model agent manager")
+		os.Exit(1)
+	}
+	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "unable to add KServe v1alpha1 to scheme")
+		os.Exit(1)
+	}
+	if err := v1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "unable to add Core APIs to scheme")
+		os.Exit(1)
+	}
+
+	downloader := &agent.Downloader{
+		ModelDir:  filepath.Join(*cacheDir, localmodelcachecontroller.DownloadingDir),
+		Providers: map[storage.Protocol]storage.Provider{},
+		Logger:    zap.NewRaw().Sugar(),
+	}
+	if err = (&localmodelcachecontroller.LocalModelCacheReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("v1alpha1Controllers").WithName("LocalModelCache"),
+		NodeName:   nodeName,
+		CacheDir:   *cacheDir,
+		Downloader: downloader,
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "LocalModelCache")
+		os.Exit(1)
+	}
+
+	log.Info("Starting the local model agent", "node", nodeName, "cacheDir", *cacheDir)
+	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+		log.Error(err, "unable to run the local model agent")
+		os.Exit(1)
+	}
+}
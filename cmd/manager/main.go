@@ -16,6 +16,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -23,9 +24,19 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	graphcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph"
+	kserveconfigcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/kserveconfig"
+	templatedisvccontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/templatedinferenceservice"
 	trainedmodelcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
 	v1beta1controller "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/janitor"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/keepwarm"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/modelrefresh"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/promote"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/smoketest"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/usagereport"
+	"github.com/kserve/kserve/pkg/fips"
+	"github.com/kserve/kserve/pkg/webhook/admission/inferenceservice"
 	"github.com/kserve/kserve/pkg/webhook/admission/pod"
 	istio_networking "istio.io/api/networking/v1alpha3"
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -35,6 +46,7 @@ import (
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -60,6 +72,8 @@ type Options struct {
 	metricsAddr          string
 	webhookPort          int
 	enableLeaderElection bool
+	tlsMinVersion        string
+	deploymentStage      string
 }
 
 // DefaultOptions returns the default values for the program options.
@@ -79,6 +93,11 @@ func GetOptions() Options {
 	flag.BoolVar(&opts.enableLeaderElection, "leader-elect", opts.enableLeaderElection,
 		"Enable leader election for kserve controller manager. "+
 			"Enabling this will ensure there is only one active kserve controller manager.")
+	flag.StringVar(&opts.tlsMinVersion, "tls-min-version", opts.tlsMinVersion,
+		"The minimum TLS version of the webhook server, e.g. 1.2. Versions lower than 1.2 are rejected in FIPS mode.")
+	flag.StringVar(&opts.deploymentStage, "deployment-stage", opts.deploymentStage,
+		"The deployment stage, e.g. production, whose overlay is applied to the InferenceServices of the namespaces "+
+			"without the serving.kserve.io/deployment-stage label.")
 	flag.Parse()
 	return opts
 }
@@ -104,6 +123,11 @@ func main() {
 	// Create a new Cmd to provide shared dependencies and start components
 	log.Info("Setting up manager")
 	options := GetOptions()
+	log.Info("Crypto configuration", "fips", fips.Enabled)
+	if _, err := fips.ParseTLSVersion(options.tlsMinVersion); err != nil {
+		log.Error(err, "invalid TLS configuration")
+		os.Exit(1)
+	}
 	mgr, err := manager.New(cfg, manager.Options{
 		MetricsBindAddress: options.metricsAddr,
 		Port:               options.webhookPort,
@@ -114,6 +138,10 @@ func main() {
 		log.Error(err, "unable to set up overall controller manager")
 		os.Exit(1)
 	}
+	if options.tlsMinVersion != "" {
+		mgr.GetWebhookServer().TLSMinVersion = options.tlsMinVersion
+	}
+	v1beta1.DefaultDeploymentStage = options.deploymentStage
 
 	log.Info("Registering Components.")
 
@@ -134,6 +162,15 @@ func main() {
 		log.Error(err, "unable to create new client.")
 	}
 
+	// Installs which predate the KServeConfig resource keep their inferenceservice-config ConfigMap
+	if err := kserveconfigcontroller.ImportConfigMap(context.Background(), client); err != nil {
+		log.Error(err, "unable to import inferenceservice-config into KServeConfig")
+	}
+	if err := kserveconfigcontroller.CheckConfig(context.Background(), client); err != nil {
+		log.Error(err, "unable to start with an invalid configuration")
+		os.Exit(1)
+	}
+
 	deployConfig, err := v1beta1.NewDeployConfig(client)
 	if err != nil {
 		log.Error(err, "unable to get deploy config.")
@@ -175,11 +212,13 @@ func main() {
 	}
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&v1beta1controller.InferenceServiceReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("v1beta1Controllers").WithName("InferenceService"),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Clientset: clientSet,
+		Log:       ctrl.Log.WithName("v1beta1Controllers").WithName("InferenceService"),
+		Scheme:    mgr.GetScheme(),
 		Recorder: eventBroadcaster.NewRecorder(
 			mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+		Clock: clock.RealClock{},
 	}).SetupWithManager(mgr, deployConfig, ingressConfig.DisableIstioVirtualHost); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1beta1Controller", "InferenceService")
 		os.Exit(1)
@@ -214,11 +253,112 @@ func main() {
 		os.Exit(1)
 	}
 
+	setupLog.Info("Setting up InferenceService janitor")
+	if err = (&janitor.TTLReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("Janitor"),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceServiceJanitor"}),
+		Clock:    clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "Janitor")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService deprecation controller")
+	if err = (&janitor.DeprecationReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("Deprecation"),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceServiceDeprecation"}),
+		Clock:    clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "Deprecation")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService model refresh controller")
+	if err = (&modelrefresh.ModelRefreshReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("ModelRefresh"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceServiceModelRefresh"}),
+		Clock:    clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "ModelRefresh")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService promote controller")
+	if err = (&promote.PromoteReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("Promote"),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceServicePromote"}),
+		Clock:    clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "Promote")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService usage report controller")
+	if err = (&usagereport.UsageReportReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("v1beta1Controllers").WithName("UsageReport"),
+		Scheme: mgr.GetScheme(),
+		Clock:  clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "UsageReport")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService keep warm controller")
+	if err = (&keepwarm.KeepWarmReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("v1beta1Controllers").WithName("KeepWarm"),
+		Scheme: mgr.GetScheme(),
+		Clock:  clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "KeepWarm")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up InferenceService smoke test controller")
+	if err = (&smoketest.SmokeTestReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("SmokeTest"),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceServiceSmokeTest"}),
+		Clock:    clock.RealClock{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "SmokeTest")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up KServeConfig controller")
+	if err = (&kserveconfigcontroller.KServeConfigReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1alpha1Controllers").WithName("KServeConfig"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "KServeConfigController"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "KServeConfig")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up TemplatedInferenceService controller")
+	if err = (&templatedisvccontroller.TemplatedInferenceServiceReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1alpha1Controllers").WithName("TemplatedInferenceService"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "TemplatedInferenceServiceController"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "TemplatedInferenceService")
+		os.Exit(1)
+	}
+
 	log.Info("setting up webhook server")
 	hookServer := mgr.GetWebhookServer()
 
 	log.Info("registering webhooks to the webhook server")
 	hookServer.Register("/mutate-pods", &webhook.Admission{Handler: &pod.Mutator{}})
+	hookServer.Register("/validate-inferenceservice-policies", &webhook.Admission{Handler: &inferenceservice.PolicyValidator{}})
 
 	if err = ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.TrainedModel{}).
@@ -18,9 +18,12 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/fips"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -40,8 +43,16 @@ import (
 
 var log = logf.Log.WithName("InferenceGraphRouter")
 
-func callService(serviceUrl string, input []byte, headers http.Header) ([]byte, error) {
-	req, err := http.NewRequest("POST", serviceUrl, bytes.NewBuffer(input))
+// callService calls the service of a step, the streamed response of the step answering the graph request is written
+// to out as it is received and nil is returned
+func callService(ctx context.Context, client *http.Client, serviceUrl string, input []byte, headers http.Header,
+	idleTimeout time.Duration, out *streamWriter) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", serviceUrl, bytes.NewBuffer(input))
+	if err != nil {
+		return nil, err
+	}
 	for _, h := range headersToPropagate {
 		if values, ok := headers[h]; ok {
 			for _, v := range values {
@@ -49,14 +60,50 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 			}
 		}
 	}
-	req.Header.Add("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	// The protobuf payloads are passed through to the services with their content type
+	contentType := headers.Get("Content-Type")
+	if !constants.IsProtobufContentType(contentType) {
+		contentType = constants.JSONContentType
+	}
+	req.Header.Set("Content-Type", contentType)
+	if tokenPath != "" {
+		// The token is read on every call as the kubelet rotates the projected token before it expires
+		token, err := ioutil.ReadFile(tokenPath)
+		if err != nil {
+			log.Error(err, "failed to read service account token", "path", tokenPath)
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := client.Do(req)
 
 	if err != nil {
 		log.Error(err, "An error has occurred from service", "service", serviceUrl)
 		return nil, err
 	}
 	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); constants.IsStreamContentType(contentType) {
+		var stream io.Reader = resp.Body
+		var idle *idleReader
+		if idleTimeout > 0 {
+			idle = newIdleReader(resp.Body, idleTimeout, cancel)
+			defer idle.stop()
+			stream = idle
+		}
+		var body []byte
+		if out != nil {
+			err = out.write(resp, stream)
+		} else {
+			body, err = readStream(contentType, stream)
+		}
+		if err != nil && idle != nil && idle.expired() {
+			err = fmt.Errorf("no data was streamed by %s for %v", serviceUrl, idleTimeout)
+		}
+		if err != nil {
+			log.Error(err, "error while reading the streamed response", "service", serviceUrl)
+		}
+		return body, err
+	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		log.Error(err, "error while reading the response")
@@ -69,22 +116,56 @@ func pickupRoute(routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
 	//generate num [0,100)
 	point := r.Intn(99)
 	end := 0
-	for _, route := range routes {
-		end += int(*route.Weight)
+	for i := range routes {
+		end += int(*routes[i].Weight)
 		if point < end {
-			return &route
+			return &routes[i]
 		}
 	}
 	return nil
 }
 
-func pickupRouteByCondition(input []byte, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	if !gjson.ValidBytes(input) {
-		return nil
+const (
+	requestConditionPrefix  = "$request"
+	responseConditionPrefix = "$response"
+)
+
+type previousResponseKey struct{}
+
+// withPreviousResponse returns the context of the step following a step of a sequence, the conditions of the switch
+// nodes it routes to can match the response of the previous step
+func withPreviousResponse(ctx context.Context, response []byte) context.Context {
+	return context.WithValue(ctx, previousResponseKey{}, response)
+}
+
+// previousResponse returns the response of the previous step of the enclosing sequence, nil outside of a sequence
+func previousResponse(ctx context.Context) []byte {
+	response, _ := ctx.Value(previousResponseKey{}).([]byte)
+	return response
+}
+
+// conditionPayload returns the payload a condition is matched on and its GJSON path. The conditions prefixed with
+// $request match the request of the node and the conditions prefixed with $response match the response of the
+// previous step, e.g. $response.predictions.#(score<0.8), the other conditions match the default payload.
+func conditionPayload(condition string, request []byte, response []byte, defaultPayload []byte) ([]byte, string) {
+	for prefix, payload := range map[string][]byte{requestConditionPrefix: request, responseConditionPrefix: response} {
+		if condition == prefix {
+			return payload, "@this"
+		}
+		if strings.HasPrefix(condition, prefix+".") {
+			return payload, strings.TrimPrefix(condition, prefix+".")
+		}
 	}
-	for _, route := range routes {
-		if gjson.GetBytes(input, route.Condition).Exists() {
-			return &route
+	return defaultPayload, condition
+}
+
+// pickupRouteByCondition returns the first step whose condition matches, the conditions match the input of the node
+// unless they are prefixed with $response
+func pickupRouteByCondition(input []byte, response []byte, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
+	for i := range routes {
+		payload, path := conditionPayload(routes[i].Condition, input, response, input)
+		if gjson.ValidBytes(payload) && gjson.GetBytes(payload, path).Exists() {
+			return &routes[i]
 		}
 	}
 	return nil
@@ -95,21 +176,30 @@ func timeTrack(start time.Time, name string) {
 	log.Info("elapsed time", "node", name, "time", elapsed)
 }
 
-func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, error) {
+// routeStep routes the input through the node, out receives the streamed response of the step answering the graph
+// request, it is nil when the response of the node is not the response of the graph
+func routeStep(ctx context.Context, nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header,
+	out *streamWriter) ([]byte, error) {
 	defer timeTrack(time.Now(), nodeName)
 	currentNode := graph.Nodes[nodeName]
 
 	if currentNode.RouterType == v1alpha1.Splitter {
-		return executeStep(pickupRoute(currentNode.Steps), graph, input, headers)
+		return executeStep(ctx, nodeName, pickupRoute(currentNode.Steps), graph, input, headers, out)
 	}
 	if currentNode.RouterType == v1alpha1.Switch {
-		route := pickupRouteByCondition(input, currentNode.Steps)
+		if constants.IsProtobufContentType(headers.Get("Content-Type")) {
+			return nil, fmt.Errorf("the conditions of the switch node %s can not be evaluated on protobuf payloads", nodeName)
+		}
+		route := pickupRouteByCondition(input, previousResponse(ctx), currentNode.Steps)
 		if route == nil {
 			return input, nil //TODO maybe should fail in this case?
 		}
-		return executeStep(route, graph, input, headers)
+		return executeStep(ctx, nodeName, route, graph, input, headers, out)
 	}
 	if currentNode.RouterType == v1alpha1.Ensemble {
+		if constants.IsProtobufContentType(headers.Get("Content-Type")) {
+			return nil, fmt.Errorf("the responses of the ensemble node %s can not be merged from protobuf payloads", nodeName)
+		}
 		ensembleRes := make([]chan map[string]interface{}, len(currentNode.Steps))
 		errChan := make(chan error)
 		for i := range currentNode.Steps {
@@ -117,7 +207,8 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			resultChan := make(chan map[string]interface{})
 			ensembleRes[i] = resultChan
 			go func() {
-				output, err := executeStep(step, graph, input, headers)
+				// The streamed responses of the parallel steps are read before they are merged
+				output, err := executeStep(ctx, nodeName, step, graph, input, headers, nil)
 				if err == nil {
 					var res map[string]interface{}
 					if err = json.Unmarshal(output, &res); err == nil {
@@ -154,15 +245,29 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			}
 
 			if step.Condition != "" {
-				if !gjson.ValidBytes(responseBytes) {
+				if constants.IsProtobufContentType(headers.Get("Content-Type")) {
+					return nil, fmt.Errorf("the condition of the step %s can not be evaluated on protobuf payloads", step.StepName)
+				}
+				// The conditions of the steps match the response of the previous step unless they are prefixed with $request
+				payload, path := conditionPayload(step.Condition, input, responseBytes, responseBytes)
+				if !gjson.ValidBytes(payload) {
 					return nil, fmt.Errorf("invalid response")
 				}
 				// if the condition does not match for the step in the sequence we stop and return the response
-				if !gjson.GetBytes(responseBytes, step.Condition).Exists() {
+				if !gjson.GetBytes(payload, path).Exists() {
 					return responseBytes, nil
 				}
 			}
-			if responseBytes, err = executeStep(step, graph, request, headers); err != nil {
+			// Only the response of the last step is streamed, the streamed responses of the other steps are read
+			var stepOut *streamWriter
+			if i == len(currentNode.Steps)-1 {
+				stepOut = out
+			}
+			stepCtx := ctx
+			if i > 0 {
+				stepCtx = withPreviousResponse(ctx, responseBytes)
+			}
+			if responseBytes, err = executeStep(stepCtx, nodeName, step, graph, request, headers, stepOut); err != nil {
 				return nil, err
 			}
 		}
@@ -172,35 +277,63 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 	return nil, fmt.Errorf("invalid route type: %v", currentNode.RouterType)
 }
 
-func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, error) {
+func executeStep(ctx context.Context, nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec,
+	input []byte, headers http.Header, out *streamWriter) ([]byte, error) {
 	if step.NodeName != "" {
 		// when nodeName is specified make a recursive call for routing to next step
-		return routeStep(step.NodeName, graph, input, headers)
+		return routeStep(ctx, step.NodeName, graph, input, headers, out)
 	}
-	return callService(step.ServiceURL, input, headers)
+	var idleTimeout time.Duration
+	if graph.StreamIdleTimeoutSeconds != nil {
+		idleTimeout = time.Duration(*graph.StreamIdleTimeoutSeconds) * time.Second
+	}
+	return callService(ctx, stepClient(nodeName, step), step.ServiceURL, input, headers, idleTimeout, out)
 }
 
 var inferenceGraph *v1alpha1.InferenceGraphSpec
 
 func graphHandler(w http.ResponseWriter, req *http.Request) {
 	inputBytes, _ := ioutil.ReadAll(req.Body)
-	if response, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header); err != nil {
+	// The calls of the steps are cancelled when the client disconnects or the graph times out
+	ctx := req.Context()
+	if inferenceGraph.TimeoutSeconds != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*inferenceGraph.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	out := &streamWriter{w: w}
+	if response, err := routeStep(ctx, v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header, out); err != nil {
 		log.Error(err, "failed to process request")
+		// The status of a streamed response is sent with its first chunk, the stream is cut short
+		if out.started {
+			return
+		}
 		w.WriteHeader(500) //TODO status code tbd
 		w.Write([]byte(fmt.Sprintf("Failed to process request: %v", err)))
-	} else {
+	} else if !out.started {
+		if contentType := req.Header.Get("Content-Type"); constants.IsProtobufContentType(contentType) {
+			w.Header().Set("Content-Type", contentType)
+		}
 		w.Write(response)
 	}
 }
 
 var (
 	jsonGraph          = flag.String("graph-json", "", "serialized json graph def")
+	tlsMinVersion      = flag.String("tls-min-version", "", "minimum TLS version of the calls to the graph nodes, e.g. 1.2")
+	tlsCipherSuites    = flag.String("tls-cipher-suites", "", "comma separated TLS cipher suites allowed for the calls to the graph nodes")
 	headersToPropagate = strings.Split(os.Getenv(constants.RouterHeadersPropagateEnvVar), ",")
+	tokenPath          = os.Getenv(constants.RouterTokenPathEnvVar)
 )
 
 func main() {
 	flag.Parse()
 	logf.SetLogger(zap.New())
+	log.Info("crypto configuration", "fips", fips.Enabled)
+	if err := fips.ConfigureDefaultTransport(*tlsMinVersion, *tlsCipherSuites); err != nil {
+		log.Error(err, "invalid TLS configuration")
+		os.Exit(1)
+	}
 	inferenceGraph = &v1alpha1.InferenceGraphSpec{}
 	err := json.Unmarshal([]byte(*jsonGraph), inferenceGraph)
 	if err != nil {
@@ -209,10 +342,11 @@ func main() {
 	}
 
 	http.HandleFunc("/", graphHandler)
+	http.HandleFunc(constants.RouterCircuitBreakersPath, circuitBreakersHandler)
 
-	err = http.ListenAndServe(":8080", nil)
+	err = http.ListenAndServe(":"+constants.RouterPort, nil)
 	if err != nil {
-		log.Error(err, "failed to listen on 8080")
+		log.Error(err, "failed to listen on "+constants.RouterPort)
 		os.Exit(1)
 	}
 }
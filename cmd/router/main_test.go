@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -9,7 +10,11 @@ import (
 	"knative.dev/pkg/apis"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestSimpleModelChainer(t *testing.T) {
@@ -76,7 +81,7 @@ func TestSimpleModelChainer(t *testing.T) {
 		"Authorization": {"Bearer Token"},
 	}
 
-	res, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -148,7 +153,7 @@ func TestSimpleModelEnsemble(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -327,7 +332,7 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedModel3Response := map[string]interface{}{
@@ -356,6 +361,89 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	assert.Equal(t, expectedModel4Response, response["model4"])
 }
 
+func TestInferenceGraphWithResponseCondition(t *testing.T) {
+	classifier := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body)
+		score := "0.95"
+		if strings.Contains(string(b), "blurry") {
+			score = "0.6"
+		}
+		_, _ = rw.Write([]byte(`{"predictions":[{"label":"cat","score":` + score + `}]}`))
+	}))
+	defer classifier.Close()
+	// The models answer with the request they received
+	echo := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			b, _ := ioutil.ReadAll(req.Body)
+			_, _ = rw.Write([]byte(`{"model":"` + name + `","request":` + string(b) + `}`))
+		}))
+	}
+	small, large := echo("small"), echo("large")
+	defer small.Close()
+	defer large.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "classifier", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: classifier.URL}},
+					{StepName: "route", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "confidence"}, Data: "$request"},
+				},
+			},
+			"confidence": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "large",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: large.URL},
+						Condition:       "$response.predictions.#(score<0.8)",
+					},
+					{
+						StepName:        "small",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: small.URL},
+						Condition:       "$request.instances",
+					},
+				},
+			},
+		},
+	}
+	// The switch matches the response of the classifier and routes the request of the graph
+	res, err := routeStep(context.Background(), "root", graphSpec, []byte(`{"instances":["blurry"]}`), http.Header{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"model":"large","request":{"instances":["blurry"]}}`, string(res))
+	res, err = routeStep(context.Background(), "root", graphSpec, []byte(`{"instances":["sharp"]}`), http.Header{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"model":"small","request":{"instances":["sharp"]}}`, string(res))
+
+	// Outside of a sequence the conditions on the response do not match
+	res, err = routeStep(context.Background(), "confidence", graphSpec, []byte(`{"instances":["blurry"]}`), http.Header{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"model":"small","request":{"instances":["blurry"]}}`, string(res))
+}
+
+func TestConditionPayload(t *testing.T) {
+	request, response := []byte(`{"instances":[1]}`), []byte(`{"predictions":[0.6]}`)
+	scenarios := map[string]struct {
+		condition string
+		payload   []byte
+		path      string
+	}{
+		"Default":       {condition: "predictions.#(>0.5)", payload: response, path: "predictions.#(>0.5)"},
+		"Request":       {condition: "$request.instances.0", payload: request, path: "instances.0"},
+		"Response":      {condition: "$response.predictions.#(<0.8)", payload: response, path: "predictions.#(<0.8)"},
+		"WholeResponse": {condition: "$response", payload: response, path: "@this"},
+		"NotAPrefix":    {condition: "$responses.predictions", payload: response, path: "$responses.predictions"},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			payload, path := conditionPayload(scenario.condition, request, response, response)
+			assert.Equal(t, string(scenario.payload), string(payload))
+			assert.Equal(t, scenario.path, path)
+		})
+	}
+}
+
 func TestCallServiceWhenNoneHeadersToPropagateIsEmpty(t *testing.T) {
 	// Start a local HTTP server
 	model1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -391,7 +479,7 @@ func TestCallServiceWhenNoneHeadersToPropagateIsEmpty(t *testing.T) {
 	}
 	// Propagating no header
 	headersToPropagate = []string{}
-	res, err := callService(model1Url.String(), jsonBytes, headers)
+	res, err := callService(context.Background(), http.DefaultClient, model1Url.String(), jsonBytes, headers, 0, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -436,7 +524,7 @@ func TestCallServiceWhen1HeaderToPropagate(t *testing.T) {
 	}
 	// Propagating only 1 header "Test-Header-Key"
 	headersToPropagate = []string{"Test-Header-Key"}
-	res, err := callService(model1Url.String(), jsonBytes, headers)
+	res, err := callService(context.Background(), http.DefaultClient, model1Url.String(), jsonBytes, headers, 0, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -482,7 +570,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 	}
 	// Propagating multiple headers "Test-Header-Key"
 	headersToPropagate = []string{"Test-Header-Key", "Authorization"}
-	res, err := callService(model1Url.String(), jsonBytes, headers)
+	res, err := callService(context.Background(), http.DefaultClient, model1Url.String(), jsonBytes, headers, 0, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -493,3 +581,232 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 	fmt.Printf("final response:%v\n", response)
 	assert.Equal(t, expectedResponse, response)
 }
+
+func TestCallServiceWithServiceAccountToken(t *testing.T) {
+	model1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response := map[string]interface{}{"Authorization": req.Header.Get("Authorization")}
+		responseBytes, _ := json.Marshal(response)
+		_, _ = rw.Write(responseBytes)
+	}))
+	defer model1.Close()
+
+	tokenFile, err := ioutil.TempFile("", "token")
+	if err != nil {
+		t.Fatalf("Failed to create token file")
+	}
+	defer os.Remove(tokenFile.Name())
+	_, _ = tokenFile.WriteString("graph-token\n")
+	tokenFile.Close()
+
+	// The service account token takes precedence over a propagated Authorization header
+	headersToPropagate = []string{"Authorization"}
+	tokenPath = tokenFile.Name()
+	defer func() { tokenPath = "" }()
+	res, err := callService(context.Background(), http.DefaultClient, model1.URL, []byte("{}"), http.Header{"Authorization": {"Bearer Token"}}, 0, nil)
+	assert.Nil(t, err)
+	var response map[string]interface{}
+	err = json.Unmarshal(res, &response)
+	assert.Equal(t, map[string]interface{}{"Authorization": "Bearer graph-token"}, response)
+}
+
+func TestInferenceGraphWithProtobuf(t *testing.T) {
+	payload := []byte{0x0a, 0x04, 't', 'e', 's', 't'}
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return
+		}
+		// Echo the protobuf payload with the content type it was sent with
+		rw.Header().Set("Content-Type", req.Header.Get("Content-Type"))
+		_, err = rw.Write(append([]byte(req.Header.Get("Content-Type")+":"), b...))
+	}))
+	modelUrl, err := apis.ParseURL(model.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse model url")
+	}
+	defer model.Close()
+
+	steps := []v1alpha1.InferenceStep{
+		{
+			StepName: "model1",
+			InferenceTarget: v1alpha1.InferenceTarget{
+				ServiceURL: modelUrl.String(),
+			},
+		},
+	}
+	headers := http.Header{
+		"Content-Type": {"application/x-protobuf"},
+	}
+
+	// The sequence passes the protobuf payload through with its content type
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps:      steps,
+			},
+		},
+	}
+	res, err := routeStep(context.Background(), "root", graphSpec, payload, headers, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, append([]byte("application/x-protobuf:"), payload...), res)
+
+	// The ensemble can not merge the protobuf responses
+	graphSpec.Nodes["root"] = v1alpha1.InferenceRouter{
+		RouterType: v1alpha1.Ensemble,
+		Steps:      steps,
+	}
+	_, err = routeStep(context.Background(), "root", graphSpec, payload, headers, nil)
+	assert.NotNil(t, err)
+}
+
+func TestInferenceGraphWithStreaming(t *testing.T) {
+	stream := "data: {\"token\":\"a\"}\n\ndata: {\"token\":\"b\"}\n\ndata: [DONE]\n\n"
+	llm := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range strings.SplitAfter(stream, "\n\n") {
+			_, _ = rw.Write([]byte(event))
+			rw.(http.Flusher).Flush()
+		}
+	}))
+	defer llm.Close()
+	echo := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body)
+		_, _ = rw.Write(b)
+	}))
+	defer echo.Close()
+
+	// The stream of the last step of the sequence is passed through to the client
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "preprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: echo.URL}},
+					{StepName: "llm", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: llm.URL}, Data: "$response"},
+				},
+			},
+		},
+	}
+	defer func() { inferenceGraph = nil }()
+	rec := httptest.NewRecorder()
+	graphHandler(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"prompt":"hello"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, stream, rec.Body.String())
+
+	// The stream of an intermediate step is read into a JSON array of its events
+	inferenceGraph.Nodes["root"] = v1alpha1.InferenceRouter{
+		RouterType: v1alpha1.Sequence,
+		Steps: []v1alpha1.InferenceStep{
+			{StepName: "llm", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: llm.URL}},
+			{StepName: "postprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: echo.URL}, Data: "$response"},
+		},
+	}
+	rec = httptest.NewRecorder()
+	graphHandler(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"prompt":"hello"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `[{"token":"a"},{"token":"b"}]`, rec.Body.String())
+}
+
+func TestCallServiceStreamIdleTimeout(t *testing.T) {
+	llm := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = rw.Write([]byte("{\"token\":\"a\"}\n"))
+		rw.(http.Flusher).Flush()
+		// The stream stalls until the router cancels the call
+		<-req.Context().Done()
+	}))
+	defer llm.Close()
+
+	_, err := callService(context.Background(), http.DefaultClient, llm.URL, []byte("{}"), http.Header{}, 100*time.Millisecond, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no data was streamed")
+}
+
+func TestInferenceGraphStepRetries(t *testing.T) {
+	var calls int32
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body)
+		// The first two calls fail with a transient failure
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte("unavailable"))
+			return
+		}
+		_, _ = rw.Write(b)
+	}))
+	defer model.Close()
+
+	retries := int32(2)
+	delay := int64(1)
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Retries:         &retries,
+						Backoff:         &v1alpha1.StepBackoff{InitialDelayMilliseconds: &delay},
+					},
+				},
+			},
+		},
+	}
+	res, err := routeStep(context.Background(), "root", graphSpec, []byte(`{"instances":[1]}`), http.Header{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"instances":[1]}`, string(res))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// The response of the last attempt is returned once the retries are exhausted
+	atomic.StoreInt32(&calls, 0)
+	retries = 1
+	res, err = routeStep(context.Background(), "root", graphSpec, []byte(`{"instances":[1]}`), http.Header{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "unavailable", string(res))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestInferenceGraphStepCircuitBreaker(t *testing.T) {
+	var calls int32
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer model.Close()
+
+	threshold := int32(2)
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "failing",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						CircuitBreaker:  &v1alpha1.StepCircuitBreaker{FailureThreshold: &threshold},
+					},
+				},
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := routeStep(context.Background(), "root", graphSpec, []byte("{}"), http.Header{}, nil)
+		assert.Nil(t, err)
+	}
+	// The open circuit fails the calls without reaching the service
+	_, err := routeStep(context.Background(), "root", graphSpec, []byte("{}"), http.Header{}, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "circuit breaker of the step root/failing is open")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	rec := httptest.NewRecorder()
+	circuitBreakersHandler(rec, httptest.NewRequest(http.MethodGet, "/circuit-breakers", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	states := map[string]string{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &states))
+	assert.Equal(t, "open", states["root/failing"])
+}
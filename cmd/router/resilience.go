@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/circuitbreaker"
+)
+
+const (
+	defaultInitialDelay     = 100 * time.Millisecond
+	defaultMaxDelay         = time.Second
+	defaultMultiplier       = 2
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+)
+
+// breakerRegistry holds the circuit breakers of the steps, they are created on the first call of their step
+type breakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitbreaker.Breaker
+}
+
+var breakers = &breakerRegistry{breakers: map[string]*circuitbreaker.Breaker{}}
+
+// stepID identifies a step within its node by its name, or else by its service
+func stepID(nodeName string, step *v1alpha1.InferenceStep) string {
+	id := step.StepName
+	if id == "" {
+		id = step.ServiceName
+	}
+	if id == "" {
+		id = step.ServiceURL
+	}
+	return nodeName + "/" + id
+}
+
+// get returns the circuit breaker of the step, nil when the step has none
+func (r *breakerRegistry) get(id string, spec *v1alpha1.StepCircuitBreaker) *circuitbreaker.Breaker {
+	if spec == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if breaker, ok := r.breakers[id]; ok {
+		return breaker
+	}
+	failureThreshold := defaultFailureThreshold
+	if spec.FailureThreshold != nil {
+		failureThreshold = int(*spec.FailureThreshold)
+	}
+	openDuration := defaultOpenDuration
+	if spec.OpenDurationSeconds != nil {
+		openDuration = time.Duration(*spec.OpenDurationSeconds) * time.Second
+	}
+	breaker := circuitbreaker.NewBreakerWithListener(failureThreshold, openDuration, func(state circuitbreaker.State) {
+		log.Info("circuit breaker state changed", "step", id, "state", state)
+	})
+	r.breakers[id] = breaker
+	return breaker
+}
+
+// states returns the state of every circuit breaker by step
+func (r *breakerRegistry) states() map[string]circuitbreaker.State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	states := make(map[string]circuitbreaker.State, len(r.breakers))
+	for id, breaker := range r.breakers {
+		states[id] = breaker.State()
+	}
+	return states
+}
+
+// stepClient returns the client calling the service of the step with its retries and its circuit breaker
+func stepClient(nodeName string, step *v1alpha1.InferenceStep) *http.Client {
+	if step.Retries == nil && step.CircuitBreaker == nil {
+		return http.DefaultClient
+	}
+	id := stepID(nodeName, step)
+	return &http.Client{Transport: &stepTransport{
+		id:      id,
+		step:    step,
+		breaker: breakers.get(id, step.CircuitBreaker),
+	}}
+}
+
+// stepTransport retries the calls of a step which can not reach the service or are answered with a transient
+// failure, the response of the last attempt is returned once the retries are exhausted. The calls fail without
+// reaching the service while the circuit breaker of the step is open.
+type stepTransport struct {
+	id      string
+	step    *v1alpha1.InferenceStep
+	breaker *circuitbreaker.Breaker
+}
+
+func (t *stepTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if t.step.Retries != nil {
+		retries = int(*t.step.Retries)
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// The body can not be sent again
+		retries = 0
+	}
+	delay, maxDelay, multiplier := backoff(t.step.Backoff)
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if t.breaker != nil {
+			if allowed, _ := t.breaker.Allow(); !allowed {
+				return nil, fmt.Errorf("the circuit breaker of the step %s is open", t.id)
+			}
+		}
+		// The default transport is configured with the TLS settings of the router
+		resp, err := http.DefaultTransport.RoundTrip(attemptReq)
+		failed := err != nil || circuitbreaker.IsFailure(resp.StatusCode)
+		if t.breaker != nil {
+			if failed {
+				t.breaker.Failure()
+			} else {
+				t.breaker.Success()
+			}
+		}
+		if !failed || attempt >= retries || req.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Info("retrying the call of the step", "step", t.id, "attempt", attempt+1, "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if delay *= time.Duration(multiplier); delay > maxDelay {
+			delay = maxDelay
+		}
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// backoff returns the delay before the first retry, the maximum delay and the multiplier of the delay
+func backoff(spec *v1alpha1.StepBackoff) (time.Duration, time.Duration, int) {
+	delay, maxDelay, multiplier := defaultInitialDelay, defaultMaxDelay, defaultMultiplier
+	if spec == nil {
+		return delay, maxDelay, multiplier
+	}
+	if spec.InitialDelayMilliseconds != nil {
+		delay = time.Duration(*spec.InitialDelayMilliseconds) * time.Millisecond
+	}
+	if spec.MaxDelayMilliseconds != nil {
+		maxDelay = time.Duration(*spec.MaxDelayMilliseconds) * time.Millisecond
+	}
+	if spec.Multiplier != nil {
+		multiplier = int(*spec.Multiplier)
+	}
+	if maxDelay < delay {
+		maxDelay = delay
+	}
+	return delay, maxDelay, multiplier
+}
+
+// circuitBreakersHandler returns the state of the circuit breakers of the steps, the graph requests sent to its path
+// are routed through the graph
+func circuitBreakersHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		graphHandler(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(breakers.states())
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// maxEventSize is the maximum size of a line of the streamed responses read by the router
+const maxEventSize = 10 * 1024 * 1024
+
+// streamWriter sends the streamed response of the step answering the graph request to the client as it is received
+type streamWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+// write copies the streamed response to the client and flushes every chunk, the status and the headers are sent
+// before the first chunk so the errors of the stream can not be reported to the client
+func (s *streamWriter) write(resp *http.Response, body io.Reader) error {
+	s.w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	s.w.Header().Set("Cache-Control", "no-cache")
+	s.w.WriteHeader(resp.StatusCode)
+	s.started = true
+	flusher, _ := s.w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, err := s.w.Write(buf[:n]); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readStream reads the streamed response of a step into a JSON array so that the next steps and the conditions see a
+// JSON payload. The items are the data of the Server-Sent Events or the lines of the newline delimited JSON, the
+// [DONE] event closing the OpenAI streams is dropped and the data that is not JSON is added as a string.
+func readStream(contentType string, body io.Reader) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	events := []json.RawMessage{}
+	add := func(data string) {
+		if data == "" || data == "[DONE]" {
+			return
+		}
+		if json.Valid([]byte(data)) {
+			events = append(events, json.RawMessage(data))
+			return
+		}
+		quoted, _ := json.Marshal(data)
+		events = append(events, quoted)
+	}
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if mediaType == constants.NDJSONContentType {
+			add(strings.TrimSpace(line))
+			continue
+		}
+		// The data lines of an event are joined, the event ends with an empty line
+		switch {
+		case line == "":
+			add(strings.Join(data, "\n"))
+			data = nil
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	add(strings.Join(data, "\n"))
+	return json.Marshal(events)
+}
+
+// idleReader cancels the call of a streamed response when no data is received for the idle timeout
+type idleReader struct {
+	r        io.Reader
+	timeout  time.Duration
+	timer    *time.Timer
+	canceled int32
+}
+
+func newIdleReader(r io.Reader, timeout time.Duration, cancel context.CancelFunc) *idleReader {
+	idle := &idleReader{r: r, timeout: timeout}
+	idle.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&idle.canceled, 1)
+		cancel()
+	})
+	return idle
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// expired returns true if the call was cancelled because the stream was idle
+func (r *idleReader) expired() bool {
+	return atomic.LoadInt32(&r.canceled) == 1
+}
+
+func (r *idleReader) stop() {
+	r.timer.Stop()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/kserve/kserve/pkg/conformance"
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	url       = flag.String("url", "http://localhost:8080", "URL of the model server of the candidate runtime")
+	host      = flag.String("host", "", "Host header of the requests, e.g. the host of an InferenceService behind an ingress gateway")
+	token     = flag.String("token", "", "Bearer token of the requests")
+	model     = flag.String("model", "", "Name of the model served by the runtime")
+	protocols = flag.StringSlice("protocols", []string{conformance.ProtocolV1, conformance.ProtocolV2}, "Comma separated protocols checked, v1, v2 and openai")
+	instances = flag.String("instances-file", "", "JSON file with the instances of the v1 infer check, the check is skipped when empty")
+	request   = flag.String("request-file", "", "JSON file with the v2 infer request, generated from the inputs of the model metadata when empty")
+	prompt    = flag.String("prompt", conformance.DefaultPrompt, "Prompt of the OpenAI chat completion checks")
+	timeout   = flag.Duration("timeout", conformance.DefaultTimeout, "Timeout of each check")
+	output    = flag.String("output", "text", "Format of the report, text or json")
+)
+
+func main() {
+	flag.Parse()
+	report, err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+	switch *output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	default:
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to write the report: %v\n", err)
+		os.Exit(2)
+	}
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
+
+func run() (*conformance.Report, error) {
+	if *output != "text" && *output != "json" {
+		return nil, fmt.Errorf("unsupported output %q, must be text or json", *output)
+	}
+	config := conformance.Config{
+		ModelName: *model,
+		Prompt:    *prompt,
+		Timeout:   *timeout,
+	}
+	for _, protocol := range *protocols {
+		config.Protocols = append(config.Protocols, strings.TrimSpace(protocol))
+	}
+	if *instances != "" {
+		if err := readJSON(*instances, &config.Instances); err != nil {
+			return nil, err
+		}
+	}
+	if *request != "" {
+		config.InferRequest = &inference.InferRequest{}
+		if err := readJSON(*request, config.InferRequest); err != nil {
+			return nil, err
+		}
+	}
+	// The requests are not retried so that the error checks observe the first response of the runtime
+	options := []inference.Option{inference.WithRetries(0, 0, 0)}
+	if *host != "" {
+		options = append(options, inference.WithHost(*host))
+	}
+	if *token != "" {
+		options = append(options, inference.WithToken(*token))
+	}
+	client, err := inference.NewClient(*url, options...)
+	if err != nil {
+		return nil, err
+	}
+	report, err := conformance.Run(context.Background(), client, config)
+	if err != nil {
+		return nil, err
+	}
+	report.URL = *url
+	return report, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
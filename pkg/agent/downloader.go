@@ -38,6 +38,8 @@ type Downloader struct {
 	mu        sync.Mutex
 	Providers map[storage.Protocol]storage.Provider
 	Logger    *zap.SugaredLogger
+	// Cache shared with the other agents the models are fetched from before they are downloaded, disabled when nil
+	Cache *storage.Cache
 }
 
 func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSpec) error {
@@ -86,9 +88,39 @@ func (d *Downloader) download(modelName string, storageUri string) error {
 	if err != nil {
 		return errors.Wrapf(err, "unable to create or get provider for protocol %s", protocol)
 	}
-	if err := provider.DownloadModel(d.ModelDir, modelName, storageUri); err != nil {
+	modelPath := filepath.Join(d.ModelDir, modelName)
+	syncProvider, canSync := provider.(storage.SyncProvider)
+	if !canSync || !storage.HasSyncManifest(modelPath) {
+		// The files of a previous model spec, e.g. of another storage uri, are not served with the new model
+		if err := os.RemoveAll(modelPath); err != nil {
+			return errors.Wrapf(err, "failed to remove the previous model")
+		}
+		if d.Cache != nil {
+			cached, err := d.Cache.Fetch(d.ModelDir, modelName, storageUri)
+			if err != nil {
+				d.Logger.Warnf("Failed to fetch model %s from cache, downloading it from %s: %v", modelName, storageUri, err)
+			} else if cached {
+				return nil
+			}
+		}
+	}
+	if canSync {
+		// Only the objects which changed since the model dir was synced are downloaded
+		if err := syncProvider.SyncModel(d.ModelDir, modelName, storageUri); err != nil {
+			return errors.Wrapf(err, "failed to sync model")
+		}
+	} else if err := provider.DownloadModel(d.ModelDir, modelName, storageUri); err != nil {
 		return errors.Wrapf(err, "failed to download model")
 	}
+	if d.Cache != nil {
+		// The model is served even when it could not be cached
+		if err := d.Cache.Store(d.ModelDir, modelName, storageUri); err != nil {
+			d.Logger.Warnf("Failed to store model %s in cache: %v", modelName, err)
+		}
+		if err := d.Cache.Evict(); err != nil {
+			d.Logger.Warnf("Failed to evict models from cache: %v", err)
+		}
+	}
 	return nil
 }
 
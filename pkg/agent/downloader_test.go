@@ -99,4 +99,57 @@ var _ = Describe("Downloader", func() {
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
+
+	Context("When the model is cached", func() {
+		It("Should fetch the model from the cache", func() {
+			bucket := mocks.NewMockS3Bucket()
+			cache := &storage.Cache{Client: bucket, Uploader: bucket, Bucket: "model-cache"}
+			cachedDir := modelDir + "/cached"
+			file, err := storage.Create(cachedDir + "/model1/model.pt")
+			Expect(err).To(BeNil())
+			_, err = file.WriteString("weights")
+			Expect(err).To(BeNil())
+			file.Close()
+			Expect(cache.Store(cachedDir, "model1", "s3://models/model1")).To(Succeed())
+
+			// The model is not downloaded from the failing storage uri
+			downloader.Providers[storage.S3] = &storage.S3Provider{
+				Client:     &mocks.MockS3Client{},
+				Downloader: &mocks.MockS3FailDownloader{},
+			}
+			downloader.Cache = cache
+			modelConfig := modelconfig.ModelConfig{
+				Name: "model1",
+				Spec: v1alpha1.ModelSpec{
+					StorageURI: "s3://models/model1",
+					Framework:  "sklearn",
+				},
+			}
+			err = downloader.DownloadModel(modelConfig.Name, &modelConfig.Spec)
+			Expect(err).To(BeNil())
+			Expect(downloader.ModelDir + "/model1/model.pt").To(BeAnExistingFile())
+		})
+	})
+
+	Context("When the model spec is updated", func() {
+		It("Should only download the changed objects", func() {
+			bucket := mocks.NewMockS3Bucket()
+			bucket.Objects["model1/v1/shard-1.bin"] = []byte("shard-1")
+			bucket.Objects["model1/v1/shard-2.bin"] = []byte("shard-2")
+			downloader.Providers[storage.S3] = &storage.S3Provider{Client: bucket, Downloader: bucket}
+			modelSpec := &v1alpha1.ModelSpec{StorageURI: "s3://models/model1/v1", Framework: "pytorch"}
+			Expect(downloader.DownloadModel("model1", modelSpec)).To(Succeed())
+			Expect(bucket.Downloaded).To(HaveLen(2))
+
+			bucket.Objects["model1/v2/shard-1.bin"] = []byte("shard-1")
+			bucket.Objects["model1/v2/shard-2.bin"] = []byte("shard-2-v2")
+			bucket.Downloaded = nil
+			updatedSpec := &v1alpha1.ModelSpec{StorageURI: "s3://models/model1/v2", Framework: "pytorch"}
+			Expect(downloader.DownloadModel("model1", updatedSpec)).To(Succeed())
+			Expect(bucket.Downloaded).To(ConsistOf("model1/v2/shard-2.bin"))
+			// The success file of the previous spec is removed with the stale files
+			Expect(downloader.ModelDir + "/model1/SUCCESS." + storage.AsSha256(modelSpec)).NotTo(BeAnExistingFile())
+			Expect(downloader.ModelDir + "/model1/SUCCESS." + storage.AsSha256(updatedSpec)).To(BeAnExistingFile())
+		})
+	})
 })
@@ -156,5 +156,14 @@ type mockWriter struct {
 func (w *mockWriter) Write(data []byte) (int, error) {
 	int, err := w.buf.Write(data)
 	w.obj.MD5 = data
+	w.obj.Size = int64(len(data))
 	return int, err
 }
+
+func (w *mockWriter) ObjectAttrs() *gstorage.ObjectAttrs {
+	return w.obj
+}
+
+func (w *mockWriter) Close() error {
+	return nil
+}
@@ -17,8 +17,18 @@ limitations under the License.
 package mocks
 
 import (
+	"bytes"
+	"crypto/md5"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -59,3 +69,128 @@ func (m *MockS3FailDownloader) DownloadWithIterator(aws.Context, s3manager.Batch
 	})
 	return s3manager.NewBatchError("BatchedDownloadIncomplete", "some objects have failed to download.", errs)
 }
+
+// MockS3Bucket is an in memory bucket, it stores the objects uploaded and put and serves them back
+type MockS3Bucket struct {
+	s3iface.S3API
+	Objects      map[string][]byte
+	LastModified map[string]time.Time
+	// Downloaded are the keys of the objects downloaded with DownloadWithIterator
+	Downloaded []string
+}
+
+func NewMockS3Bucket() *MockS3Bucket {
+	return &MockS3Bucket{Objects: map[string][]byte{}, LastModified: map[string]time.Time{}}
+}
+
+func (m *MockS3Bucket) notFound() error {
+	return awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "not found", nil), 404, "")
+}
+
+func (m *MockS3Bucket) put(key string, body io.Reader) error {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.Objects[key] = data
+	m.LastModified[key] = time.Now()
+	return nil
+}
+
+func (m *MockS3Bucket) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	data, ok := m.Objects[*input.Key]
+	if !ok {
+		return nil, m.notFound()
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (m *MockS3Bucket) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	data, ok := m.Objects[*input.Key]
+	if !ok {
+		return nil, m.notFound()
+	}
+	return &s3.GetObjectOutput{
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: aws.Int64(int64(len(data))),
+	}, nil
+}
+
+func (m *MockS3Bucket) HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	if _, ok := m.Objects[*input.Key]; !ok {
+		return nil, m.notFound()
+	}
+	return &s3.HeadObjectOutput{}, nil
+}
+
+func (m *MockS3Bucket) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	return &s3.PutObjectOutput{}, m.put(*input.Key, input.Body)
+}
+
+func (m *MockS3Bucket) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	return m.PutObject(input)
+}
+
+func (m *MockS3Bucket) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	delete(m.Objects, *input.Key)
+	delete(m.LastModified, *input.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (m *MockS3Bucket) ListObjectsPages(input *s3.ListObjectsInput, fn func(*s3.ListObjectsOutput, bool) bool) error {
+	keys := make([]string, 0, len(m.Objects))
+	for key := range m.Objects {
+		if strings.HasPrefix(key, *input.Prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	page := &s3.ListObjectsOutput{}
+	for _, key := range keys {
+		page.Contents = append(page.Contents, &s3.Object{
+			Key:          aws.String(key),
+			LastModified: aws.Time(m.LastModified[key]),
+			ETag:         aws.String(fmt.Sprintf("\"%x\"", md5.Sum(m.Objects[key]))),
+			Size:         aws.Int64(int64(len(m.Objects[key]))),
+		})
+	}
+	fn(page, true)
+	return nil
+}
+
+func (m *MockS3Bucket) ListObjects(input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
+	output := &s3.ListObjectsOutput{}
+	err := m.ListObjectsPages(input, func(page *s3.ListObjectsOutput, _ bool) bool {
+		output.Contents = page.Contents
+		return false
+	})
+	return output, err
+}
+
+func (m *MockS3Bucket) DownloadWithIterator(_ aws.Context, iter s3manager.BatchDownloadIterator, _ ...func(*s3manager.Downloader)) error {
+	for iter.Next() {
+		object := iter.DownloadObject()
+		data, ok := m.Objects[*object.Object.Key]
+		if !ok {
+			return m.notFound()
+		}
+		if _, err := object.Writer.WriteAt(data, 0); err != nil {
+			return err
+		}
+		m.Downloaded = append(m.Downloaded, *object.Object.Key)
+		if object.After != nil {
+			if err := object.After(); err != nil {
+				return err
+			}
+		}
+	}
+	return iter.Err()
+}
+
+func (m *MockS3Bucket) Upload(input *s3manager.UploadInput, _ ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	return &s3manager.UploadOutput{}, m.put(*input.Key, input.Body)
+}
+
+func (m *MockS3Bucket) UploadWithContext(_ aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	return m.Upload(input, opts...)
+}
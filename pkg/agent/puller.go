@@ -17,10 +17,7 @@ limitations under the License.
 package agent
 
 import (
-	"bytes"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"path/filepath"
 	"sync"
 	"syscall"
@@ -43,6 +40,9 @@ type Puller struct {
 	opStats     map[string]map[OpType]int
 	waitGroup   WaitGroupWrapper
 	Downloader  *Downloader
+	repository  *ModelRepository
+	readiness   *Readiness
+	status      *ModelStatus
 	logger      *zap.SugaredLogger
 }
 
@@ -57,13 +57,20 @@ type WaitGroupWrapper struct {
 	wg sync.WaitGroup
 }
 
-func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp, logger *zap.SugaredLogger) {
+// StartPullerAndProcessModels processes the model operations, it returns once the models of the model config at
+// startup are processed. The models are loaded on the model server through the repository, the readiness records the
+// models loaded and the status records the load results, both may be nil.
+func StartPullerAndProcessModels(downloader *Downloader, repository *ModelRepository, commands <-chan ModelOp,
+	readiness *Readiness, status *ModelStatus, logger *zap.SugaredLogger) {
 	puller := Puller{
 		channelMap:  make(map[string]*ModelChannel),
 		completions: make(chan *ModelOp, 4),
 		opStats:     make(map[string]map[OpType]int),
 		waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
 		Downloader:  downloader,
+		repository:  repository,
+		readiness:   readiness,
+		status:      status,
 		logger:      logger,
 	}
 
@@ -75,6 +82,7 @@ func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp
 	puller.waitGroup.wg.Add(len(commands))
 	go puller.processCommands(commands)
 	puller.waitGroup.wg.Wait()
+	readiness.initialModelsProcessed()
 }
 
 func (p *Puller) processCommands(commands <-chan ModelOp) {
@@ -107,6 +115,12 @@ func (p *Puller) enqueueModelOp(modelOp *ModelOp) {
 		go p.modelProcessor(modelOp.ModelName, modelChan.modelOps)
 		p.channelMap[modelOp.ModelName] = modelChan
 	}
+	switch {
+	case modelOp.Op == Add && modelOp.OnStartup:
+		p.readiness.expectModel(modelOp.ModelName)
+	case modelOp.Op == Remove:
+		p.readiness.forgetModel(modelOp.ModelName)
+	}
 	modelChan.opsInFlight += 1
 	modelChan.modelOps <- modelOp
 }
@@ -149,57 +163,44 @@ func (p *Puller) modelProcessor(modelName string, ops <-chan *ModelOp) {
 		switch modelOp.Op {
 		case Add:
 			p.logger.Infof("Downloading model from %s", modelOp.Spec.StorageURI)
+			p.status.modelLoading(modelName)
 			err := p.Downloader.DownloadModel(modelName, modelOp.Spec)
 			if err != nil {
 				// If there is an error, we will NOT send a request. As such, to know about errors, you will
-				// need to call the error endpoint of the puller
+				// need to get the model status of the agent
 				p.logger.Errorf("Failed to download model %s with err %v", modelName, err)
+				p.status.modelFailed(modelName, fmt.Sprintf("failed to download the model: %v", err))
+			} else if err := p.repository.Load(modelName); err != nil {
+				p.logger.Errorf("Failed to load model %s with err %v", modelName, err)
+				p.status.modelFailed(modelName, loadFailure(err))
 			} else {
-				// Load the model onto the model server
-				resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/load", modelName),
-					"application/json",
-					bytes.NewBufferString("{}"))
-				if err != nil {
-					// handle error
-					p.logger.Errorf("Failed to Load model %s", modelName)
-				} else {
-					defer resp.Body.Close()
-					if resp.StatusCode == 200 {
-						p.logger.Infof("Successfully loaded model %s", modelName)
-					} else {
-						body, err := ioutil.ReadAll(resp.Body)
-						if err == nil {
-							p.logger.Infof("Failed to load model %s with status [%d] and resp:%v", modelName, resp.StatusCode, body)
-						}
-					}
-				}
+				p.logger.Infof("Successfully loaded model %s", modelName)
+				p.readiness.modelLoaded(modelName)
+				p.status.modelLoaded(modelName)
 			}
 		case Remove:
 			p.logger.Infof("unloading model %s", modelName)
-			// If there is an error, we will NOT do a delete... that could be problematic
+			p.readiness.modelUnloaded(modelName)
+			p.status.modelRemoved(modelName)
+			// The model is unloaded before its directory is deleted, the model server may still read its files
+			if err := p.repository.Unload(modelName); err != nil {
+				p.logger.Errorf("Failed to unload model %s with err %v", modelName, err)
+			} else {
+				p.logger.Infof("Successfully unloaded model %s", modelName)
+			}
 			if err := storage.RemoveDir(filepath.Join(p.Downloader.ModelDir, modelName)); err != nil {
 				p.logger.Error(err, "failing to delete model directory")
-			} else {
-				// unload model from model server
-				resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/unload", modelName),
-					"application/json",
-					bytes.NewBufferString("{}"))
-				if err != nil {
-					// handle error
-					p.logger.Errorf("Failed to Unload model %s", modelName)
-				} else {
-					defer resp.Body.Close()
-					if resp.StatusCode == 200 {
-						p.logger.Infof("Successfully unloaded model %s", modelName)
-					} else {
-						body, err := ioutil.ReadAll(resp.Body)
-						if err == nil {
-							p.logger.Infof("Failed to unload model %s with status [%d] and resp:%v", modelName, resp.StatusCode, body)
-						}
-					}
-				}
 			}
 		}
 		p.completions <- modelOp
 	}
 }
+
+// loadFailure returns the reason of the failed load of a model reported in the model status, the model server
+// explains the failure in its response, e.g. the model format is not supported
+func loadFailure(err error) string {
+	if _, ok := err.(*RepositoryError); ok {
+		return fmt.Sprintf("failed to load the model with %v", err)
+	}
+	return fmt.Sprintf("failed to load the model: %v", err)
+}
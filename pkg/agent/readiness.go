@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// ReadinessPolicy decides when the agent reports the multi-model server ready while the models load
+type ReadinessPolicy string
+
+const (
+	// ReadyAlways reports the server ready whichever models are loaded
+	ReadyAlways ReadinessPolicy = constants.ModelReadinessAlways
+	// ReadyMinModels reports the server ready once a minimum number of models are loaded
+	ReadyMinModels ReadinessPolicy = constants.ModelReadinessMinModels
+	// ReadyInitialModels reports the server ready once the models of the model config at startup are loaded
+	ReadyInitialModels ReadinessPolicy = constants.ModelReadinessInitialModels
+)
+
+// Readiness tracks the models loaded by the puller to answer the readiness probes of the agent.
+// A nil Readiness tracks nothing.
+type Readiness struct {
+	policy    ReadinessPolicy
+	minModels int
+	mu        sync.Mutex
+	loaded    map[string]bool
+	initial   map[string]bool
+	synced    bool
+}
+
+// NewReadiness creates the readiness of the policy, minModels is only used by the min-models policy
+func NewReadiness(policy ReadinessPolicy, minModels int) (*Readiness, error) {
+	switch policy {
+	case ReadyAlways, ReadyInitialModels:
+	case ReadyMinModels:
+		if minModels <= 0 {
+			return nil, fmt.Errorf("invalid minimum number of models %d, must be greater than 0", minModels)
+		}
+	default:
+		return nil, fmt.Errorf("invalid readiness policy %q, must be one of [%s, %s, %s]", policy, ReadyAlways,
+			ReadyMinModels, ReadyInitialModels)
+	}
+	return &Readiness{
+		policy:    policy,
+		minModels: minModels,
+		loaded:    make(map[string]bool),
+		initial:   make(map[string]bool),
+	}, nil
+}
+
+// Ready returns whether the models loaded satisfy the policy
+func (r *Readiness) Ready() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.policy {
+	case ReadyMinModels:
+		return len(r.loaded) >= r.minModels
+	case ReadyInitialModels:
+		if !r.synced {
+			return false
+		}
+		for name := range r.initial {
+			if !r.loaded[name] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// expectModel adds a model of the model config at startup to the models the initial-models policy waits for
+func (r *Readiness) expectModel(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.initial[name] = true
+}
+
+// forgetModel stops waiting for a model removed from the model config
+func (r *Readiness) forgetModel(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.initial, name)
+}
+
+// modelLoaded records a model loaded onto the model server
+func (r *Readiness) modelLoaded(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loaded[name] = true
+}
+
+// modelUnloaded records a model unloaded from the model server
+func (r *Readiness) modelUnloaded(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.loaded, name)
+}
+
+// initialModelsProcessed records that the puller processed the models of the model config at startup, the models
+// which failed to load are still waited for
+func (r *Readiness) initialModelsProcessed() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.synced = true
+}
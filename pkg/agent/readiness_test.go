@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Readiness", func() {
+	Context("With the always policy", func() {
+		It("Should be ready without models", func() {
+			readiness, err := NewReadiness(ReadyAlways, 0)
+			Expect(err).To(BeNil())
+			Expect(readiness.Ready()).To(BeTrue())
+		})
+	})
+	Context("With the min-models policy", func() {
+		It("Should be ready once enough models are loaded", func() {
+			readiness, err := NewReadiness(ReadyMinModels, 2)
+			Expect(err).To(BeNil())
+			readiness.modelLoaded("model1")
+			Expect(readiness.Ready()).To(BeFalse())
+			readiness.modelLoaded("model2")
+			Expect(readiness.Ready()).To(BeTrue())
+			readiness.modelUnloaded("model1")
+			Expect(readiness.Ready()).To(BeFalse())
+		})
+		It("Should reject a minimum of zero models", func() {
+			_, err := NewReadiness(ReadyMinModels, 0)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+	Context("With the initial-models policy", func() {
+		It("Should be ready once the initial models are loaded", func() {
+			readiness, err := NewReadiness(ReadyInitialModels, 0)
+			Expect(err).To(BeNil())
+			readiness.expectModel("model1")
+			readiness.expectModel("model2")
+			readiness.modelLoaded("model1")
+			readiness.initialModelsProcessed()
+			Expect(readiness.Ready()).To(BeFalse())
+			// A model removed from the model config is no longer waited for
+			readiness.forgetModel("model2")
+			Expect(readiness.Ready()).To(BeTrue())
+		})
+		It("Should not be ready before the initial models are processed", func() {
+			readiness, err := NewReadiness(ReadyInitialModels, 0)
+			Expect(err).To(BeNil())
+			Expect(readiness.Ready()).To(BeFalse())
+		})
+	})
+	It("Should reject an unknown policy", func() {
+		_, err := NewReadiness("ready", 0)
+		Expect(err).NotTo(BeNil())
+	})
+})
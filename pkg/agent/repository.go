@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxRepositoryErrorSize bounds the response body read to explain a failed repository request
+const maxRepositoryErrorSize = 1 << 16
+
+// ModelRepository loads and unloads the models of the model config on the model server through the repository
+// extension of the Open Inference Protocol, which is implemented by the KServe model server, Triton and MLServer.
+// The model server finds the model named after the directory the puller downloaded it to in its model repository.
+type ModelRepository struct {
+	serverURL string
+	client    *http.Client
+}
+
+// RepositoryError is the failure of a repository request answered by the model server
+type RepositoryError struct {
+	StatusCode int
+	// Message is the error of the response of the model server, the raw body when it is not an inference error
+	Message string
+}
+
+func (e *RepositoryError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// NewModelRepository creates the model repository of the model server listening at serverURL. The requests have no
+// timeout, loading a large model may take minutes.
+func NewModelRepository(serverURL string) *ModelRepository {
+	return &ModelRepository{
+		serverURL: serverURL,
+		client:    &http.Client{},
+	}
+}
+
+// Load requests the model server to load the model, or to reload it when it is already loaded
+func (r *ModelRepository) Load(name string) error {
+	return r.post(name, "load")
+}
+
+// Unload requests the model server to unload the model, a model unknown to the model server is already unloaded
+func (r *ModelRepository) Unload(name string) error {
+	err := r.post(name, "unload")
+	if repositoryErr, ok := err.(*RepositoryError); ok && repositoryErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (r *ModelRepository) post(name string, action string) error {
+	if r == nil {
+		return fmt.Errorf("no model server to %s the model %s", action, name)
+	}
+	resp, err := r.client.Post(fmt.Sprintf("%s/v2/repository/models/%s/%s", r.serverURL, url.PathEscape(name), action),
+		"application/json", bytes.NewBufferString("{}"))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRepositoryErrorSize))
+	if err != nil {
+		return err
+	}
+	// Triton and MLServer answer 200, other model servers may answer an empty body with 204
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return &RepositoryError{StatusCode: resp.StatusCode, Message: repositoryErrorMessage(body)}
+}
+
+// repositoryErrorMessage returns the error of the inference error response, {"error": "..."}, or the raw body
+func repositoryErrorMessage(body []byte) string {
+	var inferenceError struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &inferenceError); err == nil && inferenceError.Error != "" {
+		return inferenceError.Error
+	}
+	return strings.TrimSpace(string(body))
+}
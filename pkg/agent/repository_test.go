@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ModelRepository", func() {
+	var paths []string
+	var status int
+	var body string
+	var server *httptest.Server
+	var repository *ModelRepository
+	BeforeEach(func() {
+		paths = nil
+		status = http.StatusOK
+		body = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			paths = append(paths, r.URL.Path)
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+		repository = NewModelRepository(server.URL)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should load and unload the models through the repository extension", func() {
+		Expect(repository.Load("model1")).To(Succeed())
+		Expect(repository.Unload("model1")).To(Succeed())
+		Expect(paths).To(Equal([]string{"/v2/repository/models/model1/load", "/v2/repository/models/model1/unload"}))
+	})
+	It("Should accept the responses without content", func() {
+		status = http.StatusNoContent
+		Expect(repository.Load("model1")).To(Succeed())
+	})
+	It("Should report the inference error of the model server", func() {
+		status = http.StatusBadRequest
+		body = `{"error": "failed to load 'model1', failed to poll from model repository"}`
+		err := repository.Load("model1")
+		Expect(err).To(Equal(&RepositoryError{StatusCode: http.StatusBadRequest,
+			Message: "failed to load 'model1', failed to poll from model repository"}))
+		Expect(loadFailure(err)).To(Equal(
+			"failed to load the model with status 400: failed to load 'model1', failed to poll from model repository"))
+
+		status = http.StatusInternalServerError
+		body = "unsupported model format\n"
+		Expect(repository.Load("model1")).To(MatchError("status 500: unsupported model format"))
+	})
+	It("Should unload the models unknown to the model server", func() {
+		status = http.StatusNotFound
+		body = `{"error": "Model model1 not found"}`
+		Expect(repository.Unload("model1")).To(Succeed())
+		Expect(repository.Load("model1")).To(MatchError("status 404: Model model1 not found"))
+	})
+	It("Should fail without a model server", func() {
+		var repository *ModelRepository
+		Expect(repository.Load("model1")).To(MatchError("no model server to load the model model1"))
+	})
+})
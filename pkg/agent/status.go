@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+	"go.uber.org/zap"
+)
+
+// ModelStatus tracks the load states of the models of the model config, the states are polled from the repository
+// index of the model server and completed with the failures of the puller, e.g. a model which failed to download is
+// never registered by the model server. A nil ModelStatus tracks nothing.
+type ModelStatus struct {
+	serverURL string
+	client    *http.Client
+	mu        sync.Mutex
+	indexed   map[string]modelconfig.ModelState
+	pulled    map[string]modelconfig.ModelState
+	logger    *zap.SugaredLogger
+}
+
+// NewModelStatus creates the model status of the model server listening at serverURL
+func NewModelStatus(serverURL string, logger *zap.SugaredLogger) *ModelStatus {
+	return &ModelStatus{
+		serverURL: serverURL,
+		client:    &http.Client{Timeout: 5 * time.Second},
+		indexed:   make(map[string]modelconfig.ModelState),
+		pulled:    make(map[string]modelconfig.ModelState),
+		logger:    logger,
+	}
+}
+
+// Start polls the repository index of the model server until the context is done
+func (s *ModelStatus) Start(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := s.poll(); err != nil {
+			s.logger.Warnf("Failed to poll the repository index: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ModelStatus) poll() error {
+	resp, err := s.client.Post(s.serverURL+"/v2/repository/index", "application/json", bytes.NewBufferString("{}"))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var states modelconfig.ModelStates
+	if err := json.NewDecoder(resp.Body).Decode(&states); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexed = make(map[string]modelconfig.ModelState, len(states))
+	for _, state := range states {
+		s.indexed[state.Name] = state
+	}
+	return nil
+}
+
+// modelLoading records a model the puller is downloading and loading
+func (s *ModelStatus) modelLoading(name string) {
+	s.record(name, modelconfig.ModelState{Name: name, State: constants.ModelStateLoading})
+}
+
+// modelLoaded records a model the model server confirmed loaded
+func (s *ModelStatus) modelLoaded(name string) {
+	s.record(name, modelconfig.ModelState{Name: name, State: constants.ModelStateReady})
+}
+
+// modelFailed records a model which failed to download or to load with the reason of the failure
+func (s *ModelStatus) modelFailed(name string, reason string) {
+	s.record(name, modelconfig.ModelState{Name: name, State: constants.ModelStateUnavailable, Reason: reason})
+}
+
+// modelRemoved forgets a model removed from the model config
+func (s *ModelStatus) modelRemoved(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pulled, name)
+	delete(s.indexed, name)
+}
+
+func (s *ModelStatus) record(name string, state modelconfig.ModelState) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pulled[name] = state
+	// The index is stale until the next poll
+	delete(s.indexed, name)
+}
+
+// States returns the states of the models of the model config sorted by name. The state of the repository index
+// wins unless it is not ready without a reason while the puller recorded why the model failed.
+func (s *ModelStatus) States() modelconfig.ModelStates {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := modelconfig.ModelStates{}
+	for name, pulled := range s.pulled {
+		state := pulled
+		if indexed, ok := s.indexed[name]; ok {
+			state = indexed
+			if indexed.State != constants.ModelStateReady && indexed.Reason == "" && pulled.Reason != "" {
+				state.Reason = pulled.Reason
+			}
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+// NewHandler answers the requests of the model status path with the states of the models and passes the other
+// requests to next
+func (s *ModelStatus) NewHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != constants.AgentModelStatusPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.States()); err != nil {
+			s.logger.Errorf("Failed to write the model states: %v", err)
+		}
+	})
+}
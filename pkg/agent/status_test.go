@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("ModelStatus", func() {
+	var index string
+	var server *httptest.Server
+	var status *ModelStatus
+	BeforeEach(func() {
+		index = "[]"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/v2/repository/index"))
+			w.Write([]byte(index))
+		}))
+		logger, _ := zap.NewDevelopment()
+		status = NewModelStatus(server.URL, logger.Sugar())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should report the states of the repository index for the models of the model config", func() {
+		status.modelLoading("model1")
+		status.modelLoaded("model2")
+		index = `[{"name": "model1", "version": "1", "state": "READY"},
+			{"name": "model2", "state": "UNAVAILABLE", "reason": "out of memory"},
+			{"name": "model3", "state": "READY"}]`
+		Expect(status.poll()).To(Succeed())
+		Expect(status.States()).To(Equal(modelconfig.ModelStates{
+			{Name: "model1", Version: "1", State: constants.ModelStateReady},
+			{Name: "model2", State: constants.ModelStateUnavailable, Reason: "out of memory"},
+		}))
+	})
+	It("Should report the failures of the puller", func() {
+		status.modelFailed("model1", "failed to download the model: unsupported storage")
+		status.modelFailed("model2", "failed to load the model with status 400: unsupported model format")
+		Expect(status.poll()).To(Succeed())
+		Expect(status.States()).To(Equal(modelconfig.ModelStates{
+			{Name: "model1", State: constants.ModelStateUnavailable, Reason: "failed to download the model: unsupported storage"},
+			{Name: "model2", State: constants.ModelStateUnavailable, Reason: "failed to load the model with status 400: unsupported model format"},
+		}))
+		// The reason of the puller completes the index
+		index = `[{"name": "model2", "state": "UNAVAILABLE"}]`
+		Expect(status.poll()).To(Succeed())
+		Expect(status.States().Find("model2").Reason).To(Equal("failed to load the model with status 400: unsupported model format"))
+	})
+	It("Should forget the removed models", func() {
+		status.modelLoaded("model1")
+		status.modelRemoved("model1")
+		Expect(status.States()).To(BeEmpty())
+	})
+	It("Should answer the model status path", func() {
+		status.modelLoaded("model1")
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		handler := status.NewHandler(next)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, constants.AgentModelStatusPath, nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var states modelconfig.ModelStates
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &states)).To(Succeed())
+		Expect(states).To(Equal(modelconfig.ModelStates{{Name: "model1", State: constants.ModelStateReady}}))
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v2/models/model1/infer", nil))
+		Expect(recorder.Code).To(Equal(http.StatusTeapot))
+	})
+})
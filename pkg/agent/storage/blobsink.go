@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	azurecredential "github.com/kserve/kserve/pkg/credentials/azure"
+)
+
+const (
+	// AzureBlobHostSuffix is the host suffix of the Azure blob storage uris,
+	// https://<account>.blob.core.windows.net/<container>/<prefix>
+	AzureBlobHostSuffix = ".blob.core.windows.net"
+	// AzureAuthorityHostEnvKey overrides the Azure AD authority the service principal token is requested from
+	AzureAuthorityHostEnvKey = "AZURE_AUTHORITY_HOST"
+
+	defaultAzureAuthorityHost = "https://login.microsoftonline.com"
+	azureStorageScope         = "https://storage.azure.com/.default"
+	azureStorageVersion       = "2020-04-08"
+	// The token is renewed before it expires, so it does not expire while a blob is written
+	azureTokenRenewal = 5 * time.Minute
+	azureTimeout      = 60 * time.Second
+)
+
+// IsBlobSinkUri returns whether the uri is a s3://<bucket>/<prefix>, gs://<bucket>/<prefix> or
+// https://<account>.blob.core.windows.net/<container>/<prefix> location the log records can be written to
+func IsBlobSinkUri(uri string) bool {
+	if strings.HasPrefix(uri, string(S3)) || strings.HasPrefix(uri, string(GCS)) {
+		return true
+	}
+	parsed, err := url.Parse(uri)
+	return err == nil && parsed.Scheme == "https" && strings.HasSuffix(parsed.Host, AzureBlobHostSuffix)
+}
+
+// BlobSinkStore writes the batches of log records under the prefix of an S3 compatible, a GCS or an Azure blob
+// storage uri, with the credentials of the storage secrets injected into the agent
+type BlobSinkStore struct {
+	uri     string
+	payload *PayloadStore
+	azure   *azureBlobClient
+}
+
+// NewBlobSinkStore creates the store of the s3://<bucket>/<prefix>, gs://<bucket>/<prefix> or
+// https://<account>.blob.core.windows.net/<container>/<prefix> uri
+func NewBlobSinkStore(uri string) (*BlobSinkStore, error) {
+	uri = strings.TrimSuffix(uri, "/")
+	if !IsBlobSinkUri(uri) {
+		return nil, fmt.Errorf("uri %s is not supported, only %s, %s and https://<account>%s uris are supported",
+			uri, S3, GCS, AzureBlobHostSuffix)
+	}
+	store := &BlobSinkStore{uri: uri}
+	if strings.HasPrefix(uri, string(HTTPS)) {
+		client, err := newAzureBlobClient()
+		if err != nil {
+			return nil, err
+		}
+		store.azure = client
+		return store, nil
+	}
+	payload, err := NewPayloadStore(uri)
+	if err != nil {
+		return nil, err
+	}
+	store.payload = payload
+	return store, nil
+}
+
+// Write writes the data to the key, relative to the prefix of the store
+func (s *BlobSinkStore) Write(ctx context.Context, key string, data []byte, contentType string) error {
+	uri := s.uri + "/" + path.Clean(key)
+	if s.azure != nil {
+		return s.azure.put(ctx, uri, data, contentType)
+	}
+	return s.payload.Put(ctx, uri, data, contentType)
+}
+
+// azureBlobClient writes the block blobs with the bearer token of the service principal of the Azure storage secret,
+// the Azure SDK is not a dependency of the agent
+type azureBlobClient struct {
+	authorityHost string
+	tenantId      string
+	clientId      string
+	clientSecret  string
+	client        *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newAzureBlobClient() (*azureBlobClient, error) {
+	c := &azureBlobClient{
+		authorityHost: defaultAzureAuthorityHost,
+		tenantId:      os.Getenv(azurecredential.AzureTenantId),
+		clientId:      os.Getenv(azurecredential.AzureClientId),
+		clientSecret:  os.Getenv(azurecredential.AzureClientSecret),
+		client:        &http.Client{Timeout: azureTimeout},
+	}
+	if host, ok := os.LookupEnv(AzureAuthorityHostEnvKey); ok {
+		c.authorityHost = strings.TrimSuffix(host, "/")
+	}
+	if c.tenantId == "" || c.clientId == "" || c.clientSecret == "" {
+		return nil, fmt.Errorf("the %s, %s and %s of the service principal are required to write to Azure blob storage",
+			azurecredential.AzureTenantId, azurecredential.AzureClientId, azurecredential.AzureClientSecret)
+	}
+	return c, nil
+}
+
+// accessToken returns the cached token of the service principal, a new token is requested before it expires
+func (c *azureBlobClient) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Add(azureTokenRenewal).Before(c.expires) {
+		return c.token, nil
+	}
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientId},
+		"client_secret": {c.clientSecret},
+		"scope":         {azureStorageScope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/%s/oauth2/v2.0/token", c.authorityHost, c.tenantId), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while requesting the Azure access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Azure access token request returned status %d", resp.StatusCode)
+	}
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("while decoding the Azure access token: %w", err)
+	}
+	c.token = token.AccessToken
+	c.expires = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return c.token, nil
+}
+
+// put writes the data as the block blob of the uri
+func (c *azureBlobClient) put(ctx context.Context, uri string, data []byte, contentType string) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("x-ms-version", azureStorageVersion)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Azure blob %s returned status %d: %s", uri, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
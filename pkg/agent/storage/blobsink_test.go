@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/onsi/gomega"
+)
+
+func TestBlobSinkStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+
+	g.Expect(IsBlobSinkUri("s3://logs/payloads")).To(gomega.BeTrue())
+	g.Expect(IsBlobSinkUri("gs://logs/payloads")).To(gomega.BeTrue())
+	g.Expect(IsBlobSinkUri("https://account.blob.core.windows.net/logs/payloads")).To(gomega.BeTrue())
+	g.Expect(IsBlobSinkUri("http://broker-ingress.knative-eventing/default/default")).To(gomega.BeFalse())
+	_, err := NewBlobSinkStore("http://broker-ingress.knative-eventing/default/default")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	bucket := mocks.NewMockS3Bucket()
+	store := &BlobSinkStore{uri: "s3://logs/payloads", payload: &PayloadStore{S3: bucket}}
+	g.Expect(store.Write(ctx, "default/sklearn/date=2023-06-01/hour=09/1.jsonl.gz", []byte("data"),
+		"application/gzip")).To(gomega.Succeed())
+	g.Expect(bucket.Objects).To(gomega.HaveKey("payloads/default/sklearn/date=2023-06-01/hour=09/1.jsonl.gz"))
+
+	// The Azure blobs are written with the token of the service principal, requested once
+	tokens := 0
+	blobs := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			tokens++
+			g.Expect(r.URL.Path).To(gomega.Equal("/tenant/oauth2/v2.0/token"))
+			g.Expect(r.ParseForm()).To(gomega.Succeed())
+			g.Expect(r.PostForm.Get("client_secret")).To(gomega.Equal("secret"))
+			w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+		case http.MethodPut:
+			if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			blobs[r.URL.Path], _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AZURE_TENANT_ID", "tenant")
+	t.Setenv("AZURE_CLIENT_ID", "client")
+	t.Setenv("AZURE_CLIENT_SECRET", "secret")
+	t.Setenv(AzureAuthorityHostEnvKey, server.URL)
+	azure, err := newAzureBlobClient()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	store = &BlobSinkStore{uri: server.URL + "/logs/payloads", azure: azure}
+	g.Expect(store.Write(ctx, "default/sklearn/1.jsonl.gz", []byte("first"), "application/gzip")).To(gomega.Succeed())
+	g.Expect(store.Write(ctx, "default/sklearn/2.jsonl.gz", []byte("second"), "application/gzip")).To(gomega.Succeed())
+	g.Expect(tokens).To(gomega.Equal(1))
+	g.Expect(string(blobs["/logs/payloads/default/sklearn/2.jsonl.gz"])).To(gomega.Equal("second"))
+
+	t.Setenv("AZURE_CLIENT_SECRET", "")
+	_, err = NewBlobSinkStore("https://account.blob.core.windows.net/logs/payloads")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
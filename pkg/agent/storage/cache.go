@@ -0,0 +1,354 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+)
+
+const (
+	cacheBlobsDir     = "blobs/sha256"
+	cacheManifestsDir = "manifests"
+)
+
+// Cache is a content addressed cache of the downloaded models in an S3 compatible bucket, shared by the agents of
+// all the clusters configured with the same bucket. The files are stored once under the sha256 of their content and
+// a manifest per storage uri lists the files of the model, the storage uris are expected to be immutable.
+type Cache struct {
+	Client   s3iface.S3API
+	Uploader s3manageriface.UploaderAPI
+	Bucket   string
+	Prefix   string
+	// Manifests not used for MaxAge are evicted, along with the files no other manifest refers to, disabled when 0
+	MaxAge time.Duration
+}
+
+// CacheManifest lists the files of a cached model
+type CacheManifest struct {
+	StorageUri string      `json:"storageUri"`
+	Files      []CacheFile `json:"files"`
+	LastUsed   time.Time   `json:"lastUsed"`
+}
+
+// CacheFile is a file of a cached model, relative to the model dir
+type CacheFile struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// NewCache creates the cache of the s3://<bucket>/<prefix> cache uri
+func NewCache(cacheUri string, maxAge time.Duration) (*Cache, error) {
+	bucket, prefix, err := parseS3Uri(cacheUri)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newS3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{
+		Client:   client,
+		Uploader: s3manager.NewUploaderWithClient(client),
+		Bucket:   bucket,
+		Prefix:   prefix,
+		MaxAge:   maxAge,
+	}, nil
+}
+
+// parseS3Uri returns the bucket and the prefix of the s3://<bucket>/<prefix> uri
+func parseS3Uri(uri string) (string, string, error) {
+	if !strings.HasPrefix(uri, string(S3)) {
+		return "", "", fmt.Errorf("uri %s is not supported, only %s is supported", uri, S3)
+	}
+	tokens := strings.SplitN(strings.TrimPrefix(uri, string(S3)), "/", 2)
+	prefix := ""
+	if len(tokens) == 2 {
+		prefix = strings.Trim(tokens[1], "/")
+	}
+	return tokens[0], prefix, nil
+}
+
+func (c *Cache) key(elem ...string) string {
+	return path.Join(append([]string{c.Prefix}, elem...)...)
+}
+
+func (c *Cache) manifestKey(storageUri string) string {
+	return c.key(cacheManifestsDir, AsSha256(storageUri)+".json")
+}
+
+func (c *Cache) blobKey(sha string) string {
+	return c.key(cacheBlobsDir, sha)
+}
+
+// Fetch downloads the model of the storage uri from the cache and returns whether it was cached. The sha256 of each
+// downloaded file is verified, a corrupted file is removed from the cache and an error is returned.
+func (c *Cache) Fetch(modelDir string, modelName string, storageUri string) (bool, error) {
+	manifest, err := c.getManifest(storageUri)
+	if err != nil || manifest == nil {
+		return false, err
+	}
+	for _, file := range manifest.Files {
+		fileName := filepath.Join(modelDir, modelName, filepath.FromSlash(file.Path))
+		if err := c.fetchFile(file, fileName); err != nil {
+			return false, err
+		}
+	}
+	manifest.LastUsed = time.Now().UTC()
+	if err := c.putManifest(manifest); err != nil {
+		log.Error(err, "Failed to update the cache manifest", "storageUri", storageUri)
+	}
+	log.Info("Fetched model from cache", "modelName", modelName, "storageUri", storageUri, "files", len(manifest.Files))
+	return true, nil
+}
+
+func (c *Cache) fetchFile(file CacheFile, fileName string) error {
+	resp, err := c.Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(c.blobKey(file.Sha256)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get cached file %s: %v", file.Path, err)
+	}
+	defer resp.Body.Close()
+	out, err := Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, h), resp.Body); err != nil {
+		return fmt.Errorf("failed to fetch cached file %s: %v", file.Path, err)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != file.Sha256 {
+		os.Remove(fileName)
+		// Remove the corrupted file so it is uploaded again once the model is downloaded from its storage uri
+		if _, err := c.Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(c.Bucket),
+			Key:    aws.String(c.blobKey(file.Sha256)),
+		}); err != nil {
+			log.Error(err, "Failed to delete corrupted cached file", "sha256", file.Sha256)
+		}
+		return fmt.Errorf("cached file %s is corrupted, expected sha256 %s but got %s", file.Path, file.Sha256, sum)
+	}
+	return nil
+}
+
+// Store uploads the downloaded model of the storage uri to the cache, the files already cached are not uploaded again
+func (c *Cache) Store(modelDir string, modelName string, storageUri string) error {
+	root := filepath.Join(modelDir, modelName)
+	manifest := &CacheManifest{StorageUri: storageUri, LastUsed: time.Now().UTC()}
+	err := filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, fileName)
+		if err != nil {
+			return err
+		}
+		sha, err := fileSha256(fileName)
+		if err != nil {
+			return err
+		}
+		if err := c.storeFile(sha, fileName); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, CacheFile{Path: filepath.ToSlash(rel), Sha256: sha, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store model %s in cache: %v", modelName, err)
+	}
+	if err := c.putManifest(manifest); err != nil {
+		return err
+	}
+	log.Info("Stored model in cache", "modelName", modelName, "storageUri", storageUri, "files", len(manifest.Files))
+	return nil
+}
+
+func (c *Cache) storeFile(sha string, fileName string) error {
+	_, err := c.Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(c.blobKey(sha)),
+	})
+	if err == nil {
+		return nil
+	} else if !isNotFound(err) {
+		return err
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = c.Uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(c.blobKey(sha)),
+		Body:   file,
+	})
+	return err
+}
+
+// Evict removes the manifests not used for MaxAge and the files no remaining manifest refers to. Files younger than
+// MaxAge are kept, as they may belong to a model being stored by another agent.
+func (c *Cache) Evict() error {
+	if c.MaxAge <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-c.MaxAge)
+	referenced := map[string]bool{}
+	var listErr error
+	err := c.Client.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(c.Bucket),
+		Prefix: aws.String(c.key(cacheManifestsDir) + "/"),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, object := range page.Contents {
+			manifest, err := c.readManifest(*object.Key)
+			if err != nil {
+				listErr = err
+				return false
+			}
+			if manifest.LastUsed.Before(cutoff) {
+				log.Info("Evicting model from cache", "storageUri", manifest.StorageUri)
+				if err := c.deleteObject(*object.Key); err != nil {
+					listErr = err
+					return false
+				}
+				continue
+			}
+			for _, file := range manifest.Files {
+				referenced[file.Sha256] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	} else if listErr != nil {
+		return listErr
+	}
+	err = c.Client.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(c.Bucket),
+		Prefix: aws.String(c.key(cacheBlobsDir) + "/"),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, object := range page.Contents {
+			if referenced[path.Base(*object.Key)] || object.LastModified == nil || object.LastModified.After(cutoff) {
+				continue
+			}
+			if err := c.deleteObject(*object.Key); err != nil {
+				listErr = err
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return listErr
+}
+
+func (c *Cache) getManifest(storageUri string) (*CacheManifest, error) {
+	manifest, err := c.readManifest(c.manifestKey(storageUri))
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return manifest, err
+}
+
+func (c *Cache) readManifest(key string) (*CacheManifest, error) {
+	resp, err := c.Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &CacheManifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("malformed cache manifest %s: %v", key, err)
+	}
+	return manifest, nil
+}
+
+func (c *Cache) putManifest(manifest *CacheManifest) error {
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	_, err = c.Client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(c.Bucket),
+		Key:         aws.String(c.manifestKey(manifest.StorageUri)),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
+
+func (c *Cache) deleteObject(key string) error {
+	_, err := c.Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func fileSha256(fileName string) (string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isNotFound(err error) bool {
+	if aerr, ok := err.(awserr.RequestFailure); ok {
+		return aerr.StatusCode() == 404
+	}
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound"
+	}
+	return false
+}
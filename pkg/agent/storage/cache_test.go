@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/onsi/gomega"
+)
+
+func newTestCache(bucket *mocks.MockS3Bucket, maxAge time.Duration) *Cache {
+	return &Cache{Client: bucket, Uploader: bucket, Bucket: "model-cache", Prefix: "kserve", MaxAge: maxAge}
+}
+
+func writeModel(g *gomega.WithT, modelDir string, files map[string]string) {
+	for name, content := range files {
+		f, err := Create(filepath.Join(modelDir, name))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = f.WriteString(content)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		f.Close()
+	}
+}
+
+func TestCacheStoreAndFetch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := ioutil.TempDir("", "test-cache-")
+	defer os.RemoveAll(tmpDir)
+
+	bucket := mocks.NewMockS3Bucket()
+	cache := newTestCache(bucket, 0)
+	cached, err := cache.Fetch(tmpDir, "model1", "s3://models/model1")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cached).To(gomega.BeFalse())
+
+	writeModel(g, filepath.Join(tmpDir, "model1"), map[string]string{
+		"model.pt":        "weights",
+		"config/cfg.json": "{}",
+	})
+	g.Expect(cache.Store(tmpDir, "model1", "s3://models/model1")).To(gomega.Succeed())
+	// The same content is stored once
+	writeModel(g, filepath.Join(tmpDir, "model2"), map[string]string{"model.pt": "weights"})
+	g.Expect(cache.Store(tmpDir, "model2", "s3://models/model2")).To(gomega.Succeed())
+	g.Expect(bucket.Objects).To(gomega.HaveLen(4))
+
+	fetchDir := filepath.Join(tmpDir, "fetched")
+	cached, err = cache.Fetch(fetchDir, "model1", "s3://models/model1")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cached).To(gomega.BeTrue())
+	content, _ := ioutil.ReadFile(filepath.Join(fetchDir, "model1", "config", "cfg.json"))
+	g.Expect(string(content)).To(gomega.Equal("{}"))
+	content, _ = ioutil.ReadFile(filepath.Join(fetchDir, "model1", "model.pt"))
+	g.Expect(string(content)).To(gomega.Equal("weights"))
+}
+
+func TestCacheFetchCorrupted(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := ioutil.TempDir("", "test-cache-")
+	defer os.RemoveAll(tmpDir)
+
+	bucket := mocks.NewMockS3Bucket()
+	cache := newTestCache(bucket, 0)
+	writeModel(g, filepath.Join(tmpDir, "model1"), map[string]string{"model.pt": "weights"})
+	g.Expect(cache.Store(tmpDir, "model1", "s3://models/model1")).To(gomega.Succeed())
+	blobKey := cache.blobKey(AsSha256("weights"))
+	bucket.Objects[blobKey] = []byte("tampered")
+
+	fetchDir := filepath.Join(tmpDir, "fetched")
+	cached, err := cache.Fetch(fetchDir, "model1", "s3://models/model1")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("corrupted")))
+	g.Expect(cached).To(gomega.BeFalse())
+	g.Expect(filepath.Join(fetchDir, "model1", "model.pt")).NotTo(gomega.BeAnExistingFile())
+	// The corrupted file is uploaded again when the model is stored
+	g.Expect(bucket.Objects).NotTo(gomega.HaveKey(blobKey))
+	g.Expect(cache.Store(tmpDir, "model1", "s3://models/model1")).To(gomega.Succeed())
+	g.Expect(string(bucket.Objects[blobKey])).To(gomega.Equal("weights"))
+}
+
+func TestCacheEvict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := ioutil.TempDir("", "test-cache-")
+	defer os.RemoveAll(tmpDir)
+
+	bucket := mocks.NewMockS3Bucket()
+	cache := newTestCache(bucket, time.Hour)
+	writeModel(g, filepath.Join(tmpDir, "old"), map[string]string{"model.pt": "old", "shared.txt": "shared"})
+	writeModel(g, filepath.Join(tmpDir, "new"), map[string]string{"model.pt": "new", "shared.txt": "shared"})
+	g.Expect(cache.Store(tmpDir, "old", "s3://models/old")).To(gomega.Succeed())
+	g.Expect(cache.Store(tmpDir, "new", "s3://models/new")).To(gomega.Succeed())
+
+	// The old model was last used two hours ago
+	oldManifest, err := cache.getManifest("s3://models/old")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	oldManifest.LastUsed = time.Now().Add(-2 * time.Hour)
+	g.Expect(cache.putManifest(oldManifest)).To(gomega.Succeed())
+	for key := range bucket.LastModified {
+		bucket.LastModified[key] = time.Now().Add(-2 * time.Hour)
+	}
+
+	g.Expect(cache.Evict()).To(gomega.Succeed())
+	cached, err := cache.Fetch(tmpDir, "evicted", "s3://models/old")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cached).To(gomega.BeFalse())
+	g.Expect(bucket.Objects).NotTo(gomega.HaveKey(cache.blobKey(AsSha256("old"))))
+	g.Expect(bucket.Objects).To(gomega.HaveKey(cache.blobKey(AsSha256("shared"))))
+	cached, err = cache.Fetch(tmpDir, "kept", "s3://models/new")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cached).To(gomega.BeTrue())
+}
+
+func TestNewCache(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cache, err := NewCache("s3://model-cache/kserve/", time.Hour)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(cache.Bucket).To(gomega.Equal("model-cache"))
+	g.Expect(cache.Prefix).To(gomega.Equal("kserve"))
+	g.Expect(strings.HasPrefix(cache.manifestKey("s3://models/model1"), "kserve/manifests/")).To(gomega.BeTrue())
+
+	_, err = NewCache("gs://model-cache", time.Hour)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
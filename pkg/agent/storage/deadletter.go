@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// DeadLetterContentType is the content type of the exported events, they are stored in the structured CloudEvents
+// format so they can be replayed to the logger sink
+const DeadLetterContentType = "application/cloudevents+json"
+
+// DeadLetterStore exports the log events the logger sink did not accept to an S3 compatible bucket
+type DeadLetterStore struct {
+	Client s3iface.S3API
+	Bucket string
+	Prefix string
+}
+
+// NewDeadLetterStore creates the dead letter store of the s3://<bucket>/<prefix> uri
+func NewDeadLetterStore(deadLetterUri string) (*DeadLetterStore, error) {
+	bucket, prefix, err := parseS3Uri(deadLetterUri)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newS3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterStore{
+		Client: client,
+		Bucket: bucket,
+		Prefix: prefix,
+	}, nil
+}
+
+// Export stores the event under the key, relative to the prefix of the store
+func (s *DeadLetterStore) Export(key string, event []byte) error {
+	_, err := s.Client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(s.Bucket),
+		Key:         aws.String(path.Join(s.Prefix, key)),
+		Body:        bytes.NewReader(event),
+		ContentType: aws.String(DeadLetterContentType),
+	})
+	return err
+}
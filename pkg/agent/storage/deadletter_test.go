@@ -0,0 +1,41 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/onsi/gomega"
+)
+
+func TestDeadLetterStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	store, err := NewDeadLetterStore("s3://logger/dead-letter/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(store.Bucket).To(gomega.Equal("logger"))
+	g.Expect(store.Prefix).To(gomega.Equal("dead-letter"))
+
+	bucket := mocks.NewMockS3Bucket()
+	store.Client = bucket
+	g.Expect(store.Export("default/sklearn/2022/10/02/1-request.json", []byte(`{"id":"1"}`))).To(gomega.Succeed())
+	g.Expect(bucket.Objects).To(gomega.HaveKeyWithValue("dead-letter/default/sklearn/2022/10/02/1-request.json",
+		[]byte(`{"id":"1"}`)))
+
+	_, err = NewDeadLetterStore("gs://logger")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -19,6 +19,7 @@ package storage
 import (
 	gstorage "cloud.google.com/go/storage"
 	"context"
+	"encoding/hex"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
@@ -33,31 +34,96 @@ type GCSProvider struct {
 	Client stiface.Client
 }
 
+var _ SyncProvider = (*GCSProvider)(nil)
+
 func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
 	log.Info("Downloading model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	gcsObjectDownloader := p.objectDownloader(modelDir, modelName, storageUri)
+	it, err := gcsObjectDownloader.GetObjectIterator(p.Client)
+	if err != nil {
+		return fmt.Errorf("unable to get object iterator because: %v", err)
+	}
+	if err := gcsObjectDownloader.Download(p.Client, it); err != nil {
+		return fmt.Errorf("unable to download object/s because: %v", err)
+	}
+	return nil
+}
+
+// SyncModel downloads the objects whose ETag or size changed since the previous sync of the model dir and removes the
+// files of the objects which no longer exist, the MD5 of the objects is compared when they have no ETag
+func (p *GCSProvider) SyncModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Sync model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	gcsObjectDownloader := p.objectDownloader(modelDir, modelName, storageUri)
+	it, err := gcsObjectDownloader.GetObjectIterator(p.Client)
+	if err != nil {
+		return fmt.Errorf("unable to get object iterator because: %v", err)
+	}
+	modelPath := filepath.Join(modelDir, modelName)
+	remote := SyncManifest{}
+	objects := map[string]*gstorage.ObjectAttrs{}
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("an error occurred while iterating: %v", err)
+		}
+		if strings.HasSuffix(attrs.Name, "/") {
+			continue
+		}
+		fileName, err := syncFileName(modelPath, strings.TrimPrefix(attrs.Name, gcsObjectDownloader.Item))
+		if err != nil {
+			return err
+		}
+		etag := attrs.Etag
+		if etag == "" && len(attrs.MD5) > 0 {
+			etag = hex.EncodeToString(attrs.MD5)
+		}
+		remote[fileName] = SyncObject{ETag: etag, Size: attrs.Size}
+		objects[fileName] = attrs
+	}
+	if len(remote) == 0 {
+		return gstorage.ErrObjectNotExist
+	}
+	changed, err := prepareSync(modelPath, remote)
+	if err != nil {
+		return fmt.Errorf("unable to compare the model dir with the objects because: %v", err)
+	}
+	log.Info("Syncing changed objects", "modelName", modelName, "changed", len(changed), "objects", len(remote))
+	var errs []error
+	for _, fileName := range changed {
+		file, err := Create(filepath.Join(modelPath, fileName))
+		if err != nil {
+			return fmt.Errorf("file is already created: %v", err)
+		}
+		if err := gcsObjectDownloader.DownloadFile(p.Client, objects[fileName], file); err != nil {
+			file.Close()
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to download object/s because: %v",
+			awserr.NewBatchError("GCSDownloadIncomplete", "some objects failed to download.", errs))
+	}
+	return writeSyncManifest(modelPath, remote)
+}
+
+func (p *GCSProvider) objectDownloader(modelDir string, modelName string, storageUri string) *GCSObjectDownloader {
 	gcsUri := strings.TrimPrefix(storageUri, string(GCS))
 	tokens := strings.SplitN(gcsUri, "/", 2)
 	prefix := ""
 	if len(tokens) == 2 {
 		prefix = tokens[1]
 	}
-	ctx := context.Background()
-	gcsObjectDownloader := &GCSObjectDownloader{
-		Context:    ctx,
+	return &GCSObjectDownloader{
+		Context:    context.Background(),
 		StorageUri: storageUri,
 		ModelDir:   modelDir,
 		ModelName:  modelName,
 		Bucket:     tokens[0],
 		Item:       prefix,
 	}
-	it, err := gcsObjectDownloader.GetObjectIterator(p.Client)
-	if err != nil {
-		return fmt.Errorf("unable to get object iterator because: %v", err)
-	}
-	if err := gcsObjectDownloader.Download(p.Client, it); err != nil {
-		return fmt.Errorf("unable to download object/s because: %v", err)
-	}
-	return nil
 }
 
 type GCSObjectDownloader struct {
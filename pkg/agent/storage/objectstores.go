@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	coscredential "github.com/kserve/kserve/pkg/credentials/cos"
+	osscredential "github.com/kserve/kserve/pkg/credentials/oss"
+	r2credential "github.com/kserve/kserve/pkg/credentials/r2"
+)
+
+const (
+	// COSIAMEndpointEnvKey overrides the IBM Cloud IAM endpoint the bearer token of the COS API key is requested from
+	COSIAMEndpointEnvKey = "COS_IAM_ENDPOINT"
+
+	defaultCOSIAMEndpoint = "https://iam.cloud.ibm.com/identity/token"
+	// The token is renewed before it expires, so it does not expire while an object is downloaded
+	cosTokenRenewal = 5 * time.Minute
+	cosTimeout      = 60 * time.Second
+	// R2 signs the requests of every bucket with the auto region
+	r2Region = "auto"
+)
+
+// newObjectStoreClient creates the client of the S3 compatible API of the Alibaba Cloud OSS, IBM Cloud Object Storage
+// or Cloudflare R2 buckets, configured with the endpoint, addressing style and signing of the provider
+func newObjectStoreClient(protocol Protocol) (*s3.S3, error) {
+	switch protocol {
+	case OSS:
+		return newOSSClient()
+	case COS:
+		return newCOSClient()
+	case R2:
+		return newR2Client()
+	}
+	return nil, fmt.Errorf("protocol %s is not an S3 compatible object store", protocol)
+}
+
+// newOSSClient creates the client of the OSS buckets, OSS only accepts the virtual hosted style requests on the
+// endpoint of the region of the bucket
+func newOSSClient() (*s3.S3, error) {
+	region := os.Getenv(osscredential.OSSRegion)
+	if region == "" {
+		return nil, fmt.Errorf("the %s of the buckets is required to read from Alibaba Cloud OSS",
+			osscredential.OSSRegion)
+	}
+	endpoint := os.Getenv(osscredential.OSSEndpoint)
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://oss-%s.aliyuncs.com", region)
+	}
+	return newS3CompatibleClient(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(false),
+		Credentials: staticOrAnonymousCredentials(os.Getenv(osscredential.OSSAccessKeyId),
+			os.Getenv(osscredential.OSSAccessKeySecret), os.Getenv(osscredential.OSSSessionToken)),
+	})
+}
+
+// newCOSClient creates the client of the IBM COS buckets. The requests are signed with the HMAC credentials, or carry
+// the IAM bearer token of the API key instead of a signature.
+func newCOSClient() (*s3.S3, error) {
+	region := os.Getenv(coscredential.COSRegion)
+	if region == "" {
+		return nil, fmt.Errorf("the %s of the buckets is required to read from IBM Cloud Object Storage",
+			coscredential.COSRegion)
+	}
+	endpoint := os.Getenv(coscredential.COSEndpoint)
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.cloud-object-storage.appdomain.cloud", region)
+	}
+	config := &aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials: staticOrAnonymousCredentials(os.Getenv(coscredential.COSHMACAccessKeyId),
+			os.Getenv(coscredential.COSHMACSecretAccessKey), ""),
+	}
+	apiKey := os.Getenv(coscredential.COSAPIKey)
+	if apiKey != "" {
+		// The requests are not signed, the anonymous credentials skip the signature V4 signer
+		config.Credentials = credentials.AnonymousCredentials
+	}
+	client, err := newS3CompatibleClient(config)
+	if err != nil || apiKey == "" {
+		return client, err
+	}
+	tokens := &cosIAMTokens{
+		endpoint: defaultCOSIAMEndpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: cosTimeout},
+	}
+	if endpoint, ok := os.LookupEnv(COSIAMEndpointEnvKey); ok {
+		tokens.endpoint = endpoint
+	}
+	client.Handlers.Sign.PushBack(tokens.authorize)
+	return client, nil
+}
+
+// newR2Client creates the client of the R2 buckets of the account, in its jurisdiction when the buckets were created
+// with one
+func newR2Client() (*s3.S3, error) {
+	accountId := os.Getenv(r2credential.R2AccountId)
+	if accountId == "" {
+		return nil, fmt.Errorf("the %s of the buckets is required to read from Cloudflare R2", r2credential.R2AccountId)
+	}
+	host := accountId
+	if jurisdiction := os.Getenv(r2credential.R2Jurisdiction); jurisdiction != "" {
+		host += "." + jurisdiction
+	}
+	return newS3CompatibleClient(&aws.Config{
+		Endpoint:         aws.String(fmt.Sprintf("https://%s.r2.cloudflarestorage.com", host)),
+		Region:           aws.String(r2Region),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials: staticOrAnonymousCredentials(os.Getenv(r2credential.R2AccessKeyId),
+			os.Getenv(r2credential.R2SecretAccessKey), ""),
+	})
+}
+
+func newS3CompatibleClient(config *aws.Config) (*s3.S3, error) {
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(sess), nil
+}
+
+// staticOrAnonymousCredentials returns the credentials of the access key, the public buckets are read without one
+func staticOrAnonymousCredentials(accessKeyId string, secretAccessKey string, sessionToken string) *credentials.Credentials {
+	if accessKeyId == "" {
+		return credentials.AnonymousCredentials
+	}
+	return credentials.NewStaticCredentials(accessKeyId, secretAccessKey, sessionToken)
+}
+
+// cosIAMTokens requests the IAM bearer tokens of the COS API key, the token is cached until it is about to expire
+type cosIAMTokens struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// authorize sets the bearer token on the request, it runs after the signers so it is set again on the retries
+func (t *cosIAMTokens) authorize(r *request.Request) {
+	token, err := t.accessToken(r.Context())
+	if err != nil {
+		r.Error = err
+		return
+	}
+	r.HTTPRequest.Header.Set("Authorization", "Bearer "+token)
+}
+
+func (t *cosIAMTokens) accessToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && time.Now().Add(cosTokenRenewal).Before(t.expires) {
+		return t.token, nil
+	}
+	form := url.Values{
+		"grant_type": {"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     {t.apiKey},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("while requesting the IBM Cloud IAM token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("IBM Cloud IAM token request returned status %d", resp.StatusCode)
+	}
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("while decoding the IBM Cloud IAM token: %w", err)
+	}
+	t.token = token.AccessToken
+	t.expires = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return t.token, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/onsi/gomega"
+)
+
+func TestObjectStoreClients(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The region is required to find the endpoint of the buckets
+	_, err := newObjectStoreClient(OSS)
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = newObjectStoreClient(COS)
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = newObjectStoreClient(R2)
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = newObjectStoreClient(S3)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	t.Setenv("OSS_REGION", "cn-hangzhou")
+	t.Setenv("OSS_ACCESS_KEY_ID", "key")
+	t.Setenv("OSS_ACCESS_KEY_SECRET", "secret")
+	t.Setenv("OSS_SESSION_TOKEN", "token")
+	client, err := newObjectStoreClient(OSS)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.StringValue(client.Config.Endpoint)).To(gomega.Equal("https://oss-cn-hangzhou.aliyuncs.com"))
+	g.Expect(aws.BoolValue(client.Config.S3ForcePathStyle)).To(gomega.BeFalse())
+	value, err := client.Config.Credentials.Get()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(value.SessionToken).To(gomega.Equal("token"))
+	t.Setenv("OSS_ENDPOINT", "https://oss-cn-hangzhou-internal.aliyuncs.com")
+	client, err = newObjectStoreClient(OSS)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.StringValue(client.Config.Endpoint)).To(gomega.Equal("https://oss-cn-hangzhou-internal.aliyuncs.com"))
+
+	// The public buckets are read without credentials
+	t.Setenv("COS_REGION", "us-south")
+	client, err = newObjectStoreClient(COS)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.StringValue(client.Config.Endpoint)).To(
+		gomega.Equal("https://s3.us-south.cloud-object-storage.appdomain.cloud"))
+	g.Expect(client.Config.Credentials).To(gomega.Equal(credentials.AnonymousCredentials))
+
+	t.Setenv("R2_ACCOUNT_ID", "account")
+	t.Setenv("R2_JURISDICTION", "eu")
+	t.Setenv("R2_ACCESS_KEY_ID", "key")
+	t.Setenv("R2_SECRET_ACCESS_KEY", "secret")
+	client, err = newObjectStoreClient(R2)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.StringValue(client.Config.Endpoint)).To(gomega.Equal("https://account.eu.r2.cloudflarestorage.com"))
+	g.Expect(aws.StringValue(client.Config.Region)).To(gomega.Equal("auto"))
+	g.Expect(aws.BoolValue(client.Config.S3ForcePathStyle)).To(gomega.BeTrue())
+
+	// The objects of the uris of every provider are listed in their bucket
+	downloader := (&S3Provider{}).objectDownloader("/mnt/models", "model", "r2://models/sklearn/v1")
+	g.Expect(downloader.Bucket).To(gomega.Equal("models"))
+	g.Expect(downloader.Prefix).To(gomega.Equal("sklearn/v1"))
+}
+
+func TestCOSIAMToken(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The objects are read with the bearer token of the API key, requested once
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/identity/token":
+			tokens++
+			g.Expect(r.ParseForm()).To(gomega.Succeed())
+			g.Expect(r.PostForm.Get("apikey")).To(gomega.Equal("apikey"))
+			w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+		case "/models/sklearn/model.joblib":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte("model"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("COS_REGION", "us-south")
+	t.Setenv("COS_ENDPOINT", server.URL)
+	t.Setenv("COS_API_KEY", "apikey")
+	t.Setenv(COSIAMEndpointEnvKey, server.URL+"/identity/token")
+	client, err := newObjectStoreClient(COS)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	for i := 0; i < 2; i++ {
+		output, err := client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String("models"),
+			Key:    aws.String("sklearn/model.joblib"),
+		})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		data, _ := ioutil.ReadAll(output.Body)
+		g.Expect(string(data)).To(gomega.Equal("model"))
+	}
+	g.Expect(tokens).To(gomega.Equal(1))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	gstorage "cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
+)
+
+// ErrPayloadNotFound is returned when the object of a payload uri does not exist
+var ErrPayloadNotFound = errors.New("payload not found")
+
+// PayloadObject is a payload passed by reference, read from its bucket
+type PayloadObject struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// PayloadStore reads the request payloads passed by reference to the agent and writes the responses back, from and
+// to S3 compatible and GCS buckets with the credentials of the agent
+type PayloadStore struct {
+	S3  s3iface.S3API
+	GCS stiface.Client
+}
+
+// NewPayloadStore creates the clients of the protocols of the s3://<bucket>/<prefix> and gs://<bucket>/<prefix> uris
+func NewPayloadStore(uris ...string) (*PayloadStore, error) {
+	store := &PayloadStore{}
+	for _, uri := range uris {
+		protocol, _, _, err := parsePayloadUri(uri)
+		if err != nil {
+			return nil, err
+		}
+		if protocol == S3 && store.S3 == nil {
+			if store.S3, err = newS3Client(); err != nil {
+				return nil, err
+			}
+		}
+		if protocol == GCS && store.GCS == nil {
+			if store.GCS, err = newGCSClient(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return store, nil
+}
+
+// parsePayloadUri returns the protocol, the bucket and the key of the s3:// or gs:// uri
+func parsePayloadUri(uri string) (Protocol, string, string, error) {
+	for _, protocol := range []Protocol{S3, GCS} {
+		if !strings.HasPrefix(uri, string(protocol)) {
+			continue
+		}
+		tokens := strings.SplitN(strings.TrimPrefix(uri, string(protocol)), "/", 2)
+		if tokens[0] == "" {
+			return "", "", "", fmt.Errorf("uri %s has no bucket", uri)
+		}
+		key := ""
+		if len(tokens) == 2 {
+			key = tokens[1]
+		}
+		return protocol, tokens[0], key, nil
+	}
+	return "", "", "", fmt.Errorf("uri %s is not supported, only %s and %s are supported", uri, S3, GCS)
+}
+
+// Open opens the object of the uri, the caller closes its body
+func (s *PayloadStore) Open(ctx context.Context, uri string) (*PayloadObject, error) {
+	protocol, bucket, key, err := parsePayloadUri(uri)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case protocol == S3 && s.S3 != nil:
+		output, err := s.S3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if isNotFound(err) {
+			return nil, ErrPayloadNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &PayloadObject{
+			Body:        output.Body,
+			Size:        aws.Int64Value(output.ContentLength),
+			ContentType: aws.StringValue(output.ContentType),
+		}, nil
+	case protocol == GCS && s.GCS != nil:
+		object := s.GCS.Bucket(bucket).Object(key)
+		attrs, err := object.Attrs(ctx)
+		if errors.Is(err, gstorage.ErrObjectNotExist) {
+			return nil, ErrPayloadNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		reader, err := object.NewReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &PayloadObject{
+			Body:        reader,
+			Size:        attrs.Size,
+			ContentType: attrs.ContentType,
+		}, nil
+	}
+	return nil, fmt.Errorf("uri %s is not allowed", uri)
+}
+
+// Put writes the data to the object of the uri
+func (s *PayloadStore) Put(ctx context.Context, uri string, data []byte, contentType string) error {
+	protocol, bucket, key, err := parsePayloadUri(uri)
+	if err != nil {
+		return err
+	}
+	switch {
+	case protocol == S3 && s.S3 != nil:
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(data),
+		}
+		if contentType != "" {
+			input.ContentType = aws.String(contentType)
+		}
+		_, err := s.S3.PutObjectWithContext(ctx, input)
+		return err
+	case protocol == GCS && s.GCS != nil:
+		writer := s.GCS.Bucket(bucket).Object(key).NewWriter(ctx)
+		writer.ObjectAttrs().ContentType = contentType
+		if _, err := writer.Write(data); err != nil {
+			writer.Close()
+			return err
+		}
+		return writer.Close()
+	}
+	return fmt.Errorf("uri %s is not allowed", uri)
+}
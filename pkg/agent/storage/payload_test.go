@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/onsi/gomega"
+)
+
+func TestPayloadStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	bucket := mocks.NewMockS3Bucket()
+	gcs := mocks.NewMockClient()
+	g.Expect(gcs.Bucket("inputs").Create(ctx, "", nil)).To(gomega.Succeed())
+	store := &PayloadStore{S3: bucket, GCS: gcs}
+
+	for _, uri := range []string{"s3://inputs/images/1.json", "gs://inputs/images/1.json"} {
+		g.Expect(store.Put(ctx, uri, []byte(`{"instances": [[1]]}`), "application/json")).To(gomega.Succeed())
+		object, err := store.Open(ctx, uri)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		data, err := ioutil.ReadAll(object.Body)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(string(data)).To(gomega.Equal(`{"instances": [[1]]}`))
+		g.Expect(object.Size).To(gomega.Equal(int64(len(data))))
+	}
+	g.Expect(bucket.Objects).To(gomega.HaveKey("images/1.json"))
+
+	_, err := store.Open(ctx, "s3://inputs/images/2.json")
+	g.Expect(err).To(gomega.Equal(ErrPayloadNotFound))
+	_, err = store.Open(ctx, "https://example.com/images/1.json")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// The clients are only created for the protocols of the configured uris
+	store, err = NewPayloadStore("s3://inputs/images")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(store.GCS).To(gomega.BeNil())
+	_, err = store.Open(ctx, "gs://inputs/images/1.json")
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = NewPayloadStore("s3:///images")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -29,9 +29,13 @@ const (
 	//File  Protocol = "file://"
 	HTTPS Protocol = "https://"
 	HTTP  Protocol = "http://"
+	// The Alibaba Cloud OSS, IBM Cloud Object Storage and Cloudflare R2 buckets are read with their S3 compatible APIs
+	OSS Protocol = "oss://"
+	COS Protocol = "cos://"
+	R2  Protocol = "r2://"
 )
 
-var SupportedProtocols = []Protocol{S3, GCS, HTTPS, HTTP}
+var SupportedProtocols = []Protocol{S3, GCS, HTTPS, HTTP, OSS, COS, R2}
 
 func GetAllProtocol() (protocols []string) {
 	for _, protocol := range SupportedProtocols {
@@ -37,6 +37,7 @@ type S3Provider struct {
 var log = logf.Log.WithName("modelAgent")
 
 var _ Provider = (*S3Provider)(nil)
+var _ SyncProvider = (*S3Provider)(nil)
 
 type S3ObjectDownloader struct {
 	StorageUri string
@@ -49,13 +50,70 @@ type S3ObjectDownloader struct {
 
 func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri string) error {
 	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
-	s3Uri := strings.TrimPrefix(storageUri, string(S3))
+	s3ObjectDownloader := m.objectDownloader(modelDir, modelName, storageUri)
+	objects, err := s3ObjectDownloader.GetAllObjects(m.Client)
+	if err != nil {
+		return fmt.Errorf("unable to get batch objects %v", err)
+	}
+	if err := s3ObjectDownloader.Download(objects); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SyncModel downloads the objects whose ETag or size changed since the previous sync of the model dir and removes the
+// files of the objects which no longer exist
+func (m *S3Provider) SyncModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Sync model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	s3ObjectDownloader := m.objectDownloader(modelDir, modelName, storageUri)
+	listed, err := s3ObjectDownloader.ListObjects(m.Client)
+	if err != nil {
+		return fmt.Errorf("unable to list objects %v", err)
+	}
+	modelPath := filepath.Join(modelDir, modelName)
+	remote := SyncManifest{}
+	keys := map[string]string{}
+	for _, object := range listed {
+		fileName, err := syncFileName(modelPath, strings.TrimPrefix(*object.Key, s3ObjectDownloader.Prefix))
+		if err != nil {
+			return err
+		}
+		remote[fileName] = SyncObject{ETag: aws.StringValue(object.ETag), Size: aws.Int64Value(object.Size)}
+		keys[fileName] = *object.Key
+	}
+	changed, err := prepareSync(modelPath, remote)
+	if err != nil {
+		return fmt.Errorf("unable to compare the model dir with the objects %v", err)
+	}
+	log.Info("Syncing changed objects", "modelName", modelName, "changed", len(changed), "objects", len(remote))
+	objects := make([]s3manager.BatchDownloadObject, 0, len(changed))
+	for _, fileName := range changed {
+		object, err := s3ObjectDownloader.batchObject(keys[fileName])
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+	if len(objects) > 0 {
+		if err := s3ObjectDownloader.Download(objects); err != nil {
+			return err
+		}
+	}
+	return writeSyncManifest(modelPath, remote)
+}
+
+func (m *S3Provider) objectDownloader(modelDir string, modelName string, storageUri string) *S3ObjectDownloader {
+	// The oss://, cos:// and r2:// uris are read with the S3 provider as well
+	s3Uri := storageUri
+	if _, bucketPath, ok := strings.Cut(storageUri, "://"); ok {
+		s3Uri = bucketPath
+	}
 	tokens := strings.SplitN(s3Uri, "/", 2)
 	prefix := ""
 	if len(tokens) == 2 {
 		prefix = tokens[1]
 	}
-	s3ObjectDownloader := &S3ObjectDownloader{
+	return &S3ObjectDownloader{
 		StorageUri: storageUri,
 		ModelDir:   modelDir,
 		ModelName:  modelName,
@@ -63,17 +121,10 @@ func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri
 		Prefix:     prefix,
 		downloader: m.Downloader,
 	}
-	objects, err := s3ObjectDownloader.GetAllObjects(m.Client)
-	if err != nil {
-		return fmt.Errorf("unable to get batch objects %v", err)
-	}
-	if err := s3ObjectDownloader.Download(objects); err != nil {
-		return err
-	}
-	return nil
 }
 
-func (s *S3ObjectDownloader) GetAllObjects(s3Svc s3iface.S3API) ([]s3manager.BatchDownloadObject, error) {
+// ListObjects lists the objects of the storage uri, the directory markers are skipped
+func (s *S3ObjectDownloader) ListObjects(s3Svc s3iface.S3API) ([]*s3.Object, error) {
 	resp, err := s3Svc.ListObjects(&s3.ListObjectsInput{
 		Bucket: aws.String(s.Bucket),
 		Prefix: aws.String(s.Prefix),
@@ -81,54 +132,64 @@ func (s *S3ObjectDownloader) GetAllObjects(s3Svc s3iface.S3API) ([]s3manager.Bat
 	if err != nil {
 		return nil, err
 	}
-	results := make([]s3manager.BatchDownloadObject, 0)
-
-	if len(resp.Contents) == 0 {
-		return nil, fmt.Errorf("%s has no objects or does not exist", s.StorageUri)
-	}
-
-	var foundObject = false
-
+	objects := make([]*s3.Object, 0, len(resp.Contents))
 	for _, object := range resp.Contents {
 		if strings.HasSuffix(*object.Key, "/") {
 			continue
 		}
-		subObjectKey := strings.TrimPrefix(*object.Key, s.Prefix)
-		fileName := filepath.Join(s.ModelDir, s.ModelName, subObjectKey)
-
-		if FileExists(fileName) {
-			// File got corrupted or is mid-download :(
-			// TODO: Figure out if we can maybe continue?
-			if err := os.Remove(fileName); err != nil {
-				return nil, fmt.Errorf("file is unable to be deleted: %v", err)
-			}
-		}
-		file, err := Create(fileName)
-		if err != nil {
-			return nil, fmt.Errorf("file is already created: %v", err)
-		}
-		object := s3manager.BatchDownloadObject{
-			Object: &s3.GetObjectInput{
-				Key:    aws.String(*object.Key),
-				Bucket: aws.String(s.Bucket),
-			},
-			Writer: file,
-			After: func() error {
-				defer file.Close()
-				return nil
-			},
-		}
-		foundObject = true
-		results = append(results, object)
+		objects = append(objects, object)
 	}
-
-	if !foundObject {
+	if len(objects) == 0 {
 		return nil, fmt.Errorf("%s has no objects or does not exist", s.StorageUri)
 	}
+	return objects, nil
+}
 
+func (s *S3ObjectDownloader) GetAllObjects(s3Svc s3iface.S3API) ([]s3manager.BatchDownloadObject, error) {
+	objects, err := s.ListObjects(s3Svc)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]s3manager.BatchDownloadObject, 0, len(objects))
+	for _, object := range objects {
+		result, err := s.batchObject(*object.Key)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
 	return results, nil
 }
 
+// batchObject creates the file of the object and returns the download of the object to the file
+func (s *S3ObjectDownloader) batchObject(key string) (s3manager.BatchDownloadObject, error) {
+	subObjectKey := strings.TrimPrefix(key, s.Prefix)
+	fileName := filepath.Join(s.ModelDir, s.ModelName, subObjectKey)
+
+	if FileExists(fileName) {
+		// File got corrupted or is mid-download :(
+		// TODO: Figure out if we can maybe continue?
+		if err := os.Remove(fileName); err != nil {
+			return s3manager.BatchDownloadObject{}, fmt.Errorf("file is unable to be deleted: %v", err)
+		}
+	}
+	file, err := Create(fileName)
+	if err != nil {
+		return s3manager.BatchDownloadObject{}, fmt.Errorf("file is already created: %v", err)
+	}
+	return s3manager.BatchDownloadObject{
+		Object: &s3.GetObjectInput{
+			Key:    aws.String(key),
+			Bucket: aws.String(s.Bucket),
+		},
+		Writer: file,
+		After: func() error {
+			defer file.Close()
+			return nil
+		},
+	}, nil
+}
+
 func (s *S3ObjectDownloader) Download(objects []s3manager.BatchDownloadObject) error {
 	iter := &s3manager.DownloadObjectsIterator{Objects: objects}
 	if err := s.downloader.DownloadWithIterator(aws.BackgroundContext(), iter); err != nil {
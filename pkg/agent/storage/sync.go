@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SyncManifestFile is the file of the model dir recording the objects the files of the model were downloaded from
+const SyncManifestFile = ".kserve-sync.json"
+
+// SyncProvider is a Provider syncing the model dir with the objects of the storage uri, only the objects which changed
+// since the previous sync of the model dir are downloaded and the files of the objects which no longer exist are
+// removed
+type SyncProvider interface {
+	Provider
+	SyncModel(modelDir string, modelName string, storageUri string) error
+}
+
+// SyncObject is the version of the object a file of the model was downloaded from
+type SyncObject struct {
+	ETag string `json:"etag"`
+	Size int64  `json:"size"`
+}
+
+// SyncManifest maps the files of the model, relative to the model dir, to the objects they were downloaded from
+type SyncManifest map[string]SyncObject
+
+// HasSyncManifest returns whether the model dir was synced before
+func HasSyncManifest(modelPath string) bool {
+	return FileExists(filepath.Join(modelPath, SyncManifestFile))
+}
+
+// syncFileName returns the file of the object, relative to the model dir
+func syncFileName(modelPath string, subObjectKey string) (string, error) {
+	return filepath.Rel(modelPath, filepath.Join(modelPath, subObjectKey))
+}
+
+func readSyncManifest(modelPath string) (SyncManifest, error) {
+	manifest := SyncManifest{}
+	data, err := ioutil.ReadFile(filepath.Join(modelPath, SyncManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A corrupted manifest only causes the model to be downloaded again
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		log.Info("Ignoring invalid sync manifest", "modelPath", modelPath, "error", err.Error())
+		return SyncManifest{}, nil
+	}
+	return manifest, nil
+}
+
+func writeSyncManifest(modelPath string, manifest SyncManifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	file, err := Create(filepath.Join(modelPath, SyncManifestFile))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(data)
+	return err
+}
+
+// prepareSync compares the objects of the storage uri with the files of the model dir and returns the files to
+// download, sorted. A file is kept when it has the size of its object and was downloaded from the same ETag, the
+// objects without an ETag are always downloaded. The files of the objects which no longer exist are removed, and the
+// manifest is rewritten with the kept files only so an interrupted sync downloads the changed files again.
+func prepareSync(modelPath string, remote SyncManifest) ([]string, error) {
+	local, err := readSyncManifest(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	kept := SyncManifest{}
+	changed := []string{}
+	for fileName, object := range remote {
+		synced, ok := local[fileName]
+		info, err := os.Stat(filepath.Join(modelPath, fileName))
+		if ok && object.ETag != "" && synced == object && err == nil && info.Mode().IsRegular() &&
+			info.Size() == object.Size {
+			kept[fileName] = object
+			continue
+		}
+		changed = append(changed, fileName)
+	}
+	sort.Strings(changed)
+	if err := removeStaleFiles(modelPath, remote); err != nil {
+		return nil, err
+	}
+	if err := writeSyncManifest(modelPath, kept); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// removeStaleFiles removes the files of the model dir which are not downloaded from an object of the storage uri,
+// e.g. the success file of the previous model spec, and the dirs left empty
+func removeStaleFiles(modelPath string, remote SyncManifest) error {
+	dirs := []string{}
+	err := filepath.WalkDir(modelPath, func(path string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == modelPath {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		fileName, err := filepath.Rel(modelPath, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != modelPath {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		if _, ok := remote[fileName]; ok || fileName == SyncManifestFile {
+			return nil
+		}
+		log.Info("Removing stale file", "file", path)
+		return os.Remove(path)
+	})
+	if err != nil {
+		return err
+	}
+	// The nested dirs are walked after their parent, they are removed first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
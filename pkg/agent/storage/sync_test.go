@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/onsi/gomega"
+)
+
+func TestS3SyncModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := ioutil.TempDir("", "test-sync-")
+	defer os.RemoveAll(tmpDir)
+
+	bucket := mocks.NewMockS3Bucket()
+	bucket.Objects["llama/v1/shard-1.bin"] = []byte("shard-1")
+	bucket.Objects["llama/v1/shard-2.bin"] = []byte("shard-2")
+	bucket.Objects["llama/v1/config.json"] = []byte("{}")
+	provider := &S3Provider{Client: bucket, Downloader: bucket}
+	modelPath := filepath.Join(tmpDir, "llama")
+
+	// The first sync downloads all the objects
+	g.Expect(provider.SyncModel(tmpDir, "llama", "s3://models/llama/v1")).To(gomega.Succeed())
+	g.Expect(bucket.Downloaded).To(gomega.ConsistOf("llama/v1/shard-1.bin", "llama/v1/shard-2.bin",
+		"llama/v1/config.json"))
+	g.Expect(HasSyncManifest(modelPath)).To(gomega.BeTrue())
+	data, err := ioutil.ReadFile(filepath.Join(modelPath, "shard-1.bin"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(data)).To(gomega.Equal("shard-1"))
+
+	// Only the changed and the new objects are downloaded, the deleted objects are removed
+	writeModel(g, modelPath, map[string]string{"SUCCESS.abc": "{}"})
+	delete(bucket.Objects, "llama/v1/config.json")
+	bucket.Objects["llama/v1/shard-2.bin"] = []byte("shard-2-v2")
+	bucket.Objects["llama/v1/tokenizer/vocab.txt"] = []byte("vocab")
+	bucket.Downloaded = nil
+	g.Expect(provider.SyncModel(tmpDir, "llama", "s3://models/llama/v1")).To(gomega.Succeed())
+	g.Expect(bucket.Downloaded).To(gomega.ConsistOf("llama/v1/shard-2.bin", "llama/v1/tokenizer/vocab.txt"))
+	data, err = ioutil.ReadFile(filepath.Join(modelPath, "shard-2.bin"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(data)).To(gomega.Equal("shard-2-v2"))
+	g.Expect(filepath.Join(modelPath, "config.json")).NotTo(gomega.BeAnExistingFile())
+	g.Expect(filepath.Join(modelPath, "SUCCESS.abc")).NotTo(gomega.BeAnExistingFile())
+
+	// A file modified or removed locally is downloaded again, the removed dirs are removed locally
+	g.Expect(os.Remove(filepath.Join(modelPath, "shard-1.bin"))).To(gomega.Succeed())
+	delete(bucket.Objects, "llama/v1/tokenizer/vocab.txt")
+	bucket.Downloaded = nil
+	g.Expect(provider.SyncModel(tmpDir, "llama", "s3://models/llama/v1")).To(gomega.Succeed())
+	g.Expect(bucket.Downloaded).To(gomega.ConsistOf("llama/v1/shard-1.bin"))
+	g.Expect(filepath.Join(modelPath, "tokenizer")).NotTo(gomega.BeADirectory())
+
+	// The objects copied to another storage uri keep their ETag and are not downloaded again
+	bucket.Objects["llama/v2/shard-1.bin"] = []byte("shard-1")
+	bucket.Objects["llama/v2/shard-2.bin"] = []byte("shard-2-v3")
+	bucket.Downloaded = nil
+	g.Expect(provider.SyncModel(tmpDir, "llama", "s3://models/llama/v2/")).To(gomega.Succeed())
+	g.Expect(bucket.Downloaded).To(gomega.ConsistOf("llama/v2/shard-2.bin"))
+}
+
+func TestS3SyncModelNotFound(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := ioutil.TempDir("", "test-sync-")
+	defer os.RemoveAll(tmpDir)
+
+	bucket := mocks.NewMockS3Bucket()
+	provider := &S3Provider{Client: bucket, Downloader: bucket}
+	g.Expect(provider.SyncModel(tmpDir, "llama", "s3://models/llama")).To(gomega.MatchError(
+		gomega.ContainSubstring("has no objects or does not exist")))
+	g.Expect(HasSyncManifest(filepath.Join(tmpDir, "llama"))).To(gomega.BeFalse())
+}
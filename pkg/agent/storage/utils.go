@@ -19,6 +19,8 @@ package storage
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -28,6 +30,7 @@ import (
 	gstorage "cloud.google.com/go/storage"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -98,57 +101,29 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 
 	switch protocol {
 	case GCS:
-		var gcsClient *gstorage.Client
-		var err error
-
-		ctx := context.Background()
-		if _, ok := os.LookupEnv(gcscredential.GCSCredentialEnvKey); ok {
-			// GCS relies on environment variable GOOGLE_APPLICATION_CREDENTIALS to point to the service-account-key
-			// If set, it will be automatically be picked up by the client.
-			gcsClient, err = gstorage.NewClient(ctx)
-		} else {
-			gcsClient, err = gstorage.NewClient(ctx, option.WithoutAuthentication())
-		}
-
+		gcsClient, err := newGCSClient()
 		if err != nil {
 			return nil, err
 		}
 
 		providers[GCS] = &GCSProvider{
-			Client: stiface.AdaptClient(gcsClient),
+			Client: gcsClient,
 		}
 	case S3:
-		var sess *session.Session
-		var err error
-
-		region, _ := os.LookupEnv(s3credential.AWSRegion)
-		useVirtualBucketString, ok := os.LookupEnv(s3credential.S3UseVirtualBucket)
-		useVirtualBucket := true
-		if ok && strings.ToLower(useVirtualBucketString) == "false" {
-			useVirtualBucket = false
-		}
-
-		awsConfig := aws.Config{
-			Region:           aws.String(region),
-			S3ForcePathStyle: aws.Bool(!useVirtualBucket),
-		}
-
-		if endpoint, ok := os.LookupEnv(s3credential.AWSEndpointUrl); ok {
-			awsConfig.Endpoint = aws.String(endpoint)
+		sessionClient, err := newS3Client()
+		if err != nil {
+			return nil, err
 		}
-
-		if useAnonCred, ok := os.LookupEnv(s3credential.AWSAnonymousCredential); ok && strings.ToLower(useAnonCred) == "true" {
-			awsConfig.Credentials = credentials.AnonymousCredentials
+		providers[S3] = &S3Provider{
+			Client:     sessionClient,
+			Downloader: s3manager.NewDownloaderWithClient(sessionClient, func(d *s3manager.Downloader) {}),
 		}
-
-		sess, err = session.NewSession(&awsConfig)
-
+	case OSS, COS, R2:
+		sessionClient, err := newObjectStoreClient(protocol)
 		if err != nil {
 			return nil, err
 		}
-
-		sessionClient := s3.New(sess)
-		providers[S3] = &S3Provider{
+		providers[protocol] = &S3Provider{
 			Client:     sessionClient,
 			Downloader: s3manager.NewDownloaderWithClient(sessionClient, func(d *s3manager.Downloader) {}),
 		}
@@ -166,3 +141,82 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 
 	return providers[protocol], nil
 }
+
+// newGCSClient creates the GCS client, authenticated with the service account key in the environment when it is set
+func newGCSClient() (stiface.Client, error) {
+	var gcsClient *gstorage.Client
+	var err error
+
+	ctx := context.Background()
+	if _, ok := os.LookupEnv(gcscredential.GCSCredentialEnvKey); ok {
+		// GCS relies on environment variable GOOGLE_APPLICATION_CREDENTIALS to point to the service-account-key
+		// If set, it will be automatically be picked up by the client.
+		gcsClient, err = gstorage.NewClient(ctx)
+	} else {
+		gcsClient, err = gstorage.NewClient(ctx, option.WithoutAuthentication())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stiface.AdaptClient(gcsClient), nil
+}
+
+// newS3Client creates the S3 client from the credentials and the s3 settings in the environment
+func newS3Client() (*s3.S3, error) {
+	region, _ := os.LookupEnv(s3credential.AWSRegion)
+	useVirtualBucketString, ok := os.LookupEnv(s3credential.S3UseVirtualBucket)
+	useVirtualBucket := true
+	if ok && strings.ToLower(useVirtualBucketString) == "false" {
+		useVirtualBucket = false
+	}
+
+	awsConfig := aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(!useVirtualBucket),
+	}
+
+	if endpoint, ok := os.LookupEnv(s3credential.AWSEndpointUrl); ok {
+		awsConfig.Endpoint = aws.String(endpoint)
+	}
+
+	if useAnonCred, ok := os.LookupEnv(s3credential.AWSAnonymousCredential); ok && strings.ToLower(useAnonCred) == "true" {
+		awsConfig.Credentials = credentials.AnonymousCredentials
+	}
+
+	// The session adds the CA bundle of AWS_CA_BUNDLE to the transport, the certificate of the on-prem stores is not
+	// verified at all when S3_VERIFY_SSL is disabled
+	if verifySSL, ok := os.LookupEnv(s3credential.S3VerifySSL); ok && (verifySSL == "0" || strings.ToLower(verifySSL) == "false") {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+		awsConfig.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	sess, err := session.NewSession(&awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := s3.New(sess)
+	if encodedKey := os.Getenv(s3credential.AWSSSECustomerKey); encodedKey != "" {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("%s must be a base64 encoded 256-bit key", s3credential.AWSSSECustomerKey)
+		}
+		client.Handlers.Validate.PushFront(sseCustomerKeyHandler(string(key)))
+	}
+	return client, nil
+}
+
+// sseCustomerKeyHandler sets the customer provided key on the requests reading and writing the objects, the objects
+// of the buckets encrypted with SSE-C cannot be read without it
+func sseCustomerKeyHandler(key string) func(*request.Request) {
+	return func(r *request.Request) {
+		switch input := r.Params.(type) {
+		case *s3.GetObjectInput:
+			input.SSECustomerAlgorithm, input.SSECustomerKey = aws.String(s3.ServerSideEncryptionAes256), aws.String(key)
+		case *s3.HeadObjectInput:
+			input.SSECustomerAlgorithm, input.SSECustomerKey = aws.String(s3.ServerSideEncryptionAes256), aws.String(key)
+		case *s3.PutObjectInput:
+			input.SSECustomerAlgorithm, input.SSECustomerKey = aws.String(s3.ServerSideEncryptionAes256), aws.String(key)
+		}
+	}
+}
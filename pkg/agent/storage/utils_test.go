@@ -18,12 +18,15 @@ package storage
 
 import (
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"syscall"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/kserve/kserve/pkg/agent/mocks"
 	"github.com/onsi/gomega"
 )
@@ -104,9 +107,38 @@ func TestGetProvider(t *testing.T) {
 	g.Expect(provider).Should(gomega.Equal(mockProviders[S3]))
 
 	// When providers map does not have specified provider
+	t.Setenv("OSS_REGION", "cn-hangzhou")
+	t.Setenv("COS_REGION", "us-south")
+	t.Setenv("R2_ACCOUNT_ID", "account")
 	for _, protocol := range SupportedProtocols {
 		provider, err = GetProvider(map[Protocol]Provider{}, protocol)
 		g.Expect(err).To(gomega.BeNil())
 		g.Expect(provider).ShouldNot(gomega.BeNil())
 	}
 }
+
+func TestNewS3ClientOnPrem(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("AWS_ENDPOINT_URL", "https://minio.minio:9000")
+	t.Setenv("AWS_DEFAULT_REGION", "us-east-1")
+	t.Setenv("S3_USER_VIRTUAL_BUCKET", "false")
+	t.Setenv("S3_VERIFY_SSL", "0")
+	t.Setenv("AWS_SSE_CUSTOMER_KEY", "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	client, err := newS3Client()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(aws.BoolValue(client.Config.S3ForcePathStyle)).To(gomega.BeTrue())
+	g.Expect(client.Config.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify).To(gomega.BeTrue())
+
+	// The objects are read with the customer provided key
+	req, _ := client.GetObjectRequest(&s3.GetObjectInput{Bucket: aws.String("models"), Key: aws.String("model.pt")})
+	g.Expect(req.Build()).To(gomega.Succeed())
+	g.Expect(req.HTTPRequest.URL.String()).To(gomega.Equal("https://minio.minio:9000/models/model.pt"))
+	g.Expect(req.HTTPRequest.Header.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm")).To(gomega.Equal("AES256"))
+	g.Expect(req.HTTPRequest.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key")).To(
+		gomega.Equal("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="))
+	g.Expect(req.HTTPRequest.Header.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5")).NotTo(gomega.BeEmpty())
+
+	t.Setenv("AWS_SSE_CUSTOMER_KEY", "c2hvcnQ=")
+	_, err = newS3Client()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
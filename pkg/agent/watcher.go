@@ -139,11 +139,11 @@ func (w *Watcher) parseConfig(modelConfigs modelconfig.ModelConfigs, initializin
 			w.modelAdded(name, &spec, initializing)
 		} else if !cmp.Equal(spec, *existing.Spec) {
 			w.ModelTracker[name] = modelWrapper{
-				Spec:  existing.Spec,
+				Spec:  &spec,
 				stale: false,
 			}
-			// Changed - replace
-			w.modelRemoved(name)
+			// Changed - replace, the model dir is synced with the new spec and the model is reloaded in place so
+			// only the changed files are downloaded
 			w.modelAdded(name, &spec, initializing)
 		} else if cmp.Equal(spec, *existing.Spec) {
 			// This model didn't change, mark the stale flag to false
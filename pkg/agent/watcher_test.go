@@ -297,7 +297,7 @@ var _ = Describe("Watcher", func() {
 				Eventually(func() int { return len(puller.channelMap) }).Should(Equal(0))
 				Eventually(func() int { return puller.opStats["model1"][Add] }).Should(Equal(1))
 				Eventually(func() int { return puller.opStats["model2"][Add] }).Should(Equal(2))
-				Eventually(func() int { return puller.opStats["model2"][Remove] }).Should(Equal(1))
+				Expect(puller.opStats["model2"][Remove]).Should(Equal(0))
 			})
 		})
 
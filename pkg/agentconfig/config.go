@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Config is the logger, batcher and maintenance configuration of an InferenceService component, the agent applies its
+// changes without a restart
+type Config struct {
+	Logger      *LoggerConfig      `json:"logger,omitempty"`
+	Batcher     *BatcherConfig     `json:"batcher,omitempty"`
+	Maintenance *MaintenanceConfig `json:"maintenance,omitempty"`
+}
+
+// LoggerConfig is the part of the logger spec the agent can change without a restart, enabling the logger or changing
+// its delivery still rolls out a new revision
+type LoggerConfig struct {
+	URL  string             `json:"url,omitempty"`
+	Mode v1beta1.LoggerType `json:"mode,omitempty"`
+}
+
+// BatcherConfig holds the limits of the batches, the defaults of the batcher are used when they are not set
+type BatcherConfig struct {
+	MaxBatchSize int `json:"maxBatchSize,omitempty"`
+	MaxLatency   int `json:"maxLatency,omitempty"`
+}
+
+// MaintenanceConfig switches the maintenance mode of the component, the agent answers the requests with a 503 and the
+// body while it is enabled
+type MaintenanceConfig struct {
+	Enabled           bool   `json:"enabled"`
+	Body              string `json:"body,omitempty"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds,omitempty"`
+}
+
+// NewMaintenanceConfig returns the maintenance config of the serving.kserve.io/maintenance annotations of the
+// InferenceService, nil when the maintenance mode is not set
+func NewMaintenanceConfig(isvc *v1beta1.InferenceService) *MaintenanceConfig {
+	value, ok := isvc.Annotations[constants.MaintenanceAnnotationKey]
+	if !ok {
+		return nil
+	}
+	config := &MaintenanceConfig{
+		Enabled: value == "true",
+		Body:    isvc.Annotations[constants.MaintenanceBodyAnnotationKey],
+	}
+	if retryAfter, err := time.ParseDuration(isvc.Annotations[constants.MaintenanceRetryAfterAnnotationKey]); err == nil {
+		config.RetryAfterSeconds = int(math.Ceil(retryAfter.Seconds()))
+	}
+	return config
+}
+
+// NewConfig returns the config of the component with the logger and batcher, nil when it has neither
+func NewConfig(logger *v1beta1.LoggerSpec, batcher *v1beta1.Batcher) *Config {
+	if logger == nil && batcher == nil {
+		return nil
+	}
+	config := &Config{}
+	if logger != nil {
+		config.Logger = &LoggerConfig{Mode: logger.Mode}
+		if logger.URL != nil {
+			config.Logger.URL = *logger.URL
+		}
+	}
+	if batcher != nil {
+		config.Batcher = &BatcherConfig{}
+		if batcher.MaxBatchSize != nil {
+			config.Batcher.MaxBatchSize = *batcher.MaxBatchSize
+		}
+		if batcher.MaxLatency != nil {
+			config.Batcher.MaxLatency = *batcher.MaxLatency
+		}
+	}
+	return config
+}
+
+// FileName returns the key of the config of the component in the agent ConfigMap
+func FileName(component string) string {
+	return component + ".json"
+}
+
+// agent ConfigMap
+// apiVersion: v1
+// kind: ConfigMap
+// metadata:
+//
+//	name: <inferenceservice>-agent-config
+//	namespace: <user-model-namespace>
+//
+// data:
+//
+//	predictor.json: |
+//	  {
+//	    "logger": {"url": "http://message-dumper.default/", "mode": "all"},
+//	    "batcher": {"maxBatchSize": 32, "maxLatency": 500}
+//	  }
+//	transformer.json: |
+//	  {"logger": {"url": "http://message-dumper.default/", "mode": "request"}}
+func CreateConfigMap(isvc *v1beta1.InferenceService) (*v1.ConfigMap, error) {
+	configs := map[string]*Config{
+		string(v1beta1.PredictorComponent): NewConfig(isvc.Spec.Predictor.Logger, isvc.Spec.Predictor.Batcher),
+	}
+	if isvc.Spec.Transformer != nil {
+		configs[string(v1beta1.TransformerComponent)] = NewConfig(isvc.Spec.Transformer.Logger, isvc.Spec.Transformer.Batcher)
+	}
+	// The explainer is not batched
+	if isvc.Spec.Explainer != nil {
+		configs[string(v1beta1.ExplainerComponent)] = NewConfig(isvc.Spec.Explainer.Logger, nil)
+	}
+	// The maintenance mode applies to all the components
+	if maintenance := NewMaintenanceConfig(isvc); maintenance != nil {
+		for component, config := range configs {
+			if config == nil {
+				config = &Config{}
+				configs[component] = config
+			}
+			config.Maintenance = maintenance
+		}
+	}
+	data := map[string]string{}
+	for component, config := range configs {
+		if config == nil {
+			continue
+		}
+		encoded, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		data[FileName(component)] = string(encoded)
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.AgentConfigName(isvc.Name),
+			Namespace: isvc.Namespace,
+			Labels:    isvc.Labels,
+		},
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logUrl := "http://message-dumper.default/"
+	maxBatchSize := 32
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					Logger:  &v1beta1.LoggerSpec{URL: &logUrl, Mode: v1beta1.LogAll},
+					Batcher: &v1beta1.Batcher{MaxBatchSize: &maxBatchSize},
+				},
+			},
+			Transformer: &v1beta1.TransformerSpec{},
+			Explainer: &v1beta1.ExplainerSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					Logger: &v1beta1.LoggerSpec{Mode: v1beta1.LogRequest},
+				},
+			},
+		},
+	}
+	configMap, err := CreateConfigMap(isvc)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(configMap.Name).To(gomega.Equal("sklearn-agent-config"))
+	g.Expect(configMap.Namespace).To(gomega.Equal("default"))
+	// The transformer has neither a logger nor a batcher
+	g.Expect(configMap.Data).To(gomega.HaveLen(2))
+	g.Expect(configMap.Data["predictor.json"]).To(gomega.MatchJSON(
+		`{"logger":{"url":"http://message-dumper.default/","mode":"all"},"batcher":{"maxBatchSize":32}}`))
+	g.Expect(configMap.Data["explainer.json"]).To(gomega.MatchJSON(`{"logger":{"mode":"request"}}`))
+}
+
+func TestCreateConfigMapMaintenance(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logUrl := "http://message-dumper.default/"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.MaintenanceAnnotationKey:           "true",
+				constants.MaintenanceBodyAnnotationKey:       `{"error": "Under maintenance"}`,
+				constants.MaintenanceRetryAfterAnnotationKey: "90s",
+			},
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					Logger: &v1beta1.LoggerSpec{URL: &logUrl, Mode: v1beta1.LogAll},
+				},
+			},
+			Transformer: &v1beta1.TransformerSpec{},
+		},
+	}
+	configMap, err := CreateConfigMap(isvc)
+	g.Expect(err).To(gomega.BeNil())
+	// The maintenance mode is set on every component, with or without a logger or a batcher
+	g.Expect(configMap.Data).To(gomega.HaveLen(2))
+	g.Expect(configMap.Data["predictor.json"]).To(gomega.MatchJSON(`{"logger":{"url":"http://message-dumper.default/","mode":"all"},
+		"maintenance":{"enabled":true,"body":"{\"error\": \"Under maintenance\"}","retryAfterSeconds":90}}`))
+	g.Expect(configMap.Data["transformer.json"]).To(gomega.MatchJSON(
+		`{"maintenance":{"enabled":true,"body":"{\"error\": \"Under maintenance\"}","retryAfterSeconds":90}}`))
+
+	// The maintenance mode is kept in the config once switched off so the agents stay mounted
+	isvc.Annotations = map[string]string{constants.MaintenanceAnnotationKey: "false"}
+	configMap, err = CreateConfigMap(isvc)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(configMap.Data["transformer.json"]).To(gomega.MatchJSON(`{"maintenance":{"enabled":false}}`))
+}
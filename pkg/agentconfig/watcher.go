@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// reloadPeriod is how often the mounted config is read again, the kubelet refreshes the mounted ConfigMap about
+// every minute
+const reloadPeriod = 10 * time.Second
+
+// Watcher reads the config mounted from the agent ConfigMap again until the agent stops
+type Watcher struct {
+	file   string
+	period time.Duration
+	log    *zap.SugaredLogger
+	data   []byte
+}
+
+// NewWatcher loads the config file, the returned config is applied when the agent starts
+func NewWatcher(file string, logger *zap.SugaredLogger) (*Watcher, *Config, error) {
+	w := &Watcher{
+		file:   file,
+		period: reloadPeriod,
+		log:    logger,
+	}
+	config, err := w.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, config, nil
+}
+
+// Start reads the config file periodically until the context is done and calls apply with the config when it
+// changed. The last valid config is kept while the file is invalid.
+func (w *Watcher) Start(ctx context.Context, apply func(*Config)) {
+	go func() {
+		ticker := time.NewTicker(w.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				config, err := w.load()
+				if err != nil {
+					w.log.Errorw("Failed to reload agent config", "file", w.file, "error", err)
+					continue
+				}
+				if config != nil {
+					w.log.Infof("Applying the changed agent config %s", w.file)
+					apply(config)
+				}
+			}
+		}
+	}()
+}
+
+// load reads the config file, nil is returned when it did not change since it was last loaded
+func (w *Watcher) load() (*Config, error) {
+	data, err := ioutil.ReadFile(w.file)
+	if err != nil {
+		return nil, err
+	}
+	if w.data != nil && bytes.Equal(data, w.data) {
+		return nil, nil
+	}
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	w.data = data
+	return config, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestWatcher(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	file := filepath.Join(t.TempDir(), "predictor.json")
+	g.Expect(ioutil.WriteFile(file, []byte(`{"logger":{"url":"http://a/","mode":"all"}}`), 0600)).To(gomega.Succeed())
+
+	watcher, config, err := NewWatcher(file, logger)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(config).To(gomega.Equal(&Config{Logger: &LoggerConfig{URL: "http://a/", Mode: v1beta1.LogAll}}))
+
+	watcher.period = 10 * time.Millisecond
+	configs := make(chan *Config, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.Start(ctx, func(config *Config) { configs <- config })
+	// The unchanged and the invalid configs are not applied
+	g.Consistently(configs, 50*time.Millisecond).ShouldNot(gomega.Receive())
+	g.Expect(ioutil.WriteFile(file, []byte("{"), 0600)).To(gomega.Succeed())
+	g.Consistently(configs, 50*time.Millisecond).ShouldNot(gomega.Receive())
+
+	g.Expect(ioutil.WriteFile(file, []byte(`{"batcher":{"maxBatchSize":8}}`), 0600)).To(gomega.Succeed())
+	g.Eventually(configs, time.Second).Should(gomega.Receive(gomega.Equal(&Config{Batcher: &BatcherConfig{MaxBatchSize: 8}})))
+}
+
+func TestNewWatcherMissingFile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	_, _, err := NewWatcher(filepath.Join(t.TempDir(), "predictor.json"), logger)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowedhosts
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// misdirectedRequests counts the requests rejected because their host is not one of the InferenceService, a
+// non zero value points to a routing misconfiguration delivering the traffic of another InferenceService.
+var misdirectedRequests = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "kserve_agent_misdirected_requests_total",
+	Help: "Number of requests rejected because their host is not allowed for the InferenceService",
+})
+
+func init() {
+	prometheus.MustRegister(misdirectedRequests)
+}
+
+// AllowedHostsHandler rejects the requests whose Host header does not match the hosts expected for the
+// InferenceService. A host is allowed when it equals an entry or starts with an entry followed by a dot, so
+// "sklearn.default" allows "sklearn.default.svc.cluster.local" and "sklearn.default.example.com". Entries starting
+// with "*." allow any host ending with the rest of the entry.
+type AllowedHostsHandler struct {
+	log   *zap.SugaredLogger
+	hosts []string
+	next  http.Handler
+}
+
+func New(hosts []string, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	normalized := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host = normalizeHost(host); host != "" {
+			normalized = append(normalized, host)
+		}
+	}
+	return &AllowedHostsHandler{
+		log:   logger,
+		hosts: normalized,
+		next:  next,
+	}
+}
+
+func (h *AllowedHostsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if splitHost, _, err := net.SplitHostPort(host); err == nil {
+		host = splitHost
+	}
+	host = normalizeHost(host)
+	if !h.allowed(host) {
+		misdirectedRequests.Inc()
+		h.log.Warnw("Rejected request for a host not allowed", "host", r.Host, "path", r.URL.Path)
+		http.Error(w, "host not allowed", http.StatusMisdirectedRequest)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *AllowedHostsHandler) allowed(host string) bool {
+	for _, allowed := range h.hosts {
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		} else if host == allowed || strings.HasPrefix(host, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowedhosts
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestAllowedHostsHandler(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := New([]string{"sklearn.default", "sklearn-predictor-default.default", "*.models.example.com"}, next, logger)
+
+	scenarios := map[string]struct {
+		host           string
+		expectedStatus int
+	}{
+		"ExactHost": {
+			host:           "sklearn.default",
+			expectedStatus: http.StatusOK,
+		},
+		"ClusterLocalHost": {
+			host:           "sklearn-predictor-default.default.svc.cluster.local",
+			expectedStatus: http.StatusOK,
+		},
+		"ExternalHostWithPort": {
+			host:           "Sklearn.default.example.com:8080",
+			expectedStatus: http.StatusOK,
+		},
+		"WildcardHost": {
+			host:           "iris.models.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		"OtherInferenceService": {
+			host:           "xgboost-predictor-default.default.svc.cluster.local",
+			expectedStatus: http.StatusMisdirectedRequest,
+		},
+		"OtherNamespace": {
+			host:           "sklearn.other.example.com",
+			expectedStatus: http.StatusMisdirectedRequest,
+		},
+		"HostPrefixOnly": {
+			host:           "sklearn.defaultx.example.com",
+			expectedStatus: http.StatusMisdirectedRequest,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			before := testutil.ToFloat64(misdirectedRequests)
+			req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+			req.Host = scenario.host
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			g.Expect(rec.Code).To(gomega.Equal(scenario.expectedStatus))
+			expectedCount := before
+			if scenario.expectedStatus == http.StatusMisdirectedRequest {
+				expectedCount++
+			}
+			g.Expect(testutil.ToFloat64(misdirectedRequests)).To(gomega.Equal(expectedCount))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// APIKeyHeader is the request header carrying the API key
+	APIKeyHeader = "X-API-Key"
+	// reloadPeriod is how often the mounted key store is read again, so keys can be added or revoked without a restart
+	reloadPeriod = 30 * time.Second
+
+	// RequestsMetricName is the name of the counter of the requests per api key, aggregated into the usage reports
+	RequestsMetricName = "kserve_agent_api_key_requests_total"
+
+	ResultAccepted    = "accepted"
+	ResultInvalid     = "invalid"
+	ResultRateLimited = "rate_limited"
+)
+
+// requests counts the requests per API key id and result, the requests without a valid key have an empty key id
+var requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: RequestsMetricName,
+	Help: "Number of requests per API key and result",
+}, []string{"key", "result"})
+
+func init() {
+	prometheus.MustRegister(requests)
+}
+
+type entry struct {
+	id      string
+	key     Key
+	limiter *rate.Limiter
+}
+
+// APIKeyHandler only lets through the requests sending one of the keys of the key store in the X-API-Key header and
+// limits the rate of the requests of each key.
+type APIKeyHandler struct {
+	log  *zap.SugaredLogger
+	dir  string
+	next http.Handler
+	mu   sync.RWMutex
+	// keys indexes the entries by the hash of the key
+	keys map[string]*entry
+}
+
+// New loads the key store mounted in dir and reloads it periodically until the context is done
+func New(ctx context.Context, dir string, next http.Handler, logger *zap.SugaredLogger) (http.Handler, error) {
+	h := &APIKeyHandler{
+		log:  logger,
+		dir:  dir,
+		next: next,
+	}
+	if err := h.reload(); err != nil {
+		return nil, err
+	}
+	go func() {
+		ticker := time.NewTicker(reloadPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// The last valid keys are kept while the key store is invalid
+				if err := h.reload(); err != nil {
+					h.log.Errorw("Failed to reload api keys", "dir", h.dir, "error", err)
+				}
+			}
+		}
+	}()
+	return h, nil
+}
+
+func (h *APIKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		requests.WithLabelValues("", ResultInvalid).Inc()
+		http.Error(w, "missing api key", http.StatusUnauthorized)
+		return
+	}
+	h.mu.RLock()
+	e, ok := h.keys[Hash(key)]
+	h.mu.RUnlock()
+	if !ok {
+		requests.WithLabelValues("", ResultInvalid).Inc()
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+		return
+	}
+	if e.limiter != nil && !e.limiter.Allow() {
+		requests.WithLabelValues(e.id, ResultRateLimited).Inc()
+		http.Error(w, "api key rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	requests.WithLabelValues(e.id, ResultAccepted).Inc()
+	// The key is not forwarded to the model server
+	r.Header.Del(APIKeyHeader)
+	h.next.ServeHTTP(w, r)
+}
+
+// reload reads the key store again, the rate limiters of the unchanged keys are kept.
+func (h *APIKeyHandler) reload() error {
+	loaded, err := LoadKeys(h.dir)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	existing := map[string]*entry{}
+	for _, e := range h.keys {
+		existing[e.id] = e
+	}
+	keys := make(map[string]*entry, len(loaded))
+	for id, key := range loaded {
+		if e, ok := existing[id]; ok && e.key == key {
+			keys[key.SHA256] = e
+			continue
+		}
+		keys[key.SHA256] = &entry{id: id, key: key, limiter: newLimiter(key)}
+	}
+	h.keys = keys
+	return nil
+}
+
+func newLimiter(key Key) *rate.Limiter {
+	if key.RequestsPerSecond == 0 {
+		return nil
+	}
+	burst := key.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(key.RequestsPerSecond))
+	}
+	return rate.NewLimiter(rate.Limit(key.RequestsPerSecond), burst)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func writeKey(t *testing.T, dir string, id string, data string) {
+	if err := os.WriteFile(filepath.Join(dir, id), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPIKeyHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	dir := t.TempDir()
+	writeKey(t, dir, "team-a", `{"sha256": "`+Hash("secret-a")+`"}`)
+	writeKey(t, dir, "team-b", `{"sha256": "`+Hash("secret-b")+`", "requestsPerSecond": 0.001, "burst": 1}`)
+
+	var forwardedKey string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwardedKey = req.Header.Get(APIKeyHeader)
+		rw.WriteHeader(http.StatusOK)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler, err := New(ctx, dir, next, logger)
+	g.Expect(err).To(gomega.BeNil())
+
+	serve := func(key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	g.Expect(serve("")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(serve("unknown")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(serve("secret-a")).To(gomega.Equal(http.StatusOK))
+	g.Expect(forwardedKey).To(gomega.BeEmpty())
+	g.Expect(serve("secret-a")).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve("secret-b")).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve("secret-b")).To(gomega.Equal(http.StatusTooManyRequests))
+
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("", ResultInvalid))).To(gomega.Equal(2.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("team-a", ResultAccepted))).To(gomega.Equal(2.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("team-b", ResultAccepted))).To(gomega.Equal(1.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("team-b", ResultRateLimited))).To(gomega.Equal(1.0))
+
+	// Revoked keys are rejected once reloaded, the rate limit of the unchanged keys is kept
+	g.Expect(os.Remove(filepath.Join(dir, "team-a"))).To(gomega.Succeed())
+	g.Expect(handler.(*APIKeyHandler).reload()).To(gomega.Succeed())
+	g.Expect(serve("secret-a")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(serve("secret-b")).To(gomega.Equal(http.StatusTooManyRequests))
+}
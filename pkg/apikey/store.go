@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Key is an API key entry of the key store secret, the secret maps the key id to the JSON encoded entry. Only the
+// SHA-256 hash of the key is stored, e.g. {"sha256": "<hex digest>", "requestsPerSecond": 10, "burst": 20}.
+type Key struct {
+	// SHA256 is the hex encoded SHA-256 digest of the key
+	SHA256 string `json:"sha256"`
+	// RequestsPerSecond limits the rate of the requests sent with the key, unlimited when zero
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	// Burst is the number of requests allowed above the rate, defaults to the rate rounded up
+	Burst int `json:"burst,omitempty"`
+}
+
+// Hash returns the hex encoded SHA-256 digest stored for the key
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadKeys reads the keys of the key store secret mounted in dir, indexed by key id. The hidden entries created by
+// the kubelet for the atomic updates of the volume are skipped.
+func LoadKeys(dir string) (map[string]Key, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]Key{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		key := Key{}
+		if err := json.Unmarshal(data, &key); err != nil {
+			return nil, fmt.Errorf("invalid api key %q: %v", entry.Name(), err)
+		}
+		key.SHA256 = strings.ToLower(key.SHA256)
+		if len(key.SHA256) != hex.EncodedLen(sha256.Size) {
+			return nil, fmt.Errorf("invalid api key %q: sha256 must be a hex encoded SHA-256 digest", entry.Name())
+		}
+		if key.RequestsPerSecond < 0 || key.Burst < 0 {
+			return nil, fmt.Errorf("invalid api key %q: the rate limit must not be negative", entry.Name())
+		}
+		keys[entry.Name()] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestLoadKeys(t *testing.T) {
+	scenarios := map[string]struct {
+		files       map[string]string
+		expected    map[string]Key
+		expectedErr bool
+	}{
+		"Keys": {
+			files: map[string]string{
+				"team-a": `{"sha256": "` + Hash("secret-a") + `"}`,
+				"team-b": `{"sha256": "` + Hash("secret-b") + `", "requestsPerSecond": 10, "burst": 20}`,
+			},
+			expected: map[string]Key{
+				"team-a": {SHA256: Hash("secret-a")},
+				"team-b": {SHA256: Hash("secret-b"), RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		"HiddenEntriesSkipped": {
+			files: map[string]string{
+				"..data": "ignored",
+				"team-a": `{"sha256": "` + Hash("secret-a") + `"}`,
+			},
+			expected: map[string]Key{
+				"team-a": {SHA256: Hash("secret-a")},
+			},
+		},
+		"PlainKey": {
+			files:       map[string]string{"team-a": "secret-a"},
+			expectedErr: true,
+		},
+		"InvalidHash": {
+			files:       map[string]string{"team-a": `{"sha256": "abc"}`},
+			expectedErr: true,
+		},
+		"NegativeRate": {
+			files:       map[string]string{"team-a": `{"sha256": "` + Hash("secret-a") + `", "requestsPerSecond": -1}`},
+			expectedErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			dir := t.TempDir()
+			for file, data := range scenario.files {
+				g.Expect(os.WriteFile(filepath.Join(dir, file), []byte(data), 0600)).To(gomega.Succeed())
+			}
+			keys, err := LoadKeys(dir)
+			if scenario.expectedErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(keys).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
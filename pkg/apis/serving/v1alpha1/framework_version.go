@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The operators of the framework version constraints, the two characters operators are matched first
+var frameworkVersionOperators = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// ValidateFrameworkVersion returns an error unless the version is a dotted numeric version such as "1", "1.4" or
+// "1.4.2", a local version label after "+" is ignored, e.g. "2.1.0+cpu"
+func ValidateFrameworkVersion(version string) error {
+	_, err := parseFrameworkVersion(version)
+	return err
+}
+
+// MatchFrameworkVersion returns whether the version satisfies all the comma separated constraints, e.g. ">=1.3,<1.6".
+// A constraint without operator matches the version exactly, the missing components of the versions are zeros.
+func MatchFrameworkVersion(constraints string, version string) (bool, error) {
+	v, err := parseFrameworkVersion(version)
+	if err != nil {
+		return false, err
+	}
+	for _, constraint := range strings.Split(constraints, ",") {
+		constraint = strings.TrimSpace(constraint)
+		if constraint == "" {
+			continue
+		}
+		operator := "=="
+		for _, op := range frameworkVersionOperators {
+			if strings.HasPrefix(constraint, op) {
+				operator = op
+				constraint = strings.TrimSpace(strings.TrimPrefix(constraint, op))
+				break
+			}
+		}
+		c, err := parseFrameworkVersion(constraint)
+		if err != nil {
+			return false, err
+		}
+		cmp := compareFrameworkVersions(v, c)
+		var ok bool
+		switch operator {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func parseFrameworkVersion(version string) ([]int, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	components := []int{}
+	for _, component := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(component)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid framework version %q", version)
+		}
+		components = append(components, n)
+	}
+	return components, nil
+}
+
+func compareFrameworkVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestMatchFrameworkVersion(t *testing.T) {
+	scenarios := map[string]struct {
+		constraints string
+		version     string
+		expected    bool
+		wantErr     bool
+	}{
+		"InRange": {
+			constraints: ">=1.3,<1.6",
+			version:     "1.4.2",
+			expected:    true,
+		},
+		"LowerBound": {
+			constraints: ">=1.3, <1.6",
+			version:     "1.3",
+			expected:    true,
+		},
+		"UpperBoundExcluded": {
+			constraints: ">=1.3,<1.6",
+			version:     "1.6.0",
+		},
+		"Exact": {
+			constraints: "2.1",
+			version:     "2.1.0",
+			expected:    true,
+		},
+		"NotEqual": {
+			constraints: ">=2,!=2.1.0",
+			version:     "2.1",
+		},
+		"LocalVersionLabel": {
+			constraints: "<2.2",
+			version:     "2.1.0+cpu",
+			expected:    true,
+		},
+		"InvalidVersion": {
+			constraints: ">=1.3",
+			version:     "latest",
+			wantErr:     true,
+		},
+		"InvalidConstraint": {
+			constraints: ">=1.x",
+			version:     "1.4",
+			wantErr:     true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res, err := MatchFrameworkVersion(scenario.constraints, scenario.version)
+			if (err != nil) != scenario.wantErr {
+				t.Errorf("MatchFrameworkVersion() error = %v, wantErr %v", err, scenario.wantErr)
+			}
+			if res != scenario.expected {
+				t.Errorf("MatchFrameworkVersion(%q, %q) = %v, want %v", scenario.constraints, scenario.version, res,
+					scenario.expected)
+			}
+		})
+	}
+}
@@ -45,6 +45,16 @@ type InferenceGraphSpec struct {
 	// Map of InferenceGraph router nodes
 	// Each node defines the router which can be different routing types
 	Nodes map[string]InferenceRouter `json:"nodes"`
+	// TimeoutSeconds specifies the number of seconds to wait before timing out a request to the graph, the streamed
+	// responses included. It sets the timeout of the router revision.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int64 `json:"timeout,omitempty"`
+	// StreamIdleTimeoutSeconds specifies the number of seconds the router waits for the next chunk of a streamed
+	// response before it cancels the call, the streams are not cancelled while they progress when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StreamIdleTimeoutSeconds *int64 `json:"streamIdleTimeout,omitempty"`
 }
 
 // InferenceRouterType constant for inference routing types
@@ -72,6 +82,11 @@ const (
 	GraphRootNodeName string = "root"
 )
 
+const (
+	// CircuitBreakersClosed is False while the circuit breaker of a step is open in a replica of the router
+	CircuitBreakersClosed apis.ConditionType = "CircuitBreakersClosed"
+)
+
 // +k8s:openapi-gen=true
 // InferenceRouter defines the router for each InferenceGraph node with one or multiple steps
 //
@@ -246,9 +261,63 @@ type InferenceStep struct {
 	// +optional
 	Weight *int64 `json:"weight,omitempty"`
 
-	// routing based on the condition
+	// routing based on the condition, a GJSON path which must exist in the payload. The conditions of the Switch nodes
+	// match the request of the node and the conditions of the Sequence steps match the response of the previous step,
+	// unless they are prefixed with $request or $response, e.g. $response.predictions.#(score<0.8) routes on the
+	// response of the previous step of the enclosing Sequence
 	// +optional
 	Condition string `json:"condition,omitempty"`
+
+	// Number of times the call of the service is retried when it can not be reached or answers with a 502, 503 or
+	// 504 status, the calls are not retried when not set
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Delay between the retries of the call of the service
+	// +optional
+	Backoff *StepBackoff `json:"backoff,omitempty"`
+
+	// Fails the calls of the service fast after consecutive failures instead of calling it until it recovers, the open
+	// circuits are reported by the CircuitBreakersClosed condition of the InferenceGraph
+	// +optional
+	CircuitBreaker *StepCircuitBreaker `json:"circuitBreaker,omitempty"`
+}
+
+// StepBackoff defines the delay between the retries of a step, the delay is multiplied by the multiplier after every
+// retry up to the max delay.
+// +k8s:openapi-gen=true
+type StepBackoff struct {
+	// Delay before the first retry in milliseconds, defaults to 100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InitialDelayMilliseconds *int64 `json:"initialDelayMs,omitempty"`
+
+	// Maximum delay between two retries in milliseconds, defaults to 1000
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDelayMilliseconds *int64 `json:"maxDelayMs,omitempty"`
+
+	// Factor the delay is multiplied by after every retry, defaults to 2
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Multiplier *int32 `json:"multiplier,omitempty"`
+}
+
+// StepCircuitBreaker defines when the circuit of a step opens. The open circuit fails the calls of the step without
+// calling the service until the open duration has passed, then a single probe call closes or opens the circuit again.
+// Every replica of the router has its own circuits.
+// +k8s:openapi-gen=true
+type StepCircuitBreaker struct {
+	// Number of consecutive failed calls of the service opening the circuit, the retries included, defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// Number of seconds the circuit stays open before a probe call is let through, defaults to 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OpenDurationSeconds *int64 `json:"openDuration,omitempty"`
 }
 
 // InferenceGraphStatus defines the InferenceGraph conditions and status
@@ -42,6 +42,10 @@ const (
 	TargetNotProvidedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" does not specify an inference target"
 	// InvalidTargetError defines the error message for inference graph target specifies more than one of nodeName, serviceName, serviceUrl
 	InvalidTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" specifies more than one of nodeName, serviceName, serviceUrl"
+	// NodeStepResilienceError defines the error message for the retries, backoff or circuit breaker of a step routing to a node
+	NodeStepResilienceError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" routes to a node, only the steps calling a service support retries, backoff and circuitBreaker"
+	// InvalidBackoffError defines the error message for the max delay of a backoff below its initial delay
+	InvalidBackoffError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has a backoff maxDelayMs below its initialDelayMs"
 )
 
 const (
@@ -83,6 +87,10 @@ func (ig *InferenceGraph) ValidateCreate() error {
 	if err := validateInferenceGraphSplitterWeight(ig); err != nil {
 		return err
 	}
+
+	if err := validateInferenceGraphStepResilience(ig); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -182,3 +190,23 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	}
 	return nil
 }
+
+// Validation of the retries, backoff and circuit breaker of the steps
+func validateInferenceGraphStepResilience(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, route := range node.Steps {
+			if route.Retries == nil && route.Backoff == nil && route.CircuitBreaker == nil {
+				continue
+			}
+			if route.NodeName != "" {
+				return fmt.Errorf(NodeStepResilienceError, i, route.StepName, nodeName, ig.Name)
+			}
+			backoff := route.Backoff
+			if backoff != nil && backoff.InitialDelayMilliseconds != nil && backoff.MaxDelayMilliseconds != nil &&
+				*backoff.MaxDelayMilliseconds < *backoff.InitialDelayMilliseconds {
+				return fmt.Errorf(InvalidBackoffError, i, route.StepName, nodeName, ig.Name)
+			}
+		}
+	}
+	return nil
+}
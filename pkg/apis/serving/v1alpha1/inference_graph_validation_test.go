@@ -242,6 +242,63 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 			},
 			matcher: gomega.MatchError(fmt.Errorf(DuplicateStepNameError, GraphRootNodeName, "foo-bar", "step1")),
 		},
+		"retries of a service step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Retries:         proto.Int32(3),
+							Backoff:         &StepBackoff{InitialDelayMilliseconds: proto.Int64(100), MaxDelayMilliseconds: proto.Int64(100)},
+							CircuitBreaker:  &StepCircuitBreaker{FailureThreshold: proto.Int32(5)},
+						},
+					},
+				},
+			},
+			matcher: gomega.MatchError(nil),
+		},
+		"circuit breaker of a node step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{NodeName: "node1"},
+							CircuitBreaker:  &StepCircuitBreaker{},
+						},
+					},
+				},
+				"node1": {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{InferenceTarget: InferenceTarget{ServiceName: "service1"}},
+					},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(NodeStepResilienceError, 0, "step1", GraphRootNodeName, "foo-bar")),
+		},
+		"backoff max delay below initial delay": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Retries:         proto.Int32(3),
+							Backoff:         &StepBackoff{InitialDelayMilliseconds: proto.Int64(500), MaxDelayMilliseconds: proto.Int64(100)},
+						},
+					},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidBackoffError, 0, "step1", GraphRootNodeName, "foo-bar")),
+		},
 	}
 
 	for testName, scenario := range scenarios {
@@ -0,0 +1,268 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	"github.com/kserve/kserve/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// TemplateRendered is set when the template of a TemplatedInferenceService is found and rendered with its parameters
+	TemplateRendered apis.ConditionType = "TemplateRendered"
+)
+
+// TemplatedInferenceService Ready condition also mirrors the readiness of the expanded InferenceService
+var templatedInferenceServiceConditionSet = apis.NewLivingConditionSet(TemplateRendered, InferenceServiceReady)
+
+// InferenceServiceTemplate is a parameterized InferenceService offered by the platform team, the namespaces create
+// TemplatedInferenceServices referencing it instead of raw InferenceServices.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=inferenceservicetemplates,scope=Cluster,shortName=isvctemplate
+type InferenceServiceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              InferenceServiceTemplateSpec `json:"spec,omitempty"`
+}
+
+// InferenceServiceTemplateSpec defines the parameters and the InferenceService manifest of a template
+// +k8s:openapi-gen=true
+type InferenceServiceTemplateSpec struct {
+	// Parameters accepted by the template
+	// +optional
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+	// InferenceService manifest in yaml rendered as a Go template, the parameters are available as
+	// {{ .Parameters.<name> }} and the TemplatedInferenceService as {{ .Name }} and {{ .Namespace }}.
+	// Only the labels, annotations and spec of the rendered manifest are used.
+	Template string `json:"template"`
+}
+
+// TemplateParameter defines a parameter of an InferenceServiceTemplate
+// +k8s:openapi-gen=true
+type TemplateParameter struct {
+	// Name of the parameter
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Default value of the parameter, the parameter is required when it has no default
+	// +optional
+	Default *string `json:"default,omitempty"`
+	// Values accepted for the parameter, e.g. the size tiers offered by the platform, any value is accepted when empty
+	// +optional
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// Regular expression the value has to match, e.g. the storage uri prefixes of the platform
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// InferenceServiceTemplateList contains a list of InferenceServiceTemplate
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type InferenceServiceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=set
+	Items []InferenceServiceTemplate `json:"items"`
+}
+
+// TemplatedInferenceService expands an InferenceServiceTemplate with its parameters into the InferenceService of the
+// same name.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".spec.templateName"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=templatedinferenceservices,shortName=tisvc
+type TemplatedInferenceService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              TemplatedInferenceServiceSpec   `json:"spec,omitempty"`
+	Status            TemplatedInferenceServiceStatus `json:"status,omitempty"`
+}
+
+// TemplatedInferenceServiceSpec selects the template and its parameter values
+// +k8s:openapi-gen=true
+type TemplatedInferenceServiceSpec struct {
+	// Name of the InferenceServiceTemplate
+	TemplateName string `json:"templateName"`
+	// Values of the template parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// TemplatedInferenceServiceStatus defines the TemplatedInferenceService conditions and status
+// +k8s:openapi-gen=true
+type TemplatedInferenceServiceStatus struct {
+	// Conditions for TemplatedInferenceService
+	duckv1.Status `json:",inline"`
+	// Url of the expanded InferenceService
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+}
+
+// TemplatedInferenceServiceList contains a list of TemplatedInferenceService
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type TemplatedInferenceServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=set
+	Items []TemplatedInferenceService `json:"items"`
+}
+
+// templateData is the data the template is executed with
+type templateData struct {
+	Name       string
+	Namespace  string
+	Parameters map[string]string
+}
+
+// Validate returns the first invalid parameter definition or template syntax error
+func (s *InferenceServiceTemplateSpec) Validate() error {
+	names := map[string]bool{}
+	for _, parameter := range s.Parameters {
+		if parameter.Name == "" {
+			return fmt.Errorf("parameter name is required")
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("duplicate parameter %q", parameter.Name)
+		}
+		names[parameter.Name] = true
+		if parameter.Pattern != "" {
+			if _, err := regexp.Compile(parameter.Pattern); err != nil {
+				return fmt.Errorf("invalid pattern of parameter %q: %v", parameter.Name, err)
+			}
+		}
+		if parameter.Default != nil {
+			if err := parameter.validateValue(*parameter.Default); err != nil {
+				return fmt.Errorf("invalid default of parameter %q: %v", parameter.Name, err)
+			}
+		}
+	}
+	if _, err := template.New("template").Option("missingkey=error").Parse(s.Template); err != nil {
+		return fmt.Errorf("invalid template: %v", err)
+	}
+	return nil
+}
+
+// Render validates the parameter values against the template parameters and executes the template, the defaults are
+// applied to the missing parameters.
+func (s *InferenceServiceTemplateSpec) Render(name string, namespace string, values map[string]string) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	parameters := map[string]string{}
+	for _, parameter := range s.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok {
+			if parameter.Default == nil {
+				return nil, fmt.Errorf("missing required parameter %q", parameter.Name)
+			}
+			value = *parameter.Default
+		}
+		if err := parameter.validateValue(value); err != nil {
+			return nil, fmt.Errorf("invalid parameter %q: %v", parameter.Name, err)
+		}
+		parameters[parameter.Name] = value
+	}
+	for key := range values {
+		if _, ok := parameters[key]; !ok {
+			return nil, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+	tmpl, err := template.New("template").Option("missingkey=error").Parse(s.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, templateData{Name: name, Namespace: namespace, Parameters: parameters}); err != nil {
+		return nil, fmt.Errorf("failed to render template: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *TemplateParameter) validateValue(value string) error {
+	if len(p.AllowedValues) != 0 && !utils.Includes(p.AllowedValues, value) {
+		return fmt.Errorf("value %q is not one of %v", value, p.AllowedValues)
+	}
+	if p.Pattern != "" {
+		matched, err := regexp.MatchString(p.Pattern, value)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return fmt.Errorf("value %q does not match %q", value, p.Pattern)
+		}
+	}
+	return nil
+}
+
+// InitializeConditions sets the TemplatedInferenceService conditions to Unknown
+func (s *TemplatedInferenceServiceStatus) InitializeConditions() {
+	templatedInferenceServiceConditionSet.Manage(s).InitializeConditions()
+}
+
+// MarkTemplateRendered sets the TemplateRendered condition to True
+func (s *TemplatedInferenceServiceStatus) MarkTemplateRendered() {
+	templatedInferenceServiceConditionSet.Manage(s).MarkTrue(TemplateRendered)
+}
+
+// MarkTemplateFailed sets the TemplateRendered condition to False
+func (s *TemplatedInferenceServiceStatus) MarkTemplateFailed(reason string, message string) {
+	templatedInferenceServiceConditionSet.Manage(s).MarkFalse(TemplateRendered, reason, "%s", message)
+}
+
+// PropagateInferenceServiceStatus mirrors the readiness and url of the expanded InferenceService
+func (s *TemplatedInferenceServiceStatus) PropagateInferenceServiceStatus(ready *apis.Condition, url *apis.URL) {
+	s.URL = url
+	switch {
+	case ready == nil:
+		templatedInferenceServiceConditionSet.Manage(s).MarkUnknown(InferenceServiceReady, "", "")
+	case ready.IsTrue():
+		templatedInferenceServiceConditionSet.Manage(s).MarkTrue(InferenceServiceReady)
+	case ready.IsFalse():
+		templatedInferenceServiceConditionSet.Manage(s).MarkFalse(InferenceServiceReady, ready.Reason, "%s", ready.Message)
+	default:
+		templatedInferenceServiceConditionSet.Manage(s).MarkUnknown(InferenceServiceReady, ready.Reason, "%s", ready.Message)
+	}
+}
+
+// IsReady returns true when the template is rendered and the InferenceService is ready
+func (s *TemplatedInferenceServiceStatus) IsReady() bool {
+	return templatedInferenceServiceConditionSet.Manage(s).IsHappy()
+}
+
+func init() {
+	SchemeBuilder.Register(&InferenceServiceTemplate{}, &InferenceServiceTemplateList{},
+		&TemplatedInferenceService{}, &TemplatedInferenceServiceList{})
+}
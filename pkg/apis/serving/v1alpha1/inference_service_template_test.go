@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"knative.dev/pkg/apis"
+)
+
+func newTemplateSpec() *InferenceServiceTemplateSpec {
+	size := "small"
+	return &InferenceServiceTemplateSpec{
+		Parameters: []TemplateParameter{
+			{Name: "storageUri", Pattern: "^s3://models/"},
+			{Name: "size", Default: &size, AllowedValues: []string{"small", "large"}},
+		},
+		Template: `metadata:
+  labels:
+    owner: {{ .Namespace }}
+spec:
+  predictor:
+    sklearn:
+      storageUri: {{ .Parameters.storageUri }}
+      resources:
+        limits:
+          cpu: {{ if eq .Parameters.size "large" }}"4"{{ else }}"1"{{ end }}
+`,
+	}
+}
+
+func TestInferenceServiceTemplateValidate(t *testing.T) {
+	invalidDefault := "medium"
+	scenarios := map[string]struct {
+		update  func(spec *InferenceServiceTemplateSpec)
+		matcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			update:  func(spec *InferenceServiceTemplateSpec) {},
+			matcher: gomega.BeNil(),
+		},
+		"DuplicateParameter": {
+			update: func(spec *InferenceServiceTemplateSpec) {
+				spec.Parameters = append(spec.Parameters, TemplateParameter{Name: "size"})
+			},
+			matcher: gomega.MatchError(`duplicate parameter "size"`),
+		},
+		"InvalidPattern": {
+			update: func(spec *InferenceServiceTemplateSpec) {
+				spec.Parameters[0].Pattern = "("
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"InvalidDefault": {
+			update: func(spec *InferenceServiceTemplateSpec) {
+				spec.Parameters[1].Default = &invalidDefault
+			},
+			matcher: gomega.MatchError(`invalid default of parameter "size": value "medium" is not one of [small large]`),
+		},
+		"InvalidTemplate": {
+			update: func(spec *InferenceServiceTemplateSpec) {
+				spec.Template = "{{ .Parameters.size"
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := newTemplateSpec()
+			scenario.update(spec)
+			g.Expect(spec.Validate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestInferenceServiceTemplateRender(t *testing.T) {
+	scenarios := map[string]struct {
+		values      map[string]string
+		expected    string
+		expectedErr string
+	}{
+		"Defaults": {
+			values: map[string]string{"storageUri": "s3://models/iris"},
+			expected: `metadata:
+  labels:
+    owner: team-a
+spec:
+  predictor:
+    sklearn:
+      storageUri: s3://models/iris
+      resources:
+        limits:
+          cpu: "1"
+`,
+		},
+		"Values": {
+			values: map[string]string{"storageUri": "s3://models/iris", "size": "large"},
+			expected: `metadata:
+  labels:
+    owner: team-a
+spec:
+  predictor:
+    sklearn:
+      storageUri: s3://models/iris
+      resources:
+        limits:
+          cpu: "4"
+`,
+		},
+		"MissingRequired": {
+			values:      map[string]string{},
+			expectedErr: `missing required parameter "storageUri"`,
+		},
+		"NotAllowed": {
+			values:      map[string]string{"storageUri": "s3://models/iris", "size": "huge"},
+			expectedErr: `invalid parameter "size": value "huge" is not one of [small large]`,
+		},
+		"PatternMismatch": {
+			values:      map[string]string{"storageUri": "gs://other/iris"},
+			expectedErr: `invalid parameter "storageUri": value "gs://other/iris" does not match "^s3://models/"`,
+		},
+		"UnknownParameter": {
+			values:      map[string]string{"storageUri": "s3://models/iris", "gpu": "1"},
+			expectedErr: `unknown parameter "gpu"`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			rendered, err := newTemplateSpec().Render("iris", "team-a", scenario.values)
+			if scenario.expectedErr != "" {
+				g.Expect(err).To(gomega.MatchError(scenario.expectedErr))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(string(rendered)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestTemplatedInferenceServiceStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &TemplatedInferenceServiceStatus{}
+	status.InitializeConditions()
+	g.Expect(status.IsReady()).To(gomega.BeFalse())
+
+	status.MarkTemplateRendered()
+	status.PropagateInferenceServiceStatus(nil, nil)
+	g.Expect(status.IsReady()).To(gomega.BeFalse())
+
+	url := &apis.URL{Scheme: "http", Host: "iris.team-a.example.com"}
+	status.PropagateInferenceServiceStatus(&apis.Condition{Type: apis.ConditionReady, Status: "True"}, url)
+	g.Expect(status.IsReady()).To(gomega.BeTrue())
+	g.Expect(status.URL).To(gomega.Equal(url))
+
+	status.MarkTemplateFailed("TemplateNotFound", "not found")
+	g.Expect(status.IsReady()).To(gomega.BeFalse())
+	g.Expect(status.GetCondition(apis.ConditionReady).Reason).To(gomega.Equal("TemplateNotFound"))
+}
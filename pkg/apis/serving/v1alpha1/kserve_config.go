@@ -0,0 +1,799 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/debugcontainer"
+	"github.com/kserve/kserve/pkg/fips"
+	"github.com/kserve/kserve/pkg/validationpolicy"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// KServeConfigName is the name of the KServeConfig honored by the controller, it lives in the KServe namespace
+	// and renders the inferenceservice-config ConfigMap.
+	KServeConfigName = "kserve"
+
+	// ConfigMap keys rendered from the KServeConfig sections
+	ExplainersConfigMapKey         = "explainers"
+	StorageInitializerConfigMapKey = "storageInitializer"
+	ImageRegistryConfigMapKey      = "imageRegistry"
+	CredentialsConfigMapKey        = "credentials"
+	IngressConfigMapKey            = "ingress"
+	LoggerConfigMapKey             = "logger"
+	BatcherConfigMapKey            = "batcher"
+	AgentConfigMapKey              = "agent"
+	RouterConfigMapKey             = "router"
+	DeployConfigMapKey             = "deploy"
+	MetricsAggregatorConfigMapKey  = "metricsAggregator"
+	LogRoutingConfigMapKey         = "logRouting"
+
+	// ConfigValid is set when the KServeConfig spec passes validation and can be rendered
+	ConfigValid apis.ConditionType = "ConfigValid"
+
+	defaultDomainTemplate = "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"
+	defaultIngressDomain  = "example.com"
+	defaultUrlScheme      = "http"
+)
+
+// KServeConfig is the typed and validated form of the inferenceservice-config ConfigMap, the controller
+// renders the ConfigMap from the KServeConfig named "kserve" in the KServe namespace.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=kserveconfigs,singular=kserveconfig
+type KServeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              KServeConfigSpec   `json:"spec,omitempty"`
+	Status            KServeConfigStatus `json:"status,omitempty"`
+}
+
+// KServeConfigSpec defines the KServe configuration, each section is rendered as the ConfigMap key of the same name
+// +k8s:openapi-gen=true
+type KServeConfigSpec struct {
+	// Explainer images keyed by explainer type
+	// +optional
+	Explainers map[string]ExplainerConfigSpec `json:"explainers,omitempty"`
+	// Storage initializer container configuration
+	// +optional
+	StorageInitializer *StorageInitializerConfigSpec `json:"storageInitializer,omitempty"`
+	// Internal mirrors of the public image registries
+	// +optional
+	ImageRegistry *ImageRegistryConfigSpec `json:"imageRegistry,omitempty"`
+	// Storage credentials configuration
+	// +optional
+	Credentials *CredentialsConfigSpec `json:"credentials,omitempty"`
+	// Ingress configuration
+	// +optional
+	Ingress *IngressConfigSpec `json:"ingress,omitempty"`
+	// Logger container configuration
+	// +optional
+	Logger *LoggerConfigSpec `json:"logger,omitempty"`
+	// Batcher container configuration
+	// +optional
+	Batcher *ContainerConfigSpec `json:"batcher,omitempty"`
+	// Agent container configuration
+	// +optional
+	Agent *AgentConfigSpec `json:"agent,omitempty"`
+	// InferenceGraph router configuration
+	// +optional
+	Router *RouterConfigSpec `json:"router,omitempty"`
+	// Deployment configuration
+	// +optional
+	Deploy *DeployConfigSpec `json:"deploy,omitempty"`
+	// Metrics aggregation configuration
+	// +optional
+	MetricsAggregator *MetricsAggregatorConfigSpec `json:"metricsAggregator,omitempty"`
+	// Log routing metadata of the pods of the InferenceServices
+	// +optional
+	LogRouting *LogRoutingConfigSpec `json:"logRouting,omitempty"`
+}
+
+// ExplainerConfigSpec defines the image of an explainer
+// +k8s:openapi-gen=true
+type ExplainerConfigSpec struct {
+	// Explainer docker image name
+	Image string `json:"image"`
+	// Default explainer docker image version
+	DefaultImageVersion string `json:"defaultImageVersion"`
+}
+
+// ResourceConfigSpec defines the resources of an injected container
+// +k8s:openapi-gen=true
+type ResourceConfigSpec struct {
+	// +optional
+	CpuRequest string `json:"cpuRequest,omitempty"`
+	// +optional
+	CpuLimit string `json:"cpuLimit,omitempty"`
+	// +optional
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	// +optional
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+}
+
+// ContainerConfigSpec defines the image and resources of an injected container
+// +k8s:openapi-gen=true
+type ContainerConfigSpec struct {
+	Image              string `json:"image"`
+	ResourceConfigSpec `json:",inline"`
+}
+
+// StorageInitializerConfigSpec defines the storage initializer container
+// +k8s:openapi-gen=true
+type StorageInitializerConfigSpec struct {
+	ContainerConfigSpec `json:",inline"`
+	// Name of the secret holding the storage spec configuration
+	// +optional
+	StorageSpecSecretName string `json:"storageSpecSecretName,omitempty"`
+	// Storage uri prefixes allowed for the inference services, all the schemes are allowed when empty
+	// +optional
+	AllowedUriSchemes []string `json:"allowedUriSchemes,omitempty"`
+	// Number of concurrent downloads of the large models from S3 and GCS
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DownloadWorkers int32 `json:"downloadWorkers,omitempty"`
+	// Broker handing out the short lived presigned urls of the model files, instead of the storage credentials being
+	// mounted in every namespace, disabled when not set
+	// +optional
+	PresignBroker *PresignBrokerConfigSpec `json:"presignBroker,omitempty"`
+}
+
+// PresignBrokerConfigSpec defines the presign broker the storage initializer fetches the presigned urls of the models
+// from, authenticated with mTLS and a service account token bound to the audience of the broker
+// +k8s:openapi-gen=true
+type PresignBrokerConfigSpec struct {
+	// URL of the presign endpoint of the broker, must be an https url
+	URL string `json:"url"`
+	// Storage uri prefixes fetched through the broker, defaults to ["s3://", "gs://"]
+	// +optional
+	UriSchemes []string `json:"uriSchemes,omitempty"`
+	// Name of the secret of the InferenceService namespace holding the tls.crt, tls.key and ca.crt of the client
+	// certificate
+	// +optional
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+	// Audience of the service account token identifying the InferenceService to the broker, defaults to
+	// kserve-presign-broker
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// ImageRegistryConfigSpec maps the public image registries to internal mirrors
+// +k8s:openapi-gen=true
+type ImageRegistryConfigSpec struct {
+	// +optional
+	Mirrors map[string]string `json:"mirrors,omitempty"`
+}
+
+// CredentialsConfigSpec defines the storage credentials configuration
+// +k8s:openapi-gen=true
+type CredentialsConfigSpec struct {
+	// +optional
+	GCS *GCSCredentialsConfigSpec `json:"gcs,omitempty"`
+	// +optional
+	S3 *S3CredentialsConfigSpec `json:"s3,omitempty"`
+}
+
+// GCSCredentialsConfigSpec defines the GCS credentials configuration
+// +k8s:openapi-gen=true
+type GCSCredentialsConfigSpec struct {
+	// +optional
+	GCSCredentialFileName string `json:"gcsCredentialFileName,omitempty"`
+}
+
+// S3CredentialsConfigSpec defines the S3 credentials configuration
+// +k8s:openapi-gen=true
+type S3CredentialsConfigSpec struct {
+	// +optional
+	S3AccessKeyIDName string `json:"s3AccessKeyIDName,omitempty"`
+	// +optional
+	S3SecretAccessKeyName string `json:"s3SecretAccessKeyName,omitempty"`
+	// +optional
+	S3Endpoint string `json:"s3Endpoint,omitempty"`
+	// +optional
+	S3UseHttps string `json:"s3UseHttps,omitempty"`
+	// +optional
+	S3Region string `json:"s3Region,omitempty"`
+	// +optional
+	S3VerifySSL string `json:"s3VerifySSL,omitempty"`
+	// +optional
+	S3UseVirtualBucket string `json:"s3UseVirtualBucket,omitempty"`
+	// +optional
+	S3UseAnonymousCredential string `json:"s3UseAnonymousCredential,omitempty"`
+	// +optional
+	S3CABundle string `json:"s3CABundle,omitempty"`
+	// Name of the ConfigMap of the InferenceService namespace holding the CA bundle of the S3 endpoint under its
+	// cabundle.crt key, mounted in the storage initializer and the agent
+	// +optional
+	S3CABundleConfigMap string `json:"s3CABundleConfigMap,omitempty"`
+}
+
+// IngressConfigSpec defines the ingress configuration
+// +k8s:openapi-gen=true
+type IngressConfigSpec struct {
+	IngressGateway     string `json:"ingressGateway"`
+	IngressServiceName string `json:"ingressService"`
+	// +optional
+	LocalGateway string `json:"localGateway,omitempty"`
+	// +optional
+	LocalGatewayServiceName string `json:"localGatewayService,omitempty"`
+	// +optional
+	IngressDomain string `json:"ingressDomain,omitempty"`
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// +optional
+	DomainTemplate string `json:"domainTemplate,omitempty"`
+	// +optional
+	GlobalDomainTemplate string `json:"globalDomainTemplate,omitempty"`
+	// Template of the external hosts of the inference services in Serverless mode, e.g.
+	// {{ .Name }}-{{ .Namespace }}.models.example.com, the hosts of the Knative services by default
+	// +optional
+	HostTemplate string `json:"hostTemplate,omitempty"`
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	UrlScheme string `json:"urlScheme,omitempty"`
+	// +optional
+	DisableIstioVirtualHost bool `json:"disableIstioVirtualHost,omitempty"`
+	// +optional
+	EnableGatewayAPI bool `json:"enableGatewayApi,omitempty"`
+	// +optional
+	KserveIngressGateway string `json:"kserveIngressGateway,omitempty"`
+	// Gateways the inference services can select with the serving.kserve.io/ingress-gateway annotation besides the
+	// ingressGateway and the kserveIngressGateway, referenced as <namespace>/<name>
+	// +optional
+	AllowedIngressGateways []string `json:"allowedIngressGateways,omitempty"`
+	// Ingress classes the inference services are also exposed through in RawDeployment mode, one ingress per class
+	// +optional
+	AdditionalIngressClasses []IngressClassConfigSpec `json:"additionalIngressClasses,omitempty"`
+
+	// CORS policy of the routes of the virtual services, the browsers can call the inference services directly
+	// +optional
+	CorsPolicy *CorsPolicySpec `json:"corsPolicy,omitempty"`
+	// gRPC routes of the virtual services, the gRPC requests are routed to the predictors over HTTP/2
+	// +optional
+	GrpcRoutes *GrpcRoutesSpec `json:"grpcRoutes,omitempty"`
+	// TLS of the ingresses of the inference services in RawDeployment mode, the certificates of the hosts are issued
+	// by cert-manager
+	// +optional
+	Tls *IngressTlsSpec `json:"tls,omitempty"`
+}
+
+// IngressTlsSpec defines the TLS of the raw deployment ingresses
+// +k8s:openapi-gen=true
+type IngressTlsSpec struct {
+	// Name of the cert-manager ClusterIssuer issuing the certificates, a Certificate is created per ingress and its
+	// secret is set in the tls block of the ingress
+	ClusterIssuer string `json:"clusterIssuer"`
+	// Whether the HTTP requests are redirected to HTTPS, set with the force-ssl-redirect annotation of ingress-nginx
+	// +optional
+	SslRedirect bool `json:"sslRedirect,omitempty"`
+}
+
+// GrpcRoutesSpec defines the gRPC routes of the inference services, e.g. to the gRPC endpoints of Triton or vLLM
+// +k8s:openapi-gen=true
+type GrpcRoutesSpec struct {
+	// Port of the local gateway the gRPC requests are sent to, the port must be declared with the http2 or grpc
+	// protocol so that the requests are upgraded to HTTP/2, defaults to 80
+	// +optional
+	LocalGatewayPort int32 `json:"localGatewayPort,omitempty"`
+	// Whether the gRPC routes are also published under the grpc-<host> subdomain of the inference services
+	// +optional
+	EnableSubdomain bool `json:"enableSubdomain,omitempty"`
+}
+
+// CorsPolicySpec defines the CORS policy of the inference services
+// +k8s:openapi-gen=true
+type CorsPolicySpec struct {
+	// Origins allowed to call the inference services, "*" allows any origin
+	// +optional
+	AllowOrigins []string `json:"allowOrigins,omitempty"`
+	// Methods allowed in the requests
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// Headers allowed in the requests
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// Headers of the responses the browsers expose
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// Seconds the browsers cache the result of the preflight requests
+	// +optional
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+	// Whether the requests with credentials are allowed
+	// +optional
+	AllowCredentials *bool `json:"allowCredentials,omitempty"`
+}
+
+// IngressClassConfigSpec defines an additional ingress class, e.g. an internal nginx next to an external ALB
+// +k8s:openapi-gen=true
+type IngressClassConfigSpec struct {
+	// Name of the ingress class
+	IngressClassName string `json:"ingressClassName"`
+	// Domain of the hosts of the ingress, the ingress domain of the ingress config by default
+	// +optional
+	IngressDomain string `json:"ingressDomain,omitempty"`
+	// Template of the hosts of the ingress, the domain template of the ingress config by default
+	// +optional
+	DomainTemplate string `json:"domainTemplate,omitempty"`
+	// Annotations of the ingress, e.g. the scheme of the load balancer
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// LoggerConfigSpec defines the logger container
+// +k8s:openapi-gen=true
+type LoggerConfigSpec struct {
+	ContainerConfigSpec `json:",inline"`
+	// Default url of the logger sink
+	// +optional
+	DefaultUrl string `json:"defaultUrl,omitempty"`
+}
+
+// AgentConfigSpec defines the agent container
+// +k8s:openapi-gen=true
+type AgentConfigSpec struct {
+	ContainerConfigSpec `json:",inline"`
+	// Minimum TLS version of the outgoing connections of the agent, e.g. "1.2"
+	// +optional
+	TLSMinVersion string `json:"tlsMinVersion,omitempty"`
+	// Comma separated TLS cipher suites of the outgoing connections of the agent
+	// +optional
+	TLSCipherSuites string `json:"tlsCipherSuites,omitempty"`
+	// s3://<bucket>/<prefix> location the model puller caches the downloaded models in, shared across clusters
+	// +optional
+	CacheStorageURI string `json:"cacheStorageUri,omitempty"`
+	// Duration the cached models are kept for since they were last used, e.g. "168h"
+	// +optional
+	CacheMaxAge string `json:"cacheMaxAge,omitempty"`
+}
+
+// RouterConfigSpec defines the InferenceGraph router container
+// +k8s:openapi-gen=true
+type RouterConfigSpec struct {
+	ContainerConfigSpec `json:",inline"`
+	// Headers propagated by the router, e.g. {"propagate": ["Authorization"]}
+	// +optional
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// DeployConfigSpec defines the default deployment mode of the inference services
+// +k8s:openapi-gen=true
+type DeployConfigSpec struct {
+	// +kubebuilder:validation:Enum=Serverless;RawDeployment;ModelMesh
+	// +optional
+	DefaultDeploymentMode string `json:"defaultDeploymentMode,omitempty"`
+}
+
+// MetricsAggregatorConfigSpec defines the metrics aggregation defaults
+// +k8s:openapi-gen=true
+type MetricsAggregatorConfigSpec struct {
+	// +optional
+	EnableMetricAggregation string `json:"enableMetricAggregation,omitempty"`
+	// +optional
+	EnablePrometheusScraping string `json:"enablePrometheusScraping,omitempty"`
+	// PodMonitors of the Prometheus operator scraping the agent and model server metrics of the InferenceServices
+	// +optional
+	PodMonitor *PodMonitorConfigSpec `json:"podMonitor,omitempty"`
+}
+
+// PodMonitorConfigSpec defines the PodMonitors the controller creates when the Prometheus operator CRDs are installed
+// +k8s:openapi-gen=true
+type PodMonitorConfigSpec struct {
+	// Creates the PodMonitors of the InferenceServices
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// InferenceService creates a PodMonitor per InferenceService, Namespace a PodMonitor per namespace selecting the
+	// pods of all its InferenceServices, defaults to InferenceService
+	// +optional
+	Scope string `json:"scope,omitempty"`
+	// Labels of the PodMonitors, matched by the podMonitorSelector of the Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Scrape interval of the metrics, e.g. 30s, the scrape interval of the Prometheus when empty
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// LogRoutingConfigSpec defines the annotations and labels stamped on the pods of the InferenceServices so the log
+// shippers, e.g. fluent-bit or datadog, parse their logs
+// +k8s:openapi-gen=true
+type LogRoutingConfigSpec struct {
+	// Metadata of the pods of all the components
+	// +optional
+	Default *PodMetadataConfigSpec `json:"default,omitempty"`
+	// Metadata of the predictor pods keyed by the name of their ServingRuntime or ClusterServingRuntime, it is
+	// merged over the default
+	// +optional
+	Runtimes map[string]PodMetadataConfigSpec `json:"runtimes,omitempty"`
+}
+
+// PodMetadataConfigSpec defines the annotations and labels of the pods
+// +k8s:openapi-gen=true
+type PodMetadataConfigSpec struct {
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// KServeConfigStatus defines the KServeConfig conditions
+// +k8s:openapi-gen=true
+type KServeConfigStatus struct {
+	// Conditions for KServeConfig
+	duckv1.Status `json:",inline"`
+}
+
+// KServeConfigList contains a list of KServeConfig
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type KServeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=set
+	Items []KServeConfig `json:"items"`
+}
+
+// Default sets the defaults applied by the ConfigMap consumers so the rendered configuration is explicit
+func (s *KServeConfigSpec) Default() {
+	if s.Ingress != nil {
+		if s.Ingress.DomainTemplate == "" {
+			s.Ingress.DomainTemplate = defaultDomainTemplate
+		}
+		if s.Ingress.IngressDomain == "" {
+			s.Ingress.IngressDomain = defaultIngressDomain
+		}
+		if s.Ingress.UrlScheme == "" {
+			s.Ingress.UrlScheme = defaultUrlScheme
+		}
+	}
+	if s.Deploy != nil && s.Deploy.DefaultDeploymentMode == "" {
+		s.Deploy.DefaultDeploymentMode = string(constants.Serverless)
+	}
+}
+
+// validateCorsOrigin returns an error unless the origin is "*" or a http or https origin without a path
+func validateCorsOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+		return fmt.Errorf("invalid CORS origin %q, must be * or a http or https origin, e.g. https://example.com", origin)
+	}
+	return nil
+}
+
+// Validate returns the first invalid setting of the spec
+func (s *KServeConfigSpec) Validate() error {
+	for name, explainer := range s.Explainers {
+		if explainer.Image == "" {
+			return fmt.Errorf("invalid %s config: image is required for explainer %q", ExplainersConfigMapKey, name)
+		}
+	}
+	if s.Ingress != nil {
+		if s.Ingress.IngressGateway == "" || s.Ingress.IngressServiceName == "" {
+			return fmt.Errorf("invalid %s config: ingressGateway and ingressService are required", IngressConfigMapKey)
+		}
+		if s.Ingress.UrlScheme != "" && s.Ingress.UrlScheme != "http" && s.Ingress.UrlScheme != "https" {
+			return fmt.Errorf("invalid %s config: unsupported urlScheme %q", IngressConfigMapKey, s.Ingress.UrlScheme)
+		}
+		if s.Ingress.HostTemplate != "" {
+			if _, err := template.New("host-template").Parse(s.Ingress.HostTemplate); err != nil {
+				return fmt.Errorf("invalid %s config: invalid hostTemplate: %v", IngressConfigMapKey, err)
+			}
+		}
+		if s.Ingress.EnableGatewayAPI && s.Ingress.KserveIngressGateway == "" {
+			return fmt.Errorf("invalid %s config: kserveIngressGateway is required when enableGatewayApi is set",
+				IngressConfigMapKey)
+		}
+		for _, gateway := range s.Ingress.AllowedIngressGateways {
+			if parts := strings.Split(gateway, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("invalid %s config: allowed ingress gateway %q is not a <namespace>/<name> reference",
+					IngressConfigMapKey, gateway)
+			}
+		}
+		classes := map[string]bool{}
+		if s.Ingress.IngressClassName != nil {
+			classes[*s.Ingress.IngressClassName] = true
+		}
+		for _, class := range s.Ingress.AdditionalIngressClasses {
+			if class.IngressClassName == "" {
+				return fmt.Errorf("invalid %s config: ingressClassName is required for the additional ingress classes",
+					IngressConfigMapKey)
+			}
+			if classes[class.IngressClassName] {
+				return fmt.Errorf("invalid %s config: duplicate ingress class %q", IngressConfigMapKey,
+					class.IngressClassName)
+			}
+			classes[class.IngressClassName] = true
+		}
+		if cors := s.Ingress.CorsPolicy; cors != nil {
+			for _, origin := range cors.AllowOrigins {
+				if err := validateCorsOrigin(origin); err != nil {
+					return fmt.Errorf("invalid %s config: %v", IngressConfigMapKey, err)
+				}
+				if origin == "*" && cors.AllowCredentials != nil && *cors.AllowCredentials {
+					return fmt.Errorf("invalid %s config: allowCredentials requires explicit allowOrigins",
+						IngressConfigMapKey)
+				}
+			}
+			if cors.MaxAgeSeconds != nil && *cors.MaxAgeSeconds < 0 {
+				return fmt.Errorf("invalid %s config: maxAgeSeconds must not be negative", IngressConfigMapKey)
+			}
+		}
+		if grpc := s.Ingress.GrpcRoutes; grpc != nil && (grpc.LocalGatewayPort < 0 || grpc.LocalGatewayPort > 65535) {
+			return fmt.Errorf("invalid %s config: invalid localGatewayPort %d", IngressConfigMapKey,
+				grpc.LocalGatewayPort)
+		}
+		if tls := s.Ingress.Tls; tls != nil && tls.ClusterIssuer == "" {
+			return fmt.Errorf("invalid %s config: the clusterIssuer of the tls is required", IngressConfigMapKey)
+		}
+	}
+	if s.Deploy != nil {
+		switch constants.DeploymentModeType(s.Deploy.DefaultDeploymentMode) {
+		case "", constants.Serverless, constants.RawDeployment, constants.ModelMeshDeployment:
+		default:
+			return fmt.Errorf("invalid %s config: unsupported defaultDeploymentMode %q", DeployConfigMapKey,
+				s.Deploy.DefaultDeploymentMode)
+		}
+	}
+	if s.MetricsAggregator != nil && s.MetricsAggregator.PodMonitor != nil {
+		podMonitor := s.MetricsAggregator.PodMonitor
+		switch podMonitor.Scope {
+		case "", constants.PodMonitorScopeInferenceService, constants.PodMonitorScopeNamespace:
+		default:
+			return fmt.Errorf("invalid %s config: unsupported podMonitor scope %q, must be %s or %s",
+				MetricsAggregatorConfigMapKey, podMonitor.Scope, constants.PodMonitorScopeInferenceService,
+				constants.PodMonitorScopeNamespace)
+		}
+		if podMonitor.Interval != "" {
+			if _, err := time.ParseDuration(podMonitor.Interval); err != nil {
+				return fmt.Errorf("invalid %s config: podMonitor interval %v", MetricsAggregatorConfigMapKey, err)
+			}
+		}
+	}
+	if s.LogRouting != nil {
+		if s.LogRouting.Default != nil {
+			if err := s.LogRouting.Default.validate(); err != nil {
+				return fmt.Errorf("invalid %s config: default %v", LogRoutingConfigMapKey, err)
+			}
+		}
+		for runtime, metadata := range s.LogRouting.Runtimes {
+			if err := metadata.validate(); err != nil {
+				return fmt.Errorf("invalid %s config: runtime %q %v", LogRoutingConfigMapKey, runtime, err)
+			}
+		}
+	}
+	if s.ImageRegistry != nil {
+		for registry, mirror := range s.ImageRegistry.Mirrors {
+			if mirror == "" {
+				return fmt.Errorf("invalid %s config: empty mirror for registry %q", ImageRegistryConfigMapKey, registry)
+			}
+		}
+	}
+	containers := map[string]*ContainerConfigSpec{
+		BatcherConfigMapKey: s.Batcher,
+	}
+	if s.Agent != nil {
+		containers[AgentConfigMapKey] = &s.Agent.ContainerConfigSpec
+		if _, err := fips.NewTLSSettings(s.Agent.TLSMinVersion, s.Agent.TLSCipherSuites); err != nil {
+			return fmt.Errorf("invalid %s config: %v", AgentConfigMapKey, err)
+		}
+		if s.Agent.CacheStorageURI != "" && !strings.HasPrefix(s.Agent.CacheStorageURI, "s3://") {
+			return fmt.Errorf("invalid %s config: cacheStorageUri %q is not an s3:// uri", AgentConfigMapKey,
+				s.Agent.CacheStorageURI)
+		}
+		if s.Agent.CacheMaxAge != "" {
+			if _, err := time.ParseDuration(s.Agent.CacheMaxAge); err != nil {
+				return fmt.Errorf("invalid %s config: cacheMaxAge %v", AgentConfigMapKey, err)
+			}
+		}
+	}
+	if s.StorageInitializer != nil {
+		containers[StorageInitializerConfigMapKey] = &s.StorageInitializer.ContainerConfigSpec
+		if s.StorageInitializer.DownloadWorkers < 0 {
+			return fmt.Errorf("invalid %s config: downloadWorkers must not be negative", StorageInitializerConfigMapKey)
+		}
+		if broker := s.StorageInitializer.PresignBroker; broker != nil {
+			if brokerURL, err := url.Parse(broker.URL); err != nil || brokerURL.Scheme != "https" || brokerURL.Host == "" {
+				return fmt.Errorf("invalid %s config: presignBroker url %q is not an https url",
+					StorageInitializerConfigMapKey, broker.URL)
+			}
+		}
+	}
+	if s.Logger != nil {
+		containers[LoggerConfigMapKey] = &s.Logger.ContainerConfigSpec
+	}
+	if s.Router != nil {
+		containers[RouterConfigMapKey] = &s.Router.ContainerConfigSpec
+	}
+	for key, container := range containers {
+		if container == nil {
+			continue
+		}
+		if container.Image == "" {
+			return fmt.Errorf("invalid %s config: image is required", key)
+		}
+		if err := container.ResourceConfigSpec.validate(); err != nil {
+			return fmt.Errorf("invalid %s config: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func (m *PodMetadataConfigSpec) validate() error {
+	for key := range m.Annotations {
+		if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			return fmt.Errorf("annotation %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for key, value := range m.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("label %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("label %q value %q: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+func (r *ResourceConfigSpec) validate() error {
+	for name, quantity := range map[string]string{
+		"cpuRequest":    r.CpuRequest,
+		"cpuLimit":      r.CpuLimit,
+		"memoryRequest": r.MemoryRequest,
+		"memoryLimit":   r.MemoryLimit,
+	} {
+		if quantity == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("failed to parse %s %q: %v", name, quantity, err)
+		}
+	}
+	return nil
+}
+
+// ToConfigMapData renders the configured sections as the json values of the inferenceservice-config ConfigMap
+func (s *KServeConfigSpec) ToConfigMapData() (map[string]string, error) {
+	sections := map[string]interface{}{}
+	if s.Explainers != nil {
+		sections[ExplainersConfigMapKey] = s.Explainers
+	}
+	if s.StorageInitializer != nil {
+		sections[StorageInitializerConfigMapKey] = s.StorageInitializer
+	}
+	if s.ImageRegistry != nil {
+		sections[ImageRegistryConfigMapKey] = s.ImageRegistry
+	}
+	if s.Credentials != nil {
+		sections[CredentialsConfigMapKey] = s.Credentials
+	}
+	if s.Ingress != nil {
+		sections[IngressConfigMapKey] = s.Ingress
+	}
+	if s.Logger != nil {
+		sections[LoggerConfigMapKey] = s.Logger
+	}
+	if s.Batcher != nil {
+		sections[BatcherConfigMapKey] = s.Batcher
+	}
+	if s.Agent != nil {
+		sections[AgentConfigMapKey] = s.Agent
+	}
+	if s.Router != nil {
+		sections[RouterConfigMapKey] = s.Router
+	}
+	if s.Deploy != nil {
+		sections[DeployConfigMapKey] = s.Deploy
+	}
+	if s.MetricsAggregator != nil {
+		sections[MetricsAggregatorConfigMapKey] = s.MetricsAggregator
+	}
+	if s.LogRouting != nil {
+		sections[LogRoutingConfigMapKey] = s.LogRouting
+	}
+	data := map[string]string{}
+	for key, section := range sections {
+		b, err := json.MarshalIndent(section, "", "    ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s config: %v", key, err)
+		}
+		data[key] = string(b)
+	}
+	return data, nil
+}
+
+// KServeConfigSpecFromConfigMap converts an existing inferenceservice-config ConfigMap, keys which are not part of
+// the spec are ignored.
+func KServeConfigSpecFromConfigMap(configMap *v1.ConfigMap) (*KServeConfigSpec, error) {
+	return parseConfigMap(configMap, false)
+}
+
+// ValidateConfigMap strictly parses all the sections of the inferenceservice-config ConfigMap, unknown fields and
+// malformed json are rejected instead of silently yielding zero values, and validates the resulting configuration.
+// The validation policies and the debug container config, which are kept in the ConfigMap only, are parsed as well.
+func ValidateConfigMap(configMap *v1.ConfigMap) error {
+	spec, err := parseConfigMap(configMap, true)
+	if err != nil {
+		return err
+	}
+	spec.Default()
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if _, err := debugcontainer.ParseConfig(configMap.Data[debugcontainer.ConfigMapKey]); err != nil {
+		return err
+	}
+	_, err = validationpolicy.Parse(configMap.Data[validationpolicy.ConfigMapKey])
+	return err
+}
+
+func parseConfigMap(configMap *v1.ConfigMap, strict bool) (*KServeConfigSpec, error) {
+	spec := &KServeConfigSpec{}
+	targets := map[string]interface{}{
+		ExplainersConfigMapKey:         &spec.Explainers,
+		StorageInitializerConfigMapKey: &spec.StorageInitializer,
+		ImageRegistryConfigMapKey:      &spec.ImageRegistry,
+		CredentialsConfigMapKey:        &spec.Credentials,
+		IngressConfigMapKey:            &spec.Ingress,
+		LoggerConfigMapKey:             &spec.Logger,
+		BatcherConfigMapKey:            &spec.Batcher,
+		AgentConfigMapKey:              &spec.Agent,
+		RouterConfigMapKey:             &spec.Router,
+		DeployConfigMapKey:             &spec.Deploy,
+		MetricsAggregatorConfigMapKey:  &spec.MetricsAggregator,
+		LogRoutingConfigMapKey:         &spec.LogRouting,
+	}
+	for key, target := range targets {
+		value, ok := configMap.Data[key]
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewBufferString(value))
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+		if err := decoder.Decode(target); err != nil {
+			return nil, fmt.Errorf("unable to parse %s config json: %v", key, err)
+		}
+	}
+	return spec, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&KServeConfig{}, &KServeConfigList{})
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kserve/kserve/pkg/debugcontainer"
+	"github.com/kserve/kserve/pkg/validationpolicy"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestKServeConfigValidate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		spec    KServeConfigSpec
+		matcher gomega.OmegaMatcher
+	}{
+		"Empty": {
+			spec:    KServeConfigSpec{},
+			matcher: gomega.BeNil(),
+		},
+		"Valid": {
+			spec: KServeConfigSpec{
+				Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway", IngressServiceName: "istio-ingressgateway"},
+				Deploy:  &DeployConfigSpec{DefaultDeploymentMode: "RawDeployment"},
+				Agent: &AgentConfigSpec{
+					ContainerConfigSpec: ContainerConfigSpec{
+						Image:              "kserve/agent:latest",
+						ResourceConfigSpec: ResourceConfigSpec{CpuRequest: "100m", MemoryLimit: "1Gi"},
+					},
+					TLSMinVersion: "1.2",
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"MissingIngressGateway": {
+			spec:    KServeConfigSpec{Ingress: &IngressConfigSpec{IngressServiceName: "istio-ingressgateway"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("ingressGateway and ingressService are required")),
+		},
+		"MissingKserveIngressGateway": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", EnableGatewayAPI: true}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("kserveIngressGateway is required")),
+		},
+		"MissingAdditionalIngressClassName": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", AdditionalIngressClasses: []IngressClassConfigSpec{{}}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("ingressClassName is required")),
+		},
+		"DuplicateIngressClass": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", IngressClassName: proto.String("nginx"),
+				AdditionalIngressClasses: []IngressClassConfigSpec{{IngressClassName: "nginx"}}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("duplicate ingress class \"nginx\"")),
+		},
+		"CorsPolicy": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", CorsPolicy: &CorsPolicySpec{
+					AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: proto.Bool(true)}}},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidCorsOrigin": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", CorsPolicy: &CorsPolicySpec{
+					AllowOrigins: []string{"app.example.com"}}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid CORS origin \"app.example.com\"")),
+		},
+		"AnyCorsOriginWithCredentials": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", CorsPolicy: &CorsPolicySpec{
+					AllowOrigins: []string{"*"}, AllowCredentials: proto.Bool(true)}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("allowCredentials requires explicit allowOrigins")),
+		},
+		"InvalidHostTemplate": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", HostTemplate: "{{ .Name }-models.example.com"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid hostTemplate")),
+		},
+		"InvalidAllowedIngressGateway": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", AllowedIngressGateways: []string{"team-a-gateway"}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("allowed ingress gateway \"team-a-gateway\" is not")),
+		},
+		"InvalidGrpcLocalGatewayPort": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", GrpcRoutes: &GrpcRoutesSpec{LocalGatewayPort: 70000}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid localGatewayPort 70000")),
+		},
+		"MissingTlsClusterIssuer": {
+			spec: KServeConfigSpec{Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway",
+				IngressServiceName: "istio-ingressgateway", Tls: &IngressTlsSpec{SslRedirect: true}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("the clusterIssuer of the tls is required")),
+		},
+		"InvalidDeploymentMode": {
+			spec:    KServeConfigSpec{Deploy: &DeployConfigSpec{DefaultDeploymentMode: "Knative"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unsupported defaultDeploymentMode")),
+		},
+		"InvalidQuantity": {
+			spec: KServeConfigSpec{StorageInitializer: &StorageInitializerConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{
+					Image:              "kserve/storage-initializer:latest",
+					ResourceConfigSpec: ResourceConfigSpec{MemoryLimit: "1GB"},
+				},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("memoryLimit")),
+		},
+		"NegativeDownloadWorkers": {
+			spec: KServeConfigSpec{StorageInitializer: &StorageInitializerConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{Image: "kserve/storage-initializer:latest"},
+				DownloadWorkers:     -1,
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("downloadWorkers must not be negative")),
+		},
+		"PresignBrokerWithoutTLS": {
+			spec: KServeConfigSpec{StorageInitializer: &StorageInitializerConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{Image: "kserve/storage-initializer:latest"},
+				PresignBroker:       &PresignBrokerConfigSpec{URL: "http://presign-broker.kserve/presign"},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("is not an https url")),
+		},
+		"MissingImage": {
+			spec:    KServeConfigSpec{Batcher: &ContainerConfigSpec{}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("image is required")),
+		},
+		"InvalidAgentTLSVersion": {
+			spec: KServeConfigSpec{Agent: &AgentConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{Image: "kserve/agent:latest"},
+				TLSMinVersion:       "1.4",
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid agent config")),
+		},
+		"InvalidAgentCacheStorageURI": {
+			spec: KServeConfigSpec{Agent: &AgentConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{Image: "kserve/agent:latest"},
+				CacheStorageURI:     "gs://model-cache",
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("cacheStorageUri")),
+		},
+		"InvalidAgentCacheMaxAge": {
+			spec: KServeConfigSpec{Agent: &AgentConfigSpec{
+				ContainerConfigSpec: ContainerConfigSpec{Image: "kserve/agent:latest"},
+				CacheStorageURI:     "s3://model-cache",
+				CacheMaxAge:         "7d",
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("cacheMaxAge")),
+		},
+		"InvalidPodMonitorScope": {
+			spec: KServeConfigSpec{MetricsAggregator: &MetricsAggregatorConfigSpec{
+				PodMonitor: &PodMonitorConfigSpec{Enabled: true, Scope: "Cluster"},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unsupported podMonitor scope")),
+		},
+		"InvalidPodMonitorInterval": {
+			spec: KServeConfigSpec{MetricsAggregator: &MetricsAggregatorConfigSpec{
+				PodMonitor: &PodMonitorConfigSpec{Enabled: true, Interval: "30"},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("podMonitor interval")),
+		},
+		"LogRouting": {
+			spec: KServeConfigSpec{LogRouting: &LogRoutingConfigSpec{
+				Default: &PodMetadataConfigSpec{Annotations: map[string]string{"fluentbit.io/parser": "kserve"}},
+				Runtimes: map[string]PodMetadataConfigSpec{
+					"kserve-sklearnserver": {Labels: map[string]string{"logging.example.com/source": "sklearn"}},
+				},
+			}},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidLogRoutingAnnotation": {
+			spec: KServeConfigSpec{LogRouting: &LogRoutingConfigSpec{
+				Default: &PodMetadataConfigSpec{Annotations: map[string]string{"fluentbit.io/parser/": "kserve"}},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid logRouting config: default annotation")),
+		},
+		"InvalidLogRoutingLabelValue": {
+			spec: KServeConfigSpec{LogRouting: &LogRoutingConfigSpec{
+				Runtimes: map[string]PodMetadataConfigSpec{
+					"kserve-sklearnserver": {Labels: map[string]string{"logging.example.com/source": "sklearn server"}},
+				},
+			}},
+			matcher: gomega.MatchError(gomega.ContainSubstring(`runtime "kserve-sklearnserver" label`)),
+		},
+		"EmptyMirror": {
+			spec:    KServeConfigSpec{ImageRegistry: &ImageRegistryConfigSpec{Mirrors: map[string]string{"docker.io": ""}}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("empty mirror")),
+		},
+	}
+	for name, scenario := range scenarios {
+		g.Expect(scenario.spec.Validate()).To(scenario.matcher, name)
+	}
+}
+
+func TestKServeConfigDefault(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := KServeConfigSpec{
+		Ingress: &IngressConfigSpec{IngressGateway: "knative-serving/knative-ingress-gateway", IngressServiceName: "istio-ingressgateway"},
+		Deploy:  &DeployConfigSpec{},
+	}
+	spec.Default()
+	g.Expect(spec.Ingress.DomainTemplate).To(gomega.Equal(defaultDomainTemplate))
+	g.Expect(spec.Ingress.IngressDomain).To(gomega.Equal(defaultIngressDomain))
+	g.Expect(spec.Ingress.UrlScheme).To(gomega.Equal(defaultUrlScheme))
+	g.Expect(spec.Deploy.DefaultDeploymentMode).To(gomega.Equal("Serverless"))
+}
+
+func TestKServeConfigFromConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap := &v1.ConfigMap{
+		Data: map[string]string{
+			ExplainersConfigMapKey: `{"alibi": {"image": "kserve/alibi-explainer", "defaultImageVersion": "latest"}}`,
+			StorageInitializerConfigMapKey: `{
+				"image": "kserve/storage-initializer:latest",
+				"memoryRequest": "100Mi",
+				"cpuLimit": "1",
+				"storageSpecSecretName": "storage-config"
+			}`,
+			IngressConfigMapKey: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "istio-ingressgateway"}`,
+			RouterConfigMapKey:  `{"image": "kserve/router:latest", "headers": {"propagate": ["Authorization"]}}`,
+			"unmanaged":         "value",
+		},
+	}
+	spec, err := KServeConfigSpecFromConfigMap(configMap)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(spec.Explainers["alibi"].Image).To(gomega.Equal("kserve/alibi-explainer"))
+	g.Expect(spec.StorageInitializer.Image).To(gomega.Equal("kserve/storage-initializer:latest"))
+	g.Expect(spec.StorageInitializer.MemoryRequest).To(gomega.Equal("100Mi"))
+	g.Expect(spec.StorageInitializer.StorageSpecSecretName).To(gomega.Equal("storage-config"))
+	g.Expect(spec.Router.Headers["propagate"]).To(gomega.Equal([]string{"Authorization"}))
+	g.Expect(spec.Agent).To(gomega.BeNil())
+
+	data, err := spec.ToConfigMapData()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(data).To(gomega.HaveLen(4))
+	roundTrip, err := KServeConfigSpecFromConfigMap(&v1.ConfigMap{Data: data})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(roundTrip).To(gomega.Equal(spec))
+
+	_, err = KServeConfigSpecFromConfigMap(&v1.ConfigMap{Data: map[string]string{IngressConfigMapKey: "{"}})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestValidateConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		data    map[string]string
+		matcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			data: map[string]string{
+				IngressConfigMapKey: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "istio-ingressgateway"}`,
+				DeployConfigMapKey:  `{"defaultDeploymentMode": "Serverless"}`,
+			},
+			matcher: gomega.BeNil(),
+		},
+		"MalformedJson": {
+			data:    map[string]string{DeployConfigMapKey: `{"defaultDeploymentMode": "Serverless"`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unable to parse deploy config json")),
+		},
+		"UnknownField": {
+			data:    map[string]string{DeployConfigMapKey: `{"defaultDeploymentMod": "RawDeployment"}`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unknown field")),
+		},
+		"InvalidValue": {
+			data:    map[string]string{IngressConfigMapKey: `{"ingressGateway": "knative-serving/knative-ingress-gateway"}`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("ingressGateway and ingressService are required")),
+		},
+		"ValidationPolicies": {
+			data:    map[string]string{validationpolicy.ConfigMapKey: `[{"name": "approved-bucket", "expression": "has(object.spec.predictor.model)"}]`},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidValidationPolicy": {
+			data:    map[string]string{validationpolicy.ConfigMapKey: `[{"name": "approved-bucket", "expression": "object.spec ==="}]`},
+			matcher: gomega.MatchError(gomega.ContainSubstring(`invalid validationPolicies config: policy "approved-bucket"`)),
+		},
+		"InvalidDebugContainer": {
+			data:    map[string]string{debugcontainer.ConfigMapKey: `{"images": "python:3.9-slim"}`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unable to parse debugContainer config json")),
+		},
+	}
+	for name, scenario := range scenarios {
+		g.Expect(ValidateConfigMap(&v1.ConfigMap{Data: scenario.data})).To(scenario.matcher, name)
+	}
+}
+
+func TestValidateShippedConfigMaps(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	kustomizeConfig, err := os.ReadFile("../../../../config/configmap/inferenceservice.yaml")
+	g.Expect(err).To(gomega.BeNil())
+	configMap := &v1.ConfigMap{}
+	g.Expect(yaml.Unmarshal(kustomizeConfig, configMap)).To(gomega.Succeed())
+	g.Expect(ValidateConfigMap(configMap)).To(gomega.Succeed())
+
+	// Render the chart template with the default values the way helm does
+	valuesFile, err := os.ReadFile("../../../../charts/kserve/values.yaml")
+	g.Expect(err).To(gomega.BeNil())
+	values := map[string]interface{}{}
+	g.Expect(yaml.Unmarshal(valuesFile, &values)).To(gomega.Succeed())
+	chartTemplate, err := template.New("configmap.yaml").Funcs(template.FuncMap{
+		"toJson": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).ParseFiles("../../../../charts/kserve/templates/configmap.yaml")
+	g.Expect(err).To(gomega.BeNil())
+	rendered := &bytes.Buffer{}
+	g.Expect(chartTemplate.Execute(rendered, map[string]interface{}{
+		"Values":  values,
+		"Release": map[string]interface{}{"Namespace": "kserve"},
+	})).To(gomega.Succeed())
+
+	var chartConfigMap *v1.ConfigMap
+	for _, document := range strings.Split(rendered.String(), "\n---\n") {
+		configMap := &v1.ConfigMap{}
+		g.Expect(yaml.Unmarshal([]byte(document), configMap)).To(gomega.Succeed())
+		if configMap.Name == "inferenceservice-config" {
+			chartConfigMap = configMap
+		}
+	}
+	g.Expect(chartConfigMap).NotTo(gomega.BeNil())
+	g.Expect(ValidateConfigMap(chartConfigMap)).To(gomega.Succeed())
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LocalModelNodeStatus is the download state of a LocalModelCache on a node
+// +kubebuilder:validation:Enum=NodeDownloading;NodeDownloaded;NodeDownloadError
+type LocalModelNodeStatus string
+
+const (
+	// NodeDownloading is set while the local model agent of the node downloads the model
+	NodeDownloading LocalModelNodeStatus = "NodeDownloading"
+	// NodeDownloaded is set once the model is available on the local disk of the node
+	NodeDownloaded LocalModelNodeStatus = "NodeDownloaded"
+	// NodeDownloadError is set when the local model agent of the node failed to download the model
+	NodeDownloadError LocalModelNodeStatus = "NodeDownloadError"
+)
+
+// LocalModelCache declares a model pre-downloaded on the local disk of the selected nodes, the predictor pods with the
+// same storage uri mount the cache instead of downloading the model in the storage initializer.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".spec.sourceModelUri"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=localmodelcaches,scope=Cluster,shortName=lmc
+type LocalModelCache struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              LocalModelCacheSpec   `json:"spec,omitempty"`
+	Status            LocalModelCacheStatus `json:"status,omitempty"`
+}
+
+// LocalModelCacheSpec defines the model to cache and the nodes caching it
+// +k8s:openapi-gen=true
+type LocalModelCacheSpec struct {
+	// Storage uri of the model, the predictors are served from the cache when their storage uri is the same
+	SourceModelUri string `json:"sourceModelUri"`
+	// Labels of the nodes caching the model, all the nodes running the local model agent cache it when empty.
+	// The predictor pods served from the cache are scheduled on the selected nodes.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// LocalModelCacheStatus defines the download state of the model on the nodes
+// +k8s:openapi-gen=true
+type LocalModelCacheStatus struct {
+	// Download state of the model keyed by node name
+	// +optional
+	NodeStatus map[string]LocalModelNodeStatus `json:"nodeStatus,omitempty"`
+}
+
+// LocalModelCacheList contains a list of LocalModelCache
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type LocalModelCacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=set
+	Items []LocalModelCache `json:"items"`
+}
+
+// SelectsNode returns true when the node labels match the node selector of the cache
+func (c *LocalModelCache) SelectsNode(nodeLabels map[string]string) bool {
+	return labels.SelectorFromSet(c.Spec.NodeSelector).Matches(labels.Set(nodeLabels))
+}
+
+func init() {
+	SchemeBuilder.Register(&LocalModelCache{}, &LocalModelCacheList{})
+}
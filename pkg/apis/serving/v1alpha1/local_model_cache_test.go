@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestLocalModelCacheSelectsNode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	nodeLabels := map[string]string{"gpu": "a100", "zone": "a"}
+	scenarios := map[string]struct {
+		nodeSelector map[string]string
+		expected     bool
+	}{
+		"EmptySelector":    {nodeSelector: nil, expected: true},
+		"MatchingSelector": {nodeSelector: map[string]string{"gpu": "a100"}, expected: true},
+		"OtherValue":       {nodeSelector: map[string]string{"gpu": "h100"}, expected: false},
+		"MissingLabel":     {nodeSelector: map[string]string{"gpu": "a100", "spot": "true"}, expected: false},
+	}
+	for name, scenario := range scenarios {
+		cache := &LocalModelCache{Spec: LocalModelCacheSpec{SourceModelUri: "gs://models/llama", NodeSelector: scenario.nodeSelector}}
+		g.Expect(cache.SelectsNode(nodeLabels)).To(gomega.Equal(scenario.expected), name)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // +k8s:openapi-gen=true
@@ -36,6 +37,10 @@ type SupportedModelFormat struct {
 	// this model format is specified with no explicit runtime.
 	// +optional
 	AutoSelect *bool `json:"autoSelect,omitempty"`
+	// Comma separated constraints on the framework version the models were saved with, e.g. ">=1.3,<1.6".
+	// Used in validating that the runtime can load a model declaring its framework version.
+	// +optional
+	FrameworkVersions *string `json:"frameworkVersions,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -112,10 +117,31 @@ type ServingRuntimeSpec struct {
 	// +optional
 	Disabled *bool `json:"disabled,omitempty"`
 
+	// Namespaces the InferenceServices can use this runtime in, only applies to ClusterServingRuntimes.
+	// The runtime is available in all the namespaces if omitted.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// Label selector of the namespaces the InferenceServices can use this runtime in, only applies to
+	// ClusterServingRuntimes. A namespace is allowed when it is one of the allowedNamespaces or matches the selector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Priority of the runtime among the runtimes supporting the model format of an InferenceService which does not
+	// select its runtime, the runtime with the highest priority is selected. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
 	// Supported protocol versions (i.e. v1 or v2 or grpc-v1 or grpc-v2)
 	// +optional
 	ProtocolVersions []constants.InferenceServiceProtocol `json:"protocolVersions,omitempty"`
 
+	// Node architectures the runtime images are published for (i.e. amd64 or arm64), the predictor pods are
+	// scheduled on nodes with one of these architectures. All the architectures are supported if omitted.
+	// +optional
+	SupportedArchitectures []string `json:"supportedArchitectures,omitempty"`
+
 	ServingRuntimePodSpec `json:",inline"`
 
 	// The following fields apply to ModelMesh deployments.
@@ -251,6 +277,35 @@ func (srSpec *ServingRuntimeSpec) IsDisabled() bool {
 	return srSpec.Disabled != nil && *srSpec.Disabled
 }
 
+// IsNamespaceAllowed returns whether the InferenceServices of the namespace with the given labels can use the runtime,
+// an invalid namespace selector matches no namespace
+func (srSpec *ServingRuntimeSpec) IsNamespaceAllowed(namespace string, namespaceLabels map[string]string) bool {
+	if len(srSpec.AllowedNamespaces) == 0 && srSpec.NamespaceSelector == nil {
+		return true
+	}
+	for _, allowed := range srSpec.AllowedNamespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+	if srSpec.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(srSpec.NamespaceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(namespaceLabels))
+}
+
+// GetPriority returns the priority of the runtime, 0 when not set
+func (srSpec *ServingRuntimeSpec) GetPriority() int32 {
+	if srSpec.Priority == nil {
+		return 0
+	}
+	return *srSpec.Priority
+}
+
 func (srSpec *ServingRuntimeSpec) IsMultiModelRuntime() bool {
 	return srSpec.MultiModel != nil && *srSpec.MultiModel
 }
@@ -266,3 +321,25 @@ func (srSpec *ServingRuntimeSpec) IsProtocolVersionSupported(modelProtocolVersio
 	}
 	return false
 }
+
+func (srSpec *ServingRuntimeSpec) IsArchitectureSupported(architecture string) bool {
+	if len(architecture) == 0 || len(srSpec.SupportedArchitectures) == 0 {
+		return true
+	}
+	for _, srArchitecture := range srSpec.SupportedArchitectures {
+		if srArchitecture == architecture {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFrameworkVersionSupported returns whether the framework version of a model satisfies the framework version
+// constraints of the model format, a model format without constraints supports any version
+func (f *SupportedModelFormat) IsFrameworkVersionSupported(version string) bool {
+	if f.FrameworkVersions == nil {
+		return true
+	}
+	supported, err := MatchFrameworkVersion(*f.FrameworkVersions, version)
+	return err == nil && supported
+}
@@ -24,6 +24,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -82,6 +83,65 @@ func TestMarshalServingRuntime(t *testing.T) {
 	fmt.Println(string(b))
 }
 
+func TestServingRuntimeSpec_IsNamespaceAllowed(t *testing.T) {
+	gpuSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"gpu-approved": "true"}}
+	scenarios := map[string]struct {
+		spec      ServingRuntimeSpec
+		namespace string
+		labels    map[string]string
+		res       bool
+	}{
+		"all namespaces by default": {
+			spec:      ServingRuntimeSpec{},
+			namespace: "team-a",
+			res:       true,
+		},
+		"allowed namespace": {
+			spec:      ServingRuntimeSpec{AllowedNamespaces: []string{"team-a", "team-b"}},
+			namespace: "team-b",
+			res:       true,
+		},
+		"other namespace": {
+			spec:      ServingRuntimeSpec{AllowedNamespaces: []string{"team-a"}},
+			namespace: "team-c",
+			res:       false,
+		},
+		"selected namespace": {
+			spec:      ServingRuntimeSpec{NamespaceSelector: gpuSelector},
+			namespace: "team-c",
+			labels:    map[string]string{"gpu-approved": "true"},
+			res:       true,
+		},
+		"not selected namespace": {
+			spec:      ServingRuntimeSpec{NamespaceSelector: gpuSelector},
+			namespace: "team-c",
+			labels:    map[string]string{"gpu-approved": "false"},
+			res:       false,
+		},
+		"allowed or selected namespace": {
+			spec:      ServingRuntimeSpec{AllowedNamespaces: []string{"team-a"}, NamespaceSelector: gpuSelector},
+			namespace: "team-a",
+			res:       true,
+		},
+		"invalid selector": {
+			spec: ServingRuntimeSpec{NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "gpu-approved", Operator: "Unknown"}},
+			}},
+			namespace: "team-c",
+			labels:    map[string]string{"gpu-approved": "true"},
+			res:       false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res := scenario.spec.IsNamespaceAllowed(scenario.namespace, scenario.labels)
+			if res != scenario.res {
+				t.Errorf("Expected %t, got %t", scenario.res, res)
+			}
+		})
+	}
+}
+
 func TestServingRuntimeSpec_IsDisabled(t *testing.T) {
 	endpoint := "endpoint"
 	version := "1.0"
@@ -45,6 +45,8 @@ const (
 	MemoryResourceAvailable apis.ConditionType = "MemoryResourceAvailable"
 	// IsMMSPredictor is set when inference service predictor is set to multi-model serving
 	IsMMSPredictor apis.ConditionType = "IsMMSPredictor"
+	// ModelLoaded is set when the model server reported the load result of the trained model
+	ModelLoaded apis.ConditionType = "ModelLoaded"
 )
 
 // TrainedModel Ready condition is depending on inference service readiness condition
@@ -54,6 +56,7 @@ var conditionSet = apis.NewLivingConditionSet(
 	FrameworkSupported,
 	MemoryResourceAvailable,
 	IsMMSPredictor,
+	ModelLoaded,
 )
 
 var _ apis.ConditionsAccessor = (*TrainedModelStatus)(nil)
@@ -24,11 +24,28 @@ package v1alpha1
 import (
 	"github.com/kserve/kserve/pkg/constants"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentConfigSpec) DeepCopyInto(out *AgentConfigSpec) {
+	*out = *in
+	out.ContainerConfigSpec = in.ContainerConfigSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentConfigSpec.
+func (in *AgentConfigSpec) DeepCopy() *AgentConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuiltInAdapter) DeepCopyInto(out *BuiltInAdapter) {
 	*out = *in
@@ -110,6 +127,174 @@ func (in *ClusterServingRuntimeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerConfigSpec) DeepCopyInto(out *ContainerConfigSpec) {
+	*out = *in
+	out.ResourceConfigSpec = in.ResourceConfigSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerConfigSpec.
+func (in *ContainerConfigSpec) DeepCopy() *ContainerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CorsPolicySpec) DeepCopyInto(out *CorsPolicySpec) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllowCredentials != nil {
+		in, out := &in.AllowCredentials, &out.AllowCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CorsPolicySpec.
+func (in *CorsPolicySpec) DeepCopy() *CorsPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CorsPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsConfigSpec) DeepCopyInto(out *CredentialsConfigSpec) {
+	*out = *in
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSCredentialsConfigSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3CredentialsConfigSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsConfigSpec.
+func (in *CredentialsConfigSpec) DeepCopy() *CredentialsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployConfigSpec) DeepCopyInto(out *DeployConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployConfigSpec.
+func (in *DeployConfigSpec) DeepCopy() *DeployConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExplainerConfigSpec) DeepCopyInto(out *ExplainerConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExplainerConfigSpec.
+func (in *ExplainerConfigSpec) DeepCopy() *ExplainerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExplainerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSCredentialsConfigSpec) DeepCopyInto(out *GCSCredentialsConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSCredentialsConfigSpec.
+func (in *GCSCredentialsConfigSpec) DeepCopy() *GCSCredentialsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSCredentialsConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrpcRoutesSpec) DeepCopyInto(out *GrpcRoutesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrpcRoutesSpec.
+func (in *GrpcRoutesSpec) DeepCopy() *GrpcRoutesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrpcRoutesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryConfigSpec) DeepCopyInto(out *ImageRegistryConfigSpec) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryConfigSpec.
+func (in *ImageRegistryConfigSpec) DeepCopy() *ImageRegistryConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceGraph) DeepCopyInto(out *InferenceGraph) {
 	*out = *in
@@ -138,150 +323,789 @@ func (in *InferenceGraph) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceGraphList) DeepCopyInto(out *InferenceGraphList) {
+func (in *InferenceGraphList) DeepCopyInto(out *InferenceGraphList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InferenceGraph, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphList.
+func (in *InferenceGraphList) DeepCopy() *InferenceGraphList {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InferenceGraphList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string]InferenceRouter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StreamIdleTimeoutSeconds != nil {
+		in, out := &in.StreamIdleTimeoutSeconds, &out.StreamIdleTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphSpec.
+func (in *InferenceGraphSpec) DeepCopy() *InferenceGraphSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphStatus) DeepCopyInto(out *InferenceGraphStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphStatus.
+func (in *InferenceGraphStatus) DeepCopy() *InferenceGraphStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceRouter) DeepCopyInto(out *InferenceRouter) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]InferenceStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouter.
+func (in *InferenceRouter) DeepCopy() *InferenceRouter {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceRouter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceTemplate) DeepCopyInto(out *InferenceServiceTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceTemplate.
+func (in *InferenceServiceTemplate) DeepCopy() *InferenceServiceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InferenceServiceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceTemplateList) DeepCopyInto(out *InferenceServiceTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InferenceServiceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceTemplateList.
+func (in *InferenceServiceTemplateList) DeepCopy() *InferenceServiceTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InferenceServiceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceTemplateSpec) DeepCopyInto(out *InferenceServiceTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceTemplateSpec.
+func (in *InferenceServiceTemplateSpec) DeepCopy() *InferenceServiceTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
+	*out = *in
+	out.InferenceTarget = in.InferenceTarget
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(StepBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(StepCircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStep.
+func (in *InferenceStep) DeepCopy() *InferenceStep {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceTarget) DeepCopyInto(out *InferenceTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceTarget.
+func (in *InferenceTarget) DeepCopy() *InferenceTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassConfigSpec) DeepCopyInto(out *IngressClassConfigSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassConfigSpec.
+func (in *IngressClassConfigSpec) DeepCopy() *IngressClassConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConfigSpec) DeepCopyInto(out *IngressConfigSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedIngressGateways != nil {
+		in, out := &in.AllowedIngressGateways, &out.AllowedIngressGateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIngressClasses != nil {
+		in, out := &in.AdditionalIngressClasses, &out.AdditionalIngressClasses
+		*out = make([]IngressClassConfigSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CorsPolicy != nil {
+		in, out := &in.CorsPolicy, &out.CorsPolicy
+		*out = new(CorsPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrpcRoutes != nil {
+		in, out := &in.GrpcRoutes, &out.GrpcRoutes
+		*out = new(GrpcRoutesSpec)
+		**out = **in
+	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(IngressTlsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfigSpec.
+func (in *IngressConfigSpec) DeepCopy() *IngressConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTlsSpec) DeepCopyInto(out *IngressTlsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTlsSpec.
+func (in *IngressTlsSpec) DeepCopy() *IngressTlsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTlsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KServeConfig) DeepCopyInto(out *KServeConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KServeConfig.
+func (in *KServeConfig) DeepCopy() *KServeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KServeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KServeConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KServeConfigList) DeepCopyInto(out *KServeConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KServeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KServeConfigList.
+func (in *KServeConfigList) DeepCopy() *KServeConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KServeConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KServeConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KServeConfigSpec) DeepCopyInto(out *KServeConfigSpec) {
+	*out = *in
+	if in.Explainers != nil {
+		in, out := &in.Explainers, &out.Explainers
+		*out = make(map[string]ExplainerConfigSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StorageInitializer != nil {
+		in, out := &in.StorageInitializer, &out.StorageInitializer
+		*out = new(StorageInitializerConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageRegistry != nil {
+		in, out := &in.ImageRegistry, &out.ImageRegistry
+		*out = new(ImageRegistryConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(CredentialsConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logger != nil {
+		in, out := &in.Logger, &out.Logger
+		*out = new(LoggerConfigSpec)
+		**out = **in
+	}
+	if in.Batcher != nil {
+		in, out := &in.Batcher, &out.Batcher
+		*out = new(ContainerConfigSpec)
+		**out = **in
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(AgentConfigSpec)
+		**out = **in
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(RouterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deploy != nil {
+		in, out := &in.Deploy, &out.Deploy
+		*out = new(DeployConfigSpec)
+		**out = **in
+	}
+	if in.MetricsAggregator != nil {
+		in, out := &in.MetricsAggregator, &out.MetricsAggregator
+		*out = new(MetricsAggregatorConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogRouting != nil {
+		in, out := &in.LogRouting, &out.LogRouting
+		*out = new(LogRoutingConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KServeConfigSpec.
+func (in *KServeConfigSpec) DeepCopy() *KServeConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KServeConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KServeConfigStatus) DeepCopyInto(out *KServeConfigStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KServeConfigStatus.
+func (in *KServeConfigStatus) DeepCopy() *KServeConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KServeConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalModelCache) DeepCopyInto(out *LocalModelCache) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalModelCache.
+func (in *LocalModelCache) DeepCopy() *LocalModelCache {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalModelCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalModelCache) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalModelCacheList) DeepCopyInto(out *LocalModelCacheList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalModelCache, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalModelCacheList.
+func (in *LocalModelCacheList) DeepCopy() *LocalModelCacheList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalModelCacheList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalModelCacheList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalModelCacheSpec) DeepCopyInto(out *LocalModelCacheSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalModelCacheSpec.
+func (in *LocalModelCacheSpec) DeepCopy() *LocalModelCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalModelCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalModelCacheStatus) DeepCopyInto(out *LocalModelCacheStatus) {
+	*out = *in
+	if in.NodeStatus != nil {
+		in, out := &in.NodeStatus, &out.NodeStatus
+		*out = make(map[string]LocalModelNodeStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalModelCacheStatus.
+func (in *LocalModelCacheStatus) DeepCopy() *LocalModelCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalModelCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRoutingConfigSpec) DeepCopyInto(out *LogRoutingConfigSpec) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(PodMetadataConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]PodMetadataConfigSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRoutingConfigSpec.
+func (in *LogRoutingConfigSpec) DeepCopy() *LogRoutingConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRoutingConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerConfigSpec) DeepCopyInto(out *LoggerConfigSpec) {
+	*out = *in
+	out.ContainerConfigSpec = in.ContainerConfigSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerConfigSpec.
+func (in *LoggerConfigSpec) DeepCopy() *LoggerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAggregatorConfigSpec) DeepCopyInto(out *MetricsAggregatorConfigSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]InferenceGraph, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(PodMonitorConfigSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphList.
-func (in *InferenceGraphList) DeepCopy() *InferenceGraphList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAggregatorConfigSpec.
+func (in *MetricsAggregatorConfigSpec) DeepCopy() *MetricsAggregatorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceGraphList)
+	out := new(MetricsAggregatorConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InferenceGraphList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	out.Memory = in.Memory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
+func (in *PodMetadataConfigSpec) DeepCopyInto(out *PodMetadataConfigSpec) {
 	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = make(map[string]InferenceRouter, len(*in))
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphSpec.
-func (in *InferenceGraphSpec) DeepCopy() *InferenceGraphSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetadataConfigSpec.
+func (in *PodMetadataConfigSpec) DeepCopy() *PodMetadataConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceGraphSpec)
+	out := new(PodMetadataConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceGraphStatus) DeepCopyInto(out *InferenceGraphStatus) {
+func (in *PodMonitorConfigSpec) DeepCopyInto(out *PodMonitorConfigSpec) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(apis.URL)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphStatus.
-func (in *InferenceGraphStatus) DeepCopy() *InferenceGraphStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMonitorConfigSpec.
+func (in *PodMonitorConfigSpec) DeepCopy() *PodMonitorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceGraphStatus)
+	out := new(PodMonitorConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceRouter) DeepCopyInto(out *InferenceRouter) {
+func (in *PresignBrokerConfigSpec) DeepCopyInto(out *PresignBrokerConfigSpec) {
 	*out = *in
-	if in.Steps != nil {
-		in, out := &in.Steps, &out.Steps
-		*out = make([]InferenceStep, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.UriSchemes != nil {
+		in, out := &in.UriSchemes, &out.UriSchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouter.
-func (in *InferenceRouter) DeepCopy() *InferenceRouter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PresignBrokerConfigSpec.
+func (in *PresignBrokerConfigSpec) DeepCopy() *PresignBrokerConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceRouter)
+	out := new(PresignBrokerConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
+func (in *ResourceConfigSpec) DeepCopyInto(out *ResourceConfigSpec) {
 	*out = *in
-	out.InferenceTarget = in.InferenceTarget
-	if in.Weight != nil {
-		in, out := &in.Weight, &out.Weight
-		*out = new(int64)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStep.
-func (in *InferenceStep) DeepCopy() *InferenceStep {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceConfigSpec.
+func (in *ResourceConfigSpec) DeepCopy() *ResourceConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceStep)
+	out := new(ResourceConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceTarget) DeepCopyInto(out *InferenceTarget) {
+func (in *RouterConfigSpec) DeepCopyInto(out *RouterConfigSpec) {
 	*out = *in
+	out.ContainerConfigSpec = in.ContainerConfigSpec
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceTarget.
-func (in *InferenceTarget) DeepCopy() *InferenceTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterConfigSpec.
+func (in *RouterConfigSpec) DeepCopy() *RouterConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceTarget)
+	out := new(RouterConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+func (in *S3CredentialsConfigSpec) DeepCopyInto(out *S3CredentialsConfigSpec) {
 	*out = *in
-	out.Memory = in.Memory.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CredentialsConfigSpec.
+func (in *S3CredentialsConfigSpec) DeepCopy() *S3CredentialsConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(S3CredentialsConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -432,11 +1256,31 @@ func (in *ServingRuntimeSpec) DeepCopyInto(out *ServingRuntimeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.ProtocolVersions != nil {
 		in, out := &in.ProtocolVersions, &out.ProtocolVersions
 		*out = make([]constants.InferenceServiceProtocol, len(*in))
 		copy(*out, *in)
 	}
+	if in.SupportedArchitectures != nil {
+		in, out := &in.SupportedArchitectures, &out.SupportedArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.ServingRuntimePodSpec.DeepCopyInto(&out.ServingRuntimePodSpec)
 	if in.GrpcMultiModelManagementEndpoint != nil {
 		in, out := &in.GrpcMultiModelManagementEndpoint, &out.GrpcMultiModelManagementEndpoint
@@ -495,6 +1339,61 @@ func (in *ServingRuntimeStatus) DeepCopy() *ServingRuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepBackoff) DeepCopyInto(out *StepBackoff) {
+	*out = *in
+	if in.InitialDelayMilliseconds != nil {
+		in, out := &in.InitialDelayMilliseconds, &out.InitialDelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxDelayMilliseconds != nil {
+		in, out := &in.MaxDelayMilliseconds, &out.MaxDelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepBackoff.
+func (in *StepBackoff) DeepCopy() *StepBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(StepBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepCircuitBreaker) DeepCopyInto(out *StepCircuitBreaker) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OpenDurationSeconds != nil {
+		in, out := &in.OpenDurationSeconds, &out.OpenDurationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepCircuitBreaker.
+func (in *StepCircuitBreaker) DeepCopy() *StepCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(StepCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageHelper) DeepCopyInto(out *StorageHelper) {
 	*out = *in
@@ -510,6 +1409,32 @@ func (in *StorageHelper) DeepCopy() *StorageHelper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageInitializerConfigSpec) DeepCopyInto(out *StorageInitializerConfigSpec) {
+	*out = *in
+	out.ContainerConfigSpec = in.ContainerConfigSpec
+	if in.AllowedUriSchemes != nil {
+		in, out := &in.AllowedUriSchemes, &out.AllowedUriSchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PresignBroker != nil {
+		in, out := &in.PresignBroker, &out.PresignBroker
+		*out = new(PresignBrokerConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageInitializerConfigSpec.
+func (in *StorageInitializerConfigSpec) DeepCopy() *StorageInitializerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageInitializerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SupportedModelFormat) DeepCopyInto(out *SupportedModelFormat) {
 	*out = *in
@@ -523,6 +1448,11 @@ func (in *SupportedModelFormat) DeepCopyInto(out *SupportedModelFormat) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FrameworkVersions != nil {
+		in, out := &in.FrameworkVersions, &out.FrameworkVersions
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupportedModelFormat.
@@ -551,6 +1481,133 @@ func (in *SupportedRuntime) DeepCopy() *SupportedRuntime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedInferenceService) DeepCopyInto(out *TemplatedInferenceService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedInferenceService.
+func (in *TemplatedInferenceService) DeepCopy() *TemplatedInferenceService {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedInferenceService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemplatedInferenceService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedInferenceServiceList) DeepCopyInto(out *TemplatedInferenceServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemplatedInferenceService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedInferenceServiceList.
+func (in *TemplatedInferenceServiceList) DeepCopy() *TemplatedInferenceServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedInferenceServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemplatedInferenceServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedInferenceServiceSpec) DeepCopyInto(out *TemplatedInferenceServiceSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedInferenceServiceSpec.
+func (in *TemplatedInferenceServiceSpec) DeepCopy() *TemplatedInferenceServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedInferenceServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedInferenceServiceStatus) DeepCopyInto(out *TemplatedInferenceServiceStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedInferenceServiceStatus.
+func (in *TemplatedInferenceServiceStatus) DeepCopy() *TemplatedInferenceServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedInferenceServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrainedModel) DeepCopyInto(out *TrainedModel) {
 	*out = *in
@@ -31,25 +31,91 @@ import (
 
 // Known error messages
 const (
-	MinReplicasShouldBeLessThanMaxError = "MinReplicas cannot be greater than MaxReplicas."
-	MinReplicasLowerBoundExceededError  = "MinReplicas cannot be less than 0."
-	MaxReplicasLowerBoundExceededError  = "MaxReplicas cannot be less than 0."
-	ParallelismLowerBoundExceededError  = "Parallelism cannot be less than 0."
-	UnsupportedStorageURIFormatError    = "storageUri, must be one of: [%s] or match https://{}.blob.core.windows.net/{}/{} or be an absolute or relative local path. StorageUri [%s] is not supported."
-	UnsupportedStorageSpecFormatError   = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported."
-	InvalidLoggerType                   = "Invalid logger type"
-	InvalidISVCNameFormatError          = "The InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
-	MaxWorkersShouldBeLessThanMaxError  = "Workers cannot be greater than %d"
-	InvalidWorkerArgument               = "Invalid workers argument"
-	InvalidProtocol                     = "Invalid protocol %s. Must be one of [%s]"
+	MinReplicasShouldBeLessThanMaxError  = "MinReplicas cannot be greater than MaxReplicas."
+	MinReplicasLowerBoundExceededError   = "MinReplicas cannot be less than 0."
+	MaxReplicasLowerBoundExceededError   = "MaxReplicas cannot be less than 0."
+	ParallelismLowerBoundExceededError   = "Parallelism cannot be less than 0."
+	UnsupportedStorageURIFormatError     = "storageUri, must be one of: [%s] or match https://{}.blob.core.windows.net/{}/{} or be an absolute or relative local path. StorageUri [%s] is not supported."
+	UnsupportedStorageSpecFormatError    = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported."
+	InvalidLoggerType                    = "Invalid logger type"
+	InvalidLoggerDeliveryError           = "Invalid logger delivery %s, must be one of [at-most-once, at-least-once]"
+	InvalidLoggerSamplingPercentError    = "Invalid logger samplingPercent %d, must be between 0 and 100"
+	InvalidLoggerResponseCodeError       = "Invalid logger response code %q, must be a status code such as 200 or a class such as 5xx"
+	InvalidLoggerRedactPathError         = "Invalid logger redact path %q, must start with $ followed by fields such as .name or .* and items such as [0] or [*]"
+	InvalidLoggerRedactPatternError      = "Invalid logger redact pattern %q: %v"
+	InvalidISVCNameFormatError           = "The InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
+	MaxWorkersShouldBeLessThanMaxError   = "Workers cannot be greater than %d"
+	InvalidWorkerArgument                = "Invalid workers argument"
+	InvalidProtocol                      = "Invalid protocol %s. Must be one of [%s]"
+	InvalidTTLError                      = "Invalid ttl %q in annotation %s, must be a positive duration such as 72h"
+	InvalidTTLFromError                  = "Invalid ttl-from %q in annotation %s, must be one of [%s]"
+	InvalidTTLActionError                = "Invalid ttl-action %q in annotation %s, must be one of [%s]"
+	RawDeploymentTTLFromLastRequestError = "The ttl of a RawDeployment InferenceService cannot run from its last request, the requests are counted by the queue-proxy of the Serverless pods"
+	InvalidDurationError                 = "Invalid duration %q in annotation %s, must be a positive duration such as 1h"
+	InvalidTimestampError                = "Invalid timestamp %q in annotation %s, must be a RFC3339 timestamp such as 2023-01-31T00:00:00Z"
+	SunsetBeforeDeprecationError         = "The sunset %s is before the deprecation %s"
+	MissingRequiredAnnotationError       = "Annotation %s requires annotation %s"
+	InvalidPercentageError               = "Invalid percentage %q in annotation %s, must be an integer between 0 and 100"
+	InvalidPositiveIntegerError          = "Invalid value %q in annotation %s, must be a positive integer"
+	InvalidRateLimitError                = "RateLimit requestsPerSecond and burst must be greater than 0."
+	InvalidBatcherTargetLatencyError     = "Batcher targetP99Latency must be greater than 0."
+	InvalidMemoryFactorError             = "Invalid factor %q in annotation %s, must be a number greater than 1"
+	InvalidMemoryQuantityError           = "Invalid memory %q in annotation %s, must be a quantity such as 16Gi"
+	InvalidConversionOutputError         = "conversion.outputStorageUri must be a pvc://<pvcname>/<path> uri. outputStorageUri [%s] is not supported."
+	UnsupportedOptimizationError         = "Graph optimization is only supported for the onnx model format served by ONNX Runtime or Triton."
+	InvalidOptimizationWorkspaceError    = "optimization.workspaceSize must be greater than 0."
+	InvalidDynamicShapeError             = "Invalid dynamic shape of input %q, min, opt and max must have the same number of dimensions greater than 0 with min <= opt <= max."
+	InvalidRefreshScheduleError          = "Invalid refreshSchedule %q, must be a cron schedule in the standard five field format such as \"0 2 * * *\"."
+	MissingRefreshStorageError           = "refreshSchedule requires the model of the predictor to be downloaded from a storageUri."
+	InvalidScheduleError                 = "Invalid schedule %q in annotation %s, must be a cron schedule in the standard five field format such as \"0 8 * * 1-5\""
+	InvalidKedaTriggersError             = "Invalid triggers in annotation %s, must be a JSON list of prometheus, kafka or aws-sqs-queue KEDA triggers: %v"
+	InvalidKedaHTTPScalingError          = "The keda-http autoscaler class scales the RawDeployment components on the concurrency of their requests, %s"
+	InvalidExplainerColocationError      = "The explainer colocation runs the explainer as a sidecar of the RawDeployment predictor pods, %s"
+	InvalidTransformerReplicaRatioError  = "The transformer replica ratio scales the transformer with the RawDeployment predictor replicas, %s"
+	InvalidSinkURLError                  = "Invalid url %q in annotation %s, must be an http or https url such as a Knative KafkaSink address"
+	InvalidBufferSizeError               = "Invalid size %q in annotation %s, must be a quantity such as 1Gi"
+	InvalidDeadLetterURIError            = "Invalid uri %q in annotation %s, must be a s3://<bucket>/<prefix> uri"
+	InvalidLoggerEncodingError           = "Invalid encoding %q in annotation %s, must be one of [binary, structured]"
+	InvalidLoggerEventTemplateError      = "Invalid template %q in annotation %s: %v"
+	InvalidTransformURLError             = "Invalid url %q in annotation %s, must be an http or https url of the transform webhook"
+	InvalidHashFieldsError               = "Invalid fields %q in annotation %s, must be a comma separated list of JSON field names"
+	InvalidKMSKeyError                   = "Invalid key %q in annotation %s, must be an aws-kms://<arn> reference of a KMS key or alias"
+	ConflictingAnnotationsError          = "Annotation %s cannot be set together with annotation %s"
+	InvalidPipelineOrderError            = "Invalid order %q in annotation %s, must be one of [log-batch, batch-log]"
+	InvalidModelReadinessPolicyError     = "Invalid policy %q in annotation %s, must be one of [always, min-models, initial-models]"
+	InvalidNamespaceDefaultsError        = "Invalid defaults in annotation %s of namespace %s: %v"
+	InvalidPayloadURIError               = "Invalid uri %q in annotation %s, must be a s3://<bucket>/<prefix> or gs://<bucket>/<prefix> uri"
+	InvalidIngressGatewayError           = "Invalid gateway %q in annotation %s, must be a <namespace>/<name> gateway reference"
+	IngressGatewayNotAllowedError        = "Gateway %q in annotation %s is not allowed, must be one of the allowedIngressGateways of the ingress config"
+	InvalidCorsOriginError               = "Invalid origin %q in annotation %s, must be * or an http or https origin such as https://example.com"
+	InvalidCanaryHeaderError             = "Invalid header %q in annotation %s, must be a <name>: <value> header such as x-model-revision: canary"
+	InvalidFallbackError                 = "Invalid InferenceService %q in annotation %s, must be the name of another InferenceService of the namespace"
+	InvalidSmokeTestError                = "Invalid smokeTest, %s"
+	InvalidFaultAbortStatusError         = "Invalid status %q in annotation %s, must be an HTTP status code between 400 and 599"
+	InvalidFaultTTLError                 = "Invalid ttl %q in annotation %s, must be a positive duration of at most 24h such as 15m"
+	MissingFaultError                    = "Annotation %s requires annotation %s or %s"
+	InvalidMaintenanceError              = "Invalid value %q in annotation %s, must be true or false"
+	InvalidMaintenanceBodyError          = "Invalid body %q in annotation %s, must be a JSON document"
+	InvalidModelNamesError               = "Invalid model names %q in annotation %s, must be comma separated <external>=<internal> model names such as support=llama-2-7b-support"
+	InvalidCallbackURLError              = "Invalid url %q in annotation %s, must be an http or https url such as a Knative broker address"
+	InvalidBrokerURLError                = "Invalid url %q in annotation %s, must be a redis:// or rediss:// url of a Redis server"
+	InvalidTrafficMirrorError            = "trafficMirror percent must be between 0 and 100."
+	InvalidRetriesError                  = "retries attempts must not be negative and perTryTimeout must be greater than 0."
+	DisabledRuntimeError                 = "The runtime %s is disabled."
+	RuntimeNotAllowedError               = "The ClusterServingRuntime %s is not allowed in the namespace %s."
+	InvalidFrameworkVersionError         = "Invalid frameworkVersion %q, must be a version such as 1.4.2."
+	UnsupportedFrameworkVersionError     = "The runtime %s does not support the version %s of the framework of the model format %s."
+	CloudCredentialEnvError              = "The environment variable %s of the container %s is set by mountCloudIdentity, the credentials must be bound to the service account of the component."
 )
 
 // Constants
 var (
-	SupportedStorageURIPrefixList     = []string{"gs://", "s3://", "pvc://", "file://", "https://", "http://", "hdfs://", "webhdfs://"}
-	SupportedStorageSpecURIPrefixList = []string{"s3://", "hdfs://", "webhdfs://"}
+	SupportedStorageURIPrefixList     = []string{"gs://", "s3://", "pvc://", "file://", "https://", "http://", "hdfs://", "webhdfs://", "hf://", "oci://", "oss://", "cos://", "r2://", "swift://"}
+	SupportedStorageSpecURIPrefixList = []string{"s3://", "hdfs://", "webhdfs://", "hf://", "swift://"}
 	AzureBlobURL                      = "blob.core.windows.net"
 	AzureBlobURIRegEx                 = "https://(.+?).blob.core.windows.net/(.+)"
+	loggerResponseCodeRegex           = regexp.MustCompile("^[1-5]([0-9]{2}|xx)$")
+	loggerRedactPathRegex             = regexp.MustCompile(`^\$(\.[A-Za-z0-9_-]+|\.\*|\[[0-9]+\]|\[\*\])+$`)
 )
 
 // ComponentImplementation interface is implemented by predictor, transformer, and explainer implementations
@@ -75,9 +141,11 @@ type Component interface {
 type ComponentExtensionSpec struct {
 	// Minimum number of replicas, defaults to 1 but can be set to 0 to enable scale-to-zero.
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	MinReplicas *int `json:"minReplicas,omitempty"`
 	// Maximum number of replicas for autoscaling.
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	MaxReplicas int `json:"maxReplicas,omitempty"`
 	// ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for.
 	// concurrency and rps targets are supported by Knative Pod Autoscaler
@@ -85,19 +153,22 @@ type ComponentExtensionSpec struct {
 	// +optional
 	ScaleTarget *int `json:"scaleTarget,omitempty"`
 	// ScaleMetric defines the scaling metric type watched by autoscaler
-	// possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via
+	// possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via
 	// Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).
 	// +optional
 	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
 	// ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container
 	// concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).
 	// +optional
+	// +kubebuilder:validation:Minimum=0
 	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
 	// TimeoutSeconds specifies the number of seconds to wait before timing out a request to the component.
 	// +optional
 	TimeoutSeconds *int64 `json:"timeout,omitempty"`
 	// CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision
 	// +optional
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=0
 	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
 	// Activate request/response logging and logger configurations
 	// +optional
@@ -105,10 +176,25 @@ type ComponentExtensionSpec struct {
 	// Activate request batching and batching configurations
 	// +optional
 	Batcher *Batcher `json:"batcher,omitempty"`
+	// Limit the rate of the requests sent to each replica, the requests above the limit are rejected
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled
+	// out revision, the responses of the mirrored requests are discarded
+	// +optional
+	TrafficMirror *TrafficMirror `json:"trafficMirror,omitempty"`
+	// Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts
+	// +optional
+	Retries *RetryPolicy `json:"retries,omitempty"`
+	// Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in
+	// the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can
+	// read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.
+	// +optional
+	MountCloudIdentity bool `json:"mountCloudIdentity,omitempty"`
 }
 
 // ScaleMetric enum
-// +kubebuilder:validation:Enum=cpu;memory;concurrency;rps
+// +kubebuilder:validation:Enum=cpu;memory;concurrency;rps;gpu;gpu-memory
 type ScaleMetric string
 
 const (
@@ -116,6 +202,8 @@ const (
 	MetricMemory      ScaleMetric = "memory"
 	MetricConcurrency ScaleMetric = "concurrency"
 	MetricRPS         ScaleMetric = "rps"
+	MetricGPU         ScaleMetric = "gpu"
+	MetricGPUMemory   ScaleMetric = "gpu-memory"
 )
 
 // Default the ComponentExtensionSpec
@@ -127,6 +215,10 @@ func (s *ComponentExtensionSpec) Validate() error {
 		validateContainerConcurrency(s.ContainerConcurrency),
 		validateReplicas(s.MinReplicas, s.MaxReplicas),
 		validateLogger(s.Logger),
+		validateBatcher(s.Batcher),
+		validateRateLimit(s.RateLimit),
+		validateTrafficMirror(s.TrafficMirror),
+		validateRetries(s.Retries),
 	})
 }
 
@@ -211,6 +303,66 @@ func validateLogger(logger *LoggerSpec) error {
 		if !(logger.Mode == LogAll || logger.Mode == LogRequest || logger.Mode == LogResponse) {
 			return fmt.Errorf(InvalidLoggerType)
 		}
+		if logger.Delivery != "" && logger.Delivery != LogAtMostOnce && logger.Delivery != LogAtLeastOnce {
+			return fmt.Errorf(InvalidLoggerDeliveryError, logger.Delivery)
+		}
+		if logger.SamplingPercent != nil && (*logger.SamplingPercent < 0 || *logger.SamplingPercent > 100) {
+			return fmt.Errorf(InvalidLoggerSamplingPercentError, *logger.SamplingPercent)
+		}
+		for _, code := range logger.ResponseCodes {
+			if !loggerResponseCodeRegex.MatchString(string(code)) {
+				return fmt.Errorf(InvalidLoggerResponseCodeError, code)
+			}
+		}
+		if logger.Redact != nil {
+			for _, path := range logger.Redact.Paths {
+				if !loggerRedactPathRegex.MatchString(path) {
+					return fmt.Errorf(InvalidLoggerRedactPathError, path)
+				}
+			}
+			for _, pattern := range logger.Redact.Patterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return fmt.Errorf(InvalidLoggerRedactPatternError, pattern, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateBatcher(batcher *Batcher) error {
+	if batcher != nil && batcher.TargetP99Latency != nil && *batcher.TargetP99Latency <= 0 {
+		return fmt.Errorf(InvalidBatcherTargetLatencyError)
+	}
+	return nil
+}
+
+func validateRateLimit(rateLimit *RateLimit) error {
+	if rateLimit == nil {
+		return nil
+	}
+	if rateLimit.RequestsPerSecond <= 0 || (rateLimit.Burst != nil && *rateLimit.Burst <= 0) {
+		return fmt.Errorf(InvalidRateLimitError)
+	}
+	return nil
+}
+
+func validateTrafficMirror(trafficMirror *TrafficMirror) error {
+	if trafficMirror == nil || trafficMirror.Percent == nil {
+		return nil
+	}
+	if *trafficMirror.Percent < 0 || *trafficMirror.Percent > 100 {
+		return fmt.Errorf(InvalidTrafficMirrorError)
+	}
+	return nil
+}
+
+func validateRetries(retries *RetryPolicy) error {
+	if retries == nil {
+		return nil
+	}
+	if retries.Attempts < 0 || (retries.PerTryTimeoutSeconds != nil && *retries.PerTryTimeoutSeconds <= 0) {
+		return fmt.Errorf(InvalidRetriesError)
 	}
 	return nil
 }
@@ -144,6 +144,71 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			},
 			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerType)),
 		},
+		"LoggerWithAtLeastOnceDelivery": {
+			logger: &LoggerSpec{
+				Mode:     LogAll,
+				Delivery: LogAtLeastOnce,
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidLoggerDelivery": {
+			logger: &LoggerSpec{
+				Mode:     LogAll,
+				Delivery: "exactly-once",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerDeliveryError, "exactly-once")),
+		},
+		"LoggerWithSamplingAndFilters": {
+			logger: &LoggerSpec{
+				Mode:            LogAll,
+				SamplingPercent: proto.Int32(5),
+				OptOutHeader:    "X-No-Log",
+				ResponseCodes:   []LoggerResponseCode{"200", "5xx"},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidLoggerSamplingPercent": {
+			logger: &LoggerSpec{
+				Mode:            LogAll,
+				SamplingPercent: proto.Int32(150),
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerSamplingPercentError, 150)),
+		},
+		"InvalidLoggerResponseCode": {
+			logger: &LoggerSpec{
+				Mode:          LogAll,
+				ResponseCodes: []LoggerResponseCode{"2XX"},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerResponseCodeError, "2XX")),
+		},
+		"LoggerWithRedact": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Redact: &LoggerRedactSpec{
+					Paths:    []string{"$.instances[*].email", "$.inputs[0].data", "$.*"},
+					Patterns: []string{"[0-9]{3}-[0-9]{2}-[0-9]{4}"},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidLoggerRedactPath": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Redact: &LoggerRedactSpec{
+					Paths: []string{"instances.email"},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerRedactPathError, "instances.email")),
+		},
+		"InvalidLoggerRedactPattern": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Redact: &LoggerRedactSpec{
+					Patterns: []string{"[0-9"},
+				},
+			},
+			matcher: gomega.MatchError(gomega.HavePrefix(`Invalid logger redact pattern "[0-9"`)),
+		},
 		"LoggerIsNil": {
 			logger:  nil,
 			matcher: gomega.BeNil(),
@@ -156,6 +221,150 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 	}
 }
 
+func TestComponentExtensionSpec_validateBatcher(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		batcher *Batcher
+		matcher types.GomegaMatcher
+	}{
+		"AdaptiveBatcher": {
+			batcher: &Batcher{
+				MaxBatchSize:     GetIntReference(32),
+				TargetP99Latency: GetIntReference(200),
+				PriorityHeader:   "X-Priority",
+			},
+			matcher: gomega.BeNil(),
+		},
+		"FixedBatcher": {
+			batcher: &Batcher{MaxBatchSize: GetIntReference(32)},
+			matcher: gomega.BeNil(),
+		},
+		"ZeroTargetP99Latency": {
+			batcher: &Batcher{TargetP99Latency: GetIntReference(0)},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidBatcherTargetLatencyError)),
+		},
+		"BatcherIsNil": {
+			batcher: nil,
+			matcher: gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateBatcher(scenario.batcher)).To(scenario.matcher)
+		})
+	}
+}
+
+func TestComponentExtensionSpec_validateRateLimit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		rateLimit *RateLimit
+		matcher   types.GomegaMatcher
+	}{
+		"RateLimitWithBurst": {
+			rateLimit: &RateLimit{
+				RequestsPerSecond: 10,
+				Burst:             GetIntReference(20),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"RateLimitWithoutBurst": {
+			rateLimit: &RateLimit{
+				RequestsPerSecond: 10,
+			},
+			matcher: gomega.BeNil(),
+		},
+		"ZeroRequestsPerSecond": {
+			rateLimit: &RateLimit{},
+			matcher:   gomega.MatchError(fmt.Errorf(InvalidRateLimitError)),
+		},
+		"NegativeBurst": {
+			rateLimit: &RateLimit{
+				RequestsPerSecond: 10,
+				Burst:             GetIntReference(-1),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidRateLimitError)),
+		},
+		"RateLimitIsNil": {
+			rateLimit: nil,
+			matcher:   gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateRateLimit(scenario.rateLimit)).To(scenario.matcher)
+		})
+	}
+}
+
+func TestComponentExtensionSpec_validateTrafficMirror(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		trafficMirror *TrafficMirror
+		matcher       types.GomegaMatcher
+	}{
+		"TrafficMirrorWithPercent": {
+			trafficMirror: &TrafficMirror{Percent: proto.Int64(20)},
+			matcher:       gomega.BeNil(),
+		},
+		"TrafficMirrorWithoutPercent": {
+			trafficMirror: &TrafficMirror{},
+			matcher:       gomega.BeNil(),
+		},
+		"PercentAbove100": {
+			trafficMirror: &TrafficMirror{Percent: proto.Int64(101)},
+			matcher:       gomega.MatchError(fmt.Errorf(InvalidTrafficMirrorError)),
+		},
+		"NegativePercent": {
+			trafficMirror: &TrafficMirror{Percent: proto.Int64(-1)},
+			matcher:       gomega.MatchError(fmt.Errorf(InvalidTrafficMirrorError)),
+		},
+		"TrafficMirrorIsNil": {
+			trafficMirror: nil,
+			matcher:       gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateTrafficMirror(scenario.trafficMirror)).To(scenario.matcher)
+		})
+	}
+}
+
+func TestComponentExtensionSpec_validateRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		retries *RetryPolicy
+		matcher types.GomegaMatcher
+	}{
+		"RetriesWithPerTryTimeout": {
+			retries: &RetryPolicy{Attempts: 3, PerTryTimeoutSeconds: proto.Int64(60), RetryOn: "5xx"},
+			matcher: gomega.BeNil(),
+		},
+		"RetriesDisabled": {
+			retries: &RetryPolicy{},
+			matcher: gomega.BeNil(),
+		},
+		"NegativeAttempts": {
+			retries: &RetryPolicy{Attempts: -1},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidRetriesError)),
+		},
+		"ZeroPerTryTimeout": {
+			retries: &RetryPolicy{Attempts: 3, PerTryTimeoutSeconds: proto.Int64(0)},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidRetriesError)),
+		},
+		"RetriesIsNil": {
+			retries: nil,
+			matcher: gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateRetries(scenario.retries)).To(scenario.matcher)
+		})
+	}
+}
+
 func TestFirstNonNilComponent(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	spec := PredictorSpec{
@@ -29,12 +29,15 @@ import (
 
 // ConfigMap Keys
 const (
-	ExplainerConfigKeyName = "explainers"
+	ExplainerConfigKeyName  = "explainers"
+	LogRoutingConfigKeyName = "logRouting"
 )
 
 const (
 	IngressConfigKeyName = "ingress"
 	DeployConfigName     = "deploy"
+	// MetricsAggregatorConfigName is the key of the metrics config holding the PodMonitor config
+	MetricsAggregatorConfigName = "metricsAggregator"
 
 	DefaultDomainTemplate = "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"
 	DefaultIngressDomain  = "example.com"
@@ -61,19 +64,94 @@ type ExplainersConfig struct {
 type InferenceServicesConfig struct {
 	// Explainer configurations
 	Explainers ExplainersConfig `json:"explainers"`
+	// Log routing metadata of the pods
+	LogRouting LogRoutingConfig `json:"logRouting"`
+}
+
+// +kubebuilder:object:generate=false
+type PodMetadataConfig struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type LogRoutingConfig struct {
+	Default  *PodMetadataConfig           `json:"default,omitempty"`
+	Runtimes map[string]PodMetadataConfig `json:"runtimes,omitempty"`
+}
+
+// GetPodMetadata returns the log routing annotations and labels of the pods of the given runtime, the metadata of the
+// runtime is merged over the default one. The runtime is empty for the components not served by a serving runtime.
+func (c *LogRoutingConfig) GetPodMetadata(runtime string) (map[string]string, map[string]string) {
+	annotations := map[string]string{}
+	labels := map[string]string{}
+	merge := func(metadata *PodMetadataConfig) {
+		for key, value := range metadata.Annotations {
+			annotations[key] = value
+		}
+		for key, value := range metadata.Labels {
+			labels[key] = value
+		}
+	}
+	if c.Default != nil {
+		merge(c.Default)
+	}
+	if metadata, ok := c.Runtimes[runtime]; ok && runtime != "" {
+		merge(&metadata)
+	}
+	return annotations, labels
 }
 
 // +kubebuilder:object:generate=false
 type IngressConfig struct {
-	IngressGateway          string  `json:"ingressGateway,omitempty"`
-	IngressServiceName      string  `json:"ingressService,omitempty"`
-	LocalGateway            string  `json:"localGateway,omitempty"`
-	LocalGatewayServiceName string  `json:"localGatewayService,omitempty"`
-	IngressDomain           string  `json:"ingressDomain,omitempty"`
-	IngressClassName        *string `json:"ingressClassName,omitempty"`
-	DomainTemplate          string  `json:"domainTemplate,omitempty"`
-	UrlScheme               string  `json:"urlScheme,omitempty"`
-	DisableIstioVirtualHost bool    `json:"disableIstioVirtualHost,omitempty"`
+	IngressGateway           string               `json:"ingressGateway,omitempty"`
+	IngressServiceName       string               `json:"ingressService,omitempty"`
+	LocalGateway             string               `json:"localGateway,omitempty"`
+	LocalGatewayServiceName  string               `json:"localGatewayService,omitempty"`
+	IngressDomain            string               `json:"ingressDomain,omitempty"`
+	IngressClassName         *string              `json:"ingressClassName,omitempty"`
+	DomainTemplate           string               `json:"domainTemplate,omitempty"`
+	GlobalDomainTemplate     string               `json:"globalDomainTemplate,omitempty"`
+	HostTemplate             string               `json:"hostTemplate,omitempty"`
+	UrlScheme                string               `json:"urlScheme,omitempty"`
+	DisableIstioVirtualHost  bool                 `json:"disableIstioVirtualHost,omitempty"`
+	EnableGatewayAPI         bool                 `json:"enableGatewayApi,omitempty"`
+	KserveIngressGateway     string               `json:"kserveIngressGateway,omitempty"`
+	AllowedIngressGateways   []string             `json:"allowedIngressGateways,omitempty"`
+	AdditionalIngressClasses []IngressClassConfig `json:"additionalIngressClasses,omitempty"`
+	CorsPolicy               *CorsPolicyConfig    `json:"corsPolicy,omitempty"`
+	GrpcRoutes               *GrpcRoutesConfig    `json:"grpcRoutes,omitempty"`
+	Tls                      *IngressTlsConfig    `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type IngressClassConfig struct {
+	IngressClassName string            `json:"ingressClassName"`
+	IngressDomain    string            `json:"ingressDomain,omitempty"`
+	DomainTemplate   string            `json:"domainTemplate,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type CorsPolicyConfig struct {
+	AllowOrigins     []string `json:"allowOrigins,omitempty"`
+	AllowMethods     []string `json:"allowMethods,omitempty"`
+	AllowHeaders     []string `json:"allowHeaders,omitempty"`
+	ExposeHeaders    []string `json:"exposeHeaders,omitempty"`
+	MaxAgeSeconds    *int64   `json:"maxAgeSeconds,omitempty"`
+	AllowCredentials *bool    `json:"allowCredentials,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type GrpcRoutesConfig struct {
+	LocalGatewayPort int32 `json:"localGatewayPort,omitempty"`
+	EnableSubdomain  bool  `json:"enableSubdomain,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type IngressTlsConfig struct {
+	ClusterIssuer string `json:"clusterIssuer"`
+	SslRedirect   bool   `json:"sslRedirect,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -81,6 +159,14 @@ type DeployConfig struct {
 	DefaultDeploymentMode string `json:"defaultDeploymentMode,omitempty"`
 }
 
+// +kubebuilder:object:generate=false
+type PodMonitorConfig struct {
+	Enabled  bool              `json:"enabled,omitempty"`
+	Scope    string            `json:"scope,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Interval string            `json:"interval,omitempty"`
+}
+
 func NewInferenceServicesConfig(cli client.Client) (*InferenceServicesConfig, error) {
 	configMap := &v1.ConfigMap{}
 	err := cli.Get(context.TODO(), types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
@@ -90,6 +176,7 @@ func NewInferenceServicesConfig(cli client.Client) (*InferenceServicesConfig, er
 	icfg := &InferenceServicesConfig{}
 	for _, err := range []error{
 		getComponentConfig(ExplainerConfigKeyName, configMap, &icfg.Explainers),
+		getComponentConfig(LogRoutingConfigKeyName, configMap, &icfg.LogRouting),
 	} {
 		if err != nil {
 			return nil, err
@@ -114,6 +201,10 @@ func NewIngressConfig(cli client.Client) (*IngressConfig, error) {
 		if ingressConfig.IngressGateway == "" || ingressConfig.IngressServiceName == "" {
 			return nil, fmt.Errorf("invalid ingress config - ingressGateway and ingressService are required")
 		}
+
+		if ingressConfig.EnableGatewayAPI && ingressConfig.KserveIngressGateway == "" {
+			return nil, fmt.Errorf("invalid ingress config - kserveIngressGateway is required when enableGatewayApi is set")
+		}
 	}
 
 	if ingressConfig.DomainTemplate == "" {
@@ -167,3 +258,25 @@ func NewDeployConfig(cli client.Client) (*DeployConfig, error) {
 	}
 	return deployConfig, nil
 }
+
+// NewPodMonitorConfig reads the PodMonitor config of the metricsAggregator config, disabled when it is not set
+func NewPodMonitorConfig(cli client.Client) (*PodMonitorConfig, error) {
+	configMap := &v1.ConfigMap{}
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
+	if err != nil {
+		return nil, err
+	}
+	metricsConfig := struct {
+		PodMonitor *PodMonitorConfig `json:"podMonitor,omitempty"`
+	}{}
+	if err := getComponentConfig(MetricsAggregatorConfigName, configMap, &metricsConfig); err != nil {
+		return nil, err
+	}
+	if metricsConfig.PodMonitor == nil {
+		return &PodMonitorConfig{}, nil
+	}
+	if metricsConfig.PodMonitor.Scope == "" {
+		metricsConfig.PodMonitor.Scope = constants.PodMonitorScopeInferenceService
+	}
+	return metricsConfig.PodMonitor, nil
+}
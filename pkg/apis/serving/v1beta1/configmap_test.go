@@ -25,6 +25,7 @@ import (
 	"github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -106,3 +107,58 @@ func TestNewDeployConfig(t *testing.T) {
 	g.Expect(err).Should(gomega.BeNil())
 	g.Expect(deployConfig).ShouldNot(gomega.BeNil())
 }
+
+func TestNewPodMonitorConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fakeClient := createFakeClient()
+
+	podMonitorConfig, err := NewPodMonitorConfig(fakeClient)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(podMonitorConfig.Enabled).Should(gomega.BeFalse())
+
+	configMap := &v1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx.TODO(), types.NamespacedName{Name: constants.InferenceServiceConfigMapName,
+		Namespace: constants.KServeNamespace}, configMap)).Should(gomega.Succeed())
+	configMap.Data = map[string]string{
+		MetricsAggregatorConfigName: `{"enableMetricAggregation": "false", "podMonitor": {"enabled": true}}`,
+	}
+	g.Expect(fakeClient.Update(ctx.TODO(), configMap)).Should(gomega.Succeed())
+	podMonitorConfig, err = NewPodMonitorConfig(fakeClient)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(podMonitorConfig).Should(gomega.Equal(&PodMonitorConfig{Enabled: true,
+		Scope: constants.PodMonitorScopeInferenceService}))
+}
+
+func TestLogRoutingConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fakeClient := createFakeClient()
+
+	isvcConfig, err := NewInferenceServicesConfig(fakeClient)
+	g.Expect(err).Should(gomega.BeNil())
+	annotations, labels := isvcConfig.LogRouting.GetPodMetadata("kserve-sklearnserver")
+	g.Expect(annotations).Should(gomega.BeEmpty())
+	g.Expect(labels).Should(gomega.BeEmpty())
+
+	configMap := &v1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx.TODO(), types.NamespacedName{Name: constants.InferenceServiceConfigMapName,
+		Namespace: constants.KServeNamespace}, configMap)).Should(gomega.Succeed())
+	configMap.Data = map[string]string{
+		LogRoutingConfigKeyName: `{
+			"default": {"annotations": {"fluentbit.io/parser": "json"}, "labels": {"logging": "enabled"}},
+			"runtimes": {"kserve-tritonserver": {"annotations": {"fluentbit.io/parser": "triton"}}}
+		}`,
+	}
+	g.Expect(fakeClient.Update(ctx.TODO(), configMap)).Should(gomega.Succeed())
+	isvcConfig, err = NewInferenceServicesConfig(fakeClient)
+	g.Expect(err).Should(gomega.BeNil())
+
+	// The metadata of the runtime is merged over the default one
+	annotations, labels = isvcConfig.LogRouting.GetPodMetadata("kserve-tritonserver")
+	g.Expect(annotations).Should(gomega.Equal(map[string]string{"fluentbit.io/parser": "triton"}))
+	g.Expect(labels).Should(gomega.Equal(map[string]string{"logging": "enabled"}))
+
+	// The components without a runtime get the default metadata
+	annotations, labels = isvcConfig.LogRouting.GetPodMetadata("")
+	g.Expect(annotations).Should(gomega.Equal(map[string]string{"fluentbit.io/parser": "json"}))
+	g.Expect(labels).Should(gomega.Equal(map[string]string{"logging": "enabled"}))
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,6 +34,84 @@ type InferenceServiceSpec struct {
 	// transformer service calls to predictor service.
 	// +optional
 	Transformer *TransformerSpec `json:"transformer,omitempty"`
+	// Overlays defines the settings of each deployment stage, e.g. production, applied on top of the spec by the
+	// defaulter. The stage is given by the serving.kserve.io/deployment-stage label of the namespace or else by the
+	// --deployment-stage flag of the controller.
+	// +optional
+	Overlays map[string]InferenceServiceOverlay `json:"overlays,omitempty"`
+	// SmokeTest defines the request sent through the external route after every new revision becomes ready and the
+	// response expected from it, the result is set in the SmokeTestPassed condition
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+}
+
+// SmokeTestSpec defines the request of the smoke test and its expected response. The smoke test reaches the latest ready
+// revision of the predictor, or of the transformer when the InferenceService has a transformer. With a canary traffic
+// percent, the canary header of the serving.kserve.io/canary-header annotation is sent with the request and the canary
+// receives no traffic until the smoke test passed on it.
+type SmokeTestSpec struct {
+	// Path of the request, defaults to the predict path of the v1 protocol, /v1/models/<name>:predict
+	// +optional
+	Path string `json:"path,omitempty"`
+	// JSON payload of the POST request
+	Payload string `json:"payload"`
+	// Status code of the response, defaults to 200
+	// +optional
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	ExpectedStatus *int `json:"expectedStatus,omitempty"`
+	// Matcher of the body of the response, any body is accepted when not set
+	// +optional
+	ExpectedResponse *SmokeTestResponseMatcher `json:"expectedResponse,omitempty"`
+	// Number of seconds to wait for the response, defaults to 30
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// SmokeTestResponseMatcher defines the body of the response expected from the smoke test, all the conditions and the
+// pattern must match
+type SmokeTestResponseMatcher struct {
+	// Paths which must exist in the JSON body, in the GJSON syntax like the conditions of the InferenceGraph steps,
+	// e.g. predictions.0 or predictions.#(==1)
+	// +optional
+	Conditions []string `json:"conditions,omitempty"`
+	// Regular expression, in the RE2 syntax, matching a part of the body
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// InferenceServiceOverlay defines the settings of a deployment stage, the settings of the predictor are given inline
+type InferenceServiceOverlay struct {
+	ComponentOverlay `json:",inline"`
+	// Settings of the explainer
+	// +optional
+	Explainer *ComponentOverlay `json:"explainer,omitempty"`
+	// Settings of the transformer
+	// +optional
+	Transformer *ComponentOverlay `json:"transformer,omitempty"`
+}
+
+// ComponentOverlay defines the settings of a component replaced in a deployment stage, the fields which are not set
+// keep the values of the spec
+type ComponentOverlay struct {
+	// Minimum number of replicas
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int `json:"minReplicas,omitempty"`
+	// Maximum number of replicas for autoscaling
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int `json:"maxReplicas,omitempty"`
+	// Integer target value of the metric type the autoscaler watches for
+	// +optional
+	ScaleTarget *int `json:"scaleTarget,omitempty"`
+	// Resources of the container, the requests and the limits replace the ones of the same resource names
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// Node selector of the pods, merged into the node selector of the spec
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // LoggerType controls the scope of log publishing
@@ -49,6 +128,22 @@ const (
 	LogResponse LoggerType = "response"
 )
 
+// LoggerDeliveryType controls the delivery guarantee of the logged events
+// +kubebuilder:validation:Enum=at-most-once;at-least-once
+type LoggerDeliveryType string
+
+// LoggerDeliveryType Enum
+const (
+	// Logger delivery sending every event once
+	LogAtMostOnce LoggerDeliveryType = "at-most-once"
+	// Logger delivery sending the events again until the sink acknowledges them
+	LogAtLeastOnce LoggerDeliveryType = "at-least-once"
+)
+
+// LoggerResponseCode is a response status code, e.g. 200, or a class of response status codes, e.g. 5xx
+// +kubebuilder:validation:Pattern=`^[1-5]([0-9]{2}|xx)$`
+type LoggerResponseCode string
+
 // LoggerSpec specifies optional payload logging available for all components
 type LoggerSpec struct {
 	// URL to send logging events
@@ -61,6 +156,51 @@ type LoggerSpec struct {
 	// - "response": log only response <br />
 	// +optional
 	Mode LoggerType `json:"mode,omitempty"`
+	// Specifies the delivery guarantee of the logged events. <br />
+	// Valid values are: <br />
+	// - "at-most-once" (default): send every event once; <br />
+	// - "at-least-once": send the events again until the sink acknowledges them with a 2xx status, the events carry
+	// an idempotency key and the events of the same request are sent in order with the request id as partition key <br />
+	// +optional
+	Delivery LoggerDeliveryType `json:"delivery,omitempty"`
+	// Specifies the percentage of the requests whose payloads are logged, between 0 and 100, defaults to 100. The
+	// requests are sampled before their payloads are read so the requests which are not logged are not buffered.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercent *int32 `json:"samplingPercent,omitempty"`
+	// Specifies the request header opting the requests out of the logging when its value is true, e.g. for the
+	// requests carrying sensitive payloads
+	// +optional
+	OptOutHeader string `json:"optOutHeader,omitempty"`
+	// Specifies the response status codes, e.g. 200, or classes, e.g. 5xx, of the requests whose request and response
+	// are logged. Only the requests answered with a 200 have their response logged when empty.
+	// +optional
+	ResponseCodes []LoggerResponseCode `json:"responseCodes,omitempty"`
+	// Specifies the values masked in the JSON payloads before the events are emitted, e.g. the personal data
+	// +optional
+	Redact *LoggerRedactSpec `json:"redact,omitempty"`
+}
+
+// LoggerRedactSpec specifies the values of the JSON payloads masked before the events are emitted. The events whose
+// payload cannot be redacted, such as the payloads which are not JSON, are dropped so they are never logged unmasked.
+type LoggerRedactSpec struct {
+	// JSON paths of the values replaced with the mask, e.g. $.instances[*].email or $.inputs[0].data. A path starts
+	// with $ followed by object fields, .name or .*, and array items, [0] or [*]. The objects and the arrays are
+	// masked as a whole.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+	// Regular expressions, in the RE2 syntax, of the substrings of the string values replaced with the mask, e.g.
+	// [0-9]{3}-[0-9]{2}-[0-9]{4} for the social security numbers
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+	// Names of the custom redactors compiled into the agent with the logger.RegisterRedactor function, applied after
+	// the paths and the patterns
+	// +optional
+	Redactors []string `json:"redactors,omitempty"`
+	// Value replacing the masked values, defaults to [REDACTED]
+	// +optional
+	Mask string `json:"mask,omitempty"`
 }
 
 // Batcher specifies optional payload batching available for all components
@@ -74,6 +214,52 @@ type Batcher struct {
 	// Specifies the timeout of a batch
 	// +optional
 	Timeout *int `json:"timeout,omitempty"`
+	// Specifies the p99 latency in milliseconds of the model server the batch size is adapted to. The batch size is
+	// decreased while the p99 latency of the batches is above the target and increased again, up to maxBatchSize,
+	// once it is below. The batch size is fixed to maxBatchSize when empty.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetP99Latency *int `json:"targetP99Latency,omitempty"`
+	// Specifies the request header sending the requests straight to the model server, without waiting for a batch,
+	// when its value is true, e.g. for the latency sensitive requests
+	// +optional
+	PriorityHeader string `json:"priorityHeader,omitempty"`
+}
+
+// RateLimit specifies the token bucket limiting the rate of the requests sent to each replica of a component
+type RateLimit struct {
+	// Specifies the number of requests per second allowed by each replica
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int `json:"requestsPerSecond"`
+	// Specifies the number of requests allowed above the rate, defaults to the rate
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Burst *int `json:"burst,omitempty"`
+}
+
+// TrafficMirror specifies the share of the requests of a component mirrored to its latest ready revision while the
+// traffic is still served by the last rolled out revision
+type TrafficMirror struct {
+	// Specifies the percentage of the requests mirrored to the latest ready revision, defaults to 100
+	// +optional
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=0
+	Percent *int64 `json:"percent,omitempty"`
+}
+
+// RetryPolicy specifies the retries of the requests routed to a component by the ingress
+type RetryPolicy struct {
+	// Specifies the number of retries of a request, 0 disables the retries
+	// +kubebuilder:validation:Minimum=0
+	Attempts int32 `json:"attempts"`
+	// Specifies the number of seconds to wait for each attempt, defaults to the timeout of the component
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PerTryTimeoutSeconds *int64 `json:"perTryTimeout,omitempty"`
+	// Specifies the comma separated conditions the requests are retried on, e.g. 5xx,connect-failure,reset
+	// (https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/router_filter#x-envoy-retry-on)
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
 }
 
 // InferenceService is the Schema for the InferenceServices API
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -24,6 +26,7 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,6 +40,9 @@ var (
 	}
 	// logger for the mutating webhook.
 	mutatorLogger = logf.Log.WithName("inferenceservice-v1beta1-mutating-webhook")
+	// DefaultDeploymentStage is the deployment stage of the namespaces without the serving.kserve.io/deployment-stage
+	// label, set with the --deployment-stage flag of the controller
+	DefaultDeploymentStage string
 )
 
 // +kubebuilder:webhook:path=/mutate-inferenceservices,mutating=true,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,verbs=create;update,versions=v1beta1,name=inferenceservice.kserve-webhook-server.defaulter
@@ -76,7 +82,168 @@ func (isvc *InferenceService) Default() {
 	if err != nil {
 		panic(err)
 	}
+	namespace := &v1.Namespace{}
+	if err := cli.Get(context.TODO(), types.NamespacedName{Name: isvc.Namespace}, namespace); err != nil {
+		panic(err)
+	}
+	// The overlay is applied first so that the resources it sets are completed with the default resources
+	isvc.ApplyOverlay(DeploymentStage(namespace))
 	isvc.DefaultInferenceService(configMap, deployConfig)
+	if err := isvc.DefaultFromNamespace(namespace); err != nil {
+		panic(err)
+	}
+}
+
+// DeploymentStage returns the deployment stage of the namespace given by its serving.kserve.io/deployment-stage label,
+// or else the DefaultDeploymentStage
+func DeploymentStage(namespace *v1.Namespace) string {
+	if stage, ok := namespace.Labels[constants.DeploymentStageLabelKey]; ok {
+		return stage
+	}
+	return DefaultDeploymentStage
+}
+
+// ApplyOverlay applies the overlay of the deployment stage on top of the spec, so that a single manifest promoted
+// from dev to production gets the settings of each stage, and records the stage in the
+// serving.kserve.io/applied-overlay annotation. The spec is kept as is when it has no overlay for the stage.
+func (isvc *InferenceService) ApplyOverlay(stage string) {
+	overlay, ok := isvc.Spec.Overlays[stage]
+	if stage == "" || !ok {
+		return
+	}
+	predictor := &isvc.Spec.Predictor
+	var container *v1.Container
+	if implementation := predictor.GetPredictorImplementation(); implementation != nil {
+		container = (*implementation).GetContainer(isvc.ObjectMeta, &predictor.ComponentExtensionSpec, nil)
+	}
+	overlay.ComponentOverlay.apply(&predictor.ComponentExtensionSpec, &predictor.PodSpec, container)
+	if explainer := isvc.Spec.Explainer; explainer != nil && overlay.Explainer != nil {
+		var container *v1.Container
+		switch {
+		case explainer.Alibi != nil:
+			container = &explainer.Alibi.Container
+		case explainer.AIX != nil:
+			container = &explainer.AIX.Container
+		case explainer.ART != nil:
+			container = &explainer.ART.Container
+		case len(explainer.Containers) > 0:
+			container = &explainer.Containers[0]
+		}
+		overlay.Explainer.apply(&explainer.ComponentExtensionSpec, &explainer.PodSpec, container)
+	}
+	if transformer := isvc.Spec.Transformer; transformer != nil && overlay.Transformer != nil {
+		var container *v1.Container
+		if len(transformer.Containers) > 0 {
+			container = &transformer.Containers[0]
+		}
+		overlay.Transformer.apply(&transformer.ComponentExtensionSpec, &transformer.PodSpec, container)
+	}
+	if isvc.Annotations == nil {
+		isvc.Annotations = map[string]string{}
+	}
+	isvc.Annotations[constants.AppliedOverlayAnnotationKey] = stage
+}
+
+// apply replaces the settings of the component set by the overlay
+func (o *ComponentOverlay) apply(extensions *ComponentExtensionSpec, podSpec *PodSpec, container *v1.Container) {
+	if o.MinReplicas != nil {
+		minReplicas := *o.MinReplicas
+		extensions.MinReplicas = &minReplicas
+	}
+	if o.MaxReplicas != nil {
+		extensions.MaxReplicas = *o.MaxReplicas
+	}
+	if o.ScaleTarget != nil {
+		scaleTarget := *o.ScaleTarget
+		extensions.ScaleTarget = &scaleTarget
+	}
+	if o.Resources != nil && container != nil {
+		if len(o.Resources.Requests) > 0 && container.Resources.Requests == nil {
+			container.Resources.Requests = v1.ResourceList{}
+		}
+		for name, quantity := range o.Resources.Requests {
+			container.Resources.Requests[name] = quantity.DeepCopy()
+		}
+		if len(o.Resources.Limits) > 0 && container.Resources.Limits == nil {
+			container.Resources.Limits = v1.ResourceList{}
+		}
+		for name, quantity := range o.Resources.Limits {
+			container.Resources.Limits[name] = quantity.DeepCopy()
+		}
+	}
+	if len(o.NodeSelector) > 0 && podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	for key, value := range o.NodeSelector {
+		podSpec.NodeSelector[key] = value
+	}
+}
+
+// DefaultFromNamespace merges the logger and batcher defaults the platform admins set in the annotations of the
+// namespace into the predictor, the fields set on the InferenceService take precedence. The InferenceServices which
+// do not set a logger or a batcher get the ones of the namespace.
+func (isvc *InferenceService) DefaultFromNamespace(namespace *v1.Namespace) error {
+	if value, ok := namespace.Annotations[constants.NamespaceLoggerDefaultsAnnotationKey]; ok {
+		defaults := &LoggerSpec{}
+		if err := json.Unmarshal([]byte(value), defaults); err != nil {
+			return fmt.Errorf(InvalidNamespaceDefaultsError, constants.NamespaceLoggerDefaultsAnnotationKey,
+				namespace.Name, err)
+		}
+		logger := isvc.Spec.Predictor.Logger
+		if logger == nil {
+			isvc.Spec.Predictor.Logger = defaults
+		} else {
+			if logger.URL == nil {
+				logger.URL = defaults.URL
+			}
+			if logger.Mode == "" {
+				logger.Mode = defaults.Mode
+			}
+			if logger.Delivery == "" {
+				logger.Delivery = defaults.Delivery
+			}
+			if logger.SamplingPercent == nil {
+				logger.SamplingPercent = defaults.SamplingPercent
+			}
+			if logger.OptOutHeader == "" {
+				logger.OptOutHeader = defaults.OptOutHeader
+			}
+			if logger.ResponseCodes == nil {
+				logger.ResponseCodes = defaults.ResponseCodes
+			}
+			if logger.Redact == nil {
+				logger.Redact = defaults.Redact
+			}
+		}
+	}
+	if value, ok := namespace.Annotations[constants.NamespaceBatcherDefaultsAnnotationKey]; ok {
+		defaults := &Batcher{}
+		if err := json.Unmarshal([]byte(value), defaults); err != nil {
+			return fmt.Errorf(InvalidNamespaceDefaultsError, constants.NamespaceBatcherDefaultsAnnotationKey,
+				namespace.Name, err)
+		}
+		batcher := isvc.Spec.Predictor.Batcher
+		if batcher == nil {
+			isvc.Spec.Predictor.Batcher = defaults
+		} else {
+			if batcher.MaxBatchSize == nil {
+				batcher.MaxBatchSize = defaults.MaxBatchSize
+			}
+			if batcher.MaxLatency == nil {
+				batcher.MaxLatency = defaults.MaxLatency
+			}
+			if batcher.Timeout == nil {
+				batcher.Timeout = defaults.Timeout
+			}
+			if batcher.TargetP99Latency == nil {
+				batcher.TargetP99Latency = defaults.TargetP99Latency
+			}
+			if batcher.PriorityHeader == "" {
+				batcher.PriorityHeader = defaults.PriorityHeader
+			}
+		}
+	}
+	return nil
 }
 
 func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesConfig, deployConfig *DeployConfig) {
@@ -26,6 +26,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -503,3 +504,147 @@ func TestMlServerDefaults(t *testing.T) {
 		g.Expect(scenario.isvc.ObjectMeta.Labels).To(scenario.matcher["labels"])
 	}
 }
+
+func TestDefaultFromNamespace(t *testing.T) {
+	namespace := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			Annotations: map[string]string{
+				constants.NamespaceLoggerDefaultsAnnotationKey:  `{"url": "http://compliance-sink.audit", "mode": "all"}`,
+				constants.NamespaceBatcherDefaultsAnnotationKey: `{"maxBatchSize": 32, "maxLatency": 500}`,
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		predictor       PredictorSpec
+		expectedLogger  *LoggerSpec
+		expectedBatcher *Batcher
+	}{
+		"NamespaceDefaults": {
+			predictor:       PredictorSpec{},
+			expectedLogger:  &LoggerSpec{URL: proto.String("http://compliance-sink.audit"), Mode: LogAll},
+			expectedBatcher: &Batcher{MaxBatchSize: GetIntReference(32), MaxLatency: GetIntReference(500)},
+		},
+		"InferenceServiceOverrides": {
+			predictor: PredictorSpec{
+				ComponentExtensionSpec: ComponentExtensionSpec{
+					Logger:  &LoggerSpec{Mode: LogRequest, Delivery: LogAtLeastOnce},
+					Batcher: &Batcher{MaxBatchSize: GetIntReference(8), Timeout: GetIntReference(60)},
+				},
+			},
+			expectedLogger: &LoggerSpec{URL: proto.String("http://compliance-sink.audit"), Mode: LogRequest,
+				Delivery: LogAtLeastOnce},
+			expectedBatcher: &Batcher{MaxBatchSize: GetIntReference(8), MaxLatency: GetIntReference(500),
+				Timeout: GetIntReference(60)},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       InferenceServiceSpec{Predictor: scenario.predictor},
+			}
+			g.Expect(isvc.DefaultFromNamespace(namespace)).To(gomega.Succeed())
+			g.Expect(isvc.Spec.Predictor.Logger).To(gomega.Equal(scenario.expectedLogger))
+			g.Expect(isvc.Spec.Predictor.Batcher).To(gomega.Equal(scenario.expectedBatcher))
+		})
+	}
+}
+
+func TestDefaultFromNamespaceInvalidDefaults(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	namespace := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "default",
+			Annotations: map[string]string{constants.NamespaceLoggerDefaultsAnnotationKey: "http://compliance-sink.audit"},
+		},
+	}
+	isvc := InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	g.Expect(isvc.DefaultFromNamespace(namespace)).ToNot(gomega.Succeed())
+	g.Expect(isvc.Spec.Predictor.Logger).To(gomega.BeNil())
+}
+
+func TestApplyOverlay(t *testing.T) {
+	newInferenceService := func() *InferenceService {
+		return &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				Predictor: PredictorSpec{
+					SKLearn: &SKLearnSpec{
+						PredictorExtensionSpec: PredictorExtensionSpec{
+							StorageURI: proto.String("gs://kfserving-examples/models/sklearn/1.0/model"),
+							Container: v1.Container{
+								Resources: v1.ResourceRequirements{
+									Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+								},
+							},
+						},
+					},
+					ComponentExtensionSpec: ComponentExtensionSpec{MinReplicas: GetIntReference(0), MaxReplicas: 1},
+					PodSpec:                PodSpec{NodeSelector: map[string]string{"pool": "default"}},
+				},
+				Transformer: &TransformerSpec{
+					PodSpec: PodSpec{Containers: []v1.Container{{Name: "kserve-container", Image: "transformer:latest"}}},
+				},
+				Overlays: map[string]InferenceServiceOverlay{
+					"production": {
+						ComponentOverlay: ComponentOverlay{
+							MinReplicas: GetIntReference(3),
+							MaxReplicas: GetIntReference(10),
+							Resources: &v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+								Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+							},
+							NodeSelector: map[string]string{"zone": "a"},
+						},
+						Transformer: &ComponentOverlay{
+							MinReplicas: GetIntReference(2),
+							Resources: &v1.ResourceRequirements{
+								Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	isvc := newInferenceService()
+	isvc.ApplyOverlay("production")
+	g.Expect(isvc.Annotations[constants.AppliedOverlayAnnotationKey]).To(gomega.Equal("production"))
+	predictor := isvc.Spec.Predictor
+	g.Expect(*predictor.MinReplicas).To(gomega.Equal(3))
+	g.Expect(predictor.MaxReplicas).To(gomega.Equal(10))
+	g.Expect(predictor.ScaleTarget).To(gomega.BeNil())
+	// The resources and the node selector are merged into the ones of the spec
+	g.Expect(predictor.SKLearn.Resources).To(gomega.Equal(v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("4Gi")},
+		Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+	}))
+	g.Expect(predictor.NodeSelector).To(gomega.Equal(map[string]string{"pool": "default", "zone": "a"}))
+	transformer := isvc.Spec.Transformer
+	g.Expect(*transformer.MinReplicas).To(gomega.Equal(2))
+	g.Expect(transformer.Containers[0].Resources.Limits).To(gomega.Equal(v1.ResourceList{
+		v1.ResourceMemory: resource.MustParse("1Gi")}))
+	g.Expect(transformer.NodeSelector).To(gomega.BeNil())
+
+	// The spec is kept when the stage has no overlay
+	for _, stage := range []string{"", "dev"} {
+		isvc := newInferenceService()
+		isvc.ApplyOverlay(stage)
+		g.Expect(isvc.Annotations).ToNot(gomega.HaveKey(constants.AppliedOverlayAnnotationKey))
+		g.Expect(isvc.Spec).To(gomega.Equal(newInferenceService().Spec))
+	}
+}
+
+func TestDeploymentStage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer func(stage string) { DefaultDeploymentStage = stage }(DefaultDeploymentStage)
+	DefaultDeploymentStage = "staging"
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	g.Expect(DeploymentStage(namespace)).To(gomega.Equal("staging"))
+	namespace.Labels = map[string]string{constants.DeploymentStageLabelKey: "production"}
+	g.Expect(DeploymentStage(namespace)).To(gomega.Equal("production"))
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -48,8 +51,45 @@ type InferenceServiceStatus struct {
 	Components map[ComponentType]ComponentStatusSpec `json:"components,omitempty"`
 	// Model related statuses
 	ModelStatus ModelStatus `json:"modelStatus,omitempty"`
+	// The endpoint attributes below are meant for the infrastructure as code tools, e.g. Terraform or Crossplane, so
+	// they do not need to parse the urls, the components or the condition messages. Their names, formats and meanings
+	// are kept across the releases of the v1beta1 API, new attributes are only added.
+
+	// ExternalURL is the base url of the InferenceService from outside the cluster, without the path of the protocol
+	// +optional
+	ExternalURL *apis.URL `json:"externalURL,omitempty"`
+	// InternalURL is the base url of the InferenceService from within the cluster, without the path of the protocol
+	// +optional
+	InternalURL *apis.URL `json:"internalURL,omitempty"`
+	// GrpcURL is the url the gRPC clients connect to from outside the cluster, with TLS when its scheme is https.
+	// It is only set when the gRPC routes are enabled in the ingress config.
+	// +optional
+	GrpcURL *apis.URL `json:"grpcURL,omitempty"`
+	// AuthMode is the authentication the requests of the InferenceService require, None or ServiceAccountToken
+	// +optional
+	AuthMode AuthMode `json:"authMode,omitempty"`
+	// Revision identifies the revision of the predictor serving the requests, it is the name of the Knative revision
+	// in Serverless mode and the revision of the deployment in RawDeployment mode, it changes on every rollout
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// SmokeTestedRevision is the revision the last smoke test ran on, the SmokeTestPassed condition holds its result
+	// +optional
+	SmokeTestedRevision string `json:"smokeTestedRevision,omitempty"`
 }
 
+// AuthMode is the authentication the requests of the InferenceService require
+// +kubebuilder:validation:Enum=None;ServiceAccountToken
+type AuthMode string
+
+// AuthMode Enum
+const (
+	// AuthModeNone accepts the requests without credentials
+	AuthModeNone AuthMode = "None"
+	// AuthModeServiceAccountToken requires a service account token for the audience of the
+	// serving.kserve.io/token-audience annotation in the Authorization header
+	AuthModeServiceAccountToken AuthMode = "ServiceAccountToken"
+)
+
 // ComponentStatusSpec describes the state of the component
 type ComponentStatusSpec struct {
 	// Latest revision name that is in ready state
@@ -114,8 +154,23 @@ const (
 	TransformerReady apis.ConditionType = "TransformerReady"
 	// ExplainerReady is set when explainer has reported readiness.
 	ExplainerReady apis.ConditionType = "ExplainerReady"
+	// PredictorScalingReady is set to false while the autoscaler of the predictor can not scale it.
+	PredictorScalingReady apis.ConditionType = "PredictorScalingReady"
+	// TransformerScalingReady is set to false while the autoscaler of the transformer can not scale it.
+	TransformerScalingReady apis.ConditionType = "TransformerScalingReady"
+	// ExplainerScalingReady is set to false while the autoscaler of the explainer can not scale it.
+	ExplainerScalingReady apis.ConditionType = "ExplainerScalingReady"
 	// IngressReady is set when Ingress is created
 	IngressReady apis.ConditionType = "IngressReady"
+	// ConfigValid is set to false while the inferenceservice-config ConfigMap is invalid and the InferenceService
+	// is not reconciled
+	ConfigValid apis.ConditionType = "ConfigValid"
+	// StorageInitialized is set to false with the guidance to fix the storage uri or its credentials when the storage
+	// initializer failed permanently, the transient failures are retried
+	StorageInitialized apis.ConditionType = "StorageInitialized"
+	// SmokeTestPassed is set to the result of the smoke test of the SmokeTestedRevision, it does not change the
+	// readiness of the InferenceService
+	SmokeTestPassed apis.ConditionType = "SmokeTestPassed"
 )
 
 type ModelStatus struct {
@@ -134,6 +189,10 @@ type ModelStatus struct {
 	// Model copy information of the predictor's model.
 	// +optional
 	ModelCopies *ModelCopies `json:"copies,omitempty"`
+
+	// Memory raised by the OOM remediation policy after the predictor's model server was OOMKilled.
+	// +optional
+	OOMRemediation *OOMRemediation `json:"oomRemediation,omitempty"`
 }
 
 type ModelRevisionStates struct {
@@ -153,6 +212,17 @@ type ModelCopies struct {
 	TotalCopies int `json:"totalCopies,omitempty"`
 }
 
+// OOMRemediation records the memory raised by the OOM remediation policy of the predictor
+type OOMRemediation struct {
+	// Memory request and limit of the kserve container after the last remediation
+	Memory string `json:"memory"`
+	// Number of times the memory was raised
+	Count int `json:"count"`
+	// Time of the last remediation
+	// +optional
+	LastRemediationTime *metav1.Time `json:"lastRemediationTime,omitempty"`
+}
+
 // TransitionStatus enum
 // +kubebuilder:validation:Enum="";UpToDate;InProgress;BlockedByFailedLoad;InvalidSpec
 type TransitionStatus string
@@ -188,7 +258,7 @@ const (
 )
 
 // FailureReason enum
-// +kubebuilder:validation:Enum=ModelLoadFailed;RuntimeUnhealthy;RuntimeDisabled;NoSupportingRuntime;RuntimeNotRecognized;InvalidPredictorSpec
+// +kubebuilder:validation:Enum=ModelLoadFailed;RuntimeUnhealthy;RuntimeDisabled;NoSupportingRuntime;RuntimeNotRecognized;InvalidPredictorSpec;StorageUnavailable;ModelNotFound;StorageAccessDenied;InvalidModelArchive
 type FailureReason string
 
 // FailureReason enum values
@@ -205,8 +275,30 @@ const (
 	RuntimeNotRecognized FailureReason = "RuntimeNotRecognized"
 	// The current Predictor Spec is invalid or unsupported
 	InvalidPredictorSpec FailureReason = "InvalidPredictorSpec"
+	// The storage initializer failed to reach the storage, e.g. a network error or a 5xx response, it is retried
+	StorageUnavailable FailureReason = "StorageUnavailable"
+	// The storage uri does not exist or has no files
+	ModelNotFound FailureReason = "ModelNotFound"
+	// The storage initializer is not allowed to read the storage uri
+	StorageAccessDenied FailureReason = "StorageAccessDenied"
+	// The downloaded model archive is corrupt
+	InvalidModelArchive FailureReason = "InvalidModelArchive"
 )
 
+// storageInitializerFailures maps the exit codes of the permanent failures of the storage initializer to their reason
+// and the guidance to fix them
+var storageInitializerFailures = map[int32]struct {
+	reason   FailureReason
+	guidance string
+}{
+	constants.StorageInitializerNotFoundExitCode: {ModelNotFound,
+		"The storage uri does not exist or has no files, check the storageUri of the predictor"},
+	constants.StorageInitializerAccessDeniedExitCode: {StorageAccessDenied,
+		"The storage uri is not readable with the credentials of the predictor, check the storage secret and the service account of the predictor"},
+	constants.StorageInitializerCorruptModelExitCode: {InvalidModelArchive,
+		"The downloaded model is corrupt, upload the model or the model archive again"},
+}
+
 type FailureInfo struct {
 	// Name of component to which the failure relates (usually Pod name)
 	//+optional
@@ -226,6 +318,12 @@ type FailureInfo struct {
 	// Exit status from the last termination of the container
 	//+optional
 	ExitCode int32 `json:"exitCode,omitempty"`
+	// Reason of the last termination of the container, e.g. OOMKilled
+	//+optional
+	TerminationReason string `json:"terminationReason,omitempty"`
+	// Tail of the logs of the last terminated container, bounded to a few KiB
+	//+optional
+	LogTail string `json:"logTail,omitempty"`
 }
 
 var conditionsMap = map[ComponentType]apis.ConditionType{
@@ -246,6 +344,12 @@ var configurationConditionsMap = map[ComponentType]apis.ConditionType{
 	TransformerComponent: TransformerConfigurationReady,
 }
 
+var scalingConditionsMap = map[ComponentType]apis.ConditionType{
+	PredictorComponent:   PredictorScalingReady,
+	ExplainerComponent:   ExplainerScalingReady,
+	TransformerComponent: TransformerScalingReady,
+}
+
 // InferenceService Ready condition is depending on predictor and route readiness condition
 var conditionSet = apis.NewLivingConditionSet(
 	PredictorReady,
@@ -273,6 +377,34 @@ func (ss *InferenceServiceStatus) IsConditionReady(t apis.ConditionType) bool {
 	return conditionSet.Manage(ss).GetCondition(t) != nil && conditionSet.Manage(ss).GetCondition(t).Status == v1.ConditionTrue
 }
 
+// SmokeTestPassed reports whether the smoke test passed on the revision
+func (ss *InferenceServiceStatus) SmokeTestPassed(revision string) bool {
+	return revision != "" && ss.SmokeTestedRevision == revision && ss.IsConditionReady(SmokeTestPassed)
+}
+
+// SmokeTestComponent returns the component the smoke test reaches, the transformer when the InferenceService has one
+// like the route of the canary header
+func SmokeTestComponent(isvc *InferenceService) ComponentType {
+	if isvc.Spec.Transformer != nil {
+		return TransformerComponent
+	}
+	return PredictorComponent
+}
+
+// SmokeTestRevision returns the revision the smoke test runs on, the latest ready revision of the component in
+// Serverless mode and its latest deployment revision once ready in RawDeployment mode
+func SmokeTestRevision(isvc *InferenceService) string {
+	component := SmokeTestComponent(isvc)
+	status := isvc.Status.Components[component]
+	if status.LatestReadyRevision != "" {
+		return status.LatestReadyRevision
+	}
+	if isvc.Status.IsConditionReady(conditionsMap[component]) {
+		return status.LatestCreatedRevision
+	}
+	return ""
+}
+
 func (ss *InferenceServiceStatus) PropagateRawStatus(
 	component ComponentType,
 	deployment *appsv1.Deployment,
@@ -295,6 +427,101 @@ func (ss *InferenceServiceStatus) PropagateRawStatus(
 	ss.Components[component] = statusSpec
 }
 
+// PropagateEndpointStatus sets the stable endpoint attributes from the url and the address of the InferenceService, the
+// status of its predictor and its annotations, the gRPC url is nil when the gRPC routes are not enabled
+func (ss *InferenceServiceStatus) PropagateEndpointStatus(annotations map[string]string, grpcURL *apis.URL) {
+	ss.ExternalURL = baseURL(ss.URL)
+	if ss.Address != nil {
+		ss.InternalURL = baseURL(ss.Address.URL)
+	}
+	ss.GrpcURL = grpcURL
+	ss.AuthMode = AuthModeNone
+	if _, ok := annotations[constants.TokenAudienceAnnotationKey]; ok {
+		ss.AuthMode = AuthModeServiceAccountToken
+	}
+	if predictor, ok := ss.Components[PredictorComponent]; ok {
+		// The revisions are rolled out in Serverless mode, the deployments only record their created revision
+		switch {
+		case predictor.LatestRolledoutRevision != "":
+			ss.Revision = predictor.LatestRolledoutRevision
+		case predictor.LatestReadyRevision != "":
+			ss.Revision = predictor.LatestReadyRevision
+		default:
+			ss.Revision = predictor.LatestCreatedRevision
+		}
+	}
+}
+
+// baseURL returns the url without its path
+func baseURL(url *apis.URL) *apis.URL {
+	if url == nil {
+		return nil
+	}
+	return &apis.URL{Scheme: url.Scheme, Host: url.Host}
+}
+
+// PropagateScalingStatus surfaces the conditions of the HPA blocking the scaling of the component, so the users do not
+// need to inspect the HPA. The scaling condition does not change the readiness of the InferenceService.
+func (ss *InferenceServiceStatus) PropagateScalingStatus(component ComponentType,
+	hpaStatus *autoscalingv2beta2.HorizontalPodAutoscalerStatus) {
+	if hpaStatus == nil || len(hpaStatus.Conditions) == 0 {
+		// The HPA has not evaluated the metrics yet
+		return
+	}
+	scalingCondition := &apis.Condition{Status: v1.ConditionTrue}
+	for _, condition := range hpaStatus.Conditions {
+		blocked := false
+		switch condition.Type {
+		case autoscalingv2beta2.AbleToScale, autoscalingv2beta2.ScalingActive:
+			// e.g. the metrics can not be fetched
+			blocked = condition.Status == v1.ConditionFalse
+		case autoscalingv2beta2.ScalingLimited:
+			// Staying at the min replicas is the steady state of an idle component, only reaching the max replicas
+			// blocks the scaling
+			blocked = condition.Status == v1.ConditionTrue && condition.Reason == "TooManyReplicas"
+		}
+		if blocked {
+			scalingCondition = &apis.Condition{
+				Status:  v1.ConditionFalse,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+			break
+		}
+	}
+	ss.SetCondition(scalingConditionsMap[component], scalingCondition)
+}
+
+// PropagateScaledObjectStatus surfaces the conditions of the KEDA ScaledObject blocking the scaling of the component,
+// like PropagateScalingStatus for the HPA. The Active condition is false while the component is idle.
+func (ss *InferenceServiceStatus) PropagateScaledObjectStatus(component ComponentType, conditions []metav1.Condition) {
+	if len(conditions) == 0 {
+		// KEDA has not reconciled the ScaledObject yet
+		return
+	}
+	scalingCondition := &apis.Condition{Status: v1.ConditionTrue}
+	for _, condition := range conditions {
+		blocked := false
+		switch condition.Type {
+		case "Ready":
+			// e.g. the triggers can not be read
+			blocked = condition.Status == metav1.ConditionFalse
+		case "Fallback":
+			// The metrics of the triggers can not be fetched, the replicas are set to the fallback replicas
+			blocked = condition.Status == metav1.ConditionTrue
+		}
+		if blocked {
+			scalingCondition = &apis.Condition{
+				Status:  v1.ConditionFalse,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+			break
+		}
+	}
+	ss.SetCondition(scalingConditionsMap[component], scalingCondition)
+}
+
 func getDeploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *apis.Condition {
 	condition := apis.Condition{}
 	for _, con := range deployment.Status.Conditions {
@@ -447,6 +674,21 @@ func (ss *InferenceServiceStatus) SetModelFailureInfo(info *FailureInfo) bool {
 	return true
 }
 
+// terminationFailureInfo describes the failure of the model server from the last termination of its container
+func terminationFailureInfo(pod *v1.Pod, terminated *v1.ContainerStateTerminated) *FailureInfo {
+	info := &FailureInfo{
+		Location:          pod.Name,
+		Reason:            ModelLoadFailed,
+		Message:           terminated.Message,
+		ExitCode:          terminated.ExitCode,
+		TerminationReason: terminated.Reason,
+	}
+	if !terminated.FinishedAt.IsZero() {
+		info.Time = terminated.FinishedAt.DeepCopy()
+	}
+	return info
+}
+
 func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatusSpec, podList *v1.PodList, rawDeplyment bool) {
 	// Check at least one pod is running for the latest revision of inferenceservice
 	totalCopies := len(podList.Items)
@@ -458,9 +700,11 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 	// For serverless deployment, the latest created revision and the latest ready revision should be equal
 	if ss.IsReady() {
 		if rawDeplyment {
+			ss.ClearCondition(StorageInitialized)
 			ss.UpdateModelRevisionStates(Loaded, totalCopies, nil)
 			return
 		} else if statusSpec.LatestCreatedRevision == statusSpec.LatestReadyRevision {
+			ss.ClearCondition(StorageInitialized)
 			ss.UpdateModelRevisionStates(Loaded, totalCopies, nil)
 			return
 		}
@@ -471,48 +715,73 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 	for _, cs := range podList.Items[0].Status.InitContainerStatuses {
 		if cs.Name == constants.StorageInitializerContainerName {
 			if cs.State.Running != nil {
+				ss.ClearCondition(StorageInitialized)
 				ss.UpdateModelRevisionStates(Loading, totalCopies, nil)
 				return
 			} else if cs.State.Terminated != nil &&
 				cs.State.Terminated.Reason == constants.StateReasonError {
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.State.Terminated.Message,
-					ExitCode: cs.State.Terminated.ExitCode,
-				})
+				ss.propagateStorageInitializerFailure(totalCopies, cs.State.Terminated)
 				return
 			} else if cs.State.Waiting != nil &&
-				cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff {
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.LastTerminationState.Terminated.Message,
-					ExitCode: cs.LastTerminationState.Terminated.ExitCode,
-				})
+				cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff &&
+				cs.LastTerminationState.Terminated != nil {
+				ss.propagateStorageInitializerFailure(totalCopies, cs.LastTerminationState.Terminated)
 				return
 			}
 		}
 	}
+	// The model is downloaded once the storage initializer completed
+	ss.ClearCondition(StorageInitialized)
 	// If the kserve container is terminated due to error or crashloopbackoff, update model
 	// state to 'ModelLoadFailed' with failure info.
 	for _, cs := range podList.Items[0].Status.ContainerStatuses {
 		if cs.Name == constants.InferenceServiceContainerName {
 			if cs.State.Terminated != nil &&
 				cs.State.Terminated.Reason == constants.StateReasonError {
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.State.Terminated.Message,
-					ExitCode: cs.State.Terminated.ExitCode,
-				})
+				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies,
+					terminationFailureInfo(&podList.Items[0], cs.State.Terminated))
 			} else if cs.State.Waiting != nil &&
-				cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff {
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.LastTerminationState.Terminated.Message,
-					ExitCode: cs.LastTerminationState.Terminated.ExitCode,
-				})
+				cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff &&
+				cs.LastTerminationState.Terminated != nil {
+				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies,
+					terminationFailureInfo(&podList.Items[0], cs.LastTerminationState.Terminated))
 			} else {
 				ss.UpdateModelRevisionStates(Pending, totalCopies, nil)
 			}
 		}
 	}
 }
+
+// propagateStorageInitializerFailure updates the model state from the exit code of the storage initializer, the model
+// is still loading while the transient failures are retried, the permanent failures set the StorageInitialized
+// condition to false at once with the guidance to fix them.
+func (ss *InferenceServiceStatus) propagateStorageInitializerFailure(totalCopies int,
+	terminated *v1.ContainerStateTerminated) {
+	info := &FailureInfo{
+		Reason:   ModelLoadFailed,
+		Message:  terminated.Message,
+		ExitCode: terminated.ExitCode,
+	}
+	if terminated.ExitCode == constants.StorageInitializerTransientExitCode {
+		info.Reason = StorageUnavailable
+		// The time of the first transient failure is kept so the controller backs off while it lasts
+		if last := ss.ModelStatus.LastFailureInfo; last != nil && last.Reason == StorageUnavailable && last.Time != nil {
+			info.Time = last.Time.DeepCopy()
+		} else {
+			now := metav1.Now()
+			info.Time = &now
+		}
+		ss.UpdateModelRevisionStates(Loading, totalCopies, info)
+		return
+	}
+	if failure, ok := storageInitializerFailures[terminated.ExitCode]; ok {
+		info.Reason = failure.reason
+		ss.SetCondition(StorageInitialized, &apis.Condition{
+			Type:    StorageInitialized,
+			Status:  v1.ConditionFalse,
+			Reason:  string(failure.reason),
+			Message: fmt.Sprintf("%s: %s", failure.guidance, strings.TrimSpace(terminated.Message)),
+		})
+	}
+	ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, info)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -219,6 +220,170 @@ func TestPropagateRawStatus(t *testing.T) {
 	}
 }
 
+func TestPropagateScalingStatus(t *testing.T) {
+	cases := map[string]struct {
+		conditions []autoscalingv2beta2.HorizontalPodAutoscalerCondition
+		expected   *apis.Condition
+	}{
+		"NotEvaluated": {
+			expected: nil,
+		},
+		"Scaling": {
+			conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2beta2.AbleToScale, Status: v1.ConditionTrue, Reason: "ReadyForNewScale"},
+				{Type: autoscalingv2beta2.ScalingActive, Status: v1.ConditionTrue, Reason: "ValidMetricFound"},
+				{Type: autoscalingv2beta2.ScalingLimited, Status: v1.ConditionTrue, Reason: "TooFewReplicas"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionTrue,
+				Severity: apis.ConditionSeverityInfo},
+		},
+		"MetricsUnavailable": {
+			conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2beta2.AbleToScale, Status: v1.ConditionTrue, Reason: "SucceededGetScale"},
+				{Type: autoscalingv2beta2.ScalingActive, Status: v1.ConditionFalse, Reason: "FailedGetResourceMetric",
+					Message: "the HPA was unable to compute the replica count: unable to fetch metrics"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionFalse,
+				Reason: "FailedGetResourceMetric", Severity: apis.ConditionSeverityInfo,
+				Message: "the HPA was unable to compute the replica count: unable to fetch metrics"},
+		},
+		"MaxReplicas": {
+			conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2beta2.AbleToScale, Status: v1.ConditionTrue, Reason: "ReadyForNewScale"},
+				{Type: autoscalingv2beta2.ScalingActive, Status: v1.ConditionTrue, Reason: "ValidMetricFound"},
+				{Type: autoscalingv2beta2.ScalingLimited, Status: v1.ConditionTrue, Reason: "TooManyReplicas",
+					Message: "the desired replica count is more than the maximum replica count"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionFalse,
+				Reason: "TooManyReplicas", Severity: apis.ConditionSeverityInfo,
+				Message: "the desired replica count is more than the maximum replica count"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{}
+			status.InitializeConditions()
+			status.PropagateScalingStatus(PredictorComponent,
+				&autoscalingv2beta2.HorizontalPodAutoscalerStatus{Conditions: tc.conditions})
+			condition := status.GetCondition(PredictorScalingReady)
+			if tc.expected == nil {
+				g.Expect(condition).To(gomega.BeNil())
+				return
+			}
+			condition.LastTransitionTime = apis.VolatileTime{}
+			g.Expect(condition).To(gomega.Equal(tc.expected))
+			// The scaling condition does not change the readiness
+			g.Expect(status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionUnknown))
+		})
+	}
+}
+
+func TestPropagateScaledObjectStatus(t *testing.T) {
+	cases := map[string]struct {
+		conditions []metav1.Condition
+		expected   *apis.Condition
+	}{
+		"NotReconciled": {
+			expected: nil,
+		},
+		"Idle": {
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ScaledObjectReady"},
+				{Type: "Active", Status: metav1.ConditionFalse, Reason: "ScalerNotActive"},
+				{Type: "Fallback", Status: metav1.ConditionFalse, Reason: "NoFallbackFound"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionTrue,
+				Severity: apis.ConditionSeverityInfo},
+		},
+		"InvalidTriggers": {
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ScaledObjectCheckFailed",
+					Message: "Failed to ensure HPA is correctly created for ScaledObject"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionFalse,
+				Reason: "ScaledObjectCheckFailed", Severity: apis.ConditionSeverityInfo,
+				Message: "Failed to ensure HPA is correctly created for ScaledObject"},
+		},
+		"Fallback": {
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ScaledObjectReady"},
+				{Type: "Fallback", Status: metav1.ConditionTrue, Reason: "FallbackExists",
+					Message: "At least one trigger is falling back on this scaled object"},
+			},
+			expected: &apis.Condition{Type: PredictorScalingReady, Status: v1.ConditionFalse,
+				Reason: "FallbackExists", Severity: apis.ConditionSeverityInfo,
+				Message: "At least one trigger is falling back on this scaled object"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{}
+			status.InitializeConditions()
+			status.PropagateScaledObjectStatus(PredictorComponent, tc.conditions)
+			condition := status.GetCondition(PredictorScalingReady)
+			if tc.expected == nil {
+				g.Expect(condition).To(gomega.BeNil())
+				return
+			}
+			condition.LastTransitionTime = apis.VolatileTime{}
+			g.Expect(condition).To(gomega.Equal(tc.expected))
+		})
+	}
+}
+
+func TestPropagateEndpointStatus(t *testing.T) {
+	externalURL, _ := apis.ParseURL("https://sklearn-default.example.com")
+	internalURL, _ := apis.ParseURL("http://sklearn.default.svc.cluster.local/v1/models/sklearn:predict")
+	grpcURL, _ := apis.ParseURL("https://grpc-sklearn-default.example.com")
+	cases := map[string]struct {
+		annotations map[string]string
+		grpcURL     *apis.URL
+		predictor   ComponentStatusSpec
+		expected    InferenceServiceStatus
+	}{
+		"Serverless": {
+			annotations: map[string]string{constants.TokenAudienceAnnotationKey: "sklearn"},
+			grpcURL:     grpcURL,
+			predictor: ComponentStatusSpec{LatestReadyRevision: "sklearn-predictor-default-00002",
+				LatestRolledoutRevision: "sklearn-predictor-default-00001"},
+			expected: InferenceServiceStatus{
+				ExternalURL: &apis.URL{Scheme: "https", Host: "sklearn-default.example.com"},
+				InternalURL: &apis.URL{Scheme: "http", Host: "sklearn.default.svc.cluster.local"},
+				GrpcURL:     grpcURL,
+				AuthMode:    AuthModeServiceAccountToken,
+				Revision:    "sklearn-predictor-default-00001",
+			},
+		},
+		"RawDeployment": {
+			predictor: ComponentStatusSpec{LatestCreatedRevision: "3"},
+			expected: InferenceServiceStatus{
+				ExternalURL: &apis.URL{Scheme: "https", Host: "sklearn-default.example.com"},
+				InternalURL: &apis.URL{Scheme: "http", Host: "sklearn.default.svc.cluster.local"},
+				AuthMode:    AuthModeNone,
+				Revision:    "3",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{
+				URL:        externalURL,
+				Address:    &duckv1.Addressable{URL: internalURL},
+				Components: map[ComponentType]ComponentStatusSpec{PredictorComponent: tc.predictor},
+			}
+			status.PropagateEndpointStatus(tc.annotations, tc.grpcURL)
+			g.Expect(status.ExternalURL).To(gomega.Equal(tc.expected.ExternalURL))
+			g.Expect(status.InternalURL).To(gomega.Equal(tc.expected.InternalURL))
+			g.Expect(status.GrpcURL).To(gomega.Equal(tc.expected.GrpcURL))
+			g.Expect(status.AuthMode).To(gomega.Equal(tc.expected.AuthMode))
+			g.Expect(status.Revision).To(gomega.Equal(tc.expected.Revision))
+		})
+	}
+}
+
 func TestPropagateStatus(t *testing.T) {
 	parsedUrl, _ := url.Parse("http://test-predictor-default.default.example.com")
 	cases := []struct {
@@ -523,9 +688,11 @@ func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 			},
 			expectedTransitionStatus: BlockedByFailedLoad,
 			expectedFailureInfo: &FailureInfo{
-				Reason:   ModelLoadFailed,
-				Message:  "For testing",
-				ExitCode: 1,
+				Location:          constants.InferenceServiceContainerName,
+				Reason:            ModelLoadFailed,
+				Message:           "For testing",
+				ExitCode:          1,
+				TerminationReason: constants.StateReasonError,
 			},
 		},
 		"kserve container failed due to crash loopBackOff": {
@@ -604,9 +771,11 @@ func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 			},
 			expectedTransitionStatus: BlockedByFailedLoad,
 			expectedFailureInfo: &FailureInfo{
-				Reason:   ModelLoadFailed,
-				Message:  "For testing",
-				ExitCode: 1,
+				Location:          constants.InferenceServiceContainerName,
+				Reason:            ModelLoadFailed,
+				Message:           "For testing",
+				ExitCode:          1,
+				TerminationReason: constants.StateReasonCrashLoopBackOff,
 			},
 		},
 		"storage initializer failed due to an error": {
@@ -1226,3 +1395,118 @@ func TestInferenceServiceStatus_UpdateModelRevisionStates(t *testing.T) {
 		})
 	}
 }
+
+func TestInferenceServiceStatus_PropagateStorageInitializerFailure(t *testing.T) {
+	firstFailure := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	newPodList := func(exitCode int32) *v1.PodList {
+		return &v1.PodList{Items: []v1.Pod{{
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{{
+					Name: constants.StorageInitializerContainerName,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: constants.StateReasonCrashLoopBackOff},
+					},
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Reason:   constants.StateReasonError,
+							Message:  "No model found in models/iris\n",
+							ExitCode: exitCode,
+						},
+					},
+				}},
+			},
+		}}}
+	}
+	scenarios := map[string]struct {
+		exitCode            int32
+		lastFailureInfo     *FailureInfo
+		expectedState       ModelState
+		expectedReason      FailureReason
+		expectedTime        *metav1.Time
+		expectedCondition   bool
+		expectedMessagePart string
+	}{
+		"permanent failure sets the condition": {
+			exitCode:            constants.StorageInitializerNotFoundExitCode,
+			expectedState:       FailedToLoad,
+			expectedReason:      ModelNotFound,
+			expectedCondition:   true,
+			expectedMessagePart: "check the storageUri of the predictor: No model found in models/iris",
+		},
+		"access denied sets the condition": {
+			exitCode:            constants.StorageInitializerAccessDeniedExitCode,
+			expectedState:       FailedToLoad,
+			expectedReason:      StorageAccessDenied,
+			expectedCondition:   true,
+			expectedMessagePart: "check the storage secret",
+		},
+		"transient failure keeps loading": {
+			exitCode:        constants.StorageInitializerTransientExitCode,
+			lastFailureInfo: &FailureInfo{Reason: StorageUnavailable, Time: &firstFailure},
+			expectedState:   Loading,
+			expectedReason:  StorageUnavailable,
+			expectedTime:    &firstFailure,
+		},
+		"unknown failure does not set the condition": {
+			exitCode:       1,
+			expectedState:  FailedToLoad,
+			expectedReason: ModelLoadFailed,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{ModelStatus: ModelStatus{LastFailureInfo: scenario.lastFailureInfo}}
+			status.PropagateModelStatus(ComponentStatusSpec{}, newPodList(scenario.exitCode), false)
+
+			g.Expect(status.ModelStatus.ModelRevisionStates.TargetModelState).To(gomega.Equal(scenario.expectedState))
+			g.Expect(status.ModelStatus.LastFailureInfo.Reason).To(gomega.Equal(scenario.expectedReason))
+			g.Expect(status.ModelStatus.LastFailureInfo.ExitCode).To(gomega.Equal(scenario.exitCode))
+			if scenario.expectedTime != nil {
+				g.Expect(status.ModelStatus.LastFailureInfo.Time).To(gomega.Equal(scenario.expectedTime))
+			}
+			condition := status.GetCondition(StorageInitialized)
+			if !scenario.expectedCondition {
+				g.Expect(condition).To(gomega.BeNil())
+				return
+			}
+			g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+			g.Expect(condition.Reason).To(gomega.Equal(string(scenario.expectedReason)))
+			g.Expect(condition.Message).To(gomega.ContainSubstring(scenario.expectedMessagePart))
+
+			// The condition is cleared once the storage initializer runs again
+			status.PropagateModelStatus(ComponentStatusSpec{}, &v1.PodList{Items: []v1.Pod{{
+				Status: v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{{
+					Name:  constants.StorageInitializerContainerName,
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				}}},
+			}}}, false)
+			g.Expect(status.GetCondition(StorageInitialized)).To(gomega.BeNil())
+		})
+	}
+}
+
+func TestSmokeTestRevision(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := &InferenceService{Spec: InferenceServiceSpec{Transformer: &TransformerSpec{}}}
+	isvc.Status.Components = map[ComponentType]ComponentStatusSpec{
+		PredictorComponent:   {LatestReadyRevision: "sklearn-predictor-00002"},
+		TransformerComponent: {LatestCreatedRevision: "2"},
+	}
+	// The smoke test reaches the transformer, its deployment revision is tested once ready
+	g.Expect(SmokeTestComponent(isvc)).To(gomega.Equal(TransformerComponent))
+	g.Expect(SmokeTestRevision(isvc)).To(gomega.BeEmpty())
+	isvc.Status.SetCondition(TransformerReady, &apis.Condition{Status: v1.ConditionTrue})
+	g.Expect(SmokeTestRevision(isvc)).To(gomega.Equal("2"))
+
+	isvc.Spec.Transformer = nil
+	g.Expect(SmokeTestRevision(isvc)).To(gomega.Equal("sklearn-predictor-00002"))
+
+	g.Expect(isvc.Status.SmokeTestPassed("sklearn-predictor-00002")).To(gomega.BeFalse())
+	isvc.Status.SmokeTestedRevision = "sklearn-predictor-00002"
+	isvc.Status.SetCondition(SmokeTestPassed, &apis.Condition{Status: v1.ConditionFalse, Reason: "SmokeTestFailed"})
+	g.Expect(isvc.Status.SmokeTestPassed("sklearn-predictor-00002")).To(gomega.BeFalse())
+	isvc.Status.SetCondition(SmokeTestPassed, &apis.Condition{Status: v1.ConditionTrue})
+	g.Expect(isvc.Status.SmokeTestPassed("sklearn-predictor-00002")).To(gomega.BeTrue())
+	g.Expect(isvc.Status.SmokeTestPassed("sklearn-predictor-00003")).To(gomega.BeFalse())
+}
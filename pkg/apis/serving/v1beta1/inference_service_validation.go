@@ -17,16 +17,32 @@ limitations under the License.
 package v1beta1
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"regexp"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
+	"github.com/robfig/cron/v3"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/serving/pkg/apis/autoscaling"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -36,6 +52,9 @@ const (
 	IsvcNameFmt string = "[a-z]([-a-z0-9]*[a-z0-9])?"
 )
 
+// maxFaultTTL bounds the duration of the faults injected in a resilience drill
+const maxFaultTTL = 24 * time.Hour
+
 var (
 	// logger for the validation webhook.
 	validatorLogger = logf.Log.WithName("inferenceservice-v1beta1-validation-webhook")
@@ -50,6 +69,16 @@ var _ webhook.Validator = &InferenceService{}
 func (isvc *InferenceService) ValidateCreate() error {
 	validatorLogger.Info("validate create", "name", isvc.Name)
 
+	if err := isvc.validate(); err != nil {
+		return err
+	}
+	if err := validateServingRuntime(isvc); err != nil {
+		return err
+	}
+	return validateAllowedIngressGateway(isvc)
+}
+
+func (isvc *InferenceService) validate() error {
 	annotations := isvc.Annotations
 
 	if err := validateInferenceServiceName(isvc); err != nil {
@@ -64,6 +93,113 @@ func (isvc *InferenceService) ValidateCreate() error {
 		return err
 	}
 
+	if err := validateTTL(isvc); err != nil {
+		return err
+	}
+
+	if err := validateDeprecation(isvc); err != nil {
+		return err
+	}
+
+	if err := validateUsageReportInterval(isvc); err != nil {
+		return err
+	}
+
+	if err := validateKeepWarm(isvc); err != nil {
+		return err
+	}
+
+	if err := validateCircuitBreaker(isvc); err != nil {
+		return err
+	}
+
+	if err := validateOOMRemediation(isvc); err != nil {
+		return err
+	}
+
+	if err := validateModelConversion(isvc); err != nil {
+		return err
+	}
+
+	if err := validateGraphOptimization(isvc); err != nil {
+		return err
+	}
+
+	if err := validateRefreshSchedule(isvc); err != nil {
+		return err
+	}
+
+	if err := validatePredictionSink(isvc); err != nil {
+		return err
+	}
+	if err := validateLoggerRetries(isvc); err != nil {
+		return err
+	}
+	if err := validateLoggerBlobSink(isvc); err != nil {
+		return err
+	}
+	if err := validateLoggerEvents(isvc); err != nil {
+		return err
+	}
+	if err := validateLoggerTransform(isvc); err != nil {
+		return err
+	}
+	if err := validateLoggerEncryption(isvc); err != nil {
+		return err
+	}
+	if err := validateAgentPipelineOrder(isvc); err != nil {
+		return err
+	}
+	if err := validateModelReadiness(isvc); err != nil {
+		return err
+	}
+	if err := validatePayloadReferences(isvc); err != nil {
+		return err
+	}
+	if err := validateIngressGateway(isvc); err != nil {
+		return err
+	}
+	if err := validateCorsAllowOrigins(isvc); err != nil {
+		return err
+	}
+	if err := validateCanaryHeader(isvc); err != nil {
+		return err
+	}
+	if err := validateFallback(isvc); err != nil {
+		return err
+	}
+	if err := validateModelNames(isvc); err != nil {
+		return err
+	}
+	if err := validateAsync(isvc); err != nil {
+		return err
+	}
+	if err := validateFrameworkVersion(isvc); err != nil {
+		return err
+	}
+	if err := validateExplainerColocation(isvc); err != nil {
+		return err
+	}
+	if err := validateStorageDownloadWorkers(isvc); err != nil {
+		return err
+	}
+	if err := validateTransformerReplicaRatio(isvc); err != nil {
+		return err
+	}
+	if err := validateSmokeTest(isvc); err != nil {
+		return err
+	}
+	if err := validateFaultInjection(isvc); err != nil {
+		return err
+	}
+	if err := validateMaintenance(isvc); err != nil {
+		return err
+	}
+
+	if err := validateCloudIdentity(isvc); err != nil {
+		return err
+	}
+
 	for _, component := range []Component{
 		&isvc.Spec.Predictor,
 		isvc.Spec.Transformer,
@@ -89,6 +225,13 @@ func (isvc *InferenceService) ValidateCreate() error {
 func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec *ComponentExtensionSpec) error {
 	deploymentMode := annotations["serving.kserve.io/deploymentMode"]
 	annotationClass := annotations[autoscaling.ClassAnnotationKey]
+	if annotations[constants.AutoscalerClass] == string(constants.AutoscalerClassKEDA) {
+		// The KEDA triggers define the scaling metrics
+		return nil
+	}
+	if annotations[constants.AutoscalerClass] == string(constants.AutoscalerClassKEDAHTTP) {
+		return validateScalingKedaHTTPCompExtension(compExtSpec)
+	}
 	if deploymentMode == string(constants.RawDeployment) || annotationClass == string(autoscaling.HPA) {
 		return validateScalingHPACompExtension(compExtSpec)
 	}
@@ -101,7 +244,98 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 func (isvc *InferenceService) ValidateUpdate(old runtime.Object) error {
 	validatorLogger.Info("validate update", "name", isvc.Name)
 
-	return isvc.ValidateCreate()
+	if err := isvc.validate(); err != nil {
+		return err
+	}
+	// The InferenceServices keep the runtime disabled or restricted and the gateway removed from the allowlist after
+	// they were created with it
+	oldIsvc, ok := old.(*InferenceService)
+	if !ok || runtimeName(oldIsvc) != runtimeName(isvc) || frameworkVersion(oldIsvc) != frameworkVersion(isvc) {
+		if err := validateServingRuntime(isvc); err != nil {
+			return err
+		}
+	}
+	if !ok || oldIsvc.Annotations[constants.IngressGatewayAnnotationKey] != isvc.Annotations[constants.IngressGatewayAnnotationKey] {
+		return validateAllowedIngressGateway(isvc)
+	}
+	return nil
+}
+
+// Validation of the runtime the model of the predictor names, the runtimes are looked up with the client of the
+// webhook like the defaulter does
+func validateServingRuntime(isvc *InferenceService) error {
+	if runtimeName(isvc) == "" {
+		return nil
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	cli, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return err
+	}
+	return isvc.Spec.Predictor.Model.ValidateRuntime(cli, isvc.Namespace)
+}
+
+// Validation of the gateway the InferenceService selects against the allowlist of the ingress config, the config is
+// read with the client of the webhook like the runtimes
+func validateAllowedIngressGateway(isvc *InferenceService) error {
+	if _, ok := isvc.ObjectMeta.Annotations[constants.IngressGatewayAnnotationKey]; !ok {
+		return nil
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	cli, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return err
+	}
+	ingressConfig, err := NewIngressConfig(cli)
+	if err != nil {
+		return err
+	}
+	return isvc.ValidateIngressGateway(ingressConfig)
+}
+
+// ValidateIngressGateway checks that the gateway selected with the ingress gateway annotation is one of the gateways
+// of the ingress config or of its allowedIngressGateways
+func (isvc *InferenceService) ValidateIngressGateway(ingressConfig *IngressConfig) error {
+	gateway, ok := isvc.ObjectMeta.Annotations[constants.IngressGatewayAnnotationKey]
+	if !ok || gateway == ingressConfig.IngressGateway || gateway == ingressConfig.KserveIngressGateway {
+		return nil
+	}
+	for _, allowed := range ingressConfig.AllowedIngressGateways {
+		if gateway == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf(IngressGatewayNotAllowedError, gateway, constants.IngressGatewayAnnotationKey)
+}
+
+func runtimeName(isvc *InferenceService) string {
+	if isvc.Spec.Predictor.Model == nil || isvc.Spec.Predictor.Model.Runtime == nil {
+		return ""
+	}
+	return *isvc.Spec.Predictor.Model.Runtime
+}
+
+func frameworkVersion(isvc *InferenceService) string {
+	if isvc.Spec.Predictor.Model == nil || isvc.Spec.Predictor.Model.ModelFormat.FrameworkVersion == nil {
+		return ""
+	}
+	return *isvc.Spec.Predictor.Model.ModelFormat.FrameworkVersion
+}
+
+// Validation of the framework version of the model format
+func validateFrameworkVersion(isvc *InferenceService) error {
+	if version := frameworkVersion(isvc); version != "" {
+		if err := v1alpha1.ValidateFrameworkVersion(version); err != nil {
+			return fmt.Errorf(InvalidFrameworkVersionError, version)
+		}
+	}
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -124,6 +358,802 @@ func validateInferenceServiceName(isvc *InferenceService) error {
 	return nil
 }
 
+// Validation of the isvc ttl annotation
+func validateTTL(isvc *InferenceService) error {
+	value, ok := isvc.ObjectMeta.Annotations[constants.TTLAnnotationKey]
+	if !ok {
+		return nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return fmt.Errorf(InvalidTTLError, value, constants.TTLAnnotationKey)
+	}
+	if from, ok := isvc.ObjectMeta.Annotations[constants.TTLFromAnnotationKey]; ok {
+		if from != constants.TTLFromCreation && from != constants.TTLFromLastRequest {
+			return fmt.Errorf(InvalidTTLFromError, from, constants.TTLFromAnnotationKey,
+				strings.Join([]string{constants.TTLFromCreation, constants.TTLFromLastRequest}, ", "))
+		}
+		if from == constants.TTLFromLastRequest &&
+			isvc.ObjectMeta.Annotations[constants.DeploymentMode] == string(constants.RawDeployment) {
+			return fmt.Errorf(RawDeploymentTTLFromLastRequestError)
+		}
+	}
+	if action, ok := isvc.ObjectMeta.Annotations[constants.TTLActionAnnotationKey]; ok &&
+		action != constants.TTLActionDelete && action != constants.TTLActionSuspend {
+		return fmt.Errorf(InvalidTTLActionError, action, constants.TTLActionAnnotationKey,
+			strings.Join([]string{constants.TTLActionDelete, constants.TTLActionSuspend}, ", "))
+	}
+	return nil
+}
+
+// Validation of the isvc usage report interval annotation
+func validateUsageReportInterval(isvc *InferenceService) error {
+	value, ok := isvc.ObjectMeta.Annotations[constants.UsageReportIntervalAnnotationKey]
+	if !ok {
+		return nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf(InvalidDurationError, value, constants.UsageReportIntervalAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the isvc keep warm annotations, the schedule and the window of the keep warm periods go together
+func validateKeepWarm(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	for _, key := range []string{constants.KeepWarmIntervalAnnotationKey, constants.KeepWarmWindowAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+				return fmt.Errorf(InvalidDurationError, value, key)
+			}
+		}
+	}
+	schedule, scheduled := annotations[constants.KeepWarmScheduleAnnotationKey]
+	_, windowed := annotations[constants.KeepWarmWindowAnnotationKey]
+	if scheduled {
+		if _, err := cron.ParseStandard(schedule); err != nil {
+			return fmt.Errorf(InvalidScheduleError, schedule, constants.KeepWarmScheduleAnnotationKey)
+		}
+	}
+	if scheduled && !windowed {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.KeepWarmScheduleAnnotationKey, constants.KeepWarmWindowAnnotationKey)
+	}
+	if windowed && !scheduled {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.KeepWarmWindowAnnotationKey, constants.KeepWarmScheduleAnnotationKey)
+	}
+	if scheduled {
+		if _, ok := annotations[constants.KeepWarmIntervalAnnotationKey]; !ok {
+			return fmt.Errorf(MissingRequiredAnnotationError, constants.KeepWarmScheduleAnnotationKey, constants.KeepWarmIntervalAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the isvc circuit breaker and retry budget annotations
+func validateCircuitBreaker(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if value, ok := annotations[constants.CircuitBreakerFailureThresholdAnnotationKey]; ok {
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold <= 0 {
+			return fmt.Errorf(InvalidPositiveIntegerError, value, constants.CircuitBreakerFailureThresholdAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.CircuitBreakerOpenDurationAnnotationKey]; ok {
+		if _, ok := annotations[constants.CircuitBreakerFailureThresholdAnnotationKey]; !ok {
+			return fmt.Errorf(MissingRequiredAnnotationError, constants.CircuitBreakerOpenDurationAnnotationKey,
+				constants.CircuitBreakerFailureThresholdAnnotationKey)
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf(InvalidDurationError, value, constants.CircuitBreakerOpenDurationAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.RetryBudgetPercentageAnnotationKey]; ok {
+		percentage, err := strconv.Atoi(value)
+		if err != nil || percentage < 0 || percentage > 100 {
+			return fmt.Errorf(InvalidPercentageError, value, constants.RetryBudgetPercentageAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the isvc OOM remediation annotations, the memory is only raised up to the max memory
+func validateOOMRemediation(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	factor, hasFactor := annotations[constants.OOMMemoryFactorAnnotationKey]
+	maxMemory, hasMaxMemory := annotations[constants.OOMMaxMemoryAnnotationKey]
+	if hasFactor && !hasMaxMemory {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.OOMMemoryFactorAnnotationKey,
+			constants.OOMMaxMemoryAnnotationKey)
+	}
+	if hasMaxMemory && !hasFactor {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.OOMMaxMemoryAnnotationKey,
+			constants.OOMMemoryFactorAnnotationKey)
+	}
+	if !hasFactor {
+		return nil
+	}
+	if value, err := strconv.ParseFloat(factor, 64); err != nil || value <= 1 {
+		return fmt.Errorf(InvalidMemoryFactorError, factor, constants.OOMMemoryFactorAnnotationKey)
+	}
+	if quantity, err := resource.ParseQuantity(maxMemory); err != nil || quantity.Sign() <= 0 {
+		return fmt.Errorf(InvalidMemoryQuantityError, maxMemory, constants.OOMMaxMemoryAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the prediction sink, the predictions are published as CloudEvents over http
+func validatePredictionSink(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	sinkUrl, hasSink := annotations[constants.PredictionSinkURLAnnotationKey]
+	bufferSize, hasBufferSize := annotations[constants.PredictionBufferSizeAnnotationKey]
+	if hasBufferSize && !hasSink {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.PredictionBufferSizeAnnotationKey,
+			constants.PredictionSinkURLAnnotationKey)
+	}
+	if !hasSink {
+		return nil
+	}
+	if parsed, err := url.Parse(sinkUrl); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+		parsed.Host == "" {
+		return fmt.Errorf(InvalidSinkURLError, sinkUrl, constants.PredictionSinkURLAnnotationKey)
+	}
+	if hasBufferSize {
+		if quantity, err := resource.ParseQuantity(bufferSize); err != nil || quantity.Sign() <= 0 {
+			return fmt.Errorf(InvalidBufferSizeError, bufferSize, constants.PredictionBufferSizeAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the logger retry queue, the buffer size and the dead letter uri require the max age enabling it
+func validateLoggerRetries(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	maxAge, hasMaxAge := annotations[constants.LoggerRetryMaxAgeAnnotationKey]
+	for _, key := range []string{constants.LoggerRetryBufferSizeAnnotationKey, constants.LoggerDeadLetterURIAnnotationKey} {
+		if _, ok := annotations[key]; ok && !hasMaxAge {
+			return fmt.Errorf(MissingRequiredAnnotationError, key, constants.LoggerRetryMaxAgeAnnotationKey)
+		}
+	}
+	if !hasMaxAge {
+		return nil
+	}
+	if duration, err := time.ParseDuration(maxAge); err != nil || duration <= 0 {
+		return fmt.Errorf(InvalidDurationError, maxAge, constants.LoggerRetryMaxAgeAnnotationKey)
+	}
+	if bufferSize, ok := annotations[constants.LoggerRetryBufferSizeAnnotationKey]; ok {
+		if quantity, err := resource.ParseQuantity(bufferSize); err != nil || quantity.Sign() <= 0 {
+			return fmt.Errorf(InvalidBufferSizeError, bufferSize, constants.LoggerRetryBufferSizeAnnotationKey)
+		}
+	}
+	if deadLetterUri, ok := annotations[constants.LoggerDeadLetterURIAnnotationKey]; ok {
+		if !strings.HasPrefix(deadLetterUri, "s3://") || strings.TrimPrefix(deadLetterUri, "s3://") == "" {
+			return fmt.Errorf(InvalidDeadLetterURIError, deadLetterUri, constants.LoggerDeadLetterURIAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the batches of the logger writing to a s3://, gs:// or Azure blob storage url
+func validateLoggerBlobSink(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if value, ok := annotations[constants.LoggerBlobBatchSizeAnnotationKey]; ok {
+		if number, err := strconv.Atoi(value); err != nil || number <= 0 {
+			return fmt.Errorf(InvalidPositiveIntegerError, value, constants.LoggerBlobBatchSizeAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.LoggerBlobFlushIntervalAnnotationKey]; ok {
+		if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+			return fmt.Errorf(InvalidDurationError, value, constants.LoggerBlobFlushIntervalAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// loggerEventTemplateData is the data the logger event type and source templates are executed with
+type loggerEventTemplateData struct {
+	InferenceService string
+	Namespace        string
+	Component        string
+	Endpoint         string
+	// Kind is request or response
+	Kind string
+}
+
+// RenderLoggerEventTemplate executes the logger event type or source template for the events of the kind, request or
+// response, of the InferenceService component
+func RenderLoggerEventTemplate(text string, inferenceService string, namespace string, component string,
+	endpoint string, kind string) (string, error) {
+	tmpl, err := template.New("event").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, loggerEventTemplateData{
+		InferenceService: inferenceService,
+		Namespace:        namespace,
+		Component:        component,
+		Endpoint:         endpoint,
+		Kind:             kind,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Validation of the logger event format, the templates must render a non empty type and a valid source uri
+func validateLoggerEvents(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if encoding, ok := annotations[constants.LoggerEncodingAnnotationKey]; ok &&
+		encoding != constants.LoggerBinaryEncoding && encoding != constants.LoggerStructuredEncoding {
+		return fmt.Errorf(InvalidLoggerEncodingError, encoding, constants.LoggerEncodingAnnotationKey)
+	}
+	if eventType, ok := annotations[constants.LoggerEventTypeAnnotationKey]; ok {
+		rendered, err := RenderLoggerEventTemplate(eventType, isvc.Name, isvc.Namespace, "predictor", "default", "request")
+		if err == nil && strings.TrimSpace(rendered) == "" {
+			err = fmt.Errorf("the event type is empty")
+		}
+		if err != nil {
+			return fmt.Errorf(InvalidLoggerEventTemplateError, eventType, constants.LoggerEventTypeAnnotationKey, err)
+		}
+	}
+	if source, ok := annotations[constants.LoggerEventSourceAnnotationKey]; ok {
+		rendered, err := RenderLoggerEventTemplate(source, isvc.Name, isvc.Namespace, "predictor", "default", "request")
+		if err == nil && strings.TrimSpace(rendered) == "" {
+			err = fmt.Errorf("the event source is empty")
+		}
+		if err == nil {
+			_, err = url.Parse(rendered)
+		}
+		if err != nil {
+			return fmt.Errorf(InvalidLoggerEventTemplateError, source, constants.LoggerEventSourceAnnotationKey, err)
+		}
+	}
+	return nil
+}
+
+// Validation of the logger payload transformation, the hash key secret requires the fields it hashes
+func validateLoggerTransform(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if transformUrl, ok := annotations[constants.LoggerTransformURLAnnotationKey]; ok {
+		if parsed, err := url.Parse(transformUrl); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+			parsed.Host == "" {
+			return fmt.Errorf(InvalidTransformURLError, transformUrl, constants.LoggerTransformURLAnnotationKey)
+		}
+	}
+	hashFields, hasHashFields := annotations[constants.LoggerHashFieldsAnnotationKey]
+	if _, ok := annotations[constants.LoggerHashKeySecretAnnotationKey]; ok && !hasHashFields {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.LoggerHashKeySecretAnnotationKey,
+			constants.LoggerHashFieldsAnnotationKey)
+	}
+	if hasHashFields {
+		for _, field := range strings.Split(hashFields, ",") {
+			if strings.TrimSpace(field) == "" {
+				return fmt.Errorf(InvalidHashFieldsError, hashFields, constants.LoggerHashFieldsAnnotationKey)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the logger encryption, the payloads are encrypted either with the static key of a secret or a KMS key
+func validateLoggerEncryption(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	keyRef, ok := annotations[constants.LoggerEncryptionKMSKeyAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if _, ok := annotations[constants.LoggerEncryptionKeySecretAnnotationKey]; ok {
+		return fmt.Errorf(ConflictingAnnotationsError, constants.LoggerEncryptionKMSKeyAnnotationKey,
+			constants.LoggerEncryptionKeySecretAnnotationKey)
+	}
+	keyArn, err := arn.Parse(strings.TrimPrefix(keyRef, constants.AWSKMSKeyPrefix))
+	if !strings.HasPrefix(keyRef, constants.AWSKMSKeyPrefix) || err != nil || keyArn.Service != "kms" || keyArn.Region == "" ||
+		(!strings.HasPrefix(keyArn.Resource, "key/") && !strings.HasPrefix(keyArn.Resource, "alias/")) {
+		return fmt.Errorf(InvalidKMSKeyError, keyRef, constants.LoggerEncryptionKMSKeyAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the order of the logger and the batcher in the agent
+func validateAgentPipelineOrder(isvc *InferenceService) error {
+	if order, ok := isvc.ObjectMeta.Annotations[constants.AgentPipelineOrderAnnotationKey]; ok &&
+		order != constants.AgentPipelineLogBatch && order != constants.AgentPipelineBatchLog {
+		return fmt.Errorf(InvalidPipelineOrderError, order, constants.AgentPipelineOrderAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the readiness policy of the multi-model servers, the minimum number of models requires the policy
+func validateModelReadiness(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	policy, hasPolicy := annotations[constants.ModelReadinessPolicyAnnotationKey]
+	if hasPolicy && policy != constants.ModelReadinessAlways && policy != constants.ModelReadinessMinModels &&
+		policy != constants.ModelReadinessInitialModels {
+		return fmt.Errorf(InvalidModelReadinessPolicyError, policy, constants.ModelReadinessPolicyAnnotationKey)
+	}
+	if minModels, ok := annotations[constants.ModelReadinessMinModelsAnnotationKey]; ok {
+		if !hasPolicy {
+			return fmt.Errorf(MissingRequiredAnnotationError, constants.ModelReadinessMinModelsAnnotationKey,
+				constants.ModelReadinessPolicyAnnotationKey)
+		}
+		if value, err := strconv.Atoi(minModels); err != nil || value <= 0 {
+			return fmt.Errorf(InvalidPositiveIntegerError, minModels, constants.ModelReadinessMinModelsAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the payloads passed by reference, the max size and the response uri require the prefixes enabling it
+func validatePayloadReferences(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	prefixes, hasPrefixes := annotations[constants.PayloadURIPrefixesAnnotationKey]
+	for _, key := range []string{constants.PayloadMaxSizeAnnotationKey, constants.PayloadResponseURIAnnotationKey} {
+		if _, ok := annotations[key]; ok && !hasPrefixes {
+			return fmt.Errorf(MissingRequiredAnnotationError, key, constants.PayloadURIPrefixesAnnotationKey)
+		}
+	}
+	if !hasPrefixes {
+		return nil
+	}
+	for _, prefix := range strings.Split(prefixes, ",") {
+		if !isPayloadURI(prefix) {
+			return fmt.Errorf(InvalidPayloadURIError, prefix, constants.PayloadURIPrefixesAnnotationKey)
+		}
+	}
+	if maxSize, ok := annotations[constants.PayloadMaxSizeAnnotationKey]; ok {
+		if quantity, err := resource.ParseQuantity(maxSize); err != nil || quantity.Sign() <= 0 {
+			return fmt.Errorf(InvalidBufferSizeError, maxSize, constants.PayloadMaxSizeAnnotationKey)
+		}
+	}
+	if responseUri, ok := annotations[constants.PayloadResponseURIAnnotationKey]; ok && !isPayloadURI(responseUri) {
+		return fmt.Errorf(InvalidPayloadURIError, responseUri, constants.PayloadResponseURIAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the ingress gateway override, the gateway is referenced as <namespace>/<name>
+func validateIngressGateway(isvc *InferenceService) error {
+	gateway, ok := isvc.ObjectMeta.Annotations[constants.IngressGatewayAnnotationKey]
+	if !ok {
+		return nil
+	}
+	parts := strings.Split(gateway, "/")
+	if len(parts) != 2 || len(validation.IsDNS1123Label(parts[0])) != 0 || len(validation.IsDNS1123Subdomain(parts[1])) != 0 {
+		return fmt.Errorf(InvalidIngressGatewayError, gateway, constants.IngressGatewayAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the origins overriding the CORS policy of the ingress config, an empty value disables CORS
+func validateCorsAllowOrigins(isvc *InferenceService) error {
+	origins, ok := isvc.ObjectMeta.Annotations[constants.CorsAllowOriginsAnnotationKey]
+	if !ok || strings.TrimSpace(origins) == "" {
+		return nil
+	}
+	for _, origin := range strings.Split(origins, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			return fmt.Errorf(InvalidCorsOriginError, origin, constants.CorsAllowOriginsAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// ParseCanaryHeader returns the lower case name and the value of a <name>: <value> header, the header names of the
+// Istio matches must be lower case
+func ParseCanaryHeader(header string) (string, string, bool) {
+	name, value, ok := strings.Cut(header, ":")
+	name, value = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)
+	if !ok || value == "" || len(validation.IsHTTPHeaderName(name)) != 0 {
+		return "", "", false
+	}
+	return name, value, true
+}
+
+// Validation of the header routing the requests to the canary revision
+func validateCanaryHeader(isvc *InferenceService) error {
+	header, ok := isvc.ObjectMeta.Annotations[constants.CanaryHeaderAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if _, _, ok := ParseCanaryHeader(header); !ok {
+		return fmt.Errorf(InvalidCanaryHeaderError, header, constants.CanaryHeaderAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the InferenceService serving the requests while the InferenceService is unavailable, it is looked up
+// in the namespace of the InferenceService
+func validateFallback(isvc *InferenceService) error {
+	fallback, ok := isvc.ObjectMeta.Annotations[constants.FallbackAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if fallback == isvc.Name || len(validation.IsDNS1123Label(fallback)) != 0 {
+		return fmt.Errorf(InvalidFallbackError, fallback, constants.FallbackAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the faults injected by the virtual service, the faults are temporary so they require a ttl
+func validateFaultInjection(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	delay, delayed := annotations[constants.FaultDelayAnnotationKey]
+	status, aborted := annotations[constants.FaultAbortStatusAnnotationKey]
+	if !delayed && !aborted {
+		for _, key := range []string{constants.FaultDelayPercentageAnnotationKey,
+			constants.FaultAbortPercentageAnnotationKey, constants.FaultTTLAnnotationKey,
+			constants.FaultExpiryAnnotationKey} {
+			if _, ok := annotations[key]; ok {
+				return fmt.Errorf(MissingFaultError, key, constants.FaultDelayAnnotationKey,
+					constants.FaultAbortStatusAnnotationKey)
+			}
+		}
+		return nil
+	}
+	if delayed {
+		if duration, err := time.ParseDuration(delay); err != nil || duration <= 0 {
+			return fmt.Errorf(InvalidDurationError, delay, constants.FaultDelayAnnotationKey)
+		}
+	} else if _, ok := annotations[constants.FaultDelayPercentageAnnotationKey]; ok {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.FaultDelayPercentageAnnotationKey,
+			constants.FaultDelayAnnotationKey)
+	}
+	if aborted {
+		if code, err := strconv.Atoi(status); err != nil || code < 400 || code > 599 {
+			return fmt.Errorf(InvalidFaultAbortStatusError, status, constants.FaultAbortStatusAnnotationKey)
+		}
+	} else if _, ok := annotations[constants.FaultAbortPercentageAnnotationKey]; ok {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.FaultAbortPercentageAnnotationKey,
+			constants.FaultAbortStatusAnnotationKey)
+	}
+	for _, key := range []string{constants.FaultDelayPercentageAnnotationKey, constants.FaultAbortPercentageAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			if percentage, err := strconv.Atoi(value); err != nil || percentage < 0 || percentage > 100 {
+				return fmt.Errorf(InvalidPercentageError, value, key)
+			}
+		}
+	}
+	value, ok := annotations[constants.FaultTTLAnnotationKey]
+	if !ok {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.FaultDelayAnnotationKey+" or "+
+			constants.FaultAbortStatusAnnotationKey, constants.FaultTTLAnnotationKey)
+	}
+	if ttl, err := time.ParseDuration(value); err != nil || ttl <= 0 || ttl > maxFaultTTL {
+		return fmt.Errorf(InvalidFaultTTLError, value, constants.FaultTTLAnnotationKey)
+	}
+	if value, ok := annotations[constants.FaultExpiryAnnotationKey]; ok {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf(InvalidTimestampError, value, constants.FaultExpiryAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the maintenance mode, it is switched by the agents reloading their config without a restart
+func validateMaintenance(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	value, ok := annotations[constants.MaintenanceAnnotationKey]
+	if !ok {
+		for _, key := range []string{constants.MaintenanceBodyAnnotationKey, constants.MaintenanceRetryAfterAnnotationKey} {
+			if _, ok := annotations[key]; ok {
+				return fmt.Errorf(MissingRequiredAnnotationError, key, constants.MaintenanceAnnotationKey)
+			}
+		}
+		return nil
+	}
+	if value != "true" && value != "false" {
+		return fmt.Errorf(InvalidMaintenanceError, value, constants.MaintenanceAnnotationKey)
+	}
+	if annotations[constants.AgentHotReloadAnnotationKey] != "true" {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.MaintenanceAnnotationKey,
+			constants.AgentHotReloadAnnotationKey)
+	}
+	if body, ok := annotations[constants.MaintenanceBodyAnnotationKey]; ok && !json.Valid([]byte(body)) {
+		return fmt.Errorf(InvalidMaintenanceBodyError, body, constants.MaintenanceBodyAnnotationKey)
+	}
+	if retryAfter, ok := annotations[constants.MaintenanceRetryAfterAnnotationKey]; ok {
+		if duration, err := time.ParseDuration(retryAfter); err != nil || duration <= 0 {
+			return fmt.Errorf(InvalidDurationError, retryAfter, constants.MaintenanceRetryAfterAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the components mounting the cloud identity of their service account in the kserve-container, their
+// containers must not set the credentials copied from a secret
+func validateCloudIdentity(isvc *InferenceService) error {
+	for _, component := range []Component{
+		&isvc.Spec.Predictor,
+		isvc.Spec.Transformer,
+		isvc.Spec.Explainer,
+	} {
+		if reflect.ValueOf(component).IsNil() || !component.GetExtensions().MountCloudIdentity {
+			continue
+		}
+		for _, container := range componentContainers(component) {
+			for _, env := range container.Env {
+				if utils.Includes(credentials.CloudCredentialEnvKeys, env.Name) {
+					return fmt.Errorf(CloudCredentialEnvError, env.Name, container.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// componentContainers returns the containers of the spec of the component
+func componentContainers(component Component) []v1.Container {
+	switch c := component.(type) {
+	case *PredictorSpec:
+		containers := append([]v1.Container{}, c.PodSpec.Containers...)
+		for _, implementation := range c.GetImplementations() {
+			if _, ok := implementation.(*CustomPredictor); !ok {
+				containers = append(containers, *implementation.GetContainer(metav1.ObjectMeta{}, nil, nil))
+			}
+		}
+		return containers
+	case *TransformerSpec:
+		return c.PodSpec.Containers
+	case *ExplainerSpec:
+		containers := append([]v1.Container{}, c.PodSpec.Containers...)
+		if c.Alibi != nil {
+			containers = append(containers, c.Alibi.Container)
+		}
+		if c.AIX != nil {
+			containers = append(containers, c.AIX.Container)
+		}
+		if c.ART != nil {
+			containers = append(containers, c.ART.Container)
+		}
+		return containers
+	}
+	return nil
+}
+
+// Validation of the model names the X-Kserve-Model header selects, each external name maps to a single model
+func validateModelNames(isvc *InferenceService) error {
+	value, ok := isvc.ObjectMeta.Annotations[constants.ModelNamesAnnotationKey]
+	if !ok {
+		return nil
+	}
+	external := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		// The agent parses the names as they are, the spaces would be part of the names
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(entry, " \t") ||
+			external[parts[0]] {
+			return fmt.Errorf(InvalidModelNamesError, value, constants.ModelNamesAnnotationKey)
+		}
+		external[parts[0]] = true
+	}
+	return nil
+}
+
+// Validation of the asynchronous requests, the settings require the annotation enabling them
+func validateAsync(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	enabled := annotations[constants.EnableAsyncAnnotationKey] == "true"
+	for _, key := range []string{constants.AsyncWorkersAnnotationKey, constants.AsyncQueueSizeAnnotationKey,
+		constants.AsyncResultTTLAnnotationKey, constants.AsyncCallbackURLAnnotationKey,
+		constants.AsyncBrokerURLAnnotationKey} {
+		if _, ok := annotations[key]; ok && !enabled {
+			return fmt.Errorf(MissingRequiredAnnotationError, key, constants.EnableAsyncAnnotationKey)
+		}
+	}
+	for _, key := range []string{constants.AsyncWorkersAnnotationKey, constants.AsyncQueueSizeAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			if number, err := strconv.Atoi(value); err != nil || number <= 0 {
+				return fmt.Errorf(InvalidPositiveIntegerError, value, key)
+			}
+		}
+	}
+	if ttl, ok := annotations[constants.AsyncResultTTLAnnotationKey]; ok {
+		if duration, err := time.ParseDuration(ttl); err != nil || duration <= 0 {
+			return fmt.Errorf(InvalidDurationError, ttl, constants.AsyncResultTTLAnnotationKey)
+		}
+	}
+	if callbackUrl, ok := annotations[constants.AsyncCallbackURLAnnotationKey]; ok {
+		if parsed, err := url.Parse(callbackUrl); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+			parsed.Host == "" {
+			return fmt.Errorf(InvalidCallbackURLError, callbackUrl, constants.AsyncCallbackURLAnnotationKey)
+		}
+	}
+	if brokerUrl, ok := annotations[constants.AsyncBrokerURLAnnotationKey]; ok {
+		if parsed, err := url.Parse(brokerUrl); err != nil || (parsed.Scheme != "redis" && parsed.Scheme != "rediss") ||
+			parsed.Host == "" {
+			return fmt.Errorf(InvalidBrokerURLError, brokerUrl, constants.AsyncBrokerURLAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// Validation of the explainer colocation, the explainer sidecar shares the model volume of the predictor pods
+func validateExplainerColocation(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if annotations[constants.ExplainerColocationAnnotationKey] != "true" {
+		return nil
+	}
+	if isvc.Spec.Explainer == nil {
+		return fmt.Errorf(InvalidExplainerColocationError, "the InferenceService must have an explainer")
+	}
+	if mode, ok := annotations[constants.DeploymentMode]; ok && mode != string(constants.RawDeployment) {
+		return fmt.Errorf(InvalidExplainerColocationError, "the deployment mode must be "+string(constants.RawDeployment))
+	}
+	if implementations := isvc.Spec.Explainer.GetImplementations(); len(implementations) != 0 &&
+		implementations[0].GetStorageUri() != nil {
+		return fmt.Errorf(InvalidExplainerColocationError, "the explainer must not have a storageUri")
+	}
+	return nil
+}
+
+// Validation of the transformer replica ratio, the transformer deployment follows the predictor deployment replicas
+func validateTransformerReplicaRatio(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	ratio, ok := annotations[constants.TransformerReplicaRatioAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if isvc.Spec.Transformer == nil {
+		return fmt.Errorf(InvalidTransformerReplicaRatioError, "the InferenceService must have a transformer")
+	}
+	if mode, ok := annotations[constants.DeploymentMode]; ok && mode != string(constants.RawDeployment) {
+		return fmt.Errorf(InvalidTransformerReplicaRatioError, "the deployment mode must be "+string(constants.RawDeployment))
+	}
+	if _, _, err := ParseReplicaRatio(ratio); err != nil {
+		return fmt.Errorf(InvalidTransformerReplicaRatioError, err.Error())
+	}
+	return nil
+}
+
+// Validation of the smoke test, a canary of the tested component is held until the smoke test passed on it, the
+// request must carry the canary header to reach the canary
+func validateSmokeTest(isvc *InferenceService) error {
+	smokeTest := isvc.Spec.SmokeTest
+	if smokeTest == nil {
+		return nil
+	}
+	if !json.Valid([]byte(smokeTest.Payload)) {
+		return fmt.Errorf(InvalidSmokeTestError, "the payload must be a JSON document")
+	}
+	if smokeTest.ExpectedResponse != nil && smokeTest.ExpectedResponse.Pattern != "" {
+		if _, err := regexp.Compile(smokeTest.ExpectedResponse.Pattern); err != nil {
+			return fmt.Errorf(InvalidSmokeTestError, "the pattern must be a regular expression: "+err.Error())
+		}
+	}
+	extension := &isvc.Spec.Predictor.ComponentExtensionSpec
+	if isvc.Spec.Transformer != nil {
+		extension = &isvc.Spec.Transformer.ComponentExtensionSpec
+	}
+	if _, ok := isvc.ObjectMeta.Annotations[constants.CanaryHeaderAnnotationKey]; extension.CanaryTrafficPercent != nil && !ok {
+		return fmt.Errorf(InvalidSmokeTestError, "the canary traffic percent requires the annotation "+
+			constants.CanaryHeaderAnnotationKey+" routing the smoke test to the canary")
+	}
+	return nil
+}
+
+// Validation of the number of concurrent downloads of the storage initializer
+func validateStorageDownloadWorkers(isvc *InferenceService) error {
+	if value, ok := isvc.ObjectMeta.Annotations[constants.StorageDownloadWorkersAnnotationKey]; ok {
+		if number, err := strconv.Atoi(value); err != nil || number <= 0 {
+			return fmt.Errorf(InvalidPositiveIntegerError, value, constants.StorageDownloadWorkersAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// isPayloadURI returns whether the uri is a s3://<bucket>/<prefix> or gs://<bucket>/<prefix> uri
+func isPayloadURI(uri string) bool {
+	for _, prefix := range []string{"s3://", "gs://"} {
+		if strings.HasPrefix(uri, prefix) {
+			return strings.SplitN(strings.TrimPrefix(uri, prefix), "/", 2)[0] != ""
+		}
+	}
+	return false
+}
+
+// Validation of the predictor model conversion, the converted models are cached in a pvc
+func validateModelConversion(isvc *InferenceService) error {
+	conversion := isvc.Spec.Predictor.Conversion
+	if conversion == nil {
+		return nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(conversion.OutputStorageURI, constants.PvcURIPrefix), "/", 2)
+	if !strings.HasPrefix(conversion.OutputStorageURI, constants.PvcURIPrefix) || parts[0] == "" {
+		return fmt.Errorf(InvalidConversionOutputError, conversion.OutputStorageURI)
+	}
+	return nil
+}
+
+// Validation of the predictor graph optimization, the optimization is only mapped for the onnx runtimes
+func validateGraphOptimization(isvc *InferenceService) error {
+	optimization := isvc.Spec.Predictor.Optimization
+	if optimization == nil {
+		return nil
+	}
+	if !isvc.Spec.Predictor.IsGraphOptimizationSupported() {
+		return fmt.Errorf(UnsupportedOptimizationError)
+	}
+	if optimization.WorkspaceSize != nil && optimization.WorkspaceSize.Sign() <= 0 {
+		return fmt.Errorf(InvalidOptimizationWorkspaceError)
+	}
+	for _, shape := range optimization.DynamicShapes {
+		if len(shape.Min) == 0 || len(shape.Min) != len(shape.Opt) || len(shape.Min) != len(shape.Max) {
+			return fmt.Errorf(InvalidDynamicShapeError, shape.Input)
+		}
+		for i := range shape.Min {
+			if shape.Min[i] <= 0 || shape.Min[i] > shape.Opt[i] || shape.Opt[i] > shape.Max[i] {
+				return fmt.Errorf(InvalidDynamicShapeError, shape.Input)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the predictor refresh schedule, the model is refreshed from its storage uri
+func validateRefreshSchedule(isvc *InferenceService) error {
+	schedule := isvc.Spec.Predictor.RefreshSchedule
+	if schedule == nil {
+		return nil
+	}
+	if _, err := cron.ParseStandard(*schedule); err != nil {
+		return fmt.Errorf(InvalidRefreshScheduleError, *schedule)
+	}
+	// The predictor implementations are validated with the components
+	for _, predictor := range isvc.Spec.Predictor.GetImplementations() {
+		if predictor.GetStorageUri() == nil && predictor.GetStorageSpec() == nil {
+			return fmt.Errorf(MissingRefreshStorageError)
+		}
+	}
+	return nil
+}
+
+// Validation of the isvc deprecation, sunset and sunset reject percentage annotations
+func validateDeprecation(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	value, deprecated := annotations[constants.DeprecationAnnotationKey]
+	if !deprecated {
+		for _, key := range []string{constants.SunsetAnnotationKey, constants.SunsetRejectPercentageAnnotationKey,
+			constants.DeprecationLinkAnnotationKey} {
+			if _, ok := annotations[key]; ok {
+				return fmt.Errorf(MissingRequiredAnnotationError, key, constants.DeprecationAnnotationKey)
+			}
+		}
+		return nil
+	}
+	deprecation, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf(InvalidTimestampError, value, constants.DeprecationAnnotationKey)
+	}
+	value, ok := annotations[constants.SunsetAnnotationKey]
+	if !ok {
+		if _, ok := annotations[constants.SunsetRejectPercentageAnnotationKey]; ok {
+			return fmt.Errorf(MissingRequiredAnnotationError, constants.SunsetRejectPercentageAnnotationKey, constants.SunsetAnnotationKey)
+		}
+		return nil
+	}
+	sunset, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf(InvalidTimestampError, value, constants.SunsetAnnotationKey)
+	}
+	if sunset.Before(deprecation) {
+		return fmt.Errorf(SunsetBeforeDeprecationError, sunset.Format(time.RFC3339), deprecation.Format(time.RFC3339))
+	}
+	if value, ok := annotations[constants.SunsetRejectPercentageAnnotationKey]; ok {
+		percentage, err := strconv.Atoi(value)
+		if err != nil || percentage < 0 || percentage > 100 {
+			return fmt.Errorf(InvalidPercentageError, value, constants.SunsetRejectPercentageAnnotationKey)
+		}
+	}
+	return nil
+}
+
 // Validation of isvc autoscaler class
 func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -139,6 +1169,13 @@ func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 					} else {
 						return nil
 					}
+				case constants.AutoscalerClassKEDA:
+					return validateKedaTriggers(annotations)
+				case constants.AutoscalerClassKEDAHTTP:
+					if mode, ok := annotations[constants.DeploymentMode]; ok && mode != string(constants.RawDeployment) {
+						return fmt.Errorf(InvalidKedaHTTPScalingError, "the deployment mode must be "+string(constants.RawDeployment))
+					}
+					return nil
 				default:
 					return fmt.Errorf("unknown autoscaler class [%s]", class)
 				}
@@ -161,6 +1198,45 @@ func validateHPAMetrics(metric ScaleMetric) error {
 
 }
 
+// kedaTriggerMetadata is the metadata required by the KEDA trigger types
+var kedaTriggerMetadata = map[string][]string{
+	"prometheus":    {"serverAddress", "query", "threshold"},
+	"kafka":         {"bootstrapServers", "consumerGroup", "topic"},
+	"aws-sqs-queue": {"queueURL"},
+}
+
+// Validate of the KEDA triggers of the keda autoscaler class
+func validateKedaTriggers(annotations map[string]string) error {
+	value, ok := annotations[constants.KedaTriggersAnnotationKey]
+	if !ok {
+		return fmt.Errorf(MissingRequiredAnnotationError, constants.AutoscalerClass, constants.KedaTriggersAnnotationKey)
+	}
+	var triggers []struct {
+		Type     string            `json:"type"`
+		Metadata map[string]string `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(value), &triggers); err != nil {
+		return fmt.Errorf(InvalidKedaTriggersError, constants.KedaTriggersAnnotationKey, err)
+	}
+	if len(triggers) == 0 {
+		return fmt.Errorf(InvalidKedaTriggersError, constants.KedaTriggersAnnotationKey, "no trigger")
+	}
+	for _, trigger := range triggers {
+		required, ok := kedaTriggerMetadata[trigger.Type]
+		if !ok {
+			return fmt.Errorf(InvalidKedaTriggersError, constants.KedaTriggersAnnotationKey,
+				fmt.Sprintf("unsupported type %q", trigger.Type))
+		}
+		for _, key := range required {
+			if trigger.Metadata[key] == "" {
+				return fmt.Errorf(InvalidKedaTriggersError, constants.KedaTriggersAnnotationKey,
+					fmt.Sprintf("the %s trigger requires the %s metadata", trigger.Type, key))
+			}
+		}
+	}
+	return nil
+}
+
 // Validate of autoscaler targetUtilizationPercentage
 func validateAutoscalerTargetUtilizationPercentage(isvc *InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -178,6 +1254,18 @@ func validateAutoscalerTargetUtilizationPercentage(isvc *InferenceService) error
 	return nil
 }
 
+// Validation of the scaling of the components scaled by the KEDA HTTP add-on, they scale to zero on the concurrency of
+// their requests
+func validateScalingKedaHTTPCompExtension(compExtSpec *ComponentExtensionSpec) error {
+	if compExtSpec.ScaleMetric != nil && *compExtSpec.ScaleMetric != MetricConcurrency {
+		return fmt.Errorf(InvalidKedaHTTPScalingError, fmt.Sprintf("scaleMetric [%s] is not supported", *compExtSpec.ScaleMetric))
+	}
+	if compExtSpec.ScaleTarget != nil && *compExtSpec.ScaleTarget < 1 {
+		return fmt.Errorf("The target concurrency should be greater than 0")
+	}
+	return nil
+}
+
 func validateScalingHPACompExtension(compExtSpec *ComponentExtensionSpec) error {
 	metric := MetricCPU
 	if compExtSpec.ScaleMetric != nil {
@@ -192,7 +1280,7 @@ func validateScalingHPACompExtension(compExtSpec *ComponentExtensionSpec) error
 
 	if compExtSpec.ScaleTarget != nil {
 		target := *compExtSpec.ScaleTarget
-		if metric == MetricCPU && target < 1 || target > 100 {
+		if metric == MetricCPU && (target < 1 || target > 100) {
 			return fmt.Errorf("The target utilization percentage should be a [1-100] integer.")
 		}
 
@@ -200,6 +1288,14 @@ func validateScalingHPACompExtension(compExtSpec *ComponentExtensionSpec) error
 			return fmt.Errorf("The target memory should be greater than 1 MiB")
 		}
 
+		if metric == MetricConcurrency && target < 1 {
+			return fmt.Errorf("The target concurrency should be greater than 0")
+		}
+
+		if (metric == MetricGPU || metric == MetricGPUMemory) && (target < 1 || target > 100) {
+			return fmt.Errorf("The target GPU utilization percentage should be a [1-100] integer.")
+		}
+
 	}
 
 	return nil
@@ -23,7 +23,9 @@ import (
 	"github.com/golang/protobuf/proto"
 
 	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -86,6 +88,106 @@ func TestInvalidAutoscalerClassType(t *testing.T) {
 	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
 }
 
+func TestValidateKedaTriggers(t *testing.T) {
+	scenarios := map[string]struct {
+		triggers *string
+		matcher  types.GomegaMatcher
+	}{
+		"Prometheus": {
+			triggers: proto.String(`[{"type": "prometheus", "metadata": {"serverAddress": "http://prometheus:9090",
+				"query": "sum(rate(http_requests_total{app=\"foo\"}[1m]))", "threshold": "50"}}]`),
+			matcher: gomega.Succeed(),
+		},
+		"KafkaAndSQS": {
+			triggers: proto.String(`[{"type": "kafka", "metadata": {"bootstrapServers": "kafka:9092",
+				"consumerGroup": "foo", "topic": "requests", "lagThreshold": "100"}},
+				{"type": "aws-sqs-queue", "authenticationRef": {"name": "aws"},
+				"metadata": {"queueURL": "https://sqs.eu-west-1.amazonaws.com/123456789012/requests"}}]`),
+			matcher: gomega.Succeed(),
+		},
+		"MissingTriggers": {
+			matcher: gomega.HaveOccurred(),
+		},
+		"InvalidJSON": {
+			triggers: proto.String(`type: prometheus`),
+			matcher:  gomega.HaveOccurred(),
+		},
+		"NoTrigger": {
+			triggers: proto.String(`[]`),
+			matcher:  gomega.HaveOccurred(),
+		},
+		"UnsupportedType": {
+			triggers: proto.String(`[{"type": "cron", "metadata": {"timezone": "Etc/UTC"}}]`),
+			matcher:  gomega.HaveOccurred(),
+		},
+		"MissingMetadata": {
+			triggers: proto.String(`[{"type": "prometheus", "metadata": {"serverAddress": "http://prometheus:9090"}}]`),
+			matcher:  gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/autoscalerClass"] = "keda"
+			if scenario.triggers != nil {
+				isvc.ObjectMeta.Annotations["serving.kserve.io/keda-triggers"] = *scenario.triggers
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateKedaHTTPScaling(t *testing.T) {
+	cpu := MetricCPU
+	concurrency := MetricConcurrency
+	scenarios := map[string]struct {
+		deploymentMode string
+		scaleMetric    *ScaleMetric
+		scaleTarget    *int
+		minReplicas    *int
+		matcher        types.GomegaMatcher
+	}{
+		"ScaleToZero": {
+			deploymentMode: "RawDeployment",
+			minReplicas:    GetIntReference(0),
+			matcher:        gomega.Succeed(),
+		},
+		"Concurrency": {
+			deploymentMode: "RawDeployment",
+			scaleMetric:    &concurrency,
+			scaleTarget:    GetIntReference(10),
+			matcher:        gomega.Succeed(),
+		},
+		"Serverless": {
+			deploymentMode: "Serverless",
+			matcher:        gomega.HaveOccurred(),
+		},
+		"CPUMetric": {
+			deploymentMode: "RawDeployment",
+			scaleMetric:    &cpu,
+			matcher:        gomega.HaveOccurred(),
+		},
+		"InvalidTarget": {
+			deploymentMode: "RawDeployment",
+			scaleTarget:    GetIntReference(0),
+			matcher:        gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/autoscalerClass"] = "keda-http"
+			isvc.ObjectMeta.Annotations["serving.kserve.io/deploymentMode"] = scenario.deploymentMode
+			isvc.Spec.Predictor.ScaleMetric = scenario.scaleMetric
+			isvc.Spec.Predictor.ScaleTarget = scenario.scaleTarget
+			isvc.Spec.Predictor.MinReplicas = scenario.minReplicas
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
 func TestValidTargetUtilizationPercentage(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestRawInferenceService()
@@ -109,6 +211,1274 @@ func TestInvalidTargetUtilizationPercentage(t *testing.T) {
 	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
 }
 
+func TestValidateTTL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl"] = "72h"
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl"] = "3d"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl"] = "-1h"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl"] = "72h"
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-action"] = "suspend"
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-from"] = "creation"
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-action"] = "scale-down"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+
+	// The requests of the raw deployments are not counted
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-action"] = "delete"
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-from"] = "last-request"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/deploymentMode"] = "Serverless"
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/ttl-from"] = "update"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+}
+
+func TestValidateUsageReportInterval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations["serving.kserve.io/usage-report-interval"] = "1h"
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/usage-report-interval"] = "1d"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations["serving.kserve.io/usage-report-interval"] = "0s"
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+}
+
+func TestValidateKeepWarm(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Interval": {
+			annotations: map[string]string{"serving.kserve.io/keep-warm-interval": "5m"},
+			matcher:     gomega.Succeed(),
+		},
+		"Schedule": {
+			annotations: map[string]string{
+				"serving.kserve.io/keep-warm-interval": "5m",
+				"serving.kserve.io/keep-warm-schedule": "CRON_TZ=Europe/Berlin 0 8 * * 1-5",
+				"serving.kserve.io/keep-warm-window":   "10h",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidInterval": {
+			annotations: map[string]string{"serving.kserve.io/keep-warm-interval": "0s"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"InvalidSchedule": {
+			annotations: map[string]string{
+				"serving.kserve.io/keep-warm-interval": "5m",
+				"serving.kserve.io/keep-warm-schedule": "weekdays",
+				"serving.kserve.io/keep-warm-window":   "10h",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"ScheduleWithoutWindow": {
+			annotations: map[string]string{
+				"serving.kserve.io/keep-warm-interval": "5m",
+				"serving.kserve.io/keep-warm-schedule": "0 8 * * 1-5",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"WindowWithoutSchedule": {
+			annotations: map[string]string{
+				"serving.kserve.io/keep-warm-interval": "5m",
+				"serving.kserve.io/keep-warm-window":   "10h",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"ScheduleWithoutInterval": {
+			annotations: map[string]string{
+				"serving.kserve.io/keep-warm-schedule": "0 8 * * 1-5",
+				"serving.kserve.io/keep-warm-window":   "10h",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateDeprecation(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Deprecated": {
+			annotations: map[string]string{
+				"serving.kserve.io/deprecation":              "2023-01-01T00:00:00Z",
+				"serving.kserve.io/sunset":                   "2023-03-01T00:00:00Z",
+				"serving.kserve.io/sunset-reject-percentage": "50",
+				"serving.kserve.io/deprecation-link":         "https://example.com/migration",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidDeprecation": {
+			annotations: map[string]string{"serving.kserve.io/deprecation": "2023-01-01"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"SunsetWithoutDeprecation": {
+			annotations: map[string]string{"serving.kserve.io/sunset": "2023-03-01T00:00:00Z"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"SunsetBeforeDeprecation": {
+			annotations: map[string]string{
+				"serving.kserve.io/deprecation": "2023-03-01T00:00:00Z",
+				"serving.kserve.io/sunset":      "2023-01-01T00:00:00Z",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"RejectPercentageWithoutSunset": {
+			annotations: map[string]string{
+				"serving.kserve.io/deprecation":              "2023-01-01T00:00:00Z",
+				"serving.kserve.io/sunset-reject-percentage": "50",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"InvalidRejectPercentage": {
+			annotations: map[string]string{
+				"serving.kserve.io/deprecation":              "2023-01-01T00:00:00Z",
+				"serving.kserve.io/sunset":                   "2023-03-01T00:00:00Z",
+				"serving.kserve.io/sunset-reject-percentage": "150",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateCircuitBreaker(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"CircuitBreakerAndRetryBudget": {
+			annotations: map[string]string{
+				"serving.kserve.io/circuit-breaker-failure-threshold": "5",
+				"serving.kserve.io/circuit-breaker-open-duration":     "30s",
+				"serving.kserve.io/retry-budget-percentage":           "20",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidFailureThreshold": {
+			annotations: map[string]string{"serving.kserve.io/circuit-breaker-failure-threshold": "0"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"OpenDurationWithoutFailureThreshold": {
+			annotations: map[string]string{"serving.kserve.io/circuit-breaker-open-duration": "30s"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"InvalidOpenDuration": {
+			annotations: map[string]string{
+				"serving.kserve.io/circuit-breaker-failure-threshold": "5",
+				"serving.kserve.io/circuit-breaker-open-duration":     "30",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"InvalidRetryBudget": {
+			annotations: map[string]string{"serving.kserve.io/retry-budget-percentage": "150"},
+			matcher:     gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateOOMRemediation(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"FactorAndMaxMemory": {
+			annotations: map[string]string{
+				"serving.kserve.io/oom-memory-factor": "1.5",
+				"serving.kserve.io/oom-max-memory":    "16Gi",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"FactorWithoutMaxMemory": {
+			annotations: map[string]string{"serving.kserve.io/oom-memory-factor": "1.5"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"MaxMemoryWithoutFactor": {
+			annotations: map[string]string{"serving.kserve.io/oom-max-memory": "16Gi"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"InvalidFactor": {
+			annotations: map[string]string{
+				"serving.kserve.io/oom-memory-factor": "0.5",
+				"serving.kserve.io/oom-max-memory":    "16Gi",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"InvalidMaxMemory": {
+			annotations: map[string]string{
+				"serving.kserve.io/oom-memory-factor": "2",
+				"serving.kserve.io/oom-max-memory":    "16 GB",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateModelConversion(t *testing.T) {
+	scenarios := map[string]struct {
+		outputStorageURI string
+		matcher          types.GomegaMatcher
+	}{
+		"PvcWithPath": {
+			outputStorageURI: "pvc://engines/llama",
+			matcher:          gomega.Succeed(),
+		},
+		"Pvc": {
+			outputStorageURI: "pvc://engines",
+			matcher:          gomega.Succeed(),
+		},
+		"MissingPvcName": {
+			outputStorageURI: "pvc:///llama",
+			matcher:          gomega.HaveOccurred(),
+		},
+		"ObjectStorage": {
+			outputStorageURI: "s3://engines/llama",
+			matcher:          gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.Spec.Predictor.Conversion = &ModelConversion{
+				Image:            "kserve/trtllm-build:latest",
+				OutputStorageURI: scenario.outputStorageURI,
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateGraphOptimization(t *testing.T) {
+	workspace := resource.MustParse("2Gi")
+	zero := resource.MustParse("0")
+	shape := DynamicShape{Input: "input", Min: []int64{1, 3, 224, 224}, Opt: []int64{8, 3, 224, 224}, Max: []int64{32, 3, 224, 224}}
+	scenarios := map[string]struct {
+		modelFormat  string
+		optimization GraphOptimization
+		matcher      types.GomegaMatcher
+	}{
+		"Onnx": {
+			modelFormat:  "onnx",
+			optimization: GraphOptimization{Precision: PrecisionFP16, WorkspaceSize: &workspace, DynamicShapes: []DynamicShape{shape}},
+			matcher:      gomega.Succeed(),
+		},
+		"UnsupportedModelFormat": {
+			modelFormat:  "sklearn",
+			optimization: GraphOptimization{Precision: PrecisionFP16},
+			matcher:      gomega.MatchError(UnsupportedOptimizationError),
+		},
+		"ZeroWorkspace": {
+			modelFormat:  "onnx",
+			optimization: GraphOptimization{WorkspaceSize: &zero},
+			matcher:      gomega.MatchError(InvalidOptimizationWorkspaceError),
+		},
+		"MismatchedDimensions": {
+			modelFormat: "onnx",
+			optimization: GraphOptimization{DynamicShapes: []DynamicShape{
+				{Input: "input", Min: []int64{1, 3}, Opt: []int64{8, 3, 224}, Max: []int64{32, 3, 224}},
+			}},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDynamicShapeError, "input")),
+		},
+		"MinGreaterThanOpt": {
+			modelFormat: "onnx",
+			optimization: GraphOptimization{DynamicShapes: []DynamicShape{
+				{Input: "input", Min: []int64{16, 3}, Opt: []int64{8, 3}, Max: []int64{32, 3}},
+			}},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDynamicShapeError, "input")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.Spec.Predictor.Tensorflow = nil
+			isvc.Spec.Predictor.Model = &ModelSpec{
+				ModelFormat: ModelFormat{Name: scenario.modelFormat},
+				PredictorExtensionSpec: PredictorExtensionSpec{
+					StorageURI: proto.String("gs://testbucket/testmodel"),
+				},
+			}
+			isvc.Spec.Predictor.Optimization = &scenario.optimization
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateRefreshSchedule(t *testing.T) {
+	scenarios := map[string]struct {
+		schedule   string
+		storageURI *string
+		matcher    types.GomegaMatcher
+	}{
+		"Nightly": {
+			schedule:   "0 2 * * *",
+			storageURI: proto.String("gs://testbucket/testmodel"),
+			matcher:    gomega.Succeed(),
+		},
+		"Descriptor": {
+			schedule:   "@daily",
+			storageURI: proto.String("gs://testbucket/testmodel"),
+			matcher:    gomega.Succeed(),
+		},
+		"InvalidSchedule": {
+			schedule:   "0 2 * *",
+			storageURI: proto.String("gs://testbucket/testmodel"),
+			matcher:    gomega.MatchError(fmt.Sprintf(InvalidRefreshScheduleError, "0 2 * *")),
+		},
+		"NoStorageURI": {
+			schedule: "0 2 * * *",
+			matcher:  gomega.MatchError(MissingRefreshStorageError),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.Spec.Predictor.Tensorflow.StorageURI = scenario.storageURI
+			isvc.Spec.Predictor.RefreshSchedule = proto.String(scenario.schedule)
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidatePredictionSink(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"KafkaSink": {
+			annotations: map[string]string{
+				"serving.kserve.io/prediction-sink-url":    "http://kafka-sink-ingress.knative-eventing.svc.cluster.local/default/predictions",
+				"serving.kserve.io/prediction-buffer-size": "2Gi",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"Webhook": {
+			annotations: map[string]string{"serving.kserve.io/prediction-sink-url": "https://events.example.com/predictions"},
+			matcher:     gomega.Succeed(),
+		},
+		"BufferSizeWithoutSink": {
+			annotations: map[string]string{"serving.kserve.io/prediction-buffer-size": "2Gi"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"KafkaURL": {
+			annotations: map[string]string{"serving.kserve.io/prediction-sink-url": "kafka://my-cluster-kafka-bootstrap:9092/predictions"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidSinkURLError, "kafka://my-cluster-kafka-bootstrap:9092/predictions",
+				"serving.kserve.io/prediction-sink-url")),
+		},
+		"InvalidBufferSize": {
+			annotations: map[string]string{
+				"serving.kserve.io/prediction-sink-url":    "https://events.example.com/predictions",
+				"serving.kserve.io/prediction-buffer-size": "2 GB",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidBufferSizeError, "2 GB", "serving.kserve.io/prediction-buffer-size")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateLoggerRetries(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"DeadLetter": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-retry-max-age":     "1h",
+				"serving.kserve.io/logger-retry-buffer-size": "2Gi",
+				"serving.kserve.io/logger-dead-letter-uri":   "s3://logger/dead-letter",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"DeadLetterWithoutMaxAge": {
+			annotations: map[string]string{"serving.kserve.io/logger-dead-letter-uri": "s3://logger/dead-letter"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError, "serving.kserve.io/logger-dead-letter-uri",
+				"serving.kserve.io/logger-retry-max-age")),
+		},
+		"InvalidMaxAge": {
+			annotations: map[string]string{"serving.kserve.io/logger-retry-max-age": "-1h"},
+			matcher:     gomega.MatchError(fmt.Sprintf(InvalidDurationError, "-1h", "serving.kserve.io/logger-retry-max-age")),
+		},
+		"InvalidBufferSize": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-retry-max-age":     "1h",
+				"serving.kserve.io/logger-retry-buffer-size": "0",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidBufferSizeError, "0", "serving.kserve.io/logger-retry-buffer-size")),
+		},
+		"GCSDeadLetter": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-retry-max-age":   "1h",
+				"serving.kserve.io/logger-dead-letter-uri": "gs://logger/dead-letter",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDeadLetterURIError, "gs://logger/dead-letter",
+				"serving.kserve.io/logger-dead-letter-uri")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateLoggerBlobSink(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Batches": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-blob-batch-size":     "500",
+				"serving.kserve.io/logger-blob-flush-interval": "5m",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidBatchSize": {
+			annotations: map[string]string{"serving.kserve.io/logger-blob-batch-size": "0"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPositiveIntegerError, "0",
+				"serving.kserve.io/logger-blob-batch-size")),
+		},
+		"InvalidFlushInterval": {
+			annotations: map[string]string{"serving.kserve.io/logger-blob-flush-interval": "5"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDurationError, "5",
+				"serving.kserve.io/logger-blob-flush-interval")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateLoggerEvents(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"StructuredEvents": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-encoding":       "structured",
+				"serving.kserve.io/logger-event-type":     "com.example.inference.{{ .Kind }}",
+				"serving.kserve.io/logger-event-source":   "/models/{{ .Namespace }}/{{ .InferenceService }}/{{ .Component }}",
+				"serving.kserve.io/logger-schema-version": "2",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidEncoding": {
+			annotations: map[string]string{"serving.kserve.io/logger-encoding": "avro"},
+			matcher:     gomega.MatchError(fmt.Sprintf(InvalidLoggerEncodingError, "avro", "serving.kserve.io/logger-encoding")),
+		},
+		"UnknownTemplateField": {
+			annotations: map[string]string{"serving.kserve.io/logger-event-type": "com.example.{{ .Model }}"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"EmptyEventType": {
+			annotations: map[string]string{"serving.kserve.io/logger-event-type": "{{ if false }}x{{ end }}"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"InvalidEventSource": {
+			annotations: map[string]string{"serving.kserve.io/logger-event-source": "http://models/%zz"},
+			matcher:     gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestRenderLoggerEventTemplate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	rendered, err := RenderLoggerEventTemplate("/models/{{ .Namespace }}/{{ .InferenceService }}/{{ .Component }}/{{ .Endpoint }}/{{ .Kind }}",
+		"sklearn", "default", "predictor", "canary", "response")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(rendered).To(gomega.Equal("/models/default/sklearn/predictor/canary/response"))
+}
+
+func TestValidateLoggerEncryption(t *testing.T) {
+	keyRef := "aws-kms://arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"KMSKey": {
+			annotations: map[string]string{"serving.kserve.io/logger-encryption-kms-key": keyRef},
+			matcher:     gomega.Succeed(),
+		},
+		"KMSAlias": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-encryption-kms-key": "aws-kms://arn:aws:kms:eu-west-1:111122223333:alias/kserve-logs",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidKMSKey": {
+			annotations: map[string]string{"serving.kserve.io/logger-encryption-kms-key": "alias/kserve-logs"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidKMSKeyError, "alias/kserve-logs",
+				"serving.kserve.io/logger-encryption-kms-key")),
+		},
+		"KMSKeyAndSecret": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-encryption-kms-key":    keyRef,
+				"serving.kserve.io/logger-encryption-key-secret": "logger-key",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(ConflictingAnnotationsError,
+				"serving.kserve.io/logger-encryption-kms-key", "serving.kserve.io/logger-encryption-key-secret")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateLoggerTransform(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"HashedFieldsAndWebhook": {
+			annotations: map[string]string{
+				"serving.kserve.io/logger-transform-url":   "http://anonymizer.default/transform",
+				"serving.kserve.io/logger-hash-fields":     "email, ssn",
+				"serving.kserve.io/logger-hash-key-secret": "hash-key",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidTransformURL": {
+			annotations: map[string]string{"serving.kserve.io/logger-transform-url": "anonymizer:8080"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTransformURLError, "anonymizer:8080",
+				"serving.kserve.io/logger-transform-url")),
+		},
+		"EmptyHashField": {
+			annotations: map[string]string{"serving.kserve.io/logger-hash-fields": "email,,ssn"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidHashFieldsError, "email,,ssn",
+				"serving.kserve.io/logger-hash-fields")),
+		},
+		"HashKeyWithoutFields": {
+			annotations: map[string]string{"serving.kserve.io/logger-hash-key-secret": "hash-key"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError,
+				"serving.kserve.io/logger-hash-key-secret", "serving.kserve.io/logger-hash-fields")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateAgentPipelineOrder(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"LogBatch": {
+			annotations: map[string]string{"serving.kserve.io/agent-pipeline-order": "log-batch"},
+			matcher:     gomega.Succeed(),
+		},
+		"BatchLog": {
+			annotations: map[string]string{"serving.kserve.io/agent-pipeline-order": "batch-log"},
+			matcher:     gomega.Succeed(),
+		},
+		"InvalidOrder": {
+			annotations: map[string]string{"serving.kserve.io/agent-pipeline-order": "batch"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPipelineOrderError, "batch",
+				"serving.kserve.io/agent-pipeline-order")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateModelReadiness(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"MinModels": {
+			annotations: map[string]string{
+				"serving.kserve.io/model-readiness-policy":     "min-models",
+				"serving.kserve.io/model-readiness-min-models": "3",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InitialModels": {
+			annotations: map[string]string{"serving.kserve.io/model-readiness-policy": "initial-models"},
+			matcher:     gomega.Succeed(),
+		},
+		"InvalidPolicy": {
+			annotations: map[string]string{"serving.kserve.io/model-readiness-policy": "ready"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelReadinessPolicyError, "ready",
+				"serving.kserve.io/model-readiness-policy")),
+		},
+		"InvalidMinModels": {
+			annotations: map[string]string{
+				"serving.kserve.io/model-readiness-policy":     "min-models",
+				"serving.kserve.io/model-readiness-min-models": "0",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPositiveIntegerError, "0",
+				"serving.kserve.io/model-readiness-min-models")),
+		},
+		"MinModelsWithoutPolicy": {
+			annotations: map[string]string{"serving.kserve.io/model-readiness-min-models": "3"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError,
+				"serving.kserve.io/model-readiness-min-models", "serving.kserve.io/model-readiness-policy")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidatePayloadReferences(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Prefixes": {
+			annotations: map[string]string{
+				"serving.kserve.io/payload-uri-prefixes": "s3://inputs/images,gs://inputs",
+				"serving.kserve.io/payload-max-size":     "500Mi",
+				"serving.kserve.io/payload-response-uri": "s3://outputs/predictions",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"ResponseURIWithoutPrefixes": {
+			annotations: map[string]string{"serving.kserve.io/payload-response-uri": "s3://outputs/predictions"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError, "serving.kserve.io/payload-response-uri",
+				"serving.kserve.io/payload-uri-prefixes")),
+		},
+		"HTTPPrefix": {
+			annotations: map[string]string{"serving.kserve.io/payload-uri-prefixes": "s3://inputs,https://example.com"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPayloadURIError, "https://example.com",
+				"serving.kserve.io/payload-uri-prefixes")),
+		},
+		"PrefixWithoutBucket": {
+			annotations: map[string]string{"serving.kserve.io/payload-uri-prefixes": "s3://"},
+			matcher:     gomega.MatchError(fmt.Sprintf(InvalidPayloadURIError, "s3://", "serving.kserve.io/payload-uri-prefixes")),
+		},
+		"InvalidMaxSize": {
+			annotations: map[string]string{
+				"serving.kserve.io/payload-uri-prefixes": "s3://inputs",
+				"serving.kserve.io/payload-max-size":     "500 MB",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidBufferSizeError, "500 MB", "serving.kserve.io/payload-max-size")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateIngressGateway(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Gateway": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "team-a/team-a-gateway"},
+			matcher:     gomega.Succeed(),
+		},
+		"GatewayWithoutNamespace": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "team-a-gateway"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidIngressGatewayError, "team-a-gateway",
+				"serving.kserve.io/ingress-gateway")),
+		},
+		"InvalidNamespace": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "Team_A/team-a-gateway"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidIngressGatewayError, "Team_A/team-a-gateway",
+				"serving.kserve.io/ingress-gateway")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.validate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateAllowedIngressGateway(t *testing.T) {
+	ingressConfig := &IngressConfig{
+		IngressGateway:         "knative-serving/knative-ingress-gateway",
+		KserveIngressGateway:   "kserve/kserve-ingress-gateway",
+		AllowedIngressGateways: []string{"team-a/team-a-gateway"},
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"NoGateway": {
+			annotations: map[string]string{},
+			matcher:     gomega.Succeed(),
+		},
+		"IngressGateway": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "knative-serving/knative-ingress-gateway"},
+			matcher:     gomega.Succeed(),
+		},
+		"KserveIngressGateway": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "kserve/kserve-ingress-gateway"},
+			matcher:     gomega.Succeed(),
+		},
+		"AllowedGateway": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "team-a/team-a-gateway"},
+			matcher:     gomega.Succeed(),
+		},
+		"GatewayNotAllowed": {
+			annotations: map[string]string{"serving.kserve.io/ingress-gateway": "team-b/team-b-gateway"},
+			matcher: gomega.MatchError(fmt.Sprintf(IngressGatewayNotAllowedError, "team-b/team-b-gateway",
+				"serving.kserve.io/ingress-gateway")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateIngressGateway(ingressConfig)).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateCorsAllowOrigins(t *testing.T) {
+	scenarios := map[string]struct {
+		origins string
+		matcher types.GomegaMatcher
+	}{
+		"Origins": {
+			origins: "https://app.example.com, http://localhost:3000",
+			matcher: gomega.Succeed(),
+		},
+		"AnyOrigin": {
+			origins: "*",
+			matcher: gomega.Succeed(),
+		},
+		"Disabled": {
+			origins: "",
+			matcher: gomega.Succeed(),
+		},
+		"OriginWithPath": {
+			origins: "https://app.example.com/ui",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidCorsOriginError, "https://app.example.com/ui",
+				"serving.kserve.io/cors-allow-origins")),
+		},
+		"OriginWithoutScheme": {
+			origins: "https://app.example.com,app.example.com",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidCorsOriginError, "app.example.com",
+				"serving.kserve.io/cors-allow-origins")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/cors-allow-origins"] = scenario.origins
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateCanaryHeader(t *testing.T) {
+	scenarios := map[string]struct {
+		header  string
+		matcher types.GomegaMatcher
+	}{
+		"Header": {
+			header:  "x-model-revision: canary",
+			matcher: gomega.Succeed(),
+		},
+		"MissingValue": {
+			header: "x-model-revision:",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidCanaryHeaderError, "x-model-revision:",
+				"serving.kserve.io/canary-header")),
+		},
+		"InvalidName": {
+			header: "x model revision: canary",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidCanaryHeaderError, "x model revision: canary",
+				"serving.kserve.io/canary-header")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/canary-header"] = scenario.header
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateFallback(t *testing.T) {
+	scenarios := map[string]struct {
+		fallback string
+		matcher  types.GomegaMatcher
+	}{
+		"InferenceService": {
+			fallback: "sklearn-baseline",
+			matcher:  gomega.Succeed(),
+		},
+		"Self": {
+			fallback: "foo",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidFallbackError, "foo",
+				"serving.kserve.io/fallback-inference-service")),
+		},
+		"InvalidName": {
+			fallback: "default/sklearn-baseline",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidFallbackError, "default/sklearn-baseline",
+				"serving.kserve.io/fallback-inference-service")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/fallback-inference-service"] = scenario.fallback
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateCloudIdentity(t *testing.T) {
+	secretEnv := v1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", Value: "copied-secret"}
+	scenarios := map[string]struct {
+		mutate  func(isvc *InferenceService)
+		matcher types.GomegaMatcher
+	}{
+		"MountCloudIdentity": {
+			mutate: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.MountCloudIdentity = true
+				isvc.Spec.Predictor.Tensorflow.Env = []v1.EnvVar{{Name: "AWS_DEFAULT_REGION", Value: "us-west-2"}}
+			},
+			matcher: gomega.Succeed(),
+		},
+		"CredentialEnvWithoutCloudIdentity": {
+			mutate: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.Tensorflow.Env = []v1.EnvVar{secretEnv}
+			},
+			matcher: gomega.Succeed(),
+		},
+		"PredictorCredentialEnv": {
+			mutate: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.MountCloudIdentity = true
+				isvc.Spec.Predictor.Tensorflow.Name = "kserve-container"
+				isvc.Spec.Predictor.Tensorflow.Env = []v1.EnvVar{secretEnv}
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(CloudCredentialEnvError, "AWS_SECRET_ACCESS_KEY", "kserve-container")),
+		},
+		"TransformerCredentialEnv": {
+			mutate: func(isvc *InferenceService) {
+				isvc.Spec.Transformer = &TransformerSpec{
+					PodSpec: PodSpec{
+						Containers: []v1.Container{{
+							Name:  "kserve-container",
+							Image: "feast-transformer:latest",
+							Env:   []v1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/secrets/key.json"}},
+						}},
+					},
+					ComponentExtensionSpec: ComponentExtensionSpec{MountCloudIdentity: true},
+				}
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(CloudCredentialEnvError, "GOOGLE_APPLICATION_CREDENTIALS",
+				"kserve-container")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			scenario.mutate(&isvc)
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateFaultInjection(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"DelayAndAbort": {
+			annotations: map[string]string{
+				"serving.kserve.io/fault-delay":            "2s",
+				"serving.kserve.io/fault-delay-percentage": "50",
+				"serving.kserve.io/fault-abort-status":     "503",
+				"serving.kserve.io/fault-abort-percentage": "10",
+				"serving.kserve.io/fault-ttl":              "15m",
+				"serving.kserve.io/fault-expiry":           "2023-05-02T09:45:00Z",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"MissingFault": {
+			annotations: map[string]string{"serving.kserve.io/fault-ttl": "15m"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingFaultError, "serving.kserve.io/fault-ttl",
+				"serving.kserve.io/fault-delay", "serving.kserve.io/fault-abort-status")),
+		},
+		"MissingTTL": {
+			annotations: map[string]string{"serving.kserve.io/fault-abort-status": "503"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError,
+				"serving.kserve.io/fault-delay or serving.kserve.io/fault-abort-status", "serving.kserve.io/fault-ttl")),
+		},
+		"InvalidDelay": {
+			annotations: map[string]string{"serving.kserve.io/fault-delay": "2", "serving.kserve.io/fault-ttl": "15m"},
+			matcher:     gomega.MatchError(fmt.Sprintf(InvalidDurationError, "2", "serving.kserve.io/fault-delay")),
+		},
+		"InvalidAbortStatus": {
+			annotations: map[string]string{"serving.kserve.io/fault-abort-status": "200", "serving.kserve.io/fault-ttl": "15m"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidFaultAbortStatusError, "200",
+				"serving.kserve.io/fault-abort-status")),
+		},
+		"DelayPercentageWithoutDelay": {
+			annotations: map[string]string{
+				"serving.kserve.io/fault-abort-status":     "503",
+				"serving.kserve.io/fault-delay-percentage": "50",
+				"serving.kserve.io/fault-ttl":              "15m",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError,
+				"serving.kserve.io/fault-delay-percentage", "serving.kserve.io/fault-delay")),
+		},
+		"InvalidPercentage": {
+			annotations: map[string]string{
+				"serving.kserve.io/fault-abort-status":     "503",
+				"serving.kserve.io/fault-abort-percentage": "150",
+				"serving.kserve.io/fault-ttl":              "15m",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPercentageError, "150",
+				"serving.kserve.io/fault-abort-percentage")),
+		},
+		"TTLTooLong": {
+			annotations: map[string]string{"serving.kserve.io/fault-delay": "2s", "serving.kserve.io/fault-ttl": "48h"},
+			matcher:     gomega.MatchError(fmt.Sprintf(InvalidFaultTTLError, "48h", "serving.kserve.io/fault-ttl")),
+		},
+		"InvalidExpiry": {
+			annotations: map[string]string{
+				"serving.kserve.io/fault-delay":  "2s",
+				"serving.kserve.io/fault-ttl":    "15m",
+				"serving.kserve.io/fault-expiry": "tomorrow",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTimestampError, "tomorrow", "serving.kserve.io/fault-expiry")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateMaintenance(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Maintenance": {
+			annotations: map[string]string{
+				"serving.kserve.io/maintenance":             "true",
+				"serving.kserve.io/maintenance-body":        `{"error": "Scheduled maintenance until 10:00 UTC"}`,
+				"serving.kserve.io/maintenance-retry-after": "30m",
+				"serving.kserve.io/agent-hot-reload":        "true",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidValue": {
+			annotations: map[string]string{
+				"serving.kserve.io/maintenance":      "on",
+				"serving.kserve.io/agent-hot-reload": "true",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidMaintenanceError, "on", "serving.kserve.io/maintenance")),
+		},
+		"MissingHotReload": {
+			annotations: map[string]string{"serving.kserve.io/maintenance": "false"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError, "serving.kserve.io/maintenance",
+				"serving.kserve.io/agent-hot-reload")),
+		},
+		"InvalidBody": {
+			annotations: map[string]string{
+				"serving.kserve.io/maintenance":      "true",
+				"serving.kserve.io/maintenance-body": "Under maintenance",
+				"serving.kserve.io/agent-hot-reload": "true",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidMaintenanceBodyError, "Under maintenance",
+				"serving.kserve.io/maintenance-body")),
+		},
+		"InvalidRetryAfter": {
+			annotations: map[string]string{
+				"serving.kserve.io/maintenance":             "true",
+				"serving.kserve.io/maintenance-retry-after": "600",
+				"serving.kserve.io/agent-hot-reload":        "true",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDurationError, "600",
+				"serving.kserve.io/maintenance-retry-after")),
+		},
+		"BodyWithoutMaintenance": {
+			annotations: map[string]string{"serving.kserve.io/maintenance-body": `{"error": "Under maintenance"}`},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError, "serving.kserve.io/maintenance-body",
+				"serving.kserve.io/maintenance")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateModelNames(t *testing.T) {
+	scenarios := map[string]struct {
+		names   string
+		matcher types.GomegaMatcher
+	}{
+		"Names": {
+			names:   "support=llama-2-7b-support,chat=llama-2-7b",
+			matcher: gomega.Succeed(),
+		},
+		"MissingInternalName": {
+			names: "support=",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelNamesError, "support=",
+				"serving.kserve.io/model-names")),
+		},
+		"DuplicateExternalName": {
+			names: "chat=llama-2-7b,chat=llama-2-13b",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelNamesError, "chat=llama-2-7b,chat=llama-2-13b",
+				"serving.kserve.io/model-names")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/model-names"] = scenario.names
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateAsync(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Async": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":       "true",
+				"serving.kserve.io/async-workers":      "2",
+				"serving.kserve.io/async-queue-size":   "50",
+				"serving.kserve.io/async-result-ttl":   "30m",
+				"serving.kserve.io/async-callback-url": "http://broker-ingress.knative-eventing/default/default",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"WorkersWithoutAsync": {
+			annotations: map[string]string{"serving.kserve.io/async-workers": "2"},
+			matcher: gomega.MatchError(fmt.Sprintf(MissingRequiredAnnotationError, "serving.kserve.io/async-workers",
+				"serving.kserve.io/enable-async")),
+		},
+		"InvalidQueueSize": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":     "true",
+				"serving.kserve.io/async-queue-size": "0",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPositiveIntegerError, "0", "serving.kserve.io/async-queue-size")),
+		},
+		"InvalidResultTTL": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":     "true",
+				"serving.kserve.io/async-result-ttl": "1 day",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidDurationError, "1 day", "serving.kserve.io/async-result-ttl")),
+		},
+		"InvalidCallbackURL": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":       "true",
+				"serving.kserve.io/async-callback-url": "kafka://my-cluster-kafka-bootstrap:9092/responses",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidCallbackURLError, "kafka://my-cluster-kafka-bootstrap:9092/responses",
+				"serving.kserve.io/async-callback-url")),
+		},
+		"Broker": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":     "true",
+				"serving.kserve.io/async-broker-url": "redis://redis.kserve:6379/0",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidBrokerURL": {
+			annotations: map[string]string{
+				"serving.kserve.io/enable-async":     "true",
+				"serving.kserve.io/async-broker-url": "nats://nats.kserve:4222",
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidBrokerURLError, "nats://nats.kserve:4222",
+				"serving.kserve.io/async-broker-url")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateRawConcurrencyScaling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations["serving.kserve.io/metrics"] = "concurrency"
+	metric := MetricConcurrency
+	isvc.Spec.Predictor.ScaleMetric = &metric
+	isvc.Spec.Predictor.ScaleTarget = GetIntReference(200)
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.Spec.Predictor.ScaleTarget = GetIntReference(0)
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+}
+
+func TestValidateRawGPUScaling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations["serving.kserve.io/metrics"] = "gpu-memory"
+	metric := MetricGPUMemory
+	isvc.Spec.Predictor.ScaleMetric = &metric
+	isvc.Spec.Predictor.ScaleTarget = GetIntReference(70)
+	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
+
+	isvc.Spec.Predictor.ScaleTarget = GetIntReference(120)
+	g.Expect(isvc.ValidateCreate()).ShouldNot(gomega.Succeed())
+}
+
 func TestInvalidAutoscalerHPAMetrics(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestRawInferenceService()
@@ -310,6 +1680,189 @@ func TestGoodExplainer(t *testing.T) {
 	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
 }
 
+func TestValidateExplainerColocation(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		explainer   *ExplainerSpec
+		matcher     types.GomegaMatcher
+	}{
+		"ColocatedExplainer": {
+			annotations: map[string]string{"serving.kserve.io/explainer-colocation": "true"},
+			explainer: &ExplainerSpec{ART: &ARTExplainerSpec{Type: "SquareAttack",
+				ExplainerExtensionSpec: ExplainerExtensionSpec{RuntimeVersion: proto.String("latest")}}},
+			matcher: gomega.Succeed(),
+		},
+		"NoExplainer": {
+			annotations: map[string]string{"serving.kserve.io/explainer-colocation": "true"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidExplainerColocationError,
+				"the InferenceService must have an explainer")),
+		},
+		"ServerlessMode": {
+			annotations: map[string]string{
+				"serving.kserve.io/explainer-colocation": "true",
+				"serving.kserve.io/deploymentMode":       "Serverless",
+			},
+			explainer: &ExplainerSpec{ART: &ARTExplainerSpec{Type: "SquareAttack",
+				ExplainerExtensionSpec: ExplainerExtensionSpec{RuntimeVersion: proto.String("latest")}}},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidExplainerColocationError,
+				"the deployment mode must be RawDeployment")),
+		},
+		"ExplainerStorageUri": {
+			annotations: map[string]string{"serving.kserve.io/explainer-colocation": "true"},
+			explainer: &ExplainerSpec{ART: &ARTExplainerSpec{Type: "SquareAttack",
+				ExplainerExtensionSpec: ExplainerExtensionSpec{RuntimeVersion: proto.String("latest"),
+					StorageURI: "gs://testbucket/explainer"}}},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidExplainerColocationError,
+				"the explainer must not have a storageUri")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			isvc.Spec.Explainer = scenario.explainer
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateStorageDownloadWorkers(t *testing.T) {
+	scenarios := map[string]struct {
+		workers string
+		matcher types.GomegaMatcher
+	}{
+		"ValidWorkers": {
+			workers: "8",
+			matcher: gomega.Succeed(),
+		},
+		"ZeroWorkers": {
+			workers: "0",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPositiveIntegerError, "0",
+				"serving.kserve.io/storage-download-workers")),
+		},
+		"InvalidWorkers": {
+			workers: "many",
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidPositiveIntegerError, "many",
+				"serving.kserve.io/storage-download-workers")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.ObjectMeta.Annotations["serving.kserve.io/storage-download-workers"] = scenario.workers
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateTransformerReplicaRatio(t *testing.T) {
+	transformer := &TransformerSpec{PodSpec: PodSpec{Containers: []v1.Container{{Image: "transformer:latest"}}}}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		transformer *TransformerSpec
+		matcher     types.GomegaMatcher
+	}{
+		"ValidRatio": {
+			annotations: map[string]string{"serving.kserve.io/transformer-replica-ratio": "2:1"},
+			transformer: transformer,
+			matcher:     gomega.Succeed(),
+		},
+		"NoTransformer": {
+			annotations: map[string]string{"serving.kserve.io/transformer-replica-ratio": "2:1"},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTransformerReplicaRatioError,
+				"the InferenceService must have a transformer")),
+		},
+		"ServerlessMode": {
+			annotations: map[string]string{
+				"serving.kserve.io/transformer-replica-ratio": "2:1",
+				"serving.kserve.io/deploymentMode":            "Serverless",
+			},
+			transformer: transformer,
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTransformerReplicaRatioError,
+				"the deployment mode must be RawDeployment")),
+		},
+		"MissingPredictorReplicas": {
+			annotations: map[string]string{"serving.kserve.io/transformer-replica-ratio": "2"},
+			transformer: transformer,
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTransformerReplicaRatioError,
+				"the ratio \"2\" must be <transformer replicas>:<predictor replicas>, e.g. 2:1")),
+		},
+		"ZeroReplicas": {
+			annotations: map[string]string{"serving.kserve.io/transformer-replica-ratio": "0:1"},
+			transformer: transformer,
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidTransformerReplicaRatioError,
+				"the replicas of the ratio \"0:1\" must be positive integers")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			isvc.Spec.Transformer = scenario.transformer
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateSmokeTest(t *testing.T) {
+	scenarios := map[string]struct {
+		smokeTest   *SmokeTestSpec
+		annotations map[string]string
+		canary      bool
+		matcher     types.GomegaMatcher
+	}{
+		"SmokeTest": {
+			smokeTest: &SmokeTestSpec{
+				Payload:          `{"instances": [[6.8, 2.8, 4.8, 1.4]]}`,
+				ExpectedResponse: &SmokeTestResponseMatcher{Conditions: []string{"predictions.0"}, Pattern: `"predictions": ?\[1\]`},
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidPayload": {
+			smokeTest: &SmokeTestSpec{Payload: `{"instances": `},
+			matcher:   gomega.MatchError(fmt.Sprintf(InvalidSmokeTestError, "the payload must be a JSON document")),
+		},
+		"InvalidPattern": {
+			smokeTest: &SmokeTestSpec{Payload: `{}`, ExpectedResponse: &SmokeTestResponseMatcher{Pattern: "predictions: [1"}},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidSmokeTestError,
+				"the pattern must be a regular expression: error parsing regexp: missing closing ]: `[1`")),
+		},
+		"CanaryHeader": {
+			smokeTest:   &SmokeTestSpec{Payload: `{}`},
+			annotations: map[string]string{"serving.kserve.io/canary-header": "x-model-revision: canary"},
+			canary:      true,
+			matcher:     gomega.Succeed(),
+		},
+		"MissingCanaryHeader": {
+			smokeTest: &SmokeTestSpec{Payload: `{}`},
+			canary:    true,
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidSmokeTestError, "the canary traffic percent requires the "+
+				"annotation serving.kserve.io/canary-header routing the smoke test to the canary")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			for key, value := range scenario.annotations {
+				isvc.ObjectMeta.Annotations[key] = value
+			}
+			isvc.Spec.SmokeTest = scenario.smokeTest
+			if scenario.canary {
+				isvc.Spec.Predictor.CanaryTrafficPercent = proto.Int64(10)
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
+
 func TestGoodName(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
@@ -357,3 +1910,37 @@ func TestPMMLWorkersArguments(t *testing.T) {
 	isvc.Spec.Predictor.PMML.Container.Args = []string{"--workers=1"}
 	g.Expect(isvc.ValidateCreate()).Should(gomega.Succeed())
 }
+
+func TestValidateFrameworkVersion(t *testing.T) {
+	scenarios := map[string]struct {
+		frameworkVersion *string
+		matcher          types.GomegaMatcher
+	}{
+		"NoFrameworkVersion": {
+			matcher: gomega.Succeed(),
+		},
+		"FrameworkVersion": {
+			frameworkVersion: proto.String("1.4.2"),
+			matcher:          gomega.Succeed(),
+		},
+		"InvalidFrameworkVersion": {
+			frameworkVersion: proto.String("1.4-rc1"),
+			matcher:          gomega.MatchError(fmt.Sprintf(InvalidFrameworkVersionError, "1.4-rc1")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestRawInferenceService()
+			isvc.Spec.Predictor = PredictorSpec{
+				Model: &ModelSpec{
+					ModelFormat: ModelFormat{Name: "sklearn", FrameworkVersion: scenario.frameworkVersion},
+					PredictorExtensionSpec: PredictorExtensionSpec{
+						StorageURI: proto.String("gs://testbucket/testmodel"),
+					},
+				},
+			}
+			g.Expect(isvc.ValidateCreate()).To(scenario.matcher)
+		})
+	}
+}
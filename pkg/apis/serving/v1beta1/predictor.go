@@ -18,10 +18,12 @@ package v1beta1
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // PredictorImplementation defines common functions for all predictors e.g Tensorflow, Triton, etc
@@ -55,6 +57,22 @@ type PredictorSpec struct {
 	// Model spec for any arbitrary framework.
 	Model *ModelSpec `json:"model,omitempty"`
 
+	// Conversion converts the model before it is served, e.g. building a TensorRT-LLM engine from a Hugging Face
+	// model. The conversion runs once per model, conversion and node selector, the predictor serves the converted
+	// model from the output storage.
+	// +optional
+	Conversion *ModelConversion `json:"conversion,omitempty"`
+
+	// Optimization of the model graph ahead of time by the runtime, e.g. building the TensorRT engines of an ONNX
+	// model. Supported for the onnx model format served by ONNX Runtime or Triton.
+	// +optional
+	Optimization *GraphOptimization `json:"optimization,omitempty"`
+
+	// RefreshSchedule is a cron schedule in the standard five field format, e.g. "0 2 * * *", on which the model is
+	// downloaded again from its storage uri. A new revision is rolled out only when the digest of the model changed.
+	// +optional
+	RefreshSchedule *string `json:"refreshSchedule,omitempty"`
+
 	// This spec is dual purpose. <br />
 	// 1) Provide a full PodSpec for custom predictor.
 	// The field PodSpec.Containers is mutually exclusive with other predictors (i.e. TFServing). <br />
@@ -103,6 +121,65 @@ type StorageSpec struct {
 	StorageKey *string `json:"key,omitempty"`
 }
 
+// ModelConversion defines a step converting the model before it is served. The model is downloaded to /mnt/models
+// and the conversion writes the converted model to /mnt/converted.
+type ModelConversion struct {
+	// Image of the conversion container.
+	Image string `json:"image"`
+	// Entrypoint array of the conversion container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Arguments to the entrypoint of the conversion container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// List of environment variables to set in the conversion container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// Compute Resources required by the conversion container.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// The pvc://<pvcname>/<path> location the converted models are cached in, each conversion is stored under its
+	// own hash in this path.
+	OutputStorageURI string `json:"outputStorageUri"`
+}
+
+// OptimizationPrecision is the precision the model graph is optimized for
+type OptimizationPrecision string
+
+// OptimizationPrecision enum
+const (
+	PrecisionFP32 OptimizationPrecision = "FP32"
+	PrecisionFP16 OptimizationPrecision = "FP16"
+	PrecisionINT8 OptimizationPrecision = "INT8"
+)
+
+// GraphOptimization defines the ahead-of-time optimization of the model graph, the controller maps it to the
+// arguments and environment variables of the runtime.
+type GraphOptimization struct {
+	// Precision the graph is optimized for, FP32 by default.
+	// +kubebuilder:validation:Enum=FP32;FP16;INT8
+	// +optional
+	Precision OptimizationPrecision `json:"precision,omitempty"`
+	// Maximum memory the optimizer may use as workspace, e.g. 2Gi.
+	// +optional
+	WorkspaceSize *resource.Quantity `json:"workspaceSize,omitempty"`
+	// Shape ranges of the inputs with dynamic dimensions the graph is optimized for.
+	// +optional
+	DynamicShapes []DynamicShape `json:"dynamicShapes,omitempty"`
+}
+
+// DynamicShape defines the range of shapes of an input with dynamic dimensions
+type DynamicShape struct {
+	// Name of the input.
+	Input string `json:"input"`
+	// Minimum shape of the input, e.g. [1, 3, 224, 224].
+	Min []int64 `json:"min"`
+	// Shape the graph is optimized for.
+	Opt []int64 `json:"opt"`
+	// Maximum shape of the input.
+	Max []int64 `json:"max"`
+}
+
 // GetImplementations returns the implementations for the component
 func (s *PredictorSpec) GetImplementations() []ComponentImplementation {
 	implementations := NonNilComponents([]ComponentImplementation{
@@ -135,6 +212,15 @@ func (s *PredictorSpec) GetExtensions() *ComponentExtensionSpec {
 	return &s.ComponentExtensionSpec
 }
 
+// IsGraphOptimizationSupported returns whether the graph optimization can be mapped to the runtime of the predictor,
+// the ONNX Runtime and Triton predictors and the onnx model format are supported
+func (s *PredictorSpec) IsGraphOptimizationSupported() bool {
+	if s.ONNX != nil || s.Triton != nil {
+		return true
+	}
+	return s.Model != nil && strings.ToLower(s.Model.ModelFormat.Name) == constants.SupportedModelONNX
+}
+
 // Validate returns an error if invalid
 func (p *PredictorExtensionSpec) Validate() error {
 	return utils.FirstNonNilError([]error{
@@ -18,11 +18,13 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -36,6 +38,10 @@ type ModelFormat struct {
 	// Can be "major", "major.minor" or "major.minor.patch".
 	// +optional
 	Version *string `json:"version,omitempty"`
+	// Version of the framework the model was saved with, e.g. "1.4.2" for a model pickled with scikit-learn 1.4.2.
+	// Used in validating that the runtime can load the model.
+	// +optional
+	FrameworkVersion *string `json:"frameworkVersion,omitempty"`
 }
 
 type ModelSpec struct {
@@ -120,19 +126,110 @@ func (m *ModelSpec) GetSupportingRuntimes(cl client.Client, namespace string, is
 		crt := &clusterRuntimes.Items[i]
 		if !crt.Spec.IsDisabled() && crt.Spec.IsMultiModelRuntime() == isMMS &&
 			m.RuntimeSupportsModel(&crt.Spec) && crt.Spec.IsProtocolVersionSupported(modelProtcolVersion) {
-			srSpecs = append(srSpecs, v1alpha1.SupportedRuntime{Name: crt.GetName(), Spec: crt.Spec})
+			allowed, err := IsClusterServingRuntimeAllowed(cl, &crt.Spec, namespace)
+			if err != nil {
+				return nil, err
+			}
+			if allowed {
+				srSpecs = append(srSpecs, v1alpha1.SupportedRuntime{Name: crt.GetName(), Spec: crt.Spec})
+			}
 		}
 	}
+	// The runtimes with the highest priority come first, the runtimes of the same priority keep their order, i.e. the
+	// ServingRuntimes before the ClusterServingRuntimes
+	sort.SliceStable(srSpecs, func(i, j int) bool {
+		return srSpecs[i].Spec.GetPriority() > srSpecs[j].Spec.GetPriority()
+	})
 	return srSpecs, nil
 }
 
+// IsClusterServingRuntimeAllowed returns whether the InferenceServices of the namespace can use the ClusterServingRuntime,
+// the labels of the namespace are only read for the runtimes restricted with a namespace selector
+func IsClusterServingRuntimeAllowed(cl client.Client, srSpec *v1alpha1.ServingRuntimeSpec, namespace string) (bool, error) {
+	var namespaceLabels map[string]string
+	if srSpec.NamespaceSelector != nil {
+		ns := &v1.Namespace{}
+		if err := cl.Get(context.TODO(), client.ObjectKey{Name: namespace}, ns); err != nil {
+			return false, err
+		}
+		namespaceLabels = ns.Labels
+	}
+	return srSpec.IsNamespaceAllowed(namespace, namespaceLabels), nil
+}
+
+// ValidateRuntime returns an error when the runtime of the model is disabled, is a ClusterServingRuntime not allowed
+// in the namespace or does not support the framework version of the model. A runtime which does not exist yet is
+// accepted, the controller waits for it.
+func (m *ModelSpec) ValidateRuntime(cl client.Client, namespace string) error {
+	if m.Runtime == nil {
+		return nil
+	}
+	runtime := &v1alpha1.ServingRuntime{}
+	err := cl.Get(context.TODO(), client.ObjectKey{Name: *m.Runtime, Namespace: namespace}, runtime)
+	if err == nil {
+		if runtime.Spec.IsDisabled() {
+			return fmt.Errorf(DisabledRuntimeError, *m.Runtime)
+		}
+		return m.validateFrameworkVersion(&runtime.Spec)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	clusterRuntime := &v1alpha1.ClusterServingRuntime{}
+	err = cl.Get(context.TODO(), client.ObjectKey{Name: *m.Runtime}, clusterRuntime)
+	if err == nil {
+		if clusterRuntime.Spec.IsDisabled() {
+			return fmt.Errorf(DisabledRuntimeError, *m.Runtime)
+		}
+		if allowed, err := IsClusterServingRuntimeAllowed(cl, &clusterRuntime.Spec, namespace); err != nil {
+			return err
+		} else if !allowed {
+			return fmt.Errorf(RuntimeNotAllowedError, *m.Runtime, namespace)
+		}
+		return m.validateFrameworkVersion(&clusterRuntime.Spec)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (m *ModelSpec) validateFrameworkVersion(srSpec *v1alpha1.ServingRuntimeSpec) error {
+	if !m.runtimeSupportsFrameworkVersion(srSpec.SupportedModelFormats) {
+		return fmt.Errorf(UnsupportedFrameworkVersionError, *m.Runtime, *m.ModelFormat.FrameworkVersion,
+			m.ModelFormat.Name)
+	}
+	return nil
+}
+
 // RuntimeSupportsModel Check if the given runtime supports the specified model.
 func (m *ModelSpec) RuntimeSupportsModel(srSpec *v1alpha1.ServingRuntimeSpec) bool {
 	// assignment to a runtime depends on the model format labels
 	runtimeLabelSet := m.getServingRuntimeSupportedModelFormatLabelSet(srSpec.SupportedModelFormats)
 	modelLabel := m.getModelFormatLabel()
 	// if the runtime has the model's label, then it supports that model.
-	return runtimeLabelSet.contains(modelLabel)
+	return runtimeLabelSet.contains(modelLabel) && m.runtimeSupportsFrameworkVersion(srSpec.SupportedModelFormats)
+}
+
+// runtimeSupportsFrameworkVersion returns whether one of the supported model formats matching the model format accepts
+// the framework version of the model, the models which do not declare their framework version are supported
+func (m *ModelSpec) runtimeSupportsFrameworkVersion(supportedModelFormats []v1alpha1.SupportedModelFormat) bool {
+	mt := m.ModelFormat
+	if mt.FrameworkVersion == nil {
+		return true
+	}
+	for i := range supportedModelFormats {
+		t := &supportedModelFormats[i]
+		if t.Name != mt.Name || (mt.Version != nil && (t.Version == nil || *t.Version != *mt.Version)) {
+			continue
+		}
+		// If runtime isn't explicitly set, only the modelFormats where AutoSelect is true are considered.
+		if m.Runtime == nil && (t.AutoSelect == nil || !*t.AutoSelect) {
+			continue
+		}
+		if t.IsFrameworkVersionSupported(*mt.FrameworkVersion) {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *ModelSpec) getModelFormatLabel() string {
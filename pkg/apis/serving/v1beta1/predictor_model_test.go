@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -315,6 +316,283 @@ func TestGetSupportingRuntimes(t *testing.T) {
 
 }
 
+func TestClusterServingRuntimeAllowedNamespaces(t *testing.T) {
+	storageUri := "s3://test/model"
+	sklearnSpec := v1alpha1.ServingRuntimeSpec{
+		SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+			{
+				Name:       "sklearn",
+				Version:    proto.String("1"),
+				AutoSelect: proto.Bool(true),
+			},
+		},
+		ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "kserve-container",
+					Image: "kserve/sklearnserver:latest",
+				},
+			},
+		},
+	}
+	restrictedSpec := *sklearnSpec.DeepCopy()
+	restrictedSpec.AllowedNamespaces = []string{"team-a"}
+	disabledSpec := *sklearnSpec.DeepCopy()
+	disabledSpec.Disabled = proto.Bool(true)
+	clusterRuntimes := &v1alpha1.ClusterServingRuntimeList{
+		Items: []v1alpha1.ClusterServingRuntime{
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-team-a"}, Spec: restrictedSpec},
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-disabled"}, Spec: disabledSpec},
+		},
+	}
+	runtimes := &v1alpha1.ServingRuntimeList{
+		Items: []v1alpha1.ServingRuntime{
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-disabled", Namespace: "team-b"}, Spec: disabledSpec},
+		},
+	}
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	mockClient := fake.NewClientBuilder().WithLists(runtimes, clusterRuntimes).WithScheme(s).Build()
+
+	scenarios := map[string]struct {
+		runtime   string
+		namespace string
+		expected  []v1alpha1.SupportedRuntime
+		matcher   types.GomegaMatcher
+	}{
+		"AllowedNamespace": {
+			namespace: "team-a",
+			runtime:   "sklearn-team-a",
+			expected:  []v1alpha1.SupportedRuntime{{Name: "sklearn-team-a", Spec: restrictedSpec}},
+			matcher:   gomega.Succeed(),
+		},
+		"OtherNamespace": {
+			namespace: "team-b",
+			runtime:   "sklearn-team-a",
+			expected:  []v1alpha1.SupportedRuntime{},
+			matcher:   gomega.MatchError(fmt.Sprintf(RuntimeNotAllowedError, "sklearn-team-a", "team-b")),
+		},
+		"DisabledClusterRuntime": {
+			namespace: "team-a",
+			runtime:   "sklearn-disabled",
+			expected:  []v1alpha1.SupportedRuntime{{Name: "sklearn-team-a", Spec: restrictedSpec}},
+			matcher:   gomega.MatchError(fmt.Sprintf(DisabledRuntimeError, "sklearn-disabled")),
+		},
+		"DisabledNamespaceRuntime": {
+			namespace: "team-b",
+			runtime:   "sklearn-disabled",
+			expected:  []v1alpha1.SupportedRuntime{},
+			matcher:   gomega.MatchError(fmt.Sprintf(DisabledRuntimeError, "sklearn-disabled")),
+		},
+		"RuntimeNotCreatedYet": {
+			namespace: "team-a",
+			runtime:   "sklearn-next",
+			expected:  []v1alpha1.SupportedRuntime{{Name: "sklearn-team-a", Spec: restrictedSpec}},
+			matcher:   gomega.Succeed(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := &ModelSpec{
+				ModelFormat: ModelFormat{Name: "sklearn"},
+				PredictorExtensionSpec: PredictorExtensionSpec{
+					StorageURI: &storageUri,
+				},
+			}
+			res, err := spec.GetSupportingRuntimes(mockClient, scenario.namespace, false)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(res).To(gomega.Equal(scenario.expected))
+
+			spec.Runtime = proto.String(scenario.runtime)
+			g.Expect(spec.ValidateRuntime(mockClient, scenario.namespace)).To(scenario.matcher)
+		})
+	}
+}
+
+func TestServingRuntimeFrameworkVersions(t *testing.T) {
+	storageUri := "s3://test/model"
+	runtimeSpec := func(frameworkVersions string) v1alpha1.ServingRuntimeSpec {
+		return v1alpha1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+				{
+					Name:              "sklearn",
+					Version:           proto.String("1"),
+					AutoSelect:        proto.Bool(true),
+					FrameworkVersions: proto.String(frameworkVersions),
+				},
+			},
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []v1.Container{
+					{
+						Name:  "kserve-container",
+						Image: "kserve/sklearnserver:latest",
+					},
+				},
+			},
+		}
+	}
+	legacySpec := runtimeSpec(">=1.0,<1.3")
+	currentSpec := runtimeSpec(">=1.3,<1.6")
+	runtimes := &v1alpha1.ServingRuntimeList{
+		Items: []v1alpha1.ServingRuntime{
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-legacy", Namespace: "default"}, Spec: legacySpec},
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-current", Namespace: "default"}, Spec: currentSpec},
+		},
+	}
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	mockClient := fake.NewClientBuilder().WithLists(runtimes).WithScheme(s).Build()
+
+	scenarios := map[string]struct {
+		frameworkVersion *string
+		runtime          string
+		expected         []v1alpha1.SupportedRuntime
+		matcher          types.GomegaMatcher
+	}{
+		"NoFrameworkVersion": {
+			runtime: "sklearn-legacy",
+			expected: []v1alpha1.SupportedRuntime{
+				{Name: "sklearn-current", Spec: currentSpec},
+				{Name: "sklearn-legacy", Spec: legacySpec},
+			},
+			matcher: gomega.Succeed(),
+		},
+		"CurrentFrameworkVersion": {
+			frameworkVersion: proto.String("1.4.2"),
+			runtime:          "sklearn-current",
+			expected:         []v1alpha1.SupportedRuntime{{Name: "sklearn-current", Spec: currentSpec}},
+			matcher:          gomega.Succeed(),
+		},
+		"LegacyFrameworkVersion": {
+			frameworkVersion: proto.String("1.2"),
+			runtime:          "sklearn-current",
+			expected:         []v1alpha1.SupportedRuntime{{Name: "sklearn-legacy", Spec: legacySpec}},
+			matcher: gomega.MatchError(fmt.Sprintf(UnsupportedFrameworkVersionError, "sklearn-current", "1.2",
+				"sklearn")),
+		},
+		"UnsupportedFrameworkVersion": {
+			frameworkVersion: proto.String("1.6.0"),
+			runtime:          "sklearn-legacy",
+			expected:         []v1alpha1.SupportedRuntime{},
+			matcher: gomega.MatchError(fmt.Sprintf(UnsupportedFrameworkVersionError, "sklearn-legacy", "1.6.0",
+				"sklearn")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := &ModelSpec{
+				ModelFormat: ModelFormat{Name: "sklearn", FrameworkVersion: scenario.frameworkVersion},
+				PredictorExtensionSpec: PredictorExtensionSpec{
+					StorageURI: &storageUri,
+				},
+			}
+			res, err := spec.GetSupportingRuntimes(mockClient, "default", false)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(res).To(gomega.Equal(scenario.expected))
+
+			spec.Runtime = proto.String(scenario.runtime)
+			g.Expect(spec.ValidateRuntime(mockClient, "default")).To(scenario.matcher)
+		})
+	}
+}
+
+func TestClusterServingRuntimePriorityAndNamespaceSelector(t *testing.T) {
+	storageUri := "s3://test/model"
+	sklearnSpec := v1alpha1.ServingRuntimeSpec{
+		SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+			{
+				Name:       "sklearn",
+				AutoSelect: proto.Bool(true),
+			},
+		},
+		ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "kserve-container",
+					Image: "kserve/sklearnserver:latest",
+				},
+			},
+		},
+	}
+	gpuSpec := *sklearnSpec.DeepCopy()
+	gpuSpec.Priority = proto.Int32(10)
+	gpuSpec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"gpu-approved": "true"}}
+	lowSpec := *sklearnSpec.DeepCopy()
+	lowSpec.Priority = proto.Int32(1)
+	clusterRuntimes := &v1alpha1.ClusterServingRuntimeList{
+		Items: []v1alpha1.ClusterServingRuntime{
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-cpu"}, Spec: sklearnSpec},
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-low"}, Spec: lowSpec},
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-gpu"}, Spec: gpuSpec},
+		},
+	}
+	runtimes := &v1alpha1.ServingRuntimeList{
+		Items: []v1alpha1.ServingRuntime{
+			{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-team-b", Namespace: "team-b"}, Spec: sklearnSpec},
+		},
+	}
+	namespaces := &v1.NamespaceList{
+		Items: []v1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"gpu-approved": "true"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		},
+	}
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	if err := v1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	mockClient := fake.NewClientBuilder().WithLists(runtimes, clusterRuntimes, namespaces).WithScheme(s).Build()
+
+	scenarios := map[string]struct {
+		namespace string
+		expected  []string
+		matcher   types.GomegaMatcher
+	}{
+		// The GPU runtime of the highest priority is only selected in the approved namespaces
+		"SelectedNamespace": {
+			namespace: "team-a",
+			expected:  []string{"sklearn-gpu", "sklearn-low", "sklearn-cpu"},
+			matcher:   gomega.Succeed(),
+		},
+		// A ClusterServingRuntime of a higher priority is selected before a ServingRuntime
+		"OtherNamespace": {
+			namespace: "team-b",
+			expected:  []string{"sklearn-low", "sklearn-team-b", "sklearn-cpu"},
+			matcher:   gomega.MatchError(fmt.Sprintf(RuntimeNotAllowedError, "sklearn-gpu", "team-b")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := &ModelSpec{
+				ModelFormat: ModelFormat{Name: "sklearn"},
+				PredictorExtensionSpec: PredictorExtensionSpec{
+					StorageURI: &storageUri,
+				},
+			}
+			res, err := spec.GetSupportingRuntimes(mockClient, scenario.namespace, false)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			names := []string{}
+			for _, runtime := range res {
+				names = append(names, runtime.Name)
+			}
+			g.Expect(names).To(gomega.Equal(scenario.expected))
+
+			spec.Runtime = proto.String("sklearn-gpu")
+			g.Expect(spec.ValidateRuntime(mockClient, scenario.namespace)).To(scenario.matcher)
+		})
+	}
+}
+
 func TestModelPredictorGetContainer(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	var storageUri = "s3://test/model"
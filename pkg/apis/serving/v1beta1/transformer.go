@@ -16,6 +16,12 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // TransformerSpec defines transformer service for pre/post processing
 type TransformerSpec struct {
 	// This spec is dual purpose. <br />
@@ -47,3 +53,21 @@ func (s *TransformerSpec) GetImplementation() ComponentImplementation {
 func (s *TransformerSpec) GetExtensions() *ComponentExtensionSpec {
 	return &s.ComponentExtensionSpec
 }
+
+// ParseReplicaRatio parses the <transformer replicas>:<predictor replicas> ratio of the transformer replica ratio
+// annotation, e.g. 2:1
+func ParseReplicaRatio(ratio string) (int32, int32, error) {
+	parts := strings.Split(ratio, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("the ratio %q must be <transformer replicas>:<predictor replicas>, e.g. 2:1", ratio)
+	}
+	units := make([]int32, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseInt(part, 10, 32)
+		if err != nil || value <= 0 {
+			return 0, 0, fmt.Errorf("the replicas of the ratio %q must be positive integers", ratio)
+		}
+		units[i] = int32(value)
+	}
+	return units[0], units[1], nil
+}
@@ -99,6 +99,11 @@ func (in *Batcher) DeepCopyInto(out *Batcher) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.TargetP99Latency != nil {
+		in, out := &in.TargetP99Latency, &out.TargetP99Latency
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -155,6 +160,21 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(Batcher)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficMirror != nil {
+		in, out := &in.TrafficMirror, &out.TrafficMirror
+		*out = new(TrafficMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -168,6 +188,49 @@ func (in *ComponentExtensionSpec) DeepCopy() *ComponentExtensionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentOverlay) DeepCopyInto(out *ComponentOverlay) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.ScaleTarget != nil {
+		in, out := &in.ScaleTarget, &out.ScaleTarget
+		*out = new(int)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentOverlay.
+func (in *ComponentOverlay) DeepCopy() *ComponentOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatusSpec) DeepCopyInto(out *ComponentStatusSpec) {
 	*out = *in
@@ -211,6 +274,52 @@ func (in *ComponentStatusSpec) DeepCopy() *ComponentStatusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CorsPolicyConfig) DeepCopyInto(out *CorsPolicyConfig) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllowCredentials != nil {
+		in, out := &in.AllowCredentials, &out.AllowCredentials
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CorsPolicyConfig.
+func (in *CorsPolicyConfig) DeepCopy() *CorsPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CorsPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomExplainer) DeepCopyInto(out *CustomExplainer) {
 	*out = *in
@@ -278,6 +387,36 @@ func (in *DeployConfig) DeepCopy() *DeployConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicShape) DeepCopyInto(out *DynamicShape) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.Opt != nil {
+		in, out := &in.Opt, &out.Opt
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicShape.
+func (in *DynamicShape) DeepCopy() *DynamicShape {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicShape)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExplainerConfig) DeepCopyInto(out *ExplainerConfig) {
 	*out = *in
@@ -400,6 +539,48 @@ func (in *FailureInfo) DeepCopy() *FailureInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphOptimization) DeepCopyInto(out *GraphOptimization) {
+	*out = *in
+	if in.WorkspaceSize != nil {
+		in, out := &in.WorkspaceSize, &out.WorkspaceSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DynamicShapes != nil {
+		in, out := &in.DynamicShapes, &out.DynamicShapes
+		*out = make([]DynamicShape, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphOptimization.
+func (in *GraphOptimization) DeepCopy() *GraphOptimization {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphOptimization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrpcRoutesConfig) DeepCopyInto(out *GrpcRoutesConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrpcRoutesConfig.
+func (in *GrpcRoutesConfig) DeepCopy() *GrpcRoutesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GrpcRoutesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceService) DeepCopyInto(out *InferenceService) {
 	*out = *in
@@ -461,6 +642,33 @@ func (in *InferenceServiceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceOverlay) DeepCopyInto(out *InferenceServiceOverlay) {
+	*out = *in
+	in.ComponentOverlay.DeepCopyInto(&out.ComponentOverlay)
+	if in.Explainer != nil {
+		in, out := &in.Explainer, &out.Explainer
+		*out = new(ComponentOverlay)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transformer != nil {
+		in, out := &in.Transformer, &out.Transformer
+		*out = new(ComponentOverlay)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceOverlay.
+func (in *InferenceServiceOverlay) DeepCopy() *InferenceServiceOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 	*out = *in
@@ -475,6 +683,18 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(TransformerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make(map[string]InferenceServiceOverlay, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -510,6 +730,21 @@ func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
 		}
 	}
 	in.ModelStatus.DeepCopyInto(&out.ModelStatus)
+	if in.ExternalURL != nil {
+		in, out := &in.ExternalURL, &out.ExternalURL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalURL != nil {
+		in, out := &in.InternalURL, &out.InternalURL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrpcURL != nil {
+		in, out := &in.GrpcURL, &out.GrpcURL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -540,6 +775,29 @@ func (in *InferenceServicesConfig) DeepCopy() *InferenceServicesConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassConfig) DeepCopyInto(out *IngressClassConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassConfig.
+func (in *IngressClassConfig) DeepCopy() *IngressClassConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
 	*out = *in
@@ -548,6 +806,33 @@ func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AllowedIngressGateways != nil {
+		in, out := &in.AllowedIngressGateways, &out.AllowedIngressGateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIngressClasses != nil {
+		in, out := &in.AdditionalIngressClasses, &out.AdditionalIngressClasses
+		*out = make([]IngressClassConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CorsPolicy != nil {
+		in, out := &in.CorsPolicy, &out.CorsPolicy
+		*out = new(CorsPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrpcRoutes != nil {
+		in, out := &in.GrpcRoutes, &out.GrpcRoutes
+		*out = new(GrpcRoutesConfig)
+		**out = **in
+	}
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(IngressTlsConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -561,6 +846,21 @@ func (in *IngressConfig) DeepCopy() *IngressConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTlsConfig) DeepCopyInto(out *IngressTlsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTlsConfig.
+func (in *IngressTlsConfig) DeepCopy() *IngressTlsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTlsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LightGBMSpec) DeepCopyInto(out *LightGBMSpec) {
 	*out = *in
@@ -578,6 +878,37 @@ func (in *LightGBMSpec) DeepCopy() *LightGBMSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerRedactSpec) DeepCopyInto(out *LoggerRedactSpec) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redactors != nil {
+		in, out := &in.Redactors, &out.Redactors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerRedactSpec.
+func (in *LoggerRedactSpec) DeepCopy() *LoggerRedactSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerRedactSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 	*out = *in
@@ -586,6 +917,21 @@ func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SamplingPercent != nil {
+		in, out := &in.SamplingPercent, &out.SamplingPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResponseCodes != nil {
+		in, out := &in.ResponseCodes, &out.ResponseCodes
+		*out = make([]LoggerResponseCode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = new(LoggerRedactSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -599,6 +945,39 @@ func (in *LoggerSpec) DeepCopy() *LoggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelConversion) DeepCopyInto(out *ModelConversion) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConversion.
+func (in *ModelConversion) DeepCopy() *ModelConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
 	*out = *in
@@ -623,6 +1002,11 @@ func (in *ModelFormat) DeepCopyInto(out *ModelFormat) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FrameworkVersion != nil {
+		in, out := &in.FrameworkVersion, &out.FrameworkVersion
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -693,6 +1077,11 @@ func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
 		*out = new(ModelCopies)
 		**out = **in
 	}
+	if in.OOMRemediation != nil {
+		in, out := &in.OOMRemediation, &out.OOMRemediation
+		*out = new(OOMRemediation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -706,6 +1095,26 @@ func (in *ModelStatus) DeepCopy() *ModelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMRemediation) DeepCopyInto(out *OOMRemediation) {
+	*out = *in
+	if in.LastRemediationTime != nil {
+		in, out := &in.LastRemediationTime, &out.LastRemediationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMRemediation.
+func (in *OOMRemediation) DeepCopy() *OOMRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ONNXRuntimeSpec) DeepCopyInto(out *ONNXRuntimeSpec) {
 	*out = *in
@@ -1001,6 +1410,16 @@ func (in *PredictorSpec) DeepCopyInto(out *PredictorSpec) {
 		*out = new(ModelSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conversion != nil {
+		in, out := &in.Conversion, &out.Conversion
+		*out = new(ModelConversion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Optimization != nil {
+		in, out := &in.Optimization, &out.Optimization
+		*out = new(GraphOptimization)
+		(*in).DeepCopyInto(*out)
+	}
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
 	in.ComponentExtensionSpec.DeepCopyInto(&out.ComponentExtensionSpec)
 	return
@@ -1016,6 +1435,48 @@ func (in *PredictorSpec) DeepCopy() *PredictorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.PerTryTimeoutSeconds != nil {
+		in, out := &in.PerTryTimeoutSeconds, &out.PerTryTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SKLearnSpec) DeepCopyInto(out *SKLearnSpec) {
 	*out = *in
@@ -1033,6 +1494,58 @@ func (in *SKLearnSpec) DeepCopy() *SKLearnSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestResponseMatcher) DeepCopyInto(out *SmokeTestResponseMatcher) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestResponseMatcher.
+func (in *SmokeTestResponseMatcher) DeepCopy() *SmokeTestResponseMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestResponseMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestSpec) DeepCopyInto(out *SmokeTestSpec) {
+	*out = *in
+	if in.ExpectedStatus != nil {
+		in, out := &in.ExpectedStatus, &out.ExpectedStatus
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExpectedResponse != nil {
+		in, out := &in.ExpectedResponse, &out.ExpectedResponse
+		*out = new(SmokeTestResponseMatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestSpec.
+func (in *SmokeTestSpec) DeepCopy() *SmokeTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
@@ -1109,6 +1622,27 @@ func (in *TorchServeSpec) DeepCopy() *TorchServeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirror) DeepCopyInto(out *TrafficMirror) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirror.
+func (in *TrafficMirror) DeepCopy() *TrafficMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransformerSpec) DeepCopyInto(out *TransformerSpec) {
 	*out = *in
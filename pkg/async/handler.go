@@ -0,0 +1,293 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/kserve/kserve/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// PreferHeader selects the asynchronous mode with the respond-async preference of RFC 7240
+	PreferHeader       = "Prefer"
+	PreferRespondAsync = "respond-async"
+	// OperationsPath is the path prefix of the operations, the status and the result of an operation are polled
+	// from OperationsPath + id
+	OperationsPath = "/v1/operations/"
+	// OperationStatusHeader is set on the polled responses with the status of the operation
+	OperationStatusHeader = "X-Kserve-Operation-Status"
+	// PriorityHeader sets the priority of an asynchronous request, one of high, normal or low
+	PriorityHeader = "X-Kserve-Priority"
+
+	QueuedOperationsMetricName   = "kserve_agent_async_queued_operations"
+	RejectedOperationsMetricName = "kserve_agent_async_rejected_operations_total"
+
+	// resultsCleanupPeriod is the period the expired results are removed with
+	resultsCleanupPeriod = time.Minute
+	// storeRetryPeriod is the period the workers wait for after the store failed
+	storeRetryPeriod = time.Second
+)
+
+// Status is the status of an operation
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+var (
+	// queuedOperations is the number of operations waiting for a worker
+	queuedOperations = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: QueuedOperationsMetricName,
+		Help: "Number of asynchronous inference requests waiting to be sent to the model server",
+	})
+	// rejectedOperations counts the operations rejected because the queue was full
+	rejectedOperations = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: RejectedOperationsMetricName,
+		Help: "Number of asynchronous inference requests rejected because the queue was full",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(queuedOperations, rejectedOperations)
+}
+
+// OperationStatus is the body of the accepted requests and of the polled operations which are not done
+type OperationStatus struct {
+	Id     string `json:"id"`
+	Status Status `json:"status"`
+}
+
+// AsyncHandler answers the inference requests preferring an asynchronous response with the id of an operation and
+// queues them in the store for a pool of workers sending them to the model server. The response of an operation is
+// polled from the operations path until it expires, and is sent to the notifier when one is set.
+type AsyncHandler struct {
+	log      *zap.SugaredLogger
+	next     http.Handler
+	workers  int
+	store    Store
+	notifier *Notifier
+}
+
+// New returns a handler running the asynchronous requests queued in store with workers concurrent workers
+func New(workers int, store Store, notifier *Notifier, next http.Handler, logger *zap.SugaredLogger) *AsyncHandler {
+	return &AsyncHandler{
+		log:      logger,
+		next:     next,
+		workers:  workers,
+		store:    store,
+		notifier: notifier,
+	}
+}
+
+// Start starts the workers and the removal of the expired responses until the context is done
+func (h *AsyncHandler) Start(ctx context.Context) {
+	for i := 0; i < h.workers; i++ {
+		go func() {
+			for {
+				op, err := h.store.Dequeue(ctx)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					h.log.Errorw("Failed to dequeue an operation", zap.Error(err))
+					select {
+					case <-time.After(storeRetryPeriod):
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				h.updateQueued(ctx)
+				h.run(ctx, op)
+			}
+		}()
+	}
+	go func() {
+		ticker := time.NewTicker(resultsCleanupPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := h.store.Expire(ctx); err != nil {
+					h.log.Errorw("Failed to expire the responses", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (h *AsyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, OperationsPath) {
+		h.poll(w, r, strings.TrimPrefix(r.URL.Path, OperationsPath))
+		return
+	}
+	if r.Method != http.MethodPost || !prefersAsync(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Like for the payload logger the callers may set the id of the operation with the Ce-Id header
+	id := r.Header.Get(logger.CloudEventsIdHeader)
+	if id == "" || strings.Contains(id, "/") {
+		id = guuid.New().String()
+	}
+	header := r.Header.Clone()
+	header.Del(PreferHeader)
+	header.Del(PriorityHeader)
+	header.Set(logger.CloudEventsIdHeader, id)
+	op := &Operation{
+		Id:       id,
+		Priority: ParsePriority(r.Header.Get(PriorityHeader)),
+		Method:   r.Method,
+		URI:      r.URL.RequestURI(),
+		Host:     r.Host,
+		Header:   header,
+		Body:     body,
+	}
+	switch err := h.store.Enqueue(r.Context(), op); {
+	case errors.Is(err, ErrDuplicateOperation):
+		// The request was already accepted, e.g. the caller retried after a timeout
+	case errors.Is(err, ErrQueueFull):
+		rejectedOperations.Inc()
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		h.log.Errorw("Failed to enqueue the operation", "id", id, zap.Error(err))
+		http.Error(w, "failed to enqueue the operation", http.StatusServiceUnavailable)
+		return
+	default:
+		h.updateQueued(r.Context())
+	}
+	h.writeAccepted(w, id)
+}
+
+// prefersAsync returns whether the request prefers an asynchronous response
+func prefersAsync(r *http.Request) bool {
+	for _, value := range r.Header.Values(PreferHeader) {
+		for _, preference := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(preference), PreferRespondAsync) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *AsyncHandler) writeAccepted(w http.ResponseWriter, id string) {
+	w.Header().Set("Location", OperationsPath+id)
+	w.Header().Set("Preference-Applied", PreferRespondAsync)
+	h.writeStatus(w, http.StatusAccepted, OperationStatus{Id: id, Status: StatusPending})
+}
+
+func (h *AsyncHandler) writeStatus(w http.ResponseWriter, code int, status OperationStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(OperationStatusHeader, string(status.Status))
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.log.Errorw("Failed to write response", zap.Error(err))
+	}
+}
+
+// poll returns the status of the operation while it is not done, its response once it is done
+func (h *AsyncHandler) poll(w http.ResponseWriter, r *http.Request, id string) {
+	op, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		h.log.Errorw("Failed to get the operation", "id", id, zap.Error(err))
+		http.Error(w, "failed to get the operation", http.StatusServiceUnavailable)
+		return
+	}
+	if op == nil {
+		http.Error(w, "operation "+id+" not found", http.StatusNotFound)
+		return
+	}
+	if op.Status == StatusPending || op.Status == StatusRunning {
+		w.Header().Set("Retry-After", "1")
+		h.writeStatus(w, http.StatusAccepted, OperationStatus{Id: id, Status: op.Status})
+		return
+	}
+	for key, values := range op.ResponseHeader {
+		w.Header()[key] = values
+	}
+	w.Header().Set(OperationStatusHeader, string(op.Status))
+	w.WriteHeader(op.Code)
+	if _, err := w.Write(op.Response); err != nil {
+		h.log.Errorw("Failed to write response", zap.Error(err))
+	}
+}
+
+// run sends the request of the operation to the model server and keeps its response
+func (h *AsyncHandler) run(ctx context.Context, op *Operation) {
+	request, err := http.NewRequestWithContext(ctx, op.Method, op.URI, bytes.NewReader(op.Body))
+	if err != nil {
+		h.log.Errorw("Malformed operation request", "id", op.Id, zap.Error(err))
+		return
+	}
+	request.RequestURI = op.URI
+	request.Host = op.Host
+	request.Header = op.Header
+	rr := httptest.NewRecorder()
+	h.next.ServeHTTP(rr, request)
+
+	op.Status = StatusSucceeded
+	if rr.Code < 200 || rr.Code >= 300 {
+		op.Status = StatusFailed
+	}
+	op.Code = rr.Code
+	op.ResponseHeader = rr.Header().Clone()
+	op.Response = rr.Body.Bytes()
+	op.Header = nil
+	op.Body = nil
+	if err := h.store.Complete(ctx, op); err != nil {
+		h.log.Errorw("Failed to store the operation response", "id", op.Id, zap.Error(err))
+	}
+	if h.notifier != nil {
+		if err := h.notifier.Notify(ctx, op.Id, op.Status, op.Code, op.ResponseHeader.Get("Content-Type"),
+			op.Response); err != nil {
+			h.log.Errorw("Failed to notify the operation response", "id", op.Id, zap.Error(err))
+		}
+	}
+}
+
+// updateQueued sets the number of queued operations, shared by the replicas when the store is shared
+func (h *AsyncHandler) updateQueued(ctx context.Context) {
+	if queued, err := h.store.Len(ctx); err == nil {
+		queuedOperations.Set(float64(queued))
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestAsyncHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	release := make(chan struct{})
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) == "fail" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		<-release
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"predictions": [1]}`))
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore(1, time.Minute)
+	handler := New(1, store, nil, predictor, logger)
+	handler.Start(ctx)
+	send := func(body string, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", bytes.NewBufferString(body))
+		req.Header.Set(PreferHeader, "wait=10, respond-async")
+		req.Header.Set("Ce-Id", id)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	poll := func(id string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OperationsPath+id, nil))
+		return rec
+	}
+
+	// The request is accepted with the location of its operation
+	rec := send(`{"instances": [[1]]}`, "request-1")
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusAccepted))
+	g.Expect(rec.Header().Get("Location")).To(gomega.Equal("/v1/operations/request-1"))
+	var status OperationStatus
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &status)).To(gomega.Succeed())
+	g.Expect(status).To(gomega.Equal(OperationStatus{Id: "request-1", Status: StatusPending}))
+	g.Eventually(func() string { return poll("request-1").Header().Get(OperationStatusHeader) }).Should(
+		gomega.Equal(string(StatusRunning)))
+
+	// The retried request is not queued again, the queue holds a single request
+	g.Expect(send(`{"instances": [[1]]}`, "request-1").Code).To(gomega.Equal(http.StatusAccepted))
+	g.Expect(send("fail", "request-2").Code).To(gomega.Equal(http.StatusAccepted))
+	g.Expect(send(`{"instances": [[1]]}`, "request-3").Code).To(gomega.Equal(http.StatusServiceUnavailable))
+
+	// The responses are polled once the operations are done
+	close(release)
+	g.Eventually(func() int { return poll("request-1").Code }).Should(gomega.Equal(http.StatusOK))
+	rec = poll("request-1")
+	g.Expect(rec.Header().Get(OperationStatusHeader)).To(gomega.Equal(string(StatusSucceeded)))
+	g.Expect(rec.Header().Get("Content-Type")).To(gomega.Equal("application/json"))
+	g.Expect(rec.Body.String()).To(gomega.Equal(`{"predictions": [1]}`))
+	g.Eventually(func() int { return poll("request-2").Code }).Should(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(poll("request-2").Header().Get(OperationStatusHeader)).To(gomega.Equal(string(StatusFailed)))
+	g.Expect(poll("request-3").Code).To(gomega.Equal(http.StatusNotFound))
+
+	// The synchronous requests are passed through
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", bytes.NewBufferString("{}")))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+
+	// The responses expire after the result ttl
+	store.mu.Lock()
+	store.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	store.mu.Unlock()
+	g.Expect(store.Expire(ctx)).To(gomega.Succeed())
+	g.Expect(poll("request-1").Code).To(gomega.Equal(http.StatusNotFound))
+}
+
+func TestNotifier(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var mu sync.Mutex
+	var attempts int
+	var ids, statuses, types []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		ids = append(ids, req.Header.Get("Ce-Id"))
+		types = append(types, req.Header.Get("Ce-Type"))
+		statuses = append(statuses, req.Header.Get("Ce-Operationstatus"))
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	callbackUrl, _ := url.Parse(server.URL)
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	notifier, err := NewNotifier(callbackUrl, sourceUri, "sklearn", "default", "default", "predictor")
+	g.Expect(err).To(gomega.BeNil())
+	notifier.backoff = 10 * time.Millisecond
+
+	// The event the callback did not acknowledge is sent again
+	g.Expect(notifier.Notify(context.Background(), "request-1", StatusSucceeded, http.StatusOK, "application/json",
+		[]byte(`{"predictions": [1]}`))).To(gomega.Succeed())
+	mu.Lock()
+	defer mu.Unlock()
+	g.Expect(attempts).To(gomega.Equal(2))
+	g.Expect(ids).To(gomega.Equal([]string{"request-1"}))
+	g.Expect(types).To(gomega.Equal([]string{CEInferenceAsyncResponse}))
+	g.Expect(statuses).To(gomega.Equal([]string{string(StatusSucceeded)}))
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/kserve/kserve/pkg/logger"
+)
+
+const (
+	// CEInferenceAsyncResponse is the type of the events of the operation responses
+	CEInferenceAsyncResponse = "org.kubeflow.serving.inference.async.response"
+	// StatusAttr and StatusCodeAttr are the extensions of the events with the status of the operation and the status
+	// code of the model server response
+	StatusAttr     = "operationstatus"
+	StatusCodeAttr = "statuscode"
+
+	// The delay between the attempts to send an event the callback did not acknowledge doubles from notifyBackoff
+	notifyAttempts = 3
+	notifyBackoff  = 1 * time.Second
+)
+
+// Notifier sends the responses of the operations as CloudEvents to a callback url such as a webhook or a Knative
+// broker, the id of the events is the id of the operation. The responses stay available to polling, a response the
+// callback did not acknowledge after a few attempts is not sent again.
+type Notifier struct {
+	client           cloudevents.Client
+	sourceUri        *url.URL
+	inferenceService string
+	namespace        string
+	endpoint         string
+	component        string
+	backoff          time.Duration
+}
+
+func NewNotifier(callbackUrl *url.URL, sourceUri *url.URL, inferenceService string, namespace string, endpoint string,
+	component string) (*Notifier, error) {
+	t, err := cloudevents.NewHTTPTransport(
+		cloudevents.WithTarget(callbackUrl.String()),
+		cloudevents.WithEncoding(cloudevents.HTTPBinaryV1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("while creating http transport: %s", err)
+	}
+	c, err := cloudevents.NewClient(t)
+	if err != nil {
+		return nil, fmt.Errorf("while creating new cloudevents client: %s", err)
+	}
+	return &Notifier{
+		client:           c,
+		sourceUri:        sourceUri,
+		inferenceService: inferenceService,
+		namespace:        namespace,
+		endpoint:         endpoint,
+		component:        component,
+		backoff:          notifyBackoff,
+	}, nil
+}
+
+// Notify sends the response of the operation to the callback
+func (n *Notifier) Notify(ctx context.Context, id string, status Status, code int, contentType string, data []byte) error {
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(id)
+	event.SetType(CEInferenceAsyncResponse)
+	event.SetTime(time.Now())
+	event.SetSource(n.sourceUri.String())
+	event.SetExtension(logger.InferenceServiceAttr, n.inferenceService)
+	event.SetExtension(logger.NamespaceAttr, n.namespace)
+	event.SetExtension(logger.ComponentAttr, n.component)
+	event.SetExtension(logger.EndpointAttr, n.endpoint)
+	event.SetExtension(StatusAttr, string(status))
+	event.SetExtension(StatusCodeAttr, code)
+	if contentType != "" {
+		event.SetDataContentType(contentType)
+	}
+	if err := event.SetData(data); err != nil {
+		return fmt.Errorf("while setting cloudevents data: %s", err)
+	}
+	backoff := n.backoff
+	var err error
+	for attempt := 1; attempt <= notifyAttempts; attempt++ {
+		if _, _, err = n.client.Send(ctx, event); err == nil {
+			return nil
+		}
+		if attempt == notifyAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+	return fmt.Errorf("while sending event: %s", err)
+}
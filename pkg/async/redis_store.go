@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisDequeueTimeout bounds the blocking pops so the workers notice the end of their context
+	redisDequeueTimeout = time.Second
+)
+
+// enqueueScript queues the id of an operation unless it is already known or the queues are full, the operation is
+// stored under its own key. KEYS are the operation key, the queue of its priority and the queues of all the
+// priorities, ARGV the operation, its id and the size of the queue.
+var enqueueScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return 0
+end
+local queued = 0
+for i = 3, #KEYS do
+  queued = queued + redis.call('LLEN', KEYS[i])
+end
+if queued >= tonumber(ARGV[3]) then
+  return -1
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('LPUSH', KEYS[2], ARGV[2])
+return 1
+`)
+
+// RedisStore keeps the operations in Redis, the queued operations survive the restarts of the agent containers and
+// are shared by the replicas of the component. Each priority is a list of operation ids, the operations and their
+// responses are stored under their own keys and the responses expire after the result ttl.
+type RedisStore struct {
+	client    redis.UniversalClient
+	prefix    string
+	queueSize int
+	resultTTL time.Duration
+	turn      uint64
+}
+
+var _ Store = &RedisStore{}
+
+// NewRedisStore returns a store in the Redis server of the redis:// or rediss:// url, the keys of the operations of
+// the component are prefixed with prefix
+func NewRedisStore(brokerUrl string, prefix string, queueSize int, resultTTL time.Duration) (*RedisStore, error) {
+	options, err := redis.ParseURL(brokerUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisStore{
+		client:    redis.NewClient(options),
+		prefix:    prefix,
+		queueSize: queueSize,
+		resultTTL: resultTTL,
+	}, nil
+}
+
+func (s *RedisStore) operationKey(id string) string {
+	return s.prefix + ":operation:" + id
+}
+
+func (s *RedisStore) queueKey(priority Priority) string {
+	return s.prefix + ":queue:" + string(priority)
+}
+
+func (s *RedisStore) queueKeys(priorities []Priority) []string {
+	keys := make([]string, 0, len(priorities))
+	for _, priority := range priorities {
+		keys = append(keys, s.queueKey(priority))
+	}
+	return keys
+}
+
+func (s *RedisStore) Enqueue(ctx context.Context, op *Operation) error {
+	op.Status = StatusPending
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	keys := append([]string{s.operationKey(op.Id), s.queueKey(op.Priority)}, s.queueKeys(Priorities)...)
+	result, err := enqueueScript.Run(ctx, s.client, keys, data, op.Id, s.queueSize).Int()
+	if err != nil {
+		return err
+	}
+	switch result {
+	case 0:
+		return ErrDuplicateOperation
+	case -1:
+		return ErrQueueFull
+	}
+	return nil
+}
+
+func (s *RedisStore) Dequeue(ctx context.Context) (*Operation, error) {
+	for {
+		turn := atomic.AddUint64(&s.turn, 1) - 1
+		// The queues are popped in the order of the keys, the queue of the scheduled priority first
+		popped, err := s.client.BRPop(ctx, redisDequeueTimeout, s.queueKeys(dequeueOrder(turn))...).Result()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		op, err := s.Get(ctx, popped[1])
+		if err != nil {
+			return nil, err
+		}
+		if op == nil {
+			// The operation was removed while it was queued
+			continue
+		}
+		op.Status = StatusRunning
+		// The running operations of a replica stopped before they are done expire like the responses
+		if err := s.set(ctx, op); err != nil {
+			return nil, err
+		}
+		return op, nil
+	}
+}
+
+func (s *RedisStore) Complete(ctx context.Context, op *Operation) error {
+	op.Done = time.Now()
+	return s.set(ctx, op)
+}
+
+func (s *RedisStore) set(ctx context.Context, op *Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.operationKey(op.Id), data, s.resultTTL).Err()
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Operation, error) {
+	data, err := s.client.Get(ctx, s.operationKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	op := &Operation{}
+	if err := json.Unmarshal(data, op); err != nil {
+		return nil, fmt.Errorf("malformed operation %s: %v", id, err)
+	}
+	return op, nil
+}
+
+func (s *RedisStore) Len(ctx context.Context) (int, error) {
+	pipe := s.client.Pipeline()
+	lengths := []*redis.IntCmd{}
+	for _, key := range s.queueKeys(Priorities) {
+		lengths = append(lengths, pipe.LLen(ctx, key))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, length := range lengths {
+		queued += int(length.Val())
+	}
+	return queued, nil
+}
+
+func (s *RedisStore) Expire(ctx context.Context) error {
+	return nil
+}
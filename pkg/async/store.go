@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned when the queued operations would exceed the size of the queue
+	ErrQueueFull = errors.New("asynchronous request queue is full")
+	// ErrDuplicateOperation is returned when an operation with the same id was already accepted
+	ErrDuplicateOperation = errors.New("operation already accepted")
+)
+
+// Priority is the priority of an operation, the workers take the operations of each priority in proportion of its
+// weight so the operations of the lower priorities are not starved
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// Priorities are the priorities of the operations from the highest to the lowest
+var Priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// prioritySchedule takes 4 high, 2 normal and 1 low priority operations out of 7 while all the priorities are queued
+var prioritySchedule = []Priority{PriorityHigh, PriorityNormal, PriorityHigh, PriorityLow, PriorityHigh, PriorityNormal,
+	PriorityHigh}
+
+// ParsePriority returns the priority named by value, the normal priority when value is empty or unknown
+func ParsePriority(value string) Priority {
+	for _, priority := range Priorities {
+		if string(priority) == value {
+			return priority
+		}
+	}
+	return PriorityNormal
+}
+
+// dequeueOrder returns the order the priorities are looked up in for the turn-th dequeued operation, the priority
+// scheduled for the turn first and the others from the highest to the lowest
+func dequeueOrder(turn uint64) []Priority {
+	scheduled := prioritySchedule[turn%uint64(len(prioritySchedule))]
+	order := []Priority{scheduled}
+	for _, priority := range Priorities {
+		if priority != scheduled {
+			order = append(order, priority)
+		}
+	}
+	return order
+}
+
+// Operation is an asynchronous inference request, its response is kept once it is done
+type Operation struct {
+	Id             string      `json:"id"`
+	Status         Status      `json:"status"`
+	Priority       Priority    `json:"priority"`
+	Method         string      `json:"method"`
+	URI            string      `json:"uri"`
+	Host           string      `json:"host,omitempty"`
+	Header         http.Header `json:"header,omitempty"`
+	Body           []byte      `json:"body,omitempty"`
+	Code           int         `json:"code,omitempty"`
+	ResponseHeader http.Header `json:"responseHeader,omitempty"`
+	Response       []byte      `json:"response,omitempty"`
+	Done           time.Time   `json:"done,omitempty"`
+}
+
+// Store queues the operations for the workers and keeps their responses once they are done
+type Store interface {
+	// Enqueue queues a pending operation, ErrQueueFull is returned when there is no room left for it and
+	// ErrDuplicateOperation when an operation with the same id is already known
+	Enqueue(ctx context.Context, op *Operation) error
+	// Dequeue blocks until an operation is queued or the context is done, the operation is returned running
+	Dequeue(ctx context.Context) (*Operation, error)
+	// Complete keeps the response of the operation done for the result ttl
+	Complete(ctx context.Context, op *Operation) error
+	// Get returns the operation with the id, nil when it is unknown or its response expired
+	Get(ctx context.Context, id string) (*Operation, error)
+	// Len returns the number of queued operations
+	Len(ctx context.Context) (int, error)
+	// Expire removes the responses kept for longer than the result ttl, the stores expiring the responses on their
+	// own do nothing
+	Expire(ctx context.Context) error
+}
+
+// MemoryStore keeps the operations in the memory of the agent, they are lost when the agent container restarts
+type MemoryStore struct {
+	queueSize int
+	resultTTL time.Duration
+	mu        sync.Mutex
+	queues    map[Priority][]*Operation
+	queued    int
+	turn      uint64
+	ops       map[string]*Operation
+	ready     chan struct{}
+	now       func() time.Time
+}
+
+var _ Store = &MemoryStore{}
+
+// NewMemoryStore returns a store queuing at most queueSize operations and keeping the responses for resultTTL
+func NewMemoryStore(queueSize int, resultTTL time.Duration) *MemoryStore {
+	return &MemoryStore{
+		queueSize: queueSize,
+		resultTTL: resultTTL,
+		queues:    map[Priority][]*Operation{},
+		ops:       map[string]*Operation{},
+		ready:     make(chan struct{}, 1),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Enqueue(ctx context.Context, op *Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ops[op.Id]; ok {
+		return ErrDuplicateOperation
+	}
+	if s.queued >= s.queueSize {
+		return ErrQueueFull
+	}
+	op.Status = StatusPending
+	s.ops[op.Id] = op
+	s.queues[op.Priority] = append(s.queues[op.Priority], op)
+	s.queued++
+	s.signal()
+	return nil
+}
+
+func (s *MemoryStore) Dequeue(ctx context.Context) (*Operation, error) {
+	for {
+		if op := s.pop(); op != nil {
+			return op, nil
+		}
+		select {
+		case <-s.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pop returns the next queued operation in the order of the priority schedule, nil when the queue is empty
+func (s *MemoryStore) pop() *Operation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queued == 0 {
+		return nil
+	}
+	for _, priority := range dequeueOrder(s.turn) {
+		if queue := s.queues[priority]; len(queue) > 0 {
+			op := queue[0]
+			s.queues[priority] = queue[1:]
+			s.queued--
+			s.turn++
+			op.Status = StatusRunning
+			// Wake up another worker for the operations left
+			if s.queued > 0 {
+				s.signal()
+			}
+			// The workers own a copy, the stored operation is only updated under the lock
+			running := *op
+			return &running
+		}
+	}
+	return nil
+}
+
+// signal wakes up a worker waiting for an operation, the lock must be held
+func (s *MemoryStore) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, op *Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	done := *op
+	done.Done = s.now()
+	s.ops[op.Id] = &done
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op, ok := s.ops[id]
+	if !ok {
+		return nil, nil
+	}
+	// The response is immutable once the operation is done
+	copied := *op
+	return &copied, nil
+}
+
+func (s *MemoryStore) Len(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queued, nil
+}
+
+func (s *MemoryStore) Expire(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for id, op := range s.ops {
+		if !op.Done.IsZero() && now.Sub(op.Done) > s.resultTTL {
+			delete(s.ops, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/onsi/gomega"
+)
+
+// testStore checks the queueing, the priorities and the responses of a store of size 7
+func testStore(t *testing.T, store Store) {
+	g := gomega.NewGomegaWithT(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The operations of each priority are queued in turn, the queue is full after 2 high, 2 normal and 3 low
+	// priority operations
+	var accepted []string
+	for i := 0; i < 3; i++ {
+		for _, priority := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+			id := string(priority) + "-" + string(rune('1'+i))
+			err := store.Enqueue(ctx, &Operation{Id: id, Priority: priority, Method: http.MethodPost,
+				URI: "/v1/models/sklearn:predict", Body: []byte("{}")})
+			if len(accepted) < 7 {
+				g.Expect(err).To(gomega.BeNil())
+				accepted = append(accepted, id)
+			} else {
+				g.Expect(err).To(gomega.Equal(ErrQueueFull))
+			}
+		}
+	}
+	g.Expect(store.Enqueue(ctx, &Operation{Id: "low-1", Priority: PriorityLow})).To(gomega.Equal(ErrDuplicateOperation))
+	g.Expect(store.Len(ctx)).To(gomega.Equal(7))
+
+	// The low priority operations are taken in their turn of the schedule, the turns of the empty queues go to the
+	// other priorities
+	var order []string
+	for i := 0; i < 7; i++ {
+		op, err := store.Dequeue(ctx)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(op.Status).To(gomega.Equal(StatusRunning))
+		order = append(order, op.Id)
+	}
+	g.Expect(order).To(gomega.Equal([]string{"high-1", "normal-1", "high-2", "low-1", "normal-2", "low-2", "low-3"}))
+	g.Expect(store.Len(ctx)).To(gomega.Equal(0))
+
+	// The running operation is returned until its response is stored
+	op, err := store.Get(ctx, "high-1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(op.Status).To(gomega.Equal(StatusRunning))
+	g.Expect(op.Body).To(gomega.Equal([]byte("{}")))
+	op.Status = StatusSucceeded
+	op.Code = http.StatusOK
+	op.Response = []byte(`{"predictions": [1]}`)
+	g.Expect(store.Complete(ctx, op)).To(gomega.Succeed())
+	op, err = store.Get(ctx, "high-1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(op.Status).To(gomega.Equal(StatusSucceeded))
+	g.Expect(op.Response).To(gomega.Equal([]byte(`{"predictions": [1]}`)))
+	op, err = store.Get(ctx, "unknown")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(op).To(gomega.BeNil())
+
+	// Dequeue waits for an operation until the context is done
+	waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer waitCancel()
+	_, err = store.Dequeue(waitCtx)
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore(7, time.Minute))
+}
+
+func TestRedisStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := miniredis.RunT(t)
+	store, err := NewRedisStore("redis://"+server.Addr(), "kserve:async:default/sklearn/predictor", 7, time.Minute)
+	g.Expect(err).To(gomega.BeNil())
+	testStore(t, store)
+
+	// The queued operations are shared by the replicas, the responses expire after the result ttl
+	other, err := NewRedisStore("redis://"+server.Addr(), "kserve:async:default/sklearn/predictor", 7, time.Minute)
+	g.Expect(err).To(gomega.BeNil())
+	op, err := other.Get(context.Background(), "high-1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(op.Status).To(gomega.Equal(StatusSucceeded))
+	server.FastForward(2 * time.Minute)
+	op, err = other.Get(context.Background(), "high-1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(op).To(gomega.BeNil())
+}
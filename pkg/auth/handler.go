@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+const (
+	// reviewCacheTTL is how long a successful token review is reused before the token is reviewed again
+	reviewCacheTTL = 1 * time.Minute
+	reviewTimeout  = 5 * time.Second
+)
+
+// TokenAuthHandler validates the service account token sent by the caller with a TokenReview for the
+// configured audience. The service account of the pod needs to be bound to the system:auth-delegator
+// ClusterRole to create token reviews, see docs/samples/graph/token-auth.yaml.
+type TokenAuthHandler struct {
+	log      *zap.SugaredLogger
+	audience string
+	reviews  authv1client.TokenReviewInterface
+	next     http.Handler
+	mu       sync.Mutex
+	cache    map[string]time.Time
+}
+
+func New(audience string, reviews authv1client.TokenReviewInterface, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &TokenAuthHandler{
+		log:      logger,
+		audience: audience,
+		reviews:  reviews,
+		next:     next,
+		cache:    map[string]time.Time{},
+	}
+}
+
+func (h *TokenAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	if !h.authenticated(r.Context(), token) {
+		http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *TokenAuthHandler) authenticated(ctx context.Context, token string) bool {
+	h.mu.Lock()
+	expiry, ok := h.cache[token]
+	h.mu.Unlock()
+	if ok && time.Now().Before(expiry) {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reviewTimeout)
+	defer cancel()
+	review, err := h.reviews.Create(ctx, &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{h.audience},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		h.log.Errorw("Failed to review token", "error", err)
+		return false
+	}
+	if !review.Status.Authenticated {
+		h.log.Infow("Rejected token", "error", review.Status.Error)
+		return false
+	}
+	// An authenticator which does not support audiences authenticates tokens of any audience and does not
+	// echo the requested one
+	if !containsAudience(review.Status.Audiences, h.audience) {
+		h.log.Infow("Rejected token not issued for the audience", "audience", h.audience, "audiences", review.Status.Audiences)
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	// drop expired entries so rotated tokens do not accumulate
+	for cached, expiry := range h.cache {
+		if now.After(expiry) {
+			delete(h.cache, cached)
+		}
+	}
+	h.cache[token] = now.Add(reviewCacheTTL)
+	return true
+}
+
+func containsAudience(audiences []string, audience string) bool {
+	for _, a := range audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	authv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestTokenAuthHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	reviews := 0
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.TokenReview)
+		switch review.Spec.Token {
+		case "valid":
+			review.Status.Authenticated = true
+			review.Status.Audiences = review.Spec.Audiences
+		case "audience-ignored":
+			// authenticators without audience support accept any token and return no audiences
+			review.Status.Authenticated = true
+		}
+		return true, review, nil
+	})
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := New("graph", clientset.AuthenticationV1().TokenReviews(), next, logger)
+
+	scenarios := map[string]struct {
+		authorization  string
+		expectedStatus int
+	}{
+		"MissingToken": {
+			authorization:  "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"NotBearer": {
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"InvalidToken": {
+			authorization:  "Bearer invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"AudienceNotConfirmed": {
+			authorization:  "Bearer audience-ignored",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"ValidToken": {
+			authorization:  "Bearer valid",
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for name, scenario := range scenarios {
+		req := httptest.NewRequest(http.MethodPost, "http://a/v1/models/sklearn:predict", nil)
+		if scenario.authorization != "" {
+			req.Header.Set("Authorization", scenario.authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		g.Expect(w.Code).To(gomega.Equal(scenario.expectedStatus), name)
+	}
+
+	// successful reviews are cached
+	before := reviews
+	req := httptest.NewRequest(http.MethodPost, "http://a/v1/models/sklearn:predict", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(reviews).To(gomega.Equal(before))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// The p99 latency is computed over the last AdaptiveWindow batches, the batch size is tuned once per window
+	AdaptiveWindow = 20
+	// The batch size grows again once the p99 latency is below AdaptiveGrowThreshold of the target, so it does not
+	// oscillate around the target
+	AdaptiveGrowThreshold = 0.8
+)
+
+// adaptiveBatchSize tunes the batch size to the target p99 latency of the model server. The batch size is halved
+// when the p99 latency of a window of batches is above the target and grown by one, up to the max batch size, when
+// it is well below, so the batch size backs off quickly from an overloaded model server and recovers slowly.
+type adaptiveBatchSize struct {
+	target       time.Duration
+	maxBatchSize int
+	size         int
+	latencies    []time.Duration
+}
+
+func newAdaptiveBatchSize(target time.Duration, maxBatchSize int) *adaptiveBatchSize {
+	return &adaptiveBatchSize{
+		target:       target,
+		maxBatchSize: maxBatchSize,
+		size:         maxBatchSize,
+		latencies:    make([]time.Duration, 0, AdaptiveWindow),
+	}
+}
+
+// observe records the latency of a batch and returns whether the batch size changed
+func (a *adaptiveBatchSize) observe(latency time.Duration) bool {
+	a.latencies = append(a.latencies, latency)
+	if len(a.latencies) < AdaptiveWindow {
+		return false
+	}
+	p99 := percentile(a.latencies, 0.99)
+	a.latencies = a.latencies[:0]
+	size := a.size
+	if p99 > a.target {
+		size = a.size / 2
+		if size < 1 {
+			size = 1
+		}
+	} else if float64(p99) < AdaptiveGrowThreshold*float64(a.target) && a.size < a.maxBatchSize {
+		size = a.size + 1
+	}
+	changed := size != a.size
+	a.size = size
+	return changed
+}
+
+// setMaxBatchSize changes the max batch size, the batch size is kept below it
+func (a *adaptiveBatchSize) setMaxBatchSize(maxBatchSize int) {
+	a.maxBatchSize = maxBatchSize
+	if a.size > maxBatchSize {
+		a.size = maxBatchSize
+	}
+}
+
+// percentile returns the nearest rank percentile of the latencies, the latencies are sorted in place
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	rank := int(math.Ceil(p*float64(len(latencies)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return latencies[rank]
+}
@@ -0,0 +1,558 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ModelInferMethod is the gRPC method of the v2 protocol whose tensors are batched
+const ModelInferMethod = "/inference.GRPCInferenceService/ModelInfer"
+
+// The fields of the ModelInferRequest and ModelInferResponse messages of the v2 protocol, see
+// https://github.com/kserve/kserve/blob/master/docs/predict-api/v2/grpc_predict_v2.proto
+const (
+	modelNameField        protowire.Number = 1
+	modelVersionField     protowire.Number = 2
+	idField               protowire.Number = 3
+	parametersField       protowire.Number = 4
+	inputsField           protowire.Number = 5
+	requestOutputsField   protowire.Number = 6
+	rawInputContentsField protowire.Number = 7
+	// The outputs of the ModelInferResponse take the place of the inputs
+	responseOutputsField   protowire.Number = 5
+	rawOutputContentsField protowire.Number = 6
+
+	// The fields of the InferInputTensor and InferOutputTensor messages
+	tensorNameField       protowire.Number = 1
+	tensorDatatypeField   protowire.Number = 2
+	tensorShapeField      protowire.Number = 3
+	tensorParametersField protowire.Number = 4
+	tensorContentsField   protowire.Number = 5
+
+	// The fields of the InferTensorContents message whose sizes are fixed and of the bytes contents, the others are
+	// varints
+	fp32ContentsField  protowire.Number = 6
+	fp64ContentsField  protowire.Number = 7
+	bytesContentsField protowire.Number = 8
+)
+
+// The size in bytes of the raw elements of the datatypes, the raw BYTES elements are prefixed with their length
+var rawElementSizes = map[string]int{
+	"BOOL":   1,
+	"UINT8":  1,
+	"INT8":   1,
+	"UINT16": 2,
+	"INT16":  2,
+	"FP16":   2,
+	"BF16":   2,
+	"UINT32": 4,
+	"INT32":  4,
+	"FP32":   4,
+	"UINT64": 8,
+	"INT64":  8,
+	"FP64":   8,
+}
+
+// isModelInfer returns true if the request is a call of the gRPC ModelInfer method
+func isModelInfer(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.ProtoMajor == 2 && r.URL.Path == ModelInferMethod &&
+		(contentType == "application/grpc" || contentType == "application/grpc+proto")
+}
+
+// protoField is a field of a protobuf message, value is the payload of the length delimited fields and the encoded
+// value of the others
+type protoField struct {
+	num     protowire.Number
+	typ     protowire.Type
+	value   []byte
+	encoded []byte
+}
+
+// parseFields returns the fields of the protobuf message in order
+func parseFields(message []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, message[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+		field := protoField{num: num, typ: typ, value: message[n : n+m], encoded: message[:n+m]}
+		if typ == protowire.BytesType {
+			field.value, _ = protowire.ConsumeBytes(field.value)
+		}
+		fields = append(fields, field)
+		message = message[n+m:]
+	}
+	return fields, nil
+}
+
+// grpcTensor is an input or output tensor of the gRPC protocol, its data are the raw contents or the packed values of
+// its typed contents
+type grpcTensor struct {
+	name       string
+	datatype   string
+	shape      []int64
+	parameters []byte
+	// contents is the field of the typed contents, 0 when the data are raw
+	contents protowire.Number
+	data     []byte
+}
+
+// parseTensor decodes an InferInputTensor or InferOutputTensor message
+func parseTensor(message []byte) (*grpcTensor, error) {
+	fields, err := parseFields(message)
+	if err != nil {
+		return nil, err
+	}
+	tensor := &grpcTensor{}
+	for _, field := range fields {
+		switch field.num {
+		case tensorNameField:
+			tensor.name = string(field.value)
+		case tensorDatatypeField:
+			tensor.datatype = string(field.value)
+		case tensorShapeField:
+			if field.typ != protowire.BytesType {
+				dim, _ := protowire.ConsumeVarint(field.value)
+				tensor.shape = append(tensor.shape, int64(dim))
+				break
+			}
+			for packed := field.value; len(packed) > 0; {
+				dim, n := protowire.ConsumeVarint(packed)
+				if n < 0 {
+					return nil, protowire.ParseError(n)
+				}
+				tensor.shape = append(tensor.shape, int64(dim))
+				packed = packed[n:]
+			}
+		case tensorParametersField:
+			tensor.parameters = append(tensor.parameters, field.encoded...)
+		case tensorContentsField:
+			if err := tensor.parseContents(field.value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown field %d of tensor %s", field.num, tensor.name)
+		}
+	}
+	return tensor, nil
+}
+
+// parseContents decodes the InferTensorContents message, the values of the packed fields are concatenated and the
+// bytes contents are kept encoded
+func (t *grpcTensor) parseContents(message []byte) error {
+	fields, err := parseFields(message)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if field.num < 1 || field.num > bytesContentsField {
+			return fmt.Errorf("unknown contents %d of tensor %s", field.num, t.name)
+		}
+		if t.contents != 0 && field.num != t.contents {
+			return fmt.Errorf("tensor %s has contents of several types", t.name)
+		}
+		t.contents = field.num
+		if field.num == bytesContentsField {
+			t.data = append(t.data, field.encoded...)
+		} else {
+			t.data = append(t.data, field.value...)
+		}
+	}
+	return nil
+}
+
+// offset returns the offset of the data after the n elements following the offset from
+func (t *grpcTensor) offset(from int, n int64) (int, error) {
+	size := 0
+	switch t.contents {
+	case 0:
+		size = rawElementSizes[t.datatype]
+		if size == 0 && t.datatype != "BYTES" {
+			return 0, fmt.Errorf("unknown datatype %s of tensor %s", t.datatype, t.name)
+		}
+	case fp32ContentsField:
+		size = 4
+	case fp64ContentsField:
+		size = 8
+	}
+	if size > 0 {
+		if end := int64(from) + n*int64(size); end <= int64(len(t.data)) {
+			return int(end), nil
+		}
+		return 0, fmt.Errorf("tensor %s has less elements than its shape %v", t.name, t.shape)
+	}
+	offset := from
+	for i := int64(0); i < n; i++ {
+		m := -1
+		data := t.data[offset:]
+		switch t.contents {
+		case 0:
+			if len(data) >= 4 {
+				m = 4 + int(binary.LittleEndian.Uint32(data))
+			}
+		case bytesContentsField:
+			_, _, m = protowire.ConsumeField(data)
+		default:
+			_, m = protowire.ConsumeVarint(data)
+		}
+		if m < 0 || m > len(data) {
+			return 0, fmt.Errorf("tensor %s has less elements than its shape %v", t.name, t.shape)
+		}
+		offset += m
+	}
+	return offset, nil
+}
+
+// split returns the data of the rows of the tensor, rows lists the number of rows of each part
+func (t *grpcTensor) split(rows []int) ([][]byte, error) {
+	if len(t.shape) == 0 || int(t.shape[0]) != sum(rows) {
+		return nil, fmt.Errorf("tensor %s of shape %v is not batched along its first dimension", t.name, t.shape)
+	}
+	rowElements := shapeElements(t.shape[1:])
+	parts := make([][]byte, 0, len(rows))
+	start := 0
+	for _, n := range rows {
+		end, err := t.offset(start, int64(n)*rowElements)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, t.data[start:end])
+		start = end
+	}
+	if start != len(t.data) {
+		return nil, fmt.Errorf("tensor %s has more elements than its shape %v", t.name, t.shape)
+	}
+	return parts, nil
+}
+
+// encode returns the tensor message with the rows and data, the raw data are not part of the message
+func (t *grpcTensor) encode(rows int, data []byte) []byte {
+	var message []byte
+	message = protowire.AppendTag(message, tensorNameField, protowire.BytesType)
+	message = protowire.AppendString(message, t.name)
+	message = protowire.AppendTag(message, tensorDatatypeField, protowire.BytesType)
+	message = protowire.AppendString(message, t.datatype)
+	shape := protowire.AppendVarint(nil, uint64(rows))
+	for _, dim := range t.shape[1:] {
+		shape = protowire.AppendVarint(shape, uint64(dim))
+	}
+	message = protowire.AppendTag(message, tensorShapeField, protowire.BytesType)
+	message = protowire.AppendBytes(message, shape)
+	message = append(message, t.parameters...)
+	if t.contents == 0 {
+		return message
+	}
+	contents := data
+	if t.contents != bytesContentsField {
+		contents = protowire.AppendTag(nil, t.contents, protowire.BytesType)
+		contents = protowire.AppendBytes(contents, data)
+	}
+	message = protowire.AppendTag(message, tensorContentsField, protowire.BytesType)
+	return protowire.AppendBytes(message, contents)
+}
+
+// modelInferPayload is a v2 inference request sent with the gRPC ModelInfer method
+type modelInferPayload struct {
+	modelName    string
+	modelVersion string
+	id           string
+	inputs       []*grpcTensor
+	outputs      []byte
+	raw          bool
+	batch        int
+}
+
+// newModelInferPayload decodes the ModelInferRequest message of the gRPC call, an error is returned when its tensors
+// can not be batched. The requests with parameters are not batched as the parameters could apply to the whole batch.
+func newModelInferPayload(body []byte) (*modelInferPayload, error) {
+	message, err := grpcMessage(body)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFields(message)
+	if err != nil {
+		return nil, err
+	}
+	payload := &modelInferPayload{}
+	var rawContents [][]byte
+	for _, field := range fields {
+		switch field.num {
+		case modelNameField:
+			payload.modelName = string(field.value)
+		case modelVersionField:
+			payload.modelVersion = string(field.value)
+		case idField:
+			payload.id = string(field.value)
+		case inputsField:
+			input, err := parseTensor(field.value)
+			if err != nil {
+				return nil, err
+			}
+			payload.inputs = append(payload.inputs, input)
+		case requestOutputsField:
+			payload.outputs = append(payload.outputs, field.encoded...)
+		case rawInputContentsField:
+			rawContents = append(rawContents, field.value)
+		case parametersField:
+			return nil, errors.New("the requests with parameters are not batched")
+		default:
+			return nil, fmt.Errorf("unknown field %d of the request", field.num)
+		}
+	}
+	if len(payload.inputs) == 0 {
+		return nil, errors.New("no inputs in the request")
+	}
+	if len(rawContents) > 0 {
+		if len(rawContents) != len(payload.inputs) {
+			return nil, fmt.Errorf("%d raw contents for %d inputs", len(rawContents), len(payload.inputs))
+		}
+		payload.raw = true
+		for i, input := range payload.inputs {
+			if input.contents != 0 {
+				return nil, fmt.Errorf("input %s has both raw and typed contents", input.name)
+			}
+			input.data = rawContents[i]
+		}
+	}
+	for i, input := range payload.inputs {
+		if len(input.parameters) > 0 {
+			return nil, fmt.Errorf("input %s has parameters", input.name)
+		}
+		if len(input.shape) == 0 || input.shape[0] <= 0 || (i > 0 && int(input.shape[0]) != payload.batch) {
+			return nil, fmt.Errorf("input %s is not batched along its first dimension", input.name)
+		}
+		payload.batch = int(input.shape[0])
+		elements := shapeElements(input.shape)
+		if elements == 0 {
+			return nil, fmt.Errorf("input %s has no elements", input.name)
+		}
+		if end, err := input.offset(0, elements); err != nil || end != len(input.data) {
+			return nil, fmt.Errorf("input %s does not have the elements of its shape %v", input.name, input.shape)
+		}
+	}
+	return payload, nil
+}
+
+func (p *modelInferPayload) signature() string {
+	var signature strings.Builder
+	fmt.Fprintf(&signature, "%q %q %t ", p.modelName, p.modelVersion, p.raw)
+	for _, input := range p.inputs {
+		fmt.Fprintf(&signature, "%q %s %v %d ", input.name, input.datatype, input.shape[1:], input.contents)
+	}
+	fmt.Fprintf(&signature, "%x", p.outputs)
+	return signature.String()
+}
+
+func (p *modelInferPayload) rows() int {
+	return p.batch
+}
+
+func (p *modelInferPayload) protocol() tensorProtocol {
+	return modelInferProtocol{}
+}
+
+// modelInferProtocol batches the v2 inference requests sent with the gRPC ModelInfer method
+type modelInferProtocol struct{}
+
+func (modelInferProtocol) newRequest(path string, payloads []tensorPayload) (*http.Request, error) {
+	first := payloads[0].(*modelInferPayload)
+	var message []byte
+	if first.modelName != "" {
+		message = protowire.AppendTag(message, modelNameField, protowire.BytesType)
+		message = protowire.AppendString(message, first.modelName)
+	}
+	if first.modelVersion != "" {
+		message = protowire.AppendTag(message, modelVersionField, protowire.BytesType)
+		message = protowire.AppendString(message, first.modelVersion)
+	}
+	rawContents := make([][]byte, len(first.inputs))
+	for i, input := range first.inputs {
+		var data []byte
+		for _, payload := range payloads {
+			data = append(data, payload.(*modelInferPayload).inputs[i].data...)
+		}
+		message = protowire.AppendTag(message, inputsField, protowire.BytesType)
+		message = protowire.AppendBytes(message, input.encode(batchRows(payloads), data))
+		rawContents[i] = data
+	}
+	message = append(message, first.outputs...)
+	if first.raw {
+		for _, data := range rawContents {
+			message = protowire.AppendTag(message, rawInputContentsField, protowire.BytesType)
+			message = protowire.AppendBytes(message, data)
+		}
+	}
+	r := httptest.NewRequest("POST", path, bytes.NewReader(grpcFrame(message)))
+	// The gRPC calls are sent over HTTP/2
+	r.Proto = "HTTP/2.0"
+	r.ProtoMajor = 2
+	r.ProtoMinor = 0
+	r.Header.Set("Content-Type", "application/grpc")
+	r.Header.Set("Te", "trailers")
+	return r, nil
+}
+
+func (modelInferProtocol) splitResponse(rr *httptest.ResponseRecorder, payloads []tensorPayload) ([]Response, error) {
+	// The header of the result is the header written with the status, the trailers are set after the body
+	result := rr.Result()
+	responses := make([]Response, len(payloads))
+	// The failed calls may return their status in the headers without trailers
+	status := result.Header.Get("Grpc-Status")
+	if status == "" {
+		status = result.Trailer.Get("Grpc-Status")
+	}
+	if rr.Code != http.StatusOK || status != strconv.Itoa(int(codes.OK)) {
+		for i := range responses {
+			responses[i] = Response{code: rr.Code, header: responseHeader(result.Header), body: rr.Body.Bytes(),
+				trailer: result.Trailer.Clone()}
+		}
+		return responses, nil
+	}
+	message, err := grpcMessage(rr.Body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFields(message)
+	if err != nil {
+		return nil, err
+	}
+	var header []byte
+	var outputs []*grpcTensor
+	var rawContents [][]byte
+	for _, field := range fields {
+		switch field.num {
+		case responseOutputsField:
+			output, err := parseTensor(field.value)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, output)
+		case rawOutputContentsField:
+			rawContents = append(rawContents, field.value)
+		case idField:
+		default:
+			// The model name, version and parameters are returned to every request
+			header = append(header, field.encoded...)
+		}
+	}
+	if len(rawContents) > 0 {
+		if len(rawContents) != len(outputs) {
+			return nil, fmt.Errorf("%d raw contents for %d outputs", len(rawContents), len(outputs))
+		}
+		for i, output := range outputs {
+			output.data = rawContents[i]
+		}
+	}
+	rows := make([]int, len(payloads))
+	for i, payload := range payloads {
+		rows[i] = payload.rows()
+	}
+	parts := make([][][]byte, len(outputs))
+	for i, output := range outputs {
+		if parts[i], err = output.split(rows); err != nil {
+			return nil, err
+		}
+	}
+	for i, payload := range payloads {
+		response := append([]byte{}, header...)
+		if id := payload.(*modelInferPayload).id; id != "" {
+			response = protowire.AppendTag(response, idField, protowire.BytesType)
+			response = protowire.AppendString(response, id)
+		}
+		for j, output := range outputs {
+			response = protowire.AppendTag(response, responseOutputsField, protowire.BytesType)
+			response = protowire.AppendBytes(response, output.encode(rows[i], parts[j][i]))
+		}
+		if len(rawContents) > 0 {
+			for j := range outputs {
+				response = protowire.AppendTag(response, rawOutputContentsField, protowire.BytesType)
+				response = protowire.AppendBytes(response, parts[j][i])
+			}
+		}
+		responses[i] = Response{code: http.StatusOK, header: responseHeader(result.Header), body: grpcFrame(response),
+			trailer: result.Trailer.Clone()}
+	}
+	return responses, nil
+}
+
+func (modelInferProtocol) errorResponse(err error) Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/grpc")
+	trailer := http.Header{}
+	trailer.Set("Grpc-Status", strconv.Itoa(int(codes.Internal)))
+	trailer.Set("Grpc-Message", encodeGrpcMessage(err.Error()))
+	return Response{code: http.StatusOK, header: header, trailer: trailer}
+}
+
+// grpcMessage returns the message of the body of a unary gRPC call, the compressed messages are not decoded
+func grpcMessage(body []byte) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, errors.New("no gRPC message")
+	}
+	if body[0] != 0 {
+		return nil, errors.New("the compressed gRPC messages are not batched")
+	}
+	if length := binary.BigEndian.Uint32(body[1:5]); int64(length) != int64(len(body)-5) {
+		return nil, errors.New("the body is not a single gRPC message")
+	}
+	return body[5:], nil
+}
+
+// grpcFrame returns the body of a gRPC call with the uncompressed message
+func grpcFrame(message []byte) []byte {
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	return append(frame, message...)
+}
+
+// encodeGrpcMessage percent encodes the message of a gRPC status
+func encodeGrpcMessage(message string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(message); i++ {
+		if c := message[i]; c >= ' ' && c <= '~' && c != '%' {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+	return encoded.String()
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	pkglogging "knative.dev/pkg/logging"
+	pkgnet "knative.dev/pkg/network"
+)
+
+// rawCodec sends and receives the raw bytes of the messages
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append((*v.(*[]byte))[:0], data...)
+	return nil
+}
+
+// Name is the name of the protobuf codec so that the servers accept the content subtype of the calls
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// encodeTensor returns an InferInputTensor or InferOutputTensor message without contents
+func encodeTensor(name string, datatype string, shape ...int64) []byte {
+	tensor := &grpcTensor{name: name, datatype: datatype, shape: shape}
+	return tensor.encode(int(shape[0]), nil)
+}
+
+// fp32Raw returns the raw contents of the fp32 values
+func fp32Raw(values ...float32) []byte {
+	raw := make([]byte, 4*len(values))
+	for i, value := range values {
+		binary.LittleEndian.PutUint32(raw[4*i:], math.Float32bits(value))
+	}
+	return raw
+}
+
+// startModelServer starts a gRPC model server whose ModelInfer method returns the sum of every row of the raw fp32
+// input, the requests of the model missing fail
+func startModelServer(t *testing.T, g *gomega.WithT, batches chan<- int64) string {
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "inference.GRPCInferenceService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "ModelInfer",
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := []byte{}
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				payload, err := newModelInferPayload(grpcFrame(req))
+				g.Expect(err).To(gomega.BeNil())
+				if payload.modelName == "missing" {
+					return nil, status.Error(codes.NotFound, "model missing not found")
+				}
+				g.Expect(payload.id).To(gomega.BeEmpty())
+				input := payload.inputs[0]
+				batches <- input.shape[0]
+				var sums []float32
+				for row := 0; row < payload.batch; row++ {
+					sum := float32(0)
+					for i := int64(0); i < input.shape[1]; i++ {
+						offset := 4 * (int64(row)*input.shape[1] + i)
+						sum += math.Float32frombits(binary.LittleEndian.Uint32(input.data[offset:]))
+					}
+					sums = append(sums, sum)
+				}
+				var resp []byte
+				resp = protowire.AppendTag(resp, modelNameField, protowire.BytesType)
+				resp = protowire.AppendString(resp, payload.modelName)
+				resp = protowire.AppendTag(resp, responseOutputsField, protowire.BytesType)
+				resp = protowire.AppendBytes(resp, encodeTensor("sum", "FP32", input.shape[0]))
+				resp = protowire.AppendTag(resp, rawOutputContentsField, protowire.BytesType)
+				resp = protowire.AppendBytes(resp, fp32Raw(sums...))
+				return &resp, nil
+			},
+		}},
+	}, struct{}{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(gomega.BeNil())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+// startGrpcBatcher serves the batcher over h2c like the agent and returns a connection to it
+func startGrpcBatcher(t *testing.T, g *gomega.WithT, maxBatchSize int, modelServer string) *grpc.ClientConn {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	httpProxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: modelServer})
+	httpProxy.Transport = pkgnet.NewAutoTransport(10, 10)
+	batchHandler := New(maxBatchSize, 60000, httpProxy, logger)
+	server := httptest.NewServer(h2c.NewHandler(batchHandler, &http2.Server{}))
+	t.Cleanup(server.Close)
+	conn, err := grpc.Dial(server.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).To(gomega.BeNil())
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// modelInfer calls the ModelInfer method with the rows of the raw fp32 input
+func modelInfer(conn *grpc.ClientConn, model string, id string, rows [][]float32) ([]byte, error) {
+	var req, data []byte
+	for _, row := range rows {
+		data = append(data, fp32Raw(row...)...)
+	}
+	req = protowire.AppendTag(req, modelNameField, protowire.BytesType)
+	req = protowire.AppendString(req, model)
+	req = protowire.AppendTag(req, idField, protowire.BytesType)
+	req = protowire.AppendString(req, id)
+	req = protowire.AppendTag(req, inputsField, protowire.BytesType)
+	req = protowire.AppendBytes(req, encodeTensor("input-0", "FP32", int64(len(rows)), int64(len(rows[0]))))
+	req = protowire.AppendTag(req, rawInputContentsField, protowire.BytesType)
+	req = protowire.AppendBytes(req, data)
+	resp := []byte{}
+	err := conn.Invoke(context.Background(), ModelInferMethod, &req, &resp, grpc.ForceCodec(rawCodec{}))
+	return resp, err
+}
+
+func TestBatcherModelInfer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	batches := make(chan int64, 10)
+	conn := startGrpcBatcher(t, g, 3, startModelServer(t, g, batches))
+
+	// The raw inputs of both calls are sent in a single batch of 3 rows
+	calls := map[string][][]float32{
+		"a": {{1, 2}},
+		"b": {{3, 4}, {5, 6}},
+	}
+	responses := map[string]*modelInferPayload{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for id, rows := range calls {
+		wg.Add(1)
+		go func(id string, rows [][]float32) {
+			defer wg.Done()
+			resp, err := modelInfer(conn, "test", id, rows)
+			g.Expect(err).To(gomega.BeNil())
+			// The responses are decoded like the requests, the outputs take the place of the inputs
+			fields, err := parseFields(resp)
+			g.Expect(err).To(gomega.BeNil())
+			response := &modelInferPayload{}
+			for _, field := range fields {
+				switch field.num {
+				case modelNameField:
+					response.modelName = string(field.value)
+				case idField:
+					response.id = string(field.value)
+				case responseOutputsField:
+					output, err := parseTensor(field.value)
+					g.Expect(err).To(gomega.BeNil())
+					response.inputs = append(response.inputs, output)
+				case rawOutputContentsField:
+					g.Expect(response.inputs).To(gomega.HaveLen(1))
+					response.inputs[0].data = field.value
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			responses[id] = response
+		}(id, rows)
+	}
+	wg.Wait()
+	g.Expect(<-batches).To(gomega.Equal(int64(3)))
+
+	g.Expect(responses["a"].modelName).To(gomega.Equal("test"))
+	g.Expect(responses["a"].id).To(gomega.Equal("a"))
+	g.Expect(responses["a"].inputs[0].shape).To(gomega.Equal([]int64{1}))
+	g.Expect(responses["a"].inputs[0].data).To(gomega.Equal(fp32Raw(3)))
+	g.Expect(responses["b"].id).To(gomega.Equal("b"))
+	g.Expect(responses["b"].inputs[0].shape).To(gomega.Equal([]int64{2}))
+	g.Expect(responses["b"].inputs[0].data).To(gomega.Equal(fp32Raw(7, 11)))
+}
+
+func TestBatcherModelInferFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	conn := startGrpcBatcher(t, g, 2, startModelServer(t, g, make(chan int64, 10)))
+
+	// The status of the failed batch is returned to every call
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := modelInfer(conn, "missing", id, [][]float32{{1, 2}})
+			g.Expect(status.Code(err)).To(gomega.Equal(codes.NotFound))
+			g.Expect(status.Convert(err).Message()).To(gomega.Equal("model missing not found"))
+		}(id)
+	}
+	wg.Wait()
+}
+
+func TestModelInferPayload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The typed contents are batched like the raw contents
+	var contents []byte
+	contents = protowire.AppendTag(contents, bytesContentsField, protowire.BytesType)
+	contents = protowire.AppendString(contents, "first")
+	contents = protowire.AppendTag(contents, bytesContentsField, protowire.BytesType)
+	contents = protowire.AppendString(contents, "second")
+	tensor := encodeTensor("input-0", "BYTES", 2)
+	tensor = protowire.AppendTag(tensor, tensorContentsField, protowire.BytesType)
+	tensor = protowire.AppendBytes(tensor, contents)
+	var req []byte
+	req = protowire.AppendTag(req, inputsField, protowire.BytesType)
+	req = protowire.AppendBytes(req, tensor)
+	payload, err := newModelInferPayload(grpcFrame(req))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(payload.rows()).To(gomega.Equal(2))
+	parts, err := payload.inputs[0].split([]int{1, 1})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parts).To(gomega.HaveLen(2))
+	part, err := parseTensor(payload.inputs[0].encode(1, parts[1]))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(part.shape).To(gomega.Equal([]int64{1}))
+	g.Expect(part.data).To(gomega.Equal(protowire.AppendString(protowire.AppendTag(nil, bytesContentsField,
+		protowire.BytesType), "second")))
+
+	// The requests with parameters, compressed or whose contents do not match their shape are not batched
+	withParameters := protowire.AppendTag(append([]byte{}, req...), parametersField, protowire.BytesType)
+	withParameters = protowire.AppendBytes(withParameters, nil)
+	_, err = newModelInferPayload(grpcFrame(withParameters))
+	g.Expect(err).ToNot(gomega.BeNil())
+	compressed := grpcFrame(req)
+	compressed[0] = 1
+	_, err = newModelInferPayload(compressed)
+	g.Expect(err).ToNot(gomega.BeNil())
+	short := encodeTensor("input-0", "FP32", 2, 2)
+	req = protowire.AppendTag(nil, inputsField, protowire.BytesType)
+	req = protowire.AppendBytes(req, short)
+	req = protowire.AppendTag(req, rawInputContentsField, protowire.BytesType)
+	req = protowire.AppendBytes(req, fp32Raw(1, 2, 3))
+	_, err = newModelInferPayload(grpcFrame(req))
+	g.Expect(err).ToNot(gomega.BeNil())
+}
@@ -20,12 +20,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"github.com/kserve/kserve/pkg/constants"
 	"github.com/satori/go.uuid"
 	"go.uber.org/zap"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -43,7 +46,14 @@ type Input struct {
 	ContextInput *context.Context
 	Path         string
 	Instances    *[]interface{}
-	ChannelOut   *chan Response
+	// Payload is the payload of a v2 request, its tensors are batched instead of the instances
+	Payload    tensorPayload
+	ChannelOut *chan Response
+}
+
+type batchLimits struct {
+	maxBatchSize int
+	maxLatency   int
 }
 
 type InputInfo struct {
@@ -55,6 +65,11 @@ type Response struct {
 	Message     string        `json:"message"`
 	BatchID     string        `json:"batchId"`
 	Predictions []interface{} `json:"predictions"`
+	// The responses of the v2 requests are written as the model server would answer the request
+	code    int
+	header  http.Header
+	body    []byte
+	trailer http.Header
 }
 
 type ResponseError struct {
@@ -75,6 +90,10 @@ type BatcherInfo struct {
 	Start              time.Time
 	Now                time.Time
 	CurrentInputLen    int
+	// Key identifies the requests of the batch, the requests of another key are sent in the next batch
+	Key string
+	// Requests are the v2 requests of the batch, their tensors are concatenated in order
+	Requests []Input
 }
 
 func GetNowTime() time.Time {
@@ -91,18 +110,66 @@ func (batcherInfo *BatcherInfo) InitializeInfo() {
 	batcherInfo.Instances = make([]interface{}, 0)
 	batcherInfo.PredictionResponse = PredictionResponse{}
 	batcherInfo.ContextMap = make(map[*context.Context]InputInfo)
+	batcherInfo.Key = ""
+	batcherInfo.Requests = nil
 	batcherInfo.Start = GetNowTime()
 	batcherInfo.Now = batcherInfo.Start
 }
 
+// serveBatch sends the batch request to the model server, the latency of the successful batches adapts the batch size
+func (handler *BatchHandler) serveBatch(r *http.Request) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	start := time.Now()
+	handler.next.ServeHTTP(rr, r)
+	if handler.adaptive != nil && rr.Code == http.StatusOK && handler.adaptive.observe(time.Since(start)) {
+		handler.log.Infof("Changed batch size to %d for the target p99 latency:%s", handler.adaptive.size,
+			handler.adaptive.target)
+	}
+	return rr
+}
+
+// batchInfer sends the tensors of the v2 requests concatenated along their first dimension and splits the output
+// tensors of the response between the requests
+func (handler *BatchHandler) batchInfer() {
+	protocol := handler.batcherInfo.Requests[0].Payload.protocol()
+	payloads := make([]tensorPayload, 0, len(handler.batcherInfo.Requests))
+	for _, req := range handler.batcherInfo.Requests {
+		payloads = append(payloads, req.Payload)
+	}
+	var responses []Response
+	r, err := protocol.newRequest(handler.batcherInfo.Path, payloads)
+	if err == nil {
+		responses, err = protocol.splitResponse(handler.serveBatch(r), payloads)
+	}
+	if err != nil {
+		handler.log.Errorf("error batching the v2 requests: %v", err)
+	}
+	handler.batcherInfo.BatchID = GenerateUUID()
+	for i, req := range handler.batcherInfo.Requests {
+		var res Response
+		if err != nil {
+			res = protocol.errorResponse(err)
+		} else {
+			res = responses[i]
+		}
+		res.BatchID = handler.batcherInfo.BatchID
+		*req.ChannelOut <- res
+	}
+	handler.batcherInfo.InitializeInfo()
+}
+
 func (handler *BatchHandler) batchPredict() {
+	if len(handler.batcherInfo.Requests) > 0 {
+		handler.batchInfer()
+		return
+	}
 	jsonStr, _ := json.Marshal(Request{
 		handler.batcherInfo.Instances,
 	})
 	reader := bytes.NewReader(jsonStr)
 	r := httptest.NewRequest("POST", handler.batcherInfo.Path, reader)
-	rr := httptest.NewRecorder()
-	handler.next.ServeHTTP(rr, r)
+	r.Header.Set("Content-Type", constants.JSONContentType)
+	rr := handler.serveBatch(r)
 	responseBody := rr.Body.Bytes()
 	if rr.Code != http.StatusOK {
 		handler.log.Errorf("error response with code %v", rr)
@@ -154,14 +221,30 @@ func (handler *BatchHandler) batchPredict() {
 }
 
 func (handler *BatchHandler) batch() {
-	handler.log.Infof("Starting batch loop maxLatency:%d, maxBatchSize:%d", handler.MaxLatency, handler.MaxBatchSize)
+	handler.log.Infof("Starting batch loop maxLatency:%d, maxBatchSize:%d, targetP99Latency:%d", handler.MaxLatency,
+		handler.MaxBatchSize, handler.TargetP99Latency)
 	for {
 		select {
 		case req := <-handler.channelIn:
-			if len(handler.batcherInfo.Instances) == 0 {
+			key := req.Path
+			if req.Payload != nil {
+				key += " " + req.Payload.signature()
+			}
+			// The requests of another model or whose tensors can not be concatenated are sent in the next batch
+			if handler.batcherInfo.CurrentInputLen > 0 && key != handler.batcherInfo.Key {
+				handler.log.Infof("batch predict with size %d %s", handler.batcherInfo.CurrentInputLen, handler.batcherInfo.Path)
+				handler.batchPredict()
+			}
+			if handler.batcherInfo.CurrentInputLen == 0 {
 				handler.batcherInfo.Start = GetNowTime()
 			}
+			handler.batcherInfo.Key = key
 			handler.batcherInfo.Path = req.Path
+			if req.Payload != nil {
+				handler.batcherInfo.Requests = append(handler.batcherInfo.Requests, req)
+				handler.batcherInfo.CurrentInputLen += req.Payload.rows()
+				break
+			}
 			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
 			handler.batcherInfo.Instances = append(handler.batcherInfo.Instances, *req.Instances...)
 			var index = make([]int, 0)
@@ -173,57 +256,120 @@ func (handler *BatchHandler) batch() {
 				index,
 			}
 			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
+		case limits := <-handler.limitsIn:
+			// The instances collected so far are sent with the batch reaching the new limits
+			handler.setLimits(limits.maxBatchSize, limits.maxLatency)
+			if handler.adaptive != nil {
+				handler.adaptive.setMaxBatchSize(handler.MaxBatchSize)
+			}
+			handler.log.Infof("Changed batch limits maxLatency:%d, maxBatchSize:%d", handler.MaxLatency, handler.MaxBatchSize)
 		case <-time.After(SleepTime):
 		}
 		handler.batcherInfo.Now = GetNowTime()
-		if handler.batcherInfo.CurrentInputLen >= handler.MaxBatchSize ||
+		if handler.batcherInfo.CurrentInputLen >= handler.batchSize() ||
 			(handler.batcherInfo.Now.Sub(handler.batcherInfo.Start).Milliseconds() >= int64(handler.MaxLatency) &&
 				handler.batcherInfo.CurrentInputLen > 0) {
-			handler.log.Infof("batch predict with size %d %s", len(handler.batcherInfo.Instances), handler.batcherInfo.Path)
+			handler.log.Infof("batch predict with size %d %s", handler.batcherInfo.CurrentInputLen, handler.batcherInfo.Path)
 			handler.batchPredict()
 		}
 	}
 }
 
 func (handler *BatchHandler) Consume() {
-	if handler.MaxBatchSize <= 0 {
-		handler.MaxBatchSize = MaxBatchSize
-	}
-	if handler.MaxLatency <= 0 {
-		handler.MaxLatency = MaxLatency
+	handler.setLimits(handler.MaxBatchSize, handler.MaxLatency)
+	if handler.TargetP99Latency > 0 {
+		handler.adaptive = newAdaptiveBatchSize(time.Duration(handler.TargetP99Latency)*time.Millisecond,
+			handler.MaxBatchSize)
 	}
 	handler.batcherInfo.InitializeInfo()
 	handler.batch()
 }
 
+// setLimits sets the max batch size and latency, the defaults are used when they are not positive
+func (handler *BatchHandler) setLimits(maxBatchSize int, maxLatency int) {
+	if maxBatchSize <= 0 {
+		maxBatchSize = MaxBatchSize
+	}
+	if maxLatency <= 0 {
+		maxLatency = MaxLatency
+	}
+	handler.MaxBatchSize = maxBatchSize
+	handler.MaxLatency = maxLatency
+}
+
+// batchSize returns the batch size triggering a batch, the max batch size unless it is adapted to the target p99
+// latency
+func (handler *BatchHandler) batchSize() int {
+	if handler.adaptive != nil {
+		return handler.adaptive.size
+	}
+	return handler.MaxBatchSize
+}
+
+// Configure changes the max batch size and latency without a restart, the requests waiting in the current batch are
+// kept
+func (handler *BatchHandler) Configure(maxBatchSize int, maxLatency int) {
+	handler.limitsIn <- batchLimits{maxBatchSize: maxBatchSize, maxLatency: maxLatency}
+}
+
 type BatchHandler struct {
-	next         http.Handler
-	log          *zap.SugaredLogger
-	channelIn    chan Input
-	MaxBatchSize int
-	MaxLatency   int
-	batcherInfo  BatcherInfo
+	next             http.Handler
+	log              *zap.SugaredLogger
+	channelIn        chan Input
+	limitsIn         chan batchLimits
+	MaxBatchSize     int
+	MaxLatency       int
+	TargetP99Latency int
+	PriorityHeader   string
+	adaptive         *adaptiveBatchSize
+	batcherInfo      BatcherInfo
 }
 
 func New(maxBatchSize int, maxLatency int, handler http.Handler, logger *zap.SugaredLogger) *BatchHandler {
+	return NewAdaptive(maxBatchSize, maxLatency, 0, "", handler, logger)
+}
+
+// NewAdaptive creates a batcher adapting the batch size to the target p99 latency in milliseconds of the model
+// server, the batch size is fixed to the max batch size when the target is not positive. The requests whose priority
+// header is true are sent to the model server without waiting for a batch.
+func NewAdaptive(maxBatchSize int, maxLatency int, targetP99Latency int, priorityHeader string, handler http.Handler,
+	logger *zap.SugaredLogger) *BatchHandler {
 	batchHandler := BatchHandler{
-		next:         handler,
-		log:          logger,
-		channelIn:    make(chan Input),
-		MaxBatchSize: maxBatchSize,
-		MaxLatency:   maxLatency,
+		next:             handler,
+		log:              logger,
+		channelIn:        make(chan Input),
+		limitsIn:         make(chan batchLimits),
+		MaxBatchSize:     maxBatchSize,
+		MaxLatency:       maxLatency,
+		TargetP99Latency: targetP99Latency,
+		PriorityHeader:   http.CanonicalHeaderKey(priorityHeader),
 	}
 	go batchHandler.Consume()
 	return &batchHandler
 }
 
 func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// only batch predict requests
+	// only batch predict and v2 infer requests
 	var predictVerb = regexp.MustCompile(`:predict$`)
-	if !predictVerb.MatchString(r.URL.Path) {
+	isPredict := predictVerb.MatchString(r.URL.Path)
+	if !isPredict && !inferPath.MatchString(r.URL.Path) && !isModelInfer(r) {
+		handler.next.ServeHTTP(w, r)
+		return
+	}
+	// The priority requests are not delayed by the batching, the model server response is returned as is
+	if handler.PriorityHeader != "" && strings.EqualFold(r.Header.Get(handler.PriorityHeader), "true") {
+		handler.next.ServeHTTP(w, r)
+		return
+	}
+	// The protobuf payloads are passed through as the instances can not be batched without decoding them
+	if constants.IsProtobufContentType(r.Header.Get("Content-Type")) {
 		handler.next.ServeHTTP(w, r)
 		return
 	}
+	if !isPredict {
+		handler.serveInfer(w, r)
+		return
+	}
 	var req Request
 	var err error
 	// Read Payload
@@ -244,10 +390,10 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var ctx = context.Background()
 	var chl = make(chan Response)
 	handler.channelIn <- Input{
-		&ctx,
-		r.URL.Path,
-		&req.Instances,
-		&chl,
+		ContextInput: &ctx,
+		Path:         r.URL.Path,
+		Instances:    &req.Instances,
+		ChannelOut:   &chl,
 	}
 
 	response := <-chl
@@ -256,9 +402,57 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
+	w.Header().Set("Content-Type", constants.JSONContentType)
 	_, err = w.Write(rspbytes)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
+
+// serveInfer batches the tensors of a v2 request, sent as JSON or with the gRPC ModelInfer method. The requests whose
+// tensors can not be batched are passed through to the model server.
+func (handler *BatchHandler) serveInfer(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	var payload tensorPayload
+	if isModelInfer(r) {
+		payload, err = newModelInferPayload(body)
+	} else if r.Header.Get(inferHeaderContentLength) == "" {
+		payload, err = newInferPayload(body)
+	} else {
+		err = errors.New("the binary tensor data extension is not batched")
+	}
+	if err != nil {
+		handler.log.Debugf("passing through request %s: %v", r.URL.Path, err)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		handler.next.ServeHTTP(w, r)
+		return
+	}
+	handler.log.Infof("serving request %s", r.URL.Path)
+	var ctx = context.Background()
+	var chl = make(chan Response)
+	handler.channelIn <- Input{
+		ContextInput: &ctx,
+		Path:         r.URL.Path,
+		Payload:      payload,
+		ChannelOut:   &chl,
+	}
+	response := <-chl
+	close(chl)
+	for key, values := range response.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(response.code)
+	if _, err = w.Write(response.body); err != nil {
+		handler.log.Errorf("error writing the response of request %s: %v", r.URL.Path, err)
+		return
+	}
+	// The trailers carry the status of the gRPC calls
+	for key, values := range response.trailer {
+		w.Header()[http.TrailerPrefix+key] = values
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 )
 
 func serveRequest(batchHandler *BatchHandler, wg *sync.WaitGroup, index int) {
@@ -170,3 +171,138 @@ func TestBatcherDefaults(t *testing.T) {
 	g.Expect(batchHandler.MaxBatchSize).To(gomega.Equal(MaxBatchSize))
 	g.Expect(batchHandler.MaxLatency).To(gomega.Equal(MaxLatency))
 }
+
+// Tests the batch limits changed without a restart
+func TestBatcherConfigure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		var request Request
+		g.Expect(json.Unmarshal(b, &request)).To(gomega.Succeed())
+		responseBytes, err := json.Marshal(Response{Predictions: request.Instances})
+		g.Expect(err).To(gomega.BeNil())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	// A single request would wait a minute for the batch to fill
+	batchHandler := New(32, 60000, httpProxy, logger)
+	batchHandler.Configure(1, 0)
+
+	r := httptest.NewRequest("POST", "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[1, 2, 3]]}`)))
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	var res Response
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(gomega.Succeed())
+	g.Expect(res.Predictions).To(gomega.HaveLen(1))
+}
+
+func TestBatcherProtobufPassthrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	payload := []byte{0x0a, 0x04, 't', 'e', 's', 't'}
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(b).To(gomega.Equal(payload))
+		g.Expect(req.Header.Get("Content-Type")).To(gomega.Equal("application/x-protobuf"))
+		rw.Header().Set("Content-Type", "application/x-protobuf")
+		_, err = rw.Write(b)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	// The protobuf requests are not held for the batch to fill
+	batchHandler := New(32, 60000, httpProxy, logger)
+
+	r := httptest.NewRequest("POST", "/v2/models/test/infer:predict", bytes.NewReader(payload))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("application/x-protobuf"))
+	g.Expect(w.Body.Bytes()).To(gomega.Equal(payload))
+}
+
+func TestBatcherPriorityHeader(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		var request Request
+		g.Expect(json.Unmarshal(b, &request)).To(gomega.Succeed())
+		responseBytes, err := json.Marshal(PredictionResponse{Predictions: request.Instances})
+		g.Expect(err).To(gomega.BeNil())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	// The priority requests are not held for the batch to fill
+	batchHandler := NewAdaptive(32, 60000, 0, "x-priority", httpProxy, logger)
+
+	r := httptest.NewRequest("POST", "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[1, 2, 3]]}`)))
+	r.Header.Set("X-Priority", "true")
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(gomega.MatchJSON(`{"predictions": [[1, 2, 3]]}`))
+}
+
+func TestAdaptiveBatchSize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	adaptive := newAdaptiveBatchSize(100*time.Millisecond, 8)
+	observe := func(latency time.Duration) bool {
+		changed := false
+		for i := 0; i < AdaptiveWindow; i++ {
+			changed = adaptive.observe(latency)
+		}
+		return changed
+	}
+
+	// The batch size is halved while the p99 latency is above the target
+	g.Expect(observe(150 * time.Millisecond)).To(gomega.BeTrue())
+	g.Expect(adaptive.size).To(gomega.Equal(4))
+	g.Expect(observe(150 * time.Millisecond)).To(gomega.BeTrue())
+	g.Expect(observe(150 * time.Millisecond)).To(gomega.BeTrue())
+	g.Expect(adaptive.size).To(gomega.Equal(1))
+	g.Expect(observe(150 * time.Millisecond)).To(gomega.BeFalse())
+	g.Expect(adaptive.size).To(gomega.Equal(1))
+
+	// The batch size is kept close to the target and grown again well below it, up to the max batch size
+	g.Expect(observe(90 * time.Millisecond)).To(gomega.BeFalse())
+	g.Expect(observe(50 * time.Millisecond)).To(gomega.BeTrue())
+	g.Expect(adaptive.size).To(gomega.Equal(2))
+	for i := 0; i < 10; i++ {
+		observe(50 * time.Millisecond)
+	}
+	g.Expect(adaptive.size).To(gomega.Equal(8))
+
+	// A single slow batch of the window is the p99 latency
+	for i := 0; i < AdaptiveWindow-1; i++ {
+		adaptive.observe(10 * time.Millisecond)
+	}
+	g.Expect(adaptive.observe(time.Second)).To(gomega.BeTrue())
+	g.Expect(adaptive.size).To(gomega.Equal(4))
+
+	adaptive.setMaxBatchSize(2)
+	g.Expect(adaptive.size).To(gomega.Equal(2))
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// The header of the binary tensor data extension, the requests using it are not batched
+const inferHeaderContentLength = "Inference-Header-Content-Length"
+
+// inferPath matches the path of the v2 inference requests
+var inferPath = regexp.MustCompile(`^/v2/models/[^/]+(/versions/[^/]+)?/infer$`)
+
+// tensorPayload is the payload of a v2 request, its input tensors are batched along their first dimension
+type tensorPayload interface {
+	// signature identifies the payloads whose tensors can be concatenated, the payloads of a batch share it
+	signature() string
+	// rows returns the size of the first dimension of the input tensors
+	rows() int
+	// protocol returns the protocol of the payload
+	protocol() tensorProtocol
+}
+
+// tensorProtocol merges the payloads of a batch into the request of the model server and splits its response
+type tensorProtocol interface {
+	// newRequest returns the request of the model server with the input tensors of the payloads concatenated
+	newRequest(path string, payloads []tensorPayload) (*http.Request, error)
+	// splitResponse splits the output tensors of the model server response between the payloads, the error responses
+	// of the model server are returned to every payload
+	splitResponse(rr *httptest.ResponseRecorder, payloads []tensorPayload) ([]Response, error)
+	// errorResponse returns the response of a payload whose batch failed with the error
+	errorResponse(err error) Response
+}
+
+// inferPayload is a v2 inference request sent as JSON
+type inferPayload struct {
+	request inference.InferRequest
+	batch   int
+}
+
+// newInferPayload decodes the v2 inference request, an error is returned when its tensors can not be batched. The
+// requests with parameters are not batched as the parameters could apply to the whole batch.
+func newInferPayload(body []byte) (*inferPayload, error) {
+	payload := &inferPayload{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// The numbers are kept as is, the int64 tensors would lose their precision as float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload.request); err != nil {
+		return nil, err
+	}
+	if len(payload.request.Inputs) == 0 {
+		return nil, errors.New("no inputs in the request")
+	}
+	if len(payload.request.Parameters) > 0 {
+		return nil, errors.New("the requests with parameters are not batched")
+	}
+	for _, output := range payload.request.Outputs {
+		if len(output.Parameters) > 0 {
+			return nil, fmt.Errorf("output %s has parameters", output.Name)
+		}
+	}
+	for i := range payload.request.Inputs {
+		input := &payload.request.Inputs[i]
+		if len(input.Parameters) > 0 {
+			return nil, fmt.Errorf("input %s has parameters", input.Name)
+		}
+		if len(input.Shape) == 0 || input.Shape[0] <= 0 || (i > 0 && int(input.Shape[0]) != payload.batch) {
+			return nil, fmt.Errorf("input %s is not batched along its first dimension", input.Name)
+		}
+		payload.batch = int(input.Shape[0])
+		data := flatten(input.Data, nil)
+		if elements := shapeElements(input.Shape); elements == 0 || int64(len(data)) != elements {
+			return nil, fmt.Errorf("input %s has %d elements for the shape %v", input.Name, len(data), input.Shape)
+		}
+		input.Data = data
+	}
+	return payload, nil
+}
+
+func (p *inferPayload) signature() string {
+	var signature strings.Builder
+	for _, input := range p.request.Inputs {
+		fmt.Fprintf(&signature, "%q %s %v ", input.Name, input.Datatype, input.Shape[1:])
+	}
+	for _, output := range p.request.Outputs {
+		fmt.Fprintf(&signature, "%q ", output.Name)
+	}
+	return signature.String()
+}
+
+func (p *inferPayload) rows() int {
+	return p.batch
+}
+
+func (p *inferPayload) protocol() tensorProtocol {
+	return inferProtocol{}
+}
+
+// inferProtocol batches the v2 inference requests sent as JSON
+type inferProtocol struct{}
+
+func (inferProtocol) newRequest(path string, payloads []tensorPayload) (*http.Request, error) {
+	first := payloads[0].(*inferPayload).request
+	request := inference.InferRequest{
+		Inputs:  make([]inference.Tensor, len(first.Inputs)),
+		Outputs: first.Outputs,
+	}
+	for i, input := range first.Inputs {
+		data := make([]interface{}, 0)
+		rows := int64(0)
+		for _, payload := range payloads {
+			data = append(data, payload.(*inferPayload).request.Inputs[i].Data.([]interface{})...)
+			rows += int64(payload.rows())
+		}
+		request.Inputs[i] = inference.Tensor{
+			Name:     input.Name,
+			Datatype: input.Datatype,
+			Shape:    append([]int64{rows}, input.Shape[1:]...),
+			Data:     data,
+		}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	r := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	r.Header.Set("Content-Type", constants.JSONContentType)
+	return r, nil
+}
+
+func (inferProtocol) splitResponse(rr *httptest.ResponseRecorder, payloads []tensorPayload) ([]Response, error) {
+	responses := make([]Response, len(payloads))
+	if rr.Code != http.StatusOK {
+		for i := range responses {
+			responses[i] = Response{code: rr.Code, header: responseHeader(rr.Header()), body: rr.Body.Bytes()}
+		}
+		return responses, nil
+	}
+	var response inference.InferResponse
+	decoder := json.NewDecoder(rr.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, err
+	}
+	rows := batchRows(payloads)
+	outputs := make([][][]interface{}, len(response.Outputs))
+	for i, output := range response.Outputs {
+		if len(output.Shape) == 0 || output.Shape[0] != int64(rows) {
+			return nil, fmt.Errorf("output %s of shape %v is not batched along its first dimension", output.Name,
+				output.Shape)
+		}
+		data := flatten(output.Data, nil)
+		if int64(len(data)) != shapeElements(output.Shape) {
+			return nil, fmt.Errorf("output %s has %d elements for the shape %v", output.Name, len(data), output.Shape)
+		}
+		rowElements := int(shapeElements(output.Shape[1:]))
+		start := 0
+		for _, payload := range payloads {
+			end := start + payload.rows()*rowElements
+			outputs[i] = append(outputs[i], data[start:end])
+			start = end
+		}
+	}
+	for i, payload := range payloads {
+		res := inference.InferResponse{
+			ModelName:    response.ModelName,
+			ModelVersion: response.ModelVersion,
+			Id:           payload.(*inferPayload).request.Id,
+			Parameters:   response.Parameters,
+			Outputs:      make([]inference.Tensor, len(response.Outputs)),
+		}
+		for j, output := range response.Outputs {
+			res.Outputs[j] = inference.Tensor{
+				Name:       output.Name,
+				Datatype:   output.Datatype,
+				Shape:      append([]int64{int64(payload.rows())}, output.Shape[1:]...),
+				Parameters: output.Parameters,
+				Data:       outputs[j][i],
+			}
+		}
+		body, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+		header := responseHeader(rr.Header())
+		header.Set("Content-Type", constants.JSONContentType)
+		responses[i] = Response{code: http.StatusOK, header: header, body: body}
+	}
+	return responses, nil
+}
+
+func (inferProtocol) errorResponse(err error) Response {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	header := http.Header{}
+	header.Set("Content-Type", constants.JSONContentType)
+	return Response{code: http.StatusInternalServerError, header: header, body: body}
+}
+
+// flatten appends the elements of the nested arrays of the data in row-major order
+func flatten(data interface{}, elements []interface{}) []interface{} {
+	if values, ok := data.([]interface{}); ok {
+		for _, value := range values {
+			elements = flatten(value, elements)
+		}
+		return elements
+	}
+	return append(elements, data)
+}
+
+// shapeElements returns the number of elements of a tensor of the shape
+func shapeElements(shape []int64) int64 {
+	elements := int64(1)
+	for _, dim := range shape {
+		elements *= dim
+	}
+	return elements
+}
+
+// batchRows returns the size of the first dimension of the tensors of the batch
+func batchRows(payloads []tensorPayload) int {
+	rows := 0
+	for _, payload := range payloads {
+		rows += payload.rows()
+	}
+	return rows
+}
+
+// responseHeader returns the header of the model server response without the length of the batch response
+func responseHeader(header http.Header) http.Header {
+	header = header.Clone()
+	header.Del("Content-Length")
+	header.Del("Trailer")
+	return header
+}
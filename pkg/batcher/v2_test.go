@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+// newV2Batcher returns a batcher in front of the model server handler
+func newV2Batcher(t *testing.T, g *gomega.WithT, maxBatchSize int, maxLatency int, modelServer http.HandlerFunc) *BatchHandler {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	predictor := httptest.NewServer(modelServer)
+	t.Cleanup(predictor.Close)
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	return New(maxBatchSize, maxLatency, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+}
+
+// infer sends the v2 inference request to the batcher
+func infer(batchHandler *BatchHandler, request string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/v2/models/test/infer", bytes.NewReader([]byte(request)))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	return w
+}
+
+func TestBatcherV2(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The model server returns the first element of every row twice
+	batchHandler := newV2Batcher(t, g, 3, 60000, func(rw http.ResponseWriter, req *http.Request) {
+		g.Expect(req.URL.Path).To(gomega.Equal("/v2/models/test/infer"))
+		decoder := json.NewDecoder(req.Body)
+		decoder.UseNumber()
+		var request inference.InferRequest
+		g.Expect(decoder.Decode(&request)).To(gomega.Succeed())
+		g.Expect(request.Id).To(gomega.BeEmpty())
+		g.Expect(request.Inputs).To(gomega.HaveLen(1))
+		g.Expect(request.Inputs[0].Shape).To(gomega.Equal([]int64{3, 3}))
+		data := request.Inputs[0].Data.([]interface{})
+		g.Expect(data).To(gomega.HaveLen(9))
+		var outputs []interface{}
+		for row := 0; row < 3; row++ {
+			outputs = append(outputs, data[row*3], data[row*3])
+		}
+		response, err := json.Marshal(inference.InferResponse{
+			ModelName: "test",
+			Outputs:   []inference.Tensor{{Name: "output-0", Datatype: "INT64", Shape: []int64{3, 2}, Data: outputs}},
+		})
+		g.Expect(err).To(gomega.BeNil())
+		rw.Header().Set("Content-Type", "application/json")
+		_, err = rw.Write(response)
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	// The nested and flattened tensors of both requests are sent in a single batch of 3 rows
+	requests := map[string]string{
+		"a": `{"id": "a", "inputs": [{"name": "input-0", "datatype": "INT64", "shape": [1, 3], "data": [[1, 2, 3]]}]}`,
+		"b": `{"id": "b", "inputs": [{"name": "input-0", "datatype": "INT64", "shape": [2, 3],
+			"data": [9007199254740993, 5, 6, 7, 8, 9]}]}`,
+	}
+	responses := map[string]inference.InferResponse{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for id, request := range requests {
+		wg.Add(1)
+		go func(id string, request string) {
+			defer wg.Done()
+			w := infer(batchHandler, request)
+			g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			decoder := json.NewDecoder(w.Body)
+			decoder.UseNumber()
+			var response inference.InferResponse
+			g.Expect(decoder.Decode(&response)).To(gomega.Succeed())
+			mu.Lock()
+			defer mu.Unlock()
+			responses[id] = response
+		}(id, request)
+	}
+	wg.Wait()
+
+	g.Expect(responses["a"].Id).To(gomega.Equal("a"))
+	g.Expect(responses["a"].ModelName).To(gomega.Equal("test"))
+	g.Expect(responses["a"].Outputs).To(gomega.HaveLen(1))
+	g.Expect(responses["a"].Outputs[0].Shape).To(gomega.Equal([]int64{1, 2}))
+	g.Expect(responses["a"].Outputs[0].Data).To(gomega.Equal([]interface{}{json.Number("1"), json.Number("1")}))
+	g.Expect(responses["b"].Id).To(gomega.Equal("b"))
+	g.Expect(responses["b"].Outputs[0].Shape).To(gomega.Equal([]int64{2, 2}))
+	// The int64 values keep their precision
+	g.Expect(responses["b"].Outputs[0].Data).To(gomega.Equal([]interface{}{json.Number("9007199254740993"),
+		json.Number("9007199254740993"), json.Number("7"), json.Number("7")}))
+}
+
+func TestBatcherV2PassThrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var received []string
+	var mu sync.Mutex
+	batchHandler := newV2Batcher(t, g, 32, 60000, func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		mu.Lock()
+		received = append(received, string(b))
+		mu.Unlock()
+		rw.Header().Set("Content-Type", "application/json")
+		_, err = rw.Write([]byte(`{"model_name": "test", "outputs": []}`))
+		g.Expect(err).To(gomega.BeNil())
+	})
+
+	// The requests whose tensors can not be batched are sent as is without waiting for a batch
+	requests := []string{
+		`{"parameters": {"binary_data_output": true}, "inputs": [{"name": "input-0", "datatype": "FP32", "shape": [1, 2], "data": [1, 2]}]}`,
+		`{"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [1, 3], "data": [1, 2]}]}`,
+		`{"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [1], "data": [1]},
+			{"name": "input-1", "datatype": "FP32", "shape": [2], "data": [1, 2]}]}`,
+		`{"inputs": []}`,
+	}
+	for _, request := range requests {
+		w := infer(batchHandler, request)
+		g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	}
+	g.Expect(received).To(gomega.Equal(requests))
+}
+
+func TestBatcherV2Failures(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	fail := true
+	batchHandler := newV2Batcher(t, g, 1, 60000, func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if fail {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"error": "unexpected shape"}`))
+			return
+		}
+		// The outputs are not batched along their first dimension
+		_, _ = rw.Write([]byte(`{"model_name": "test", "outputs": [{"name": "output-0", "datatype": "FP32", "shape": [2], "data": [1, 2]}]}`))
+	})
+
+	request := `{"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [1, 2], "data": [1, 2]}]}`
+	// The errors of the model server are returned as is
+	w := infer(batchHandler, request)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"error": "unexpected shape"}`))
+
+	fail = false
+	w = infer(batchHandler, request)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("output-0 of shape [2] is not batched"))
+}
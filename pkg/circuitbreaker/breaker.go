@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// State is the state of the circuit breaker
+type State string
+
+const (
+	// StateClosed lets all the requests through
+	StateClosed State = "closed"
+	// StateOpen rejects all the requests until the open duration has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets a single probe request through, its result closes or opens the circuit again
+	StateHalfOpen State = "half_open"
+
+	// StateMetricName is the name of the gauge set to 1 for the current state of the circuit breaker
+	StateMetricName = "kserve_agent_circuit_breaker_state"
+)
+
+var states = []State{StateClosed, StateOpen, StateHalfOpen}
+
+// stateGauge exposes the current state of the circuit breaker, the gauge of the current state is 1 and the others 0
+var stateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: StateMetricName,
+	Help: "State of the circuit breaker of the model server calls, 1 for the current state",
+}, []string{"state"})
+
+func init() {
+	prometheus.MustRegister(stateGauge)
+}
+
+// Breaker opens the circuit after a number of consecutive failures of the model server, so an unhealthy model server
+// is given time to recover instead of receiving all the requests and their retries.
+type Breaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+	listener         func(State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker returns a closed circuit breaker opening for openDuration after failureThreshold consecutive failures,
+// its state is exposed by the state gauge of the agent
+func NewBreaker(failureThreshold int, openDuration time.Duration) *Breaker {
+	return NewBreakerWithListener(failureThreshold, openDuration, setStateGauge)
+}
+
+// NewBreakerWithListener returns a closed circuit breaker calling listener with every state it enters, the listener
+// is called while the breaker is locked and must not call it back
+func NewBreakerWithListener(failureThreshold int, openDuration time.Duration, listener func(State)) *Breaker {
+	b := &Breaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+		listener:         listener,
+	}
+	b.setState(StateClosed)
+	return b
+}
+
+// Allow reports whether a request can be sent to the model server, or else how long the circuit stays open
+func (b *Breaker) Allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		remaining := b.openedAt.Add(b.openDuration).Sub(b.now())
+		if remaining > 0 {
+			return false, remaining
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+		return true, 0
+	case StateHalfOpen:
+		// Only one probe request is in flight while half open
+		if b.probing {
+			return false, 0
+		}
+		b.probing = true
+		return true, 0
+	}
+	return true, 0
+}
+
+// Success records a successful call of the model server, closing the circuit
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	if b.state != StateClosed {
+		b.setState(StateClosed)
+	}
+}
+
+// Failure records a failed call of the model server, opening the circuit once the threshold is reached or when the
+// probe request of the half open circuit failed
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.failureThreshold) {
+		b.openedAt = b.now()
+		b.setState(StateOpen)
+	}
+}
+
+// State returns the current state of the circuit breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) setState(state State) {
+	b.state = state
+	if b.listener != nil {
+		b.listener(state)
+	}
+}
+
+// setStateGauge sets the gauge of state to 1 and the gauges of the other states to 0
+func setStateGauge(state State) {
+	for _, s := range states {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		stateGauge.WithLabelValues(string(s)).Set(value)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBreaker(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewBreaker(2, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	// A success resets the consecutive failures
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+	g.Expect(breaker.State()).To(gomega.Equal(StateClosed))
+	breaker.Failure()
+	g.Expect(breaker.State()).To(gomega.Equal(StateOpen))
+	g.Expect(testutil.ToFloat64(stateGauge.WithLabelValues(string(StateOpen)))).To(gomega.Equal(1.0))
+	g.Expect(testutil.ToFloat64(stateGauge.WithLabelValues(string(StateClosed)))).To(gomega.Equal(0.0))
+
+	now = now.Add(10 * time.Second)
+	allowed, wait := breaker.Allow()
+	g.Expect(allowed).To(gomega.BeFalse())
+	g.Expect(wait).To(gomega.Equal(20 * time.Second))
+
+	// A single probe request is let through once the open duration has passed
+	now = now.Add(20 * time.Second)
+	allowed, _ = breaker.Allow()
+	g.Expect(allowed).To(gomega.BeTrue())
+	g.Expect(breaker.State()).To(gomega.Equal(StateHalfOpen))
+	allowed, _ = breaker.Allow()
+	g.Expect(allowed).To(gomega.BeFalse())
+
+	// A failed probe opens the circuit again, a successful one closes it
+	breaker.Failure()
+	g.Expect(breaker.State()).To(gomega.Equal(StateOpen))
+	now = now.Add(30 * time.Second)
+	allowed, _ = breaker.Allow()
+	g.Expect(allowed).To(gomega.BeTrue())
+	breaker.Success()
+	g.Expect(breaker.State()).To(gomega.Equal(StateClosed))
+	g.Expect(testutil.ToFloat64(stateGauge.WithLabelValues(string(StateClosed)))).To(gomega.Equal(1.0))
+}
+
+func TestBreakerListener(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var entered []State
+	breaker := NewBreakerWithListener(1, time.Second, func(state State) { entered = append(entered, state) })
+	breaker.now = func() time.Time { return now }
+
+	breaker.Failure()
+	now = now.Add(time.Second)
+	breaker.Allow()
+	breaker.Success()
+	g.Expect(entered).To(gomega.Equal([]State{StateClosed, StateOpen, StateHalfOpen, StateClosed}))
+}
+
+func TestRetryBudget(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := NewRetryBudget(10)
+	budget.now = func() time.Time { return now }
+
+	for i := 0; i < 20; i++ {
+		budget.Request()
+	}
+	// The minimum retries are allowed above 10% of 20 requests
+	for i := 0; i < minRetriesPerWindow; i++ {
+		g.Expect(budget.Retry()).To(gomega.BeTrue())
+	}
+	g.Expect(budget.Retry()).To(gomega.BeFalse())
+
+	for i := 0; i < 80; i++ {
+		budget.Request()
+	}
+	// 10% of 100 requests
+	for i := minRetriesPerWindow; i < 10; i++ {
+		g.Expect(budget.Retry()).To(gomega.BeTrue())
+	}
+	g.Expect(budget.Retry()).To(gomega.BeFalse())
+
+	// The budget is renewed every window
+	now = now.Add(budgetWindow)
+	g.Expect(budget.Retry()).To(gomega.BeTrue())
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// budgetWindow is the period the requests and retries are counted over
+	budgetWindow = 10 * time.Second
+	// minRetriesPerWindow lets the low traffic replicas retry regardless of the budget percentage
+	minRetriesPerWindow = 3
+)
+
+// RetryBudget bounds the retries to a percentage of the requests, so the retries of a failing model server don't
+// multiply its load.
+type RetryBudget struct {
+	percentage int
+	now        func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    int
+	retries     int
+}
+
+// NewRetryBudget returns a budget allowing retries for percentage percent of the requests
+func NewRetryBudget(percentage int) *RetryBudget {
+	return &RetryBudget{
+		percentage: percentage,
+		now:        time.Now,
+	}
+}
+
+// Request records a request received by the agent
+func (b *RetryBudget) Request() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rotate()
+	b.requests++
+}
+
+// Retry reports whether a retry is left in the budget and records it
+func (b *RetryBudget) Retry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rotate()
+	allowed := b.requests * b.percentage / 100
+	if allowed < minRetriesPerWindow {
+		allowed = minRetriesPerWindow
+	}
+	if b.retries >= allowed {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+func (b *RetryBudget) rotate() {
+	now := b.now()
+	if now.Sub(b.windowStart) >= budgetWindow {
+		b.windowStart = now
+		b.requests = 0
+		b.retries = 0
+	}
+}
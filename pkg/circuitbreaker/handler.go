@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// RejectedMetricName is the name of the counter of the requests rejected while the circuit is open
+	RejectedMetricName = "kserve_agent_circuit_breaker_rejected_requests_total"
+	// RetriesMetricName is the name of the counter of the retries of the model server calls per result
+	RetriesMetricName = "kserve_agent_retries_total"
+
+	ResultRetried         = "retried"
+	ResultBudgetExhausted = "budget_exhausted"
+
+	// maxRetries bounds the retries of a single request
+	maxRetries = 2
+)
+
+var (
+	rejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: RejectedMetricName,
+		Help: "Number of requests rejected by the open circuit breaker",
+	})
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: RetriesMetricName,
+		Help: "Number of retries of the model server calls per result",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(rejected, retries)
+}
+
+// IsFailure reports whether the model server call failed with a transient error, the proxy answers 502 when the
+// model server can't be reached.
+func IsFailure(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
+
+// CircuitBreakerHandler rejects the requests while the circuit breaker is open and retries the transient failures
+// of the model server within the retry budget. Either of the breaker and the budget may be nil.
+type CircuitBreakerHandler struct {
+	log     *zap.SugaredLogger
+	breaker *Breaker
+	budget  *RetryBudget
+	next    http.Handler
+}
+
+func New(breaker *Breaker, budget *RetryBudget, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &CircuitBreakerHandler{
+		log:     logger,
+		breaker: breaker,
+		budget:  budget,
+		next:    next,
+	}
+}
+
+func (h *CircuitBreakerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.breaker != nil {
+		if ok, wait := h.breaker.Allow(); !ok {
+			rejected.Inc()
+			if wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			}
+			http.Error(w, "model server unavailable", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	var body []byte
+	if h.budget != nil {
+		h.budget.Request()
+		// The body is buffered to be sent again on retry
+		if r.Body != nil && r.Body != http.NoBody {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				http.Error(w, "failed to read request", http.StatusBadRequest)
+				return
+			}
+			r.Body.Close()
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		aw := &attemptWriter{
+			ResponseWriter: w,
+			header:         http.Header{},
+			handler:        h,
+			request:        r,
+			attempt:        attempt,
+		}
+		h.next.ServeHTTP(aw, r)
+		if !aw.decided {
+			aw.WriteHeader(http.StatusOK)
+		}
+		if !aw.retry {
+			return
+		}
+		h.log.Debugw("Retrying model server call", "attempt", attempt+1, "status", aw.status)
+	}
+}
+
+// shouldRetry reports whether the failed attempt is retried, the retry must be left in the budget and allowed by
+// the circuit breaker.
+func (h *CircuitBreakerHandler) shouldRetry(r *http.Request, attempt int) bool {
+	if h.budget == nil || attempt >= maxRetries || r.Context().Err() != nil {
+		return false
+	}
+	if !h.budget.Retry() {
+		retries.WithLabelValues(ResultBudgetExhausted).Inc()
+		return false
+	}
+	if h.breaker != nil {
+		if ok, _ := h.breaker.Allow(); !ok {
+			return false
+		}
+	}
+	retries.WithLabelValues(ResultRetried).Inc()
+	return true
+}
+
+// attemptWriter holds back the response of an attempt until its status is known, the response of a retried
+// attempt is discarded.
+type attemptWriter struct {
+	http.ResponseWriter
+	header  http.Header
+	handler *CircuitBreakerHandler
+	request *http.Request
+	attempt int
+	status  int
+	decided bool
+	retry   bool
+}
+
+func (a *attemptWriter) Header() http.Header {
+	return a.header
+}
+
+func (a *attemptWriter) WriteHeader(status int) {
+	if a.decided {
+		return
+	}
+	a.decided = true
+	a.status = status
+	failed := IsFailure(status)
+	if a.handler.breaker != nil {
+		if failed {
+			a.handler.breaker.Failure()
+		} else {
+			a.handler.breaker.Success()
+		}
+	}
+	if failed && a.handler.shouldRetry(a.request, a.attempt) {
+		a.retry = true
+		return
+	}
+	for key, values := range a.header {
+		a.ResponseWriter.Header()[key] = values
+	}
+	// The trailers set once the body is written, e.g. the status of the gRPC calls, are sent with the response
+	a.header = a.ResponseWriter.Header()
+	a.ResponseWriter.WriteHeader(status)
+}
+
+func (a *attemptWriter) Write(b []byte) (int, error) {
+	if !a.decided {
+		a.WriteHeader(http.StatusOK)
+	}
+	if a.retry {
+		return len(b), nil
+	}
+	return a.ResponseWriter.Write(b)
+}
+
+// Flush lets the proxy stream the responses
+func (a *attemptWriter) Flush() {
+	if a.decided && !a.retry {
+		if flusher, ok := a.ResponseWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
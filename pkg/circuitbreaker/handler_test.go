@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestCircuitBreakerHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	var statuses []int
+	var bodies []string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		status := statuses[0]
+		statuses = statuses[1:]
+		rw.Header().Set("X-Attempt", string(body))
+		rw.WriteHeader(status)
+		rw.Write([]byte(http.StatusText(status)))
+	})
+	breaker := NewBreaker(3, time.Minute)
+	handler := New(breaker, NewRetryBudget(100), next, logger)
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", strings.NewReader(`{"instances": []}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The transient failure is retried with the same body, only the last response is sent
+	statuses = []int{http.StatusBadGateway, http.StatusOK}
+	rec := serve()
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(gomega.Equal("OK"))
+	g.Expect(bodies).To(gomega.Equal([]string{`{"instances": []}`, `{"instances": []}`}))
+	g.Expect(testutil.ToFloat64(retries.WithLabelValues(ResultRetried))).To(gomega.Equal(1.0))
+
+	// The client errors are not retried
+	statuses = []int{http.StatusBadRequest}
+	g.Expect(serve().Code).To(gomega.Equal(http.StatusBadRequest))
+
+	// The retries are bounded and the consecutive failures open the circuit
+	statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	rec = serve()
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(rec.Body.String()).To(gomega.Equal(http.StatusText(http.StatusServiceUnavailable)))
+	g.Expect(breaker.State()).To(gomega.Equal(StateOpen))
+
+	rec = serve()
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(rec.Header().Get("Retry-After")).To(gomega.Equal("60"))
+	g.Expect(testutil.ToFloat64(rejected)).To(gomega.Equal(1.0))
+}
+
+func TestCircuitBreakerHandlerBudgetExhausted(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusGatewayTimeout)
+	})
+	handler := New(nil, NewRetryBudget(1), next, logger)
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(http.StatusGatewayTimeout))
+	}
+	// The minimum retries of the window are spent by the first two requests
+	g.Expect(testutil.ToFloat64(retries.WithLabelValues(ResultBudgetExhausted))).To(gomega.Equal(2.0))
+}
+
+func TestCircuitBreakerHandlerTrailers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	// The status of the gRPC calls is set in the trailers once the message is written
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Trailer", "Grpc-Status")
+		rw.Write([]byte{0, 0, 0, 0, 0})
+		rw.Header().Set("Grpc-Status", "0")
+	})
+	handler := New(NewBreaker(3, time.Minute), NewRetryBudget(100), next, logger)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/inference.GRPCInferenceService/ModelInfer", nil))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rec.Result().Trailer.Get("Grpc-Status")).To(gomega.Equal("0"))
+}
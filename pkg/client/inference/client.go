@@ -0,0 +1,388 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inference provides a client for the data plane of an InferenceService,
+// supporting the v1, v2 and OpenAI compatible REST protocols and the v2 gRPC protocol.
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kserve/kserve/pkg/client/clientset/versioned"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultMaxRetries     = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 5 * time.Second
+	DefaultTimeout        = 60 * time.Second
+)
+
+// Client sends inference requests to a single InferenceService
+type Client struct {
+	baseUrl        *url.URL
+	host           string
+	httpClient     *http.Client
+	tokenSource    func() (string, error)
+	headers        map[string]string
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	// grpcDialOptions are only used by the GRPCClient
+	grpcDialOptions []grpc.DialOption
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the http client used to send the requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHost overrides the Host header, this is needed when the requests are sent to the ingress gateway address
+// instead of the InferenceService url.
+func WithHost(host string) Option {
+	return func(c *Client) {
+		c.host = host
+	}
+}
+
+// WithToken sets a static bearer token on every request
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.tokenSource = func() (string, error) {
+			return token, nil
+		}
+	}
+}
+
+// WithTokenFile reads the bearer token from the file on every request so that rotated tokens,
+// e.g. projected service account tokens, are picked up.
+func WithTokenFile(path string) Option {
+	return func(c *Client) {
+		c.tokenSource = func() (string, error) {
+			token, err := ioutil.ReadFile(path)
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to read token file %s", path)
+			}
+			return strings.TrimSpace(string(token)), nil
+		}
+	}
+}
+
+// WithHeader sets an additional header on every request
+func WithHeader(name string, value string) Option {
+	return func(c *Client) {
+		c.headers[name] = value
+	}
+}
+
+// WithRetries sets the number of times a failed request is retried and the backoff between the attempts.
+func WithRetries(maxRetries int, initialBackoff time.Duration, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.initialBackoff = initialBackoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// NewClient creates a client for the InferenceService served on baseUrl
+func NewClient(baseUrl string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid url %s", baseUrl)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("url %s must be absolute", baseUrl)
+	}
+	c := &Client{
+		baseUrl:        parsed,
+		httpClient:     &http.Client{Timeout: DefaultTimeout},
+		headers:        map[string]string{},
+		maxRetries:     DefaultMaxRetries,
+		initialBackoff: DefaultInitialBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ResolveURL returns the url of the InferenceService from its status, the cluster local address is returned
+// when internal is true.
+func ResolveURL(ctx context.Context, clientset versioned.Interface, name string, namespace string, internal bool) (string, error) {
+	isvc, err := clientset.ServingV1beta1().InferenceServices(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !isvc.Status.IsReady() {
+		return "", fmt.Errorf("InferenceService %s/%s is not ready", namespace, name)
+	}
+	if internal {
+		if isvc.Status.Address == nil || isvc.Status.Address.URL == nil {
+			return "", fmt.Errorf("InferenceService %s/%s has no address", namespace, name)
+		}
+		return isvc.Status.Address.URL.String(), nil
+	}
+	if isvc.Status.URL == nil {
+		return "", fmt.Errorf("InferenceService %s/%s has no url", namespace, name)
+	}
+	return isvc.Status.URL.String(), nil
+}
+
+// NewClientForInferenceService creates a client for the InferenceService, resolving its url from the status
+func NewClientForInferenceService(ctx context.Context, clientset versioned.Interface, name string, namespace string,
+	internal bool, opts ...Option) (*Client, error) {
+	isvcUrl, err := ResolveURL(ctx, clientset, name, namespace, internal)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(isvcUrl, opts...)
+}
+
+// Predict sends a v1 predict request
+func (c *Client) Predict(ctx context.Context, modelName string, request *V1Request) (*V1Response, error) {
+	response := &V1Response{}
+	if err := c.doJSON(ctx, http.MethodPost, constants.PredictPath(modelName, constants.ProtocolV1), request, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Explain sends a v1 explain request, the explanation format depends on the explainer so it is returned as raw json
+func (c *Client) Explain(ctx context.Context, modelName string, request *V1Request) (json.RawMessage, error) {
+	response := json.RawMessage{}
+	if err := c.doJSON(ctx, http.MethodPost, constants.ExplainPath(modelName), request, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Infer sends a v2 inference request
+func (c *Client) Infer(ctx context.Context, modelName string, request *InferRequest) (*InferResponse, error) {
+	response := &InferResponse{}
+	if err := c.doJSON(ctx, http.MethodPost, constants.PredictPath(modelName, constants.ProtocolV2), request, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ModelMetadata returns the v2 metadata of the model
+func (c *Client) ModelMetadata(ctx context.Context, modelName string) (*ModelMetadata, error) {
+	response := &ModelMetadata{}
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/v2/models/%s", modelName), nil, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ModelReady reports whether the model is ready using the v2 readiness endpoint
+func (c *Client) ModelReady(ctx context.Context, modelName string) (bool, error) {
+	return c.ready(ctx, fmt.Sprintf("/v2/models/%s/ready", modelName))
+}
+
+// ServerLive reports whether the server is live using the v2 liveness endpoint
+func (c *Client) ServerLive(ctx context.Context) (bool, error) {
+	return c.ready(ctx, "/v2/health/live")
+}
+
+// ServerReady reports whether the server is ready using the v2 readiness endpoint
+func (c *Client) ServerReady(ctx context.Context) (bool, error) {
+	return c.ready(ctx, "/v2/health/ready")
+}
+
+// ServerMetadata returns the v2 metadata of the server
+func (c *Client) ServerMetadata(ctx context.Context) (*ServerMetadata, error) {
+	response := &ServerMetadata{}
+	if err := c.doJSON(ctx, http.MethodGet, "/v2", nil, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// V1ModelReady reports whether the model is ready using the v1 model endpoint
+func (c *Client) V1ModelReady(ctx context.Context, modelName string) (bool, error) {
+	return c.ready(ctx, fmt.Sprintf("/v1/models/%s", modelName))
+}
+
+// ChatCompletion sends an OpenAI compatible chat completion request
+func (c *Client) ChatCompletion(ctx context.Context, request *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	request.Stream = false
+	response := &ChatCompletionResponse{}
+	if err := c.doJSON(ctx, http.MethodPost, chatCompletionsPath, request, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ChatCompletionStream sends an OpenAI compatible chat completion request and streams the response chunks.
+// The caller must close the returned stream.
+func (c *Client) ChatCompletionStream(ctx context.Context, request *ChatCompletionRequest) (*ChatCompletionStream, error) {
+	request.Stream = true
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+	return newChatCompletionStream(resp.Body), nil
+}
+
+func (c *Client) ready(ctx context.Context, path string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+func (c *Client) doJSON(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	var body []byte
+	if request != nil {
+		var err error
+		if body, err = json.Marshal(request); err != nil {
+			return err
+		}
+	}
+	resp, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return errors.Wrapf(err, "failed to decode response from %s", path)
+	}
+	return nil
+}
+
+// do sends the request, retrying on retryable status codes and on connection errors, see isRetryable. The body is
+// buffered so it can be replayed on every attempt. A StatusError is returned for non 2xx responses.
+func (c *Client) do(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	backoff := c.initialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, body)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+		if err == nil {
+			err = newStatusError(resp)
+		}
+		if attempt >= c.maxRetries || !isRetryable(method, err) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl.String()+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.host != "" {
+		req.Host = c.host
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+	if c.tokenSource != nil {
+		token, err := c.tokenSource()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.httpClient.Do(req)
+}
+
+// StatusError is returned when the InferenceService responds with a non 2xx status code
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+func newStatusError(resp *http.Response) *StatusError {
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+func isRetryable(method string, err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// context cancellation is final
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// the request was not sent when the connection could not be established
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	// any other transport error may happen after the model processed the request, only idempotent requests
+	// are replayed
+	return method == http.MethodGet || method == http.MethodHead
+}
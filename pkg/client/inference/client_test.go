@@ -0,0 +1,239 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/client/clientset/versioned/fake"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestInfer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		g.Expect(req.URL.Path).To(gomega.Equal("/v2/models/mymodel/infer"))
+		g.Expect(req.Header.Get("Authorization")).To(gomega.Equal("Bearer secret"))
+		g.Expect(req.Host).To(gomega.Equal("mymodel.default.example.com"))
+		request := &InferRequest{}
+		g.Expect(json.NewDecoder(req.Body).Decode(request)).To(gomega.Succeed())
+		g.Expect(request.Inputs).To(gomega.HaveLen(1))
+		rw.Write([]byte(`{"model_name":"mymodel","outputs":[{"name":"output-0","datatype":"INT64","shape":[1],"data":[1]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithToken("secret"), WithHost("mymodel.default.example.com"))
+	g.Expect(err).To(gomega.BeNil())
+	response, err := client.Infer(context.TODO(), "mymodel", &InferRequest{
+		Inputs: []Tensor{{Name: "input-0", Datatype: "FP32", Shape: []int64{1, 2}, Data: []float32{1, 2}}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(response.ModelName).To(gomega.Equal("mymodel"))
+	g.Expect(response.Outputs[0].Name).To(gomega.Equal("output-0"))
+}
+
+func TestRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		status           int
+		maxRetries       int
+		expectedAttempts int
+		expectErr        bool
+	}{
+		"RetryUnavailable": {
+			status:           http.StatusServiceUnavailable,
+			maxRetries:       2,
+			expectedAttempts: 3,
+			expectErr:        true,
+		},
+		"NoRetryBadRequest": {
+			status:           http.StatusBadRequest,
+			maxRetries:       2,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		"SucceedAfterRetry": {
+			status:           http.StatusOK,
+			maxRetries:       2,
+			expectedAttempts: 2,
+		},
+	}
+	for name, scenario := range scenarios {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			attempts++
+			if scenario.status == http.StatusOK && attempts > 1 {
+				rw.Write([]byte(`{"predictions":[1]}`))
+				return
+			}
+			status := scenario.status
+			if status == http.StatusOK {
+				status = http.StatusBadGateway
+			}
+			rw.WriteHeader(status)
+		}))
+		client, err := NewClient(server.URL, WithRetries(scenario.maxRetries, time.Millisecond, time.Millisecond))
+		g.Expect(err).To(gomega.BeNil())
+		_, err = client.Predict(context.TODO(), "mymodel", &V1Request{Instances: []interface{}{1}})
+		server.Close()
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+		} else {
+			g.Expect(err).To(gomega.BeNil(), name)
+		}
+		g.Expect(attempts).To(gomega.Equal(scenario.expectedAttempts), name)
+	}
+}
+
+// countingTransport counts the attempts sent through it
+type countingTransport struct {
+	attempts int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTransportErrorRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// the connection is closed without a response after the request was received
+	reset := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, _, err := rw.(http.Hijacker).Hijack()
+		g.Expect(err).To(gomega.BeNil())
+		conn.Close()
+	}))
+	defer reset.Close()
+	// the connection is refused
+	refused := httptest.NewServer(http.NotFoundHandler())
+	refused.Close()
+
+	scenarios := map[string]struct {
+		url              string
+		call             func(client *Client) error
+		expectedAttempts int
+	}{
+		"NoRetryResetPost": {
+			url: reset.URL,
+			call: func(client *Client) error {
+				_, err := client.Predict(context.TODO(), "mymodel", &V1Request{Instances: []interface{}{1}})
+				return err
+			},
+			expectedAttempts: 1,
+		},
+		"RetryResetGet": {
+			url: reset.URL,
+			call: func(client *Client) error {
+				_, err := client.ModelMetadata(context.TODO(), "mymodel")
+				return err
+			},
+			expectedAttempts: 3,
+		},
+		"RetryRefusedPost": {
+			url: refused.URL,
+			call: func(client *Client) error {
+				_, err := client.Predict(context.TODO(), "mymodel", &V1Request{Instances: []interface{}{1}})
+				return err
+			},
+			expectedAttempts: 3,
+		},
+	}
+	for name, scenario := range scenarios {
+		transport := &countingTransport{}
+		client, err := NewClient(scenario.url, WithHTTPClient(&http.Client{Transport: transport}),
+			WithRetries(2, time.Millisecond, time.Millisecond))
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(scenario.call(client)).NotTo(gomega.BeNil(), name)
+		g.Expect(transport.attempts).To(gomega.Equal(scenario.expectedAttempts), name)
+	}
+}
+
+func TestChatCompletionStream(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		g.Expect(req.URL.Path).To(gomega.Equal(chatCompletionsPath))
+		request := &ChatCompletionRequest{}
+		g.Expect(json.NewDecoder(req.Body).Decode(request)).To(gomega.Succeed())
+		g.Expect(request.Stream).To(gomega.BeTrue())
+		rw.Header().Set("Content-Type", "text/event-stream")
+		for _, token := range []string{"Hello", " world"} {
+			fmt.Fprintf(rw, "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":%q}}]}\n\n", token)
+		}
+		fmt.Fprint(rw, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+	stream, err := client.ChatCompletionStream(context.TODO(), &ChatCompletionRequest{
+		Model:    "mymodel",
+		Messages: []ChatMessage{{Role: "user", Content: "Hi"}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+	defer stream.Close()
+
+	content := ""
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).To(gomega.BeNil())
+		content += chunk.Choices[0].Delta.Content
+	}
+	g.Expect(content).To(gomega.Equal("Hello world"))
+}
+
+func TestResolveURL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	externalUrl, _ := apis.ParseURL("http://mymodel.default.example.com")
+	internalUrl, _ := apis.ParseURL("http://mymodel.default.svc.cluster.local")
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mymodel",
+			Namespace: "default",
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionReady, Status: "True"}},
+			},
+			URL:     externalUrl,
+			Address: &duckv1.Addressable{URL: internalUrl},
+		},
+	}
+	clientset := fake.NewSimpleClientset(isvc)
+
+	resolved, err := ResolveURL(context.TODO(), clientset, "mymodel", "default", false)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resolved).To(gomega.Equal(externalUrl.String()))
+	resolved, err = ResolveURL(context.TODO(), clientset, "mymodel", "default", true)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resolved).To(gomega.Equal(internalUrl.String()))
+	_, err = ResolveURL(context.TODO(), clientset, "missing", "default", false)
+	g.Expect(err).NotTo(gomega.BeNil())
+}
@@ -0,0 +1,860 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/kserve/kserve/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// grpcService is the service of the v2 protocol, see
+// https://github.com/kserve/kserve/blob/master/docs/predict-api/v2/grpc_predict_v2.proto
+const grpcService = "/inference.GRPCInferenceService/"
+
+// The fields of the messages of the v2 protocol. The Go stubs of the protocol are not generated in this repository,
+// the messages are encoded and decoded with protowire like in the batcher.
+const (
+	// ServerLiveResponse, ServerReadyResponse and ModelReadyResponse
+	readyField protowire.Number = 1
+	// ModelReadyRequest, ModelMetadataRequest and ModelMetadataResponse
+	metadataNameField     protowire.Number = 1
+	metadataVersionField  protowire.Number = 2
+	metadataPlatformField protowire.Number = 3
+	metadataInputsField   protowire.Number = 4
+	metadataOutputsField  protowire.Number = 5
+	// ServerMetadataResponse
+	serverExtensionsField protowire.Number = 3
+
+	// ModelInferRequest and ModelInferResponse
+	inferModelNameField    protowire.Number = 1
+	inferModelVersionField protowire.Number = 2
+	inferIdField           protowire.Number = 3
+	inferParametersField   protowire.Number = 4
+	inferTensorsField      protowire.Number = 5
+	inferRequestedField    protowire.Number = 6
+	inferRawRequestField   protowire.Number = 7
+	inferRawResponseField  protowire.Number = 6
+
+	// InferInputTensor, InferOutputTensor and TensorMetadata
+	tensorNameField       protowire.Number = 1
+	tensorDatatypeField   protowire.Number = 2
+	tensorShapeField      protowire.Number = 3
+	tensorParametersField protowire.Number = 4
+	tensorContentsField   protowire.Number = 5
+	// InferRequestedOutputTensor
+	requestedNameField       protowire.Number = 1
+	requestedParametersField protowire.Number = 2
+
+	// InferParameter
+	boolParamField   protowire.Number = 1
+	int64ParamField  protowire.Number = 2
+	stringParamField protowire.Number = 3
+
+	// InferTensorContents
+	boolContentsField   protowire.Number = 1
+	intContentsField    protowire.Number = 2
+	int64ContentsField  protowire.Number = 3
+	uintContentsField   protowire.Number = 4
+	uint64ContentsField protowire.Number = 5
+	fp32ContentsField   protowire.Number = 6
+	fp64ContentsField   protowire.Number = 7
+	bytesContentsField  protowire.Number = 8
+)
+
+// contentsFields are the fields of the InferTensorContents holding the elements of the datatypes, the FP16 and BF16
+// tensors only have raw contents
+var contentsFields = map[string]protowire.Number{
+	"BOOL":   boolContentsField,
+	"INT8":   intContentsField,
+	"INT16":  intContentsField,
+	"INT32":  intContentsField,
+	"INT64":  int64ContentsField,
+	"UINT8":  uintContentsField,
+	"UINT16": uintContentsField,
+	"UINT32": uintContentsField,
+	"UINT64": uint64ContentsField,
+	"FP32":   fp32ContentsField,
+	"FP64":   fp64ContentsField,
+	"BYTES":  bytesContentsField,
+}
+
+// GRPCClient sends the v2 requests to a single InferenceService with the gRPC protocol. It shares the options of the
+// REST Client: the token and the headers are sent as metadata, the host overrides the authority and the retried
+// calls are the ones rejected with the Unavailable or ResourceExhausted codes.
+//
+// The tensor data of the requests may be flat or nested slices of any Go type convertible to the datatype, the
+// []interface{} of decoded JSON included. The data of the response tensors are decoded into []bool, []int32 for the
+// 8 to 32 bits integers, []int64, []uint32 for the 8 to 32 bits unsigned integers, []uint64, []float32, []float64 and
+// [][]byte, the raw FP16 and BF16 data are returned as []byte.
+type GRPCClient struct {
+	config *Client
+	conn   *grpc.ClientConn
+}
+
+// WithGRPCDialOptions adds dial options to the connection of a GRPCClient
+func WithGRPCDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) {
+		c.grpcDialOptions = append(c.grpcDialOptions, opts...)
+	}
+}
+
+// NewGRPCClient creates a gRPC client for the InferenceService served on baseUrl, the https urls are dialed with TLS
+func NewGRPCClient(baseUrl string, opts ...Option) (*GRPCClient, error) {
+	config, err := NewClient(baseUrl, opts...)
+	if err != nil {
+		return nil, err
+	}
+	address := config.baseUrl.Host
+	transport := insecure.NewCredentials()
+	if config.baseUrl.Scheme == "https" {
+		transport = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	if config.baseUrl.Port() == "" {
+		port := "80"
+		if config.baseUrl.Scheme == "https" {
+			port = "443"
+		}
+		address = net.JoinHostPort(config.baseUrl.Hostname(), port)
+	}
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+	}
+	if config.host != "" {
+		dialOptions = append(dialOptions, grpc.WithAuthority(config.host))
+	}
+	conn, err := grpc.Dial(address, append(dialOptions, config.grpcDialOptions...)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", address)
+	}
+	return &GRPCClient{config: config, conn: conn}, nil
+}
+
+// NewGRPCClientForInferenceService creates a gRPC client for the InferenceService, resolving its url from the status
+func NewGRPCClientForInferenceService(ctx context.Context, clientset versioned.Interface, name string, namespace string,
+	internal bool, opts ...Option) (*GRPCClient, error) {
+	isvcUrl, err := ResolveURL(ctx, clientset, name, namespace, internal)
+	if err != nil {
+		return nil, err
+	}
+	return NewGRPCClient(isvcUrl, opts...)
+}
+
+// Close closes the connection of the client
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// ServerLive reports whether the server is live
+func (c *GRPCClient) ServerLive(ctx context.Context) (bool, error) {
+	return c.ready(ctx, "ServerLive", nil)
+}
+
+// ServerReady reports whether the server is ready
+func (c *GRPCClient) ServerReady(ctx context.Context) (bool, error) {
+	return c.ready(ctx, "ServerReady", nil)
+}
+
+// ModelReady reports whether the model is ready
+func (c *GRPCClient) ModelReady(ctx context.Context, modelName string) (bool, error) {
+	return c.ready(ctx, "ModelReady", appendString(nil, metadataNameField, modelName))
+}
+
+// ServerMetadata returns the metadata of the server
+func (c *GRPCClient) ServerMetadata(ctx context.Context) (*ServerMetadata, error) {
+	message, err := c.invoke(ctx, "ServerMetadata", nil)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	response := &ServerMetadata{}
+	for _, field := range fields {
+		switch field.num {
+		case metadataNameField:
+			response.Name = string(field.value)
+		case metadataVersionField:
+			response.Version = string(field.value)
+		case serverExtensionsField:
+			response.Extensions = append(response.Extensions, string(field.value))
+		}
+	}
+	return response, nil
+}
+
+// ModelMetadata returns the metadata of the model
+func (c *GRPCClient) ModelMetadata(ctx context.Context, modelName string) (*ModelMetadata, error) {
+	message, err := c.invoke(ctx, "ModelMetadata", appendString(nil, metadataNameField, modelName))
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	response := &ModelMetadata{}
+	for _, field := range fields {
+		switch field.num {
+		case metadataNameField:
+			response.Name = string(field.value)
+		case metadataVersionField:
+			response.Versions = append(response.Versions, string(field.value))
+		case metadataPlatformField:
+			response.Platform = string(field.value)
+		case metadataInputsField, metadataOutputsField:
+			tensor, err := decodeTensor(field.value)
+			if err != nil {
+				return nil, err
+			}
+			tensorMetadata := TensorMetadata{Name: tensor.Name, Datatype: tensor.Datatype, Shape: tensor.Shape}
+			if field.num == metadataInputsField {
+				response.Inputs = append(response.Inputs, tensorMetadata)
+			} else {
+				response.Outputs = append(response.Outputs, tensorMetadata)
+			}
+		}
+	}
+	return response, nil
+}
+
+// Infer sends a v2 inference request with the ModelInfer method
+func (c *GRPCClient) Infer(ctx context.Context, modelName string, request *InferRequest) (*InferResponse, error) {
+	message, err := encodeInferRequest(modelName, request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.invoke(ctx, "ModelInfer", message)
+	if err != nil {
+		return nil, err
+	}
+	return decodeInferResponse(response)
+}
+
+func (c *GRPCClient) ready(ctx context.Context, method string, request []byte) (bool, error) {
+	message, err := c.invoke(ctx, method, request)
+	if err != nil {
+		return false, err
+	}
+	fields, err := parseMessage(message)
+	if err != nil {
+		return false, err
+	}
+	for _, field := range fields {
+		if field.num == readyField {
+			value, _ := protowire.ConsumeVarint(field.value)
+			return value != 0, nil
+		}
+	}
+	return false, nil
+}
+
+// invoke calls the method, retrying the calls rejected by the server or the proxies with the backoff of the client
+func (c *GRPCClient) invoke(ctx context.Context, method string, request []byte) ([]byte, error) {
+	pairs := make([]string, 0, 2*len(c.config.headers)+2)
+	for name, value := range c.config.headers {
+		pairs = append(pairs, name, value)
+	}
+	if c.config.tokenSource != nil {
+		token, err := c.config.tokenSource()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, "authorization", "Bearer "+token)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	if request == nil {
+		request = []byte{}
+	}
+	backoff := c.config.initialBackoff
+	for attempt := 0; ; attempt++ {
+		response := []byte{}
+		err := c.conn.Invoke(ctx, grpcService+method, &request, &response)
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= c.config.maxRetries || !isRetryableCode(status.Code(err)) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > c.config.maxBackoff {
+			backoff = c.config.maxBackoff
+		}
+	}
+}
+
+// isRetryableCode returns whether the call was rejected before the model processed it, the gRPC counterpart of the
+// retried 429 and 503 status codes
+func isRetryableCode(code codes.Code) bool {
+	return code == codes.Unavailable || code == codes.ResourceExhausted
+}
+
+// rawCodec passes the messages encoded with protowire through gRPC, its name is the one of the protobuf codec so that
+// the servers accept the content subtype of the calls
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append((*v.(*[]byte))[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+func encodeInferRequest(modelName string, request *InferRequest) ([]byte, error) {
+	message := appendString(nil, inferModelNameField, modelName)
+	if request.Id != "" {
+		message = appendString(message, inferIdField, request.Id)
+	}
+	var err error
+	if message, err = appendParameters(message, inferParametersField, request.Parameters); err != nil {
+		return nil, err
+	}
+	for _, input := range request.Inputs {
+		tensor := appendString(nil, tensorNameField, input.Name)
+		tensor = appendString(tensor, tensorDatatypeField, input.Datatype)
+		shape := []byte{}
+		for _, dim := range input.Shape {
+			shape = protowire.AppendVarint(shape, uint64(dim))
+		}
+		tensor = protowire.AppendTag(tensor, tensorShapeField, protowire.BytesType)
+		tensor = protowire.AppendBytes(tensor, shape)
+		if tensor, err = appendParameters(tensor, tensorParametersField, input.Parameters); err != nil {
+			return nil, err
+		}
+		contents, err := encodeContents(input.Datatype, input.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid data of input %s", input.Name)
+		}
+		tensor = protowire.AppendTag(tensor, tensorContentsField, protowire.BytesType)
+		tensor = protowire.AppendBytes(tensor, contents)
+		message = protowire.AppendTag(message, inferTensorsField, protowire.BytesType)
+		message = protowire.AppendBytes(message, tensor)
+	}
+	for _, output := range request.Outputs {
+		requested := appendString(nil, requestedNameField, output.Name)
+		if requested, err = appendParameters(requested, requestedParametersField, output.Parameters); err != nil {
+			return nil, err
+		}
+		message = protowire.AppendTag(message, inferRequestedField, protowire.BytesType)
+		message = protowire.AppendBytes(message, requested)
+	}
+	return message, nil
+}
+
+// appendParameters appends the map<string, InferParameter> field, the parameters are sorted so the messages are
+// deterministic. The JSON numbers are integers in the protocol, decoded as float64 they must be whole numbers.
+func appendParameters(message []byte, num protowire.Number, parameters map[string]interface{}) ([]byte, error) {
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var parameter []byte
+		value := reflect.ValueOf(parameters[name])
+		switch value.Kind() {
+		case reflect.Bool:
+			parameter = protowire.AppendTag(nil, boolParamField, protowire.VarintType)
+			parameter = protowire.AppendVarint(parameter, protowire.EncodeBool(value.Bool()))
+		case reflect.String:
+			parameter = appendString(nil, stringParamField, value.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+			reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			number, ok := toInt64(value)
+			if !ok {
+				return nil, fmt.Errorf("parameter %s must be an integer, got %v", name, parameters[name])
+			}
+			parameter = protowire.AppendTag(nil, int64ParamField, protowire.VarintType)
+			parameter = protowire.AppendVarint(parameter, uint64(number))
+		default:
+			return nil, fmt.Errorf("parameter %s must be a bool, an integer or a string, got %T", name, parameters[name])
+		}
+		entry := appendString(nil, 1, name)
+		entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, parameter)
+		message = protowire.AppendTag(message, num, protowire.BytesType)
+		message = protowire.AppendBytes(message, entry)
+	}
+	return message, nil
+}
+
+// encodeContents encodes the InferTensorContents message of the data
+func encodeContents(datatype string, data interface{}) ([]byte, error) {
+	num, ok := contentsFields[datatype]
+	if !ok {
+		return nil, fmt.Errorf("datatype %s is not supported by the gRPC client", datatype)
+	}
+	elements := flatten(reflect.ValueOf(data), nil)
+	if num == bytesContentsField {
+		var contents []byte
+		for _, element := range elements {
+			var value []byte
+			switch {
+			case element.Kind() == reflect.String:
+				value = []byte(element.String())
+			case element.Kind() == reflect.Slice && element.Type().Elem().Kind() == reflect.Uint8:
+				value = element.Bytes()
+			default:
+				return nil, fmt.Errorf("BYTES element %v must be a string or []byte", element)
+			}
+			contents = protowire.AppendTag(contents, num, protowire.BytesType)
+			contents = protowire.AppendBytes(contents, value)
+		}
+		return contents, nil
+	}
+	packed := []byte{}
+	for _, element := range elements {
+		switch num {
+		case boolContentsField:
+			if element.Kind() != reflect.Bool {
+				return nil, fmt.Errorf("BOOL element %v must be a bool", element)
+			}
+			packed = protowire.AppendVarint(packed, protowire.EncodeBool(element.Bool()))
+		case fp32ContentsField, fp64ContentsField:
+			number, ok := toFloat64(element)
+			if !ok {
+				return nil, fmt.Errorf("%s element %v must be a number", datatype, element)
+			}
+			if num == fp32ContentsField {
+				packed = protowire.AppendFixed32(packed, math.Float32bits(float32(number)))
+			} else {
+				packed = protowire.AppendFixed64(packed, math.Float64bits(number))
+			}
+		case uintContentsField, uint64ContentsField:
+			number, ok := toInt64(element)
+			if !ok || number < 0 {
+				return nil, fmt.Errorf("%s element %v must be an unsigned integer", datatype, element)
+			}
+			packed = protowire.AppendVarint(packed, uint64(number))
+		default:
+			number, ok := toInt64(element)
+			if !ok {
+				return nil, fmt.Errorf("%s element %v must be an integer", datatype, element)
+			}
+			packed = protowire.AppendVarint(packed, uint64(number))
+		}
+	}
+	contents := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(contents, packed), nil
+}
+
+// flatten appends the elements of the nested slices in row-major order
+func flatten(value reflect.Value, elements []reflect.Value) []reflect.Value {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return elements
+		}
+		value = value.Elem()
+	}
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < value.Len(); i++ {
+			elements = flatten(value.Index(i), elements)
+		}
+		return elements
+	}
+	return append(elements, value)
+}
+
+func toFloat64(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+func toInt64(value reflect.Value) (int64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint()), value.Uint() <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		number := value.Float()
+		return int64(number), number == math.Trunc(number) && math.Abs(number) <= 1<<53
+	}
+	return 0, false
+}
+
+func decodeInferResponse(message []byte) (*InferResponse, error) {
+	fields, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	response := &InferResponse{}
+	var rawContents [][]byte
+	for _, field := range fields {
+		switch field.num {
+		case inferModelNameField:
+			response.ModelName = string(field.value)
+		case inferModelVersionField:
+			response.ModelVersion = string(field.value)
+		case inferIdField:
+			response.Id = string(field.value)
+		case inferParametersField:
+			if response.Parameters == nil {
+				response.Parameters = map[string]interface{}{}
+			}
+			if err := decodeParameter(field.value, response.Parameters); err != nil {
+				return nil, err
+			}
+		case inferTensorsField:
+			output, err := decodeTensor(field.value)
+			if err != nil {
+				return nil, err
+			}
+			response.Outputs = append(response.Outputs, *output)
+		case inferRawResponseField:
+			rawContents = append(rawContents, field.value)
+		}
+	}
+	// The raw contents are the data of the outputs in order
+	if len(rawContents) > 0 {
+		if len(rawContents) != len(response.Outputs) {
+			return nil, fmt.Errorf("%d raw contents for %d outputs", len(rawContents), len(response.Outputs))
+		}
+		for i := range response.Outputs {
+			data, err := decodeRawContents(response.Outputs[i].Datatype, rawContents[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid raw contents of output %s", response.Outputs[i].Name)
+			}
+			response.Outputs[i].Data = data
+		}
+	}
+	return response, nil
+}
+
+// decodeTensor decodes the InferOutputTensor message, or the TensorMetadata message which shares its first fields
+func decodeTensor(message []byte) (*Tensor, error) {
+	fields, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	tensor := &Tensor{}
+	for _, field := range fields {
+		switch field.num {
+		case tensorNameField:
+			tensor.Name = string(field.value)
+		case tensorDatatypeField:
+			tensor.Datatype = string(field.value)
+		case tensorShapeField:
+			tensor.Shape = append(tensor.Shape, decodeVarints(field)...)
+		case tensorParametersField:
+			if tensor.Parameters == nil {
+				tensor.Parameters = map[string]interface{}{}
+			}
+			if err := decodeParameter(field.value, tensor.Parameters); err != nil {
+				return nil, err
+			}
+		case tensorContentsField:
+			if tensor.Data, err = decodeContents(tensor.Datatype, field.value); err != nil {
+				return nil, errors.Wrapf(err, "invalid contents of tensor %s", tensor.Name)
+			}
+		}
+	}
+	return tensor, nil
+}
+
+// decodeParameter decodes a map entry of InferParameter into the parameters
+func decodeParameter(entry []byte, parameters map[string]interface{}) error {
+	fields, err := parseMessage(entry)
+	if err != nil {
+		return err
+	}
+	var name string
+	var value interface{}
+	for _, field := range fields {
+		switch field.num {
+		case 1:
+			name = string(field.value)
+		case 2:
+			choices, err := parseMessage(field.value)
+			if err != nil {
+				return err
+			}
+			for _, choice := range choices {
+				switch choice.num {
+				case boolParamField:
+					number, _ := protowire.ConsumeVarint(choice.value)
+					value = protowire.DecodeBool(number)
+				case int64ParamField:
+					number, _ := protowire.ConsumeVarint(choice.value)
+					value = int64(number)
+				case stringParamField:
+					value = string(choice.value)
+				}
+			}
+		}
+	}
+	parameters[name] = value
+	return nil
+}
+
+// decodeContents decodes the InferTensorContents message into the slice of the datatype
+func decodeContents(datatype string, message []byte) (interface{}, error) {
+	fields, err := parseMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	var values []uint64
+	var bytesValues [][]byte
+	for _, field := range fields {
+		if field.num != contentsFields[datatype] {
+			return nil, fmt.Errorf("unexpected contents %d for datatype %s", field.num, datatype)
+		}
+		switch field.num {
+		case bytesContentsField:
+			bytesValues = append(bytesValues, field.value)
+		case fp32ContentsField:
+			values = append(values, decodeFixed(field, 4)...)
+		case fp64ContentsField:
+			values = append(values, decodeFixed(field, 8)...)
+		default:
+			for _, value := range decodeVarints(field) {
+				values = append(values, uint64(value))
+			}
+		}
+	}
+	switch contentsFields[datatype] {
+	case bytesContentsField:
+		if bytesValues == nil {
+			bytesValues = [][]byte{}
+		}
+		return bytesValues, nil
+	case boolContentsField:
+		data := make([]bool, len(values))
+		for i, value := range values {
+			data[i] = value != 0
+		}
+		return data, nil
+	case intContentsField:
+		data := make([]int32, len(values))
+		for i, value := range values {
+			data[i] = int32(value)
+		}
+		return data, nil
+	case int64ContentsField:
+		data := make([]int64, len(values))
+		for i, value := range values {
+			data[i] = int64(value)
+		}
+		return data, nil
+	case uintContentsField:
+		data := make([]uint32, len(values))
+		for i, value := range values {
+			data[i] = uint32(value)
+		}
+		return data, nil
+	case uint64ContentsField:
+		return values, nil
+	case fp32ContentsField:
+		data := make([]float32, len(values))
+		for i, value := range values {
+			data[i] = math.Float32frombits(uint32(value))
+		}
+		return data, nil
+	case fp64ContentsField:
+		data := make([]float64, len(values))
+		for i, value := range values {
+			data[i] = math.Float64frombits(value)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unknown datatype %s", datatype)
+}
+
+// decodeRawContents decodes the little endian raw contents into the slice of the datatype, the BYTES elements are
+// prefixed with their 4 bytes length
+func decodeRawContents(datatype string, raw []byte) (interface{}, error) {
+	size := map[string]int{"BOOL": 1, "INT8": 1, "UINT8": 1, "INT16": 2, "UINT16": 2, "INT32": 4, "UINT32": 4,
+		"FP32": 4, "INT64": 8, "UINT64": 8, "FP64": 8}[datatype]
+	switch {
+	case datatype == "BYTES":
+		data := [][]byte{}
+		for len(raw) > 0 {
+			if len(raw) < 4 || int(binary.LittleEndian.Uint32(raw)) > len(raw)-4 {
+				return nil, fmt.Errorf("truncated BYTES element")
+			}
+			n := int(binary.LittleEndian.Uint32(raw))
+			data = append(data, raw[4:4+n])
+			raw = raw[4+n:]
+		}
+		return data, nil
+	case datatype == "FP16" || datatype == "BF16":
+		return raw, nil
+	case size == 0:
+		return nil, fmt.Errorf("unknown datatype %s", datatype)
+	case len(raw)%size != 0:
+		return nil, fmt.Errorf("%d bytes are not a whole number of %s elements", len(raw), datatype)
+	}
+	n := len(raw) / size
+	element := func(i int) uint64 {
+		switch size {
+		case 1:
+			return uint64(raw[i])
+		case 2:
+			return uint64(binary.LittleEndian.Uint16(raw[2*i:]))
+		case 4:
+			return uint64(binary.LittleEndian.Uint32(raw[4*i:]))
+		}
+		return binary.LittleEndian.Uint64(raw[8*i:])
+	}
+	switch datatype {
+	case "BOOL":
+		data := make([]bool, n)
+		for i := range data {
+			data[i] = element(i) != 0
+		}
+		return data, nil
+	case "INT8", "INT16", "INT32":
+		data := make([]int32, n)
+		for i := range data {
+			// sign extend the narrow integers
+			shift := 64 - 8*size
+			data[i] = int32(int64(element(i)<<shift) >> shift)
+		}
+		return data, nil
+	case "UINT8", "UINT16", "UINT32":
+		data := make([]uint32, n)
+		for i := range data {
+			data[i] = uint32(element(i))
+		}
+		return data, nil
+	case "INT64":
+		data := make([]int64, n)
+		for i := range data {
+			data[i] = int64(element(i))
+		}
+		return data, nil
+	case "UINT64":
+		data := make([]uint64, n)
+		for i := range data {
+			data[i] = element(i)
+		}
+		return data, nil
+	case "FP32":
+		data := make([]float32, n)
+		for i := range data {
+			data[i] = math.Float32frombits(uint32(element(i)))
+		}
+		return data, nil
+	}
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = math.Float64frombits(element(i))
+	}
+	return data, nil
+}
+
+// messageField is a field of a protobuf message, value is the payload of the length delimited fields and the
+// encoded value of the others
+type messageField struct {
+	num   protowire.Number
+	typ   protowire.Type
+	value []byte
+}
+
+// parseMessage returns the fields of the protobuf message in order
+func parseMessage(message []byte) ([]messageField, error) {
+	var fields []messageField
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, message[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+		field := messageField{num: num, typ: typ, value: message[n : n+m]}
+		if typ == protowire.BytesType {
+			field.value, _ = protowire.ConsumeBytes(field.value)
+		}
+		fields = append(fields, field)
+		message = message[n+m:]
+	}
+	return fields, nil
+}
+
+// decodeVarints decodes a packed or a single varint field
+func decodeVarints(field messageField) []int64 {
+	if field.typ != protowire.BytesType {
+		value, _ := protowire.ConsumeVarint(field.value)
+		return []int64{int64(value)}
+	}
+	var values []int64
+	for packed := field.value; len(packed) > 0; {
+		value, n := protowire.ConsumeVarint(packed)
+		if n < 0 {
+			break
+		}
+		values = append(values, int64(value))
+		packed = packed[n:]
+	}
+	return values
+}
+
+// decodeFixed decodes a packed or a single fixed32 or fixed64 field
+func decodeFixed(field messageField, size int) []uint64 {
+	var values []uint64
+	for data := field.value; len(data) >= size; data = data[size:] {
+		if size == 4 {
+			values = append(values, uint64(binary.LittleEndian.Uint32(data)))
+		} else {
+			values = append(values, binary.LittleEndian.Uint64(data))
+		}
+	}
+	return values
+}
+
+func appendString(message []byte, num protowire.Number, value string) []byte {
+	message = protowire.AppendTag(message, num, protowire.BytesType)
+	return protowire.AppendString(message, value)
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// startGRPCServer serves the v2 methods with handlers of the raw messages
+func startGRPCServer(g *gomega.WithT, handlers map[string]func(ctx context.Context, request []byte) ([]byte, error)) (string, func()) {
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	desc := &grpc.ServiceDesc{
+		ServiceName: "inference.GRPCInferenceService",
+		HandlerType: (*interface{})(nil),
+	}
+	for method, handler := range handlers {
+		handler := handler
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: method,
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := []byte{}
+				if err := dec(&request); err != nil {
+					return nil, err
+				}
+				response, err := handler(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+				return &response, nil
+			},
+		})
+	}
+	server.RegisterService(desc, nil)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(gomega.BeNil())
+	go server.Serve(listener)
+	return "http://" + listener.Addr().String(), server.Stop
+}
+
+func TestGRPCInfer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	url, stop := startGRPCServer(g, map[string]func(ctx context.Context, request []byte) ([]byte, error){
+		// ModelInfer echoes the inputs of the request as the outputs of the response
+		"ModelInfer": func(ctx context.Context, request []byte) ([]byte, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			g.Expect(md.Get("authorization")).To(gomega.Equal([]string{"Bearer secret"}))
+			g.Expect(md.Get("x-tenant")).To(gomega.Equal([]string{"team-a"}))
+			g.Expect(md.Get(":authority")).To(gomega.Equal([]string{"mymodel.default.example.com"}))
+			fields, err := parseMessage(request)
+			g.Expect(err).To(gomega.BeNil())
+			response := []byte{}
+			for _, field := range fields {
+				if field.num <= inferTensorsField {
+					response = protowire.AppendTag(response, field.num, protowire.BytesType)
+					response = protowire.AppendBytes(response, field.value)
+				}
+			}
+			return response, nil
+		},
+	})
+	defer stop()
+
+	client, err := NewGRPCClient(url, WithToken("secret"), WithHeader("X-Tenant", "team-a"),
+		WithHost("mymodel.default.example.com"))
+	g.Expect(err).To(gomega.BeNil())
+	defer client.Close()
+	response, err := client.Infer(context.TODO(), "mymodel", &InferRequest{
+		Id:         "42",
+		Parameters: map[string]interface{}{"priority": float64(2), "tag": "canary"},
+		Inputs: []Tensor{
+			{Name: "input-0", Datatype: "FP32", Shape: []int64{2, 2}, Data: []interface{}{[]interface{}{1.5, 2.0}, []interface{}{3.0, -4.0}}},
+			{Name: "input-1", Datatype: "INT32", Shape: []int64{2}, Data: []int{-1, 7}},
+			{Name: "input-2", Datatype: "BYTES", Shape: []int64{1}, Data: []string{"hello"}},
+			{Name: "input-3", Datatype: "BOOL", Shape: []int64{1}, Data: []bool{true}},
+		},
+		Outputs: []RequestedOutput{{Name: "output-0"}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(response.ModelName).To(gomega.Equal("mymodel"))
+	g.Expect(response.Id).To(gomega.Equal("42"))
+	g.Expect(response.Parameters).To(gomega.Equal(map[string]interface{}{"priority": int64(2), "tag": "canary"}))
+	g.Expect(response.Outputs).To(gomega.HaveLen(4))
+	g.Expect(response.Outputs[0].Shape).To(gomega.Equal([]int64{2, 2}))
+	g.Expect(response.Outputs[0].Data).To(gomega.Equal([]float32{1.5, 2, 3, -4}))
+	g.Expect(response.Outputs[1].Data).To(gomega.Equal([]int32{-1, 7}))
+	g.Expect(response.Outputs[2].Data).To(gomega.Equal([][]byte{[]byte("hello")}))
+	g.Expect(response.Outputs[3].Data).To(gomega.Equal([]bool{true}))
+
+	_, err = client.Infer(context.TODO(), "mymodel", &InferRequest{
+		Inputs: []Tensor{{Name: "input-0", Datatype: "FP16", Shape: []int64{1}, Data: []float32{1}}},
+	})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("FP16 is not supported")))
+}
+
+func TestGRPCMetadata(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	url, stop := startGRPCServer(g, map[string]func(ctx context.Context, request []byte) ([]byte, error){
+		"ModelReady": func(ctx context.Context, request []byte) ([]byte, error) {
+			fields, err := parseMessage(request)
+			g.Expect(err).To(gomega.BeNil())
+			ready := len(fields) == 1 && string(fields[0].value) == "mymodel"
+			response := protowire.AppendTag(nil, readyField, protowire.VarintType)
+			return protowire.AppendVarint(response, protowire.EncodeBool(ready)), nil
+		},
+		"ModelMetadata": func(ctx context.Context, request []byte) ([]byte, error) {
+			tensor := appendString(nil, tensorNameField, "input-0")
+			tensor = appendString(tensor, tensorDatatypeField, "FP32")
+			tensor = protowire.AppendTag(tensor, tensorShapeField, protowire.BytesType)
+			tensor = protowire.AppendBytes(tensor, protowire.AppendVarint(protowire.AppendVarint(nil, math.MaxUint64), 4))
+			response := appendString(nil, metadataNameField, "mymodel")
+			response = appendString(response, metadataVersionField, "1")
+			response = appendString(response, metadataPlatformField, "sklearn")
+			response = protowire.AppendTag(response, metadataInputsField, protowire.BytesType)
+			return protowire.AppendBytes(response, tensor), nil
+		},
+	})
+	defer stop()
+
+	client, err := NewGRPCClient(url)
+	g.Expect(err).To(gomega.BeNil())
+	defer client.Close()
+	ready, err := client.ModelReady(context.TODO(), "mymodel")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(ready).To(gomega.BeTrue())
+	modelMetadata, err := client.ModelMetadata(context.TODO(), "mymodel")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(modelMetadata).To(gomega.Equal(&ModelMetadata{
+		Name:     "mymodel",
+		Versions: []string{"1"},
+		Platform: "sklearn",
+		Inputs:   []TensorMetadata{{Name: "input-0", Datatype: "FP32", Shape: []int64{-1, 4}}},
+	}))
+	_, err = client.ServerLive(context.TODO())
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.Unimplemented))
+}
+
+func TestGRPCRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		code             codes.Code
+		expectedAttempts int
+		expectErr        bool
+	}{
+		"RetryUnavailable": {
+			code:             codes.Unavailable,
+			expectedAttempts: 3,
+			expectErr:        true,
+		},
+		"NoRetryInvalidArgument": {
+			code:             codes.InvalidArgument,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		"SucceedAfterRetry": {
+			code:             codes.OK,
+			expectedAttempts: 2,
+		},
+	}
+	for name, scenario := range scenarios {
+		attempts := 0
+		url, stop := startGRPCServer(g, map[string]func(ctx context.Context, request []byte) ([]byte, error){
+			"ServerReady": func(ctx context.Context, request []byte) ([]byte, error) {
+				attempts++
+				if scenario.code == codes.OK && attempts > 1 {
+					return []byte{}, nil
+				}
+				code := scenario.code
+				if code == codes.OK {
+					code = codes.ResourceExhausted
+				}
+				return nil, status.Error(code, "rejected")
+			},
+		})
+		client, err := NewGRPCClient(url, WithRetries(2, time.Millisecond, time.Millisecond))
+		g.Expect(err).To(gomega.BeNil())
+		_, err = client.ServerReady(context.TODO())
+		client.Close()
+		stop()
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+		} else {
+			g.Expect(err).To(gomega.BeNil(), name)
+		}
+		g.Expect(attempts).To(gomega.Equal(scenario.expectedAttempts), name)
+	}
+}
+
+func TestDecodeRawOutputs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	output := func(name string, datatype string) []byte {
+		tensor := appendString(nil, tensorNameField, name)
+		tensor = appendString(tensor, tensorDatatypeField, datatype)
+		message := protowire.AppendTag(nil, inferTensorsField, protowire.BytesType)
+		return protowire.AppendBytes(message, tensor)
+	}
+	raw := func(data []byte) []byte {
+		message := protowire.AppendTag(nil, inferRawResponseField, protowire.BytesType)
+		return protowire.AppendBytes(message, data)
+	}
+	fp32 := make([]byte, 8)
+	binary.LittleEndian.PutUint32(fp32, math.Float32bits(0.5))
+	binary.LittleEndian.PutUint32(fp32[4:], math.Float32bits(-2))
+	bytesData := []byte{2, 0, 0, 0, 'h', 'i'}
+
+	message := appendString(nil, inferModelNameField, "mymodel")
+	message = append(message, output("output-0", "FP32")...)
+	message = append(message, output("output-1", "INT8")...)
+	message = append(message, output("output-2", "BYTES")...)
+	message = append(message, raw(fp32)...)
+	message = append(message, raw([]byte{0xff, 0x02})...)
+	message = append(message, raw(bytesData)...)
+	response, err := decodeInferResponse(message)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(response.Outputs[0].Data).To(gomega.Equal([]float32{0.5, -2}))
+	g.Expect(response.Outputs[1].Data).To(gomega.Equal([]int32{-1, 2}))
+	g.Expect(response.Outputs[2].Data).To(gomega.Equal([][]byte{[]byte("hi")}))
+
+	_, err = decodeInferResponse(append(message, raw([]byte{1})...))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("4 raw contents for 3 outputs")))
+}
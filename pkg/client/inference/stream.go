@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+const (
+	chatCompletionsPath = "/openai/v1/chat/completions"
+
+	sseDataPrefix = "data:"
+	sseDone       = "[DONE]"
+)
+
+// ChatCompletionStream reads the server sent events of a streamed chat completion
+type ChatCompletionStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+func newChatCompletionStream(body io.ReadCloser) *ChatCompletionStream {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &ChatCompletionStream{
+		body:    body,
+		scanner: scanner,
+	}
+}
+
+// Recv returns the next chunk of the completion, io.EOF is returned once the stream is complete
+func (s *ChatCompletionStream) Recv() (*ChatCompletionResponse, error) {
+	for s.scanner.Scan() {
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if !bytes.HasPrefix(line, []byte(sseDataPrefix)) {
+			// blank separators, comments and other event fields are ignored
+			continue
+		}
+		data := bytes.TrimSpace(line[len(sseDataPrefix):])
+		if string(data) == sseDone {
+			return nil, io.EOF
+		}
+		chunk := &ChatCompletionResponse{}
+		if err := json.Unmarshal(data, chunk); err != nil {
+			return nil, err
+		}
+		return chunk, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close releases the underlying connection
+func (s *ChatCompletionStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inference
+
+// V1Request is the body of a v1 predict or explain request
+type V1Request struct {
+	Instances []interface{} `json:"instances"`
+}
+
+// V1Response is the body of a v1 predict response
+type V1Response struct {
+	Predictions []interface{} `json:"predictions"`
+}
+
+// Tensor is an input or output tensor of the v2 protocol, data is sent flattened in row-major order
+type Tensor struct {
+	Name       string                 `json:"name"`
+	Datatype   string                 `json:"datatype"`
+	Shape      []int64                `json:"shape"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       interface{}            `json:"data"`
+}
+
+// RequestedOutput selects an output tensor to be returned by a v2 inference request
+type RequestedOutput struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// InferRequest is the body of a v2 inference request
+type InferRequest struct {
+	Id         string                 `json:"id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Inputs     []Tensor               `json:"inputs"`
+	Outputs    []RequestedOutput      `json:"outputs,omitempty"`
+}
+
+// InferResponse is the body of a v2 inference response
+type InferResponse struct {
+	ModelName    string                 `json:"model_name"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Outputs      []Tensor               `json:"outputs"`
+}
+
+// TensorMetadata describes a tensor reported by the v2 model metadata endpoint
+type TensorMetadata struct {
+	Name     string  `json:"name"`
+	Datatype string  `json:"datatype"`
+	Shape    []int64 `json:"shape"`
+}
+
+// ModelMetadata is the v2 model metadata response, see
+// https://github.com/kserve/kserve/blob/master/docs/predict-api/v2/required_api.md#model-metadata
+type ModelMetadata struct {
+	Name     string           `json:"name"`
+	Versions []string         `json:"versions,omitempty"`
+	Platform string           `json:"platform"`
+	Inputs   []TensorMetadata `json:"inputs"`
+	Outputs  []TensorMetadata `json:"outputs"`
+}
+
+// ServerMetadata is the v2 server metadata response
+type ServerMetadata struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// ChatMessage is a message of an OpenAI compatible chat completion
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatCompletionRequest is the body of an OpenAI compatible chat completion request
+type ChatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []ChatMessage `json:"messages"`
+	MaxTokens   *int          `json:"max_tokens,omitempty"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	TopP        *float64      `json:"top_p,omitempty"`
+	Stop        []string      `json:"stop,omitempty"`
+	Stream      bool          `json:"stream,omitempty"`
+}
+
+// ChatCompletionChoice is a single choice of a chat completion, Delta is set instead of Message when streaming
+type ChatCompletionChoice struct {
+	Index        int          `json:"index"`
+	Message      *ChatMessage `json:"message,omitempty"`
+	Delta        *ChatMessage `json:"delta,omitempty"`
+	FinishReason *string      `json:"finish_reason,omitempty"`
+}
+
+// Usage reports the number of tokens consumed by a completion
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// ChatCompletionResponse is the body of an OpenAI compatible chat completion response, or a single
+// chunk of it when streaming
+type ChatCompletionResponse struct {
+	Id      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   *Usage                 `json:"usage,omitempty"`
+}
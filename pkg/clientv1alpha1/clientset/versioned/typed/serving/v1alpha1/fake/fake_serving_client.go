@@ -36,10 +36,26 @@ func (c *FakeServingV1alpha1) InferenceGraphs(namespace string) v1alpha1.Inferen
 	return &FakeInferenceGraphs{c, namespace}
 }
 
+func (c *FakeServingV1alpha1) InferenceServiceTemplates() v1alpha1.InferenceServiceTemplateInterface {
+	return &FakeInferenceServiceTemplates{c}
+}
+
+func (c *FakeServingV1alpha1) KServeConfigs(namespace string) v1alpha1.KServeConfigInterface {
+	return &FakeKServeConfigs{c, namespace}
+}
+
+func (c *FakeServingV1alpha1) LocalModelCaches() v1alpha1.LocalModelCacheInterface {
+	return &FakeLocalModelCaches{c}
+}
+
 func (c *FakeServingV1alpha1) ServingRuntimes(namespace string) v1alpha1.ServingRuntimeInterface {
 	return &FakeServingRuntimes{c, namespace}
 }
 
+func (c *FakeServingV1alpha1) TemplatedInferenceServices(namespace string) v1alpha1.TemplatedInferenceServiceInterface {
+	return &FakeTemplatedInferenceServices{c, namespace}
+}
+
 func (c *FakeServingV1alpha1) TrainedModels(namespace string) v1alpha1.TrainedModelInterface {
 	return &FakeTrainedModels{c, namespace}
 }
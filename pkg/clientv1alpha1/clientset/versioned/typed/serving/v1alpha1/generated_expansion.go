@@ -22,6 +22,14 @@ type ClusterServingRuntimeExpansion interface{}
 
 type InferenceGraphExpansion interface{}
 
+type InferenceServiceTemplateExpansion interface{}
+
+type KServeConfigExpansion interface{}
+
+type LocalModelCacheExpansion interface{}
+
 type ServingRuntimeExpansion interface{}
 
+type TemplatedInferenceServiceExpansion interface{}
+
 type TrainedModelExpansion interface{}
@@ -30,7 +30,11 @@ type ServingV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterServingRuntimesGetter
 	InferenceGraphsGetter
+	InferenceServiceTemplatesGetter
+	KServeConfigsGetter
+	LocalModelCachesGetter
 	ServingRuntimesGetter
+	TemplatedInferenceServicesGetter
 	TrainedModelsGetter
 }
 
@@ -47,10 +51,26 @@ func (c *ServingV1alpha1Client) InferenceGraphs(namespace string) InferenceGraph
 	return newInferenceGraphs(c, namespace)
 }
 
+func (c *ServingV1alpha1Client) InferenceServiceTemplates() InferenceServiceTemplateInterface {
+	return newInferenceServiceTemplates(c)
+}
+
+func (c *ServingV1alpha1Client) KServeConfigs(namespace string) KServeConfigInterface {
+	return newKServeConfigs(c, namespace)
+}
+
+func (c *ServingV1alpha1Client) LocalModelCaches() LocalModelCacheInterface {
+	return newLocalModelCaches(c)
+}
+
 func (c *ServingV1alpha1Client) ServingRuntimes(namespace string) ServingRuntimeInterface {
 	return newServingRuntimes(c, namespace)
 }
 
+func (c *ServingV1alpha1Client) TemplatedInferenceServices(namespace string) TemplatedInferenceServiceInterface {
+	return newTemplatedInferenceServices(c, namespace)
+}
+
 func (c *ServingV1alpha1Client) TrainedModels(namespace string) TrainedModelInterface {
 	return newTrainedModels(c, namespace)
 }
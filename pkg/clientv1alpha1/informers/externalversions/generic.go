@@ -57,8 +57,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ClusterServingRuntimes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("inferencegraphs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().InferenceGraphs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("inferenceservicetemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().InferenceServiceTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("kserveconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().KServeConfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("localmodelcaches"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().LocalModelCaches().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("servingruntimes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ServingRuntimes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("templatedinferenceservices"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().TemplatedInferenceServices().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("trainedmodels"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().TrainedModels().Informer()}, nil
 
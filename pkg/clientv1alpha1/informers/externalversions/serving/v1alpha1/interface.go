@@ -28,8 +28,16 @@ type Interface interface {
 	ClusterServingRuntimes() ClusterServingRuntimeInformer
 	// InferenceGraphs returns a InferenceGraphInformer.
 	InferenceGraphs() InferenceGraphInformer
+	// InferenceServiceTemplates returns a InferenceServiceTemplateInformer.
+	InferenceServiceTemplates() InferenceServiceTemplateInformer
+	// KServeConfigs returns a KServeConfigInformer.
+	KServeConfigs() KServeConfigInformer
+	// LocalModelCaches returns a LocalModelCacheInformer.
+	LocalModelCaches() LocalModelCacheInformer
 	// ServingRuntimes returns a ServingRuntimeInformer.
 	ServingRuntimes() ServingRuntimeInformer
+	// TemplatedInferenceServices returns a TemplatedInferenceServiceInformer.
+	TemplatedInferenceServices() TemplatedInferenceServiceInformer
 	// TrainedModels returns a TrainedModelInformer.
 	TrainedModels() TrainedModelInformer
 }
@@ -55,11 +63,31 @@ func (v *version) InferenceGraphs() InferenceGraphInformer {
 	return &inferenceGraphInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// InferenceServiceTemplates returns a InferenceServiceTemplateInformer.
+func (v *version) InferenceServiceTemplates() InferenceServiceTemplateInformer {
+	return &inferenceServiceTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// KServeConfigs returns a KServeConfigInformer.
+func (v *version) KServeConfigs() KServeConfigInformer {
+	return &kServeConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// LocalModelCaches returns a LocalModelCacheInformer.
+func (v *version) LocalModelCaches() LocalModelCacheInformer {
+	return &localModelCacheInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ServingRuntimes returns a ServingRuntimeInformer.
 func (v *version) ServingRuntimes() ServingRuntimeInformer {
 	return &servingRuntimeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TemplatedInferenceServices returns a TemplatedInferenceServiceInformer.
+func (v *version) TemplatedInferenceServices() TemplatedInferenceServiceInformer {
+	return &templatedInferenceServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TrainedModels returns a TrainedModelInformer.
 func (v *version) TrainedModels() TrainedModelInformer {
 	return &trainedModelInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -34,6 +34,22 @@ type InferenceGraphListerExpansion interface{}
 // InferenceGraphNamespaceLister.
 type InferenceGraphNamespaceListerExpansion interface{}
 
+// InferenceServiceTemplateListerExpansion allows custom methods to be added to
+// InferenceServiceTemplateLister.
+type InferenceServiceTemplateListerExpansion interface{}
+
+// KServeConfigListerExpansion allows custom methods to be added to
+// KServeConfigLister.
+type KServeConfigListerExpansion interface{}
+
+// KServeConfigNamespaceListerExpansion allows custom methods to be added to
+// KServeConfigNamespaceLister.
+type KServeConfigNamespaceListerExpansion interface{}
+
+// LocalModelCacheListerExpansion allows custom methods to be added to
+// LocalModelCacheLister.
+type LocalModelCacheListerExpansion interface{}
+
 // ServingRuntimeListerExpansion allows custom methods to be added to
 // ServingRuntimeLister.
 type ServingRuntimeListerExpansion interface{}
@@ -42,6 +58,14 @@ type ServingRuntimeListerExpansion interface{}
 // ServingRuntimeNamespaceLister.
 type ServingRuntimeNamespaceListerExpansion interface{}
 
+// TemplatedInferenceServiceListerExpansion allows custom methods to be added to
+// TemplatedInferenceServiceLister.
+type TemplatedInferenceServiceListerExpansion interface{}
+
+// TemplatedInferenceServiceNamespaceListerExpansion allows custom methods to be added to
+// TemplatedInferenceServiceNamespaceLister.
+type TemplatedInferenceServiceNamespaceListerExpansion interface{}
+
 // TrainedModelListerExpansion allows custom methods to be added to
 // TrainedModelLister.
 type TrainedModelListerExpansion interface{}
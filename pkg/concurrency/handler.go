@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"net/http"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// inFlight is the number of requests being served by the replica, averaged over the replicas by the autoscaler
+var inFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: constants.AgentConcurrencyMetricName,
+	Help: "Number of requests in flight",
+})
+
+func init() {
+	prometheus.MustRegister(inFlight)
+}
+
+// ConcurrencyHandler reports the number of requests in flight, the raw deployments scaling on concurrency are
+// scaled on this metric like the Knative autoscaler scales on the concurrency reported by the queue proxy.
+type ConcurrencyHandler struct {
+	log  *zap.SugaredLogger
+	next http.Handler
+}
+
+func New(next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &ConcurrencyHandler{
+		log:  logger,
+		next: next,
+	}
+}
+
+func (h *ConcurrencyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	inFlight.Inc()
+	defer inFlight.Dec()
+	h.next.ServeHTTP(w, r)
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks that a model server implements the v1, v2 and OpenAI compatible protocols of the
+// InferenceServices, so that the images of custom runtimes can be validated before they are registered as
+// ServingRuntimes.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/pkg/errors"
+)
+
+// Protocols checked by the suite
+const (
+	ProtocolV1     = "v1"
+	ProtocolV2     = "v2"
+	ProtocolOpenAI = "openai"
+)
+
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultPrompt  = "Say hello."
+	// The model the unknown model checks send their requests to
+	unknownModelSuffix = "-conformance-unknown"
+)
+
+// CheckStatus is the outcome of a check
+type CheckStatus string
+
+const (
+	Passed  CheckStatus = "Passed"
+	Failed  CheckStatus = "Failed"
+	Skipped CheckStatus = "Skipped"
+)
+
+// Config configures the checks of the suite
+type Config struct {
+	// Name of the model served by the runtime
+	ModelName string
+	// Protocols checked, usually the protocol versions of the ServingRuntime
+	Protocols []string
+	// Instances of the v1 predict check, the check is skipped when empty
+	Instances []interface{}
+	// Request of the v2 infer check, generated from the inputs of the model metadata when nil
+	InferRequest *inference.InferRequest
+	// Prompt of the OpenAI chat completion checks, DefaultPrompt when empty
+	Prompt string
+	// Timeout of each check, DefaultTimeout when zero
+	Timeout time.Duration
+}
+
+// CheckResult is the result of a single check
+type CheckResult struct {
+	Protocol   string      `json:"protocol"`
+	Name       string      `json:"name"`
+	Status     CheckStatus `json:"status"`
+	Message    string      `json:"message,omitempty"`
+	DurationMs int64       `json:"durationMs"`
+}
+
+// skipError reports a check which cannot run with the given config, e.g. the v1 predict check without instances
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+func skip(format string, args ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Run runs the checks of the configured protocols against the model server of the client and returns the report.
+// The client should not retry the requests so that the error checks observe the first response.
+func Run(ctx context.Context, client *inference.Client, config Config) (*Report, error) {
+	if config.ModelName == "" {
+		return nil, fmt.Errorf("the model name is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Prompt == "" {
+		config.Prompt = DefaultPrompt
+	}
+	suite := &suite{client: client, config: config}
+	report := &Report{ModelName: config.ModelName}
+	for _, protocol := range config.Protocols {
+		checks, ok := map[string][]check{
+			ProtocolV1:     suite.v1Checks(),
+			ProtocolV2:     suite.v2Checks(),
+			ProtocolOpenAI: suite.openAIChecks(),
+		}[protocol]
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol %q, must be one of [%s, %s, %s]", protocol, ProtocolV1,
+				ProtocolV2, ProtocolOpenAI)
+		}
+		for _, c := range checks {
+			report.Results = append(report.Results, suite.runCheck(ctx, protocol, c))
+		}
+	}
+	return report, nil
+}
+
+type suite struct {
+	client *inference.Client
+	config Config
+}
+
+func (s *suite) runCheck(ctx context.Context, protocol string, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	start := time.Now()
+	err := c.run(ctx)
+	result := CheckResult{
+		Protocol:   protocol,
+		Name:       c.name,
+		Status:     Passed,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	var skipErr *skipError
+	if errors.As(err, &skipErr) {
+		result.Status = Skipped
+		result.Message = skipErr.reason
+	} else if err != nil {
+		result.Status = Failed
+		result.Message = err.Error()
+	}
+	return result
+}
+
+func (s *suite) v1Checks() []check {
+	model := s.config.ModelName
+	return []check{
+		{name: "model-health", run: func(ctx context.Context) error {
+			return expectReady(s.client.V1ModelReady(ctx, model))
+		}},
+		{name: "infer", run: func(ctx context.Context) error {
+			if len(s.config.Instances) == 0 {
+				return skip("no instances configured")
+			}
+			response, err := s.client.Predict(ctx, model, &inference.V1Request{Instances: s.config.Instances})
+			if err != nil {
+				return err
+			}
+			if len(response.Predictions) != len(s.config.Instances) {
+				return fmt.Errorf("expected %d predictions, got %d", len(s.config.Instances), len(response.Predictions))
+			}
+			return nil
+		}},
+		{name: "unknown-model", run: func(ctx context.Context) error {
+			_, err := s.client.Predict(ctx, model+unknownModelSuffix, &inference.V1Request{Instances: []interface{}{1}})
+			return expectStatus(err, http.StatusNotFound)
+		}},
+		{name: "invalid-request", run: func(ctx context.Context) error {
+			_, err := s.client.Predict(ctx, model, &inference.V1Request{})
+			return expectClientError(err)
+		}},
+	}
+}
+
+func (s *suite) v2Checks() []check {
+	model := s.config.ModelName
+	return []check{
+		{name: "server-live", run: func(ctx context.Context) error {
+			return expectReady(s.client.ServerLive(ctx))
+		}},
+		{name: "server-ready", run: func(ctx context.Context) error {
+			return expectReady(s.client.ServerReady(ctx))
+		}},
+		{name: "server-metadata", run: func(ctx context.Context) error {
+			metadata, err := s.client.ServerMetadata(ctx)
+			if err != nil {
+				return err
+			}
+			if metadata.Name == "" || metadata.Version == "" {
+				return fmt.Errorf("the server metadata must have a name and a version")
+			}
+			return nil
+		}},
+		{name: "model-ready", run: func(ctx context.Context) error {
+			return expectReady(s.client.ModelReady(ctx, model))
+		}},
+		{name: "model-metadata", run: func(ctx context.Context) error {
+			metadata, err := s.client.ModelMetadata(ctx, model)
+			if err != nil {
+				return err
+			}
+			if metadata.Name != model {
+				return fmt.Errorf("expected the metadata of the model %s, got %s", model, metadata.Name)
+			}
+			return nil
+		}},
+		{name: "infer", run: func(ctx context.Context) error {
+			request, err := s.inferRequest(ctx)
+			if err != nil {
+				return err
+			}
+			response, err := s.client.Infer(ctx, model, request)
+			if err != nil {
+				return err
+			}
+			if response.ModelName != model {
+				return fmt.Errorf("expected the response of the model %s, got %s", model, response.ModelName)
+			}
+			if len(response.Outputs) == 0 {
+				return fmt.Errorf("the response has no outputs")
+			}
+			return nil
+		}},
+		{name: "unknown-model", run: func(ctx context.Context) error {
+			_, err := s.client.Infer(ctx, model+unknownModelSuffix, &inference.InferRequest{
+				Inputs: []inference.Tensor{{Name: "input-0", Datatype: "FP32", Shape: []int64{1}, Data: []float32{0}}},
+			})
+			return expectStatus(err, http.StatusNotFound)
+		}},
+		{name: "invalid-request", run: func(ctx context.Context) error {
+			// The data does not match the shape of the tensor
+			_, err := s.client.Infer(ctx, model, &inference.InferRequest{
+				Inputs: []inference.Tensor{{Name: "input-0", Datatype: "FP32", Shape: []int64{2, 2}, Data: []float32{0}}},
+			})
+			return expectClientError(err)
+		}},
+	}
+}
+
+// inferRequest returns the configured request, or a request with zeros for the inputs of the model metadata
+func (s *suite) inferRequest(ctx context.Context) (*inference.InferRequest, error) {
+	if s.config.InferRequest != nil {
+		return s.config.InferRequest, nil
+	}
+	metadata, err := s.client.ModelMetadata(ctx, s.config.ModelName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the inputs from the model metadata")
+	}
+	if len(metadata.Inputs) == 0 {
+		return nil, skip("no inputs in the model metadata, an infer request must be configured")
+	}
+	request := &inference.InferRequest{}
+	for _, input := range metadata.Inputs {
+		shape := make([]int64, len(input.Shape))
+		size := 1
+		for i, dim := range input.Shape {
+			// Variable dimensions, e.g. the batch dimension, are sent with a single element
+			if dim < 1 {
+				dim = 1
+			}
+			shape[i] = dim
+			size *= int(dim)
+		}
+		data := make([]interface{}, size)
+		for i := range data {
+			data[i] = zeroValue(input.Datatype)
+		}
+		request.Inputs = append(request.Inputs, inference.Tensor{
+			Name:     input.Name,
+			Datatype: input.Datatype,
+			Shape:    shape,
+			Data:     data,
+		})
+	}
+	return request, nil
+}
+
+func zeroValue(datatype string) interface{} {
+	switch datatype {
+	case "BOOL":
+		return false
+	case "BYTES":
+		return ""
+	default:
+		return 0
+	}
+}
+
+func (s *suite) openAIChecks() []check {
+	maxTokens := 16
+	chatRequest := func() *inference.ChatCompletionRequest {
+		return &inference.ChatCompletionRequest{
+			Model:     s.config.ModelName,
+			Messages:  []inference.ChatMessage{{Role: "user", Content: s.config.Prompt}},
+			MaxTokens: &maxTokens,
+		}
+	}
+	return []check{
+		{name: "chat-completion", run: func(ctx context.Context) error {
+			response, err := s.client.ChatCompletion(ctx, chatRequest())
+			if err != nil {
+				return err
+			}
+			if len(response.Choices) == 0 || response.Choices[0].Message == nil {
+				return fmt.Errorf("the response has no message")
+			}
+			return nil
+		}},
+		{name: "chat-completion-stream", run: func(ctx context.Context) error {
+			stream, err := s.client.ChatCompletionStream(ctx, chatRequest())
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+			chunks := 0
+			for {
+				chunk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return errors.Wrap(err, "failed to read the stream")
+				}
+				if len(chunk.Choices) > 0 && chunk.Choices[0].Delta == nil {
+					return fmt.Errorf("the chunks of the stream must have a delta")
+				}
+				chunks++
+			}
+			if chunks == 0 {
+				return fmt.Errorf("the stream has no chunks")
+			}
+			return nil
+		}},
+		{name: "invalid-request", run: func(ctx context.Context) error {
+			request := chatRequest()
+			request.Messages = nil
+			_, err := s.client.ChatCompletion(ctx, request)
+			return expectClientError(err)
+		}},
+	}
+}
+
+func expectReady(ready bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return fmt.Errorf("not ready")
+	}
+	return nil
+}
+
+func expectStatus(err error, status int) error {
+	var statusErr *inference.StatusError
+	if !errors.As(err, &statusErr) {
+		return fmt.Errorf("expected the status code %d, got %v", status, describe(err))
+	}
+	if statusErr.StatusCode != status {
+		return fmt.Errorf("expected the status code %d, got %d", status, statusErr.StatusCode)
+	}
+	return nil
+}
+
+// expectClientError expects the server to reject the request with a 4xx status code rather than failing with 5xx
+func expectClientError(err error) error {
+	var statusErr *inference.StatusError
+	if !errors.As(err, &statusErr) {
+		return fmt.Errorf("expected a 4xx status code, got %v", describe(err))
+	}
+	if statusErr.StatusCode < 400 || statusErr.StatusCode >= 500 {
+		return fmt.Errorf("expected a 4xx status code, got %d", statusErr.StatusCode)
+	}
+	return nil
+}
+
+func describe(err error) string {
+	if err == nil {
+		return "a successful response"
+	}
+	return err.Error()
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/onsi/gomega"
+)
+
+// fakeRuntime serves the v1, v2 and OpenAI protocols for the model mymodel. When brokenErrors is set the invalid
+// requests fail with 500 instead of 400.
+func fakeRuntime(brokenErrors bool) http.Handler {
+	invalidStatus := http.StatusBadRequest
+	if brokenErrors {
+		invalidStatus = http.StatusInternalServerError
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		http.NotFound(rw, req)
+	})
+	mux.HandleFunc("/v1/models/mymodel", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"mymodel","ready":true}`))
+	})
+	mux.HandleFunc("/v1/models/mymodel:predict", func(rw http.ResponseWriter, req *http.Request) {
+		request := &inference.V1Request{}
+		json.NewDecoder(req.Body).Decode(request)
+		if len(request.Instances) == 0 {
+			rw.WriteHeader(invalidStatus)
+			return
+		}
+		predictions := make([]interface{}, len(request.Instances))
+		for i := range predictions {
+			predictions[i] = 1
+		}
+		json.NewEncoder(rw).Encode(&inference.V1Response{Predictions: predictions})
+	})
+	mux.HandleFunc("/v2/health/live", func(rw http.ResponseWriter, req *http.Request) {})
+	mux.HandleFunc("/v2/health/ready", func(rw http.ResponseWriter, req *http.Request) {})
+	mux.HandleFunc("/v2", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"fake","version":"1.0.0"}`))
+	})
+	mux.HandleFunc("/v2/models/mymodel/ready", func(rw http.ResponseWriter, req *http.Request) {})
+	mux.HandleFunc("/v2/models/mymodel", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"name":"mymodel","platform":"fake","inputs":[{"name":"input-0","datatype":"FP32","shape":[-1,2]}]}`))
+	})
+	mux.HandleFunc("/v2/models/mymodel/infer", func(rw http.ResponseWriter, req *http.Request) {
+		request := &inference.InferRequest{}
+		json.NewDecoder(req.Body).Decode(request)
+		for _, input := range request.Inputs {
+			size := int64(1)
+			for _, dim := range input.Shape {
+				size *= dim
+			}
+			if data, ok := input.Data.([]interface{}); !ok || int64(len(data)) != size {
+				rw.WriteHeader(invalidStatus)
+				return
+			}
+		}
+		rw.Write([]byte(`{"model_name":"mymodel","outputs":[{"name":"output-0","datatype":"INT64","shape":[1],"data":[1]}]}`))
+	})
+	mux.HandleFunc("/openai/v1/chat/completions", func(rw http.ResponseWriter, req *http.Request) {
+		request := &inference.ChatCompletionRequest{}
+		json.NewDecoder(req.Body).Decode(request)
+		if len(request.Messages) == 0 {
+			rw.WriteHeader(invalidStatus)
+			return
+		}
+		if request.Stream {
+			buf := &bytes.Buffer{}
+			for _, token := range []string{"Hello", "!"} {
+				fmt.Fprintf(buf, "data: {\"model\":\"mymodel\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":%q}}]}\n\n", token)
+			}
+			buf.WriteString("data: [DONE]\n\n")
+			rw.Write(buf.Bytes())
+			return
+		}
+		rw.Write([]byte(`{"model":"mymodel","choices":[{"index":0,"message":{"role":"assistant","content":"Hello!"}}]}`))
+	})
+	return mux
+}
+
+func TestRun(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		brokenErrors bool
+		config       Config
+		expectFailed []string
+		expectSkip   []string
+	}{
+		"Compatible": {
+			config: Config{
+				ModelName: "mymodel",
+				Protocols: []string{ProtocolV1, ProtocolV2, ProtocolOpenAI},
+				Instances: []interface{}{[]float32{1, 2}},
+			},
+		},
+		"SkipV1InferWithoutInstances": {
+			config: Config{
+				ModelName: "mymodel",
+				Protocols: []string{ProtocolV1},
+			},
+			expectSkip: []string{"v1/infer"},
+		},
+		"ServerErrorOnInvalidRequests": {
+			brokenErrors: true,
+			config: Config{
+				ModelName: "mymodel",
+				Protocols: []string{ProtocolV1, ProtocolV2, ProtocolOpenAI},
+				Instances: []interface{}{[]float32{1, 2}},
+			},
+			expectFailed: []string{"v1/invalid-request", "v2/invalid-request", "openai/invalid-request"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(fakeRuntime(scenario.brokenErrors))
+			defer server.Close()
+			client, err := inference.NewClient(server.URL, inference.WithRetries(0, 0, 0))
+			g.Expect(err).To(gomega.BeNil())
+
+			report, err := Run(context.TODO(), client, scenario.config)
+			g.Expect(err).To(gomega.BeNil())
+			var failed, skipped []string
+			for _, result := range report.Results {
+				switch result.Status {
+				case Failed:
+					failed = append(failed, result.Protocol+"/"+result.Name)
+				case Skipped:
+					skipped = append(skipped, result.Protocol+"/"+result.Name)
+				}
+			}
+			g.Expect(failed).To(gomega.ConsistOf(scenario.expectFailed))
+			g.Expect(skipped).To(gomega.ConsistOf(scenario.expectSkip))
+			g.Expect(report.Failed()).To(gomega.Equal(len(scenario.expectFailed) > 0))
+			for _, protocol := range scenario.config.Protocols {
+				g.Expect(report.Compatible(protocol)).To(gomega.Equal(len(scenario.expectFailed) == 0))
+			}
+		})
+	}
+}
+
+func TestRunUnsupportedProtocol(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client, err := inference.NewClient("http://localhost")
+	g.Expect(err).To(gomega.BeNil())
+	_, err = Run(context.TODO(), client, Config{ModelName: "mymodel", Protocols: []string{"grpc-v2"}})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported protocol \"grpc-v2\"")))
+}
+
+func TestWriteText(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report := &Report{
+		ModelName: "mymodel",
+		Results: []CheckResult{
+			{Protocol: ProtocolV2, Name: "server-live", Status: Passed},
+			{Protocol: ProtocolOpenAI, Name: "chat-completion", Status: Failed, Message: "not found"},
+		},
+	}
+	buf := &bytes.Buffer{}
+	g.Expect(report.WriteText(buf)).To(gomega.Succeed())
+	g.Expect(buf.String()).To(gomega.ContainSubstring("v2: compatible\n"))
+	g.Expect(buf.String()).To(gomega.ContainSubstring("openai: NOT compatible\n"))
+}
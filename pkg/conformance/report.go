@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Report is the compatibility report of a model server
+type Report struct {
+	URL       string        `json:"url"`
+	ModelName string        `json:"modelName"`
+	Results   []CheckResult `json:"results"`
+}
+
+// Failed returns true if any check failed
+func (r *Report) Failed() bool {
+	for _, result := range r.Results {
+		if result.Status == Failed {
+			return true
+		}
+	}
+	return false
+}
+
+// Compatible returns true if the protocol was checked and none of its checks failed
+func (r *Report) Compatible(protocol string) bool {
+	checked := false
+	for _, result := range r.Results {
+		if result.Protocol != protocol {
+			continue
+		}
+		if result.Status == Failed {
+			return false
+		}
+		checked = true
+	}
+	return checked
+}
+
+// Protocols returns the checked protocols in the order they were checked
+func (r *Report) Protocols() []string {
+	var protocols []string
+	seen := map[string]bool{}
+	for _, result := range r.Results {
+		if !seen[result.Protocol] {
+			seen[result.Protocol] = true
+			protocols = append(protocols, result.Protocol)
+		}
+	}
+	return protocols
+}
+
+// WriteText writes the results as a table followed by the compatibility of each protocol
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "PROTOCOL\tCHECK\tSTATUS\tDURATION\tMESSAGE\n")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%dms\t%s\n", result.Protocol, result.Name, result.Status, result.DurationMs,
+			result.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	for _, protocol := range r.Protocols() {
+		compatibility := "compatible"
+		if !r.Compatible(protocol) {
+			compatibility = "NOT compatible"
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", protocol, compatibility); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package constants
 
 import (
 	"fmt"
+	"mime"
 	"os"
 	"regexp"
 	"strings"
@@ -35,8 +36,14 @@ var (
 	KServeAPIGroupName             = "serving.kserve.io"
 	KnativeAutoscalingAPIGroupName = "autoscaling.knative.dev"
 	KnativeServingAPIGroupName     = "serving.knative.dev"
+	KedaAPIGroupName               = "keda.sh"
+	KedaHTTPAPIGroupName           = "http.keda.sh"
+	PrometheusOperatorAPIGroupName = "monitoring.coreos.com"
 	KServeNamespace                = getEnvOrDefault("POD_NAMESPACE", "kserve")
 	KServeDefaultVersion           = "v0.5.0"
+	// KedaHTTPInterceptorHost is the host of the service forwarding the port 80 to the proxy of the KEDA HTTP add-on
+	// interceptor, the requests of the components scaled to zero are held by the interceptor until they are scaled up
+	KedaHTTPInterceptorHost = getEnvOrDefault("KEDA_HTTP_INTERCEPTOR_HOST", "keda-add-ons-http-interceptor-kserve.keda.svc."+network.GetClusterDomainName())
 )
 
 // InferenceService Constants
@@ -50,6 +57,13 @@ var (
 // InferenceGraph Constants
 const (
 	RouterHeadersPropagateEnvVar = "PROPAGATE_HEADERS"
+	RouterTokenPathEnvVar        = "SERVICE_ACCOUNT_TOKEN_PATH"
+	RouterTokenVolumeName        = "kserve-service-account-token"
+	RouterTokenMountPath         = "/var/run/secrets/kserve/serviceaccount"
+	RouterTokenFileName          = "token"
+	RouterTokenExpirationSeconds = 3600
+	RouterPort                   = "8080"
+	RouterCircuitBreakersPath    = "/circuit-breakers"
 )
 
 // TrainedModel Constants
@@ -64,11 +78,61 @@ var (
 
 // Model agent Constants
 const (
-	AgentContainerName    = "agent"
-	AgentConfigMapKeyName = "agent"
-	AgentEnableFlag       = "--enable-puller"
-	AgentConfigDirArgName = "--config-dir"
-	AgentModelDirArgName  = "--model-dir"
+	AgentContainerName           = "agent"
+	AgentConfigMapKeyName        = "agent"
+	AgentEnableFlag              = "--enable-puller"
+	AgentConfigDirArgName        = "--config-dir"
+	AgentModelDirArgName         = "--model-dir"
+	AgentCacheStorageURIArgName  = "--cache-storage-uri"
+	AgentCacheMaxAgeArgName      = "--cache-max-age"
+	AgentReadinessPolicyArgName  = "--readiness-policy"
+	AgentMinModelsArgName        = "--readiness-min-models"
+	AgentEnableOpenAPIFlag       = "--enable-openapi"
+	AgentModelNameArgName        = "--model-name"
+	AgentTokenAudienceArgName    = "--token-audience"
+	AgentTLSMinVersionArgName    = "--tls-min-version"
+	AgentTLSCipherSuitesArgName  = "--tls-cipher-suites"
+	AgentAllowedHostsArgName     = "--allowed-hosts"
+	AgentMetricsPortArgName      = "--metrics-port"
+	AgentDeprecationArgName      = "--deprecation"
+	AgentSunsetArgName           = "--sunset"
+	AgentSunsetRejectArgName     = "--sunset-reject-percentage"
+	AgentDeprecationLinkArgName  = "--deprecation-link"
+	AgentAPIKeysDirArgName       = "--api-keys-dir"
+	AgentTenantHeaderArgName     = "--tenant-header"
+	AgentModelNamesArgName       = "--model-names"
+	AgentRateLimitRPSArgName     = "--rate-limit-rps"
+	AgentRateLimitBurstArgName   = "--rate-limit-burst"
+	AgentFailureThresholdArgName = "--circuit-breaker-failure-threshold"
+	AgentOpenDurationArgName     = "--circuit-breaker-open-duration"
+	AgentRetryBudgetArgName      = "--retry-budget-percentage"
+	AgentConcurrencyMetricsFlag  = "--enable-concurrency-metrics"
+	AgentEnableGrpcFlag          = "--enable-grpc"
+	AgentPredictionSinkArgName   = "--prediction-sink-url"
+	AgentBufferDirArgName        = "--prediction-buffer-dir"
+	AgentBufferSizeArgName       = "--prediction-buffer-size"
+	AgentPayloadPrefixesArgName  = "--payload-uri-prefixes"
+	AgentPayloadMaxSizeArgName   = "--payload-max-size"
+	AgentPayloadResponseArgName  = "--payload-response-uri"
+	AgentEnableAsyncFlag         = "--enable-async"
+	AgentAsyncWorkersArgName     = "--async-workers"
+	AgentAsyncQueueSizeArgName   = "--async-queue-size"
+	AgentAsyncResultTTLArgName   = "--async-result-ttl"
+	AgentAsyncCallbackArgName    = "--async-callback-url"
+	AgentAsyncBrokerArgName      = "--async-broker-url"
+	AgentLogRetryDirArgName      = "--log-retry-dir"
+	AgentLogRetrySizeArgName     = "--log-retry-buffer-size"
+	AgentLogRetryMaxAgeArgName   = "--log-retry-max-age"
+	AgentDeadLetterURIArgName    = "--log-dead-letter-uri"
+	AgentLogBlobBatchSizeArgName = "--log-blob-batch-size"
+	AgentLogBlobFlushArgName     = "--log-blob-flush-interval"
+	AgentConfigFileArgName       = "--agent-config-file"
+	AgentPipelineOrderArgName    = "--pipeline-order"
+	AgentMetricsPortStr          = "9082"
+	AgentMetricsPort             = 9082
+
+	// AgentConcurrencyMetricName is the gauge of the in-flight requests of the agent the raw deployments scale on
+	AgentConcurrencyMetricName = "kserve_agent_concurrent_requests"
 )
 
 // InferenceService Annotations
@@ -78,6 +142,7 @@ var (
 	EnableRoutingTagAnnotationKey               = KServeAPIGroupName + "/enable-tag-routing"
 	AutoscalerClass                             = KServeAPIGroupName + "/autoscalerClass"
 	AutoscalerMetrics                           = KServeAPIGroupName + "/metrics"
+	KedaTriggersAnnotationKey                   = KServeAPIGroupName + "/keda-triggers"
 	TargetUtilizationPercentage                 = KServeAPIGroupName + "/targetUtilizationPercentage"
 	MinScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/min-scale"
 	MaxScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/max-scale"
@@ -91,6 +156,110 @@ var (
 	DefaultPrometheusPath                       = "/metrics"
 	QueueProxyAggregatePrometheusMetricsPort    = "9088"
 	DefaultPodPrometheusPort                    = "9090"
+	EnableOpenAPIAnnotationKey                  = KServeAPIGroupName + "/enable-openapi"
+	OpenAPIModelNameAnnotationKey               = KServeAPIGroupName + "/openapi-model-name"
+	TokenAudienceAnnotationKey                  = KServeAPIGroupName + "/token-audience"
+	LoggerEncryptionKeySecretAnnotationKey      = KServeAPIGroupName + "/logger-encryption-key-secret"
+	LoggerEncryptionKMSKeyAnnotationKey         = KServeAPIGroupName + "/logger-encryption-kms-key"
+	NodeOSAnnotationKey                         = KServeAPIGroupName + "/node-os"
+	TTLAnnotationKey                            = KServeAPIGroupName + "/ttl"
+	TTLFromAnnotationKey                        = KServeAPIGroupName + "/ttl-from"
+	TTLActionAnnotationKey                      = KServeAPIGroupName + "/ttl-action"
+	TTLStartTimeAnnotationKey                   = KServeAPIGroupName + "/ttl-start-time"
+	TTLWarnedAnnotationKey                      = KServeAPIGroupName + "/ttl-warned"
+	TTLExpiredAnnotationKey                     = KServeAPIGroupName + "/ttl-expired"
+	SuspendedAnnotationKey                      = KServeAPIGroupName + "/suspended"
+	CreateServiceAccountAnnotationKey           = KServeAPIGroupName + "/create-service-account"
+	ServiceAccountSecretsAnnotationKey          = KServeAPIGroupName + "/service-account-secrets"
+	EnforceAllowedHostsAnnotationKey            = KServeAPIGroupName + "/enforce-allowed-hosts"
+	AdditionalAllowedHostsAnnotationKey         = KServeAPIGroupName + "/additional-allowed-hosts"
+	APIKeySecretAnnotationKey                   = KServeAPIGroupName + "/api-key-secret"
+	TenantHeaderAnnotationKey                   = KServeAPIGroupName + "/tenant-header"
+	ModelNamesAnnotationKey                     = KServeAPIGroupName + "/model-names"
+	UsageReportIntervalAnnotationKey            = KServeAPIGroupName + "/usage-report-interval"
+	KeepWarmIntervalAnnotationKey               = KServeAPIGroupName + "/keep-warm-interval"
+	KeepWarmScheduleAnnotationKey               = KServeAPIGroupName + "/keep-warm-schedule"
+	KeepWarmWindowAnnotationKey                 = KServeAPIGroupName + "/keep-warm-window"
+	DeprecationAnnotationKey                    = KServeAPIGroupName + "/deprecation"
+	SunsetAnnotationKey                         = KServeAPIGroupName + "/sunset"
+	SunsetRejectPercentageAnnotationKey         = KServeAPIGroupName + "/sunset-reject-percentage"
+	DeprecationLinkAnnotationKey                = KServeAPIGroupName + "/deprecation-link"
+	CircuitBreakerFailureThresholdAnnotationKey = KServeAPIGroupName + "/circuit-breaker-failure-threshold"
+	CircuitBreakerOpenDurationAnnotationKey     = KServeAPIGroupName + "/circuit-breaker-open-duration"
+	RetryBudgetPercentageAnnotationKey          = KServeAPIGroupName + "/retry-budget-percentage"
+	OOMMemoryFactorAnnotationKey                = KServeAPIGroupName + "/oom-memory-factor"
+	OOMMaxMemoryAnnotationKey                   = KServeAPIGroupName + "/oom-max-memory"
+	ModelDigestAnnotationKey                    = KServeAPIGroupName + "/model-digest"
+	ModelRefreshDigestAnnotationKey             = KServeAPIGroupName + "/model-refresh-digest"
+	ModelRefreshTimeAnnotationKey               = KServeAPIGroupName + "/model-refresh-time"
+	PredictionSinkURLAnnotationKey              = KServeAPIGroupName + "/prediction-sink-url"
+	PredictionBufferSizeAnnotationKey           = KServeAPIGroupName + "/prediction-buffer-size"
+	LoggerRetryMaxAgeAnnotationKey              = KServeAPIGroupName + "/logger-retry-max-age"
+	LoggerRetryBufferSizeAnnotationKey          = KServeAPIGroupName + "/logger-retry-buffer-size"
+	LoggerDeadLetterURIAnnotationKey            = KServeAPIGroupName + "/logger-dead-letter-uri"
+	LoggerBlobBatchSizeAnnotationKey            = KServeAPIGroupName + "/logger-blob-batch-size"
+	LoggerBlobFlushIntervalAnnotationKey        = KServeAPIGroupName + "/logger-blob-flush-interval"
+	LoggerEncodingAnnotationKey                 = KServeAPIGroupName + "/logger-encoding"
+	LoggerEventTypeAnnotationKey                = KServeAPIGroupName + "/logger-event-type"
+	LoggerEventSourceAnnotationKey              = KServeAPIGroupName + "/logger-event-source"
+	LoggerSchemaVersionAnnotationKey            = KServeAPIGroupName + "/logger-schema-version"
+	LoggerTransformURLAnnotationKey             = KServeAPIGroupName + "/logger-transform-url"
+	LoggerHashFieldsAnnotationKey               = KServeAPIGroupName + "/logger-hash-fields"
+	LoggerHashKeySecretAnnotationKey            = KServeAPIGroupName + "/logger-hash-key-secret"
+	AgentHotReloadAnnotationKey                 = KServeAPIGroupName + "/agent-hot-reload"
+	AgentPipelineOrderAnnotationKey             = KServeAPIGroupName + "/agent-pipeline-order"
+	ModelReadinessPolicyAnnotationKey           = KServeAPIGroupName + "/model-readiness-policy"
+	ModelReadinessMinModelsAnnotationKey        = KServeAPIGroupName + "/model-readiness-min-models"
+	NamespaceLoggerDefaultsAnnotationKey        = KServeAPIGroupName + "/logger-defaults"
+	NamespaceBatcherDefaultsAnnotationKey       = KServeAPIGroupName + "/batcher-defaults"
+	EnableRevisionRoutesAnnotationKey           = KServeAPIGroupName + "/enable-revision-routes"
+	PayloadURIPrefixesAnnotationKey             = KServeAPIGroupName + "/payload-uri-prefixes"
+	PayloadMaxSizeAnnotationKey                 = KServeAPIGroupName + "/payload-max-size"
+	PayloadResponseURIAnnotationKey             = KServeAPIGroupName + "/payload-response-uri"
+	IngressGatewayAnnotationKey                 = KServeAPIGroupName + "/ingress-gateway"
+	CorsAllowOriginsAnnotationKey               = KServeAPIGroupName + "/cors-allow-origins"
+	CanaryHeaderAnnotationKey                   = KServeAPIGroupName + "/canary-header"
+	FallbackAnnotationKey                       = KServeAPIGroupName + "/fallback-inference-service"
+	FaultDelayAnnotationKey                     = KServeAPIGroupName + "/fault-delay"
+	FaultDelayPercentageAnnotationKey           = KServeAPIGroupName + "/fault-delay-percentage"
+	FaultAbortStatusAnnotationKey               = KServeAPIGroupName + "/fault-abort-status"
+	FaultAbortPercentageAnnotationKey           = KServeAPIGroupName + "/fault-abort-percentage"
+	FaultTTLAnnotationKey                       = KServeAPIGroupName + "/fault-ttl"
+	FaultExpiryAnnotationKey                    = KServeAPIGroupName + "/fault-expiry"
+	MaintenanceAnnotationKey                    = KServeAPIGroupName + "/maintenance"
+	MaintenanceBodyAnnotationKey                = KServeAPIGroupName + "/maintenance-body"
+	MaintenanceRetryAfterAnnotationKey          = KServeAPIGroupName + "/maintenance-retry-after"
+	EnableAsyncAnnotationKey                    = KServeAPIGroupName + "/enable-async"
+	AsyncWorkersAnnotationKey                   = KServeAPIGroupName + "/async-workers"
+	AsyncQueueSizeAnnotationKey                 = KServeAPIGroupName + "/async-queue-size"
+	AsyncResultTTLAnnotationKey                 = KServeAPIGroupName + "/async-result-ttl"
+	AsyncCallbackURLAnnotationKey               = KServeAPIGroupName + "/async-callback-url"
+	AsyncBrokerURLAnnotationKey                 = KServeAPIGroupName + "/async-broker-url"
+	HuggingFaceAllowPatternsAnnotationKey       = KServeAPIGroupName + "/hf-allow-patterns"
+	HuggingFaceIgnorePatternsAnnotationKey      = KServeAPIGroupName + "/hf-ignore-patterns"
+	// StorageDownloadWorkersAnnotationKey overrides the number of concurrent downloads of the storage initializer
+	StorageDownloadWorkersAnnotationKey = KServeAPIGroupName + "/storage-download-workers"
+	// ExplainerColocationAnnotationKey runs the explainer as a sidecar of the predictor pods when set to true
+	ExplainerColocationAnnotationKey = KServeAPIGroupName + "/explainer-colocation"
+	// TransformerReplicaRatioAnnotationKey scales the transformer with the predictor replicas at a ratio, e.g. 2:1 runs
+	// two transformer replicas per predictor replica
+	TransformerReplicaRatioAnnotationKey = KServeAPIGroupName + "/transformer-replica-ratio"
+	// PromoteToAnnotationKey requests the promotion of the InferenceService to another namespace, given as
+	// <namespace> or <namespace>/<name>
+	PromoteToAnnotationKey = KServeAPIGroupName + "/promote-to"
+	// PromoteMappingAnnotationKey maps the service accounts, secrets, storage keys and uris, runtimes and runtime
+	// classes of the source namespace to the ones of the target namespace, given as json
+	PromoteMappingAnnotationKey = KServeAPIGroupName + "/promote-mapping"
+	// PromoteSourceNamespacesAnnotationKey is set on the target namespaces with the comma separated namespaces whose
+	// InferenceServices may be promoted to it, or *
+	PromoteSourceNamespacesAnnotationKey = KServeAPIGroupName + "/promote-source-namespaces"
+	// The lineage of the promoted InferenceServices, the source records the last promotion
+	PromotedFromAnnotationKey       = KServeAPIGroupName + "/promoted-from"
+	PromotedGenerationAnnotationKey = KServeAPIGroupName + "/promoted-generation"
+	PromotedTimeAnnotationKey       = KServeAPIGroupName + "/promoted-time"
+	PromotedToAnnotationKey         = KServeAPIGroupName + "/promoted-to"
+	// AppliedOverlayAnnotationKey records the deployment stage whose overlay the defaulter applied to the spec
+	AppliedOverlayAnnotationKey = KServeAPIGroupName + "/applied-overlay"
 )
 
 // InferenceService Internal Annotations
@@ -103,16 +272,42 @@ var (
 	LoggerInternalAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/logger"
 	LoggerSinkUrlInternalAnnotationKey               = InferenceServiceInternalAnnotationsPrefix + "/logger-sink-url"
 	LoggerModeInternalAnnotationKey                  = InferenceServiceInternalAnnotationsPrefix + "/logger-mode"
+	LoggerDeliveryInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/logger-delivery"
+	LoggerSamplingPercentInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/logger-sampling-percent"
+	LoggerOptOutHeaderInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/logger-opt-out-header"
+	LoggerResponseCodesInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/logger-response-codes"
+	LoggerRedactInternalAnnotationKey                = InferenceServiceInternalAnnotationsPrefix + "/logger-redact"
 	BatcherInternalAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/batcher"
 	BatcherMaxBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-batchsize"
 	BatcherMaxLatencyInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-latency"
 	BatcherTimeoutInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/batcher-timeout"
+	BatcherTargetP99LatencyInternalAnnotationKey     = InferenceServiceInternalAnnotationsPrefix + "/batcher-target-p99-latency"
+	BatcherPriorityHeaderInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/batcher-priority-header"
+	RateLimitRPSInternalAnnotationKey                = InferenceServiceInternalAnnotationsPrefix + "/rate-limit-rps"
+	RateLimitBurstInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/rate-limit-burst"
+	ConcurrencyMetricsInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/concurrency-metrics"
+	AgentConfigInternalAnnotationKey                 = InferenceServiceInternalAnnotationsPrefix + "/agent-config"
 	AgentShouldInjectAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/agent"
 	AgentModelConfigVolumeNameAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/configVolumeName"
 	AgentModelConfigMountPathAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/configMountPath"
 	AgentModelDirAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/modelDir"
 	PredictorHostAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/predictor-host"
 	PredictorProtocolAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/predictor-protocol"
+	// LocalModelCacheInternalAnnotationKey records the LocalModelCache the pod is served from
+	LocalModelCacheInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/local-model-cache"
+	// CloudIdentityInternalAnnotationKey mounts the cloud credentials of the service account in the kserve-container
+	CloudIdentityInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/mount-cloud-identity"
+)
+
+// LocalModelCache Constants
+const (
+	// LocalModelCachePath is the host directory the local model agents download the models of the LocalModelCaches to,
+	// each model is cached in the sub directory named after its LocalModelCache
+	LocalModelCachePath = "/mnt/models-cache"
+	// LocalModelCacheVolumeName is the name of the host path volume mounting the cached model in the predictor pods
+	LocalModelCacheVolumeName = "kserve-local-model-cache"
+	// LocalModelAgentNodeNameEnvVarKey is the env variable the node name is passed to the local model agent with
+	LocalModelAgentNodeNameEnvVarKey = "NODE_NAME"
 )
 
 // StorageSpec Constants
@@ -143,7 +338,10 @@ var (
 
 // Autoscaler Class
 var (
-	AutoscalerClassHPA AutoscalerClassType = "hpa"
+	AutoscalerClassHPA  AutoscalerClassType = "hpa"
+	AutoscalerClassKEDA AutoscalerClassType = "keda"
+	// AutoscalerClassKEDAHTTP scales the raw deployments to zero with the KEDA HTTP add-on
+	AutoscalerClassKEDAHTTP AutoscalerClassType = "keda-http"
 )
 
 // Autoscaler Metrics
@@ -156,15 +354,31 @@ var (
 	AutoScalerMetricsMemory AutoscalerMetricsType = "memory"
 )
 
+// Autoscaler Concurrency metrics
+var (
+	AutoScalerMetricsConcurrency AutoscalerMetricsType = "concurrency"
+)
+
+// Autoscaler GPU metrics
+var (
+	AutoScalerMetricsGPU       AutoscalerMetricsType = "gpu"
+	AutoScalerMetricsGPUMemory AutoscalerMetricsType = "gpu-memory"
+)
+
 // Autoscaler Class Allowed List
 var AutoscalerAllowedClassList = []AutoscalerClassType{
 	AutoscalerClassHPA,
+	AutoscalerClassKEDA,
+	AutoscalerClassKEDAHTTP,
 }
 
 // Autoscaler Metrics Allowed List
 var AutoscalerAllowedMetricsList = []AutoscalerMetricsType{
 	AutoScalerMetricsCPU,
 	AutoScalerMetricsMemory,
+	AutoScalerMetricsConcurrency,
+	AutoScalerMetricsGPU,
+	AutoScalerMetricsGPUMemory,
 }
 
 // Autoscaler KPA Metrics Allowed List
@@ -175,17 +389,26 @@ var AutoScalerKPAMetricsAllowedList = []AutoScalerKPAMetricsType{
 
 // Autoscaler Default Metrics Value
 var (
-	DefaultCPUUtilization int32 = 80
+	DefaultCPUUtilization    int32 = 80
+	DefaultConcurrencyTarget int32 = 100
+	DefaultGPUUtilization    int32 = 80
 )
 
 // Webhook Constants
 var (
-	PodMutatorWebhookName = KServeName + "-pod-mutator-webhook"
+	PodMutatorWebhookName      = KServeName + "-pod-mutator-webhook"
+	PolicyValidatorWebhookName = KServeName + "-policy-validator-webhook"
 )
 
 // GPU Constants
 const (
 	NvidiaGPUResourceType = "nvidia.com/gpu"
+
+	// GPUUtilizationMetricName is the GPU utilization percentage reported by the NVIDIA DCGM exporter
+	GPUUtilizationMetricName = "DCGM_FI_DEV_GPU_UTIL"
+	// GPUMemoryUtilizationMetricName is the percentage of the GPU framebuffer in use, derived from the
+	// DCGM_FI_DEV_FB_USED and DCGM_FI_DEV_FB_FREE metrics of the DCGM exporter by the Prometheus adapter
+	GPUMemoryUtilizationMetricName = "kserve_gpu_memory_utilization"
 )
 
 // InferenceService Environment Variables
@@ -196,6 +419,15 @@ const (
 	KServeContainerPrometheusMetricsPortEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PORT"
 	KServeContainerPrometheusMetricsPathEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PATH"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
+	// The comma separated file patterns the storage initializer downloads from or skips in a Hugging Face Hub repo
+	HuggingFaceAllowPatternsEnvVarKey  = "HF_ALLOW_PATTERNS"
+	HuggingFaceIgnorePatternsEnvVarKey = "HF_IGNORE_PATTERNS"
+	// The number of concurrent file and ranged part downloads of the storage initializer
+	StorageDownloadWorkersEnvVarKey = "STORAGE_DOWNLOAD_WORKERS"
+	// The presign broker the storage initializer fetches the presigned urls of the model files from, and the directory
+	// holding its client certificate and service account token
+	StoragePresignBrokerUrlEnvVarKey = "STORAGE_PRESIGN_BROKER_URL"
+	StoragePresignBrokerDirEnvVarKey = "STORAGE_PRESIGN_BROKER_DIR"
 )
 
 type InferenceServiceComponent string
@@ -237,12 +469,47 @@ const (
 	ProtocolUnknown InferenceServiceProtocol = ""
 )
 
+// Content types of the inference payloads over REST, the v2 payloads can be encoded with protobuf
+const (
+	JSONContentType     = "application/json"
+	ProtobufContentType = "application/x-protobuf"
+	GrpcContentType     = "application/grpc"
+	// The streamed responses, e.g. the tokens of the LLMs, are sent as Server-Sent Events or newline delimited JSON
+	EventStreamContentType = "text/event-stream"
+	NDJSONContentType      = "application/x-ndjson"
+)
+
+// GrpcHostPrefix prefixes the hosts of the InferenceServices to publish their gRPC routes on a distinct subdomain
+const GrpcHostPrefix = "grpc-"
+
+// TLS of the raw deployment ingresses, the certificates are issued by cert-manager
+const (
+	IngressTlsSecretSuffix          = "-tls"
+	NginxForceSslRedirectAnnotation = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+	CertManagerClusterIssuerKind    = "ClusterIssuer"
+	CertManagerGroup                = "cert-manager.io"
+)
+
+// PodMonitors of the Prometheus operator scraping the metrics of the InferenceServices
+const (
+	PodMonitorScopeInferenceService = "InferenceService"
+	PodMonitorScopeNamespace        = "Namespace"
+	// NamespacePodMonitorName is the name of the PodMonitor of the namespace scope
+	NamespacePodMonitorName = "kserve-inferenceservices"
+	AgentMetricsPortName    = "agent-metrics"
+)
+
 // InferenceService Endpoint Ports
 const (
 	InferenceServiceDefaultHttpPort     = "8080"
 	InferenceServiceDefaultAgentPortStr = "9081"
 	InferenceServiceDefaultAgentPort    = 9081
 	CommonDefaultHttpPort               = 80
+	// ColocatedExplainerPort is the port of the explainer sidecar of the predictor pods
+	ColocatedExplainerPort    = 8083
+	ColocatedExplainerPortStr = "8083"
+	// GrpcPortName is the name of the container port Knative serves gRPC on
+	GrpcPortName = "h2c"
 )
 
 // Labels to put on kservice
@@ -258,6 +525,13 @@ const (
 	InferenceServiceLabel       = "serving.kserve.io/inferenceservice"
 )
 
+// Labels of the namespaces
+var (
+	// DeploymentStageLabelKey gives the deployment stage of the namespace, e.g. production, whose overlay is applied to
+	// the InferenceServices of the namespace
+	DeploymentStageLabelKey = KServeAPIGroupName + "/deployment-stage"
+)
+
 // InferenceService default/canary constants
 const (
 	InferenceServiceDefault = "default"
@@ -278,6 +552,8 @@ const (
 const (
 	InferenceServiceContainerName   = "kserve-container"
 	StorageInitializerContainerName = "storage-initializer"
+	// ExplainerContainerName is the name of the explainer sidecar of the predictor pods
+	ExplainerContainerName = "kserve-explainer"
 )
 
 // DefaultModelLocalMountPath is where models will be mounted by the storage-initializer
@@ -291,13 +567,148 @@ const (
 	ModelDir              = DefaultModelLocalMountPath
 )
 
+// Model load states, the agent reports the states of the models of the model config polled from the repository index
+// of the model server at the model status path
+const (
+	AgentModelStatusPath  = "/agent/models"
+	ModelStateReady       = "READY"
+	ModelStateLoading     = "LOADING"
+	ModelStateUnavailable = "UNAVAILABLE"
+)
+
+// Model readiness policies, when the multi-model server is ready while the models load
+const (
+	ModelReadinessAlways        = "always"
+	ModelReadinessMinModels     = "min-models"
+	ModelReadinessInitialModels = "initial-models"
+)
+
+// Agent pipeline orders, the logger logs the requests before they are batched or the batched requests
+const (
+	AgentPipelineLogBatch = "log-batch"
+	AgentPipelineBatchLog = "batch-log"
+)
+
+// Agent config, the logger and batcher configuration the agent reloads without a restart
+const (
+	AgentConfigVolumeName = "agent-config"
+	AgentConfigDir        = "/mnt/agent-config"
+)
+
+// Model conversion
+const (
+	PvcURIPrefix                  = "pvc://"
+	ConversionJobNamePrefix       = "model-conversion-"
+	ConversionOutputVolumeName    = "converted-model"
+	ConversionOutputMountPath     = "/mnt/converted"
+	ConversionPollIntervalSeconds = 10
+	ConversionHashLength          = 16
+	ConversionJobBackoffLimit     = 2
+)
+
+// Model refresh
+const (
+	ModelRefreshJobNameSuffix   = "-model-refresh"
+	ModelRefreshJobBackoffLimit = 2
+	// ModelRefreshDigestCommand hashes the paths and the content of the files of the downloaded model
+	ModelRefreshDigestCommand = "cd " + DefaultModelLocalMountPath + " && find . -type f -print0 | LC_ALL=C sort -z | " +
+		"xargs -0 -r sha256sum | sha256sum | cut -d ' ' -f 1 > /dev/termination-log"
+)
+
+// Graph optimization, mapped to the TensorRT execution provider of ONNX Runtime
+const (
+	ORTTensorRTFP16EnableEnvVarKey       = "ORT_TENSORRT_FP16_ENABLE"
+	ORTTensorRTINT8EnableEnvVarKey       = "ORT_TENSORRT_INT8_ENABLE"
+	ORTTensorRTMaxWorkspaceSizeEnvVarKey = "ORT_TENSORRT_MAX_WORKSPACE_SIZE"
+	ORTTensorRTProfileMinShapesEnvVarKey = "ORT_TENSORRT_PROFILE_MIN_SHAPES"
+	ORTTensorRTProfileOptShapesEnvVarKey = "ORT_TENSORRT_PROFILE_OPT_SHAPES"
+	ORTTensorRTProfileMaxShapesEnvVarKey = "ORT_TENSORRT_PROFILE_MAX_SHAPES"
+	ORTTensorRTEngineCacheEnvVarKey      = "ORT_TENSORRT_ENGINE_CACHE_ENABLE"
+)
+
+// Logger payload encryption
+const (
+	AWSKMSKeyPrefix               = "aws-kms://"
+	LoggerEncryptionKeyVolumeName = "logger-encryption-key"
+	LoggerEncryptionKeyDir        = "/mnt/logger-encryption"
+	LoggerEncryptionKeySecretKey  = "key"
+	LoggerHashKeyVolumeName       = "logger-hash-key"
+	LoggerHashKeyDir              = "/mnt/logger-hash-key"
+	LoggerHashKeySecretKey        = "key"
+	APIKeysVolumeName             = "api-keys"
+	APIKeysDir                    = "/mnt/api-keys"
+)
+
+// Storage presign broker, the storage initializer authenticates to it with the client certificate of a secret and a
+// service account token bound to the audience of the broker
+const (
+	StoragePresignBrokerVolumeName             = "kserve-presign-broker"
+	StoragePresignBrokerDir                    = "/var/run/secrets/kserve/presign-broker"
+	StoragePresignBrokerTokenFileName          = "token"
+	StoragePresignBrokerTokenExpirationSeconds = 600
+	DefaultStoragePresignBrokerAudience        = "kserve-presign-broker"
+)
+
+// Prediction sink, the predictions are buffered on disk by the agent until the sink acknowledged them
+const (
+	PredictionBufferVolumeName  = "prediction-buffer"
+	PredictionBufferDir         = "/mnt/prediction-buffer"
+	DefaultPredictionBufferSize = "1Gi"
+)
+
+// Logger event format, the CloudEvents are sent in binary mode by default
+const (
+	LoggerBinaryEncoding     = "binary"
+	LoggerStructuredEncoding = "structured"
+)
+
+// Logger retry queue, the log events the logger sink did not accept are queued on disk by the agent
+const (
+	LoggerRetryVolumeName        = "logger-retry"
+	LoggerRetryDir               = "/mnt/logger-retry"
+	DefaultLoggerRetryBufferSize = "1Gi"
+)
+
+// TTL of the InferenceServices, measured from their creation or from their last request, and what happens on its expiry
+const (
+	TTLFromCreation    = "creation"
+	TTLFromLastRequest = "last-request"
+	TTLActionDelete    = "delete"
+	TTLActionSuspend   = "suspend"
+)
+
 var (
-	ServiceAnnotationDisallowedList = []string{
+	// TTLStateAnnotationKeys are the annotations the janitor records the TTL of the InferenceServices in
+	TTLStateAnnotationKeys = []string{
+		TTLStartTimeAnnotationKey,
+		TTLWarnedAnnotationKey,
+		TTLExpiredAnnotationKey,
+	}
+
+	// FaultAnnotationKeys are the annotations of the fault injection, they are removed together when the faults expire
+	FaultAnnotationKeys = []string{
+		FaultDelayAnnotationKey,
+		FaultDelayPercentageAnnotationKey,
+		FaultAbortStatusAnnotationKey,
+		FaultAbortPercentageAnnotationKey,
+		FaultTTLAnnotationKey,
+		FaultExpiryAnnotationKey,
+	}
+
+	// The faults are injected by the virtual service, the maintenance mode is reloaded by the agents and the TTL state
+	// is recorded by the janitor, their annotations must not roll out new revisions
+	ServiceAnnotationDisallowedList = append(append([]string{
 		autoscaling.MinScaleAnnotationKey,
 		autoscaling.MaxScaleAnnotationKey,
 		StorageInitializerSourceUriInternalAnnotationKey,
+		ModelRefreshDigestAnnotationKey,
+		ModelRefreshTimeAnnotationKey,
 		"kubectl.kubernetes.io/last-applied-configuration",
-	}
+		MaintenanceAnnotationKey,
+		MaintenanceBodyAnnotationKey,
+		MaintenanceRetryAfterAnnotationKey,
+		SuspendedAnnotationKey,
+	}, FaultAnnotationKeys...), TTLStateAnnotationKeys...)
 
 	RevisionTemplateLabelDisallowedList = []string{
 		VisibilityLabel,
@@ -391,6 +802,22 @@ const (
 	StateReasonCompleted        = "Completed"
 	StateReasonError            = "Error"
 	StateReasonCrashLoopBackOff = "CrashLoopBackOff"
+	StateReasonOOMKilled        = "OOMKilled"
+)
+
+// Exit codes of the storage initializer, following the sysexits.h codes. The transient failures are retried, the
+// permanent ones are reported at once as they fail again until the storage uri or the credentials are fixed.
+const (
+	StorageInitializerCorruptModelExitCode int32 = 65
+	StorageInitializerNotFoundExitCode     int32 = 66
+	StorageInitializerTransientExitCode    int32 = 75
+	StorageInitializerAccessDeniedExitCode int32 = 77
+)
+
+// Bounds of the logs of the crashed kserve container surfaced in the model status
+const (
+	FailureLogTailLines = 50
+	FailureLogTailBytes = 2048
 )
 
 // GetRawServiceLabel generate native service label
@@ -398,6 +825,12 @@ func GetRawServiceLabel(service string) string {
 	return "isvc." + service
 }
 
+// RawOriginServiceName is the name of the service selecting the pods of a component routed through the KEDA HTTP
+// interceptor, the service of the component points to the interceptor
+func RawOriginServiceName(service string) string {
+	return service + "-origin"
+}
+
 func (e InferenceServiceComponent) String() string {
 	return string(e)
 }
@@ -461,6 +894,12 @@ func ModelConfigName(inferenceserviceName string, shardId int) string {
 	return fmt.Sprintf("modelconfig-%s-%d", inferenceserviceName, shardId)
 }
 
+// AgentConfigName returns the name of the ConfigMap holding the logger, batcher and maintenance configuration the
+// agents of the InferenceService reload
+func AgentConfigName(inferenceserviceName string) string {
+	return inferenceserviceName + "-agent-config"
+}
+
 func InferenceServicePrefix(name string) string {
 	return fmt.Sprintf("/v1/models/%s", name)
 }
@@ -487,6 +926,20 @@ func ExplainPrefix() string {
 	return fmt.Sprintf("^/v1/models/[\\w-]+:explain$")
 }
 
+// ModelPrefix matches the v1 and v2 paths of any model
+func ModelPrefix() string {
+	return "^/v[12]/models/[^/:]+([:/].*)?$"
+}
+
+// ModelPathRegExp matches the v1 and v2 paths of the named models
+func ModelPathRegExp(models []string) string {
+	quoted := make([]string, 0, len(models))
+	for _, model := range models {
+		quoted = append(quoted, regexp.QuoteMeta(model))
+	}
+	return fmt.Sprintf("^/v[12]/models/(%s)([:/].*)?$", strings.Join(quoted, "|"))
+}
+
 func VirtualServiceHostname(name string, predictorHostName string) string {
 	index := strings.Index(predictorHostName, ".")
 	return name + predictorHostName[index:]
@@ -511,6 +964,20 @@ func TransformerURL(metadata metav1.ObjectMeta, isCanary bool) string {
 // Should only match 1..65535, but for simplicity it matches 0-99999.
 const portMatch = `(?::\d{1,5})?`
 
+// IsProtobufContentType returns whether the payload of the content type is encoded with protobuf, the payloads of
+// the other content types are treated as JSON
+func IsProtobufContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ProtobufContentType
+}
+
+// IsStreamContentType returns whether the response of the content type is streamed as Server-Sent Events or newline
+// delimited JSON
+func IsStreamContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == EventStreamContentType || mediaType == NDJSONContentType)
+}
+
 // HostRegExp returns an ECMAScript regular expression to match either host or host:<any port>
 // for clusterLocalHost, we will also match the prefixes.
 func HostRegExp(host string) string {
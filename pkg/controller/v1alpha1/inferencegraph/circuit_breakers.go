@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/serving"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CircuitOpenReason is the reason of the CircuitBreakersClosed condition while a circuit is open
+	CircuitOpenReason = "CircuitOpen"
+	// CircuitBreakersRequeueInterval is the interval the circuit breakers of the routers are checked at
+	CircuitBreakersRequeueInterval = 30 * time.Second
+
+	// circuitOpenState is the state reported by the router for the open circuits
+	circuitOpenState = "open"
+	scrapeTimeout    = 10 * time.Second
+)
+
+// hasCircuitBreakers reports whether a step of the graph has a circuit breaker
+func hasCircuitBreakers(graph *v1alpha1api.InferenceGraph) bool {
+	for _, router := range graph.Spec.Nodes {
+		for _, step := range router.Steps {
+			if step.CircuitBreaker != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// openCircuits returns the steps whose circuit breaker is open in a running router pod of the graph, the pods which
+// could not be scraped are skipped
+func (r *InferenceGraphReconciler) openCircuits(ctx context.Context, graph *v1alpha1api.InferenceGraph) ([]string, error) {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(graph.Namespace),
+		client.MatchingLabels{serving.ServiceLabelKey: graph.Name}); err != nil {
+		return nil, err
+	}
+	scrape := r.Scrape
+	if scrape == nil {
+		scrape = scrapeRouter
+	}
+	open := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != v1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		states, err := scrape(ctx, pod)
+		if err != nil {
+			r.Log.Error(err, "Failed to scrape the circuit breakers of the router", "pod", pod.Name,
+				"namespace", pod.Namespace)
+			continue
+		}
+		for step, state := range states {
+			if state == circuitOpenState {
+				open[step] = true
+			}
+		}
+	}
+	steps := make([]string, 0, len(open))
+	for step := range open {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+	return steps, nil
+}
+
+// setCircuitBreakersCondition sets the CircuitBreakersClosed condition, it is False while a step has an open circuit
+func setCircuitBreakersCondition(graph *v1alpha1api.InferenceGraph, open []string) {
+	condition := apis.Condition{
+		Type:   v1alpha1api.CircuitBreakersClosed,
+		Status: v1.ConditionTrue,
+	}
+	if len(open) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Severity = apis.ConditionSeverityWarning
+		condition.Reason = CircuitOpenReason
+		condition.Message = fmt.Sprintf("The circuit breakers of the steps %s are open", strings.Join(open, ", "))
+	}
+	apis.NewLivingConditionSet().Manage(&graph.Status).SetCondition(condition)
+}
+
+func scrapeRouter(ctx context.Context, pod *v1.Pod) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, constants.RouterPort) + constants.RouterCircuitBreakersPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d scraping %s", resp.StatusCode, url)
+	}
+	states := map[string]string{}
+	if err := json.NewDecoder(resp.Body).Decode(&states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/serving"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRouterPod(name string, graph string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{serving.ServiceLabelKey: graph},
+		},
+		Status: v1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+	}
+}
+
+func TestCircuitBreakersCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	graph := &v1alpha1api.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Spec: v1alpha1api.InferenceGraphSpec{
+			Nodes: map[string]v1alpha1api.InferenceRouter{
+				"root": {
+					RouterType: v1alpha1api.Sequence,
+					Steps: []v1alpha1api.InferenceStep{
+						{StepName: "model", CircuitBreaker: &v1alpha1api.StepCircuitBreaker{}},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(hasCircuitBreakers(graph)).To(gomega.BeTrue())
+
+	states := map[string]map[string]string{
+		"router-a": {"root/model": "closed", "root/other": "open"},
+		"router-b": {"root/model": "open", "root/other": "open"},
+		"router-c": {"root/model": "open"},
+		"other":    {"root/failing": "open"},
+	}
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	reconciler := &InferenceGraphReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			newRouterPod("router-a", "graph", v1.PodRunning),
+			newRouterPod("router-b", "graph", v1.PodRunning),
+			newRouterPod("router-c", "graph", v1.PodPending),
+			newRouterPod("router-d", "graph", v1.PodRunning),
+			newRouterPod("other", "other-graph", v1.PodRunning),
+		).Build(),
+		Log: ctrl.Log.WithName("test"),
+		Scrape: func(ctx context.Context, pod *v1.Pod) (map[string]string, error) {
+			if states, ok := states[pod.Name]; ok {
+				return states, nil
+			}
+			return nil, fmt.Errorf("connection refused")
+		},
+	}
+	// The open circuits of every running router pod are reported, the pods which can't be scraped are skipped
+	open, err := reconciler.openCircuits(context.TODO(), graph)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(open).To(gomega.Equal([]string{"root/model", "root/other"}))
+
+	setCircuitBreakersCondition(graph, open)
+	condition := graph.Status.GetCondition(v1alpha1api.CircuitBreakersClosed)
+	g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(condition.Severity).To(gomega.Equal(apis.ConditionSeverityWarning))
+	g.Expect(condition.Reason).To(gomega.Equal(CircuitOpenReason))
+	g.Expect(condition.Message).To(gomega.Equal("The circuit breakers of the steps root/model, root/other are open"))
+
+	setCircuitBreakersCondition(graph, nil)
+	condition = graph.Status.GetCondition(v1alpha1api.CircuitBreakersClosed)
+	g.Expect(condition.Status).To(gomega.Equal(v1.ConditionTrue))
+	g.Expect(condition.Message).To(gomega.BeEmpty())
+}
@@ -19,6 +19,7 @@ limitations under the License.
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 package inferencegraph
 
 import (
@@ -33,6 +34,7 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/kserveconfig"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -55,6 +57,9 @@ type InferenceGraphReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// Scrape returns the state of the circuit breakers of the steps reported by the router pod, defaults to
+	// scrapeRouter
+	Scrape func(ctx context.Context, pod *v1.Pod) (map[string]string, error)
 }
 
 // InferenceGraphState describes the Readiness of the InferenceGraph
@@ -127,6 +132,17 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	r.Log.Info("Reconciling inference graph", "apiVersion", graph.APIVersion, "graph", graph.Name)
+	if err := kserveconfig.ConfigError(ctx, r.Client); err != nil {
+		// Keep the router as it is until the configuration is fixed
+		r.Log.Error(err, "Refusing to reconcile InferenceGraph with an invalid configuration", "graph", graph.Name)
+		apis.NewLivingConditionSet().Manage(&graph.Status).
+			MarkFalse(v1alpha1api.ConfigValid, kserveconfig.InvalidConfigReason, "%s", err.Error())
+		r.Recorder.Event(graph, v1.EventTypeWarning, kserveconfig.InvalidConfigReason, err.Error())
+		if err := r.updateStatus(graph); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: kserveconfig.InvalidConfigRequeueInterval}, nil
+	}
 	configMap := &v1.ConfigMap{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
 	if err != nil {
@@ -186,7 +202,21 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	r.Log.Info("updating inference graph status", "status", ksvcStatus)
+	// The circuit breakers condition is kept so its transition time only changes with its status
+	circuitBreakers := graph.Status.GetCondition(v1alpha1api.CircuitBreakersClosed)
 	graph.Status.Conditions = ksvcStatus.Status.Conditions
+	result := ctrl.Result{}
+	if hasCircuitBreakers(graph) {
+		if circuitBreakers != nil {
+			graph.Status.Conditions = append(graph.Status.Conditions, *circuitBreakers)
+		}
+		open, err := r.openCircuits(ctx, graph)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		setCircuitBreakersCondition(graph, open)
+		result.RequeueAfter = CircuitBreakersRequeueInterval
+	}
 	//@TODO Need to check the status of all the graph components, find the inference services from all the nodes and collect the status
 	for _, con := range ksvcStatus.Status.Conditions {
 		if con.Type == apis.ConditionReady {
@@ -202,7 +232,7 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *InferenceGraphReconciler) updateStatus(desiredGraph *v1alpha1api.InferenceGraph) error {
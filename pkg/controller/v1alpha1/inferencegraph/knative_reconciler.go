@@ -183,8 +183,48 @@ func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.In
 		}
 	}
 
+	// Mount an audience scoped service account token so the router can authenticate the calls to the graph nodes
+	if audience, ok := graph.ObjectMeta.Annotations[constants.TokenAudienceAnnotationKey]; ok {
+		addServiceAccountToken(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec, audience)
+	}
+
+	// The router revision answers the graph requests for up to the timeout of the graph, the streamed responses included
+	if graph.Spec.TimeoutSeconds != nil {
+		service.Spec.ConfigurationSpec.Template.Spec.TimeoutSeconds = graph.Spec.TimeoutSeconds
+	}
+
 	//Call setDefaults on desired knative service here to avoid diffs generated because knative defaulter webhook is
 	//called when creating or updating the knative service
 	service.SetDefaults(context.TODO())
 	return service
 }
+
+func addServiceAccountToken(podSpec *v1.PodSpec, audience string) {
+	expirationSeconds := int64(constants.RouterTokenExpirationSeconds)
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: constants.RouterTokenVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{
+						ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              constants.RouterTokenFileName,
+						},
+					},
+				},
+			},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      constants.RouterTokenVolumeName,
+		MountPath: constants.RouterTokenMountPath,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, v1.EnvVar{
+		Name:  constants.RouterTokenPathEnvVar,
+		Value: constants.RouterTokenMountPath + "/" + constants.RouterTokenFileName,
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateKnativeServiceWithTokenAudience(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &RouterConfig{
+		Image:         "kserve/router:latest",
+		CpuRequest:    "100m",
+		CpuLimit:      "1",
+		MemoryRequest: "100Mi",
+		MemoryLimit:   "1Gi",
+		Headers:       map[string][]string{"propagate": {"Authorization"}},
+	}
+	expectedExpiration := int64(constants.RouterTokenExpirationSeconds)
+
+	scenarios := map[string]struct {
+		annotations     map[string]string
+		expectedVolumes []v1.Volume
+		expectedMounts  []v1.VolumeMount
+		expectedEnv     []v1.EnvVar
+	}{
+		"NoAudience": {
+			annotations: map[string]string{},
+			expectedEnv: []v1.EnvVar{{Name: constants.RouterHeadersPropagateEnvVar, Value: "Authorization"}},
+		},
+		"Audience": {
+			annotations: map[string]string{constants.TokenAudienceAnnotationKey: "graph"},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.RouterTokenVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Projected: &v1.ProjectedVolumeSource{
+						Sources: []v1.VolumeProjection{{
+							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+								Audience:          "graph",
+								ExpirationSeconds: &expectedExpiration,
+								Path:              constants.RouterTokenFileName,
+							},
+						}},
+					},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.RouterTokenVolumeName,
+				MountPath: constants.RouterTokenMountPath,
+				ReadOnly:  true,
+			}},
+			expectedEnv: []v1.EnvVar{
+				{Name: constants.RouterHeadersPropagateEnvVar, Value: "Authorization"},
+				{Name: constants.RouterTokenPathEnvVar, Value: "/var/run/secrets/kserve/serviceaccount/token"},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		graph := &v1alpha1api.InferenceGraph{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "graph",
+				Namespace:   "default",
+				Annotations: scenario.annotations,
+			},
+		}
+		service := createKnativeService(graph.ObjectMeta, graph, config)
+		podSpec := service.Spec.Template.Spec.PodSpec
+		g.Expect(podSpec.Volumes).To(gomega.Equal(scenario.expectedVolumes), name)
+		g.Expect(podSpec.Containers[0].VolumeMounts).To(gomega.Equal(scenario.expectedMounts), name)
+		g.Expect(podSpec.Containers[0].Env).To(gomega.Equal(scenario.expectedEnv), name)
+	}
+}
+
+func TestCreateKnativeServiceWithTimeout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &RouterConfig{
+		Image:         "kserve/router:latest",
+		CpuRequest:    "100m",
+		CpuLimit:      "1",
+		MemoryRequest: "100Mi",
+		MemoryLimit:   "1Gi",
+	}
+	timeout := int64(900)
+	graph := &v1alpha1api.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Spec: v1alpha1api.InferenceGraphSpec{
+			Nodes:          map[string]v1alpha1api.InferenceRouter{},
+			TimeoutSeconds: &timeout,
+		},
+	}
+	service := createKnativeService(graph.ObjectMeta, graph, config)
+	g.Expect(*service.Spec.Template.Spec.TimeoutSeconds).To(gomega.Equal(timeout))
+	// The router reads the timeouts from the graph passed in its arguments
+	g.Expect(service.Spec.Template.Spec.Containers[0].Args[1]).To(gomega.ContainSubstring(`"timeout":900`))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=kserveconfigs,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=kserveconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update
+package kserveconfig
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	InvalidConfigReason  = "InvalidConfig"
+	RenderFailedReason   = "RenderFailed"
+	ConfigAppliedMessage = "inferenceservice-config is up to date"
+)
+
+var conditionSet = apis.NewLivingConditionSet(v1alpha1api.ConfigValid)
+
+// KServeConfigReconciler renders the inferenceservice-config ConfigMap from the KServeConfig
+type KServeConfigReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func (r *KServeConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != v1alpha1api.KServeConfigName || req.Namespace != constants.KServeNamespace {
+		r.Log.Info("Ignoring KServeConfig, only the config in the KServe namespace is honored",
+			"name", req.Name, "namespace", req.Namespace)
+		return reconcile.Result{}, nil
+	}
+	// Also triggered by the changes of the ConfigMap, report manual edits which broke the configuration
+	if err := CheckConfig(ctx, r.Client); err != nil {
+		r.Log.Error(err, "Invalid inferenceservice-config, InferenceServices are not reconciled until it is fixed")
+	}
+	kserveConfig := &v1alpha1api.KServeConfig{}
+	if err := r.Get(ctx, req.NamespacedName, kserveConfig); err != nil {
+		if apierr.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	r.Log.Info("Reconciling KServeConfig", "name", kserveConfig.Name)
+	desired := kserveConfig.DeepCopy()
+	desired.Status.ObservedGeneration = kserveConfig.Generation
+	spec := desired.Spec.DeepCopy()
+	spec.Default()
+	if err := spec.Validate(); err != nil {
+		r.Log.Error(err, "Invalid KServeConfig, keeping the current inferenceservice-config")
+		conditionSet.Manage(&desired.Status).MarkFalse(v1alpha1api.ConfigValid, InvalidConfigReason, "%s", err.Error())
+		return reconcile.Result{}, r.updateStatus(desired)
+	}
+	conditionSet.Manage(&desired.Status).MarkTrue(v1alpha1api.ConfigValid)
+	data, err := spec.ToConfigMapData()
+	if err != nil {
+		conditionSet.Manage(&desired.Status).MarkFalse(apis.ConditionReady, RenderFailedReason, "%s", err.Error())
+		return reconcile.Result{}, r.updateStatus(desired)
+	}
+	if err := r.applyConfigMap(ctx, data); err != nil {
+		return reconcile.Result{}, err
+	}
+	conditionSet.Manage(&desired.Status).MarkTrueWithReason(apis.ConditionReady, "", ConfigAppliedMessage)
+	return reconcile.Result{}, r.updateStatus(desired)
+}
+
+// applyConfigMap merges the rendered keys into the inferenceservice-config ConfigMap, the keys which are not part
+// of the KServeConfig spec are left untouched.
+func (r *KServeConfigReconciler) applyConfigMap(ctx context.Context, data map[string]string) error {
+	configMap := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
+	if apierr.IsNotFound(err) {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.InferenceServiceConfigMapName,
+				Namespace: constants.KServeNamespace,
+			},
+			Data: data,
+		}
+		r.Log.Info("Creating ConfigMap", "name", configMap.Name)
+		return r.Create(ctx, configMap)
+	} else if err != nil {
+		return err
+	}
+	desired := configMap.DeepCopy()
+	if desired.Data == nil {
+		desired.Data = map[string]string{}
+	}
+	for key, value := range data {
+		desired.Data[key] = value
+	}
+	if equality.Semantic.DeepEqual(configMap.Data, desired.Data) {
+		return nil
+	}
+	r.Log.Info("Updating ConfigMap", "name", configMap.Name)
+	return r.Update(ctx, desired)
+}
+
+func (r *KServeConfigReconciler) updateStatus(desired *v1alpha1api.KServeConfig) error {
+	existing := &v1alpha1api.KServeConfig{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Status, desired.Status) {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), desired); err != nil {
+		r.Log.Error(err, "Failed to update KServeConfig status", "KServeConfig", desired.Name)
+		return errors.Wrapf(err, "fails to update KServeConfig status")
+	}
+	if ready := desired.Status.GetCondition(apis.ConditionReady); ready != nil && ready.Status == v1.ConditionFalse {
+		r.Recorder.Event(desired, v1.EventTypeWarning, ready.Reason, ready.Message)
+	}
+	return nil
+}
+
+// ImportConfigMap creates the KServeConfig from the existing inferenceservice-config ConfigMap so upgraded installs
+// keep their configuration, nothing is done when the KServeConfig already exists.
+func ImportConfigMap(ctx context.Context, cli client.Client) error {
+	name := types.NamespacedName{Name: v1alpha1api.KServeConfigName, Namespace: constants.KServeNamespace}
+	if err := cli.Get(ctx, name, &v1alpha1api.KServeConfig{}); err == nil {
+		return nil
+	} else if !apierr.IsNotFound(err) {
+		return err
+	}
+	configMap := &v1.ConfigMap{}
+	err := cli.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
+	if err != nil {
+		return err
+	}
+	spec, err := v1alpha1api.KServeConfigSpecFromConfigMap(configMap)
+	if err != nil {
+		return err
+	}
+	return cli.Create(ctx, &v1alpha1api.KServeConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Spec:       *spec,
+	})
+}
+
+// configMapToKServeConfig maps the changes of the inferenceservice-config ConfigMap to the KServeConfig, so manual
+// edits of the ConfigMap are reconciled back to the KServeConfig.
+func configMapToKServeConfig(obj client.Object) []reconcile.Request {
+	if obj.GetName() != constants.InferenceServiceConfigMapName || obj.GetNamespace() != constants.KServeNamespace {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      v1alpha1api.KServeConfigName,
+		Namespace: constants.KServeNamespace,
+	}}}
+}
+
+func (r *KServeConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1api.KServeConfig{}).
+		Watches(&source.Kind{Type: &v1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(configMapToKServeConfig)).
+		Complete(r)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserveconfig
+
+import (
+	"context"
+	"testing"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	configMapName    = types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}
+	kserveConfigName = types.NamespacedName{Name: v1alpha1api.KServeConfigName, Namespace: constants.KServeNamespace}
+)
+
+// renderedRawDeployment is the deploy key rendered from a KServeConfig defaulting to RawDeployment
+const renderedRawDeployment = "{\n    \"defaultDeploymentMode\": \"RawDeployment\"\n}"
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	v1alpha1api.AddToScheme(s)
+	return s
+}
+
+func newConfigMap(data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace},
+		Data:       data,
+	}
+}
+
+func TestImportConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap := newConfigMap(map[string]string{
+		v1alpha1api.IngressConfigMapKey: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "istio-ingressgateway.istio-system.svc.cluster.local"}`,
+		v1alpha1api.DeployConfigMapKey:  `{"defaultDeploymentMode": "RawDeployment"}`,
+		"transformers":                  `{}`,
+	})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(configMap).Build()
+
+	// Imported on first start
+	g.Expect(ImportConfigMap(context.TODO(), cli)).To(gomega.Succeed())
+	imported := &v1alpha1api.KServeConfig{}
+	g.Expect(cli.Get(context.TODO(), kserveConfigName, imported)).To(gomega.Succeed())
+	g.Expect(imported.Spec.Ingress).NotTo(gomega.BeNil())
+	g.Expect(imported.Spec.Ingress.IngressGateway).To(gomega.Equal("knative-serving/knative-ingress-gateway"))
+	g.Expect(imported.Spec.Deploy).To(gomega.Equal(&v1alpha1api.DeployConfigSpec{DefaultDeploymentMode: "RawDeployment"}))
+	g.Expect(imported.Spec.Logger).To(gomega.BeNil())
+
+	// The existing KServeConfig is kept on the next starts
+	configMap.Data[v1alpha1api.DeployConfigMapKey] = `{"defaultDeploymentMode": "Serverless"}`
+	g.Expect(cli.Update(context.TODO(), configMap)).To(gomega.Succeed())
+	g.Expect(ImportConfigMap(context.TODO(), cli)).To(gomega.Succeed())
+	existing := &v1alpha1api.KServeConfig{}
+	g.Expect(cli.Get(context.TODO(), kserveConfigName, existing)).To(gomega.Succeed())
+	g.Expect(existing.Spec).To(gomega.Equal(imported.Spec))
+}
+
+func TestImportConfigMapWithoutConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	g.Expect(ImportConfigMap(context.TODO(), cli)).NotTo(gomega.Succeed())
+	err := cli.Get(context.TODO(), kserveConfigName, &v1alpha1api.KServeConfig{})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestKServeConfigReconcile(t *testing.T) {
+	scenarios := map[string]struct {
+		spec              v1alpha1api.KServeConfigSpec
+		configMap         *v1.ConfigMap
+		expectedData      map[string]string
+		expectedCondition apis.ConditionType
+		expectedStatus    v1.ConditionStatus
+	}{
+		"CreatesConfigMap": {
+			spec: v1alpha1api.KServeConfigSpec{
+				Deploy: &v1alpha1api.DeployConfigSpec{DefaultDeploymentMode: "RawDeployment"},
+			},
+			expectedData: map[string]string{
+				v1alpha1api.DeployConfigMapKey: renderedRawDeployment,
+			},
+			expectedCondition: apis.ConditionReady,
+			expectedStatus:    v1.ConditionTrue,
+		},
+		"MergeKeepsUnrelatedKeys": {
+			spec: v1alpha1api.KServeConfigSpec{
+				Deploy: &v1alpha1api.DeployConfigSpec{DefaultDeploymentMode: "RawDeployment"},
+			},
+			configMap: newConfigMap(map[string]string{
+				v1alpha1api.DeployConfigMapKey: `{"defaultDeploymentMode": "Serverless"}`,
+				"transformers":                 `{"feast": {"image": "kserve/feast-transformer"}}`,
+			}),
+			expectedData: map[string]string{
+				v1alpha1api.DeployConfigMapKey: renderedRawDeployment,
+				"transformers":                 `{"feast": {"image": "kserve/feast-transformer"}}`,
+			},
+			expectedCondition: apis.ConditionReady,
+			expectedStatus:    v1.ConditionTrue,
+		},
+		"InvalidSpecKeepsConfigMap": {
+			spec: v1alpha1api.KServeConfigSpec{
+				Explainers: map[string]v1alpha1api.ExplainerConfigSpec{"alibi": {DefaultImageVersion: "latest"}},
+				Deploy:     &v1alpha1api.DeployConfigSpec{DefaultDeploymentMode: "RawDeployment"},
+			},
+			configMap: newConfigMap(map[string]string{
+				v1alpha1api.DeployConfigMapKey: `{"defaultDeploymentMode": "Serverless"}`,
+			}),
+			expectedData: map[string]string{
+				v1alpha1api.DeployConfigMapKey: `{"defaultDeploymentMode": "Serverless"}`,
+			},
+			expectedCondition: v1alpha1api.ConfigValid,
+			expectedStatus:    v1.ConditionFalse,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			kserveConfig := &v1alpha1api.KServeConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: kserveConfigName.Name, Namespace: kserveConfigName.Namespace},
+				Spec:       scenario.spec,
+			}
+			objects := []client.Object{kserveConfig}
+			if scenario.configMap != nil {
+				objects = append(objects, scenario.configMap)
+			}
+			cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+			reconciler := &KServeConfigReconciler{
+				Client:   cli,
+				Log:      ctrl.Log.WithName("test"),
+				Scheme:   cli.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: kserveConfigName})
+			g.Expect(err).To(gomega.BeNil())
+
+			configMap := &v1.ConfigMap{}
+			g.Expect(cli.Get(context.TODO(), configMapName, configMap)).To(gomega.Succeed())
+			g.Expect(configMap.Data).To(gomega.Equal(scenario.expectedData))
+
+			updated := &v1alpha1api.KServeConfig{}
+			g.Expect(cli.Get(context.TODO(), kserveConfigName, updated)).To(gomega.Succeed())
+			condition := updated.Status.GetCondition(scenario.expectedCondition)
+			g.Expect(condition).NotTo(gomega.BeNil())
+			g.Expect(condition.Status).To(gomega.Equal(scenario.expectedStatus))
+		})
+	}
+}
+
+func TestConfigMapToKServeConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		name     types.NamespacedName
+		expected []reconcile.Request
+	}{
+		"InferenceServiceConfig": {
+			name:     configMapName,
+			expected: []reconcile.Request{{NamespacedName: kserveConfigName}},
+		},
+		"OtherConfigMap": {
+			name: types.NamespacedName{Name: "other", Namespace: constants.KServeNamespace},
+		},
+		"OtherNamespace": {
+			name: types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: "default"},
+		},
+	}
+	for name, scenario := range scenarios {
+		configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: scenario.name.Name, Namespace: scenario.name.Namespace}}
+		g.Expect(configMapToKServeConfig(configMap)).To(gomega.Equal(scenario.expected), name)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserveconfig
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// configValid reports whether the inferenceservice-config ConfigMap passed the last validation, it is served on
+// the controller manager metrics endpoint.
+var configValid = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "kserve_config_valid",
+	Help: "Whether the inferenceservice-config ConfigMap is valid (1) or not (0)",
+})
+
+// InvalidConfigRequeueInterval is how often the reconcilers gated on ConfigError check again for a fixed configuration
+const InvalidConfigRequeueInterval = time.Minute
+
+// lastCheck caches the result of the last CheckConfig
+var lastCheck struct {
+	sync.RWMutex
+	checked bool
+	err     error
+}
+
+func init() {
+	metrics.Registry.MustRegister(configValid)
+}
+
+// CheckConfig validates the inferenceservice-config ConfigMap, records the result in the kserve_config_valid
+// metric and caches it for ConfigError. It runs at startup and whenever the ConfigMap or the KServeConfig changes.
+func CheckConfig(ctx context.Context, cli client.Client) error {
+	configMap := &v1.ConfigMap{}
+	err := cli.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
+	if err != nil {
+		return err
+	}
+	if err = v1alpha1api.ValidateConfigMap(configMap); err != nil {
+		err = fmt.Errorf("invalid %s ConfigMap: %v", constants.InferenceServiceConfigMapName, err)
+		configValid.Set(0)
+	} else {
+		configValid.Set(1)
+	}
+	lastCheck.Lock()
+	defer lastCheck.Unlock()
+	lastCheck.checked = true
+	lastCheck.err = err
+	return err
+}
+
+// ConfigError returns the result of the last CheckConfig so reconcilers do not parse the ConfigMap on every
+// reconcile, the ConfigMap is only validated here when it was never checked before.
+func ConfigError(ctx context.Context, cli client.Client) error {
+	lastCheck.RLock()
+	checked, err := lastCheck.checked, lastCheck.err
+	lastCheck.RUnlock()
+	if checked {
+		return err
+	}
+	return CheckConfig(ctx, cli)
+}
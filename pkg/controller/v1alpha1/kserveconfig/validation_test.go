@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserveconfig
+
+import (
+	"context"
+	"testing"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigError(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{v1alpha1api.DeployConfigMapKey: `{"defaultDeploymentMode": "Serverless"}`},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(configMap).Build()
+
+	// Validated on first use
+	g.Expect(ConfigError(context.TODO(), cli)).To(gomega.Succeed())
+
+	// Breaking the ConfigMap is not noticed until the next CheckConfig
+	configMap.Data[v1alpha1api.DeployConfigMapKey] = `{"defaultDeploymentMode": "Knative"}`
+	g.Expect(cli.Update(context.TODO(), configMap)).To(gomega.Succeed())
+	g.Expect(ConfigError(context.TODO(), cli)).To(gomega.Succeed())
+	g.Expect(CheckConfig(context.TODO(), cli)).To(gomega.MatchError(gomega.ContainSubstring("unsupported defaultDeploymentMode")))
+	g.Expect(ConfigError(context.TODO(), cli)).To(gomega.MatchError(gomega.ContainSubstring("unsupported defaultDeploymentMode")))
+
+	configMap.Data[v1alpha1api.DeployConfigMapKey] = `{"defaultDeploymentMode": "RawDeployment"}`
+	g.Expect(cli.Update(context.TODO(), configMap)).To(gomega.Succeed())
+	g.Expect(CheckConfig(context.TODO(), cli)).To(gomega.Succeed())
+	g.Expect(ConfigError(context.TODO(), cli)).To(gomega.Succeed())
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=localmodelcaches,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=localmodelcaches/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get
+package localmodelcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/agent"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DownloadingDir is the sub directory of the cache directory the models are downloaded to, they are moved to the cache
+// directory once complete so the predictor pods never mount a partially downloaded model
+const DownloadingDir = ".downloading"
+
+// downloadPollInterval is the interval the LocalModelCaches are requeued at while their model is downloading
+const downloadPollInterval = 10 * time.Second
+
+// download is the download of the model of a LocalModelCache running in the background
+type download struct {
+	storageURI string
+	done       bool
+	err        error
+}
+
+// LocalModelCacheReconciler runs in the local model agent on every node, it downloads the models of the
+// LocalModelCaches selecting the node to the cache directory of the node and reports their state in the node status of
+// the LocalModelCaches.
+type LocalModelCacheReconciler struct {
+	client.Client
+	Log        logr.Logger
+	NodeName   string
+	CacheDir   string
+	Downloader *agent.Downloader
+
+	mu sync.Mutex
+	// downloads are the running and the completed downloads keyed by the name of their LocalModelCache, a completed
+	// download is removed once its result is reported in the status
+	downloads map[string]*download
+}
+
+func (r *LocalModelCacheReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	modelDir := filepath.Join(r.CacheDir, req.Name)
+	cache := &v1alpha1api.LocalModelCache{}
+	if err := r.Get(ctx, req.NamespacedName, cache); err != nil {
+		if apierr.IsNotFound(err) {
+			// The running download would move the model back to the cache directory
+			if r.downloading(req.Name) {
+				return reconcile.Result{RequeueAfter: downloadPollInterval}, nil
+			}
+			r.Log.Info("Removing the model of the deleted LocalModelCache", "name", req.Name)
+			return reconcile.Result{}, os.RemoveAll(modelDir)
+		}
+		return reconcile.Result{}, err
+	}
+
+	node := &v1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: r.NodeName}, node); err != nil {
+		return reconcile.Result{}, err
+	}
+	if !cache.SelectsNode(node.Labels) {
+		if r.downloading(cache.Name) {
+			return reconcile.Result{RequeueAfter: downloadPollInterval}, nil
+		}
+		if err := os.RemoveAll(modelDir); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.updateNodeStatus(ctx, cache, "")
+	}
+
+	// The models are downloaded in the background so the download of a large model does not block the reconciles of
+	// the other LocalModelCaches, the cache is requeued until its download completed
+	r.mu.Lock()
+	var current download
+	tracked, ok := r.downloads[cache.Name]
+	if ok {
+		current = *tracked
+		if current.done {
+			delete(r.downloads, cache.Name)
+		}
+	}
+	r.mu.Unlock()
+	if ok && !current.done {
+		// The download of a previous storage uri also completes before the model is downloaded again
+		return reconcile.Result{RequeueAfter: downloadPollInterval},
+			r.updateNodeStatus(ctx, cache, v1alpha1api.NodeDownloading)
+	}
+	if ok && current.err != nil && current.storageURI == cache.Spec.SourceModelUri {
+		r.Log.Error(current.err, "Failed to download model", "name", cache.Name, "uri", cache.Spec.SourceModelUri)
+		if statusErr := r.updateNodeStatus(ctx, cache, v1alpha1api.NodeDownloadError); statusErr != nil {
+			return reconcile.Result{}, statusErr
+		}
+		// The download is retried with the backoff of the failed reconciles
+		return reconcile.Result{}, current.err
+	}
+
+	modelSpec := &v1alpha1api.ModelSpec{StorageURI: cache.Spec.SourceModelUri}
+	successFile := filepath.Join(modelDir, fmt.Sprintf("SUCCESS.%s", storage.AsSha256(modelSpec)))
+	if _, err := os.Stat(successFile); err == nil {
+		return reconcile.Result{}, r.updateNodeStatus(ctx, cache, v1alpha1api.NodeDownloaded)
+	}
+
+	if err := r.updateNodeStatus(ctx, cache, v1alpha1api.NodeDownloading); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.Log.Info("Downloading model", "name", cache.Name, "uri", cache.Spec.SourceModelUri)
+	r.startDownload(cache.Name, modelSpec, modelDir)
+	return reconcile.Result{RequeueAfter: downloadPollInterval}, nil
+}
+
+// startDownload downloads the model in the background, the result is recorded in the downloads of the reconciler
+func (r *LocalModelCacheReconciler) startDownload(name string, modelSpec *v1alpha1api.ModelSpec, modelDir string) {
+	current := &download{storageURI: modelSpec.StorageURI}
+	r.mu.Lock()
+	if r.downloads == nil {
+		r.downloads = map[string]*download{}
+	}
+	r.downloads[name] = current
+	r.mu.Unlock()
+	go func() {
+		err := r.download(name, modelSpec, modelDir)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		current.done = true
+		current.err = err
+	}()
+}
+
+// downloading returns whether the model of the LocalModelCache is downloading
+func (r *LocalModelCacheReconciler) downloading(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.downloads[name]
+	return ok && !current.done
+}
+
+// download downloads the model to the downloading directory and moves it to the model directory, the model of a
+// previous storage uri is replaced
+func (r *LocalModelCacheReconciler) download(name string, modelSpec *v1alpha1api.ModelSpec, modelDir string) error {
+	downloadingDir := filepath.Join(r.Downloader.ModelDir, name)
+	// A download interrupted by a restart of the agent is started over
+	if err := os.RemoveAll(downloadingDir); err != nil {
+		return err
+	}
+	if err := r.Downloader.DownloadModel(name, modelSpec); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(modelDir); err != nil {
+		return err
+	}
+	return errors.Wrapf(os.Rename(downloadingDir, modelDir), "failed to move model to %s", modelDir)
+}
+
+// updateNodeStatus patches the status of the node only so the agents of the other nodes are not conflicted with, the
+// node is removed from the status when the status is empty
+func (r *LocalModelCacheReconciler) updateNodeStatus(ctx context.Context, cache *v1alpha1api.LocalModelCache,
+	status v1alpha1api.LocalModelNodeStatus) error {
+	current, ok := cache.Status.NodeStatus[r.NodeName]
+	if current == status && (ok || status == "") {
+		return nil
+	}
+	patch := client.MergeFrom(cache.DeepCopy())
+	if status == "" {
+		delete(cache.Status.NodeStatus, r.NodeName)
+	} else {
+		if cache.Status.NodeStatus == nil {
+			cache.Status.NodeStatus = map[string]v1alpha1api.LocalModelNodeStatus{}
+		}
+		cache.Status.NodeStatus[r.NodeName] = status
+	}
+	return errors.Wrapf(r.Status().Patch(ctx, cache, patch), "fails to update LocalModelCache status")
+}
+
+func (r *LocalModelCacheReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1api.LocalModelCache{}).
+		Complete(r)
+}
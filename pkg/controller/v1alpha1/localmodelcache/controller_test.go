@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localmodelcache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeProvider writes a model file instead of downloading the model, the download waits for the release channel when
+// it is set
+type fakeProvider struct {
+	err     error
+	release chan struct{}
+}
+
+func (p *fakeProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	if p.release != nil {
+		<-p.release
+	}
+	if p.err != nil {
+		return p.err
+	}
+	if err := os.MkdirAll(filepath.Join(modelDir, modelName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(modelDir, modelName, "model.bin"), []byte(storageUri), 0644)
+}
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	v1alpha1api.AddToScheme(s)
+	return s
+}
+
+func newReconciler(t *testing.T, provider storage.Provider, objects ...client.Object) *LocalModelCacheReconciler {
+	cacheDir := t.TempDir()
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"gpu": "a100"}}}
+	return &LocalModelCacheReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(append(objects, node)...).Build(),
+		Log:      ctrl.Log.WithName("test"),
+		NodeName: "node-a",
+		CacheDir: cacheDir,
+		Downloader: &agent.Downloader{
+			ModelDir:  filepath.Join(cacheDir, DownloadingDir),
+			Providers: map[storage.Protocol]storage.Provider{storage.S3: provider},
+			Logger:    zap.NewNop().Sugar(),
+		},
+	}
+}
+
+func newCache(nodeSelector map[string]string) *v1alpha1api.LocalModelCache {
+	return &v1alpha1api.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama"},
+		Spec: v1alpha1api.LocalModelCacheSpec{
+			SourceModelUri: "s3://models/llama",
+			NodeSelector:   nodeSelector,
+		},
+	}
+}
+
+// reconcileUntilDone reconciles the LocalModelCache until its download completed
+func reconcileUntilDone(g *gomega.WithT, reconciler *LocalModelCacheReconciler, request ctrl.Request) error {
+	var err error
+	g.Eventually(func() time.Duration {
+		var result ctrl.Result
+		result, err = reconciler.Reconcile(context.TODO(), request)
+		return result.RequeueAfter
+	}, 5*time.Second, 10*time.Millisecond).Should(gomega.BeZero())
+	return err
+}
+
+func TestLocalModelCacheReconcile(t *testing.T) {
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llama"}}
+
+	t.Run("DownloadsModel", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		provider := &fakeProvider{release: make(chan struct{})}
+		reconciler := newReconciler(t, provider, newCache(map[string]string{"gpu": "a100"}))
+
+		// The cache is requeued while the model downloads in the background
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(downloadPollInterval))
+		cache := &v1alpha1api.LocalModelCache{}
+		g.Expect(reconciler.Get(context.TODO(), request.NamespacedName, cache)).To(gomega.Succeed())
+		g.Expect(cache.Status.NodeStatus).To(gomega.Equal(map[string]v1alpha1api.LocalModelNodeStatus{
+			"node-a": v1alpha1api.NodeDownloading,
+		}))
+		result, err = reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(downloadPollInterval))
+
+		close(provider.release)
+		g.Expect(reconcileUntilDone(g, reconciler, request)).To(gomega.Succeed())
+		g.Expect(filepath.Join(reconciler.CacheDir, "llama", "model.bin")).To(gomega.BeAnExistingFile())
+		g.Expect(filepath.Join(reconciler.CacheDir, DownloadingDir, "llama")).NotTo(gomega.BeAnExistingFile())
+		g.Expect(reconciler.Get(context.TODO(), request.NamespacedName, cache)).To(gomega.Succeed())
+		g.Expect(cache.Status.NodeStatus).To(gomega.Equal(map[string]v1alpha1api.LocalModelNodeStatus{
+			"node-a": v1alpha1api.NodeDownloaded,
+		}))
+
+		// The downloaded model is not downloaded again
+		reconciler.Downloader.Providers[storage.S3] = &fakeProvider{err: fmt.Errorf("unexpected download")}
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("DownloadError", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		reconciler := newReconciler(t, &fakeProvider{err: fmt.Errorf("access denied")}, newCache(nil))
+		g.Expect(reconcileUntilDone(g, reconciler, request)).To(gomega.HaveOccurred())
+		g.Expect(filepath.Join(reconciler.CacheDir, "llama")).NotTo(gomega.BeAnExistingFile())
+		cache := &v1alpha1api.LocalModelCache{}
+		g.Expect(reconciler.Get(context.TODO(), request.NamespacedName, cache)).To(gomega.Succeed())
+		g.Expect(cache.Status.NodeStatus).To(gomega.Equal(map[string]v1alpha1api.LocalModelNodeStatus{
+			"node-a": v1alpha1api.NodeDownloadError,
+		}))
+	})
+
+	t.Run("NodeNotSelected", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		cache := newCache(map[string]string{"gpu": "h100"})
+		cache.Status.NodeStatus = map[string]v1alpha1api.LocalModelNodeStatus{"node-a": v1alpha1api.NodeDownloaded}
+		reconciler := newReconciler(t, &fakeProvider{}, cache)
+		g.Expect(os.MkdirAll(filepath.Join(reconciler.CacheDir, "llama"), 0755)).To(gomega.Succeed())
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(filepath.Join(reconciler.CacheDir, "llama")).NotTo(gomega.BeAnExistingFile())
+		g.Expect(reconciler.Get(context.TODO(), request.NamespacedName, cache)).To(gomega.Succeed())
+		g.Expect(cache.Status.NodeStatus).To(gomega.BeEmpty())
+	})
+
+	t.Run("CacheDeletedWhileDownloading", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		provider := &fakeProvider{release: make(chan struct{})}
+		cache := newCache(nil)
+		reconciler := newReconciler(t, provider, cache)
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(reconciler.Delete(context.TODO(), cache)).To(gomega.Succeed())
+
+		// The model is removed once the running download completed
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(downloadPollInterval))
+		close(provider.release)
+		g.Expect(reconcileUntilDone(g, reconciler, request)).To(gomega.Succeed())
+		g.Expect(filepath.Join(reconciler.CacheDir, "llama")).NotTo(gomega.BeAnExistingFile())
+	})
+
+	t.Run("CacheDeleted", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		reconciler := newReconciler(t, &fakeProvider{})
+		g.Expect(os.MkdirAll(filepath.Join(reconciler.CacheDir, "llama"), 0755)).To(gomega.Succeed())
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(filepath.Join(reconciler.CacheDir, "llama")).NotTo(gomega.BeAnExistingFile())
+	})
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=templatedinferenceservices,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=templatedinferenceservices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservicetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch;create;update;patch;delete
+package templatedinferenceservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/utils"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	TemplateNotFoundReason         = "TemplateNotFound"
+	RenderFailedReason             = "RenderFailed"
+	InferenceServiceConflictReason = "InferenceServiceConflict"
+)
+
+// TemplatedInferenceServiceReconciler expands the TemplatedInferenceService with its InferenceServiceTemplate into
+// the InferenceService of the same name
+type TemplatedInferenceServiceReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func (r *TemplatedInferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	templated := &v1alpha1api.TemplatedInferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, templated); err != nil {
+		if apierr.IsNotFound(err) {
+			// The InferenceService is garbage collected through its owner reference
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	r.Log.Info("Reconciling TemplatedInferenceService", "name", templated.Name, "namespace", templated.Namespace)
+	desired := templated.DeepCopy()
+	desired.Status.InitializeConditions()
+	desired.Status.ObservedGeneration = templated.Generation
+
+	template := &v1alpha1api.InferenceServiceTemplate{}
+	if err := r.Get(ctx, types.NamespacedName{Name: templated.Spec.TemplateName}, template); err != nil {
+		if !apierr.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		desired.Status.MarkTemplateFailed(TemplateNotFoundReason,
+			fmt.Sprintf("InferenceServiceTemplate %q not found", templated.Spec.TemplateName))
+		return reconcile.Result{}, r.updateStatus(desired)
+	}
+	isvc, err := renderInferenceService(templated, template)
+	if err != nil {
+		desired.Status.MarkTemplateFailed(RenderFailedReason, err.Error())
+		return reconcile.Result{}, r.updateStatus(desired)
+	}
+	desired.Status.MarkTemplateRendered()
+	if err := controllerutil.SetControllerReference(templated, isvc, r.Scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	existing, err := r.applyInferenceService(ctx, templated, isvc)
+	if err != nil {
+		if errors.Is(err, errConflict) {
+			desired.Status.MarkTemplateFailed(InferenceServiceConflictReason, err.Error())
+			return reconcile.Result{}, r.updateStatus(desired)
+		}
+		return reconcile.Result{}, err
+	}
+	desired.Status.PropagateInferenceServiceStatus(existing.Status.GetCondition(apis.ConditionReady), existing.Status.URL)
+	return reconcile.Result{}, r.updateStatus(desired)
+}
+
+var errConflict = fmt.Errorf("InferenceService is not owned by the TemplatedInferenceService")
+
+// renderInferenceService renders the template with the parameters of the TemplatedInferenceService, only the labels,
+// annotations and spec of the rendered manifest are kept.
+func renderInferenceService(templated *v1alpha1api.TemplatedInferenceService, template *v1alpha1api.InferenceServiceTemplate) (*v1beta1.InferenceService, error) {
+	manifest, err := template.Spec.Render(templated.Name, templated.Namespace, templated.Spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	rendered := &v1beta1.InferenceService{}
+	if err := yaml.UnmarshalStrict(manifest, rendered); err != nil {
+		return nil, fmt.Errorf("rendered template is not a valid InferenceService: %v", err)
+	}
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        templated.Name,
+			Namespace:   templated.Namespace,
+			Labels:      rendered.Labels,
+			Annotations: rendered.Annotations,
+		},
+		Spec: rendered.Spec,
+	}, nil
+}
+
+// applyInferenceService creates or updates the expanded InferenceService and returns the one on the cluster, the
+// labels and annotations added by other controllers are kept and the fields defaulted by the webhook are not
+// reverted.
+func (r *TemplatedInferenceServiceReconciler) applyInferenceService(ctx context.Context, owner *v1alpha1api.TemplatedInferenceService,
+	desired *v1beta1.InferenceService) (*v1beta1.InferenceService, error) {
+	existing := &v1beta1.InferenceService{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if apierr.IsNotFound(err) {
+		r.Log.Info("Creating InferenceService", "name", desired.Name, "namespace", desired.Namespace)
+		return desired, r.Create(ctx, desired)
+	} else if err != nil {
+		return nil, err
+	}
+	// InferenceServices created by users are not taken over
+	if !metav1.IsControlledBy(existing, owner) {
+		return nil, errConflict
+	}
+	updated := existing.DeepCopy()
+	updated.Labels = utils.Union(existing.Labels, desired.Labels)
+	updated.Annotations = utils.Union(existing.Annotations, desired.Annotations)
+	updated.Spec = desired.Spec
+	if equality.Semantic.DeepEqual(existing.Labels, updated.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, updated.Annotations) &&
+		equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+		return existing, nil
+	}
+	r.Log.Info("Updating InferenceService", "name", desired.Name, "namespace", desired.Namespace)
+	return updated, r.Update(ctx, updated)
+}
+
+func (r *TemplatedInferenceServiceReconciler) updateStatus(desired *v1alpha1api.TemplatedInferenceService) error {
+	existing := &v1alpha1api.TemplatedInferenceService{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Status, desired.Status) {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), desired); err != nil {
+		r.Log.Error(err, "Failed to update TemplatedInferenceService status", "TemplatedInferenceService", desired.Name)
+		return errors.Wrapf(err, "fails to update TemplatedInferenceService status")
+	}
+	if rendered := desired.Status.GetCondition(v1alpha1api.TemplateRendered); rendered != nil && rendered.Status == v1.ConditionFalse {
+		r.Recorder.Event(desired, v1.EventTypeWarning, rendered.Reason, rendered.Message)
+	}
+	return nil
+}
+
+// templateToTemplatedInferenceServices maps the changes of an InferenceServiceTemplate to the
+// TemplatedInferenceServices referencing it, so they are rendered again.
+func (r *TemplatedInferenceServiceReconciler) templateToTemplatedInferenceServices(obj client.Object) []reconcile.Request {
+	list := &v1alpha1api.TemplatedInferenceServiceList{}
+	if err := r.List(context.TODO(), list); err != nil {
+		r.Log.Error(err, "Failed to list TemplatedInferenceServices", "InferenceServiceTemplate", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, templated := range list.Items {
+		if templated.Spec.TemplateName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      templated.Name,
+				Namespace: templated.Namespace,
+			}})
+		}
+	}
+	return requests
+}
+
+func (r *TemplatedInferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1api.TemplatedInferenceService{}).
+		Owns(&v1beta1.InferenceService{}).
+		Watches(&source.Kind{Type: &v1alpha1api.InferenceServiceTemplate{}},
+			handler.EnqueueRequestsFromMapFunc(r.templateToTemplatedInferenceServices)).
+		Complete(r)
+}
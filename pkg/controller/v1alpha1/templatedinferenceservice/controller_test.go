@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templatedinferenceservice
+
+import (
+	"context"
+	"testing"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var templatedName = types.NamespacedName{Name: "iris", Namespace: "team-a"}
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	v1alpha1api.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	return s
+}
+
+func newTemplate() *v1alpha1api.InferenceServiceTemplate {
+	return &v1alpha1api.InferenceServiceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn"},
+		Spec: v1alpha1api.InferenceServiceTemplateSpec{
+			Parameters: []v1alpha1api.TemplateParameter{{Name: "storageUri", Pattern: "^s3://models/"}},
+			Template: `metadata:
+  labels:
+    team: {{ .Namespace }}
+spec:
+  predictor:
+    sklearn:
+      storageUri: {{ .Parameters.storageUri }}
+`,
+		},
+	}
+}
+
+func newTemplated(templateName string, parameters map[string]string) *v1alpha1api.TemplatedInferenceService {
+	return &v1alpha1api.TemplatedInferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: templatedName.Name, Namespace: templatedName.Namespace, UID: "templated-uid"},
+		Spec: v1alpha1api.TemplatedInferenceServiceSpec{
+			TemplateName: templateName,
+			Parameters:   parameters,
+		},
+	}
+}
+
+func TestTemplatedInferenceServiceReconcile(t *testing.T) {
+	storageUri := "s3://models/iris"
+	scenarios := map[string]struct {
+		templated       *v1alpha1api.TemplatedInferenceService
+		isvc            *v1beta1.InferenceService
+		expectedStatus  v1.ConditionStatus
+		expectedReason  string
+		expectedStorage *string
+	}{
+		"CreatesInferenceService": {
+			templated:       newTemplated("sklearn", map[string]string{"storageUri": storageUri}),
+			expectedStatus:  v1.ConditionTrue,
+			expectedStorage: &storageUri,
+		},
+		"TemplateNotFound": {
+			templated:      newTemplated("xgboost", map[string]string{"storageUri": storageUri}),
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: TemplateNotFoundReason,
+		},
+		"InvalidParameter": {
+			templated:      newTemplated("sklearn", map[string]string{"storageUri": "gs://other/iris"}),
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: RenderFailedReason,
+		},
+		"ExistingInferenceServiceNotTakenOver": {
+			templated: newTemplated("sklearn", map[string]string{"storageUri": storageUri}),
+			isvc: &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: templatedName.Name, Namespace: templatedName.Namespace},
+			},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: InferenceServiceConflictReason,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			objects := []client.Object{newTemplate(), scenario.templated}
+			if scenario.isvc != nil {
+				objects = append(objects, scenario.isvc)
+			}
+			cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+			reconciler := &TemplatedInferenceServiceReconciler{
+				Client:   cli,
+				Log:      ctrl.Log.WithName("test"),
+				Scheme:   cli.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: templatedName})
+			g.Expect(err).To(gomega.BeNil())
+
+			updated := &v1alpha1api.TemplatedInferenceService{}
+			g.Expect(cli.Get(context.TODO(), templatedName, updated)).To(gomega.Succeed())
+			condition := updated.Status.GetCondition(v1alpha1api.TemplateRendered)
+			g.Expect(condition).NotTo(gomega.BeNil())
+			g.Expect(condition.Status).To(gomega.Equal(scenario.expectedStatus))
+			g.Expect(condition.Reason).To(gomega.Equal(scenario.expectedReason))
+			g.Expect(updated.Status.GetCondition(apis.ConditionReady).IsTrue()).To(gomega.BeFalse())
+
+			isvc := &v1beta1.InferenceService{}
+			err = cli.Get(context.TODO(), templatedName, isvc)
+			if scenario.expectedStorage == nil {
+				if scenario.isvc == nil {
+					g.Expect(err).To(gomega.HaveOccurred())
+				}
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(isvc.Labels).To(gomega.Equal(map[string]string{"team": templatedName.Namespace}))
+			g.Expect(isvc.Spec.Predictor.SKLearn.StorageURI).To(gomega.Equal(scenario.expectedStorage))
+			g.Expect(metav1.IsControlledBy(isvc, updated)).To(gomega.BeTrue())
+		})
+	}
+}
+
+func TestTemplateToTemplatedInferenceServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	other := newTemplated("xgboost", nil)
+	other.Name = "other"
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(newTemplated("sklearn", nil), other).Build()
+	reconciler := &TemplatedInferenceServiceReconciler{Client: cli, Log: ctrl.Log.WithName("test")}
+	g.Expect(reconciler.templateToTemplatedInferenceServices(newTemplate())).
+		To(gomega.Equal([]reconcile.Request{{NamespacedName: templatedName}}))
+}
@@ -27,7 +27,10 @@ package trainedmodel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -35,6 +38,7 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	pkgmodelconfig "github.com/kserve/kserve/pkg/modelconfig"
 	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -44,6 +48,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -55,10 +60,21 @@ const (
 	FrameworkNotSupported      = "Inference Service \"%s\" does not support the Trained Model \"%s\" framework \"%s\""
 	MemoryResourceNotAvailable = "Inference Service \"%s\" memory resources are not available. Trained Model \"%s\" cannot deploy"
 	IsNotMMSPredictor          = "Inference Service \"%s\" predictor is not configured for multi-model serving. Trained Model \"%s\" cannot deploy"
+	ModelNotReported           = "The model server has not reported the load result of the Trained Model yet"
+)
+
+const (
+	// The load state is polled until the model server reports the model loaded
+	modelLoadingRequeuePeriod = 10 * time.Second
+	// The model server may still load a model which failed to load, e.g. once memory is freed
+	modelLoadFailedRequeuePeriod = 60 * time.Second
 )
 
 var log = logf.Log.WithName("TrainedModel controller")
 
+// The agents of the multi-model predictors answer the model states of the model servers
+var modelStatusClient = &http.Client{Timeout: 5 * time.Second}
+
 // TrainedModelReconciler reconciles a TrainedModel object
 type TrainedModelReconciler struct {
 	client.Client
@@ -147,7 +163,118 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.ModelConfigReconciler.Reconcile(req, tm); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// The TrainedModel is ready once the model server confirms it loaded the model
+	return r.updateModelLoaded(req, tm)
+}
+
+// updateModelLoaded sets the ModelLoaded condition from the load state of the model reported by the agent of the
+// parent InferenceService, the TrainedModel is requeued until the model is loaded
+func (r *TrainedModelReconciler) updateModelLoaded(req ctrl.Request, tm *v1alpha1api.TrainedModel) (ctrl.Result, error) {
+	isvc := &v1beta1api.InferenceService{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: req.Namespace, Name: tm.Spec.InferenceService}, isvc); err != nil {
+		return ctrl.Result{}, err
+	}
+	condition := &apis.Condition{
+		Type:    v1alpha1api.ModelLoaded,
+		Status:  v1.ConditionUnknown,
+		Reason:  "ModelStatusUnavailable",
+		Message: ModelNotReported,
+	}
+	if states, err := getModelStates(modelStatusURL(isvc)); err != nil {
+		log.Info("Failed to get the model states", "TrainedModel", tm.Name, "InferenceService", isvc.Name, "error", err)
+		condition.Message = fmt.Sprintf("Failed to get the model states of Inference Service %q: %v", isvc.Name, err)
+	} else {
+		condition = modelLoadedCondition(states, tm.Name)
+	}
+	tm.Status.SetCondition(v1alpha1api.ModelLoaded, condition)
+
+	existingModel := &v1alpha1api.TrainedModel{}
+	if err := r.Get(context.TODO(), req.NamespacedName, existingModel); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !equality.Semantic.DeepEqual(existingModel.Status, tm.Status) {
+		if err := r.Status().Update(context.TODO(), tm); err != nil {
+			r.Recorder.Eventf(tm, v1.EventTypeWarning, "UpdateFailed",
+				"Failed to update status for TrainedModel %q: %v", tm.Name, err)
+			return ctrl.Result{}, err
+		}
+		if condition.Status == v1.ConditionFalse {
+			r.Recorder.Eventf(tm, v1.EventTypeWarning, "ModelLoadFailed", condition.Message)
+		}
+	}
+
+	switch condition.Status {
+	case v1.ConditionTrue:
+		return ctrl.Result{}, nil
+	case v1.ConditionFalse:
+		return ctrl.Result{RequeueAfter: modelLoadFailedRequeuePeriod}, nil
+	default:
+		return ctrl.Result{RequeueAfter: modelLoadingRequeuePeriod}, nil
+	}
+}
+
+// modelStatusURL returns the url of the model states of the agents of the predictor of the inference service
+func modelStatusURL(isvc *v1beta1api.InferenceService) string {
+	return fmt.Sprintf("http://%s%s", network.GetServiceHostname(constants.DefaultPredictorServiceName(isvc.Name),
+		isvc.Namespace), constants.AgentModelStatusPath)
+}
+
+func getModelStates(url string) (pkgmodelconfig.ModelStates, error) {
+	resp, err := modelStatusClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var states pkgmodelconfig.ModelStates
+	if err := json.NewDecoder(resp.Body).Decode(&states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// modelLoadedCondition returns the ModelLoaded condition of the model state, the failures carry the reason reported
+// by the model server, e.g. the model format is not supported or the server is out of memory
+func modelLoadedCondition(states pkgmodelconfig.ModelStates, name string) *apis.Condition {
+	state := states.Find(name)
+	switch {
+	case state == nil:
+		return &apis.Condition{
+			Type:    v1alpha1api.ModelLoaded,
+			Status:  v1.ConditionUnknown,
+			Reason:  "ModelNotReported",
+			Message: ModelNotReported,
+		}
+	case state.State == constants.ModelStateReady:
+		return &apis.Condition{
+			Type:   v1alpha1api.ModelLoaded,
+			Status: v1.ConditionTrue,
+		}
+	case state.State == constants.ModelStateUnavailable:
+		condition := &apis.Condition{
+			Type:    v1alpha1api.ModelLoaded,
+			Status:  v1.ConditionFalse,
+			Reason:  "ModelLoadFailed",
+			Message: state.Reason,
+		}
+		if condition.Message == "" {
+			condition.Message = "The model server failed to load the Trained Model"
+		}
+		return condition
+	default:
+		return &apis.Condition{
+			Type:    v1alpha1api.ModelLoaded,
+			Status:  v1.ConditionUnknown,
+			Reason:  "ModelLoading",
+			Message: fmt.Sprintf("The model server reports the Trained Model %s", state.State),
+		}
+	}
 }
 
 func (r *TrainedModelReconciler) updateStatus(req ctrl.Request, desiredModel *v1alpha1api.TrainedModel) error {
@@ -157,10 +284,13 @@ func (r *TrainedModelReconciler) updateStatus(req ctrl.Request, desiredModel *v1
 		return err
 	}
 
+	// The path of the model is routed at the gateway of the parent inference service once the model is registered
+	path := predictPath(isvc, desiredModel.Name)
+
 	// Check if parent inference service has the status URL
 	if isvc.Status.URL != nil {
 		// Update status to contain the isvc URL with /v1/models/trained-model-name:predict appended
-		url := isvc.Status.URL.String() + constants.PredictPath(desiredModel.Name, isvc.Spec.Predictor.GetImplementation().GetProtocol())
+		url := isvc.Status.URL.String() + path
 		externURL, err := apis.ParseURL(url)
 		if err != nil {
 			return err
@@ -172,7 +302,7 @@ func (r *TrainedModelReconciler) updateStatus(req ctrl.Request, desiredModel *v1
 	if isvc.Status.Address != nil {
 		if isvc.Status.Address.URL != nil {
 			////Update status to contain the isvc address with /v1/models/trained-model-name:predict appended
-			url := isvc.Status.Address.URL.String() + constants.PredictPath(desiredModel.Name, isvc.Spec.Predictor.GetImplementation().GetProtocol())
+			url := isvc.Status.Address.URL.String() + path
 			clusterURL, err := apis.ParseURL(url)
 			if err != nil {
 				return err
@@ -205,6 +335,15 @@ func (r *TrainedModelReconciler) updateStatus(req ctrl.Request, desiredModel *v1
 	return nil
 }
 
+// predictPath returns the predict path of the model on its parent inference service, the transformer only supports
+// the protocol V1 as of now
+func predictPath(isvc *v1beta1api.InferenceService, name string) string {
+	if isvc.Spec.Transformer != nil {
+		return constants.PredictPath(name, constants.ProtocolV1)
+	}
+	return constants.PredictPath(name, isvc.Spec.Predictor.GetImplementation().GetProtocol())
+}
+
 func (r *TrainedModelReconciler) updateConditions(req ctrl.Request, tm *v1alpha1api.TrainedModel) error {
 	// Get the parent inference service
 	isvc := &v1beta1api.InferenceService{}
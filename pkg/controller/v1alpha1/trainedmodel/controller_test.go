@@ -18,12 +18,16 @@ package trainedmodel
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
@@ -797,3 +801,69 @@ var _ = Describe("v1beta1 TrainedModel controller", func() {
 		})
 	})
 })
+
+func TestModelLoadedCondition(t *testing.T) {
+	states := modelconfig.ModelStates{
+		{Name: "loaded", State: constants.ModelStateReady},
+		{Name: "loading", State: constants.ModelStateLoading},
+		{Name: "oom", State: constants.ModelStateUnavailable, Reason: "out of memory"},
+		{Name: "failed", State: constants.ModelStateUnavailable},
+	}
+	scenarios := map[string]struct {
+		name     string
+		expected *apis.Condition
+	}{
+		"Loaded": {
+			name:     "loaded",
+			expected: &apis.Condition{Type: v1alpha1api.ModelLoaded, Status: v1.ConditionTrue},
+		},
+		"Loading": {
+			name: "loading",
+			expected: &apis.Condition{Type: v1alpha1api.ModelLoaded, Status: v1.ConditionUnknown, Reason: "ModelLoading",
+				Message: "The model server reports the Trained Model LOADING"},
+		},
+		"OutOfMemory": {
+			name: "oom",
+			expected: &apis.Condition{Type: v1alpha1api.ModelLoaded, Status: v1.ConditionFalse, Reason: "ModelLoadFailed",
+				Message: "out of memory"},
+		},
+		"FailedWithoutReason": {
+			name: "failed",
+			expected: &apis.Condition{Type: v1alpha1api.ModelLoaded, Status: v1.ConditionFalse, Reason: "ModelLoadFailed",
+				Message: "The model server failed to load the Trained Model"},
+		},
+		"NotReported": {
+			name: "unknown",
+			expected: &apis.Condition{Type: v1alpha1api.ModelLoaded, Status: v1.ConditionUnknown, Reason: "ModelNotReported",
+				Message: ModelNotReported},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(modelLoadedCondition(states, scenario.name)).To(Equal(scenario.expected))
+		})
+	}
+}
+
+func TestGetModelStates(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != constants.AgentModelStatusPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"name": "model1", "state": "READY"}]`))
+	}))
+	defer server.Close()
+
+	states, err := getModelStates(server.URL + constants.AgentModelStatusPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(states).To(Equal(modelconfig.ModelStates{{Name: "model1", State: constants.ModelStateReady}}))
+	// An agent without the model status path
+	_, err = getModelStates(server.URL + "/unknown")
+	g.Expect(err).To(MatchError("unexpected status 404"))
+
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default"}}
+	g.Expect(modelStatusURL(isvc)).To(Equal("http://my-model-predictor-default.default.svc.cluster.local/agent/models"))
+}
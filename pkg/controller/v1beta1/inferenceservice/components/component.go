@@ -22,12 +22,19 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/serviceaccount"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // Component can be reconciled to create underlying resources for an InferenceService
@@ -56,6 +63,52 @@ func addStorageSpecAnnotations(storageSpec *v1beta1.StorageSpec, annotations map
 	return true
 }
 
+// PredictorStorageAnnotations returns the annotations the storage initializer downloads the model of the predictor with
+func PredictorStorageAnnotations(isvc *v1beta1.InferenceService) map[string]string {
+	annotations := map[string]string{}
+	predictor := isvc.Spec.Predictor.GetImplementation()
+	addStorageSpecAnnotations(predictor.GetStorageSpec(), annotations)
+	if sourceURI := predictor.GetStorageUri(); sourceURI != nil {
+		annotations[constants.StorageInitializerSourceUriInternalAnnotationKey] = *sourceURI
+	}
+	return annotations
+}
+
+// smokeTestExtension returns the extension of the component with no traffic to the canary while the smoke test has
+// not passed on the revision created for it, the traffic is split again once the smoke test passed
+func smokeTestExtension(isvc *v1beta1.InferenceService, component v1beta1.ComponentType,
+	extension *v1beta1.ComponentExtensionSpec) *v1beta1.ComponentExtensionSpec {
+	if isvc.Spec.SmokeTest == nil || extension.CanaryTrafficPercent == nil ||
+		v1beta1.SmokeTestComponent(isvc) != component {
+		return extension
+	}
+	status := isvc.Status.Components[component]
+	candidate := status.LatestCreatedRevision
+	if candidate == "" {
+		candidate = status.LatestReadyRevision
+	}
+	// The rolled out revision keeps its traffic, holding it would roll it back
+	if status.LatestRolledoutRevision == "" || candidate == "" || candidate == status.LatestRolledoutRevision ||
+		isvc.Status.SmokeTestPassed(candidate) {
+		return extension
+	}
+	held := extension.DeepCopy()
+	held.CanaryTrafficPercent = proto.Int64(0)
+	return held
+}
+
+// suspendedExtension returns the extension of a serverless component of a suspended InferenceService, its revision
+// scales to zero once the requests rejected at the gateway stop reaching it
+func suspendedExtension(isvc *v1beta1.InferenceService,
+	extension *v1beta1.ComponentExtensionSpec) *v1beta1.ComponentExtensionSpec {
+	if !v1beta1utils.IsSuspended(isvc.Annotations) {
+		return extension
+	}
+	suspended := extension.DeepCopy()
+	suspended.MinReplicas = v1beta1.GetIntReference(0)
+	return suspended
+}
+
 func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]string) bool {
 	if logger != nil {
 		annotations[constants.LoggerInternalAnnotationKey] = "true"
@@ -63,6 +116,29 @@ func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]str
 			annotations[constants.LoggerSinkUrlInternalAnnotationKey] = *logger.URL
 		}
 		annotations[constants.LoggerModeInternalAnnotationKey] = string(logger.Mode)
+		if logger.Delivery != "" {
+			annotations[constants.LoggerDeliveryInternalAnnotationKey] = string(logger.Delivery)
+		}
+		if logger.SamplingPercent != nil {
+			annotations[constants.LoggerSamplingPercentInternalAnnotationKey] =
+				strconv.FormatInt(int64(*logger.SamplingPercent), 10)
+		}
+		if logger.OptOutHeader != "" {
+			annotations[constants.LoggerOptOutHeaderInternalAnnotationKey] = logger.OptOutHeader
+		}
+		if len(logger.ResponseCodes) > 0 {
+			codes := make([]string, 0, len(logger.ResponseCodes))
+			for _, code := range logger.ResponseCodes {
+				codes = append(codes, string(code))
+			}
+			annotations[constants.LoggerResponseCodesInternalAnnotationKey] = strings.Join(codes, ",")
+		}
+		if logger.Redact != nil {
+			// The redaction is passed to the agent as JSON as its paths and patterns may contain any character
+			if redact, err := json.Marshal(logger.Redact); err == nil {
+				annotations[constants.LoggerRedactInternalAnnotationKey] = string(redact)
+			}
+		}
 		return true
 	}
 	return false
@@ -84,6 +160,68 @@ func addBatcherAnnotations(batcher *v1beta1.Batcher, annotations map[string]stri
 			s := strconv.Itoa(*batcher.Timeout)
 			annotations[constants.BatcherTimeoutInternalAnnotationKey] = s
 		}
+		if batcher.TargetP99Latency != nil {
+			s := strconv.Itoa(*batcher.TargetP99Latency)
+			annotations[constants.BatcherTargetP99LatencyInternalAnnotationKey] = s
+		}
+		if batcher.PriorityHeader != "" {
+			annotations[constants.BatcherPriorityHeaderInternalAnnotationKey] = batcher.PriorityHeader
+		}
+		return true
+	}
+	return false
+}
+
+// addAgentConfigAnnotations mounts the agent config to the components with a logger, a batcher or a maintenance mode
+// when the agent hot reload is enabled. The logger and batcher settings the agent reloads are removed from the pod
+// annotations, so changing them does not roll out a new revision.
+func addAgentConfigAnnotations(isvc *v1beta1.InferenceService, annotations map[string]string) bool {
+	if isvc.Annotations[constants.AgentHotReloadAnnotationKey] != "true" {
+		return false
+	}
+	_, hasLogger := annotations[constants.LoggerInternalAnnotationKey]
+	_, hasBatcher := annotations[constants.BatcherInternalAnnotationKey]
+	_, hasMaintenance := isvc.Annotations[constants.MaintenanceAnnotationKey]
+	if !hasLogger && !hasBatcher && !hasMaintenance {
+		return false
+	}
+	annotations[constants.AgentConfigInternalAnnotationKey] = constants.AgentConfigName(isvc.Name)
+	for _, key := range []string{
+		constants.LoggerSinkUrlInternalAnnotationKey,
+		constants.LoggerModeInternalAnnotationKey,
+		constants.BatcherMaxBatchSizeInternalAnnotationKey,
+		constants.BatcherMaxLatencyInternalAnnotationKey,
+	} {
+		delete(annotations, key)
+	}
+	return true
+}
+
+func addRateLimitAnnotations(rateLimit *v1beta1.RateLimit, annotations map[string]string) bool {
+	if rateLimit != nil {
+		annotations[constants.RateLimitRPSInternalAnnotationKey] = strconv.Itoa(rateLimit.RequestsPerSecond)
+		if rateLimit.Burst != nil {
+			annotations[constants.RateLimitBurstInternalAnnotationKey] = strconv.Itoa(*rateLimit.Burst)
+		}
+		return true
+	}
+	return false
+}
+
+// addCloudIdentityAnnotations mounts the cloud credentials of the service account of the component in the kserve-container
+func addCloudIdentityAnnotations(componentExt *v1beta1.ComponentExtensionSpec, annotations map[string]string) bool {
+	if componentExt.MountCloudIdentity {
+		annotations[constants.CloudIdentityInternalAnnotationKey] = "true"
+		return true
+	}
+	return false
+}
+
+// addConcurrencyMetricsAnnotations injects the agent reporting the requests in flight to the raw deployments scaling on
+// concurrency, the serverless deployments are scaled on the concurrency reported by the queue proxy.
+func addConcurrencyMetricsAnnotations(componentExt *v1beta1.ComponentExtensionSpec, annotations map[string]string) bool {
+	if componentExt.ScaleMetric != nil && *componentExt.ScaleMetric == v1beta1.MetricConcurrency {
+		annotations[constants.ConcurrencyMetricsInternalAnnotationKey] = "true"
 		return true
 	}
 	return false
@@ -103,3 +241,38 @@ func addAgentAnnotations(isvc *v1beta1.InferenceService, annotations map[string]
 	}
 	return false
 }
+
+// reconcileServiceAccount creates a dedicated service account for the component when the InferenceService sets the
+// create-service-account annotation, instead of running with the default service account of the namespace. The
+// components which set their own service account are left untouched. The cloud identity annotations are set on the
+// service account before the pods are rolled out, they are also part of the pod template annotations so the pods are
+// recreated with the new identity when they change.
+func reconcileServiceAccount(cl client.Client, scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
+	componentMeta metav1.ObjectMeta, podSpec *v1.PodSpec) error {
+	if isvc.Annotations[constants.CreateServiceAccountAnnotationKey] != "true" || podSpec.ServiceAccountName != "" {
+		return nil
+	}
+	var secrets []string
+	for _, secret := range strings.Split(isvc.Annotations[constants.ServiceAccountSecretsAnnotationKey], ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	r := serviceaccount.NewServiceAccountReconciler(cl, scheme, componentMeta, secrets)
+	if err := controllerutil.SetControllerReference(isvc, r.ServiceAccount, scheme); err != nil {
+		return err
+	}
+	if r.Role != nil {
+		if err := controllerutil.SetControllerReference(isvc, r.Role, scheme); err != nil {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(isvc, r.RoleBinding, scheme); err != nil {
+			return err
+		}
+	}
+	if err := r.Reconcile(); err != nil {
+		return err
+	}
+	podSpec.ServiceAccountName = r.ServiceAccount.Name
+	return nil
+}
@@ -17,17 +17,26 @@ limitations under the License.
 package components
 
 import (
+	"context"
+	"strconv"
+	"strings"
+
 	"github.com/go-logr/logr"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -68,17 +77,31 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		annotations[constants.StorageInitializerSourceUriInternalAnnotationKey] = *sourceURI
 	}
 	addLoggerAnnotations(isvc.Spec.Explainer.Logger, annotations)
+	addAgentConfigAnnotations(isvc, annotations)
+	addRateLimitAnnotations(isvc.Spec.Explainer.RateLimit, annotations)
+	addCloudIdentityAnnotations(&isvc.Spec.Explainer.ComponentExtensionSpec, annotations)
 	// Add StorageSpec annotations so mutator will mount storage credentials to InferenceService's explainer
 	addStorageSpecAnnotations(explainer.GetStorageSpec(), annotations)
+	// Labels and annotations from isvc will overwrite the default log routing labels and annotations
+	logAnnotations, logLabels := e.inferenceServiceConfig.LogRouting.GetPodMetadata("")
 	objectMeta := metav1.ObjectMeta{
 		Name:      constants.DefaultExplainerServiceName(isvc.Name),
 		Namespace: isvc.Namespace,
-		Labels: utils.Union(isvc.Labels, map[string]string{
+		Labels: utils.Union(logLabels, isvc.Labels, map[string]string{
 			constants.InferenceServicePodLabelKey: isvc.Name,
 			constants.KServiceComponentLabel:      string(v1beta1.ExplainerComponent),
 		}),
-		Annotations: annotations,
+		Annotations: utils.Union(logAnnotations, annotations),
+	}
+	deployConfig, err := v1beta1.NewDeployConfig(e.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// The explainer container is added to the predictor pods by the predictor
+	if isvcutils.IsExplainerColocated(isvc, deployConfig) {
+		return e.reconcileColocated(isvc, objectMeta)
 	}
+
 	container := explainer.GetContainer(isvc.ObjectMeta, isvc.Spec.Explainer.GetExtensions(), e.inferenceServiceConfig)
 	if len(isvc.Spec.Explainer.PodSpec.Containers) == 0 {
 		isvc.Spec.Explainer.PodSpec.Containers = []v1.Container{
@@ -89,13 +112,14 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	}
 
 	podSpec := v1.PodSpec(isvc.Spec.Explainer.PodSpec)
-	deployConfig, err := v1beta1.NewDeployConfig(e.client)
-	if err != nil {
-		return ctrl.Result{}, err
+
+	if err := reconcileServiceAccount(e.client, e.scheme, isvc, objectMeta, &podSpec); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile service account for explainer")
 	}
 
 	// Here we allow switch between knative and vanilla deployment
 	if isvcutils.GetDeploymentMode(annotations, deployConfig) == constants.RawDeployment {
+		addConcurrencyMetricsAnnotations(&isvc.Spec.Explainer.ComponentExtensionSpec, objectMeta.Annotations)
 		r, err := raw.NewRawKubeReconciler(e.client, e.scheme, objectMeta, &isvc.Spec.Explainer.ComponentExtensionSpec,
 			&podSpec)
 		if err != nil {
@@ -115,15 +139,40 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 				return ctrl.Result{}, errors.Wrapf(err, "fails to set HPA owner reference for explainer")
 			}
 		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDA {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDA.ScaledObject, e.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set ScaledObject owner reference for explainer")
+			}
+		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDAHTTP {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDAHTTP.HTTPScaledObject, e.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set HTTPScaledObject owner reference for explainer")
+			}
+			if err := r.RouteHosts(isvc); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to route the ingress hosts of the explainer")
+			}
+		}
+		if r.Service.OriginService != nil {
+			if err := controllerutil.SetControllerReference(isvc, r.Service.OriginService, e.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set origin service owner reference for explainer")
+			}
+		}
+
+		// The deployment of a suspended InferenceService is scaled to zero without an autoscaler
+		if isvcutils.IsSuspended(isvc.Annotations) {
+			r.PinReplicas(0)
+		}
 
 		deployment, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile explainer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.ExplainerComponent, deployment, r.URL)
+		isvc.Status.PropagateScalingStatus(v1beta1.ExplainerComponent, r.Scaler.Autoscaler.HPAStatus)
+		isvc.Status.PropagateScaledObjectStatus(v1beta1.ExplainerComponent, r.Scaler.Autoscaler.ScaledObjectConditions)
 	} else {
-		r := knative.NewKsvcReconciler(e.client, e.scheme, objectMeta, &isvc.Spec.Explainer.ComponentExtensionSpec,
-			&podSpec, isvc.Status.Components[v1beta1.ExplainerComponent])
+		r := knative.NewKsvcReconciler(e.client, e.scheme, objectMeta,
+			suspendedExtension(isvc, &isvc.Spec.Explainer.ComponentExtensionSpec), &podSpec, isvc.Status.Components[v1beta1.ExplainerComponent])
 
 		if err := controllerutil.SetControllerReference(isvc, r.Service, e.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set owner reference for explainer")
@@ -136,3 +185,86 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	}
 	return ctrl.Result{}, nil
 }
+
+// reconcileColocated routes the explainer service to the explainer sidecar of the predictor pods, the explainer is ready
+// when the predictor deployment is available. The deployment and autoscaler of the explainer are deleted when it moves
+// to the predictor pods.
+func (e *Explainer) reconcileColocated(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) (ctrl.Result, error) {
+	r := service.NewSidecarServiceReconciler(e.client, e.scheme, objectMeta,
+		constants.DefaultPredictorServiceName(isvc.Name), constants.ColocatedExplainerPort)
+	if err := controllerutil.SetControllerReference(isvc, r.Service, e.scheme); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to set service owner reference for explainer")
+	}
+	if _, err := r.Reconcile(); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile colocated explainer service")
+	}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &v2beta2.HorizontalPodAutoscaler{}} {
+		if err := e.client.Get(context.TODO(), types.NamespacedName{Namespace: objectMeta.Namespace,
+			Name: objectMeta.Name}, obj); err != nil {
+			if apierr.IsNotFound(err) {
+				continue
+			}
+			return ctrl.Result{}, err
+		}
+		if metav1.IsControlledBy(obj, isvc) {
+			e.Log.Info("Deleting the explainer resource replaced by the colocated explainer", "name", obj.GetName())
+			if err := client.IgnoreNotFound(e.client.Delete(context.TODO(), obj)); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	predictor := &appsv1.Deployment{}
+	if err := e.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace,
+		Name: constants.DefaultPredictorServiceName(isvc.Name)}, predictor); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to get the predictor deployment of the colocated explainer")
+	}
+	url, err := raw.CreateRawURL(e.client, objectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	isvc.Status.PropagateRawStatus(v1beta1.ExplainerComponent, predictor, url)
+	return ctrl.Result{}, nil
+}
+
+// colocatedExplainerContainer returns the explainer container running as a sidecar of the predictor pods, it shares
+// the model volume of the predictor and sends its requests to the predictor container over localhost
+func colocatedExplainerContainer(isvc *v1beta1.InferenceService, predictorContainer *v1.Container,
+	config *v1beta1.InferenceServicesConfig) *v1.Container {
+	// The explainer spec is copied, getting the container of the explainer appends its arguments
+	explainerSpec := isvc.Spec.Explainer.DeepCopy()
+	container := explainerSpec.GetImplementation().GetContainer(isvc.ObjectMeta, explainerSpec.GetExtensions(), config)
+	predictorPort := constants.InferenceServiceDefaultHttpPort
+	if len(predictorContainer.Ports) != 0 {
+		predictorPort = strconv.Itoa(int(predictorContainer.Ports[0].ContainerPort))
+	}
+	container.Name = constants.ExplainerContainerName
+	container.Args = setArg(container.Args, constants.ArgumentHttpPort, constants.ColocatedExplainerPortStr)
+	container.Args = setArg(container.Args, constants.ArgumentPredictorHost, "localhost:"+predictorPort)
+	container.Ports = []v1.ContainerPort{
+		{
+			ContainerPort: constants.ColocatedExplainerPort,
+			Protocol:      v1.ProtocolTCP,
+		},
+	}
+	return container
+}
+
+// setArg sets the value of all the occurrences of the argument, as --name value or --name=value, or appends it
+func setArg(args []string, name string, value string) []string {
+	found := false
+	for i := 0; i < len(args); i++ {
+		if args[i] == name && i+1 < len(args) {
+			args[i+1] = value
+			found = true
+			i++
+		} else if strings.HasPrefix(args[i], name+"=") {
+			args[i] = name + "=" + value
+			found = true
+		}
+	}
+	if !found {
+		args = append(args, name, value)
+	}
+	return args
+}
@@ -18,9 +18,11 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/conversion"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
 	raw "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
@@ -29,8 +31,10 @@ import (
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,15 +48,17 @@ var _ Component = &Predictor{}
 // Predictor reconciles resources for this component.
 type Predictor struct {
 	client                 client.Client
+	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
 	credentialBuilder      *credentials.CredentialBuilder
 	Log                    logr.Logger
 }
 
-func NewPredictor(client client.Client, scheme *runtime.Scheme, inferenceServiceConfig *v1beta1.InferenceServicesConfig) Component {
+func NewPredictor(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, inferenceServiceConfig *v1beta1.InferenceServicesConfig) Component {
 	return &Predictor{
 		client:                 client,
+		clientset:              clientset,
 		scheme:                 scheme,
 		inferenceServiceConfig: inferenceServiceConfig,
 		Log:                    ctrl.Log.WithName("PredictorReconciler"),
@@ -72,6 +78,9 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 
 	addLoggerAnnotations(isvc.Spec.Predictor.Logger, annotations)
 	addBatcherAnnotations(isvc.Spec.Predictor.Batcher, annotations)
+	addAgentConfigAnnotations(isvc, annotations)
+	addRateLimitAnnotations(isvc.Spec.Predictor.RateLimit, annotations)
+	addCloudIdentityAnnotations(&isvc.Spec.Predictor.ComponentExtensionSpec, annotations)
 	// Add StorageSpec annotations so mutator will mount storage credentials to InferenceService's predictor
 	addStorageSpecAnnotations(isvc.Spec.Predictor.GetImplementation().GetStorageSpec(), annotations)
 	// Add agent annotations so mutator will mount model agent to multi-model InferenceService's predictor
@@ -134,6 +143,16 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			if err != nil {
 				return ctrl.Result{}, err
 			}
+			// Skip the runtimes which are not published for the architecture selected by the predictor
+			if arch, ok := isvc.Spec.Predictor.NodeSelector[v1.LabelArchStable]; ok {
+				archRuntimes := []v1alpha1.SupportedRuntime{}
+				for _, runtime := range runtimes {
+					if runtime.Spec.IsArchitectureSupported(arch) {
+						archRuntimes = append(archRuntimes, runtime)
+					}
+				}
+				runtimes = archRuntimes
+			}
 			if len(runtimes) == 0 {
 				isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 					Reason:  v1beta1.NoSupportingRuntime,
@@ -184,6 +203,14 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "failed to consolidate serving runtime PodSpecs")
 		}
 
+		if err := isvcutils.SetArchitectureAffinity(mergedPodSpec, &sRuntime); err != nil {
+			isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
+				Reason:  v1beta1.NoSupportingRuntime,
+				Message: "Specified runtime does not support the requested node architecture",
+			})
+			return ctrl.Result{}, errors.Wrapf(err, "failed to set the architecture node affinity")
+		}
+
 		// Other dependencies rely on the container to be a specific name.
 		container.Name = constants.InferenceServiceContainerName
 
@@ -232,15 +259,27 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		annotations[constants.StorageInitializerSourceUriInternalAnnotationKey] = *sourceURI
 	}
 
-	// Labels and annotations from isvc will overwrite labels and annotations from ServingRuntimePodSpec
+	var runtimeName string
+	if isvc.Spec.Predictor.Model != nil && isvc.Spec.Predictor.Model.Runtime != nil {
+		runtimeName = *isvc.Spec.Predictor.Model.Runtime
+	}
+	logAnnotations, logLabels := p.inferenceServiceConfig.LogRouting.GetPodMetadata(runtimeName)
+
+	// Labels and annotations from isvc will overwrite labels and annotations from ServingRuntimePodSpec, which
+	// overwrite the log routing labels and annotations of the runtime
 	objectMeta := metav1.ObjectMeta{
 		Name:      constants.DefaultPredictorServiceName(isvc.Name),
 		Namespace: isvc.Namespace,
-		Labels: utils.Union(sRuntimeLabels, isvc.Labels, map[string]string{
+		Labels: utils.Union(logLabels, sRuntimeLabels, isvc.Labels, map[string]string{
 			constants.InferenceServicePodLabelKey: isvc.Name,
 			constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
 		}),
-		Annotations: utils.Union(sRuntimeAnnotations, annotations),
+		Annotations: utils.Union(logAnnotations, sRuntimeAnnotations, annotations),
+	}
+
+	applyOOMRemediation(isvc, &podSpec.Containers[0])
+	if isvc.Spec.Predictor.Optimization != nil {
+		isvcutils.SetContainerEnv(&podSpec.Containers[0], isvcutils.GraphOptimizationEnv(isvc.Spec.Predictor.Optimization))
 	}
 
 	p.Log.Info("Resolved container", "container", container, "podSpec", podSpec)
@@ -254,10 +293,25 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	var podLabelKey string
 	var podLabelValue string
 
+	if err := reconcileServiceAccount(p.client, p.scheme, isvc, objectMeta, &podSpec); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile service account for predictor")
+	}
+
+	if isvc.Spec.Predictor.Conversion != nil {
+		if result, err := p.reconcileConversion(isvc, objectMeta.Annotations, &podSpec); err != nil || !result.IsZero() {
+			return result, err
+		}
+	}
+
 	// Here we allow switch between knative and vanilla deployment
 	if isvcutils.GetDeploymentMode(annotations, deployConfig) == constants.RawDeployment {
 		rawDeployment = true
 		podLabelKey = constants.RawDeploymentAppLabel
+		addConcurrencyMetricsAnnotations(&isvc.Spec.Predictor.ComponentExtensionSpec, objectMeta.Annotations)
+		if isvcutils.IsExplainerColocated(isvc, deployConfig) {
+			podSpec.Containers = append(podSpec.Containers,
+				*colocatedExplainerContainer(isvc, &podSpec.Containers[0], p.inferenceServiceConfig))
+		}
 		r, err := raw.NewRawKubeReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
 			&podSpec)
 		if err != nil {
@@ -277,16 +331,43 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 				return ctrl.Result{}, errors.Wrapf(err, "fails to set HPA owner reference for predictor")
 			}
 		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDA {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDA.ScaledObject, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set ScaledObject owner reference for predictor")
+			}
+		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDAHTTP {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDAHTTP.HTTPScaledObject, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set HTTPScaledObject owner reference for predictor")
+			}
+			if err := r.RouteHosts(isvc); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to route the ingress hosts of the predictor")
+			}
+		}
+		if r.Service.OriginService != nil {
+			if err := controllerutil.SetControllerReference(isvc, r.Service.OriginService, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set origin service owner reference for predictor")
+			}
+		}
+
+		// The deployment of a suspended InferenceService is scaled to zero without an autoscaler
+		if isvcutils.IsSuspended(isvc.Annotations) {
+			r.PinReplicas(0)
+		}
 
 		deployment, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile predictor")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.PredictorComponent, deployment, r.URL)
+		isvc.Status.PropagateScalingStatus(v1beta1.PredictorComponent, r.Scaler.Autoscaler.HPAStatus)
+		isvc.Status.PropagateScaledObjectStatus(v1beta1.PredictorComponent, r.Scaler.Autoscaler.ScaledObjectConditions)
 	} else {
 		podLabelKey = constants.RevisionLabel
-		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
-			&podSpec, isvc.Status.Components[v1beta1.PredictorComponent])
+		extension := suspendedExtension(isvc,
+			smokeTestExtension(isvc, v1beta1.PredictorComponent, &isvc.Spec.Predictor.ComponentExtensionSpec))
+		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, extension, &podSpec,
+			isvc.Status.Components[v1beta1.PredictorComponent])
 		if err := controllerutil.SetControllerReference(isvc, r.Service, p.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set owner reference for predictor")
 		}
@@ -307,6 +388,106 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrapf(err, "fails to list inferenceservice pods by label")
 	}
 	isvc.Status.PropagateModelStatus(statusSpec, podList, rawDeployment)
+	p.addFailureLogTail(isvc, podList)
+	p.remediateOOM(isvc, podList, &podSpec.Containers[0])
+
+	return ctrl.Result{}, nil
+}
 
+// addFailureLogTail adds the tail of the logs of the crashed kserve container to the model failure info, so the
+// cause of the crash loop is visible without access to the node or the pod logs.
+func (p *Predictor) addFailureLogTail(isvc *v1beta1.InferenceService, podList *v1.PodList) {
+	info := isvc.Status.ModelStatus.LastFailureInfo
+	if p.clientset == nil || info == nil || info.TerminationReason == "" || len(podList.Items) == 0 ||
+		podList.Items[0].Name != info.Location {
+		return
+	}
+	logs, err := isvcutils.GetTerminatedContainerLogTail(p.clientset, &podList.Items[0], constants.InferenceServiceContainerName)
+	if err != nil {
+		p.Log.Error(err, "Failed to get the logs of the crashed container", "pod", info.Location)
+		return
+	}
+	info.LogTail = logs
+}
+
+// applyOOMRemediation raises the memory of the kserve container to the memory recorded by the OOM remediation policy.
+func applyOOMRemediation(isvc *v1beta1.InferenceService, container *v1.Container) {
+	remediation := isvc.Status.ModelStatus.OOMRemediation
+	if _, ok := isvc.Annotations[constants.OOMMemoryFactorAnnotationKey]; !ok || remediation == nil {
+		return
+	}
+	if memory, err := resource.ParseQuantity(remediation.Memory); err == nil {
+		isvcutils.SetContainerMemory(container, memory)
+	}
+}
+
+// remediateOOM raises the memory of the kserve container by the factor of the OOM remediation policy when the model
+// server of the latest pod was OOMKilled. The raised memory is recorded in the model status and applied by the next
+// reconcile, the pods running with less memory than the kserve container are not remediated again.
+func (p *Predictor) remediateOOM(isvc *v1beta1.InferenceService, podList *v1.PodList, container *v1.Container) {
+	info := isvc.Status.ModelStatus.LastFailureInfo
+	if info == nil || info.TerminationReason != constants.StateReasonOOMKilled || len(podList.Items) == 0 ||
+		podList.Items[0].Name != info.Location {
+		return
+	}
+	memory, ok := isvcutils.GetContainerMemory(container)
+	if !ok {
+		return
+	}
+	for i := range podList.Items[0].Spec.Containers {
+		podContainer := &podList.Items[0].Spec.Containers[i]
+		if podContainer.Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		if podMemory, ok := isvcutils.GetContainerMemory(podContainer); !ok || podMemory.Cmp(memory) != 0 {
+			return
+		}
+	}
+	raised, ok := isvcutils.RaiseMemoryOnOOM(isvc.Annotations, memory)
+	if !ok {
+		return
+	}
+	count := 1
+	if isvc.Status.ModelStatus.OOMRemediation != nil {
+		count = isvc.Status.ModelStatus.OOMRemediation.Count + 1
+	}
+	now := metav1.Now()
+	isvc.Status.ModelStatus.OOMRemediation = &v1beta1.OOMRemediation{
+		Memory:              raised.String(),
+		Count:               count,
+		LastRemediationTime: &now,
+	}
+	p.Log.Info("Raising the memory of the OOMKilled predictor", "isvc", isvc.Name, "memory", raised.String())
+}
+
+// reconcileConversion runs the model conversion job, the storage initializer of the predictor downloads the converted
+// model once the conversion completed
+func (p *Predictor) reconcileConversion(isvc *v1beta1.InferenceService, annotations map[string]string,
+	podSpec *v1.PodSpec) (ctrl.Result, error) {
+	r, err := conversion.NewConversionReconciler(p.client, isvc.Name, isvc.Namespace, isvc.Spec.Predictor.Conversion,
+		annotations, podSpec)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to create NewConversionReconciler for predictor")
+	}
+	done, err := r.Reconcile()
+	if err != nil {
+		isvc.Status.UpdateModelRevisionStates(v1beta1.FailedToLoad, 0, &v1beta1.FailureInfo{
+			Location: r.Job.Name,
+			Reason:   v1beta1.ModelLoadFailed,
+			Message:  err.Error(),
+		})
+		return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile model conversion for predictor")
+	}
+	if !done {
+		p.Log.Info("Waiting for the model conversion", "job", r.Job.Name, "inferenceservice", isvc.Name)
+		isvc.Status.UpdateModelRevisionStates(v1beta1.Loading, 0, nil)
+		return ctrl.Result{RequeueAfter: constants.ConversionPollIntervalSeconds * time.Second}, nil
+	}
+	annotations[constants.StorageInitializerSourceUriInternalAnnotationKey] = r.StorageURI
+	// The converted model is read from the output pvc, the storage spec credentials are not needed anymore
+	for _, key := range []string{constants.StorageSpecAnnotationKey, constants.StorageSpecParamAnnotationKey,
+		constants.StorageSpecKeyAnnotationKey} {
+		delete(annotations, key)
+	}
 	return ctrl.Result{}, nil
 }
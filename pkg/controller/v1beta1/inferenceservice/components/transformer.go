@@ -17,6 +17,7 @@ limitations under the License.
 package components
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"time"
@@ -29,9 +30,12 @@ import (
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -73,20 +77,25 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 	}
 	addLoggerAnnotations(isvc.Spec.Transformer.Logger, annotations)
 	addBatcherAnnotations(isvc.Spec.Transformer.Batcher, annotations)
+	addAgentConfigAnnotations(isvc, annotations)
+	addRateLimitAnnotations(isvc.Spec.Transformer.RateLimit, annotations)
+	addCloudIdentityAnnotations(&isvc.Spec.Transformer.ComponentExtensionSpec, annotations)
 
 	deployConfig, err := v1beta1.NewDeployConfig(p.client)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Labels and annotations from isvc will overwrite the default log routing labels and annotations
+	logAnnotations, logLabels := p.inferenceServiceConfig.LogRouting.GetPodMetadata("")
 	objectMeta := metav1.ObjectMeta{
 		Name:      constants.DefaultTransformerServiceName(isvc.Name),
 		Namespace: isvc.Namespace,
-		Labels: utils.Union(isvc.Labels, map[string]string{
+		Labels: utils.Union(logLabels, isvc.Labels, map[string]string{
 			constants.InferenceServicePodLabelKey: isvc.Name,
 			constants.KServiceComponentLabel:      string(v1beta1.TransformerComponent),
 		}),
-		Annotations: annotations,
+		Annotations: utils.Union(logAnnotations, annotations),
 	}
 
 	// Need to wait for predictor URL in modelmesh deployment mode
@@ -125,8 +134,13 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 
 	podSpec := corev1.PodSpec(isvc.Spec.Transformer.PodSpec)
 
+	if err := reconcileServiceAccount(p.client, p.scheme, isvc, objectMeta, &podSpec); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile service account for transformer")
+	}
+
 	// Here we allow switch between knative and vanilla deployment
 	if isvcutils.GetDeploymentMode(annotations, deployConfig) == constants.RawDeployment {
+		addConcurrencyMetricsAnnotations(&isvc.Spec.Transformer.ComponentExtensionSpec, objectMeta.Annotations)
 		r, err := raw.NewRawKubeReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Transformer.ComponentExtensionSpec,
 			&podSpec)
 		if err != nil {
@@ -146,16 +160,50 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 				return ctrl.Result{}, errors.Wrapf(err, "fails to set HPA owner reference for transformer")
 			}
 		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDA {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDA.ScaledObject, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set ScaledObject owner reference for transformer")
+			}
+		}
+		if r.Scaler.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDAHTTP {
+			if err := controllerutil.SetControllerReference(isvc, r.Scaler.Autoscaler.KEDAHTTP.HTTPScaledObject, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set HTTPScaledObject owner reference for transformer")
+			}
+			if err := r.RouteHosts(isvc); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to route the ingress hosts of the transformer")
+			}
+		}
+		if r.Service.OriginService != nil {
+			if err := controllerutil.SetControllerReference(isvc, r.Service.OriginService, p.scheme); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to set origin service owner reference for transformer")
+			}
+		}
+		if ratio, ok := isvc.Annotations[constants.TransformerReplicaRatioAnnotationKey]; ok {
+			replicas, err := p.linkedReplicas(isvc, ratio)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to link the transformer replicas to the predictor")
+			}
+			r.PinReplicas(replicas)
+		}
+
+		// The deployment of a suspended InferenceService is scaled to zero without an autoscaler
+		if isvcutils.IsSuspended(isvc.Annotations) {
+			r.PinReplicas(0)
+		}
 
 		deployment, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile transformer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.TransformerComponent, deployment, r.URL)
+		isvc.Status.PropagateScalingStatus(v1beta1.TransformerComponent, r.Scaler.Autoscaler.HPAStatus)
+		isvc.Status.PropagateScaledObjectStatus(v1beta1.TransformerComponent, r.Scaler.Autoscaler.ScaledObjectConditions)
 
 	} else {
-		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Transformer.ComponentExtensionSpec,
-			&podSpec, isvc.Status.Components[v1beta1.TransformerComponent])
+		extension := suspendedExtension(isvc,
+			smokeTestExtension(isvc, v1beta1.TransformerComponent, &isvc.Spec.Transformer.ComponentExtensionSpec))
+		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, extension, &podSpec,
+			isvc.Status.Components[v1beta1.TransformerComponent])
 		if err := controllerutil.SetControllerReference(isvc, r.Service, p.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set owner reference for predictor")
 		}
@@ -168,3 +216,24 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 
 	return ctrl.Result{}, nil
 }
+
+// linkedReplicas returns the transformer replicas at the replica ratio of the predictor deployment replicas, the
+// predictor is reconciled first and its deployment changes requeue the InferenceService
+func (p *Transformer) linkedReplicas(isvc *v1beta1.InferenceService, ratio string) (int32, error) {
+	transformerUnits, predictorUnits, err := v1beta1.ParseReplicaRatio(ratio)
+	if err != nil {
+		return 0, err
+	}
+	predictorReplicas := int32(1)
+	predictor := &appsv1.Deployment{}
+	if err := p.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace,
+		Name: constants.DefaultPredictorServiceName(isvc.Name)}, predictor); err != nil {
+		if !apierr.IsNotFound(err) {
+			return 0, err
+		}
+	} else if predictor.Spec.Replicas != nil {
+		predictorReplicas = *predictor.Spec.Replicas
+	}
+	return isvcutils.GetLinkedReplicas(predictorReplicas, transformerUnits, predictorUnits,
+		&isvc.Spec.Transformer.ComponentExtensionSpec), nil
+}
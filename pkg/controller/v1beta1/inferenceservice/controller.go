@@ -20,31 +20,46 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/kserveconfig"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/agentconfig"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/monitoring"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices;inferenceservices/finalizers,verbs=get;list;watch;create;update;patch;delete
@@ -54,6 +69,8 @@ import (
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
@@ -63,13 +80,19 @@ import (
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=http.keda.sh,resources=httpscaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
 
 // InferenceState describes the Readiness of the InferenceService
 type InferenceServiceState string
@@ -83,9 +106,13 @@ const (
 // InferenceServiceReconciler reconciles a InferenceService object
 type InferenceServiceReconciler struct {
 	client.Client
-	Log      logr.Logger
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
+	// Clientset reads the logs of the crashed model servers, they are not surfaced in the status when it is not set
+	Clientset kubernetes.Interface
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	// Clock starts and expires the faults of the resilience drills, the tests use a fake clock
+	Clock clock.Clock
 }
 
 func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -106,6 +133,22 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
 	})
 
+	if err := kserveconfig.ConfigError(ctx, r.Client); err != nil {
+		// Keep the deployed resources as they are until the configuration is fixed
+		r.Log.Error(err, "Refusing to reconcile InferenceService with an invalid configuration", "isvc", isvc.Name)
+		isvc.Status.SetCondition(v1beta1api.ConfigValid, &apis.Condition{
+			Type:    v1beta1api.ConfigValid,
+			Status:  v1.ConditionFalse,
+			Reason:  kserveconfig.InvalidConfigReason,
+			Message: err.Error(),
+		})
+		r.Recorder.Event(isvc, v1.EventTypeWarning, kserveconfig.InvalidConfigReason, err.Error())
+		if err := r.updateStatus(isvc, constants.DeploymentModeType(annotations[constants.DeploymentMode])); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: kserveconfig.InvalidConfigRequeueInterval}, nil
+	}
+	isvc.Status.ClearCondition(v1beta1api.ConfigValid)
 	deployConfig, err := v1beta1api.NewDeployConfig(r.Client)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
@@ -160,14 +203,28 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
+	// The faults of a resilience drill are started on their first reconcile and removed once they expired
+	faultRemaining, faultExpired, err := ingress.ReconcileFaultInjection(ctx, r.Client, isvc, r.Clock.Now())
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile the fault injection")
+	}
+	if faultExpired {
+		r.Recorder.Event(isvc, v1.EventTypeNormal, "FaultInjectionExpired", "Removed the faults injected in the routes")
+	}
+
 	r.Log.Info("Reconciling inference service", "apiVersion", isvc.APIVersion, "isvc", isvc.Name)
 	isvcConfig, err := v1beta1api.NewInferenceServicesConfig(r.Client)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create InferenceServicesConfig")
 	}
+	// The agent config is mounted by the pods of the components
+	agentConfigReconciler := agentconfig.NewAgentConfigReconciler(r.Client, r.Scheme)
+	if err := agentConfigReconciler.Reconcile(isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile agent config")
+	}
 	reconcilers := []components.Component{}
 	if deploymentMode != constants.ModelMeshDeployment {
-		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Scheme, isvcConfig))
+		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, isvcConfig))
 	}
 	if isvc.Spec.Transformer != nil {
 		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Scheme, isvcConfig))
@@ -175,6 +232,12 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if isvc.Spec.Explainer != nil {
 		reconcilers = append(reconcilers, components.NewExplainer(r.Client, r.Scheme, isvcConfig))
 	}
+	scalingConditions := scalingConditions(isvc.Status)
+	oomRemediations := 0
+	if isvc.Status.ModelStatus.OOMRemediation != nil {
+		oomRemediations = isvc.Status.ModelStatus.OOMRemediation.Count
+	}
+	storageFailed := isvc.Status.GetCondition(v1beta1api.StorageInitialized) != nil
 	for _, reconciler := range reconcilers {
 		result, err := reconciler.Reconcile(isvc)
 		if err != nil {
@@ -184,9 +247,22 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile component")
 		}
 		if result.Requeue || result.RequeueAfter > 0 {
+			// The status of the conversion Job is surfaced while the component waits for it
+			if err = r.updateStatus(isvc, deploymentMode); err != nil {
+				r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
+				return reconcile.Result{}, err
+			}
 			return result, nil
 		}
 	}
+	if remediation := isvc.Status.ModelStatus.OOMRemediation; remediation != nil && remediation.Count > oomRemediations {
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "OOMRemediated",
+			"Raised the predictor memory to %s after the model server was OOMKilled", remediation.Memory)
+	}
+	if condition := isvc.Status.GetCondition(v1beta1api.StorageInitialized); condition != nil && !storageFailed {
+		r.Recorder.Event(isvc, v1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	r.recordScalingEvents(isvc, scalingConditions)
 	//Reconcile ingress
 	ingressConfig, err := v1beta1api.NewIngressConfig(r.Client)
 	if err != nil {
@@ -194,7 +270,13 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	//check raw deployment
-	if deploymentMode == constants.RawDeployment {
+	var grpcURL *apis.URL
+	if deploymentMode == constants.RawDeployment && ingressConfig.EnableGatewayAPI {
+		reconciler := ingress.NewRawHTTPRouteReconciler(r.Client, r.Scheme, ingressConfig)
+		if err := reconciler.Reconcile(isvc); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile HTTPRoutes")
+		}
+	} else if deploymentMode == constants.RawDeployment {
 		reconciler, err := ingress.NewRawIngressReconciler(r.Client, r.Scheme, ingressConfig)
 		if err != nil {
 			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
@@ -203,12 +285,14 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
 		}
 	} else {
-		reconciler := ingress.NewIngressReconciler(r.Client, r.Scheme, ingressConfig)
+		reconciler := ingress.NewIngressReconciler(r.Client, r.Scheme, ingressConfig, r.Clock)
 		r.Log.Info("Reconciling ingress for inference service", "isvc", isvc.Name)
 		if err := reconciler.Reconcile(isvc, ingressConfig.DisableIstioVirtualHost); err != nil {
 			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
 		}
+		grpcURL = ingress.GrpcURL(isvc, ingressConfig)
 	}
+	isvc.Status.PropagateEndpointStatus(isvc.Annotations, grpcURL)
 
 	// Reconcile modelConfig
 	configMapReconciler := modelconfig.NewModelConfigReconciler(r.Client, r.Scheme)
@@ -216,12 +300,30 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, err
 	}
 
+	// Reconcile the PodMonitor, the CRDs of the Prometheus operator are looked up with the clientset
+	podMonitorConfig, err := v1beta1api.NewPodMonitorConfig(r.Client)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create PodMonitorConfig")
+	}
+	if podMonitorConfig.Enabled && r.Clientset != nil {
+		podMonitorReconciler := monitoring.NewPodMonitorReconciler(r.Client, r.Scheme, r.Clientset.Discovery(),
+			podMonitorConfig)
+		if err := podMonitorReconciler.Reconcile(isvc); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile PodMonitor")
+		}
+	}
+
 	if err = r.updateStatus(isvc, deploymentMode); err != nil {
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// The pods are not watched, the model status is checked again while the storage initializer retries
+	requeueAfter := isvcutils.StorageRetryInterval(&isvc.Status, time.Now())
+	if faultRemaining > 0 && (requeueAfter == 0 || faultRemaining < requeueAfter) {
+		requeueAfter = faultRemaining
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.InferenceService, deploymentMode constants.DeploymentModeType) error {
@@ -255,6 +357,76 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.Inf
 	return nil
 }
 
+var scalingConditionTypes = []apis.ConditionType{
+	v1beta1api.PredictorScalingReady,
+	v1beta1api.TransformerScalingReady,
+	v1beta1api.ExplainerScalingReady,
+}
+
+// scalingConditions returns the scaling conditions of the components indexed by their type
+func scalingConditions(status v1beta1api.InferenceServiceStatus) map[apis.ConditionType]apis.Condition {
+	conditions := map[apis.ConditionType]apis.Condition{}
+	for _, conditionType := range scalingConditionTypes {
+		if condition := status.GetCondition(conditionType); condition != nil {
+			conditions[conditionType] = *condition
+		}
+	}
+	return conditions
+}
+
+// recordScalingEvents records an event when the autoscaler of a component gets blocked or recovers, the users may not
+// have access to the autoscaler events
+func (r *InferenceServiceReconciler) recordScalingEvents(isvc *v1beta1api.InferenceService,
+	previous map[apis.ConditionType]apis.Condition) {
+	for conditionType, condition := range scalingConditions(isvc.Status) {
+		previousCondition, ok := previous[conditionType]
+		if condition.Status == v1.ConditionFalse && (!ok || previousCondition.Status != v1.ConditionFalse ||
+			previousCondition.Reason != condition.Reason) {
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "ScalingBlocked", "%s: %s", conditionType, condition.Message)
+		} else if condition.Status == v1.ConditionTrue && ok && previousCondition.Status == v1.ConditionFalse {
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, "ScalingRecovered", "%s", conditionType)
+		}
+	}
+}
+
+// hpaConditionsChanged filters the HPA updates to the changes of its conditions, the metrics of the HPA status are
+// refreshed on every sync of the HPA
+var hpaConditionsChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldHPA, ok := e.ObjectOld.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+		if !ok {
+			return true
+		}
+		newHPA, ok := e.ObjectNew.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldHPA.Spec, newHPA.Spec) ||
+			!equality.Semantic.DeepEqual(oldHPA.Status.Conditions, newHPA.Status.Conditions)
+	},
+}
+
+// kedaObjectChanged filters the updates of the KEDA objects to the changes of their spec, metadata and conditions,
+// e.g. a ScaledObject edited out of band is reverted to the spec of the InferenceService
+var kedaObjectChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldObject, ok := e.ObjectOld.(*unstructured.Unstructured)
+		if !ok {
+			return true
+		}
+		newObject, ok := e.ObjectNew.(*unstructured.Unstructured)
+		if !ok {
+			return true
+		}
+		oldConditions, _, _ := unstructured.NestedSlice(oldObject.Object, "status", "conditions")
+		newConditions, _, _ := unstructured.NestedSlice(newObject.Object, "status", "conditions")
+		return !equality.Semantic.DeepEqual(oldObject.Object["spec"], newObject.Object["spec"]) ||
+			!equality.Semantic.DeepEqual(oldObject.GetLabels(), newObject.GetLabels()) ||
+			!equality.Semantic.DeepEqual(oldObject.GetAnnotations(), newObject.GetAnnotations()) ||
+			!equality.Semantic.DeepEqual(oldConditions, newConditions)
+	},
+}
+
 func inferenceServiceReadiness(status v1beta1api.InferenceServiceStatus) bool {
 	return status.Conditions != nil &&
 		status.GetCondition(apis.ConditionReady) != nil &&
@@ -274,27 +446,50 @@ func inferenceServiceStatusEqual(s1, s2 v1beta1api.InferenceServiceStatus, deplo
 	return equality.Semantic.DeepEqual(s1, s2)
 }
 
-func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *v1beta1api.DeployConfig, disableIstioVirtualHost bool) error {
-	if deployConfig.DefaultDeploymentMode == string(constants.RawDeployment) {
-		return ctrl.NewControllerManagedBy(mgr).
-			For(&v1beta1api.InferenceService{}).
-			Owns(&appsv1.Deployment{}).
-			Complete(r)
-	} else if disableIstioVirtualHost == false {
-		return ctrl.NewControllerManagedBy(mgr).
-			For(&v1beta1api.InferenceService{}).
-			Owns(&knservingv1.Service{}).
-			Owns(&v1alpha3.VirtualService{}).
-			Owns(&appsv1.Deployment{}).
-			Complete(r)
-	} else {
-		return ctrl.NewControllerManagedBy(mgr).
-			For(&v1beta1api.InferenceService{}).
-			Owns(&knservingv1.Service{}).
-			Owns(&appsv1.Deployment{}).
-			Complete(r)
+// trainedModelToInferenceService maps the changes of a TrainedModel to its parent InferenceService, so the paths of
+// the registered models are routed at the gateway
+func trainedModelToInferenceService(obj client.Object) []reconcile.Request {
+	tm, ok := obj.(*v1alpha1api.TrainedModel)
+	if !ok || tm.Spec.InferenceService == "" {
+		return nil
 	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      tm.Spec.InferenceService,
+		Namespace: tm.Namespace,
+	}}}
+}
 
+func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *v1beta1api.DeployConfig, disableIstioVirtualHost bool) error {
+	// The RawDeployment components can be annotated on an InferenceService of any default deployment mode, their HPA
+	// conditions are surfaced on the InferenceService in all modes
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1api.InferenceService{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}, builder.WithPredicates(hpaConditionsChanged))
+	// The KEDA objects are only watched when KEDA is installed, the informers of the kinds which are not served by the
+	// API server fail to sync
+	if r.Clientset != nil {
+		for _, gvk := range []schema.GroupVersionKind{keda.ScaledObjectGVK, keda.HTTPScaledObjectGVK} {
+			installed, err := keda.Installed(r.Clientset.Discovery(), gvk)
+			if err != nil {
+				return err
+			}
+			if installed {
+				object := &unstructured.Unstructured{}
+				object.SetGroupVersionKind(gvk)
+				controllerBuilder = controllerBuilder.Owns(object, builder.WithPredicates(kedaObjectChanged))
+			}
+		}
+	}
+	if deployConfig.DefaultDeploymentMode != string(constants.RawDeployment) {
+		controllerBuilder = controllerBuilder.Owns(&knservingv1.Service{})
+		if !disableIstioVirtualHost {
+			controllerBuilder = controllerBuilder.Owns(&v1alpha3.VirtualService{}).
+				Watches(&source.Kind{Type: &v1alpha1api.TrainedModel{}},
+					handler.EnqueueRequestsFromMapFunc(trainedModelToInferenceService))
+		}
+	}
+	return controllerBuilder.Complete(r)
 }
 
 func (r *InferenceServiceReconciler) deleteExternalResources(isvc *v1beta1api.InferenceService) error {
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/kserveconfig"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileWithInvalidConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{v1alpha1.DeployConfigMapKey: `{"defaultDeploymentMod": "RawDeployment"}`},
+	}
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid-config", Namespace: "default"},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(configMap, isvc).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &InferenceServiceReconciler{
+		Client:   cli,
+		Log:      ctrl.Log.WithName("test"),
+		Scheme:   s,
+		Recorder: recorder,
+		Clock:    clock.RealClock{},
+	}
+	g.Expect(kserveconfig.CheckConfig(context.TODO(), cli)).NotTo(gomega.Succeed())
+	defer func() {
+		// Restore the cached result for the other tests of the package
+		configMap.Data = map[string]string{}
+		g.Expect(cli.Update(context.TODO(), configMap)).To(gomega.Succeed())
+		g.Expect(kserveconfig.CheckConfig(context.TODO(), cli)).To(gomega.Succeed())
+	}()
+
+	name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(kserveconfig.InvalidConfigRequeueInterval))
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+
+	updated := &v1beta1.InferenceService{}
+	g.Expect(cli.Get(context.TODO(), name, updated)).To(gomega.Succeed())
+	condition := updated.Status.GetCondition(v1beta1.ConfigValid)
+	g.Expect(condition).NotTo(gomega.BeNil())
+	g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal(kserveconfig.InvalidConfigReason))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("unknown field"))
+	// The InferenceService readiness is left as it was
+	g.Expect(updated.Status.GetCondition("Ready")).To(gomega.BeNil())
+}
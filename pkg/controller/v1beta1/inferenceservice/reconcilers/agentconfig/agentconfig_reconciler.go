@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/agentconfig"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("AgentConfigReconciler")
+
+// AgentConfigReconciler writes the logger, batcher and maintenance configuration of the components to the ConfigMap the
+// agents reload, so changing them does not restart the pods
+type AgentConfigReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewAgentConfigReconciler(client client.Client, scheme *runtime.Scheme) *AgentConfigReconciler {
+	return &AgentConfigReconciler{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+func (r *AgentConfigReconciler) Reconcile(isvc *v1beta1api.InferenceService) error {
+	if isvc.Annotations[constants.AgentHotReloadAnnotationKey] != "true" {
+		return nil
+	}
+	desired, err := agentconfig.CreateConfigMap(isvc)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+		return err
+	}
+	existing := &corev1.ConfigMap{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		log.Info("Creating agent config", "configmap", desired.Name, "namespace", desired.Namespace)
+		return r.client.Create(context.TODO(), desired)
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Data, desired.Data) {
+		return nil
+	}
+	log.Info("Updating agent config", "configmap", desired.Name, "namespace", desired.Namespace)
+	existing.Data = desired.Data
+	return r.client.Update(context.TODO(), existing)
+}
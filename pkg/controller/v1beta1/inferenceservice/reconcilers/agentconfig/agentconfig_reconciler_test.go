@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agentconfig
+
+import (
+	"context"
+	"testing"
+
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	corev1.AddToScheme(s)
+	v1beta1api.AddToScheme(s)
+	return s
+}
+
+func newTestInferenceService(annotations map[string]string) *v1beta1api.InferenceService {
+	logUrl := "http://message-dumper.default/"
+	return &v1beta1api.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", Annotations: annotations},
+		Spec: v1beta1api.InferenceServiceSpec{
+			Predictor: v1beta1api.PredictorSpec{
+				ComponentExtensionSpec: v1beta1api.ComponentExtensionSpec{
+					Logger: &v1beta1api.LoggerSpec{URL: &logUrl, Mode: v1beta1api.LogAll},
+				},
+			},
+		},
+	}
+}
+
+func TestAgentConfigReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	c := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := NewAgentConfigReconciler(c, newScheme())
+	configMapName := types.NamespacedName{Name: "sklearn-agent-config", Namespace: "default"}
+
+	// Without the hot reload the agent config is not written
+	g.Expect(reconciler.Reconcile(newTestInferenceService(nil))).To(gomega.Succeed())
+	err := c.Get(context.TODO(), configMapName, &corev1.ConfigMap{})
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+
+	isvc := newTestInferenceService(map[string]string{constants.AgentHotReloadAnnotationKey: "true"})
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), configMapName, configMap)).To(gomega.Succeed())
+	g.Expect(configMap.OwnerReferences).To(gomega.HaveLen(1))
+	g.Expect(configMap.Data["predictor.json"]).To(gomega.MatchJSON(
+		`{"logger":{"url":"http://message-dumper.default/","mode":"all"}}`))
+
+	// The changed logger is written to the existing agent config
+	isvc.Spec.Predictor.Logger.Mode = v1beta1api.LogResponse
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(c.Get(context.TODO(), configMapName, configMap)).To(gomega.Succeed())
+	g.Expect(configMap.Data["predictor.json"]).To(gomega.MatchJSON(
+		`{"logger":{"url":"http://message-dumper.default/","mode":"response"}}`))
+}
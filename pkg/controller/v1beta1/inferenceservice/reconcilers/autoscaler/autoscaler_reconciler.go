@@ -17,13 +17,19 @@ limitations under the License.
 package autoscaler
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	hpa "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -33,14 +39,21 @@ var log = logf.Log.WithName("AutoscalerReconciler")
 type Autoscaler struct {
 	AutoscalerClass constants.AutoscalerClassType
 	HPA             *hpa.HPAReconciler
+	// HPAStatus is the status of the reconciled HPA, its conditions report why the component can not be scaled
+	HPAStatus *v2beta2.HorizontalPodAutoscalerStatus
+	KEDA      *keda.KedaReconciler
+	KEDAHTTP  *keda.KedaHTTPReconciler
+	// ScaledObjectConditions are the conditions of the reconciled KEDA ScaledObject or HTTPScaledObject
+	ScaledObjectConditions []metav1.Condition
 }
 
 // AutoscalerReconciler is the struct of Raw K8S Object
 type AutoscalerReconciler struct {
-	client       client.Client
-	scheme       *runtime.Scheme
-	Autoscaler   *Autoscaler
-	componentExt *v1beta1.ComponentExtensionSpec
+	client        client.Client
+	scheme        *runtime.Scheme
+	Autoscaler    *Autoscaler
+	componentMeta metav1.ObjectMeta
+	componentExt  *v1beta1.ComponentExtensionSpec
 }
 
 func NewAutoscalerReconciler(client client.Client,
@@ -52,10 +65,11 @@ func NewAutoscalerReconciler(client client.Client,
 		return nil, err
 	}
 	return &AutoscalerReconciler{
-		client:       client,
-		scheme:       scheme,
-		Autoscaler:   as,
-		componentExt: componentExt,
+		client:        client,
+		scheme:        scheme,
+		Autoscaler:    as,
+		componentMeta: componentMeta,
+		componentExt:  componentExt,
 	}, err
 }
 
@@ -77,6 +91,14 @@ func createAutoscaler(client client.Client,
 	switch ac {
 	case constants.AutoscalerClassHPA:
 		as.HPA = hpa.NewHPAReconciler(client, scheme, componentMeta, componentExt)
+	case constants.AutoscalerClassKEDA:
+		kedaReconciler, err := keda.NewKedaReconciler(client, scheme, componentMeta, componentExt)
+		if err != nil {
+			return nil, err
+		}
+		as.KEDA = kedaReconciler
+	case constants.AutoscalerClassKEDAHTTP:
+		as.KEDAHTTP = keda.NewKedaHTTPReconciler(client, scheme, componentMeta, componentExt)
 	default:
 		return nil, errors.New("unknown autoscaler class type.")
 	}
@@ -87,10 +109,69 @@ func createAutoscaler(client client.Client,
 func (r *AutoscalerReconciler) Reconcile() (*Autoscaler, error) {
 	//reconcile Autoscaler
 	if r.Autoscaler.AutoscalerClass == constants.AutoscalerClassHPA {
-		_, err := r.Autoscaler.HPA.Reconcile()
+		reconciledHPA, err := r.Autoscaler.HPA.Reconcile()
+		if err != nil {
+			return nil, err
+		}
+		r.Autoscaler.HPAStatus = &reconciledHPA.Status
+		// The KEDA objects of a component switched from the keda autoscaler classes would keep scaling it
+		if err := r.deleteKedaObjects(keda.ScaledObjectGVK, keda.HTTPScaledObjectGVK); err != nil {
+			return nil, err
+		}
+	}
+	if r.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDA {
+		reconciledScaledObject, err := r.Autoscaler.KEDA.Reconcile()
+		if err != nil {
+			return nil, err
+		}
+		r.Autoscaler.ScaledObjectConditions = keda.Conditions(reconciledScaledObject)
+		if err := r.deleteHPA(); err != nil {
+			return nil, err
+		}
+		// The HTTPScaledObject would take over the ScaledObject of the same name
+		if err := r.deleteKedaObjects(keda.HTTPScaledObjectGVK); err != nil {
+			return nil, err
+		}
+	}
+	if r.Autoscaler.AutoscalerClass == constants.AutoscalerClassKEDAHTTP {
+		// The HTTP add-on creates the ScaledObject of the HTTPScaledObject with the same name, which takes over the
+		// ScaledObject of a component switched from the keda autoscaler class
+		reconciledHTTPScaledObject, err := r.Autoscaler.KEDAHTTP.Reconcile()
 		if err != nil {
 			return nil, err
 		}
+		r.Autoscaler.ScaledObjectConditions = keda.Conditions(reconciledHTTPScaledObject)
+		if err := r.deleteHPA(); err != nil {
+			return nil, err
+		}
 	}
 	return r.Autoscaler, nil
 }
+
+// Delete deletes the HPA and KEDA objects of a component whose replicas are no longer autoscaled
+func (r *AutoscalerReconciler) Delete() error {
+	if err := r.deleteHPA(); err != nil {
+		return err
+	}
+	return r.deleteKedaObjects(keda.ScaledObjectGVK, keda.HTTPScaledObjectGVK)
+}
+
+// deleteHPA deletes the HPA of a component switched from the hpa autoscaler class, which would fight with the HPA
+// created by KEDA
+func (r *AutoscalerReconciler) deleteHPA() error {
+	existing := &v2beta2.HorizontalPodAutoscaler{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: r.componentMeta.Name,
+		Namespace: r.componentMeta.Namespace}, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+}
+
+func (r *AutoscalerReconciler) deleteKedaObjects(gvks ...schema.GroupVersionKind) error {
+	for _, gvk := range gvks {
+		if err := keda.Delete(r.client, r.componentMeta, gvk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
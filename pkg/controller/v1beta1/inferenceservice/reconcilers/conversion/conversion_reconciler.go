@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("ConversionReconciler")
+
+// storageAnnotationKeys are the annotations the storage initializer downloads the model with, the model digest set
+// by the model refresh converts the refreshed model again
+var storageAnnotationKeys = []string{
+	constants.StorageInitializerSourceUriInternalAnnotationKey,
+	constants.StorageSpecAnnotationKey,
+	constants.StorageSpecParamAnnotationKey,
+	constants.StorageSpecKeyAnnotationKey,
+	constants.ModelDigestAnnotationKey,
+}
+
+// ConversionReconciler runs the model conversion of the predictor as a Job. The Job is named after the hash of the
+// model, the conversion and the node selector and has no owner, so the converted model is cached in the output
+// storage and shared by all the InferenceServices of the namespace converting the same model on the same hardware.
+type ConversionReconciler struct {
+	client client.Client
+	Job    *batchv1.Job
+	// Hash of the inputs of the conversion
+	Hash string
+	// StorageURI of the converted model
+	StorageURI string
+}
+
+func NewConversionReconciler(client client.Client,
+	isvcName string,
+	namespace string,
+	conversion *v1beta1.ModelConversion,
+	annotations map[string]string,
+	podSpec *corev1.PodSpec) (*ConversionReconciler, error) {
+	storageAnnotations := utils.Filter(annotations, func(key string) bool {
+		return utils.Includes(storageAnnotationKeys, key)
+	})
+	hash, err := conversionHash(conversion, storageAnnotations, podSpec.NodeSelector)
+	if err != nil {
+		return nil, err
+	}
+	claimName, path := parsePvcURI(conversion.OutputStorageURI)
+	return &ConversionReconciler{
+		client:     client,
+		Job:        createJob(isvcName, namespace, hash, claimName, path, conversion, storageAnnotations, podSpec),
+		Hash:       hash,
+		StorageURI: strings.TrimSuffix(conversion.OutputStorageURI, "/") + "/" + hash,
+	}, nil
+}
+
+// conversionHash hashes the model location, the conversion and the node selector the model is converted on
+func conversionHash(conversion *v1beta1.ModelConversion, storageAnnotations map[string]string,
+	nodeSelector map[string]string) (string, error) {
+	inputs, err := json.Marshal(struct {
+		Storage      map[string]string        `json:"storage"`
+		Conversion   *v1beta1.ModelConversion `json:"conversion"`
+		NodeSelector map[string]string        `json:"nodeSelector,omitempty"`
+	}{storageAnnotations, conversion, nodeSelector})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(inputs)
+	return hex.EncodeToString(sum[:])[:constants.ConversionHashLength], nil
+}
+
+func parsePvcURI(uri string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(uri, constants.PvcURIPrefix), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], strings.Trim(parts[1], "/")
+}
+
+func createJob(isvcName string, namespace string, hash string, claimName string, path string,
+	conversion *v1beta1.ModelConversion, storageAnnotations map[string]string, podSpec *corev1.PodSpec) *batchv1.Job {
+	subPath := hash
+	if path != "" {
+		subPath = path + "/" + hash
+	}
+	backoffLimit := int32(constants.ConversionJobBackoffLimit)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.ConversionJobNamePrefix + hash,
+			Namespace: namespace,
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: isvcName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					// The pod mutator injects the storage initializer downloading the model to /mnt/models
+					Labels: map[string]string{
+						constants.InferenceServicePodLabelKey: isvcName,
+					},
+					Annotations: storageAnnotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: podSpec.ServiceAccountName,
+					ImagePullSecrets:   podSpec.ImagePullSecrets,
+					NodeSelector:       podSpec.NodeSelector,
+					Affinity:           podSpec.Affinity,
+					Tolerations:        podSpec.Tolerations,
+					Containers: []corev1.Container{{
+						Name:      constants.InferenceServiceContainerName,
+						Image:     conversion.Image,
+						Command:   conversion.Command,
+						Args:      conversion.Args,
+						Env:       conversion.Env,
+						Resources: conversion.Resources,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      constants.ConversionOutputVolumeName,
+							MountPath: constants.ConversionOutputMountPath,
+							SubPath:   subPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: constants.ConversionOutputVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: claimName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// Reconcile creates the conversion job if it does not exist yet and returns whether the conversion completed, an
+// error is returned when the job failed.
+func (r *ConversionReconciler) Reconcile() (bool, error) {
+	existing := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: r.Job.Name, Namespace: r.Job.Namespace}, existing)
+	if apierr.IsNotFound(err) {
+		log.Info("Creating model conversion job", "name", r.Job.Name, "namespace", r.Job.Namespace)
+		return false, r.client.Create(context.TODO(), r.Job)
+	} else if err != nil {
+		return false, err
+	}
+	for _, condition := range existing.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("model conversion job %s failed: %s", existing.Name, condition.Message)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	corev1.AddToScheme(s)
+	batchv1.AddToScheme(s)
+	return s
+}
+
+func newConversion() *v1beta1.ModelConversion {
+	return &v1beta1.ModelConversion{
+		Image:            "kserve/trtllm-build:latest",
+		Args:             []string{"--checkpoint_dir=/mnt/models", "--output_dir=/mnt/converted"},
+		OutputStorageURI: "pvc://engines/llama",
+	}
+}
+
+func TestConversionHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	annotations := map[string]string{
+		constants.StorageInitializerSourceUriInternalAnnotationKey: "hf://meta-llama/Llama-2-7b",
+		constants.LoggerInternalAnnotationKey:                      "true",
+	}
+	podSpec := &corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"}}
+	r, err := NewConversionReconciler(nil, "llama", "default", newConversion(), annotations, podSpec)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(r.Hash).To(gomega.HaveLen(constants.ConversionHashLength))
+	g.Expect(r.StorageURI).To(gomega.Equal("pvc://engines/llama/" + r.Hash))
+	g.Expect(r.Job.Name).To(gomega.Equal(constants.ConversionJobNamePrefix + r.Hash))
+
+	// Annotations which do not change the model are not part of the hash
+	delete(annotations, constants.LoggerInternalAnnotationKey)
+	same, _ := NewConversionReconciler(nil, "other", "default", newConversion(), annotations, podSpec)
+	g.Expect(same.Hash).To(gomega.Equal(r.Hash))
+	g.Expect(same.Job.Spec.Template.Annotations).To(gomega.Equal(map[string]string{
+		constants.StorageInitializerSourceUriInternalAnnotationKey: "hf://meta-llama/Llama-2-7b",
+	}))
+
+	// The model is converted again on other hardware
+	otherHardware, _ := NewConversionReconciler(nil, "llama", "default", newConversion(), annotations,
+		&corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-H100-80GB-HBM3"}})
+	g.Expect(otherHardware.Hash).NotTo(gomega.Equal(r.Hash))
+
+	container := r.Job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Name).To(gomega.Equal(constants.InferenceServiceContainerName))
+	g.Expect(container.VolumeMounts[0].SubPath).To(gomega.Equal("llama/" + r.Hash))
+	g.Expect(r.Job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(gomega.Equal("engines"))
+}
+
+func TestConversionReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	annotations := map[string]string{
+		constants.StorageInitializerSourceUriInternalAnnotationKey: "hf://meta-llama/Llama-2-7b",
+	}
+	scenarios := map[string]struct {
+		conditions    []batchv1.JobCondition
+		expectedDone  bool
+		expectedError bool
+	}{
+		"Running": {},
+		"Complete": {
+			conditions:   []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			expectedDone: true,
+		},
+		"Failed": {
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+			},
+			expectedError: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+			r, err := NewConversionReconciler(c, "llama", "default", newConversion(), annotations, &corev1.PodSpec{})
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			// The job is created on the first reconcile
+			done, err := r.Reconcile()
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(done).To(gomega.BeFalse())
+			job := &batchv1.Job{}
+			g.Expect(c.Get(context.TODO(), types.NamespacedName{Name: r.Job.Name, Namespace: "default"}, job)).To(gomega.Succeed())
+
+			job.Status.Conditions = scenario.conditions
+			g.Expect(c.Status().Update(context.TODO(), job)).To(gomega.Succeed())
+			done, err = r.Reconcile()
+			g.Expect(err != nil).To(gomega.Equal(scenario.expectedError))
+			g.Expect(done).To(gomega.Equal(scenario.expectedDone))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package deployment
 import (
 	"context"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -96,15 +97,18 @@ func (r *DeploymentReconciler) checkDeploymentExist(client kclient.Client) (cons
 		return constants.CheckResultUnknown, nil, err
 	}
 	//existed, check equivalence
-	//for HPA scaling, we should ignore Replicas of Deployment
-	ignoreFields := cmpopts.IgnoreFields(appsv1.DeploymentSpec{}, "Replicas")
+	//for HPA scaling, we should ignore Replicas of Deployment unless the component pins them
+	var ignoreFields []cmp.Option
+	if r.Deployment.Spec.Replicas == nil {
+		ignoreFields = append(ignoreFields, cmpopts.IgnoreFields(appsv1.DeploymentSpec{}, "Replicas"))
+	}
 	// Do a dry-run update. This will populate our local deployment object with any default values
 	// that are present on the remote version.
 	if err := client.Update(context.TODO(), r.Deployment, kclient.DryRunAll); err != nil {
 		log.Error(err, "Failed to perform dry-run update of deployment", "Deployment", r.Deployment.Name)
 		return constants.CheckResultUnknown, nil, err
 	}
-	if diff, err := kmp.SafeDiff(r.Deployment.Spec, existingDeployment.Spec, ignoreFields); err != nil {
+	if diff, err := kmp.SafeDiff(r.Deployment.Spec, existingDeployment.Spec, ignoreFields...); err != nil {
 		return constants.CheckResultUnknown, nil, err
 	} else if diff != "" {
 		log.Info("Deployment Updated", "Diff", diff)
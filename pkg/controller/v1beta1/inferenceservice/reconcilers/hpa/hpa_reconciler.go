@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -52,8 +53,77 @@ func NewHPAReconciler(client client.Client,
 	}
 }
 
+// getConcurrencyMetrics scales on the requests in flight reported by the agent, like the Knative autoscaler the
+// target defaults to the container concurrency and is scaled by the target utilization percentage.
+func getConcurrencyMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) []v2beta2.MetricSpec {
+	target := int64(constants.DefaultConcurrencyTarget)
+	if componentExt.ScaleTarget != nil {
+		target = int64(*componentExt.ScaleTarget)
+	} else if componentExt.ContainerConcurrency != nil && *componentExt.ContainerConcurrency > 0 {
+		target = *componentExt.ContainerConcurrency
+	}
+	averageValue := resource.NewQuantity(target, resource.DecimalSI)
+	if value, ok := metadata.Annotations[constants.TargetUtilizationPercentage]; ok {
+		utilization, _ := strconv.Atoi(value)
+		averageValue = resource.NewMilliQuantity(target*int64(utilization)*10, resource.DecimalSI)
+	}
+	return []v2beta2.MetricSpec{
+		{
+			Type: v2beta2.PodsMetricSourceType,
+			Pods: &v2beta2.PodsMetricSource{
+				Metric: v2beta2.MetricIdentifier{
+					Name: constants.AgentConcurrencyMetricName,
+				},
+				Target: v2beta2.MetricTarget{
+					Type:         v2beta2.AverageValueMetricType,
+					AverageValue: averageValue,
+				},
+			},
+		},
+	}
+}
+
+// getGPUMetrics scales on the GPU utilization or framebuffer usage percentage of the pods reported by the NVIDIA
+// DCGM exporter, the target is read like the cpu utilization.
+func getGPUMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) []v2beta2.MetricSpec {
+	utilization := int64(constants.DefaultGPUUtilization)
+	if value, ok := metadata.Annotations[constants.TargetUtilizationPercentage]; ok {
+		utilizationInt, _ := strconv.Atoi(value)
+		utilization = int64(utilizationInt)
+	}
+	if componentExt.ScaleTarget != nil {
+		utilization = int64(*componentExt.ScaleTarget)
+	}
+	metricName := constants.GPUUtilizationMetricName
+	if *componentExt.ScaleMetric == v1beta1.MetricGPUMemory {
+		metricName = constants.GPUMemoryUtilizationMetricName
+	}
+	return []v2beta2.MetricSpec{
+		{
+			Type: v2beta2.PodsMetricSourceType,
+			Pods: &v2beta2.PodsMetricSource{
+				Metric: v2beta2.MetricIdentifier{
+					Name: metricName,
+				},
+				Target: v2beta2.MetricTarget{
+					Type:         v2beta2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(utilization, resource.DecimalSI),
+				},
+			},
+		},
+	}
+}
+
 func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) []v2beta2.MetricSpec {
 	var metrics []v2beta2.MetricSpec
+	if componentExt.ScaleMetric != nil {
+		switch *componentExt.ScaleMetric {
+		case v1beta1.MetricConcurrency:
+			return getConcurrencyMetrics(metadata, componentExt)
+		case v1beta1.MetricGPU, v1beta1.MetricGPUMemory:
+			return getGPUMetrics(metadata, componentExt)
+		}
+	}
 	var utilization int32
 	annotations := metadata.Annotations
 
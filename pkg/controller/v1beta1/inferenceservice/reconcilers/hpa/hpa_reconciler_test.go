@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpa
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetHPAMetrics(t *testing.T) {
+	cpu := v1beta1.MetricCPU
+	concurrency := v1beta1.MetricConcurrency
+	gpu := v1beta1.MetricGPU
+	gpuMemory := v1beta1.MetricGPUMemory
+	utilization := constants.DefaultCPUUtilization
+	containerConcurrency := int64(4)
+	scenarios := map[string]struct {
+		annotations  map[string]string
+		componentExt *v1beta1.ComponentExtensionSpec
+		expected     []v2beta2.MetricSpec
+	}{
+		"DefaultCPU": {
+			componentExt: &v1beta1.ComponentExtensionSpec{ScaleMetric: &cpu},
+			expected: []v2beta2.MetricSpec{{
+				Type: v2beta2.ResourceMetricSourceType,
+				Resource: &v2beta2.ResourceMetricSource{
+					Name:   corev1.ResourceCPU,
+					Target: v2beta2.MetricTarget{Type: "Utilization", AverageUtilization: &utilization},
+				},
+			}},
+		},
+		"Concurrency": {
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				ScaleMetric: &concurrency,
+				ScaleTarget: v1beta1.GetIntReference(10),
+			},
+			expected: []v2beta2.MetricSpec{{
+				Type: v2beta2.PodsMetricSourceType,
+				Pods: &v2beta2.PodsMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: constants.AgentConcurrencyMetricName},
+					Target: v2beta2.MetricTarget{
+						Type:         v2beta2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+					},
+				},
+			}},
+		},
+		"ConcurrencyWithContainerConcurrencyAndUtilization": {
+			annotations: map[string]string{constants.TargetUtilizationPercentage: "70"},
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				ScaleMetric:          &concurrency,
+				ContainerConcurrency: &containerConcurrency,
+			},
+			expected: []v2beta2.MetricSpec{{
+				Type: v2beta2.PodsMetricSourceType,
+				Pods: &v2beta2.PodsMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: constants.AgentConcurrencyMetricName},
+					Target: v2beta2.MetricTarget{
+						Type:         v2beta2.AverageValueMetricType,
+						AverageValue: resource.NewMilliQuantity(2800, resource.DecimalSI),
+					},
+				},
+			}},
+		},
+		"DefaultGPU": {
+			componentExt: &v1beta1.ComponentExtensionSpec{ScaleMetric: &gpu},
+			expected: []v2beta2.MetricSpec{{
+				Type: v2beta2.PodsMetricSourceType,
+				Pods: &v2beta2.PodsMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: constants.GPUUtilizationMetricName},
+					Target: v2beta2.MetricTarget{
+						Type:         v2beta2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(80, resource.DecimalSI),
+					},
+				},
+			}},
+		},
+		"GPUMemoryWithUtilization": {
+			annotations:  map[string]string{constants.TargetUtilizationPercentage: "60"},
+			componentExt: &v1beta1.ComponentExtensionSpec{ScaleMetric: &gpuMemory},
+			expected: []v2beta2.MetricSpec{{
+				Type: v2beta2.PodsMetricSourceType,
+				Pods: &v2beta2.PodsMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: constants.GPUMemoryUtilizationMetricName},
+					Target: v2beta2.MetricTarget{
+						Type:         v2beta2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(60, resource.DecimalSI),
+					},
+				},
+			}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			metadata := metav1.ObjectMeta{Name: "sklearn-predictor-default", Annotations: scenario.annotations}
+			g.Expect(getHPAMetrics(metadata, scenario.componentExt)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
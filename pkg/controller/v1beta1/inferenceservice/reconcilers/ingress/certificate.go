@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The cert-manager types are not vendored, the Certificates are handled as unstructured objects
+var (
+	certificateGVK     = schema.GroupVersionKind{Group: constants.CertManagerGroup, Version: "v1", Kind: "Certificate"}
+	certificateListGVK = schema.GroupVersionKind{Group: constants.CertManagerGroup, Version: "v1", Kind: "CertificateList"}
+)
+
+// rawIngressTlsEnabled returns true if the certificates of the raw deployment ingresses are issued by cert-manager,
+// the TLS of the HTTPRoutes is terminated by the gateway
+func rawIngressTlsEnabled(ingressConfig *v1beta1api.IngressConfig) bool {
+	return ingressConfig.Tls != nil && !ingressConfig.EnableGatewayAPI
+}
+
+// ingressHosts returns the distinct hosts of the rules of the ingress
+func ingressHosts(ingress *netv1.Ingress) []string {
+	var hosts []string
+	for _, rule := range ingress.Spec.Rules {
+		if !utils.Includes(hosts, rule.Host) {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	return hosts
+}
+
+// setRawIngressTls sets the tls block of the ingress with the secret of the certificate of its hosts
+func setRawIngressTls(ingress *netv1.Ingress, tls *v1beta1api.IngressTlsConfig) {
+	ingress.Spec.TLS = []netv1.IngressTLS{
+		{
+			Hosts:      ingressHosts(ingress),
+			SecretName: ingress.Name + constants.IngressTlsSecretSuffix,
+		},
+	}
+	if tls.SslRedirect {
+		ingress.Annotations = utils.Union(ingress.Annotations, map[string]string{
+			constants.NginxForceSslRedirectAnnotation: "true",
+		})
+	}
+}
+
+// createCertificate returns the cert-manager Certificate of the hosts of the ingress, issued by the ClusterIssuer of
+// the ingress config into the secret of the tls block of the ingress
+func createCertificate(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService, ingress *netv1.Ingress,
+	tls *v1beta1api.IngressTlsConfig) (*unstructured.Unstructured, error) {
+	var dnsNames []interface{}
+	for _, host := range ingressHosts(ingress) {
+		dnsNames = append(dnsNames, host)
+	}
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(ingress.Name)
+	certificate.SetNamespace(ingress.Namespace)
+	certificate.SetLabels(map[string]string{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+	})
+	certificate.Object["spec"] = map[string]interface{}{
+		"secretName": ingress.Name + constants.IngressTlsSecretSuffix,
+		"dnsNames":   dnsNames,
+		"issuerRef": map[string]interface{}{
+			"name":  tls.ClusterIssuer,
+			"kind":  constants.CertManagerClusterIssuerKind,
+			"group": constants.CertManagerGroup,
+		},
+	}
+	if err := controllerutil.SetControllerReference(isvc, certificate, scheme); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+func (r *RawIngressReconciler) reconcileCertificates(isvc *v1beta1api.InferenceService,
+	ingresses []*netv1.Ingress) error {
+	desired := map[string]bool{}
+	for _, ingress := range ingresses {
+		certificate, err := createCertificate(r.scheme, isvc, ingress, r.ingressConfig.Tls)
+		if err != nil {
+			return err
+		}
+		desired[certificate.GetName()] = true
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(certificateGVK)
+		err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: certificate.GetNamespace(),
+			Name: certificate.GetName()}, existing)
+		if err != nil {
+			if !apierr.IsNotFound(err) {
+				return err
+			}
+			err = r.client.Create(context.TODO(), certificate)
+			log.Info("creating certificate", "name", certificate.GetName(), "err", err)
+		} else if !equality.Semantic.DeepEqual(certificate.Object["spec"], existing.Object["spec"]) {
+			existing.Object["spec"] = certificate.Object["spec"]
+			err = r.client.Update(context.TODO(), existing)
+			log.Info("updating certificate", "name", certificate.GetName(), "err", err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Delete the certificates of the ingress classes removed from the ingress config
+	existingCertificates := &unstructured.UnstructuredList{}
+	existingCertificates.SetGroupVersionKind(certificateListGVK)
+	if err := r.client.List(context.TODO(), existingCertificates, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		return err
+	}
+	for i := range existingCertificates.Items {
+		certificate := &existingCertificates.Items[i]
+		if desired[certificate.GetName()] || !metav1.IsControlledBy(certificate, isvc) {
+			continue
+		}
+		log.Info("deleting certificate", "name", certificate.GetName())
+		if err := r.client.Delete(context.TODO(), certificate); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package ingress
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
@@ -37,6 +38,72 @@ type DomainTemplateValues struct {
 
 // GenerateDomainName generate domain name using template configured in IngressConfig
 func GenerateDomainName(name string, obj metav1.ObjectMeta, ingressConfig *v1beta1.IngressConfig) (string, error) {
+	domain, err := renderDomainTemplate(ingressConfig.DomainTemplate, name, obj, ingressConfig)
+	if err != nil {
+		return "", err
+	}
+	if err := validateDomainName(domain); err != nil {
+		return "", err
+	}
+	return domain, nil
+}
+
+// GenerateGlobalDomainName generates the global domain name of an InferenceService served by several clusters using
+// the global domain template configured in IngressConfig. It is empty when the template is not configured or renders
+// an empty domain for the InferenceService.
+func GenerateGlobalDomainName(obj metav1.ObjectMeta, ingressConfig *v1beta1.IngressConfig) (string, error) {
+	if ingressConfig.GlobalDomainTemplate == "" {
+		return "", nil
+	}
+	domain, err := renderDomainTemplate(ingressConfig.GlobalDomainTemplate, obj.Name, obj, ingressConfig)
+	if err != nil || domain == "" {
+		return "", err
+	}
+	if err := validateDomainName(domain); err != nil {
+		return "", err
+	}
+	return domain, nil
+}
+
+// GenerateHostName generates the external host of an InferenceService in Serverless mode using the host template
+// configured in IngressConfig. It is empty when the template is not configured, the host of the Knative service is used
+// instead.
+func GenerateHostName(obj metav1.ObjectMeta, ingressConfig *v1beta1.IngressConfig) (string, error) {
+	if ingressConfig.HostTemplate == "" {
+		return "", nil
+	}
+	domain, err := renderDomainTemplate(ingressConfig.HostTemplate, obj.Name, obj, ingressConfig)
+	if err != nil {
+		return "", err
+	}
+	if err := validateDomainName(domain); err != nil {
+		return "", err
+	}
+	return domain, nil
+}
+
+// GenerateRevisionHostName generates the external host of a revision of an InferenceService published under the given
+// name. The host template configured in IngressConfig is rendered like the host of the InferenceService, otherwise the
+// name replaces the name of the Knative service in the host of the Knative service which Knative renders first.
+func GenerateRevisionHostName(name string, obj metav1.ObjectMeta, serviceName string, serviceHost string,
+	ingressConfig *v1beta1.IngressConfig) (string, error) {
+	if ingressConfig.HostTemplate != "" {
+		revision := obj
+		revision.Name = name
+		return GenerateHostName(revision, ingressConfig)
+	}
+	if !strings.HasPrefix(serviceHost, serviceName) {
+		return "", fmt.Errorf("host %q does not start with the name of the service %q", serviceHost, serviceName)
+	}
+	domain := name + strings.TrimPrefix(serviceHost, serviceName)
+	if err := validateDomainName(domain); err != nil {
+		return "", err
+	}
+	return domain, nil
+}
+
+func renderDomainTemplate(domainTemplate string, name string, obj metav1.ObjectMeta,
+	ingressConfig *v1beta1.IngressConfig) (string, error) {
 	values := DomainTemplateValues{
 		Name:          name,
 		Namespace:     obj.Namespace,
@@ -45,7 +112,7 @@ func GenerateDomainName(name string, obj metav1.ObjectMeta, ingressConfig *v1bet
 		Labels:        obj.Labels,
 	}
 
-	tpl, err := template.New("domain-template").Parse(ingressConfig.DomainTemplate)
+	tpl, err := template.New("domain-template").Parse(domainTemplate)
 	if err != nil {
 		return "", err
 	}
@@ -54,11 +121,13 @@ func GenerateDomainName(name string, obj metav1.ObjectMeta, ingressConfig *v1bet
 	if err := tpl.Execute(&buf, values); err != nil {
 		return "", fmt.Errorf("error rendering the domain template: %w", err)
 	}
+	return buf.String(), nil
+}
 
-	urlErrs := validation.IsFullyQualifiedDomainName(field.NewPath("url"), buf.String())
+func validateDomainName(domain string) error {
+	urlErrs := validation.IsFullyQualifiedDomainName(field.NewPath("url"), domain)
 	if urlErrs != nil {
-		return "", fmt.Errorf("invalid domain name %q: %w", buf.String(), urlErrs.ToAggregate())
+		return fmt.Errorf("invalid domain name %q: %w", domain, urlErrs.ToAggregate())
 	}
-
-	return buf.String(), nil
+	return nil
 }
@@ -134,3 +134,161 @@ func TestGenerateDomainName(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateGlobalDomainName(t *testing.T) {
+	obj := v1.ObjectMeta{
+		Name:      "model",
+		Namespace: "test",
+		Annotations: map[string]string{
+			"serving.kserve.io/global": "true",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		globalTemplate string
+		want           string
+		wantErr        bool
+	}{
+		{
+			name: "no global domain template",
+		},
+		{
+			name:           "global domain template",
+			globalTemplate: "{{ .Name }}-{{ .Namespace }}.global.example.com",
+			want:           "model-test.global.example.com",
+		},
+		{
+			name:           "template opting in",
+			globalTemplate: `{{ if index .Annotations "serving.kserve.io/global" }}{{ .Name }}.global.example.com{{ end }}`,
+			want:           "model.global.example.com",
+		},
+		{
+			name:           "template rendering an empty domain",
+			globalTemplate: `{{ if index .Annotations "serving.kserve.io/regional" }}{{ .Name }}.global.example.com{{ end }}`,
+		},
+		{
+			name:           "invalid domain name",
+			globalTemplate: "{{ .Name }}_{{ .Namespace }}.global.example.com",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateGlobalDomainName(obj, &v1beta1.IngressConfig{
+				IngressDomain:        v1beta1.DefaultIngressDomain,
+				GlobalDomainTemplate: tt.globalTemplate,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateGlobalDomainName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Test %q unexpected domain (-want +got): %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateHostName(t *testing.T) {
+	obj := v1.ObjectMeta{
+		Name:      "model",
+		Namespace: "test",
+		Labels: map[string]string{
+			"team": "fraud",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		hostTemplate string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "no host template",
+		},
+		{
+			name:         "host template",
+			hostTemplate: "{{ .Name }}-{{ .Namespace }}.models.{{ .IngressDomain }}",
+			want:         "model-test.models.example.com",
+		},
+		{
+			name:         "host template with labels",
+			hostTemplate: `{{ .Name }}.{{ index .Labels "team" }}.example.com`,
+			want:         "model.fraud.example.com",
+		},
+		{
+			name:         "invalid domain name",
+			hostTemplate: "{{ .Name }}_{{ .Namespace }}.example.com",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateHostName(obj, &v1beta1.IngressConfig{
+				IngressDomain: v1beta1.DefaultIngressDomain,
+				HostTemplate:  tt.hostTemplate,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateHostName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Test %q unexpected host (-want +got): %v", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateRevisionHostName(t *testing.T) {
+	tests := []struct {
+		name         string
+		obj          v1.ObjectMeta
+		serviceHost  string
+		hostTemplate string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:        "knative host",
+			obj:         v1.ObjectMeta{Name: "model", Namespace: "test"},
+			serviceHost: "model-predictor-default.test.example.com",
+			want:        "model-00002.test.example.com",
+		},
+		{
+			name:        "knative host with the name in the domain",
+			obj:         v1.ObjectMeta{Name: "model", Namespace: "model"},
+			serviceHost: "model-predictor-default.model.model.example.com",
+			want:        "model-00002.model.model.example.com",
+		},
+		{
+			name:         "host template with the name in the namespace",
+			obj:          v1.ObjectMeta{Name: "model", Namespace: "model"},
+			hostTemplate: "{{ .Namespace }}-{{ .Name }}.models.{{ .IngressDomain }}",
+			want:         "model-model-00002.models.example.com",
+		},
+		{
+			name:        "knative host not starting with the service",
+			obj:         v1.ObjectMeta{Name: "model", Namespace: "test"},
+			serviceHost: "test.model-predictor-default.example.com",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateRevisionHostName("model-00002", tt.obj, "model-predictor-default", tt.serviceHost,
+				&v1beta1.IngressConfig{
+					IngressDomain: v1beta1.DefaultIngressDomain,
+					HostTemplate:  tt.hostTemplate,
+				})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateRevisionHostName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Test %q unexpected host (-want +got): %v", tt.name, diff)
+			}
+		})
+	}
+}
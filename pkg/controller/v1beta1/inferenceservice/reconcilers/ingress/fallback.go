@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/network"
+)
+
+// fallbackInferenceService returns the InferenceService of the serving.kserve.io/fallback-inference-service annotation
+// when it is ready, "" otherwise, so that two InferenceServices falling back on each other never route their requests
+// in a loop when both are unavailable
+func (ir *IngressReconciler) fallbackInferenceService(isvc *v1beta1.InferenceService) (string, error) {
+	name := isvc.Annotations[constants.FallbackAnnotationKey]
+	if name == "" || name == isvc.Name {
+		return "", nil
+	}
+	fallback := &v1beta1.InferenceService{}
+	if err := ir.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: isvc.Namespace}, fallback); err != nil {
+		if apierr.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if !fallback.Status.IsReady() {
+		return "", nil
+	}
+	return name, nil
+}
+
+// createFallbackRoutes returns the routes of the requests of the InferenceService while its top level component is
+// unavailable, they are sent to the fallback InferenceService through the local gateway with the timeout and the
+// retries of the predict route. The model of an InferenceService is named after it so the paths of the model are
+// rewritten to the paths of the model of the fallback InferenceService, the other paths are kept.
+func createFallbackRoutes(isvc *v1beta1.InferenceService, predictRoute *istiov1alpha3.HTTPRoute, fallback string,
+	config *v1beta1.IngressConfig) []*istiov1alpha3.HTTPRoute {
+	fallbackRoute := gogoproto.Clone(predictRoute).(*istiov1alpha3.HTTPRoute)
+	fallbackRoute.Route = []*istiov1alpha3.HTTPRouteDestination{
+		createHTTPRouteDestination(fallback, isvc.Namespace, config.LocalGatewayServiceName),
+	}
+	fallbackRoute.Headers = &istiov1alpha3.Headers{
+		Request: &istiov1alpha3.Headers_HeaderOperations{
+			Set: map[string]string{
+				"Host": network.GetServiceHostname(fallback, isvc.Namespace),
+			},
+		},
+	}
+	fallbackRoute.Mirror = nil
+	fallbackRoute.MirrorPercentage = nil
+
+	rewrites := []struct {
+		uri     *istiov1alpha3.StringMatch
+		rewrite string
+	}{
+		{
+			uri:     &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "/v1/models/" + isvc.Name}},
+			rewrite: "/v1/models/" + fallback,
+		},
+		{
+			uri:     &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Prefix{Prefix: "/v1/models/" + isvc.Name + ":"}},
+			rewrite: "/v1/models/" + fallback + ":",
+		},
+		{
+			uri:     &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "/v2/models/" + isvc.Name}},
+			rewrite: "/v2/models/" + fallback,
+		},
+		{
+			uri:     &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Prefix{Prefix: "/v2/models/" + isvc.Name + "/"}},
+			rewrite: "/v2/models/" + fallback + "/",
+		},
+	}
+	routes := []*istiov1alpha3.HTTPRoute{}
+	for _, rewrite := range rewrites {
+		route := gogoproto.Clone(fallbackRoute).(*istiov1alpha3.HTTPRoute)
+		for _, match := range route.Match {
+			match.Uri = gogoproto.Clone(rewrite.uri).(*istiov1alpha3.StringMatch)
+		}
+		// The prefix of a prefix match is replaced, the whole path of an exact match
+		route.Rewrite = &istiov1alpha3.HTTPRewrite{Uri: rewrite.rewrite}
+		routes = append(routes, route)
+	}
+	return append(routes, fallbackRoute)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateVirtualServiceFallback(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	timeout := int64(10)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{TimeoutSeconds: &timeout},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionFalse}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The virtual service is not created for an unavailable predictor without fallback
+	if virtualService := createIngress(isvc, ingressConfig, nil, ""); virtualService != nil {
+		t.Fatalf("expected no virtual service, got %v", virtualService)
+	}
+
+	// The requests are routed to the fallback with the paths of its model
+	virtualService := createIngress(isvc, ingressConfig, nil, "my-baseline")
+	if virtualService == nil {
+		t.Fatal("expected a virtual service")
+	}
+	if len(virtualService.Spec.Http) != 5 {
+		t.Fatalf("expected 5 routes, got %d", len(virtualService.Spec.Http))
+	}
+	fallbackRoute := virtualService.Spec.Http[4]
+	expectedRoute := []*istiov1alpha3.HTTPRouteDestination{
+		{
+			Destination: &istiov1alpha3.Destination{
+				Host: "knative-local-gateway.istio-system.svc.cluster.local",
+				Port: &istiov1alpha3.PortSelector{Number: constants.CommonDefaultHttpPort},
+			},
+			Weight: 100,
+		},
+	}
+	if diff := cmp.Diff(expectedRoute, fallbackRoute.Route); diff != "" {
+		t.Errorf("unexpected destination (-want +got): %v", diff)
+	}
+	if host := fallbackRoute.Headers.Request.Set["Host"]; host != network.GetServiceHostname("my-baseline", namespace) {
+		t.Errorf("unexpected host %s", host)
+	}
+	if diff := cmp.Diff(&gogotypes.Duration{Seconds: 10}, fallbackRoute.Timeout); diff != "" {
+		t.Errorf("unexpected timeout (-want +got): %v", diff)
+	}
+	expectedRewrites := []struct {
+		uri     *istiov1alpha3.StringMatch
+		rewrite string
+	}{
+		{&istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "/v1/models/my-model"}}, "/v1/models/my-baseline"},
+		{&istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Prefix{Prefix: "/v1/models/my-model:"}}, "/v1/models/my-baseline:"},
+		{&istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "/v2/models/my-model"}}, "/v2/models/my-baseline"},
+		{&istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Prefix{Prefix: "/v2/models/my-model/"}}, "/v2/models/my-baseline/"},
+	}
+	for i, expected := range expectedRewrites {
+		route := virtualService.Spec.Http[i]
+		if diff := cmp.Diff(expectedRoute, route.Route); diff != "" {
+			t.Errorf("unexpected destination of the route %d (-want +got): %v", i, diff)
+		}
+		if diff := cmp.Diff(&istiov1alpha3.HTTPRewrite{Uri: expected.rewrite}, route.Rewrite); diff != "" {
+			t.Errorf("unexpected rewrite of the route %d (-want +got): %v", i, diff)
+		}
+		for _, match := range route.Match {
+			if diff := cmp.Diff(expected.uri, match.Uri); diff != "" {
+				t.Errorf("unexpected uri of the route %d (-want +got): %v", i, diff)
+			}
+		}
+	}
+
+	// The predictor is routed again once it is ready
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Status: corev1.ConditionTrue})
+	virtualService = createIngress(isvc, ingressConfig, nil, "my-baseline")
+	if len(virtualService.Spec.Http) != 1 {
+		t.Fatalf("expected 1 route, got %d", len(virtualService.Spec.Http))
+	}
+	if host := virtualService.Spec.Http[0].Headers.Request.Set["Host"]; host != network.GetServiceHostname(constants.DefaultPredictorServiceName(serviceName), namespace) {
+		t.Errorf("unexpected host %s", host)
+	}
+}
+
+func TestFallbackInferenceService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	ready := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test"}}
+	ready.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	notReady := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "not-ready", Namespace: "test"}}
+	notReady.Status.InitializeConditions()
+	reconciler := NewIngressReconciler(fake.NewClientBuilder().WithScheme(s).WithObjects(ready, notReady).Build(), s,
+		&v1beta1.IngressConfig{}, clock.RealClock{})
+
+	scenarios := map[string]struct {
+		fallback string
+		expected string
+	}{
+		"Ready":    {fallback: "ready", expected: "ready"},
+		"NotReady": {fallback: "not-ready"},
+		"NotFound": {fallback: "missing"},
+		"Self":     {fallback: "my-model"},
+		"None":     {},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test", Annotations: map[string]string{}},
+			}
+			if scenario.fallback != "" {
+				isvc.Annotations[constants.FallbackAnnotationKey] = scenario.fallback
+			}
+			fallback, err := reconciler.fallbackInferenceService(isvc)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(fallback).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// faultExpiry returns the expiry of the faults of the InferenceService, false while the faults are not started
+func faultExpiry(isvc *v1beta1.InferenceService) (time.Time, bool) {
+	value, ok := isvc.Annotations[constants.FaultExpiryAnnotationKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiry, true
+}
+
+// hasFaults reports whether the InferenceService has a delay or an abort fault
+func hasFaults(isvc *v1beta1.InferenceService) bool {
+	_, delayed := isvc.Annotations[constants.FaultDelayAnnotationKey]
+	_, aborted := isvc.Annotations[constants.FaultAbortStatusAnnotationKey]
+	return delayed || aborted
+}
+
+// faultPercentage returns the percentage of the requests a fault is injected in, 100 by default
+func faultPercentage(isvc *v1beta1.InferenceService, key string) *istiov1alpha3.Percent {
+	percentage := 100
+	if value, ok := isvc.Annotations[key]; ok {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			percentage = parsed
+		}
+	}
+	return &istiov1alpha3.Percent{Value: float64(percentage)}
+}
+
+// createFaultInjection returns the faults injected in the routes of the InferenceService from the
+// serving.kserve.io/fault-delay and serving.kserve.io/fault-abort-status annotations, nil when there are none or
+// when they are not started or expired
+func createFaultInjection(isvc *v1beta1.InferenceService, now time.Time) *istiov1alpha3.HTTPFaultInjection {
+	if !hasFaults(isvc) {
+		return nil
+	}
+	if expiry, ok := faultExpiry(isvc); !ok || !now.Before(expiry) {
+		return nil
+	}
+	fault := &istiov1alpha3.HTTPFaultInjection{}
+	if value, ok := isvc.Annotations[constants.FaultDelayAnnotationKey]; ok {
+		if delay, err := time.ParseDuration(value); err == nil && delay > 0 {
+			fault.Delay = &istiov1alpha3.HTTPFaultInjection_Delay{
+				HttpDelayType: &istiov1alpha3.HTTPFaultInjection_Delay_FixedDelay{
+					FixedDelay: gogotypes.DurationProto(delay),
+				},
+				Percentage: faultPercentage(isvc, constants.FaultDelayPercentageAnnotationKey),
+			}
+		}
+	}
+	if value, ok := isvc.Annotations[constants.FaultAbortStatusAnnotationKey]; ok {
+		if status, err := strconv.Atoi(value); err == nil {
+			fault.Abort = &istiov1alpha3.HTTPFaultInjection_Abort{
+				ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: int32(status)},
+				Percentage: faultPercentage(isvc, constants.FaultAbortPercentageAnnotationKey),
+			}
+		}
+	}
+	if fault.Delay == nil && fault.Abort == nil {
+		return nil
+	}
+	return fault
+}
+
+// setFaultInjection injects the faults of the InferenceService in its routes, the routes which already reject the
+// requests at the gateway, e.g. the unknown models route, keep their fault
+func setFaultInjection(routes []*istiov1alpha3.HTTPRoute, isvc *v1beta1.InferenceService, now time.Time) {
+	fault := createFaultInjection(isvc, now)
+	if fault == nil {
+		return
+	}
+	for _, route := range routes {
+		if route.Fault == nil {
+			route.Fault = fault
+		}
+	}
+}
+
+// ReconcileFaultInjection starts the faults of the InferenceService by setting their expiry, the
+// serving.kserve.io/fault-ttl after the first reconcile which sees them, and removes the annotations of the faults
+// once they expired. It returns the time left until the expiry, 0 when there are no faults, and whether the faults
+// expired and were removed.
+func ReconcileFaultInjection(ctx context.Context, cli client.Client, isvc *v1beta1.InferenceService,
+	now time.Time) (time.Duration, bool, error) {
+	if !hasFaults(isvc) {
+		return 0, false, nil
+	}
+	expiry, started := faultExpiry(isvc)
+	if started && now.Before(expiry) {
+		return expiry.Sub(now), false, nil
+	}
+	patch := client.MergeFrom(isvc.DeepCopy())
+	if started {
+		for _, key := range constants.FaultAnnotationKeys {
+			delete(isvc.Annotations, key)
+		}
+		if err := cli.Patch(ctx, isvc, patch); err != nil {
+			return 0, false, err
+		}
+		return 0, true, nil
+	}
+	ttl, err := time.ParseDuration(isvc.Annotations[constants.FaultTTLAnnotationKey])
+	if err != nil || ttl <= 0 {
+		// Rejected by the validating webhook, only reachable for objects created before it was enabled
+		return 0, false, nil
+	}
+	// The expiry is rounded up to the second of the timestamp so the faults last at least their ttl
+	expiry = now.Add(ttl + time.Second - 1).Truncate(time.Second)
+	isvc.Annotations[constants.FaultExpiryAnnotationKey] = expiry.UTC().Format(time.RFC3339)
+	if err := cli.Patch(ctx, isvc, patch); err != nil {
+		return 0, false, err
+	}
+	return expiry.Sub(now), false, nil
+}
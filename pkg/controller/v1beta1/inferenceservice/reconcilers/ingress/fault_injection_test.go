@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	istiov1alpha3 "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateVirtualServiceFaultInjection(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+	now := time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)
+	expiry := now.Add(time.Hour).Format(time.RFC3339)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    *istiov1alpha3.HTTPFaultInjection
+	}{
+		"DelayAndAbort": {
+			annotations: map[string]string{
+				constants.FaultDelayAnnotationKey:           "2s",
+				constants.FaultDelayPercentageAnnotationKey: "50",
+				constants.FaultAbortStatusAnnotationKey:     "503",
+				constants.FaultTTLAnnotationKey:             "1h",
+				constants.FaultExpiryAnnotationKey:          expiry,
+			},
+			expected: &istiov1alpha3.HTTPFaultInjection{
+				Delay: &istiov1alpha3.HTTPFaultInjection_Delay{
+					HttpDelayType: &istiov1alpha3.HTTPFaultInjection_Delay_FixedDelay{
+						FixedDelay: &gogotypes.Duration{Seconds: 2},
+					},
+					Percentage: &istiov1alpha3.Percent{Value: 50},
+				},
+				Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+					ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 503},
+					Percentage: &istiov1alpha3.Percent{Value: 100},
+				},
+			},
+		},
+		"Suspended": {
+			annotations: map[string]string{
+				constants.SuspendedAnnotationKey: "true",
+			},
+			expected: &istiov1alpha3.HTTPFaultInjection{
+				Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+					ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 503},
+					Percentage: &istiov1alpha3.Percent{Value: 100},
+				},
+			},
+		},
+		"NotStarted": {
+			annotations: map[string]string{
+				constants.FaultAbortStatusAnnotationKey: "503",
+				constants.FaultTTLAnnotationKey:         "1h",
+			},
+		},
+		"Expired": {
+			annotations: map[string]string{
+				constants.FaultAbortStatusAnnotationKey: "503",
+				constants.FaultTTLAnnotationKey:         "1h",
+				constants.FaultExpiryAnnotationKey:      "2023-05-02T09:30:00Z",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceName,
+					Namespace:   namespace,
+					Annotations: scenario.annotations,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Explainer: &v1beta1.ExplainerSpec{},
+				},
+				Status: v1beta1.InferenceServiceStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{
+							{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue},
+							{Type: v1beta1.ExplainerReady, Status: corev1.ConditionTrue},
+						},
+					},
+					Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+						v1beta1.PredictorComponent: {
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+							},
+						},
+					},
+				},
+			}
+			virtualService := createIngress(isvc, ingressConfig, []string{"model-a"}, "")
+			setFaultInjection(virtualService.Spec.Http, isvc, now)
+			// The explain, the model and the predict routes get the faults, the unknown model route keeps its 404
+			if len(virtualService.Spec.Http) != 4 {
+				t.Fatalf("expected 4 routes, got %d", len(virtualService.Spec.Http))
+			}
+			for i, route := range virtualService.Spec.Http {
+				expected := scenario.expected
+				if i == 2 {
+					expected = &istiov1alpha3.HTTPFaultInjection{
+						Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+							ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 404},
+							Percentage: &istiov1alpha3.Percent{Value: 100},
+						},
+					}
+				}
+				if diff := cmp.Diff(expected, route.Fault); diff != "" {
+					t.Errorf("unexpected fault of the route %d (-want +got): %v", i, diff)
+				}
+			}
+			// The annotations of the faults are not copied to the virtual service
+			for _, key := range constants.FaultAnnotationKeys {
+				if _, ok := virtualService.Annotations[key]; ok {
+					t.Errorf("unexpected annotation %s of the virtual service", key)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileFaultInjection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "test",
+			Annotations: map[string]string{
+				constants.FaultAbortStatusAnnotationKey:     "503",
+				constants.FaultAbortPercentageAnnotationKey: "10",
+				constants.FaultTTLAnnotationKey:             "15m",
+				constants.CanaryHeaderAnnotationKey:         "x-model-revision: canary",
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build()
+	name := types.NamespacedName{Name: "my-model", Namespace: "test"}
+	now := time.Date(2023, 5, 2, 9, 30, 0, 0, time.UTC)
+
+	// The faults start on the first reconcile, their expiry is the ttl after it
+	remaining, expired, err := ReconcileFaultInjection(context.TODO(), cli, isvc, now)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(expired).To(gomega.BeFalse())
+	g.Expect(remaining).To(gomega.Equal(15 * time.Minute))
+	g.Expect(cli.Get(context.TODO(), name, isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Annotations[constants.FaultExpiryAnnotationKey]).To(gomega.Equal("2023-05-02T09:45:00Z"))
+
+	// The expiry is kept by the following reconciles
+	remaining, expired, err = ReconcileFaultInjection(context.TODO(), cli, isvc, now.Add(10*time.Minute))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(expired).To(gomega.BeFalse())
+	g.Expect(remaining).To(gomega.Equal(5 * time.Minute))
+
+	// The annotations of the faults are removed once they expired, the other annotations are kept
+	remaining, expired, err = ReconcileFaultInjection(context.TODO(), cli, isvc, now.Add(15*time.Minute))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(expired).To(gomega.BeTrue())
+	g.Expect(remaining).To(gomega.BeZero())
+	g.Expect(cli.Get(context.TODO(), name, isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Annotations).To(gomega.Equal(map[string]string{
+		constants.CanaryHeaderAnnotationKey: "x-model-revision: canary",
+	}))
+
+	// Nothing is done without faults
+	remaining, expired, err = ReconcileFaultInjection(context.TODO(), cli, isvc, now.Add(20*time.Minute))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(expired).To(gomega.BeFalse())
+	g.Expect(remaining).To(gomega.BeZero())
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"strings"
+
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The Gateway API types are not vendored, the HTTPRoutes are handled as unstructured objects
+var (
+	httpRouteGVK     = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"}
+	httpRouteListGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRouteList"}
+)
+
+// RawHTTPRouteReconciler reconciles the Gateway API HTTPRoutes of the raw deployments, it replaces the kubernetes
+// ingress when the Gateway API is enabled in the ingress config
+type RawHTTPRouteReconciler struct {
+	client        client.Client
+	scheme        *runtime.Scheme
+	ingressConfig *v1beta1api.IngressConfig
+}
+
+func NewRawHTTPRouteReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	ingressConfig *v1beta1api.IngressConfig) *RawHTTPRouteReconciler {
+	return &RawHTTPRouteReconciler{
+		client:        client,
+		scheme:        scheme,
+		ingressConfig: ingressConfig,
+	}
+}
+
+// parentRef returns the reference to the gateway configured as <namespace>/<name>, the gateway is looked up in the
+// namespace of the route when the namespace is omitted
+func parentRef(gateway string) map[string]interface{} {
+	ref := map[string]interface{}{
+		"group": httpRouteGVK.Group,
+		"kind":  "Gateway",
+		"name":  gateway,
+	}
+	if parts := strings.SplitN(gateway, "/", 2); len(parts) == 2 {
+		ref["namespace"] = parts[0]
+		ref["name"] = parts[1]
+	}
+	return ref
+}
+
+// createRawHTTPRoutes returns one HTTPRoute per component service with the hosts routed to it. The defaults of the
+// Gateway API are set explicitly so the routes read back from the API server compare equal.
+func createRawHTTPRoutes(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
+	ingressConfig *v1beta1api.IngressConfig) ([]*unstructured.Unstructured, error) {
+	rules, err := createRawIngressRules(isvc, ingressConfig)
+	if rules == nil || err != nil {
+		return nil, err
+	}
+	var services []string
+	hostnames := map[string][]interface{}{}
+	for _, rule := range rules {
+		service := rule.HTTP.Paths[0].Backend.Service.Name
+		if _, ok := hostnames[service]; !ok {
+			services = append(services, service)
+		}
+		hostnames[service] = append(hostnames[service], rule.Host)
+	}
+	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
+		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
+	})
+	var routes []*unstructured.Unstructured
+	for _, service := range services {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		route.SetName(service)
+		route.SetNamespace(isvc.Namespace)
+		route.SetLabels(utils.Union(isvc.Labels, map[string]string{
+			constants.InferenceServicePodLabelKey: isvc.Name,
+		}))
+		route.SetAnnotations(annotations)
+		route.Object["spec"] = map[string]interface{}{
+			"parentRefs": []interface{}{parentRef(ingressGateway(isvc, ingressConfig.KserveIngressGateway))},
+			"hostnames":  hostnames[service],
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": []interface{}{
+						map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":  "PathPrefix",
+								"value": "/",
+							},
+						},
+					},
+					"backendRefs": []interface{}{
+						map[string]interface{}{
+							"group":  "",
+							"kind":   "Service",
+							"name":   service,
+							"port":   int64(constants.CommonDefaultHttpPort),
+							"weight": int64(1),
+						},
+					},
+				},
+			},
+		}
+		if err := controllerutil.SetControllerReference(isvc, route, scheme); err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func semanticHTTPRouteEquals(desired, existing *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepEqual(desired.Object["spec"], existing.Object["spec"]) &&
+		equality.Semantic.DeepEqual(desired.GetLabels(), existing.GetLabels()) &&
+		equality.Semantic.DeepEqual(desired.GetAnnotations(), existing.GetAnnotations())
+}
+
+func (r *RawHTTPRouteReconciler) Reconcile(isvc *v1beta1api.InferenceService) error {
+	routes, err := createRawHTTPRoutes(r.scheme, isvc, r.ingressConfig)
+	if routes == nil || err != nil {
+		return err
+	}
+	desired := map[string]bool{}
+	for _, route := range routes {
+		desired[route.GetName()] = true
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(httpRouteGVK)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()},
+			existing)
+		if err != nil {
+			if !apierr.IsNotFound(err) {
+				return err
+			}
+			err = r.client.Create(context.TODO(), route)
+			log.Info("creating HTTPRoute", "name", route.GetName(), "err", err)
+		} else if !semanticHTTPRouteEquals(route, existing) {
+			existing.Object["spec"] = route.Object["spec"]
+			existing.SetLabels(route.GetLabels())
+			existing.SetAnnotations(route.GetAnnotations())
+			err = r.client.Update(context.TODO(), existing)
+			log.Info("updating HTTPRoute", "name", route.GetName(), "err", err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Delete the routes of the removed components
+	existingRoutes := &unstructured.UnstructuredList{}
+	existingRoutes.SetGroupVersionKind(httpRouteListGVK)
+	if err := r.client.List(context.TODO(), existingRoutes, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		return err
+	}
+	for i := range existingRoutes.Items {
+		route := &existingRoutes.Items[i]
+		if desired[route.GetName()] || !metav1.IsControlledBy(route, isvc) {
+			continue
+		}
+		log.Info("deleting HTTPRoute", "name", route.GetName())
+		if err := r.client.Delete(context.TODO(), route); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return propagateRawIngressStatus(isvc, r.ingressConfig)
+}
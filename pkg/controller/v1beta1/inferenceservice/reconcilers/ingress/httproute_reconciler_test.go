@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRawHTTPRouteReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test", UID: "uid"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transformer: &v1beta1.TransformerSpec{},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{
+					{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue},
+					{Type: v1beta1.TransformerReady, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:        "example.com",
+		DomainTemplate:       "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:            "http",
+		EnableGatewayAPI:     true,
+		KserveIngressGateway: "kserve/kserve-ingress-gateway",
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	reconciler := NewRawHTTPRouteReconciler(cli, s, ingressConfig)
+
+	getRoute := func(name string) *unstructured.Unstructured {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, route)).To(gomega.Succeed())
+		return route
+	}
+
+	// The top level host routes to the transformer
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	transformerRoute := getRoute(constants.DefaultTransformerServiceName("my-model"))
+	hostnames, _, _ := unstructured.NestedStringSlice(transformerRoute.Object, "spec", "hostnames")
+	g.Expect(hostnames).To(gomega.Equal([]string{"my-model-test.example.com",
+		"my-model-transformer-default-test.example.com"}))
+	parentRefs, _, _ := unstructured.NestedSlice(transformerRoute.Object, "spec", "parentRefs")
+	g.Expect(parentRefs).To(gomega.Equal([]interface{}{map[string]interface{}{"group": "gateway.networking.k8s.io",
+		"kind": "Gateway", "namespace": "kserve", "name": "kserve-ingress-gateway"}}))
+	g.Expect(transformerRoute.GetOwnerReferences()).To(gomega.HaveLen(1))
+	predictorRoute := getRoute(constants.DefaultPredictorServiceName("my-model"))
+	hostnames, _, _ = unstructured.NestedStringSlice(predictorRoute.Object, "spec", "hostnames")
+	g.Expect(hostnames).To(gomega.Equal([]string{"my-model-predictor-default-test.example.com"}))
+	g.Expect(isvc.Status.URL.String()).To(gomega.Equal("http://my-model-test.example.com"))
+	g.Expect(isvc.Status.IsConditionReady(v1beta1.IngressReady)).To(gomega.BeTrue())
+
+	// The unchanged routes are not updated
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(getRoute(constants.DefaultPredictorServiceName("my-model")).GetResourceVersion()).To(
+		gomega.Equal(predictorRoute.GetResourceVersion()))
+
+	// The routes of the removed transformer are deleted
+	isvc.Spec.Transformer = nil
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(httpRouteListGVK)
+	g.Expect(cli.List(context.TODO(), routes)).To(gomega.Succeed())
+	g.Expect(routes.Items).To(gomega.HaveLen(1))
+	hostnames, _, _ = unstructured.NestedStringSlice(routes.Items[0].Object, "spec", "hostnames")
+	g.Expect(hostnames).To(gomega.Equal([]string{"my-model-test.example.com",
+		"my-model-predictor-default-test.example.com"}))
+
+	// The routes are attached to the gateway of the InferenceService when it selects its own gateway
+	isvc.Annotations = map[string]string{constants.IngressGatewayAnnotationKey: "team-a/team-a-gateway"}
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	parentRefs, _, _ = unstructured.NestedSlice(getRoute(constants.DefaultPredictorServiceName("my-model")).Object,
+		"spec", "parentRefs")
+	g.Expect(parentRefs).To(gomega.Equal([]interface{}{map[string]interface{}{"group": "gateway.networking.k8s.io",
+		"kind": "Gateway", "namespace": "team-a", "name": "team-a-gateway"}}))
+}
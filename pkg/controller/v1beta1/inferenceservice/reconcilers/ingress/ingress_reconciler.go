@@ -19,7 +19,11 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	gogoproto "github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
@@ -33,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
@@ -53,13 +58,17 @@ type IngressReconciler struct {
 	client        client.Client
 	scheme        *runtime.Scheme
 	ingressConfig *v1beta1.IngressConfig
+	// clock is the clock of the InferenceService reconciler the faults are started and expired on
+	clock clock.PassiveClock
 }
 
-func NewIngressReconciler(client client.Client, scheme *runtime.Scheme, ingressConfig *v1beta1.IngressConfig) *IngressReconciler {
+func NewIngressReconciler(client client.Client, scheme *runtime.Scheme, ingressConfig *v1beta1.IngressConfig,
+	clock clock.PassiveClock) *IngressReconciler {
 	return &IngressReconciler{
 		client:        client,
 		scheme:        scheme,
 		ingressConfig: ingressConfig,
+		clock:         clock,
 	}
 }
 
@@ -188,7 +197,7 @@ func createHTTPRouteDestination(targetHost, namespace string, gatewayService str
 	return httpRouteDestination
 }
 
-func createHTTPMatchRequest(prefix, targetHost, internalHost string, isInternal bool, config *v1beta1.IngressConfig) []*istiov1alpha3.HTTPMatchRequest {
+func createHTTPMatchRequest(prefix, targetHost, internalHost, globalHost string, isInternal bool, config *v1beta1.IngressConfig) []*istiov1alpha3.HTTPMatchRequest {
 	var uri *istiov1alpha3.StringMatch
 	if prefix != "" {
 		uri = &istiov1alpha3.StringMatch{
@@ -219,17 +228,344 @@ func createHTTPMatchRequest(prefix, targetHost, internalHost string, isInternal
 				},
 				Gateways: []string{config.IngressGateway},
 			})
+		// The global load balancer sends the requests of the InferenceServices served by several clusters with the
+		// global host
+		if globalHost != "" {
+			matchRequests = append(matchRequests,
+				&istiov1alpha3.HTTPMatchRequest{
+					Uri: uri,
+					Authority: &istiov1alpha3.StringMatch{
+						MatchType: &istiov1alpha3.StringMatch_Regex{
+							Regex: constants.HostRegExp(globalHost),
+						},
+					},
+					Gateways: []string{config.IngressGateway},
+				})
+		}
 	}
 	return matchRequests
 }
 
-func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig) *v1alpha3.VirtualService {
+// isInternalService returns whether the service is labelled with cluster local or knative domain is configured as
+// internal
+func isInternalService(isvc *v1beta1.InferenceService, serviceHost string) bool {
+	if val, ok := isvc.Labels[constants.VisibilityLabel]; ok && val == "cluster-local" {
+		return true
+	}
+	return serviceHost == network.GetServiceHostname(isvc.Name, isvc.Namespace)
+}
+
+// createRevisionRoutes returns the routes and the hosts of the revisions of the top level component, each revision
+// is published under the host of <isvc>-<revision> through the ingress gateway and routed directly to the revision
+// service
+func createRevisionRoutes(isvc *v1beta1.InferenceService, backend string, config *v1beta1.IngressConfig) ([]*istiov1alpha3.HTTPRoute, []string, error) {
+	if isvc.Annotations[constants.EnableRevisionRoutesAnnotationKey] != "true" {
+		return nil, nil, nil
+	}
+	component := v1beta1.PredictorComponent
+	if isvc.Spec.Transformer != nil {
+		component = v1beta1.TransformerComponent
+	}
+	status := isvc.Status.Components[component]
+	revisions := []string{status.LatestReadyRevision, status.LatestRolledoutRevision, status.PreviousRolledoutRevision}
+	for _, traffic := range status.Traffic {
+		revisions = append(revisions, traffic.RevisionName)
+	}
+	routes := []*istiov1alpha3.HTTPRoute{}
+	hosts := []string{}
+	for _, revision := range revisions {
+		if revision == "" {
+			continue
+		}
+		// The revisions are named <component service>-<generation>, only the generation is kept in the host
+		revisionHost, err := GenerateRevisionHostName(isvc.Name+"-"+strings.TrimPrefix(revision, backend+"-"),
+			isvc.ObjectMeta, backend, status.URL.Host, config)
+		if err != nil {
+			return nil, nil, err
+		}
+		if utils.Includes(hosts, revisionHost) {
+			continue
+		}
+		hosts = append(hosts, revisionHost)
+		routes = append(routes, &istiov1alpha3.HTTPRoute{
+			Match: []*istiov1alpha3.HTTPMatchRequest{
+				{
+					Authority: &istiov1alpha3.StringMatch{
+						MatchType: &istiov1alpha3.StringMatch_Regex{
+							Regex: constants.HostRegExp(revisionHost),
+						},
+					},
+					Gateways: []string{config.IngressGateway},
+				},
+			},
+			Route: []*istiov1alpha3.HTTPRouteDestination{
+				createHTTPRouteDestination(revision, isvc.Namespace, network.GetServiceHostname(revision, isvc.Namespace)),
+			},
+		})
+	}
+	return routes, hosts, nil
+}
+
+// setRoutePolicy sets the timeout and the retries of the component on the route, the retries of the route otherwise
+// default to the Istio retries which do not wait for the model server to come back
+func setRoutePolicy(route *istiov1alpha3.HTTPRoute, extension *v1beta1.ComponentExtensionSpec) {
+	if extension.TimeoutSeconds != nil {
+		route.Timeout = &gogotypes.Duration{Seconds: *extension.TimeoutSeconds}
+	}
+	if extension.Retries == nil {
+		return
+	}
+	route.Retries = &istiov1alpha3.HTTPRetry{
+		Attempts: extension.Retries.Attempts,
+		RetryOn:  extension.Retries.RetryOn,
+	}
+	if extension.Retries.PerTryTimeoutSeconds != nil {
+		route.Retries.PerTryTimeout = &gogotypes.Duration{Seconds: *extension.Retries.PerTryTimeoutSeconds}
+	}
+}
+
+// setTrafficMirror mirrors the requests of the route to the latest ready revision of the top level component while the
+// traffic is still served by its last rolled out revision, i.e. while the canary does not receive all the traffic
+func setTrafficMirror(route *istiov1alpha3.HTTPRoute, isvc *v1beta1.InferenceService) {
+	component := v1beta1.PredictorComponent
+	extension := &isvc.Spec.Predictor.ComponentExtensionSpec
+	if isvc.Spec.Transformer != nil {
+		component = v1beta1.TransformerComponent
+		extension = &isvc.Spec.Transformer.ComponentExtensionSpec
+	}
+	if extension.TrafficMirror == nil {
+		return
+	}
+	status := isvc.Status.Components[component]
+	if status.LatestReadyRevision == "" || status.LatestRolledoutRevision == "" ||
+		status.LatestReadyRevision == status.LatestRolledoutRevision {
+		return
+	}
+	percent := int64(100)
+	if extension.TrafficMirror.Percent != nil {
+		percent = *extension.TrafficMirror.Percent
+	}
+	route.Mirror = &istiov1alpha3.Destination{
+		Host: network.GetServiceHostname(status.LatestReadyRevision, isvc.Namespace),
+		Port: &istiov1alpha3.PortSelector{
+			Number: constants.CommonDefaultHttpPort,
+		},
+	}
+	route.MirrorPercentage = &istiov1alpha3.Percent{Value: float64(percent)}
+}
+
+// createCanaryRoute returns the route of the requests carrying the canary header of the InferenceService, they are
+// routed directly to the latest ready revision of the top level component whatever the split of the traffic so that
+// the canary can be tested before it receives any traffic
+func createCanaryRoute(isvc *v1beta1.InferenceService, predictRoute *istiov1alpha3.HTTPRoute) *istiov1alpha3.HTTPRoute {
+	header, ok := isvc.Annotations[constants.CanaryHeaderAnnotationKey]
+	if !ok {
+		return nil
+	}
+	name, value, ok := v1beta1.ParseCanaryHeader(header)
+	if !ok {
+		return nil
+	}
+	component := v1beta1.PredictorComponent
+	extension := &isvc.Spec.Predictor.ComponentExtensionSpec
+	if isvc.Spec.Transformer != nil {
+		component = v1beta1.TransformerComponent
+		extension = &isvc.Spec.Transformer.ComponentExtensionSpec
+	}
+	revision := isvc.Status.Components[component].LatestReadyRevision
+	if revision == "" {
+		return nil
+	}
+	revisionHost := network.GetServiceHostname(revision, isvc.Namespace)
+	route := &istiov1alpha3.HTTPRoute{
+		Route: []*istiov1alpha3.HTTPRouteDestination{
+			createHTTPRouteDestination(revision, isvc.Namespace, revisionHost),
+		},
+		Headers: &istiov1alpha3.Headers{
+			Request: &istiov1alpha3.Headers_HeaderOperations{
+				Set: map[string]string{
+					"Host": revisionHost,
+				},
+			},
+		},
+	}
+	for _, match := range predictRoute.Match {
+		canaryMatch := gogoproto.Clone(match).(*istiov1alpha3.HTTPMatchRequest)
+		canaryMatch.Headers = map[string]*istiov1alpha3.StringMatch{
+			name: {
+				MatchType: &istiov1alpha3.StringMatch_Exact{
+					Exact: value,
+				},
+			},
+		}
+		route.Match = append(route.Match, canaryMatch)
+	}
+	setRoutePolicy(route, extension)
+	return route
+}
+
+// createModelRoutes returns the routes of the models of a multi-model predictor, the paths of the registered
+// TrainedModels are routed like the predict route while the paths of the other models are rejected with 404 at the
+// gateway instead of reaching a model server which does not serve them
+func createModelRoutes(predictRoute *istiov1alpha3.HTTPRoute, models []string) []*istiov1alpha3.HTTPRoute {
+	routes := []*istiov1alpha3.HTTPRoute{}
+	if len(models) > 0 {
+		modelRoute := gogoproto.Clone(predictRoute).(*istiov1alpha3.HTTPRoute)
+		setMatchURI(modelRoute, constants.ModelPathRegExp(models))
+		routes = append(routes, modelRoute)
+	}
+	unknownModelRoute := &istiov1alpha3.HTTPRoute{
+		Route: predictRoute.Route,
+		Fault: &istiov1alpha3.HTTPFaultInjection{
+			Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+				ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 404},
+				Percentage: &istiov1alpha3.Percent{Value: 100},
+			},
+		},
+	}
+	for _, match := range predictRoute.Match {
+		unknownModelRoute.Match = append(unknownModelRoute.Match, gogoproto.Clone(match).(*istiov1alpha3.HTTPMatchRequest))
+	}
+	setMatchURI(unknownModelRoute, constants.ModelPrefix())
+	return append(routes, unknownModelRoute)
+}
+
+func setMatchURI(route *istiov1alpha3.HTTPRoute, regex string) {
+	for _, match := range route.Match {
+		match.Uri = &istiov1alpha3.StringMatch{
+			MatchType: &istiov1alpha3.StringMatch_Regex{
+				Regex: regex,
+			},
+		}
+	}
+}
+
+// createGrpcRoute returns the route of the gRPC requests of the InferenceService, the gRPC requests of the hosts of the
+// predict route are matched by their content type and the requests of the gRPC host are all matched. The gRPC
+// endpoints are served by the predictor, e.g. Triton or vLLM, so the requests bypass the transformer and are sent to
+// the gRPC port of the local gateway which upgrades them to HTTP/2.
+func createGrpcRoute(isvc *v1beta1.InferenceService, predictRoute *istiov1alpha3.HTTPRoute, grpcHost string,
+	config *v1beta1.IngressConfig) *istiov1alpha3.HTTPRoute {
+	predictor := constants.DefaultPredictorServiceName(isvc.Name)
+	destination := createHTTPRouteDestination(predictor, isvc.Namespace, config.LocalGatewayServiceName)
+	if config.GrpcRoutes.LocalGatewayPort != 0 {
+		destination.Destination.Port.Number = uint32(config.GrpcRoutes.LocalGatewayPort)
+	}
+	route := &istiov1alpha3.HTTPRoute{
+		Route: []*istiov1alpha3.HTTPRouteDestination{destination},
+		Headers: &istiov1alpha3.Headers{
+			Request: &istiov1alpha3.Headers_HeaderOperations{
+				Set: map[string]string{
+					"Host": network.GetServiceHostname(predictor, isvc.Namespace),
+				},
+			},
+		},
+	}
+	for _, match := range predictRoute.Match {
+		grpcMatch := gogoproto.Clone(match).(*istiov1alpha3.HTTPMatchRequest)
+		grpcMatch.Headers = map[string]*istiov1alpha3.StringMatch{
+			"content-type": {
+				MatchType: &istiov1alpha3.StringMatch_Prefix{
+					Prefix: constants.GrpcContentType,
+				},
+			},
+		}
+		route.Match = append(route.Match, grpcMatch)
+	}
+	if grpcHost != "" {
+		route.Match = append(route.Match, &istiov1alpha3.HTTPMatchRequest{
+			Authority: &istiov1alpha3.StringMatch{
+				MatchType: &istiov1alpha3.StringMatch_Regex{
+					Regex: constants.HostRegExp(grpcHost),
+				},
+			},
+			Gateways: []string{config.IngressGateway},
+		})
+	}
+	setRoutePolicy(route, &isvc.Spec.Predictor.ComponentExtensionSpec)
+	return route
+}
+
+// GrpcURL returns the url the gRPC clients of the InferenceService connect to through the ingress gateway, the gRPC host
+// when the gRPC subdomain is enabled or the host of the url of the InferenceService, nil when the gRPC routes are not
+// enabled or the InferenceService is not routed yet
+func GrpcURL(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig) *apis.URL {
+	if config.GrpcRoutes == nil || config.DisableIstioVirtualHost || isvc.Status.URL == nil {
+		return nil
+	}
+	url := &apis.URL{Scheme: isvc.Status.URL.Scheme, Host: isvc.Status.URL.Host}
+	serviceHost := getServiceHost(isvc)
+	if config.GrpcRoutes.EnableSubdomain && serviceHost != "" && !isInternalService(isvc, serviceHost) {
+		url.Host = constants.GrpcHostPrefix + serviceHost
+	}
+	return url
+}
+
+// corsPolicy returns the CORS policy of the routes of the InferenceService, the policy of the ingress config with the
+// origins of the InferenceService annotation if set, an empty annotation disables CORS for the InferenceService
+func corsPolicy(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig) *istiov1alpha3.CorsPolicy {
+	cors := v1beta1.CorsPolicyConfig{}
+	if config.CorsPolicy != nil {
+		cors = *config.CorsPolicy
+	}
+	if origins, ok := isvc.Annotations[constants.CorsAllowOriginsAnnotationKey]; ok {
+		cors.AllowOrigins = nil
+		for _, origin := range strings.Split(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cors.AllowOrigins = append(cors.AllowOrigins, origin)
+			}
+		}
+	}
+	if len(cors.AllowOrigins) == 0 {
+		return nil
+	}
+	policy := &istiov1alpha3.CorsPolicy{
+		AllowMethods:  cors.AllowMethods,
+		AllowHeaders:  cors.AllowHeaders,
+		ExposeHeaders: cors.ExposeHeaders,
+	}
+	for _, origin := range cors.AllowOrigins {
+		match := &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: origin}}
+		if origin == "*" {
+			match = &istiov1alpha3.StringMatch{MatchType: &istiov1alpha3.StringMatch_Regex{Regex: ".*"}}
+		}
+		policy.AllowOrigins = append(policy.AllowOrigins, match)
+	}
+	if cors.MaxAgeSeconds != nil {
+		policy.MaxAge = &gogotypes.Duration{Seconds: *cors.MaxAgeSeconds}
+	}
+	if cors.AllowCredentials != nil {
+		policy.AllowCredentials = &gogotypes.BoolValue{Value: *cors.AllowCredentials}
+	}
+	return policy
+}
+
+// ingressGateway returns the gateway the InferenceService is exposed through, the gateway of the ingress config unless
+// the InferenceService selects its own gateway, e.g. a team gateway with its own certificates
+func ingressGateway(isvc *v1beta1.InferenceService, defaultGateway string) string {
+	if gateway := isvc.Annotations[constants.IngressGatewayAnnotationKey]; gateway != "" {
+		return gateway
+	}
+	return defaultGateway
+}
+
+// createIngress returns the virtual service of the InferenceService, models are the names of the registered
+// TrainedModels of a multi-model predictor and nil for the other predictors. The requests are routed to the fallback
+// InferenceService, when not empty, while the top level component is not ready instead of keeping the routes to it.
+func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig, models []string,
+	fallback string) *v1alpha3.VirtualService {
+	if gateway := ingressGateway(isvc, config.IngressGateway); gateway != config.IngressGateway {
+		override := *config
+		override.IngressGateway = gateway
+		config = &override
+	}
 	serviceHost := getServiceHost(isvc)
 	if serviceHost == "" {
 		return nil
 	}
 
-	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+	unavailable := !isvc.Status.IsConditionReady(v1beta1.PredictorReady)
+	if unavailable && fallback == "" {
 		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
 			Type:   v1beta1.IngressReady,
 			Status: corev1.ConditionFalse,
@@ -241,7 +577,8 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 
 	if isvc.Spec.Transformer != nil {
 		backend = constants.DefaultTransformerServiceName(isvc.Name)
-		if !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
+		unavailable = unavailable || !isvc.Status.IsConditionReady(v1beta1.TransformerReady)
+		if unavailable && fallback == "" {
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
 				Type:   v1beta1.IngressReady,
 				Status: corev1.ConditionFalse,
@@ -250,14 +587,33 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 			return nil
 		}
 	}
-	isInternal := false
-	//if service is labelled with cluster local or knative domain is configured as internal
-	if val, ok := isvc.Labels[constants.VisibilityLabel]; ok && val == "cluster-local" {
-		isInternal = true
-	}
-	serviceInternalHostName := network.GetServiceHostname(isvc.Name, isvc.Namespace)
-	if serviceHost == serviceInternalHostName {
-		isInternal = true
+	isInternal := isInternalService(isvc, serviceHost)
+	globalHost := ""
+	if !isInternal {
+		var err error
+		if globalHost, err = GenerateGlobalDomainName(isvc.ObjectMeta, config); err != nil {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Invalid global domain name",
+				Message: err.Error(),
+			})
+			return nil
+		}
+		// The host rendered from the host template replaces the host of the Knative service
+		host, err := GenerateHostName(isvc.ObjectMeta, config)
+		if err != nil {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Invalid host name",
+				Message: err.Error(),
+			})
+			return nil
+		}
+		if host != "" {
+			serviceHost = host
+		}
 	}
 	httpRoutes := []*istiov1alpha3.HTTPRoute{}
 	// Build explain route
@@ -272,7 +628,7 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 		}
 		explainerRouter := istiov1alpha3.HTTPRoute{
 			Match: createHTTPMatchRequest(constants.ExplainPrefix(), serviceHost,
-				network.GetServiceHostname(isvc.Name, isvc.Namespace), isInternal, config),
+				network.GetServiceHostname(isvc.Name, isvc.Namespace), globalHost, isInternal, config),
 			Route: []*istiov1alpha3.HTTPRouteDestination{
 				createHTTPRouteDestination(constants.DefaultExplainerServiceName(isvc.Name), isvc.Namespace, config.LocalGatewayServiceName),
 			},
@@ -284,12 +640,13 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 				},
 			},
 		}
+		setRoutePolicy(&explainerRouter, &isvc.Spec.Explainer.ComponentExtensionSpec)
 		httpRoutes = append(httpRoutes, &explainerRouter)
 	}
 	// Add predict route
-	httpRoutes = append(httpRoutes, &istiov1alpha3.HTTPRoute{
+	predictRoute := &istiov1alpha3.HTTPRoute{
 		Match: createHTTPMatchRequest("", serviceHost,
-			network.GetServiceHostname(isvc.Name, isvc.Namespace), isInternal, config),
+			network.GetServiceHostname(isvc.Name, isvc.Namespace), globalHost, isInternal, config),
 		Route: []*istiov1alpha3.HTTPRouteDestination{
 			createHTTPRouteDestination(backend, isvc.Namespace, config.LocalGatewayServiceName),
 		},
@@ -300,7 +657,34 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 				},
 			},
 		},
-	})
+	}
+	if isvc.Spec.Transformer != nil {
+		setRoutePolicy(predictRoute, &isvc.Spec.Transformer.ComponentExtensionSpec)
+	} else {
+		setRoutePolicy(predictRoute, &isvc.Spec.Predictor.ComponentExtensionSpec)
+	}
+	setTrafficMirror(predictRoute, isvc)
+	grpcHost := ""
+	if unavailable {
+		// The critical applications degrade to the fallback InferenceService instead of failing
+		httpRoutes = append(httpRoutes, createFallbackRoutes(isvc, predictRoute, fallback, config)...)
+	} else {
+		// The gRPC requests are matched before the paths of the models which are only served over HTTP
+		if config.GrpcRoutes != nil {
+			if config.GrpcRoutes.EnableSubdomain && !isInternal {
+				grpcHost = constants.GrpcHostPrefix + serviceHost
+			}
+			httpRoutes = append(httpRoutes, createGrpcRoute(isvc, predictRoute, grpcHost, config))
+		}
+		if canaryRoute := createCanaryRoute(isvc, predictRoute); canaryRoute != nil {
+			httpRoutes = append(httpRoutes, canaryRoute)
+		}
+		// The paths of the models are matched before the paths of the server, e.g. the health and the metadata paths
+		if models != nil {
+			httpRoutes = append(httpRoutes, createModelRoutes(predictRoute, models)...)
+		}
+		httpRoutes = append(httpRoutes, predictRoute)
+	}
 	hosts := []string{
 		network.GetServiceHostname(isvc.Name, isvc.Namespace),
 	}
@@ -310,6 +694,45 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 	if !isInternal {
 		hosts = append(hosts, serviceHost)
 		gateways = append(gateways, config.IngressGateway)
+		if globalHost != "" {
+			hosts = append(hosts, globalHost)
+		}
+		if grpcHost != "" {
+			hosts = append(hosts, grpcHost)
+		}
+		// Add the routes to the previous and the candidate revisions
+		revisionRoutes, revisionHosts, err := createRevisionRoutes(isvc, backend, config)
+		if err != nil {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Invalid revision host name",
+				Message: err.Error(),
+			})
+			return nil
+		}
+		httpRoutes = append(httpRoutes, revisionRoutes...)
+		hosts = append(hosts, revisionHosts...)
+	}
+
+	// The preflight requests are answered at the gateway for all the routes
+	if cors := corsPolicy(isvc, config); cors != nil {
+		for _, route := range httpRoutes {
+			route.CorsPolicy = cors
+		}
+	}
+	// The requests of a suspended InferenceService are rejected at the gateway so its revisions scale to zero
+	if isvcutils.IsSuspended(isvc.Annotations) {
+		for _, route := range httpRoutes {
+			if route.Fault == nil {
+				route.Fault = &istiov1alpha3.HTTPFaultInjection{
+					Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+						ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 503},
+						Percentage: &istiov1alpha3.Percent{Value: 100},
+					},
+				}
+			}
+		}
 	}
 
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
@@ -340,10 +763,19 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService, disableIs
 	// When Istio virtual host is disabled, we return the underlying component url.
 	// When Istio virtual host is enabled. we return the url using inference service virtual host name and redirect to the corresponding transformer, predictor or explainer url.
 	if disableIstioVirtualHost == false {
-		desiredIngress := createIngress(isvc, ir.ingressConfig)
+		models, err := ir.trainedModels(isvc)
+		if err != nil {
+			return errors.Wrapf(err, "fails to list the trained models")
+		}
+		fallback, err := ir.fallbackInferenceService(isvc)
+		if err != nil {
+			return errors.Wrapf(err, "fails to get the fallback inference service")
+		}
+		desiredIngress := createIngress(isvc, ir.ingressConfig, models, fallback)
 		if desiredIngress == nil {
 			return nil
 		}
+		setFaultInjection(desiredIngress.Spec.Http, isvc, ir.clock.Now())
 
 		//Create external service which points to local gateway
 		if err := ir.reconcileExternalService(isvc, ir.ingressConfig); err != nil {
@@ -355,7 +787,7 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService, disableIs
 		}
 
 		existing := &v1alpha3.VirtualService{}
-		err := ir.client.Get(context.TODO(), types.NamespacedName{Name: desiredIngress.Name, Namespace: desiredIngress.Namespace}, existing)
+		err = ir.client.Get(context.TODO(), types.NamespacedName{Name: desiredIngress.Name, Namespace: desiredIngress.Namespace}, existing)
 		if err != nil {
 			if apierr.IsNotFound(err) {
 				log.Info("Creating Ingress for isvc", "namespace", desiredIngress.Namespace, "name", desiredIngress.Name)
@@ -376,6 +808,24 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService, disableIs
 	}
 
 	if url, err := apis.ParseURL(serviceUrl); err == nil {
+		// The clients of the InferenceServices served by several clusters use the global host, the address stays
+		// cluster local
+		if !disableIstioVirtualHost && !isInternalService(isvc, serviceHost) {
+			host, err := GenerateHostName(isvc.ObjectMeta, ir.ingressConfig)
+			if err != nil {
+				return errors.Wrapf(err, "fails to generate the host")
+			}
+			if host != "" {
+				url.Host = host
+			}
+			globalHost, err := GenerateGlobalDomainName(isvc.ObjectMeta, ir.ingressConfig)
+			if err != nil {
+				return errors.Wrapf(err, "fails to generate the global host")
+			}
+			if globalHost != "" {
+				url.Host = globalHost
+			}
+		}
 		isvc.Status.URL = url
 		path := ""
 		if isvc.Spec.Transformer != nil {
@@ -409,6 +859,27 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService, disableIs
 	}
 }
 
+// trainedModels returns the sorted names of the TrainedModels registered on a multi-model predictor, i.e. allocated to
+// the predictor and not being deleted, nil when the predictor is not a multi-model predictor
+func (ir *IngressReconciler) trainedModels(isvc *v1beta1.InferenceService) ([]string, error) {
+	if len(isvc.Spec.Predictor.GetImplementations()) == 0 || !isvcutils.IsMMSPredictor(&isvc.Spec.Predictor) {
+		return nil, nil
+	}
+	trainedModels := &v1alpha1.TrainedModelList{}
+	if err := ir.client.List(context.TODO(), trainedModels, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.TrainedModelAllocated: isvc.Name}); err != nil {
+		return nil, err
+	}
+	models := []string{}
+	for _, tm := range trainedModels.Items {
+		if tm.Spec.InferenceService == isvc.Name && tm.DeletionTimestamp.IsZero() {
+			models = append(models, tm.Name)
+		}
+	}
+	sort.Strings(models)
+	return models, nil
+}
+
 func routeSemanticEquals(desired, existing *v1alpha3.VirtualService) bool {
 	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
 		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, existing.ObjectMeta.Labels) &&
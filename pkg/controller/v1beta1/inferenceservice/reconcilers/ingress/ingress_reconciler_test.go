@@ -17,7 +17,11 @@ limitations under the License.
 package ingress
 
 import (
+	gogoproto "github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/golang/protobuf/proto"
 	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
@@ -26,10 +30,14 @@ import (
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/network"
 	"net/url"
+	"regexp"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
@@ -493,7 +501,7 @@ func TestCreateVirtualService(t *testing.T) {
 				LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
 			}
 
-			actualService := createIngress(testIsvc, ingressConfig)
+			actualService := createIngress(testIsvc, ingressConfig, nil, "")
 			if diff := cmp.Diff(tc.expectedService, actualService); diff != "" {
 				t.Errorf("Test %q unexpected status (-want +got): %v", tc.name, diff)
 			}
@@ -682,3 +690,844 @@ func TestGetServiceUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateVirtualServiceGlobalHost(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	globalHost := "my-model-test.global.example.com"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+		GlobalDomainTemplate:    "{{ .Name }}-{{ .Namespace }}.global.example.com",
+	}
+
+	// The global host is routed through the ingress gateway
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if virtualService == nil {
+		t.Fatal("expected a virtual service")
+	}
+	expectedHosts := []string{network.GetServiceHostname(serviceName, namespace),
+		constants.InferenceServiceHostName(serviceName, namespace, "example.com"), globalHost}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	expectedMatch := &istiov1alpha3.HTTPMatchRequest{
+		Authority: &istiov1alpha3.StringMatch{
+			MatchType: &istiov1alpha3.StringMatch_Regex{Regex: constants.HostRegExp(globalHost)},
+		},
+		Gateways: []string{constants.KnativeIngressGateway},
+	}
+	if diff := cmp.Diff(expectedMatch, virtualService.Spec.Http[0].Match[2]); diff != "" {
+		t.Errorf("unexpected global host match (-want +got): %v", diff)
+	}
+
+	// The cluster local InferenceServices are not published under the global host
+	isvc.Labels = map[string]string{constants.VisibilityLabel: "cluster-local"}
+	virtualService = createIngress(isvc, ingressConfig, nil, "")
+	if diff := cmp.Diff([]string{network.GetServiceHostname(serviceName, namespace)}, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+}
+
+func TestCreateVirtualServiceHostTemplate(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	host := "my-model-test.models.example.com"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+		IngressDomain:           "example.com",
+		HostTemplate:            "{{ .Name }}-{{ .Namespace }}.models.{{ .IngressDomain }}",
+	}
+
+	// The host rendered from the template replaces the host of the Knative service
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if virtualService == nil {
+		t.Fatal("expected a virtual service")
+	}
+	expectedHosts := []string{network.GetServiceHostname(serviceName, namespace), host}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	expectedMatch := &istiov1alpha3.HTTPMatchRequest{
+		Authority: &istiov1alpha3.StringMatch{
+			MatchType: &istiov1alpha3.StringMatch_Regex{Regex: constants.HostRegExp(host)},
+		},
+		Gateways: []string{constants.KnativeIngressGateway},
+	}
+	if diff := cmp.Diff(expectedMatch, virtualService.Spec.Http[0].Match[1]); diff != "" {
+		t.Errorf("unexpected host match (-want +got): %v", diff)
+	}
+
+	// An invalid host is reported on the IngressReady condition
+	ingressConfig.HostTemplate = "{{ .Name }}_{{ .Namespace }}.models.example.com"
+	if virtualService = createIngress(isvc, ingressConfig, nil, ""); virtualService != nil {
+		t.Error("expected no virtual service for an invalid host")
+	}
+	if condition := isvc.Status.GetCondition(v1beta1.IngressReady); condition == nil ||
+		condition.Reason != "Invalid host name" {
+		t.Errorf("unexpected IngressReady condition %v", condition)
+	}
+}
+
+func TestCreateVirtualServiceRevisionRoutes(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	latestRevision := constants.DefaultPredictorServiceName(serviceName) + "-00002"
+	previousRevision := constants.DefaultPredictorServiceName(serviceName) + "-00001"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+					LatestReadyRevision:       latestRevision,
+					LatestRolledoutRevision:   previousRevision,
+					PreviousRolledoutRevision: previousRevision,
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The revision routes are opt-in
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if len(virtualService.Spec.Http) != 1 || len(virtualService.Spec.Hosts) != 2 {
+		t.Errorf("unexpected revision routes: %v", virtualService.Spec.Http)
+	}
+
+	// Each revision is published once and routed directly to the revision service
+	isvc.Annotations = map[string]string{constants.EnableRevisionRoutesAnnotationKey: "true"}
+	virtualService = createIngress(isvc, ingressConfig, nil, "")
+	latestHost := constants.InferenceServiceHostName(serviceName+"-00002", namespace, "example.com")
+	previousHost := constants.InferenceServiceHostName(serviceName+"-00001", namespace, "example.com")
+	expectedHosts := []string{network.GetServiceHostname(serviceName, namespace),
+		constants.InferenceServiceHostName(serviceName, namespace, "example.com"), latestHost, previousHost}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	expectedRoute := &istiov1alpha3.HTTPRoute{
+		Match: []*istiov1alpha3.HTTPMatchRequest{
+			{
+				Authority: &istiov1alpha3.StringMatch{
+					MatchType: &istiov1alpha3.StringMatch_Regex{Regex: constants.HostRegExp(previousHost)},
+				},
+				Gateways: []string{constants.KnativeIngressGateway},
+			},
+		},
+		Route: []*istiov1alpha3.HTTPRouteDestination{
+			{
+				Destination: &istiov1alpha3.Destination{
+					Host: network.GetServiceHostname(previousRevision, namespace),
+					Port: &istiov1alpha3.PortSelector{Number: constants.CommonDefaultHttpPort},
+				},
+				Weight: 100,
+			},
+		},
+	}
+	if len(virtualService.Spec.Http) != 3 {
+		t.Fatalf("expected 3 routes, got %d", len(virtualService.Spec.Http))
+	}
+	if diff := cmp.Diff(expectedRoute, virtualService.Spec.Http[2]); diff != "" {
+		t.Errorf("unexpected revision route (-want +got): %v", diff)
+	}
+
+	// The revision hosts are rendered from the host template even when the name of the InferenceService repeats in
+	// the host
+	templateConfig := *ingressConfig
+	templateConfig.IngressDomain = "example.com"
+	templateConfig.HostTemplate = "{{ .Namespace }}-{{ .Name }}.{{ .Name }}.{{ .IngressDomain }}"
+	virtualService = createIngress(isvc, &templateConfig, nil, "")
+	expectedHosts = []string{network.GetServiceHostname(serviceName, namespace),
+		"test-my-model.my-model.example.com", "test-my-model-00002.my-model-00002.example.com",
+		"test-my-model-00001.my-model-00001.example.com"}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+
+	// The cluster local InferenceServices have no revision routes
+	isvc.Labels = map[string]string{constants.VisibilityLabel: "cluster-local"}
+	virtualService = createIngress(isvc, ingressConfig, nil, "")
+	if len(virtualService.Spec.Http) != 1 {
+		t.Errorf("unexpected revision routes: %v", virtualService.Spec.Http)
+	}
+}
+
+func TestCreateVirtualServiceTrafficMirror(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	latestRevision := constants.DefaultPredictorServiceName(serviceName) + "-00002"
+	previousRevision := constants.DefaultPredictorServiceName(serviceName) + "-00001"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					CanaryTrafficPercent: proto.Int64(0),
+					TrafficMirror:        &v1beta1.TrafficMirror{Percent: proto.Int64(20)},
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+					LatestReadyRevision:     latestRevision,
+					LatestRolledoutRevision: previousRevision,
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The requests are mirrored to the latest ready revision while the previous revision serves the traffic
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	expectedMirror := &istiov1alpha3.Destination{
+		Host: network.GetServiceHostname(latestRevision, namespace),
+		Port: &istiov1alpha3.PortSelector{Number: constants.CommonDefaultHttpPort},
+	}
+	if diff := cmp.Diff(expectedMirror, virtualService.Spec.Http[0].Mirror); diff != "" {
+		t.Errorf("unexpected mirror (-want +got): %v", diff)
+	}
+	if diff := cmp.Diff(&istiov1alpha3.Percent{Value: 20}, virtualService.Spec.Http[0].MirrorPercentage); diff != "" {
+		t.Errorf("unexpected mirror percentage (-want +got): %v", diff)
+	}
+
+	// The requests are no longer mirrored once the latest ready revision is rolled out
+	status := isvc.Status.Components[v1beta1.PredictorComponent]
+	status.LatestRolledoutRevision = latestRevision
+	isvc.Status.Components[v1beta1.PredictorComponent] = status
+	virtualService = createIngress(isvc, ingressConfig, nil, "")
+	if virtualService.Spec.Http[0].Mirror != nil || virtualService.Spec.Http[0].MirrorPercentage != nil {
+		t.Errorf("unexpected mirror: %v", virtualService.Spec.Http[0].Mirror)
+	}
+}
+
+func TestCreateVirtualServiceRoutePolicy(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					TimeoutSeconds: proto.Int64(600),
+					Retries: &v1beta1.RetryPolicy{
+						Attempts:             3,
+						PerTryTimeoutSeconds: proto.Int64(200),
+						RetryOn:              "5xx,connect-failure",
+					},
+				},
+			},
+			Explainer: &v1beta1.ExplainerSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					TimeoutSeconds: proto.Int64(60),
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{
+					{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue},
+					{Type: v1beta1.ExplainerReady, Status: corev1.ConditionTrue},
+				},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// Each route takes the timeout and the retries of the component it is routed to
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if len(virtualService.Spec.Http) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(virtualService.Spec.Http))
+	}
+	explainRoute, predictRoute := virtualService.Spec.Http[0], virtualService.Spec.Http[1]
+	if diff := cmp.Diff(&gogotypes.Duration{Seconds: 60}, explainRoute.Timeout); diff != "" {
+		t.Errorf("unexpected explain timeout (-want +got): %v", diff)
+	}
+	if explainRoute.Retries != nil {
+		t.Errorf("unexpected explain retries: %v", explainRoute.Retries)
+	}
+	if diff := cmp.Diff(&gogotypes.Duration{Seconds: 600}, predictRoute.Timeout); diff != "" {
+		t.Errorf("unexpected predict timeout (-want +got): %v", diff)
+	}
+	expectedRetries := &istiov1alpha3.HTTPRetry{
+		Attempts:      3,
+		PerTryTimeout: &gogotypes.Duration{Seconds: 200},
+		RetryOn:       "5xx,connect-failure",
+	}
+	if diff := cmp.Diff(expectedRetries, predictRoute.Retries); diff != "" {
+		t.Errorf("unexpected predict retries (-want +got): %v", diff)
+	}
+}
+
+func TestCreateVirtualServiceIngressGateway(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceName,
+			Namespace:   namespace,
+			Annotations: map[string]string{constants.IngressGatewayAnnotationKey: "team-a/team-a-gateway"},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The external hosts are routed through the gateway of the InferenceService, the cluster local host is unchanged
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if diff := cmp.Diff([]string{constants.KnativeLocalGateway, "team-a/team-a-gateway"}, virtualService.Spec.Gateways); diff != "" {
+		t.Errorf("unexpected gateways (-want +got): %v", diff)
+	}
+	var matchGateways []string
+	for _, match := range virtualService.Spec.Http[0].Match {
+		matchGateways = append(matchGateways, match.Gateways...)
+	}
+	if diff := cmp.Diff([]string{constants.KnativeLocalGateway, "team-a/team-a-gateway"}, matchGateways); diff != "" {
+		t.Errorf("unexpected match gateways (-want +got): %v", diff)
+	}
+	if ingressConfig.IngressGateway != constants.KnativeIngressGateway {
+		t.Errorf("the ingress config was modified: %s", ingressConfig.IngressGateway)
+	}
+}
+
+func TestCreateVirtualServiceCorsPolicy(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+		CorsPolicy: &v1beta1.CorsPolicyConfig{
+			AllowOrigins:     []string{"*"},
+			AllowMethods:     []string{"GET", "POST"},
+			AllowHeaders:     []string{"Content-Type"},
+			MaxAgeSeconds:    proto.Int64(600),
+			AllowCredentials: proto.Bool(false),
+		},
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    *istiov1alpha3.CorsPolicy
+	}{
+		"ConfigPolicy": {
+			expected: &istiov1alpha3.CorsPolicy{
+				AllowOrigins: []*istiov1alpha3.StringMatch{
+					{MatchType: &istiov1alpha3.StringMatch_Regex{Regex: ".*"}},
+				},
+				AllowMethods:     []string{"GET", "POST"},
+				AllowHeaders:     []string{"Content-Type"},
+				MaxAge:           &gogotypes.Duration{Seconds: 600},
+				AllowCredentials: &gogotypes.BoolValue{Value: false},
+			},
+		},
+		"AnnotationOrigins": {
+			annotations: map[string]string{
+				constants.CorsAllowOriginsAnnotationKey: "https://app.example.com, http://localhost:3000",
+			},
+			expected: &istiov1alpha3.CorsPolicy{
+				AllowOrigins: []*istiov1alpha3.StringMatch{
+					{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "https://app.example.com"}},
+					{MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "http://localhost:3000"}},
+				},
+				AllowMethods:     []string{"GET", "POST"},
+				AllowHeaders:     []string{"Content-Type"},
+				MaxAge:           &gogotypes.Duration{Seconds: 600},
+				AllowCredentials: &gogotypes.BoolValue{Value: false},
+			},
+		},
+		"AnnotationDisabled": {
+			annotations: map[string]string{constants.CorsAllowOriginsAnnotationKey: ""},
+			expected:    nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceName,
+					Namespace:   namespace,
+					Annotations: scenario.annotations,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Explainer: &v1beta1.ExplainerSpec{},
+				},
+				Status: v1beta1.InferenceServiceStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{
+							{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue},
+							{Type: v1beta1.ExplainerReady, Status: corev1.ConditionTrue},
+						},
+					},
+					Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+						v1beta1.PredictorComponent: {
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+							},
+						},
+					},
+				},
+			}
+			virtualService := createIngress(isvc, ingressConfig, []string{"model-a"}, "")
+			// The explain, the model, the unknown model and the predict routes share the policy
+			if len(virtualService.Spec.Http) != 4 {
+				t.Fatalf("expected 4 routes, got %d", len(virtualService.Spec.Http))
+			}
+			for _, route := range virtualService.Spec.Http {
+				if diff := cmp.Diff(scenario.expected, route.CorsPolicy); diff != "" {
+					t.Errorf("unexpected CORS policy (-want +got): %v", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateVirtualServiceGrpcRoutes(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	serviceHost := constants.InferenceServiceHostName(serviceName, namespace, "example.com")
+	predictorHost := network.GetServiceHostname(constants.DefaultPredictorServiceName(serviceName), namespace)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transformer: &v1beta1.TransformerSpec{},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{
+					{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue},
+					{Type: v1beta1.TransformerReady, Status: corev1.ConditionTrue},
+				},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+				v1beta1.TransformerComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultTransformerServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+		GrpcRoutes: &v1beta1.GrpcRoutesConfig{
+			LocalGatewayPort: 8081,
+			EnableSubdomain:  true,
+		},
+	}
+	grpcMatch := func(host, gateway string) *istiov1alpha3.HTTPMatchRequest {
+		return &istiov1alpha3.HTTPMatchRequest{
+			Authority: &istiov1alpha3.StringMatch{
+				MatchType: &istiov1alpha3.StringMatch_Regex{Regex: constants.HostRegExp(host)},
+			},
+			Headers: map[string]*istiov1alpha3.StringMatch{
+				"content-type": {MatchType: &istiov1alpha3.StringMatch_Prefix{Prefix: "application/grpc"}},
+			},
+			Gateways: []string{gateway},
+		}
+	}
+
+	// The gRPC requests are routed to the predictor on the gRPC port of the local gateway before the predict route
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if virtualService == nil {
+		t.Fatal("expected a virtual service")
+	}
+	expectedHosts := []string{network.GetServiceHostname(serviceName, namespace), serviceHost, "grpc-" + serviceHost}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	if len(virtualService.Spec.Http) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(virtualService.Spec.Http))
+	}
+	grpcSubdomainMatch := grpcMatch("grpc-"+serviceHost, constants.KnativeIngressGateway)
+	grpcSubdomainMatch.Headers = nil
+	expectedRoute := &istiov1alpha3.HTTPRoute{
+		Match: []*istiov1alpha3.HTTPMatchRequest{
+			grpcMatch(network.GetServiceHostname(serviceName, namespace), constants.KnativeLocalGateway),
+			grpcMatch(serviceHost, constants.KnativeIngressGateway),
+			grpcSubdomainMatch,
+		},
+		Route: []*istiov1alpha3.HTTPRouteDestination{
+			{
+				Destination: &istiov1alpha3.Destination{
+					Host: "knative-local-gateway.istio-system.svc.cluster.local",
+					Port: &istiov1alpha3.PortSelector{Number: 8081},
+				},
+				Weight: 100,
+			},
+		},
+		Headers: &istiov1alpha3.Headers{
+			Request: &istiov1alpha3.Headers_HeaderOperations{
+				Set: map[string]string{"Host": predictorHost},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedRoute, virtualService.Spec.Http[0]); diff != "" {
+		t.Errorf("unexpected gRPC route (-want +got): %v", diff)
+	}
+
+	// The gRPC clients connect to the gRPC subdomain
+	isvc.Status.URL = &apis.URL{Scheme: "https", Host: serviceHost, Path: "/v1/models/my-model:predict"}
+	if diff := cmp.Diff(&apis.URL{Scheme: "https", Host: "grpc-" + serviceHost}, GrpcURL(isvc, ingressConfig)); diff != "" {
+		t.Errorf("unexpected gRPC url (-want +got): %v", diff)
+	}
+
+	// The cluster local InferenceServices are not published under the gRPC subdomain
+	isvc.Labels = map[string]string{constants.VisibilityLabel: "cluster-local"}
+	virtualService = createIngress(isvc, ingressConfig, nil, "")
+	if diff := cmp.Diff([]string{network.GetServiceHostname(serviceName, namespace)}, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	if diff := cmp.Diff(expectedRoute.Match[:1], virtualService.Spec.Http[0].Match); diff != "" {
+		t.Errorf("unexpected gRPC matches (-want +got): %v", diff)
+	}
+	isvc.Status.URL = &apis.URL{Scheme: "http", Host: network.GetServiceHostname(serviceName, namespace)}
+	if diff := cmp.Diff(isvc.Status.URL, GrpcURL(isvc, ingressConfig)); diff != "" {
+		t.Errorf("unexpected gRPC url (-want +got): %v", diff)
+	}
+
+	// No gRPC url is published without the gRPC routes
+	ingressConfig.GrpcRoutes = nil
+	if url := GrpcURL(isvc, ingressConfig); url != nil {
+		t.Errorf("expected no gRPC url, got %v", url)
+	}
+}
+
+func TestCreateVirtualServiceCanaryHeader(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	latestRevision := constants.DefaultPredictorServiceName(serviceName) + "-00002"
+	previousRevision := constants.DefaultPredictorServiceName(serviceName) + "-00001"
+	latestRevisionHost := network.GetServiceHostname(latestRevision, namespace)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace,
+			Annotations: map[string]string{constants.CanaryHeaderAnnotationKey: "X-Model-Revision: canary"}},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					CanaryTrafficPercent: proto.Int64(0),
+					TimeoutSeconds:       proto.Int64(600),
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+					LatestReadyRevision:     latestRevision,
+					LatestRolledoutRevision: previousRevision,
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The requests with the canary header are routed to the latest ready revision before the predict route
+	virtualService := createIngress(isvc, ingressConfig, nil, "")
+	if len(virtualService.Spec.Http) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(virtualService.Spec.Http))
+	}
+	predictRoute := virtualService.Spec.Http[1]
+	expectedRoute := &istiov1alpha3.HTTPRoute{
+		Route: []*istiov1alpha3.HTTPRouteDestination{
+			{
+				Destination: &istiov1alpha3.Destination{
+					Host: latestRevisionHost,
+					Port: &istiov1alpha3.PortSelector{Number: constants.CommonDefaultHttpPort},
+				},
+				Weight: 100,
+			},
+		},
+		Headers: &istiov1alpha3.Headers{
+			Request: &istiov1alpha3.Headers_HeaderOperations{
+				Set: map[string]string{"Host": latestRevisionHost},
+			},
+		},
+		Timeout: &gogotypes.Duration{Seconds: 600},
+	}
+	for _, match := range predictRoute.Match {
+		canaryMatch := gogoproto.Clone(match).(*istiov1alpha3.HTTPMatchRequest)
+		canaryMatch.Headers = map[string]*istiov1alpha3.StringMatch{
+			"x-model-revision": {MatchType: &istiov1alpha3.StringMatch_Exact{Exact: "canary"}},
+		}
+		expectedRoute.Match = append(expectedRoute.Match, canaryMatch)
+	}
+	if diff := cmp.Diff(expectedRoute, virtualService.Spec.Http[0]); diff != "" {
+		t.Errorf("unexpected canary route (-want +got): %v", diff)
+	}
+	if predictRoute.Match[0].Headers != nil {
+		t.Errorf("unexpected headers of the predict route: %v", predictRoute.Match[0].Headers)
+	}
+
+	// No route is added without a ready revision
+	status := isvc.Status.Components[v1beta1.PredictorComponent]
+	status.LatestReadyRevision = ""
+	isvc.Status.Components[v1beta1.PredictorComponent] = status
+	if virtualService = createIngress(isvc, ingressConfig, nil, ""); len(virtualService.Spec.Http) != 1 {
+		t.Errorf("expected 1 route, got %d", len(virtualService.Spec.Http))
+	}
+}
+
+func TestCreateVirtualServiceModelRoutes(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespace,
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					TimeoutSeconds: proto.Int64(60),
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.DefaultPredictorServiceName(serviceName), namespace, "example.com"),
+					},
+				},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+
+	// The registered models are routed like the predict route, the other models are rejected and the paths of the
+	// server are still routed to the predictor
+	virtualService := createIngress(isvc, ingressConfig, []string{"model-a", "model.b"}, "")
+	if len(virtualService.Spec.Http) != 3 {
+		t.Fatalf("expected 3 routes, got %d", len(virtualService.Spec.Http))
+	}
+	modelRoute, unknownModelRoute, predictRoute := virtualService.Spec.Http[0], virtualService.Spec.Http[1], virtualService.Spec.Http[2]
+	routed := func(route *istiov1alpha3.HTTPRoute, path string) bool {
+		if len(route.Match) != 2 {
+			t.Fatalf("expected 2 matches, got %d", len(route.Match))
+		}
+		for _, match := range route.Match {
+			if match.Uri == nil {
+				return true
+			}
+			if !regexp.MustCompile(match.Uri.GetRegex()).MatchString(path) {
+				return false
+			}
+		}
+		return true
+	}
+	scenarios := map[string]struct {
+		path     string
+		expected *istiov1alpha3.HTTPRoute
+	}{
+		"V1Predict":          {path: "/v1/models/model-a:predict", expected: modelRoute},
+		"V2Infer":            {path: "/v2/models/model-a/infer", expected: modelRoute},
+		"V2Metadata":         {path: "/v2/models/model.b", expected: modelRoute},
+		"UnknownModel":       {path: "/v2/models/model-c/infer", expected: unknownModelRoute},
+		"EscapedModel":       {path: "/v2/models/modelxb/infer", expected: unknownModelRoute},
+		"ModelPrefix":        {path: "/v1/models/model-a-2:predict", expected: unknownModelRoute},
+		"ServerHealth":       {path: "/v2/health/ready", expected: predictRoute},
+		"ServerModelListing": {path: "/v1/models", expected: predictRoute},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			for _, route := range virtualService.Spec.Http {
+				if routed(route, scenario.path) {
+					if route != scenario.expected {
+						t.Errorf("path %s routed by the unexpected route %v", scenario.path, route)
+					}
+					return
+				}
+			}
+			t.Errorf("path %s not routed", scenario.path)
+		})
+	}
+	if diff := cmp.Diff(predictRoute.Timeout, modelRoute.Timeout); diff != "" {
+		t.Errorf("unexpected model timeout (-want +got): %v", diff)
+	}
+	expectedFault := &istiov1alpha3.HTTPFaultInjection{
+		Abort: &istiov1alpha3.HTTPFaultInjection_Abort{
+			ErrorType:  &istiov1alpha3.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: 404},
+			Percentage: &istiov1alpha3.Percent{Value: 100},
+		},
+	}
+	if diff := cmp.Diff(expectedFault, unknownModelRoute.Fault); diff != "" {
+		t.Errorf("unexpected unknown model fault (-want +got): %v", diff)
+	}
+	if predictRoute.Match[0].Uri != nil {
+		t.Errorf("the predict route was modified: %v", predictRoute.Match[0].Uri)
+	}
+
+	// Without registered models all the model paths are rejected
+	virtualService = createIngress(isvc, ingressConfig, []string{}, "")
+	if len(virtualService.Spec.Http) != 2 || virtualService.Spec.Http[0].Fault == nil {
+		t.Errorf("expected the unknown model and the predict routes, got %v", virtualService.Spec.Http)
+	}
+}
+
+func TestTrainedModels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+	now := metav1.Now()
+	trainedModel := func(name, isvc string, allocated bool) *v1alpha1.TrainedModel {
+		tm := &v1alpha1.TrainedModel{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: map[string]string{}},
+			Spec:       v1alpha1.TrainedModelSpec{InferenceService: isvc},
+		}
+		if allocated {
+			tm.Labels[constants.TrainedModelAllocated] = isvc
+		}
+		return tm
+	}
+	deleted := trainedModel("deleted", "my-model", true)
+	deleted.DeletionTimestamp = &now
+	deleted.Finalizers = []string{"trainedmodel.finalizer"}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		trainedModel("model-b", "my-model", true),
+		trainedModel("model-a", "my-model", true),
+		trainedModel("not-allocated", "my-model", false),
+		trainedModel("other", "other-model", true),
+		deleted,
+	).Build()
+	reconciler := NewIngressReconciler(cli, s, &v1beta1.IngressConfig{}, clock.RealClock{})
+
+	// Only the models allocated to the multi-model predictor and not being deleted are routed
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Triton: &v1beta1.TritonSpec{},
+			},
+		},
+	}
+	models, err := reconciler.trainedModels(isvc)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(models).To(gomega.Equal([]string{"model-a", "model-b"}))
+
+	// The predictors serving a single model are not routed by model
+	isvc.Spec.Predictor.Triton.StorageURI = proto.String("gs://kfserving-examples/models/triton")
+	models, err = reconciler.trainedModels(isvc)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(models).To(gomega.BeNil())
+}
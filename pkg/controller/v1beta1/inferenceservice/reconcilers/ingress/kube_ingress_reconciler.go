@@ -61,10 +61,21 @@ func createRawURL(isvc *v1beta1api.InferenceService,
 	var err error
 	url := &knapis.URL{}
 	url.Scheme = ingressConfig.UrlScheme
+	if rawIngressTlsEnabled(ingressConfig) {
+		url.Scheme = "https"
+	}
 	url.Host, err = GenerateDomainName(isvc.Name, isvc.ObjectMeta, ingressConfig)
 	if err != nil {
 		return nil, err
 	}
+	// The clients of the InferenceServices served by several clusters use the global host
+	globalHost, err := GenerateGlobalDomainName(isvc.ObjectMeta, ingressConfig)
+	if err != nil {
+		return nil, err
+	}
+	if globalHost != "" {
+		url.Host = globalHost
+	}
 
 	return url, nil
 }
@@ -136,8 +147,10 @@ func generateIngressHost(ingressConfig *v1beta1api.IngressConfig,
 	}
 }
 
-func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
-	ingressConfig *v1beta1api.IngressConfig) (*netv1.Ingress, error) {
+// createRawIngressRules returns the rules routing the hosts of the InferenceService to the services of its components,
+// no rules are returned until the components are ready
+func createRawIngressRules(isvc *v1beta1api.InferenceService,
+	ingressConfig *v1beta1api.IngressConfig) ([]netv1.IngressRule, error) {
 	if !isvc.Status.IsConditionReady(v1beta1api.PredictorReady) {
 		isvc.Status.SetCondition(v1beta1api.IngressReady, &apis.Condition{
 			Type:   v1beta1api.IngressReady,
@@ -146,7 +159,6 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
 		})
 		return nil, nil
 	}
-	var rules []netv1.IngressRule
 	if isvc.Spec.Transformer != nil {
 		if !isvc.Status.IsConditionReady(v1beta1api.TransformerReady) {
 			isvc.Status.SetCondition(v1beta1api.IngressReady, &apis.Condition{
@@ -156,6 +168,26 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
 			})
 			return nil, nil
 		}
+	} else if isvc.Spec.Explainer != nil {
+		if !isvc.Status.IsConditionReady(v1beta1api.ExplainerReady) {
+			isvc.Status.SetCondition(v1beta1api.IngressReady, &apis.Condition{
+				Type:   v1beta1api.IngressReady,
+				Status: corev1.ConditionFalse,
+				Reason: "Explainer ingress not created",
+			})
+			return nil, nil
+		}
+	}
+	return generateRawIngressRules(isvc, ingressConfig)
+}
+
+func generateRawIngressRules(isvc *v1beta1api.InferenceService,
+	ingressConfig *v1beta1api.IngressConfig) ([]netv1.IngressRule, error) {
+	var rules []netv1.IngressRule
+	// The service the requests for the top level host are routed to
+	topLevelService := constants.DefaultPredictorServiceName(isvc.Name)
+	if isvc.Spec.Transformer != nil {
+		topLevelService = constants.DefaultTransformerServiceName(isvc.Name)
 		host, err := generateIngressHost(ingressConfig, isvc, string(constants.Transformer), true)
 		if err != nil {
 			return nil, fmt.Errorf("failed creating top level transformer ingress host: %v", err)
@@ -175,14 +207,6 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
 		rules = append(rules, generateRule(host, constants.DefaultTransformerServiceName(isvc.Name), "/"))
 		rules = append(rules, generateRule(transformerHost, constants.DefaultTransformerServiceName(isvc.Name), "/"))
 	} else if isvc.Spec.Explainer != nil {
-		if !isvc.Status.IsConditionReady(v1beta1api.ExplainerReady) {
-			isvc.Status.SetCondition(v1beta1api.IngressReady, &apis.Condition{
-				Type:   v1beta1api.IngressReady,
-				Status: corev1.ConditionFalse,
-				Reason: "Explainer ingress not created",
-			})
-			return nil, nil
-		}
 		host, err := generateIngressHost(ingressConfig, isvc, string(constants.Explainer), true)
 		if err != nil {
 			return nil, fmt.Errorf("failed creating top level explainer ingress host: %v", err)
@@ -207,7 +231,45 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
 		return nil, fmt.Errorf("failed creating predictor ingress host: %v", err)
 	}
 	rules = append(rules, generateRule(predictorHost, constants.DefaultPredictorServiceName(isvc.Name), "/"))
+	// The global load balancer sends the requests of the InferenceServices served by several clusters with the global
+	// host
+	globalHost, err := GenerateGlobalDomainName(isvc.ObjectMeta, ingressConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating global ingress host: %v", err)
+	}
+	if globalHost != "" {
+		rules = append(rules, generateRule(globalHost, topLevelService, "/"))
+	}
+	return rules, nil
+}
 
+// RawServiceHosts returns the hosts routed to each component service of the InferenceService by the raw ingresses of
+// the ingress config and of its additional ingress classes, whether the components are ready or not
+func RawServiceHosts(isvc *v1beta1api.InferenceService, ingressConfig *v1beta1api.IngressConfig) (map[string][]string, error) {
+	configs := []*v1beta1api.IngressConfig{ingressConfig}
+	for _, class := range ingressConfig.AdditionalIngressClasses {
+		configs = append(configs, ingressClassConfig(ingressConfig, class))
+	}
+	hosts := map[string][]string{}
+	for _, config := range configs {
+		rules, err := generateRawIngressRules(isvc, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			service := rule.HTTP.Paths[0].Backend.Service.Name
+			hosts[service] = append(hosts[service], rule.Host)
+		}
+	}
+	return hosts, nil
+}
+
+func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
+	ingressConfig *v1beta1api.IngressConfig) (*netv1.Ingress, error) {
+	rules, err := createRawIngressRules(isvc, ingressConfig)
+	if rules == nil || err != nil {
+		return nil, err
+	}
 	ingress := &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        isvc.ObjectMeta.Name,
@@ -225,6 +287,60 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
 	return ingress, nil
 }
 
+// createAdditionalRawIngresses returns an ingress per additional ingress class of the ingress config, named
+// <isvc>-<ingress class>, the hosts are generated with the domain of the class and the annotations of the class are
+// added to the annotations of the InferenceService
+func createAdditionalRawIngresses(scheme *runtime.Scheme, isvc *v1beta1api.InferenceService,
+	ingressConfig *v1beta1api.IngressConfig) ([]*netv1.Ingress, error) {
+	ingresses := []*netv1.Ingress{}
+	for _, class := range ingressConfig.AdditionalIngressClasses {
+		rules, err := createRawIngressRules(isvc, ingressClassConfig(ingressConfig, class))
+		if rules == nil || err != nil {
+			return nil, err
+		}
+		className := class.IngressClassName
+		ingress := &netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        additionalIngressName(isvc, className),
+				Namespace:   isvc.ObjectMeta.Namespace,
+				Annotations: utils.Union(isvc.Annotations, class.Annotations),
+				Labels: map[string]string{
+					constants.InferenceServicePodLabelKey: isvc.Name,
+				},
+			},
+			Spec: netv1.IngressSpec{
+				IngressClassName: &className,
+				Rules:            rules,
+			},
+		}
+		if err := controllerutil.SetControllerReference(isvc, ingress, scheme); err != nil {
+			return nil, err
+		}
+		ingresses = append(ingresses, ingress)
+	}
+	return ingresses, nil
+}
+
+// ingressClassConfig returns the ingress config generating the hosts of an additional ingress class, the global host
+// stays on the ingress of the ingress config
+func ingressClassConfig(ingressConfig *v1beta1api.IngressConfig, class v1beta1api.IngressClassConfig) *v1beta1api.IngressConfig {
+	config := *ingressConfig
+	config.IngressClassName = &class.IngressClassName
+	config.GlobalDomainTemplate = ""
+	config.AdditionalIngressClasses = nil
+	if class.IngressDomain != "" {
+		config.IngressDomain = class.IngressDomain
+	}
+	if class.DomainTemplate != "" {
+		config.DomainTemplate = class.DomainTemplate
+	}
+	return &config
+}
+
+func additionalIngressName(isvc *v1beta1api.InferenceService, ingressClassName string) string {
+	return isvc.Name + "-" + ingressClassName
+}
+
 func semanticIngressEquals(desired, existing *netv1.Ingress) bool {
 	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec)
 }
@@ -237,36 +353,88 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1api.InferenceService) erro
 	if err != nil {
 		return err
 	}
-	//reconcile ingress
-	existingIngress := &netv1.Ingress{}
-	err = r.client.Get(context.TODO(), types.NamespacedName{
-		Namespace: isvc.Namespace,
-		Name:      isvc.Name,
-	}, existingIngress)
+	additionalIngresses, err := createAdditionalRawIngresses(r.scheme, isvc, r.ingressConfig)
 	if err != nil {
-		if apierr.IsNotFound(err) {
-			err = r.client.Create(context.TODO(), ingress)
-			log.Info("creating ingress", "ingressName", isvc.Name, "err", err)
-		} else {
+		return err
+	}
+	ingresses := append([]*netv1.Ingress{ingress}, additionalIngresses...)
+	if rawIngressTlsEnabled(r.ingressConfig) {
+		for _, desired := range ingresses {
+			setRawIngressTls(desired, r.ingressConfig.Tls)
+		}
+		if err := r.reconcileCertificates(isvc, ingresses); err != nil {
 			return err
 		}
-	} else {
-		if !semanticIngressEquals(ingress, existingIngress) {
-			err = r.client.Update(context.TODO(), ingress)
-			log.Info("updating ingress", "ingressName", isvc.Name, "err", err)
+	}
+	for _, desired := range ingresses {
+		if err := r.reconcileIngress(desired); err != nil {
+			return err
 		}
 	}
+	if err := r.deleteStaleIngresses(isvc, additionalIngresses); err != nil {
+		return err
+	}
+	return propagateRawIngressStatus(isvc, r.ingressConfig)
+}
+
+func (r *RawIngressReconciler) reconcileIngress(desired *netv1.Ingress) error {
+	existingIngress := &netv1.Ingress{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{
+		Namespace: desired.Namespace,
+		Name:      desired.Name,
+	}, existingIngress)
 	if err != nil {
+		if apierr.IsNotFound(err) {
+			err = r.client.Create(context.TODO(), desired)
+			log.Info("creating ingress", "ingressName", desired.Name, "err", err)
+		}
 		return err
 	}
-	isvc.Status.URL, err = createRawURL(isvc, r.ingressConfig)
+	if !semanticIngressEquals(desired, existingIngress) {
+		existingIngress.Spec = desired.Spec
+		existingIngress.Annotations = desired.Annotations
+		existingIngress.Labels = desired.Labels
+		err = r.client.Update(context.TODO(), existingIngress)
+		log.Info("updating ingress", "ingressName", desired.Name, "err", err)
+	}
+	return err
+}
+
+// deleteStaleIngresses deletes the ingresses of the ingress classes removed from the ingress config
+func (r *RawIngressReconciler) deleteStaleIngresses(isvc *v1beta1api.InferenceService, desired []*netv1.Ingress) error {
+	ingresses := &netv1.IngressList{}
+	if err := r.client.List(context.TODO(), ingresses, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		return err
+	}
+	names := []string{isvc.Name}
+	for _, ingress := range desired {
+		names = append(names, ingress.Name)
+	}
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		if utils.Includes(names, ingress.Name) || !metav1.IsControlledBy(ingress, isvc) {
+			continue
+		}
+		log.Info("deleting ingress", "ingressName", ingress.Name)
+		if err := r.client.Delete(context.TODO(), ingress); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagateRawIngressStatus sets the url and the address of the InferenceService once its routes are reconciled
+func propagateRawIngressStatus(isvc *v1beta1api.InferenceService, ingressConfig *v1beta1api.IngressConfig) error {
+	var err error
+	isvc.Status.URL, err = createRawURL(isvc, ingressConfig)
 	if err != nil {
 		return err
 	}
 	isvc.Status.Address = &duckv1.Addressable{
 		URL: &apis.URL{
 			Host:   network.GetServiceHostname(isvc.Name, isvc.Namespace),
-			Scheme: r.ingressConfig.UrlScheme,
+			Scheme: ingressConfig.UrlScheme,
 			Path:   "",
 		},
 	}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRawIngressReconcilerIngressClasses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	g.Expect(netv1.AddToScheme(s)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test", UID: "uid",
+			Annotations: map[string]string{"team": "a"}},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		IngressClassName: proto.String("alb"),
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		AdditionalIngressClasses: []v1beta1.IngressClassConfig{
+			{
+				IngressClassName: "nginx",
+				IngressDomain:    "internal.example.com",
+				Annotations:      map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "100m"},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	reconciler, err := NewRawIngressReconciler(cli, s, ingressConfig)
+	g.Expect(err).To(gomega.BeNil())
+
+	getIngress := func(name string) *netv1.Ingress {
+		ingress := &netv1.Ingress{}
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, ingress)).To(gomega.Succeed())
+		return ingress
+	}
+	hosts := func(ingress *netv1.Ingress) []string {
+		var hosts []string
+		for _, rule := range ingress.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+		return hosts
+	}
+
+	// An ingress is created per ingress class with the hosts of its domain and its annotations
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	external := getIngress("my-model")
+	g.Expect(*external.Spec.IngressClassName).To(gomega.Equal("alb"))
+	g.Expect(hosts(external)).To(gomega.Equal([]string{"my-model-test.example.com",
+		"my-model-predictor-default-test.example.com"}))
+	internal := getIngress("my-model-nginx")
+	g.Expect(*internal.Spec.IngressClassName).To(gomega.Equal("nginx"))
+	g.Expect(hosts(internal)).To(gomega.Equal([]string{"my-model-test.internal.example.com",
+		"my-model-predictor-default-test.internal.example.com"}))
+	g.Expect(internal.Annotations).To(gomega.Equal(map[string]string{"team": "a",
+		"nginx.ingress.kubernetes.io/proxy-body-size": "100m"}))
+	g.Expect(internal.OwnerReferences).To(gomega.HaveLen(1))
+	// The url of the InferenceService stays the host of the ingress config
+	g.Expect(isvc.Status.URL.String()).To(gomega.Equal("http://my-model-test.example.com"))
+
+	// The ingresses of the ingress classes removed from the ingress config are deleted
+	ingressConfig.AdditionalIngressClasses = nil
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	ingresses := &netv1.IngressList{}
+	g.Expect(cli.List(context.TODO(), ingresses)).To(gomega.Succeed())
+	g.Expect(ingresses.Items).To(gomega.HaveLen(1))
+	g.Expect(ingresses.Items[0].Name).To(gomega.Equal("my-model"))
+}
+
+func TestRawIngressReconcilerTls(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	g.Expect(netv1.AddToScheme(s)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test", UID: "uid"},
+		Status: v1beta1.InferenceServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		IngressClassName: proto.String("nginx"),
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		AdditionalIngressClasses: []v1beta1.IngressClassConfig{
+			{IngressClassName: "internal", IngressDomain: "internal.example.com"},
+		},
+		Tls: &v1beta1.IngressTlsConfig{ClusterIssuer: "letsencrypt", SslRedirect: true},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	reconciler, err := NewRawIngressReconciler(cli, s, ingressConfig)
+	g.Expect(err).To(gomega.BeNil())
+
+	getCertificate := func(name string) *unstructured.Unstructured {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: name}, certificate)).To(
+			gomega.Succeed())
+		return certificate
+	}
+
+	// The ingress serves its hosts with the secret of the certificate issued for them
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	ingress := &netv1.Ingress{}
+	g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "my-model"}, ingress)).To(
+		gomega.Succeed())
+	g.Expect(ingress.Spec.TLS).To(gomega.Equal([]netv1.IngressTLS{{
+		Hosts:      []string{"my-model-test.example.com", "my-model-predictor-default-test.example.com"},
+		SecretName: "my-model-tls",
+	}}))
+	g.Expect(ingress.Annotations).To(gomega.HaveKeyWithValue("nginx.ingress.kubernetes.io/force-ssl-redirect", "true"))
+	certificate := getCertificate("my-model")
+	g.Expect(certificate.Object["spec"]).To(gomega.Equal(map[string]interface{}{
+		"secretName": "my-model-tls",
+		"dnsNames":   []interface{}{"my-model-test.example.com", "my-model-predictor-default-test.example.com"},
+		"issuerRef": map[string]interface{}{
+			"name":  "letsencrypt",
+			"kind":  "ClusterIssuer",
+			"group": "cert-manager.io",
+		},
+	}))
+	g.Expect(certificate.GetOwnerReferences()).To(gomega.HaveLen(1))
+	dnsNames, _, _ := unstructured.NestedStringSlice(getCertificate("my-model-internal").Object, "spec", "dnsNames")
+	g.Expect(dnsNames).To(gomega.Equal([]string{"my-model-test.internal.example.com",
+		"my-model-predictor-default-test.internal.example.com"}))
+	g.Expect(isvc.Status.URL.String()).To(gomega.Equal("https://my-model-test.example.com"))
+
+	// The unchanged certificates are not updated
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(getCertificate("my-model").GetResourceVersion()).To(gomega.Equal(certificate.GetResourceVersion()))
+
+	// The certificates of the ingress classes removed from the ingress config are deleted
+	ingressConfig.AdditionalIngressClasses = nil
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(certificateListGVK)
+	g.Expect(cli.List(context.TODO(), certificates)).To(gomega.Succeed())
+	g.Expect(certificates.Items).To(gomega.HaveLen(1))
+	g.Expect(certificates.Items[0].GetName()).To(gomega.Equal("my-model"))
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"sort"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var HTTPScaledObjectGVK = schema.GroupVersionKind{Group: constants.KedaHTTPAPIGroupName, Version: "v1alpha1",
+	Kind: "HTTPScaledObject"}
+
+// KedaHTTPReconciler reconciles the HTTPScaledObject of the KEDA HTTP add-on scaling the deployment of a component on
+// its requests in flight. The service of the component points to the interceptor of the add-on, which holds the
+// requests while the component is scaled to zero and forwards them to the origin service selecting its pods.
+type KedaHTTPReconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	HTTPScaledObject *unstructured.Unstructured
+	componentExt     *v1beta1.ComponentExtensionSpec
+}
+
+func NewKedaHTTPReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) *KedaHTTPReconciler {
+	return &KedaHTTPReconciler{
+		client:           client,
+		scheme:           scheme,
+		HTTPScaledObject: createHTTPScaledObject(componentMeta, componentExt),
+		componentExt:     componentExt,
+	}
+}
+
+// serviceHosts returns the hosts the service of the component is called with from within the cluster
+func serviceHosts(componentMeta metav1.ObjectMeta) []string {
+	name := componentMeta.Name
+	namespace := componentMeta.Namespace
+	return []string{
+		name,
+		name + "." + namespace,
+		name + "." + namespace + ".svc",
+		name + "." + namespace + ".svc." + network.GetClusterDomainName(),
+	}
+}
+
+func createHTTPScaledObject(componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) *unstructured.Unstructured {
+	minReplicas := int64(constants.DefaultMinReplicas)
+	if componentExt.MinReplicas != nil && *componentExt.MinReplicas >= 0 {
+		minReplicas = int64(*componentExt.MinReplicas)
+	}
+	maxReplicas := int64(componentExt.MaxReplicas)
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+	if maxReplicas < 1 {
+		maxReplicas = 1
+	}
+	// Like the concurrency metric of the HPA, the target defaults to the container concurrency
+	target := int64(constants.DefaultConcurrencyTarget)
+	if componentExt.ScaleTarget != nil {
+		target = int64(*componentExt.ScaleTarget)
+	} else if componentExt.ContainerConcurrency != nil && *componentExt.ContainerConcurrency > 0 {
+		target = *componentExt.ContainerConcurrency
+	}
+	var hosts []interface{}
+	for _, host := range serviceHosts(componentMeta) {
+		hosts = append(hosts, host)
+	}
+	httpScaledObject := &unstructured.Unstructured{}
+	httpScaledObject.SetGroupVersionKind(HTTPScaledObjectGVK)
+	httpScaledObject.SetName(componentMeta.Name)
+	httpScaledObject.SetNamespace(componentMeta.Namespace)
+	httpScaledObject.SetLabels(componentMeta.Labels)
+	httpScaledObject.SetAnnotations(componentMeta.Annotations)
+	httpScaledObject.Object["spec"] = map[string]interface{}{
+		"hosts": hosts,
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       componentMeta.Name,
+			"service":    constants.RawOriginServiceName(componentMeta.Name),
+			"port":       int64(constants.CommonDefaultHttpPort),
+		},
+		"replicas": map[string]interface{}{
+			"min": minReplicas,
+			"max": maxReplicas,
+		},
+		"scalingMetric": map[string]interface{}{
+			"concurrency": map[string]interface{}{
+				"targetValue": target,
+			},
+		},
+	}
+	return httpScaledObject
+}
+
+// AddHosts adds the ingress hosts routed to the component, the interceptor routes the requests by their host
+func (r *KedaHTTPReconciler) AddHosts(hosts ...string) {
+	spec := r.HTTPScaledObject.Object["spec"].(map[string]interface{})
+	existing := map[string]bool{}
+	for _, host := range spec["hosts"].([]interface{}) {
+		existing[host.(string)] = true
+	}
+	for _, host := range hosts {
+		existing[host] = true
+	}
+	var sorted []string
+	for host := range existing {
+		sorted = append(sorted, host)
+	}
+	sort.Strings(sorted)
+	var all []interface{}
+	for _, host := range sorted {
+		all = append(all, host)
+	}
+	spec["hosts"] = all
+}
+
+// Reconcile ...
+func (r *KedaHTTPReconciler) Reconcile() (*unstructured.Unstructured, error) {
+	return reconcileObject(r.client, r.HTTPScaledObject)
+}
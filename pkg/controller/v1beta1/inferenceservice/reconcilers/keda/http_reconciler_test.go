@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKedaHTTPReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor-default", Namespace: "default"}
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(0), MaxReplicas: 3,
+		ContainerConcurrency: proto.Int64(4)}
+	getHTTPScaledObject := func() *unstructured.Unstructured {
+		httpScaledObject := &unstructured.Unstructured{}
+		httpScaledObject.SetGroupVersionKind(HTTPScaledObjectGVK)
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-default"},
+			httpScaledObject)).To(gomega.Succeed())
+		return httpScaledObject
+	}
+
+	// The HTTPScaledObject scales the deployment to zero on the requests for the hosts of its service and ingress
+	reconciler := NewKedaHTTPReconciler(cli, s, componentMeta, componentExt)
+	reconciler.AddHosts("sklearn-default.example.com", "sklearn-predictor-default-default.example.com")
+	_, err := reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	httpScaledObject := getHTTPScaledObject()
+	g.Expect(httpScaledObject.Object["spec"]).To(gomega.Equal(map[string]interface{}{
+		"hosts": []interface{}{
+			"sklearn-default.example.com",
+			"sklearn-predictor-default",
+			"sklearn-predictor-default-default.example.com",
+			"sklearn-predictor-default.default",
+			"sklearn-predictor-default.default.svc",
+			"sklearn-predictor-default.default.svc.cluster.local",
+		},
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       "sklearn-predictor-default",
+			"service":    "sklearn-predictor-default-origin",
+			"port":       int64(80),
+		},
+		"replicas":      map[string]interface{}{"min": int64(0), "max": int64(3)},
+		"scalingMetric": map[string]interface{}{"concurrency": map[string]interface{}{"targetValue": int64(4)}},
+	}))
+
+	// The unchanged HTTPScaledObject is not updated
+	reconciler = NewKedaHTTPReconciler(cli, s, componentMeta, componentExt)
+	reconciler.AddHosts("sklearn-predictor-default-default.example.com", "sklearn-default.example.com")
+	_, err = reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(getHTTPScaledObject().GetResourceVersion()).To(gomega.Equal(httpScaledObject.GetResourceVersion()))
+
+	// The scale target of the component updates the HTTPScaledObject
+	componentExt.ScaleTarget = v1beta1.GetIntReference(10)
+	reconciler = NewKedaHTTPReconciler(cli, s, componentMeta, componentExt)
+	_, err = reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	target, _, _ := unstructured.NestedInt64(getHTTPScaledObject().Object, "spec", "scalingMetric", "concurrency",
+		"targetValue")
+	g.Expect(target).To(gomega.Equal(int64(10)))
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("KedaReconciler")
+
+var ScaledObjectGVK = schema.GroupVersionKind{Group: constants.KedaAPIGroupName, Version: "v1alpha1", Kind: "ScaledObject"}
+
+// KedaReconciler reconciles the KEDA ScaledObject scaling the deployment of a component on the triggers of the
+// serving.kserve.io/keda-triggers annotation. KEDA creates and drives the HPA of the deployment, and scales it to zero
+// when the min replicas is 0.
+type KedaReconciler struct {
+	client       client.Client
+	scheme       *runtime.Scheme
+	ScaledObject *unstructured.Unstructured
+	componentExt *v1beta1.ComponentExtensionSpec
+}
+
+func NewKedaReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) (*KedaReconciler, error) {
+	scaledObject, err := createScaledObject(componentMeta, componentExt)
+	if err != nil {
+		return nil, err
+	}
+	return &KedaReconciler{
+		client:       client,
+		scheme:       scheme,
+		ScaledObject: scaledObject,
+		componentExt: componentExt,
+	}, nil
+}
+
+func createScaledObject(componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) (*unstructured.Unstructured, error) {
+	// The triggers are passed through in the format of KEDA, e.g. with their authenticationRef
+	var triggers []interface{}
+	if err := json.Unmarshal([]byte(componentMeta.Annotations[constants.KedaTriggersAnnotationKey]), &triggers); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", constants.KedaTriggersAnnotationKey, err)
+	}
+	// Unlike the HPA, KEDA scales the deployment to zero
+	minReplicas := int64(constants.DefaultMinReplicas)
+	if componentExt.MinReplicas != nil && *componentExt.MinReplicas >= 0 {
+		minReplicas = int64(*componentExt.MinReplicas)
+	}
+	maxReplicas := int64(componentExt.MaxReplicas)
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+	if maxReplicas < 1 {
+		maxReplicas = 1
+	}
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName(componentMeta.Name)
+	scaledObject.SetNamespace(componentMeta.Namespace)
+	scaledObject.SetLabels(componentMeta.Labels)
+	scaledObject.SetAnnotations(componentMeta.Annotations)
+	scaledObject.Object["spec"] = map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       componentMeta.Name,
+		},
+		"minReplicaCount": minReplicas,
+		"maxReplicaCount": maxReplicas,
+		"triggers":        triggers,
+	}
+	return scaledObject, nil
+}
+
+// Reconcile ...
+func (r *KedaReconciler) Reconcile() (*unstructured.Unstructured, error) {
+	return reconcileObject(r.client, r.ScaledObject)
+}
+
+// reconcileObject creates or updates the KEDA object, the spec is compared in the JSON representation of the existing
+// object. The labels and annotations added to the existing object by other controllers are kept.
+func reconcileObject(c client.Client, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing)
+	if err != nil {
+		if !apierr.IsNotFound(err) {
+			return nil, err
+		}
+		log.Info("Creating "+desired.GetKind(), "namespace", desired.GetNamespace(), "name", desired.GetName())
+		return desired, c.Create(context.TODO(), desired)
+	}
+	desiredSpec, err := normalize(desired.Object["spec"])
+	if err != nil {
+		return nil, err
+	}
+	if equality.Semantic.DeepEqual(desiredSpec, existing.Object["spec"]) &&
+		containsAll(existing.GetLabels(), desired.GetLabels()) &&
+		containsAll(existing.GetAnnotations(), desired.GetAnnotations()) {
+		return existing, nil
+	}
+	log.Info("Updating "+desired.GetKind(), "namespace", desired.GetNamespace(), "name", desired.GetName())
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	desired.SetLabels(utils.Union(existing.GetLabels(), desired.GetLabels()))
+	desired.SetAnnotations(utils.Union(existing.GetAnnotations(), desired.GetAnnotations()))
+	// The status of the object is kept by the update of its spec
+	desired.Object["status"] = existing.Object["status"]
+	return desired, c.Update(context.TODO(), desired)
+}
+
+func containsAll(existing map[string]string, desired map[string]string) bool {
+	for key, value := range desired {
+		if existingValue, ok := existing[key]; !ok || existingValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func normalize(spec interface{}) (interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	// The integers are decoded as int64 like in the unstructured objects read from the API server
+	var normalized interface{}
+	if err := utiljson.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Installed returns whether the API server serves the kind of the gvk, the KEDA resources are only served when KEDA or
+// its HTTP add-on is installed
+func Installed(discovery discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (bool, error) {
+	resources, err := discovery.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == gvk.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Conditions returns the conditions of the ScaledObject, e.g. Ready false when KEDA can not read its triggers
+func Conditions(scaledObject *unstructured.Unstructured) []metav1.Condition {
+	items, _, _ := unstructured.NestedSlice(scaledObject.Object, "status", "conditions")
+	var conditions []metav1.Condition
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := metav1.Condition{}
+		condition.Type, _ = fields["type"].(string)
+		status, _ := fields["status"].(string)
+		condition.Status = metav1.ConditionStatus(status)
+		condition.Reason, _ = fields["reason"].(string)
+		condition.Message, _ = fields["message"].(string)
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+// HPAName returns the name of the HPA created by KEDA for the ScaledObject of the component
+func HPAName(componentName string) string {
+	return "keda-hpa-" + componentName
+}
+
+// Delete deletes the KEDA object of the kind of the gvk of the component after its autoscaler class changed. The
+// object is only looked up when the cached HPA created by KEDA for the component exists, so the components which never
+// used KEDA do not call the API server, which may not serve the KEDA resources.
+func Delete(c client.Client, componentMeta metav1.ObjectMeta, gvk schema.GroupVersionKind) error {
+	hpa := &v2beta2.HorizontalPodAutoscaler{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: componentMeta.Namespace,
+		Name: HPAName(componentMeta.Name)}, hpa); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	object.SetName(componentMeta.Name)
+	object.SetNamespace(componentMeta.Namespace)
+	if err := c.Delete(context.TODO(), object); err != nil {
+		if apierr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	log.Info("Deleted "+gvk.Kind, "namespace", componentMeta.Namespace, "name", componentMeta.Name)
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const triggers = `[{"type": "kafka", "metadata": {"bootstrapServers": "kafka:9092", "consumerGroup": "sklearn",
+	"topic": "requests", "lagThreshold": "100"}}]`
+
+func TestKedaReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v2beta2.AddToScheme(s)).To(gomega.Succeed())
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	componentMeta := metav1.ObjectMeta{
+		Name:        "sklearn-predictor-default",
+		Namespace:   "default",
+		Annotations: map[string]string{constants.KedaTriggersAnnotationKey: triggers},
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(0), MaxReplicas: 5}
+	getScaledObject := func() *unstructured.Unstructured {
+		scaledObject := &unstructured.Unstructured{}
+		scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+		g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-default"},
+			scaledObject)).To(gomega.Succeed())
+		return scaledObject
+	}
+
+	// The ScaledObject scales the deployment of the component to zero on the triggers
+	reconciler, err := NewKedaReconciler(cli, s, componentMeta, componentExt)
+	g.Expect(err).To(gomega.BeNil())
+	_, err = reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	scaledObject := getScaledObject()
+	g.Expect(scaledObject.Object["spec"]).To(gomega.Equal(map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       "sklearn-predictor-default",
+		},
+		"minReplicaCount": int64(0),
+		"maxReplicaCount": int64(5),
+		"triggers": []interface{}{map[string]interface{}{
+			"type": "kafka",
+			"metadata": map[string]interface{}{"bootstrapServers": "kafka:9092", "consumerGroup": "sklearn",
+				"topic": "requests", "lagThreshold": "100"},
+		}},
+	}))
+
+	// The unchanged ScaledObject is not updated and its conditions are returned
+	g.Expect(unstructured.SetNestedSlice(scaledObject.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False", "reason": "ScaledObjectCheckFailed",
+			"message": "no kafka broker reachable"},
+	}, "status", "conditions")).To(gomega.Succeed())
+	g.Expect(cli.Update(context.TODO(), scaledObject)).To(gomega.Succeed())
+	resourceVersion := getScaledObject().GetResourceVersion()
+	reconciler, _ = NewKedaReconciler(cli, s, componentMeta, componentExt)
+	reconciled, err := reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(getScaledObject().GetResourceVersion()).To(gomega.Equal(resourceVersion))
+	g.Expect(Conditions(reconciled)).To(gomega.Equal([]metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse,
+		Reason: "ScaledObjectCheckFailed", Message: "no kafka broker reachable"}}))
+
+	// A changed max replicas updates the ScaledObject
+	componentExt.MaxReplicas = 10
+	reconciler, _ = NewKedaReconciler(cli, s, componentMeta, componentExt)
+	_, err = reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	maxReplicas, _, _ := unstructured.NestedInt64(getScaledObject().Object, "spec", "maxReplicaCount")
+	g.Expect(maxReplicas).To(gomega.Equal(int64(10)))
+
+	// The labels and annotations edited out of band are reverted, the ones added by other controllers are kept
+	scaledObject = getScaledObject()
+	scaledObject.SetLabels(map[string]string{"team": "ml"})
+	scaledObject.SetAnnotations(map[string]string{constants.KedaTriggersAnnotationKey: "[]",
+		"autoscaling.keda.sh/paused-replicas": "0"})
+	g.Expect(cli.Update(context.TODO(), scaledObject)).To(gomega.Succeed())
+	componentMeta.Labels = map[string]string{constants.InferenceServicePodLabelKey: "sklearn"}
+	reconciler, _ = NewKedaReconciler(cli, s, componentMeta, componentExt)
+	_, err = reconciler.Reconcile()
+	g.Expect(err).To(gomega.BeNil())
+	scaledObject = getScaledObject()
+	g.Expect(scaledObject.GetLabels()).To(gomega.Equal(map[string]string{"team": "ml",
+		constants.InferenceServicePodLabelKey: "sklearn"}))
+	g.Expect(scaledObject.GetAnnotations()).To(gomega.Equal(map[string]string{constants.KedaTriggersAnnotationKey: triggers,
+		"autoscaling.keda.sh/paused-replicas": "0"}))
+
+	// The ScaledObject is deleted with the HPA created by KEDA when the component switched to the hpa class
+	g.Expect(cli.Create(context.TODO(), &v2beta2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
+		Name: HPAName("sklearn-predictor-default"), Namespace: "default"}})).To(gomega.Succeed())
+	g.Expect(Delete(cli, componentMeta, ScaledObjectGVK)).To(gomega.Succeed())
+	scaledObjects := &unstructured.UnstructuredList{}
+	scaledObjects.SetGroupVersionKind(ScaledObjectGVK.GroupVersion().WithKind("ScaledObjectList"))
+	g.Expect(cli.List(context.TODO(), scaledObjects)).To(gomega.Succeed())
+	g.Expect(scaledObjects.Items).To(gomega.BeEmpty())
+}
+
+func TestCreateScaledObjectInvalidTriggers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	componentMeta := metav1.ObjectMeta{
+		Name:        "sklearn-predictor-default",
+		Namespace:   "default",
+		Annotations: map[string]string{constants.KedaTriggersAnnotationKey: "type: kafka"},
+	}
+	_, err := createScaledObject(componentMeta, &v1beta1.ComponentExtensionSpec{})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestInstalled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	discovery := fakeclientset.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	installed, err := Installed(discovery, ScaledObjectGVK)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(installed).To(gomega.BeFalse())
+
+	// The HTTP add-on serves its own API group
+	discovery.Resources = []*metav1.APIResourceList{{
+		GroupVersion: ScaledObjectGVK.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "scaledobjects", Kind: "ScaledObject"}},
+	}}
+	installed, err = Installed(discovery, ScaledObjectGVK)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(installed).To(gomega.BeTrue())
+	installed, err = Installed(discovery, HTTPScaledObjectGVK)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(installed).To(gomega.BeFalse())
+}
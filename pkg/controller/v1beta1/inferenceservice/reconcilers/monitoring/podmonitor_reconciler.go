@@ -0,0 +1,278 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("PodMonitorReconciler")
+
+var PodMonitorGVK = schema.GroupVersionKind{Group: constants.PrometheusOperatorAPIGroupName, Version: "v1",
+	Kind: "PodMonitor"}
+
+// The meta labels of the prometheus.kserve.io annotations of the pods, set from the serving runtimes
+const (
+	kserveContainerPortMetaLabel = "__meta_kubernetes_pod_annotation_prometheus_kserve_io_port"
+	kserveContainerPathMetaLabel = "__meta_kubernetes_pod_annotation_prometheus_kserve_io_path"
+	kserveContainerPortLabel     = "__tmp_kserve_port"
+)
+
+// PodMonitorReconciler reconciles the PodMonitors of the Prometheus operator scraping the metrics of the agent and of
+// the model server of the InferenceServices. The component services only expose the serving ports, so the pods are
+// scraped directly instead of with ServiceMonitors.
+type PodMonitorReconciler struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	discovery discovery.DiscoveryInterface
+	config    *v1beta1.PodMonitorConfig
+}
+
+func NewPodMonitorReconciler(client client.Client, scheme *runtime.Scheme, discovery discovery.DiscoveryInterface,
+	config *v1beta1.PodMonitorConfig) *PodMonitorReconciler {
+	return &PodMonitorReconciler{
+		client:    client,
+		scheme:    scheme,
+		discovery: discovery,
+		config:    config,
+	}
+}
+
+// Reconcile creates or updates the PodMonitor of the InferenceService or of its namespace depending on the scope, it
+// does nothing when the PodMonitors are disabled or the Prometheus operator CRDs are not installed
+func (r *PodMonitorReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
+	if !r.config.Enabled {
+		return nil
+	}
+	installed, err := r.podMonitorInstalled()
+	if err != nil || !installed {
+		return err
+	}
+	if r.config.Scope == constants.PodMonitorScopeNamespace {
+		// The PodMonitor of the InferenceService scope is replaced by the one of the namespace
+		if err := r.deleteInferenceServicePodMonitor(isvc); err != nil {
+			return err
+		}
+		return r.reconcileNamespacePodMonitor(isvc)
+	}
+	if err := r.removeNamespacePodMonitorOwner(isvc); err != nil {
+		return err
+	}
+	desired := r.createPodMonitor(isvc.Namespace, isvc.Name, &metav1.LabelSelector{
+		MatchLabels: map[string]string{constants.InferenceServicePodLabelKey: isvc.Name},
+	})
+	if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+		return err
+	}
+	return r.reconcileObject(desired)
+}
+
+func (r *PodMonitorReconciler) podMonitorInstalled() (bool, error) {
+	resources, err := r.discovery.ServerResourcesForGroupVersion(PodMonitorGVK.GroupVersion().String())
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == PodMonitorGVK.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *PodMonitorReconciler) createPodMonitor(namespace string, name string,
+	selector *metav1.LabelSelector) *unstructured.Unstructured {
+	agentEndpoint := map[string]interface{}{
+		"port": constants.AgentMetricsPortName,
+		"path": constants.DefaultPrometheusPath,
+	}
+	// The model server port is not named consistently across the serving runtimes, the target of the kserve-container
+	// is rewritten to the port and path of the prometheus.kserve.io annotations, like the metrics aggregation does
+	kserveContainerEndpoint := map[string]interface{}{
+		"path": constants.DefaultPrometheusPath,
+		"relabelings": []interface{}{
+			map[string]interface{}{
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_container_name"},
+				"regex":        constants.InferenceServiceContainerName,
+				"action":       "keep",
+			},
+			map[string]interface{}{
+				"targetLabel": kserveContainerPortLabel,
+				"replacement": constants.InferenceServiceDefaultHttpPort,
+				"action":      "replace",
+			},
+			map[string]interface{}{
+				"sourceLabels": []interface{}{kserveContainerPortMetaLabel},
+				"regex":        "(\\d+)",
+				"targetLabel":  kserveContainerPortLabel,
+				"replacement":  "$1",
+				"action":       "replace",
+			},
+			map[string]interface{}{
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_ip", kserveContainerPortLabel},
+				"separator":    ";",
+				"regex":        "(.+);(.+)",
+				"targetLabel":  "__address__",
+				"replacement":  "$1:$2",
+				"action":       "replace",
+			},
+			map[string]interface{}{
+				"sourceLabels": []interface{}{kserveContainerPathMetaLabel},
+				"regex":        "(.+)",
+				"targetLabel":  "__metrics_path__",
+				"replacement":  "$1",
+				"action":       "replace",
+			},
+		},
+	}
+	if r.config.Interval != "" {
+		agentEndpoint["interval"] = r.config.Interval
+		kserveContainerEndpoint["interval"] = r.config.Interval
+	}
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(PodMonitorGVK)
+	podMonitor.SetName(name)
+	podMonitor.SetNamespace(namespace)
+	podMonitor.SetLabels(r.config.Labels)
+	selectorFields, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(selector)
+	podMonitor.Object["spec"] = map[string]interface{}{
+		"selector":            selectorFields,
+		"podMetricsEndpoints": []interface{}{agentEndpoint, kserveContainerEndpoint},
+	}
+	return podMonitor
+}
+
+// reconcileNamespacePodMonitor adds the InferenceService to the owners of the PodMonitor of its namespace, the
+// PodMonitor is garbage collected with the last InferenceService of the namespace
+func (r *PodMonitorReconciler) reconcileNamespacePodMonitor(isvc *v1beta1.InferenceService) error {
+	desired := r.createPodMonitor(isvc.Namespace, constants.NamespacePodMonitorName, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      constants.InferenceServicePodLabelKey,
+			Operator: metav1.LabelSelectorOpExists,
+		}},
+	})
+	existing, err := r.getPodMonitor(isvc.Namespace, constants.NamespacePodMonitorName)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		desired.SetOwnerReferences(existing.GetOwnerReferences())
+	}
+	if err := controllerutil.SetOwnerReference(isvc, desired, r.scheme); err != nil {
+		return err
+	}
+	return r.reconcileObject(desired)
+}
+
+func (r *PodMonitorReconciler) removeNamespacePodMonitorOwner(isvc *v1beta1.InferenceService) error {
+	existing, err := r.getPodMonitor(isvc.Namespace, constants.NamespacePodMonitorName)
+	if err != nil || existing == nil {
+		return err
+	}
+	var owners []metav1.OwnerReference
+	for _, owner := range existing.GetOwnerReferences() {
+		if owner.UID != isvc.UID {
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) == len(existing.GetOwnerReferences()) {
+		return nil
+	}
+	if len(owners) == 0 {
+		log.Info("Deleting PodMonitor", "namespace", existing.GetNamespace(), "name", existing.GetName())
+		return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+	}
+	existing.SetOwnerReferences(owners)
+	return r.client.Update(context.TODO(), existing)
+}
+
+func (r *PodMonitorReconciler) deleteInferenceServicePodMonitor(isvc *v1beta1.InferenceService) error {
+	existing, err := r.getPodMonitor(isvc.Namespace, isvc.Name)
+	if err != nil || existing == nil || !metav1.IsControlledBy(existing, isvc) {
+		return err
+	}
+	log.Info("Deleting PodMonitor", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+}
+
+func (r *PodMonitorReconciler) getPodMonitor(namespace string, name string) (*unstructured.Unstructured, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(PodMonitorGVK)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *PodMonitorReconciler) reconcileObject(desired *unstructured.Unstructured) error {
+	existing, err := r.getPodMonitor(desired.GetNamespace(), desired.GetName())
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		log.Info("Creating PodMonitor", "namespace", desired.GetNamespace(), "name", desired.GetName())
+		return r.client.Create(context.TODO(), desired)
+	}
+	desiredSpec, err := normalize(desired.Object["spec"])
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(desiredSpec, existing.Object["spec"]) &&
+		equality.Semantic.DeepEqual(desired.GetLabels(), existing.GetLabels()) &&
+		equality.Semantic.DeepEqual(desired.GetOwnerReferences(), existing.GetOwnerReferences()) {
+		return nil
+	}
+	log.Info("Updating PodMonitor", "namespace", desired.GetNamespace(), "name", desired.GetName())
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	return r.client.Update(context.TODO(), desired)
+}
+
+func normalize(spec interface{}) (interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	// The integers are decoded as int64 like in the unstructured objects read from the API server
+	var normalized interface{}
+	if err := utiljson.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPodMonitorReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	cli := fake.NewClientBuilder().WithScheme(s).Build()
+	clientset := fakeclientset.NewSimpleClientset()
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	sklearn := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default",
+		UID: "sklearn-uid"}}
+	xgboost := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "xgboost", Namespace: "default",
+		UID: "xgboost-uid"}}
+	getPodMonitor := func(name string) (*unstructured.Unstructured, error) {
+		podMonitor := &unstructured.Unstructured{}
+		podMonitor.SetGroupVersionKind(PodMonitorGVK)
+		err := cli.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, podMonitor)
+		return podMonitor, err
+	}
+	config := &v1beta1.PodMonitorConfig{Enabled: true, Scope: constants.PodMonitorScopeInferenceService,
+		Labels: map[string]string{"release": "prometheus"}, Interval: "30s"}
+
+	// No PodMonitor is created without the Prometheus operator CRDs
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, config).Reconcile(sklearn)).To(gomega.Succeed())
+	_, err := getPodMonitor("sklearn")
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+
+	discovery.Resources = []*metav1.APIResourceList{{
+		GroupVersion: PodMonitorGVK.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "podmonitors", Kind: "PodMonitor"}},
+	}}
+
+	// No PodMonitor is created when they are disabled
+	disabled := &v1beta1.PodMonitorConfig{}
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, disabled).Reconcile(sklearn)).To(gomega.Succeed())
+	_, err = getPodMonitor("sklearn")
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+
+	// The PodMonitor of the InferenceService scrapes the agent and the model server of its pods
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, config).Reconcile(sklearn)).To(gomega.Succeed())
+	podMonitor, err := getPodMonitor("sklearn")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(podMonitor.GetLabels()).To(gomega.Equal(map[string]string{"release": "prometheus"}))
+	g.Expect(metav1.IsControlledBy(podMonitor, sklearn)).To(gomega.BeTrue())
+	selector, _, _ := unstructured.NestedMap(podMonitor.Object, "spec", "selector")
+	g.Expect(selector).To(gomega.Equal(map[string]interface{}{
+		"matchLabels": map[string]interface{}{constants.InferenceServicePodLabelKey: "sklearn"},
+	}))
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	g.Expect(endpoints).To(gomega.HaveLen(2))
+	g.Expect(endpoints[0]).To(gomega.Equal(map[string]interface{}{
+		"port":     "agent-metrics",
+		"path":     "/metrics",
+		"interval": "30s",
+	}))
+	relabelings, _, _ := unstructured.NestedSlice(endpoints[1].(map[string]interface{}), "relabelings")
+	g.Expect(relabelings[0]).To(gomega.Equal(map[string]interface{}{
+		"sourceLabels": []interface{}{"__meta_kubernetes_pod_container_name"},
+		"regex":        "kserve-container",
+		"action":       "keep",
+	}))
+	g.Expect(relabelings[3]).To(gomega.Equal(map[string]interface{}{
+		"sourceLabels": []interface{}{"__meta_kubernetes_pod_ip", "__tmp_kserve_port"},
+		"separator":    ";",
+		"regex":        "(.+);(.+)",
+		"targetLabel":  "__address__",
+		"replacement":  "$1:$2",
+		"action":       "replace",
+	}))
+
+	// The unchanged PodMonitor is not updated
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, config).Reconcile(sklearn)).To(gomega.Succeed())
+	unchanged, err := getPodMonitor("sklearn")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(unchanged.GetResourceVersion()).To(gomega.Equal(podMonitor.GetResourceVersion()))
+
+	// The PodMonitor of the namespace selects the pods of all the InferenceServices and is owned by all of them
+	namespaceConfig := &v1beta1.PodMonitorConfig{Enabled: true, Scope: constants.PodMonitorScopeNamespace}
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, namespaceConfig).Reconcile(sklearn)).To(gomega.Succeed())
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, namespaceConfig).Reconcile(xgboost)).To(gomega.Succeed())
+	_, err = getPodMonitor("sklearn")
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+	podMonitor, err = getPodMonitor(constants.NamespacePodMonitorName)
+	g.Expect(err).To(gomega.BeNil())
+	selector, _, _ = unstructured.NestedMap(podMonitor.Object, "spec", "selector")
+	g.Expect(selector).To(gomega.Equal(map[string]interface{}{
+		"matchExpressions": []interface{}{map[string]interface{}{
+			"key":      constants.InferenceServicePodLabelKey,
+			"operator": "Exists",
+		}},
+	}))
+	var owners []string
+	for _, owner := range podMonitor.GetOwnerReferences() {
+		g.Expect(owner.Controller).To(gomega.BeNil())
+		owners = append(owners, owner.Name)
+	}
+	g.Expect(owners).To(gomega.Equal([]string{"sklearn", "xgboost"}))
+
+	// The PodMonitor of the namespace is deleted when its last InferenceService moves back to its own PodMonitor
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, config).Reconcile(sklearn)).To(gomega.Succeed())
+	podMonitor, err = getPodMonitor(constants.NamespacePodMonitorName)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(podMonitor.GetOwnerReferences()).To(gomega.HaveLen(1))
+	g.Expect(NewPodMonitorReconciler(cli, s, discovery, config).Reconcile(xgboost)).To(gomega.Succeed())
+	_, err = getPodMonitor(constants.NamespacePodMonitorName)
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+}
@@ -40,6 +40,8 @@ type RawKubeReconciler struct {
 	Service    *service.ServiceReconciler
 	Scaler     *autoscaler.AutoscalerReconciler
 	URL        *knapis.URL
+	// pinned is set when the deployment replicas are set by the component instead of its autoscaler
+	pinned bool
 }
 
 // RawKubeReconciler creates raw kubernetes resource reconciler.
@@ -53,7 +55,7 @@ func NewRawKubeReconciler(client client.Client,
 		return nil, err
 	}
 
-	url, err := createRawURL(client, componentMeta)
+	url, err := CreateRawURL(client, componentMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +70,8 @@ func NewRawKubeReconciler(client client.Client,
 	}, nil
 }
 
-func createRawURL(client client.Client, metadata metav1.ObjectMeta) (*knapis.URL, error) {
+// CreateRawURL returns the url of the ingress host of the component
+func CreateRawURL(client client.Client, metadata metav1.ObjectMeta) (*knapis.URL, error) {
 	ingressConfig, err := v1beta1.NewIngressConfig(client)
 	if err != nil {
 		return nil, err
@@ -84,6 +87,31 @@ func createRawURL(client client.Client, metadata metav1.ObjectMeta) (*knapis.URL
 	return url, nil
 }
 
+// RouteHosts adds the ingress hosts routed to the service of the component to the hosts the KEDA HTTP interceptor
+// routes to it, the interceptor routes the requests by their host
+func (r *RawKubeReconciler) RouteHosts(isvc *v1beta1.InferenceService) error {
+	if r.Scaler.Autoscaler.KEDAHTTP == nil {
+		return nil
+	}
+	ingressConfig, err := v1beta1.NewIngressConfig(r.client)
+	if err != nil {
+		return err
+	}
+	hosts, err := ingress.RawServiceHosts(isvc, ingressConfig)
+	if err != nil {
+		return err
+	}
+	r.Scaler.Autoscaler.KEDAHTTP.AddHosts(hosts[r.Service.Service.Name]...)
+	return nil
+}
+
+// PinReplicas sets the replicas of the deployment instead of autoscaling it, e.g. the transformer replicas linked to the
+// predictor replicas, the autoscaler objects of the component are deleted
+func (r *RawKubeReconciler) PinReplicas(replicas int32) {
+	r.Deployment.Deployment.Spec.Replicas = &replicas
+	r.pinned = true
+}
+
 // Reconcile ...
 func (r *RawKubeReconciler) Reconcile() (*appsv1.Deployment, error) {
 	//reconcile Deployment
@@ -97,6 +125,12 @@ func (r *RawKubeReconciler) Reconcile() (*appsv1.Deployment, error) {
 		return nil, err
 	}
 	//reconcile HPA
+	if r.pinned {
+		if err := r.Scaler.Delete(); err != nil {
+			return nil, err
+		}
+		return deployment, nil
+	}
 	_, err = r.Scaler.Reconcile()
 	if err != nil {
 		return nil, err
@@ -22,6 +22,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -37,10 +38,12 @@ var log = logf.Log.WithName("ServiceReconciler")
 
 // ServiceReconciler is the struct of Raw K8S Object
 type ServiceReconciler struct {
-	client       client.Client
-	scheme       *runtime.Scheme
-	Service      *corev1.Service
-	componentExt *v1beta1.ComponentExtensionSpec
+	client  client.Client
+	scheme  *runtime.Scheme
+	Service *corev1.Service
+	// OriginService selects the pods of the component when its service points to the KEDA HTTP interceptor
+	OriginService *corev1.Service
+	componentExt  *v1beta1.ComponentExtensionSpec
 }
 
 func NewServiceReconciler(client client.Client,
@@ -48,12 +51,63 @@ func NewServiceReconciler(client client.Client,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec) *ServiceReconciler {
-	return &ServiceReconciler{
+	service := createService(componentMeta, componentExt, podSpec)
+	reconciler := &ServiceReconciler{
 		client:       client,
 		scheme:       scheme,
-		Service:      createService(componentMeta, componentExt, podSpec),
+		Service:      service,
 		componentExt: componentExt,
 	}
+	if componentMeta.Annotations[constants.AutoscalerClass] == string(constants.AutoscalerClassKEDAHTTP) {
+		// The requests are held by the interceptor while the component is scaled to zero, the interceptor forwards
+		// them to the origin service
+		origin := service.DeepCopy()
+		origin.Name = constants.RawOriginServiceName(componentMeta.Name)
+		reconciler.OriginService = origin
+		reconciler.Service = &corev1.Service{
+			ObjectMeta: componentMeta,
+			Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: constants.KedaHTTPInterceptorHost,
+				Ports:        service.Spec.Ports,
+			},
+		}
+	}
+	return reconciler
+}
+
+// NewSidecarServiceReconciler reconciles the service of a component running as a sidecar of the pods of another
+// component, e.g. the colocated explainer of the predictor pods, the service routes to the port of the sidecar
+func NewSidecarServiceReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	podComponentName string,
+	port int32) *ServiceReconciler {
+	service := &corev1.Service{
+		ObjectMeta: componentMeta,
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": constants.GetRawServiceLabel(podComponentName),
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name: componentMeta.Name,
+					Port: constants.CommonDefaultHttpPort,
+					TargetPort: intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: port,
+					},
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	return &ServiceReconciler{
+		client:       client,
+		scheme:       scheme,
+		Service:      service,
+		componentExt: &v1beta1.ComponentExtensionSpec{},
+	}
 }
 
 func createService(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec,
@@ -64,10 +118,8 @@ func createService(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compon
 	} else {
 		port, _ = strconv.Atoi(constants.InferenceServiceDefaultHttpPort)
 	}
-	if componentExt.Batcher != nil {
-		port = int(constants.InferenceServiceDefaultAgentPort)
-	}
-	if componentExt.Logger != nil {
+	// The features served by the agent are bypassed when the service routes to the component container
+	if utils.IsAgentInjected(componentMeta.Annotations) {
 		port = int(constants.InferenceServiceDefaultAgentPort)
 	}
 
@@ -117,7 +169,8 @@ func (r *ServiceReconciler) checkServiceExist(client client.Client) (constants.C
 
 func semanticServiceEquals(desired, existing *corev1.Service) bool {
 	return equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) &&
-		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector)
+		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) &&
+		desired.Spec.ExternalName == existing.Spec.ExternalName
 }
 
 // Reconcile ...
@@ -128,6 +181,9 @@ func (r *ServiceReconciler) Reconcile() (*corev1.Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := r.reconcileOriginService(); err != nil {
+		return nil, err
+	}
 
 	if checkResult == constants.CheckResultCreate {
 		err = r.client.Create(context.TODO(), r.Service)
@@ -147,3 +203,28 @@ func (r *ServiceReconciler) Reconcile() (*corev1.Service, error) {
 		return existingService, nil
 	}
 }
+
+// reconcileOriginService creates the origin service before the service of the component points to the interceptor,
+// and deletes it after the component switched from the keda-http autoscaler class
+func (r *ServiceReconciler) reconcileOriginService() error {
+	name := types.NamespacedName{Namespace: r.Service.Namespace, Name: constants.RawOriginServiceName(r.Service.Name)}
+	existing := &corev1.Service{}
+	if err := r.client.Get(context.TODO(), name, existing); err != nil {
+		if !apierr.IsNotFound(err) {
+			return err
+		}
+		if r.OriginService != nil {
+			return r.client.Create(context.TODO(), r.OriginService)
+		}
+		return nil
+	}
+	if r.OriginService == nil {
+		return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+	}
+	if semanticServiceEquals(r.OriginService, existing) {
+		return nil
+	}
+	existing.Spec.Ports = r.OriginService.Spec.Ports
+	existing.Spec.Selector = r.OriginService.Spec.Selector
+	return r.client.Update(context.TODO(), existing)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateServiceTargetPort(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  constants.InferenceServiceContainerName,
+			Ports: []corev1.ContainerPort{{ContainerPort: 8085}},
+		}},
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    int32
+	}{
+		"NoAgent": {
+			annotations: map[string]string{},
+			expected:    8085,
+		},
+		"Logger": {
+			annotations: map[string]string{constants.LoggerInternalAnnotationKey: "true"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"APIKeys": {
+			annotations: map[string]string{constants.APIKeySecretAnnotationKey: "api-keys"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"AllowedHosts": {
+			annotations: map[string]string{constants.EnforceAllowedHostsAnnotationKey: "true"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"AllowedHostsDisabled": {
+			annotations: map[string]string{constants.EnforceAllowedHostsAnnotationKey: "false"},
+			expected:    8085,
+		},
+		"TokenAudience": {
+			annotations: map[string]string{constants.TokenAudienceAnnotationKey: "inference"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"Deprecation": {
+			annotations: map[string]string{constants.DeprecationAnnotationKey: "2026-01-01T00:00:00Z"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"AgentConfig": {
+			annotations: map[string]string{constants.AgentConfigInternalAnnotationKey: "sklearn-agent-config"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"CircuitBreaker": {
+			annotations: map[string]string{constants.CircuitBreakerFailureThresholdAnnotationKey: "5"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+		"RateLimit": {
+			annotations: map[string]string{constants.RateLimitRPSInternalAnnotationKey: "10"},
+			expected:    constants.InferenceServiceDefaultAgentPort,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			componentMeta := metav1.ObjectMeta{
+				Name:        "sklearn-predictor-default",
+				Namespace:   "default",
+				Annotations: scenario.annotations,
+			}
+			service := createService(componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec.DeepCopy())
+			g.Expect(service.Spec.Ports).To(gomega.HaveLen(1))
+			g.Expect(service.Spec.Ports[0].TargetPort.IntVal).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
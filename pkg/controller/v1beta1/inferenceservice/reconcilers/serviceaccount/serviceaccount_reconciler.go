@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("ServiceAccountReconciler")
+
+// ServiceAccountReconciler creates the service account of an InferenceService component, the service account only
+// references the credential secrets of the component and is bound to a role which can only read them. The cloud
+// identity annotations of the component, e.g. the IRSA role or the GKE workload identity, are set on the service
+// account.
+type ServiceAccountReconciler struct {
+	client         client.Client
+	scheme         *runtime.Scheme
+	ServiceAccount *corev1.ServiceAccount
+	Role           *rbacv1.Role
+	RoleBinding    *rbacv1.RoleBinding
+}
+
+func NewServiceAccountReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	secrets []string) *ServiceAccountReconciler {
+	meta := metav1.ObjectMeta{
+		Name:      componentMeta.Name,
+		Namespace: componentMeta.Namespace,
+		Labels:    componentMeta.Labels,
+	}
+	r := &ServiceAccountReconciler{
+		client:         client,
+		scheme:         scheme,
+		ServiceAccount: createServiceAccount(meta, secrets, identityAnnotations(componentMeta.Annotations)),
+	}
+	if len(secrets) != 0 {
+		r.Role = createRole(meta, secrets)
+		r.RoleBinding = createRoleBinding(meta)
+	}
+	return r
+}
+
+func identityAnnotations(annotations map[string]string) map[string]string {
+	identity := utils.Filter(annotations, func(key string) bool {
+		return utils.Includes(credentials.CloudIdentityAnnotationKeys, key)
+	})
+	if len(identity) == 0 {
+		return nil
+	}
+	return identity
+}
+
+func createServiceAccount(meta metav1.ObjectMeta, secrets []string, annotations map[string]string) *corev1.ServiceAccount {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: meta}
+	serviceAccount.Annotations = annotations
+	for _, secret := range secrets {
+		serviceAccount.Secrets = append(serviceAccount.Secrets, corev1.ObjectReference{Name: secret})
+	}
+	return serviceAccount
+}
+
+func createRole(meta metav1.ObjectMeta, secrets []string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			ResourceNames: secrets,
+			Verbs:         []string{"get"},
+		}},
+	}
+}
+
+func createRoleBinding(meta metav1.ObjectMeta) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     meta.Name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+		}},
+	}
+}
+
+// Reconcile creates or updates the service account, role and role binding, the role and role binding are deleted
+// when the component has no credential secrets.
+func (r *ServiceAccountReconciler) Reconcile() error {
+	if err := r.reconcileServiceAccount(); err != nil {
+		return err
+	}
+	if r.Role == nil {
+		name := types.NamespacedName{Name: r.ServiceAccount.Name, Namespace: r.ServiceAccount.Namespace}
+		if err := r.deleteIfExists(name, &rbacv1.RoleBinding{}); err != nil {
+			return err
+		}
+		return r.deleteIfExists(name, &rbacv1.Role{})
+	}
+	if err := r.reconcileRole(); err != nil {
+		return err
+	}
+	return r.reconcileRoleBinding()
+}
+
+func (r *ServiceAccountReconciler) reconcileServiceAccount() error {
+	existing := &corev1.ServiceAccount{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: r.ServiceAccount.Name, Namespace: r.ServiceAccount.Namespace}, existing)
+	if apierr.IsNotFound(err) {
+		log.Info("Creating service account", "name", r.ServiceAccount.Name, "namespace", r.ServiceAccount.Namespace)
+		return r.client.Create(context.TODO(), r.ServiceAccount)
+	} else if err != nil {
+		return err
+	}
+	desired := existing.DeepCopy()
+	desired.Labels = r.ServiceAccount.Labels
+	// The identity annotations removed from the InferenceService are removed, the other annotations are kept
+	desired.Annotations = utils.Union(utils.Filter(existing.Annotations, func(key string) bool {
+		return !utils.Includes(credentials.CloudIdentityAnnotationKeys, key)
+	}), r.ServiceAccount.Annotations)
+	// The token secrets added by the token controller are kept
+	desired.Secrets = r.ServiceAccount.Secrets
+	for _, secret := range existing.Secrets {
+		if strings.HasPrefix(secret.Name, existing.Name+"-token-") {
+			desired.Secrets = append(desired.Secrets, secret)
+		}
+	}
+	if equality.Semantic.DeepEqual(existing.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, desired.Annotations) &&
+		equality.Semantic.DeepEqual(existing.Secrets, desired.Secrets) {
+		return nil
+	}
+	log.Info("Updating service account", "name", desired.Name, "namespace", desired.Namespace)
+	return r.client.Update(context.TODO(), desired)
+}
+
+func (r *ServiceAccountReconciler) reconcileRole() error {
+	existing := &rbacv1.Role{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: r.Role.Name, Namespace: r.Role.Namespace}, existing)
+	if apierr.IsNotFound(err) {
+		log.Info("Creating role", "name", r.Role.Name, "namespace", r.Role.Namespace)
+		return r.client.Create(context.TODO(), r.Role)
+	} else if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Labels, r.Role.Labels) && equality.Semantic.DeepEqual(existing.Rules, r.Role.Rules) {
+		return nil
+	}
+	desired := existing.DeepCopy()
+	desired.Labels = r.Role.Labels
+	desired.Rules = r.Role.Rules
+	log.Info("Updating role", "name", desired.Name, "namespace", desired.Namespace)
+	return r.client.Update(context.TODO(), desired)
+}
+
+func (r *ServiceAccountReconciler) reconcileRoleBinding() error {
+	existing := &rbacv1.RoleBinding{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: r.RoleBinding.Name, Namespace: r.RoleBinding.Namespace}, existing)
+	if apierr.IsNotFound(err) {
+		log.Info("Creating role binding", "name", r.RoleBinding.Name, "namespace", r.RoleBinding.Namespace)
+		return r.client.Create(context.TODO(), r.RoleBinding)
+	} else if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Labels, r.RoleBinding.Labels) &&
+		equality.Semantic.DeepEqual(existing.Subjects, r.RoleBinding.Subjects) {
+		return nil
+	}
+	// The role ref is immutable and always points to the role of the same name
+	desired := existing.DeepCopy()
+	desired.Labels = r.RoleBinding.Labels
+	desired.Subjects = r.RoleBinding.Subjects
+	log.Info("Updating role binding", "name", desired.Name, "namespace", desired.Namespace)
+	return r.client.Update(context.TODO(), desired)
+}
+
+func (r *ServiceAccountReconciler) deleteIfExists(name types.NamespacedName, obj client.Object) error {
+	if err := r.client.Get(context.TODO(), name, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	log.Info("Deleting credential access of service account", "name", name.Name, "namespace", name.Namespace)
+	return client.IgnoreNotFound(r.client.Delete(context.TODO(), obj))
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/utils"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var componentName = types.NamespacedName{Name: "sklearn-predictor-default", Namespace: "default"}
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	corev1.AddToScheme(s)
+	rbacv1.AddToScheme(s)
+	return s
+}
+
+func TestServiceAccountReconcile(t *testing.T) {
+	roleArn := "arn:aws:iam::123456789012:role/s3access"
+	scenarios := map[string]struct {
+		existing            []client.Object
+		annotations         map[string]string
+		secrets             []string
+		expectedSecrets     []corev1.ObjectReference
+		expectedAnnotations map[string]string
+		expectedRole        bool
+	}{
+		"CreatesServiceAccountAndRole": {
+			secrets:         []string{"s3-credentials"},
+			expectedSecrets: []corev1.ObjectReference{{Name: "s3-credentials"}},
+			expectedRole:    true,
+		},
+		"WithoutSecrets": {},
+		"UpdatesSecretsKeepingTokens": {
+			existing: []client.Object{&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: componentName.Name, Namespace: componentName.Namespace},
+				Secrets: []corev1.ObjectReference{
+					{Name: "gcs-credentials"},
+					{Name: componentName.Name + "-token-abcde"},
+				},
+			}},
+			secrets: []string{"s3-credentials"},
+			expectedSecrets: []corev1.ObjectReference{
+				{Name: "s3-credentials"},
+				{Name: componentName.Name + "-token-abcde"},
+			},
+			expectedRole: true,
+		},
+		"IdentityAnnotations": {
+			annotations: map[string]string{
+				credentials.AwsIrsaAnnotationKey:             roleArn,
+				credentials.GcpWorkloadIdentityAnnotationKey: "model@project.iam.gserviceaccount.com",
+			},
+			expectedAnnotations: map[string]string{
+				credentials.AwsIrsaAnnotationKey:             roleArn,
+				credentials.GcpWorkloadIdentityAnnotationKey: "model@project.iam.gserviceaccount.com",
+			},
+		},
+		"UpdatesIdentityAnnotations": {
+			existing: []client.Object{&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      componentName.Name,
+					Namespace: componentName.Namespace,
+					Annotations: map[string]string{
+						credentials.AwsIrsaAnnotationKey:             "arn:aws:iam::123456789012:role/old",
+						credentials.GcpWorkloadIdentityAnnotationKey: "model@project.iam.gserviceaccount.com",
+						"team": "models",
+					},
+				},
+			}},
+			annotations: map[string]string{credentials.AwsIrsaAnnotationKey: roleArn},
+			expectedAnnotations: map[string]string{
+				credentials.AwsIrsaAnnotationKey: roleArn,
+				"team":                           "models",
+			},
+		},
+		"DeletesRoleWithoutSecrets": {
+			existing: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{Name: componentName.Name, Namespace: componentName.Namespace},
+					Secrets:    []corev1.ObjectReference{{Name: "s3-credentials"}},
+				},
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: componentName.Name, Namespace: componentName.Namespace}},
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: componentName.Name, Namespace: componentName.Namespace}},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			componentMeta := metav1.ObjectMeta{
+				Name:      componentName.Name,
+				Namespace: componentName.Namespace,
+				Labels:    map[string]string{"component": "predictor"},
+				Annotations: utils.Union(scenario.annotations, map[string]string{
+					"serving.kserve.io/deploymentMode": "RawDeployment",
+				}),
+			}
+			cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(scenario.existing...).Build()
+			r := NewServiceAccountReconciler(cli, cli.Scheme(), componentMeta, scenario.secrets)
+			g.Expect(r.Reconcile()).To(gomega.Succeed())
+
+			serviceAccount := &corev1.ServiceAccount{}
+			g.Expect(cli.Get(context.TODO(), componentName, serviceAccount)).To(gomega.Succeed())
+			g.Expect(serviceAccount.Secrets).To(gomega.Equal(scenario.expectedSecrets))
+			if scenario.expectedAnnotations == nil {
+				g.Expect(serviceAccount.Annotations).To(gomega.BeEmpty())
+			} else {
+				g.Expect(serviceAccount.Annotations).To(gomega.Equal(scenario.expectedAnnotations))
+			}
+
+			role := &rbacv1.Role{}
+			roleBinding := &rbacv1.RoleBinding{}
+			if !scenario.expectedRole {
+				g.Expect(apierr.IsNotFound(cli.Get(context.TODO(), componentName, role))).To(gomega.BeTrue())
+				g.Expect(apierr.IsNotFound(cli.Get(context.TODO(), componentName, roleBinding))).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(cli.Get(context.TODO(), componentName, role)).To(gomega.Succeed())
+			g.Expect(role.Rules).To(gomega.Equal([]rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: scenario.secrets,
+				Verbs:         []string{"get"},
+			}}))
+			g.Expect(cli.Get(context.TODO(), componentName, roleBinding)).To(gomega.Succeed())
+			g.Expect(roleBinding.RoleRef.Name).To(gomega.Equal(componentName.Name))
+			g.Expect(roleBinding.Subjects).To(gomega.Equal([]rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      componentName.Name,
+				Namespace: componentName.Namespace,
+			}}))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestRecordScalingEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &InferenceServiceReconciler{
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+	isvc := &v1beta1.InferenceService{}
+	isvc.Status.InitializeConditions()
+	blocked := &apis.Condition{Status: v1.ConditionFalse, Reason: "TooManyReplicas",
+		Message: "the desired replica count is more than the maximum replica count"}
+
+	// The blocked autoscaler is recorded once
+	previous := scalingConditions(isvc.Status)
+	isvc.Status.SetCondition(v1beta1.PredictorScalingReady, blocked)
+	reconciler.recordScalingEvents(isvc, previous)
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+		"Warning ScalingBlocked PredictorScalingReady: the desired replica count is more than the maximum replica count")))
+	reconciler.recordScalingEvents(isvc, scalingConditions(isvc.Status))
+	g.Expect(recorder.Events).NotTo(gomega.Receive())
+
+	// The recovery is recorded
+	previous = scalingConditions(isvc.Status)
+	isvc.Status.SetCondition(v1beta1.PredictorScalingReady, &apis.Condition{Status: v1.ConditionTrue})
+	reconciler.recordScalingEvents(isvc, previous)
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal("Normal ScalingRecovered PredictorScalingReady")))
+}
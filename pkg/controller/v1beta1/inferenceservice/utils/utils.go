@@ -20,11 +20,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"math"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
@@ -36,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -76,8 +81,39 @@ func GetDeploymentMode(annotations map[string]string, deployConfig *v1beta1api.D
 	return constants.DeploymentModeType(deployConfig.DefaultDeploymentMode)
 }
 
+// IsExplainerColocated returns whether the explainer runs as a sidecar of the predictor pods instead of its own
+// deployment, the colocation is only supported in the RawDeployment mode
+func IsExplainerColocated(isvc *v1beta1api.InferenceService, deployConfig *v1beta1api.DeployConfig) bool {
+	return isvc.Spec.Explainer != nil && isvc.Annotations[constants.ExplainerColocationAnnotationKey] == "true" &&
+		GetDeploymentMode(isvc.Annotations, deployConfig) == constants.RawDeployment
+}
+
+// IsSuspended returns whether the components of the InferenceService are scaled to zero and its requests rejected, e.g.
+// by the janitor on the expiry of its TTL, until the serving.kserve.io/suspended annotation is removed
+func IsSuspended(annotations map[string]string) bool {
+	return annotations[constants.SuspendedAnnotationKey] == "true"
+}
+
 // MergeRuntimeContainers Merge the predictor Container struct with the runtime Container struct, allowing users
 // to override runtime container settings from the predictor spec.
+// GetLinkedReplicas returns the transformer replicas at the transformer:predictor replica ratio of the predictor
+// replicas, rounded up and bounded by the min and max replicas of the transformer
+func GetLinkedReplicas(predictorReplicas int32, transformerUnits int32, predictorUnits int32,
+	componentExt *v1beta1api.ComponentExtensionSpec) int32 {
+	replicas := (predictorReplicas*transformerUnits + predictorUnits - 1) / predictorUnits
+	minReplicas := int32(constants.DefaultMinReplicas)
+	if componentExt.MinReplicas != nil {
+		minReplicas = int32(*componentExt.MinReplicas)
+	}
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	if componentExt.MaxReplicas > 0 && replicas > int32(componentExt.MaxReplicas) {
+		replicas = int32(componentExt.MaxReplicas)
+	}
+	return replicas
+}
+
 func MergeRuntimeContainers(runtimeContainer *v1.Container, predictorContainer *v1.Container) (*v1.Container, error) {
 	// Save runtime container name, as the name can be overridden as empty string during the Unmarshal below
 	// since the Name field does not have the 'omitempty' struct tag.
@@ -148,8 +184,45 @@ func MergePodSpec(runtimePodSpec *v1alpha1.ServingRuntimePodSpec, predictorPodSp
 	return &corePodSpec, nil
 }
 
+// SetArchitectureAffinity Require the pods to be scheduled on nodes with one of the architectures supported by the
+// runtime. An error is returned when the predictor selects nodes of an unsupported architecture.
+func SetArchitectureAffinity(podSpec *v1.PodSpec, runtime *v1alpha1.ServingRuntimeSpec) error {
+	if len(runtime.SupportedArchitectures) == 0 {
+		return nil
+	}
+	if arch, ok := podSpec.NodeSelector[v1.LabelArchStable]; ok && !runtime.IsArchitectureSupported(arch) {
+		return fmt.Errorf("runtime does not support the %s architecture, supported architectures are %v",
+			arch, runtime.SupportedArchitectures)
+	}
+	requirement := v1.NodeSelectorRequirement{
+		Key:      v1.LabelArchStable,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   append([]string{}, runtime.SupportedArchitectures...),
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &v1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []v1.NodeSelectorTerm{{}}
+	}
+	// Node selector terms are ORed, the requirement has to be added to each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return nil
+}
+
 // GetServingRuntime Get a ServingRuntime by name. First, ServingRuntimes in the given namespace will be checked.
-// If a resource of the specified name is not found, then ClusterServingRuntimes will be checked.
+// If a resource of the specified name is not found, then ClusterServingRuntimes will be checked, a ClusterServingRuntime
+// restricted to other namespaces is not available.
 func GetServingRuntime(cl client.Client, name string, namespace string) (*v1alpha1.ServingRuntimeSpec, error) {
 
 	runtime := &v1alpha1.ServingRuntime{}
@@ -163,6 +236,11 @@ func GetServingRuntime(cl client.Client, name string, namespace string) (*v1alph
 	clusterRuntime := &v1alpha1.ClusterServingRuntime{}
 	err = cl.Get(context.TODO(), client.ObjectKey{Name: name}, clusterRuntime)
 	if err == nil {
+		if allowed, err := v1beta1api.IsClusterServingRuntimeAllowed(cl, &clusterRuntime.Spec, namespace); err != nil {
+			return nil, err
+		} else if !allowed {
+			return nil, fmt.Errorf("ClusterServingRuntime %s is not allowed in the namespace %s", name, namespace)
+		}
 		return &clusterRuntime.Spec, nil
 	} else if !errors.IsNotFound(err) {
 		return nil, err
@@ -224,6 +302,156 @@ func ListPodsByLabel(cl client.Client, namespace string, labelKey string, labelV
 	return podList, nil
 }
 
+// GetTerminatedContainerLogTail returns the last lines of the logs of the terminated container of the pod, the
+// logs of the previous instance are read when the container is waiting to be restarted. The tail is bounded to
+// FailureLogTailBytes so it can be kept in the status.
+func GetTerminatedContainerLogTail(clientset kubernetes.Interface, pod *v1.Pod, containerName string) (string, error) {
+	previous := false
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == containerName {
+			previous = cs.State.Terminated == nil
+		}
+	}
+	tailLines := int64(constants.FailureLogTailLines)
+	logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: containerName,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	if len(logs) > constants.FailureLogTailBytes {
+		logs = logs[len(logs)-constants.FailureLogTailBytes:]
+	}
+	return strings.ToValidUTF8(string(logs), ""), nil
+}
+
+// GetContainerMemory returns the memory limit of the container, or its memory request when it has no limit.
+func GetContainerMemory(container *v1.Container) (resource.Quantity, bool) {
+	if memory, ok := container.Resources.Limits[v1.ResourceMemory]; ok {
+		return memory, true
+	}
+	memory, ok := container.Resources.Requests[v1.ResourceMemory]
+	return memory, ok
+}
+
+// SetContainerMemory raises the memory request and limit of the container which are lower than the memory.
+func SetContainerMemory(container *v1.Container, memory resource.Quantity) {
+	container.Resources = *container.Resources.DeepCopy()
+	for _, resources := range []v1.ResourceList{container.Resources.Requests, container.Resources.Limits} {
+		if current, ok := resources[v1.ResourceMemory]; ok && current.Cmp(memory) < 0 {
+			resources[v1.ResourceMemory] = memory
+		}
+	}
+}
+
+// RaiseMemoryOnOOM returns the memory raised by the factor of the OOM remediation policy of the annotations, bounded
+// by the max memory of the policy. It returns false when there is no policy or the memory already reached the max.
+func RaiseMemoryOnOOM(annotations map[string]string, memory resource.Quantity) (resource.Quantity, bool) {
+	factor, err := strconv.ParseFloat(annotations[constants.OOMMemoryFactorAnnotationKey], 64)
+	if err != nil || factor <= 1 {
+		return resource.Quantity{}, false
+	}
+	maxMemory, err := resource.ParseQuantity(annotations[constants.OOMMaxMemoryAnnotationKey])
+	if err != nil || memory.Cmp(maxMemory) >= 0 {
+		return resource.Quantity{}, false
+	}
+	raised := resource.NewQuantity(int64(math.Ceil(float64(memory.Value())*factor)), memory.Format)
+	if raised.Cmp(maxMemory) > 0 {
+		return maxMemory, true
+	}
+	return *raised, true
+}
+
+// Bounds of the interval the model status is checked at while the storage initializer retries a transient failure
+const (
+	storageRetryMinInterval = 10 * time.Second
+	storageRetryMaxInterval = 5 * time.Minute
+)
+
+// StorageRetryInterval returns the interval the InferenceService is reconciled again at while the storage initializer
+// retries a transient failure, it grows with the time the failure lasts like the restart backoff of the kubelet. Zero
+// is returned when the storage initializer is not retrying.
+func StorageRetryInterval(status *v1beta1api.InferenceServiceStatus, now time.Time) time.Duration {
+	info := status.ModelStatus.LastFailureInfo
+	states := status.ModelStatus.ModelRevisionStates
+	if info == nil || info.Reason != v1beta1api.StorageUnavailable || info.Time == nil || states == nil ||
+		states.TargetModelState != v1beta1api.Loading {
+		return 0
+	}
+	interval := now.Sub(info.Time.Time)
+	if interval < storageRetryMinInterval {
+		return storageRetryMinInterval
+	}
+	if interval > storageRetryMaxInterval {
+		return storageRetryMaxInterval
+	}
+	return interval
+}
+
+// GraphOptimizationEnv maps the graph optimization to the environment variables of the TensorRT execution provider of
+// ONNX Runtime, which is used by the ONNX Runtime server and the onnxruntime backend of Triton.
+func GraphOptimizationEnv(optimization *v1beta1api.GraphOptimization) []v1.EnvVar {
+	envs := []v1.EnvVar{{Name: constants.ORTTensorRTEngineCacheEnvVarKey, Value: "1"}}
+	switch optimization.Precision {
+	case v1beta1api.PrecisionFP16:
+		envs = append(envs, v1.EnvVar{Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "1"})
+	case v1beta1api.PrecisionINT8:
+		// The layers without INT8 implementation fall back to FP16
+		envs = append(envs,
+			v1.EnvVar{Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "1"},
+			v1.EnvVar{Name: constants.ORTTensorRTINT8EnableEnvVarKey, Value: "1"})
+	}
+	if optimization.WorkspaceSize != nil {
+		envs = append(envs, v1.EnvVar{
+			Name:  constants.ORTTensorRTMaxWorkspaceSizeEnvVarKey,
+			Value: strconv.FormatInt(optimization.WorkspaceSize.Value(), 10),
+		})
+	}
+	if len(optimization.DynamicShapes) > 0 {
+		var minShapes, optShapes, maxShapes []string
+		for _, shape := range optimization.DynamicShapes {
+			minShapes = append(minShapes, formatInputShape(shape.Input, shape.Min))
+			optShapes = append(optShapes, formatInputShape(shape.Input, shape.Opt))
+			maxShapes = append(maxShapes, formatInputShape(shape.Input, shape.Max))
+		}
+		envs = append(envs,
+			v1.EnvVar{Name: constants.ORTTensorRTProfileMinShapesEnvVarKey, Value: strings.Join(minShapes, ",")},
+			v1.EnvVar{Name: constants.ORTTensorRTProfileOptShapesEnvVarKey, Value: strings.Join(optShapes, ",")},
+			v1.EnvVar{Name: constants.ORTTensorRTProfileMaxShapesEnvVarKey, Value: strings.Join(maxShapes, ",")})
+	}
+	return envs
+}
+
+// formatInputShape formats the shape of the input as <input>:<dim>x<dim>
+func formatInputShape(input string, dims []int64) string {
+	formatted := make([]string, len(dims))
+	for i, dim := range dims {
+		formatted[i] = strconv.FormatInt(dim, 10)
+	}
+	return input + ":" + strings.Join(formatted, "x")
+}
+
+// SetContainerEnv sets the environment variables of the container, overriding the variables with the same name.
+func SetContainerEnv(container *v1.Container, envs []v1.EnvVar) {
+	// The env of the container may be shared with the InferenceService spec
+	container.Env = append([]v1.EnvVar{}, container.Env...)
+	for _, env := range envs {
+		found := false
+		for i := range container.Env {
+			if container.Env[i].Name == env.Name {
+				container.Env[i] = env
+				found = true
+				break
+			}
+		}
+		if !found {
+			container.Env = append(container.Env, env)
+		}
+	}
+}
+
 func sortPodsByCreatedTimestampDesc(pods *v1.PodList) {
 	sort.Slice(pods.Items, func(i, j int) bool {
 		return pods.Items[j].ObjectMeta.CreationTimestamp.Before(&pods.Items[i].ObjectMeta.CreationTimestamp)
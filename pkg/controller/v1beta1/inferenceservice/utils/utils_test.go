@@ -19,6 +19,7 @@ package utils
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -860,6 +862,92 @@ func TestMergePodSpec(t *testing.T) {
 	}
 }
 
+func TestSetArchitectureAffinity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	archRequirement := v1.NodeSelectorRequirement{
+		Key:      v1.LabelArchStable,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   []string{"amd64", "arm64"},
+	}
+	zoneRequirement := v1.NodeSelectorRequirement{
+		Key:      v1.LabelTopologyZone,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   []string{"us-east-1a"},
+	}
+
+	scenarios := map[string]struct {
+		runtime   *v1alpha1.ServingRuntimeSpec
+		podSpec   *v1.PodSpec
+		expected  *v1.PodSpec
+		expectErr bool
+	}{
+		"AllArchitectures": {
+			runtime:  &v1alpha1.ServingRuntimeSpec{},
+			podSpec:  &v1.PodSpec{},
+			expected: &v1.PodSpec{},
+		},
+		"NoAffinity": {
+			runtime: &v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			podSpec: &v1.PodSpec{},
+			expected: &v1.PodSpec{
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{archRequirement}}},
+					},
+				}},
+			},
+		},
+		"ExistingNodeSelectorTerms": {
+			runtime: &v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			podSpec: &v1.PodSpec{
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{zoneRequirement}}},
+					},
+				}},
+			},
+			expected: &v1.PodSpec{
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{
+							{MatchExpressions: []v1.NodeSelectorRequirement{zoneRequirement, archRequirement}},
+						},
+					},
+				}},
+			},
+		},
+		"SupportedNodeSelector": {
+			runtime: &v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			podSpec: &v1.PodSpec{NodeSelector: map[string]string{v1.LabelArchStable: "arm64"}},
+			expected: &v1.PodSpec{
+				NodeSelector: map[string]string{v1.LabelArchStable: "arm64"},
+				Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{archRequirement}}},
+					},
+				}},
+			},
+		},
+		"UnsupportedNodeSelector": {
+			runtime:   &v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64"}},
+			podSpec:   &v1.PodSpec{NodeSelector: map[string]string{v1.LabelArchStable: "arm64"}},
+			expectErr: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := SetArchitectureAffinity(scenario.podSpec, scenario.runtime)
+			if scenario.expectErr {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(scenario.podSpec).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
 func TestGetServingRuntime(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -964,6 +1052,46 @@ func TestGetServingRuntime(t *testing.T) {
 		g.Expect(err.Error()).To(gomega.ContainSubstring("No ServingRuntimes or ClusterServingRuntimes with the name"))
 	})
 
+	// A ClusterServingRuntime restricted to other namespaces is not available
+	t.Run("NotAllowedClusterServingRuntime", func(t *testing.T) {
+		restricted := &v1alpha1.ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "restricted-runtime",
+			},
+			Spec: servingRuntimeSpecs[sklearnRuntime],
+		}
+		restricted.Spec.AllowedNamespaces = []string{"team-a"}
+		mockClient := fake.NewClientBuilder().WithObjects(restricted).WithScheme(s).Build()
+		res, err := GetServingRuntime(mockClient, "restricted-runtime", "team-a")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(res.AllowedNamespaces).To(gomega.Equal([]string{"team-a"}))
+		res, err = GetServingRuntime(mockClient, "restricted-runtime", namespace)
+		g.Expect(res).To(gomega.BeNil())
+		g.Expect(err).To(gomega.MatchError("ClusterServingRuntime restricted-runtime is not allowed in the namespace default"))
+	})
+
+	// A ClusterServingRuntime with a namespace selector is available in the namespaces matching it
+	t.Run("NamespaceSelectorClusterServingRuntime", func(t *testing.T) {
+		selected := &v1alpha1.ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gpu-runtime",
+			},
+			Spec: servingRuntimeSpecs[sklearnRuntime],
+		}
+		selected.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"gpu-approved": "true"}}
+		s := runtime.NewScheme()
+		v1alpha1.AddToScheme(s)
+		v1.AddToScheme(s)
+		mockClient := fake.NewClientBuilder().WithObjects(selected,
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"gpu-approved": "true"}}},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}).WithScheme(s).Build()
+		res, err := GetServingRuntime(mockClient, "gpu-runtime", "team-a")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(res.NamespaceSelector).To(gomega.Equal(selected.Spec.NamespaceSelector))
+		res, err = GetServingRuntime(mockClient, "gpu-runtime", namespace)
+		g.Expect(res).To(gomega.BeNil())
+		g.Expect(err).To(gomega.MatchError("ClusterServingRuntime gpu-runtime is not allowed in the namespace default"))
+	})
 }
 
 func TestReplacePlaceholders(t *testing.T) {
@@ -1236,3 +1364,274 @@ func TestGetDeploymentMode(t *testing.T) {
 		})
 	}
 }
+
+func TestIsExplainerColocated(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	explainer := &v1beta1.ExplainerSpec{ART: &v1beta1.ARTExplainerSpec{Type: "SquareAttack"}}
+	scenarios := map[string]struct {
+		annotations  map[string]string
+		explainer    *v1beta1.ExplainerSpec
+		deployConfig *v1beta1.DeployConfig
+		expected     bool
+	}{
+		"ColocatedRawDeployment": {
+			annotations:  map[string]string{constants.ExplainerColocationAnnotationKey: "true"},
+			explainer:    explainer,
+			deployConfig: &v1beta1.DeployConfig{DefaultDeploymentMode: string(constants.RawDeployment)},
+			expected:     true,
+		},
+		"ColocatedServerless": {
+			annotations:  map[string]string{constants.ExplainerColocationAnnotationKey: "true"},
+			explainer:    explainer,
+			deployConfig: &v1beta1.DeployConfig{DefaultDeploymentMode: string(constants.Serverless)},
+			expected:     false,
+		},
+		"NotColocated": {
+			annotations:  map[string]string{constants.DeploymentMode: string(constants.RawDeployment)},
+			explainer:    explainer,
+			deployConfig: &v1beta1.DeployConfig{},
+			expected:     false,
+		},
+		"NoExplainer": {
+			annotations:  map[string]string{constants.ExplainerColocationAnnotationKey: "true"},
+			deployConfig: &v1beta1.DeployConfig{DefaultDeploymentMode: string(constants.RawDeployment)},
+			expected:     false,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		isvc := &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Annotations: scenario.annotations},
+			Spec:       v1beta1.InferenceServiceSpec{Explainer: scenario.explainer},
+		}
+		g.Expect(IsExplainerColocated(isvc, scenario.deployConfig)).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestGetLinkedReplicas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		predictorReplicas int32
+		transformerUnits  int32
+		predictorUnits    int32
+		componentExt      *v1beta1.ComponentExtensionSpec
+		expected          int32
+	}{
+		"TwoTransformersPerPredictor": {
+			predictorReplicas: 3,
+			transformerUnits:  2,
+			predictorUnits:    1,
+			componentExt:      &v1beta1.ComponentExtensionSpec{},
+			expected:          6,
+		},
+		"RoundedUp": {
+			predictorReplicas: 4,
+			transformerUnits:  1,
+			predictorUnits:    3,
+			componentExt:      &v1beta1.ComponentExtensionSpec{},
+			expected:          2,
+		},
+		"DefaultMinReplicas": {
+			predictorReplicas: 0,
+			transformerUnits:  2,
+			predictorUnits:    1,
+			componentExt:      &v1beta1.ComponentExtensionSpec{},
+			expected:          1,
+		},
+		"ScaledToZero": {
+			predictorReplicas: 0,
+			transformerUnits:  2,
+			predictorUnits:    1,
+			componentExt:      &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(0)},
+			expected:          0,
+		},
+		"MaxReplicas": {
+			predictorReplicas: 10,
+			transformerUnits:  2,
+			predictorUnits:    1,
+			componentExt:      &v1beta1.ComponentExtensionSpec{MaxReplicas: 8},
+			expected:          8,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		replicas := GetLinkedReplicas(scenario.predictorReplicas, scenario.transformerUnits, scenario.predictorUnits,
+			scenario.componentExt)
+		g.Expect(replicas).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestGetTerminatedContainerLogTail(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-default-0001", Namespace: "default"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: constants.InferenceServiceContainerName,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: constants.StateReasonCrashLoopBackOff},
+					},
+				},
+			},
+		},
+	}
+	clientset := fakeclientset.NewSimpleClientset(pod)
+	logs, err := GetTerminatedContainerLogTail(clientset, pod, constants.InferenceServiceContainerName)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(logs).To(gomega.Equal("fake logs"))
+}
+
+func TestRaiseMemoryOnOOM(t *testing.T) {
+	policy := map[string]string{
+		constants.OOMMemoryFactorAnnotationKey: "1.5",
+		constants.OOMMaxMemoryAnnotationKey:    "4Gi",
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		memory      string
+		expected    string
+		raised      bool
+	}{
+		"Raised": {
+			annotations: policy,
+			memory:      "2Gi",
+			expected:    "3Gi",
+			raised:      true,
+		},
+		"BoundedByMaxMemory": {
+			annotations: policy,
+			memory:      "3Gi",
+			expected:    "4Gi",
+			raised:      true,
+		},
+		"MaxMemoryReached": {
+			annotations: policy,
+			memory:      "4Gi",
+			raised:      false,
+		},
+		"NoPolicy": {
+			annotations: map[string]string{},
+			memory:      "2Gi",
+			raised:      false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			memory, raised := RaiseMemoryOnOOM(scenario.annotations, resource.MustParse(scenario.memory))
+			g.Expect(raised).To(gomega.Equal(scenario.raised))
+			if raised {
+				g.Expect(memory.String()).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestStorageRetryInterval(t *testing.T) {
+	now := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+	newStatus := func(reason v1beta1.FailureReason, state v1beta1.ModelState, failedAgo time.Duration) *v1beta1.InferenceServiceStatus {
+		failure := metav1.NewTime(now.Add(-failedAgo))
+		return &v1beta1.InferenceServiceStatus{ModelStatus: v1beta1.ModelStatus{
+			ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: state},
+			LastFailureInfo:     &v1beta1.FailureInfo{Reason: reason, Time: &failure},
+		}}
+	}
+	scenarios := map[string]struct {
+		status   *v1beta1.InferenceServiceStatus
+		expected time.Duration
+	}{
+		"FirstRetry": {
+			status:   newStatus(v1beta1.StorageUnavailable, v1beta1.Loading, 0),
+			expected: 10 * time.Second,
+		},
+		"GrowsWithTheFailure": {
+			status:   newStatus(v1beta1.StorageUnavailable, v1beta1.Loading, time.Minute),
+			expected: time.Minute,
+		},
+		"BoundedByMaxInterval": {
+			status:   newStatus(v1beta1.StorageUnavailable, v1beta1.Loading, time.Hour),
+			expected: 5 * time.Minute,
+		},
+		"PermanentFailure": {
+			status:   newStatus(v1beta1.ModelNotFound, v1beta1.FailedToLoad, time.Minute),
+			expected: 0,
+		},
+		"Loaded": {
+			status:   newStatus(v1beta1.StorageUnavailable, v1beta1.Loaded, time.Minute),
+			expected: 0,
+		},
+		"NoFailure": {
+			status:   &v1beta1.InferenceServiceStatus{},
+			expected: 0,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(StorageRetryInterval(scenario.status, now)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestSetContainerMemory(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	requests := v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}
+	container := &v1.Container{
+		Resources: v1.ResourceRequirements{
+			Requests: requests,
+			Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+	}
+	memory, ok := GetContainerMemory(container)
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(memory.String()).To(gomega.Equal("2Gi"))
+
+	SetContainerMemory(container, resource.MustParse("3Gi"))
+	g.Expect(container.Resources.Requests.Memory().String()).To(gomega.Equal("3Gi"))
+	g.Expect(container.Resources.Limits.Memory().String()).To(gomega.Equal("3Gi"))
+	// The resources of the spec the container was built from are left untouched
+	g.Expect(requests.Memory().String()).To(gomega.Equal("1Gi"))
+}
+
+func TestGraphOptimizationEnv(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	workspace := resource.MustParse("1Gi")
+	envs := GraphOptimizationEnv(&v1beta1.GraphOptimization{
+		Precision:     v1beta1.PrecisionINT8,
+		WorkspaceSize: &workspace,
+		DynamicShapes: []v1beta1.DynamicShape{
+			{Input: "input", Min: []int64{1, 3, 224, 224}, Opt: []int64{8, 3, 224, 224}, Max: []int64{32, 3, 224, 224}},
+			{Input: "mask", Min: []int64{1}, Opt: []int64{8}, Max: []int64{32}},
+		},
+	})
+	g.Expect(envs).To(gomega.Equal([]v1.EnvVar{
+		{Name: constants.ORTTensorRTEngineCacheEnvVarKey, Value: "1"},
+		{Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "1"},
+		{Name: constants.ORTTensorRTINT8EnableEnvVarKey, Value: "1"},
+		{Name: constants.ORTTensorRTMaxWorkspaceSizeEnvVarKey, Value: "1073741824"},
+		{Name: constants.ORTTensorRTProfileMinShapesEnvVarKey, Value: "input:1x3x224x224,mask:1"},
+		{Name: constants.ORTTensorRTProfileOptShapesEnvVarKey, Value: "input:8x3x224x224,mask:8"},
+		{Name: constants.ORTTensorRTProfileMaxShapesEnvVarKey, Value: "input:32x3x224x224,mask:32"},
+	}))
+
+	envs = GraphOptimizationEnv(&v1beta1.GraphOptimization{})
+	g.Expect(envs).To(gomega.Equal([]v1.EnvVar{{Name: constants.ORTTensorRTEngineCacheEnvVarKey, Value: "1"}}))
+}
+
+func TestSetContainerEnv(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	env := []v1.EnvVar{{Name: "STORAGE_URI", Value: "gs://models"}, {Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "0"}}
+	container := &v1.Container{Env: env}
+	SetContainerEnv(container, []v1.EnvVar{
+		{Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "1"},
+		{Name: constants.ORTTensorRTEngineCacheEnvVarKey, Value: "1"},
+	})
+	g.Expect(container.Env).To(gomega.Equal([]v1.EnvVar{
+		{Name: "STORAGE_URI", Value: "gs://models"},
+		{Name: constants.ORTTensorRTFP16EnableEnvVarKey, Value: "1"},
+		{Name: constants.ORTTensorRTEngineCacheEnvVarKey, Value: "1"},
+	}))
+	// The env of the spec the container was built from is left untouched
+	g.Expect(env[1].Value).To(gomega.Equal("0"))
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+package janitor
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	TTLExpiringReason = "TTLExpiring"
+	TTLExpiredReason  = "TTLExpired"
+	TTLResumedReason  = "TTLResumed"
+	// maxWarningPeriod bounds how long before the expiry the TTLExpiring event is emitted, short TTLs are warned
+	// a tenth of their TTL ahead.
+	maxWarningPeriod = time.Hour
+	// requestsPollInterval is how often the request counters of the InferenceServices whose TTL runs from their last
+	// request are scraped, it bounds how late a request resets their TTL.
+	requestsPollInterval = time.Minute
+)
+
+// TTLReconciler deletes or suspends the InferenceServices annotated with serving.kserve.io/ttl once the TTL has passed
+// since their creation, or since their last request with serving.kserve.io/ttl-from: last-request, a warning event is
+// emitted shortly before. The TTL state is recorded in annotations of the InferenceService so a restart of the
+// controller neither repeats the warning nor forgets a reset of the TTL.
+type TTLReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Clock is the clock the TTL of the InferenceServices runs out on, the tests use a fake clock
+	Clock clock.Clock
+	// Scrape returns the queue-proxy metrics of the pod in the prometheus text format, defaults to scrapeQueueProxy
+	Scrape func(ctx context.Context, pod *v1.Pod) ([]byte, error)
+
+	mu       sync.Mutex
+	requests map[types.NamespacedName]map[string]float64
+}
+
+func (r *TTLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			r.forget(req.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	value, ok := isvc.Annotations[constants.TTLAnnotationKey]
+	if !ok || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.forget(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		// The ttl is validated on admission, the InferenceServices annotated before the webhook was enabled are kept
+		r.Log.Info("Ignoring invalid ttl annotation", "InferenceService", req.NamespacedName, "ttl", value)
+		return reconcile.Result{}, nil
+	}
+	// The suspended InferenceServices are reconciled again once they are resumed by removing the annotation
+	if isvcutils.IsSuspended(isvc.Annotations) {
+		return reconcile.Result{}, nil
+	}
+
+	now := r.Clock.Now()
+	original := isvc.DeepCopy()
+	var events []func()
+	if _, ok := isvc.Annotations[constants.TTLExpiredAnnotationKey]; ok {
+		delete(isvc.Annotations, constants.TTLExpiredAnnotationKey)
+		delete(isvc.Annotations, constants.TTLWarnedAnnotationKey)
+		isvc.Annotations[constants.TTLStartTimeAnnotationKey] = now.UTC().Format(time.RFC3339)
+		events = append(events, func() {
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, TTLResumedReason,
+				"InferenceService was resumed, its TTL of %s starts again", value)
+		})
+	}
+	fromLastRequest := isvc.Annotations[constants.TTLFromAnnotationKey] == constants.TTLFromLastRequest
+	if fromLastRequest {
+		requested, err := r.requestsServed(ctx, isvc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if requested {
+			isvc.Annotations[constants.TTLStartTimeAnnotationKey] = now.UTC().Format(time.RFC3339)
+		}
+	}
+
+	expiry := ttlStart(isvc).Add(ttl)
+	expiryTime := expiry.UTC().Format(time.RFC3339)
+	suspend := isvc.Annotations[constants.TTLActionAnnotationKey] == constants.TTLActionSuspend
+	remaining := expiry.Sub(now)
+	if remaining <= 0 && !suspend {
+		r.Log.Info("Deleting InferenceService past its TTL", "InferenceService", req.NamespacedName, "ttl", value)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, TTLExpiredReason,
+			"Deleting InferenceService, its TTL of %s expired at %s", value, expiryTime)
+		if err := r.Delete(ctx, isvc); err != nil && !apierr.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		r.forget(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	result := reconcile.Result{}
+	if remaining <= 0 {
+		r.Log.Info("Suspending InferenceService past its TTL", "InferenceService", req.NamespacedName, "ttl", value)
+		delete(isvc.Annotations, constants.TTLWarnedAnnotationKey)
+		isvc.Annotations[constants.TTLExpiredAnnotationKey] = expiryTime
+		isvc.Annotations[constants.SuspendedAnnotationKey] = "true"
+		events = append(events, func() {
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, TTLExpiredReason,
+				"Suspending InferenceService, its TTL of %s expired at %s", value, expiryTime)
+		})
+		r.forget(req.NamespacedName)
+	} else {
+		warningPeriod := ttl / 10
+		if warningPeriod > maxWarningPeriod {
+			warningPeriod = maxWarningPeriod
+		}
+		result.RequeueAfter = remaining
+		if remaining > warningPeriod {
+			result.RequeueAfter = remaining - warningPeriod
+		} else if isvc.Annotations[constants.TTLWarnedAnnotationKey] != expiryTime {
+			// The warned expiry is recorded so the reconciles triggered by unrelated updates do not repeat the event
+			isvc.Annotations[constants.TTLWarnedAnnotationKey] = expiryTime
+			action := "deleted"
+			if suspend {
+				action = "suspended"
+			}
+			events = append(events, func() {
+				r.Recorder.Eventf(isvc, v1.EventTypeWarning, TTLExpiringReason,
+					"InferenceService will be %s at %s when its TTL of %s expires", action, expiryTime, value)
+			})
+		}
+		if fromLastRequest && result.RequeueAfter > requestsPollInterval {
+			result.RequeueAfter = requestsPollInterval
+		}
+	}
+
+	if !reflect.DeepEqual(original.Annotations, isvc.Annotations) {
+		if err := r.Patch(ctx, isvc, client.MergeFrom(original)); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	for _, event := range events {
+		event()
+	}
+	return result, nil
+}
+
+// ttlStart returns the time the TTL of the InferenceService runs from, its creation unless the TTL was reset since by
+// a request or by resuming the InferenceService
+func ttlStart(isvc *v1beta1.InferenceService) time.Time {
+	start := isvc.CreationTimestamp.Time
+	if value, ok := isvc.Annotations[constants.TTLStartTimeAnnotationKey]; ok {
+		if reset, err := time.Parse(time.RFC3339, value); err == nil && reset.After(start) {
+			start = reset
+		}
+	}
+	return start
+}
+
+func (r *TTLReconciler) forget(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.requests, name)
+}
+
+func (r *TTLReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-janitor").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Complete(r)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTTLReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	created := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		now         time.Time
+		deleted     bool
+		suspended   bool
+		requeue     time.Duration
+		events      int
+	}{
+		"NoTTL": {
+			annotations: map[string]string{},
+			now:         created.Add(100 * time.Hour),
+		},
+		"BeforeWarning": {
+			annotations: map[string]string{constants.TTLAnnotationKey: "72h"},
+			now:         created.Add(24 * time.Hour),
+			requeue:     47 * time.Hour,
+		},
+		"WithinWarning": {
+			annotations: map[string]string{constants.TTLAnnotationKey: "72h"},
+			now:         created.Add(71*time.Hour + 30*time.Minute),
+			requeue:     30 * time.Minute,
+			events:      1,
+		},
+		"ShortTTLWarning": {
+			annotations: map[string]string{constants.TTLAnnotationKey: "10m"},
+			now:         created.Add(9*time.Minute + 30*time.Second),
+			requeue:     30 * time.Second,
+			events:      1,
+		},
+		"Expired": {
+			annotations: map[string]string{constants.TTLAnnotationKey: "72h"},
+			now:         created.Add(73 * time.Hour),
+			deleted:     true,
+			events:      1,
+		},
+		"Suspend": {
+			annotations: map[string]string{
+				constants.TTLAnnotationKey:       "72h",
+				constants.TTLActionAnnotationKey: constants.TTLActionSuspend,
+			},
+			now:       created.Add(73 * time.Hour),
+			suspended: true,
+			events:    1,
+		},
+		"Suspended": {
+			annotations: map[string]string{
+				constants.TTLAnnotationKey:        "72h",
+				constants.TTLActionAnnotationKey:  constants.TTLActionSuspend,
+				constants.TTLExpiredAnnotationKey: "2022-10-04T00:00:00Z",
+				constants.SuspendedAnnotationKey:  "true",
+			},
+			now:       created.Add(100 * time.Hour),
+			suspended: true,
+		},
+		"Resumed": {
+			annotations: map[string]string{
+				constants.TTLAnnotationKey:        "72h",
+				constants.TTLActionAnnotationKey:  constants.TTLActionSuspend,
+				constants.TTLExpiredAnnotationKey: "2022-10-04T00:00:00Z",
+			},
+			now:     created.Add(100 * time.Hour),
+			requeue: 71 * time.Hour,
+			events:  1,
+		},
+		"StartTimeReset": {
+			annotations: map[string]string{
+				constants.TTLAnnotationKey:          "72h",
+				constants.TTLStartTimeAnnotationKey: "2022-10-03T00:00:00Z",
+			},
+			now:     created.Add(73 * time.Hour),
+			requeue: 46 * time.Hour,
+		},
+		"InvalidTTL": {
+			annotations: map[string]string{constants.TTLAnnotationKey: "3d"},
+			now:         created.Add(100 * time.Hour),
+		},
+	}
+
+	s := runtime.NewScheme()
+	v1beta1.AddToScheme(s)
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "experiment",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(created),
+					Annotations:       scenario.annotations,
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &TTLReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build(),
+				Log:      ctrl.Log.WithName("test"),
+				Recorder: recorder,
+				Clock:    testclock.NewFakeClock(scenario.now),
+			}
+			name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.RequeueAfter).To(gomega.Equal(scenario.requeue))
+			g.Expect(recorder.Events).To(gomega.HaveLen(scenario.events))
+
+			updated := &v1beta1.InferenceService{}
+			err = reconciler.Get(context.TODO(), name, updated)
+			if scenario.deleted {
+				g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+			} else {
+				g.Expect(err).To(gomega.BeNil())
+				g.Expect(updated.Annotations[constants.SuspendedAnnotationKey] == "true").To(gomega.Equal(scenario.suspended))
+			}
+
+			// The events are only emitted once, also by the reconcilers of a restarted controller
+			reconciler = &TTLReconciler{
+				Client:   reconciler.Client,
+				Log:      ctrl.Log.WithName("test"),
+				Recorder: recorder,
+				Clock:    testclock.NewFakeClock(scenario.now),
+			}
+			_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(recorder.Events).To(gomega.HaveLen(scenario.events))
+		})
+	}
+}
+
+func TestTTLReconcileLastRequest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	created := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	s := runtime.NewScheme()
+	v1beta1.AddToScheme(s)
+	v1.AddToScheme(s)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "experiment",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations: map[string]string{
+				constants.TTLAnnotationKey:     "72h",
+				constants.TTLFromAnnotationKey: constants.TTLFromLastRequest,
+			},
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "experiment-predictor-default-00001-deployment-7d9c5",
+			Namespace: "default",
+			Labels:    map[string]string{constants.InferenceServiceLabel: "experiment"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "kserve-container"}, {Name: queueProxyContainerName}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	requests := 5
+	fakeClock := testclock.NewFakeClock(created.Add(24 * time.Hour))
+	reconciler := &TTLReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(isvc, pod).Build(),
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(10),
+		Clock:    fakeClock,
+		Scrape: func(ctx context.Context, pod *v1.Pod) ([]byte, error) {
+			return []byte(fmt.Sprintf("# TYPE revision_request_count counter\n"+
+				"revision_request_count{response_code=\"200\"} %d\n"+
+				"revision_request_count{response_code=\"500\"} 1\n", requests)), nil
+		},
+	}
+	name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+	startTime := func() string {
+		updated := &v1beta1.InferenceService{}
+		g.Expect(reconciler.Get(context.TODO(), name, updated)).To(gomega.Succeed())
+		return updated.Annotations[constants.TTLStartTimeAnnotationKey]
+	}
+
+	// The first scrape is the baseline of the counters, the TTL runs from the creation
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(requestsPollInterval))
+	g.Expect(startTime()).To(gomega.BeEmpty())
+
+	// The served requests restart the TTL
+	requests = 8
+	fakeClock.Step(time.Hour)
+	_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(startTime()).To(gomega.Equal(created.Add(25 * time.Hour).Format(time.RFC3339)))
+
+	// Without new requests the TTL expires 72h after the last request
+	fakeClock.Step(72 * time.Hour)
+	_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(apierr.IsNotFound(reconciler.Get(context.TODO(), name, &v1beta1.InferenceService{}))).To(gomega.BeTrue())
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	DeprecatedReason = "Deprecated"
+	SunsetReason     = "Sunset"
+)
+
+// DeprecationReconciler emits warning events for the InferenceServices annotated with serving.kserve.io/deprecation
+// once they are deprecated and again once their serving.kserve.io/sunset has passed. The Deprecation and Sunset
+// response headers and the rejection of the requests past the sunset are handled by the agent.
+type DeprecationReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Clock is the clock the deprecation and sunset timestamps are compared to, the tests use a fake clock
+	Clock clock.Clock
+
+	mu     sync.Mutex
+	warned map[types.NamespacedName]string
+}
+
+func (r *DeprecationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			r.forget(req.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	value, ok := isvc.Annotations[constants.DeprecationAnnotationKey]
+	if !ok || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.forget(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+	deprecation, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// The timestamp is validated on admission, the InferenceServices annotated before are not warned about
+		r.Log.Info("Ignoring invalid deprecation annotation", "InferenceService", req.NamespacedName, "deprecation", value)
+		return reconcile.Result{}, nil
+	}
+	var sunset *time.Time
+	if value, ok := isvc.Annotations[constants.SunsetAnnotationKey]; ok {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			r.Log.Info("Ignoring invalid sunset annotation", "InferenceService", req.NamespacedName, "sunset", value)
+			return reconcile.Result{}, nil
+		}
+		sunset = &parsed
+	}
+
+	now := r.Clock.Now()
+	if now.Before(deprecation) {
+		return reconcile.Result{RequeueAfter: deprecation.Sub(now)}, nil
+	}
+	if sunset == nil || now.Before(*sunset) {
+		message := fmt.Sprintf("InferenceService is deprecated since %s", deprecation.Format(time.RFC3339))
+		if sunset != nil {
+			message += fmt.Sprintf(" and will be sunset at %s", sunset.Format(time.RFC3339))
+		}
+		if r.markWarned(req.NamespacedName, message) {
+			r.Recorder.Event(isvc, v1.EventTypeWarning, DeprecatedReason, message)
+		}
+		if sunset == nil {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{RequeueAfter: sunset.Sub(now)}, nil
+	}
+	message := fmt.Sprintf("InferenceService was sunset at %s", sunset.Format(time.RFC3339))
+	if percentage, ok := isvc.Annotations[constants.SunsetRejectPercentageAnnotationKey]; ok {
+		message += fmt.Sprintf(", %s%% of the requests are rejected", percentage)
+	}
+	if r.markWarned(req.NamespacedName, message) {
+		r.Recorder.Event(isvc, v1.EventTypeWarning, SunsetReason, message)
+	}
+	return reconcile.Result{}, nil
+}
+
+// markWarned records the warning the InferenceService was warned with and returns false when it already was, so
+// reconciles triggered by unrelated updates do not repeat the event.
+func (r *DeprecationReconciler) markWarned(name types.NamespacedName, message string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.warned == nil {
+		r.warned = map[types.NamespacedName]string{}
+	}
+	if warned, ok := r.warned[name]; ok && warned == message {
+		return false
+	}
+	r.warned[name] = message
+	return true
+}
+
+func (r *DeprecationReconciler) forget(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.warned, name)
+}
+
+func (r *DeprecationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-deprecation").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
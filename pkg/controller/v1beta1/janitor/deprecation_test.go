@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeprecationReconcile(t *testing.T) {
+	deprecation := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	annotations := map[string]string{
+		constants.DeprecationAnnotationKey:            deprecation.Format(time.RFC3339),
+		constants.SunsetAnnotationKey:                 sunset.Format(time.RFC3339),
+		constants.SunsetRejectPercentageAnnotationKey: "50",
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		now         time.Time
+		requeue     time.Duration
+		event       string
+	}{
+		"NotDeprecated": {
+			annotations: map[string]string{},
+			now:         sunset,
+		},
+		"BeforeDeprecation": {
+			annotations: annotations,
+			now:         deprecation.Add(-time.Hour),
+			requeue:     time.Hour,
+		},
+		"Deprecated": {
+			annotations: annotations,
+			now:         sunset.Add(-24 * time.Hour),
+			requeue:     24 * time.Hour,
+			event:       "Warning Deprecated InferenceService is deprecated since 2023-01-01T00:00:00Z and will be sunset at 2023-03-01T00:00:00Z",
+		},
+		"DeprecatedWithoutSunset": {
+			annotations: map[string]string{constants.DeprecationAnnotationKey: deprecation.Format(time.RFC3339)},
+			now:         sunset,
+			event:       "Warning Deprecated InferenceService is deprecated since 2023-01-01T00:00:00Z",
+		},
+		"Sunset": {
+			annotations: annotations,
+			now:         sunset.Add(time.Hour),
+			event:       "Warning Sunset InferenceService was sunset at 2023-03-01T00:00:00Z, 50% of the requests are rejected",
+		},
+		"InvalidDeprecation": {
+			annotations: map[string]string{constants.DeprecationAnnotationKey: "2023-01-01"},
+			now:         sunset,
+		},
+	}
+
+	s := runtime.NewScheme()
+	v1beta1.AddToScheme(s)
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "legacy",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &DeprecationReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build(),
+				Log:      ctrl.Log.WithName("test"),
+				Recorder: recorder,
+				Clock:    testclock.NewFakeClock(scenario.now),
+			}
+			name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.RequeueAfter).To(gomega.Equal(scenario.requeue))
+			if scenario.event == "" {
+				g.Expect(recorder.Events).To(gomega.BeEmpty())
+			} else {
+				g.Expect(recorder.Events).To(gomega.HaveLen(1))
+				g.Expect(<-recorder.Events).To(gomega.Equal(scenario.event))
+			}
+
+			// The warning is only emitted once
+			_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(recorder.Events).To(gomega.BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/prometheus/common/expfmt"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/serving/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// queueProxyContainerName is the container of the serverless pods counting the requests of the revision
+	queueProxyContainerName = "queue-proxy"
+	// requestsMetricName is the counter of the requests routed to the queue-proxy
+	requestsMetricName = "revision_request_count"
+	scrapeTimeout      = 10 * time.Second
+)
+
+// requestsServed scrapes the request counters of the running pods of the InferenceService and returns whether they
+// served requests since the previous scrape. The first scrape after a restart of the controller is the baseline, the
+// pods which could not be scraped keep their previous counters.
+func (r *TTLReconciler) requestsServed(ctx context.Context, isvc *v1beta1.InferenceService) (bool, error) {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServiceLabel: isvc.Name}); err != nil {
+		return false, err
+	}
+	scrape := r.Scrape
+	if scrape == nil {
+		scrape = scrapeQueueProxy
+	}
+	name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+	r.mu.Lock()
+	previous, ok := r.requests[name]
+	r.mu.Unlock()
+	current := map[string]float64{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != v1.PodRunning || pod.Status.PodIP == "" || !hasQueueProxy(pod) {
+			continue
+		}
+		metrics, err := scrape(ctx, pod)
+		if err == nil {
+			current[pod.Name], err = parseRequests(metrics)
+		}
+		if err != nil {
+			r.Log.Error(err, "Failed to scrape queue-proxy metrics", "pod", pod.Name, "namespace", pod.Namespace)
+			if count, scraped := previous[pod.Name]; scraped {
+				current[pod.Name] = count
+			}
+		}
+	}
+	r.mu.Lock()
+	if r.requests == nil {
+		r.requests = map[types.NamespacedName]map[string]float64{}
+	}
+	r.requests[name] = current
+	r.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	// The pods started since the previous scrape have no previous counter
+	for pod, count := range current {
+		if count > previous[pod] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func hasQueueProxy(pod *v1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == queueProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRequests returns the requests counted by the queue-proxy across the response codes
+func parseRequests(metrics []byte) (float64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return 0, err
+	}
+	requests := 0.0
+	if family, ok := families[requestsMetricName]; ok {
+		for _, metric := range family.Metric {
+			requests += metric.GetCounter().GetValue()
+		}
+	}
+	return requests, nil
+}
+
+func scrapeQueueProxy(ctx context.Context, pod *v1.Pod) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(networking.UserQueueMetricsPort)) +
+		constants.DefaultPrometheusPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d scraping %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
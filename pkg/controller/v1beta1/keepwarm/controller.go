@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+package keepwarm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/robfig/cron/v3"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// UserAgent identifies the keep warm pings in the access logs of the model servers
+	UserAgent   = "kserve-keep-warm"
+	pingTimeout = 30 * time.Second
+)
+
+// KeepWarmReconciler keeps the components of the InferenceServices annotated with serving.kserve.io/keep-warm-interval
+// warm by sending a GET request to their cluster local address every interval. The pings are counted as traffic by the
+// Knative autoscaler, so the components are not scaled to zero during short traffic gaps. When the
+// serving.kserve.io/keep-warm-schedule and serving.kserve.io/keep-warm-window annotations are set, the components are
+// only pinged during the windows starting on the schedule and scale to zero outside of them.
+type KeepWarmReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Clock is the clock the ping interval and the keep warm windows are checked against, the tests use a fake clock
+	Clock clock.Clock
+	// Ping sends a keep warm request to the url, defaults to pingComponent
+	Ping func(ctx context.Context, url string) error
+
+	mu sync.Mutex
+	// pinged is the time of the last pings of each InferenceService, the components are pinged again right away
+	// after a restart of the controller
+	pinged map[types.NamespacedName]time.Time
+}
+
+func (r *KeepWarmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			r.setPinged(req.NamespacedName, time.Time{})
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	value, ok := isvc.Annotations[constants.KeepWarmIntervalAnnotationKey]
+	if !ok || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.setPinged(req.NamespacedName, time.Time{})
+		return reconcile.Result{}, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		// The interval is validated on admission, the InferenceServices annotated before are not pinged
+		r.Log.Info("Ignoring invalid keep warm interval annotation", "InferenceService", req.NamespacedName, "interval", value)
+		return reconcile.Result{}, nil
+	}
+
+	now := r.Clock.Now()
+	if next, err := r.nextWindow(isvc, now); err != nil {
+		// An invalid schedule or window stops the pings rather than keeping the component warm around the clock
+		r.Log.Info("Ignoring invalid keep warm schedule", "InferenceService", req.NamespacedName, "error", err.Error())
+		return reconcile.Result{}, nil
+	} else if next.After(now) {
+		return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+	if last := r.lastPinged(req.NamespacedName); now.Before(last.Add(interval)) {
+		return reconcile.Result{RequeueAfter: last.Add(interval).Sub(now)}, nil
+	}
+
+	ping := r.Ping
+	if ping == nil {
+		ping = pingComponent
+	}
+	for _, url := range componentURLs(isvc) {
+		// The failed pings are not retried before the next interval, a cold start is only delayed by them
+		if err := ping(ctx, url); err != nil {
+			r.Log.Info("Failed to ping component", "InferenceService", req.NamespacedName, "url", url, "error", err.Error())
+		}
+	}
+	r.setPinged(req.NamespacedName, now)
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// nextWindow returns the start of the keep warm window containing now, or the start of the next window when now is
+// outside of the windows. The InferenceServices without schedule are always kept warm.
+func (r *KeepWarmReconciler) nextWindow(isvc *v1beta1.InferenceService, now time.Time) (time.Time, error) {
+	value, ok := isvc.Annotations[constants.KeepWarmScheduleAnnotationKey]
+	if !ok {
+		return now, nil
+	}
+	schedule, err := cron.ParseStandard(value)
+	if err != nil {
+		return now, err
+	}
+	window, err := time.ParseDuration(isvc.Annotations[constants.KeepWarmWindowAnnotationKey])
+	if err != nil || window <= 0 {
+		return now, fmt.Errorf("invalid keep warm window %q", isvc.Annotations[constants.KeepWarmWindowAnnotationKey])
+	}
+	// The first start after now - window is before now when a window started during the last window duration
+	return schedule.Next(now.Add(-window)), nil
+}
+
+func (r *KeepWarmReconciler) lastPinged(name types.NamespacedName) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pinged[name]
+}
+
+func (r *KeepWarmReconciler) setPinged(name types.NamespacedName, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t.IsZero() {
+		delete(r.pinged, name)
+		return
+	}
+	if r.pinged == nil {
+		r.pinged = map[types.NamespacedName]time.Time{}
+	}
+	r.pinged[name] = t
+}
+
+// componentURLs returns the cluster local urls of the components of the InferenceService, the components are pinged
+// directly as the transformer does not call the predictor on a ping
+func componentURLs(isvc *v1beta1.InferenceService) []string {
+	var urls []string
+	for _, status := range isvc.Status.Components {
+		if status.Address != nil && status.Address.URL != nil {
+			urls = append(urls, status.Address.URL.String())
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// pingComponent sends a GET request to the root of the component, any response keeps the component warm
+func pingComponent(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+func (r *KeepWarmReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-keep-warm").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
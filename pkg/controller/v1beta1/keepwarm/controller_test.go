@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepwarm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInferenceService(annotations map[string]string) *v1beta1.InferenceService {
+	address := func(host string) *duckv1.Addressable {
+		return &duckv1.Addressable{URL: &apis.URL{Scheme: "http", Host: host}}
+	}
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", Annotations: annotations},
+		Status: v1beta1.InferenceServiceStatus{
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent:   {Address: address("sklearn-predictor-default.default.svc.cluster.local")},
+				v1beta1.TransformerComponent: {Address: address("sklearn-transformer-default.default.svc.cluster.local")},
+			},
+		},
+	}
+}
+
+func TestKeepWarmReconcile(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		// now is the time of the first reconcile
+		now           time.Time
+		expectedPings int
+		expectedAfter time.Duration
+	}{
+		"NoAnnotation": {
+			annotations:   map[string]string{},
+			now:           time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+			expectedPings: 0,
+			expectedAfter: 0,
+		},
+		"Interval": {
+			annotations:   map[string]string{constants.KeepWarmIntervalAnnotationKey: "5m"},
+			now:           time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC),
+			expectedPings: 2,
+			expectedAfter: 5 * time.Minute,
+		},
+		"InsideWindow": {
+			annotations: map[string]string{
+				constants.KeepWarmIntervalAnnotationKey: "5m",
+				constants.KeepWarmScheduleAnnotationKey: "0 8 * * 1-5",
+				constants.KeepWarmWindowAnnotationKey:   "10h",
+			},
+			// Monday
+			now:           time.Date(2023, 1, 2, 17, 0, 0, 0, time.UTC),
+			expectedPings: 2,
+			expectedAfter: 5 * time.Minute,
+		},
+		"OutsideWindow": {
+			annotations: map[string]string{
+				constants.KeepWarmIntervalAnnotationKey: "5m",
+				constants.KeepWarmScheduleAnnotationKey: "0 8 * * 1-5",
+				constants.KeepWarmWindowAnnotationKey:   "10h",
+			},
+			// Friday night, the next window starts on Monday morning
+			now:           time.Date(2023, 1, 6, 20, 0, 0, 0, time.UTC),
+			expectedPings: 0,
+			expectedAfter: 60 * time.Hour,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			v1beta1.AddToScheme(s)
+			cli := fake.NewClientBuilder().WithScheme(s).WithObjects(newInferenceService(scenario.annotations)).Build()
+			var pings []string
+			reconciler := &KeepWarmReconciler{
+				Client: cli,
+				Log:    ctrl.Log.WithName("KeepWarm"),
+				Scheme: s,
+				Clock:  testclock.NewFakeClock(scenario.now),
+				Ping: func(ctx context.Context, url string) error {
+					pings = append(pings, url)
+					return nil
+				},
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sklearn", Namespace: "default"}}
+			result, err := reconciler.Reconcile(context.TODO(), req)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(pings).To(gomega.HaveLen(scenario.expectedPings))
+			g.Expect(result.RequeueAfter).To(gomega.Equal(scenario.expectedAfter))
+		})
+	}
+}
+
+func TestKeepWarmReconcileInterval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1beta1.AddToScheme(s)
+	isvc := newInferenceService(map[string]string{
+		constants.KeepWarmIntervalAnnotationKey: "5m",
+		constants.KeepWarmScheduleAnnotationKey: "0 8 * * *",
+		constants.KeepWarmWindowAnnotationKey:   "1h",
+	})
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build()
+	fakeClock := testclock.NewFakeClock(time.Date(2023, 1, 2, 8, 50, 0, 0, time.UTC))
+	pings := 0
+	reconciler := &KeepWarmReconciler{
+		Client: cli,
+		Log:    ctrl.Log.WithName("KeepWarm"),
+		Scheme: s,
+		Clock:  fakeClock,
+		Ping: func(ctx context.Context, url string) error {
+			pings++
+			return nil
+		},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sklearn", Namespace: "default"}}
+
+	result, err := reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(pings).To(gomega.Equal(2))
+	g.Expect(result.RequeueAfter).To(gomega.Equal(5 * time.Minute))
+
+	// The reconciles triggered by updates of the InferenceService do not ping before the interval elapsed
+	fakeClock.Step(2 * time.Minute)
+	result, err = reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(pings).To(gomega.Equal(2))
+	g.Expect(result.RequeueAfter).To(gomega.Equal(3 * time.Minute))
+
+	fakeClock.Step(3 * time.Minute)
+	_, err = reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(pings).To(gomega.Equal(4))
+
+	// After the window the components are not pinged until the next window
+	fakeClock.Step(5 * time.Minute)
+	result, err = reconciler.Reconcile(context.TODO(), req)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(pings).To(gomega.Equal(4))
+	g.Expect(result.RequeueAfter).To(gomega.Equal(23 * time.Hour))
+}
+
+func TestPingComponent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userAgent = req.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// Any response keeps the component warm
+	g.Expect(pingComponent(context.TODO(), server.URL)).To(gomega.Succeed())
+	g.Expect(userAgent).To(gomega.Equal(UserAgent))
+}
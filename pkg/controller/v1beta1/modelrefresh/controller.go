@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+package modelrefresh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ModelRefreshedReason     = "ModelRefreshed"
+	ModelRefreshFailedReason = "ModelRefreshFailed"
+	// storageInitializerConfigKeyName is the inferenceservice-config entry of the storage initializer, its image
+	// computes the digest of the model it downloaded
+	storageInitializerConfigKeyName = "storageInitializer"
+	// jobNameLabel is set by the job controller on the pods of a job
+	jobNameLabel = "job-name"
+)
+
+// ModelRefreshReconciler downloads the model of the InferenceServices with a predictor refreshSchedule again on the
+// schedule, with a Job running the storage initializer. The digest of the downloaded model is recorded in the
+// serving.kserve.io/model-refresh-digest annotation, the first refresh records the digest of the deployed model.
+// When the digest changed it is set in the serving.kserve.io/model-digest annotation, which is propagated to the
+// predictor pod template and rolls out a new revision downloading the new model.
+type ModelRefreshReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Clock is the clock the next run of the refresh schedule is computed from, the tests use a fake clock
+	Clock clock.Clock
+}
+
+func ModelRefreshJobName(isvcName string) string {
+	return isvcName + constants.ModelRefreshJobNameSuffix
+}
+
+func (r *ModelRefreshReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			// The refresh job is garbage collected through its owner reference
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	value := isvc.Spec.Predictor.RefreshSchedule
+	if value == nil || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	schedule, err := cron.ParseStandard(*value)
+	if err != nil {
+		// The schedule is validated on admission, the InferenceServices created before are never refreshed
+		r.Log.Info("Ignoring invalid refresh schedule", "InferenceService", req.NamespacedName, "schedule", *value)
+		return reconcile.Result{}, nil
+	}
+
+	now := r.Clock.Now()
+	if refreshed, ok := isvc.Annotations[constants.ModelRefreshTimeAnnotationKey]; ok {
+		if last, err := time.Parse(time.RFC3339, refreshed); err == nil {
+			if next := schedule.Next(last); now.Before(next) {
+				return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+			}
+		}
+	}
+
+	job := &batchv1.Job{}
+	jobName := types.NamespacedName{Name: ModelRefreshJobName(isvc.Name), Namespace: isvc.Namespace}
+	if err := r.Get(ctx, jobName, job); err != nil {
+		if !apierr.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		image, err := r.storageInitializerImage(ctx)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		job = createJob(isvc, image)
+		if err := controllerutil.SetControllerReference(isvc, job, r.Scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.Log.Info("Creating model refresh job", "InferenceService", req.NamespacedName, "job", job.Name)
+		// The job status updates trigger the next reconcile
+		return reconcile.Result{}, r.Create(ctx, job)
+	}
+
+	var digest string
+	switch jobCondition(job) {
+	case batchv1.JobComplete:
+		if digest, err = r.jobDigest(ctx, job); err != nil {
+			return reconcile.Result{}, err
+		}
+	case batchv1.JobFailed:
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, ModelRefreshFailedReason,
+			"Failed to download the model, the model is refreshed again on the next schedule: %s", jobMessage(job))
+	default:
+		return reconcile.Result{}, nil
+	}
+
+	desired := isvc.DeepCopy()
+	if desired.Annotations == nil {
+		desired.Annotations = map[string]string{}
+	}
+	desired.Annotations[constants.ModelRefreshTimeAnnotationKey] = now.UTC().Format(time.RFC3339)
+	if digest != "" {
+		previous := isvc.Annotations[constants.ModelRefreshDigestAnnotationKey]
+		desired.Annotations[constants.ModelRefreshDigestAnnotationKey] = digest
+		if previous != "" && previous != digest {
+			r.Log.Info("Model digest changed, rolling out a new revision", "InferenceService", req.NamespacedName,
+				"previous", previous, "digest", digest)
+			desired.Annotations[constants.ModelDigestAnnotationKey] = digest
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, ModelRefreshedReason,
+				"The model digest changed from %s to %s, rolling out a new revision", previous, digest)
+		}
+	}
+	if err := r.Update(ctx, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierr.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: schedule.Next(now).Sub(now)}, nil
+}
+
+// storageInitializerImage returns the image of the storage initializer configured in the inferenceservice-config
+func (r *ModelRefreshReconciler) storageInitializerImage(ctx context.Context) (string, error) {
+	configMap := &v1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName,
+		Namespace: constants.KServeNamespace}, configMap); err != nil {
+		return "", err
+	}
+	config := struct {
+		Image string `json:"image"`
+	}{}
+	if err := json.Unmarshal([]byte(configMap.Data[storageInitializerConfigKeyName]), &config); err != nil {
+		return "", fmt.Errorf("unable to parse %s config: %v", storageInitializerConfigKeyName, err)
+	}
+	if config.Image == "" {
+		return "", fmt.Errorf("%s config has no image", storageInitializerConfigKeyName)
+	}
+	return config.Image, nil
+}
+
+// createJob creates the job downloading the model of the predictor with the storage initializer injected by the pod
+// mutator, the kserve container writes the digest of the downloaded model to its termination message.
+func createJob(isvc *v1beta1.InferenceService, image string) *batchv1.Job {
+	serviceAccountName := isvc.Spec.Predictor.ServiceAccountName
+	if serviceAccountName == "" && isvc.Annotations[constants.CreateServiceAccountAnnotationKey] == "true" {
+		serviceAccountName = constants.DefaultPredictorServiceName(isvc.Name)
+	}
+	backoffLimit := int32(constants.ModelRefreshJobBackoffLimit)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ModelRefreshJobName(isvc.Name),
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						constants.InferenceServicePodLabelKey: isvc.Name,
+					},
+					Annotations: components.PredictorStorageAnnotations(isvc),
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy:      v1.RestartPolicyNever,
+					ServiceAccountName: serviceAccountName,
+					ImagePullSecrets:   isvc.Spec.Predictor.ImagePullSecrets,
+					Containers: []v1.Container{{
+						Name:    constants.InferenceServiceContainerName,
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", constants.ModelRefreshDigestCommand},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobCondition returns JobComplete or JobFailed once the job finished, an empty condition otherwise
+func jobCondition(job *batchv1.Job) batchv1.JobConditionType {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status == v1.ConditionTrue &&
+			(condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+			return condition.Type
+		}
+	}
+	return ""
+}
+
+func jobMessage(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// jobDigest returns the digest written to the termination message of the kserve container of the succeeded pod
+func (r *ModelRefreshReconciler) jobDigest(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(job.Namespace),
+		client.MatchingLabels{jobNameLabel: job.Name}); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == constants.InferenceServiceContainerName && status.State.Terminated != nil {
+				if digest := strings.TrimSpace(status.State.Terminated.Message); digest != "" {
+					return digest, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("model refresh job %s did not report the digest of the model", job.Name)
+}
+
+func (r *ModelRefreshReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-model-refresh").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelrefresh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestInferenceService(annotations map[string]string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "embeddings",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: proto.String("s3://models/embeddings"),
+					},
+				},
+				RefreshSchedule: proto.String("0 2 * * *"),
+			},
+		},
+	}
+}
+
+func newTestConfigMap() *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{storageInitializerConfigKeyName: `{"image": "kserve/storage-initializer:latest"}`},
+	}
+}
+
+func newTestJob(condition batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: ModelRefreshJobName("embeddings"), Namespace: "default"},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: condition, Status: v1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+		},
+	}
+}
+
+func newTestJobPod(digest string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ModelRefreshJobName("embeddings") + "-x7k2p",
+			Namespace: "default",
+			Labels:    map[string]string{jobNameLabel: ModelRefreshJobName("embeddings")},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:  constants.InferenceServiceContainerName,
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: digest + "\n"}},
+			}},
+		},
+	}
+}
+
+func TestModelRefreshReconcile(t *testing.T) {
+	now := time.Date(2022, 10, 2, 2, 0, 30, 0, time.UTC)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		objects     []client.Object
+		requeue     time.Duration
+		jobCreated  bool
+		expected    map[string]string
+		events      int
+	}{
+		"FirstRefresh": {
+			jobCreated: true,
+		},
+		"NotDue": {
+			annotations: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:10Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			requeue: 24*time.Hour - 30*time.Second,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:10Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+		},
+		"Due": {
+			annotations: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-01T02:00:00Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			jobCreated: true,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-01T02:00:00Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+		},
+		"BaselineDigest": {
+			objects: []client.Object{newTestJob(batchv1.JobComplete), newTestJobPod("aaa")},
+			requeue: 24*time.Hour - 30*time.Second,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:30Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+		},
+		"UnchangedDigest": {
+			annotations: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-01T02:00:00Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			objects: []client.Object{newTestJob(batchv1.JobComplete), newTestJobPod("aaa")},
+			requeue: 24*time.Hour - 30*time.Second,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:30Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+		},
+		"ChangedDigest": {
+			annotations: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-01T02:00:00Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			objects: []client.Object{newTestJob(batchv1.JobComplete), newTestJobPod("bbb")},
+			requeue: 24*time.Hour - 30*time.Second,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:30Z",
+				constants.ModelRefreshDigestAnnotationKey: "bbb",
+				constants.ModelDigestAnnotationKey:        "bbb",
+			},
+			events: 1,
+		},
+		"FailedJob": {
+			annotations: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-01T02:00:00Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			objects: []client.Object{newTestJob(batchv1.JobFailed)},
+			requeue: 24*time.Hour - 30*time.Second,
+			expected: map[string]string{
+				constants.ModelRefreshTimeAnnotationKey:   "2022-10-02T02:00:30Z",
+				constants.ModelRefreshDigestAnnotationKey: "aaa",
+			},
+			events: 1,
+		},
+	}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := newTestInferenceService(scenario.annotations)
+			objects := append([]client.Object{isvc, newTestConfigMap()}, scenario.objects...)
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &ModelRefreshReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+				Log:      ctrl.Log.WithName("test"),
+				Scheme:   s,
+				Recorder: recorder,
+				Clock:    testclock.NewFakeClock(now),
+			}
+			name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.RequeueAfter).To(gomega.Equal(scenario.requeue))
+			g.Expect(recorder.Events).To(gomega.HaveLen(scenario.events))
+
+			updated := &v1beta1.InferenceService{}
+			g.Expect(reconciler.Get(context.TODO(), name, updated)).To(gomega.Succeed())
+			g.Expect(updated.Annotations).To(gomega.Equal(scenario.expected))
+
+			job := &batchv1.Job{}
+			err = reconciler.Get(context.TODO(), types.NamespacedName{Name: ModelRefreshJobName(isvc.Name),
+				Namespace: isvc.Namespace}, job)
+			if !scenario.jobCreated {
+				// The finished job is deleted once its digest is recorded
+				g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(job.Spec.Template.Annotations).To(gomega.Equal(map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey: "s3://models/embeddings",
+			}))
+			g.Expect(job.Spec.Template.Spec.Containers[0].Image).To(gomega.Equal("kserve/storage-initializer:latest"))
+			g.Expect(job.OwnerReferences).To(gomega.HaveLen(1))
+		})
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch
+package promote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	PromotedReason        = "Promoted"
+	PromotionFailedReason = "PromotionFailed"
+)
+
+// promotionAnnotations are the annotations of the source which are not copied to the promoted InferenceService, the
+// promotion requests and lineage and the state of the source
+var promotionAnnotations = []string{
+	constants.PromoteToAnnotationKey,
+	constants.PromoteMappingAnnotationKey,
+	constants.PromotedFromAnnotationKey,
+	constants.PromotedGenerationAnnotationKey,
+	constants.PromotedTimeAnnotationKey,
+	constants.PromotedToAnnotationKey,
+	constants.ModelRefreshDigestAnnotationKey,
+	constants.ModelRefreshTimeAnnotationKey,
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// PromoteMapping maps the names of the source namespace to the names of the target namespace. The storage uris are
+// mapped by prefix, the longest prefix matching the uri is replaced.
+type PromoteMapping struct {
+	ServiceAccounts map[string]string `json:"serviceAccounts,omitempty"`
+	Secrets         map[string]string `json:"secrets,omitempty"`
+	StorageKeys     map[string]string `json:"storageKeys,omitempty"`
+	StorageUris     map[string]string `json:"storageUris,omitempty"`
+	Runtimes        map[string]string `json:"runtimes,omitempty"`
+	RuntimeClasses  map[string]string `json:"runtimeClasses,omitempty"`
+}
+
+// PromoteReconciler copies the InferenceServices with the serving.kserve.io/promote-to annotation to the target
+// namespace, e.g. from staging to production, with the names of the serving.kserve.io/promote-mapping annotation.
+// The target namespace opts in with the serving.kserve.io/promote-source-namespaces annotation listing the namespaces
+// it accepts the promotions from. The promoted InferenceService records its source and the generation of the source
+// it was promoted from, it is updated by the next promotions of the same source and never overwritten by another one.
+// Once promoted, the promote-to annotation of the source is replaced by the promoted-to and promoted-time lineage.
+type PromoteReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Clock stamps the promoted-time lineage of the promoted InferenceServices, the tests use a fake clock
+	Clock clock.Clock
+}
+
+func (r *PromoteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	value, ok := isvc.Annotations[constants.PromoteToAnnotationKey]
+	if !ok || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	// The invalid requests are reported until the annotations are fixed, which reconciles the source again
+	target, err := promoteTarget(isvc, value)
+	if err != nil {
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, PromotionFailedReason, "Invalid promotion: %v", err)
+		return reconcile.Result{}, nil
+	}
+	mapping := &PromoteMapping{}
+	if value, ok := isvc.Annotations[constants.PromoteMappingAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(value), mapping); err != nil {
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, PromotionFailedReason, "Invalid %s annotation: %v",
+				constants.PromoteMappingAnnotationKey, err)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	namespace := &v1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: target.Namespace}, namespace); err != nil {
+		if apierr.IsNotFound(err) {
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, PromotionFailedReason, "Namespace %s not found",
+				target.Namespace)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !acceptsPromotions(namespace, isvc.Namespace) {
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, PromotionFailedReason,
+			"Namespace %s does not accept the promotions from namespace %s, see its %s annotation", target.Namespace,
+			isvc.Namespace, constants.PromoteSourceNamespacesAnnotationKey)
+		return reconcile.Result{}, nil
+	}
+
+	now := r.Clock.Now().UTC().Format(time.RFC3339)
+	source := isvc.Namespace + "/" + isvc.Name
+	promoted := PromotedInferenceService(isvc, target, mapping)
+	promoted.Annotations[constants.PromotedTimeAnnotationKey] = now
+	existing := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, target, existing); err != nil {
+		if !apierr.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		r.Log.Info("Promoting InferenceService", "InferenceService", req.NamespacedName, "target", target)
+		if err := r.Create(ctx, promoted); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else {
+		if existing.Annotations[constants.PromotedFromAnnotationKey] != source {
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, PromotionFailedReason,
+				"InferenceService %s already exists and was not promoted from %s", target, source)
+			return reconcile.Result{}, nil
+		}
+		r.Log.Info("Promoting InferenceService again", "InferenceService", req.NamespacedName, "target", target)
+		desired := existing.DeepCopy()
+		desired.Spec = promoted.Spec
+		if desired.Labels == nil {
+			desired.Labels = map[string]string{}
+		}
+		for key, value := range promoted.Labels {
+			desired.Labels[key] = value
+		}
+		if desired.Annotations == nil {
+			desired.Annotations = map[string]string{}
+		}
+		for key, value := range promoted.Annotations {
+			desired.Annotations[key] = value
+		}
+		if err := r.Update(ctx, desired); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	desired := isvc.DeepCopy()
+	delete(desired.Annotations, constants.PromoteToAnnotationKey)
+	desired.Annotations[constants.PromotedToAnnotationKey] = target.String()
+	desired.Annotations[constants.PromotedTimeAnnotationKey] = now
+	if err := r.Update(ctx, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.Recorder.Eventf(isvc, v1.EventTypeNormal, PromotedReason, "Promoted generation %d to %s", isvc.Generation,
+		target)
+	return reconcile.Result{}, nil
+}
+
+// promoteTarget returns the InferenceService the source is promoted to, the name of the source is kept when the
+// annotation only gives the namespace
+func promoteTarget(isvc *v1beta1.InferenceService, value string) (types.NamespacedName, error) {
+	target := types.NamespacedName{Namespace: value, Name: isvc.Name}
+	if namespace, name, ok := strings.Cut(value, "/"); ok {
+		target = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	if errs := validation.IsDNS1123Label(target.Namespace); len(errs) > 0 {
+		return target, fmt.Errorf("invalid namespace %q of the %s annotation: %s", target.Namespace,
+			constants.PromoteToAnnotationKey, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Label(target.Name); len(errs) > 0 {
+		return target, fmt.Errorf("invalid name %q of the %s annotation: %s", target.Name,
+			constants.PromoteToAnnotationKey, strings.Join(errs, ", "))
+	}
+	if target.Namespace == isvc.Namespace {
+		return target, fmt.Errorf("the target namespace is the namespace %s of the source", isvc.Namespace)
+	}
+	return target, nil
+}
+
+// acceptsPromotions returns whether the namespace lists the source namespace in its promote-source-namespaces
+func acceptsPromotions(namespace *v1.Namespace, source string) bool {
+	for _, accepted := range strings.Split(namespace.Annotations[constants.PromoteSourceNamespacesAnnotationKey], ",") {
+		if accepted = strings.TrimSpace(accepted); accepted == "*" || accepted == source {
+			return true
+		}
+	}
+	return false
+}
+
+// PromotedInferenceService returns the copy of the InferenceService in the target namespace with the names of the
+// mapping and the lineage annotations
+func PromotedInferenceService(isvc *v1beta1.InferenceService, target types.NamespacedName,
+	mapping *PromoteMapping) *v1beta1.InferenceService {
+	promoted := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        target.Name,
+			Namespace:   target.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *isvc.Spec.DeepCopy(),
+	}
+	for key, value := range isvc.Labels {
+		promoted.Labels[key] = value
+	}
+	for key, value := range isvc.Annotations {
+		promoted.Annotations[key] = value
+	}
+	for _, key := range promotionAnnotations {
+		delete(promoted.Annotations, key)
+	}
+	if secrets, ok := promoted.Annotations[constants.ServiceAccountSecretsAnnotationKey]; ok {
+		names := strings.Split(secrets, ",")
+		for i, name := range names {
+			names[i] = mapName(mapping.Secrets, strings.TrimSpace(name))
+		}
+		promoted.Annotations[constants.ServiceAccountSecretsAnnotationKey] = strings.Join(names, ",")
+	}
+	promoted.Annotations[constants.PromotedFromAnnotationKey] = isvc.Namespace + "/" + isvc.Name
+	promoted.Annotations[constants.PromotedGenerationAnnotationKey] = strconv.FormatInt(isvc.Generation, 10)
+
+	predictor := &promoted.Spec.Predictor
+	mapComponent(mapping, &predictor.PodSpec, predictor.GetPredictorImplementations())
+	if predictor.Model != nil && predictor.Model.Runtime != nil {
+		runtime := mapName(mapping.Runtimes, *predictor.Model.Runtime)
+		predictor.Model.Runtime = &runtime
+	}
+	if explainer := promoted.Spec.Explainer; explainer != nil {
+		mapComponent(mapping, &explainer.PodSpec, explainer.GetImplementations())
+	}
+	if transformer := promoted.Spec.Transformer; transformer != nil {
+		mapComponent(mapping, &transformer.PodSpec, transformer.GetImplementations())
+	}
+	return promoted
+}
+
+// mapComponent maps the names of the pod spec and the storage of the implementations of a component
+func mapComponent(mapping *PromoteMapping, podSpec *v1beta1.PodSpec, implementations []v1beta1.ComponentImplementation) {
+	if podSpec.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = mapName(mapping.ServiceAccounts, podSpec.ServiceAccountName)
+	}
+	if podSpec.RuntimeClassName != nil {
+		runtimeClass := mapName(mapping.RuntimeClasses, *podSpec.RuntimeClassName)
+		podSpec.RuntimeClassName = &runtimeClass
+	}
+	for i := range podSpec.ImagePullSecrets {
+		podSpec.ImagePullSecrets[i].Name = mapName(mapping.Secrets, podSpec.ImagePullSecrets[i].Name)
+	}
+	// The custom containers give their storage uri in the STORAGE_URI env
+	for i := range podSpec.Containers {
+		for j := range podSpec.Containers[i].Env {
+			if env := &podSpec.Containers[i].Env[j]; env.Name == constants.CustomSpecStorageUriEnvVarKey {
+				env.Value = mapStorageUri(mapping.StorageUris, env.Value)
+			}
+		}
+	}
+	for _, implementation := range implementations {
+		if uri := implementation.GetStorageUri(); uri != nil {
+			*uri = mapStorageUri(mapping.StorageUris, *uri)
+		}
+		if storage := implementation.GetStorageSpec(); storage != nil && storage.StorageKey != nil {
+			key := mapName(mapping.StorageKeys, *storage.StorageKey)
+			storage.StorageKey = &key
+		}
+	}
+}
+
+func mapName(names map[string]string, name string) string {
+	if mapped, ok := names[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+// mapStorageUri replaces the longest prefix of the uri found in the mapping
+func mapStorageUri(prefixes map[string]string, uri string) string {
+	keys := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		keys = append(keys, prefix)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, prefix := range keys {
+		if strings.HasPrefix(uri, prefix) {
+			return prefixes[prefix] + uri[len(prefix):]
+		}
+	}
+	return uri
+}
+
+func (r *PromoteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-promote").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testMapping = `{
+	"serviceAccounts": {"staging-sa": "prod-sa"},
+	"secrets": {"staging-s3": "prod-s3", "staging-registry": "prod-registry"},
+	"storageKeys": {"staging": "prod"},
+	"storageUris": {"s3://staging/": "s3://prod/", "s3://staging/models/shared/": "s3://shared/"},
+	"runtimes": {"kserve-sklearnserver": "prod-sklearnserver"},
+	"runtimeClasses": {"gvisor": "kata"}
+}`
+
+func newTestInferenceService(annotations map[string]string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "staging",
+			Generation:  3,
+			Labels:      map[string]string{"team": "fraud"},
+			Annotations: annotations,
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "sklearn"},
+					Runtime:     proto.String("kserve-sklearnserver"),
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: proto.String("s3://staging/models/fraud"),
+					},
+				},
+				PodSpec: v1beta1.PodSpec{
+					ServiceAccountName: "staging-sa",
+					RuntimeClassName:   proto.String("gvisor"),
+					ImagePullSecrets:   []v1.LocalObjectReference{{Name: "staging-registry"}},
+				},
+			},
+			Transformer: &v1beta1.TransformerSpec{
+				PodSpec: v1beta1.PodSpec{
+					ServiceAccountName: "other-sa",
+					Containers: []v1.Container{{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "fraud-transformer:latest",
+						Env: []v1.EnvVar{{Name: constants.CustomSpecStorageUriEnvVarKey,
+							Value: "s3://staging/models/shared/tokenizer"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func newTestNamespace(name string, sources string) *v1.Namespace {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if sources != "" {
+		namespace.Annotations = map[string]string{constants.PromoteSourceNamespacesAnnotationKey: sources}
+	}
+	return namespace
+}
+
+func TestPromoteReconcile(t *testing.T) {
+	now := time.Date(2023, 5, 2, 9, 30, 0, 0, time.UTC)
+	existing := func(source string) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn",
+				Namespace:   "prod",
+				Annotations: map[string]string{constants.PromotedFromAnnotationKey: source, "owner": "prod-team"},
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{
+					SKLearn: &v1beta1.SKLearnSpec{
+						PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{StorageURI: proto.String("s3://prod/old")},
+					},
+				},
+			},
+		}
+	}
+	scenarios := map[string]struct {
+		promoteTo string
+		sources   string
+		objects   []client.Object
+		promoted  bool
+		event     string
+	}{
+		"Promoted": {
+			promoteTo: "prod",
+			sources:   "staging, qa",
+			promoted:  true,
+			event:     PromotedReason,
+		},
+		"PromotedAgain": {
+			promoteTo: "prod/sklearn",
+			sources:   "*",
+			objects:   []client.Object{existing("staging/sklearn")},
+			promoted:  true,
+			event:     PromotedReason,
+		},
+		"NotAccepted": {
+			promoteTo: "prod",
+			sources:   "qa",
+			event:     PromotionFailedReason,
+		},
+		"NotPromotedFromSource": {
+			promoteTo: "prod",
+			sources:   "staging",
+			objects:   []client.Object{existing("qa/sklearn")},
+			event:     PromotionFailedReason,
+		},
+		"SameNamespace": {
+			promoteTo: "staging/sklearn-copy",
+			sources:   "staging",
+			event:     PromotionFailedReason,
+		},
+		"MissingNamespace": {
+			promoteTo: "production",
+			event:     PromotionFailedReason,
+		},
+	}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := newTestInferenceService(map[string]string{
+				constants.PromoteToAnnotationKey:             scenario.promoteTo,
+				constants.PromoteMappingAnnotationKey:        testMapping,
+				constants.ServiceAccountSecretsAnnotationKey: "staging-s3,registry",
+				constants.ModelRefreshDigestAnnotationKey:    "aaa",
+			})
+			objects := append([]client.Object{isvc, newTestNamespace("staging", ""),
+				newTestNamespace("prod", scenario.sources)}, scenario.objects...)
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &PromoteReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+				Log:      ctrl.Log.WithName("test"),
+				Recorder: recorder,
+				Clock:    testclock.NewFakeClock(now),
+			}
+			name := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(recorder.Events).To(gomega.HaveLen(1))
+			g.Expect(<-recorder.Events).To(gomega.ContainSubstring(scenario.event))
+
+			source := &v1beta1.InferenceService{}
+			g.Expect(reconciler.Get(context.TODO(), name, source)).To(gomega.Succeed())
+			promoted := &v1beta1.InferenceService{}
+			err = reconciler.Get(context.TODO(), types.NamespacedName{Name: "sklearn", Namespace: "prod"}, promoted)
+			if !scenario.promoted {
+				// The request is kept until it is fixed
+				g.Expect(source.Annotations[constants.PromoteToAnnotationKey]).To(gomega.Equal(scenario.promoteTo))
+				g.Expect(source.Annotations).ToNot(gomega.HaveKey(constants.PromotedToAnnotationKey))
+				if len(scenario.objects) == 0 {
+					g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+				} else {
+					g.Expect(promoted.Annotations[constants.PromotedFromAnnotationKey]).To(gomega.Equal("qa/sklearn"))
+				}
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+
+			g.Expect(source.Annotations).ToNot(gomega.HaveKey(constants.PromoteToAnnotationKey))
+			g.Expect(source.Annotations[constants.PromotedToAnnotationKey]).To(gomega.Equal("prod/sklearn"))
+			g.Expect(source.Annotations[constants.PromotedTimeAnnotationKey]).To(gomega.Equal("2023-05-02T09:30:00Z"))
+
+			g.Expect(promoted.Labels).To(gomega.Equal(map[string]string{"team": "fraud"}))
+			g.Expect(promoted.Annotations[constants.PromotedFromAnnotationKey]).To(gomega.Equal("staging/sklearn"))
+			g.Expect(promoted.Annotations[constants.PromotedGenerationAnnotationKey]).To(gomega.Equal("3"))
+			g.Expect(promoted.Annotations[constants.PromotedTimeAnnotationKey]).To(gomega.Equal("2023-05-02T09:30:00Z"))
+			g.Expect(promoted.Annotations[constants.ServiceAccountSecretsAnnotationKey]).To(gomega.Equal("prod-s3,registry"))
+			g.Expect(promoted.Annotations).ToNot(gomega.HaveKey(constants.PromoteToAnnotationKey))
+			g.Expect(promoted.Annotations).ToNot(gomega.HaveKey(constants.PromoteMappingAnnotationKey))
+			g.Expect(promoted.Annotations).ToNot(gomega.HaveKey(constants.ModelRefreshDigestAnnotationKey))
+			if len(scenario.objects) > 0 {
+				// The annotations of the target are kept
+				g.Expect(promoted.Annotations["owner"]).To(gomega.Equal("prod-team"))
+				g.Expect(promoted.Spec.Predictor.SKLearn).To(gomega.BeNil())
+			}
+
+			predictor := promoted.Spec.Predictor
+			g.Expect(*predictor.Model.StorageURI).To(gomega.Equal("s3://prod/models/fraud"))
+			g.Expect(*predictor.Model.Runtime).To(gomega.Equal("prod-sklearnserver"))
+			g.Expect(predictor.ServiceAccountName).To(gomega.Equal("prod-sa"))
+			g.Expect(*predictor.RuntimeClassName).To(gomega.Equal("kata"))
+			g.Expect(predictor.ImagePullSecrets).To(gomega.Equal([]v1.LocalObjectReference{{Name: "prod-registry"}}))
+			transformer := promoted.Spec.Transformer
+			g.Expect(transformer.ServiceAccountName).To(gomega.Equal("other-sa"))
+			// The longest prefix is replaced
+			g.Expect(transformer.Containers[0].Env[0].Value).To(gomega.Equal("s3://shared/tokenizer"))
+		})
+	}
+}
+
+func TestPromotedInferenceServiceStorageKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := newTestInferenceService(nil)
+	isvc.Spec.Predictor.Model.StorageURI = nil
+	isvc.Spec.Predictor.Model.Storage = &v1beta1.StorageSpec{StorageKey: proto.String("staging"),
+		Path: proto.String("models/fraud")}
+	promoted := PromotedInferenceService(isvc, types.NamespacedName{Name: "fraud", Namespace: "prod"},
+		&PromoteMapping{StorageKeys: map[string]string{"staging": "prod"}})
+	g.Expect(promoted.Name).To(gomega.Equal("fraud"))
+	g.Expect(*promoted.Spec.Predictor.Model.Storage.StorageKey).To(gomega.Equal("prod"))
+	g.Expect(*promoted.Spec.Predictor.Model.Storage.Path).To(gomega.Equal("models/fraud"))
+	// The names missing from the mapping are kept
+	g.Expect(promoted.Spec.Predictor.ServiceAccountName).To(gomega.Equal("staging-sa"))
+	// The source is not modified
+	g.Expect(*isvc.Spec.Predictor.Model.Storage.StorageKey).To(gomega.Equal("staging"))
+}
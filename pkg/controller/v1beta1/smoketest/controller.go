@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/tidwall/gjson"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	SmokeTestPassedReason = "SmokeTestPassed"
+	SmokeTestFailedReason = "SmokeTestFailed"
+	// RetryInterval is the interval a failed smoke test is run again at while its revision is the latest
+	RetryInterval = time.Minute
+
+	defaultExpectedStatus = http.StatusOK
+	defaultTimeout        = 30 * time.Second
+	// maxBodySize bounds the response body read by the smoke test
+	maxBodySize = 1 << 20
+)
+
+// SmokeTestReconciler runs the smoke test of the InferenceServices with a spec.smokeTest once a new revision of the
+// tested component is ready. The request is sent through the ingress gateway with the host of the InferenceService,
+// and with the canary header when the serving.kserve.io/canary-header annotation is set so that it reaches the latest
+// ready revision even while the canary receives no traffic. The result is set in the SmokeTestPassed condition, a
+// failed smoke test is run again every minute until it passes or a new revision is ready.
+type SmokeTestReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// Clock is the clock the retries of the failed smoke tests are spaced on, the tests use a fake clock
+	Clock clock.Clock
+
+	mu sync.Mutex
+	// tested is the time of the last smoke test of each InferenceService
+	tested map[types.NamespacedName]time.Time
+}
+
+func (r *SmokeTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			r.setTested(req.NamespacedName, time.Time{})
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if isvc.Spec.SmokeTest == nil || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.setTested(req.NamespacedName, time.Time{})
+		return reconcile.Result{}, nil
+	}
+	revision := v1beta1.SmokeTestRevision(isvc)
+	if revision == "" || isvc.Status.URL == nil || !isvc.Status.IsConditionReady(v1beta1.IngressReady) {
+		return reconcile.Result{}, nil
+	}
+	if isvc.Status.SmokeTestPassed(revision) {
+		return reconcile.Result{}, nil
+	}
+	now := r.Clock.Now()
+	if isvc.Status.SmokeTestedRevision == revision {
+		if last := r.lastTested(req.NamespacedName); now.Before(last.Add(RetryInterval)) {
+			return reconcile.Result{RequeueAfter: last.Add(RetryInterval).Sub(now)}, nil
+		}
+	}
+
+	ingressConfig, err := v1beta1.NewIngressConfig(r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	testErr := runSmokeTest(ctx, isvc, ingressConfig)
+	r.setTested(req.NamespacedName, now)
+
+	isvc.Status.SmokeTestedRevision = revision
+	if testErr != nil {
+		isvc.Status.SetCondition(v1beta1.SmokeTestPassed, &apis.Condition{
+			Status:  v1.ConditionFalse,
+			Reason:  SmokeTestFailedReason,
+			Message: fmt.Sprintf("The smoke test of the revision %s failed: %v", revision, testErr),
+		})
+	} else {
+		isvc.Status.SetCondition(v1beta1.SmokeTestPassed, &apis.Condition{Status: v1.ConditionTrue})
+	}
+	if err := r.Status().Update(ctx, isvc); err != nil {
+		return reconcile.Result{}, err
+	}
+	if testErr != nil {
+		r.Log.Info("Smoke test failed", "InferenceService", req.NamespacedName, "revision", revision,
+			"error", testErr.Error())
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, SmokeTestFailedReason, "The smoke test of the revision %s failed: %v",
+			revision, testErr)
+		return reconcile.Result{RequeueAfter: RetryInterval}, nil
+	}
+	r.Recorder.Eventf(isvc, v1.EventTypeNormal, SmokeTestPassedReason, "The smoke test of the revision %s passed",
+		revision)
+	return reconcile.Result{}, nil
+}
+
+// runSmokeTest sends the request of the smoke test through the ingress gateway and matches the response
+func runSmokeTest(ctx context.Context, isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) error {
+	smokeTest := isvc.Spec.SmokeTest
+	timeout := defaultTimeout
+	if smokeTest.TimeoutSeconds != nil {
+		timeout = time.Duration(*smokeTest.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path := smokeTest.Path
+	if path == "" {
+		path = constants.PredictPath(isvc.Name, constants.ProtocolV1)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+ingressConfig.IngressServiceName+path,
+		strings.NewReader(smokeTest.Payload))
+	if err != nil {
+		return err
+	}
+	req.Host = isvc.Status.URL.Host
+	req.Header.Set("Content-Type", "application/json")
+	if header, ok := isvc.Annotations[constants.CanaryHeaderAnnotationKey]; ok {
+		if name, value, ok := v1beta1.ParseCanaryHeader(header); ok {
+			req.Header.Set(name, value)
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+	return matchResponse(smokeTest, resp.StatusCode, body)
+}
+
+// matchResponse returns the reason the response does not match the expected response of the smoke test
+func matchResponse(smokeTest *v1beta1.SmokeTestSpec, status int, body []byte) error {
+	expectedStatus := defaultExpectedStatus
+	if smokeTest.ExpectedStatus != nil {
+		expectedStatus = *smokeTest.ExpectedStatus
+	}
+	if status != expectedStatus {
+		return fmt.Errorf("unexpected status %d, expected %d: %s", status, expectedStatus, bytes.TrimSpace(body))
+	}
+	matcher := smokeTest.ExpectedResponse
+	if matcher == nil {
+		return nil
+	}
+	if len(matcher.Conditions) > 0 && !gjson.ValidBytes(body) {
+		return fmt.Errorf("the response is not a JSON document")
+	}
+	for _, condition := range matcher.Conditions {
+		if !gjson.GetBytes(body, condition).Exists() {
+			return fmt.Errorf("the response does not match the condition %s", condition)
+		}
+	}
+	if matcher.Pattern != "" {
+		pattern, err := regexp.Compile(matcher.Pattern)
+		if err != nil {
+			return err
+		}
+		if !pattern.Match(body) {
+			return fmt.Errorf("the response does not match the pattern %s", matcher.Pattern)
+		}
+	}
+	return nil
+}
+
+func (r *SmokeTestReconciler) lastTested(name types.NamespacedName) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tested[name]
+}
+
+func (r *SmokeTestReconciler) setTested(name types.NamespacedName, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t.IsZero() {
+		delete(r.tested, name)
+		return
+	}
+	if r.tested == nil {
+		r.tested = map[types.NamespacedName]time.Time{}
+	}
+	r.tested[name] = t
+}
+
+func (r *SmokeTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-smoke-test").
+		For(&v1beta1.InferenceService{}).
+		Complete(r)
+}
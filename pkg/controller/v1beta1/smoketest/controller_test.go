@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoketest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInferenceService(smokeTest *v1beta1.SmokeTestSpec) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "default",
+			Annotations: map[string]string{constants.CanaryHeaderAnnotationKey: "X-Model-Revision: canary"},
+		},
+		Spec: v1beta1.InferenceServiceSpec{SmokeTest: smokeTest},
+		Status: v1beta1.InferenceServiceStatus{
+			URL: &apis.URL{Scheme: "http", Host: "sklearn.default.example.com"},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					LatestReadyRevision:     "sklearn-predictor-00002",
+					LatestRolledoutRevision: "sklearn-predictor-00001",
+				},
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{Status: v1.ConditionTrue})
+	return isvc
+}
+
+func newIngressConfigMap(ingressService string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			v1beta1.IngressConfigKeyName: fmt.Sprintf(`{"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": %q}`, ingressService),
+		},
+	}
+}
+
+func TestSmokeTestReconcile(t *testing.T) {
+	scenarios := map[string]struct {
+		smokeTest *v1beta1.SmokeTestSpec
+		status    v1.ConditionStatus
+		message   string
+		requeue   time.Duration
+	}{
+		"Passed": {
+			smokeTest: &v1beta1.SmokeTestSpec{
+				Payload: `{"instances": [[6.8, 2.8, 4.8, 1.4]]}`,
+				ExpectedResponse: &v1beta1.SmokeTestResponseMatcher{
+					Conditions: []string{"predictions.#(==1)"},
+					Pattern:    `"predictions": ?\[`,
+				},
+			},
+			status: v1.ConditionTrue,
+		},
+		"UnexpectedStatus": {
+			smokeTest: &v1beta1.SmokeTestSpec{Payload: `{"instances": []}`},
+			status:    v1.ConditionFalse,
+			message:   `The smoke test of the revision sklearn-predictor-00002 failed: unexpected status 400, expected 200: {"error": "empty instances"}`,
+			requeue:   RetryInterval,
+		},
+		"ConditionNotMatched": {
+			smokeTest: &v1beta1.SmokeTestSpec{
+				Payload:          `{"instances": [[6.8, 2.8, 4.8, 1.4]]}`,
+				ExpectedResponse: &v1beta1.SmokeTestResponseMatcher{Conditions: []string{"predictions.#(==2)"}},
+			},
+			status:  v1.ConditionFalse,
+			message: "The smoke test of the revision sklearn-predictor-00002 failed: the response does not match the condition predictions.#(==2)",
+			requeue: RetryInterval,
+		},
+	}
+
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req)
+		body, _ := io.ReadAll(req.Body)
+		if strings.Contains(string(body), "[]") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "empty instances"}`))
+			return
+		}
+		w.Write([]byte(`{"predictions": [1]}`))
+	}))
+	defer server.Close()
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			requests = nil
+			recorder := record.NewFakeRecorder(10)
+			fakeClock := testclock.NewFakeClock(time.Date(2023, 5, 2, 9, 30, 0, 0, time.UTC))
+			reconciler := &SmokeTestReconciler{
+				Client: fake.NewClientBuilder().WithScheme(s).WithObjects(newInferenceService(scenario.smokeTest),
+					newIngressConfigMap(strings.TrimPrefix(server.URL, "http://"))).Build(),
+				Log:      ctrl.Log.WithName("test"),
+				Recorder: recorder,
+				Clock:    fakeClock,
+			}
+			name := types.NamespacedName{Name: "sklearn", Namespace: "default"}
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(result.RequeueAfter).To(gomega.Equal(scenario.requeue))
+
+			// The request reaches the latest ready revision through the ingress gateway
+			g.Expect(requests).To(gomega.HaveLen(1))
+			g.Expect(requests[0].Host).To(gomega.Equal("sklearn.default.example.com"))
+			g.Expect(requests[0].URL.Path).To(gomega.Equal("/v1/models/sklearn:predict"))
+			g.Expect(requests[0].Header.Get("X-Model-Revision")).To(gomega.Equal("canary"))
+
+			isvc := &v1beta1.InferenceService{}
+			g.Expect(reconciler.Get(context.TODO(), name, isvc)).To(gomega.Succeed())
+			g.Expect(isvc.Status.SmokeTestedRevision).To(gomega.Equal("sklearn-predictor-00002"))
+			condition := isvc.Status.GetCondition(v1beta1.SmokeTestPassed)
+			g.Expect(condition.Status).To(gomega.Equal(scenario.status))
+			g.Expect(condition.Message).To(gomega.Equal(scenario.message))
+			// The smoke test does not change the readiness of the InferenceService
+			g.Expect(isvc.Status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionUnknown))
+			if scenario.status == v1.ConditionTrue {
+				g.Expect(<-recorder.Events).To(gomega.ContainSubstring(SmokeTestPassedReason))
+			} else {
+				g.Expect(<-recorder.Events).To(gomega.ContainSubstring(SmokeTestFailedReason))
+			}
+
+			// The revision is not tested again once passed, a failed smoke test is retried after the interval
+			fakeClock.Step(30 * time.Second)
+			result, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(requests).To(gomega.HaveLen(1))
+			if scenario.status == v1.ConditionFalse {
+				g.Expect(result.RequeueAfter).To(gomega.Equal(30 * time.Second))
+				fakeClock.Step(30 * time.Second)
+				_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+				g.Expect(err).To(gomega.BeNil())
+				g.Expect(requests).To(gomega.HaveLen(2))
+			}
+		})
+	}
+}
+
+func TestSmokeTestReconcileNotReady(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	isvc := newInferenceService(&v1beta1.SmokeTestSpec{Payload: `{}`})
+	isvc.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{}
+	reconciler := &SmokeTestReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build(),
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(10),
+	}
+	// The smoke test waits for a ready revision, the InferenceService is reconciled again once it is ready
+	name := types.NamespacedName{Name: "sklearn", Namespace: "default"}
+	result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.RequeueAfter).To(gomega.BeZero())
+	g.Expect(reconciler.Get(context.TODO(), name, isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.GetCondition(v1beta1.SmokeTestPassed)).To(gomega.BeNil())
+}
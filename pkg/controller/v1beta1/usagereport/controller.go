@@ -0,0 +1,349 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update
+package usagereport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/apikey"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/tenant"
+	"github.com/prometheus/common/expfmt"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// SnapshotKey is the ConfigMap entry holding the counters of the agents at the end of the last period
+	SnapshotKey = "snapshot.json"
+	// reportPrefix prefixes the ConfigMap entries of the reports, followed by the end of the period
+	reportPrefix = "report-"
+	// reportTimeFormat is the format of the end of the period in the report entries, ConfigMap keys can't hold colons
+	reportTimeFormat = "20060102T150405Z"
+	// maxReports is the number of periods kept in the ConfigMap, the oldest reports are removed
+	maxReports    = 24
+	scrapeTimeout = 10 * time.Second
+
+	APIKeyUsage = "apiKey"
+	TenantUsage = "tenant"
+)
+
+// UsageReportReconciler aggregates the requests per api key and per tenant counted by the agents of the
+// InferenceServices annotated with serving.kserve.io/usage-report-interval. Every interval the agent metrics of the
+// InferenceService pods are scraped and the requests since the previous scrape are written as JSON and CSV report into
+// the <name>-usage-report ConfigMap. The requests of pods deleted since the previous scrape are not reported.
+type UsageReportReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Clock stamps the usage snapshots and schedules the next scrape, the tests use a fake clock
+	Clock clock.Clock
+	// Scrape returns the agent metrics of the pod in the prometheus text format, defaults to scrapeAgent
+	Scrape func(ctx context.Context, pod *v1.Pod) ([]byte, error)
+}
+
+// Snapshot is the cumulative count of each usage series per pod at the end of the last period
+type Snapshot struct {
+	Time time.Time                     `json:"time"`
+	Pods map[string]map[string]float64 `json:"pods"`
+}
+
+// Report is the usage of an InferenceService during a period
+type Report struct {
+	InferenceService string    `json:"inferenceService"`
+	Namespace        string    `json:"namespace"`
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	Usage            []Usage   `json:"usage"`
+}
+
+// Usage is the number of requests of an api key or a tenant
+type Usage struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Requests int64  `json:"requests"`
+}
+
+func UsageReportConfigMapName(isvcName string) string {
+	return isvcName + "-usage-report"
+}
+
+func (r *UsageReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, req.NamespacedName, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			// The ConfigMap is garbage collected through its owner reference
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	value, ok := isvc.Annotations[constants.UsageReportIntervalAnnotationKey]
+	if !ok || !isvc.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		// The interval is validated on admission, the InferenceServices annotated before are not reported
+		r.Log.Info("Ignoring invalid usage report interval annotation", "InferenceService", req.NamespacedName, "interval", value)
+		return reconcile.Result{}, nil
+	}
+
+	configMap := &v1.ConfigMap{}
+	configMapName := types.NamespacedName{Name: UsageReportConfigMapName(isvc.Name), Namespace: isvc.Namespace}
+	if err := r.Get(ctx, configMapName, configMap); err != nil {
+		if !apierr.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		configMap = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace}}
+	}
+	var previous *Snapshot
+	if data, ok := configMap.Data[SnapshotKey]; ok {
+		previous = &Snapshot{}
+		if err := json.Unmarshal([]byte(data), previous); err != nil {
+			r.Log.Error(err, "Discarding invalid usage snapshot", "ConfigMap", configMapName)
+			previous = nil
+		}
+	}
+	now := r.Clock.Now()
+	if previous != nil && now.Before(previous.Time.Add(interval)) {
+		return reconcile.Result{RequeueAfter: previous.Time.Add(interval).Sub(now)}, nil
+	}
+
+	current, err := r.scrapePods(ctx, isvc, previous)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	current.Time = now
+	desired := configMap.DeepCopy()
+	if desired.Data == nil {
+		desired.Data = map[string]string{}
+	}
+	snapshot, err := json.Marshal(current)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	desired.Data[SnapshotKey] = string(snapshot)
+	// The first scrape is the baseline of the first period
+	if previous != nil {
+		report := newReport(isvc, previous, current)
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		name := reportPrefix + now.UTC().Format(reportTimeFormat)
+		desired.Data[name+".json"] = string(data)
+		desired.Data[name+".csv"] = reportCSV(report)
+		pruneReports(desired.Data)
+	}
+	if err := controllerutil.SetControllerReference(isvc, desired, r.Scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if desired.ResourceVersion == "" {
+		r.Log.Info("Creating usage report", "ConfigMap", configMapName)
+		err = r.Create(ctx, desired)
+	} else {
+		r.Log.Info("Updating usage report", "ConfigMap", configMapName)
+		err = r.Update(ctx, desired)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// scrapePods returns the usage counters of the running pods of the InferenceService, the previous counters of the
+// pods which could not be scraped are kept so their requests are reported with the next period.
+func (r *UsageReportReconciler) scrapePods(ctx context.Context, isvc *v1beta1.InferenceService, previous *Snapshot) (*Snapshot, error) {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServiceLabel: isvc.Name}); err != nil {
+		return nil, err
+	}
+	scrape := r.Scrape
+	if scrape == nil {
+		scrape = scrapeAgent
+	}
+	snapshot := &Snapshot{Pods: map[string]map[string]float64{}}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != v1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		metrics, err := scrape(ctx, pod)
+		if err == nil {
+			snapshot.Pods[pod.Name], err = parseUsage(metrics)
+		}
+		if err != nil {
+			r.Log.Error(err, "Failed to scrape agent metrics", "pod", pod.Name, "namespace", pod.Namespace)
+			if previous != nil {
+				if counters, ok := previous.Pods[pod.Name]; ok {
+					snapshot.Pods[pod.Name] = counters
+				}
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func scrapeAgent(ctx context.Context, pod *v1.Pod) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, constants.AgentMetricsPortStr) + constants.DefaultPrometheusPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d scraping %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseUsage returns the accepted requests per api key and the requests per tenant, indexed by <type>/<id>
+func parseUsage(metrics []byte) (map[string]float64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, err
+	}
+	usage := map[string]float64{}
+	if family, ok := families[apikey.RequestsMetricName]; ok {
+		for _, metric := range family.Metric {
+			labels := map[string]string{}
+			for _, label := range metric.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["result"] == apikey.ResultAccepted {
+				usage[APIKeyUsage+"/"+labels["key"]] += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	if family, ok := families[tenant.RequestsMetricName]; ok {
+		for _, metric := range family.Metric {
+			for _, label := range metric.Label {
+				if label.GetName() == "tenant" {
+					usage[TenantUsage+"/"+label.GetValue()] += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return usage, nil
+}
+
+// newReport sums the requests of each usage series since the previous snapshot over the pods, a counter lower than
+// in the previous snapshot was reset by a restart of the agent.
+func newReport(isvc *v1beta1.InferenceService, previous *Snapshot, current *Snapshot) *Report {
+	totals := map[string]float64{}
+	for pod, counters := range current.Pods {
+		for series, value := range counters {
+			delta := value - previous.Pods[pod][series]
+			if delta < 0 {
+				delta = value
+			}
+			totals[series] += delta
+		}
+	}
+	report := &Report{
+		InferenceService: isvc.Name,
+		Namespace:        isvc.Namespace,
+		Start:            previous.Time,
+		End:              current.Time,
+		Usage:            []Usage{},
+	}
+	for series, total := range totals {
+		if total == 0 {
+			continue
+		}
+		parts := strings.SplitN(series, "/", 2)
+		report.Usage = append(report.Usage, Usage{Type: parts[0], ID: parts[1], Requests: int64(total)})
+	}
+	sort.Slice(report.Usage, func(i, j int) bool {
+		if report.Usage[i].Type != report.Usage[j].Type {
+			return report.Usage[i].Type < report.Usage[j].Type
+		}
+		return report.Usage[i].ID < report.Usage[j].ID
+	})
+	return report
+}
+
+func reportCSV(report *Report) string {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Write([]string{"inferenceService", "namespace", "start", "end", "type", "id", "requests"})
+	for _, usage := range report.Usage {
+		writer.Write([]string{report.InferenceService, report.Namespace, report.Start.UTC().Format(time.RFC3339),
+			report.End.UTC().Format(time.RFC3339), usage.Type, usage.ID, strconv.FormatInt(usage.Requests, 10)})
+	}
+	writer.Flush()
+	return buf.String()
+}
+
+// pruneReports removes the reports of the oldest periods above maxReports
+func pruneReports(data map[string]string) {
+	var periods []string
+	for key := range data {
+		if strings.HasPrefix(key, reportPrefix) && strings.HasSuffix(key, ".json") {
+			periods = append(periods, strings.TrimSuffix(key, ".json"))
+		}
+	}
+	if len(periods) <= maxReports {
+		return
+	}
+	sort.Strings(periods)
+	for _, period := range periods[:len(periods)-maxReports] {
+		delete(data, period+".json")
+		delete(data, period+".csv")
+	}
+}
+
+func (r *UsageReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("inferenceservice-usage-report").
+		For(&v1beta1.InferenceService{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usagereport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func agentMetrics(teamA int, teamB int, tenant int) []byte {
+	return []byte(fmt.Sprintf(`# HELP kserve_agent_api_key_requests_total Number of requests per API key and result
+# TYPE kserve_agent_api_key_requests_total counter
+kserve_agent_api_key_requests_total{key="",result="invalid"} 7
+kserve_agent_api_key_requests_total{key="team-a",result="accepted"} %d
+kserve_agent_api_key_requests_total{key="team-a",result="rate_limited"} 3
+kserve_agent_api_key_requests_total{key="team-b",result="accepted"} %d
+# HELP kserve_agent_tenant_requests_total Number of requests per tenant
+# TYPE kserve_agent_tenant_requests_total counter
+kserve_agent_tenant_requests_total{tenant="acme"} %d
+`, teamA, teamB, tenant))
+}
+
+func newPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{constants.InferenceServiceLabel: "sklearn"},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.0.0.1"},
+	}
+}
+
+func TestUsageReportReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	v1beta1.AddToScheme(s)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "default",
+			UID:         "sklearn-uid",
+			Annotations: map[string]string{constants.UsageReportIntervalAnnotationKey: "1h"},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(isvc, newPod("sklearn-1"), newPod("sklearn-2")).Build()
+
+	fakeClock := testclock.NewFakeClock(start)
+	metrics := map[string][]byte{
+		"sklearn-1": agentMetrics(10, 0, 4),
+		"sklearn-2": agentMetrics(5, 2, 0),
+	}
+	reconciler := &UsageReportReconciler{
+		Client: cli,
+		Log:    ctrl.Log.WithName("test"),
+		Scheme: s,
+		Clock:  fakeClock,
+		Scrape: func(ctx context.Context, pod *v1.Pod) ([]byte, error) {
+			if data, ok := metrics[pod.Name]; ok {
+				return data, nil
+			}
+			return nil, fmt.Errorf("connection refused")
+		},
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sklearn", Namespace: "default"}}
+	configMapName := types.NamespacedName{Name: UsageReportConfigMapName("sklearn"), Namespace: "default"}
+
+	// The first scrape is the baseline
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(time.Hour))
+	configMap := &v1.ConfigMap{}
+	g.Expect(cli.Get(context.TODO(), configMapName, configMap)).To(gomega.Succeed())
+	g.Expect(configMap.Data).To(gomega.HaveLen(1))
+	g.Expect(metav1.IsControlledBy(configMap, isvc)).To(gomega.BeTrue())
+
+	// Not due yet
+	fakeClock.SetTime(start.Add(30 * time.Minute))
+	result, err = reconciler.Reconcile(context.TODO(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(30 * time.Minute))
+
+	// The second pod restarted its agent and the third pod can't be scraped
+	g.Expect(cli.Create(context.TODO(), newPod("sklearn-3"))).To(gomega.Succeed())
+	metrics["sklearn-1"] = agentMetrics(25, 1, 9)
+	metrics["sklearn-2"] = agentMetrics(4, 0, 0)
+	fakeClock.SetTime(start.Add(time.Hour))
+	_, err = reconciler.Reconcile(context.TODO(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(cli.Get(context.TODO(), configMapName, configMap)).To(gomega.Succeed())
+
+	report := &Report{}
+	g.Expect(json.Unmarshal([]byte(configMap.Data["report-20230101T010000Z.json"]), report)).To(gomega.Succeed())
+	g.Expect(report.Start).To(gomega.Equal(start))
+	g.Expect(report.End).To(gomega.Equal(start.Add(time.Hour)))
+	g.Expect(report.Usage).To(gomega.Equal([]Usage{
+		{Type: APIKeyUsage, ID: "team-a", Requests: 19},
+		{Type: APIKeyUsage, ID: "team-b", Requests: 1},
+		{Type: TenantUsage, ID: "acme", Requests: 5},
+	}))
+	g.Expect(configMap.Data["report-20230101T010000Z.csv"]).To(gomega.Equal(
+		"inferenceService,namespace,start,end,type,id,requests\n" +
+			"sklearn,default,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,apiKey,team-a,19\n" +
+			"sklearn,default,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,apiKey,team-b,1\n" +
+			"sklearn,default,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,tenant,acme,5\n"))
+}
+
+func TestPruneReports(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	data := map[string]string{SnapshotKey: "{}"}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxReports+2; i++ {
+		name := reportPrefix + start.Add(time.Duration(i)*time.Hour).Format(reportTimeFormat)
+		data[name+".json"] = "{}"
+		data[name+".csv"] = ""
+	}
+	pruneReports(data)
+	g.Expect(data).To(gomega.HaveLen(2*maxReports + 1))
+	g.Expect(data).NotTo(gomega.HaveKey("report-20230101T000000Z.json"))
+	g.Expect(data).NotTo(gomega.HaveKey("report-20230101T010000Z.csv"))
+	g.Expect(data).To(gomega.HaveKey("report-20230101T020000Z.json"))
+}
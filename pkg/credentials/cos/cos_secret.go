@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+)
+
+/*
+The IBM Cloud Object Storage secret holds either the HMAC credentials of a service credential, the objects are then
+read with AWS signature V4 requests, or the API key of a service ID, the objects are then read with the IAM bearer
+token requested for the key:
+https://cloud.ibm.com/docs/cloud-object-storage?topic=cloud-object-storage-service-credentials
+*/
+const (
+	COSHMACAccessKeyId     = "COS_HMAC_ACCESS_KEY_ID"
+	COSHMACSecretAccessKey = "COS_HMAC_SECRET_ACCESS_KEY"
+	COSAPIKey              = "COS_API_KEY"
+	COSRegion              = "COS_REGION"
+	COSEndpoint            = "COS_ENDPOINT"
+)
+
+var (
+	// InferenceServiceCOSSecretRegionAnnotation is the region of the buckets, e.g. us-south
+	InferenceServiceCOSSecretRegionAnnotation = constants.KServeAPIGroupName + "/" + "cos-region"
+	// InferenceServiceCOSSecretEndpointAnnotation overrides the public endpoint of the region, e.g. with the private
+	// endpoint https://s3.private.us-south.cloud-object-storage.appdomain.cloud
+	InferenceServiceCOSSecretEndpointAnnotation = constants.KServeAPIGroupName + "/" + "cos-endpoint"
+)
+
+func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{}
+	for _, key := range []string{COSHMACAccessKeyId, COSHMACSecretAccessKey, COSAPIKey} {
+		if _, ok := secret.Data[key]; !ok {
+			continue
+		}
+		envs = append(envs, v1.EnvVar{
+			Name: key,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: key,
+				},
+			},
+		})
+	}
+	if region, ok := secret.Annotations[InferenceServiceCOSSecretRegionAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: COSRegion, Value: region})
+	}
+	if endpoint, ok := secret.Annotations[InferenceServiceCOSSecretEndpointAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: COSEndpoint, Value: endpoint})
+	}
+	return envs
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func secretKeyEnv(name string, key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: key,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: name,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+func TestCOSSecret(t *testing.T) {
+	scenarios := map[string]struct {
+		secret   *v1.Secret
+		expected []v1.EnvVar
+	}{
+		"COSHMACSecretEnvs": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cos-hmac",
+					Annotations: map[string]string{
+						InferenceServiceCOSSecretRegionAnnotation: "us-south",
+					},
+				},
+				Data: map[string][]byte{
+					COSHMACAccessKeyId:     []byte("key"),
+					COSHMACSecretAccessKey: []byte("secret"),
+				},
+			},
+			expected: []v1.EnvVar{
+				secretKeyEnv("cos-hmac", COSHMACAccessKeyId),
+				secretKeyEnv("cos-hmac", COSHMACSecretAccessKey),
+				{Name: COSRegion, Value: "us-south"},
+			},
+		},
+		"COSIAMSecretEnvs": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cos-iam",
+					Annotations: map[string]string{
+						InferenceServiceCOSSecretEndpointAnnotation: "https://s3.private.us-south.cloud-object-storage.appdomain.cloud",
+					},
+				},
+				Data: map[string][]byte{
+					COSAPIKey: []byte("apikey"),
+				},
+			},
+			expected: []v1.EnvVar{
+				secretKeyEnv("cos-iam", COSAPIKey),
+				{Name: COSEndpoint, Value: "https://s3.private.us-south.cloud-object-storage.appdomain.cloud"},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		envs := BuildSecretEnvs(scenario.secret)
+
+		if diff := cmp.Diff(scenario.expected, envs); diff != "" {
+			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+		}
+	}
+}
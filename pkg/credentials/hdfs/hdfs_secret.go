@@ -25,11 +25,15 @@ const (
 	HdfsRootPath      = "HDFS_ROOTPATH"
 	KerberosPrincipal = "KERBEROS_PRINCIPAL"
 	KerberosKeytab    = "KERBEROS_KEYTAB"
-	TlsCert           = "TLS_CERT"
-	TlsKey            = "TLS_KEY"
-	TlsCa             = "TLS_CA"
-	MountPath         = "/var/secrets/kserve-hdfscreds"
-	HdfsVolumeName    = "hdfs-secrets"
+	// Krb5Conf is the krb5.conf of the realm of the principal, the default configuration of the image is used without it
+	Krb5Conf = "KRB5_CONF"
+	// KerberosRenewSeconds is the interval the ticket is acquired again from the keytab while the model is downloaded
+	KerberosRenewSeconds = "KERBEROS_RENEW_SECONDS"
+	TlsCert              = "TLS_CERT"
+	TlsKey               = "TLS_KEY"
+	TlsCa                = "TLS_CA"
+	MountPath            = "/var/secrets/kserve-hdfscreds"
+	HdfsVolumeName       = "hdfs-secrets"
 )
 
 func BuildSecret(secret *v1.Secret) (v1.Volume, v1.VolumeMount) {
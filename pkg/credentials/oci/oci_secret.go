@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// MountPath is the directory of the image pull secrets, mounted in a subdirectory per secret
+	MountPath        = "/var/secrets/kserve-oci"
+	VolumeNamePrefix = "oci-registry-secret-"
+)
+
+// BuildSecretVolumes mounts the image pull secrets so the storage initializer reads the registry credentials of their
+// .dockerconfigjson or .dockercfg keys. The secrets are optional like the image pull secrets, the missing ones do not
+// block the pod.
+func BuildSecretVolumes(secrets []v1.LocalObjectReference) ([]v1.Volume, []v1.VolumeMount) {
+	volumes := []v1.Volume{}
+	volumeMounts := []v1.VolumeMount{}
+	optional := true
+	for i, secret := range secrets {
+		if secret.Name == "" {
+			continue
+		}
+		// The secret names may be longer than the volume names
+		name := fmt.Sprintf("%s%d", VolumeNamePrefix, i)
+		volumes = append(volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Optional:   &optional,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: MountPath + "/" + secret.Name,
+			ReadOnly:  true,
+		})
+	}
+	return volumes, volumeMounts
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestOCISecretVolumes(t *testing.T) {
+	optional := true
+	scenarios := map[string]struct {
+		secrets              []v1.LocalObjectReference
+		expectedVolumes      []v1.Volume
+		expectedVolumeMounts []v1.VolumeMount
+	}{
+		"ImagePullSecrets": {
+			secrets: []v1.LocalObjectReference{{Name: "regcred"}, {Name: ""}, {Name: "ghcr"}},
+			expectedVolumes: []v1.Volume{
+				{
+					Name: "oci-registry-secret-0",
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{
+							SecretName: "regcred",
+							Optional:   &optional,
+						},
+					},
+				},
+				{
+					Name: "oci-registry-secret-2",
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{
+							SecretName: "ghcr",
+							Optional:   &optional,
+						},
+					},
+				},
+			},
+			expectedVolumeMounts: []v1.VolumeMount{
+				{
+					Name:      "oci-registry-secret-0",
+					MountPath: "/var/secrets/kserve-oci/regcred",
+					ReadOnly:  true,
+				},
+				{
+					Name:      "oci-registry-secret-2",
+					MountPath: "/var/secrets/kserve-oci/ghcr",
+					ReadOnly:  true,
+				},
+			},
+		},
+		"NoImagePullSecrets": {
+			expectedVolumes:      []v1.Volume{},
+			expectedVolumeMounts: []v1.VolumeMount{},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		volumes, volumeMounts := BuildSecretVolumes(scenario.secrets)
+
+		if diff := cmp.Diff(scenario.expectedVolumes, volumes); diff != "" {
+			t.Errorf("Test %q unexpected volumes (-want +got): %v", name, diff)
+		}
+		if diff := cmp.Diff(scenario.expectedVolumeMounts, volumeMounts); diff != "" {
+			t.Errorf("Test %q unexpected volume mounts (-want +got): %v", name, diff)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oss
+
+import (
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+)
+
+/*
+The Alibaba Cloud OSS secret holds the AccessKey pair of a RAM user, or the temporary STS credentials with their
+security token. The environment variables are the ones of the OSS SDKs and ossutil:
+https://www.alibabacloud.com/help/en/oss/developer-reference/oss-python-configure-access-credentials
+*/
+const (
+	OSSAccessKeyId     = "OSS_ACCESS_KEY_ID"
+	OSSAccessKeySecret = "OSS_ACCESS_KEY_SECRET"
+	OSSSessionToken    = "OSS_SESSION_TOKEN"
+	OSSRegion          = "OSS_REGION"
+	OSSEndpoint        = "OSS_ENDPOINT"
+)
+
+var (
+	// InferenceServiceOSSSecretRegionAnnotation is the region of the buckets, e.g. cn-hangzhou
+	InferenceServiceOSSSecretRegionAnnotation = constants.KServeAPIGroupName + "/" + "oss-region"
+	// InferenceServiceOSSSecretEndpointAnnotation overrides the public endpoint of the region, e.g. with the internal
+	// endpoint https://oss-cn-hangzhou-internal.aliyuncs.com of the clusters running in Alibaba Cloud
+	InferenceServiceOSSSecretEndpointAnnotation = constants.KServeAPIGroupName + "/" + "oss-endpoint"
+)
+
+func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{}
+	for _, key := range []string{OSSAccessKeyId, OSSAccessKeySecret, OSSSessionToken} {
+		if _, ok := secret.Data[key]; !ok {
+			continue
+		}
+		envs = append(envs, v1.EnvVar{
+			Name: key,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: key,
+				},
+			},
+		})
+	}
+	if region, ok := secret.Annotations[InferenceServiceOSSSecretRegionAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: OSSRegion, Value: region})
+	}
+	if endpoint, ok := secret.Annotations[InferenceServiceOSSSecretEndpointAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: OSSEndpoint, Value: endpoint})
+	}
+	return envs
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package r2
+
+import (
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+)
+
+/*
+The Cloudflare R2 secret holds the access key of an R2 API token:
+https://developers.cloudflare.com/r2/api/s3/tokens/
+*/
+const (
+	R2AccessKeyId     = "R2_ACCESS_KEY_ID"
+	R2SecretAccessKey = "R2_SECRET_ACCESS_KEY"
+	R2AccountId       = "R2_ACCOUNT_ID"
+	R2Jurisdiction    = "R2_JURISDICTION"
+)
+
+var (
+	// InferenceServiceR2SecretAccountIdAnnotation is the id of the Cloudflare account of the buckets
+	InferenceServiceR2SecretAccountIdAnnotation = constants.KServeAPIGroupName + "/" + "r2-account-id"
+	// InferenceServiceR2SecretJurisdictionAnnotation is the jurisdiction of the buckets created with one, e.g. eu
+	InferenceServiceR2SecretJurisdictionAnnotation = constants.KServeAPIGroupName + "/" + "r2-jurisdiction"
+)
+
+func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{}
+	for _, key := range []string{R2AccessKeyId, R2SecretAccessKey} {
+		envs = append(envs, v1.EnvVar{
+			Name: key,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: key,
+				},
+			},
+		})
+	}
+	if accountId, ok := secret.Annotations[InferenceServiceR2SecretAccountIdAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: R2AccountId, Value: accountId})
+	}
+	if jurisdiction, ok := secret.Annotations[InferenceServiceR2SecretJurisdictionAnnotation]; ok {
+		envs = append(envs, v1.EnvVar{Name: R2Jurisdiction, Value: jurisdiction})
+	}
+	return envs
+}
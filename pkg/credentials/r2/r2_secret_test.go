@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package r2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestR2Secret(t *testing.T) {
+	scenarios := map[string]struct {
+		secret   *v1.Secret
+		expected []v1.EnvVar
+	}{
+		"R2SecretEnvs": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "r2-secret",
+					Annotations: map[string]string{
+						InferenceServiceR2SecretAccountIdAnnotation:    "account",
+						InferenceServiceR2SecretJurisdictionAnnotation: "eu",
+					},
+				},
+				Data: map[string][]byte{
+					R2AccessKeyId:     []byte("key"),
+					R2SecretAccessKey: []byte("secret"),
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name: R2AccessKeyId,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "r2-secret",
+							},
+							Key: R2AccessKeyId,
+						},
+					},
+				},
+				{
+					Name: R2SecretAccessKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "r2-secret",
+							},
+							Key: R2SecretAccessKey,
+						},
+					},
+				},
+				{Name: R2AccountId, Value: "account"},
+				{Name: R2Jurisdiction, Value: "eu"},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		envs := BuildSecretEnvs(scenario.secret)
+
+		if diff := cmp.Diff(scenario.expected, envs); diff != "" {
+			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+		}
+	}
+}
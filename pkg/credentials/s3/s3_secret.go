@@ -39,6 +39,14 @@ const (
 	S3UseVirtualBucket     = "S3_USER_VIRTUAL_BUCKET"
 	AWSAnonymousCredential = "awsAnonymousCredential"
 	AWSCABundle            = "AWS_CA_BUNDLE"
+	// AWSSSECustomerKey is the base64 encoded 256-bit key of the objects encrypted with SSE-C, read from the
+	// AWSSSECustomerKeyName key of the secret
+	AWSSSECustomerKey     = "AWS_SSE_CUSTOMER_KEY"
+	AWSSSECustomerKeyName = "awsSSECustomerKey"
+	// The CA bundle of the s3-cabundle-configmap ConfigMap is mounted from its S3CABundleConfigMapKey key
+	S3CABundleConfigMapKey = "cabundle.crt"
+	S3CABundleVolumeName   = "s3-cabundle"
+	S3CABundleMountPath    = "/etc/ssl/custom-certs/s3"
 )
 
 type S3Config struct {
@@ -51,6 +59,7 @@ type S3Config struct {
 	S3UseVirtualBucket       string `json:"s3UseVirtualBucket,omitempty"`
 	S3UseAnonymousCredential string `json:"s3UseAnonymousCredential,omitempty"`
 	S3CABundle               string `json:"s3CABundle,omitempty"`
+	S3CABundleConfigMap      string `json:"s3CABundleConfigMap,omitempty"`
 }
 
 var (
@@ -61,6 +70,9 @@ var (
 	InferenceServiceS3UseVirtualBucketAnnotation = constants.KServeAPIGroupName + "/" + "s3-usevirtualbucket"
 	InferenceServiceS3UseAnonymousCredential     = constants.KServeAPIGroupName + "/" + "s3-useanoncredential"
 	InferenceServiceS3CABundleAnnotation         = constants.KServeAPIGroupName + "/" + "s3-cabundle"
+	// InferenceServiceS3CABundleConfigMapAnnotation names the ConfigMap of the namespace holding the CA bundle of a
+	// S3 compatible store with a private certificate authority, such as an on-prem Minio or Ceph
+	InferenceServiceS3CABundleConfigMapAnnotation = constants.KServeAPIGroupName + "/" + "s3-cabundle-configmap"
 )
 
 func BuildSecretEnvs(secret *v1.Secret, s3Config *S3Config) []v1.EnvVar {
@@ -98,6 +110,21 @@ func BuildSecretEnvs(secret *v1.Secret, s3Config *S3Config) []v1.EnvVar {
 		},
 	}
 
+	// The objects encrypted with a customer provided key are read with the key of the secret
+	if _, ok := secret.Data[AWSSSECustomerKeyName]; ok {
+		envs = append(envs, v1.EnvVar{
+			Name: AWSSSECustomerKey,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: AWSSSECustomerKeyName,
+				},
+			},
+		})
+	}
+
 	envs = append(envs, BuildS3EnvVars(secret.Annotations, s3Config)...)
 
 	return envs
@@ -174,6 +174,52 @@ func TestS3Secret(t *testing.T) {
 			},
 		},
 
+		"S3SecretEnvsWithSSECustomerKey": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "s3-secret",
+				},
+				Data: map[string][]byte{
+					AWSSSECustomerKeyName: []byte("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="),
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name: AWSAccessKeyId,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "s3-secret",
+							},
+							Key: AWSAccessKeyIdName,
+						},
+					},
+				},
+				{
+					Name: AWSSecretAccessKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "s3-secret",
+							},
+							Key: AWSSecretAccessKeyName,
+						},
+					},
+				},
+				{
+					Name: AWSSSECustomerKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "s3-secret",
+							},
+							Key: AWSSSECustomerKeyName,
+						},
+					},
+				},
+			},
+		},
+
 		"S3Config": {
 			config: S3Config{
 				S3AccessKeyIDName:     "test-keyId",
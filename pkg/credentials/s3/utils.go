@@ -110,6 +110,10 @@ func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvV
 	if !ok {
 		customCABundle = s3Config.S3CABundle
 	}
+	// The CA bundle of the ConfigMap is mounted by BuildCABundleVolume
+	if _, ok := caBundleConfigMap(annotations, s3Config); ok {
+		customCABundle = S3CABundleMountPath + "/" + S3CABundleConfigMapKey
+	}
 	if customCABundle != "" {
 		envs = append(envs, v1.EnvVar{
 			Name:  AWSCABundle,
@@ -119,3 +123,36 @@ func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvV
 
 	return envs
 }
+
+// BuildCABundleVolume returns the volume and the mount of the ConfigMap holding the CA bundle the certificate of the
+// S3 endpoint is verified with, it returns false when neither the annotations nor the s3 config set a ConfigMap
+func BuildCABundleVolume(annotations map[string]string, s3Config *S3Config) (v1.Volume, v1.VolumeMount, bool) {
+	name, ok := caBundleConfigMap(annotations, s3Config)
+	if !ok {
+		return v1.Volume{}, v1.VolumeMount{}, false
+	}
+	volume := v1.Volume{
+		Name: S3CABundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Items:                []v1.KeyToPath{{Key: S3CABundleConfigMapKey, Path: S3CABundleConfigMapKey}},
+			},
+		},
+	}
+	volumeMount := v1.VolumeMount{
+		Name:      S3CABundleVolumeName,
+		MountPath: S3CABundleMountPath,
+		ReadOnly:  true,
+	}
+	return volume, volumeMount, true
+}
+
+// caBundleConfigMap returns the CA bundle ConfigMap of the annotation, otherwise of the s3 config
+func caBundleConfigMap(annotations map[string]string, s3Config *S3Config) (string, bool) {
+	name, ok := annotations[InferenceServiceS3CABundleConfigMapAnnotation]
+	if !ok {
+		name = s3Config.S3CABundleConfigMap
+	}
+	return name, name != ""
+}
@@ -29,6 +29,25 @@ func TestBuildS3EnvVars(t *testing.T) {
 		annotations map[string]string
 		expected    []v1.EnvVar
 	}{
+		"CABundleConfigMap": {
+			config: S3Config{
+				S3CABundle:          "/etc/ssl/certs/ca.crt",
+				S3CABundleConfigMap: "minio-ca",
+			},
+			annotations: map[string]string{
+				InferenceServiceS3UseVirtualBucketAnnotation: "false",
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  S3UseVirtualBucket,
+					Value: "false",
+				},
+				{
+					Name:  AWSCABundle,
+					Value: "/etc/ssl/custom-certs/s3/cabundle.crt",
+				},
+			},
+		},
 		"S3Endpoint": {
 			annotations: map[string]string{
 				InferenceServiceS3SecretEndpointAnnotation: "s3.aws.com",
@@ -98,3 +117,33 @@ func TestBuildS3EnvVars(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildCABundleVolume(t *testing.T) {
+	_, _, ok := BuildCABundleVolume(map[string]string{}, &S3Config{})
+	if ok {
+		t.Errorf("Unexpected CA bundle volume without a ConfigMap")
+	}
+	// The annotation of the secret overrides the s3 config
+	volume, volumeMount, ok := BuildCABundleVolume(map[string]string{
+		InferenceServiceS3CABundleConfigMapAnnotation: "ceph-ca",
+	}, &S3Config{S3CABundleConfigMap: "minio-ca"})
+	if !ok {
+		t.Fatalf("Expected the CA bundle volume of the ConfigMap")
+	}
+	expectedVolume := v1.Volume{
+		Name: S3CABundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "ceph-ca"},
+				Items:                []v1.KeyToPath{{Key: "cabundle.crt", Path: "cabundle.crt"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedVolume, volume); diff != "" {
+		t.Errorf("Unexpected volume (-want +got): %v", diff)
+	}
+	expectedMount := v1.VolumeMount{Name: S3CABundleVolumeName, MountPath: S3CABundleMountPath, ReadOnly: true}
+	if diff := cmp.Diff(expectedMount, volumeMount); diff != "" {
+		t.Errorf("Unexpected volume mount (-want +got): %v", diff)
+	}
+}
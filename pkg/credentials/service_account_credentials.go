@@ -32,26 +32,39 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kserve/kserve/pkg/credentials/azure"
+	"github.com/kserve/kserve/pkg/credentials/cos"
 	"github.com/kserve/kserve/pkg/credentials/gcs"
 	"github.com/kserve/kserve/pkg/credentials/hdfs"
+	"github.com/kserve/kserve/pkg/credentials/hf"
+	"github.com/kserve/kserve/pkg/credentials/oss"
+	"github.com/kserve/kserve/pkg/credentials/r2"
 	"github.com/kserve/kserve/pkg/credentials/s3"
+	"github.com/kserve/kserve/pkg/credentials/swift"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
 const (
-	CredentialConfigKeyName     = "credentials"
-	UriSchemePlaceholder        = "<scheme-placeholder>"
-	StorageConfigEnvKey         = "STORAGE_CONFIG"
-	StorageOverrideConfigEnvKey = "STORAGE_OVERRIDE_CONFIG"
-	DefaultStorageSecretKey     = "default"
-	UnsupportedStorageSpecType  = "storage type must be one of [%s]. storage type [%s] is not supported"
-	MissingBucket               = "format [%s] requires a bucket but one wasn't found in storage data or parameters"
-	AwsIrsaAnnotationKey        = "eks.amazonaws.com/role-arn"
+	CredentialConfigKeyName          = "credentials"
+	UriSchemePlaceholder             = "<scheme-placeholder>"
+	StorageConfigEnvKey              = "STORAGE_CONFIG"
+	StorageOverrideConfigEnvKey      = "STORAGE_OVERRIDE_CONFIG"
+	DefaultStorageSecretKey          = "default"
+	UnsupportedStorageSpecType       = "storage type must be one of [%s]. storage type [%s] is not supported"
+	MissingBucket                    = "format [%s] requires a bucket but one wasn't found in storage data or parameters"
+	AwsIrsaAnnotationKey             = "eks.amazonaws.com/role-arn"
+	GcpWorkloadIdentityAnnotationKey = "iam.gke.io/gcp-service-account"
 )
 
 var (
-	SupportedStorageSpecTypes = []string{"s3", "hdfs", "webhdfs"}
-	StorageBucketTypes        = []string{"s3"}
+	SupportedStorageSpecTypes = []string{"s3", "hdfs", "webhdfs", "swift"}
+	StorageBucketTypes        = []string{"s3", "swift"}
+	// CloudIdentityAnnotationKeys bind a service account to a cloud identity, they are copied from the
+	// InferenceService to the service accounts created by the controller
+	CloudIdentityAnnotationKeys = []string{AwsIrsaAnnotationKey, GcpWorkloadIdentityAnnotationKey}
+	// CloudCredentialEnvKeys hold the credentials set from the secrets of a service account, they must not be set by
+	// the containers which get the credentials of their service account
+	CloudCredentialEnvKeys = []string{s3.AWSAccessKeyId, s3.AWSSecretAccessKey, gcs.GCSCredentialEnvKey,
+		azure.AzureClientSecret, azure.AzureStorageAccessKey}
 )
 
 type CredentialConfig struct {
@@ -200,6 +213,7 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, serviceAc
 			log.Info("AWS IAM Role annotation found, setting service account envs for s3", "ServiceAccountName", serviceAccountName)
 			envs := s3.BuildServiceAccountEnvs(serviceAccount, &c.config.S3)
 			container.Env = append(container.Env, envs...)
+			mountS3CABundle(serviceAccount.Annotations, &c.config.S3, container, volumes)
 		}
 	}
 
@@ -217,6 +231,7 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, serviceAc
 			envs := s3.BuildSecretEnvs(secret, &c.config.S3)
 			// Merge envs here to override values possibly present from IAM Role annotations with values from secret annotations
 			container.Env = utils.MergeEnvs(container.Env, envs)
+			mountS3CABundle(secret.Annotations, &c.config.S3, container, volumes)
 		} else if _, ok := secret.Data[gcsCredentialFileName]; ok {
 			log.Info("Setting secret volume for gcs", "GCSSecret", secret.Name)
 			volume, volumeMount := gcs.BuildSecretVolume(secret)
@@ -249,6 +264,34 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, serviceAc
 			volume, volumeMount := hdfs.BuildSecret(secret)
 			*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
 			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		} else if _, ok := secret.Data[hf.HFToken]; ok {
+			log.Info("Setting secret envs for hugging face hub", "HFSecret", secret.Name)
+			envs := hf.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[oss.OSSAccessKeyId]; ok {
+			log.Info("Setting secret envs for alibaba cloud oss", "OSSSecret", secret.Name)
+			envs := oss.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[cos.COSHMACAccessKeyId]; ok {
+			log.Info("Setting secret envs with hmac credentials for ibm cos", "COSSecret", secret.Name)
+			envs := cos.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[cos.COSAPIKey]; ok {
+			log.Info("Setting secret envs with iam api key for ibm cos", "COSSecret", secret.Name)
+			envs := cos.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[r2.R2AccessKeyId]; ok {
+			log.Info("Setting secret envs for cloudflare r2", "R2Secret", secret.Name)
+			envs := r2.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[swift.SwiftAuthURL]; ok {
+			log.Info("Setting secret envs for openstack swift", "SwiftSecret", secret.Name)
+			envs := swift.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
+		} else if _, ok := secret.Data[swift.SwiftStorageURL]; ok {
+			log.Info("Setting secret envs with a pre-authenticated token for openstack swift", "SwiftSecret", secret.Name)
+			envs := swift.BuildSecretEnvs(secret)
+			container.Env = append(container.Env, envs...)
 		} else {
 			log.V(5).Info("Skipping non gcs/s3/azure secret", "Secret", secret.Name)
 		}
@@ -256,3 +299,19 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, serviceAc
 
 	return nil
 }
+
+// mountS3CABundle mounts the ConfigMap of the CA bundle of the S3 endpoint once in the container
+func mountS3CABundle(annotations map[string]string, s3Config *s3.S3Config, container *v1.Container,
+	volumes *[]v1.Volume) {
+	volume, volumeMount, ok := s3.BuildCABundleVolume(annotations, s3Config)
+	if !ok {
+		return
+	}
+	*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volumeMount.Name {
+			return
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+}
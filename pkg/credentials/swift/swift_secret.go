@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+/*
+The OpenStack Swift secret holds the Keystone credentials of the object storage, the same variables the openstack
+client reads: https://docs.openstack.org/python-swiftclient/latest/cli/index.html#authentication
+The password, an application credential or a pre-authenticated token with its storage url can be used.
+*/
+const (
+	SwiftAuthURL                     = "OS_AUTH_URL"
+	SwiftUsername                    = "OS_USERNAME"
+	SwiftPassword                    = "OS_PASSWORD"
+	SwiftProjectName                 = "OS_PROJECT_NAME"
+	SwiftProjectId                   = "OS_PROJECT_ID"
+	SwiftUserDomainName              = "OS_USER_DOMAIN_NAME"
+	SwiftProjectDomainName           = "OS_PROJECT_DOMAIN_NAME"
+	SwiftRegionName                  = "OS_REGION_NAME"
+	SwiftApplicationCredentialId     = "OS_APPLICATION_CREDENTIAL_ID"
+	SwiftApplicationCredentialSecret = "OS_APPLICATION_CREDENTIAL_SECRET"
+	SwiftAuthToken                   = "OS_AUTH_TOKEN"
+	SwiftStorageURL                  = "OS_STORAGE_URL"
+)
+
+var secretKeys = []string{
+	SwiftAuthURL,
+	SwiftUsername,
+	SwiftPassword,
+	SwiftProjectName,
+	SwiftProjectId,
+	SwiftUserDomainName,
+	SwiftProjectDomainName,
+	SwiftRegionName,
+	SwiftApplicationCredentialId,
+	SwiftApplicationCredentialSecret,
+	SwiftAuthToken,
+	SwiftStorageURL,
+}
+
+// BuildSecretEnvs returns the envs of the keys present in the secret
+func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{}
+	for _, key := range secretKeys {
+		if _, ok := secret.Data[key]; !ok {
+			continue
+		}
+		envs = append(envs, v1.EnvVar{
+			Name: key,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: key,
+				},
+			},
+		})
+	}
+	return envs
+}
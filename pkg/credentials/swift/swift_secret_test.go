@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package swift
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func secretEnv(key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: key,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "swift-secret",
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+func TestSwiftSecret(t *testing.T) {
+	scenarios := map[string]struct {
+		secret   *v1.Secret
+		expected []v1.EnvVar
+	}{
+		"SwiftPassword": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "swift-secret",
+				},
+				Data: map[string][]byte{
+					SwiftAuthURL:           []byte("https://keystone.example.com:5000/v3"),
+					SwiftUsername:          []byte("kserve"),
+					SwiftPassword:          []byte("password"),
+					SwiftProjectName:       []byte("models"),
+					SwiftUserDomainName:    []byte("Default"),
+					SwiftProjectDomainName: []byte("Default"),
+				},
+			},
+			expected: []v1.EnvVar{
+				secretEnv(SwiftAuthURL),
+				secretEnv(SwiftUsername),
+				secretEnv(SwiftPassword),
+				secretEnv(SwiftProjectName),
+				secretEnv(SwiftUserDomainName),
+				secretEnv(SwiftProjectDomainName),
+			},
+		},
+		"SwiftApplicationCredential": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "swift-secret",
+				},
+				Data: map[string][]byte{
+					SwiftAuthURL:                     []byte("https://keystone.example.com:5000/v3"),
+					SwiftRegionName:                  []byte("RegionOne"),
+					SwiftApplicationCredentialId:     []byte("id"),
+					SwiftApplicationCredentialSecret: []byte("secret"),
+				},
+			},
+			expected: []v1.EnvVar{
+				secretEnv(SwiftAuthURL),
+				secretEnv(SwiftRegionName),
+				secretEnv(SwiftApplicationCredentialId),
+				secretEnv(SwiftApplicationCredentialSecret),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		envs := BuildSecretEnvs(scenario.secret)
+
+		if diff := cmp.Diff(scenario.expected, envs); diff != "" {
+			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+		}
+	}
+}
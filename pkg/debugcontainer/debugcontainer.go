@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debugcontainer attaches an ephemeral debug container to the pods of the InferenceServices, it shares the
+// process namespace of the model server container and mounts its model volume read-only so a model failing to load
+// is inspected in place, even in clusters where exec into the model server container is not allowed.
+package debugcontainer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigMapKey is the key of the inferenceservice-config ConfigMap holding the debug container config
+	ConfigMapKey = "debugContainer"
+
+	// NamePrefix prefixes the names of the debug containers
+	NamePrefix = "kserve-debug-"
+)
+
+// Config is the debug container config of the inferenceservice-config ConfigMap
+type Config struct {
+	// Image of the debug containers, e.g. an image with the python and the tools of the model servers
+	Image string `json:"image"`
+	// Command of the debug containers, defaults to the entrypoint of the image
+	// +optional
+	Command []string `json:"command,omitempty"`
+}
+
+// ParseConfig strictly parses the debug container config of the ConfigMap, an empty value has no image configured
+func ParseConfig(data string) (*Config, error) {
+	config := &Config{}
+	if strings.TrimSpace(data) == "" {
+		return config, nil
+	}
+	decoder := json.NewDecoder(bytes.NewBufferString(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s config json: %v", ConfigMapKey, err)
+	}
+	return config, nil
+}
+
+// LoadConfig reads the debug container config of the inferenceservice-config ConfigMap of the KServe namespace
+func LoadConfig(ctx context.Context, clientset kubernetes.Interface, namespace string) (*Config, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, constants.InferenceServiceConfigMapName,
+		metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(configMap.Data[ConfigMapKey])
+}
+
+// Options selects the pod and the container of the InferenceService the debug container is attached to
+type Options struct {
+	Namespace        string
+	InferenceService string
+	// Component of the pods, predictor, transformer or explainer
+	Component string
+	// Pod of the component, the first pod which is not ready when empty, or the first pod when they are all ready
+	Pod string
+	// Container the debug container targets, defaults to the model server container
+	Container string
+}
+
+// SelectPod returns the pod to debug among the pods of the component
+func SelectPod(pods []v1.Pod, podName string) (*v1.Pod, error) {
+	if podName != "" {
+		for i := range pods {
+			if pods[i].Name == podName {
+				return &pods[i], nil
+			}
+		}
+		return nil, fmt.Errorf("pod %s is not a pod of the component", podName)
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("the component has no pods")
+	}
+	sorted := make([]*v1.Pod, 0, len(pods))
+	for i := range pods {
+		sorted = append(sorted, &pods[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	// The pods failing to load the model are the ones being debugged
+	for _, pod := range sorted {
+		if !isPodReady(pod) {
+			return pod, nil
+		}
+	}
+	return sorted[0], nil
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// NewEphemeralContainer returns the debug container of the target container of the pod. It has the env and the
+// security context of the target container, so it is admitted by the same pod security policies, and mounts the model
+// volumes of the target container read-only.
+func NewEphemeralContainer(pod *v1.Pod, target string, config *Config) (*v1.EphemeralContainer, error) {
+	if config.Image == "" {
+		return nil, fmt.Errorf("the image of the debug container is not configured, set it in the %s config", ConfigMapKey)
+	}
+	var container *v1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == target {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		return nil, fmt.Errorf("pod %s has no container %s", pod.Name, target)
+	}
+	mounts := []v1.VolumeMount{}
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == constants.DefaultModelLocalMountPath ||
+			strings.HasPrefix(mount.MountPath, constants.DefaultModelLocalMountPath+"/") {
+			mount.ReadOnly = true
+			mounts = append(mounts, mount)
+		}
+	}
+	return &v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     containerName(pod),
+			Image:                    config.Image,
+			Command:                  config.Command,
+			Env:                      container.Env,
+			EnvFrom:                  container.EnvFrom,
+			VolumeMounts:             mounts,
+			SecurityContext:          container.SecurityContext.DeepCopy(),
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+			Stdin:                    true,
+			TTY:                      true,
+		},
+		TargetContainerName: target,
+	}, nil
+}
+
+// containerName returns a name of debug container which is not used by the pod yet
+func containerName(pod *v1.Pod) string {
+	used := map[string]bool{}
+	for _, container := range pod.Spec.EphemeralContainers {
+		used[container.Name] = true
+	}
+	for {
+		name := NamePrefix + utilrand.String(5)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// Attach adds a debug container to the selected pod of the InferenceService and returns the pod and the name of the
+// debug container, the ephemeral containers require Kubernetes 1.23 or newer.
+func Attach(ctx context.Context, clientset kubernetes.Interface, config *Config, options Options) (*v1.Pod, string, error) {
+	if options.Container == "" {
+		options.Container = constants.InferenceServiceContainerName
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		constants.InferenceServicePodLabelKey: options.InferenceService,
+		constants.KServiceComponentLabel:      options.Component,
+	})
+	pods, err := clientset.CoreV1().Pods(options.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	pod, err := SelectPod(pods.Items, options.Pod)
+	if err != nil {
+		return nil, "", fmt.Errorf("InferenceService %s/%s %s: %v", options.Namespace, options.InferenceService,
+			options.Component, err)
+	}
+	container, err := NewEphemeralContainer(pod, options.Container, config)
+	if err != nil {
+		return nil, "", err
+	}
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, *container)
+	updated, err = clientset.CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(ctx, pod.Name, updated,
+		metav1.UpdateOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to add the debug container to pod %s: %v", pod.Name, err)
+	}
+	return updated, container.Name, nil
+}
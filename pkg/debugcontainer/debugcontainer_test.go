@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugcontainer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(name string, ready bool) *v1.Pod {
+	runAsNonRoot := true
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "llama",
+				constants.KServiceComponentLabel:      string(constants.Predictor),
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: constants.InferenceServiceContainerName,
+					Env:  []v1.EnvVar{{Name: "MODEL_NAME", Value: "llama"}},
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "kserve-provision-location", MountPath: constants.DefaultModelLocalMountPath},
+						{Name: "cache", MountPath: "/cache"},
+					},
+					SecurityContext: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+				},
+			},
+		},
+		Status: v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}}},
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := ParseConfig(`{"image": "python:3.9", "command": ["sh"]}`)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&Config{Image: "python:3.9", Command: []string{"sh"}}))
+
+	config, err = ParseConfig("")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Image).To(gomega.BeEmpty())
+
+	_, err = ParseConfig(`{"img": "python:3.9"}`)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unable to parse debugContainer config json")))
+}
+
+func TestSelectPod(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pods := []v1.Pod{*newPod("llama-c", false), *newPod("llama-a", true), *newPod("llama-b", false)}
+
+	pod, err := SelectPod(pods, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pod.Name).To(gomega.Equal("llama-b"))
+
+	pod, err = SelectPod(pods, "llama-a")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pod.Name).To(gomega.Equal("llama-a"))
+
+	pod, err = SelectPod([]v1.Pod{*newPod("llama-b", true), *newPod("llama-a", true)}, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pod.Name).To(gomega.Equal("llama-a"))
+
+	_, err = SelectPod(pods, "llama-d")
+	g.Expect(err).To(gomega.MatchError("pod llama-d is not a pod of the component"))
+	_, err = SelectPod(nil, "")
+	g.Expect(err).To(gomega.MatchError("the component has no pods"))
+}
+
+func TestNewEphemeralContainer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := newPod("llama-a", false)
+	container, err := NewEphemeralContainer(pod, constants.InferenceServiceContainerName, &Config{Image: "python:3.9"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(strings.HasPrefix(container.Name, NamePrefix)).To(gomega.BeTrue())
+	g.Expect(container.Image).To(gomega.Equal("python:3.9"))
+	g.Expect(container.TargetContainerName).To(gomega.Equal(constants.InferenceServiceContainerName))
+	g.Expect(container.Env).To(gomega.Equal(pod.Spec.Containers[0].Env))
+	g.Expect(container.SecurityContext).To(gomega.Equal(pod.Spec.Containers[0].SecurityContext))
+	g.Expect(container.VolumeMounts).To(gomega.Equal([]v1.VolumeMount{
+		{Name: "kserve-provision-location", MountPath: constants.DefaultModelLocalMountPath, ReadOnly: true},
+	}))
+	g.Expect(container.Stdin).To(gomega.BeTrue())
+	g.Expect(container.TTY).To(gomega.BeTrue())
+	// The model volume of the pod is not modified
+	g.Expect(pod.Spec.Containers[0].VolumeMounts[0].ReadOnly).To(gomega.BeFalse())
+
+	_, err = NewEphemeralContainer(pod, "queue-proxy", &Config{Image: "python:3.9"})
+	g.Expect(err).To(gomega.MatchError("pod llama-a has no container queue-proxy"))
+	_, err = NewEphemeralContainer(pod, constants.InferenceServiceContainerName, &Config{})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("the image of the debug container is not configured")))
+}
+
+func TestAttach(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fake.NewSimpleClientset(
+		newPod("llama-a", true),
+		newPod("llama-b", false),
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: "kserve"},
+			Data:       map[string]string{ConfigMapKey: `{"image": "python:3.9"}`},
+		},
+	)
+	config, err := LoadConfig(context.TODO(), clientset, "kserve")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	pod, name, err := Attach(context.TODO(), clientset, config, Options{
+		Namespace:        "default",
+		InferenceService: "llama",
+		Component:        string(constants.Predictor),
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(pod.Name).To(gomega.Equal("llama-b"))
+	updated, err := clientset.CoreV1().Pods("default").Get(context.TODO(), "llama-b", metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(updated.Spec.EphemeralContainers).To(gomega.HaveLen(1))
+	g.Expect(updated.Spec.EphemeralContainers[0].Name).To(gomega.Equal(name))
+
+	_, _, err = Attach(context.TODO(), clientset, config, Options{
+		Namespace:        "default",
+		InferenceService: "llama",
+		Component:        string(constants.Transformer),
+	})
+	g.Expect(err).To(gomega.MatchError("InferenceService default/llama transformer: the component has no pods"))
+}
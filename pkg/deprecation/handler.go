@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+	LinkHeader        = "Link"
+)
+
+// DeprecationHandler adds the Deprecation (RFC 9745) and Sunset (RFC 8594) headers to the responses of a deprecated
+// InferenceService, once the sunset has passed the configured percentage of the requests is rejected with 410 Gone.
+type DeprecationHandler struct {
+	log              *zap.SugaredLogger
+	deprecation      time.Time
+	sunset           *time.Time
+	rejectPercentage int
+	link             string
+	next             http.Handler
+	// now and random are replaced in tests
+	now    func() time.Time
+	random func(n int) int
+}
+
+func New(deprecation time.Time, sunset *time.Time, rejectPercentage int, link string, next http.Handler,
+	logger *zap.SugaredLogger) http.Handler {
+	return &DeprecationHandler{
+		log:              logger,
+		deprecation:      deprecation,
+		sunset:           sunset,
+		rejectPercentage: rejectPercentage,
+		link:             link,
+		next:             next,
+		now:              time.Now,
+		random:           rand.Intn,
+	}
+}
+
+func (h *DeprecationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Set(DeprecationHeader, fmt.Sprintf("@%d", h.deprecation.Unix()))
+	if h.sunset != nil {
+		header.Set(SunsetHeader, h.sunset.UTC().Format(http.TimeFormat))
+	}
+	if h.link != "" {
+		header.Add(LinkHeader, fmt.Sprintf("<%s>; rel=\"deprecation\"", h.link))
+	}
+	if h.sunset != nil && !h.now().Before(*h.sunset) && h.random(100) < h.rejectPercentage {
+		h.log.Debugw("Rejected request past the sunset", "sunset", h.sunset, "path", r.URL.Path)
+		http.Error(w, "the endpoint has been sunset", http.StatusGone)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestDeprecationHandler(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	deprecation := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	scenarios := map[string]struct {
+		sunset           *time.Time
+		rejectPercentage int
+		link             string
+		now              time.Time
+		random           int
+		expectedStatus   int
+		expectedHeaders  http.Header
+	}{
+		"Deprecated": {
+			now:            deprecation.Add(time.Hour),
+			expectedStatus: http.StatusOK,
+			expectedHeaders: http.Header{
+				"Deprecation": {"@1672531200"},
+			},
+		},
+		"BeforeSunset": {
+			sunset:           &sunset,
+			rejectPercentage: 100,
+			link:             "https://example.com/migration",
+			now:              sunset.Add(-time.Hour),
+			expectedStatus:   http.StatusOK,
+			expectedHeaders: http.Header{
+				"Deprecation": {"@1672531200"},
+				"Sunset":      {"Wed, 01 Mar 2023 00:00:00 GMT"},
+				"Link":        {`<https://example.com/migration>; rel="deprecation"`},
+			},
+		},
+		"PastSunsetRejected": {
+			sunset:           &sunset,
+			rejectPercentage: 50,
+			now:              sunset.Add(time.Hour),
+			random:           49,
+			expectedStatus:   http.StatusGone,
+			expectedHeaders: http.Header{
+				"Deprecation": {"@1672531200"},
+				"Sunset":      {"Wed, 01 Mar 2023 00:00:00 GMT"},
+			},
+		},
+		"PastSunsetServed": {
+			sunset:           &sunset,
+			rejectPercentage: 50,
+			now:              sunset.Add(time.Hour),
+			random:           50,
+			expectedStatus:   http.StatusOK,
+			expectedHeaders: http.Header{
+				"Deprecation": {"@1672531200"},
+				"Sunset":      {"Wed, 01 Mar 2023 00:00:00 GMT"},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			handler := New(deprecation, scenario.sunset, scenario.rejectPercentage, scenario.link, next, logger).(*DeprecationHandler)
+			handler.now = func() time.Time { return scenario.now }
+			handler.random = func(n int) int { return scenario.random }
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+			g.Expect(rec.Code).To(gomega.Equal(scenario.expectedStatus))
+			for key, values := range scenario.expectedHeaders {
+				g.Expect(rec.Header().Values(key)).To(gomega.Equal(values))
+			}
+			if scenario.sunset == nil {
+				g.Expect(rec.Header().Get(SunsetHeader)).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
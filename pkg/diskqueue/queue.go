@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diskqueue
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// recordFileExt is the extension of the queued records, the files being written have a tmpFileExt extension
+	// until they are complete
+	recordFileExt = ".json"
+	tmpFileExt    = ".tmp"
+	// BlockSize is the allocation unit of the files, each record uses at least one block of the queue volume
+	BlockSize = 4096
+)
+
+// ErrQueueFull is returned when the queued records would exceed the size of the queue
+var ErrQueueFull = errors.New("queue is full")
+
+type entry struct {
+	name string
+	size int64
+}
+
+// Queue is a disk backed FIFO queue of records. Each record is written to its own file named after its sequence
+// number, the records left in the directory by a previous agent container are loaded first.
+type Queue struct {
+	dir     string
+	maxSize int64
+	mu      sync.Mutex
+	entries []entry
+	size    int64
+	next    uint64
+	ready   chan struct{}
+}
+
+// New loads the records queued in dir, maxSize bounds the size of the queued records in bytes
+func New(dir string, maxSize int64) (*Queue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	q := &Queue{
+		dir:     dir,
+		maxSize: maxSize,
+		ready:   make(chan struct{}, 1),
+	}
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasSuffix(name, tmpFileExt) {
+			// Interrupted write, the record was never acknowledged to the queue
+			os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, recordFileExt), 10, 64)
+		if file.IsDir() || !strings.HasSuffix(name, recordFileExt) || err != nil {
+			continue
+		}
+		q.entries = append(q.entries, entry{name: name, size: diskSize(file.Size())})
+		q.size += diskSize(file.Size())
+		if seq >= q.next {
+			q.next = seq + 1
+		}
+	}
+	// The names are zero padded, their order is the order of the sequence numbers
+	sort.Slice(q.entries, func(i, j int) bool { return q.entries[i].name < q.entries[j].name })
+	if len(q.entries) > 0 {
+		q.ready <- struct{}{}
+	}
+	return q, nil
+}
+
+// Put appends the record to the queue, ErrQueueFull is returned when there is no room left for it
+func (q *Queue) Put(data []byte) error {
+	size := diskSize(int64(len(data)))
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size+size > q.maxSize {
+		return ErrQueueFull
+	}
+	name := fmt.Sprintf("%020d%s", q.next, recordFileExt)
+	tmp := filepath.Join(q.dir, name+tmpFileExt)
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.dir, name)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	q.next++
+	q.entries = append(q.entries, entry{name: name, size: size})
+	q.size += size
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Oldest returns the name and the record at the head of the queue, an empty name when the queue is empty.
+// The record stays queued until it is removed.
+func (q *Queue) Oldest() (string, []byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) == 0 {
+		return "", nil, nil
+	}
+	name := q.entries[0].name
+	data, err := ioutil.ReadFile(filepath.Join(q.dir, name))
+	return name, data, err
+}
+
+// Remove removes the record at the head of the queue once it was processed
+func (q *Queue) Remove(name string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) == 0 || q.entries[0].name != name {
+		return fmt.Errorf("record %s is not the oldest queued record", name)
+	}
+	if err := os.Remove(filepath.Join(q.dir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.size -= q.entries[0].size
+	q.entries = q.entries[1:]
+	return nil
+}
+
+// Len returns the number of queued records
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// Ready is signaled when records are added to the queue
+func (q *Queue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// diskSize returns the size of a file on disk, rounded up to the block size
+func diskSize(size int64) int64 {
+	return (size + BlockSize - 1) / BlockSize * BlockSize
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diskqueue
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestQueue(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := t.TempDir()
+	queue, err := New(dir, 2*BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	name, _, err := queue.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(name).To(gomega.BeEmpty())
+
+	for _, record := range []string{"a", "b"} {
+		g.Expect(queue.Put([]byte(record))).To(gomega.Succeed())
+	}
+	g.Expect(queue.Len()).To(gomega.Equal(2))
+	name, record, err := queue.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(record)).To(gomega.Equal("a"))
+	g.Expect(queue.Remove("00000000000000000001.json")).ToNot(gomega.Succeed())
+	g.Expect(queue.Remove(name)).To(gomega.Succeed())
+
+	// The records left by an interrupted agent are loaded in order, the incomplete writes are discarded
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "00000000000000000002.json.tmp"), []byte("c"), 0600)).To(gomega.Succeed())
+	queue, err = New(dir, 2*BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(queue.Len()).To(gomega.Equal(1))
+	g.Expect(queue.Ready()).To(gomega.Receive())
+	g.Expect(queue.Put([]byte("c"))).To(gomega.Succeed())
+	files, err := ioutil.ReadDir(dir)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(files).To(gomega.HaveLen(2))
+	g.Expect(files[1].Name()).To(gomega.Equal("00000000000000000002.json"))
+	_, record, err = queue.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(record)).To(gomega.Equal("b"))
+
+	// The records above the size of the queue are rejected, each record uses at least one block
+	g.Expect(queue.Put([]byte("d"))).To(gomega.Equal(ErrQueueFull))
+	g.Expect(queue.Len()).To(gomega.Equal(2))
+}
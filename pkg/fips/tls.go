@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fips validates the TLS settings of the KServe binaries. When built with the boringcrypto tag
+// the binaries use the FIPS validated BoringCrypto module and only FIPS approved TLS settings are accepted.
+package fips
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ApprovedCipherSuites are the TLS 1.2 cipher suites allowed by FIPS 140-2, TLS 1.3 suites are not configurable
+var ApprovedCipherSuites = map[uint16]bool{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: true,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: true,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   true,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   true,
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256:         true,
+	tls.TLS_RSA_WITH_AES_256_GCM_SHA384:         true,
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion converts a human readable TLS version such as "1.2", an empty version returns 0 so the Go
+// default is used. TLS versions lower than 1.2 are rejected in FIPS mode.
+func ParseTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		return 0, nil
+	}
+	v, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("invalid TLS version %q", version)
+	}
+	if Enabled && v < tls.VersionTLS12 {
+		return 0, fmt.Errorf("TLS version %q is not allowed in FIPS mode", version)
+	}
+	return v, nil
+}
+
+// ParseCipherSuites converts a comma separated list of IANA cipher suite names, an empty list returns nil so
+// the Go default is used. Insecure suites are always rejected and suites which are not FIPS approved are
+// rejected in FIPS mode.
+func ParseCipherSuites(names string) ([]uint16, error) {
+	if names == "" {
+		return nil, nil
+	}
+	known := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	var ids []uint16
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure TLS cipher suite %q", name)
+		}
+		if Enabled && !ApprovedCipherSuites[id] {
+			return nil, fmt.Errorf("TLS cipher suite %q is not allowed in FIPS mode", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// TLSSettings are the validated TLS settings of the outgoing connections, zero values keep the Go defaults
+type TLSSettings struct {
+	MinVersion   uint16
+	CipherSuites []uint16
+}
+
+// NewTLSSettings parses the minimum TLS version and the comma separated cipher suites
+func NewTLSSettings(minVersion string, cipherSuites string) (*TLSSettings, error) {
+	version, err := ParseTLSVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := ParseCipherSuites(cipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	return &TLSSettings{MinVersion: version, CipherSuites: suites}, nil
+}
+
+// Apply sets the TLS settings on config, a new config is returned when config is nil
+func (s *TLSSettings) Apply(config *tls.Config) *tls.Config {
+	if config == nil {
+		config = &tls.Config{}
+	}
+	config.MinVersion = s.MinVersion
+	config.CipherSuites = s.CipherSuites
+	return config
+}
+
+// ConfigureTransport applies the TLS settings to an *http.Transport, the transports cloned from it afterwards, such
+// as the ones created by knative.dev/pkg/network.NewAutoTransport from http.DefaultTransport, inherit them.
+func (s *TLSSettings) ConfigureTransport(rt http.RoundTripper) error {
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected transport type %T", rt)
+	}
+	transport.TLSClientConfig = s.Apply(transport.TLSClientConfig)
+	return nil
+}
+
+// WrapTransport is a client-go rest.Config WrapTransport applying the TLS settings to the transport of the
+// Kubernetes clients, client-go builds its own transports which are not cloned from http.DefaultTransport.
+func (s *TLSSettings) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+	// client-go shares its transports between the clients with the same TLS config, configure a copy
+	transport = transport.Clone()
+	transport.TLSClientConfig = s.Apply(transport.TLSClientConfig)
+	return transport
+}
+
+// ConfigureDefaultTransport applies the TLS settings to the outgoing connections of http.DefaultTransport
+func ConfigureDefaultTransport(minVersion string, cipherSuites string) error {
+	settings, err := NewTLSSettings(minVersion, cipherSuites)
+	if err != nil {
+		return err
+	}
+	return settings.ConfigureTransport(http.DefaultTransport)
+}
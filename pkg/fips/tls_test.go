@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fips
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+	pkgnet "knative.dev/pkg/network"
+)
+
+func TestParseCipherSuites(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		names     string
+		expected  []uint16
+		expectErr bool
+	}{
+		"Empty": {
+			names:    "",
+			expected: nil,
+		},
+		"Approved": {
+			names:    "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			expected: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		},
+		"NotApproved": {
+			names:     "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+			expected:  []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256},
+			expectErr: Enabled,
+		},
+		"Insecure": {
+			names:     "TLS_RSA_WITH_RC4_128_SHA",
+			expectErr: true,
+		},
+		"Unknown": {
+			names:     "TLS_FOO",
+			expectErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		ids, err := ParseCipherSuites(scenario.names)
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+			continue
+		}
+		g.Expect(err).To(gomega.BeNil(), name)
+		g.Expect(ids).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		version   string
+		expected  uint16
+		expectErr bool
+	}{
+		"Empty": {
+			version:  "",
+			expected: 0,
+		},
+		"TLS12": {
+			version:  "1.2",
+			expected: tls.VersionTLS12,
+		},
+		"TLS10": {
+			version:   "1.0",
+			expected:  tls.VersionTLS10,
+			expectErr: Enabled,
+		},
+		"Invalid": {
+			version:   "2.0",
+			expectErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		version, err := ParseTLSVersion(scenario.version)
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+			continue
+		}
+		g.Expect(err).To(gomega.BeNil(), name)
+		g.Expect(version).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestTLSSettingsTransports(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// The server does not support TLS 1.3, the requests fail once the settings are applied
+	settings, err := NewTLSSettings("1.3", "")
+	g.Expect(err).To(gomega.BeNil())
+	defaultTransport := http.DefaultTransport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	scenarios := map[string]func(configure bool) http.RoundTripper{
+		"AutoTransport": func(configure bool) http.RoundTripper {
+			http.DefaultTransport = server.Client().Transport.(*http.Transport).Clone()
+			if configure {
+				g.Expect(settings.ConfigureTransport(http.DefaultTransport)).To(gomega.Succeed())
+			}
+			return pkgnet.NewAutoTransport(1, 1)
+		},
+		"ClientGo": func(configure bool) http.RoundTripper {
+			cfg := &rest.Config{Host: server.URL, TLSClientConfig: rest.TLSClientConfig{CAData: caData}}
+			if configure {
+				cfg.WrapTransport = settings.WrapTransport
+			}
+			transport, err := rest.TransportFor(cfg)
+			g.Expect(err).To(gomega.BeNil())
+			return transport
+		},
+	}
+	for name, newTransport := range scenarios {
+		for _, configure := range []bool{false, true} {
+			client := &http.Client{Transport: newTransport(configure)}
+			resp, err := client.Get(server.URL)
+			if configure {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("protocol version")), name)
+				continue
+			}
+			g.Expect(err).To(gomega.BeNil(), name)
+			resp.Body.Close()
+		}
+	}
+}
+
+// TestBoringCryptoBuild builds the package in the FIPS build mode and runs its tests with the FIPS approved settings
+func TestBoringCryptoBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the standard library is rebuilt with BoringCrypto")
+	}
+	if Enabled {
+		t.Skip("already built with BoringCrypto")
+	}
+	if runtime.GOOS != "linux" || (runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64") {
+		t.Skip("BoringCrypto is only available on linux/amd64 and linux/arm64")
+	}
+	if _, err := exec.LookPath("gcc"); err != nil {
+		t.Skip("BoringCrypto requires cgo")
+	}
+	g := gomega.NewGomegaWithT(t)
+	env := append(os.Environ(), "CGO_ENABLED=1")
+	goFiles := func(env []string) string {
+		cmd := exec.Command("go", "list", "-tags", "boringcrypto", "-f", "{{.GoFiles}}", ".")
+		cmd.Env = env
+		out, err := cmd.CombinedOutput()
+		g.Expect(err).To(gomega.BeNil(), string(out))
+		return string(out)
+	}
+	// The boringcrypto tag selects BoringCrypto on the goboring toolchains, the later toolchains need the experiment
+	if !strings.Contains(goFiles(env), "enabled.go") {
+		env = append(env, "GOEXPERIMENT=boringcrypto")
+	}
+	g.Expect(goFiles(env)).To(gomega.ContainSubstring("enabled.go"))
+
+	cmd := exec.Command("go", "test", "-tags", "boringcrypto", "-run", "TestParseCipherSuites|TestParseTLSVersion", ".")
+	cmd.Env = env
+	out, err := cmd.CombinedOutput()
+	g.Expect(err).To(gomega.BeNil(), string(out))
+}
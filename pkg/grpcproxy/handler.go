@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcproxy
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+const (
+	// HealthService is the standard gRPC health service answered by the agent
+	HealthService = "grpc.health.v1.Health"
+	// ReflectionService is the gRPC server reflection service answered by the agent
+	ReflectionService = "grpc.reflection.v1alpha.ServerReflection"
+)
+
+// GrpcHandler answers the standard gRPC health and server reflection services of a gRPC component. The health of
+// the component merges the readiness of the agent with the health service of the component, or with the readiness
+// of its v2 protocol server when the component does not implement the health service. The services listed by the
+// reflection are the services of the agent merged with the services of the component, the other reflection requests
+// are proxied to the component. Every other request, gRPC or not, is passed to the next handler.
+type GrpcHandler struct {
+	log    *zap.SugaredLogger
+	server *grpc.Server
+	next   http.Handler
+}
+
+// New returns the handler of the health and reflection services of the component reached through the connection,
+// the agent is ready when ready returns true.
+func New(conn *grpc.ClientConn, ready func() bool, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{
+		log:       logger,
+		conn:      conn,
+		component: grpc_health_v1.NewHealthClient(conn),
+		ready:     ready,
+		period:    DefaultWatchPeriod,
+	})
+	grpc_reflection_v1alpha.RegisterServerReflectionServer(server, &reflectionServer{
+		log:       logger,
+		component: grpc_reflection_v1alpha.NewServerReflectionClient(conn),
+	})
+	return &GrpcHandler{
+		log:    logger,
+		server: server,
+		next:   next,
+	}
+}
+
+func (h *GrpcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if IsGrpc(r) && (strings.HasPrefix(r.URL.Path, "/"+HealthService+"/") ||
+		strings.HasPrefix(r.URL.Path, "/"+ReflectionService+"/")) {
+		h.server.ServeHTTP(w, r)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// IsGrpc returns true if the request is a gRPC call, gRPC runs over HTTP/2 only
+func IsGrpc(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
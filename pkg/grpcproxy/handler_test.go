@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcproxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+// startComponent starts a gRPC component serving the ServerReady method of the v2 protocol and the reflection
+func startComponent(t *testing.T, ready *atomic.Value, register func(*grpc.Server)) *grpc.ClientConn {
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: InferenceService,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "ServerReady",
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := []byte{}
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				// The ready field of the ServerReadyResponse
+				resp := []byte{0x08, 0x00}
+				if ready.Load().(bool) {
+					resp[1] = 0x01
+				}
+				return &resp, nil
+			},
+		}},
+	}, struct{}{})
+	reflection.Register(server)
+	if register != nil {
+		register(server)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// startAgent serves the handler over h2c like the agent and returns a connection to it
+func startAgent(t *testing.T, handler http.Handler) *grpc.ClientConn {
+	server := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	t.Cleanup(server.Close)
+	conn, err := grpc.Dial(server.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestHealthWithoutComponentHealthService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	ready := &atomic.Value{}
+	ready.Store(true)
+	agentReady := &atomic.Value{}
+	agentReady.Store(true)
+	component := startComponent(t, ready, nil)
+	handler := New(component, func() bool { return agentReady.Load().(bool) }, http.NotFoundHandler(), logger)
+	client := grpc_health_v1.NewHealthClient(startAgent(t, handler))
+
+	check := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		g.Expect(err).To(gomega.BeNil())
+		return resp.Status
+	}
+	// The health of the component is the readiness of its v2 server
+	g.Expect(check("")).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+	g.Expect(check(InferenceService)).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+	ready.Store(false)
+	g.Expect(check("")).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+	// The component is not serving while the agent is not ready
+	ready.Store(true)
+	agentReady.Store(false)
+	g.Expect(check("")).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+	agentReady.Store(true)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "other.Service"})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.NotFound))
+
+	// The watchers receive the current health first
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	g.Expect(err).To(gomega.BeNil())
+	resp, err := stream.Recv()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resp.Status).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+}
+
+func TestHealthWithComponentHealthService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	ready := &atomic.Value{}
+	ready.Store(true)
+	componentHealth := health.NewServer()
+	component := startComponent(t, ready, func(server *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(server, componentHealth)
+	})
+	handler := New(component, func() bool { return true }, http.NotFoundHandler(), logger)
+	client := grpc_health_v1.NewHealthClient(startAgent(t, handler))
+
+	// The health service of the component is checked rather than the readiness of its v2 server
+	componentHealth.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resp.Status).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+	componentHealth.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	resp, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resp.Status).To(gomega.Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+}
+
+func TestReflection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	ready := &atomic.Value{}
+	ready.Store(true)
+	component := startComponent(t, ready, nil)
+	handler := New(component, func() bool { return true }, http.NotFoundHandler(), logger)
+	stream, err := rpb.NewServerReflectionClient(startAgent(t, handler)).ServerReflectionInfo(context.Background())
+	g.Expect(err).To(gomega.BeNil())
+	call := func(req *rpb.ServerReflectionRequest) *rpb.ServerReflectionResponse {
+		g.Expect(stream.Send(req)).To(gomega.Succeed())
+		resp, err := stream.Recv()
+		g.Expect(err).To(gomega.BeNil())
+		return resp
+	}
+
+	// The services of the agent are merged with the services of the component
+	resp := call(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}})
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	g.Expect(services).To(gomega.ConsistOf(HealthService, ReflectionService, InferenceService))
+
+	// The agent describes the health service
+	resp = call(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: HealthService},
+	})
+	files := resp.GetFileDescriptorResponse().GetFileDescriptorProto()
+	g.Expect(files).To(gomega.HaveLen(1))
+	file := &descriptorpb.FileDescriptorProto{}
+	g.Expect(proto.Unmarshal(files[0], file)).To(gomega.Succeed())
+	g.Expect(file.GetName()).To(gomega.Equal("grpc/health/v1/health.proto"))
+
+	// The other requests are answered by the component
+	resp = call(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: "missing.proto"},
+	})
+	g.Expect(resp.GetErrorResponse().GetErrorCode()).To(gomega.Equal(int32(codes.NotFound)))
+}
+
+func TestHandlerPassesOtherRequests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+	conn, err := grpc.Dial("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).To(gomega.BeNil())
+	defer conn.Close()
+	handler := New(conn, func() bool { return true }, next, logger)
+
+	// Only the gRPC calls of the health and reflection services are answered by the agent
+	http1 := httptest.NewRequest(http.MethodGet, "/"+HealthService+"/Check", nil)
+	infer := httptest.NewRequest(http.MethodPost, "/"+InferenceService+"/ModelInfer", nil)
+	infer.ProtoMajor = 2
+	infer.Header.Set("Content-Type", "application/grpc")
+	for _, req := range []*http.Request{http1, infer} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(http.StatusTeapot))
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcproxy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// InferenceService is the service of the v2 protocol, its health is the readiness of the v2 server
+	InferenceService = "inference.GRPCInferenceService"
+	// DefaultWatchPeriod is the period the health of the component is checked at for the watchers
+	DefaultWatchPeriod = 5 * time.Second
+
+	serverReadyMethod = "/" + InferenceService + "/ServerReady"
+)
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	log       *zap.SugaredLogger
+	conn      *grpc.ClientConn
+	component grpc_health_v1.HealthClient
+	ready     func() bool
+	period    time.Duration
+}
+
+func (s *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	servingStatus, err := s.check(ctx, req.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// Watch sends the health of the service when it changes, the health is checked every period
+func (s *healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+	last := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+	for {
+		servingStatus, err := s.check(stream.Context(), req.Service)
+		if status.Code(err) == codes.NotFound {
+			servingStatus = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		} else if err != nil {
+			return err
+		}
+		if servingStatus != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			last = servingStatus
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *healthServer) check(ctx context.Context, service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	if !s.ready() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+	resp, err := s.component.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.Status, nil
+	case codes.NotFound:
+		return 0, err
+	case codes.Unimplemented:
+	default:
+		s.log.Debugw("Failed to check the health of the component", "service", service, "error", err)
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+	// The component does not implement the health service, its health is the readiness of its v2 server
+	if service != "" && service != InferenceService {
+		return 0, status.Errorf(codes.NotFound, "unknown service %s", service)
+	}
+	ready, err := s.serverReady(ctx)
+	if err != nil {
+		s.log.Debugw("Failed to check the readiness of the component", "error", err)
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+	if !ready {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING, nil
+}
+
+// serverReady calls the ServerReady method of the v2 protocol, the v2 protos are not compiled in the agent so the
+// empty request is sent as is and the ready field of the response is read from the wire
+func (s *healthServer) serverReady(ctx context.Context) (bool, error) {
+	req, resp := []byte{}, []byte{}
+	if err := s.conn.Invoke(ctx, serverReadyMethod, &req, &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return false, err
+	}
+	ready := false
+	for len(resp) > 0 {
+		num, typ, n := protowire.ConsumeTag(resp)
+		if n < 0 {
+			return false, protowire.ParseError(n)
+		}
+		resp = resp[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, m := protowire.ConsumeVarint(resp)
+			if m < 0 {
+				return false, protowire.ParseError(m)
+			}
+			ready, n = v != 0, m
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, resp)
+			if n < 0 {
+				return false, protowire.ParseError(n)
+			}
+		}
+		resp = resp[n:]
+	}
+	return ready, nil
+}
+
+// rawCodec sends and receives the raw bytes of the messages, the protobuf messages are encoded as usual
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	if b, ok := v.(*[]byte); ok {
+		return *b, nil
+	}
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	if b, ok := v.(*[]byte); ok {
+		*b = append((*b)[:0], data...)
+		return nil
+	}
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+// Name is the name of the protobuf codec so that the servers accept the content subtype of the calls
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcproxy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// localFiles are the proto files of the services answered by the agent
+var localFiles = []protoreflect.FileDescriptor{
+	grpc_health_v1.File_grpc_health_v1_health_proto,
+	rpb.File_reflection_grpc_reflection_v1alpha_reflection_proto,
+}
+
+type reflectionServer struct {
+	rpb.UnimplementedServerReflectionServer
+	log       *zap.SugaredLogger
+	component rpb.ServerReflectionClient
+}
+
+// componentReflection forwards the reflection requests to the component over a single stream, the stream is opened
+// with the first request the agent does not answer
+type componentReflection struct {
+	server *reflectionServer
+	caller rpb.ServerReflection_ServerReflectionInfoServer
+	stream rpb.ServerReflection_ServerReflectionInfoClient
+	err    error
+}
+
+func (c *componentReflection) forward(req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.stream == nil {
+		c.stream, c.err = c.server.component.ServerReflectionInfo(c.caller.Context())
+		if c.err != nil {
+			return nil, c.err
+		}
+	}
+	// The error of a failed send is returned by the receive
+	_ = c.stream.Send(req)
+	resp, err := c.stream.Recv()
+	if err != nil {
+		// The component does not implement the reflection or is not reachable, the agent answers alone
+		c.server.log.Debugw("Failed to forward the reflection request to the component", "error", err)
+		c.err = err
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *reflectionServer) ServerReflectionInfo(stream rpb.ServerReflection_ServerReflectionInfoServer) error {
+	component := &componentReflection{server: s, caller: stream}
+	defer func() {
+		if component.stream != nil {
+			_ = component.stream.CloseSend()
+		}
+	}()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := s.respond(req, component)
+		resp.ValidHost = req.Host
+		resp.OriginalRequest = req
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *reflectionServer) respond(req *rpb.ServerReflectionRequest, component *componentReflection) *rpb.ServerReflectionResponse {
+	switch r := req.MessageRequest.(type) {
+	case *rpb.ServerReflectionRequest_ListServices:
+		return listServicesResponse(component)
+	case *rpb.ServerReflectionRequest_FileByFilename:
+		for _, file := range localFiles {
+			if file.Path() == r.FileByFilename {
+				return fileResponse(file)
+			}
+		}
+	case *rpb.ServerReflectionRequest_FileContainingSymbol:
+		for _, file := range localFiles {
+			if strings.HasPrefix(r.FileContainingSymbol, string(file.Package())+".") {
+				return fileResponse(file)
+			}
+		}
+	}
+	resp, err := component.forward(req)
+	if err != nil {
+		return errorResponse(codes.NotFound, fmt.Sprintf("the reflection of the component is not available: %v", err))
+	}
+	return resp
+}
+
+// listServicesResponse merges the services of the agent with the services listed by the component
+func listServicesResponse(component *componentReflection) *rpb.ServerReflectionResponse {
+	services := []*rpb.ServiceResponse{{Name: HealthService}, {Name: ReflectionService}}
+	listed := map[string]bool{HealthService: true, ReflectionService: true}
+	resp, err := component.forward(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{ListServices: "*"},
+	})
+	if err == nil {
+		for _, service := range resp.GetListServicesResponse().GetService() {
+			if !listed[service.Name] {
+				services = append(services, service)
+				listed[service.Name] = true
+			}
+		}
+	}
+	return &rpb.ServerReflectionResponse{
+		MessageResponse: &rpb.ServerReflectionResponse_ListServicesResponse{
+			ListServicesResponse: &rpb.ListServiceResponse{Service: services},
+		},
+	}
+}
+
+func fileResponse(file protoreflect.FileDescriptor) *rpb.ServerReflectionResponse {
+	b, err := proto.Marshal(protodesc.ToFileDescriptorProto(file))
+	if err != nil {
+		return errorResponse(codes.Internal, err.Error())
+	}
+	return &rpb.ServerReflectionResponse{
+		MessageResponse: &rpb.ServerReflectionResponse_FileDescriptorResponse{
+			FileDescriptorResponse: &rpb.FileDescriptorResponse{FileDescriptorProto: [][]byte{b}},
+		},
+	}
+}
+
+func errorResponse(code codes.Code, message string) *rpb.ServerReflectionResponse {
+	return &rpb.ServerReflectionResponse{
+		MessageResponse: &rpb.ServerReflectionResponse_ErrorResponse{
+			ErrorResponse: &rpb.ErrorResponse{ErrorCode: int32(code), ErrorMessage: message},
+		},
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcproxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+)
+
+// grpcStatusHeader holds the status code of the gRPC calls, in the trailers or in the headers of the trailers-only
+// responses
+const grpcStatusHeader = "Grpc-Status"
+
+// Message returns the message of the body of a unary gRPC call or response, the body holds a single length-prefixed
+// message which is not compressed
+func Message(body []byte) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, errors.New("the gRPC message is truncated")
+	}
+	if body[0] != 0 {
+		return nil, errors.New("the gRPC message is compressed")
+	}
+	if length := binary.BigEndian.Uint32(body[1:5]); int64(length) != int64(len(body)-5) {
+		return nil, errors.New("the body is not a single gRPC message")
+	}
+	return body[5:], nil
+}
+
+// Status returns the status code of the gRPC response recorded by the handlers
+func Status(rr *httptest.ResponseRecorder) codes.Code {
+	res := rr.Result()
+	value := res.Trailer.Get(grpcStatusHeader)
+	if value == "" {
+		value = res.Header.Get(grpcStatusHeader)
+	}
+	code, err := strconv.ParseUint(value, 10, 32)
+	if res.StatusCode != http.StatusOK || err != nil {
+		return codes.Unknown
+	}
+	return codes.Code(code)
+}
+
+// HTTPStatus returns the HTTP status code matching the gRPC status code, the gRPC responses are always sent with the
+// 200 status code so the handlers filtering the responses by status map them first
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteResponse writes the gRPC response recorded by the handlers, the status of the call is sent in the trailers
+func WriteResponse(w http.ResponseWriter, rr *httptest.ResponseRecorder) error {
+	res := rr.Result()
+	for key, values := range res.Header {
+		w.Header()[key] = values
+	}
+	// The trailers are announced by their prefix once the body is written
+	w.Header().Del("Trailer")
+	w.Header().Del("Content-Length")
+	w.WriteHeader(res.StatusCode)
+	if _, err := w.Write(rr.Body.Bytes()); err != nil {
+		return err
+	}
+	for key, values := range res.Trailer {
+		w.Header()[http.TrailerPrefix+key] = values
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	guuid "github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	BlobRecordsMetricName = "kserve_agent_logger_blob_records_total"
+
+	// BlobContentType is the content type of the written batches, gzip compressed JSON lines of the log events in
+	// the structured CloudEvents format
+	BlobContentType = "application/gzip"
+
+	DefaultBlobBatchSize     = 1000
+	DefaultBlobFlushInterval = time.Minute
+
+	// A batch the blob storage did not accept is written again up to blobWriteAttempts times
+	blobWriteAttempts = 3
+)
+
+// blobRecords counts the log events written to the blob storage
+var blobRecords = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: BlobRecordsMetricName,
+	Help: "Number of log events written to the blob storage",
+})
+
+func init() {
+	prometheus.MustRegister(blobRecords)
+}
+
+// BlobWriter writes the batches of log events under the prefix of a blob storage bucket
+type BlobWriter interface {
+	Write(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// BlobSink writes the log events in batches to blob storage instead of sending them one by one, for the offline
+// analysis of the payloads without an event ingestion service. The events are batched per hour partition and each
+// batch is written, as gzip compressed JSON lines, once it holds maxRecords events or at the latest every
+// flushInterval. The events of a batch the blob storage did not accept are dropped.
+type BlobSink struct {
+	log           *zap.SugaredLogger
+	writer        BlobWriter
+	maxRecords    int
+	flushInterval time.Duration
+	backoff       time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*blobBatch
+}
+
+// blobBatch is the compressed events of a partition waiting to be written
+type blobBatch struct {
+	partition string
+	buffer    bytes.Buffer
+	gzip      *gzip.Writer
+	records   int
+}
+
+func NewBlobSink(writer BlobWriter, maxRecords int, flushInterval time.Duration, log *zap.SugaredLogger) *BlobSink {
+	return &BlobSink{
+		log:           log,
+		writer:        writer,
+		maxRecords:    maxRecords,
+		flushInterval: flushInterval,
+		backoff:       minDeliveryBackoff,
+		batches:       map[string]*blobBatch{},
+	}
+}
+
+// Add appends the event to the batch of its partition, the batch is written once it is full
+func (s *BlobSink) Add(ctx context.Context, event cloudevents.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while encoding the log event: %s", err)
+	}
+	partition := blobPartition(event)
+	s.mu.Lock()
+	batch, ok := s.batches[partition]
+	if !ok {
+		batch = &blobBatch{partition: partition}
+		batch.gzip = gzip.NewWriter(&batch.buffer)
+		s.batches[partition] = batch
+	}
+	batch.gzip.Write(append(data, '\n'))
+	batch.records++
+	if batch.records < s.maxRecords {
+		s.mu.Unlock()
+		return nil
+	}
+	delete(s.batches, partition)
+	s.mu.Unlock()
+	return s.write(ctx, batch)
+}
+
+// Start writes the batches every flush interval until the context is done
+func (s *BlobSink) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Flush(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Flush writes the batches of every partition, it is called again once the agent stops serving so the events are
+// written before the pod is deleted
+func (s *BlobSink) Flush(ctx context.Context) {
+	s.mu.Lock()
+	batches := s.batches
+	s.batches = map[string]*blobBatch{}
+	s.mu.Unlock()
+	for _, batch := range batches {
+		if err := s.write(ctx, batch); err != nil {
+			s.log.Error(err)
+		}
+	}
+}
+
+// write writes the batch under a unique key of its partition
+func (s *BlobSink) write(ctx context.Context, batch *blobBatch) error {
+	if err := batch.gzip.Close(); err != nil {
+		droppedEvents.Add(float64(batch.records))
+		return fmt.Errorf("while compressing the log events: %s", err)
+	}
+	key := path.Join(batch.partition, fmt.Sprintf("%d-%s.jsonl.gz", time.Now().UnixNano(), guuid.New().String()))
+	backoff := s.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = s.writer.Write(ctx, key, batch.buffer.Bytes(), BlobContentType); err == nil {
+			blobRecords.Add(float64(batch.records))
+			return nil
+		}
+		if attempt >= blobWriteAttempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	droppedEvents.Add(float64(batch.records))
+	return fmt.Errorf("while writing %d log events to %s: %s", batch.records, key, err)
+}
+
+// blobPartition returns <namespace>/<inferenceservice>/<yyyy>/<mm>/<dd>/<hh>, the hour is the hour of the event
+func blobPartition(event cloudevents.Event) string {
+	extensions := event.Extensions()
+	return path.Join(fmt.Sprint(extensions[NamespaceAttr]), fmt.Sprint(extensions[InferenceServiceAttr]),
+		event.Time().UTC().Format("2006/01/02/15"))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type mockBlobWriter struct {
+	mu       sync.Mutex
+	failures int
+	blobs    map[string][]byte
+}
+
+func (m *mockBlobWriter) Write(ctx context.Context, key string, data []byte, contentType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("service unavailable")
+	}
+	m.blobs[key] = data
+	return nil
+}
+
+// events decodes the events of the blobs
+func (m *mockBlobWriter) events(g *gomega.WithT) map[string][]cloudevents.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events := map[string][]cloudevents.Event{}
+	for key, data := range m.blobs {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		g.Expect(err).To(gomega.BeNil())
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			event := cloudevents.NewEvent()
+			g.Expect(json.Unmarshal(scanner.Bytes(), &event)).To(gomega.Succeed())
+			events[key] = append(events[key], event)
+		}
+	}
+	return events
+}
+
+func TestBlobSink(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	writer := &mockBlobWriter{blobs: map[string][]byte{}}
+	sink := NewBlobSink(writer, 2, time.Hour, logger)
+	worker := NewWorker(1, nil, nil, nil, logger)
+	worker.BlobSink = sink
+	written := testutil.ToFloat64(blobRecords)
+
+	// The batch is written once it is full
+	for _, id := range []string{"request-1", "request-2", "request-3"} {
+		g.Expect(worker.sendCloudEvent(newTestLogRequest(g, "s3://logs/payloads", id))).To(gomega.Succeed())
+	}
+	g.Expect(writer.blobs).To(gomega.HaveLen(1))
+	g.Expect(testutil.ToFloat64(blobRecords)).To(gomega.Equal(written + 2))
+	sink.Flush(context.Background())
+	g.Expect(testutil.ToFloat64(blobRecords)).To(gomega.Equal(written + 3))
+
+	partition := "default/sklearn/" + time.Now().UTC().Format("2006/01/02/15") + "/"
+	var ids []string
+	for key, events := range writer.events(g) {
+		g.Expect(strings.HasPrefix(key, partition)).To(gomega.BeTrue())
+		g.Expect(key).To(gomega.HaveSuffix(".jsonl.gz"))
+		for _, event := range events {
+			g.Expect(event.Type()).To(gomega.Equal(CEInferenceRequest))
+			g.Expect(event.Data).To(gomega.Equal([]byte(`{"instances":[[0,0,0]]}`)))
+			ids = append(ids, event.ID())
+		}
+	}
+	g.Expect(ids).To(gomega.ConsistOf("request-1", "request-2", "request-3"))
+
+	// The batch is written again until the attempts are exhausted
+	writer = &mockBlobWriter{blobs: map[string][]byte{}, failures: blobWriteAttempts}
+	sink = NewBlobSink(writer, 1, time.Hour, logger)
+	sink.backoff = time.Millisecond
+	worker.BlobSink = sink
+	dropped := testutil.ToFloat64(droppedEvents)
+	g.Expect(worker.sendCloudEvent(newTestLogRequest(g, "s3://logs/payloads", "request-4"))).ToNot(gomega.Succeed())
+	g.Expect(testutil.ToFloat64(droppedEvents)).To(gomega.Equal(dropped + 1))
+	g.Expect(worker.sendCloudEvent(newTestLogRequest(g, "s3://logs/payloads", "request-5"))).To(gomega.Succeed())
+	g.Expect(writer.blobs).To(gomega.HaveLen(1))
+}
+
+func TestBlobSinkFlushInterval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	writer := &mockBlobWriter{blobs: map[string][]byte{}}
+	sink := NewBlobSink(writer, DefaultBlobBatchSize, 10*time.Millisecond, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink.Start(ctx)
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID("request-1")
+	event.SetType(CEInferenceResponse)
+	event.SetSource("http://localhost:9081/")
+	event.SetTime(time.Date(2023, 6, 1, 9, 30, 0, 0, time.UTC))
+	event.SetExtension(NamespaceAttr, "default")
+	event.SetExtension(InferenceServiceAttr, "sklearn")
+	g.Expect(sink.Add(ctx, event)).To(gomega.Succeed())
+	g.Eventually(func() map[string][]cloudevents.Event {
+		return writer.events(g)
+	}, 5*time.Second).Should(gomega.HaveLen(1))
+	for key := range writer.events(g) {
+		g.Expect(key).To(gomega.HavePrefix("default/sklearn/2023/06/01/09/"))
+	}
+}
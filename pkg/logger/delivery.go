@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+)
+
+const (
+	// IdempotencyKeyAttr is set on the events delivered at least once, it is unique per inference request and event
+	// type so the consumers can discard the events delivered again
+	IdempotencyKeyAttr = "idempotencykey"
+	// PartitionKeyAttr is the CloudEvents partitioning extension, the Knative KafkaSink uses it as the record key so
+	// the events of the same inference request land in the same partition in order
+	PartitionKeyAttr = "partitionkey"
+
+	DefaultDeliveryAttempts = 5
+
+	// The delay between the attempts to send an event the logger sink did not acknowledge doubles up to
+	// maxDeliveryBackoff
+	minDeliveryBackoff = 100 * time.Millisecond
+	maxDeliveryBackoff = 10 * time.Second
+)
+
+// Delivery configures the at least once delivery of the log events. An event is sent again until the logger sink
+// acknowledges it with a 2xx status, up to maxAttempts times, after which it is handed to the retry queue when there
+// is one. The events of the same inference request are sent in order by the same worker.
+type Delivery struct {
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// NewAtLeastOnceDelivery returns the at least once delivery making up to maxAttempts attempts per event
+func NewAtLeastOnceDelivery(maxAttempts int) *Delivery {
+	return &Delivery{
+		maxAttempts: maxAttempts,
+		minBackoff:  minDeliveryBackoff,
+		maxBackoff:  maxDeliveryBackoff,
+	}
+}
+
+// setKeys sets the idempotency key and the partition key of the event from the id of the inference request
+func (d *Delivery) setKeys(event *cloudevents.Event) {
+	kind := event.Type()[strings.LastIndex(event.Type(), ".")+1:]
+	event.SetExtension(IdempotencyKeyAttr, fmt.Sprintf("%s-%s", event.ID(), kind))
+	event.SetExtension(PartitionKeyAttr, event.ID())
+}
+
+// send sends the event until the logger sink acknowledges it or the attempts are exhausted
+func (d *Delivery) send(ctx context.Context, url string, event cloudevents.Event, structured bool) error {
+	backoff := d.minBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = sendEvent(ctx, url, event, structured); err == nil || attempt >= d.maxAttempts {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		retriedEvents.Inc()
+		if backoff *= 2; backoff > d.maxBackoff {
+			backoff = d.maxBackoff
+		}
+	}
+}
+
+// partition returns the worker the events of the inference request are sent by
+func partition(id string, nworkers int) int {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return int(h.Sum32() % uint32(nworkers))
+}
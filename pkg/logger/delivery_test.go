@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestAtLeastOnceDelivery(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	// The logger sink fails the first attempt of every event
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	var keys, partitions []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := req.Header.Get("Ce-Idempotencykey")
+		if attempts[key]++; attempts[key] == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		keys = append(keys, key)
+		partitions = append(partitions, req.Header.Get("Ce-Partitionkey"))
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	delivery := NewAtLeastOnceDelivery(2)
+	delivery.minBackoff = 10 * time.Millisecond
+	worker := NewWorker(1, nil, delivery, nil, logger)
+	retried := testutil.ToFloat64(retriedEvents)
+	request := newTestLogRequest(g, server.URL, "request-1")
+	g.Expect(worker.sendCloudEvent(request)).To(gomega.Succeed())
+	response := newTestLogRequest(g, server.URL, "request-1")
+	response.ReqType = InferenceResponse
+	g.Expect(worker.sendCloudEvent(response)).To(gomega.Succeed())
+	g.Expect(testutil.ToFloat64(retriedEvents)).To(gomega.Equal(retried + 2))
+
+	mu.Lock()
+	defer mu.Unlock()
+	g.Expect(keys).To(gomega.Equal([]string{"request-1-request", "request-1-response"}))
+	g.Expect(partitions).To(gomega.Equal([]string{"request-1", "request-1"}))
+
+	// The attempts are bounded
+	server.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+	g.Expect(worker.sendCloudEvent(newTestLogRequest(g, server.URL, "request-2"))).ToNot(gomega.Succeed())
+}
+
+func TestPartition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, id := range []string{"request-1", "request-2", "3d1b0c4e-6f0a-4d3b-9c1e-8f2a7b6c5d4e"} {
+		g.Expect(partition(id, 5)).To(gomega.Equal(partition(id, 5)))
+		g.Expect(partition(id, 5)).To(gomega.And(gomega.BeNumerically(">=", 0), gomega.BeNumerically("<", 5)))
+	}
+	g.Expect(partition("request-1", 1)).To(gomega.Equal(0))
+}
@@ -22,14 +22,30 @@ import (
 
 var WorkerQueue chan chan LogRequest
 
-func StartDispatcher(nworkers int, logger *zap.SugaredLogger) {
+// StartDispatcher starts the workers sending the log events, the events the logger sink did not accept are queued to
+// retries when it is set. The events are delivered at least once when delivery is set.
+func StartDispatcher(nworkers int, delivery *Delivery, retries *RetryQueue, logger *zap.SugaredLogger) {
+	startDispatcher(nworkers, delivery, retries, nil, logger)
+}
+
+// StartBlobDispatcher starts the workers transforming the log events and adding them to the batches of the blob sink
+func StartBlobDispatcher(nworkers int, sink *BlobSink, logger *zap.SugaredLogger) {
+	startDispatcher(nworkers, nil, nil, sink, logger)
+}
+
+func startDispatcher(nworkers int, delivery *Delivery, retries *RetryQueue, sink *BlobSink, logger *zap.SugaredLogger) {
+	if delivery != nil {
+		startPartitionedDispatcher(nworkers, delivery, retries, logger)
+		return
+	}
 	// First, initialize the channel we are going to but the workers' work channels into.
 	WorkerQueue = make(chan chan LogRequest, nworkers)
 
 	// Now, create all of our workers.
 	for i := 0; i < nworkers; i++ {
 		logger.Info("Starting worker ", i+1)
-		worker := NewWorker(i+1, WorkerQueue, logger)
+		worker := NewWorker(i+1, WorkerQueue, nil, retries, logger)
+		worker.BlobSink = sink
 		worker.Start()
 	}
 
@@ -46,3 +62,22 @@ func StartDispatcher(nworkers int, logger *zap.SugaredLogger) {
 		}
 	}()
 }
+
+// startPartitionedDispatcher sends the events of the same inference request to the same worker, so they are sent in
+// order even when an event is sent again
+func startPartitionedDispatcher(nworkers int, delivery *Delivery, retries *RetryQueue, logger *zap.SugaredLogger) {
+	workers := make([]chan LogRequest, nworkers)
+	for i := 0; i < nworkers; i++ {
+		logger.Info("Starting worker ", i+1)
+		worker := NewWorker(i+1, nil, delivery, retries, logger)
+		worker.Work = make(chan LogRequest, LoggerWorkerQueueSize)
+		worker.Start()
+		workers[i] = worker.Work
+	}
+
+	go func() {
+		for work := range WorkQueue {
+			workers[partition(work.Id, nworkers)] <- work
+		}
+	}()
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// EncryptionAlgorithm is reported in the EncryptionAlgorithmAttr extension of encrypted events
+	EncryptionAlgorithm = "AES256-GCM"
+	// EncryptedContentType is the data content type of encrypted events, the original content type is
+	// reported in the EncryptedContentTypeAttr extension
+	EncryptedContentType = "application/octet-stream"
+
+	dataKeySize = 32
+)
+
+// EncryptedPayload is a payload sealed with a data key, the data key itself is wrapped by the key identified by
+// KeyId. The key id and the algorithm are authenticated as GCM additional data of both the ciphertext and the
+// wrapped key, so they cannot be swapped in the event attributes without failing the decryption.
+type EncryptedPayload struct {
+	Ciphertext []byte
+	WrappedKey []byte
+	KeyId      string
+	Algorithm  string
+}
+
+// KeyWrapper wraps and unwraps the data keys with a key encryption key which is never handed to the Encryptor, the
+// KMS backed implementation keeps the key encryption key in the KMS.
+type KeyWrapper interface {
+	// KeyId identifies the key encryption key, it is reported in the EncryptionKeyIdAttr extension
+	KeyId() string
+	WrapKey(dataKey []byte, additionalData []byte) ([]byte, error)
+	UnwrapKey(wrappedKey []byte, additionalData []byte) ([]byte, error)
+}
+
+// staticKeyWrapper wraps the data keys locally with AES-GCM. This is the static key mode: the key encryption key is
+// a 256 bit key read from a Secret of the InferenceService namespace, not a KMS key, so anyone who can read the
+// Secret can decrypt the logged payloads and rotating it requires restarting the pods.
+type staticKeyWrapper struct {
+	keyId string
+	kek   cipher.AEAD
+}
+
+// NewStaticKeyWrapper creates a KeyWrapper in static key mode from a 256 bit key encryption key
+func NewStaticKeyWrapper(keyId string, key []byte) (KeyWrapper, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("key encryption key %s must be %d bytes, got %d", keyId, dataKeySize, len(key))
+	}
+	kek, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &staticKeyWrapper{keyId: keyId, kek: kek}, nil
+}
+
+// LoadStaticKeyWrapper reads the base64 encoded key encryption key from keyFile, usually mounted from a secret
+// of the InferenceService namespace.
+func LoadStaticKeyWrapper(keyId string, keyFile string) (KeyWrapper, error) {
+	encoded, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("while reading key file %s: %s", keyFile, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("while decoding key file %s: %s", keyFile, err)
+	}
+	return NewStaticKeyWrapper(keyId, key)
+}
+
+func (w *staticKeyWrapper) KeyId() string {
+	return w.keyId
+}
+
+func (w *staticKeyWrapper) WrapKey(dataKey []byte, additionalData []byte) ([]byte, error) {
+	return seal(w.kek, dataKey, additionalData)
+}
+
+func (w *staticKeyWrapper) UnwrapKey(wrappedKey []byte, additionalData []byte) ([]byte, error) {
+	return open(w.kek, wrappedKey, additionalData)
+}
+
+// Encryptor envelope encrypts the logged payloads so they are never sent to the sink in plaintext
+type Encryptor struct {
+	wrapper KeyWrapper
+}
+
+// NewEncryptor creates an Encryptor wrapping its data keys with wrapper
+func NewEncryptor(wrapper KeyWrapper) *Encryptor {
+	return &Encryptor{wrapper: wrapper}
+}
+
+// Encrypt seals the payload with a fresh data key which is then wrapped with the key encryption key
+func (e *Encryptor) Encrypt(plaintext []byte) (*EncryptedPayload, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	dek, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	keyId := e.wrapper.KeyId()
+	additionalData := encryptionAdditionalData(keyId, EncryptionAlgorithm)
+	ciphertext, err := seal(dek, plaintext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := e.wrapper.WrapKey(dataKey, additionalData)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedPayload{
+		Ciphertext: ciphertext,
+		WrappedKey: wrappedKey,
+		KeyId:      keyId,
+		Algorithm:  EncryptionAlgorithm,
+	}, nil
+}
+
+// Decrypt unwraps the data key and opens the payload, it is the inverse of Encrypt
+func (e *Encryptor) Decrypt(payload *EncryptedPayload) ([]byte, error) {
+	if payload.KeyId != e.wrapper.KeyId() {
+		return nil, fmt.Errorf("payload was encrypted with key %s, not %s", payload.KeyId, e.wrapper.KeyId())
+	}
+	if payload.Algorithm != EncryptionAlgorithm {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", payload.Algorithm)
+	}
+	additionalData := encryptionAdditionalData(payload.KeyId, payload.Algorithm)
+	dataKey, err := e.wrapper.UnwrapKey(payload.WrappedKey, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("while unwrapping data key: %s", err)
+	}
+	dek, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dek, payload.Ciphertext, additionalData)
+}
+
+// encryptionAdditionalData binds the key id and algorithm CloudEvent attributes to the ciphertext
+func encryptionAdditionalData(keyId string, algorithm string) []byte {
+	return []byte(EncryptionKeyIdAttr + "=" + keyId + "\n" + EncryptionAlgorithmAttr + "=" + algorithm)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestEncryptor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	key := bytes.Repeat([]byte{1}, 32)
+
+	wrapper, err := NewStaticKeyWrapper("default/logger-key", key)
+	g.Expect(err).To(gomega.BeNil())
+	encryptor := NewEncryptor(wrapper)
+	payload, err := encryptor.Encrypt([]byte(`{"instances":[[0,0,0]]}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(payload.KeyId).To(gomega.Equal("default/logger-key"))
+	g.Expect(payload.Algorithm).To(gomega.Equal(EncryptionAlgorithm))
+	g.Expect(payload.Ciphertext).NotTo(gomega.ContainSubstring("instances"))
+
+	plaintext, err := encryptor.Decrypt(payload)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(plaintext)).To(gomega.Equal(`{"instances":[[0,0,0]]}`))
+
+	otherWrapper, err := NewStaticKeyWrapper("default/logger-key", bytes.Repeat([]byte{2}, 32))
+	g.Expect(err).To(gomega.BeNil())
+	_, err = NewEncryptor(otherWrapper).Decrypt(payload)
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	_, err = NewStaticKeyWrapper("default/logger-key", []byte("short"))
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestEncryptorAdditionalData(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	wrapper, err := NewStaticKeyWrapper("default/logger-key", bytes.Repeat([]byte{1}, 32))
+	g.Expect(err).To(gomega.BeNil())
+	encryptor := NewEncryptor(wrapper)
+	payload, err := encryptor.Encrypt([]byte(`{"instances":[[0,0,0]]}`))
+	g.Expect(err).To(gomega.BeNil())
+
+	// The key id and algorithm attributes are authenticated with the ciphertext
+	tampered := *payload
+	tampered.Algorithm = "AES128-GCM"
+	_, err = encryptor.Decrypt(&tampered)
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	aliasWrapper, err := NewStaticKeyWrapper("default/other-key", bytes.Repeat([]byte{1}, 32))
+	g.Expect(err).To(gomega.BeNil())
+	tampered = *payload
+	tampered.KeyId = "default/other-key"
+	_, err = NewEncryptor(aliasWrapper).Decrypt(&tampered)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("while unwrapping data key")))
+}
+
+func TestLoadStaticKeyWrapper(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	keyFile, err := ioutil.TempFile("", "key")
+	g.Expect(err).To(gomega.BeNil())
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.WriteString(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)) + "\n")
+	g.Expect(err).To(gomega.BeNil())
+	keyFile.Close()
+
+	wrapper, err := LoadStaticKeyWrapper("default/logger-key", keyFile.Name())
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(wrapper.KeyId()).To(gomega.Equal("default/logger-key"))
+	_, err = LoadStaticKeyWrapper("default/logger-key", "/does/not/exist")
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestLoggerEncryption(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictorRequest := []byte(`{"instances":[[0,0,0]]}`)
+	predictorResponse := []byte(`{"predictions":[1]}`)
+	wrapper, err := NewStaticKeyWrapper("default/logger-key", bytes.Repeat([]byte{1}, 32))
+	g.Expect(err).To(gomega.BeNil())
+	encryptor := NewEncryptor(wrapper)
+
+	responseChan := make(chan []byte)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := ioutil.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(req.Header.Get("Ce-" + EncryptionKeyIdAttr)).To(gomega.Equal("default/logger-key"))
+		g.Expect(req.Header.Get("Ce-" + EncryptionAlgorithmAttr)).To(gomega.Equal(EncryptionAlgorithm))
+		g.Expect(req.Header.Get("Ce-" + EncryptedContentTypeAttr)).To(gomega.Equal("application/json"))
+		wrappedKey, err := base64.StdEncoding.DecodeString(req.Header.Get("Ce-" + EncryptionWrappedKeyAttr))
+		g.Expect(err).To(gomega.BeNil())
+		plaintext, err := encryptor.Decrypt(&EncryptedPayload{
+			Ciphertext: b,
+			WrappedKey: wrappedKey,
+			KeyId:      req.Header.Get("Ce-" + EncryptionKeyIdAttr),
+			Algorithm:  req.Header.Get("Ce-" + EncryptionAlgorithmAttr),
+		})
+		g.Expect(err).To(gomega.BeNil())
+		responseChan <- plaintext
+		_, err = rw.Write([]byte(`ok`))
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer logSvc.Close()
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, err := rw.Write(predictorResponse)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+
+	r := httptest.NewRequest("POST", "http://a", bytes.NewReader(predictorRequest))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	StartDispatcher(5, nil, nil, logger)
+	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", encryptor, nil, nil, nil, httpProxy)
+	oh.ServeHTTP(w, r)
+
+	logged := []string{string(<-responseChan), string(<-responseChan)}
+	g.Expect(logged).To(gomega.ConsistOf(string(predictorRequest), string(predictorResponse)))
+}
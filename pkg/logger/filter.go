@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var responseCodeRegex = regexp.MustCompile("^[1-5]([0-9]{2}|xx)$")
+
+// Filter selects the requests whose payloads are logged. The sampling and the opt out header are decided before the
+// payload of the request is read, so the requests which are not logged are proxied without being buffered. A nil
+// filter logs every request.
+type Filter struct {
+	samplingPercent int
+	optOutHeader    string
+	responseCodes   []string
+	random          func(n int) int
+}
+
+// NewFilter returns the filter logging the samplingPercent percentage of the requests which do not set the opt out
+// header to true, the requests are only logged when their response status code matches one of the response codes, e.g.
+// 200 or 5xx, when the response codes are set
+func NewFilter(samplingPercent int, optOutHeader string, responseCodes []string) (*Filter, error) {
+	if samplingPercent < 0 || samplingPercent > 100 {
+		return nil, fmt.Errorf("invalid sampling percent %d, must be between 0 and 100", samplingPercent)
+	}
+	for _, code := range responseCodes {
+		if !responseCodeRegex.MatchString(code) {
+			return nil, fmt.Errorf("invalid response code %q, must be a status code such as 200 or a class such as 5xx",
+				code)
+		}
+	}
+	return &Filter{
+		samplingPercent: samplingPercent,
+		optOutHeader:    http.CanonicalHeaderKey(optOutHeader),
+		responseCodes:   responseCodes,
+		random:          rand.Intn,
+	}, nil
+}
+
+// ParseResponseCodes returns the comma separated response codes
+func ParseResponseCodes(value string) []string {
+	var codes []string
+	for _, code := range strings.Split(value, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// Sample returns whether the request is logged, the request is not logged when it opted out or was not sampled
+func (f *Filter) Sample(r *http.Request) bool {
+	if f == nil {
+		return true
+	}
+	if f.optOutHeader != "" && strings.EqualFold(r.Header.Get(f.optOutHeader), "true") {
+		return false
+	}
+	return f.samplingPercent >= 100 || f.random(100) < f.samplingPercent
+}
+
+// FiltersResponses returns whether the requests are only logged once their response status code is known
+func (f *Filter) FiltersResponses() bool {
+	return f != nil && len(f.responseCodes) > 0
+}
+
+// MatchResponse returns whether the request and the response are logged for the response status code, only the 200
+// responses are logged without response codes
+func (f *Filter) MatchResponse(code int) bool {
+	if !f.FiltersResponses() {
+		return code == http.StatusOK
+	}
+	status := strconv.Itoa(code)
+	for _, responseCode := range f.responseCodes {
+		if responseCode == status || (strings.HasSuffix(responseCode, "xx") && responseCode[0] == status[0]) {
+			return true
+		}
+	}
+	return false
+}
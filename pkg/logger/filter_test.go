@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestNewFilter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := NewFilter(150, "", nil)
+	g.Expect(err).To(gomega.MatchError("invalid sampling percent 150, must be between 0 and 100"))
+	_, err = NewFilter(100, "", []string{"200", "6xx"})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`invalid response code "6xx"`)))
+	g.Expect(ParseResponseCodes(" 200, 5xx,,")).To(gomega.Equal([]string{"200", "5xx"}))
+	g.Expect(ParseResponseCodes("")).To(gomega.BeEmpty())
+}
+
+func TestFilterSample(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	filter, err := NewFilter(50, "x-no-log", nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	random := 20
+	filter.random = func(n int) int { return random }
+
+	r := httptest.NewRequest("POST", "http://a", nil)
+	g.Expect(filter.Sample(r)).To(gomega.BeTrue())
+	random = 70
+	g.Expect(filter.Sample(r)).To(gomega.BeFalse())
+	random = 20
+	r.Header.Set("X-No-Log", "True")
+	g.Expect(filter.Sample(r)).To(gomega.BeFalse())
+	r.Header.Set("X-No-Log", "false")
+	g.Expect(filter.Sample(r)).To(gomega.BeTrue())
+
+	var nilFilter *Filter
+	g.Expect(nilFilter.Sample(r)).To(gomega.BeTrue())
+	g.Expect(nilFilter.MatchResponse(http.StatusOK)).To(gomega.BeTrue())
+	g.Expect(nilFilter.MatchResponse(http.StatusInternalServerError)).To(gomega.BeFalse())
+}
+
+func TestFilterMatchResponse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	filter, err := NewFilter(100, "", []string{"400", "5xx"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(filter.FiltersResponses()).To(gomega.BeTrue())
+	g.Expect(filter.MatchResponse(http.StatusOK)).To(gomega.BeFalse())
+	g.Expect(filter.MatchResponse(http.StatusBadRequest)).To(gomega.BeTrue())
+	g.Expect(filter.MatchResponse(http.StatusNotFound)).To(gomega.BeFalse())
+	g.Expect(filter.MatchResponse(http.StatusServiceUnavailable)).To(gomega.BeTrue())
+}
+
+func TestLoggerFilter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	events := make(chan string, 10)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		events <- req.Header.Get("Ce-Type")
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer logSvc.Close()
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/fail" {
+			http.Error(rw, "failed", http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions":[1]}`))
+	})
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logSvcUrl, _ := url.Parse(logSvc.URL)
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	StartDispatcher(1, nil, nil, logger)
+	filter, err := NewFilter(100, "x-no-log", []string{"5xx"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, nil, filter,
+		predictor)
+
+	// The successful and the opted out requests are not logged
+	w := httptest.NewRecorder()
+	oh.ServeHTTP(w, httptest.NewRequest("POST", "http://a/ok", bytes.NewReader([]byte(`{}`))))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	r := httptest.NewRequest("POST", "http://a/fail", bytes.NewReader([]byte(`{}`)))
+	r.Header.Set("X-No-Log", "true")
+	w = httptest.NewRecorder()
+	oh.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	g.Consistently(events, 500*time.Millisecond).ShouldNot(gomega.Receive())
+
+	// The failed requests are logged with their response
+	w = httptest.NewRecorder()
+	oh.ServeHTTP(w, httptest.NewRequest("POST", "http://a/fail", bytes.NewReader([]byte(`{}`))))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	received := []string{}
+	for i := 0; i < 2; i++ {
+		var event string
+		g.Eventually(events).Should(gomega.Receive(&event))
+		received = append(received, event)
+	}
+	g.Expect(received).To(gomega.ConsistOf(CEInferenceRequest, CEInferenceResponse))
+}
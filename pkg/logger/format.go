@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// SchemaVersionAttr is set on the events when a schema version is configured
+const SchemaVersionAttr = "schemaversion"
+
+// EventFormat customizes the CloudEvents sent by the logger for the consumers with strict event contracts
+type EventFormat struct {
+	// Structured sends the events in the structured mode, the attributes and the data in a JSON envelope, instead of
+	// the binary mode
+	Structured bool
+	// The types of the request and response events and their source, the defaults are kept when empty
+	RequestType  string
+	ResponseType string
+	Source       string
+	// SchemaVersion is the version of the event contract, set as schemaversion extension when not empty
+	SchemaVersion string
+}
+
+// NewEventFormat renders the event type and source templates for the InferenceService component
+func NewEventFormat(encoding string, typeTemplate string, sourceTemplate string, schemaVersion string,
+	inferenceService string, namespace string, component string, endpoint string) (*EventFormat, error) {
+	format := &EventFormat{SchemaVersion: schemaVersion}
+	switch encoding {
+	case "", constants.LoggerBinaryEncoding:
+	case constants.LoggerStructuredEncoding:
+		format.Structured = true
+	default:
+		return nil, fmt.Errorf("unsupported encoding %s", encoding)
+	}
+	render := func(text string, kind LogRequestType) (string, error) {
+		if text == "" {
+			return "", nil
+		}
+		return v1beta1.RenderLoggerEventTemplate(text, inferenceService, namespace, component, endpoint,
+			strings.ToLower(string(kind)))
+	}
+	var err error
+	if format.RequestType, err = render(typeTemplate, InferenceRequest); err != nil {
+		return nil, fmt.Errorf("invalid event type template: %v", err)
+	}
+	if format.ResponseType, err = render(typeTemplate, InferenceResponse); err != nil {
+		return nil, fmt.Errorf("invalid event type template: %v", err)
+	}
+	if format.Source, err = render(sourceTemplate, InferenceRequest); err != nil {
+		return nil, fmt.Errorf("invalid event source template: %v", err)
+	}
+	return format, nil
+}
+
+// apply sets the type, the source and the schema version of the event
+func (f *EventFormat) apply(event *cloudevents.Event, reqType LogRequestType) {
+	if reqType == InferenceRequest && f.RequestType != "" {
+		event.SetType(f.RequestType)
+	} else if reqType != InferenceRequest && f.ResponseType != "" {
+		event.SetType(f.ResponseType)
+	}
+	if f.Source != "" {
+		event.SetSource(f.Source)
+	}
+	if f.SchemaVersion != "" {
+		event.SetExtension(SchemaVersionAttr, f.SchemaVersion)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestNewEventFormat(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	format, err := NewEventFormat("structured", "com.example.{{ .Component }}.{{ .Kind }}",
+		"/models/{{ .Namespace }}/{{ .InferenceService }}", "2", "sklearn", "default", "predictor", "default")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(format).To(gomega.Equal(&EventFormat{
+		Structured:    true,
+		RequestType:   "com.example.predictor.request",
+		ResponseType:  "com.example.predictor.response",
+		Source:        "/models/default/sklearn",
+		SchemaVersion: "2",
+	}))
+
+	format, err = NewEventFormat("", "", "", "", "sklearn", "default", "predictor", "default")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(format).To(gomega.Equal(&EventFormat{}))
+
+	_, err = NewEventFormat("avro", "", "", "", "sklearn", "default", "predictor", "default")
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = NewEventFormat("binary", "com.example.{{ .Model }}", "", "", "sklearn", "default", "predictor", "default")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestStructuredEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	bodies := make(chan []byte, 1)
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		contentType = req.Header.Get("Content-Type")
+		bodies <- body
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	format, err := NewEventFormat("structured", "com.example.inference.{{ .Kind }}", "/models/{{ .InferenceService }}",
+		"2", "sklearn", "default", "predictor", "default")
+	g.Expect(err).To(gomega.BeNil())
+	request := newTestLogRequest(g, server.URL, "request-1")
+	request.Format = format
+	worker := NewWorker(1, nil, nil, nil, logger)
+	g.Expect(worker.sendCloudEvent(request)).To(gomega.Succeed())
+
+	body := <-bodies
+	g.Expect(contentType).To(gomega.HavePrefix("application/cloudevents+json"))
+	envelope := map[string]interface{}{}
+	g.Expect(json.Unmarshal(body, &envelope)).To(gomega.Succeed())
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("id", "request-1"))
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("type", "com.example.inference.request"))
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("source", "/models/sklearn"))
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("schemaversion", "2"))
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("inferenceservicename", "sklearn"))
+	// The JSON payloads are embedded in the envelope
+	g.Expect(envelope).To(gomega.HaveKeyWithValue("data",
+		map[string]interface{}{"instances": []interface{}{[]interface{}{0.0, 0.0, 0.0}}}))
+}
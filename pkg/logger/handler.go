@@ -22,10 +22,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"sync"
 
 	"github.com/go-logr/logr"
 	guuid "github.com/google/uuid"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/grpcproxy"
 	"knative.dev/pkg/network"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -33,6 +37,7 @@ import (
 
 type LoggerHandler struct {
 	log              logr.Logger
+	mu               sync.RWMutex
 	logUrl           *url.URL
 	sourceUri        *url.URL
 	logMode          v1beta1.LoggerType
@@ -40,11 +45,16 @@ type LoggerHandler struct {
 	namespace        string
 	component        string
 	endpoint         string
+	encryptor        *Encryptor
+	format           *EventFormat
+	transformer      Transformer
+	filter           *Filter
 	next             http.Handler
 }
 
 func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
-	inferenceService string, namespace string, endpoint string, component string, next http.Handler) http.Handler {
+	inferenceService string, namespace string, endpoint string, component string, encryptor *Encryptor,
+	format *EventFormat, transformer Transformer, filter *Filter, next http.Handler) *LoggerHandler {
 	logf.SetLogger(zap.New())
 	return &LoggerHandler{
 		log:              logf.Log.WithName("Logger"),
@@ -55,10 +65,23 @@ func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 		namespace:        namespace,
 		component:        component,
 		endpoint:         endpoint,
+		encryptor:        encryptor,
+		format:           format,
+		transformer:      transformer,
+		filter:           filter,
 		next:             next,
 	}
 }
 
+// Configure changes the url and the mode of the logger without a restart, the requests in flight are logged with the
+// previous configuration
+func (eh *LoggerHandler) Configure(logUrl *url.URL, logMode v1beta1.LoggerType) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	eh.logUrl = logUrl
+	eh.logMode = logMode
+}
+
 func getOrCreateID(r *http.Request) string {
 	id := r.Header.Get(CloudEventsIdHeader)
 	if id == "" {
@@ -75,6 +98,11 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	// The requests which are not logged are proxied without buffering their payloads
+	if !eh.filter.Sample(r) {
+		eh.next.ServeHTTP(w, r)
+		return
+	}
 	// Read Payload
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -82,58 +110,88 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	eh.mu.RLock()
+	logUrl, logMode := eh.logUrl, eh.logMode
+	eh.mu.RUnlock()
+
 	// Get or Create an ID
 	id := getOrCreateID(r)
-	contentType := r.Header.Get("Content-Type")
-	// log Request
-	if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest {
-		if err := QueueLogRequest(LogRequest{
-			Url:              eh.logUrl,
-			Bytes:            &body,
+	logPayload := func(payload []byte, contentType string, reqType LogRequestType) error {
+		return QueueLogRequest(LogRequest{
+			Url:              logUrl,
+			Bytes:            &payload,
 			ContentType:      contentType,
-			ReqType:          InferenceRequest,
+			ReqType:          reqType,
 			Id:               id,
 			SourceUri:        eh.sourceUri,
 			InferenceService: eh.inferenceService,
 			Namespace:        eh.namespace,
 			Endpoint:         eh.endpoint,
 			Component:        eh.component,
-		}); err != nil {
+			Encryptor:        eh.encryptor,
+			Format:           eh.format,
+			Transformer:      eh.transformer,
+		})
+	}
+	requestContentType := r.Header.Get("Content-Type")
+	// The messages of the unary gRPC calls are logged without their length prefix
+	isGrpc := grpcproxy.IsGrpc(r)
+	requestPayload := body
+	if message, err := grpcproxy.Message(body); isGrpc && err == nil {
+		requestPayload, requestContentType = message, constants.ProtobufContentType
+	}
+	logRequest := logMode == v1beta1.LogAll || logMode == v1beta1.LogRequest
+	// log Request, it is logged with the response when the responses are filtered by their status code
+	if logRequest && !eh.filter.FiltersResponses() {
+		if err := logPayload(requestPayload, requestContentType, InferenceRequest); err != nil {
 			eh.log.Error(err, "Failed to log request")
 		}
 	}
 
 	// Proxy Request
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+	// The body is forwarded with a known length, e.g. for the chunked protobuf payloads
+	r.ContentLength = int64(len(body))
 	rr := httptest.NewRecorder()
 	eh.next.ServeHTTP(rr, r)
 	responseBody := rr.Body.Bytes()
-	contentType = rr.Header().Get("Content-Type")
-	if contentType != "" {
+	contentType := rr.Header().Get("Content-Type")
+	code := rr.Code
+	if isGrpc {
+		// The status of the gRPC calls is in the trailers of the response
+		code = grpcproxy.HTTPStatus(grpcproxy.Status(rr))
+		if message, err := grpcproxy.Message(responseBody); err == nil {
+			responseBody, contentType = message, constants.ProtobufContentType
+		}
+	} else if contentType != "" {
 		w.Header().Set("Content-Type", contentType)
 	}
-	// log response if OK
-	if rr.Code == http.StatusOK {
-		if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse {
-			if err := QueueLogRequest(LogRequest{
-				Url:              eh.logUrl,
-				Bytes:            &responseBody,
-				ContentType:      contentType,
-				ReqType:          InferenceResponse,
-				Id:               id,
-				SourceUri:        eh.sourceUri,
-				InferenceService: eh.inferenceService,
-				Namespace:        eh.namespace,
-				Endpoint:         eh.endpoint,
-				Component:        eh.component,
-			}); err != nil {
+	// log response if its status code matches, only OK by default
+	if eh.filter.MatchResponse(code) {
+		if logRequest && eh.filter.FiltersResponses() {
+			if err := logPayload(requestPayload, requestContentType, InferenceRequest); err != nil {
+				eh.log.Error(err, "Failed to log request")
+			}
+		}
+		if logMode == v1beta1.LogAll || logMode == v1beta1.LogResponse {
+			if err := logPayload(responseBody, contentType, InferenceResponse); err != nil {
 				eh.log.Error(err, "Failed to log response")
 			}
 		}
-	} else {
-		eh.log.Info("Failed to proxy request", "status code", rr.Code)
+	}
+	if code != http.StatusOK {
+		eh.log.Info("Failed to proxy request", "status code", code)
+	}
+	if isGrpc {
+		if err := grpcproxy.WriteResponse(w, rr); err != nil {
+			eh.log.Error(err, "Failed to write response")
+		}
+		return
 	}
 
+	if len(responseBody) > 0 {
+		w.Header().Set("Content-Length", strconv.Itoa(len(responseBody)))
+	}
 	w.WriteHeader(rr.Code)
 	_, err = w.Write(rr.Body.Bytes())
 	if err != nil {
@@ -18,7 +18,9 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
@@ -26,8 +28,16 @@ import (
 	"testing"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	pkglogging "knative.dev/pkg/logging"
+	pkgnet "knative.dev/pkg/network"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
@@ -76,9 +86,9 @@ func TestLogger(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(5, logger)
+	StartDispatcher(5, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, nil, nil, httpProxy)
 
 	oh.ServeHTTP(w, r)
 
@@ -119,11 +129,141 @@ func TestBadResponse(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(1, logger)
+	StartDispatcher(1, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, nil, nil, httpProxy)
 
 	oh.ServeHTTP(w, r)
 	g.Expect(w.Code).To(gomega.Equal(400))
 	g.Expect(w.Body.String()).To(gomega.Equal(predictorResponse))
 }
+
+func TestLoggerConfigure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	events := make(chan string, 10)
+	newLogSvc := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			events <- name + " " + req.Header.Get("Ce-Type")
+			rw.WriteHeader(http.StatusAccepted)
+		}))
+	}
+	previous, current := newLogSvc("previous"), newLogSvc("current")
+	defer previous.Close()
+	defer current.Close()
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"predictions":[1]}`))
+	}))
+	defer predictor.Close()
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	previousUrl, _ := url.Parse(previous.URL)
+	currentUrl, _ := url.Parse(current.URL)
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	targetUri, _ := url.Parse(predictor.URL)
+	StartDispatcher(1, nil, nil, logger)
+	oh := New(previousUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, nil, nil,
+		httputil.NewSingleHostReverseProxy(targetUri))
+
+	// Only the responses are logged to the new url
+	oh.Configure(currentUrl, v1beta1.LogResponse)
+	w := httptest.NewRecorder()
+	oh.ServeHTTP(w, httptest.NewRequest("POST", "http://a", bytes.NewReader([]byte(`{"instances":[[0,0,0]]}`))))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Eventually(events).Should(gomega.Receive(gomega.Equal("current " + CEInferenceResponse)))
+	g.Consistently(events).ShouldNot(gomega.Receive())
+}
+
+// rawCodec sends and receives the raw bytes of the messages
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append((*v.(*[]byte))[:0], data...)
+	return nil
+}
+
+// Name is the name of the protobuf codec so that the servers accept the content subtype of the calls
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+func TestLoggerGrpc(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// The ModelInfer method of the predictor returns the request message reversed, the empty messages fail
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "inference.GRPCInferenceService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "ModelInfer",
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := []byte{}
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				if len(req) == 0 {
+					return nil, status.Error(codes.InvalidArgument, "no inputs")
+				}
+				resp := make([]byte, len(req))
+				for i := range req {
+					resp[i] = req[len(req)-1-i]
+				}
+				return &resp, nil
+			},
+		}},
+	}, struct{}{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(gomega.BeNil())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	type event struct {
+		ceType      string
+		contentType string
+		data        string
+	}
+	events := make(chan event, 10)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body)
+		events <- event{req.Header.Get("Ce-Type"), req.Header.Get("Content-Type"), string(b)}
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer logSvc.Close()
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logSvcUrl, _ := url.Parse(logSvc.URL)
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	StartDispatcher(1, nil, nil, logger)
+	// The agent proxies the gRPC calls over h2c
+	httpProxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: listener.Addr().String()})
+	httpProxy.Transport = pkgnet.NewAutoTransport(10, 10)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, nil, nil,
+		httpProxy)
+	agent := httptest.NewServer(h2c.NewHandler(oh, &http2.Server{}))
+	defer agent.Close()
+	conn, err := grpc.Dial(agent.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).To(gomega.BeNil())
+	defer conn.Close()
+
+	req, resp := []byte("abc"), []byte{}
+	g.Expect(conn.Invoke(context.Background(), "/inference.GRPCInferenceService/ModelInfer", &req, &resp,
+		grpc.ForceCodec(rawCodec{}))).To(gomega.Succeed())
+	g.Expect(string(resp)).To(gomega.Equal("cba"))
+	// The messages are logged without their length prefix
+	g.Eventually(events).Should(gomega.Receive(gomega.Equal(event{CEInferenceRequest, constants.ProtobufContentType, "abc"})))
+	g.Eventually(events).Should(gomega.Receive(gomega.Equal(event{CEInferenceResponse, constants.ProtobufContentType, "cba"})))
+
+	// The status of the failed calls is returned, only the successful responses are logged by default
+	req = []byte{}
+	err = conn.Invoke(context.Background(), "/inference.GRPCInferenceService/ModelInfer", &req, &resp,
+		grpc.ForceCodec(rawCodec{}))
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.InvalidArgument))
+	g.Expect(status.Convert(err).Message()).To(gomega.Equal("no inputs"))
+	g.Eventually(events).Should(gomega.Receive(gomega.Equal(event{CEInferenceRequest, constants.ProtobufContentType, ""})))
+	g.Consistently(events).ShouldNot(gomega.Receive())
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// kmsEncryptionContextKey holds the additional data in the encryption context of the KMS calls, so the wrapped key is
+// bound to the key id and algorithm of the event just like with the static key
+const kmsEncryptionContextKey = "kserve-log-event"
+
+// ParseKMSKeyRef returns the arn of the AWS KMS key or alias of the aws-kms://<arn> key reference
+func ParseKMSKeyRef(keyRef string) (arn.ARN, error) {
+	if !strings.HasPrefix(keyRef, constants.AWSKMSKeyPrefix) {
+		return arn.ARN{}, fmt.Errorf("unsupported KMS key reference %q, must start with %s", keyRef, constants.AWSKMSKeyPrefix)
+	}
+	keyArn, err := arn.Parse(strings.TrimPrefix(keyRef, constants.AWSKMSKeyPrefix))
+	if err != nil {
+		return arn.ARN{}, fmt.Errorf("invalid KMS key reference %q: %s", keyRef, err)
+	}
+	if keyArn.Service != kms.ServiceName || keyArn.Region == "" ||
+		(!strings.HasPrefix(keyArn.Resource, "key/") && !strings.HasPrefix(keyArn.Resource, "alias/")) {
+		return arn.ARN{}, fmt.Errorf("invalid KMS key reference %q, must be the arn of a KMS key or alias", keyRef)
+	}
+	return keyArn, nil
+}
+
+// awsKMSKeyWrapper wraps the data keys with a key of AWS KMS, the key encryption key never leaves the KMS. The agent
+// is authorized with the credentials or the workload identity of the service account of the InferenceService, so
+// decrypting the logged payloads requires a kms:Decrypt grant on the key rather than reading a Secret.
+type awsKMSKeyWrapper struct {
+	keyRef string
+	keyArn string
+	client kmsiface.KMSAPI
+}
+
+// NewKMSKeyWrapper creates a KeyWrapper wrapping the data keys with the KMS key of the key reference
+func NewKMSKeyWrapper(keyRef string) (KeyWrapper, error) {
+	keyArn, err := ParseKMSKeyRef(keyRef)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(keyArn.Region)})
+	if err != nil {
+		return nil, fmt.Errorf("while creating the KMS session of %s: %s", keyRef, err)
+	}
+	return newAWSKMSKeyWrapper(keyRef, keyArn.String(), kms.New(sess)), nil
+}
+
+func newAWSKMSKeyWrapper(keyRef string, keyArn string, client kmsiface.KMSAPI) KeyWrapper {
+	return &awsKMSKeyWrapper{keyRef: keyRef, keyArn: keyArn, client: client}
+}
+
+func (w *awsKMSKeyWrapper) KeyId() string {
+	return w.keyRef
+}
+
+func (w *awsKMSKeyWrapper) WrapKey(dataKey []byte, additionalData []byte) ([]byte, error) {
+	output, err := w.client.Encrypt(&kms.EncryptInput{
+		KeyId:             aws.String(w.keyArn),
+		Plaintext:         dataKey,
+		EncryptionContext: kmsEncryptionContext(additionalData),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while wrapping the data key with %s: %s", w.keyRef, err)
+	}
+	return output.CiphertextBlob, nil
+}
+
+func (w *awsKMSKeyWrapper) UnwrapKey(wrappedKey []byte, additionalData []byte) ([]byte, error) {
+	output, err := w.client.Decrypt(&kms.DecryptInput{
+		KeyId:             aws.String(w.keyArn),
+		CiphertextBlob:    wrappedKey,
+		EncryptionContext: kmsEncryptionContext(additionalData),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while unwrapping the data key with %s: %s", w.keyRef, err)
+	}
+	return output.Plaintext, nil
+}
+
+func kmsEncryptionContext(additionalData []byte) map[string]*string {
+	return map[string]*string{kmsEncryptionContextKey: aws.String(string(additionalData))}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+)
+
+const testKeyArn = "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+// fakeKMS seals the data keys with a local key standing in for the KMS key, bound to the encryption context
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	keyArn string
+	kek    cipher.AEAD
+}
+
+func (f *fakeKMS) Encrypt(input *kms.EncryptInput) (*kms.EncryptOutput, error) {
+	if aws.StringValue(input.KeyId) != f.keyArn {
+		return nil, fmt.Errorf("NotFoundException: key %s", aws.StringValue(input.KeyId))
+	}
+	blob, err := seal(f.kek, input.Plaintext, []byte(aws.StringValue(input.EncryptionContext[kmsEncryptionContextKey])))
+	if err != nil {
+		return nil, err
+	}
+	return &kms.EncryptOutput{CiphertextBlob: blob, KeyId: input.KeyId}, nil
+}
+
+func (f *fakeKMS) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	if aws.StringValue(input.KeyId) != f.keyArn {
+		return nil, fmt.Errorf("IncorrectKeyException: key %s", aws.StringValue(input.KeyId))
+	}
+	plaintext, err := open(f.kek, input.CiphertextBlob, []byte(aws.StringValue(input.EncryptionContext[kmsEncryptionContextKey])))
+	if err != nil {
+		return nil, fmt.Errorf("InvalidCiphertextException: %s", err)
+	}
+	return &kms.DecryptOutput{Plaintext: plaintext, KeyId: input.KeyId}, nil
+}
+
+func TestKMSKeyWrapper(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	kek, err := newGCM(bytes.Repeat([]byte{1}, 32))
+	g.Expect(err).To(gomega.BeNil())
+	client := &fakeKMS{keyArn: testKeyArn, kek: kek}
+
+	encryptor := NewEncryptor(newAWSKMSKeyWrapper(constants.AWSKMSKeyPrefix+testKeyArn, testKeyArn, client))
+	payload, err := encryptor.Encrypt([]byte(`{"instances":[[0,0,0]]}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(payload.KeyId).To(gomega.Equal(constants.AWSKMSKeyPrefix + testKeyArn))
+	g.Expect(payload.Ciphertext).NotTo(gomega.ContainSubstring("instances"))
+
+	plaintext, err := encryptor.Decrypt(payload)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(plaintext)).To(gomega.Equal(`{"instances":[[0,0,0]]}`))
+
+	// The wrapped key is bound to the algorithm through the encryption context
+	tampered := *payload
+	tampered.Algorithm = "AES128-GCM"
+	_, err = encryptor.Decrypt(&tampered)
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	otherArn := "arn:aws:kms:us-east-1:111122223333:key/other"
+	_, err = NewEncryptor(newAWSKMSKeyWrapper(constants.AWSKMSKeyPrefix+otherArn, otherArn, client)).Encrypt([]byte("{}"))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("while wrapping the data key")))
+}
+
+func TestParseKMSKeyRef(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		keyRef  string
+		region  string
+		invalid bool
+	}{
+		"Key": {
+			keyRef: constants.AWSKMSKeyPrefix + testKeyArn,
+			region: "us-east-1",
+		},
+		"Alias": {
+			keyRef: constants.AWSKMSKeyPrefix + "arn:aws:kms:eu-west-1:111122223333:alias/kserve-logs",
+			region: "eu-west-1",
+		},
+		"MissingPrefix": {
+			keyRef:  testKeyArn,
+			invalid: true,
+		},
+		"OtherService": {
+			keyRef:  constants.AWSKMSKeyPrefix + "arn:aws:s3:::bucket",
+			invalid: true,
+		},
+		"NotAnArn": {
+			keyRef:  constants.AWSKMSKeyPrefix + "alias/kserve-logs",
+			invalid: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		keyArn, err := ParseKMSKeyRef(scenario.keyRef)
+		if scenario.invalid {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+			continue
+		}
+		g.Expect(err).To(gomega.BeNil(), name)
+		g.Expect(keyArn.Region).To(gomega.Equal(scenario.region), name)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// DefaultRedactMask replaces the masked values when the redaction does not set a mask
+const DefaultRedactMask = "[REDACTED]"
+
+var redactPathSegmentRegex = regexp.MustCompile(`^(\.([A-Za-z0-9_-]+)|\.\*|\[([0-9]+)\]|\[\*\])`)
+
+// Redactor is a custom redaction, e.g. a detection of the personal data in free text, compiled into the agent. It is
+// given the decoded JSON payload, whose objects are map[string]interface{}, arrays []interface{} and numbers
+// json.Number, and returns the redacted payload.
+type Redactor interface {
+	Redact(document interface{}, mask string) (interface{}, error)
+}
+
+// RedactorFunc adapts a function to the Redactor interface
+type RedactorFunc func(document interface{}, mask string) (interface{}, error)
+
+func (f RedactorFunc) Redact(document interface{}, mask string) (interface{}, error) {
+	return f(document, mask)
+}
+
+var (
+	redactorsMu sync.RWMutex
+	redactors   = map[string]Redactor{}
+)
+
+// RegisterRedactor registers the redactor the InferenceServices refer to by name in the redactors of their logger. It
+// is called from the init function of the package implementing the redactor, which is imported by the agent.
+func RegisterRedactor(name string, redactor Redactor) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	redactors[name] = redactor
+}
+
+// RegisteredRedactors returns the sorted names of the registered redactors
+func RegisteredRedactors() []string {
+	redactorsMu.RLock()
+	defer redactorsMu.RUnlock()
+	names := make([]string, 0, len(redactors))
+	for name := range redactors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// redactSegment is a segment of a redacted JSON path, an object field or an array item, any of them when wildcard
+type redactSegment struct {
+	field    string
+	item     bool
+	index    int
+	wildcard bool
+}
+
+// RedactTransformer masks the values of the JSON payloads at the JSON paths, the substrings of the string values
+// matching the patterns, then applies the custom redactors. Like the other transformers it fails for the payloads
+// which are not JSON, so their events are dropped instead of being logged unmasked.
+type RedactTransformer struct {
+	paths     [][]redactSegment
+	patterns  []*regexp.Regexp
+	redactors []Redactor
+	mask      string
+}
+
+// NewRedactTransformer returns the transformer of the redaction, it fails when a path or a pattern is malformed or a
+// redactor is not registered
+func NewRedactTransformer(spec v1beta1.LoggerRedactSpec) (*RedactTransformer, error) {
+	t := &RedactTransformer{mask: spec.Mask}
+	if t.mask == "" {
+		t.mask = DefaultRedactMask
+	}
+	for _, path := range spec.Paths {
+		segments, err := parseRedactPath(path)
+		if err != nil {
+			return nil, err
+		}
+		t.paths = append(t.paths, segments)
+	}
+	for _, pattern := range spec.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %v", pattern, err)
+		}
+		t.patterns = append(t.patterns, re)
+	}
+	redactorsMu.RLock()
+	defer redactorsMu.RUnlock()
+	for _, name := range spec.Redactors {
+		redactor, ok := redactors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redactor %q, the agent registers %v", name, RegisteredRedactors())
+		}
+		t.redactors = append(t.redactors, redactor)
+	}
+	return t, nil
+}
+
+// parseRedactPath parses a JSON path such as $.instances[*].email
+func parseRedactPath(path string) ([]redactSegment, error) {
+	if len(path) < 2 || path[0] != '$' {
+		return nil, fmt.Errorf("invalid redact path %q, must start with $ followed by fields or items", path)
+	}
+	var segments []redactSegment
+	for rest := path[1:]; rest != ""; {
+		match := redactPathSegmentRegex.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("invalid redact path %q at %q", path, rest)
+		}
+		switch {
+		case match[2] != "":
+			segments = append(segments, redactSegment{field: match[2]})
+		case match[3] != "":
+			index, err := strconv.Atoi(match[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid redact path %q: %v", path, err)
+			}
+			segments = append(segments, redactSegment{item: true, index: index})
+		default:
+			segments = append(segments, redactSegment{item: match[0] == "[*]", wildcard: true})
+		}
+		rest = rest[len(match[0]):]
+	}
+	return segments, nil
+}
+
+func (t *RedactTransformer) Transform(ctx context.Context, payload Payload) (Payload, error) {
+	document, err := decodeJSONPayload(payload, "redact")
+	if err != nil {
+		return payload, err
+	}
+	for _, path := range t.paths {
+		document = t.maskPath(document, path)
+	}
+	if len(t.patterns) > 0 {
+		document = t.maskPatterns(document)
+	}
+	for _, redactor := range t.redactors {
+		if document, err = redactor.Redact(document, t.mask); err != nil {
+			return payload, fmt.Errorf("cannot redact the payload: %v", err)
+		}
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return payload, err
+	}
+	payload.Data = data
+	return payload, nil
+}
+
+// maskPath replaces the values at the path with the mask, the paths which do not match the payload are ignored
+func (t *RedactTransformer) maskPath(value interface{}, path []redactSegment) interface{} {
+	if len(path) == 0 {
+		return t.mask
+	}
+	segment, rest := path[0], path[1:]
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if segment.item {
+			break
+		}
+		if segment.wildcard {
+			for name, field := range v {
+				v[name] = t.maskPath(field, rest)
+			}
+		} else if field, ok := v[segment.field]; ok {
+			v[segment.field] = t.maskPath(field, rest)
+		}
+	case []interface{}:
+		if !segment.item {
+			break
+		}
+		if segment.wildcard {
+			for i, item := range v {
+				v[i] = t.maskPath(item, rest)
+			}
+		} else if segment.index < len(v) {
+			v[segment.index] = t.maskPath(v[segment.index], rest)
+		}
+	}
+	return value
+}
+
+// maskPatterns replaces the substrings of the string values matching the patterns with the mask, at any depth
+func (t *RedactTransformer) maskPatterns(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		for _, pattern := range t.patterns {
+			v = pattern.ReplaceAllLiteralString(v, t.mask)
+		}
+		return v
+	case map[string]interface{}:
+		for name, field := range v {
+			v[name] = t.maskPatterns(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = t.maskPatterns(item)
+		}
+	}
+	return value
+}
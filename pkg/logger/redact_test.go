@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestRedactTransformer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	data := `{"instances":[{"email":"jane@example.com","age":42,"note":"ssn 123-45-6789"},{"email":"john@example.com"}],` +
+		`"inputs":[{"name":"x","data":[1,2]},{"name":"y","data":[3]}]}`
+	scenarios := map[string]struct {
+		spec     v1beta1.LoggerRedactSpec
+		expected string
+	}{
+		"WildcardItems": {
+			spec: v1beta1.LoggerRedactSpec{Paths: []string{"$.instances[*].email"}},
+			expected: `{"instances":[{"email":"[REDACTED]","age":42,"note":"ssn 123-45-6789"},{"email":"[REDACTED]"}],` +
+				`"inputs":[{"name":"x","data":[1,2]},{"name":"y","data":[3]}]}`,
+		},
+		"IndexedItemMaskedAsAWhole": {
+			spec: v1beta1.LoggerRedactSpec{Paths: []string{"$.inputs[0].data"}, Mask: "***"},
+			expected: `{"instances":[{"email":"jane@example.com","age":42,"note":"ssn 123-45-6789"},{"email":"john@example.com"}],` +
+				`"inputs":[{"name":"x","data":"***"},{"name":"y","data":[3]}]}`,
+		},
+		"WildcardFieldsAndMissingPaths": {
+			spec: v1beta1.LoggerRedactSpec{Paths: []string{"$.instances[0].*", "$.instances[5].email", "$.outputs"}},
+			expected: `{"instances":[{"email":"[REDACTED]","age":"[REDACTED]","note":"[REDACTED]"},{"email":"john@example.com"}],` +
+				`"inputs":[{"name":"x","data":[1,2]},{"name":"y","data":[3]}]}`,
+		},
+		"Patterns": {
+			spec: v1beta1.LoggerRedactSpec{Patterns: []string{`[0-9]{3}-[0-9]{2}-[0-9]{4}`, `[a-z]+@example\.com`}},
+			expected: `{"instances":[{"email":"[REDACTED]","age":42,"note":"ssn [REDACTED]"},{"email":"[REDACTED]"}],` +
+				`"inputs":[{"name":"x","data":[1,2]},{"name":"y","data":[3]}]}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			transformer, err := NewRedactTransformer(scenario.spec)
+			g.Expect(err).To(gomega.BeNil())
+			payload, err := transformer.Transform(context.Background(), Payload{
+				Id:          "request-1",
+				ReqType:     InferenceRequest,
+				ContentType: "application/json",
+				Data:        []byte(data),
+			})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(payload.Data).To(gomega.MatchJSON(scenario.expected))
+		})
+	}
+
+	// The payloads which are not JSON cannot be redacted
+	transformer, err := NewRedactTransformer(v1beta1.LoggerRedactSpec{Paths: []string{"$.instances"}})
+	g.Expect(err).To(gomega.BeNil())
+	_, err = transformer.Transform(context.Background(), Payload{ContentType: "text/plain", Data: []byte("x")})
+	g.Expect(err).NotTo(gomega.BeNil())
+	_, err = transformer.Transform(context.Background(), Payload{ContentType: "application/json", Data: []byte("{")})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestNewRedactTransformer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		spec    v1beta1.LoggerRedactSpec
+		matcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			spec:    v1beta1.LoggerRedactSpec{Paths: []string{"$.a.b[0][*].*"}, Patterns: []string{"[0-9]+"}},
+			matcher: gomega.BeNil(),
+		},
+		"PathWithoutRoot": {
+			spec:    v1beta1.LoggerRedactSpec{Paths: []string{"instances"}},
+			matcher: gomega.HaveOccurred(),
+		},
+		"MalformedPath": {
+			spec:    v1beta1.LoggerRedactSpec{Paths: []string{"$.instances[x]"}},
+			matcher: gomega.HaveOccurred(),
+		},
+		"MalformedPattern": {
+			spec:    v1beta1.LoggerRedactSpec{Patterns: []string{"[0-9"}},
+			matcher: gomega.HaveOccurred(),
+		},
+		"UnknownRedactor": {
+			spec:    v1beta1.LoggerRedactSpec{Redactors: []string{"unknown"}},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRedactTransformer(scenario.spec)
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestRegisterRedactor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	RegisterRedactor("upper-names", RedactorFunc(func(document interface{}, mask string) (interface{}, error) {
+		if object, ok := document.(map[string]interface{}); ok {
+			if name, ok := object["name"].(string); ok && strings.ToUpper(name) == name {
+				object["name"] = mask
+			}
+		}
+		return document, nil
+	}))
+	RegisterRedactor("failing", RedactorFunc(func(document interface{}, mask string) (interface{}, error) {
+		return nil, fmt.Errorf("model unavailable")
+	}))
+	g.Expect(RegisteredRedactors()).To(gomega.ContainElements("failing", "upper-names"))
+
+	// The redactors are applied after the paths
+	transformer, err := NewRedactTransformer(v1beta1.LoggerRedactSpec{
+		Paths:     []string{"$.email"},
+		Redactors: []string{"upper-names"},
+		Mask:      "-",
+	})
+	g.Expect(err).To(gomega.BeNil())
+	payload, err := transformer.Transform(context.Background(), Payload{
+		ContentType: "application/json",
+		Data:        []byte(`{"name":"JANE","email":"jane@example.com"}`),
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(payload.Data).To(gomega.MatchJSON(`{"name":"-","email":"-"}`))
+
+	// The payloads a redactor fails for are not logged
+	transformer, err = NewRedactTransformer(v1beta1.LoggerRedactSpec{Redactors: []string{"failing"}})
+	g.Expect(err).To(gomega.BeNil())
+	_, err = transformer.Transform(context.Background(), Payload{ContentType: "application/json", Data: []byte(`{}`)})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("model unavailable")))
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/kserve/kserve/pkg/diskqueue"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	QueuedEventsMetricName     = "kserve_agent_logger_queued_events"
+	RetriedEventsMetricName    = "kserve_agent_logger_retried_events_total"
+	DeadLetterEventsMetricName = "kserve_agent_logger_dead_letter_events_total"
+	DroppedEventsMetricName    = "kserve_agent_logger_dropped_events_total"
+
+	// The delay between the attempts to deliver the oldest queued event doubles up to maxRetryBackoff
+	minRetryBackoff = 1 * time.Second
+	maxRetryBackoff = 1 * time.Minute
+)
+
+var (
+	// queuedEvents is the number of events waiting to be delivered again to the logger sink
+	queuedEvents = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: QueuedEventsMetricName,
+		Help: "Number of log events queued until the logger sink accepts them",
+	})
+	// retriedEvents counts the attempts to deliver the events again, from the workers and from the queue
+	retriedEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: RetriedEventsMetricName,
+		Help: "Number of attempts to deliver the log events again",
+	})
+	// deadLetterEvents counts the events exported to the dead letter store
+	deadLetterEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: DeadLetterEventsMetricName,
+		Help: "Number of log events exported to the dead letter store",
+	})
+	// droppedEvents counts the events lost because they could neither be delivered, queued nor exported
+	droppedEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: DroppedEventsMetricName,
+		Help: "Number of log events dropped without being delivered",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(queuedEvents, retriedEvents, deadLetterEvents, droppedEvents)
+}
+
+// DeadLetterExporter stores the events the logger sink did not accept before they expired
+type DeadLetterExporter interface {
+	Export(key string, event []byte) error
+}
+
+// retryRecord is a queued event with the url of the logger sink it was sent to
+type retryRecord struct {
+	Url string `json:"url"`
+	// Time is the time of the first failed delivery, the event expires maxAge after it
+	Time time.Time `json:"time"`
+	// Structured is set when the event is sent in the structured mode
+	Structured bool              `json:"structured,omitempty"`
+	Event      cloudevents.Event `json:"event"`
+}
+
+// RetryQueue delivers the events the logger sink did not accept again, in order, from a disk backed queue so they
+// survive the restarts of the agent container. The events that are still not accepted after maxAge, or that do not
+// fit in the queue, are exported to the dead letter store, or dropped when there is none. The encrypted events are
+// queued and exported encrypted.
+type RetryQueue struct {
+	log        *zap.SugaredLogger
+	queue      *diskqueue.Queue
+	deadLetter DeadLetterExporter
+	maxAge     time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	done       chan struct{}
+}
+
+// NewRetryQueue loads the events queued in dir, maxSize bounds the size of the queued events in bytes. deadLetter is
+// optional.
+func NewRetryQueue(dir string, maxSize int64, maxAge time.Duration, deadLetter DeadLetterExporter,
+	log *zap.SugaredLogger) (*RetryQueue, error) {
+	queue, err := diskqueue.New(dir, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	queuedEvents.Set(float64(queue.Len()))
+	return &RetryQueue{
+		log:        log,
+		queue:      queue,
+		deadLetter: deadLetter,
+		maxAge:     maxAge,
+		minBackoff: minRetryBackoff,
+		maxBackoff: maxRetryBackoff,
+		done:       make(chan struct{}),
+	}, nil
+}
+
+// Put queues the event the logger sink at url did not accept
+func (r *RetryQueue) Put(url string, event cloudevents.Event, structured bool) {
+	data, err := json.Marshal(&retryRecord{Url: url, Time: time.Now(), Structured: structured, Event: event})
+	if err != nil {
+		r.log.Errorw("Dropping malformed log event", "id", event.ID(), zap.Error(err))
+		droppedEvents.Inc()
+		return
+	}
+	if err := r.queue.Put(data); err != nil {
+		r.log.Errorw("Failed to queue log event", "id", event.ID(), zap.Error(err))
+		r.export(event)
+		return
+	}
+	queuedEvents.Set(float64(r.queue.Len()))
+}
+
+// Start delivers the queued events until the context is done
+func (r *RetryQueue) Start(ctx context.Context) {
+	go func() {
+		defer close(r.done)
+		backoff := r.minBackoff
+		for {
+			name, record, err := r.oldest()
+			if err == nil && name == "" {
+				select {
+				case <-r.queue.Ready():
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				// The event can never be delivered, drop it rather than blocking the events behind it
+				r.log.Errorw("Dropping unreadable queued log event", "event", name, zap.Error(err))
+				droppedEvents.Inc()
+				r.remove(name)
+				continue
+			}
+			retriedEvents.Inc()
+			if err := sendEvent(ctx, record.Url, record.Event, record.Structured); err != nil {
+				if time.Since(record.Time) >= r.maxAge {
+					r.log.Infof("Log event %s expired: %v", record.Event.ID(), err)
+					r.export(record.Event)
+					r.remove(name)
+					continue
+				}
+				r.log.Infof("Failed to deliver log event %s, retrying in %v: %v", record.Event.ID(), backoff, err)
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				if backoff *= 2; backoff > r.maxBackoff {
+					backoff = r.maxBackoff
+				}
+				continue
+			}
+			backoff = r.minBackoff
+			r.remove(name)
+		}
+	}()
+}
+
+// Flush exports the events left in the queue to the dead letter store once the queue is stopped, the pod may be
+// deleted along with the queue volume. The events stay queued when there is no dead letter store.
+func (r *RetryQueue) Flush() {
+	<-r.done
+	if r.deadLetter == nil {
+		return
+	}
+	for {
+		name, record, err := r.oldest()
+		if name == "" {
+			return
+		}
+		if err == nil {
+			r.export(record.Event)
+		}
+		if err := r.queue.Remove(name); err != nil {
+			r.log.Errorw("Failed to remove queued log event", "event", name, zap.Error(err))
+			return
+		}
+	}
+}
+
+func (r *RetryQueue) oldest() (string, *retryRecord, error) {
+	name, data, err := r.queue.Oldest()
+	if name == "" || err != nil {
+		return name, nil, err
+	}
+	record := &retryRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return name, nil, fmt.Errorf("malformed queued log event %s: %v", name, err)
+	}
+	// The payload is decoded from data_base64 as raw bytes, set it again to send it unchanged
+	if data, ok := record.Event.Data.([]byte); ok {
+		if err := record.Event.SetData(data); err != nil {
+			return name, nil, err
+		}
+	}
+	return name, record, nil
+}
+
+func (r *RetryQueue) remove(name string) {
+	if err := r.queue.Remove(name); err != nil {
+		r.log.Errorw("Failed to remove queued log event", "event", name, zap.Error(err))
+	}
+	queuedEvents.Set(float64(r.queue.Len()))
+}
+
+// export stores the event in the dead letter store, the event is dropped when there is none or the export fails
+func (r *RetryQueue) export(event cloudevents.Event) {
+	if r.deadLetter == nil {
+		droppedEvents.Inc()
+		return
+	}
+	data, err := json.Marshal(event)
+	if err == nil {
+		err = r.deadLetter.Export(deadLetterKey(event), data)
+	}
+	if err != nil {
+		r.log.Errorw("Failed to export log event to the dead letter store", "id", event.ID(), zap.Error(err))
+		droppedEvents.Inc()
+		return
+	}
+	deadLetterEvents.Inc()
+}
+
+// deadLetterKey returns <namespace>/<inferenceservice>/<yyyy>/<mm>/<dd>/<id>-<request|response>.json, the date is
+// the date of the event
+func deadLetterKey(event cloudevents.Event) string {
+	extensions := event.Extensions()
+	kind := event.Type()[strings.LastIndex(event.Type(), ".")+1:]
+	return path.Join(fmt.Sprint(extensions[NamespaceAttr]), fmt.Sprint(extensions[InferenceServiceAttr]),
+		event.Time().UTC().Format("2006/01/02"), fmt.Sprintf("%s-%s.json", event.ID(), kind))
+}
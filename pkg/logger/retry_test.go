@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/kserve/kserve/pkg/diskqueue"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type mockDeadLetter struct {
+	mu     sync.Mutex
+	events map[string][]byte
+}
+
+func (m *mockDeadLetter) Export(key string, event []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events[key] = event
+	return nil
+}
+
+func (m *mockDeadLetter) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.events {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func newTestLogRequest(g *gomega.WithT, sinkUrl string, id string) LogRequest {
+	logUrl, err := url.Parse(sinkUrl)
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	payload := []byte(`{"instances":[[0,0,0]]}`)
+	return LogRequest{
+		Url:              logUrl,
+		Bytes:            &payload,
+		ContentType:      "application/json",
+		ReqType:          InferenceRequest,
+		Id:               id,
+		SourceUri:        sourceUri,
+		InferenceService: "sklearn",
+		Namespace:        "default",
+		Component:        "predictor",
+		Endpoint:         "default",
+	}
+}
+
+func TestRetryQueue(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	// The logger sink is down for the first two attempts
+	var mu sync.Mutex
+	var attempts int
+	var ids, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts <= 2 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		ids = append(ids, req.Header.Get("Ce-Id"))
+		bodies = append(bodies, string(body))
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	retries, err := NewRetryQueue(t.TempDir(), 4*diskqueue.BlockSize, time.Hour, nil, logger)
+	g.Expect(err).To(gomega.BeNil())
+	retries.minBackoff = 10 * time.Millisecond
+	worker := NewWorker(1, nil, nil, retries, logger)
+	retried := testutil.ToFloat64(retriedEvents)
+	for _, id := range []string{"request-1", "request-2"} {
+		g.Expect(worker.sendCloudEvent(newTestLogRequest(g, server.URL, id))).ToNot(gomega.Succeed())
+	}
+	g.Expect(testutil.ToFloat64(queuedEvents)).To(gomega.Equal(float64(2)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	retries.Start(ctx)
+	g.Eventually(retries.queue.Len, 5*time.Second).Should(gomega.Equal(0))
+	cancel()
+	retries.Flush()
+	mu.Lock()
+	defer mu.Unlock()
+	g.Expect(ids).To(gomega.Equal([]string{"request-1", "request-2"}))
+	g.Expect(bodies).To(gomega.Equal([]string{`{"instances":[[0,0,0]]}`, `{"instances":[[0,0,0]]}`}))
+	g.Expect(testutil.ToFloat64(retriedEvents)).To(gomega.Equal(retried + 2))
+	g.Expect(testutil.ToFloat64(queuedEvents)).To(gomega.Equal(float64(0)))
+}
+
+func TestRetryQueueDeadLetter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	deadLetter := &mockDeadLetter{events: map[string][]byte{}}
+	retries, err := NewRetryQueue(t.TempDir(), diskqueue.BlockSize, 0, deadLetter, logger)
+	g.Expect(err).To(gomega.BeNil())
+	worker := NewWorker(1, nil, nil, retries, logger)
+	exported := testutil.ToFloat64(deadLetterEvents)
+	// The second event does not fit in the queue and is exported right away, the first one once it expired
+	for _, id := range []string{"request-1", "request-2"} {
+		g.Expect(worker.sendCloudEvent(newTestLogRequest(g, server.URL, id))).ToNot(gomega.Succeed())
+	}
+	g.Expect(deadLetter.keys()).To(gomega.HaveLen(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	retries.Start(ctx)
+	g.Eventually(deadLetter.keys, 5*time.Second).Should(gomega.HaveLen(2))
+	cancel()
+	retries.Flush()
+	g.Expect(testutil.ToFloat64(deadLetterEvents)).To(gomega.Equal(exported + 2))
+
+	today := time.Now().UTC().Format("2006/01/02")
+	data, ok := deadLetter.events["default/sklearn/"+today+"/request-1-request.json"]
+	g.Expect(ok).To(gomega.BeTrue())
+	event := cloudevents.NewEvent()
+	g.Expect(json.Unmarshal(data, &event)).To(gomega.Succeed())
+	g.Expect(event.ID()).To(gomega.Equal("request-1"))
+	g.Expect(event.Type()).To(gomega.Equal(CEInferenceRequest))
+	g.Expect(event.Data).To(gomega.Equal([]byte(`{"instances":[[0,0,0]]}`)))
+}
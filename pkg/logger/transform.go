@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HashPrefix prefixes the hashed values, so the consumers can tell them apart from the original values
+	HashPrefix = "sha256:"
+
+	transformTimeout = 10 * time.Second
+)
+
+// Payload is the payload of a log event with the attributes identifying it
+type Payload struct {
+	Id          string
+	ReqType     LogRequestType
+	ContentType string
+	Data        []byte
+}
+
+// Transformer anonymizes or transforms the payload of every log event before it is encrypted and sent. An event is
+// dropped when its payload cannot be transformed, so a payload is never logged before it is anonymized.
+type Transformer interface {
+	Transform(ctx context.Context, payload Payload) (Payload, error)
+}
+
+// chain applies the transformers in order
+type chain []Transformer
+
+// Chain returns the transformer applying the transformers in order
+func Chain(transformers ...Transformer) Transformer {
+	return chain(transformers)
+}
+
+func (c chain) Transform(ctx context.Context, payload Payload) (Payload, error) {
+	var err error
+	for _, transformer := range c {
+		if payload, err = transformer.Transform(ctx, payload); err != nil {
+			return payload, err
+		}
+	}
+	return payload, nil
+}
+
+// WebhookTransformer delegates the transformation to a webhook, for the organizations whose redaction rules are
+// implemented in their own service. The payload is posted with its content type, the Ce-Id header set to the id of
+// the inference request and the Ce-Type header set to the type of the event. The webhook answers with a 200 status
+// and the transformed payload, with its content type.
+type WebhookTransformer struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookTransformer(url string) *WebhookTransformer {
+	return &WebhookTransformer{
+		url:    url,
+		client: &http.Client{Timeout: transformTimeout},
+	}
+}
+
+func (w *WebhookTransformer) Transform(ctx context.Context, payload Payload) (Payload, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload.Data))
+	if err != nil {
+		return payload, err
+	}
+	if payload.ContentType != "" {
+		req.Header.Set("Content-Type", payload.ContentType)
+	}
+	req.Header.Set(CloudEventsIdHeader, payload.Id)
+	if payload.ReqType == InferenceRequest {
+		req.Header.Set("Ce-Type", CEInferenceRequest)
+	} else {
+		req.Header.Set("Ce-Type", CEInferenceResponse)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return payload, fmt.Errorf("while calling the transform webhook: %s", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return payload, fmt.Errorf("while reading the transform webhook response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return payload, fmt.Errorf("transform webhook returned status %d", resp.StatusCode)
+	}
+	payload.Data = body
+	payload.ContentType = resp.Header.Get("Content-Type")
+	return payload, nil
+}
+
+// HashTransformer is a sample anonymization replacing the values of the JSON object fields with the given names, at
+// any depth, with their HMAC-SHA256 hash. The same value is always replaced with the same hash, so the anonymized
+// payloads can still be joined on the hashed fields. Without a key the values are hashed with SHA-256, which does not
+// protect the values with few possibilities, such as ages or zip codes, from a dictionary attack.
+type HashTransformer struct {
+	fields map[string]bool
+	key    []byte
+}
+
+func NewHashTransformer(fields []string, key []byte) *HashTransformer {
+	t := &HashTransformer{fields: map[string]bool{}, key: key}
+	for _, field := range fields {
+		t.fields[field] = true
+	}
+	return t
+}
+
+// LoadHashKey reads the base64 encoded hashing key from keyFile, usually mounted from a secret of the
+// InferenceService namespace
+func LoadHashKey(keyFile string) ([]byte, error) {
+	encoded, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+}
+
+// Transform fails for the payloads which are not JSON, they cannot be anonymized
+func (t *HashTransformer) Transform(ctx context.Context, payload Payload) (Payload, error) {
+	document, err := decodeJSONPayload(payload, "hash the fields of")
+	if err != nil {
+		return payload, err
+	}
+	data, err := json.Marshal(t.hash(document))
+	if err != nil {
+		return payload, err
+	}
+	payload.Data = data
+	return payload, nil
+}
+
+func (t *HashTransformer) hash(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if t.fields[name] {
+				v[name] = t.hashValue(field)
+			} else {
+				v[name] = t.hash(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = t.hash(item)
+		}
+	}
+	return value
+}
+
+// hashValue hashes the JSON encoding of the value, the objects and the arrays are hashed as a whole
+func (t *HashTransformer) hashValue(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	if s, ok := value.(string); ok {
+		encoded = []byte(s)
+	}
+	var sum []byte
+	if len(t.key) > 0 {
+		mac := hmac.New(sha256.New, t.key)
+		mac.Write(encoded)
+		sum = mac.Sum(nil)
+	} else {
+		digest := sha256.Sum256(encoded)
+		sum = digest[:]
+	}
+	return HashPrefix + hex.EncodeToString(sum)
+}
+
+// decodeJSONPayload decodes the JSON payload the action is applied to, the payloads with another content type fail
+func decodeJSONPayload(payload Payload, action string) (interface{}, error) {
+	if mediaType, _, err := mime.ParseMediaType(payload.ContentType); payload.ContentType != "" &&
+		(err != nil || !strings.HasSuffix(mediaType, "json")) {
+		return nil, fmt.Errorf("cannot %s a %s payload", action, payload.ContentType)
+	}
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload.Data))
+	// Keep the numbers as they are written
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("cannot %s a malformed JSON payload: %s", action, err)
+	}
+	return document, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestHashTransformer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	key := []byte("secret")
+	transformer := NewHashTransformer([]string{"email", "ssn"}, key)
+	payload, err := transformer.Transform(context.Background(), Payload{
+		Id:          "request-1",
+		ReqType:     InferenceRequest,
+		ContentType: "application/json",
+		Data:        []byte(`{"instances":[{"email":"jane@example.com","age":42,"ssn":123456789},{"email":"jane@example.com"}]}`),
+	})
+	g.Expect(err).To(gomega.BeNil())
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("jane@example.com"))
+	email := HashPrefix + hex.EncodeToString(mac.Sum(nil))
+	mac = hmac.New(sha256.New, key)
+	mac.Write([]byte("123456789"))
+	ssn := HashPrefix + hex.EncodeToString(mac.Sum(nil))
+	// The same values are hashed the same, the other fields and the numbers are kept as they are
+	g.Expect(payload.Data).To(gomega.MatchJSON(`{"instances":[{"email":"` + email + `","age":42,"ssn":"` + ssn +
+		`"},{"email":"` + email + `"}]}`))
+	g.Expect(payload.ContentType).To(gomega.Equal("application/json"))
+
+	// The payloads which are not JSON cannot be anonymized
+	_, err = transformer.Transform(context.Background(), Payload{ContentType: "application/octet-stream", Data: []byte("x")})
+	g.Expect(err).ToNot(gomega.BeNil())
+	_, err = transformer.Transform(context.Background(), Payload{ContentType: "application/json", Data: []byte("{")})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestLoadHashKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	g.Expect(ioutil.WriteFile(keyFile, []byte("c2VjcmV0\n"), 0600)).To(gomega.Succeed())
+	key, err := LoadHashKey(keyFile)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(key).To(gomega.Equal([]byte("secret")))
+}
+
+func TestWebhookTransformer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	webhook := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if req.Header.Get("Ce-Id") != "request-1" || req.Header.Get("Ce-Type") != CEInferenceRequest {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write(bytes.ReplaceAll(body, []byte("instances"), []byte("redacted")))
+	}))
+	defer webhook.Close()
+
+	transformer := Chain(NewHashTransformer([]string{"email"}, nil), NewWebhookTransformer(webhook.URL))
+	payload, err := transformer.Transform(context.Background(), Payload{
+		Id:          "request-1",
+		ReqType:     InferenceRequest,
+		ContentType: "application/json",
+		Data:        []byte(`{"instances":[[0,0,0]]}`),
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(payload.Data).To(gomega.MatchJSON(`{"redacted":[[0,0,0]]}`))
+
+	_, err = transformer.Transform(context.Background(), Payload{Id: "request-2", ReqType: InferenceRequest,
+		ContentType: "application/json", Data: []byte(`{}`)})
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func TestTransformedEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	worker := NewWorker(1, nil, nil, nil, logger)
+
+	// The events whose payload cannot be transformed are dropped, they are never sent as they are
+	dropped := testutil.ToFloat64(droppedEvents)
+	request := newTestLogRequest(g, server.URL, "request-1")
+	request.ContentType = "text/plain"
+	request.Transformer = NewHashTransformer([]string{"instances"}, nil)
+	g.Expect(worker.sendCloudEvent(request)).ToNot(gomega.Succeed())
+	g.Expect(testutil.ToFloat64(droppedEvents)).To(gomega.Equal(dropped + 1))
+	g.Expect(attempts).To(gomega.Equal(0))
+
+	request.ContentType = "application/json"
+	g.Expect(worker.sendCloudEvent(request)).To(gomega.Succeed())
+	g.Expect(attempts).To(gomega.Equal(1))
+}
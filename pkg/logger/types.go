@@ -38,4 +38,7 @@ type LogRequest struct {
 	Namespace        string
 	Component        string
 	Endpoint         string
+	Encryptor        *Encryptor
+	Format           *EventFormat
+	Transformer      Transformer
 }
@@ -18,6 +18,8 @@ package logger
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/cloudevents/sdk-go"
 	"github.com/cloudevents/sdk-go/pkg/cloudevents/transport"
@@ -37,6 +39,11 @@ const (
 	ComponentAttr        = "component"
 	//endpoint would be either default or canary
 	EndpointAttr = "endpoint"
+	// set on envelope encrypted events, the wrapped data key is base64 encoded
+	EncryptionKeyIdAttr      = "encryptionkeyid"
+	EncryptionWrappedKeyAttr = "encryptionwrappedkey"
+	EncryptionAlgorithmAttr  = "encryptionalgorithm"
+	EncryptedContentTypeAttr = "encryptedcontenttype"
 
 	LoggerWorkerQueueSize = 100
 	CloudEventsIdHeader   = "Ce-Id"
@@ -53,13 +60,16 @@ func QueueLogRequest(req LogRequest) error {
 // NewWorker creates, and returns a new Worker object. Its only argument
 // is a channel that the worker can add itself to whenever it is done its
 // work.
-func NewWorker(id int, workerQueue chan chan LogRequest, logger *zap.SugaredLogger) Worker {
+func NewWorker(id int, workerQueue chan chan LogRequest, delivery *Delivery, retries *RetryQueue,
+	logger *zap.SugaredLogger) Worker {
 	// Create, and return the worker.
 	return Worker{
 		Log:         logger,
 		ID:          id,
 		Work:        make(chan LogRequest),
 		WorkerQueue: workerQueue,
+		Delivery:    delivery,
+		Retries:     retries,
 		QuitChan:    make(chan bool),
 		Client: http.Client{
 			Timeout: 60 * time.Second,
@@ -73,6 +83,9 @@ type Worker struct {
 	ID          int
 	Work        chan LogRequest
 	WorkerQueue chan chan LogRequest
+	Delivery    *Delivery
+	Retries     *RetryQueue
+	BlobSink    *BlobSink
 	QuitChan    chan bool
 	Client      http.Client
 	CeCtx       context.Context
@@ -80,23 +93,36 @@ type Worker struct {
 }
 
 func (w *Worker) sendCloudEvent(logReq LogRequest) error {
-	t, err := cloudevents.NewHTTPTransport(
-		cloudevents.WithTarget(logReq.Url.String()),
-		cloudevents.WithEncoding(cloudevents.HTTPBinaryV1),
-	)
-
+	event, err := newCloudEvent(w.CeCtx, logReq)
 	if err != nil {
-		return fmt.Errorf("while creating http transport: %s", err)
+		return err
+	}
+	// The events are batched to the blob storage instead of being sent
+	if w.BlobSink != nil {
+		return w.BlobSink.Add(w.CeCtx, event)
+	}
+	structured := logReq.Format != nil && logReq.Format.Structured
+	if w.Delivery != nil {
+		w.Delivery.setKeys(&event)
+		err = w.Delivery.send(w.CeCtx, logReq.Url.String(), event, structured)
+	} else {
+		err = sendEvent(w.CeCtx, logReq.Url.String(), event, structured)
 	}
-
-	c, err := cloudevents.NewClient(t,
-		cloudevents.WithTimeNow(),
-	)
 	if err != nil {
-		return fmt.Errorf("while creating new cloudevents client: %s", err)
+		if w.Retries != nil {
+			w.Retries.Put(logReq.Url.String(), event, structured)
+		}
+		return err
 	}
+	return nil
+}
+
+// newCloudEvent builds the event of the log request, the payload is transformed then encrypted before the event is
+// sent or queued
+func newCloudEvent(ctx context.Context, logReq LogRequest) (cloudevents.Event, error) {
 	event := cloudevents.NewEvent(cloudevents.VersionV1)
 	event.SetID(logReq.Id)
+	event.SetTime(time.Now())
 	if logReq.ReqType == InferenceRequest {
 		event.SetType(CEInferenceRequest)
 	} else {
@@ -109,14 +135,73 @@ func (w *Worker) sendCloudEvent(logReq LogRequest) error {
 	event.SetExtension(EndpointAttr, logReq.Endpoint)
 
 	event.SetSource(logReq.SourceUri.String())
-	if logReq.ContentType != "" {
-		event.SetDataContentType(logReq.ContentType)
+	if logReq.Format != nil {
+		logReq.Format.apply(&event, logReq.ReqType)
+	}
+	data, contentType := *logReq.Bytes, logReq.ContentType
+	if logReq.Transformer != nil {
+		payload, err := logReq.Transformer.Transform(ctx, Payload{
+			Id:          logReq.Id,
+			ReqType:     logReq.ReqType,
+			ContentType: contentType,
+			Data:        data,
+		})
+		if err != nil {
+			// The payload is never logged before it is transformed
+			droppedEvents.Inc()
+			return event, fmt.Errorf("while transforming payload: %s", err)
+		}
+		data, contentType = payload.Data, payload.ContentType
 	}
-	if err := event.SetData(*logReq.Bytes); err != nil {
-		return fmt.Errorf("while setting cloudevents data: %s", err)
+	if logReq.Encryptor != nil {
+		payload, err := logReq.Encryptor.Encrypt(data)
+		if err != nil {
+			return event, fmt.Errorf("while encrypting payload: %s", err)
+		}
+		event.SetExtension(EncryptionKeyIdAttr, payload.KeyId)
+		event.SetExtension(EncryptionWrappedKeyAttr, base64.StdEncoding.EncodeToString(payload.WrappedKey))
+		event.SetExtension(EncryptionAlgorithmAttr, payload.Algorithm)
+		if contentType != "" {
+			event.SetExtension(EncryptedContentTypeAttr, contentType)
+		}
+		event.SetDataContentType(EncryptedContentType)
+		data = payload.Ciphertext
+	} else if contentType != "" {
+		event.SetDataContentType(contentType)
 	}
+	if err := event.SetData(data); err != nil {
+		return event, fmt.Errorf("while setting cloudevents data: %s", err)
+	}
+	return event, nil
+}
+
+// sendEvent sends the event in the binary mode, or in the structured mode where the JSON payloads are embedded as
+// data and the other payloads are base64 encoded as data_base64
+func sendEvent(ctx context.Context, url string, event cloudevents.Event, structured bool) error {
+	encoding := cloudevents.HTTPBinaryV1
+	if structured {
+		encoding = cloudevents.HTTPStructuredV1
+		if data, ok := event.Data.([]byte); ok && event.DataMediaType() == cloudevents.ApplicationJSON && json.Valid(data) {
+			event.DataBinary = false
+		}
+	}
+	t, err := cloudevents.NewHTTPTransport(
+		cloudevents.WithTarget(url),
+		cloudevents.WithEncoding(encoding),
+	)
 
-	if _, _, err := c.Send(w.CeCtx, event); err != nil {
+	if err != nil {
+		return fmt.Errorf("while creating http transport: %s", err)
+	}
+
+	c, err := cloudevents.NewClient(t,
+		cloudevents.WithTimeNow(),
+	)
+	if err != nil {
+		return fmt.Errorf("while creating new cloudevents client: %s", err)
+	}
+
+	if _, _, err := c.Send(ctx, event); err != nil {
 		return fmt.Errorf("while sending event: %s", err)
 	}
 	return nil
@@ -127,8 +212,10 @@ func (w *Worker) sendCloudEvent(logReq LogRequest) error {
 func (w *Worker) Start() {
 	go func() {
 		for {
-			// Add ourselves into the worker queue.
-			w.WorkerQueue <- w.Work
+			// Add ourselves into the worker queue, unless the work is partitioned between the workers.
+			if w.WorkerQueue != nil {
+				w.WorkerQueue <- w.Work
+			}
 
 			select {
 			case work := <-w.Work:
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultBody is the response of the requests during the maintenance when no body is configured
+const DefaultBody = `{"error": "The model is under maintenance"}`
+
+// MaintenanceHandler answers the requests with a static 503 response while the maintenance mode is on, the requests
+// are passed to the next handler otherwise. The maintenance mode is switched without a restart from the agent config,
+// the model server keeps running so the requests are served again as soon as it is switched off.
+type MaintenanceHandler struct {
+	log  *zap.SugaredLogger
+	next http.Handler
+
+	mu         sync.RWMutex
+	enabled    bool
+	body       []byte
+	retryAfter time.Duration
+}
+
+func New(next http.Handler, logger *zap.SugaredLogger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		log:  logger,
+		next: next,
+	}
+}
+
+// Configure switches the maintenance mode on or off, the body defaults to DefaultBody and the Retry-After header is
+// only sent when retryAfter is positive
+func (h *MaintenanceHandler) Configure(enabled bool, body string, retryAfter time.Duration) {
+	if body == "" {
+		body = DefaultBody
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.enabled = enabled
+	h.body = []byte(body)
+	h.retryAfter = retryAfter
+}
+
+func (h *MaintenanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	enabled, body, retryAfter := h.enabled, h.body, h.retryAfter
+	h.mu.RUnlock()
+	if !enabled {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	h.log.Debugw("Rejected request during the maintenance", "path", r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	if retryAfter > 0 {
+		// The header is in whole seconds, a fraction of a second is rounded up
+		w.Header().Set("Retry-After", strconv.FormatInt(int64((retryAfter+time.Second-1)/time.Second), 10))
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	w.Write(body)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestMaintenanceHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	served := 0
+	handler := New(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served++
+		rw.Write([]byte(`{"predictions": [1]}`))
+	}), logger)
+	request := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+		return w
+	}
+
+	// The requests are served until the maintenance mode is switched on
+	w := request()
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(served).To(gomega.Equal(1))
+
+	handler.Configure(true, `{"error": "Scheduled maintenance until 10:00 UTC"}`, 90*time.Second)
+	w = request()
+	g.Expect(w.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"error": "Scheduled maintenance until 10:00 UTC"}`))
+	g.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("application/json"))
+	g.Expect(w.Header().Get("Retry-After")).To(gomega.Equal("90"))
+	g.Expect(served).To(gomega.Equal(1))
+
+	// The default body is sent without a Retry-After header when they are not configured
+	handler.Configure(true, "", 0)
+	w = request()
+	g.Expect(w.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(w.Body.String()).To(gomega.Equal(DefaultBody))
+	g.Expect(w.Header().Get("Retry-After")).To(gomega.BeEmpty())
+
+	handler.Configure(false, "", 0)
+	w = request()
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(served).To(gomega.Equal(2))
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+// ModelState is the load state of a model, an entry of the repository index of the v2 protocol
+// POST /v2/repository/index
+// [
+//
+//	{
+//	  "name": "model1",
+//	  "version": "1",
+//	  "state": "READY"
+//	},
+//	{
+//	  "name": "model2",
+//	  "state": "UNAVAILABLE",
+//	  "reason": "failed to load model2: out of memory"
+//	}
+//
+// ]
+type ModelState struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	State   string `json:"state,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ModelStates []ModelState
+
+// Find returns the state of the named model, nil if the model is not reported
+func (states ModelStates) Find(name string) *ModelState {
+	for i := range states {
+		if states[i].Name == name {
+			return &states[i]
+		}
+	}
+	return nil
+}
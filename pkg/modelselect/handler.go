@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelselect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+	"go.uber.org/zap"
+)
+
+// ModelHeader selects the model of the request by its external name, the agent maps it to the name of the model or
+// adapter registered with the model server
+const ModelHeader = "X-Kserve-Model"
+
+// Models returns the states of the models registered with the model server, e.g. the models of the model config
+// tracked by the agent
+type Models interface {
+	States() modelconfig.ModelStates
+}
+
+// ModelSelectHandler maps the model selected by the X-Kserve-Model header to the name of the model or adapter the
+// model server knows it by, so the external name of a model does not depend on the naming inside the runtime. The
+// model is replaced in the path of the v1 and v2 protocols and in the model field of the OpenAI completions, the
+// requests without the header are passed unchanged.
+type ModelSelectHandler struct {
+	log    *zap.SugaredLogger
+	names  map[string]string
+	models Models
+	next   http.Handler
+}
+
+// New returns the handler mapping the external names to the model server names, the models of the model config are
+// selected by their own name when models is not nil
+func New(names map[string]string, models Models, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &ModelSelectHandler{
+		log:    logger,
+		names:  names,
+		models: models,
+		next:   next,
+	}
+}
+
+func (h *ModelSelectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	selected := r.Header.Get(ModelHeader)
+	if selected == "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	name, status, err := h.resolve(selected)
+	if err != nil {
+		h.log.Debugw("Rejected the request for a model not available", "model", selected, "error", err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if err := selectModel(r, name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// resolve returns the model server name of the selected model, the models which are registered but not loaded are
+// rejected as unavailable
+func (h *ModelSelectHandler) resolve(selected string) (string, int, error) {
+	states := map[string]modelconfig.ModelState{}
+	if h.models != nil {
+		for _, state := range h.models.States() {
+			states[state.Name] = state
+		}
+	}
+	name, ok := h.names[selected]
+	if !ok {
+		if _, ok := states[selected]; !ok {
+			return "", http.StatusNotFound, fmt.Errorf("model %s is not registered", selected)
+		}
+		name = selected
+	}
+	if state, ok := states[name]; ok && state.State != constants.ModelStateReady {
+		return "", http.StatusServiceUnavailable, fmt.Errorf("model %s is not ready", selected)
+	}
+	return name, 0, nil
+}
+
+// selectModel replaces the model of the request with the model server name
+func selectModel(r *http.Request, name string) error {
+	for _, prefix := range []string{"/v1/models/", "/v2/models/"} {
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			continue
+		}
+		rest := r.URL.Path[len(prefix):]
+		end := strings.IndexAny(rest, ":/")
+		if end < 0 {
+			end = len(rest)
+		}
+		r.URL.Path = prefix + name + rest[end:]
+		r.URL.RawPath = ""
+		return nil
+	}
+	if strings.HasSuffix(r.URL.Path, "/completions") || strings.HasSuffix(r.URL.Path, "/embeddings") {
+		return selectOpenAIModel(r, name)
+	}
+	return nil
+}
+
+// selectOpenAIModel sets the model field of the JSON body of an OpenAI request
+func selectOpenAIModel(r *http.Request, name string) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	fields["model"], _ = json.Marshal(name)
+	if body, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
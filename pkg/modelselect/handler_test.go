@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelselect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type fakeModels modelconfig.ModelStates
+
+func (m fakeModels) States() modelconfig.ModelStates {
+	return modelconfig.ModelStates(m)
+}
+
+func TestModelSelectHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	var path, body string
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+		rw.WriteHeader(http.StatusOK)
+	})
+	models := fakeModels{
+		{Name: "llama-2-7b-support", State: constants.ModelStateReady},
+		{Name: "iris", State: constants.ModelStateReady},
+		{Name: "mnist", State: constants.ModelStateLoading},
+	}
+	handler := New(map[string]string{"support": "llama-2-7b-support", "chat": "llama-2-7b"}, models, next, logger)
+
+	scenarios := map[string]struct {
+		model          string
+		path           string
+		body           string
+		expectedStatus int
+		expectedPath   string
+		expectedBody   string
+	}{
+		"NoHeader": {
+			path:           "/v1/models/support:predict",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/v1/models/support:predict",
+		},
+		"V1": {
+			model:          "support",
+			path:           "/v1/models/default:predict",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/v1/models/llama-2-7b-support:predict",
+		},
+		"V2": {
+			model:          "chat",
+			path:           "/v2/models/default/versions/1/infer",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/v2/models/llama-2-7b/versions/1/infer",
+		},
+		"OpenAI": {
+			model:          "support",
+			path:           "/openai/v1/chat/completions",
+			body:           `{"model":"support","stream":true}`,
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/openai/v1/chat/completions",
+			expectedBody:   `{"model":"llama-2-7b-support","stream":true}`,
+		},
+		"ModelOfTheModelConfig": {
+			model:          "iris",
+			path:           "/v1/models/default:predict",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/v1/models/iris:predict",
+		},
+		"ModelNotRegistered": {
+			model:          "other",
+			path:           "/v1/models/default:predict",
+			expectedStatus: http.StatusNotFound,
+		},
+		"ModelNotReady": {
+			model:          "mnist",
+			path:           "/v1/models/default:predict",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		"InvalidOpenAIBody": {
+			model:          "chat",
+			path:           "/v1/completions",
+			body:           "prompt",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for name, scenario := range scenarios {
+		path, body = "", ""
+		req := httptest.NewRequest(http.MethodPost, scenario.path, strings.NewReader(scenario.body))
+		if scenario.model != "" {
+			req.Header.Set(ModelHeader, scenario.model)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(scenario.expectedStatus), name)
+		g.Expect(path).To(gomega.Equal(scenario.expectedPath), name)
+		if scenario.expectedBody != "" {
+			g.Expect(body).To(gomega.MatchJSON(scenario.expectedBody), name)
+		}
+	}
+}
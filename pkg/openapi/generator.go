@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	// SpecPath is the well known path the OpenAPI document of an InferenceService is served on
+	SpecPath = "/openapi.json"
+
+	openAPIVersion = "3.0.0"
+)
+
+// Generate builds the OpenAPI document for the model. When v2 metadata is available the v2 endpoints
+// are described with the tensor schemas reported by the runtime, otherwise the v1 endpoints are described.
+func Generate(modelName string, metadata *inference.ModelMetadata) *openapi3.T {
+	if metadata == nil {
+		return v1Spec(modelName)
+	}
+	return v2Spec(modelName, metadata)
+}
+
+func v1Spec(modelName string) *openapi3.T {
+	request := openapi3.NewObjectSchema().
+		WithProperty("instances", openapi3.NewArraySchema().WithItems(openapi3.NewSchema()))
+	response := openapi3.NewObjectSchema().
+		WithProperty("predictions", openapi3.NewArraySchema().WithItems(openapi3.NewSchema()))
+	modelReady := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("ready", openapi3.NewBoolSchema())
+
+	return &openapi3.T{
+		OpenAPI: openAPIVersion,
+		Info: &openapi3.Info{
+			Title:   fmt.Sprintf("%s inference API", modelName),
+			Version: string(constants.ProtocolV1),
+		},
+		Paths: openapi3.Paths{
+			fmt.Sprintf("/v1/models/%s", modelName): &openapi3.PathItem{
+				Get: operation("Model readiness", nil, modelReady),
+			},
+			constants.PredictPath(modelName, constants.ProtocolV1): &openapi3.PathItem{
+				Post: operation("Model inference", request, response),
+			},
+		},
+	}
+}
+
+func v2Spec(modelName string, metadata *inference.ModelMetadata) *openapi3.T {
+	request := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("inputs", tensorsSchema(metadata.Inputs))
+	response := openapi3.NewObjectSchema().
+		WithProperty("model_name", openapi3.NewStringSchema()).
+		WithProperty("model_version", openapi3.NewStringSchema()).
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("outputs", tensorsSchema(metadata.Outputs))
+	modelReady := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("ready", openapi3.NewBoolSchema())
+	tensorMetadata := openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("datatype", openapi3.NewStringSchema()).
+		WithProperty("shape", openapi3.NewArraySchema().WithItems(openapi3.NewInt64Schema())))
+	modelMetadata := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("versions", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())).
+		WithProperty("platform", openapi3.NewStringSchema()).
+		WithProperty("inputs", tensorMetadata).
+		WithProperty("outputs", tensorMetadata)
+
+	version := string(constants.ProtocolV2)
+	if len(metadata.Versions) > 0 {
+		version = metadata.Versions[len(metadata.Versions)-1]
+	}
+	return &openapi3.T{
+		OpenAPI: openAPIVersion,
+		Info: &openapi3.Info{
+			Title:       fmt.Sprintf("%s inference API", modelName),
+			Description: fmt.Sprintf("Open Inference Protocol endpoints served by %s", metadata.Platform),
+			Version:     version,
+		},
+		Paths: openapi3.Paths{
+			fmt.Sprintf("/v2/models/%s", modelName): &openapi3.PathItem{
+				Get: operation("Model metadata", nil, modelMetadata),
+			},
+			fmt.Sprintf("/v2/models/%s/ready", modelName): &openapi3.PathItem{
+				Get: operation("Model readiness", nil, modelReady),
+			},
+			constants.PredictPath(modelName, constants.ProtocolV2): &openapi3.PathItem{
+				Post: operation("Model inference", request, response),
+			},
+		},
+	}
+}
+
+// tensorsSchema describes the v2 json tensor representation, the data of a tensor is always sent flattened
+// in row-major order so the shape is only used to document the expected number of elements.
+func tensorsSchema(tensors []inference.TensorMetadata) *openapi3.Schema {
+	items := make([]*openapi3.Schema, 0, len(tensors))
+	for _, tensor := range tensors {
+		data := openapi3.NewArraySchema().WithItems(datatypeSchema(tensor.Datatype))
+		if size := numElements(tensor.Shape); size > 0 {
+			data = data.WithMinItems(size).WithMaxItems(size)
+		}
+		items = append(items, openapi3.NewObjectSchema().
+			WithProperty("name", openapi3.NewStringSchema().WithEnum(tensor.Name)).
+			WithProperty("datatype", openapi3.NewStringSchema().WithEnum(tensor.Datatype)).
+			WithProperty("shape", openapi3.NewArraySchema().WithItems(openapi3.NewInt64Schema())).
+			WithProperty("data", data))
+	}
+	if len(items) == 1 {
+		return openapi3.NewArraySchema().WithItems(items[0])
+	}
+	return openapi3.NewArraySchema().WithItems(openapi3.NewOneOfSchema(items...))
+}
+
+// numElements returns the number of elements for a fully known shape, -1 when any dimension is variable
+func numElements(shape []int64) int64 {
+	if len(shape) == 0 {
+		return -1
+	}
+	size := int64(1)
+	for _, dim := range shape {
+		if dim < 0 {
+			return -1
+		}
+		size *= dim
+	}
+	return size
+}
+
+func datatypeSchema(datatype string) *openapi3.Schema {
+	switch datatype {
+	case "BOOL":
+		return openapi3.NewBoolSchema()
+	case "BYTES":
+		return openapi3.NewStringSchema()
+	case "UINT8", "UINT16", "UINT32", "UINT64", "INT8", "INT16", "INT32", "INT64":
+		return openapi3.NewInt64Schema()
+	case "FP16", "FP32", "FP64":
+		return openapi3.NewFloat64Schema()
+	default:
+		return openapi3.NewSchema()
+	}
+}
+
+func operation(summary string, request *openapi3.Schema, response *openapi3.Schema) *openapi3.Operation {
+	op := &openapi3.Operation{
+		Summary: summary,
+		Responses: openapi3.Responses{
+			"200": &openapi3.ResponseRef{
+				Value: &openapi3.Response{
+					Description: utils.String("OK"),
+					Content:     openapi3.NewContentWithJSONSchema(response),
+				},
+			},
+		},
+	}
+	if request != nil {
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: &openapi3.RequestBody{
+				Required: true,
+				Content:  openapi3.NewContentWithJSONSchema(request),
+			},
+		}
+	}
+	return op
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+type OpenAPIHandler struct {
+	log         *zap.SugaredLogger
+	modelName   string
+	metadataUrl string
+	client      http.Client
+	next        http.Handler
+	// The concurrent requests for the spec share a single metadata fetch, the lock only guards the cached spec so
+	// a slow runtime does not block the requests served from the cache
+	fetch singleflight.Group
+	mu    sync.RWMutex
+	// v2 spec is cached once the runtime reported its model metadata
+	v2Spec []byte
+}
+
+// New returns a handler serving the OpenAPI document of the model on SpecPath and passing
+// all the other requests to the next handler.
+func New(modelName string, componentUrl *url.URL, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &OpenAPIHandler{
+		log:         logger,
+		modelName:   modelName,
+		metadataUrl: fmt.Sprintf("%s/v2/models/%s", componentUrl.String(), modelName),
+		client: http.Client{
+			Timeout: 5 * time.Second,
+		},
+		next: next,
+	}
+}
+
+func (h *OpenAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || r.URL.Path != SpecPath {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	spec, err := h.getSpec()
+	if err != nil {
+		h.log.Errorw("Failed to generate OpenAPI specification", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(spec); err != nil {
+		h.log.Errorw("Failed to write OpenAPI specification", "error", err)
+	}
+}
+
+func (h *OpenAPIHandler) getSpec() ([]byte, error) {
+	if spec := h.cachedSpec(); spec != nil {
+		return spec, nil
+	}
+	spec, err, _ := h.fetch.Do(h.metadataUrl, func() (interface{}, error) {
+		// The spec may have been cached by a fetch completed since the cache was checked
+		if spec := h.cachedSpec(); spec != nil {
+			return spec, nil
+		}
+		metadata, err := h.fetchMetadata()
+		if err != nil {
+			// The runtime does not implement the v2 protocol or the model is not loaded yet,
+			// fall back to the v1 specification without caching it.
+			h.log.Debugw("v2 model metadata is not available", "url", h.metadataUrl, "error", err)
+			return Generate(h.modelName, nil).MarshalJSON()
+		}
+		spec, err := Generate(h.modelName, metadata).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		h.mu.Lock()
+		h.v2Spec = spec
+		h.mu.Unlock()
+		return spec, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spec.([]byte), nil
+}
+
+func (h *OpenAPIHandler) cachedSpec() []byte {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.v2Spec
+}
+
+func (h *OpenAPIHandler) fetchMetadata() (*inference.ModelMetadata, error) {
+	resp, err := h.client.Get(h.metadataUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from model metadata endpoint", resp.StatusCode)
+	}
+	metadata := &inference.ModelMetadata{}
+	if err := json.Unmarshal(body, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/client/inference"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestOpenAPIHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	scenarios := map[string]struct {
+		metadataStatus int
+		expectedPaths  []string
+	}{
+		"V2Runtime": {
+			metadataStatus: http.StatusOK,
+			expectedPaths:  []string{"/v2/models/mymodel", "/v2/models/mymodel/ready", "/v2/models/mymodel/infer"},
+		},
+		"V1Runtime": {
+			metadataStatus: http.StatusNotFound,
+			expectedPaths:  []string{"/v1/models/mymodel", "/v1/models/mymodel:predict"},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		runtime := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			g.Expect(req.URL.Path).To(gomega.Equal("/v2/models/mymodel"))
+			rw.WriteHeader(scenario.metadataStatus)
+			rw.Write([]byte(`{"name":"mymodel","platform":"mlserver","inputs":[{"name":"input-0","datatype":"FP32","shape":[1,4]}],"outputs":[{"name":"output-0","datatype":"INT64","shape":[1]}]}`))
+		}))
+		runtimeUrl, err := url.Parse(runtime.URL)
+		g.Expect(err).To(gomega.BeNil())
+
+		handler := New("mymodel", runtimeUrl, http.NotFoundHandler(), logger)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://a"+SpecPath, nil))
+		runtime.Close()
+
+		g.Expect(w.Code).To(gomega.Equal(http.StatusOK), name)
+		body, err := ioutil.ReadAll(w.Result().Body)
+		g.Expect(err).To(gomega.BeNil())
+		spec := map[string]interface{}{}
+		g.Expect(json.Unmarshal(body, &spec)).To(gomega.Succeed())
+		paths := spec["paths"].(map[string]interface{})
+		g.Expect(paths).To(gomega.HaveLen(len(scenario.expectedPaths)), name)
+		for _, path := range scenario.expectedPaths {
+			g.Expect(paths).To(gomega.HaveKey(path), name)
+		}
+	}
+}
+
+func TestOpenAPIHandlerConcurrentFetch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var fetches int32
+	release := make(chan struct{})
+	runtime := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		rw.Write([]byte(`{"name":"mymodel","platform":"mlserver","inputs":[],"outputs":[]}`))
+	}))
+	defer runtime.Close()
+	runtimeUrl, err := url.Parse(runtime.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	handler := New("mymodel", runtimeUrl, http.NotFoundHandler(), logger)
+	codes := make([]int, 5)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://a"+SpecPath, nil))
+			codes[i] = w.Code
+		}(i)
+	}
+	// The requests waiting for the slow runtime share its single metadata fetch
+	g.Eventually(func() int32 { return atomic.LoadInt32(&fetches) }, time.Second).Should(gomega.Equal(int32(1)))
+	g.Consistently(func() int32 { return atomic.LoadInt32(&fetches) }, 100*time.Millisecond).Should(gomega.Equal(int32(1)))
+	close(release)
+	wg.Wait()
+	for _, code := range codes {
+		g.Expect(code).To(gomega.Equal(http.StatusOK))
+	}
+
+	// The cached spec is served without fetching the metadata again
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://a"+SpecPath, nil))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(atomic.LoadInt32(&fetches)).To(gomega.Equal(int32(1)))
+}
+
+func TestOpenAPIHandlerPassThrough(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	runtimeUrl, _ := url.Parse("http://localhost:8080")
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+	handler := New("mymodel", runtimeUrl, next, logger)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "http://a/v1/models/mymodel:predict", nil))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusTeapot))
+}
+
+func TestTensorsSchema(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	schema := tensorsSchema([]inference.TensorMetadata{{Name: "input-0", Datatype: "FP32", Shape: []int64{2, 3}}})
+	data := schema.Items.Value.Properties["data"].Value
+	g.Expect(data.MinItems).To(gomega.Equal(uint64(6)))
+	g.Expect(*data.MaxItems).To(gomega.Equal(uint64(6)))
+
+	schema = tensorsSchema([]inference.TensorMetadata{{Name: "input-0", Datatype: "FP32", Shape: []int64{-1, 3}}})
+	data = schema.Items.Value.Properties["data"].Value
+	g.Expect(data.MaxItems).To(gomega.BeNil())
+}
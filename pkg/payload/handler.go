@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	guuid "github.com/google/uuid"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/logger"
+	"go.uber.org/zap"
+	"knative.dev/pkg/network"
+)
+
+// PayloadURIHeader is the header of the requests passing their payload by reference, it is set on the responses
+// written back by reference
+const PayloadURIHeader = "X-Kserve-Payload-Uri"
+
+// PayloadHandler reads the payloads of the requests passed by reference from an object store, so the payloads above
+// the request size limits of the gateways reach the model server. The objects are only read under the allowed
+// prefixes with the credentials of the agent. When a response uri is set the successful responses are written back
+// under it and only their reference is returned.
+type PayloadHandler struct {
+	log         *zap.SugaredLogger
+	store       *storage.PayloadStore
+	prefixes    []string
+	maxSize     int64
+	responseUri string
+	next        http.Handler
+}
+
+func New(store *storage.PayloadStore, prefixes []string, maxSize int64, responseUri string, next http.Handler,
+	logger *zap.SugaredLogger) http.Handler {
+	// The prefixes only match whole path segments, s3://bucket/inputs does not allow s3://bucket/inputs-other
+	normalized := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		normalized = append(normalized, strings.TrimSuffix(prefix, "/")+"/")
+	}
+	return &PayloadHandler{
+		log:         logger,
+		store:       store,
+		prefixes:    normalized,
+		maxSize:     maxSize,
+		responseUri: strings.TrimSuffix(responseUri, "/"),
+		next:        next,
+	}
+}
+
+func (h *PayloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := r.Header.Get(PayloadURIHeader)
+	if uri == "" || network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if !h.allowed(uri) {
+		http.Error(w, fmt.Sprintf("payload uri %s is not allowed", uri), http.StatusForbidden)
+		return
+	}
+	object, err := h.store.Open(r.Context(), uri)
+	if errors.Is(err, storage.ErrPayloadNotFound) {
+		http.Error(w, fmt.Sprintf("payload %s not found", uri), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.log.Errorw("Failed to read payload", "uri", uri, zap.Error(err))
+		http.Error(w, fmt.Sprintf("failed to read payload %s", uri), http.StatusBadGateway)
+		return
+	}
+	defer object.Body.Close()
+	if object.Size > h.maxSize {
+		http.Error(w, fmt.Sprintf("payload %s of %d bytes exceeds the maximum size of %d bytes", uri, object.Size,
+			h.maxSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// The object is streamed to the model server with its length
+	r.Body = object.Body
+	r.ContentLength = object.Size
+	r.Header.Del("Content-Length")
+	r.Header.Del(PayloadURIHeader)
+	if object.ContentType != "" {
+		r.Header.Set("Content-Type", object.ContentType)
+	}
+	if h.responseUri == "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	rr := httptest.NewRecorder()
+	h.next.ServeHTTP(rr, r)
+	if rr.Code != http.StatusOK {
+		// The failures are returned inline, they are small and the callers handle them directly
+		writeResponse(w, rr, h.log)
+		return
+	}
+	// Like for the payload logger the callers may set the id of the request with the Ce-Id header
+	id := r.Header.Get(logger.CloudEventsIdHeader)
+	if id == "" || strings.Contains(id, "/") {
+		id = guuid.New().String()
+	}
+	responseUri := h.responseUri + "/" + id
+	if err := h.store.Put(r.Context(), responseUri, rr.Body.Bytes(), rr.Header().Get("Content-Type")); err != nil {
+		h.log.Errorw("Failed to write response payload", "uri", responseUri, zap.Error(err))
+		http.Error(w, fmt.Sprintf("failed to write response payload %s", responseUri), http.StatusBadGateway)
+		return
+	}
+	for key, values := range rr.Header() {
+		w.Header()[key] = values
+	}
+	w.Header().Del("Content-Type")
+	w.Header().Del("Content-Length")
+	w.Header().Set(PayloadURIHeader, responseUri)
+	w.WriteHeader(rr.Code)
+}
+
+// writeResponse copies the recorded response of the model server
+func writeResponse(w http.ResponseWriter, rr *httptest.ResponseRecorder, log *zap.SugaredLogger) {
+	for key, values := range rr.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rr.Code)
+	if _, err := w.Write(rr.Body.Bytes()); err != nil {
+		log.Errorw("Failed to write response", zap.Error(err))
+	}
+}
+
+// allowed returns whether the uri is under one of the allowed prefixes
+func (h *PayloadHandler) allowed(uri string) bool {
+	for _, prefix := range h.prefixes {
+		if strings.HasPrefix(uri, prefix) {
+			return true
+		}
+	}
+	return false
+}
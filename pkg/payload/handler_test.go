@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payload
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestPayloadHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	bucket := mocks.NewMockS3Bucket()
+	bucket.Objects["images/1.json"] = []byte(`{"instances": [[1]]}`)
+	bucket.Objects["images/large.json"] = make([]byte, 1024)
+	store := &storage.PayloadStore{S3: bucket}
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if req.ContentLength != int64(len(body)) {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(body) == 0 {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"predictions": [1]}`))
+	})
+
+	scenarios := map[string]struct {
+		uri          string
+		responseUri  string
+		expectedCode int
+		expectedBody string
+	}{
+		"Inline": {
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		"Reference": {
+			uri:          "s3://inputs/images/1.json",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"predictions": [1]}`,
+		},
+		"ResponseReference": {
+			uri:          "s3://inputs/images/1.json",
+			responseUri:  "s3://outputs/predictions/",
+			expectedCode: http.StatusOK,
+		},
+		"NotAllowed": {
+			uri:          "s3://inputs/images-other/1.json",
+			expectedCode: http.StatusForbidden,
+		},
+		"NotFound": {
+			uri:          "s3://inputs/images/2.json",
+			expectedCode: http.StatusNotFound,
+		},
+		"TooLarge": {
+			uri:          "s3://inputs/images/large.json",
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			handler := New(store, []string{"s3://inputs/images"}, 512, scenario.responseUri, predictor, logger)
+			req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", bytes.NewReader(nil))
+			if scenario.uri != "" {
+				req.Header.Set(PayloadURIHeader, scenario.uri)
+			}
+			req.Header.Set("Ce-Id", "request-1")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			g.Expect(rec.Code).To(gomega.Equal(scenario.expectedCode))
+			if scenario.expectedBody != "" {
+				g.Expect(rec.Body.String()).To(gomega.Equal(scenario.expectedBody))
+			}
+		})
+	}
+
+	// The responses are written back under the response uri and only their reference is returned
+	g.Expect(bucket.Objects).To(gomega.HaveKeyWithValue("predictions/request-1", []byte(`{"predictions": [1]}`)))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+	req.Header.Set(PayloadURIHeader, "s3://inputs/images/1.json")
+	New(store, []string{"s3://inputs/images/"}, 512, "s3://outputs/predictions", predictor, logger).ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rec.Body.Len()).To(gomega.Equal(0))
+	g.Expect(rec.Header().Get(PayloadURIHeader)).To(gomega.HavePrefix("s3://outputs/predictions/"))
+	g.Expect(bucket.Objects).To(gomega.HaveLen(4))
+}
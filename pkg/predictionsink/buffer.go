@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictionsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kserve/kserve/pkg/diskqueue"
+)
+
+// ErrBufferFull is returned when the buffered predictions would exceed the size of the buffer
+var ErrBufferFull = diskqueue.ErrQueueFull
+
+// Prediction is a successful response of the model server waiting to be published to the sink
+type Prediction struct {
+	Id          string    `json:"id"`
+	ContentType string    `json:"contentType,omitempty"`
+	Time        time.Time `json:"time"`
+	Data        []byte    `json:"data"`
+}
+
+// Buffer is the disk backed FIFO queue of the predictions, the predictions left by a previous agent container are
+// published first.
+type Buffer struct {
+	queue *diskqueue.Queue
+}
+
+// NewBuffer loads the predictions buffered in dir, maxSize bounds the size of the buffered predictions in bytes
+func NewBuffer(dir string, maxSize int64) (*Buffer, error) {
+	queue, err := diskqueue.New(dir, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	bufferedPredictions.Set(float64(queue.Len()))
+	return &Buffer{queue: queue}, nil
+}
+
+// Put appends the prediction to the buffer, ErrBufferFull is returned when there is no room left for it
+func (b *Buffer) Put(prediction *Prediction) error {
+	data, err := json.Marshal(prediction)
+	if err != nil {
+		return err
+	}
+	if err := b.queue.Put(data); err != nil {
+		return err
+	}
+	bufferedPredictions.Set(float64(b.queue.Len()))
+	return nil
+}
+
+// Oldest returns the name and the prediction at the head of the buffer, an empty name when the buffer is empty.
+// The prediction stays buffered until it is removed.
+func (b *Buffer) Oldest() (string, *Prediction, error) {
+	name, data, err := b.queue.Oldest()
+	if name == "" || err != nil {
+		return name, nil, err
+	}
+	prediction := &Prediction{}
+	if err := json.Unmarshal(data, prediction); err != nil {
+		return name, nil, fmt.Errorf("malformed buffered prediction %s: %v", name, err)
+	}
+	return name, prediction, nil
+}
+
+// Remove removes the prediction at the head of the buffer once the sink acknowledged it
+func (b *Buffer) Remove(name string) error {
+	if err := b.queue.Remove(name); err != nil {
+		return err
+	}
+	bufferedPredictions.Set(float64(b.queue.Len()))
+	return nil
+}
+
+// Len returns the number of buffered predictions
+func (b *Buffer) Len() int {
+	return b.queue.Len()
+}
+
+// Ready is signaled when predictions are added to the buffer
+func (b *Buffer) Ready() <-chan struct{} {
+	return b.queue.Ready()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictionsink
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/diskqueue"
+	"github.com/onsi/gomega"
+)
+
+func TestBuffer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buffer, err := NewBuffer(t.TempDir(), 2*diskqueue.BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	now := time.Date(2022, 10, 2, 2, 0, 0, 0, time.UTC)
+	g.Expect(buffer.Put(&Prediction{Id: "a", ContentType: "application/json", Time: now,
+		Data: []byte(`{"predictions": [1]}`)})).To(gomega.Succeed())
+	name, prediction, err := buffer.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(prediction).To(gomega.Equal(&Prediction{Id: "a", ContentType: "application/json", Time: now,
+		Data: []byte(`{"predictions": [1]}`)}))
+	g.Expect(buffer.Remove(name)).To(gomega.Succeed())
+	g.Expect(buffer.Len()).To(gomega.Equal(0))
+}
+
+func TestBufferMalformedPrediction(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := t.TempDir()
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "00000000000000000000.json"), []byte("{"), 0600)).To(gomega.Succeed())
+	buffer, err := NewBuffer(dir, 2*diskqueue.BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	name, _, err := buffer.Oldest()
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(buffer.Remove(name)).To(gomega.Succeed())
+	_, err = os.Stat(filepath.Join(dir, name))
+	g.Expect(os.IsNotExist(err)).To(gomega.BeTrue())
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictionsink
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/grpcproxy"
+	"github.com/kserve/kserve/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"knative.dev/pkg/network"
+)
+
+// PredictionSinkHandler buffers the successful responses of the inference requests for the sink, unlike the
+// payload logger the requests are not published. The responses are buffered before they are returned, a response
+// that could not be buffered is still returned and counted as dropped.
+type PredictionSinkHandler struct {
+	log    *zap.SugaredLogger
+	buffer *Buffer
+	next   http.Handler
+	now    func() time.Time
+}
+
+func New(buffer *Buffer, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &PredictionSinkHandler{
+		log:    logger,
+		buffer: buffer,
+		next:   next,
+		now:    time.Now,
+	}
+}
+
+func (h *PredictionSinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) || r.Method != http.MethodPost {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	// Like for the payload logger the callers may set the id of the prediction with the Ce-Id header
+	id := r.Header.Get(logger.CloudEventsIdHeader)
+	if id == "" {
+		id = guuid.New().String()
+	}
+	rr := httptest.NewRecorder()
+	h.next.ServeHTTP(rr, r)
+	isGrpc := grpcproxy.IsGrpc(r)
+	prediction := &Prediction{
+		Id:          id,
+		ContentType: rr.Header().Get("Content-Type"),
+		Time:        h.now(),
+		Data:        rr.Body.Bytes(),
+	}
+	succeeded := rr.Code == http.StatusOK
+	if isGrpc {
+		// The gRPC calls succeed with their status in the trailers, the response message is published
+		succeeded = grpcproxy.Status(rr) == codes.OK
+		if message, err := grpcproxy.Message(prediction.Data); err == nil {
+			prediction.Data, prediction.ContentType = message, constants.ProtobufContentType
+		}
+	}
+	if succeeded {
+		if err := h.buffer.Put(prediction); err != nil {
+			h.log.Errorw("Failed to buffer prediction", "id", id, zap.Error(err))
+			droppedPredictions.Inc()
+		}
+	}
+	if isGrpc {
+		if err := grpcproxy.WriteResponse(w, rr); err != nil {
+			h.log.Errorw("Failed to write response", zap.Error(err))
+		}
+		return
+	}
+	for key, values := range rr.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rr.Code)
+	if _, err := w.Write(rr.Body.Bytes()); err != nil {
+		h.log.Errorw("Failed to write response", zap.Error(err))
+	}
+}
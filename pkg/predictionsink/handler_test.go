@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictionsink
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/diskqueue"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestPredictionSinkHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	buffer, err := NewBuffer(t.TempDir(), 2*diskqueue.BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) == "fail" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"predictions": [1]}`))
+	})
+	handler := New(buffer, predictor, logger)
+
+	// Only the predictions are buffered, not the failed requests
+	for _, body := range []string{`{"instances": [[1]]}`, "fail"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", bytes.NewBufferString(body))
+		req.Header.Set("Ce-Id", "request-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if body == "fail" {
+			g.Expect(rec.Code).To(gomega.Equal(http.StatusInternalServerError))
+			continue
+		}
+		g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+		g.Expect(rec.Header().Get("Content-Type")).To(gomega.Equal("application/json"))
+		g.Expect(rec.Body.String()).To(gomega.Equal(`{"predictions": [1]}`))
+	}
+	g.Expect(buffer.Len()).To(gomega.Equal(1))
+	_, prediction, err := buffer.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(prediction.Id).To(gomega.Equal("request-1"))
+	g.Expect(prediction.ContentType).To(gomega.Equal("application/json"))
+	g.Expect(string(prediction.Data)).To(gomega.Equal(`{"predictions": [1]}`))
+
+	// The predictions that do not fit in the buffer are still returned
+	large := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write(make([]byte, diskqueue.BlockSize))
+	})
+	dropped := testutil.ToFloat64(droppedPredictions)
+	rec := httptest.NewRecorder()
+	New(buffer, large, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(rec.Body.Len()).To(gomega.Equal(diskqueue.BlockSize))
+	g.Expect(testutil.ToFloat64(droppedPredictions)).To(gomega.Equal(dropped + 1))
+	g.Expect(buffer.Len()).To(gomega.Equal(1))
+}
+
+func TestSink(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	buffer, err := NewBuffer(t.TempDir(), 2*diskqueue.BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+
+	// The sink fails the first attempt, the prediction is published again
+	var mu sync.Mutex
+	var attempts int
+	var ids, types, services []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		ids = append(ids, req.Header.Get("Ce-Id"))
+		types = append(types, req.Header.Get("Ce-Type"))
+		services = append(services, req.Header.Get("Ce-Inferenceservicename"))
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sinkUrl, _ := url.Parse(server.URL)
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	sink, err := NewSink(buffer, sinkUrl, sourceUri, "sklearn", "default", "default", "predictor", logger)
+	g.Expect(err).To(gomega.BeNil())
+	sink.minBackoff = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink.Start(ctx)
+
+	now := time.Now()
+	for _, id := range []string{"request-1", "request-2"} {
+		g.Expect(buffer.Put(&Prediction{Id: id, ContentType: "application/json", Time: now,
+			Data: []byte(`{"predictions": [1]}`)})).To(gomega.Succeed())
+	}
+	g.Eventually(buffer.Len, 5*time.Second).Should(gomega.Equal(0))
+	mu.Lock()
+	defer mu.Unlock()
+	g.Expect(attempts).To(gomega.Equal(3))
+	g.Expect(ids).To(gomega.Equal([]string{"request-1", "request-2"}))
+	g.Expect(types).To(gomega.Equal([]string{CEInferencePrediction, CEInferencePrediction}))
+	g.Expect(services).To(gomega.Equal([]string{"sklearn", "sklearn"}))
+}
+
+func TestPredictionSinkHandlerGrpc(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	buffer, err := NewBuffer(t.TempDir(), 2*diskqueue.BlockSize)
+	g.Expect(err).To(gomega.BeNil())
+	// The status of the gRPC calls is sent in the trailers after the length-prefixed message
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		rw.Header().Set("Content-Type", "application/grpc")
+		rw.Header().Set("Trailer", "Grpc-Status")
+		rw.Write([]byte{0, 0, 0, 0, 2, 'o', 'k'})
+		if string(body[5:]) == "fail" {
+			rw.Header().Set("Grpc-Status", "3")
+			return
+		}
+		rw.Header().Set("Grpc-Status", "0")
+	})
+	handler := New(buffer, predictor, logger)
+
+	for _, message := range []string{"fail", "infer"} {
+		req := httptest.NewRequest(http.MethodPost, "/inference.GRPCInferenceService/ModelInfer",
+			bytes.NewReader(append([]byte{0, 0, 0, 0, byte(len(message))}, message...)))
+		req.ProtoMajor = 2
+		req.Header.Set("Content-Type", "application/grpc")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+		g.Expect(rec.Body.Bytes()).To(gomega.Equal([]byte{0, 0, 0, 0, 2, 'o', 'k'}))
+		expected := "0"
+		if message == "fail" {
+			expected = "3"
+		}
+		g.Expect(rec.Result().Trailer.Get("Grpc-Status")).To(gomega.Equal(expected))
+	}
+	// Only the message of the successful call is buffered
+	g.Expect(buffer.Len()).To(gomega.Equal(1))
+	_, prediction, err := buffer.Oldest()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(prediction.ContentType).To(gomega.Equal("application/x-protobuf"))
+	g.Expect(string(prediction.Data)).To(gomega.Equal("ok"))
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictionsink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cloudevents/sdk-go"
+	"github.com/kserve/kserve/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// CEInferencePrediction is the type of the events published to the sink
+	CEInferencePrediction = "org.kubeflow.serving.inference.prediction"
+
+	BufferedPredictionsMetricName = "kserve_agent_buffered_predictions"
+	DroppedPredictionsMetricName  = "kserve_agent_dropped_predictions_total"
+
+	// The delay between the attempts to publish a prediction the sink did not acknowledge doubles up to maxBackoff
+	minBackoff = 1 * time.Second
+	maxBackoff = 1 * time.Minute
+)
+
+var (
+	// bufferedPredictions is the number of predictions waiting to be acknowledged by the sink
+	bufferedPredictions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: BufferedPredictionsMetricName,
+		Help: "Number of predictions buffered until the sink acknowledges them",
+	})
+	// droppedPredictions counts the predictions that could not be buffered
+	droppedPredictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: DroppedPredictionsMetricName,
+		Help: "Number of predictions dropped because the buffer was full",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(bufferedPredictions, droppedPredictions)
+}
+
+// Sink publishes the buffered predictions in order as CloudEvents to a webhook or a Knative KafkaSink. A prediction
+// is removed from the buffer only once the sink acknowledged it with a 2xx response, so the predictions are
+// delivered at least once and may be delivered again after an agent restart. Consumers deduplicate on the event id,
+// which is the id of the inference request.
+type Sink struct {
+	log              *zap.SugaredLogger
+	buffer           *Buffer
+	client           cloudevents.Client
+	sourceUri        *url.URL
+	inferenceService string
+	namespace        string
+	endpoint         string
+	component        string
+	minBackoff       time.Duration
+	maxBackoff       time.Duration
+}
+
+func NewSink(buffer *Buffer, sinkUrl *url.URL, sourceUri *url.URL, inferenceService string, namespace string,
+	endpoint string, component string, log *zap.SugaredLogger) (*Sink, error) {
+	t, err := cloudevents.NewHTTPTransport(
+		cloudevents.WithTarget(sinkUrl.String()),
+		cloudevents.WithEncoding(cloudevents.HTTPBinaryV1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("while creating http transport: %s", err)
+	}
+	c, err := cloudevents.NewClient(t)
+	if err != nil {
+		return nil, fmt.Errorf("while creating new cloudevents client: %s", err)
+	}
+	return &Sink{
+		log:              log,
+		buffer:           buffer,
+		client:           c,
+		sourceUri:        sourceUri,
+		inferenceService: inferenceService,
+		namespace:        namespace,
+		endpoint:         endpoint,
+		component:        component,
+		minBackoff:       minBackoff,
+		maxBackoff:       maxBackoff,
+	}, nil
+}
+
+// Start publishes the buffered predictions until the context is done
+func (s *Sink) Start(ctx context.Context) {
+	go func() {
+		backoff := s.minBackoff
+		for {
+			name, prediction, err := s.buffer.Oldest()
+			if err == nil && name == "" {
+				select {
+				case <-s.buffer.Ready():
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				// The prediction can never be published, drop it rather than blocking the predictions behind it
+				s.log.Errorw("Dropping unreadable buffered prediction", "prediction", name, zap.Error(err))
+				droppedPredictions.Inc()
+				if err := s.buffer.Remove(name); err != nil {
+					s.log.Errorw("Failed to remove buffered prediction", "prediction", name, zap.Error(err))
+				}
+				continue
+			}
+			if err := s.send(ctx, prediction); err != nil {
+				s.log.Infof("Failed to publish prediction %s, retrying in %v: %v", prediction.Id, backoff, err)
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				if backoff *= 2; backoff > s.maxBackoff {
+					backoff = s.maxBackoff
+				}
+				continue
+			}
+			backoff = s.minBackoff
+			if err := s.buffer.Remove(name); err != nil {
+				s.log.Errorw("Failed to remove published prediction", "prediction", name, zap.Error(err))
+			}
+		}
+	}()
+}
+
+func (s *Sink) send(ctx context.Context, prediction *Prediction) error {
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(prediction.Id)
+	event.SetType(CEInferencePrediction)
+	event.SetTime(prediction.Time)
+	event.SetSource(s.sourceUri.String())
+	event.SetExtension(logger.InferenceServiceAttr, s.inferenceService)
+	event.SetExtension(logger.NamespaceAttr, s.namespace)
+	event.SetExtension(logger.ComponentAttr, s.component)
+	event.SetExtension(logger.EndpointAttr, s.endpoint)
+	if prediction.ContentType != "" {
+		event.SetDataContentType(prediction.ContentType)
+	}
+	if err := event.SetData(prediction.Data); err != nil {
+		return fmt.Errorf("while setting cloudevents data: %s", err)
+	}
+	if _, _, err := s.client.Send(ctx, event); err != nil {
+		return fmt.Errorf("while sending event: %s", err)
+	}
+	return nil
+}
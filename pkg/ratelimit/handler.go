@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// RequestsMetricName is the name of the counter of the requests per result of the rate limit
+	RequestsMetricName = "kserve_agent_rate_limit_requests_total"
+
+	ResultAccepted    = "accepted"
+	ResultRateLimited = "rate_limited"
+)
+
+// requests counts the requests accepted and rejected by the rate limit
+var requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: RequestsMetricName,
+	Help: "Number of requests per result of the replica rate limit",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(requests)
+}
+
+// RateLimitHandler limits the rate of the requests sent to the model server of the replica with a token bucket, the
+// requests above the limit are rejected with a Retry-After header instead of being queued.
+type RateLimitHandler struct {
+	log     *zap.SugaredLogger
+	limiter *rate.Limiter
+	next    http.Handler
+}
+
+// New returns a handler allowing requestsPerSecond requests per second with bursts of burst requests
+func New(requestsPerSecond int, burst int, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &RateLimitHandler{
+		log:     logger,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+		next:    next,
+	}
+}
+
+func (h *RateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reservation := h.limiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		// The token is given back so the rejected requests don't delay the next ones
+		reservation.Cancel()
+		requests.WithLabelValues(ResultRateLimited).Inc()
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	requests.WithLabelValues(ResultAccepted).Inc()
+	h.next.ServeHTTP(w, r)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestRateLimitHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := New(1, 2, next, logger)
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The burst is let through, the next request has to wait for a token
+	g.Expect(serve().Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve().Code).To(gomega.Equal(http.StatusOK))
+	rec := serve()
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusTooManyRequests))
+	g.Expect(rec.Header().Get("Retry-After")).To(gomega.Equal("1"))
+
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues(ResultAccepted))).To(gomega.Equal(2.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues(ResultRateLimited))).To(gomega.Equal(1.0))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestsMetricName is the name of the counter of the requests per tenant, aggregated into the usage reports
+	RequestsMetricName = "kserve_agent_tenant_requests_total"
+	// OtherTenant counts the requests of the tenants above maxTenants and the requests without the tenant header
+	OtherTenant = "other"
+	// maxTenants bounds the number of distinct tenants counted, the header is set by the callers
+	maxTenants = 1000
+)
+
+// requests counts the requests per tenant
+var requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: RequestsMetricName,
+	Help: "Number of requests per tenant",
+}, []string{"tenant"})
+
+func init() {
+	prometheus.MustRegister(requests)
+}
+
+// TenantHandler counts the requests per value of the tenant header, the header is expected to be set by a trusted
+// gateway in front of the InferenceService.
+type TenantHandler struct {
+	log     *zap.SugaredLogger
+	header  string
+	next    http.Handler
+	mu      sync.Mutex
+	tenants map[string]struct{}
+}
+
+func New(header string, next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &TenantHandler{
+		log:     logger,
+		header:  header,
+		next:    next,
+		tenants: map[string]struct{}{},
+	}
+}
+
+func (h *TenantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requests.WithLabelValues(h.tenant(r.Header.Get(h.header))).Inc()
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *TenantHandler) tenant(value string) string {
+	if value == "" {
+		return OtherTenant
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.tenants[value]; ok {
+		return value
+	}
+	if len(h.tenants) >= maxTenants {
+		return OtherTenant
+	}
+	h.tenants[value] = struct{}{}
+	return value
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestTenantHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := New("X-Tenant-Id", next, logger)
+	serve := func(tenant string) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+		if tenant != "" {
+			req.Header.Set("X-Tenant-Id", tenant)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+	}
+
+	serve("team-a")
+	serve("team-a")
+	serve("")
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("team-a"))).To(gomega.Equal(2.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues(OtherTenant))).To(gomega.Equal(1.0))
+
+	// The tenants above the limit are counted as other
+	for i := 1; i < maxTenants; i++ {
+		serve(fmt.Sprintf("tenant-%d", i))
+	}
+	serve("team-b")
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues("team-b"))).To(gomega.Equal(0.0))
+	g.Expect(testutil.ToFloat64(requests.WithLabelValues(OtherTenant))).To(gomega.Equal(2.0))
+}
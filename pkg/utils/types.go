@@ -23,3 +23,7 @@ func Bool(b bool) *bool {
 func UInt64(u uint64) *uint64 {
 	return &u
 }
+
+func String(s string) *string {
+	return &s
+}
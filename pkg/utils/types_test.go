@@ -38,3 +38,12 @@ func TestUInt64(t *testing.T) {
 		t.Errorf("Test %q unexpected result (-want +got): %v", t.Name(), diff)
 	}
 }
+
+func TestString(t *testing.T) {
+	input := "input"
+	expected := &input
+	result := String(input)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("Test %q unexpected result (-want +got): %v", t.Name(), diff)
+	}
+}
@@ -137,3 +137,46 @@ func MergeEnvs(baseEnvs []v1.EnvVar, overrideEnvs []v1.EnvVar) []v1.EnvVar {
 
 	return append(baseEnvs, extra...)
 }
+
+// agentAnnotations are the pod annotations the agent sidecar is injected for, the features they enable are served
+// by the agent in front of the component container
+var agentAnnotations = []string{
+	constants.LoggerInternalAnnotationKey,
+	constants.AgentShouldInjectAnnotationKey,
+	constants.BatcherInternalAnnotationKey,
+	constants.TokenAudienceAnnotationKey,
+	constants.DeprecationAnnotationKey,
+	constants.APIKeySecretAnnotationKey,
+	constants.TenantHeaderAnnotationKey,
+	constants.ModelNamesAnnotationKey,
+	constants.RateLimitRPSInternalAnnotationKey,
+	constants.CircuitBreakerFailureThresholdAnnotationKey,
+	constants.RetryBudgetPercentageAnnotationKey,
+	constants.ConcurrencyMetricsInternalAnnotationKey,
+	constants.PredictionSinkURLAnnotationKey,
+	constants.PayloadURIPrefixesAnnotationKey,
+	constants.AgentConfigInternalAnnotationKey,
+}
+
+// agentFlagAnnotations are the boolean pod annotations the agent sidecar is injected for when set to true
+var agentFlagAnnotations = []string{
+	constants.EnableOpenAPIAnnotationKey,
+	constants.EnforceAllowedHostsAnnotationKey,
+	constants.EnableAsyncAnnotationKey,
+}
+
+// IsAgentInjected returns whether the pod webhook injects the agent sidecar for the pod annotations. The traffic of
+// the component must then be sent to the agent port, the features it enables are bypassed otherwise.
+func IsAgentInjected(annotations map[string]string) bool {
+	for _, key := range agentAnnotations {
+		if _, ok := annotations[key]; ok {
+			return true
+		}
+	}
+	for _, key := range agentFlagAnnotations {
+		if annotations[key] == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -541,3 +541,38 @@ func TestIsPrefixSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAgentInjected(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    bool
+	}{
+		"NoAnnotations": {
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		"Batcher": {
+			annotations: map[string]string{constants.BatcherInternalAnnotationKey: "true"},
+			expected:    true,
+		},
+		"APIKeys": {
+			annotations: map[string]string{constants.APIKeySecretAnnotationKey: "api-keys"},
+			expected:    true,
+		},
+		"OpenAPIEnabled": {
+			annotations: map[string]string{constants.EnableOpenAPIAnnotationKey: "true"},
+			expected:    true,
+		},
+		"OpenAPIDisabled": {
+			annotations: map[string]string{constants.EnableOpenAPIAnnotationKey: "false"},
+			expected:    false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res := IsAgentInjected(scenario.annotations)
+			g.Expect(res).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validationpolicy evaluates the admission rules the platform admins define for the InferenceServices as CEL
+// expressions (https://github.com/google/cel-spec) in the inferenceservice-config ConfigMap.
+package validationpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+)
+
+const (
+	// ConfigMapKey is the key of the inferenceservice-config ConfigMap holding the json list of the policies
+	ConfigMapKey = "validationPolicies"
+
+	// ObjectVariable is the InferenceService being admitted
+	ObjectVariable = "object"
+	// OldObjectVariable is the existing InferenceService on updates, null on creates
+	OldObjectVariable = "oldObject"
+
+	// costLimit bounds the evaluation of an expression, it is the per expression limit of the Kubernetes CRD
+	// validation rules
+	costLimit = 1000000
+)
+
+// Policy is an admission rule of the platform admins, the InferenceServices the expression does not evaluate to true
+// for are rejected with the message
+type Policy struct {
+	// Name of the policy, reported in the rejections
+	Name string `json:"name"`
+	// Boolean CEL expression over the object and oldObject variables, e.g.
+	// object.spec.predictor.model.storageUri.startsWith("s3://approved-bucket/")
+	Expression string `json:"expression"`
+	// Message of the rejections, defaults to the expression
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Program is a compiled Policy
+type Program struct {
+	Policy
+	program cel.Program
+}
+
+// Parse strictly parses the json list of the policies of the ConfigMap and compiles them, an empty value has no
+// policies
+func Parse(data string) ([]Program, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+	policies := []Policy{}
+	decoder := json.NewDecoder(bytes.NewBufferString(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policies); err != nil {
+		return nil, fmt.Errorf("unable to parse %s config json: %v", ConfigMapKey, err)
+	}
+	return Compile(policies)
+}
+
+// Compile compiles the expressions of the policies, the names of the policies are required and unique
+func Compile(policies []Policy) ([]Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(ObjectVariable, cel.DynType),
+		cel.Variable(OldObjectVariable, cel.DynType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	programs := make([]Program, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("invalid %s config: the name of the policies is required", ConfigMapKey)
+		}
+		if names[policy.Name] {
+			return nil, fmt.Errorf("invalid %s config: duplicate policy %q", ConfigMapKey, policy.Name)
+		}
+		names[policy.Name] = true
+		ast, issues := env.Compile(policy.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid %s config: policy %q: %v", ConfigMapKey, policy.Name, issues.Err())
+		}
+		// The dyn expressions, e.g. object.spec.predictor.enabled, are only checked when they are evaluated
+		if !ast.OutputType().IsAssignableType(cel.BoolType) {
+			return nil, fmt.Errorf("invalid %s config: policy %q must evaluate to a bool, not %v", ConfigMapKey,
+				policy.Name, ast.OutputType())
+		}
+		program, err := env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s config: policy %q: %v", ConfigMapKey, policy.Name, err)
+		}
+		programs = append(programs, Program{Policy: policy, program: program})
+	}
+	return programs, nil
+}
+
+// Evaluate evaluates the policies in order and returns the violation of the first policy which does not hold for the
+// object, the old object is nil on creates. The policies failing to evaluate, e.g. on a field missing from the object,
+// are violated as well so the expressions guard the optional fields with has().
+func Evaluate(programs []Program, object map[string]interface{}, oldObject map[string]interface{}) error {
+	var old interface{}
+	if oldObject != nil {
+		old = oldObject
+	}
+	for _, program := range programs {
+		out, _, err := program.program.Eval(map[string]interface{}{
+			ObjectVariable:    object,
+			OldObjectVariable: old,
+		})
+		if err != nil {
+			return fmt.Errorf("validation policy %q failed to evaluate: %v", program.Name, err)
+		}
+		if allowed, ok := out.Value().(bool); !ok || !allowed {
+			message := program.Message
+			if message == "" {
+				message = fmt.Sprintf("failed expression: %s", program.Expression)
+			}
+			return fmt.Errorf("validation policy %q denied the request: %s", program.Name, message)
+		}
+	}
+	return nil
+}
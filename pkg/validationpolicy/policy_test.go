@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validationpolicy
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	gomegaTypes "github.com/onsi/gomega/types"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := map[string]struct {
+		data    string
+		matcher gomegaTypes.GomegaMatcher
+	}{
+		"Empty": {
+			data:    "",
+			matcher: gomega.BeNil(),
+		},
+		"Valid": {
+			data:    `[{"name": "approved-bucket", "expression": "object.spec.predictor.model.storageUri.startsWith('s3://approved/')"}]`,
+			matcher: gomega.BeNil(),
+		},
+		"UnknownField": {
+			data:    `[{"name": "approved-bucket", "rule": "true"}]`,
+			matcher: gomega.MatchError(gomega.ContainSubstring("unable to parse validationPolicies config json")),
+		},
+		"MissingName": {
+			data:    `[{"expression": "true"}]`,
+			matcher: gomega.MatchError(gomega.ContainSubstring("the name of the policies is required")),
+		},
+		"DuplicateName": {
+			data:    `[{"name": "policy", "expression": "true"}, {"name": "policy", "expression": "false"}]`,
+			matcher: gomega.MatchError(gomega.ContainSubstring(`duplicate policy "policy"`)),
+		},
+		"InvalidExpression": {
+			data:    `[{"name": "policy", "expression": "object.spec.predictor ==="}]`,
+			matcher: gomega.MatchError(gomega.ContainSubstring(`policy "policy"`)),
+		},
+		"NotBool": {
+			data:    `[{"name": "policy", "expression": "'gpu'"}]`,
+			matcher: gomega.MatchError(gomega.ContainSubstring("must evaluate to a bool")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			_, err := Parse(scenario.data)
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	programs, err := Compile([]Policy{
+		{
+			Name:       "gpu-priority-class",
+			Expression: "!has(object.spec.predictor.model.resources.limits) || !('nvidia.com/gpu' in object.spec.predictor.model.resources.limits) || has(object.spec.predictor.priorityClassName)",
+			Message:    "the GPU InferenceServices must set a priorityClassName",
+		},
+		{
+			Name:       "immutable-storage-uri",
+			Expression: "oldObject == null || object.spec.predictor.model.storageUri == oldObject.spec.predictor.model.storageUri",
+		},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	isvc := func(storageUri string, gpu bool, priorityClassName string) map[string]interface{} {
+		model := map[string]interface{}{"storageUri": storageUri, "resources": map[string]interface{}{}}
+		if gpu {
+			model["resources"] = map[string]interface{}{"limits": map[string]interface{}{"nvidia.com/gpu": "1"}}
+		}
+		predictor := map[string]interface{}{"model": model}
+		if priorityClassName != "" {
+			predictor["priorityClassName"] = priorityClassName
+		}
+		return map[string]interface{}{"spec": map[string]interface{}{"predictor": predictor}}
+	}
+
+	g.Expect(Evaluate(programs, isvc("s3://models/llama", false, ""), nil)).To(gomega.Succeed())
+	g.Expect(Evaluate(programs, isvc("s3://models/llama", true, "high"), nil)).To(gomega.Succeed())
+	g.Expect(Evaluate(programs, isvc("s3://models/llama", true, ""), nil)).To(gomega.MatchError(
+		`validation policy "gpu-priority-class" denied the request: the GPU InferenceServices must set a priorityClassName`))
+	g.Expect(Evaluate(programs, isvc("s3://models/llama", false, ""), isvc("s3://models/llama", false, ""))).To(
+		gomega.Succeed())
+	g.Expect(Evaluate(programs, isvc("s3://models/mistral", false, ""), isvc("s3://models/llama", false, ""))).To(
+		gomega.MatchError(gomega.ContainSubstring(`validation policy "immutable-storage-uri" denied the request: failed expression`)))
+	g.Expect(Evaluate(programs, map[string]interface{}{"spec": map[string]interface{}{}}, nil)).To(
+		gomega.MatchError(gomega.ContainSubstring(`validation policy "gpu-priority-class" failed to evaluate`)))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/validationpolicy"
+	v1 "k8s.io/api/core/v1"
+	k8types "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-inferenceservice-policies,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,verbs=create;update,versions=v1beta1,name=inferenceservice.kserve-webhook-server.policy-validator
+var log = logf.Log.WithName(constants.PolicyValidatorWebhookName)
+
+// PolicyValidator is a webhook that rejects the InferenceServices violating the validation policies of the
+// inferenceservice-config ConfigMap
+type PolicyValidator struct {
+	Client client.Client
+
+	// The policies are only compiled again when the ConfigMap changes
+	mu       sync.Mutex
+	data     string
+	programs []validationpolicy.Program
+}
+
+// Handle evaluates the validation policies for the incoming InferenceService.
+func (validator *PolicyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	configMap := &v1.ConfigMap{}
+	err := validator.Client.Get(ctx, k8types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap)
+	if err != nil {
+		log.Error(err, "Failed to find config map", "name", constants.InferenceServiceConfigMapName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	programs, err := validator.getPrograms(configMap.Data[validationpolicy.ConfigMapKey])
+	if err != nil {
+		log.Error(err, "Failed to compile the validation policies", "name", constants.InferenceServiceConfigMapName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(programs) == 0 {
+		return admission.Allowed("")
+	}
+
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &object); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var oldObject map[string]interface{}
+	if len(req.OldObject.Raw) != 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &oldObject); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+	if err := validationpolicy.Evaluate(programs, object, oldObject); err != nil {
+		log.Info("Denied InferenceService", "namespace", req.Namespace, "name", req.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (validator *PolicyValidator) getPrograms(data string) ([]validationpolicy.Program, error) {
+	validator.mu.Lock()
+	defer validator.mu.Unlock()
+	if validator.programs != nil && validator.data == data {
+		return validator.programs, nil
+	}
+	programs, err := validationpolicy.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	validator.data = data
+	validator.programs = programs
+	return programs, nil
+}
+
+// InjectClient injects the client.
+func (validator *PolicyValidator) InjectClient(c client.Client) error {
+	validator.Client = c
+	return nil
+}
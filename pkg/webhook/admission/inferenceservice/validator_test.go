@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/validationpolicy"
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestPolicyValidator(t *testing.T) {
+	policies := `[{
+		"name": "approved-bucket",
+		"expression": "object.spec.predictor.model.storageUri.startsWith('s3://approved/')",
+		"message": "the models must be stored in the approved bucket"
+	}]`
+	newRequest := func(storageUri string, operation admissionv1.Operation, oldStorageUri string) admission.Request {
+		newRaw := func(storageUri string) []byte {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
+							ModelFormat: v1beta1.ModelFormat{Name: "huggingface"},
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								StorageURI: &storageUri,
+							},
+						},
+					},
+				},
+			}
+			raw, _ := json.Marshal(isvc)
+			return raw
+		}
+		request := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Name:      "llama",
+			Namespace: "default",
+			Object:    runtime.RawExtension{Raw: newRaw(storageUri)},
+		}}
+		if oldStorageUri != "" {
+			request.OldObject = runtime.RawExtension{Raw: newRaw(oldStorageUri)}
+		}
+		return request
+	}
+	newValidator := func(data map[string]string) *PolicyValidator {
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data:       data,
+		}
+		validator := &PolicyValidator{}
+		validator.InjectClient(fake.NewClientBuilder().WithObjects(configMap).Build())
+		return validator
+	}
+
+	scenarios := map[string]struct {
+		data    map[string]string
+		request admission.Request
+		allowed bool
+		code    int32
+		reason  string
+	}{
+		"NoPolicies": {
+			data:    map[string]string{},
+			request: newRequest("gs://unapproved/llama", admissionv1.Create, ""),
+			allowed: true,
+			code:    200,
+		},
+		"Allowed": {
+			data:    map[string]string{validationpolicy.ConfigMapKey: policies},
+			request: newRequest("s3://approved/llama", admissionv1.Create, ""),
+			allowed: true,
+			code:    200,
+		},
+		"Denied": {
+			data:    map[string]string{validationpolicy.ConfigMapKey: policies},
+			request: newRequest("gs://unapproved/llama", admissionv1.Create, ""),
+			allowed: false,
+			code:    403,
+			reason:  `validation policy "approved-bucket" denied the request: the models must be stored in the approved bucket`,
+		},
+		"OldObject": {
+			data: map[string]string{validationpolicy.ConfigMapKey: `[{
+				"name": "immutable-storage-uri",
+				"expression": "oldObject == null || object.spec.predictor.model.storageUri == oldObject.spec.predictor.model.storageUri"
+			}]`},
+			request: newRequest("s3://approved/mistral", admissionv1.Update, "s3://approved/llama"),
+			allowed: false,
+			code:    403,
+			reason:  `validation policy "immutable-storage-uri" denied the request: failed expression: oldObject == null || object.spec.predictor.model.storageUri == oldObject.spec.predictor.model.storageUri`,
+		},
+		"InvalidPolicies": {
+			data:    map[string]string{validationpolicy.ConfigMapKey: `[{"name": "policy", "expression": "object.spec ==="}]`},
+			request: newRequest("s3://approved/llama", admissionv1.Create, ""),
+			allowed: false,
+			code:    500,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			response := newValidator(scenario.data).Handle(context.TODO(), scenario.request)
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			g.Expect(response.Result.Code).To(gomega.Equal(scenario.code))
+			if scenario.reason != "" {
+				g.Expect(string(response.Result.Reason)).To(gomega.Equal(scenario.reason))
+			}
+		})
+	}
+}
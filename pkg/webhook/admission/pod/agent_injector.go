@@ -20,25 +20,44 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/kserve/kserve/pkg/agentconfig"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/fips"
+	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
-	LoggerConfigMapKeyName         = "logger"
-	LoggerArgumentLogUrl           = "--log-url"
-	LoggerArgumentSourceUri        = "--source-uri"
-	LoggerArgumentMode             = "--log-mode"
-	LoggerArgumentInferenceService = "--inference-service"
-	LoggerArgumentNamespace        = "--namespace"
-	LoggerArgumentEndpoint         = "--endpoint"
-	LoggerArgumentComponent        = "--component"
+	LoggerConfigMapKeyName          = "logger"
+	LoggerArgumentLogUrl            = "--log-url"
+	LoggerArgumentSourceUri         = "--source-uri"
+	LoggerArgumentMode              = "--log-mode"
+	LoggerArgumentInferenceService  = "--inference-service"
+	LoggerArgumentNamespace         = "--namespace"
+	LoggerArgumentEndpoint          = "--endpoint"
+	LoggerArgumentComponent         = "--component"
+	LoggerArgumentEncryptionKeyFile = "--log-encryption-key-file"
+	LoggerArgumentEncryptionKeyId   = "--log-encryption-key-id"
+	LoggerArgumentEncryptionKMSKey  = "--log-encryption-kms-key"
+	LoggerArgumentDelivery          = "--log-delivery"
+	LoggerArgumentSamplingPercent   = "--log-sampling-percent"
+	LoggerArgumentOptOutHeader      = "--log-opt-out-header"
+	LoggerArgumentResponseCodes     = "--log-response-codes"
+	LoggerArgumentRedact            = "--log-redact"
+	LoggerArgumentEncoding          = "--log-encoding"
+	LoggerArgumentEventType         = "--log-event-type"
+	LoggerArgumentEventSource       = "--log-event-source"
+	LoggerArgumentSchemaVersion     = "--log-schema-version"
+	LoggerArgumentTransformUrl      = "--log-transform-url"
+	LoggerArgumentHashFields        = "--log-hash-fields"
+	LoggerArgumentHashKeyFile       = "--log-hash-key-file"
 )
 
 type AgentConfig struct {
@@ -47,6 +66,13 @@ type AgentConfig struct {
 	CpuLimit      string `json:"cpuLimit"`
 	MemoryRequest string `json:"memoryRequest"`
 	MemoryLimit   string `json:"memoryLimit"`
+	// TLS settings of the outgoing connections of the agent, the Go defaults are used when empty
+	TLSMinVersion   string `json:"tlsMinVersion,omitempty"`
+	TLSCipherSuites string `json:"tlsCipherSuites,omitempty"`
+	// s3://<bucket>/<prefix> location the model puller caches the downloaded models in, shared across clusters
+	CacheStorageURI string `json:"cacheStorageUri,omitempty"`
+	// Duration the cached models are kept for since they were last used, e.g. 168h
+	CacheMaxAge string `json:"cacheMaxAge,omitempty"`
 }
 
 type LoggerConfig struct {
@@ -87,6 +113,18 @@ func getAgentConfigs(configMap *v1.ConfigMap) (*AgentConfig, error) {
 				constants.AgentConfigMapKeyName, err.Error())
 		}
 	}
+	if _, err := fips.NewTLSSettings(agentConfig.TLSMinVersion, agentConfig.TLSCipherSuites); err != nil {
+		return agentConfig, fmt.Errorf("invalid TLS configuration for %q: %s", constants.AgentConfigMapKeyName, err.Error())
+	}
+	if agentConfig.CacheStorageURI != "" && !strings.HasPrefix(agentConfig.CacheStorageURI, "s3://") {
+		return agentConfig, fmt.Errorf("invalid cache storage uri for %q: %s is not an s3:// uri",
+			constants.AgentConfigMapKeyName, agentConfig.CacheStorageURI)
+	}
+	if agentConfig.CacheMaxAge != "" {
+		if _, err := time.ParseDuration(agentConfig.CacheMaxAge); err != nil {
+			return agentConfig, fmt.Errorf("invalid cache max age for %q: %s", constants.AgentConfigMapKeyName, err.Error())
+		}
+	}
 
 	return agentConfig, nil
 }
@@ -121,8 +159,32 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	_, injectLogger := pod.ObjectMeta.Annotations[constants.LoggerInternalAnnotationKey]
 	_, injectPuller := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]
 	_, injectBatcher := pod.ObjectMeta.Annotations[constants.BatcherInternalAnnotationKey]
-
-	if !injectLogger && !injectPuller && !injectBatcher {
+	injectOpenAPI := pod.ObjectMeta.Annotations[constants.EnableOpenAPIAnnotationKey] == "true"
+	tokenAudience, injectAuth := pod.ObjectMeta.Annotations[constants.TokenAudienceAnnotationKey]
+	injectAllowedHosts := pod.ObjectMeta.Annotations[constants.EnforceAllowedHostsAnnotationKey] == "true"
+	deprecation, injectDeprecation := pod.ObjectMeta.Annotations[constants.DeprecationAnnotationKey]
+	apiKeySecret, injectAPIKeys := pod.ObjectMeta.Annotations[constants.APIKeySecretAnnotationKey]
+	tenantHeader, injectTenants := pod.ObjectMeta.Annotations[constants.TenantHeaderAnnotationKey]
+	modelNames, injectModelNames := pod.ObjectMeta.Annotations[constants.ModelNamesAnnotationKey]
+	rateLimitRPS, injectRateLimit := pod.ObjectMeta.Annotations[constants.RateLimitRPSInternalAnnotationKey]
+	failureThreshold, injectCircuitBreaker := pod.ObjectMeta.Annotations[constants.CircuitBreakerFailureThresholdAnnotationKey]
+	retryBudget, injectRetryBudget := pod.ObjectMeta.Annotations[constants.RetryBudgetPercentageAnnotationKey]
+	_, injectConcurrency := pod.ObjectMeta.Annotations[constants.ConcurrencyMetricsInternalAnnotationKey]
+	predictionSinkUrl, injectPredictionSink := pod.ObjectMeta.Annotations[constants.PredictionSinkURLAnnotationKey]
+	injectAsync := pod.ObjectMeta.Annotations[constants.EnableAsyncAnnotationKey] == "true"
+	payloadPrefixes, injectPayload := pod.ObjectMeta.Annotations[constants.PayloadURIPrefixesAnnotationKey]
+	logRetryMaxAge, injectLogRetries := pod.ObjectMeta.Annotations[constants.LoggerRetryMaxAgeAnnotationKey]
+	injectLogRetries = injectLogRetries && injectLogger
+	// The agent config is set on the components with a logger, a batcher or a maintenance mode
+	agentConfigName, injectAgentConfig := pod.ObjectMeta.Annotations[constants.AgentConfigInternalAnnotationKey]
+	// The rejected requests, the retries, the requests in flight, the requests per api key and tenant, the
+	// buffered predictions, the queued log events and the queued asynchronous requests are counted in the agent metrics
+	exposeMetrics := anyOf(injectAllowedHosts, injectAPIKeys, injectTenants, injectRateLimit, injectCircuitBreaker,
+		injectRetryBudget, injectConcurrency, injectPredictionSink, injectLogRetries, injectAsync)
+
+	// A single agent container serves all the features enabled on the pod, the raw deployment services route to it
+	// with the same predicate
+	if !utils.IsAgentInjected(pod.ObjectMeta.Annotations) {
 		return nil
 	}
 
@@ -147,6 +209,21 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			args = append(args, constants.AgentModelDirArgName)
 			args = append(args, modelDir)
 		}
+
+		if ag.agentConfig.CacheStorageURI != "" {
+			args = append(args, constants.AgentCacheStorageURIArgName, ag.agentConfig.CacheStorageURI)
+			if ag.agentConfig.CacheMaxAge != "" {
+				args = append(args, constants.AgentCacheMaxAgeArgName, ag.agentConfig.CacheMaxAge)
+			}
+		}
+
+		// The agent reports the server ready according to the models loaded
+		if policy, ok := pod.ObjectMeta.Annotations[constants.ModelReadinessPolicyAnnotationKey]; ok {
+			args = append(args, constants.AgentReadinessPolicyArgName, policy)
+			if minModels, ok := pod.ObjectMeta.Annotations[constants.ModelReadinessMinModelsAnnotationKey]; ok {
+				args = append(args, constants.AgentMinModelsArgName, minModels)
+			}
+		}
 	}
 	// Only inject if the batcher required annotations are set
 	if injectBatcher {
@@ -162,6 +239,16 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			args = append(args, BatcherArgumentMaxLatency)
 			args = append(args, maxLatency)
 		}
+
+		targetP99Latency, ok := pod.ObjectMeta.Annotations[constants.BatcherTargetP99LatencyInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentTargetP99Latency, targetP99Latency)
+		}
+
+		priorityHeader, ok := pod.ObjectMeta.Annotations[constants.BatcherPriorityHeaderInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentPriorityHeader, priorityHeader)
+		}
 	}
 	// Only inject if the logger required annotations are set
 	if injectLogger {
@@ -197,6 +284,221 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			component,
 		}
 		args = append(args, loggerArgs...)
+
+		if delivery, ok := pod.ObjectMeta.Annotations[constants.LoggerDeliveryInternalAnnotationKey]; ok {
+			args = append(args, LoggerArgumentDelivery, delivery)
+		}
+		// The requests are sampled and filtered before their payloads are buffered
+		for _, filter := range []struct{ annotation, arg string }{
+			{constants.LoggerSamplingPercentInternalAnnotationKey, LoggerArgumentSamplingPercent},
+			{constants.LoggerOptOutHeaderInternalAnnotationKey, LoggerArgumentOptOutHeader},
+			{constants.LoggerResponseCodesInternalAnnotationKey, LoggerArgumentResponseCodes},
+			{constants.LoggerRedactInternalAnnotationKey, LoggerArgumentRedact},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[filter.annotation]; ok {
+				args = append(args, filter.arg, value)
+			}
+		}
+		// The log events are written in batches when the log url is a blob storage uri
+		for _, blob := range []struct{ annotation, arg string }{
+			{constants.LoggerBlobBatchSizeAnnotationKey, constants.AgentLogBlobBatchSizeArgName},
+			{constants.LoggerBlobFlushIntervalAnnotationKey, constants.AgentLogBlobFlushArgName},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[blob.annotation]; ok {
+				args = append(args, blob.arg, value)
+			}
+		}
+		// The event format is customized for the consumers with strict event contracts
+		for _, format := range []struct{ annotation, arg string }{
+			{constants.LoggerEncodingAnnotationKey, LoggerArgumentEncoding},
+			{constants.LoggerEventTypeAnnotationKey, LoggerArgumentEventType},
+			{constants.LoggerEventSourceAnnotationKey, LoggerArgumentEventSource},
+			{constants.LoggerSchemaVersionAnnotationKey, LoggerArgumentSchemaVersion},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[format.annotation]; ok {
+				args = append(args, format.arg, value)
+			}
+		}
+
+		// The key encryption key is read from a secret of the InferenceService namespace
+		if secretName, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionKeySecretAnnotationKey]; ok {
+			args = append(args, LoggerArgumentEncryptionKeyFile,
+				constants.LoggerEncryptionKeyDir+"/"+constants.LoggerEncryptionKeySecretKey)
+			args = append(args, LoggerArgumentEncryptionKeyId, namespace+"/"+secretName)
+		}
+		// The key encryption key stays in the KMS, the agent calls it with the identity of the service account
+		if keyRef, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionKMSKeyAnnotationKey]; ok {
+			args = append(args, LoggerArgumentEncryptionKMSKey, keyRef)
+		}
+		// The payloads are anonymized before they are encrypted and sent
+		if transformUrl, ok := pod.ObjectMeta.Annotations[constants.LoggerTransformURLAnnotationKey]; ok {
+			args = append(args, LoggerArgumentTransformUrl, transformUrl)
+		}
+		if hashFields, ok := pod.ObjectMeta.Annotations[constants.LoggerHashFieldsAnnotationKey]; ok {
+			args = append(args, LoggerArgumentHashFields, hashFields)
+			if _, ok := pod.ObjectMeta.Annotations[constants.LoggerHashKeySecretAnnotationKey]; ok {
+				args = append(args, LoggerArgumentHashKeyFile, constants.LoggerHashKeyDir+"/"+constants.LoggerHashKeySecretKey)
+			}
+		}
+		// The log events the logger sink did not accept are retried from a disk queue
+		if injectLogRetries {
+			bufferSize, ok := pod.ObjectMeta.Annotations[constants.LoggerRetryBufferSizeAnnotationKey]
+			if !ok {
+				bufferSize = constants.DefaultLoggerRetryBufferSize
+			}
+			args = append(args, constants.AgentLogRetryMaxAgeArgName, logRetryMaxAge)
+			args = append(args, constants.AgentLogRetryDirArgName, constants.LoggerRetryDir)
+			args = append(args, constants.AgentLogRetrySizeArgName, bufferSize)
+			if deadLetterUri, ok := pod.ObjectMeta.Annotations[constants.LoggerDeadLetterURIAnnotationKey]; ok {
+				args = append(args, constants.AgentDeadLetterURIArgName, deadLetterUri)
+			}
+		}
+	}
+	// The logger sees the requests before they are batched unless the pipeline order says otherwise
+	if pipelineOrder, ok := pod.ObjectMeta.Annotations[constants.AgentPipelineOrderAnnotationKey]; ok &&
+		injectLogger && injectBatcher {
+		args = append(args, constants.AgentPipelineOrderArgName, pipelineOrder)
+	}
+	// The logger, batcher and maintenance settings are reloaded from the agent config of the component
+	if injectAgentConfig {
+		args = append(args, constants.AgentConfigFileArgName, constants.AgentConfigDir+"/"+
+			agentconfig.FileName(pod.ObjectMeta.Labels[constants.KServiceComponentLabel]))
+	}
+	// Only inject if the prediction sink annotation is set
+	if injectPredictionSink {
+		bufferSize, ok := pod.ObjectMeta.Annotations[constants.PredictionBufferSizeAnnotationKey]
+		if !ok {
+			bufferSize = constants.DefaultPredictionBufferSize
+		}
+		args = append(args, constants.AgentPredictionSinkArgName, predictionSinkUrl)
+		args = append(args, constants.AgentBufferDirArgName, constants.PredictionBufferDir)
+		args = append(args, constants.AgentBufferSizeArgName, bufferSize)
+		// The predictions are published with the same attributes as the logged payloads
+		if !injectLogger {
+			args = append(args,
+				LoggerArgumentSourceUri, pod.ObjectMeta.Name,
+				LoggerArgumentInferenceService, pod.ObjectMeta.Labels[constants.InferenceServiceLabel],
+				LoggerArgumentNamespace, pod.ObjectMeta.Namespace,
+				LoggerArgumentEndpoint, pod.ObjectMeta.Labels[constants.KServiceEndpointLabel],
+				LoggerArgumentComponent, pod.ObjectMeta.Labels[constants.KServiceComponentLabel],
+			)
+		}
+	}
+	// Only inject if the payload uri prefixes annotation is set
+	if injectPayload {
+		args = append(args, constants.AgentPayloadPrefixesArgName, payloadPrefixes)
+		if maxSize, ok := pod.ObjectMeta.Annotations[constants.PayloadMaxSizeAnnotationKey]; ok {
+			args = append(args, constants.AgentPayloadMaxSizeArgName, maxSize)
+		}
+		if responseUri, ok := pod.ObjectMeta.Annotations[constants.PayloadResponseURIAnnotationKey]; ok {
+			args = append(args, constants.AgentPayloadResponseArgName, responseUri)
+		}
+	}
+	// Only inject if the enable async annotation is set
+	if injectAsync {
+		args = append(args, constants.AgentEnableAsyncFlag)
+		for _, setting := range []struct{ annotation, arg string }{
+			{constants.AsyncWorkersAnnotationKey, constants.AgentAsyncWorkersArgName},
+			{constants.AsyncQueueSizeAnnotationKey, constants.AgentAsyncQueueSizeArgName},
+			{constants.AsyncResultTTLAnnotationKey, constants.AgentAsyncResultTTLArgName},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[setting.annotation]; ok {
+				args = append(args, setting.arg, value)
+			}
+		}
+		brokerUrl, hasBroker := pod.ObjectMeta.Annotations[constants.AsyncBrokerURLAnnotationKey]
+		if hasBroker {
+			args = append(args, constants.AgentAsyncBrokerArgName, brokerUrl)
+		}
+		callbackUrl, hasCallback := pod.ObjectMeta.Annotations[constants.AsyncCallbackURLAnnotationKey]
+		if hasCallback {
+			args = append(args, constants.AgentAsyncCallbackArgName, callbackUrl)
+		}
+		// The responses are sent and the shared queue is named with the same attributes as the logged payloads
+		if (hasBroker || hasCallback) && !injectLogger && !injectPredictionSink {
+			args = append(args,
+				LoggerArgumentSourceUri, pod.ObjectMeta.Name,
+				LoggerArgumentInferenceService, pod.ObjectMeta.Labels[constants.InferenceServiceLabel],
+				LoggerArgumentNamespace, pod.ObjectMeta.Namespace,
+				LoggerArgumentEndpoint, pod.ObjectMeta.Labels[constants.KServiceEndpointLabel],
+				LoggerArgumentComponent, pod.ObjectMeta.Labels[constants.KServiceComponentLabel],
+			)
+		}
+	}
+	// Only inject if the openapi annotation is set
+	if injectOpenAPI {
+		// v2 runtimes name the model after the model repository, which may differ from the InferenceService
+		modelName, ok := pod.ObjectMeta.Annotations[constants.OpenAPIModelNameAnnotationKey]
+		if !ok || modelName == "" {
+			modelName = pod.ObjectMeta.Labels[constants.InferenceServiceLabel]
+		}
+		args = append(args, constants.AgentEnableOpenAPIFlag)
+		args = append(args, constants.AgentModelNameArgName)
+		args = append(args, modelName)
+	}
+	// Only inject if the token audience annotation is set
+	if injectAuth {
+		args = append(args, constants.AgentTokenAudienceArgName, tokenAudience)
+	}
+	// Only inject if the deprecation annotation is set
+	if injectDeprecation {
+		args = append(args, constants.AgentDeprecationArgName, deprecation)
+		if sunset, ok := pod.ObjectMeta.Annotations[constants.SunsetAnnotationKey]; ok {
+			args = append(args, constants.AgentSunsetArgName, sunset)
+		}
+		if percentage, ok := pod.ObjectMeta.Annotations[constants.SunsetRejectPercentageAnnotationKey]; ok {
+			args = append(args, constants.AgentSunsetRejectArgName, percentage)
+		}
+		if link, ok := pod.ObjectMeta.Annotations[constants.DeprecationLinkAnnotationKey]; ok {
+			args = append(args, constants.AgentDeprecationLinkArgName, link)
+		}
+	}
+	// Only inject if the api key secret annotation is set
+	if injectAPIKeys {
+		args = append(args, constants.AgentAPIKeysDirArgName, constants.APIKeysDir)
+	}
+	// Only inject if the tenant header annotation is set
+	if injectTenants {
+		args = append(args, constants.AgentTenantHeaderArgName, tenantHeader)
+	}
+	// Only inject if the model names annotation is set
+	if injectModelNames {
+		args = append(args, constants.AgentModelNamesArgName, modelNames)
+	}
+	// Only inject if the rate limit required annotations are set
+	if injectRateLimit {
+		args = append(args, constants.AgentRateLimitRPSArgName, rateLimitRPS)
+		if burst, ok := pod.ObjectMeta.Annotations[constants.RateLimitBurstInternalAnnotationKey]; ok {
+			args = append(args, constants.AgentRateLimitBurstArgName, burst)
+		}
+	}
+	// Only inject if the circuit breaker annotation is set
+	if injectCircuitBreaker {
+		args = append(args, constants.AgentFailureThresholdArgName, failureThreshold)
+		if openDuration, ok := pod.ObjectMeta.Annotations[constants.CircuitBreakerOpenDurationAnnotationKey]; ok {
+			args = append(args, constants.AgentOpenDurationArgName, openDuration)
+		}
+	}
+	// Only inject if the retry budget annotation is set
+	if injectRetryBudget {
+		args = append(args, constants.AgentRetryBudgetArgName, retryBudget)
+	}
+	// Only inject if the concurrency metrics annotation is set
+	if injectConcurrency {
+		args = append(args, constants.AgentConcurrencyMetricsFlag)
+	}
+	// Only inject if the enforce allowed hosts annotation is set
+	if injectAllowedHosts {
+		args = append(args, constants.AgentAllowedHostsArgName, strings.Join(allowedHosts(pod), ","))
+	}
+	if exposeMetrics {
+		args = append(args, constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr)
+	}
+	if ag.agentConfig.TLSMinVersion != "" {
+		args = append(args, constants.AgentTLSMinVersionArgName, ag.agentConfig.TLSMinVersion)
+	}
+	if ag.agentConfig.TLSCipherSuites != "" {
+		args = append(args, constants.AgentTLSCipherSuitesArgName, ag.agentConfig.TLSCipherSuites)
 	}
 
 	var queueProxyEnvs []v1.EnvVar
@@ -218,6 +520,10 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			}
 
 			args = append(args, "--component-port", containerPort)
+			// The health and reflection services of the gRPC runtimes are answered by the agent
+			if len(container.Ports) > 0 && container.Ports[0].Name == constants.GrpcPortName {
+				args = append(args, constants.AgentEnableGrpcFlag)
+			}
 		}
 	}
 
@@ -279,6 +585,14 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 		},
 	}
 
+	if exposeMetrics {
+		agentContainer.Ports = append(agentContainer.Ports, v1.ContainerPort{
+			Name:          constants.AgentMetricsPortName,
+			ContainerPort: constants.AgentMetricsPort,
+			Protocol:      "TCP",
+		})
+	}
+
 	// Inject credentials
 	if err := ag.credentialBuilder.CreateSecretVolumeAndEnv(
 		pod.Namespace,
@@ -292,6 +606,37 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	// Add container to the spec
 	pod.Spec.Containers = append(pod.Spec.Containers, *agentContainer)
 
+	if secretName, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionKeySecretAnnotationKey]; ok && injectLogger {
+		mountLoggerKey(pod, secretName, constants.LoggerEncryptionKeyVolumeName, constants.LoggerEncryptionKeySecretKey,
+			constants.LoggerEncryptionKeyDir)
+	}
+	if secretName, ok := pod.ObjectMeta.Annotations[constants.LoggerHashKeySecretAnnotationKey]; ok && injectLogger {
+		if _, ok := pod.ObjectMeta.Annotations[constants.LoggerHashFieldsAnnotationKey]; ok {
+			mountLoggerKey(pod, secretName, constants.LoggerHashKeyVolumeName, constants.LoggerHashKeySecretKey,
+				constants.LoggerHashKeyDir)
+		}
+	}
+
+	if injectAPIKeys {
+		mountAPIKeys(pod, apiKeySecret)
+	}
+
+	if injectPredictionSink {
+		if err := mountPredictionBuffer(pod); err != nil {
+			return err
+		}
+	}
+
+	if injectLogRetries {
+		if err := mountLoggerRetryQueue(pod); err != nil {
+			return err
+		}
+	}
+
+	if injectAgentConfig {
+		mountAgentConfig(pod, agentConfigName)
+	}
+
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]; ok {
 		// Mount the modelDir volume to the pod and model agent container
 		err := mountModelDir(pod)
@@ -308,6 +653,29 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	return nil
 }
 
+// allowedHosts returns the hosts the requests of the InferenceService component are routed with, the top level
+// InferenceService host and the component service host. The agent also allows the cluster local and external hosts
+// they prefix, the hosts of custom domain templates are added with the additional allowed hosts annotation.
+func allowedHosts(pod *v1.Pod) []string {
+	name := pod.ObjectMeta.Labels[constants.InferenceServiceLabel]
+	component := constants.InferenceServiceComponent(pod.ObjectMeta.Labels[constants.KServiceComponentLabel])
+	if component == "" {
+		component = constants.Predictor
+	}
+	hosts := []string{
+		name + "." + pod.ObjectMeta.Namespace,
+		constants.DefaultServiceName(name, component) + "." + pod.ObjectMeta.Namespace,
+	}
+	if additional, ok := pod.ObjectMeta.Annotations[constants.AdditionalAllowedHostsAnnotationKey]; ok {
+		for _, host := range strings.Split(additional, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
+
 func mountModelDir(pod *v1.Pod) error {
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentModelDirAnnotationKey]; ok {
 		modelDirVolume := v1.Volume{
@@ -325,6 +693,30 @@ func mountModelDir(pod *v1.Pod) error {
 	return fmt.Errorf("can not find %v label", constants.AgentModelConfigVolumeNameAnnotationKey)
 }
 
+// anyOf returns whether any of the features is enabled
+func anyOf(features ...bool) bool {
+	for _, enabled := range features {
+		if enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func mountAgentConfig(pod *v1.Pod, configMapName string) {
+	agentConfigVolume := v1.Volume{
+		Name: constants.AgentConfigVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: configMapName,
+				},
+			},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, agentConfigVolume, constants.AgentConfigDir)
+}
+
 func mountModelConfig(pod *v1.Pod) error {
 	if modelConfigName, ok := pod.ObjectMeta.Annotations[constants.AgentModelConfigVolumeNameAnnotationKey]; ok {
 		modelConfigVolume := v1.Volume{
@@ -343,6 +735,80 @@ func mountModelConfig(pod *v1.Pod) error {
 	return fmt.Errorf("can not find %v label", constants.AgentModelConfigVolumeNameAnnotationKey)
 }
 
+// mountLoggerKey mounts a key of the logger, the encryption or the hashing key, from a secret of the InferenceService
+// namespace
+func mountLoggerKey(pod *v1.Pod, secretName string, volumeName string, key string, dir string) {
+	keyVolume := v1.Volume{
+		Name: volumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secretName,
+				Items: []v1.KeyToPath{
+					{
+						Key:  key,
+						Path: key,
+					},
+				},
+			},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, keyVolume, dir)
+}
+
+func mountAPIKeys(pod *v1.Pod, secretName string) {
+	keysVolume := v1.Volume{
+		Name: constants.APIKeysVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, keysVolume, constants.APIKeysDir)
+}
+
+// mountPredictionBuffer mounts an emptyDir holding the buffered predictions, it outlives the restarts of the agent
+// container and is bounded by the size of the buffer
+func mountPredictionBuffer(pod *v1.Pod) error {
+	bufferSize, ok := pod.ObjectMeta.Annotations[constants.PredictionBufferSizeAnnotationKey]
+	if !ok {
+		bufferSize = constants.DefaultPredictionBufferSize
+	}
+	sizeLimit, err := resource.ParseQuantity(bufferSize)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation %q: %v", constants.PredictionBufferSizeAnnotationKey, bufferSize, err)
+	}
+	bufferVolume := v1.Volume{
+		Name: constants.PredictionBufferVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{SizeLimit: &sizeLimit},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, bufferVolume, constants.PredictionBufferDir)
+	return nil
+}
+
+// mountLoggerRetryQueue mounts an emptyDir holding the queued log events, it outlives the restarts of the agent
+// container and is bounded by the size of the retry buffer
+func mountLoggerRetryQueue(pod *v1.Pod) error {
+	bufferSize, ok := pod.ObjectMeta.Annotations[constants.LoggerRetryBufferSizeAnnotationKey]
+	if !ok {
+		bufferSize = constants.DefaultLoggerRetryBufferSize
+	}
+	sizeLimit, err := resource.ParseQuantity(bufferSize)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation %q: %v", constants.LoggerRetryBufferSizeAnnotationKey, bufferSize, err)
+	}
+	retryVolume := v1.Volume{
+		Name: constants.LoggerRetryVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{SizeLimit: &sizeLimit},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, retryVolume, constants.LoggerRetryDir)
+	return nil
+}
+
 func mountVolumeToContainer(containerName string, pod *v1.Pod, additionalVolume v1.Volume, mountPath string) {
 	pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, additionalVolume)
 	var mountedContainers []v1.Container
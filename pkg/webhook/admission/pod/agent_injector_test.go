@@ -24,7 +24,9 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"knative.dev/pkg/kmp"
 
@@ -526,6 +528,681 @@ func TestAgentInjector(t *testing.T) {
 	}
 }
 
+func TestAgentInjectorArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	bufferSize := resource.MustParse("2Gi")
+	scenarios := map[string]struct {
+		agentConfig     *AgentConfig
+		annotations     map[string]string
+		labels          map[string]string
+		ports           []v1.ContainerPort
+		expectedArgs    []string
+		expectedVolumes []v1.Volume
+		expectedMounts  []v1.VolumeMount
+	}{
+		"LoggerEncryption": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:            "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:     "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:        string(v1beta1.LogAll),
+				constants.LoggerEncryptionKeySecretAnnotationKey: "logger-key",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentEncryptionKeyFile, "/mnt/logger-encryption/key",
+				LoggerArgumentEncryptionKeyId, "default/logger-key",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.LoggerEncryptionKeyVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: "logger-key",
+						Items:      []v1.KeyToPath{{Key: "key", Path: "key"}},
+					},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.LoggerEncryptionKeyVolumeName,
+				MountPath: constants.LoggerEncryptionKeyDir,
+			}},
+		},
+		"LoggerEncryptionKMS": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:         "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:  "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:     string(v1beta1.LogAll),
+				constants.LoggerEncryptionKMSKeyAnnotationKey: "aws-kms://arn:aws:kms:us-east-1:111122223333:alias/kserve-logs",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentEncryptionKMSKey, "aws-kms://arn:aws:kms:us-east-1:111122223333:alias/kserve-logs",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerTransform": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+				constants.LoggerTransformURLAnnotationKey:    "http://anonymizer.default/transform",
+				constants.LoggerHashFieldsAnnotationKey:      "email,ssn",
+				constants.LoggerHashKeySecretAnnotationKey:   "hash-key",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentTransformUrl, "http://anonymizer.default/transform",
+				LoggerArgumentHashFields, "email,ssn",
+				LoggerArgumentHashKeyFile, "/mnt/logger-hash-key/key",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.LoggerHashKeyVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: "hash-key",
+						Items:      []v1.KeyToPath{{Key: "key", Path: "key"}},
+					},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.LoggerHashKeyVolumeName,
+				MountPath: constants.LoggerHashKeyDir,
+			}},
+		},
+		"AgentConfig": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:      "true",
+				constants.BatcherInternalAnnotationKey:     "true",
+				constants.AgentConfigInternalAnnotationKey: "sklearn-agent-config",
+			},
+			labels: map[string]string{constants.KServiceComponentLabel: "predictor"},
+			expectedArgs: []string{
+				BatcherEnableFlag,
+				LoggerArgumentLogUrl, loggerConfig.DefaultUrl,
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "predictor",
+				constants.AgentConfigFileArgName, "/mnt/agent-config/predictor.json",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.AgentConfigVolumeName,
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "sklearn-agent-config"},
+					},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.AgentConfigVolumeName,
+				MountPath: constants.AgentConfigDir,
+			}},
+		},
+		"AgentConfigMaintenance": {
+			annotations: map[string]string{
+				constants.AgentConfigInternalAnnotationKey: "sklearn-agent-config",
+			},
+			labels: map[string]string{constants.KServiceComponentLabel: "transformer"},
+			expectedArgs: []string{
+				constants.AgentConfigFileArgName, "/mnt/agent-config/transformer.json",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.AgentConfigVolumeName,
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "sklearn-agent-config"},
+					},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.AgentConfigVolumeName,
+				MountPath: constants.AgentConfigDir,
+			}},
+		},
+		"AdaptiveBatcher": {
+			annotations: map[string]string{
+				constants.BatcherInternalAnnotationKey:                 "true",
+				constants.BatcherMaxBatchSizeInternalAnnotationKey:     "32",
+				constants.BatcherTargetP99LatencyInternalAnnotationKey: "200",
+				constants.BatcherPriorityHeaderInternalAnnotationKey:   "X-Priority",
+			},
+			expectedArgs: []string{
+				BatcherEnableFlag,
+				BatcherArgumentMaxBatchSize, "32",
+				BatcherArgumentTargetP99Latency, "200",
+				BatcherArgumentPriorityHeader, "X-Priority",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"AgentPipelineOrder": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:     "true",
+				constants.BatcherInternalAnnotationKey:    "true",
+				constants.AgentPipelineOrderAnnotationKey: constants.AgentPipelineBatchLog,
+			},
+			expectedArgs: []string{
+				BatcherEnableFlag,
+				LoggerArgumentLogUrl, loggerConfig.DefaultUrl,
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				constants.AgentPipelineOrderArgName, constants.AgentPipelineBatchLog,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerRetries": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+				constants.LoggerRetryMaxAgeAnnotationKey:     "1h",
+				constants.LoggerRetryBufferSizeAnnotationKey: "2Gi",
+				constants.LoggerDeadLetterURIAnnotationKey:   "s3://logger/dead-letter",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				constants.AgentLogRetryMaxAgeArgName, "1h",
+				constants.AgentLogRetryDirArgName, constants.LoggerRetryDir,
+				constants.AgentLogRetrySizeArgName, "2Gi",
+				constants.AgentDeadLetterURIArgName, "s3://logger/dead-letter",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.LoggerRetryVolumeName,
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{SizeLimit: &bufferSize},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.LoggerRetryVolumeName,
+				MountPath: constants.LoggerRetryDir,
+			}},
+		},
+		"LoggerAtLeastOnce": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:         "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:  "http://kafka-sink-ingress.knative-eventing/default/logs",
+				constants.LoggerModeInternalAnnotationKey:     string(v1beta1.LogAll),
+				constants.LoggerDeliveryInternalAnnotationKey: string(v1beta1.LogAtLeastOnce),
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://kafka-sink-ingress.knative-eventing/default/logs",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentDelivery, string(v1beta1.LogAtLeastOnce),
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerSamplingAndFilters": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:                "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:         "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:            string(v1beta1.LogAll),
+				constants.LoggerSamplingPercentInternalAnnotationKey: "5",
+				constants.LoggerOptOutHeaderInternalAnnotationKey:    "X-No-Log",
+				constants.LoggerResponseCodesInternalAnnotationKey:   "200,5xx",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentSamplingPercent, "5",
+				LoggerArgumentOptOutHeader, "X-No-Log",
+				LoggerArgumentResponseCodes, "200,5xx",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerRedact": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+				constants.LoggerRedactInternalAnnotationKey:  `{"paths":["$.instances[*].email"]}`,
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentRedact, `{"paths":["$.instances[*].email"]}`,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerBlobSink": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:          "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:   "s3://logs/payloads",
+				constants.LoggerModeInternalAnnotationKey:      string(v1beta1.LogAll),
+				constants.LoggerBlobBatchSizeAnnotationKey:     "500",
+				constants.LoggerBlobFlushIntervalAnnotationKey: "5m",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "s3://logs/payloads",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogAll),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				constants.AgentLogBlobBatchSizeArgName, "500",
+				constants.AgentLogBlobFlushArgName, "5m",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"LoggerEventFormat": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogRequest),
+				constants.LoggerEncodingAnnotationKey:        constants.LoggerStructuredEncoding,
+				constants.LoggerEventTypeAnnotationKey:       "com.example.inference.{{ .Kind }}",
+				constants.LoggerSchemaVersionAnnotationKey:   "2",
+			},
+			expectedArgs: []string{
+				LoggerArgumentLogUrl, "http://httpbin.org/",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentMode, string(v1beta1.LogRequest),
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				LoggerArgumentEncoding, constants.LoggerStructuredEncoding,
+				LoggerArgumentEventType, "com.example.inference.{{ .Kind }}",
+				LoggerArgumentSchemaVersion, "2",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"PredictionSink": {
+			annotations: map[string]string{
+				constants.PredictionSinkURLAnnotationKey:    "http://kafka-sink-ingress.knative-eventing/default/predictions",
+				constants.PredictionBufferSizeAnnotationKey: "2Gi",
+			},
+			expectedArgs: []string{
+				constants.AgentPredictionSinkArgName, "http://kafka-sink-ingress.knative-eventing/default/predictions",
+				constants.AgentBufferDirArgName, constants.PredictionBufferDir,
+				constants.AgentBufferSizeArgName, "2Gi",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.PredictionBufferVolumeName,
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{SizeLimit: &bufferSize},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.PredictionBufferVolumeName,
+				MountPath: constants.PredictionBufferDir,
+			}},
+		},
+		"TokenAudience": {
+			annotations: map[string]string{
+				constants.TokenAudienceAnnotationKey: "graph",
+			},
+			expectedArgs: []string{
+				constants.AgentTokenAudienceArgName, "graph",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"APIKeys": {
+			annotations: map[string]string{
+				constants.APIKeySecretAnnotationKey:        "sklearn-api-keys",
+				constants.EnforceAllowedHostsAnnotationKey: "true",
+			},
+			expectedArgs: []string{
+				constants.AgentAPIKeysDirArgName, constants.APIKeysDir,
+				constants.AgentAllowedHostsArgName, "sklearn.default,sklearn-predictor-default.default",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{{
+				Name: constants.APIKeysVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "sklearn-api-keys"},
+				},
+			}},
+			expectedMounts: []v1.VolumeMount{{
+				Name:      constants.APIKeysVolumeName,
+				MountPath: constants.APIKeysDir,
+			}},
+		},
+		"TenantHeader": {
+			annotations: map[string]string{
+				constants.TenantHeaderAnnotationKey: "X-Tenant-Id",
+			},
+			expectedArgs: []string{
+				constants.AgentTenantHeaderArgName, "X-Tenant-Id",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"ModelNames": {
+			annotations: map[string]string{
+				constants.ModelNamesAnnotationKey: "support=llama-2-7b-support,chat=llama-2-7b",
+			},
+			expectedArgs: []string{
+				constants.AgentModelNamesArgName, "support=llama-2-7b-support,chat=llama-2-7b",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"GrpcRuntime": {
+			annotations: map[string]string{
+				constants.TenantHeaderAnnotationKey: "X-Tenant-Id",
+			},
+			ports: []v1.ContainerPort{{Name: constants.GrpcPortName, ContainerPort: 8081}},
+			expectedArgs: []string{
+				constants.AgentTenantHeaderArgName, "X-Tenant-Id",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", "8081",
+				constants.AgentEnableGrpcFlag,
+			},
+		},
+		"RateLimit": {
+			annotations: map[string]string{
+				constants.RateLimitRPSInternalAnnotationKey:   "10",
+				constants.RateLimitBurstInternalAnnotationKey: "20",
+			},
+			expectedArgs: []string{
+				constants.AgentRateLimitRPSArgName, "10",
+				constants.AgentRateLimitBurstArgName, "20",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"CircuitBreaker": {
+			annotations: map[string]string{
+				constants.CircuitBreakerFailureThresholdAnnotationKey: "5",
+				constants.CircuitBreakerOpenDurationAnnotationKey:     "30s",
+				constants.RetryBudgetPercentageAnnotationKey:          "20",
+			},
+			expectedArgs: []string{
+				constants.AgentFailureThresholdArgName, "5",
+				constants.AgentOpenDurationArgName, "30s",
+				constants.AgentRetryBudgetArgName, "20",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"ConcurrencyMetrics": {
+			annotations: map[string]string{
+				constants.ConcurrencyMetricsInternalAnnotationKey: "true",
+			},
+			expectedArgs: []string{
+				constants.AgentConcurrencyMetricsFlag,
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"Deprecation": {
+			annotations: map[string]string{
+				constants.DeprecationAnnotationKey:            "2023-01-01T00:00:00Z",
+				constants.SunsetAnnotationKey:                 "2023-03-01T00:00:00Z",
+				constants.SunsetRejectPercentageAnnotationKey: "50",
+				constants.DeprecationLinkAnnotationKey:        "https://example.com/migration",
+			},
+			expectedArgs: []string{
+				constants.AgentDeprecationArgName, "2023-01-01T00:00:00Z",
+				constants.AgentSunsetArgName, "2023-03-01T00:00:00Z",
+				constants.AgentSunsetRejectArgName, "50",
+				constants.AgentDeprecationLinkArgName, "https://example.com/migration",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"AllowedHosts": {
+			annotations: map[string]string{
+				constants.EnforceAllowedHostsAnnotationKey:    "true",
+				constants.AdditionalAllowedHostsAnnotationKey: "sklearn-default.example.com, *.models.example.com",
+			},
+			expectedArgs: []string{
+				constants.AgentAllowedHostsArgName,
+				"sklearn.default,sklearn-predictor-default.default,sklearn-default.example.com,*.models.example.com",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"PayloadReferences": {
+			annotations: map[string]string{
+				constants.PayloadURIPrefixesAnnotationKey: "s3://inputs/images,gs://inputs",
+				constants.PayloadMaxSizeAnnotationKey:     "500Mi",
+				constants.PayloadResponseURIAnnotationKey: "s3://outputs/predictions",
+			},
+			expectedArgs: []string{
+				constants.AgentPayloadPrefixesArgName, "s3://inputs/images,gs://inputs",
+				constants.AgentPayloadMaxSizeArgName, "500Mi",
+				constants.AgentPayloadResponseArgName, "s3://outputs/predictions",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"Async": {
+			annotations: map[string]string{
+				constants.EnableAsyncAnnotationKey:      "true",
+				constants.AsyncWorkersAnnotationKey:     "2",
+				constants.AsyncResultTTLAnnotationKey:   "30m",
+				constants.AsyncCallbackURLAnnotationKey: "http://broker-ingress.knative-eventing/default/default",
+				constants.AsyncBrokerURLAnnotationKey:   "redis://redis.kserve:6379/0",
+			},
+			expectedArgs: []string{
+				constants.AgentEnableAsyncFlag,
+				constants.AgentAsyncWorkersArgName, "2",
+				constants.AgentAsyncResultTTLArgName, "30m",
+				constants.AgentAsyncBrokerArgName, "redis://redis.kserve:6379/0",
+				constants.AgentAsyncCallbackArgName, "http://broker-ingress.knative-eventing/default/default",
+				LoggerArgumentSourceUri, "sklearn-predictor",
+				LoggerArgumentInferenceService, "sklearn",
+				LoggerArgumentNamespace, "default",
+				LoggerArgumentEndpoint, "",
+				LoggerArgumentComponent, "",
+				constants.AgentMetricsPortArgName, constants.AgentMetricsPortStr,
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"OpenAPI": {
+			annotations: map[string]string{
+				constants.EnableOpenAPIAnnotationKey: "true",
+			},
+			expectedArgs: []string{
+				constants.AgentEnableOpenAPIFlag,
+				constants.AgentModelNameArgName, "sklearn",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"OpenAPIModelName": {
+			annotations: map[string]string{
+				constants.EnableOpenAPIAnnotationKey:    "true",
+				constants.OpenAPIModelNameAnnotationKey: "mnist",
+			},
+			expectedArgs: []string{
+				constants.AgentEnableOpenAPIFlag,
+				constants.AgentModelNameArgName, "mnist",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"TLSSettings": {
+			agentConfig: &AgentConfig{
+				Image:           "gcr.io/kfserving/agent:latest",
+				CpuRequest:      "100m",
+				CpuLimit:        "1",
+				MemoryRequest:   "200Mi",
+				MemoryLimit:     "1Gi",
+				TLSMinVersion:   "1.2",
+				TLSCipherSuites: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			},
+			annotations: map[string]string{
+				constants.TokenAudienceAnnotationKey: "graph",
+			},
+			expectedArgs: []string{
+				constants.AgentTokenAudienceArgName, "graph",
+				constants.AgentTLSMinVersionArgName, "1.2",
+				constants.AgentTLSCipherSuitesArgName, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+		},
+		"ModelCache": {
+			agentConfig: &AgentConfig{
+				Image:           "gcr.io/kfserving/agent:latest",
+				CpuRequest:      "100m",
+				CpuLimit:        "1",
+				MemoryRequest:   "200Mi",
+				MemoryLimit:     "1Gi",
+				CacheStorageURI: "s3://model-cache/kserve",
+				CacheMaxAge:     "168h",
+			},
+			annotations: map[string]string{
+				constants.AgentShouldInjectAnnotationKey:          "true",
+				constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-sklearn-0",
+				constants.AgentModelConfigMountPathAnnotationKey:  "/mnt/configs",
+				constants.AgentModelDirAnnotationKey:              "/mnt/models",
+			},
+			expectedArgs: []string{
+				constants.AgentEnableFlag,
+				constants.AgentConfigDirArgName, "/mnt/configs",
+				constants.AgentModelDirArgName, "/mnt/models",
+				constants.AgentCacheStorageURIArgName, "s3://model-cache/kserve",
+				constants.AgentCacheMaxAgeArgName, "168h",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{
+				{
+					Name:         constants.ModelDirVolumeName,
+					VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+				},
+				{
+					Name: constants.ModelConfigVolumeName,
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: v1.LocalObjectReference{Name: "modelconfig-sklearn-0"},
+						},
+					},
+				},
+			},
+			expectedMounts: []v1.VolumeMount{
+				{Name: constants.ModelDirVolumeName, MountPath: constants.ModelDir},
+				{Name: constants.ModelConfigVolumeName, MountPath: constants.ModelConfigDir},
+			},
+		},
+		"ModelReadiness": {
+			annotations: map[string]string{
+				constants.AgentShouldInjectAnnotationKey:          "true",
+				constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-sklearn-0",
+				constants.AgentModelConfigMountPathAnnotationKey:  "/mnt/configs",
+				constants.AgentModelDirAnnotationKey:              "/mnt/models",
+				constants.ModelReadinessPolicyAnnotationKey:       constants.ModelReadinessMinModels,
+				constants.ModelReadinessMinModelsAnnotationKey:    "3",
+			},
+			expectedArgs: []string{
+				constants.AgentEnableFlag,
+				constants.AgentConfigDirArgName, "/mnt/configs",
+				constants.AgentModelDirArgName, "/mnt/models",
+				constants.AgentReadinessPolicyArgName, constants.ModelReadinessMinModels,
+				constants.AgentMinModelsArgName, "3",
+				"--component-port", constants.InferenceServiceDefaultHttpPort,
+			},
+			expectedVolumes: []v1.Volume{
+				{
+					Name:         constants.ModelDirVolumeName,
+					VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+				},
+				{
+					Name: constants.ModelConfigVolumeName,
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{
+							LocalObjectReference: v1.LocalObjectReference{Name: "modelconfig-sklearn-0"},
+						},
+					},
+				},
+			},
+			expectedMounts: []v1.VolumeMount{
+				{Name: constants.ModelDirVolumeName, MountPath: constants.ModelDir},
+				{Name: constants.ModelConfigVolumeName, MountPath: constants.ModelConfigDir},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		injector := &AgentInjector{
+			credentials.NewCredentialBulder(fake.NewClientBuilder().WithScheme(s).Build(), &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			agentConfig,
+			loggerConfig,
+			batcherTestConfig,
+		}
+		if scenario.agentConfig != nil {
+			injector.agentConfig = scenario.agentConfig
+		}
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: scenario.annotations,
+				Labels:      map[string]string{constants.InferenceServiceLabel: "sklearn"},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "kserve-container", Image: "kserve/sklearnserver:latest",
+					Ports: scenario.ports}},
+			},
+		}
+		for key, value := range scenario.labels {
+			pod.ObjectMeta.Labels[key] = value
+		}
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed(), name)
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2), name)
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.Equal(scenario.expectedArgs), name)
+		g.Expect(pod.Spec.Volumes).To(gomega.Equal(scenario.expectedVolumes), name)
+		g.Expect(pod.Spec.Containers[1].VolumeMounts).To(gomega.Equal(scenario.expectedMounts), name)
+	}
+}
+
 func TestGetLoggerConfigs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	cases := []struct {
@@ -657,6 +1334,66 @@ func TestGetAgentConfigs(t *testing.T) {
 				gomega.HaveOccurred(),
 			},
 		},
+		{
+			name: "Invalid TLS Min Version",
+			configMap: &v1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{},
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string]string{
+					constants.AgentConfigMapKeyName: `{
+						"Image":         "gcr.io/kfserving/agent:latest",
+						"CpuRequest":    "100m",
+						"CpuLimit":      "1",
+						"MemoryRequest": "200Mi",
+						"MemoryLimit":   "1Gi",
+						"tlsMinVersion": "1.4"
+					}`,
+				},
+				BinaryData: map[string][]byte{},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&AgentConfig{
+					Image:         "gcr.io/kfserving/agent:latest",
+					CpuRequest:    "100m",
+					CpuLimit:      "1",
+					MemoryRequest: "200Mi",
+					MemoryLimit:   "1Gi",
+					TLSMinVersion: "1.4",
+				}),
+				gomega.HaveOccurred(),
+			},
+		},
+		{
+			name: "Invalid Cache Max Age",
+			configMap: &v1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{},
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string]string{
+					constants.AgentConfigMapKeyName: `{
+						"Image":           "gcr.io/kfserving/agent:latest",
+						"CpuRequest":      "100m",
+						"CpuLimit":        "1",
+						"MemoryRequest":   "200Mi",
+						"MemoryLimit":     "1Gi",
+						"cacheStorageUri": "s3://model-cache",
+						"cacheMaxAge":     "7d"
+					}`,
+				},
+				BinaryData: map[string][]byte{},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&AgentConfig{
+					Image:           "gcr.io/kfserving/agent:latest",
+					CpuRequest:      "100m",
+					CpuLimit:        "1",
+					MemoryRequest:   "200Mi",
+					MemoryLimit:     "1Gi",
+					CacheStorageURI: "s3://model-cache",
+					CacheMaxAge:     "7d",
+				}),
+				gomega.HaveOccurred(),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -19,22 +19,23 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
-	"github.com/kserve/kserve/pkg/constants"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
-	BatcherContainerName        = "batcher"
-	BatcherConfigMapKeyName     = "batcher"
-	BatcherEnableFlag           = "--enable-batcher"
-	BatcherArgumentMaxBatchSize = "--max-batchsize"
-	BatcherArgumentMaxLatency   = "--max-latency"
-	BatcherArgumentTimeout      = "--timeout"
+	BatcherConfigMapKeyName         = "batcher"
+	BatcherEnableFlag               = "--enable-batcher"
+	BatcherArgumentMaxBatchSize     = "--max-batchsize"
+	BatcherArgumentMaxLatency       = "--max-latency"
+	BatcherArgumentTimeout          = "--timeout"
+	BatcherArgumentTargetP99Latency = "--target-p99-latency"
+	BatcherArgumentPriorityHeader   = "--batch-priority-header"
 )
 
+// BatcherConfig is the batcher section of the inferenceservice config, the batcher runs in the agent container with
+// the other agent features
 type BatcherConfig struct {
 	Image         string `json:"image"`
 	CpuRequest    string `json:"cpuRequest"`
@@ -43,10 +44,6 @@ type BatcherConfig struct {
 	MemoryLimit   string `json:"memoryLimit"`
 }
 
-type BatcherInjector struct {
-	config *BatcherConfig
-}
-
 func getBatcherConfigs(configMap *v1.ConfigMap) (*BatcherConfig, error) {
 
 	batcherConfig := &BatcherConfig{}
@@ -72,63 +69,3 @@ func getBatcherConfigs(configMap *v1.ConfigMap) (*BatcherConfig, error) {
 
 	return batcherConfig, nil
 }
-
-func (il *BatcherInjector) InjectBatcher(pod *v1.Pod) error {
-	// Only inject if the required annotations are set
-	_, ok := pod.ObjectMeta.Annotations[constants.BatcherInternalAnnotationKey]
-	if !ok {
-		return nil
-	}
-
-	var args []string
-
-	maxBatchSize, ok := pod.ObjectMeta.Annotations[constants.BatcherMaxBatchSizeInternalAnnotationKey]
-	if ok {
-		args = append(args, BatcherArgumentMaxBatchSize)
-		args = append(args, maxBatchSize)
-	}
-
-	maxLatency, ok := pod.ObjectMeta.Annotations[constants.BatcherMaxLatencyInternalAnnotationKey]
-	if ok {
-		args = append(args, BatcherArgumentMaxLatency)
-		args = append(args, maxLatency)
-	}
-
-	timeout, ok := pod.ObjectMeta.Annotations[constants.BatcherTimeoutInternalAnnotationKey]
-	if ok {
-		args = append(args, BatcherArgumentTimeout)
-		args = append(args, timeout)
-	}
-
-	// Don't inject if Container already injected
-	for _, container := range pod.Spec.Containers {
-		if strings.Compare(container.Name, BatcherContainerName) == 0 {
-			return nil
-		}
-	}
-
-	// Make sure securityContext is initialized and valid
-	securityContext := pod.Spec.Containers[0].SecurityContext.DeepCopy()
-
-	batcherContainer := &v1.Container{
-		Name:  BatcherContainerName,
-		Image: il.config.Image,
-		Args:  args,
-		Resources: v1.ResourceRequirements{
-			Limits: map[v1.ResourceName]resource.Quantity{
-				v1.ResourceCPU:    resource.MustParse(il.config.CpuLimit),
-				v1.ResourceMemory: resource.MustParse(il.config.MemoryLimit),
-			},
-			Requests: map[v1.ResourceName]resource.Quantity{
-				v1.ResourceCPU:    resource.MustParse(il.config.CpuRequest),
-				v1.ResourceMemory: resource.MustParse(il.config.MemoryRequest),
-			},
-		},
-		SecurityContext: securityContext,
-	}
-
-	// Add container to the spec
-	pod.Spec.Containers = append(pod.Spec.Containers, *batcherContainer)
-
-	return nil
-}
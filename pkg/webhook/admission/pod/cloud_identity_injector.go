@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/credentials"
+	v1 "k8s.io/api/core/v1"
+)
+
+// CloudIdentityInjector mounts the cloud credentials of the service account of the pod in the kserve-container of the
+// components with mountCloudIdentity, so the model reads the cloud stores at inference time with the same credentials
+// as the storage initializer.
+type CloudIdentityInjector struct {
+	credentialBuilder *credentials.CredentialBuilder
+}
+
+// InjectCloudIdentity sets the credential envs and mounts the credential volumes of the service account in the
+// kserve-container, the envs set by the container are kept.
+func (ci *CloudIdentityInjector) InjectCloudIdentity(pod *v1.Pod) error {
+	if pod.ObjectMeta.Annotations[constants.CloudIdentityInternalAnnotationKey] != "true" {
+		return nil
+	}
+	var container *v1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == constants.InferenceServiceContainerName {
+			container = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return nil
+	}
+
+	// The credentials are built in a scratch container, the volumes are shared with the storage initializer
+	identity := &v1.Container{}
+	if err := ci.credentialBuilder.CreateSecretVolumeAndEnv(
+		pod.Namespace,
+		pod.Spec.ServiceAccountName,
+		identity,
+		&pod.Spec.Volumes,
+	); err != nil {
+		return err
+	}
+	for _, env := range identity.Env {
+		if !hasEnv(container.Env, env.Name) {
+			container.Env = append(container.Env, env)
+		}
+	}
+	for _, volumeMount := range identity.VolumeMounts {
+		if !hasVolumeMount(container.VolumeMounts, volumeMount.Name) {
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
+	}
+	return nil
+}
+
+func hasEnv(envs []v1.EnvVar, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(volumeMounts []v1.VolumeMount, name string) bool {
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/credentials/gcs"
+	"github.com/kserve/kserve/pkg/credentials/s3"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInjectCloudIdentity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	serviceAccount := &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "feature-reader",
+			Namespace: "default",
+			Annotations: map[string]string{
+				credentials.AwsIrsaAnnotationKey:              "arn:aws:iam::123456789012:role/feature-reader",
+				s3.InferenceServiceS3SecretRegionAnnotation:   "us-east-2",
+				s3.InferenceServiceS3SecretEndpointAnnotation: "s3.us-east-2.amazonaws.com",
+			},
+		},
+		Secrets: []v1.ObjectReference{{Name: "gcs-credentials"}},
+	}
+	gcsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcs-credentials", Namespace: "default"},
+		Data:       map[string][]byte{gcs.GCSCredentialFileName: []byte("{}")},
+	}
+	injector := &CloudIdentityInjector{
+		credentialBuilder: credentials.NewCredentialBulder(
+			fake.NewClientBuilder().WithObjects(serviceAccount, gcsSecret).Build(), &v1.ConfigMap{}),
+	}
+	makePod := func(annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", Annotations: annotations},
+			Spec: v1.PodSpec{
+				ServiceAccountName: "feature-reader",
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+						Env:  []v1.EnvVar{{Name: s3.AWSRegion, Value: "eu-west-1"}},
+					},
+					{Name: "sidecar"},
+				},
+			},
+		}
+	}
+
+	// The credentials are mounted in the kserve-container, the region set by the container is kept
+	pod := makePod(map[string]string{constants.CloudIdentityInternalAnnotationKey: "true"})
+	g.Expect(injector.InjectCloudIdentity(pod)).To(gomega.Succeed())
+	container := pod.Spec.Containers[0]
+	g.Expect(container.Env).To(gomega.ConsistOf(
+		v1.EnvVar{Name: s3.AWSRegion, Value: "eu-west-1"},
+		v1.EnvVar{Name: s3.S3Endpoint, Value: "s3.us-east-2.amazonaws.com"},
+		v1.EnvVar{Name: s3.AWSEndpointUrl, Value: "https://s3.us-east-2.amazonaws.com"},
+		v1.EnvVar{Name: gcs.GCSCredentialEnvKey, Value: gcs.GCSCredentialVolumeMountPath + gcs.GCSCredentialFileName},
+	))
+	g.Expect(container.VolumeMounts).To(gomega.ConsistOf(v1.VolumeMount{
+		Name:      gcs.GCSCredentialVolumeName,
+		ReadOnly:  true,
+		MountPath: gcs.GCSCredentialVolumeMountPath,
+	}))
+	g.Expect(pod.Spec.Volumes).To(gomega.HaveLen(1))
+	g.Expect(pod.Spec.Containers[1].Env).To(gomega.BeEmpty())
+
+	// The credentials are mounted once when the pod is mutated again
+	g.Expect(injector.InjectCloudIdentity(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers[0].Env).To(gomega.HaveLen(4))
+	g.Expect(pod.Spec.Containers[0].VolumeMounts).To(gomega.HaveLen(1))
+	g.Expect(pod.Spec.Volumes).To(gomega.HaveLen(1))
+
+	// The credentials are only mounted in the storage initializer without mountCloudIdentity
+	pod = makePod(nil)
+	g.Expect(injector.InjectCloudIdentity(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers[0].Env).To(gomega.HaveLen(1))
+	g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	ImageRegistryConfigMapKeyName = "imageRegistry"
+	defaultImageRegistry          = "docker.io"
+)
+
+// ImageRegistryConfig maps public image registries to internal mirrors for air-gapped clusters, e.g.
+//
+//	imageRegistry: |-
+//	  {
+//	    "mirrors": {
+//	      "docker.io": "registry.internal:5000/dockerhub",
+//	      "gcr.io": "registry.internal:5000/gcr"
+//	    }
+//	  }
+type ImageRegistryConfig struct {
+	Mirrors map[string]string `json:"mirrors"`
+}
+
+func getImageRegistryConfig(configMap *v1.ConfigMap) (*ImageRegistryConfig, error) {
+	registryConfig := &ImageRegistryConfig{}
+	if registryConfigValue, ok := configMap.Data[ImageRegistryConfigMapKeyName]; ok {
+		err := json.Unmarshal([]byte(registryConfigValue), &registryConfig)
+		if err != nil {
+			panic(fmt.Errorf("Unable to unmarshall %v json string due to %v ", ImageRegistryConfigMapKeyName, err))
+		}
+	}
+	for registry, mirror := range registryConfig.Mirrors {
+		if mirror == "" {
+			return registryConfig, fmt.Errorf("Invalid configuration for %q: empty mirror for registry %q",
+				ImageRegistryConfigMapKeyName, registry)
+		}
+	}
+	return registryConfig, nil
+}
+
+// RewriteImages points the images of all the containers of the pod, including the ones injected by the other
+// mutators and the serving runtime images, to the configured mirrors.
+func (c *ImageRegistryConfig) RewriteImages(pod *v1.Pod) error {
+	if len(c.Mirrors) == 0 {
+		return nil
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Image = c.rewrite(pod.Spec.InitContainers[i].Image)
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Image = c.rewrite(pod.Spec.Containers[i].Image)
+	}
+	return nil
+}
+
+func (c *ImageRegistryConfig) rewrite(image string) string {
+	if image == "" {
+		return image
+	}
+	registry, repository := splitImage(image)
+	mirror, ok := c.Mirrors[registry]
+	if !ok {
+		return image
+	}
+	return strings.TrimSuffix(mirror, "/") + "/" + repository
+}
+
+// splitImage splits an image reference into its registry and repository following the docker conventions,
+// references without a registry host are docker hub images.
+func splitImage(image string) (string, string) {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0], parts[1]
+	}
+	if len(parts) == 1 {
+		return defaultImageRegistry, "library/" + image
+	}
+	return defaultImageRegistry, image
+}
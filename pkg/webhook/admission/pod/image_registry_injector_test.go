@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestRewriteImages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &ImageRegistryConfig{
+		Mirrors: map[string]string{
+			"docker.io":            "registry.internal:5000/dockerhub/",
+			"gcr.io":               "registry.internal:5000/gcr",
+			"localhost:5000":       "registry.internal:5000/local",
+			"nvcr.io":              "registry.internal:5000/nvcr",
+			"public.ecr.aws":       "registry.internal:5000/ecr",
+			"unused.registry.test": "registry.internal:5000/unused",
+		},
+	}
+
+	scenarios := map[string]struct {
+		image    string
+		expected string
+	}{
+		"DockerHubImage": {
+			image:    "kserve/sklearnserver:v0.9.0",
+			expected: "registry.internal:5000/dockerhub/kserve/sklearnserver:v0.9.0",
+		},
+		"DockerHubOfficialImage": {
+			image:    "python:3.9",
+			expected: "registry.internal:5000/dockerhub/library/python:3.9",
+		},
+		"ExplicitDockerHubImage": {
+			image:    "docker.io/kserve/agent:latest",
+			expected: "registry.internal:5000/dockerhub/kserve/agent:latest",
+		},
+		"RegistryWithPort": {
+			image:    "localhost:5000/kserve/agent:latest",
+			expected: "registry.internal:5000/local/kserve/agent:latest",
+		},
+		"Digest": {
+			image:    "gcr.io/knative-releases/queue@sha256:abcdef",
+			expected: "registry.internal:5000/gcr/knative-releases/queue@sha256:abcdef",
+		},
+		"NoMirror": {
+			image:    "quay.io/kserve/agent:latest",
+			expected: "quay.io/kserve/agent:latest",
+		},
+	}
+	for name, scenario := range scenarios {
+		pod := &v1.Pod{
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{{Name: StorageInitializerContainerName, Image: scenario.image}},
+				Containers:     []v1.Container{{Name: "kserve-container", Image: scenario.image}},
+			},
+		}
+		g.Expect(config.RewriteImages(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.InitContainers[0].Image).To(gomega.Equal(scenario.expected), name)
+		g.Expect(pod.Spec.Containers[0].Image).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestGetImageRegistryConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := getImageRegistryConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImageRegistryConfigMapKeyName: `{"mirrors": {"docker.io": "registry.internal/dockerhub"}}`,
+		},
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(config.Mirrors).To(gomega.HaveKeyWithValue("docker.io", "registry.internal/dockerhub"))
+
+	_, err = getImageRegistryConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImageRegistryConfigMapKeyName: `{"mirrors": {"docker.io": ""}}`,
+		},
+	})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=localmodelcaches,verbs=get;list;watch
+
+// LocalModelCacheInjector serves the models cached on the local disk of the nodes by the local model agents
+type LocalModelCacheInjector struct {
+	client client.Client
+}
+
+// InjectLocalModelCache mounts the cached model of the LocalModelCache with the storage uri of the pod instead of
+// downloading the model in the storage initializer. The pod is scheduled on the nodes whose local model agent finished
+// the download, the model is downloaded in the storage initializer while no node cached it yet.
+func (mi *LocalModelCacheInjector) InjectLocalModelCache(pod *v1.Pod) error {
+	// The spec of existing pods is immutable, only the pods being created are mutated
+	if pod.UID != "" {
+		return nil
+	}
+	srcURI, ok := pod.ObjectMeta.Annotations[constants.StorageInitializerSourceUriInternalAnnotationKey]
+	if !ok {
+		return nil
+	}
+	// The models of the multi-model servers are pulled by the model agent
+	if _, ok := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]; ok {
+		return nil
+	}
+
+	caches := &v1alpha1.LocalModelCacheList{}
+	if err := mi.client.List(context.TODO(), caches); err != nil {
+		// The LocalModelCache CRD is optional
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	var cache *v1alpha1.LocalModelCache
+	for i := range caches.Items {
+		if caches.Items[i].Spec.SourceModelUri == srcURI {
+			cache = &caches.Items[i]
+			break
+		}
+	}
+	if cache == nil {
+		return nil
+	}
+	nodes := downloadedNodes(cache)
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var userContainer *v1.Container
+	var explainerContainer *v1.Container
+	for idx, container := range pod.Spec.Containers {
+		switch container.Name {
+		case constants.InferenceServiceContainerName:
+			userContainer = &pod.Spec.Containers[idx]
+		case constants.ExplainerContainerName:
+			explainerContainer = &pod.Spec.Containers[idx]
+		}
+	}
+	if userContainer == nil {
+		return nil
+	}
+
+	hostPathType := v1.HostPathDirectory
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: constants.LocalModelCacheVolumeName,
+		VolumeSource: v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{
+				Path: filepath.Join(constants.LocalModelCachePath, cache.Name),
+				Type: &hostPathType,
+			},
+		},
+	})
+	cacheMount := v1.VolumeMount{
+		Name:      constants.LocalModelCacheVolumeName,
+		MountPath: constants.DefaultModelLocalMountPath,
+		ReadOnly:  true,
+	}
+	userContainer.VolumeMounts = append(userContainer.VolumeMounts, cacheMount)
+	if explainerContainer != nil {
+		explainerContainer.VolumeMounts = append(explainerContainer.VolumeMounts, cacheMount)
+	}
+	// Change the CustomSpecStorageUri env variable value to the default model path if present
+	for index, envVar := range userContainer.Env {
+		if envVar.Name == constants.CustomSpecStorageUriEnvVarKey && envVar.Value != "" {
+			userContainer.Env[index].Value = constants.DefaultModelLocalMountPath
+		}
+	}
+
+	// The model is already on the node, the storage initializer is skipped
+	delete(pod.ObjectMeta.Annotations, constants.StorageInitializerSourceUriInternalAnnotationKey)
+	pod.ObjectMeta.Annotations[constants.LocalModelCacheInternalAnnotationKey] = cache.Name
+
+	requireNodes(pod, nodes)
+	return nil
+}
+
+// downloadedNodes returns the sorted names of the nodes which finished downloading the model of the cache
+func downloadedNodes(cache *v1alpha1.LocalModelCache) []string {
+	var nodes []string
+	for node, status := range cache.Status.NodeStatus {
+		if status == v1alpha1.NodeDownloaded {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// requireNodes adds the nodes to every required node selector term of the pod so they are ANDed with the node affinity
+// of the pod, e.g. from the serving runtime
+func requireNodes(pod *v1.Pod, nodes []string) {
+	fields := []v1.NodeSelectorRequirement{{
+		Key:      metav1.ObjectNameField,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   nodes,
+	}}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &v1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	required := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchFields: fields}},
+		}
+		return
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchFields = append(required.NodeSelectorTerms[i].MatchFields, fields...)
+	}
+}
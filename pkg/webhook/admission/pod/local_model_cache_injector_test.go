@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInjectLocalModelCache(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	v1.AddToScheme(s)
+	v1alpha1.AddToScheme(s)
+	cache := &v1alpha1.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama"},
+		Spec: v1alpha1.LocalModelCacheSpec{
+			SourceModelUri: "gs://models/llama",
+			NodeSelector:   map[string]string{"gpu": "a100"},
+		},
+		Status: v1alpha1.LocalModelCacheStatus{
+			NodeStatus: map[string]v1alpha1.LocalModelNodeStatus{
+				"node-b": v1alpha1.NodeDownloaded,
+				"node-a": v1alpha1.NodeDownloaded,
+				"node-c": v1alpha1.NodeDownloading,
+			},
+		},
+	}
+	downloading := &v1alpha1.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{Name: "mistral"},
+		Spec:       v1alpha1.LocalModelCacheSpec{SourceModelUri: "gs://models/mistral"},
+		Status: v1alpha1.LocalModelCacheStatus{
+			NodeStatus: map[string]v1alpha1.LocalModelNodeStatus{"node-a": v1alpha1.NodeDownloading},
+		},
+	}
+	injector := &LocalModelCacheInjector{client: fake.NewClientBuilder().WithScheme(s).WithObjects(cache, downloading).Build()}
+	newPod := func(srcURI string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "llama-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.StorageInitializerSourceUriInternalAnnotationKey: srcURI},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+	hostPathType := v1.HostPathDirectory
+
+	t.Run("CachedModel", func(t *testing.T) {
+		pod := newPod("gs://models/llama")
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Annotations).NotTo(gomega.HaveKey(constants.StorageInitializerSourceUriInternalAnnotationKey))
+		g.Expect(pod.Annotations).To(gomega.HaveKeyWithValue(constants.LocalModelCacheInternalAnnotationKey, "llama"))
+		g.Expect(pod.Spec.Volumes).To(gomega.Equal([]v1.Volume{{
+			Name: constants.LocalModelCacheVolumeName,
+			VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{
+				Path: constants.LocalModelCachePath + "/llama",
+				Type: &hostPathType,
+			}},
+		}}))
+		g.Expect(pod.Spec.Containers[0].VolumeMounts).To(gomega.Equal([]v1.VolumeMount{{
+			Name:      constants.LocalModelCacheVolumeName,
+			MountPath: constants.DefaultModelLocalMountPath,
+			ReadOnly:  true,
+		}}))
+		// The pod is only scheduled on the nodes which finished the download
+		g.Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+			gomega.Equal([]v1.NodeSelectorTerm{{MatchFields: []v1.NodeSelectorRequirement{
+				{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-a", "node-b"}},
+			}}}))
+	})
+
+	t.Run("ExistingNodeAffinity", func(t *testing.T) {
+		pod := newPod("gs://models/llama")
+		pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}},
+				}}},
+			},
+		}}
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+			gomega.Equal([]v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}},
+				},
+				MatchFields: []v1.NodeSelectorRequirement{
+					{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-a", "node-b"}},
+				},
+			}}))
+	})
+
+	t.Run("DownloadingModel", func(t *testing.T) {
+		pod := newPod("gs://models/mistral")
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Annotations).To(gomega.HaveKey(constants.StorageInitializerSourceUriInternalAnnotationKey))
+		g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+		g.Expect(pod.Spec.Affinity).To(gomega.BeNil())
+	})
+
+	t.Run("UncachedModel", func(t *testing.T) {
+		pod := newPod("gs://models/falcon")
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Annotations).To(gomega.HaveKey(constants.StorageInitializerSourceUriInternalAnnotationKey))
+		g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+		g.Expect(pod.Spec.Affinity).To(gomega.BeNil())
+	})
+
+	t.Run("ModelAgent", func(t *testing.T) {
+		pod := newPod("gs://models/llama")
+		pod.Annotations[constants.AgentShouldInjectAnnotationKey] = "true"
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Annotations).To(gomega.HaveKey(constants.StorageInitializerSourceUriInternalAnnotationKey))
+		g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+	})
+
+	t.Run("ExistingPod", func(t *testing.T) {
+		pod := newPod("gs://models/llama")
+		pod.UID = "uid"
+		g.Expect(injector.InjectLocalModelCache(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+	})
+}
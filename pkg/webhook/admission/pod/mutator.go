@@ -89,6 +89,10 @@ func (mutator *Mutator) mutate(pod *v1.Pod, configMap *v1.ConfigMap) error {
 		config:            storageInitializerConfig,
 	}
 
+	cloudIdentity := &CloudIdentityInjector{
+		credentialBuilder: credentialBuilder,
+	}
+
 	loggerConfig, err := getLoggerConfigs(configMap)
 	if err != nil {
 		return err
@@ -116,11 +120,26 @@ func (mutator *Mutator) mutate(pod *v1.Pod, configMap *v1.ConfigMap) error {
 		return err
 	}
 
+	imageRegistryConfig, err := getImageRegistryConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	localModelCache := &LocalModelCacheInjector{
+		client: mutator.Client,
+	}
+
 	mutators := []func(pod *v1.Pod) error{
 		InjectGKEAcceleratorSelector,
+		InjectOSNodeSelector,
+		// mount the cached model before the storage initializer so it is not downloaded again
+		localModelCache.InjectLocalModelCache,
 		storageInitializer.InjectStorageInitializer,
+		cloudIdentity.InjectCloudIdentity,
 		agentInjector.InjectAgent,
 		metricsAggregator.InjectMetricsAggregator,
+		// rewrite the images last so the containers injected above are covered
+		imageRegistryConfig.RewriteImages,
 	}
 
 	for _, mutator := range mutators {
@@ -287,6 +287,13 @@ func TestMutator_Handle(t *testing.T) {
 						Path:      "/metadata/namespace",
 						Value:     "default",
 					},
+					{
+						Operation: "add",
+						Path:      "/spec/nodeSelector",
+						Value: map[string]interface{}{
+							"kubernetes.io/os": "linux",
+						},
+					},
 				},
 				AdmissionResponse: admissionv1.AdmissionResponse{
 					UID:              "",
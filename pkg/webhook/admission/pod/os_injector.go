@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	DefaultNodeOS = "linux"
+)
+
+var supportedNodeOS = []string{"linux", "windows"}
+
+// InjectOSNodeSelector schedules the pods on linux nodes so they don't land on the windows nodes of mixed OS
+// clusters. Runtimes built for another OS select it with the node-os annotation, the pods which already select an
+// OS with a node selector or a node affinity, e.g. from the serving runtime, are left untouched.
+// InferenceGraph router pods are not mutated, the node selector has to be set on their Knative service instead which
+// Knative rejects unless its podspec-nodeselector feature is enabled.
+func InjectOSNodeSelector(pod *v1.Pod) error {
+	// The node selector of existing pods is immutable, only the pods being created are mutated
+	if pod.UID != "" {
+		return nil
+	}
+	if _, ok := pod.Spec.NodeSelector[v1.LabelOSStable]; ok || hasOSNodeAffinity(pod) {
+		return nil
+	}
+	nodeOS := DefaultNodeOS
+	if value, ok := pod.Annotations[constants.NodeOSAnnotationKey]; ok {
+		if !utils.Includes(supportedNodeOS, value) {
+			return fmt.Errorf("invalid %s annotation %q, supported values are %v",
+				constants.NodeOSAnnotationKey, value, supportedNodeOS)
+		}
+		nodeOS = value
+	}
+	pod.Spec.NodeSelector = utils.Union(pod.Spec.NodeSelector, map[string]string{v1.LabelOSStable: nodeOS})
+	return nil
+}
+
+func hasOSNodeAffinity(pod *v1.Pod) bool {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil ||
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == v1.LabelOSStable {
+				return true
+			}
+		}
+	}
+	return false
+}
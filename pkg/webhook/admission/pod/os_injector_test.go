@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInjectOSNodeSelector(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	osAffinity := &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: v1.LabelOSStable, Operator: v1.NodeSelectorOpIn, Values: []string{"windows"}},
+			}}},
+		},
+	}}
+
+	scenarios := map[string]struct {
+		original  *v1.Pod
+		expected  map[string]string
+		expectErr bool
+	}{
+		"DefaultLinux": {
+			original: &v1.Pod{Spec: v1.PodSpec{NodeSelector: map[string]string{"foo": "bar"}}},
+			expected: map[string]string{"foo": "bar", v1.LabelOSStable: "linux"},
+		},
+		"AnnotationOverride": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.NodeOSAnnotationKey: "windows"}},
+			},
+			expected: map[string]string{v1.LabelOSStable: "windows"},
+		},
+		"InvalidAnnotation": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.NodeOSAnnotationKey: "plan9"}},
+			},
+			expectErr: true,
+		},
+		"ExistingNodeSelector": {
+			original: &v1.Pod{Spec: v1.PodSpec{NodeSelector: map[string]string{v1.LabelOSStable: "windows"}}},
+			expected: map[string]string{v1.LabelOSStable: "windows"},
+		},
+		"ExistingNodeAffinity": {
+			original: &v1.Pod{Spec: v1.PodSpec{Affinity: osAffinity}},
+			expected: nil,
+		},
+		"ExistingPod": {
+			original: &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid"}},
+			expected: nil,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		err := InjectOSNodeSelector(scenario.original)
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+			continue
+		}
+		g.Expect(err).To(gomega.BeNil(), name)
+		g.Expect(scenario.original.Spec.NodeSelector).To(gomega.Equal(scenario.expected), name)
+	}
+}
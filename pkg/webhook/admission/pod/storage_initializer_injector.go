@@ -19,12 +19,16 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/credentials/oci"
+	"github.com/kserve/kserve/pkg/utils"
 
 	v1 "k8s.io/api/core/v1"
 )
@@ -36,6 +40,10 @@ const (
 	StorageInitializerContainerImage        = "kserve/storage-initializer"
 	StorageInitializerContainerImageVersion = "latest"
 	PvcURIPrefix                            = "pvc://"
+	HuggingFaceURIPrefix                    = "hf://"
+	OCIURIPrefix                            = "oci://"
+	S3URIPrefix                             = "s3://"
+	GCSURIPrefix                            = "gs://"
 	PvcSourceMountName                      = "kserve-pvc-source"
 	PvcSourceMountPath                      = "/mnt/pvc"
 )
@@ -47,6 +55,40 @@ type StorageInitializerConfig struct {
 	MemoryRequest         string `json:"memoryRequest"`
 	MemoryLimit           string `json:"memoryLimit"`
 	StorageSpecSecretName string `json:"storageSpecSecretName"`
+	// AllowedUriSchemes restricts the storage uri schemes models can be downloaded from, e.g. ["pvc://", "s3://"]
+	AllowedUriSchemes []string `json:"allowedUriSchemes,omitempty"`
+	// DownloadWorkers is the default number of concurrent downloads of the large models from S3 and GCS
+	DownloadWorkers int `json:"downloadWorkers,omitempty"`
+	// PresignBroker fetches the models from short lived presigned urls rather than with the credentials of the
+	// service account, disabled when nil
+	PresignBroker *PresignBrokerConfig `json:"presignBroker,omitempty"`
+}
+
+// PresignBrokerConfig configures the broker service handing out the presigned urls of the model files, so the access
+// to the artifacts is controlled in one place instead of long lived credentials being mounted in every namespace
+type PresignBrokerConfig struct {
+	// URL of the presign endpoint of the broker, e.g. https://presign-broker.kserve:8443/presign
+	URL string `json:"url"`
+	// UriSchemes are the storage uri schemes fetched through the broker, defaults to ["s3://", "gs://"]
+	UriSchemes []string `json:"uriSchemes,omitempty"`
+	// ClientCertSecretName is the secret of the InferenceService namespace holding the tls.crt, tls.key and ca.crt
+	// of the mTLS client certificate, e.g. issued by cert-manager
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+	// Audience of the service account token identifying the InferenceService to the broker, defaults to
+	// kserve-presign-broker
+	Audience string `json:"audience,omitempty"`
+}
+
+// fetches returns whether the models of the storage uri are fetched through the broker
+func (b *PresignBrokerConfig) fetches(srcURI string) bool {
+	if b == nil || b.URL == "" {
+		return false
+	}
+	schemes := b.UriSchemes
+	if len(schemes) == 0 {
+		schemes = []string{S3URIPrefix, GCSURIPrefix}
+	}
+	return utils.IsPrefixSupported(srcURI, schemes)
 }
 
 type StorageInitializerInjector struct {
@@ -73,6 +115,13 @@ func getStorageInitializerConfigs(configMap *v1.ConfigMap) (*StorageInitializerC
 			return storageInitializerConfig, fmt.Errorf("Failed to parse resource configuration for %q: %q", StorageInitializerConfigMapKeyName, err.Error())
 		}
 	}
+	// The client certificate and the token are only sent to the broker over TLS
+	if broker := storageInitializerConfig.PresignBroker; broker != nil {
+		if brokerURL, err := url.Parse(broker.URL); err != nil || brokerURL.Scheme != "https" || brokerURL.Host == "" {
+			return storageInitializerConfig, fmt.Errorf("Invalid presignBroker url %q of %q, must be an https url",
+				broker.URL, StorageInitializerConfigMapKeyName)
+		}
+	}
 
 	return storageInitializerConfig, nil
 }
@@ -88,6 +137,11 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		return nil
 	}
 
+	if len(mi.config.AllowedUriSchemes) > 0 && !utils.IsPrefixSupported(srcURI, mi.config.AllowedUriSchemes) {
+		return fmt.Errorf("storage uri %s is not allowed, the allowed schemes are: %s", srcURI,
+			strings.Join(mi.config.AllowedUriSchemes, ", "))
+	}
+
 	// Don't inject if model agent is injected
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]; ok {
 		return nil
@@ -112,6 +166,14 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	if userContainer == nil {
 		return fmt.Errorf("Invalid configuration: cannot find container: %s", constants.InferenceServiceContainerName)
 	}
+	// The colocated explainer shares the model of the predictor
+	var explainerContainer *v1.Container
+	for idx, container := range pod.Spec.Containers {
+		if container.Name == constants.ExplainerContainerName {
+			explainerContainer = &pod.Spec.Containers[idx]
+			break
+		}
+	}
 
 	podVolumes := []v1.Volume{}
 	storageInitializerMounts := []v1.VolumeMount{}
@@ -145,6 +207,9 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 
 		// Since the model path is linked from source pvc, userContainer also need to mount the pvc.
 		userContainer.VolumeMounts = append(userContainer.VolumeMounts, pvcSourceVolumeMount)
+		if explainerContainer != nil {
+			explainerContainer.VolumeMounts = append(explainerContainer.VolumeMounts, pvcSourceVolumeMount)
+		}
 
 		// modify the sourceURI to point to the PVC path
 		srcURI = PvcSourceMountPath + "/" + pvcPath
@@ -203,6 +268,9 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		ReadOnly:  true,
 	}
 	userContainer.VolumeMounts = append(userContainer.VolumeMounts, sharedVolumeReadMount)
+	if explainerContainer != nil {
+		explainerContainer.VolumeMounts = append(explainerContainer.VolumeMounts, sharedVolumeReadMount)
+	}
 	// Change the CustomSpecStorageUri env variable value to the default model path if present
 	for index, envVar := range userContainer.Env {
 		if envVar.Name == constants.CustomSpecStorageUriEnvVarKey && envVar.Value != "" {
@@ -213,6 +281,46 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	// Add volumes to the PodSpec
 	pod.Spec.Volumes = append(pod.Spec.Volumes, podVolumes...)
 
+	// Pass the file patterns of the Hugging Face Hub repo, e.g. only the safetensors weights
+	if strings.HasPrefix(srcURI, HuggingFaceURIPrefix) {
+		if patterns, ok := pod.ObjectMeta.Annotations[constants.HuggingFaceAllowPatternsAnnotationKey]; ok {
+			initContainer.Env = append(initContainer.Env,
+				v1.EnvVar{Name: constants.HuggingFaceAllowPatternsEnvVarKey, Value: patterns})
+		}
+		if patterns, ok := pod.ObjectMeta.Annotations[constants.HuggingFaceIgnorePatternsAnnotationKey]; ok {
+			initContainer.Env = append(initContainer.Env,
+				v1.EnvVar{Name: constants.HuggingFaceIgnorePatternsEnvVarKey, Value: patterns})
+		}
+	}
+
+	// Pass the number of concurrent downloads, the annotation of the pod overrides the default of the config
+	downloadWorkers := ""
+	if mi.config.DownloadWorkers > 0 {
+		downloadWorkers = strconv.Itoa(mi.config.DownloadWorkers)
+	}
+	if workers, ok := pod.ObjectMeta.Annotations[constants.StorageDownloadWorkersAnnotationKey]; ok {
+		downloadWorkers = workers
+	}
+	if downloadWorkers != "" {
+		initContainer.Env = append(initContainer.Env,
+			v1.EnvVar{Name: constants.StorageDownloadWorkersEnvVarKey, Value: downloadWorkers})
+	}
+
+	// Mount the image pull secrets of the pod, which include the ones of its service account, so the storage initializer
+	// authenticates to the registry of the OCI artifact
+	if strings.HasPrefix(srcURI, OCIURIPrefix) {
+		volumes, volumeMounts := oci.BuildSecretVolumes(pod.Spec.ImagePullSecrets)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+		initContainer.VolumeMounts = append(initContainer.VolumeMounts, volumeMounts...)
+	}
+
+	// The presigned urls of the broker replace the credentials
+	if mi.config.PresignBroker.fetches(srcURI) {
+		injectPresignBroker(pod, initContainer, mi.config.PresignBroker)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+		return nil
+	}
+
 	// Inject credentials
 	hasStorageSpec := pod.ObjectMeta.Annotations[constants.StorageSpecAnnotationKey]
 	storageKey := pod.ObjectMeta.Annotations[constants.StorageSpecKeyAnnotationKey]
@@ -255,6 +363,47 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	return nil
 }
 
+// injectPresignBroker mounts the service account token bound to the audience of the broker and the client
+// certificate in the storage initializer, which exchanges them for the presigned urls of the model files
+func injectPresignBroker(pod *v1.Pod, initContainer *v1.Container, broker *PresignBrokerConfig) {
+	audience := broker.Audience
+	if audience == "" {
+		audience = constants.DefaultStoragePresignBrokerAudience
+	}
+	expirationSeconds := int64(constants.StoragePresignBrokerTokenExpirationSeconds)
+	sources := []v1.VolumeProjection{
+		{
+			ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+				Audience:          audience,
+				ExpirationSeconds: &expirationSeconds,
+				Path:              constants.StoragePresignBrokerTokenFileName,
+			},
+		},
+	}
+	if broker.ClientCertSecretName != "" {
+		sources = append(sources, v1.VolumeProjection{
+			Secret: &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: broker.ClientCertSecretName},
+			},
+		})
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: constants.StoragePresignBrokerVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{Sources: sources},
+		},
+	})
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, v1.VolumeMount{
+		Name:      constants.StoragePresignBrokerVolumeName,
+		MountPath: constants.StoragePresignBrokerDir,
+		ReadOnly:  true,
+	})
+	initContainer.Env = append(initContainer.Env,
+		v1.EnvVar{Name: constants.StoragePresignBrokerUrlEnvVarKey, Value: broker.URL},
+		v1.EnvVar{Name: constants.StoragePresignBrokerDirEnvVarKey, Value: constants.StoragePresignBrokerDir},
+	)
+}
+
 func parsePvcURI(srcURI string) (pvcName string, pvcPath string, err error) {
 	parts := strings.Split(strings.TrimPrefix(srcURI, PvcURIPrefix), "/")
 	if len(parts) > 1 {
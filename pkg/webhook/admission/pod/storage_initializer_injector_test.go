@@ -31,6 +31,7 @@ import (
 	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const (
@@ -402,6 +403,367 @@ func TestStorageInitializerFailureCases(t *testing.T) {
 	}
 }
 
+func TestStorageInitializerAllowedUriSchemes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := *storageInitializerConfig
+	config.AllowedUriSchemes = []string{"pvc://", "s3://"}
+	scenarios := map[string]struct {
+		storageUri string
+		expectErr  bool
+	}{
+		"Allowed": {
+			storageUri: "s3://models/sklearn",
+			expectErr:  false,
+		},
+		"NotAllowed": {
+			storageUri: "https://example.com/models/sklearn.joblib",
+			expectErr:  true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
+				},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+					},
+				},
+			},
+		}
+		injector := &StorageInitializerInjector{
+			credentialBuilder: credentials.NewCredentialBulder(c, &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			config: &config,
+		}
+		err := injector.InjectStorageInitializer(pod)
+		if scenario.expectErr {
+			g.Expect(err).NotTo(gomega.BeNil(), name)
+			g.Expect(pod.Spec.InitContainers).To(gomega.BeEmpty(), name)
+		} else {
+			g.Expect(err).To(gomega.BeNil(), name)
+			g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1), name)
+		}
+	}
+}
+
+func TestStorageInitializerHuggingFacePatterns(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		storageUri  string
+		annotations map[string]string
+		expectedEnv []v1.EnvVar
+	}{
+		"HuggingFacePatterns": {
+			storageUri: "hf://meta-llama/Llama-3-8b:main",
+			annotations: map[string]string{
+				constants.HuggingFaceAllowPatternsAnnotationKey:  "*.safetensors,*.json",
+				constants.HuggingFaceIgnorePatternsAnnotationKey: "original/*",
+			},
+			expectedEnv: []v1.EnvVar{
+				{Name: constants.HuggingFaceAllowPatternsEnvVarKey, Value: "*.safetensors,*.json"},
+				{Name: constants.HuggingFaceIgnorePatternsEnvVarKey, Value: "original/*"},
+			},
+		},
+		"NotHuggingFaceUri": {
+			storageUri: "s3://models/sklearn",
+			annotations: map[string]string{
+				constants.HuggingFaceAllowPatternsAnnotationKey: "*.safetensors",
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		annotations := map[string]string{
+			constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
+		}
+		for key, value := range scenario.annotations {
+			annotations[key] = value
+		}
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+					},
+				},
+			},
+		}
+		injector := &StorageInitializerInjector{
+			credentialBuilder: credentials.NewCredentialBulder(fake.NewClientBuilder().Build(), &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			config: storageInitializerConfig,
+		}
+		g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed(), name)
+		g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1), name)
+		g.Expect(pod.Spec.InitContainers[0].Env).To(gomega.Equal(scenario.expectedEnv), name)
+	}
+}
+
+func TestStorageInitializerDownloadWorkers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		downloadWorkers int
+		annotations     map[string]string
+		expectedEnv     []v1.EnvVar
+	}{
+		"DefaultWorkers": {},
+		"ConfigWorkers": {
+			downloadWorkers: 8,
+			expectedEnv:     []v1.EnvVar{{Name: constants.StorageDownloadWorkersEnvVarKey, Value: "8"}},
+		},
+		"AnnotationWorkers": {
+			downloadWorkers: 8,
+			annotations:     map[string]string{constants.StorageDownloadWorkersAnnotationKey: "16"},
+			expectedEnv:     []v1.EnvVar{{Name: constants.StorageDownloadWorkersEnvVarKey, Value: "16"}},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		annotations := map[string]string{
+			constants.StorageInitializerSourceUriInternalAnnotationKey: "s3://models/llm",
+		}
+		for key, value := range scenario.annotations {
+			annotations[key] = value
+		}
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+					},
+				},
+			},
+		}
+		config := *storageInitializerConfig
+		config.DownloadWorkers = scenario.downloadWorkers
+		injector := &StorageInitializerInjector{
+			credentialBuilder: credentials.NewCredentialBulder(fake.NewClientBuilder().Build(), &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			config: &config,
+		}
+		g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed(), name)
+		g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1), name)
+		g.Expect(pod.Spec.InitContainers[0].Env).To(gomega.Equal(scenario.expectedEnv), name)
+	}
+}
+
+func TestStorageInitializerOCIImagePullSecrets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		storageUri           string
+		expectedVolumeMounts []v1.VolumeMount
+	}{
+		"OCIUri": {
+			storageUri: "oci://ghcr.io/kserve/models/sklearn:v1",
+			expectedVolumeMounts: []v1.VolumeMount{
+				{
+					Name:      StorageInitializerVolumeName,
+					MountPath: constants.DefaultModelLocalMountPath,
+				},
+				{
+					Name:      "oci-registry-secret-0",
+					MountPath: "/var/secrets/kserve-oci/regcred",
+					ReadOnly:  true,
+				},
+			},
+		},
+		"NotOCIUri": {
+			storageUri: "s3://models/sklearn",
+			expectedVolumeMounts: []v1.VolumeMount{
+				{
+					Name:      StorageInitializerVolumeName,
+					MountPath: constants.DefaultModelLocalMountPath,
+				},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
+				},
+			},
+			Spec: v1.PodSpec{
+				ImagePullSecrets: []v1.LocalObjectReference{{Name: "regcred"}},
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+					},
+				},
+			},
+		}
+		injector := &StorageInitializerInjector{
+			credentialBuilder: credentials.NewCredentialBulder(fake.NewClientBuilder().Build(), &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			config: storageInitializerConfig,
+		}
+		g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed(), name)
+		g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1), name)
+		g.Expect(pod.Spec.InitContainers[0].VolumeMounts).To(gomega.Equal(scenario.expectedVolumeMounts), name)
+	}
+}
+
+func TestStorageInitializerPresignBroker(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	expirationSeconds := int64(constants.StoragePresignBrokerTokenExpirationSeconds)
+	scenarios := map[string]struct {
+		storageUri      string
+		broker          *PresignBrokerConfig
+		expectedVolumes []v1.Volume
+		expectedEnv     []v1.EnvVar
+	}{
+		"BrokerWithClientCert": {
+			storageUri: "s3://models/sklearn",
+			broker: &PresignBrokerConfig{
+				URL:                  "https://presign-broker.kserve:8443/presign",
+				ClientCertSecretName: "presign-client-cert",
+			},
+			expectedVolumes: []v1.Volume{
+				{
+					Name: StorageInitializerVolumeName,
+					VolumeSource: v1.VolumeSource{
+						EmptyDir: &v1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: constants.StoragePresignBrokerVolumeName,
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+										Audience:          constants.DefaultStoragePresignBrokerAudience,
+										ExpirationSeconds: &expirationSeconds,
+										Path:              constants.StoragePresignBrokerTokenFileName,
+									},
+								},
+								{
+									Secret: &v1.SecretProjection{
+										LocalObjectReference: v1.LocalObjectReference{Name: "presign-client-cert"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedEnv: []v1.EnvVar{
+				{Name: constants.StoragePresignBrokerUrlEnvVarKey, Value: "https://presign-broker.kserve:8443/presign"},
+				{Name: constants.StoragePresignBrokerDirEnvVarKey, Value: constants.StoragePresignBrokerDir},
+			},
+		},
+		"SchemeNotFetchedThroughBroker": {
+			storageUri: "hf://meta-llama/Llama-2-7b",
+			broker: &PresignBrokerConfig{
+				URL:      "https://presign-broker.kserve:8443/presign",
+				Audience: "presign",
+			},
+			expectedVolumes: []v1.Volume{
+				{
+					Name: StorageInitializerVolumeName,
+					VolumeSource: v1.VolumeSource{
+						EmptyDir: &v1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
+				},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+					},
+				},
+			},
+		}
+		config := *storageInitializerConfig
+		config.PresignBroker = scenario.broker
+		injector := &StorageInitializerInjector{
+			credentialBuilder: credentials.NewCredentialBulder(fake.NewClientBuilder().Build(), &v1.ConfigMap{
+				Data: map[string]string{},
+			}),
+			config: &config,
+		}
+		g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed(), name)
+		g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1), name)
+		g.Expect(pod.Spec.Volumes).To(gomega.Equal(scenario.expectedVolumes), name)
+		g.Expect(pod.Spec.InitContainers[0].Env).To(gomega.Equal(scenario.expectedEnv), name)
+	}
+}
+
+func TestStorageInitializerColocatedExplainer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey: "pvc://models/sklearn",
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: constants.InferenceServiceContainerName,
+				},
+				{
+					Name: constants.ExplainerContainerName,
+				},
+			},
+		},
+	}
+	injector := &StorageInitializerInjector{
+		credentialBuilder: credentials.NewCredentialBulder(fake.NewClientBuilder().Build(), &v1.ConfigMap{
+			Data: map[string]string{},
+		}),
+		config: storageInitializerConfig,
+	}
+	g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed())
+	// The explainer sidecar mounts the model volumes of the predictor container
+	g.Expect(pod.Spec.Containers[1].VolumeMounts).To(gomega.Equal(pod.Spec.Containers[0].VolumeMounts))
+	g.Expect(pod.Spec.Containers[1].VolumeMounts).To(gomega.Equal([]v1.VolumeMount{
+		{
+			Name:      PvcSourceMountName,
+			MountPath: PvcSourceMountPath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      StorageInitializerVolumeName,
+			MountPath: constants.DefaultModelLocalMountPath,
+			ReadOnly:  true,
+		},
+	}))
+}
+
 func TestCustomSpecStorageUriInjection(t *testing.T) {
 	scenarios := map[string]struct {
 		original                      *v1.Pod
@@ -1109,6 +1471,30 @@ func TestGetStorageInitializerConfigs(t *testing.T) {
 				gomega.HaveOccurred(),
 			},
 		},
+		{
+			name: "Presign Broker Without TLS",
+			configMap: &v1.ConfigMap{
+				Data: map[string]string{
+					StorageInitializerConfigMapKeyName: `{
+						"CpuRequest":    "100m",
+						"CpuLimit":      "1",
+						"MemoryRequest": "200Mi",
+						"MemoryLimit":   "1Gi",
+						"presignBroker": {"url": "http://presign-broker.kserve/presign"}
+					}`,
+				},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&StorageInitializerConfig{
+					CpuRequest:    "100m",
+					CpuLimit:      "1",
+					MemoryRequest: "200Mi",
+					MemoryLimit:   "1Gi",
+					PresignBroker: &PresignBrokerConfig{URL: "http://presign-broker.kserve/presign"},
+				}),
+				gomega.HaveOccurred(),
+			},
+		},
 	}
 
 	for _, tc := range cases {
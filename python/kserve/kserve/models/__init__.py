@@ -28,23 +28,63 @@
 from __future__ import absolute_import
 
 # import models into model package
+from kserve.models.v1alpha1_agent_config_spec import V1alpha1AgentConfigSpec
 from kserve.models.v1alpha1_built_in_adapter import V1alpha1BuiltInAdapter
 from kserve.models.v1alpha1_cluster_serving_runtime import V1alpha1ClusterServingRuntime
 from kserve.models.v1alpha1_cluster_serving_runtime_list import V1alpha1ClusterServingRuntimeList
+from kserve.models.v1alpha1_container_config_spec import V1alpha1ContainerConfigSpec
+from kserve.models.v1alpha1_cors_policy_spec import V1alpha1CorsPolicySpec
+from kserve.models.v1alpha1_credentials_config_spec import V1alpha1CredentialsConfigSpec
+from kserve.models.v1alpha1_deploy_config_spec import V1alpha1DeployConfigSpec
+from kserve.models.v1alpha1_explainer_config_spec import V1alpha1ExplainerConfigSpec
+from kserve.models.v1alpha1_gcs_credentials_config_spec import V1alpha1GCSCredentialsConfigSpec
+from kserve.models.v1alpha1_grpc_routes_spec import V1alpha1GrpcRoutesSpec
+from kserve.models.v1alpha1_image_registry_config_spec import V1alpha1ImageRegistryConfigSpec
 from kserve.models.v1alpha1_inference_graph import V1alpha1InferenceGraph
 from kserve.models.v1alpha1_inference_graph_list import V1alpha1InferenceGraphList
 from kserve.models.v1alpha1_inference_graph_spec import V1alpha1InferenceGraphSpec
 from kserve.models.v1alpha1_inference_graph_status import V1alpha1InferenceGraphStatus
 from kserve.models.v1alpha1_inference_router import V1alpha1InferenceRouter
+from kserve.models.v1alpha1_inference_service_template import V1alpha1InferenceServiceTemplate
+from kserve.models.v1alpha1_inference_service_template_list import V1alpha1InferenceServiceTemplateList
+from kserve.models.v1alpha1_inference_service_template_spec import V1alpha1InferenceServiceTemplateSpec
 from kserve.models.v1alpha1_inference_step import V1alpha1InferenceStep
 from kserve.models.v1alpha1_inference_target import V1alpha1InferenceTarget
+from kserve.models.v1alpha1_ingress_class_config_spec import V1alpha1IngressClassConfigSpec
+from kserve.models.v1alpha1_ingress_config_spec import V1alpha1IngressConfigSpec
+from kserve.models.v1alpha1_ingress_tls_spec import V1alpha1IngressTlsSpec
+from kserve.models.v1alpha1_k_serve_config import V1alpha1KServeConfig
+from kserve.models.v1alpha1_k_serve_config_list import V1alpha1KServeConfigList
+from kserve.models.v1alpha1_k_serve_config_spec import V1alpha1KServeConfigSpec
+from kserve.models.v1alpha1_k_serve_config_status import V1alpha1KServeConfigStatus
+from kserve.models.v1alpha1_local_model_cache import V1alpha1LocalModelCache
+from kserve.models.v1alpha1_local_model_cache_list import V1alpha1LocalModelCacheList
+from kserve.models.v1alpha1_local_model_cache_spec import V1alpha1LocalModelCacheSpec
+from kserve.models.v1alpha1_local_model_cache_status import V1alpha1LocalModelCacheStatus
+from kserve.models.v1alpha1_log_routing_config_spec import V1alpha1LogRoutingConfigSpec
+from kserve.models.v1alpha1_logger_config_spec import V1alpha1LoggerConfigSpec
+from kserve.models.v1alpha1_metrics_aggregator_config_spec import V1alpha1MetricsAggregatorConfigSpec
 from kserve.models.v1alpha1_model_spec import V1alpha1ModelSpec
+from kserve.models.v1alpha1_pod_metadata_config_spec import V1alpha1PodMetadataConfigSpec
+from kserve.models.v1alpha1_pod_monitor_config_spec import V1alpha1PodMonitorConfigSpec
+from kserve.models.v1alpha1_presign_broker_config_spec import V1alpha1PresignBrokerConfigSpec
+from kserve.models.v1alpha1_resource_config_spec import V1alpha1ResourceConfigSpec
+from kserve.models.v1alpha1_router_config_spec import V1alpha1RouterConfigSpec
+from kserve.models.v1alpha1_s3_credentials_config_spec import V1alpha1S3CredentialsConfigSpec
 from kserve.models.v1alpha1_serving_runtime import V1alpha1ServingRuntime
 from kserve.models.v1alpha1_serving_runtime_list import V1alpha1ServingRuntimeList
 from kserve.models.v1alpha1_serving_runtime_pod_spec import V1alpha1ServingRuntimePodSpec
 from kserve.models.v1alpha1_serving_runtime_spec import V1alpha1ServingRuntimeSpec
+from kserve.models.v1alpha1_step_backoff import V1alpha1StepBackoff
+from kserve.models.v1alpha1_step_circuit_breaker import V1alpha1StepCircuitBreaker
 from kserve.models.v1alpha1_storage_helper import V1alpha1StorageHelper
+from kserve.models.v1alpha1_storage_initializer_config_spec import V1alpha1StorageInitializerConfigSpec
 from kserve.models.v1alpha1_supported_model_format import V1alpha1SupportedModelFormat
+from kserve.models.v1alpha1_template_parameter import V1alpha1TemplateParameter
+from kserve.models.v1alpha1_templated_inference_service import V1alpha1TemplatedInferenceService
+from kserve.models.v1alpha1_templated_inference_service_list import V1alpha1TemplatedInferenceServiceList
+from kserve.models.v1alpha1_templated_inference_service_spec import V1alpha1TemplatedInferenceServiceSpec
+from kserve.models.v1alpha1_templated_inference_service_status import V1alpha1TemplatedInferenceServiceStatus
 from kserve.models.v1alpha1_trained_model import V1alpha1TrainedModel
 from kserve.models.v1alpha1_trained_model_list import V1alpha1TrainedModelList
 from kserve.models.v1alpha1_trained_model_spec import V1alpha1TrainedModelSpec
@@ -53,39 +93,55 @@ from kserve.models.v1beta1_art_explainer_spec import V1beta1ARTExplainerSpec
 from kserve.models.v1beta1_alibi_explainer_spec import V1beta1AlibiExplainerSpec
 from kserve.models.v1beta1_batcher import V1beta1Batcher
 from kserve.models.v1beta1_component_extension_spec import V1beta1ComponentExtensionSpec
+from kserve.models.v1beta1_component_overlay import V1beta1ComponentOverlay
 from kserve.models.v1beta1_component_status_spec import V1beta1ComponentStatusSpec
+from kserve.models.v1beta1_cors_policy_config import V1beta1CorsPolicyConfig
 from kserve.models.v1beta1_custom_explainer import V1beta1CustomExplainer
 from kserve.models.v1beta1_custom_predictor import V1beta1CustomPredictor
 from kserve.models.v1beta1_custom_transformer import V1beta1CustomTransformer
 from kserve.models.v1beta1_deploy_config import V1beta1DeployConfig
+from kserve.models.v1beta1_dynamic_shape import V1beta1DynamicShape
 from kserve.models.v1beta1_explainer_config import V1beta1ExplainerConfig
 from kserve.models.v1beta1_explainer_extension_spec import V1beta1ExplainerExtensionSpec
 from kserve.models.v1beta1_explainer_spec import V1beta1ExplainerSpec
 from kserve.models.v1beta1_explainers_config import V1beta1ExplainersConfig
 from kserve.models.v1beta1_failure_info import V1beta1FailureInfo
+from kserve.models.v1beta1_graph_optimization import V1beta1GraphOptimization
+from kserve.models.v1beta1_grpc_routes_config import V1beta1GrpcRoutesConfig
 from kserve.models.v1beta1_inference_service import V1beta1InferenceService
 from kserve.models.v1beta1_inference_service_list import V1beta1InferenceServiceList
+from kserve.models.v1beta1_inference_service_overlay import V1beta1InferenceServiceOverlay
 from kserve.models.v1beta1_inference_service_spec import V1beta1InferenceServiceSpec
 from kserve.models.v1beta1_inference_service_status import V1beta1InferenceServiceStatus
 from kserve.models.v1beta1_inference_services_config import V1beta1InferenceServicesConfig
+from kserve.models.v1beta1_ingress_class_config import V1beta1IngressClassConfig
 from kserve.models.v1beta1_ingress_config import V1beta1IngressConfig
+from kserve.models.v1beta1_ingress_tls_config import V1beta1IngressTlsConfig
 from kserve.models.v1beta1_light_gbm_spec import V1beta1LightGBMSpec
+from kserve.models.v1beta1_logger_redact_spec import V1beta1LoggerRedactSpec
 from kserve.models.v1beta1_logger_spec import V1beta1LoggerSpec
+from kserve.models.v1beta1_model_conversion import V1beta1ModelConversion
 from kserve.models.v1beta1_model_copies import V1beta1ModelCopies
 from kserve.models.v1beta1_model_format import V1beta1ModelFormat
 from kserve.models.v1beta1_model_revision_states import V1beta1ModelRevisionStates
 from kserve.models.v1beta1_model_spec import V1beta1ModelSpec
 from kserve.models.v1beta1_model_status import V1beta1ModelStatus
 from kserve.models.v1beta1_onnx_runtime_spec import V1beta1ONNXRuntimeSpec
+from kserve.models.v1beta1_oom_remediation import V1beta1OOMRemediation
 from kserve.models.v1beta1_pmml_spec import V1beta1PMMLSpec
 from kserve.models.v1beta1_paddle_server_spec import V1beta1PaddleServerSpec
 from kserve.models.v1beta1_pod_spec import V1beta1PodSpec
 from kserve.models.v1beta1_predictor_extension_spec import V1beta1PredictorExtensionSpec
 from kserve.models.v1beta1_predictor_spec import V1beta1PredictorSpec
+from kserve.models.v1beta1_rate_limit import V1beta1RateLimit
+from kserve.models.v1beta1_retry_policy import V1beta1RetryPolicy
 from kserve.models.v1beta1_sk_learn_spec import V1beta1SKLearnSpec
+from kserve.models.v1beta1_smoke_test_response_matcher import V1beta1SmokeTestResponseMatcher
+from kserve.models.v1beta1_smoke_test_spec import V1beta1SmokeTestSpec
 from kserve.models.v1beta1_storage_spec import V1beta1StorageSpec
 from kserve.models.v1beta1_tf_serving_spec import V1beta1TFServingSpec
 from kserve.models.v1beta1_torch_serve_spec import V1beta1TorchServeSpec
+from kserve.models.v1beta1_traffic_mirror import V1beta1TrafficMirror
 from kserve.models.v1beta1_transformer_spec import V1beta1TransformerSpec
 from kserve.models.v1beta1_triton_spec import V1beta1TritonSpec
 from kserve.models.v1beta1_xg_boost_spec import V1beta1XGBoostSpec
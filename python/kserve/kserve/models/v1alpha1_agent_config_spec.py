@@ -0,0 +1,351 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1AgentConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'cache_max_age': 'str',
+        'cache_storage_uri': 'str',
+        'cpu_limit': 'str',
+        'cpu_request': 'str',
+        'image': 'str',
+        'memory_limit': 'str',
+        'memory_request': 'str',
+        'tls_cipher_suites': 'str',
+        'tls_min_version': 'str'
+    }
+
+    attribute_map = {
+        'cache_max_age': 'cacheMaxAge',
+        'cache_storage_uri': 'cacheStorageUri',
+        'cpu_limit': 'cpuLimit',
+        'cpu_request': 'cpuRequest',
+        'image': 'image',
+        'memory_limit': 'memoryLimit',
+        'memory_request': 'memoryRequest',
+        'tls_cipher_suites': 'tlsCipherSuites',
+        'tls_min_version': 'tlsMinVersion'
+    }
+
+    def __init__(self, cache_max_age=None, cache_storage_uri=None, cpu_limit=None, cpu_request=None, image=None, memory_limit=None, memory_request=None, tls_cipher_suites=None, tls_min_version=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1AgentConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._cache_max_age = None
+        self._cache_storage_uri = None
+        self._cpu_limit = None
+        self._cpu_request = None
+        self._image = None
+        self._memory_limit = None
+        self._memory_request = None
+        self._tls_cipher_suites = None
+        self._tls_min_version = None
+        self.discriminator = None
+
+        if cache_max_age is not None:
+            self.cache_max_age = cache_max_age
+        if cache_storage_uri is not None:
+            self.cache_storage_uri = cache_storage_uri
+        if cpu_limit is not None:
+            self.cpu_limit = cpu_limit
+        if cpu_request is not None:
+            self.cpu_request = cpu_request
+        self.image = image
+        if memory_limit is not None:
+            self.memory_limit = memory_limit
+        if memory_request is not None:
+            self.memory_request = memory_request
+        if tls_cipher_suites is not None:
+            self.tls_cipher_suites = tls_cipher_suites
+        if tls_min_version is not None:
+            self.tls_min_version = tls_min_version
+
+    @property
+    def cache_max_age(self):
+        """Gets the cache_max_age of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+        Duration the cached models are kept for since they were last used, e.g. \"168h\"  # noqa: E501
+
+        :return: The cache_max_age of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cache_max_age
+
+    @cache_max_age.setter
+    def cache_max_age(self, cache_max_age):
+        """Sets the cache_max_age of this V1alpha1AgentConfigSpec.
+
+        Duration the cached models are kept for since they were last used, e.g. \"168h\"  # noqa: E501
+
+        :param cache_max_age: The cache_max_age of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cache_max_age = cache_max_age
+
+    @property
+    def cache_storage_uri(self):
+        """Gets the cache_storage_uri of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+        s3://<bucket>/<prefix> location the model puller caches the downloaded models in, shared across clusters  # noqa: E501
+
+        :return: The cache_storage_uri of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cache_storage_uri
+
+    @cache_storage_uri.setter
+    def cache_storage_uri(self, cache_storage_uri):
+        """Sets the cache_storage_uri of this V1alpha1AgentConfigSpec.
+
+        s3://<bucket>/<prefix> location the model puller caches the downloaded models in, shared across clusters  # noqa: E501
+
+        :param cache_storage_uri: The cache_storage_uri of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cache_storage_uri = cache_storage_uri
+
+    @property
+    def cpu_limit(self):
+        """Gets the cpu_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+
+        :return: The cpu_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cpu_limit
+
+    @cpu_limit.setter
+    def cpu_limit(self, cpu_limit):
+        """Sets the cpu_limit of this V1alpha1AgentConfigSpec.
+
+
+        :param cpu_limit: The cpu_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cpu_limit = cpu_limit
+
+    @property
+    def cpu_request(self):
+        """Gets the cpu_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+
+        :return: The cpu_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cpu_request
+
+    @cpu_request.setter
+    def cpu_request(self, cpu_request):
+        """Sets the cpu_request of this V1alpha1AgentConfigSpec.
+
+
+        :param cpu_request: The cpu_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cpu_request = cpu_request
+
+    @property
+    def image(self):
+        """Gets the image of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+
+        :return: The image of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1alpha1AgentConfigSpec.
+
+
+        :param image: The image of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and image is None:  # noqa: E501
+            raise ValueError("Invalid value for `image`, must not be `None`")  # noqa: E501
+
+        self._image = image
+
+    @property
+    def memory_limit(self):
+        """Gets the memory_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+
+        :return: The memory_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_limit
+
+    @memory_limit.setter
+    def memory_limit(self, memory_limit):
+        """Sets the memory_limit of this V1alpha1AgentConfigSpec.
+
+
+        :param memory_limit: The memory_limit of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_limit = memory_limit
+
+    @property
+    def memory_request(self):
+        """Gets the memory_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+
+        :return: The memory_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_request
+
+    @memory_request.setter
+    def memory_request(self, memory_request):
+        """Sets the memory_request of this V1alpha1AgentConfigSpec.
+
+
+        :param memory_request: The memory_request of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_request = memory_request
+
+    @property
+    def tls_cipher_suites(self):
+        """Gets the tls_cipher_suites of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+        Comma separated TLS cipher suites of the outgoing connections of the agent  # noqa: E501
+
+        :return: The tls_cipher_suites of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._tls_cipher_suites
+
+    @tls_cipher_suites.setter
+    def tls_cipher_suites(self, tls_cipher_suites):
+        """Sets the tls_cipher_suites of this V1alpha1AgentConfigSpec.
+
+        Comma separated TLS cipher suites of the outgoing connections of the agent  # noqa: E501
+
+        :param tls_cipher_suites: The tls_cipher_suites of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._tls_cipher_suites = tls_cipher_suites
+
+    @property
+    def tls_min_version(self):
+        """Gets the tls_min_version of this V1alpha1AgentConfigSpec.  # noqa: E501
+
+        Minimum TLS version of the outgoing connections of the agent, e.g. \"1.2\"  # noqa: E501
+
+        :return: The tls_min_version of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._tls_min_version
+
+    @tls_min_version.setter
+    def tls_min_version(self, tls_min_version):
+        """Sets the tls_min_version of this V1alpha1AgentConfigSpec.
+
+        Minimum TLS version of the outgoing connections of the agent, e.g. \"1.2\"  # noqa: E501
+
+        :param tls_min_version: The tls_min_version of this V1alpha1AgentConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._tls_min_version = tls_min_version
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1AgentConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1AgentConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
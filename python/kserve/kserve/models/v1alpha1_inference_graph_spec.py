@@ -47,23 +47,33 @@ class V1alpha1InferenceGraphSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
-        'nodes': 'dict(str, V1alpha1InferenceRouter)'
+        'nodes': 'dict(str, V1alpha1InferenceRouter)',
+        'stream_idle_timeout': 'int',
+        'timeout': 'int'
     }
 
     attribute_map = {
-        'nodes': 'nodes'
+        'nodes': 'nodes',
+        'stream_idle_timeout': 'streamIdleTimeout',
+        'timeout': 'timeout'
     }
 
-    def __init__(self, nodes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, nodes=None, stream_idle_timeout=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceGraphSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._nodes = None
+        self._stream_idle_timeout = None
+        self._timeout = None
         self.discriminator = None
 
         self.nodes = nodes
+        if stream_idle_timeout is not None:
+            self.stream_idle_timeout = stream_idle_timeout
+        if timeout is not None:
+            self.timeout = timeout
 
     @property
     def nodes(self):
@@ -90,6 +100,52 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._nodes = nodes
 
+    @property
+    def stream_idle_timeout(self):
+        """Gets the stream_idle_timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        StreamIdleTimeoutSeconds specifies the number of seconds the router waits for the next chunk of a streamed response before it cancels the call, the streams are not cancelled while they progress when not set.  # noqa: E501
+
+        :return: The stream_idle_timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._stream_idle_timeout
+
+    @stream_idle_timeout.setter
+    def stream_idle_timeout(self, stream_idle_timeout):
+        """Sets the stream_idle_timeout of this V1alpha1InferenceGraphSpec.
+
+        StreamIdleTimeoutSeconds specifies the number of seconds the router waits for the next chunk of a streamed response before it cancels the call, the streams are not cancelled while they progress when not set.  # noqa: E501
+
+        :param stream_idle_timeout: The stream_idle_timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._stream_idle_timeout = stream_idle_timeout
+
+    @property
+    def timeout(self):
+        """Gets the timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        TimeoutSeconds specifies the number of seconds to wait before timing out a request to the graph, the streamed responses included. It sets the timeout of the router revision.  # noqa: E501
+
+        :return: The timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._timeout
+
+    @timeout.setter
+    def timeout(self, timeout):
+        """Sets the timeout of this V1alpha1InferenceGraphSpec.
+
+        TimeoutSeconds specifies the number of seconds to wait before timing out a request to the graph, the streamed responses included. It sets the timeout of the router revision.  # noqa: E501
+
+        :param timeout: The timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._timeout = timeout
+
     def to_dict(self):
         """Returns the model properties as a dict"""
         result = {}
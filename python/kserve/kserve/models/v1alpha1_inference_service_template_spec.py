@@ -0,0 +1,165 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1InferenceServiceTemplateSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'parameters': 'list[V1alpha1TemplateParameter]',
+        'template': 'str'
+    }
+
+    attribute_map = {
+        'parameters': 'parameters',
+        'template': 'template'
+    }
+
+    def __init__(self, parameters=None, template=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1InferenceServiceTemplateSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._parameters = None
+        self._template = None
+        self.discriminator = None
+
+        if parameters is not None:
+            self.parameters = parameters
+        self.template = template
+
+    @property
+    def parameters(self):
+        """Gets the parameters of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+
+        Parameters accepted by the template  # noqa: E501
+
+        :return: The parameters of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+        :rtype: list[V1alpha1TemplateParameter]
+        """
+        return self._parameters
+
+    @parameters.setter
+    def parameters(self, parameters):
+        """Sets the parameters of this V1alpha1InferenceServiceTemplateSpec.
+
+        Parameters accepted by the template  # noqa: E501
+
+        :param parameters: The parameters of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+        :type: list[V1alpha1TemplateParameter]
+        """
+
+        self._parameters = parameters
+
+    @property
+    def template(self):
+        """Gets the template of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+
+        InferenceService manifest in yaml rendered as a Go template, the parameters are available as {{ .Parameters.<name> }} and the TemplatedInferenceService as {{ .Name }} and {{ .Namespace }}. Only the labels, annotations and spec of the rendered manifest are used.  # noqa: E501
+
+        :return: The template of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._template
+
+    @template.setter
+    def template(self, template):
+        """Sets the template of this V1alpha1InferenceServiceTemplateSpec.
+
+        InferenceService manifest in yaml rendered as a Go template, the parameters are available as {{ .Parameters.<name> }} and the TemplatedInferenceService as {{ .Name }} and {{ .Namespace }}. Only the labels, annotations and spec of the rendered manifest are used.  # noqa: E501
+
+        :param template: The template of this V1alpha1InferenceServiceTemplateSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and template is None:  # noqa: E501
+            raise ValueError("Invalid value for `template`, must not be `None`")  # noqa: E501
+
+        self._template = template
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1InferenceServiceTemplateSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1InferenceServiceTemplateSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
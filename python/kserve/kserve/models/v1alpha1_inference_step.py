@@ -47,40 +47,53 @@ class V1alpha1InferenceStep(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'backoff': 'V1alpha1StepBackoff',
+        'circuit_breaker': 'V1alpha1StepCircuitBreaker',
         'condition': 'str',
         'data': 'str',
         'name': 'str',
         'node_name': 'str',
+        'retries': 'int',
         'service_name': 'str',
         'service_url': 'str',
         'weight': 'int'
     }
 
     attribute_map = {
+        'backoff': 'backoff',
+        'circuit_breaker': 'circuitBreaker',
         'condition': 'condition',
         'data': 'data',
         'name': 'name',
         'node_name': 'nodeName',
+        'retries': 'retries',
         'service_name': 'serviceName',
         'service_url': 'serviceUrl',
         'weight': 'weight'
     }
 
-    def __init__(self, condition=None, data=None, name=None, node_name=None, service_name=None, service_url=None, weight=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, backoff=None, circuit_breaker=None, condition=None, data=None, name=None, node_name=None, retries=None, service_name=None, service_url=None, weight=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceStep - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._backoff = None
+        self._circuit_breaker = None
         self._condition = None
         self._data = None
         self._name = None
         self._node_name = None
+        self._retries = None
         self._service_name = None
         self._service_url = None
         self._weight = None
         self.discriminator = None
 
+        if backoff is not None:
+            self.backoff = backoff
+        if circuit_breaker is not None:
+            self.circuit_breaker = circuit_breaker
         if condition is not None:
             self.condition = condition
         if data is not None:
@@ -89,6 +102,8 @@ class V1alpha1InferenceStep(object):
             self.name = name
         if node_name is not None:
             self.node_name = node_name
+        if retries is not None:
+            self.retries = retries
         if service_name is not None:
             self.service_name = service_name
         if service_url is not None:
@@ -96,11 +111,57 @@ class V1alpha1InferenceStep(object):
         if weight is not None:
             self.weight = weight
 
+    @property
+    def backoff(self):
+        """Gets the backoff of this V1alpha1InferenceStep.  # noqa: E501
+
+        Delay between the retries of the call of the service  # noqa: E501
+
+        :return: The backoff of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1StepBackoff
+        """
+        return self._backoff
+
+    @backoff.setter
+    def backoff(self, backoff):
+        """Sets the backoff of this V1alpha1InferenceStep.
+
+        Delay between the retries of the call of the service  # noqa: E501
+
+        :param backoff: The backoff of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1StepBackoff
+        """
+
+        self._backoff = backoff
+
+    @property
+    def circuit_breaker(self):
+        """Gets the circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+
+        Fails the calls of the service fast after consecutive failures instead of calling it until it recovers, the open circuits are reported by the CircuitBreakersClosed condition of the InferenceGraph  # noqa: E501
+
+        :return: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1StepCircuitBreaker
+        """
+        return self._circuit_breaker
+
+    @circuit_breaker.setter
+    def circuit_breaker(self, circuit_breaker):
+        """Sets the circuit_breaker of this V1alpha1InferenceStep.
+
+        Fails the calls of the service fast after consecutive failures instead of calling it until it recovers, the open circuits are reported by the CircuitBreakersClosed condition of the InferenceGraph  # noqa: E501
+
+        :param circuit_breaker: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1StepCircuitBreaker
+        """
+
+        self._circuit_breaker = circuit_breaker
+
     @property
     def condition(self):
         """Gets the condition of this V1alpha1InferenceStep.  # noqa: E501
 
-        routing based on the condition  # noqa: E501
+        routing based on the condition, a GJSON path which must exist in the payload. The conditions of the Switch nodes match the request of the node and the conditions of the Sequence steps match the response of the previous step, unless they are prefixed with $request or $response, e.g. $response.predictions.#(score<0.8) routes on the response of the previous step of the enclosing Sequence  # noqa: E501
 
         :return: The condition of this V1alpha1InferenceStep.  # noqa: E501
         :rtype: str
@@ -111,7 +172,7 @@ class V1alpha1InferenceStep(object):
     def condition(self, condition):
         """Sets the condition of this V1alpha1InferenceStep.
 
-        routing based on the condition  # noqa: E501
+        routing based on the condition, a GJSON path which must exist in the payload. The conditions of the Switch nodes match the request of the node and the conditions of the Sequence steps match the response of the previous step, unless they are prefixed with $request or $response, e.g. $response.predictions.#(score<0.8) routes on the response of the previous step of the enclosing Sequence  # noqa: E501
 
         :param condition: The condition of this V1alpha1InferenceStep.  # noqa: E501
         :type: str
@@ -188,6 +249,29 @@ class V1alpha1InferenceStep(object):
 
         self._node_name = node_name
 
+    @property
+    def retries(self):
+        """Gets the retries of this V1alpha1InferenceStep.  # noqa: E501
+
+        Number of times the call of the service is retried when it can not be reached or answers with a 502, 503 or 504 status, the calls are not retried when not set  # noqa: E501
+
+        :return: The retries of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: int
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1alpha1InferenceStep.
+
+        Number of times the call of the service is retried when it can not be reached or answers with a 502, 503 or 504 status, the calls are not retried when not set  # noqa: E501
+
+        :param retries: The retries of this V1alpha1InferenceStep.  # noqa: E501
+        :type: int
+        """
+
+        self._retries = retries
+
     @property
     def service_name(self):
         """Gets the service_name of this V1alpha1InferenceStep.  # noqa: E501
@@ -0,0 +1,221 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1IngressClassConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'annotations': 'dict(str, str)',
+        'domain_template': 'str',
+        'ingress_class_name': 'str',
+        'ingress_domain': 'str'
+    }
+
+    attribute_map = {
+        'annotations': 'annotations',
+        'domain_template': 'domainTemplate',
+        'ingress_class_name': 'ingressClassName',
+        'ingress_domain': 'ingressDomain'
+    }
+
+    def __init__(self, annotations=None, domain_template=None, ingress_class_name='', ingress_domain=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1IngressClassConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._annotations = None
+        self._domain_template = None
+        self._ingress_class_name = None
+        self._ingress_domain = None
+        self.discriminator = None
+
+        if annotations is not None:
+            self.annotations = annotations
+        if domain_template is not None:
+            self.domain_template = domain_template
+        self.ingress_class_name = ingress_class_name
+        if ingress_domain is not None:
+            self.ingress_domain = ingress_domain
+
+    @property
+    def annotations(self):
+        """Gets the annotations of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+
+        Annotations of the ingress, e.g. the scheme of the load balancer  # noqa: E501
+
+        :return: The annotations of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._annotations
+
+    @annotations.setter
+    def annotations(self, annotations):
+        """Sets the annotations of this V1alpha1IngressClassConfigSpec.
+
+        Annotations of the ingress, e.g. the scheme of the load balancer  # noqa: E501
+
+        :param annotations: The annotations of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._annotations = annotations
+
+    @property
+    def domain_template(self):
+        """Gets the domain_template of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+
+        Template of the hosts of the ingress, the domain template of the ingress config by default  # noqa: E501
+
+        :return: The domain_template of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._domain_template
+
+    @domain_template.setter
+    def domain_template(self, domain_template):
+        """Sets the domain_template of this V1alpha1IngressClassConfigSpec.
+
+        Template of the hosts of the ingress, the domain template of the ingress config by default  # noqa: E501
+
+        :param domain_template: The domain_template of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._domain_template = domain_template
+
+    @property
+    def ingress_class_name(self):
+        """Gets the ingress_class_name of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+
+        Name of the ingress class  # noqa: E501
+
+        :return: The ingress_class_name of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_class_name
+
+    @ingress_class_name.setter
+    def ingress_class_name(self, ingress_class_name):
+        """Sets the ingress_class_name of this V1alpha1IngressClassConfigSpec.
+
+        Name of the ingress class  # noqa: E501
+
+        :param ingress_class_name: The ingress_class_name of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and ingress_class_name is None:  # noqa: E501
+            raise ValueError("Invalid value for `ingress_class_name`, must not be `None`")  # noqa: E501
+
+        self._ingress_class_name = ingress_class_name
+
+    @property
+    def ingress_domain(self):
+        """Gets the ingress_domain of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+
+        Domain of the hosts of the ingress, the ingress domain of the ingress config by default  # noqa: E501
+
+        :return: The ingress_domain of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_domain
+
+    @ingress_domain.setter
+    def ingress_domain(self, ingress_domain):
+        """Sets the ingress_domain of this V1alpha1IngressClassConfigSpec.
+
+        Domain of the hosts of the ingress, the ingress domain of the ingress config by default  # noqa: E501
+
+        :param ingress_domain: The ingress_domain of this V1alpha1IngressClassConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._ingress_domain = ingress_domain
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1IngressClassConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1IngressClassConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,590 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1IngressConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'additional_ingress_classes': 'list[V1alpha1IngressClassConfigSpec]',
+        'allowed_ingress_gateways': 'list[str]',
+        'cors_policy': 'V1alpha1CorsPolicySpec',
+        'disable_istio_virtual_host': 'bool',
+        'domain_template': 'str',
+        'enable_gateway_api': 'bool',
+        'global_domain_template': 'str',
+        'grpc_routes': 'V1alpha1GrpcRoutesSpec',
+        'host_template': 'str',
+        'ingress_class_name': 'str',
+        'ingress_domain': 'str',
+        'ingress_gateway': 'str',
+        'ingress_service': 'str',
+        'kserve_ingress_gateway': 'str',
+        'local_gateway': 'str',
+        'local_gateway_service': 'str',
+        'tls': 'V1alpha1IngressTlsSpec',
+        'url_scheme': 'str'
+    }
+
+    attribute_map = {
+        'additional_ingress_classes': 'additionalIngressClasses',
+        'allowed_ingress_gateways': 'allowedIngressGateways',
+        'cors_policy': 'corsPolicy',
+        'disable_istio_virtual_host': 'disableIstioVirtualHost',
+        'domain_template': 'domainTemplate',
+        'enable_gateway_api': 'enableGatewayApi',
+        'global_domain_template': 'globalDomainTemplate',
+        'grpc_routes': 'grpcRoutes',
+        'host_template': 'hostTemplate',
+        'ingress_class_name': 'ingressClassName',
+        'ingress_domain': 'ingressDomain',
+        'ingress_gateway': 'ingressGateway',
+        'ingress_service': 'ingressService',
+        'kserve_ingress_gateway': 'kserveIngressGateway',
+        'local_gateway': 'localGateway',
+        'local_gateway_service': 'localGatewayService',
+        'tls': 'tls',
+        'url_scheme': 'urlScheme'
+    }
+
+    def __init__(self, additional_ingress_classes=None, allowed_ingress_gateways=None, cors_policy=None, disable_istio_virtual_host=None, domain_template=None, enable_gateway_api=None, global_domain_template=None, grpc_routes=None, host_template=None, ingress_class_name=None, ingress_domain=None, ingress_gateway=None, ingress_service=None, kserve_ingress_gateway=None, local_gateway=None, local_gateway_service=None, tls=None, url_scheme=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1IngressConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._additional_ingress_classes = None
+        self._allowed_ingress_gateways = None
+        self._cors_policy = None
+        self._disable_istio_virtual_host = None
+        self._domain_template = None
+        self._enable_gateway_api = None
+        self._global_domain_template = None
+        self._grpc_routes = None
+        self._host_template = None
+        self._ingress_class_name = None
+        self._ingress_domain = None
+        self._ingress_gateway = None
+        self._ingress_service = None
+        self._kserve_ingress_gateway = None
+        self._local_gateway = None
+        self._local_gateway_service = None
+        self._tls = None
+        self._url_scheme = None
+        self.discriminator = None
+
+        if additional_ingress_classes is not None:
+            self.additional_ingress_classes = additional_ingress_classes
+        if allowed_ingress_gateways is not None:
+            self.allowed_ingress_gateways = allowed_ingress_gateways
+        if cors_policy is not None:
+            self.cors_policy = cors_policy
+        if disable_istio_virtual_host is not None:
+            self.disable_istio_virtual_host = disable_istio_virtual_host
+        if domain_template is not None:
+            self.domain_template = domain_template
+        if enable_gateway_api is not None:
+            self.enable_gateway_api = enable_gateway_api
+        if global_domain_template is not None:
+            self.global_domain_template = global_domain_template
+        if grpc_routes is not None:
+            self.grpc_routes = grpc_routes
+        if host_template is not None:
+            self.host_template = host_template
+        if ingress_class_name is not None:
+            self.ingress_class_name = ingress_class_name
+        if ingress_domain is not None:
+            self.ingress_domain = ingress_domain
+        self.ingress_gateway = ingress_gateway
+        self.ingress_service = ingress_service
+        if kserve_ingress_gateway is not None:
+            self.kserve_ingress_gateway = kserve_ingress_gateway
+        if local_gateway is not None:
+            self.local_gateway = local_gateway
+        if local_gateway_service is not None:
+            self.local_gateway_service = local_gateway_service
+        if tls is not None:
+            self.tls = tls
+        if url_scheme is not None:
+            self.url_scheme = url_scheme
+
+    @property
+    def additional_ingress_classes(self):
+        """Gets the additional_ingress_classes of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        Ingress classes the inference services are also exposed through in RawDeployment mode, one ingress per class  # noqa: E501
+
+        :return: The additional_ingress_classes of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: list[V1alpha1IngressClassConfigSpec]
+        """
+        return self._additional_ingress_classes
+
+    @additional_ingress_classes.setter
+    def additional_ingress_classes(self, additional_ingress_classes):
+        """Sets the additional_ingress_classes of this V1alpha1IngressConfigSpec.
+
+        Ingress classes the inference services are also exposed through in RawDeployment mode, one ingress per class  # noqa: E501
+
+        :param additional_ingress_classes: The additional_ingress_classes of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: list[V1alpha1IngressClassConfigSpec]
+        """
+
+        self._additional_ingress_classes = additional_ingress_classes
+
+    @property
+    def allowed_ingress_gateways(self):
+        """Gets the allowed_ingress_gateways of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        Gateways the inference services can select with the serving.kserve.io/ingress-gateway annotation besides the ingressGateway and the kserveIngressGateway, referenced as <namespace>/<name>  # noqa: E501
+
+        :return: The allowed_ingress_gateways of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allowed_ingress_gateways
+
+    @allowed_ingress_gateways.setter
+    def allowed_ingress_gateways(self, allowed_ingress_gateways):
+        """Sets the allowed_ingress_gateways of this V1alpha1IngressConfigSpec.
+
+        Gateways the inference services can select with the serving.kserve.io/ingress-gateway annotation besides the ingressGateway and the kserveIngressGateway, referenced as <namespace>/<name>  # noqa: E501
+
+        :param allowed_ingress_gateways: The allowed_ingress_gateways of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allowed_ingress_gateways = allowed_ingress_gateways
+
+    @property
+    def cors_policy(self):
+        """Gets the cors_policy of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        CORS policy of the routes of the virtual services, the browsers can call the inference services directly  # noqa: E501
+
+        :return: The cors_policy of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: V1alpha1CorsPolicySpec
+        """
+        return self._cors_policy
+
+    @cors_policy.setter
+    def cors_policy(self, cors_policy):
+        """Sets the cors_policy of this V1alpha1IngressConfigSpec.
+
+        CORS policy of the routes of the virtual services, the browsers can call the inference services directly  # noqa: E501
+
+        :param cors_policy: The cors_policy of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: V1alpha1CorsPolicySpec
+        """
+
+        self._cors_policy = cors_policy
+
+    @property
+    def disable_istio_virtual_host(self):
+        """Gets the disable_istio_virtual_host of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The disable_istio_virtual_host of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._disable_istio_virtual_host
+
+    @disable_istio_virtual_host.setter
+    def disable_istio_virtual_host(self, disable_istio_virtual_host):
+        """Sets the disable_istio_virtual_host of this V1alpha1IngressConfigSpec.
+
+
+        :param disable_istio_virtual_host: The disable_istio_virtual_host of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._disable_istio_virtual_host = disable_istio_virtual_host
+
+    @property
+    def domain_template(self):
+        """Gets the domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._domain_template
+
+    @domain_template.setter
+    def domain_template(self, domain_template):
+        """Sets the domain_template of this V1alpha1IngressConfigSpec.
+
+
+        :param domain_template: The domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._domain_template = domain_template
+
+    @property
+    def enable_gateway_api(self):
+        """Gets the enable_gateway_api of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The enable_gateway_api of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._enable_gateway_api
+
+    @enable_gateway_api.setter
+    def enable_gateway_api(self, enable_gateway_api):
+        """Sets the enable_gateway_api of this V1alpha1IngressConfigSpec.
+
+
+        :param enable_gateway_api: The enable_gateway_api of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._enable_gateway_api = enable_gateway_api
+
+    @property
+    def global_domain_template(self):
+        """Gets the global_domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The global_domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._global_domain_template
+
+    @global_domain_template.setter
+    def global_domain_template(self, global_domain_template):
+        """Sets the global_domain_template of this V1alpha1IngressConfigSpec.
+
+
+        :param global_domain_template: The global_domain_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._global_domain_template = global_domain_template
+
+    @property
+    def grpc_routes(self):
+        """Gets the grpc_routes of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        gRPC routes of the virtual services, the gRPC requests are routed to the predictors over HTTP/2  # noqa: E501
+
+        :return: The grpc_routes of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: V1alpha1GrpcRoutesSpec
+        """
+        return self._grpc_routes
+
+    @grpc_routes.setter
+    def grpc_routes(self, grpc_routes):
+        """Sets the grpc_routes of this V1alpha1IngressConfigSpec.
+
+        gRPC routes of the virtual services, the gRPC requests are routed to the predictors over HTTP/2  # noqa: E501
+
+        :param grpc_routes: The grpc_routes of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: V1alpha1GrpcRoutesSpec
+        """
+
+        self._grpc_routes = grpc_routes
+
+    @property
+    def host_template(self):
+        """Gets the host_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        Template of the external hosts of the inference services in Serverless mode, e.g. {{ .Name }}-{{ .Namespace }}.models.example.com, the hosts of the Knative services by default  # noqa: E501
+
+        :return: The host_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._host_template
+
+    @host_template.setter
+    def host_template(self, host_template):
+        """Sets the host_template of this V1alpha1IngressConfigSpec.
+
+        Template of the external hosts of the inference services in Serverless mode, e.g. {{ .Name }}-{{ .Namespace }}.models.example.com, the hosts of the Knative services by default  # noqa: E501
+
+        :param host_template: The host_template of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._host_template = host_template
+
+    @property
+    def ingress_class_name(self):
+        """Gets the ingress_class_name of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The ingress_class_name of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_class_name
+
+    @ingress_class_name.setter
+    def ingress_class_name(self, ingress_class_name):
+        """Sets the ingress_class_name of this V1alpha1IngressConfigSpec.
+
+
+        :param ingress_class_name: The ingress_class_name of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._ingress_class_name = ingress_class_name
+
+    @property
+    def ingress_domain(self):
+        """Gets the ingress_domain of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The ingress_domain of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_domain
+
+    @ingress_domain.setter
+    def ingress_domain(self, ingress_domain):
+        """Sets the ingress_domain of this V1alpha1IngressConfigSpec.
+
+
+        :param ingress_domain: The ingress_domain of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._ingress_domain = ingress_domain
+
+    @property
+    def ingress_gateway(self):
+        """Gets the ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_gateway
+
+    @ingress_gateway.setter
+    def ingress_gateway(self, ingress_gateway):
+        """Sets the ingress_gateway of this V1alpha1IngressConfigSpec.
+
+
+        :param ingress_gateway: The ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and ingress_gateway is None:  # noqa: E501
+            raise ValueError("Invalid value for `ingress_gateway`, must not be `None`")  # noqa: E501
+
+        self._ingress_gateway = ingress_gateway
+
+    @property
+    def ingress_service(self):
+        """Gets the ingress_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The ingress_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_service
+
+    @ingress_service.setter
+    def ingress_service(self, ingress_service):
+        """Sets the ingress_service of this V1alpha1IngressConfigSpec.
+
+
+        :param ingress_service: The ingress_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and ingress_service is None:  # noqa: E501
+            raise ValueError("Invalid value for `ingress_service`, must not be `None`")  # noqa: E501
+
+        self._ingress_service = ingress_service
+
+    @property
+    def kserve_ingress_gateway(self):
+        """Gets the kserve_ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The kserve_ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._kserve_ingress_gateway
+
+    @kserve_ingress_gateway.setter
+    def kserve_ingress_gateway(self, kserve_ingress_gateway):
+        """Sets the kserve_ingress_gateway of this V1alpha1IngressConfigSpec.
+
+
+        :param kserve_ingress_gateway: The kserve_ingress_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._kserve_ingress_gateway = kserve_ingress_gateway
+
+    @property
+    def local_gateway(self):
+        """Gets the local_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The local_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._local_gateway
+
+    @local_gateway.setter
+    def local_gateway(self, local_gateway):
+        """Sets the local_gateway of this V1alpha1IngressConfigSpec.
+
+
+        :param local_gateway: The local_gateway of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._local_gateway = local_gateway
+
+    @property
+    def local_gateway_service(self):
+        """Gets the local_gateway_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The local_gateway_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._local_gateway_service
+
+    @local_gateway_service.setter
+    def local_gateway_service(self, local_gateway_service):
+        """Sets the local_gateway_service of this V1alpha1IngressConfigSpec.
+
+
+        :param local_gateway_service: The local_gateway_service of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._local_gateway_service = local_gateway_service
+
+    @property
+    def tls(self):
+        """Gets the tls of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+        TLS of the ingresses of the inference services in RawDeployment mode, the certificates of the hosts are issued by cert-manager  # noqa: E501
+
+        :return: The tls of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: V1alpha1IngressTlsSpec
+        """
+        return self._tls
+
+    @tls.setter
+    def tls(self, tls):
+        """Sets the tls of this V1alpha1IngressConfigSpec.
+
+        TLS of the ingresses of the inference services in RawDeployment mode, the certificates of the hosts are issued by cert-manager  # noqa: E501
+
+        :param tls: The tls of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: V1alpha1IngressTlsSpec
+        """
+
+        self._tls = tls
+
+    @property
+    def url_scheme(self):
+        """Gets the url_scheme of this V1alpha1IngressConfigSpec.  # noqa: E501
+
+
+        :return: The url_scheme of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._url_scheme
+
+    @url_scheme.setter
+    def url_scheme(self, url_scheme):
+        """Sets the url_scheme of this V1alpha1IngressConfigSpec.
+
+
+        :param url_scheme: The url_scheme of this V1alpha1IngressConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._url_scheme = url_scheme
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1IngressConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1IngressConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
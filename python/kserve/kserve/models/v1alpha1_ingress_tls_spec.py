@@ -0,0 +1,165 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1IngressTlsSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'cluster_issuer': 'str',
+        'ssl_redirect': 'bool'
+    }
+
+    attribute_map = {
+        'cluster_issuer': 'clusterIssuer',
+        'ssl_redirect': 'sslRedirect'
+    }
+
+    def __init__(self, cluster_issuer='', ssl_redirect=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1IngressTlsSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._cluster_issuer = None
+        self._ssl_redirect = None
+        self.discriminator = None
+
+        self.cluster_issuer = cluster_issuer
+        if ssl_redirect is not None:
+            self.ssl_redirect = ssl_redirect
+
+    @property
+    def cluster_issuer(self):
+        """Gets the cluster_issuer of this V1alpha1IngressTlsSpec.  # noqa: E501
+
+        Name of the cert-manager ClusterIssuer issuing the certificates, a Certificate is created per ingress and its secret is set in the tls block of the ingress  # noqa: E501
+
+        :return: The cluster_issuer of this V1alpha1IngressTlsSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cluster_issuer
+
+    @cluster_issuer.setter
+    def cluster_issuer(self, cluster_issuer):
+        """Sets the cluster_issuer of this V1alpha1IngressTlsSpec.
+
+        Name of the cert-manager ClusterIssuer issuing the certificates, a Certificate is created per ingress and its secret is set in the tls block of the ingress  # noqa: E501
+
+        :param cluster_issuer: The cluster_issuer of this V1alpha1IngressTlsSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and cluster_issuer is None:  # noqa: E501
+            raise ValueError("Invalid value for `cluster_issuer`, must not be `None`")  # noqa: E501
+
+        self._cluster_issuer = cluster_issuer
+
+    @property
+    def ssl_redirect(self):
+        """Gets the ssl_redirect of this V1alpha1IngressTlsSpec.  # noqa: E501
+
+        Whether the HTTP requests are redirected to HTTPS, set with the force-ssl-redirect annotation of ingress-nginx  # noqa: E501
+
+        :return: The ssl_redirect of this V1alpha1IngressTlsSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._ssl_redirect
+
+    @ssl_redirect.setter
+    def ssl_redirect(self, ssl_redirect):
+        """Sets the ssl_redirect of this V1alpha1IngressTlsSpec.
+
+        Whether the HTTP requests are redirected to HTTPS, set with the force-ssl-redirect annotation of ingress-nginx  # noqa: E501
+
+        :param ssl_redirect: The ssl_redirect of this V1alpha1IngressTlsSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._ssl_redirect = ssl_redirect
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1IngressTlsSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1IngressTlsSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
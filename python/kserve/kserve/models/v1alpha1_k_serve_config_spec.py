@@ -0,0 +1,444 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1KServeConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'agent': 'V1alpha1AgentConfigSpec',
+        'batcher': 'V1alpha1ContainerConfigSpec',
+        'credentials': 'V1alpha1CredentialsConfigSpec',
+        'deploy': 'V1alpha1DeployConfigSpec',
+        'explainers': 'dict(str, V1alpha1ExplainerConfigSpec)',
+        'image_registry': 'V1alpha1ImageRegistryConfigSpec',
+        'ingress': 'V1alpha1IngressConfigSpec',
+        'log_routing': 'V1alpha1LogRoutingConfigSpec',
+        'logger': 'V1alpha1LoggerConfigSpec',
+        'metrics_aggregator': 'V1alpha1MetricsAggregatorConfigSpec',
+        'router': 'V1alpha1RouterConfigSpec',
+        'storage_initializer': 'V1alpha1StorageInitializerConfigSpec'
+    }
+
+    attribute_map = {
+        'agent': 'agent',
+        'batcher': 'batcher',
+        'credentials': 'credentials',
+        'deploy': 'deploy',
+        'explainers': 'explainers',
+        'image_registry': 'imageRegistry',
+        'ingress': 'ingress',
+        'log_routing': 'logRouting',
+        'logger': 'logger',
+        'metrics_aggregator': 'metricsAggregator',
+        'router': 'router',
+        'storage_initializer': 'storageInitializer'
+    }
+
+    def __init__(self, agent=None, batcher=None, credentials=None, deploy=None, explainers=None, image_registry=None, ingress=None, log_routing=None, logger=None, metrics_aggregator=None, router=None, storage_initializer=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1KServeConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._agent = None
+        self._batcher = None
+        self._credentials = None
+        self._deploy = None
+        self._explainers = None
+        self._image_registry = None
+        self._ingress = None
+        self._log_routing = None
+        self._logger = None
+        self._metrics_aggregator = None
+        self._router = None
+        self._storage_initializer = None
+        self.discriminator = None
+
+        if agent is not None:
+            self.agent = agent
+        if batcher is not None:
+            self.batcher = batcher
+        if credentials is not None:
+            self.credentials = credentials
+        if deploy is not None:
+            self.deploy = deploy
+        if explainers is not None:
+            self.explainers = explainers
+        if image_registry is not None:
+            self.image_registry = image_registry
+        if ingress is not None:
+            self.ingress = ingress
+        if log_routing is not None:
+            self.log_routing = log_routing
+        if logger is not None:
+            self.logger = logger
+        if metrics_aggregator is not None:
+            self.metrics_aggregator = metrics_aggregator
+        if router is not None:
+            self.router = router
+        if storage_initializer is not None:
+            self.storage_initializer = storage_initializer
+
+    @property
+    def agent(self):
+        """Gets the agent of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Agent container configuration  # noqa: E501
+
+        :return: The agent of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1AgentConfigSpec
+        """
+        return self._agent
+
+    @agent.setter
+    def agent(self, agent):
+        """Sets the agent of this V1alpha1KServeConfigSpec.
+
+        Agent container configuration  # noqa: E501
+
+        :param agent: The agent of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1AgentConfigSpec
+        """
+
+        self._agent = agent
+
+    @property
+    def batcher(self):
+        """Gets the batcher of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Batcher container configuration  # noqa: E501
+
+        :return: The batcher of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1ContainerConfigSpec
+        """
+        return self._batcher
+
+    @batcher.setter
+    def batcher(self, batcher):
+        """Sets the batcher of this V1alpha1KServeConfigSpec.
+
+        Batcher container configuration  # noqa: E501
+
+        :param batcher: The batcher of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1ContainerConfigSpec
+        """
+
+        self._batcher = batcher
+
+    @property
+    def credentials(self):
+        """Gets the credentials of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Storage credentials configuration  # noqa: E501
+
+        :return: The credentials of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1CredentialsConfigSpec
+        """
+        return self._credentials
+
+    @credentials.setter
+    def credentials(self, credentials):
+        """Sets the credentials of this V1alpha1KServeConfigSpec.
+
+        Storage credentials configuration  # noqa: E501
+
+        :param credentials: The credentials of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1CredentialsConfigSpec
+        """
+
+        self._credentials = credentials
+
+    @property
+    def deploy(self):
+        """Gets the deploy of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Deployment configuration  # noqa: E501
+
+        :return: The deploy of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1DeployConfigSpec
+        """
+        return self._deploy
+
+    @deploy.setter
+    def deploy(self, deploy):
+        """Sets the deploy of this V1alpha1KServeConfigSpec.
+
+        Deployment configuration  # noqa: E501
+
+        :param deploy: The deploy of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1DeployConfigSpec
+        """
+
+        self._deploy = deploy
+
+    @property
+    def explainers(self):
+        """Gets the explainers of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Explainer images keyed by explainer type  # noqa: E501
+
+        :return: The explainers of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: dict(str, V1alpha1ExplainerConfigSpec)
+        """
+        return self._explainers
+
+    @explainers.setter
+    def explainers(self, explainers):
+        """Sets the explainers of this V1alpha1KServeConfigSpec.
+
+        Explainer images keyed by explainer type  # noqa: E501
+
+        :param explainers: The explainers of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: dict(str, V1alpha1ExplainerConfigSpec)
+        """
+
+        self._explainers = explainers
+
+    @property
+    def image_registry(self):
+        """Gets the image_registry of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Internal mirrors of the public image registries  # noqa: E501
+
+        :return: The image_registry of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1ImageRegistryConfigSpec
+        """
+        return self._image_registry
+
+    @image_registry.setter
+    def image_registry(self, image_registry):
+        """Sets the image_registry of this V1alpha1KServeConfigSpec.
+
+        Internal mirrors of the public image registries  # noqa: E501
+
+        :param image_registry: The image_registry of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1ImageRegistryConfigSpec
+        """
+
+        self._image_registry = image_registry
+
+    @property
+    def ingress(self):
+        """Gets the ingress of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Ingress configuration  # noqa: E501
+
+        :return: The ingress of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1IngressConfigSpec
+        """
+        return self._ingress
+
+    @ingress.setter
+    def ingress(self, ingress):
+        """Sets the ingress of this V1alpha1KServeConfigSpec.
+
+        Ingress configuration  # noqa: E501
+
+        :param ingress: The ingress of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1IngressConfigSpec
+        """
+
+        self._ingress = ingress
+
+    @property
+    def log_routing(self):
+        """Gets the log_routing of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Log routing metadata of the pods of the InferenceServices  # noqa: E501
+
+        :return: The log_routing of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1LogRoutingConfigSpec
+        """
+        return self._log_routing
+
+    @log_routing.setter
+    def log_routing(self, log_routing):
+        """Sets the log_routing of this V1alpha1KServeConfigSpec.
+
+        Log routing metadata of the pods of the InferenceServices  # noqa: E501
+
+        :param log_routing: The log_routing of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1LogRoutingConfigSpec
+        """
+
+        self._log_routing = log_routing
+
+    @property
+    def logger(self):
+        """Gets the logger of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Logger container configuration  # noqa: E501
+
+        :return: The logger of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1LoggerConfigSpec
+        """
+        return self._logger
+
+    @logger.setter
+    def logger(self, logger):
+        """Sets the logger of this V1alpha1KServeConfigSpec.
+
+        Logger container configuration  # noqa: E501
+
+        :param logger: The logger of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1LoggerConfigSpec
+        """
+
+        self._logger = logger
+
+    @property
+    def metrics_aggregator(self):
+        """Gets the metrics_aggregator of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Metrics aggregation configuration  # noqa: E501
+
+        :return: The metrics_aggregator of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1MetricsAggregatorConfigSpec
+        """
+        return self._metrics_aggregator
+
+    @metrics_aggregator.setter
+    def metrics_aggregator(self, metrics_aggregator):
+        """Sets the metrics_aggregator of this V1alpha1KServeConfigSpec.
+
+        Metrics aggregation configuration  # noqa: E501
+
+        :param metrics_aggregator: The metrics_aggregator of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1MetricsAggregatorConfigSpec
+        """
+
+        self._metrics_aggregator = metrics_aggregator
+
+    @property
+    def router(self):
+        """Gets the router of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        InferenceGraph router configuration  # noqa: E501
+
+        :return: The router of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1RouterConfigSpec
+        """
+        return self._router
+
+    @router.setter
+    def router(self, router):
+        """Sets the router of this V1alpha1KServeConfigSpec.
+
+        InferenceGraph router configuration  # noqa: E501
+
+        :param router: The router of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1RouterConfigSpec
+        """
+
+        self._router = router
+
+    @property
+    def storage_initializer(self):
+        """Gets the storage_initializer of this V1alpha1KServeConfigSpec.  # noqa: E501
+
+        Storage initializer container configuration  # noqa: E501
+
+        :return: The storage_initializer of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :rtype: V1alpha1StorageInitializerConfigSpec
+        """
+        return self._storage_initializer
+
+    @storage_initializer.setter
+    def storage_initializer(self, storage_initializer):
+        """Sets the storage_initializer of this V1alpha1KServeConfigSpec.
+
+        Storage initializer container configuration  # noqa: E501
+
+        :param storage_initializer: The storage_initializer of this V1alpha1KServeConfigSpec.  # noqa: E501
+        :type: V1alpha1StorageInitializerConfigSpec
+        """
+
+        self._storage_initializer = storage_initializer
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1KServeConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1KServeConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,165 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1LocalModelCacheSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'node_selector': 'dict(str, str)',
+        'source_model_uri': 'str'
+    }
+
+    attribute_map = {
+        'node_selector': 'nodeSelector',
+        'source_model_uri': 'sourceModelUri'
+    }
+
+    def __init__(self, node_selector=None, source_model_uri=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1LocalModelCacheSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._node_selector = None
+        self._source_model_uri = None
+        self.discriminator = None
+
+        if node_selector is not None:
+            self.node_selector = node_selector
+        self.source_model_uri = source_model_uri
+
+    @property
+    def node_selector(self):
+        """Gets the node_selector of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+
+        Labels of the nodes caching the model, all the nodes running the local model agent cache it when empty. The predictor pods served from the cache are scheduled on the selected nodes.  # noqa: E501
+
+        :return: The node_selector of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._node_selector
+
+    @node_selector.setter
+    def node_selector(self, node_selector):
+        """Sets the node_selector of this V1alpha1LocalModelCacheSpec.
+
+        Labels of the nodes caching the model, all the nodes running the local model agent cache it when empty. The predictor pods served from the cache are scheduled on the selected nodes.  # noqa: E501
+
+        :param node_selector: The node_selector of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._node_selector = node_selector
+
+    @property
+    def source_model_uri(self):
+        """Gets the source_model_uri of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+
+        Storage uri of the model, the predictors are served from the cache when their storage uri is the same  # noqa: E501
+
+        :return: The source_model_uri of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._source_model_uri
+
+    @source_model_uri.setter
+    def source_model_uri(self, source_model_uri):
+        """Sets the source_model_uri of this V1alpha1LocalModelCacheSpec.
+
+        Storage uri of the model, the predictors are served from the cache when their storage uri is the same  # noqa: E501
+
+        :param source_model_uri: The source_model_uri of this V1alpha1LocalModelCacheSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and source_model_uri is None:  # noqa: E501
+            raise ValueError("Invalid value for `source_model_uri`, must not be `None`")  # noqa: E501
+
+        self._source_model_uri = source_model_uri
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1LocalModelCacheSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1LocalModelCacheSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
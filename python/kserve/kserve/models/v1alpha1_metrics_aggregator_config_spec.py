@@ -0,0 +1,188 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1MetricsAggregatorConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'enable_metric_aggregation': 'str',
+        'enable_prometheus_scraping': 'str',
+        'pod_monitor': 'V1alpha1PodMonitorConfigSpec'
+    }
+
+    attribute_map = {
+        'enable_metric_aggregation': 'enableMetricAggregation',
+        'enable_prometheus_scraping': 'enablePrometheusScraping',
+        'pod_monitor': 'podMonitor'
+    }
+
+    def __init__(self, enable_metric_aggregation=None, enable_prometheus_scraping=None, pod_monitor=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1MetricsAggregatorConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._enable_metric_aggregation = None
+        self._enable_prometheus_scraping = None
+        self._pod_monitor = None
+        self.discriminator = None
+
+        if enable_metric_aggregation is not None:
+            self.enable_metric_aggregation = enable_metric_aggregation
+        if enable_prometheus_scraping is not None:
+            self.enable_prometheus_scraping = enable_prometheus_scraping
+        if pod_monitor is not None:
+            self.pod_monitor = pod_monitor
+
+    @property
+    def enable_metric_aggregation(self):
+        """Gets the enable_metric_aggregation of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+
+
+        :return: The enable_metric_aggregation of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._enable_metric_aggregation
+
+    @enable_metric_aggregation.setter
+    def enable_metric_aggregation(self, enable_metric_aggregation):
+        """Sets the enable_metric_aggregation of this V1alpha1MetricsAggregatorConfigSpec.
+
+
+        :param enable_metric_aggregation: The enable_metric_aggregation of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._enable_metric_aggregation = enable_metric_aggregation
+
+    @property
+    def enable_prometheus_scraping(self):
+        """Gets the enable_prometheus_scraping of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+
+
+        :return: The enable_prometheus_scraping of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._enable_prometheus_scraping
+
+    @enable_prometheus_scraping.setter
+    def enable_prometheus_scraping(self, enable_prometheus_scraping):
+        """Sets the enable_prometheus_scraping of this V1alpha1MetricsAggregatorConfigSpec.
+
+
+        :param enable_prometheus_scraping: The enable_prometheus_scraping of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._enable_prometheus_scraping = enable_prometheus_scraping
+
+    @property
+    def pod_monitor(self):
+        """Gets the pod_monitor of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+
+        PodMonitors of the Prometheus operator scraping the agent and model server metrics of the InferenceServices  # noqa: E501
+
+        :return: The pod_monitor of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :rtype: V1alpha1PodMonitorConfigSpec
+        """
+        return self._pod_monitor
+
+    @pod_monitor.setter
+    def pod_monitor(self, pod_monitor):
+        """Sets the pod_monitor of this V1alpha1MetricsAggregatorConfigSpec.
+
+        PodMonitors of the Prometheus operator scraping the agent and model server metrics of the InferenceServices  # noqa: E501
+
+        :param pod_monitor: The pod_monitor of this V1alpha1MetricsAggregatorConfigSpec.  # noqa: E501
+        :type: V1alpha1PodMonitorConfigSpec
+        """
+
+        self._pod_monitor = pod_monitor
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1MetricsAggregatorConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1MetricsAggregatorConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,220 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1PodMonitorConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'enabled': 'bool',
+        'interval': 'str',
+        'labels': 'dict(str, str)',
+        'scope': 'str'
+    }
+
+    attribute_map = {
+        'enabled': 'enabled',
+        'interval': 'interval',
+        'labels': 'labels',
+        'scope': 'scope'
+    }
+
+    def __init__(self, enabled=None, interval=None, labels=None, scope=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1PodMonitorConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._enabled = None
+        self._interval = None
+        self._labels = None
+        self._scope = None
+        self.discriminator = None
+
+        if enabled is not None:
+            self.enabled = enabled
+        if interval is not None:
+            self.interval = interval
+        if labels is not None:
+            self.labels = labels
+        if scope is not None:
+            self.scope = scope
+
+    @property
+    def enabled(self):
+        """Gets the enabled of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+
+        Creates the PodMonitors of the InferenceServices  # noqa: E501
+
+        :return: The enabled of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._enabled
+
+    @enabled.setter
+    def enabled(self, enabled):
+        """Sets the enabled of this V1alpha1PodMonitorConfigSpec.
+
+        Creates the PodMonitors of the InferenceServices  # noqa: E501
+
+        :param enabled: The enabled of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._enabled = enabled
+
+    @property
+    def interval(self):
+        """Gets the interval of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+
+        Scrape interval of the metrics, e.g. 30s, the scrape interval of the Prometheus when empty  # noqa: E501
+
+        :return: The interval of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._interval
+
+    @interval.setter
+    def interval(self, interval):
+        """Sets the interval of this V1alpha1PodMonitorConfigSpec.
+
+        Scrape interval of the metrics, e.g. 30s, the scrape interval of the Prometheus when empty  # noqa: E501
+
+        :param interval: The interval of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._interval = interval
+
+    @property
+    def labels(self):
+        """Gets the labels of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+
+        Labels of the PodMonitors, matched by the podMonitorSelector of the Prometheus  # noqa: E501
+
+        :return: The labels of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._labels
+
+    @labels.setter
+    def labels(self, labels):
+        """Sets the labels of this V1alpha1PodMonitorConfigSpec.
+
+        Labels of the PodMonitors, matched by the podMonitorSelector of the Prometheus  # noqa: E501
+
+        :param labels: The labels of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._labels = labels
+
+    @property
+    def scope(self):
+        """Gets the scope of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+
+        InferenceService creates a PodMonitor per InferenceService, Namespace a PodMonitor per namespace selecting the pods of all its InferenceServices, defaults to InferenceService  # noqa: E501
+
+        :return: The scope of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._scope
+
+    @scope.setter
+    def scope(self, scope):
+        """Sets the scope of this V1alpha1PodMonitorConfigSpec.
+
+        InferenceService creates a PodMonitor per InferenceService, Namespace a PodMonitor per namespace selecting the pods of all its InferenceServices, defaults to InferenceService  # noqa: E501
+
+        :param scope: The scope of this V1alpha1PodMonitorConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._scope = scope
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1PodMonitorConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1PodMonitorConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,222 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1PresignBrokerConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'audience': 'str',
+        'client_cert_secret_name': 'str',
+        'uri_schemes': 'list[str]',
+        'url': 'str'
+    }
+
+    attribute_map = {
+        'audience': 'audience',
+        'client_cert_secret_name': 'clientCertSecretName',
+        'uri_schemes': 'uriSchemes',
+        'url': 'url'
+    }
+
+    def __init__(self, audience=None, client_cert_secret_name=None, uri_schemes=None, url='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1PresignBrokerConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._audience = None
+        self._client_cert_secret_name = None
+        self._uri_schemes = None
+        self._url = None
+        self.discriminator = None
+
+        if audience is not None:
+            self.audience = audience
+        if client_cert_secret_name is not None:
+            self.client_cert_secret_name = client_cert_secret_name
+        if uri_schemes is not None:
+            self.uri_schemes = uri_schemes
+        self.url = url
+
+    @property
+    def audience(self):
+        """Gets the audience of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+
+        Audience of the service account token identifying the InferenceService to the broker, defaults to kserve-presign-broker  # noqa: E501
+
+        :return: The audience of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._audience
+
+    @audience.setter
+    def audience(self, audience):
+        """Sets the audience of this V1alpha1PresignBrokerConfigSpec.
+
+        Audience of the service account token identifying the InferenceService to the broker, defaults to kserve-presign-broker  # noqa: E501
+
+        :param audience: The audience of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._audience = audience
+
+    @property
+    def client_cert_secret_name(self):
+        """Gets the client_cert_secret_name of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+
+        Name of the secret of the InferenceService namespace holding the tls.crt, tls.key and ca.crt of the client certificate  # noqa: E501
+
+        :return: The client_cert_secret_name of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._client_cert_secret_name
+
+    @client_cert_secret_name.setter
+    def client_cert_secret_name(self, client_cert_secret_name):
+        """Sets the client_cert_secret_name of this V1alpha1PresignBrokerConfigSpec.
+
+        Name of the secret of the InferenceService namespace holding the tls.crt, tls.key and ca.crt of the client certificate  # noqa: E501
+
+        :param client_cert_secret_name: The client_cert_secret_name of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._client_cert_secret_name = client_cert_secret_name
+
+    @property
+    def uri_schemes(self):
+        """Gets the uri_schemes of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+
+        Storage uri prefixes fetched through the broker, defaults to [\"s3://\", \"gs://\"]  # noqa: E501
+
+        :return: The uri_schemes of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._uri_schemes
+
+    @uri_schemes.setter
+    def uri_schemes(self, uri_schemes):
+        """Sets the uri_schemes of this V1alpha1PresignBrokerConfigSpec.
+
+        Storage uri prefixes fetched through the broker, defaults to [\"s3://\", \"gs://\"]  # noqa: E501
+
+        :param uri_schemes: The uri_schemes of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._uri_schemes = uri_schemes
+
+    @property
+    def url(self):
+        """Gets the url of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+
+        URL of the presign endpoint of the broker, must be an https url  # noqa: E501
+
+        :return: The url of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._url
+
+    @url.setter
+    def url(self, url):
+        """Sets the url of this V1alpha1PresignBrokerConfigSpec.
+
+        URL of the presign endpoint of the broker, must be an https url  # noqa: E501
+
+        :param url: The url of this V1alpha1PresignBrokerConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and url is None:  # noqa: E501
+            raise ValueError("Invalid value for `url`, must not be `None`")  # noqa: E501
+
+
+        self._url = url
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1PresignBrokerConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1PresignBrokerConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
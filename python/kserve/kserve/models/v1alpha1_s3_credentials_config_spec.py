@@ -0,0 +1,370 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1S3CredentialsConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        's3_access_key_id_name': 'str',
+        's3_ca_bundle': 'str',
+        's3_ca_bundle_config_map': 'str',
+        's3_endpoint': 'str',
+        's3_region': 'str',
+        's3_secret_access_key_name': 'str',
+        's3_use_anonymous_credential': 'str',
+        's3_use_https': 'str',
+        's3_use_virtual_bucket': 'str',
+        's3_verify_ssl': 'str'
+    }
+
+    attribute_map = {
+        's3_access_key_id_name': 's3AccessKeyIDName',
+        's3_ca_bundle': 's3CABundle',
+        's3_ca_bundle_config_map': 's3CABundleConfigMap',
+        's3_endpoint': 's3Endpoint',
+        's3_region': 's3Region',
+        's3_secret_access_key_name': 's3SecretAccessKeyName',
+        's3_use_anonymous_credential': 's3UseAnonymousCredential',
+        's3_use_https': 's3UseHttps',
+        's3_use_virtual_bucket': 's3UseVirtualBucket',
+        's3_verify_ssl': 's3VerifySSL'
+    }
+
+    def __init__(self, s3_access_key_id_name=None, s3_ca_bundle=None, s3_ca_bundle_config_map=None, s3_endpoint=None, s3_region=None, s3_secret_access_key_name=None, s3_use_anonymous_credential=None, s3_use_https=None, s3_use_virtual_bucket=None, s3_verify_ssl=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1S3CredentialsConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._s3_access_key_id_name = None
+        self._s3_ca_bundle = None
+        self._s3_ca_bundle_config_map = None
+        self._s3_endpoint = None
+        self._s3_region = None
+        self._s3_secret_access_key_name = None
+        self._s3_use_anonymous_credential = None
+        self._s3_use_https = None
+        self._s3_use_virtual_bucket = None
+        self._s3_verify_ssl = None
+        self.discriminator = None
+
+        if s3_access_key_id_name is not None:
+            self.s3_access_key_id_name = s3_access_key_id_name
+        if s3_ca_bundle is not None:
+            self.s3_ca_bundle = s3_ca_bundle
+        if s3_ca_bundle_config_map is not None:
+            self.s3_ca_bundle_config_map = s3_ca_bundle_config_map
+        if s3_endpoint is not None:
+            self.s3_endpoint = s3_endpoint
+        if s3_region is not None:
+            self.s3_region = s3_region
+        if s3_secret_access_key_name is not None:
+            self.s3_secret_access_key_name = s3_secret_access_key_name
+        if s3_use_anonymous_credential is not None:
+            self.s3_use_anonymous_credential = s3_use_anonymous_credential
+        if s3_use_https is not None:
+            self.s3_use_https = s3_use_https
+        if s3_use_virtual_bucket is not None:
+            self.s3_use_virtual_bucket = s3_use_virtual_bucket
+        if s3_verify_ssl is not None:
+            self.s3_verify_ssl = s3_verify_ssl
+
+    @property
+    def s3_access_key_id_name(self):
+        """Gets the s3_access_key_id_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_access_key_id_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_access_key_id_name
+
+    @s3_access_key_id_name.setter
+    def s3_access_key_id_name(self, s3_access_key_id_name):
+        """Sets the s3_access_key_id_name of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_access_key_id_name: The s3_access_key_id_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_access_key_id_name = s3_access_key_id_name
+
+    @property
+    def s3_ca_bundle(self):
+        """Gets the s3_ca_bundle of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_ca_bundle of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_ca_bundle
+
+    @s3_ca_bundle.setter
+    def s3_ca_bundle(self, s3_ca_bundle):
+        """Sets the s3_ca_bundle of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_ca_bundle: The s3_ca_bundle of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_ca_bundle = s3_ca_bundle
+
+    @property
+    def s3_ca_bundle_config_map(self):
+        """Gets the s3_ca_bundle_config_map of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+        Name of the ConfigMap of the InferenceService namespace holding the CA bundle of the S3 endpoint under its cabundle.crt key, mounted in the storage initializer and the agent  # noqa: E501
+
+        :return: The s3_ca_bundle_config_map of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_ca_bundle_config_map
+
+    @s3_ca_bundle_config_map.setter
+    def s3_ca_bundle_config_map(self, s3_ca_bundle_config_map):
+        """Sets the s3_ca_bundle_config_map of this V1alpha1S3CredentialsConfigSpec.
+
+        Name of the ConfigMap of the InferenceService namespace holding the CA bundle of the S3 endpoint under its cabundle.crt key, mounted in the storage initializer and the agent  # noqa: E501
+
+        :param s3_ca_bundle_config_map: The s3_ca_bundle_config_map of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_ca_bundle_config_map = s3_ca_bundle_config_map
+
+    @property
+    def s3_endpoint(self):
+        """Gets the s3_endpoint of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_endpoint of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_endpoint
+
+    @s3_endpoint.setter
+    def s3_endpoint(self, s3_endpoint):
+        """Sets the s3_endpoint of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_endpoint: The s3_endpoint of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_endpoint = s3_endpoint
+
+    @property
+    def s3_region(self):
+        """Gets the s3_region of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_region of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_region
+
+    @s3_region.setter
+    def s3_region(self, s3_region):
+        """Sets the s3_region of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_region: The s3_region of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_region = s3_region
+
+    @property
+    def s3_secret_access_key_name(self):
+        """Gets the s3_secret_access_key_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_secret_access_key_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_secret_access_key_name
+
+    @s3_secret_access_key_name.setter
+    def s3_secret_access_key_name(self, s3_secret_access_key_name):
+        """Sets the s3_secret_access_key_name of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_secret_access_key_name: The s3_secret_access_key_name of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_secret_access_key_name = s3_secret_access_key_name
+
+    @property
+    def s3_use_anonymous_credential(self):
+        """Gets the s3_use_anonymous_credential of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_use_anonymous_credential of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_use_anonymous_credential
+
+    @s3_use_anonymous_credential.setter
+    def s3_use_anonymous_credential(self, s3_use_anonymous_credential):
+        """Sets the s3_use_anonymous_credential of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_use_anonymous_credential: The s3_use_anonymous_credential of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_use_anonymous_credential = s3_use_anonymous_credential
+
+    @property
+    def s3_use_https(self):
+        """Gets the s3_use_https of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_use_https of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_use_https
+
+    @s3_use_https.setter
+    def s3_use_https(self, s3_use_https):
+        """Sets the s3_use_https of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_use_https: The s3_use_https of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_use_https = s3_use_https
+
+    @property
+    def s3_use_virtual_bucket(self):
+        """Gets the s3_use_virtual_bucket of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_use_virtual_bucket of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_use_virtual_bucket
+
+    @s3_use_virtual_bucket.setter
+    def s3_use_virtual_bucket(self, s3_use_virtual_bucket):
+        """Sets the s3_use_virtual_bucket of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_use_virtual_bucket: The s3_use_virtual_bucket of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_use_virtual_bucket = s3_use_virtual_bucket
+
+    @property
+    def s3_verify_ssl(self):
+        """Gets the s3_verify_ssl of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+
+
+        :return: The s3_verify_ssl of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._s3_verify_ssl
+
+    @s3_verify_ssl.setter
+    def s3_verify_ssl(self, s3_verify_ssl):
+        """Sets the s3_verify_ssl of this V1alpha1S3CredentialsConfigSpec.
+
+
+        :param s3_verify_ssl: The s3_verify_ssl of this V1alpha1S3CredentialsConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._s3_verify_ssl = s3_verify_ssl
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1S3CredentialsConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1S3CredentialsConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
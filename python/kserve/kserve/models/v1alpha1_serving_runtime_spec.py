@@ -48,6 +48,7 @@ class V1alpha1ServingRuntimeSpec(object):
     """
     openapi_types = {
         'affinity': 'V1Affinity',
+        'allowed_namespaces': 'list[str]',
         'annotations': 'dict(str, str)',
         'built_in_adapter': 'V1alpha1BuiltInAdapter',
         'containers': 'list[V1Container]',
@@ -58,10 +59,13 @@ class V1alpha1ServingRuntimeSpec(object):
         'image_pull_secrets': 'list[V1LocalObjectReference]',
         'labels': 'dict(str, str)',
         'multi_model': 'bool',
+        'namespace_selector': 'V1LabelSelector',
         'node_selector': 'dict(str, str)',
+        'priority': 'int',
         'protocol_versions': 'list[str]',
         'replicas': 'int',
         'storage_helper': 'V1alpha1StorageHelper',
+        'supported_architectures': 'list[str]',
         'supported_model_formats': 'list[V1alpha1SupportedModelFormat]',
         'tolerations': 'list[V1Toleration]',
         'volumes': 'list[V1Volume]'
@@ -69,6 +73,7 @@ class V1alpha1ServingRuntimeSpec(object):
 
     attribute_map = {
         'affinity': 'affinity',
+        'allowed_namespaces': 'allowedNamespaces',
         'annotations': 'annotations',
         'built_in_adapter': 'builtInAdapter',
         'containers': 'containers',
@@ -79,22 +84,26 @@ class V1alpha1ServingRuntimeSpec(object):
         'image_pull_secrets': 'imagePullSecrets',
         'labels': 'labels',
         'multi_model': 'multiModel',
+        'namespace_selector': 'namespaceSelector',
         'node_selector': 'nodeSelector',
+        'priority': 'priority',
         'protocol_versions': 'protocolVersions',
         'replicas': 'replicas',
         'storage_helper': 'storageHelper',
+        'supported_architectures': 'supportedArchitectures',
         'supported_model_formats': 'supportedModelFormats',
         'tolerations': 'tolerations',
         'volumes': 'volumes'
     }
 
-    def __init__(self, affinity=None, annotations=None, built_in_adapter=None, containers=None, disabled=None, grpc_data_endpoint=None, grpc_endpoint=None, http_data_endpoint=None, image_pull_secrets=None, labels=None, multi_model=None, node_selector=None, protocol_versions=None, replicas=None, storage_helper=None, supported_model_formats=None, tolerations=None, volumes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, affinity=None, allowed_namespaces=None, annotations=None, built_in_adapter=None, containers=None, disabled=None, grpc_data_endpoint=None, grpc_endpoint=None, http_data_endpoint=None, image_pull_secrets=None, labels=None, multi_model=None, namespace_selector=None, node_selector=None, priority=None, protocol_versions=None, replicas=None, storage_helper=None, supported_architectures=None, supported_model_formats=None, tolerations=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1ServingRuntimeSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._affinity = None
+        self._allowed_namespaces = None
         self._annotations = None
         self._built_in_adapter = None
         self._containers = None
@@ -105,10 +114,13 @@ class V1alpha1ServingRuntimeSpec(object):
         self._image_pull_secrets = None
         self._labels = None
         self._multi_model = None
+        self._namespace_selector = None
         self._node_selector = None
+        self._priority = None
         self._protocol_versions = None
         self._replicas = None
         self._storage_helper = None
+        self._supported_architectures = None
         self._supported_model_formats = None
         self._tolerations = None
         self._volumes = None
@@ -116,6 +128,8 @@ class V1alpha1ServingRuntimeSpec(object):
 
         if affinity is not None:
             self.affinity = affinity
+        if allowed_namespaces is not None:
+            self.allowed_namespaces = allowed_namespaces
         if annotations is not None:
             self.annotations = annotations
         if built_in_adapter is not None:
@@ -135,14 +149,20 @@ class V1alpha1ServingRuntimeSpec(object):
             self.labels = labels
         if multi_model is not None:
             self.multi_model = multi_model
+        if namespace_selector is not None:
+            self.namespace_selector = namespace_selector
         if node_selector is not None:
             self.node_selector = node_selector
+        if priority is not None:
+            self.priority = priority
         if protocol_versions is not None:
             self.protocol_versions = protocol_versions
         if replicas is not None:
             self.replicas = replicas
         if storage_helper is not None:
             self.storage_helper = storage_helper
+        if supported_architectures is not None:
+            self.supported_architectures = supported_architectures
         if supported_model_formats is not None:
             self.supported_model_formats = supported_model_formats
         if tolerations is not None:
@@ -171,6 +191,29 @@ class V1alpha1ServingRuntimeSpec(object):
 
         self._affinity = affinity
 
+    @property
+    def allowed_namespaces(self):
+        """Gets the allowed_namespaces of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+
+        Namespaces the InferenceServices can use this runtime in, only applies to ClusterServingRuntimes. The runtime is available in all the namespaces if omitted.  # noqa: E501
+
+        :return: The allowed_namespaces of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allowed_namespaces
+
+    @allowed_namespaces.setter
+    def allowed_namespaces(self, allowed_namespaces):
+        """Sets the allowed_namespaces of this V1alpha1ServingRuntimeSpec.
+
+        Namespaces the InferenceServices can use this runtime in, only applies to ClusterServingRuntimes. The runtime is available in all the namespaces if omitted.  # noqa: E501
+
+        :param allowed_namespaces: The allowed_namespaces of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allowed_namespaces = allowed_namespaces
+
     @property
     def annotations(self):
         """Gets the annotations of this V1alpha1ServingRuntimeSpec.  # noqa: E501
@@ -401,6 +444,29 @@ class V1alpha1ServingRuntimeSpec(object):
 
         self._multi_model = multi_model
 
+    @property
+    def namespace_selector(self):
+        """Gets the namespace_selector of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+
+        Label selector of the namespaces the InferenceServices can use this runtime in, only applies to ClusterServingRuntimes. A namespace is allowed when it is one of the allowedNamespaces or matches the selector.  # noqa: E501
+
+        :return: The namespace_selector of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :rtype: V1LabelSelector
+        """
+        return self._namespace_selector
+
+    @namespace_selector.setter
+    def namespace_selector(self, namespace_selector):
+        """Sets the namespace_selector of this V1alpha1ServingRuntimeSpec.
+
+        Label selector of the namespaces the InferenceServices can use this runtime in, only applies to ClusterServingRuntimes. A namespace is allowed when it is one of the allowedNamespaces or matches the selector.  # noqa: E501
+
+        :param namespace_selector: The namespace_selector of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :type: V1LabelSelector
+        """
+
+        self._namespace_selector = namespace_selector
+
     @property
     def node_selector(self):
         """Gets the node_selector of this V1alpha1ServingRuntimeSpec.  # noqa: E501
@@ -424,6 +490,29 @@ class V1alpha1ServingRuntimeSpec(object):
 
         self._node_selector = node_selector
 
+    @property
+    def priority(self):
+        """Gets the priority of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+
+        Priority of the runtime among the runtimes supporting the model format of an InferenceService which does not select its runtime, the runtime with the highest priority is selected. Defaults to 0.  # noqa: E501
+
+        :return: The priority of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._priority
+
+    @priority.setter
+    def priority(self, priority):
+        """Sets the priority of this V1alpha1ServingRuntimeSpec.
+
+        Priority of the runtime among the runtimes supporting the model format of an InferenceService which does not select its runtime, the runtime with the highest priority is selected. Defaults to 0.  # noqa: E501
+
+        :param priority: The priority of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._priority = priority
+
     @property
     def protocol_versions(self):
         """Gets the protocol_versions of this V1alpha1ServingRuntimeSpec.  # noqa: E501
@@ -491,6 +580,29 @@ class V1alpha1ServingRuntimeSpec(object):
 
         self._storage_helper = storage_helper
 
+    @property
+    def supported_architectures(self):
+        """Gets the supported_architectures of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+
+        Node architectures the runtime images are published for (i.e. amd64 or arm64), the predictor pods are scheduled on nodes with one of these architectures. All the architectures are supported if omitted.  # noqa: E501
+
+        :return: The supported_architectures of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._supported_architectures
+
+    @supported_architectures.setter
+    def supported_architectures(self, supported_architectures):
+        """Sets the supported_architectures of this V1alpha1ServingRuntimeSpec.
+
+        Node architectures the runtime images are published for (i.e. amd64 or arm64), the predictor pods are scheduled on nodes with one of these architectures. All the architectures are supported if omitted.  # noqa: E501
+
+        :param supported_architectures: The supported_architectures of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._supported_architectures = supported_architectures
+
     @property
     def supported_model_formats(self):
         """Gets the supported_model_formats of this V1alpha1ServingRuntimeSpec.  # noqa: E501
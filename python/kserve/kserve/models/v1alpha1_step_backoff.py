@@ -0,0 +1,192 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1StepBackoff(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'initial_delay_ms': 'int',
+        'max_delay_ms': 'int',
+        'multiplier': 'int'
+    }
+
+    attribute_map = {
+        'initial_delay_ms': 'initialDelayMs',
+        'max_delay_ms': 'maxDelayMs',
+        'multiplier': 'multiplier'
+    }
+
+    def __init__(self, initial_delay_ms=None, max_delay_ms=None, multiplier=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1StepBackoff - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._initial_delay_ms = None
+        self._max_delay_ms = None
+        self._multiplier = None
+        self.discriminator = None
+
+        if initial_delay_ms is not None:
+            self.initial_delay_ms = initial_delay_ms
+        if max_delay_ms is not None:
+            self.max_delay_ms = max_delay_ms
+        if multiplier is not None:
+            self.multiplier = multiplier
+
+    @property
+    def initial_delay_ms(self):
+        """Gets the initial_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+
+        Delay before the first retry in milliseconds, defaults to 100  # noqa: E501
+
+        :return: The initial_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+        :rtype: int
+        """
+        return self._initial_delay_ms
+
+    @initial_delay_ms.setter
+    def initial_delay_ms(self, initial_delay_ms):
+        """Sets the initial_delay_ms of this V1alpha1StepBackoff.
+
+        Delay before the first retry in milliseconds, defaults to 100  # noqa: E501
+
+        :param initial_delay_ms: The initial_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+        :type: int
+        """
+
+        self._initial_delay_ms = initial_delay_ms
+
+    @property
+    def max_delay_ms(self):
+        """Gets the max_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+
+        Maximum delay between two retries in milliseconds, defaults to 1000  # noqa: E501
+
+        :return: The max_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_delay_ms
+
+    @max_delay_ms.setter
+    def max_delay_ms(self, max_delay_ms):
+        """Sets the max_delay_ms of this V1alpha1StepBackoff.
+
+        Maximum delay between two retries in milliseconds, defaults to 1000  # noqa: E501
+
+        :param max_delay_ms: The max_delay_ms of this V1alpha1StepBackoff.  # noqa: E501
+        :type: int
+        """
+
+        self._max_delay_ms = max_delay_ms
+
+    @property
+    def multiplier(self):
+        """Gets the multiplier of this V1alpha1StepBackoff.  # noqa: E501
+
+        Factor the delay is multiplied by after every retry, defaults to 2  # noqa: E501
+
+        :return: The multiplier of this V1alpha1StepBackoff.  # noqa: E501
+        :rtype: int
+        """
+        return self._multiplier
+
+    @multiplier.setter
+    def multiplier(self, multiplier):
+        """Sets the multiplier of this V1alpha1StepBackoff.
+
+        Factor the delay is multiplied by after every retry, defaults to 2  # noqa: E501
+
+        :param multiplier: The multiplier of this V1alpha1StepBackoff.  # noqa: E501
+        :type: int
+        """
+
+        self._multiplier = multiplier
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1StepBackoff):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1StepBackoff):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,351 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1StorageInitializerConfigSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'allowed_uri_schemes': 'list[str]',
+        'cpu_limit': 'str',
+        'cpu_request': 'str',
+        'download_workers': 'int',
+        'image': 'str',
+        'memory_limit': 'str',
+        'memory_request': 'str',
+        'presign_broker': 'V1alpha1PresignBrokerConfigSpec',
+        'storage_spec_secret_name': 'str'
+    }
+
+    attribute_map = {
+        'allowed_uri_schemes': 'allowedUriSchemes',
+        'cpu_limit': 'cpuLimit',
+        'cpu_request': 'cpuRequest',
+        'download_workers': 'downloadWorkers',
+        'image': 'image',
+        'memory_limit': 'memoryLimit',
+        'memory_request': 'memoryRequest',
+        'presign_broker': 'presignBroker',
+        'storage_spec_secret_name': 'storageSpecSecretName'
+    }
+
+    def __init__(self, allowed_uri_schemes=None, cpu_limit=None, cpu_request=None, download_workers=None, image=None, memory_limit=None, memory_request=None, presign_broker=None, storage_spec_secret_name=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1StorageInitializerConfigSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._allowed_uri_schemes = None
+        self._cpu_limit = None
+        self._cpu_request = None
+        self._download_workers = None
+        self._image = None
+        self._memory_limit = None
+        self._memory_request = None
+        self._presign_broker = None
+        self._storage_spec_secret_name = None
+        self.discriminator = None
+
+        if allowed_uri_schemes is not None:
+            self.allowed_uri_schemes = allowed_uri_schemes
+        if cpu_limit is not None:
+            self.cpu_limit = cpu_limit
+        if cpu_request is not None:
+            self.cpu_request = cpu_request
+        if download_workers is not None:
+            self.download_workers = download_workers
+        self.image = image
+        if memory_limit is not None:
+            self.memory_limit = memory_limit
+        if memory_request is not None:
+            self.memory_request = memory_request
+        if presign_broker is not None:
+            self.presign_broker = presign_broker
+        if storage_spec_secret_name is not None:
+            self.storage_spec_secret_name = storage_spec_secret_name
+
+    @property
+    def allowed_uri_schemes(self):
+        """Gets the allowed_uri_schemes of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+        Storage uri prefixes allowed for the inference services, all the schemes are allowed when empty  # noqa: E501
+
+        :return: The allowed_uri_schemes of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allowed_uri_schemes
+
+    @allowed_uri_schemes.setter
+    def allowed_uri_schemes(self, allowed_uri_schemes):
+        """Sets the allowed_uri_schemes of this V1alpha1StorageInitializerConfigSpec.
+
+        Storage uri prefixes allowed for the inference services, all the schemes are allowed when empty  # noqa: E501
+
+        :param allowed_uri_schemes: The allowed_uri_schemes of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allowed_uri_schemes = allowed_uri_schemes
+
+    @property
+    def cpu_limit(self):
+        """Gets the cpu_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+
+        :return: The cpu_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cpu_limit
+
+    @cpu_limit.setter
+    def cpu_limit(self, cpu_limit):
+        """Sets the cpu_limit of this V1alpha1StorageInitializerConfigSpec.
+
+
+        :param cpu_limit: The cpu_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cpu_limit = cpu_limit
+
+    @property
+    def cpu_request(self):
+        """Gets the cpu_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+
+        :return: The cpu_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cpu_request
+
+    @cpu_request.setter
+    def cpu_request(self, cpu_request):
+        """Sets the cpu_request of this V1alpha1StorageInitializerConfigSpec.
+
+
+        :param cpu_request: The cpu_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cpu_request = cpu_request
+
+    @property
+    def download_workers(self):
+        """Gets the download_workers of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+        Number of concurrent downloads of the large models from S3 and GCS  # noqa: E501
+
+        :return: The download_workers of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._download_workers
+
+    @download_workers.setter
+    def download_workers(self, download_workers):
+        """Sets the download_workers of this V1alpha1StorageInitializerConfigSpec.
+
+        Number of concurrent downloads of the large models from S3 and GCS  # noqa: E501
+
+        :param download_workers: The download_workers of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._download_workers = download_workers
+
+    @property
+    def image(self):
+        """Gets the image of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+
+        :return: The image of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1alpha1StorageInitializerConfigSpec.
+
+
+        :param image: The image of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and image is None:  # noqa: E501
+            raise ValueError("Invalid value for `image`, must not be `None`")  # noqa: E501
+
+        self._image = image
+
+    @property
+    def memory_limit(self):
+        """Gets the memory_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+
+        :return: The memory_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_limit
+
+    @memory_limit.setter
+    def memory_limit(self, memory_limit):
+        """Sets the memory_limit of this V1alpha1StorageInitializerConfigSpec.
+
+
+        :param memory_limit: The memory_limit of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_limit = memory_limit
+
+    @property
+    def memory_request(self):
+        """Gets the memory_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+
+        :return: The memory_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_request
+
+    @memory_request.setter
+    def memory_request(self, memory_request):
+        """Sets the memory_request of this V1alpha1StorageInitializerConfigSpec.
+
+
+        :param memory_request: The memory_request of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_request = memory_request
+
+    @property
+    def presign_broker(self):
+        """Gets the presign_broker of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+        Broker handing out the short lived presigned urls of the model files, instead of the storage credentials being mounted in every namespace, disabled when not set  # noqa: E501
+
+        :return: The presign_broker of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: V1alpha1PresignBrokerConfigSpec
+        """
+        return self._presign_broker
+
+    @presign_broker.setter
+    def presign_broker(self, presign_broker):
+        """Sets the presign_broker of this V1alpha1StorageInitializerConfigSpec.
+
+        Broker handing out the short lived presigned urls of the model files, instead of the storage credentials being mounted in every namespace, disabled when not set  # noqa: E501
+
+        :param presign_broker: The presign_broker of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: V1alpha1PresignBrokerConfigSpec
+        """
+
+        self._presign_broker = presign_broker
+
+    @property
+    def storage_spec_secret_name(self):
+        """Gets the storage_spec_secret_name of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+
+        Name of the secret holding the storage spec configuration  # noqa: E501
+
+        :return: The storage_spec_secret_name of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._storage_spec_secret_name
+
+    @storage_spec_secret_name.setter
+    def storage_spec_secret_name(self, storage_spec_secret_name):
+        """Sets the storage_spec_secret_name of this V1alpha1StorageInitializerConfigSpec.
+
+        Name of the secret holding the storage spec configuration  # noqa: E501
+
+        :param storage_spec_secret_name: The storage_spec_secret_name of this V1alpha1StorageInitializerConfigSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._storage_spec_secret_name = storage_spec_secret_name
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1StorageInitializerConfigSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1StorageInitializerConfigSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -48,29 +48,34 @@ class V1alpha1SupportedModelFormat(object):
     """
     openapi_types = {
         'auto_select': 'bool',
+        'framework_versions': 'str',
         'name': 'str',
         'version': 'str'
     }
 
     attribute_map = {
         'auto_select': 'autoSelect',
+        'framework_versions': 'frameworkVersions',
         'name': 'name',
         'version': 'version'
     }
 
-    def __init__(self, auto_select=None, name='', version=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, auto_select=None, framework_versions=None, name='', version=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1SupportedModelFormat - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._auto_select = None
+        self._framework_versions = None
         self._name = None
         self._version = None
         self.discriminator = None
 
         if auto_select is not None:
             self.auto_select = auto_select
+        if framework_versions is not None:
+            self.framework_versions = framework_versions
         if name is not None:
             self.name = name
         if version is not None:
@@ -99,6 +104,29 @@ class V1alpha1SupportedModelFormat(object):
 
         self._auto_select = auto_select
 
+    @property
+    def framework_versions(self):
+        """Gets the framework_versions of this V1alpha1SupportedModelFormat.  # noqa: E501
+
+        Comma separated constraints on the framework version the models were saved with, e.g. \">=1.3,<1.6\". Used in validating that the runtime can load a model declaring its framework version.  # noqa: E501
+
+        :return: The framework_versions of this V1alpha1SupportedModelFormat.  # noqa: E501
+        :rtype: str
+        """
+        return self._framework_versions
+
+    @framework_versions.setter
+    def framework_versions(self, framework_versions):
+        """Sets the framework_versions of this V1alpha1SupportedModelFormat.
+
+        Comma separated constraints on the framework version the models were saved with, e.g. \">=1.3,<1.6\". Used in validating that the runtime can load a model declaring its framework version.  # noqa: E501
+
+        :param framework_versions: The framework_versions of this V1alpha1SupportedModelFormat.  # noqa: E501
+        :type: str
+        """
+
+        self._framework_versions = framework_versions
+
     @property
     def name(self):
         """Gets the name of this V1alpha1SupportedModelFormat.  # noqa: E501
@@ -0,0 +1,247 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1TemplateParameter(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'allowed_values': 'list[str]',
+        'default': 'str',
+        'description': 'str',
+        'name': 'str',
+        'pattern': 'str'
+    }
+
+    attribute_map = {
+        'allowed_values': 'allowedValues',
+        'default': 'default',
+        'description': 'description',
+        'name': 'name',
+        'pattern': 'pattern'
+    }
+
+    def __init__(self, allowed_values=None, default=None, description=None, name=None, pattern=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1TemplateParameter - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._allowed_values = None
+        self._default = None
+        self._description = None
+        self._name = None
+        self._pattern = None
+        self.discriminator = None
+
+        if allowed_values is not None:
+            self.allowed_values = allowed_values
+        if default is not None:
+            self.default = default
+        if description is not None:
+            self.description = description
+        self.name = name
+        if pattern is not None:
+            self.pattern = pattern
+
+    @property
+    def allowed_values(self):
+        """Gets the allowed_values of this V1alpha1TemplateParameter.  # noqa: E501
+
+        Values accepted for the parameter, e.g. the size tiers offered by the platform, any value is accepted when empty  # noqa: E501
+
+        :return: The allowed_values of this V1alpha1TemplateParameter.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allowed_values
+
+    @allowed_values.setter
+    def allowed_values(self, allowed_values):
+        """Sets the allowed_values of this V1alpha1TemplateParameter.
+
+        Values accepted for the parameter, e.g. the size tiers offered by the platform, any value is accepted when empty  # noqa: E501
+
+        :param allowed_values: The allowed_values of this V1alpha1TemplateParameter.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allowed_values = allowed_values
+
+    @property
+    def default(self):
+        """Gets the default of this V1alpha1TemplateParameter.  # noqa: E501
+
+        Default value of the parameter, the parameter is required when it has no default  # noqa: E501
+
+        :return: The default of this V1alpha1TemplateParameter.  # noqa: E501
+        :rtype: str
+        """
+        return self._default
+
+    @default.setter
+    def default(self, default):
+        """Sets the default of this V1alpha1TemplateParameter.
+
+        Default value of the parameter, the parameter is required when it has no default  # noqa: E501
+
+        :param default: The default of this V1alpha1TemplateParameter.  # noqa: E501
+        :type: str
+        """
+
+        self._default = default
+
+    @property
+    def description(self):
+        """Gets the description of this V1alpha1TemplateParameter.  # noqa: E501
+
+
+        :return: The description of this V1alpha1TemplateParameter.  # noqa: E501
+        :rtype: str
+        """
+        return self._description
+
+    @description.setter
+    def description(self, description):
+        """Sets the description of this V1alpha1TemplateParameter.
+
+
+        :param description: The description of this V1alpha1TemplateParameter.  # noqa: E501
+        :type: str
+        """
+
+        self._description = description
+
+    @property
+    def name(self):
+        """Gets the name of this V1alpha1TemplateParameter.  # noqa: E501
+
+        Name of the parameter  # noqa: E501
+
+        :return: The name of this V1alpha1TemplateParameter.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1alpha1TemplateParameter.
+
+        Name of the parameter  # noqa: E501
+
+        :param name: The name of this V1alpha1TemplateParameter.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def pattern(self):
+        """Gets the pattern of this V1alpha1TemplateParameter.  # noqa: E501
+
+        Regular expression the value has to match, e.g. the storage uri prefixes of the platform  # noqa: E501
+
+        :return: The pattern of this V1alpha1TemplateParameter.  # noqa: E501
+        :rtype: str
+        """
+        return self._pattern
+
+    @pattern.setter
+    def pattern(self, pattern):
+        """Sets the pattern of this V1alpha1TemplateParameter.
+
+        Regular expression the value has to match, e.g. the storage uri prefixes of the platform  # noqa: E501
+
+        :param pattern: The pattern of this V1alpha1TemplateParameter.  # noqa: E501
+        :type: str
+        """
+
+        self._pattern = pattern
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1TemplateParameter):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1TemplateParameter):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -49,16 +49,20 @@ class V1beta1Batcher(object):
     openapi_types = {
         'max_batch_size': 'int',
         'max_latency': 'int',
+        'priority_header': 'str',
+        'target_p99_latency': 'int',
         'timeout': 'int'
     }
 
     attribute_map = {
         'max_batch_size': 'maxBatchSize',
         'max_latency': 'maxLatency',
+        'priority_header': 'priorityHeader',
+        'target_p99_latency': 'targetP99Latency',
         'timeout': 'timeout'
     }
 
-    def __init__(self, max_batch_size=None, max_latency=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, max_batch_size=None, max_latency=None, priority_header=None, target_p99_latency=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1Batcher - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -66,6 +70,8 @@ class V1beta1Batcher(object):
 
         self._max_batch_size = None
         self._max_latency = None
+        self._priority_header = None
+        self._target_p99_latency = None
         self._timeout = None
         self.discriminator = None
 
@@ -73,6 +79,10 @@ class V1beta1Batcher(object):
             self.max_batch_size = max_batch_size
         if max_latency is not None:
             self.max_latency = max_latency
+        if priority_header is not None:
+            self.priority_header = priority_header
+        if target_p99_latency is not None:
+            self.target_p99_latency = target_p99_latency
         if timeout is not None:
             self.timeout = timeout
 
@@ -122,6 +132,52 @@ class V1beta1Batcher(object):
 
         self._max_latency = max_latency
 
+    @property
+    def priority_header(self):
+        """Gets the priority_header of this V1beta1Batcher.  # noqa: E501
+
+        Specifies the request header sending the requests straight to the model server, without waiting for a batch, when its value is true, e.g. for the latency sensitive requests  # noqa: E501
+
+        :return: The priority_header of this V1beta1Batcher.  # noqa: E501
+        :rtype: str
+        """
+        return self._priority_header
+
+    @priority_header.setter
+    def priority_header(self, priority_header):
+        """Sets the priority_header of this V1beta1Batcher.
+
+        Specifies the request header sending the requests straight to the model server, without waiting for a batch, when its value is true, e.g. for the latency sensitive requests  # noqa: E501
+
+        :param priority_header: The priority_header of this V1beta1Batcher.  # noqa: E501
+        :type: str
+        """
+
+        self._priority_header = priority_header
+
+    @property
+    def target_p99_latency(self):
+        """Gets the target_p99_latency of this V1beta1Batcher.  # noqa: E501
+
+        Specifies the p99 latency in milliseconds of the model server the batch size is adapted to. The batch size is decreased while the p99 latency of the batches is above the target and increased again, up to maxBatchSize, once it is below. The batch size is fixed to maxBatchSize when empty.  # noqa: E501
+
+        :return: The target_p99_latency of this V1beta1Batcher.  # noqa: E501
+        :rtype: int
+        """
+        return self._target_p99_latency
+
+    @target_p99_latency.setter
+    def target_p99_latency(self, target_p99_latency):
+        """Sets the target_p99_latency of this V1beta1Batcher.
+
+        Specifies the p99 latency in milliseconds of the model server the batch size is adapted to. The batch size is decreased while the p99 latency of the batches is above the target and increased again, up to maxBatchSize, once it is below. The batch size is fixed to maxBatchSize when empty.  # noqa: E501
+
+        :param target_p99_latency: The target_p99_latency of this V1beta1Batcher.  # noqa: E501
+        :type: int
+        """
+
+        self._target_p99_latency = target_p99_latency
+
     @property
     def timeout(self):
         """Gets the timeout of this V1beta1Batcher.  # noqa: E501
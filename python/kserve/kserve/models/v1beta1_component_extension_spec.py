@@ -53,9 +53,13 @@ class V1beta1ComponentExtensionSpec(object):
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
         'min_replicas': 'int',
+        'mount_cloud_identity': 'bool',
+        'rate_limit': 'V1beta1RateLimit',
+        'retries': 'V1beta1RetryPolicy',
         'scale_metric': 'str',
         'scale_target': 'int',
-        'timeout': 'int'
+        'timeout': 'int',
+        'traffic_mirror': 'V1beta1TrafficMirror'
     }
 
     attribute_map = {
@@ -65,12 +69,16 @@ class V1beta1ComponentExtensionSpec(object):
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
         'min_replicas': 'minReplicas',
+        'mount_cloud_identity': 'mountCloudIdentity',
+        'rate_limit': 'rateLimit',
+        'retries': 'retries',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
-        'timeout': 'timeout'
+        'timeout': 'timeout',
+        'traffic_mirror': 'trafficMirror'
     }
 
-    def __init__(self, batcher=None, canary_traffic_percent=None, container_concurrency=None, logger=None, max_replicas=None, min_replicas=None, scale_metric=None, scale_target=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, batcher=None, canary_traffic_percent=None, container_concurrency=None, logger=None, max_replicas=None, min_replicas=None, mount_cloud_identity=None, rate_limit=None, retries=None, scale_metric=None, scale_target=None, timeout=None, traffic_mirror=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ComponentExtensionSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -82,9 +90,13 @@ class V1beta1ComponentExtensionSpec(object):
         self._logger = None
         self._max_replicas = None
         self._min_replicas = None
+        self._mount_cloud_identity = None
+        self._rate_limit = None
+        self._retries = None
         self._scale_metric = None
         self._scale_target = None
         self._timeout = None
+        self._traffic_mirror = None
         self.discriminator = None
 
         if batcher is not None:
@@ -99,12 +111,20 @@ class V1beta1ComponentExtensionSpec(object):
             self.max_replicas = max_replicas
         if min_replicas is not None:
             self.min_replicas = min_replicas
+        if mount_cloud_identity is not None:
+            self.mount_cloud_identity = mount_cloud_identity
+        if rate_limit is not None:
+            self.rate_limit = rate_limit
+        if retries is not None:
+            self.retries = retries
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
         if timeout is not None:
             self.timeout = timeout
+        if traffic_mirror is not None:
+            self.traffic_mirror = traffic_mirror
 
     @property
     def batcher(self):
@@ -240,11 +260,80 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._min_replicas = min_replicas
 
+    @property
+    def mount_cloud_identity(self):
+        """Gets the mount_cloud_identity of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :return: The mount_cloud_identity of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._mount_cloud_identity
+
+    @mount_cloud_identity.setter
+    def mount_cloud_identity(self, mount_cloud_identity):
+        """Sets the mount_cloud_identity of this V1beta1ComponentExtensionSpec.
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :param mount_cloud_identity: The mount_cloud_identity of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._mount_cloud_identity = mount_cloud_identity
+
+    @property
+    def rate_limit(self):
+        """Gets the rate_limit of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :return: The rate_limit of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1RateLimit
+        """
+        return self._rate_limit
+
+    @rate_limit.setter
+    def rate_limit(self, rate_limit):
+        """Sets the rate_limit of this V1beta1ComponentExtensionSpec.
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :param rate_limit: The rate_limit of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1RateLimit
+        """
+
+        self._rate_limit = rate_limit
+
+    @property
+    def retries(self):
+        """Gets the retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :return: The retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1RetryPolicy
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1beta1ComponentExtensionSpec.
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :param retries: The retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1RetryPolicy
+        """
+
+        self._retries = retries
+
     @property
     def scale_metric(self):
         """Gets the scale_metric of this V1beta1ComponentExtensionSpec.  # noqa: E501
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :return: The scale_metric of this V1beta1ComponentExtensionSpec.  # noqa: E501
         :rtype: str
@@ -255,7 +344,7 @@ class V1beta1ComponentExtensionSpec(object):
     def scale_metric(self, scale_metric):
         """Sets the scale_metric of this V1beta1ComponentExtensionSpec.
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :param scale_metric: The scale_metric of this V1beta1ComponentExtensionSpec.  # noqa: E501
         :type: str
@@ -309,6 +398,29 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._timeout = timeout
 
+    @property
+    def traffic_mirror(self):
+        """Gets the traffic_mirror of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :return: The traffic_mirror of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1TrafficMirror
+        """
+        return self._traffic_mirror
+
+    @traffic_mirror.setter
+    def traffic_mirror(self, traffic_mirror):
+        """Sets the traffic_mirror of this V1beta1ComponentExtensionSpec.
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :param traffic_mirror: The traffic_mirror of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1TrafficMirror
+        """
+
+        self._traffic_mirror = traffic_mirror
+
     def to_dict(self):
         """Returns the model properties as a dict"""
         result = {}
@@ -0,0 +1,264 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1CorsPolicyConfig(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'allow_credentials': 'bool',
+        'allow_headers': 'list[str]',
+        'allow_methods': 'list[str]',
+        'allow_origins': 'list[str]',
+        'expose_headers': 'list[str]',
+        'max_age_seconds': 'int'
+    }
+
+    attribute_map = {
+        'allow_credentials': 'allowCredentials',
+        'allow_headers': 'allowHeaders',
+        'allow_methods': 'allowMethods',
+        'allow_origins': 'allowOrigins',
+        'expose_headers': 'exposeHeaders',
+        'max_age_seconds': 'maxAgeSeconds'
+    }
+
+    def __init__(self, allow_credentials=None, allow_headers=None, allow_methods=None, allow_origins=None, expose_headers=None, max_age_seconds=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1CorsPolicyConfig - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._allow_credentials = None
+        self._allow_headers = None
+        self._allow_methods = None
+        self._allow_origins = None
+        self._expose_headers = None
+        self._max_age_seconds = None
+        self.discriminator = None
+
+        if allow_credentials is not None:
+            self.allow_credentials = allow_credentials
+        if allow_headers is not None:
+            self.allow_headers = allow_headers
+        if allow_methods is not None:
+            self.allow_methods = allow_methods
+        if allow_origins is not None:
+            self.allow_origins = allow_origins
+        if expose_headers is not None:
+            self.expose_headers = expose_headers
+        if max_age_seconds is not None:
+            self.max_age_seconds = max_age_seconds
+
+    @property
+    def allow_credentials(self):
+        """Gets the allow_credentials of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The allow_credentials of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: bool
+        """
+        return self._allow_credentials
+
+    @allow_credentials.setter
+    def allow_credentials(self, allow_credentials):
+        """Sets the allow_credentials of this V1beta1CorsPolicyConfig.
+
+
+        :param allow_credentials: The allow_credentials of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: bool
+        """
+
+        self._allow_credentials = allow_credentials
+
+    @property
+    def allow_headers(self):
+        """Gets the allow_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The allow_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allow_headers
+
+    @allow_headers.setter
+    def allow_headers(self, allow_headers):
+        """Sets the allow_headers of this V1beta1CorsPolicyConfig.
+
+
+        :param allow_headers: The allow_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allow_headers = allow_headers
+
+    @property
+    def allow_methods(self):
+        """Gets the allow_methods of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The allow_methods of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allow_methods
+
+    @allow_methods.setter
+    def allow_methods(self, allow_methods):
+        """Sets the allow_methods of this V1beta1CorsPolicyConfig.
+
+
+        :param allow_methods: The allow_methods of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allow_methods = allow_methods
+
+    @property
+    def allow_origins(self):
+        """Gets the allow_origins of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The allow_origins of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allow_origins
+
+    @allow_origins.setter
+    def allow_origins(self, allow_origins):
+        """Sets the allow_origins of this V1beta1CorsPolicyConfig.
+
+
+        :param allow_origins: The allow_origins of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allow_origins = allow_origins
+
+    @property
+    def expose_headers(self):
+        """Gets the expose_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The expose_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._expose_headers
+
+    @expose_headers.setter
+    def expose_headers(self, expose_headers):
+        """Sets the expose_headers of this V1beta1CorsPolicyConfig.
+
+
+        :param expose_headers: The expose_headers of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._expose_headers = expose_headers
+
+    @property
+    def max_age_seconds(self):
+        """Gets the max_age_seconds of this V1beta1CorsPolicyConfig.  # noqa: E501
+
+
+        :return: The max_age_seconds of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_age_seconds
+
+    @max_age_seconds.setter
+    def max_age_seconds(self, max_age_seconds):
+        """Sets the max_age_seconds of this V1beta1CorsPolicyConfig.
+
+
+        :param max_age_seconds: The max_age_seconds of this V1beta1CorsPolicyConfig.  # noqa: E501
+        :type: int
+        """
+
+        self._max_age_seconds = max_age_seconds
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1CorsPolicyConfig):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1CorsPolicyConfig):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,228 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1DynamicShape(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'input': 'str',
+        'max': 'list[int]',
+        'min': 'list[int]',
+        'opt': 'list[int]'
+    }
+
+    attribute_map = {
+        'input': 'input',
+        'max': 'max',
+        'min': 'min',
+        'opt': 'opt'
+    }
+
+    def __init__(self, input='', max=None, min=None, opt=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1DynamicShape - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._input = None
+        self._max = None
+        self._min = None
+        self._opt = None
+        self.discriminator = None
+
+        self.input = input
+        self.max = max
+        self.min = min
+        self.opt = opt
+
+    @property
+    def input(self):
+        """Gets the input of this V1beta1DynamicShape.  # noqa: E501
+
+        Name of the input.  # noqa: E501
+
+        :return: The input of this V1beta1DynamicShape.  # noqa: E501
+        :rtype: str
+        """
+        return self._input
+
+    @input.setter
+    def input(self, input):
+        """Sets the input of this V1beta1DynamicShape.
+
+        Name of the input.  # noqa: E501
+
+        :param input: The input of this V1beta1DynamicShape.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and input is None:  # noqa: E501
+            raise ValueError("Invalid value for `input`, must not be `None`")  # noqa: E501
+
+
+        self._input = input
+
+    @property
+    def max(self):
+        """Gets the max of this V1beta1DynamicShape.  # noqa: E501
+
+        Maximum shape of the input.  # noqa: E501
+
+        :return: The max of this V1beta1DynamicShape.  # noqa: E501
+        :rtype: list[int]
+        """
+        return self._max
+
+    @max.setter
+    def max(self, max):
+        """Sets the max of this V1beta1DynamicShape.
+
+        Maximum shape of the input.  # noqa: E501
+
+        :param max: The max of this V1beta1DynamicShape.  # noqa: E501
+        :type: list[int]
+        """
+        if self.local_vars_configuration.client_side_validation and max is None:  # noqa: E501
+            raise ValueError("Invalid value for `max`, must not be `None`")  # noqa: E501
+
+
+        self._max = max
+
+    @property
+    def min(self):
+        """Gets the min of this V1beta1DynamicShape.  # noqa: E501
+
+        Minimum shape of the input, e.g. [1, 3, 224, 224].  # noqa: E501
+
+        :return: The min of this V1beta1DynamicShape.  # noqa: E501
+        :rtype: list[int]
+        """
+        return self._min
+
+    @min.setter
+    def min(self, min):
+        """Sets the min of this V1beta1DynamicShape.
+
+        Minimum shape of the input, e.g. [1, 3, 224, 224].  # noqa: E501
+
+        :param min: The min of this V1beta1DynamicShape.  # noqa: E501
+        :type: list[int]
+        """
+        if self.local_vars_configuration.client_side_validation and min is None:  # noqa: E501
+            raise ValueError("Invalid value for `min`, must not be `None`")  # noqa: E501
+
+
+        self._min = min
+
+    @property
+    def opt(self):
+        """Gets the opt of this V1beta1DynamicShape.  # noqa: E501
+
+        Shape the graph is optimized for.  # noqa: E501
+
+        :return: The opt of this V1beta1DynamicShape.  # noqa: E501
+        :rtype: list[int]
+        """
+        return self._opt
+
+    @opt.setter
+    def opt(self, opt):
+        """Sets the opt of this V1beta1DynamicShape.
+
+        Shape the graph is optimized for.  # noqa: E501
+
+        :param opt: The opt of this V1beta1DynamicShape.  # noqa: E501
+        :type: list[int]
+        """
+        if self.local_vars_configuration.client_side_validation and opt is None:  # noqa: E501
+            raise ValueError("Invalid value for `opt`, must not be `None`")  # noqa: E501
+
+
+        self._opt = opt
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1DynamicShape):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1DynamicShape):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -71,6 +71,7 @@ class V1beta1ExplainerSpec(object):
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
         'min_replicas': 'int',
+        'mount_cloud_identity': 'bool',
         'node_name': 'str',
         'node_selector': 'dict(str, str)',
         'os': 'V1PodOS',
@@ -78,8 +79,10 @@ class V1beta1ExplainerSpec(object):
         'preemption_policy': 'str',
         'priority': 'int',
         'priority_class_name': 'str',
+        'rate_limit': 'V1beta1RateLimit',
         'readiness_gates': 'list[V1PodReadinessGate]',
         'restart_policy': 'str',
+        'retries': 'V1beta1RetryPolicy',
         'runtime_class_name': 'str',
         'scale_metric': 'str',
         'scale_target': 'int',
@@ -94,6 +97,7 @@ class V1beta1ExplainerSpec(object):
         'timeout': 'int',
         'tolerations': 'list[V1Toleration]',
         'topology_spread_constraints': 'list[V1TopologySpreadConstraint]',
+        'traffic_mirror': 'V1beta1TrafficMirror',
         'volumes': 'list[V1Volume]'
     }
 
@@ -122,6 +126,7 @@ class V1beta1ExplainerSpec(object):
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
         'min_replicas': 'minReplicas',
+        'mount_cloud_identity': 'mountCloudIdentity',
         'node_name': 'nodeName',
         'node_selector': 'nodeSelector',
         'os': 'os',
@@ -129,8 +134,10 @@ class V1beta1ExplainerSpec(object):
         'preemption_policy': 'preemptionPolicy',
         'priority': 'priority',
         'priority_class_name': 'priorityClassName',
+        'rate_limit': 'rateLimit',
         'readiness_gates': 'readinessGates',
         'restart_policy': 'restartPolicy',
+        'retries': 'retries',
         'runtime_class_name': 'runtimeClassName',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
@@ -145,10 +152,11 @@ class V1beta1ExplainerSpec(object):
         'timeout': 'timeout',
         'tolerations': 'tolerations',
         'topology_spread_constraints': 'topologySpreadConstraints',
+        'traffic_mirror': 'trafficMirror',
         'volumes': 'volumes'
     }
 
-    def __init__(self, active_deadline_seconds=None, affinity=None, aix=None, alibi=None, art=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, hostname=None, image_pull_secrets=None, init_containers=None, logger=None, max_replicas=None, min_replicas=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, readiness_gates=None, restart_policy=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, volumes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_deadline_seconds=None, affinity=None, aix=None, alibi=None, art=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, hostname=None, image_pull_secrets=None, init_containers=None, logger=None, max_replicas=None, min_replicas=None, mount_cloud_identity=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, rate_limit=None, readiness_gates=None, restart_policy=None, retries=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, traffic_mirror=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ExplainerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -178,6 +186,7 @@ class V1beta1ExplainerSpec(object):
         self._logger = None
         self._max_replicas = None
         self._min_replicas = None
+        self._mount_cloud_identity = None
         self._node_name = None
         self._node_selector = None
         self._os = None
@@ -185,8 +194,10 @@ class V1beta1ExplainerSpec(object):
         self._preemption_policy = None
         self._priority = None
         self._priority_class_name = None
+        self._rate_limit = None
         self._readiness_gates = None
         self._restart_policy = None
+        self._retries = None
         self._runtime_class_name = None
         self._scale_metric = None
         self._scale_target = None
@@ -201,6 +212,7 @@ class V1beta1ExplainerSpec(object):
         self._timeout = None
         self._tolerations = None
         self._topology_spread_constraints = None
+        self._traffic_mirror = None
         self._volumes = None
         self.discriminator = None
 
@@ -252,6 +264,8 @@ class V1beta1ExplainerSpec(object):
             self.max_replicas = max_replicas
         if min_replicas is not None:
             self.min_replicas = min_replicas
+        if mount_cloud_identity is not None:
+            self.mount_cloud_identity = mount_cloud_identity
         if node_name is not None:
             self.node_name = node_name
         if node_selector is not None:
@@ -266,10 +280,14 @@ class V1beta1ExplainerSpec(object):
             self.priority = priority
         if priority_class_name is not None:
             self.priority_class_name = priority_class_name
+        if rate_limit is not None:
+            self.rate_limit = rate_limit
         if readiness_gates is not None:
             self.readiness_gates = readiness_gates
         if restart_policy is not None:
             self.restart_policy = restart_policy
+        if retries is not None:
+            self.retries = retries
         if runtime_class_name is not None:
             self.runtime_class_name = runtime_class_name
         if scale_metric is not None:
@@ -298,6 +316,8 @@ class V1beta1ExplainerSpec(object):
             self.tolerations = tolerations
         if topology_spread_constraints is not None:
             self.topology_spread_constraints = topology_spread_constraints
+        if traffic_mirror is not None:
+            self.traffic_mirror = traffic_mirror
         if volumes is not None:
             self.volumes = volumes
 
@@ -839,6 +859,29 @@ class V1beta1ExplainerSpec(object):
 
         self._min_replicas = min_replicas
 
+    @property
+    def mount_cloud_identity(self):
+        """Gets the mount_cloud_identity of this V1beta1ExplainerSpec.  # noqa: E501
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :return: The mount_cloud_identity of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._mount_cloud_identity
+
+    @mount_cloud_identity.setter
+    def mount_cloud_identity(self, mount_cloud_identity):
+        """Sets the mount_cloud_identity of this V1beta1ExplainerSpec.
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :param mount_cloud_identity: The mount_cloud_identity of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._mount_cloud_identity = mount_cloud_identity
+
     @property
     def node_name(self):
         """Gets the node_name of this V1beta1ExplainerSpec.  # noqa: E501
@@ -998,6 +1041,29 @@ class V1beta1ExplainerSpec(object):
 
         self._priority_class_name = priority_class_name
 
+    @property
+    def rate_limit(self):
+        """Gets the rate_limit of this V1beta1ExplainerSpec.  # noqa: E501
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :return: The rate_limit of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: V1beta1RateLimit
+        """
+        return self._rate_limit
+
+    @rate_limit.setter
+    def rate_limit(self, rate_limit):
+        """Sets the rate_limit of this V1beta1ExplainerSpec.
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :param rate_limit: The rate_limit of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: V1beta1RateLimit
+        """
+
+        self._rate_limit = rate_limit
+
     @property
     def readiness_gates(self):
         """Gets the readiness_gates of this V1beta1ExplainerSpec.  # noqa: E501
@@ -1044,6 +1110,29 @@ class V1beta1ExplainerSpec(object):
 
         self._restart_policy = restart_policy
 
+    @property
+    def retries(self):
+        """Gets the retries of this V1beta1ExplainerSpec.  # noqa: E501
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :return: The retries of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: V1beta1RetryPolicy
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1beta1ExplainerSpec.
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :param retries: The retries of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: V1beta1RetryPolicy
+        """
+
+        self._retries = retries
+
     @property
     def runtime_class_name(self):
         """Gets the runtime_class_name of this V1beta1ExplainerSpec.  # noqa: E501
@@ -1071,7 +1160,7 @@ class V1beta1ExplainerSpec(object):
     def scale_metric(self):
         """Gets the scale_metric of this V1beta1ExplainerSpec.  # noqa: E501
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :return: The scale_metric of this V1beta1ExplainerSpec.  # noqa: E501
         :rtype: str
@@ -1082,7 +1171,7 @@ class V1beta1ExplainerSpec(object):
     def scale_metric(self, scale_metric):
         """Sets the scale_metric of this V1beta1ExplainerSpec.
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :param scale_metric: The scale_metric of this V1beta1ExplainerSpec.  # noqa: E501
         :type: str
@@ -1364,6 +1453,29 @@ class V1beta1ExplainerSpec(object):
 
         self._topology_spread_constraints = topology_spread_constraints
 
+    @property
+    def traffic_mirror(self):
+        """Gets the traffic_mirror of this V1beta1ExplainerSpec.  # noqa: E501
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :return: The traffic_mirror of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: V1beta1TrafficMirror
+        """
+        return self._traffic_mirror
+
+    @traffic_mirror.setter
+    def traffic_mirror(self, traffic_mirror):
+        """Sets the traffic_mirror of this V1beta1ExplainerSpec.
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :param traffic_mirror: The traffic_mirror of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: V1beta1TrafficMirror
+        """
+
+        self._traffic_mirror = traffic_mirror
+
     @property
     def volumes(self):
         """Gets the volumes of this V1beta1ExplainerSpec.  # noqa: E501
@@ -49,22 +49,26 @@ class V1beta1FailureInfo(object):
     openapi_types = {
         'exit_code': 'int',
         'location': 'str',
+        'log_tail': 'str',
         'message': 'str',
         'model_revision_name': 'str',
         'reason': 'str',
+        'termination_reason': 'str',
         'time': 'V1Time'
     }
 
     attribute_map = {
         'exit_code': 'exitCode',
         'location': 'location',
+        'log_tail': 'logTail',
         'message': 'message',
         'model_revision_name': 'modelRevisionName',
         'reason': 'reason',
+        'termination_reason': 'terminationReason',
         'time': 'time'
     }
 
-    def __init__(self, exit_code=None, location=None, message=None, model_revision_name=None, reason=None, time=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, exit_code=None, location=None, log_tail=None, message=None, model_revision_name=None, reason=None, termination_reason=None, time=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1FailureInfo - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -72,9 +76,11 @@ class V1beta1FailureInfo(object):
 
         self._exit_code = None
         self._location = None
+        self._log_tail = None
         self._message = None
         self._model_revision_name = None
         self._reason = None
+        self._termination_reason = None
         self._time = None
         self.discriminator = None
 
@@ -82,12 +88,16 @@ class V1beta1FailureInfo(object):
             self.exit_code = exit_code
         if location is not None:
             self.location = location
+        if log_tail is not None:
+            self.log_tail = log_tail
         if message is not None:
             self.message = message
         if model_revision_name is not None:
             self.model_revision_name = model_revision_name
         if reason is not None:
             self.reason = reason
+        if termination_reason is not None:
+            self.termination_reason = termination_reason
         if time is not None:
             self.time = time
 
@@ -137,6 +147,29 @@ class V1beta1FailureInfo(object):
 
         self._location = location
 
+    @property
+    def log_tail(self):
+        """Gets the log_tail of this V1beta1FailureInfo.  # noqa: E501
+
+        Tail of the logs of the last terminated container, bounded to a few KiB  # noqa: E501
+
+        :return: The log_tail of this V1beta1FailureInfo.  # noqa: E501
+        :rtype: str
+        """
+        return self._log_tail
+
+    @log_tail.setter
+    def log_tail(self, log_tail):
+        """Sets the log_tail of this V1beta1FailureInfo.
+
+        Tail of the logs of the last terminated container, bounded to a few KiB  # noqa: E501
+
+        :param log_tail: The log_tail of this V1beta1FailureInfo.  # noqa: E501
+        :type: str
+        """
+
+        self._log_tail = log_tail
+
     @property
     def message(self):
         """Gets the message of this V1beta1FailureInfo.  # noqa: E501
@@ -206,6 +239,29 @@ class V1beta1FailureInfo(object):
 
         self._reason = reason
 
+    @property
+    def termination_reason(self):
+        """Gets the termination_reason of this V1beta1FailureInfo.  # noqa: E501
+
+        Reason of the last termination of the container, e.g. OOMKilled  # noqa: E501
+
+        :return: The termination_reason of this V1beta1FailureInfo.  # noqa: E501
+        :rtype: str
+        """
+        return self._termination_reason
+
+    @termination_reason.setter
+    def termination_reason(self, termination_reason):
+        """Sets the termination_reason of this V1beta1FailureInfo.
+
+        Reason of the last termination of the container, e.g. OOMKilled  # noqa: E501
+
+        :param termination_reason: The termination_reason of this V1beta1FailureInfo.  # noqa: E501
+        :type: str
+        """
+
+        self._termination_reason = termination_reason
+
     @property
     def time(self):
         """Gets the time of this V1beta1FailureInfo.  # noqa: E501
@@ -0,0 +1,192 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1GraphOptimization(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'dynamic_shapes': 'list[V1beta1DynamicShape]',
+        'precision': 'str',
+        'workspace_size': 'ResourceQuantity'
+    }
+
+    attribute_map = {
+        'dynamic_shapes': 'dynamicShapes',
+        'precision': 'precision',
+        'workspace_size': 'workspaceSize'
+    }
+
+    def __init__(self, dynamic_shapes=None, precision=None, workspace_size=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1GraphOptimization - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._dynamic_shapes = None
+        self._precision = None
+        self._workspace_size = None
+        self.discriminator = None
+
+        if dynamic_shapes is not None:
+            self.dynamic_shapes = dynamic_shapes
+        if precision is not None:
+            self.precision = precision
+        if workspace_size is not None:
+            self.workspace_size = workspace_size
+
+    @property
+    def dynamic_shapes(self):
+        """Gets the dynamic_shapes of this V1beta1GraphOptimization.  # noqa: E501
+
+        Shape ranges of the inputs with dynamic dimensions the graph is optimized for.  # noqa: E501
+
+        :return: The dynamic_shapes of this V1beta1GraphOptimization.  # noqa: E501
+        :rtype: list[V1beta1DynamicShape]
+        """
+        return self._dynamic_shapes
+
+    @dynamic_shapes.setter
+    def dynamic_shapes(self, dynamic_shapes):
+        """Sets the dynamic_shapes of this V1beta1GraphOptimization.
+
+        Shape ranges of the inputs with dynamic dimensions the graph is optimized for.  # noqa: E501
+
+        :param dynamic_shapes: The dynamic_shapes of this V1beta1GraphOptimization.  # noqa: E501
+        :type: list[V1beta1DynamicShape]
+        """
+
+        self._dynamic_shapes = dynamic_shapes
+
+    @property
+    def precision(self):
+        """Gets the precision of this V1beta1GraphOptimization.  # noqa: E501
+
+        Precision the graph is optimized for, FP32 by default.  # noqa: E501
+
+        :return: The precision of this V1beta1GraphOptimization.  # noqa: E501
+        :rtype: str
+        """
+        return self._precision
+
+    @precision.setter
+    def precision(self, precision):
+        """Sets the precision of this V1beta1GraphOptimization.
+
+        Precision the graph is optimized for, FP32 by default.  # noqa: E501
+
+        :param precision: The precision of this V1beta1GraphOptimization.  # noqa: E501
+        :type: str
+        """
+
+        self._precision = precision
+
+    @property
+    def workspace_size(self):
+        """Gets the workspace_size of this V1beta1GraphOptimization.  # noqa: E501
+
+        Maximum memory the optimizer may use as workspace, e.g. 2Gi.  # noqa: E501
+
+        :return: The workspace_size of this V1beta1GraphOptimization.  # noqa: E501
+        :rtype: ResourceQuantity
+        """
+        return self._workspace_size
+
+    @workspace_size.setter
+    def workspace_size(self, workspace_size):
+        """Sets the workspace_size of this V1beta1GraphOptimization.
+
+        Maximum memory the optimizer may use as workspace, e.g. 2Gi.  # noqa: E501
+
+        :param workspace_size: The workspace_size of this V1beta1GraphOptimization.  # noqa: E501
+        :type: ResourceQuantity
+        """
+
+        self._workspace_size = workspace_size
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1GraphOptimization):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1GraphOptimization):
+            return True
+
+        return self.to_dict() != other.to_dict()
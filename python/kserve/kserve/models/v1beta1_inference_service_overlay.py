@@ -0,0 +1,304 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1InferenceServiceOverlay(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'explainer': 'V1beta1ComponentOverlay',
+        'max_replicas': 'int',
+        'min_replicas': 'int',
+        'node_selector': 'dict(str, str)',
+        'resources': 'V1ResourceRequirements',
+        'scale_target': 'int',
+        'transformer': 'V1beta1ComponentOverlay'
+    }
+
+    attribute_map = {
+        'explainer': 'explainer',
+        'max_replicas': 'maxReplicas',
+        'min_replicas': 'minReplicas',
+        'node_selector': 'nodeSelector',
+        'resources': 'resources',
+        'scale_target': 'scaleTarget',
+        'transformer': 'transformer'
+    }
+
+    def __init__(self, explainer=None, max_replicas=None, min_replicas=None, node_selector=None, resources=None, scale_target=None, transformer=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1InferenceServiceOverlay - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._explainer = None
+        self._max_replicas = None
+        self._min_replicas = None
+        self._node_selector = None
+        self._resources = None
+        self._scale_target = None
+        self._transformer = None
+        self.discriminator = None
+
+        if explainer is not None:
+            self.explainer = explainer
+        if max_replicas is not None:
+            self.max_replicas = max_replicas
+        if min_replicas is not None:
+            self.min_replicas = min_replicas
+        if node_selector is not None:
+            self.node_selector = node_selector
+        if resources is not None:
+            self.resources = resources
+        if scale_target is not None:
+            self.scale_target = scale_target
+        if transformer is not None:
+            self.transformer = transformer
+
+    @property
+    def explainer(self):
+        """Gets the explainer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Settings of the explainer  # noqa: E501
+
+        :return: The explainer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: V1beta1ComponentOverlay
+        """
+        return self._explainer
+
+    @explainer.setter
+    def explainer(self, explainer):
+        """Sets the explainer of this V1beta1InferenceServiceOverlay.
+
+        Settings of the explainer  # noqa: E501
+
+        :param explainer: The explainer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: V1beta1ComponentOverlay
+        """
+
+        self._explainer = explainer
+
+    @property
+    def max_replicas(self):
+        """Gets the max_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Maximum number of replicas for autoscaling  # noqa: E501
+
+        :return: The max_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_replicas
+
+    @max_replicas.setter
+    def max_replicas(self, max_replicas):
+        """Sets the max_replicas of this V1beta1InferenceServiceOverlay.
+
+        Maximum number of replicas for autoscaling  # noqa: E501
+
+        :param max_replicas: The max_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: int
+        """
+
+        self._max_replicas = max_replicas
+
+    @property
+    def min_replicas(self):
+        """Gets the min_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Minimum number of replicas  # noqa: E501
+
+        :return: The min_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_replicas
+
+    @min_replicas.setter
+    def min_replicas(self, min_replicas):
+        """Sets the min_replicas of this V1beta1InferenceServiceOverlay.
+
+        Minimum number of replicas  # noqa: E501
+
+        :param min_replicas: The min_replicas of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: int
+        """
+
+        self._min_replicas = min_replicas
+
+    @property
+    def node_selector(self):
+        """Gets the node_selector of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Node selector of the pods, merged into the node selector of the spec  # noqa: E501
+
+        :return: The node_selector of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._node_selector
+
+    @node_selector.setter
+    def node_selector(self, node_selector):
+        """Sets the node_selector of this V1beta1InferenceServiceOverlay.
+
+        Node selector of the pods, merged into the node selector of the spec  # noqa: E501
+
+        :param node_selector: The node_selector of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._node_selector = node_selector
+
+    @property
+    def resources(self):
+        """Gets the resources of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Resources of the container, the requests and the limits replace the ones of the same resource names  # noqa: E501
+
+        :return: The resources of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: V1ResourceRequirements
+        """
+        return self._resources
+
+    @resources.setter
+    def resources(self, resources):
+        """Sets the resources of this V1beta1InferenceServiceOverlay.
+
+        Resources of the container, the requests and the limits replace the ones of the same resource names  # noqa: E501
+
+        :param resources: The resources of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: V1ResourceRequirements
+        """
+
+        self._resources = resources
+
+    @property
+    def scale_target(self):
+        """Gets the scale_target of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Integer target value of the metric type the autoscaler watches for  # noqa: E501
+
+        :return: The scale_target of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: int
+        """
+        return self._scale_target
+
+    @scale_target.setter
+    def scale_target(self, scale_target):
+        """Sets the scale_target of this V1beta1InferenceServiceOverlay.
+
+        Integer target value of the metric type the autoscaler watches for  # noqa: E501
+
+        :param scale_target: The scale_target of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: int
+        """
+
+        self._scale_target = scale_target
+
+    @property
+    def transformer(self):
+        """Gets the transformer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+
+        Settings of the transformer  # noqa: E501
+
+        :return: The transformer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :rtype: V1beta1ComponentOverlay
+        """
+        return self._transformer
+
+    @transformer.setter
+    def transformer(self, transformer):
+        """Sets the transformer of this V1beta1InferenceServiceOverlay.
+
+        Settings of the transformer  # noqa: E501
+
+        :param transformer: The transformer of this V1beta1InferenceServiceOverlay.  # noqa: E501
+        :type: V1beta1ComponentOverlay
+        """
+
+        self._transformer = transformer
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1InferenceServiceOverlay):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1InferenceServiceOverlay):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -48,30 +48,40 @@ class V1beta1InferenceServiceSpec(object):
     """
     openapi_types = {
         'explainer': 'V1beta1ExplainerSpec',
+        'overlays': 'dict(str, V1beta1InferenceServiceOverlay)',
         'predictor': 'V1beta1PredictorSpec',
+        'smoke_test': 'V1beta1SmokeTestSpec',
         'transformer': 'V1beta1TransformerSpec'
     }
 
     attribute_map = {
         'explainer': 'explainer',
+        'overlays': 'overlays',
         'predictor': 'predictor',
+        'smoke_test': 'smokeTest',
         'transformer': 'transformer'
     }
 
-    def __init__(self, explainer=None, predictor=None, transformer=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, explainer=None, overlays=None, predictor=None, smoke_test=None, transformer=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1InferenceServiceSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._explainer = None
+        self._overlays = None
         self._predictor = None
+        self._smoke_test = None
         self._transformer = None
         self.discriminator = None
 
         if explainer is not None:
             self.explainer = explainer
+        if overlays is not None:
+            self.overlays = overlays
         self.predictor = predictor
+        if smoke_test is not None:
+            self.smoke_test = smoke_test
         if transformer is not None:
             self.transformer = transformer
 
@@ -96,6 +106,29 @@ class V1beta1InferenceServiceSpec(object):
 
         self._explainer = explainer
 
+    @property
+    def overlays(self):
+        """Gets the overlays of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+        Overlays defines the settings of each deployment stage, e.g. production, applied on top of the spec by the defaulter. The stage is given by the serving.kserve.io/deployment-stage label of the namespace or else by the --deployment-stage flag of the controller.  # noqa: E501
+
+        :return: The overlays of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: dict(str, V1beta1InferenceServiceOverlay)
+        """
+        return self._overlays
+
+    @overlays.setter
+    def overlays(self, overlays):
+        """Sets the overlays of this V1beta1InferenceServiceSpec.
+
+        Overlays defines the settings of each deployment stage, e.g. production, applied on top of the spec by the defaulter. The stage is given by the serving.kserve.io/deployment-stage label of the namespace or else by the --deployment-stage flag of the controller.  # noqa: E501
+
+        :param overlays: The overlays of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: dict(str, V1beta1InferenceServiceOverlay)
+        """
+
+        self._overlays = overlays
+
     @property
     def predictor(self):
         """Gets the predictor of this V1beta1InferenceServiceSpec.  # noqa: E501
@@ -119,6 +152,27 @@ class V1beta1InferenceServiceSpec(object):
 
         self._predictor = predictor
 
+    @property
+    def smoke_test(self):
+        """Gets the smoke_test of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+
+        :return: The smoke_test of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: V1beta1SmokeTestSpec
+        """
+        return self._smoke_test
+
+    @smoke_test.setter
+    def smoke_test(self, smoke_test):
+        """Sets the smoke_test of this V1beta1InferenceServiceSpec.
+
+
+        :param smoke_test: The smoke_test of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: V1beta1SmokeTestSpec
+        """
+
+        self._smoke_test = smoke_test
+
     @property
     def transformer(self):
         """Gets the transformer of this V1beta1InferenceServiceSpec.  # noqa: E501
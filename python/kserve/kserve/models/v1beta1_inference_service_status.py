@@ -49,24 +49,36 @@ class V1beta1InferenceServiceStatus(object):
     openapi_types = {
         'address': 'KnativeAddressable',
         'annotations': 'dict(str, str)',
+        'auth_mode': 'str',
         'components': 'dict(str, V1beta1ComponentStatusSpec)',
         'conditions': 'list[KnativeCondition]',
+        'external_url': 'KnativeURL',
+        'grpc_url': 'KnativeURL',
+        'internal_url': 'KnativeURL',
         'model_status': 'V1beta1ModelStatus',
         'observed_generation': 'int',
+        'revision': 'str',
+        'smoke_tested_revision': 'str',
         'url': 'KnativeURL'
     }
 
     attribute_map = {
         'address': 'address',
         'annotations': 'annotations',
+        'auth_mode': 'authMode',
         'components': 'components',
         'conditions': 'conditions',
+        'external_url': 'externalURL',
+        'grpc_url': 'grpcURL',
+        'internal_url': 'internalURL',
         'model_status': 'modelStatus',
         'observed_generation': 'observedGeneration',
+        'revision': 'revision',
+        'smoke_tested_revision': 'smokeTestedRevision',
         'url': 'url'
     }
 
-    def __init__(self, address=None, annotations=None, components=None, conditions=None, model_status=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, address=None, annotations=None, auth_mode=None, components=None, conditions=None, external_url=None, grpc_url=None, internal_url=None, model_status=None, observed_generation=None, revision=None, smoke_tested_revision=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1InferenceServiceStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -74,10 +86,16 @@ class V1beta1InferenceServiceStatus(object):
 
         self._address = None
         self._annotations = None
+        self._auth_mode = None
         self._components = None
         self._conditions = None
+        self._external_url = None
+        self._grpc_url = None
+        self._internal_url = None
         self._model_status = None
         self._observed_generation = None
+        self._revision = None
+        self._smoke_tested_revision = None
         self._url = None
         self.discriminator = None
 
@@ -85,14 +103,26 @@ class V1beta1InferenceServiceStatus(object):
             self.address = address
         if annotations is not None:
             self.annotations = annotations
+        if auth_mode is not None:
+            self.auth_mode = auth_mode
         if components is not None:
             self.components = components
         if conditions is not None:
             self.conditions = conditions
+        if external_url is not None:
+            self.external_url = external_url
+        if grpc_url is not None:
+            self.grpc_url = grpc_url
+        if internal_url is not None:
+            self.internal_url = internal_url
         if model_status is not None:
             self.model_status = model_status
         if observed_generation is not None:
             self.observed_generation = observed_generation
+        if revision is not None:
+            self.revision = revision
+        if smoke_tested_revision is not None:
+            self.smoke_tested_revision = smoke_tested_revision
         if url is not None:
             self.url = url
 
@@ -140,6 +170,29 @@ class V1beta1InferenceServiceStatus(object):
 
         self._annotations = annotations
 
+    @property
+    def auth_mode(self):
+        """Gets the auth_mode of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        AuthMode is the authentication the requests of the InferenceService require, None or ServiceAccountToken  # noqa: E501
+
+        :return: The auth_mode of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._auth_mode
+
+    @auth_mode.setter
+    def auth_mode(self, auth_mode):
+        """Sets the auth_mode of this V1beta1InferenceServiceStatus.
+
+        AuthMode is the authentication the requests of the InferenceService require, None or ServiceAccountToken  # noqa: E501
+
+        :param auth_mode: The auth_mode of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._auth_mode = auth_mode
+
     @property
     def components(self):
         """Gets the components of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -186,6 +239,69 @@ class V1beta1InferenceServiceStatus(object):
 
         self._conditions = conditions
 
+    @property
+    def external_url(self):
+        """Gets the external_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+
+        :return: The external_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: KnativeURL
+        """
+        return self._external_url
+
+    @external_url.setter
+    def external_url(self, external_url):
+        """Sets the external_url of this V1beta1InferenceServiceStatus.
+
+
+        :param external_url: The external_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: KnativeURL
+        """
+
+        self._external_url = external_url
+
+    @property
+    def grpc_url(self):
+        """Gets the grpc_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+
+        :return: The grpc_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: KnativeURL
+        """
+        return self._grpc_url
+
+    @grpc_url.setter
+    def grpc_url(self, grpc_url):
+        """Sets the grpc_url of this V1beta1InferenceServiceStatus.
+
+
+        :param grpc_url: The grpc_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: KnativeURL
+        """
+
+        self._grpc_url = grpc_url
+
+    @property
+    def internal_url(self):
+        """Gets the internal_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+
+        :return: The internal_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: KnativeURL
+        """
+        return self._internal_url
+
+    @internal_url.setter
+    def internal_url(self, internal_url):
+        """Sets the internal_url of this V1beta1InferenceServiceStatus.
+
+
+        :param internal_url: The internal_url of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: KnativeURL
+        """
+
+        self._internal_url = internal_url
+
     @property
     def model_status(self):
         """Gets the model_status of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -230,6 +346,52 @@ class V1beta1InferenceServiceStatus(object):
 
         self._observed_generation = observed_generation
 
+    @property
+    def revision(self):
+        """Gets the revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        Revision identifies the revision of the predictor serving the requests, it is the name of the Knative revision in Serverless mode and the revision of the deployment in RawDeployment mode, it changes on every rollout  # noqa: E501
+
+        :return: The revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._revision
+
+    @revision.setter
+    def revision(self, revision):
+        """Sets the revision of this V1beta1InferenceServiceStatus.
+
+        Revision identifies the revision of the predictor serving the requests, it is the name of the Knative revision in Serverless mode and the revision of the deployment in RawDeployment mode, it changes on every rollout  # noqa: E501
+
+        :param revision: The revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._revision = revision
+
+    @property
+    def smoke_tested_revision(self):
+        """Gets the smoke_tested_revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        SmokeTestedRevision is the revision the last smoke test ran on, the SmokeTestPassed condition holds its result  # noqa: E501
+
+        :return: The smoke_tested_revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._smoke_tested_revision
+
+    @smoke_tested_revision.setter
+    def smoke_tested_revision(self, smoke_tested_revision):
+        """Sets the smoke_tested_revision of this V1beta1InferenceServiceStatus.
+
+        SmokeTestedRevision is the revision the last smoke test ran on, the SmokeTestPassed condition holds its result  # noqa: E501
+
+        :param smoke_tested_revision: The smoke_tested_revision of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._smoke_tested_revision = smoke_tested_revision
+
     @property
     def url(self):
         """Gets the url of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -47,50 +47,91 @@ class V1beta1IngressConfig(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'additional_ingress_classes': 'list[V1beta1IngressClassConfig]',
+        'allowed_ingress_gateways': 'list[str]',
+        'cors_policy': 'V1beta1CorsPolicyConfig',
         'disable_istio_virtual_host': 'bool',
         'domain_template': 'str',
+        'enable_gateway_api': 'bool',
+        'global_domain_template': 'str',
+        'grpc_routes': 'V1beta1GrpcRoutesConfig',
+        'host_template': 'str',
         'ingress_class_name': 'str',
         'ingress_domain': 'str',
         'ingress_gateway': 'str',
         'ingress_service': 'str',
+        'kserve_ingress_gateway': 'str',
         'local_gateway': 'str',
         'local_gateway_service': 'str',
+        'tls': 'V1beta1IngressTlsConfig',
         'url_scheme': 'str'
     }
 
     attribute_map = {
+        'additional_ingress_classes': 'additionalIngressClasses',
+        'allowed_ingress_gateways': 'allowedIngressGateways',
+        'cors_policy': 'corsPolicy',
         'disable_istio_virtual_host': 'disableIstioVirtualHost',
         'domain_template': 'domainTemplate',
+        'enable_gateway_api': 'enableGatewayApi',
+        'global_domain_template': 'globalDomainTemplate',
+        'grpc_routes': 'grpcRoutes',
+        'host_template': 'hostTemplate',
         'ingress_class_name': 'ingressClassName',
         'ingress_domain': 'ingressDomain',
         'ingress_gateway': 'ingressGateway',
         'ingress_service': 'ingressService',
+        'kserve_ingress_gateway': 'kserveIngressGateway',
         'local_gateway': 'localGateway',
         'local_gateway_service': 'localGatewayService',
+        'tls': 'tls',
         'url_scheme': 'urlScheme'
     }
 
-    def __init__(self, disable_istio_virtual_host=None, domain_template=None, ingress_class_name=None, ingress_domain=None, ingress_gateway=None, ingress_service=None, local_gateway=None, local_gateway_service=None, url_scheme=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, additional_ingress_classes=None, allowed_ingress_gateways=None, cors_policy=None, disable_istio_virtual_host=None, domain_template=None, enable_gateway_api=None, global_domain_template=None, grpc_routes=None, host_template=None, ingress_class_name=None, ingress_domain=None, ingress_gateway=None, ingress_service=None, kserve_ingress_gateway=None, local_gateway=None, local_gateway_service=None, tls=None, url_scheme=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1IngressConfig - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._additional_ingress_classes = None
+        self._allowed_ingress_gateways = None
+        self._cors_policy = None
         self._disable_istio_virtual_host = None
         self._domain_template = None
+        self._enable_gateway_api = None
+        self._global_domain_template = None
+        self._grpc_routes = None
+        self._host_template = None
         self._ingress_class_name = None
         self._ingress_domain = None
         self._ingress_gateway = None
         self._ingress_service = None
+        self._kserve_ingress_gateway = None
         self._local_gateway = None
         self._local_gateway_service = None
+        self._tls = None
         self._url_scheme = None
         self.discriminator = None
 
+        if additional_ingress_classes is not None:
+            self.additional_ingress_classes = additional_ingress_classes
+        if allowed_ingress_gateways is not None:
+            self.allowed_ingress_gateways = allowed_ingress_gateways
+        if cors_policy is not None:
+            self.cors_policy = cors_policy
         if disable_istio_virtual_host is not None:
             self.disable_istio_virtual_host = disable_istio_virtual_host
         if domain_template is not None:
             self.domain_template = domain_template
+        if enable_gateway_api is not None:
+            self.enable_gateway_api = enable_gateway_api
+        if global_domain_template is not None:
+            self.global_domain_template = global_domain_template
+        if grpc_routes is not None:
+            self.grpc_routes = grpc_routes
+        if host_template is not None:
+            self.host_template = host_template
         if ingress_class_name is not None:
             self.ingress_class_name = ingress_class_name
         if ingress_domain is not None:
@@ -99,13 +140,80 @@ class V1beta1IngressConfig(object):
             self.ingress_gateway = ingress_gateway
         if ingress_service is not None:
             self.ingress_service = ingress_service
+        if kserve_ingress_gateway is not None:
+            self.kserve_ingress_gateway = kserve_ingress_gateway
         if local_gateway is not None:
             self.local_gateway = local_gateway
         if local_gateway_service is not None:
             self.local_gateway_service = local_gateway_service
+        if tls is not None:
+            self.tls = tls
         if url_scheme is not None:
             self.url_scheme = url_scheme
 
+    @property
+    def additional_ingress_classes(self):
+        """Gets the additional_ingress_classes of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The additional_ingress_classes of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: list[V1beta1IngressClassConfig]
+        """
+        return self._additional_ingress_classes
+
+    @additional_ingress_classes.setter
+    def additional_ingress_classes(self, additional_ingress_classes):
+        """Sets the additional_ingress_classes of this V1beta1IngressConfig.
+
+
+        :param additional_ingress_classes: The additional_ingress_classes of this V1beta1IngressConfig.  # noqa: E501
+        :type: list[V1beta1IngressClassConfig]
+        """
+
+        self._additional_ingress_classes = additional_ingress_classes
+
+    @property
+    def allowed_ingress_gateways(self):
+        """Gets the allowed_ingress_gateways of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The allowed_ingress_gateways of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._allowed_ingress_gateways
+
+    @allowed_ingress_gateways.setter
+    def allowed_ingress_gateways(self, allowed_ingress_gateways):
+        """Sets the allowed_ingress_gateways of this V1beta1IngressConfig.
+
+
+        :param allowed_ingress_gateways: The allowed_ingress_gateways of this V1beta1IngressConfig.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._allowed_ingress_gateways = allowed_ingress_gateways
+
+    @property
+    def cors_policy(self):
+        """Gets the cors_policy of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The cors_policy of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: V1beta1CorsPolicyConfig
+        """
+        return self._cors_policy
+
+    @cors_policy.setter
+    def cors_policy(self, cors_policy):
+        """Sets the cors_policy of this V1beta1IngressConfig.
+
+
+        :param cors_policy: The cors_policy of this V1beta1IngressConfig.  # noqa: E501
+        :type: V1beta1CorsPolicyConfig
+        """
+
+        self._cors_policy = cors_policy
+
     @property
     def disable_istio_virtual_host(self):
         """Gets the disable_istio_virtual_host of this V1beta1IngressConfig.  # noqa: E501
@@ -148,6 +256,90 @@ class V1beta1IngressConfig(object):
 
         self._domain_template = domain_template
 
+    @property
+    def enable_gateway_api(self):
+        """Gets the enable_gateway_api of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The enable_gateway_api of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: bool
+        """
+        return self._enable_gateway_api
+
+    @enable_gateway_api.setter
+    def enable_gateway_api(self, enable_gateway_api):
+        """Sets the enable_gateway_api of this V1beta1IngressConfig.
+
+
+        :param enable_gateway_api: The enable_gateway_api of this V1beta1IngressConfig.  # noqa: E501
+        :type: bool
+        """
+
+        self._enable_gateway_api = enable_gateway_api
+
+    @property
+    def global_domain_template(self):
+        """Gets the global_domain_template of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The global_domain_template of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._global_domain_template
+
+    @global_domain_template.setter
+    def global_domain_template(self, global_domain_template):
+        """Sets the global_domain_template of this V1beta1IngressConfig.
+
+
+        :param global_domain_template: The global_domain_template of this V1beta1IngressConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._global_domain_template = global_domain_template
+
+    @property
+    def grpc_routes(self):
+        """Gets the grpc_routes of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The grpc_routes of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: V1beta1GrpcRoutesConfig
+        """
+        return self._grpc_routes
+
+    @grpc_routes.setter
+    def grpc_routes(self, grpc_routes):
+        """Sets the grpc_routes of this V1beta1IngressConfig.
+
+
+        :param grpc_routes: The grpc_routes of this V1beta1IngressConfig.  # noqa: E501
+        :type: V1beta1GrpcRoutesConfig
+        """
+
+        self._grpc_routes = grpc_routes
+
+    @property
+    def host_template(self):
+        """Gets the host_template of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The host_template of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._host_template
+
+    @host_template.setter
+    def host_template(self, host_template):
+        """Sets the host_template of this V1beta1IngressConfig.
+
+
+        :param host_template: The host_template of this V1beta1IngressConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._host_template = host_template
+
     @property
     def ingress_class_name(self):
         """Gets the ingress_class_name of this V1beta1IngressConfig.  # noqa: E501
@@ -232,6 +424,27 @@ class V1beta1IngressConfig(object):
 
         self._ingress_service = ingress_service
 
+    @property
+    def kserve_ingress_gateway(self):
+        """Gets the kserve_ingress_gateway of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The kserve_ingress_gateway of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._kserve_ingress_gateway
+
+    @kserve_ingress_gateway.setter
+    def kserve_ingress_gateway(self, kserve_ingress_gateway):
+        """Sets the kserve_ingress_gateway of this V1beta1IngressConfig.
+
+
+        :param kserve_ingress_gateway: The kserve_ingress_gateway of this V1beta1IngressConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._kserve_ingress_gateway = kserve_ingress_gateway
+
     @property
     def local_gateway(self):
         """Gets the local_gateway of this V1beta1IngressConfig.  # noqa: E501
@@ -274,6 +487,27 @@ class V1beta1IngressConfig(object):
 
         self._local_gateway_service = local_gateway_service
 
+    @property
+    def tls(self):
+        """Gets the tls of this V1beta1IngressConfig.  # noqa: E501
+
+
+        :return: The tls of this V1beta1IngressConfig.  # noqa: E501
+        :rtype: V1beta1IngressTlsConfig
+        """
+        return self._tls
+
+    @tls.setter
+    def tls(self, tls):
+        """Sets the tls of this V1beta1IngressConfig.
+
+
+        :param tls: The tls of this V1beta1IngressConfig.  # noqa: E501
+        :type: V1beta1IngressTlsConfig
+        """
+
+        self._tls = tls
+
     @property
     def url_scheme(self):
         """Gets the url_scheme of this V1beta1IngressConfig.  # noqa: E501
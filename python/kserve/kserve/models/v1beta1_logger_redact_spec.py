@@ -0,0 +1,220 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1LoggerRedactSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'mask': 'str',
+        'paths': 'list[str]',
+        'patterns': 'list[str]',
+        'redactors': 'list[str]'
+    }
+
+    attribute_map = {
+        'mask': 'mask',
+        'paths': 'paths',
+        'patterns': 'patterns',
+        'redactors': 'redactors'
+    }
+
+    def __init__(self, mask=None, paths=None, patterns=None, redactors=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1LoggerRedactSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._mask = None
+        self._paths = None
+        self._patterns = None
+        self._redactors = None
+        self.discriminator = None
+
+        if mask is not None:
+            self.mask = mask
+        if paths is not None:
+            self.paths = paths
+        if patterns is not None:
+            self.patterns = patterns
+        if redactors is not None:
+            self.redactors = redactors
+
+    @property
+    def mask(self):
+        """Gets the mask of this V1beta1LoggerRedactSpec.  # noqa: E501
+
+        Value replacing the masked values, defaults to [REDACTED]  # noqa: E501
+
+        :return: The mask of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._mask
+
+    @mask.setter
+    def mask(self, mask):
+        """Sets the mask of this V1beta1LoggerRedactSpec.
+
+        Value replacing the masked values, defaults to [REDACTED]  # noqa: E501
+
+        :param mask: The mask of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._mask = mask
+
+    @property
+    def paths(self):
+        """Gets the paths of this V1beta1LoggerRedactSpec.  # noqa: E501
+
+        JSON paths of the values replaced with the mask, e.g. $.instances[*].email or $.inputs[0].data. A path starts with $ followed by object fields, .name or .*, and array items, [0] or [*]. The objects and the arrays are masked as a whole.  # noqa: E501
+
+        :return: The paths of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._paths
+
+    @paths.setter
+    def paths(self, paths):
+        """Sets the paths of this V1beta1LoggerRedactSpec.
+
+        JSON paths of the values replaced with the mask, e.g. $.instances[*].email or $.inputs[0].data. A path starts with $ followed by object fields, .name or .*, and array items, [0] or [*]. The objects and the arrays are masked as a whole.  # noqa: E501
+
+        :param paths: The paths of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._paths = paths
+
+    @property
+    def patterns(self):
+        """Gets the patterns of this V1beta1LoggerRedactSpec.  # noqa: E501
+
+        Regular expressions, in the RE2 syntax, of the substrings of the string values replaced with the mask, e.g. [0-9]{3}-[0-9]{2}-[0-9]{4} for the social security numbers  # noqa: E501
+
+        :return: The patterns of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._patterns
+
+    @patterns.setter
+    def patterns(self, patterns):
+        """Sets the patterns of this V1beta1LoggerRedactSpec.
+
+        Regular expressions, in the RE2 syntax, of the substrings of the string values replaced with the mask, e.g. [0-9]{3}-[0-9]{2}-[0-9]{4} for the social security numbers  # noqa: E501
+
+        :param patterns: The patterns of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._patterns = patterns
+
+    @property
+    def redactors(self):
+        """Gets the redactors of this V1beta1LoggerRedactSpec.  # noqa: E501
+
+        Names of the custom redactors compiled into the agent with the logger.RegisterRedactor function, applied after the paths and the patterns  # noqa: E501
+
+        :return: The redactors of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._redactors
+
+    @redactors.setter
+    def redactors(self, redactors):
+        """Sets the redactors of this V1beta1LoggerRedactSpec.
+
+        Names of the custom redactors compiled into the agent with the logger.RegisterRedactor function, applied after the paths and the patterns  # noqa: E501
+
+        :param redactors: The redactors of this V1beta1LoggerRedactSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._redactors = redactors
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1LoggerRedactSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1LoggerRedactSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -47,30 +47,78 @@ class V1beta1LoggerSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'delivery': 'str',
         'mode': 'str',
+        'opt_out_header': 'str',
+        'redact': 'V1beta1LoggerRedactSpec',
+        'response_codes': 'list[str]',
+        'sampling_percent': 'int',
         'url': 'str'
     }
 
     attribute_map = {
+        'delivery': 'delivery',
         'mode': 'mode',
+        'opt_out_header': 'optOutHeader',
+        'redact': 'redact',
+        'response_codes': 'responseCodes',
+        'sampling_percent': 'samplingPercent',
         'url': 'url'
     }
 
-    def __init__(self, mode=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, delivery=None, mode=None, opt_out_header=None, redact=None, response_codes=None, sampling_percent=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1LoggerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._delivery = None
         self._mode = None
+        self._opt_out_header = None
+        self._redact = None
+        self._response_codes = None
+        self._sampling_percent = None
         self._url = None
         self.discriminator = None
 
+        if delivery is not None:
+            self.delivery = delivery
         if mode is not None:
             self.mode = mode
+        if opt_out_header is not None:
+            self.opt_out_header = opt_out_header
+        if redact is not None:
+            self.redact = redact
+        if response_codes is not None:
+            self.response_codes = response_codes
+        if sampling_percent is not None:
+            self.sampling_percent = sampling_percent
         if url is not None:
             self.url = url
 
+    @property
+    def delivery(self):
+        """Gets the delivery of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies the delivery guarantee of the logged events. <br /> Valid values are: <br /> - \"at-most-once\" (default): send every event once; <br /> - \"at-least-once\": send the events again until the sink acknowledges them with a 2xx status, the events carry an idempotency key and the events of the same request are sent in order with the request id as partition key <br />  # noqa: E501
+
+        :return: The delivery of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._delivery
+
+    @delivery.setter
+    def delivery(self, delivery):
+        """Sets the delivery of this V1beta1LoggerSpec.
+
+        Specifies the delivery guarantee of the logged events. <br /> Valid values are: <br /> - \"at-most-once\" (default): send every event once; <br /> - \"at-least-once\": send the events again until the sink acknowledges them with a 2xx status, the events carry an idempotency key and the events of the same request are sent in order with the request id as partition key <br />  # noqa: E501
+
+        :param delivery: The delivery of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._delivery = delivery
+
     @property
     def mode(self):
         """Gets the mode of this V1beta1LoggerSpec.  # noqa: E501
@@ -94,6 +142,98 @@ class V1beta1LoggerSpec(object):
 
         self._mode = mode
 
+    @property
+    def opt_out_header(self):
+        """Gets the opt_out_header of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies the request header opting the requests out of the logging when its value is true, e.g. for the requests carrying sensitive payloads  # noqa: E501
+
+        :return: The opt_out_header of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._opt_out_header
+
+    @opt_out_header.setter
+    def opt_out_header(self, opt_out_header):
+        """Sets the opt_out_header of this V1beta1LoggerSpec.
+
+        Specifies the request header opting the requests out of the logging when its value is true, e.g. for the requests carrying sensitive payloads  # noqa: E501
+
+        :param opt_out_header: The opt_out_header of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._opt_out_header = opt_out_header
+
+    @property
+    def redact(self):
+        """Gets the redact of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies the values masked in the JSON payloads before the events are emitted, e.g. the personal data  # noqa: E501
+
+        :return: The redact of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: V1beta1LoggerRedactSpec
+        """
+        return self._redact
+
+    @redact.setter
+    def redact(self, redact):
+        """Sets the redact of this V1beta1LoggerSpec.
+
+        Specifies the values masked in the JSON payloads before the events are emitted, e.g. the personal data  # noqa: E501
+
+        :param redact: The redact of this V1beta1LoggerSpec.  # noqa: E501
+        :type: V1beta1LoggerRedactSpec
+        """
+
+        self._redact = redact
+
+    @property
+    def response_codes(self):
+        """Gets the response_codes of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies the response status codes, e.g. 200, or classes, e.g. 5xx, of the requests whose request and response are logged. Only the requests answered with a 200 have their response logged when empty.  # noqa: E501
+
+        :return: The response_codes of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._response_codes
+
+    @response_codes.setter
+    def response_codes(self, response_codes):
+        """Sets the response_codes of this V1beta1LoggerSpec.
+
+        Specifies the response status codes, e.g. 200, or classes, e.g. 5xx, of the requests whose request and response are logged. Only the requests answered with a 200 have their response logged when empty.  # noqa: E501
+
+        :param response_codes: The response_codes of this V1beta1LoggerSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._response_codes = response_codes
+
+    @property
+    def sampling_percent(self):
+        """Gets the sampling_percent of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies the percentage of the requests whose payloads are logged, between 0 and 100, defaults to 100. The requests are sampled before their payloads are read so the requests which are not logged are not buffered.  # noqa: E501
+
+        :return: The sampling_percent of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._sampling_percent
+
+    @sampling_percent.setter
+    def sampling_percent(self, sampling_percent):
+        """Sets the sampling_percent of this V1beta1LoggerSpec.
+
+        Specifies the percentage of the requests whose payloads are logged, between 0 and 100, defaults to 100. The requests are sampled before their payloads are read so the requests which are not logged are not buffered.  # noqa: E501
+
+        :param sampling_percent: The sampling_percent of this V1beta1LoggerSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._sampling_percent = sampling_percent
+
     @property
     def url(self):
         """Gets the url of this V1beta1LoggerSpec.  # noqa: E501
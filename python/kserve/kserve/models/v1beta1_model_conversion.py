@@ -0,0 +1,280 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1ModelConversion(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'args': 'list[str]',
+        'command': 'list[str]',
+        'env': 'list[V1EnvVar]',
+        'image': 'str',
+        'output_storage_uri': 'str',
+        'resources': 'V1ResourceRequirements'
+    }
+
+    attribute_map = {
+        'args': 'args',
+        'command': 'command',
+        'env': 'env',
+        'image': 'image',
+        'output_storage_uri': 'outputStorageUri',
+        'resources': 'resources'
+    }
+
+    def __init__(self, args=None, command=None, env=None, image='', output_storage_uri='', resources=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1ModelConversion - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._args = None
+        self._command = None
+        self._env = None
+        self._image = None
+        self._output_storage_uri = None
+        self._resources = None
+        self.discriminator = None
+
+        if args is not None:
+            self.args = args
+        if command is not None:
+            self.command = command
+        if env is not None:
+            self.env = env
+        self.image = image
+        self.output_storage_uri = output_storage_uri
+        if resources is not None:
+            self.resources = resources
+
+    @property
+    def args(self):
+        """Gets the args of this V1beta1ModelConversion.  # noqa: E501
+
+        Arguments to the entrypoint of the conversion container.  # noqa: E501
+
+        :return: The args of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._args
+
+    @args.setter
+    def args(self, args):
+        """Sets the args of this V1beta1ModelConversion.
+
+        Arguments to the entrypoint of the conversion container.  # noqa: E501
+
+        :param args: The args of this V1beta1ModelConversion.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._args = args
+
+    @property
+    def command(self):
+        """Gets the command of this V1beta1ModelConversion.  # noqa: E501
+
+        Entrypoint array of the conversion container.  # noqa: E501
+
+        :return: The command of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._command
+
+    @command.setter
+    def command(self, command):
+        """Sets the command of this V1beta1ModelConversion.
+
+        Entrypoint array of the conversion container.  # noqa: E501
+
+        :param command: The command of this V1beta1ModelConversion.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._command = command
+
+    @property
+    def env(self):
+        """Gets the env of this V1beta1ModelConversion.  # noqa: E501
+
+        List of environment variables to set in the conversion container.  # noqa: E501
+
+        :return: The env of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: list[V1EnvVar]
+        """
+        return self._env
+
+    @env.setter
+    def env(self, env):
+        """Sets the env of this V1beta1ModelConversion.
+
+        List of environment variables to set in the conversion container.  # noqa: E501
+
+        :param env: The env of this V1beta1ModelConversion.  # noqa: E501
+        :type: list[V1EnvVar]
+        """
+
+        self._env = env
+
+    @property
+    def image(self):
+        """Gets the image of this V1beta1ModelConversion.  # noqa: E501
+
+        Image of the conversion container.  # noqa: E501
+
+        :return: The image of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1beta1ModelConversion.
+
+        Image of the conversion container.  # noqa: E501
+
+        :param image: The image of this V1beta1ModelConversion.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and image is None:  # noqa: E501
+            raise ValueError("Invalid value for `image`, must not be `None`")  # noqa: E501
+
+
+        self._image = image
+
+    @property
+    def output_storage_uri(self):
+        """Gets the output_storage_uri of this V1beta1ModelConversion.  # noqa: E501
+
+        The pvc://<pvcname>/<path> location the converted models are cached in, each conversion is stored under its own hash in this path.  # noqa: E501
+
+        :return: The output_storage_uri of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: str
+        """
+        return self._output_storage_uri
+
+    @output_storage_uri.setter
+    def output_storage_uri(self, output_storage_uri):
+        """Sets the output_storage_uri of this V1beta1ModelConversion.
+
+        The pvc://<pvcname>/<path> location the converted models are cached in, each conversion is stored under its own hash in this path.  # noqa: E501
+
+        :param output_storage_uri: The output_storage_uri of this V1beta1ModelConversion.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and output_storage_uri is None:  # noqa: E501
+            raise ValueError("Invalid value for `output_storage_uri`, must not be `None`")  # noqa: E501
+
+
+        self._output_storage_uri = output_storage_uri
+
+    @property
+    def resources(self):
+        """Gets the resources of this V1beta1ModelConversion.  # noqa: E501
+
+        Compute Resources required by the conversion container.  # noqa: E501
+
+        :return: The resources of this V1beta1ModelConversion.  # noqa: E501
+        :rtype: V1ResourceRequirements
+        """
+        return self._resources
+
+    @resources.setter
+    def resources(self, resources):
+        """Sets the resources of this V1beta1ModelConversion.
+
+        Compute Resources required by the conversion container.  # noqa: E501
+
+        :param resources: The resources of this V1beta1ModelConversion.  # noqa: E501
+        :type: V1ResourceRequirements
+        """
+
+        self._resources = resources
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1ModelConversion):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1ModelConversion):
+            return True
+
+        return self.to_dict() != other.to_dict()
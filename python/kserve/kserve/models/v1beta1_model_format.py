@@ -47,30 +47,58 @@ class V1beta1ModelFormat(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'framework_version': 'str',
         'name': 'str',
         'version': 'str'
     }
 
     attribute_map = {
+        'framework_version': 'frameworkVersion',
         'name': 'name',
         'version': 'version'
     }
 
-    def __init__(self, name='', version=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, framework_version=None, name='', version=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ModelFormat - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._framework_version = None
         self._name = None
         self._version = None
         self.discriminator = None
 
+        if framework_version is not None:
+            self.framework_version = framework_version
         if name is not None:
             self.name = name
         if version is not None:
             self.version = version
 
+    @property
+    def framework_version(self):
+        """Gets the framework_version of this V1beta1ModelFormat.  # noqa: E501
+
+        Version of the framework the model was saved with, e.g. \"1.4.2\" for a model pickled with scikit-learn 1.4.2. Used in validating that the runtime can load the model.  # noqa: E501
+
+        :return: The framework_version of this V1beta1ModelFormat.  # noqa: E501
+        :rtype: str
+        """
+        return self._framework_version
+
+    @framework_version.setter
+    def framework_version(self, framework_version):
+        """Sets the framework_version of this V1beta1ModelFormat.
+
+        Version of the framework the model was saved with, e.g. \"1.4.2\" for a model pickled with scikit-learn 1.4.2. Used in validating that the runtime can load the model.  # noqa: E501
+
+        :param framework_version: The framework_version of this V1beta1ModelFormat.  # noqa: E501
+        :type: str
+        """
+
+        self._framework_version = framework_version
+
     @property
     def name(self):
         """Gets the name of this V1beta1ModelFormat.  # noqa: E501
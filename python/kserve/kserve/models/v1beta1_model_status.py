@@ -49,6 +49,7 @@ class V1beta1ModelStatus(object):
     openapi_types = {
         'copies': 'V1beta1ModelCopies',
         'last_failure_info': 'V1beta1FailureInfo',
+        'oom_remediation': 'V1beta1OOMRemediation',
         'states': 'V1beta1ModelRevisionStates',
         'transition_status': 'str'
     }
@@ -56,11 +57,12 @@ class V1beta1ModelStatus(object):
     attribute_map = {
         'copies': 'copies',
         'last_failure_info': 'lastFailureInfo',
+        'oom_remediation': 'oomRemediation',
         'states': 'states',
         'transition_status': 'transitionStatus'
     }
 
-    def __init__(self, copies=None, last_failure_info=None, states=None, transition_status='', local_vars_configuration=None):  # noqa: E501
+    def __init__(self, copies=None, last_failure_info=None, oom_remediation=None, states=None, transition_status='', local_vars_configuration=None):  # noqa: E501
         """V1beta1ModelStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -68,6 +70,7 @@ class V1beta1ModelStatus(object):
 
         self._copies = None
         self._last_failure_info = None
+        self._oom_remediation = None
         self._states = None
         self._transition_status = None
         self.discriminator = None
@@ -76,6 +79,8 @@ class V1beta1ModelStatus(object):
             self.copies = copies
         if last_failure_info is not None:
             self.last_failure_info = last_failure_info
+        if oom_remediation is not None:
+            self.oom_remediation = oom_remediation
         if states is not None:
             self.states = states
         self.transition_status = transition_status
@@ -122,6 +127,29 @@ class V1beta1ModelStatus(object):
 
         self._last_failure_info = last_failure_info
 
+    @property
+    def oom_remediation(self):
+        """Gets the oom_remediation of this V1beta1ModelStatus.  # noqa: E501
+
+        Memory raised by the OOM remediation policy after the predictor's model server was OOMKilled.  # noqa: E501
+
+        :return: The oom_remediation of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: V1beta1OOMRemediation
+        """
+        return self._oom_remediation
+
+    @oom_remediation.setter
+    def oom_remediation(self, oom_remediation):
+        """Sets the oom_remediation of this V1beta1ModelStatus.
+
+        Memory raised by the OOM remediation policy after the predictor's model server was OOMKilled.  # noqa: E501
+
+        :param oom_remediation: The oom_remediation of this V1beta1ModelStatus.  # noqa: E501
+        :type: V1beta1OOMRemediation
+        """
+
+        self._oom_remediation = oom_remediation
+
     @property
     def states(self):
         """Gets the states of this V1beta1ModelStatus.  # noqa: E501
@@ -0,0 +1,196 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1OOMRemediation(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'count': 'int',
+        'last_remediation_time': 'V1Time',
+        'memory': 'str'
+    }
+
+    attribute_map = {
+        'count': 'count',
+        'last_remediation_time': 'lastRemediationTime',
+        'memory': 'memory'
+    }
+
+    def __init__(self, count=0, last_remediation_time=None, memory='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1OOMRemediation - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._count = None
+        self._last_remediation_time = None
+        self._memory = None
+        self.discriminator = None
+
+        self.count = count
+        if last_remediation_time is not None:
+            self.last_remediation_time = last_remediation_time
+        self.memory = memory
+
+    @property
+    def count(self):
+        """Gets the count of this V1beta1OOMRemediation.  # noqa: E501
+
+        Number of times the memory was raised  # noqa: E501
+
+        :return: The count of this V1beta1OOMRemediation.  # noqa: E501
+        :rtype: int
+        """
+        return self._count
+
+    @count.setter
+    def count(self, count):
+        """Sets the count of this V1beta1OOMRemediation.
+
+        Number of times the memory was raised  # noqa: E501
+
+        :param count: The count of this V1beta1OOMRemediation.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and count is None:  # noqa: E501
+            raise ValueError("Invalid value for `count`, must not be `None`")  # noqa: E501
+
+
+        self._count = count
+
+    @property
+    def last_remediation_time(self):
+        """Gets the last_remediation_time of this V1beta1OOMRemediation.  # noqa: E501
+
+        Time of the last remediation  # noqa: E501
+
+        :return: The last_remediation_time of this V1beta1OOMRemediation.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._last_remediation_time
+
+    @last_remediation_time.setter
+    def last_remediation_time(self, last_remediation_time):
+        """Sets the last_remediation_time of this V1beta1OOMRemediation.
+
+        Time of the last remediation  # noqa: E501
+
+        :param last_remediation_time: The last_remediation_time of this V1beta1OOMRemediation.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._last_remediation_time = last_remediation_time
+
+    @property
+    def memory(self):
+        """Gets the memory of this V1beta1OOMRemediation.  # noqa: E501
+
+        Memory request and limit of the kserve container after the last remediation  # noqa: E501
+
+        :return: The memory of this V1beta1OOMRemediation.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory
+
+    @memory.setter
+    def memory(self, memory):
+        """Sets the memory of this V1beta1OOMRemediation.
+
+        Memory request and limit of the kserve container after the last remediation  # noqa: E501
+
+        :param memory: The memory of this V1beta1OOMRemediation.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and memory is None:  # noqa: E501
+            raise ValueError("Invalid value for `memory`, must not be `None`")  # noqa: E501
+
+
+        self._memory = memory
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1OOMRemediation):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1OOMRemediation):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -54,6 +54,7 @@ class V1beta1PredictorSpec(object):
         'canary_traffic_percent': 'int',
         'container_concurrency': 'int',
         'containers': 'list[V1Container]',
+        'conversion': 'V1beta1ModelConversion',
         'dns_config': 'V1PodDNSConfig',
         'dns_policy': 'str',
         'enable_service_links': 'bool',
@@ -70,9 +71,11 @@ class V1beta1PredictorSpec(object):
         'max_replicas': 'int',
         'min_replicas': 'int',
         'model': 'V1beta1ModelSpec',
+        'mount_cloud_identity': 'bool',
         'node_name': 'str',
         'node_selector': 'dict(str, str)',
         'onnx': 'V1beta1ONNXRuntimeSpec',
+        'optimization': 'V1beta1GraphOptimization',
         'os': 'V1PodOS',
         'overhead': 'dict(str, ResourceQuantity)',
         'paddle': 'V1beta1PaddleServerSpec',
@@ -81,8 +84,11 @@ class V1beta1PredictorSpec(object):
         'priority': 'int',
         'priority_class_name': 'str',
         'pytorch': 'V1beta1TorchServeSpec',
+        'rate_limit': 'V1beta1RateLimit',
         'readiness_gates': 'list[V1PodReadinessGate]',
+        'refresh_schedule': 'str',
         'restart_policy': 'str',
+        'retries': 'V1beta1RetryPolicy',
         'runtime_class_name': 'str',
         'scale_metric': 'str',
         'scale_target': 'int',
@@ -99,6 +105,7 @@ class V1beta1PredictorSpec(object):
         'timeout': 'int',
         'tolerations': 'list[V1Toleration]',
         'topology_spread_constraints': 'list[V1TopologySpreadConstraint]',
+        'traffic_mirror': 'V1beta1TrafficMirror',
         'triton': 'V1beta1TritonSpec',
         'volumes': 'list[V1Volume]',
         'xgboost': 'V1beta1XGBoostSpec'
@@ -112,6 +119,7 @@ class V1beta1PredictorSpec(object):
         'canary_traffic_percent': 'canaryTrafficPercent',
         'container_concurrency': 'containerConcurrency',
         'containers': 'containers',
+        'conversion': 'conversion',
         'dns_config': 'dnsConfig',
         'dns_policy': 'dnsPolicy',
         'enable_service_links': 'enableServiceLinks',
@@ -128,9 +136,11 @@ class V1beta1PredictorSpec(object):
         'max_replicas': 'maxReplicas',
         'min_replicas': 'minReplicas',
         'model': 'model',
+        'mount_cloud_identity': 'mountCloudIdentity',
         'node_name': 'nodeName',
         'node_selector': 'nodeSelector',
         'onnx': 'onnx',
+        'optimization': 'optimization',
         'os': 'os',
         'overhead': 'overhead',
         'paddle': 'paddle',
@@ -139,8 +149,11 @@ class V1beta1PredictorSpec(object):
         'priority': 'priority',
         'priority_class_name': 'priorityClassName',
         'pytorch': 'pytorch',
+        'rate_limit': 'rateLimit',
         'readiness_gates': 'readinessGates',
+        'refresh_schedule': 'refreshSchedule',
         'restart_policy': 'restartPolicy',
+        'retries': 'retries',
         'runtime_class_name': 'runtimeClassName',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
@@ -157,12 +170,13 @@ class V1beta1PredictorSpec(object):
         'timeout': 'timeout',
         'tolerations': 'tolerations',
         'topology_spread_constraints': 'topologySpreadConstraints',
+        'traffic_mirror': 'trafficMirror',
         'triton': 'triton',
         'volumes': 'volumes',
         'xgboost': 'xgboost'
     }
 
-    def __init__(self, active_deadline_seconds=None, affinity=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, hostname=None, image_pull_secrets=None, init_containers=None, lightgbm=None, logger=None, max_replicas=None, min_replicas=None, model=None, node_name=None, node_selector=None, onnx=None, os=None, overhead=None, paddle=None, pmml=None, preemption_policy=None, priority=None, priority_class_name=None, pytorch=None, readiness_gates=None, restart_policy=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, sklearn=None, subdomain=None, tensorflow=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, triton=None, volumes=None, xgboost=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_deadline_seconds=None, affinity=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, conversion=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, hostname=None, image_pull_secrets=None, init_containers=None, lightgbm=None, logger=None, max_replicas=None, min_replicas=None, model=None, mount_cloud_identity=None, node_name=None, node_selector=None, onnx=None, optimization=None, os=None, overhead=None, paddle=None, pmml=None, preemption_policy=None, priority=None, priority_class_name=None, pytorch=None, rate_limit=None, readiness_gates=None, refresh_schedule=None, restart_policy=None, retries=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, sklearn=None, subdomain=None, tensorflow=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, traffic_mirror=None, triton=None, volumes=None, xgboost=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1PredictorSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -175,6 +189,7 @@ class V1beta1PredictorSpec(object):
         self._canary_traffic_percent = None
         self._container_concurrency = None
         self._containers = None
+        self._conversion = None
         self._dns_config = None
         self._dns_policy = None
         self._enable_service_links = None
@@ -191,9 +206,11 @@ class V1beta1PredictorSpec(object):
         self._max_replicas = None
         self._min_replicas = None
         self._model = None
+        self._mount_cloud_identity = None
         self._node_name = None
         self._node_selector = None
         self._onnx = None
+        self._optimization = None
         self._os = None
         self._overhead = None
         self._paddle = None
@@ -202,8 +219,11 @@ class V1beta1PredictorSpec(object):
         self._priority = None
         self._priority_class_name = None
         self._pytorch = None
+        self._rate_limit = None
         self._readiness_gates = None
+        self._refresh_schedule = None
         self._restart_policy = None
+        self._retries = None
         self._runtime_class_name = None
         self._scale_metric = None
         self._scale_target = None
@@ -220,6 +240,7 @@ class V1beta1PredictorSpec(object):
         self._timeout = None
         self._tolerations = None
         self._topology_spread_constraints = None
+        self._traffic_mirror = None
         self._triton = None
         self._volumes = None
         self._xgboost = None
@@ -239,6 +260,8 @@ class V1beta1PredictorSpec(object):
             self.container_concurrency = container_concurrency
         if containers is not None:
             self.containers = containers
+        if conversion is not None:
+            self.conversion = conversion
         if dns_config is not None:
             self.dns_config = dns_config
         if dns_policy is not None:
@@ -271,12 +294,16 @@ class V1beta1PredictorSpec(object):
             self.min_replicas = min_replicas
         if model is not None:
             self.model = model
+        if mount_cloud_identity is not None:
+            self.mount_cloud_identity = mount_cloud_identity
         if node_name is not None:
             self.node_name = node_name
         if node_selector is not None:
             self.node_selector = node_selector
         if onnx is not None:
             self.onnx = onnx
+        if optimization is not None:
+            self.optimization = optimization
         if os is not None:
             self.os = os
         if overhead is not None:
@@ -293,10 +320,16 @@ class V1beta1PredictorSpec(object):
             self.priority_class_name = priority_class_name
         if pytorch is not None:
             self.pytorch = pytorch
+        if rate_limit is not None:
+            self.rate_limit = rate_limit
         if readiness_gates is not None:
             self.readiness_gates = readiness_gates
+        if refresh_schedule is not None:
+            self.refresh_schedule = refresh_schedule
         if restart_policy is not None:
             self.restart_policy = restart_policy
+        if retries is not None:
+            self.retries = retries
         if runtime_class_name is not None:
             self.runtime_class_name = runtime_class_name
         if scale_metric is not None:
@@ -329,6 +362,8 @@ class V1beta1PredictorSpec(object):
             self.tolerations = tolerations
         if topology_spread_constraints is not None:
             self.topology_spread_constraints = topology_spread_constraints
+        if traffic_mirror is not None:
+            self.traffic_mirror = traffic_mirror
         if triton is not None:
             self.triton = triton
         if volumes is not None:
@@ -493,6 +528,29 @@ class V1beta1PredictorSpec(object):
 
         self._containers = containers
 
+    @property
+    def conversion(self):
+        """Gets the conversion of this V1beta1PredictorSpec.  # noqa: E501
+
+        Conversion converts the model before it is served, e.g. building a TensorRT-LLM engine from a Hugging Face model. The conversion runs once per model, conversion and node selector, the predictor serves the converted model from the output storage.  # noqa: E501
+
+        :return: The conversion of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: V1beta1ModelConversion
+        """
+        return self._conversion
+
+    @conversion.setter
+    def conversion(self, conversion):
+        """Sets the conversion of this V1beta1PredictorSpec.
+
+        Conversion converts the model before it is served, e.g. building a TensorRT-LLM engine from a Hugging Face model. The conversion runs once per model, conversion and node selector, the predictor serves the converted model from the output storage.  # noqa: E501
+
+        :param conversion: The conversion of this V1beta1PredictorSpec.  # noqa: E501
+        :type: V1beta1ModelConversion
+        """
+
+        self._conversion = conversion
+
     @property
     def dns_config(self):
         """Gets the dns_config of this V1beta1PredictorSpec.  # noqa: E501
@@ -853,6 +911,29 @@ class V1beta1PredictorSpec(object):
 
         self._model = model
 
+    @property
+    def mount_cloud_identity(self):
+        """Gets the mount_cloud_identity of this V1beta1PredictorSpec.  # noqa: E501
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :return: The mount_cloud_identity of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._mount_cloud_identity
+
+    @mount_cloud_identity.setter
+    def mount_cloud_identity(self, mount_cloud_identity):
+        """Sets the mount_cloud_identity of this V1beta1PredictorSpec.
+
+        Mount the cloud credentials of the service account of the component in the kserve-container, as they are mounted in the storage initializer, e.g. the IRSA role, the GKE workload identity or the credential secrets, so the model can read the cloud stores at inference time. The credentials are mounted in the storage initializer only otherwise.  # noqa: E501
+
+        :param mount_cloud_identity: The mount_cloud_identity of this V1beta1PredictorSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._mount_cloud_identity = mount_cloud_identity
+
     @property
     def node_name(self):
         """Gets the node_name of this V1beta1PredictorSpec.  # noqa: E501
@@ -920,6 +1001,29 @@ class V1beta1PredictorSpec(object):
 
         self._onnx = onnx
 
+    @property
+    def optimization(self):
+        """Gets the optimization of this V1beta1PredictorSpec.  # noqa: E501
+
+        Optimization of the model graph ahead of time by the runtime, e.g. building the TensorRT engines of an ONNX model. Supported for the onnx model format served by ONNX Runtime or Triton.  # noqa: E501
+
+        :return: The optimization of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: V1beta1GraphOptimization
+        """
+        return self._optimization
+
+    @optimization.setter
+    def optimization(self, optimization):
+        """Sets the optimization of this V1beta1PredictorSpec.
+
+        Optimization of the model graph ahead of time by the runtime, e.g. building the TensorRT engines of an ONNX model. Supported for the onnx model format served by ONNX Runtime or Triton.  # noqa: E501
+
+        :param optimization: The optimization of this V1beta1PredictorSpec.  # noqa: E501
+        :type: V1beta1GraphOptimization
+        """
+
+        self._optimization = optimization
+
     @property
     def os(self):
         """Gets the os of this V1beta1PredictorSpec.  # noqa: E501
@@ -1096,6 +1200,29 @@ class V1beta1PredictorSpec(object):
 
         self._pytorch = pytorch
 
+    @property
+    def rate_limit(self):
+        """Gets the rate_limit of this V1beta1PredictorSpec.  # noqa: E501
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :return: The rate_limit of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: V1beta1RateLimit
+        """
+        return self._rate_limit
+
+    @rate_limit.setter
+    def rate_limit(self, rate_limit):
+        """Sets the rate_limit of this V1beta1PredictorSpec.
+
+        Limit the rate of the requests sent to each replica, the requests above the limit are rejected  # noqa: E501
+
+        :param rate_limit: The rate_limit of this V1beta1PredictorSpec.  # noqa: E501
+        :type: V1beta1RateLimit
+        """
+
+        self._rate_limit = rate_limit
+
     @property
     def readiness_gates(self):
         """Gets the readiness_gates of this V1beta1PredictorSpec.  # noqa: E501
@@ -1119,6 +1246,29 @@ class V1beta1PredictorSpec(object):
 
         self._readiness_gates = readiness_gates
 
+    @property
+    def refresh_schedule(self):
+        """Gets the refresh_schedule of this V1beta1PredictorSpec.  # noqa: E501
+
+        RefreshSchedule is a cron schedule in the standard five field format, e.g. "0 2 * * *", on which the model is downloaded again from its storage uri. A new revision is rolled out only when the digest of the model changed.  # noqa: E501
+
+        :return: The refresh_schedule of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._refresh_schedule
+
+    @refresh_schedule.setter
+    def refresh_schedule(self, refresh_schedule):
+        """Sets the refresh_schedule of this V1beta1PredictorSpec.
+
+        RefreshSchedule is a cron schedule in the standard five field format, e.g. "0 2 * * *", on which the model is downloaded again from its storage uri. A new revision is rolled out only when the digest of the model changed.  # noqa: E501
+
+        :param refresh_schedule: The refresh_schedule of this V1beta1PredictorSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._refresh_schedule = refresh_schedule
+
     @property
     def restart_policy(self):
         """Gets the restart_policy of this V1beta1PredictorSpec.  # noqa: E501
@@ -1142,6 +1292,29 @@ class V1beta1PredictorSpec(object):
 
         self._restart_policy = restart_policy
 
+    @property
+    def retries(self):
+        """Gets the retries of this V1beta1PredictorSpec.  # noqa: E501
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :return: The retries of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: V1beta1RetryPolicy
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1beta1PredictorSpec.
+
+        Retry the requests routed to the component by the ingress, e.g. on the 503 returned while the model server restarts  # noqa: E501
+
+        :param retries: The retries of this V1beta1PredictorSpec.  # noqa: E501
+        :type: V1beta1RetryPolicy
+        """
+
+        self._retries = retries
+
     @property
     def runtime_class_name(self):
         """Gets the runtime_class_name of this V1beta1PredictorSpec.  # noqa: E501
@@ -1169,7 +1342,7 @@ class V1beta1PredictorSpec(object):
     def scale_metric(self):
         """Gets the scale_metric of this V1beta1PredictorSpec.  # noqa: E501
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :return: The scale_metric of this V1beta1PredictorSpec.  # noqa: E501
         :rtype: str
@@ -1180,7 +1353,7 @@ class V1beta1PredictorSpec(object):
     def scale_metric(self, scale_metric):
         """Sets the scale_metric of this V1beta1PredictorSpec.
 
-        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
+        ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory, gpu, gpu-memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).  # noqa: E501
 
         :param scale_metric: The scale_metric of this V1beta1PredictorSpec.  # noqa: E501
         :type: str
@@ -1504,6 +1677,29 @@ class V1beta1PredictorSpec(object):
 
         self._topology_spread_constraints = topology_spread_constraints
 
+    @property
+    def traffic_mirror(self):
+        """Gets the traffic_mirror of this V1beta1PredictorSpec.  # noqa: E501
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :return: The traffic_mirror of this V1beta1PredictorSpec.  # noqa: E501
+        :rtype: V1beta1TrafficMirror
+        """
+        return self._traffic_mirror
+
+    @traffic_mirror.setter
+    def traffic_mirror(self, traffic_mirror):
+        """Sets the traffic_mirror of this V1beta1PredictorSpec.
+
+        Mirror the requests to the latest ready revision while canaryTrafficPercent keeps the traffic on the last rolled out revision, the responses of the mirrored requests are discarded  # noqa: E501
+
+        :param traffic_mirror: The traffic_mirror of this V1beta1PredictorSpec.  # noqa: E501
+        :type: V1beta1TrafficMirror
+        """
+
+        self._traffic_mirror = traffic_mirror
+
     @property
     def triton(self):
         """Gets the triton of this V1beta1PredictorSpec.  # noqa: E501
@@ -0,0 +1,164 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1SmokeTestResponseMatcher(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'conditions': 'list[str]',
+        'pattern': 'str'
+    }
+
+    attribute_map = {
+        'conditions': 'conditions',
+        'pattern': 'pattern'
+    }
+
+    def __init__(self, conditions=None, pattern=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1SmokeTestResponseMatcher - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._conditions = None
+        self._pattern = None
+        self.discriminator = None
+
+        if conditions is not None:
+            self.conditions = conditions
+        if pattern is not None:
+            self.pattern = pattern
+
+    @property
+    def conditions(self):
+        """Gets the conditions of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+
+        Paths which must exist in the JSON body, in the GJSON syntax like the conditions of the InferenceGraph steps, e.g. predictions.0 or predictions.#(==1)  # noqa: E501
+
+        :return: The conditions of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._conditions
+
+    @conditions.setter
+    def conditions(self, conditions):
+        """Sets the conditions of this V1beta1SmokeTestResponseMatcher.
+
+        Paths which must exist in the JSON body, in the GJSON syntax like the conditions of the InferenceGraph steps, e.g. predictions.0 or predictions.#(==1)  # noqa: E501
+
+        :param conditions: The conditions of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._conditions = conditions
+
+    @property
+    def pattern(self):
+        """Gets the pattern of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+
+        Regular expression, in the RE2 syntax, matching a part of the body  # noqa: E501
+
+        :return: The pattern of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+        :rtype: str
+        """
+        return self._pattern
+
+    @pattern.setter
+    def pattern(self, pattern):
+        """Sets the pattern of this V1beta1SmokeTestResponseMatcher.
+
+        Regular expression, in the RE2 syntax, matching a part of the body  # noqa: E501
+
+        :param pattern: The pattern of this V1beta1SmokeTestResponseMatcher.  # noqa: E501
+        :type: str
+        """
+
+        self._pattern = pattern
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1SmokeTestResponseMatcher):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1SmokeTestResponseMatcher):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,247 @@
+# Copyright 2022 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1SmokeTestSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'expected_response': 'V1beta1SmokeTestResponseMatcher',
+        'expected_status': 'int',
+        'path': 'str',
+        'payload': 'str',
+        'timeout_seconds': 'int'
+    }
+
+    attribute_map = {
+        'expected_response': 'expectedResponse',
+        'expected_status': 'expectedStatus',
+        'path': 'path',
+        'payload': 'payload',
+        'timeout_seconds': 'timeoutSeconds'
+    }
+
+    def __init__(self, expected_response=None, expected_status=None, path=None, payload=None, timeout_seconds=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1SmokeTestSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._expected_response = None
+        self._expected_status = None
+        self._path = None
+        self._payload = None
+        self._timeout_seconds = None
+        self.discriminator = None
+
+        if expected_response is not None:
+            self.expected_response = expected_response
+        if expected_status is not None:
+            self.expected_status = expected_status
+        if path is not None:
+            self.path = path
+        self.payload = payload
+        if timeout_seconds is not None:
+            self.timeout_seconds = timeout_seconds
+
+    @property
+    def expected_response(self):
+        """Gets the expected_response of this V1beta1SmokeTestSpec.  # noqa: E501
+
+
+        :return: The expected_response of this V1beta1SmokeTestSpec.  # noqa: E501
+        :rtype: V1beta1SmokeTestResponseMatcher
+        """
+        return self._expected_response
+
+    @expected_response.setter
+    def expected_response(self, expected_response):
+        """Sets the expected_response of this V1beta1SmokeTestSpec.
+
+
+        :param expected_response: The expected_response of this V1beta1SmokeTestSpec.  # noqa: E501
+        :type: V1beta1SmokeTestResponseMatcher
+        """
+
+        self._expected_response = expected_response
+
+    @property
+    def expected_status(self):
+        """Gets the expected_status of this V1beta1SmokeTestSpec.  # noqa: E501
+
+        Status code of the response, defaults to 200  # noqa: E501
+
+        :return: The expected_status of this V1beta1SmokeTestSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._expected_status
+
+    @expected_status.setter
+    def expected_status(self, expected_status):
+        """Sets the expected_status of this V1beta1SmokeTestSpec.
+
+        Status code of the response, defaults to 200  # noqa: E501
+
+        :param expected_status: The expected_status of this V1beta1SmokeTestSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._expected_status = expected_status
+
+    @property
+    def path(self):
+        """Gets the path of this V1beta1SmokeTestSpec.  # noqa: E501
+
+        Path of the request, defaults to the predict path of the v1 protocol, /v1/models/<name>:predict  # noqa: E501
+
+        :return: The path of this V1beta1SmokeTestSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._path
+
+    @path.setter
+    def path(self, path):
+        """Sets the path of this V1beta1SmokeTestSpec.
+
+        Path of the request, defaults to the predict path of the v1 protocol, /v1/models/<name>:predict  # noqa: E501
+
+        :param path: The path of this V1beta1SmokeTestSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._path = path
+
+    @property
+    def payload(self):
+        """Gets the payload of this V1beta1SmokeTestSpec.  # noqa: E501
+
+        JSON payload of the POST request  # noqa: E501
+
+        :return: The payload of this V1beta1SmokeTestSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._payload
+
+    @payload.setter
+    def payload(self, payload):
+        """Sets the payload of this V1beta1SmokeTestSpec.
+
+        JSON payload of the POST request  # noqa: E501
+
+        :param payload: The payload of this V1beta1SmokeTestSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and payload is None:  # noqa: E501
+            raise ValueError("Invalid value for `payload`, must not be `None`")  # noqa: E501
+
+        self._payload = payload
+
+    @property
+    def timeout_seconds(self):
+        """Gets the timeout_seconds of this V1beta1SmokeTestSpec.  # noqa: E501
+
+        Number of seconds to wait for the response, defaults to 30  # noqa: E501
+
+        :return: The timeout_seconds of this V1beta1SmokeTestSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._timeout_seconds
+
+    @timeout_seconds.setter
+    def timeout_seconds(self, timeout_seconds):
+        """Sets the timeout_seconds of this V1beta1SmokeTestSpec.
+
+        Number of seconds to wait for the response, defaults to 30  # noqa: E501
+
+        :param timeout_seconds: The timeout_seconds of this V1beta1SmokeTestSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._timeout_seconds = timeout_seconds
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1SmokeTestSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1SmokeTestSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -13,8 +13,10 @@
 # limitations under the License.
 
 import base64
+import binascii
 import glob
 import gzip
+import hashlib
 import logging
 import mimetypes
 import os
@@ -23,18 +25,24 @@ import json
 import shutil
 import tarfile
 import tempfile
+import threading
+import time
+from concurrent.futures import ThreadPoolExecutor
 from typing import Dict
 import zipfile
 from urllib.parse import urlparse
 import requests
 from pathlib import Path
+from azure.core import exceptions as azure_exceptions
 from azure.storage.blob import BlobServiceClient
 from azure.storage.blob._list_blobs_helper import BlobPrefix
 from azure.storage.fileshare import ShareServiceClient
 
 from botocore.client import Config
 from botocore import UNSIGNED
+from botocore import exceptions as botocore_exceptions
 import boto3
+from google.api_core import exceptions as google_exceptions
 from google.auth import exceptions
 from google.cloud import storage
 
@@ -44,6 +52,12 @@ _GCS_PREFIX = "gs://"
 _S3_PREFIX = "s3://"
 _HDFS_PREFIX = "hdfs://"
 _WEBHDFS_PREFIX = "webhdfs://"
+_HF_PREFIX = "hf://"
+_OCI_PREFIX = "oci://"
+_OSS_PREFIX = "oss://"
+_COS_PREFIX = "cos://"
+_R2_PREFIX = "r2://"
+_SWIFT_PREFIX = "swift://"
 _AZURE_BLOB_RE = "https://(.+?).blob.core.windows.net/(.+)"
 _AZURE_FILE_RE = "https://(.+?).file.core.windows.net/(.+)"
 _LOCAL_PREFIX = "file://"
@@ -53,7 +67,80 @@ _HEADERS_SUFFIX = "-headers"
 _PVC_PREFIX = "/mnt/pvc"
 
 _HDFS_SECRET_DIRECTORY = "/var/secrets/kserve-hdfscreds"
-_HDFS_FILE_SECRETS = ["KERBEROS_KEYTAB", "TLS_CERT", "TLS_KEY", "TLS_CA"]
+_HDFS_FILE_SECRETS = ["KERBEROS_KEYTAB", "KRB5_CONF", "TLS_CERT", "TLS_KEY", "TLS_CA"]
+# The Kerberos ticket is acquired again from the keytab on this interval while the model is downloaded, a large model
+# can take longer to download than the ticket lifetime of the KDC
+_DEFAULT_KERBEROS_RENEW_SECONDS = "3600"
+
+# The image pull secrets of the pod are mounted in a directory per secret
+_OCI_SECRET_DIRECTORY = "/var/secrets/kserve-oci"
+_OCI_TITLE_ANNOTATION = "org.opencontainers.image.title"
+_OCI_UNPACK_ANNOTATION = "io.deis.oras.content.unpack"
+# The Keystone credentials of the Swift storage config and their environment variables
+_SWIFT_STORAGE_SPEC_ENVS = {
+    "auth_url": "OS_AUTH_URL",
+    "username": "OS_USERNAME",
+    "password": "OS_PASSWORD",
+    "project_name": "OS_PROJECT_NAME",
+    "project_id": "OS_PROJECT_ID",
+    "user_domain_name": "OS_USER_DOMAIN_NAME",
+    "project_domain_name": "OS_PROJECT_DOMAIN_NAME",
+    "region": "OS_REGION_NAME",
+    "application_credential_id": "OS_APPLICATION_CREDENTIAL_ID",
+    "application_credential_secret": "OS_APPLICATION_CREDENTIAL_SECRET",
+}
+# The IBM COS objects are read with the IAM bearer token of the API key of COS_API_KEY, renewed before it expires
+_COS_IAM_ENDPOINT = "https://iam.cloud.ibm.com/identity/token"
+_COS_IAM_TOKEN_RENEWAL_SECONDS = 300
+# The S3 and GCS objects larger than a part are downloaded with concurrent ranged requests, the completed parts are
+# recorded next to the partial file so a restarted storage initializer resumes the download
+_DOWNLOAD_WORKERS_ENV = "STORAGE_DOWNLOAD_WORKERS"
+_DEFAULT_DOWNLOAD_WORKERS = 4
+_DOWNLOAD_PART_SIZE = 32 * 1024 * 1024
+_PARTIAL_FILE_SUFFIX = ".kserve-partial"
+_PARTS_FILE_SUFFIX = ".kserve-parts"
+# Written in the model directory once the whole storage uri is downloaded
+_COMPLETION_MARKER = ".kserve-download-complete"
+
+# The presign broker exchanges the service account token and the client certificate mounted in its directory for the
+# presigned urls of the model files, the credentials of the storage are never mounted in the pod
+_PRESIGN_BROKER_URL_ENV = "STORAGE_PRESIGN_BROKER_URL"
+_PRESIGN_BROKER_DIR_ENV = "STORAGE_PRESIGN_BROKER_DIR"
+_DEFAULT_PRESIGN_BROKER_DIR = "/var/run/secrets/kserve/presign-broker"
+_PRESIGN_BROKER_TIMEOUT_SECONDS = 60
+
+# Exit codes of the storage initializer, the controller retries the transient failures and reports the permanent ones,
+# following the sysexits.h codes. The other failures exit with 1.
+EXIT_CODE_CORRUPT_MODEL = 65
+EXIT_CODE_NOT_FOUND = 66
+EXIT_CODE_TRANSIENT = 75
+EXIT_CODE_ACCESS_DENIED = 77
+EXIT_CODE_UNKNOWN = 1
+
+
+class StorageError(RuntimeError):
+    """Failure to download the model, the exit code of the storage initializer tells whether it is retried"""
+    exit_code = EXIT_CODE_UNKNOWN
+
+
+class TransientStorageError(StorageError):
+    """The storage is unreachable or failed, e.g. a network error or a 5xx response"""
+    exit_code = EXIT_CODE_TRANSIENT
+
+
+class ModelNotFoundError(StorageError):
+    """The storage uri does not exist or has no files"""
+    exit_code = EXIT_CODE_NOT_FOUND
+
+
+class AccessDeniedError(StorageError):
+    """The credentials are missing or are not allowed to read the storage uri"""
+    exit_code = EXIT_CODE_ACCESS_DENIED
+
+
+class CorruptModelError(StorageError):
+    """The downloaded archive or file is corrupt"""
+    exit_code = EXIT_CODE_CORRUPT_MODEL
 
 
 class Storage(object):  # pylint: disable=too-few-public-methods
@@ -63,7 +150,7 @@ class Storage(object):  # pylint: disable=too-few-public-methods
         logging.info("Copying contents of %s to local", uri)
 
         if uri.startswith(_PVC_PREFIX) and not os.path.exists(uri):
-            raise Exception(f"Cannot locate source uri {uri} for PVC")
+            raise ModelNotFoundError(f"Cannot locate source uri {uri} for PVC")
 
         is_local = False
         if uri.startswith(_LOCAL_PREFIX) or os.path.exists(uri):
@@ -77,12 +164,30 @@ class Storage(object):  # pylint: disable=too-few-public-methods
         elif not os.path.exists(out_dir):
             os.mkdir(out_dir)
 
-        if uri.startswith(_GCS_PREFIX):
+        if Storage._is_download_completed(uri, out_dir):
+            logging.info("Skipping the download of %s, already completed in %s", uri, out_dir)
+            return out_dir
+
+        if os.getenv(_PRESIGN_BROKER_URL_ENV) and not is_local:
+            Storage._download_presigned(uri, out_dir)
+        elif uri.startswith(_GCS_PREFIX):
             Storage._download_gcs(uri, out_dir)
         elif uri.startswith(_S3_PREFIX):
             Storage._download_s3(uri, out_dir)
+        elif uri.startswith(_OSS_PREFIX):
+            Storage._download_oss(uri, out_dir)
+        elif uri.startswith(_COS_PREFIX):
+            Storage._download_cos(uri, out_dir)
+        elif uri.startswith(_R2_PREFIX):
+            Storage._download_r2(uri, out_dir)
+        elif uri.startswith(_SWIFT_PREFIX):
+            Storage._download_swift(uri, out_dir)
         elif uri.startswith(_HDFS_PREFIX) or uri.startswith(_WEBHDFS_PREFIX):
             Storage._download_hdfs(uri, out_dir)
+        elif uri.startswith(_HF_PREFIX):
+            Storage._download_hf(uri, out_dir)
+        elif uri.startswith(_OCI_PREFIX):
+            Storage._download_oci(uri, out_dir)
         elif re.search(_AZURE_BLOB_RE, uri):
             Storage._download_azure_blob(uri, out_dir)
         elif re.search(_AZURE_FILE_RE, uri):
@@ -97,12 +202,69 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             return out_dir
         else:
             raise Exception("Cannot recognize storage type for " + uri +
-                            "\n'%s', '%s', '%s', and '%s' are the current available storage type." %
-                            (_GCS_PREFIX, _S3_PREFIX, _LOCAL_PREFIX, _HTTP_PREFIX))
+                            "\n'%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s' and '%s' are the current "
+                            "available storage type." % (_GCS_PREFIX, _S3_PREFIX, _OSS_PREFIX, _COS_PREFIX, _R2_PREFIX,
+                                                         _SWIFT_PREFIX, _LOCAL_PREFIX, _HTTP_PREFIX, _HF_PREFIX,
+                                                         _OCI_PREFIX))
 
+        with open(os.path.join(out_dir, _COMPLETION_MARKER), "w") as f:
+            f.write(uri)
         logging.info("Successfully copied %s to %s", uri, out_dir)
         return out_dir
 
+    @staticmethod
+    def exit_code(error: BaseException) -> int:
+        """Returns the exit code of the storage initializer for the error raised by download"""
+        if isinstance(error, StorageError):
+            return error.exit_code
+        status = None
+        if isinstance(error, botocore_exceptions.ClientError):
+            code = error.response.get("Error", {}).get("Code", "")
+            if code in ("NoSuchBucket", "NoSuchKey"):
+                return EXIT_CODE_NOT_FOUND
+            if code in ("AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch"):
+                return EXIT_CODE_ACCESS_DENIED
+            status = error.response.get("ResponseMetadata", {}).get("HTTPStatusCode")
+        elif isinstance(error, botocore_exceptions.NoCredentialsError):
+            return EXIT_CODE_ACCESS_DENIED
+        elif isinstance(error, (botocore_exceptions.EndpointConnectionError, botocore_exceptions.ConnectionClosedError,
+                                botocore_exceptions.ReadTimeoutError, botocore_exceptions.ConnectTimeoutError)):
+            return EXIT_CODE_TRANSIENT
+        elif isinstance(error, google_exceptions.GoogleAPICallError):
+            status = error.code
+        elif isinstance(error, azure_exceptions.ClientAuthenticationError):
+            return EXIT_CODE_ACCESS_DENIED
+        elif isinstance(error, azure_exceptions.HttpResponseError):
+            status = error.status_code
+        elif isinstance(error, azure_exceptions.ServiceRequestError):
+            return EXIT_CODE_TRANSIENT
+        elif isinstance(error, requests.exceptions.HTTPError) and error.response is not None:
+            status = error.response.status_code
+        elif isinstance(error, (requests.exceptions.ConnectionError, requests.exceptions.Timeout,
+                                ConnectionError, TimeoutError)):
+            return EXIT_CODE_TRANSIENT
+        if status is None:
+            return EXIT_CODE_UNKNOWN
+        return Storage._status_error(status, "").exit_code
+
+    @staticmethod
+    def _status_error(status: int, message: str) -> StorageError:
+        if status in (401, 403):
+            return AccessDeniedError(message)
+        if status == 404:
+            return ModelNotFoundError(message)
+        if status in (408, 429) or status >= 500:
+            return TransientStorageError(message)
+        return StorageError(message)
+
+    @staticmethod
+    def _is_download_completed(uri, out_dir: str) -> bool:
+        marker = os.path.join(out_dir, _COMPLETION_MARKER)
+        if not os.path.isfile(marker):
+            return False
+        with open(marker) as f:
+            return f.read() == uri
+
     @staticmethod
     def _update_with_storage_spec():
         storage_secret_json = json.loads(os.environ.get("STORAGE_CONFIG", "{}"))
@@ -118,6 +280,21 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             os.environ["AWS_DEFAULT_REGION"] = storage_secret_json.get("region", "")
             os.environ["AWS_CA_BUNDLE"] = storage_secret_json.get("certificate", "")
             os.environ["awsAnonymousCredential"] = storage_secret_json.get("anonymous", "")
+            # The on-prem stores such as Minio or Ceph may need the path style addressing, an unverified certificate
+            # or the key of the objects encrypted with SSE-C
+            if "force_path_style" in storage_secret_json:
+                force_path_style = str(storage_secret_json["force_path_style"]).lower() == "true"
+                os.environ["S3_USER_VIRTUAL_BUCKET"] = "false" if force_path_style else "true"
+            if "verify_ssl" in storage_secret_json:
+                os.environ["S3_VERIFY_SSL"] = str(storage_secret_json["verify_ssl"])
+            if "sse_customer_key" in storage_secret_json:
+                os.environ["AWS_SSE_CUSTOMER_KEY"] = storage_secret_json["sse_customer_key"]
+
+        if storage_secret_json.get("type", "") == "swift":
+            # The Keystone credentials of the storage config are the variables of the openstack clients
+            for key, env in _SWIFT_STORAGE_SPEC_ENVS.items():
+                if key in storage_secret_json:
+                    os.environ[env] = storage_secret_json[key]
 
         if storage_secret_json.get("type", "") == "hdfs" or storage_secret_json.get("type", "") == "webhdfs":
             temp_dir = tempfile.mkdtemp()
@@ -136,12 +313,31 @@ class Storage(object):  # pylint: disable=too-few-public-methods
 
     @staticmethod
     def get_S3_config():
-        # anon environment variable defined in s3_secret.go
+        # anon and virtual bucket environment variables defined in s3_secret.go
         anon = ("True" == os.getenv("awsAnonymousCredential", "false").capitalize())
-        if anon:
-            return Config(signature_version=UNSIGNED)
-        else:
+        path_style = os.getenv("S3_USER_VIRTUAL_BUCKET", "true").lower() == "false"
+        if not anon and not path_style:
             return None
+        kwargs = {}
+        if anon:
+            kwargs["signature_version"] = UNSIGNED
+        if path_style:
+            kwargs["s3"] = {"addressing_style": "path"}
+        return Config(**kwargs)
+
+    @staticmethod
+    def _get_s3_sse_customer_args():
+        # The objects encrypted with SSE-C are read with the base64 encoded 256-bit key of AWS_SSE_CUSTOMER_KEY
+        encoded_key = os.getenv("AWS_SSE_CUSTOMER_KEY")
+        if not encoded_key:
+            return {}
+        try:
+            key = base64.b64decode(encoded_key, validate=True)
+        except binascii.Error:
+            key = b""
+        if len(key) != 32:
+            raise ValueError("AWS_SSE_CUSTOMER_KEY must be a base64 encoded 256-bit key")
+        return {"SSECustomerAlgorithm": "AES256", "SSECustomerKey": key}
 
     @staticmethod
     def _download_s3(uri, temp_dir: str):
@@ -157,12 +353,97 @@ class Storage(object):  # pylint: disable=too-few-public-methods
         endpoint_url = os.getenv("AWS_ENDPOINT_URL")
         if endpoint_url:
             kwargs.update({"endpoint_url": endpoint_url})
+        # The CA bundle of AWS_CA_BUNDLE is picked up by boto3, the certificate is not verified at all when
+        # S3_VERIFY_SSL is disabled
+        if os.getenv("S3_VERIFY_SSL", "").lower() in ["0", "false"]:
+            kwargs.update({"verify": False})
+        sse_args = Storage._get_s3_sse_customer_args()
         s3 = boto3.resource("s3", **kwargs)
+        Storage._download_s3_objects(s3, uri, temp_dir, sse_args)
+
+    @staticmethod
+    def _object_store_resource(endpoint_url: str, region: str, addressing_style: str, access_key_id: str = None,
+                               secret_access_key: str = None, session_token: str = None):
+        # The S3 compatible stores are read with their own endpoint, region and credentials rather than the AWS ones
+        # of the environment, the public buckets are read without signing the requests
+        config = {"s3": {"addressing_style": addressing_style}}
+        kwargs = {}
+        if access_key_id:
+            config["signature_version"] = "s3v4"
+            kwargs = {"aws_access_key_id": access_key_id, "aws_secret_access_key": secret_access_key,
+                      "aws_session_token": session_token}
+        else:
+            config["signature_version"] = UNSIGNED
+        return boto3.resource("s3", endpoint_url=endpoint_url, region_name=region, config=Config(**config), **kwargs)
+
+    @staticmethod
+    def _download_oss(uri, temp_dir: str):
+        # OSS only accepts the virtual hosted style requests on the endpoint of the region of the bucket
+        region = os.getenv("OSS_REGION")
+        if not region:
+            raise ValueError("OSS_REGION of the buckets is required to download from %s" % uri)
+        endpoint_url = os.getenv("OSS_ENDPOINT") or f"https://oss-{region}.aliyuncs.com"
+        s3 = Storage._object_store_resource(endpoint_url, region, "virtual", os.getenv("OSS_ACCESS_KEY_ID"),
+                                            os.getenv("OSS_ACCESS_KEY_SECRET"), os.getenv("OSS_SESSION_TOKEN"))
+        Storage._download_s3_objects(s3, uri, temp_dir)
+
+    @staticmethod
+    def _download_cos(uri, temp_dir: str):
+        # The requests are signed with the HMAC credentials, or carry the IAM bearer token of the API key instead of a
+        # signature
+        region = os.getenv("COS_REGION")
+        if not region:
+            raise ValueError("COS_REGION of the buckets is required to download from %s" % uri)
+        endpoint_url = os.getenv("COS_ENDPOINT") or f"https://s3.{region}.cloud-object-storage.appdomain.cloud"
+        s3 = Storage._object_store_resource(endpoint_url, region, "path", os.getenv("COS_HMAC_ACCESS_KEY_ID"),
+                                            os.getenv("COS_HMAC_SECRET_ACCESS_KEY"))
+        api_key = os.getenv("COS_API_KEY")
+        if api_key and not os.getenv("COS_HMAC_ACCESS_KEY_ID"):
+            s3.meta.client.meta.events.register("before-sign.s3", Storage._cos_iam_authorizer(api_key))
+        Storage._download_s3_objects(s3, uri, temp_dir)
+
+    @staticmethod
+    def _cos_iam_authorizer(api_key: str):
+        endpoint = os.getenv("COS_IAM_ENDPOINT", _COS_IAM_ENDPOINT)
+        lock = threading.Lock()
+        token = {"value": None, "expires": 0}
+
+        def authorize(request, **_):
+            with lock:
+                if token["value"] is None or time.time() + _COS_IAM_TOKEN_RENEWAL_SECONDS > token["expires"]:
+                    response = requests.post(endpoint, headers={"Accept": "application/json"}, timeout=60,
+                                             data={"grant_type": "urn:ibm:params:oauth:grant-type:apikey",
+                                                   "apikey": api_key})
+                    response.raise_for_status()
+                    body = response.json()
+                    token["value"] = body["access_token"]
+                    token["expires"] = time.time() + body.get("expires_in", 3600)
+                request.headers["Authorization"] = "Bearer " + token["value"]
+
+        return authorize
+
+    @staticmethod
+    def _download_r2(uri, temp_dir: str):
+        # R2 signs the requests of every bucket with the auto region, the buckets created with a jurisdiction have
+        # their own endpoint
+        account_id = os.getenv("R2_ACCOUNT_ID")
+        if not account_id:
+            raise ValueError("R2_ACCOUNT_ID of the buckets is required to download from %s" % uri)
+        host = account_id
+        if os.getenv("R2_JURISDICTION"):
+            host += "." + os.getenv("R2_JURISDICTION")
+        s3 = Storage._object_store_resource(f"https://{host}.r2.cloudflarestorage.com", "auto", "path",
+                                            os.getenv("R2_ACCESS_KEY_ID"), os.getenv("R2_SECRET_ACCESS_KEY"))
+        Storage._download_s3_objects(s3, uri, temp_dir)
+
+    @staticmethod
+    def _download_s3_objects(s3, uri, temp_dir: str, sse_args: Dict = None):
+        sse_args = sse_args or {}
         parsed = urlparse(uri, scheme='s3')
         bucket_name = parsed.netloc
         bucket_path = parsed.path.lstrip('/')
 
-        count = 0
+        files = []
         bucket = s3.Bucket(bucket_name)
         for obj in bucket.objects.filter(Prefix=bucket_path):
             # Skip where boto3 lists the directory as an object
@@ -187,18 +468,28 @@ class Storage(object):  # pylint: disable=too-few-public-methods
                 if bucket_path == obj.key
                 else obj.key.replace(bucket_path, "", 1).lstrip("/")
             )
-            target = f"{temp_dir}/{target_key}"
-            if not os.path.exists(os.path.dirname(target)):
-                os.makedirs(os.path.dirname(target), exist_ok=True)
-            bucket.download_file(obj.key, target)
-            logging.info('Downloaded object %s to %s' % (obj.key, target))
-            count = count + 1
-        if count == 0:
-            raise RuntimeError(
+            files.append((obj.key, obj.size, f"{temp_dir}/{target_key}"))
+        if len(files) == 0:
+            raise ModelNotFoundError(
                 "Failed to fetch model. No model found in %s." % bucket_path)
 
+        def download_file(key, target):
+            if sse_args:
+                bucket.download_file(key, target, ExtraArgs=sse_args)
+            else:
+                bucket.download_file(key, target)
+            logging.info('Downloaded object %s to %s' % (key, target))
+
+        def download_range(key, start, end):
+            response = s3.meta.client.get_object(Bucket=bucket_name, Key=key, Range=f"bytes={start}-{end}",
+                                                 **sse_args)
+            return response["Body"].read()
+
+        Storage._download_files(files, download_file, download_range)
+
         # Unpack compressed file, supports .tgz, tar.gz and zip file formats.
-        if count == 1:
+        if len(files) == 1:
+            target = files[0][2]
             mimetype, _ = mimetypes.guess_type(target)
             if mimetype in ["application/x-tar", "application/zip"]:
                 Storage._unpack_archive_file(target, mimetype, temp_dir)
@@ -218,6 +509,8 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             prefix = prefix + "/"
         blobs = bucket.list_blobs(prefix=prefix)
         count = 0
+        files = []
+        blobs_by_name = {}
         for blob in blobs:
             # Replace any prefix from the object key with temp_dir
             subdir_object_key = blob.name.replace(bucket_path, "", 1).lstrip("/")
@@ -229,19 +522,112 @@ class Storage(object):  # pylint: disable=too-few-public-methods
                     os.makedirs(local_object_dir, exist_ok=True)
             if subdir_object_key.strip() != "" and not subdir_object_key.endswith("/"):
                 dest_path = os.path.join(temp_dir, subdir_object_key)
-                logging.info("Downloading: %s", dest_path)
-                blob.download_to_filename(dest_path)
+                files.append((blob.name, blob.size, dest_path))
+                blobs_by_name[blob.name] = blob
             count = count + 1
         if count == 0:
-            raise RuntimeError(
+            raise ModelNotFoundError(
                 "Failed to fetch model. No model found in %s." % uri)
 
+        def download_file(name, target):
+            logging.info("Downloading: %s", target)
+            blobs_by_name[name].download_to_filename(target)
+
+        def download_range(name, start, end):
+            return blobs_by_name[name].download_as_bytes(start=start, end=end)
+
+        Storage._download_files(files, download_file, download_range)
+
         # Unpack compressed file, supports .tgz, tar.gz and zip file formats.
         if count == 1:
             mimetype, _ = mimetypes.guess_type(blob.name)
             if mimetype in ["application/x-tar", "application/zip"]:
                 Storage._unpack_archive_file(dest_path, mimetype, temp_dir)
 
+    @staticmethod
+    def _get_download_workers() -> int:
+        workers = os.getenv(_DOWNLOAD_WORKERS_ENV, "")
+        if not workers:
+            return _DEFAULT_DOWNLOAD_WORKERS
+        if not workers.isdigit() or int(workers) <= 0:
+            raise ValueError(f"Invalid {_DOWNLOAD_WORKERS_ENV} {workers}, must be a positive integer")
+        return int(workers)
+
+    @staticmethod
+    def _download_files(files, download_file, download_range):
+        """Downloads the (key, size, target) files with a pool of workers. The files up to a part are downloaded with
+        download_file(key, target), the larger ones in parts with download_range(key, start, end) returning the bytes of
+        the inclusive range. The files already downloaded and the parts completed before a restart are skipped."""
+        progress = _DownloadProgress(sum(size for _, size, _ in files))
+        lock = threading.Lock()
+        partial_files = []
+        with ThreadPoolExecutor(max_workers=Storage._get_download_workers()) as executor:
+            futures = []
+            for key, size, target in files:
+                os.makedirs(os.path.dirname(target) or ".", exist_ok=True)
+                parts_file = target + _PARTS_FILE_SUFFIX
+                if os.path.isfile(target) and os.path.getsize(target) == size and not os.path.exists(parts_file):
+                    logging.info("Skipping %s, already downloaded", target)
+                    progress.update(size)
+                elif size <= _DOWNLOAD_PART_SIZE:
+                    futures.append(executor.submit(Storage._download_whole_file, download_file, key, size, target,
+                                                   progress))
+                else:
+                    partial_file = target + _PARTIAL_FILE_SUFFIX
+                    for start in Storage._prepare_partial_file(partial_file, parts_file, size, progress):
+                        end = min(start + _DOWNLOAD_PART_SIZE, size) - 1
+                        futures.append(executor.submit(Storage._download_part, download_range, key, start, end,
+                                                       partial_file, parts_file, lock, progress))
+                    partial_files.append((partial_file, parts_file, target))
+            for future in futures:
+                future.result()
+        for partial_file, parts_file, target in partial_files:
+            os.replace(partial_file, target)
+            os.remove(parts_file)
+
+    @staticmethod
+    def _download_whole_file(download_file, key, size, target, progress):
+        download_file(key, target)
+        progress.update(size)
+
+    @staticmethod
+    def _prepare_partial_file(partial_file, parts_file, size, progress):
+        """Returns the offsets of the parts left to download, the parts file starts with the size of the file and of
+        its parts so a changed object or part size restarts the download"""
+        header = f"{size} {_DOWNLOAD_PART_SIZE}"
+        completed = set()
+        if os.path.isfile(partial_file) and os.path.isfile(parts_file):
+            with open(parts_file) as f:
+                lines = f.read().splitlines()
+            if lines and lines[0] == header:
+                completed = {int(line) for line in lines[1:] if line.isdigit()}
+        if not completed:
+            with open(partial_file, "wb") as f:
+                f.truncate(size)
+            with open(parts_file, "w") as f:
+                f.write(header + "\n")
+        offsets = range(0, size, _DOWNLOAD_PART_SIZE)
+        if completed:
+            logging.info("Resuming %s from %d of %d parts", partial_file, len(completed), len(offsets))
+            for start in completed:
+                progress.update(min(start + _DOWNLOAD_PART_SIZE, size) - start)
+        return [start for start in offsets if start not in completed]
+
+    @staticmethod
+    def _download_part(download_range, key, start, end, partial_file, parts_file, lock, progress):
+        data = download_range(key, start, end)
+        if len(data) != end - start + 1:
+            raise RuntimeError(f"Failed to download the bytes {start}-{end} of {key}, got {len(data)} bytes")
+        with open(partial_file, "r+b") as f:
+            f.seek(start)
+            f.write(data)
+            f.flush()
+            os.fsync(f.fileno())
+        with lock:
+            with open(parts_file, "a") as f:
+                f.write(f"{start}\n")
+        progress.update(len(data))
+
     @staticmethod
     def _load_hdfs_configuration() -> Dict:
         config = {
@@ -256,6 +642,8 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             "N_THREADS": "2",
             "KERBEROS_KEYTAB": None,
             "KERBEROS_PRINCIPAL": None,
+            "KRB5_CONF": None,
+            "KERBEROS_RENEW_SECONDS": _DEFAULT_KERBEROS_RENEW_SECONDS,
         }
 
         secret_dir = _HDFS_SECRET_DIRECTORY
@@ -310,13 +698,24 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             headers = json.loads(config["HEADERS"])
             s.headers.update(headers)
 
+        renewer = None
         if config["KERBEROS_PRINCIPAL"]:
+            if config["KRB5_CONF"]:
+                os.environ["KRB5_CONFIG"] = config["KRB5_CONF"]
+            # The ticket is kept in a cache of the storage initializer, the ticket of the keytab principal does not
+            # replace the default cache of the image
+            ccache_file = os.path.join(tempfile.mkdtemp(), "krb5cc")
+            os.environ["KRB5CCNAME"] = "FILE:" + ccache_file
             context = krbContext(
                 using_keytab=True,
                 principal=config["KERBEROS_PRINCIPAL"],
-                keytab_file=config["KERBEROS_KEYTAB"]
+                keytab_file=config["KERBEROS_KEYTAB"],
+                ccache_file=ccache_file
             )
             context.init_with_keytab()
+            renewer = _KerberosTicketRenewer(context, config["KERBEROS_PRINCIPAL"],
+                                             float(config["KERBEROS_RENEW_SECONDS"]))
+            renewer.start()
             client = KerberosClient(
                 config["HDFS_NAMENODE"],
                 proxy=config["USER_PROXY"],
@@ -331,16 +730,233 @@ class Storage(object):  # pylint: disable=too-few-public-methods
                 session=s
             )
 
-        # Check path exists and get path status
-        # Raises HdfsError when path does not exist
-        status = client.status(path)
+        try:
+            # Check path exists and get path status
+            # Raises HdfsError when path does not exist
+            status = client.status(path)
 
-        if status["type"] == "FILE":
-            client.download(path, out_dir, n_threads=1)
-        else:
-            files = client.list(path)
-            for f in files:
-                client.download(f"{path}/{f}", out_dir, n_threads=int(config["N_THREADS"]))
+            if status["type"] == "FILE":
+                client.download(path, out_dir, n_threads=1)
+            else:
+                files = client.list(path)
+                for f in files:
+                    client.download(f"{path}/{f}", out_dir, n_threads=int(config["N_THREADS"]))
+        finally:
+            if renewer:
+                renewer.stop()
+
+    @staticmethod
+    def _parse_swift_uri(uri):
+        # swift://<container>[/<prefix>], e.g. swift://models/sklearn/iris
+        container, _, prefix = uri[len(_SWIFT_PREFIX):].partition("/")
+        if not container:
+            raise ValueError("Invalid Swift uri %s, expected swift://<container>[/<prefix>]" % uri)
+        return container, prefix.strip("/")
+
+    @staticmethod
+    def _swift_connection(preauth=None):
+        """Returns a Swift connection authenticated with the Keystone credentials of the OS_* variables, or with the
+        pre-authenticated (storage url, token) of OS_STORAGE_URL and OS_AUTH_TOKEN"""
+        from swiftclient.client import Connection
+
+        options = {
+            "cacert": os.getenv("OS_CACERT"),
+            "insecure": os.getenv("OS_INSECURE", "false").lower() == "true",
+        }
+        if preauth is None and os.getenv("OS_STORAGE_URL") and os.getenv("OS_AUTH_TOKEN"):
+            preauth = (os.getenv("OS_STORAGE_URL"), os.getenv("OS_AUTH_TOKEN"))
+        if preauth is not None:
+            return Connection(preauthurl=preauth[0], preauthtoken=preauth[1], **options)
+        if not os.getenv("OS_AUTH_URL"):
+            raise AccessDeniedError("OS_AUTH_URL, or OS_STORAGE_URL and OS_AUTH_TOKEN, are required to download from "
+                                    "Swift")
+        os_options = {
+            "project_name": os.getenv("OS_PROJECT_NAME"),
+            "project_id": os.getenv("OS_PROJECT_ID"),
+            "user_domain_name": os.getenv("OS_USER_DOMAIN_NAME"),
+            "project_domain_name": os.getenv("OS_PROJECT_DOMAIN_NAME"),
+            "region_name": os.getenv("OS_REGION_NAME"),
+        }
+        if os.getenv("OS_APPLICATION_CREDENTIAL_ID"):
+            os_options.update({
+                "auth_type": "v3applicationcredential",
+                "application_credential_id": os.getenv("OS_APPLICATION_CREDENTIAL_ID"),
+                "application_credential_secret": os.getenv("OS_APPLICATION_CREDENTIAL_SECRET"),
+            })
+        return Connection(authurl=os.getenv("OS_AUTH_URL"),
+                          user=os.getenv("OS_USERNAME"),
+                          key=os.getenv("OS_PASSWORD"),
+                          auth_version=os.getenv("OS_IDENTITY_API_VERSION", "3"),
+                          os_options={key: value for key, value in os_options.items() if value},
+                          **options)
+
+    @staticmethod
+    def _download_swift(uri, temp_dir: str):
+        from swiftclient.exceptions import ClientException
+
+        container, prefix = Storage._parse_swift_uri(uri)
+        logging.info("Connecting to Swift container: [%s], prefix: [%s]", container, prefix)
+        connection = Storage._swift_connection()
+        try:
+            # The connections of the download workers reuse the token of the first connection
+            preauth = connection.get_auth()
+            _, objects = connection.get_container(container, prefix=prefix or None, full_listing=True)
+            files = []
+            for obj in objects:
+                name = obj["name"]
+                # Skip the pseudo directories created by the swift clients
+                if name.endswith("/") or obj.get("content_type") == "application/directory":
+                    continue
+                # The layout is the same as for the S3 objects, see _download_s3_objects
+                target_key = name.rsplit("/", 1)[-1] if prefix == name else name.replace(prefix, "", 1).lstrip("/")
+                files.append((name, obj["bytes"], f"{temp_dir}/{target_key}"))
+            if len(files) == 0:
+                raise ModelNotFoundError("Failed to fetch model. No model found in %s." % uri)
+
+            connections = threading.local()
+
+            def worker_connection():
+                if not hasattr(connections, "connection"):
+                    connections.connection = Storage._swift_connection(preauth)
+                return connections.connection
+
+            def download_file(key, target):
+                _, body = worker_connection().get_object(container, key, resp_chunk_size=1024 * 1024)
+                with open(target, "wb") as f:
+                    for chunk in body:
+                        f.write(chunk)
+                logging.info('Downloaded object %s to %s' % (key, target))
+
+            def download_range(key, start, end):
+                _, data = worker_connection().get_object(container, key, headers={"Range": f"bytes={start}-{end}"})
+                return data
+
+            Storage._download_files(files, download_file, download_range)
+        except ClientException as e:
+            if e.http_status:
+                raise Storage._status_error(e.http_status, "Failed to download %s: %s" % (uri, e)) from e
+            raise TransientStorageError("Failed to download %s: %s" % (uri, e)) from e
+
+        # Unpack compressed file, supports .tgz, tar.gz and zip file formats.
+        if len(files) == 1:
+            target = files[0][2]
+            mimetype, _ = mimetypes.guess_type(target)
+            if mimetype in ["application/x-tar", "application/zip"]:
+                Storage._unpack_archive_file(target, mimetype, temp_dir)
+
+    @staticmethod
+    def _parse_hf_uri(uri):
+        # hf://<owner>/<repo>[:<revision>], e.g. hf://meta-llama/Llama-3-8b:main
+        repo_id, _, revision = uri[len(_HF_PREFIX):].strip("/").partition(":")
+        if len(repo_id.split("/")) != 2:
+            raise ValueError("Invalid Hugging Face Hub uri %s, expected hf://<owner>/<repo>[:<revision>]" % uri)
+        return repo_id, revision or None
+
+    @staticmethod
+    def _get_hf_patterns(env_key):
+        # Comma separated file patterns, e.g. *.safetensors,*.json
+        patterns = [pattern.strip() for pattern in os.getenv(env_key, "").split(",") if pattern.strip()]
+        return patterns or None
+
+    @staticmethod
+    def _download_hf(uri, out_dir: str):
+        from huggingface_hub import snapshot_download
+
+        repo_id, revision = Storage._parse_hf_uri(uri)
+        allow_patterns = Storage._get_hf_patterns("HF_ALLOW_PATTERNS")
+        ignore_patterns = Storage._get_hf_patterns("HF_IGNORE_PATTERNS")
+        logging.info("Downloading Hugging Face Hub repo: [%s], revision: [%s], allow patterns: [%s], "
+                     "ignore patterns: [%s]", repo_id, revision, allow_patterns, ignore_patterns)
+        # The token is only needed by the gated and private repos
+        snapshot_download(repo_id=repo_id,
+                          revision=revision,
+                          local_dir=out_dir,
+                          allow_patterns=allow_patterns,
+                          ignore_patterns=ignore_patterns,
+                          token=os.getenv("HF_TOKEN") or None)
+
+    @staticmethod
+    def _parse_oci_uri(uri):
+        # oci://<registry>/<repository>[:<tag>|@<digest>], e.g. oci://ghcr.io/kserve/models/sklearn:v1
+        target = uri[len(_OCI_PREFIX):].strip("/")
+        registry, _, repository = target.partition("/")
+        if not repository or ("." not in registry and ":" not in registry and registry != "localhost"):
+            raise ValueError("Invalid OCI uri %s, expected oci://<registry>/<repository>[:<tag>|@<digest>]" % uri)
+        return registry, target
+
+    @staticmethod
+    def _get_oci_credentials(registry):
+        # The dockerconfigjson secrets hold the credentials under "auths", the legacy dockercfg ones at the top level
+        for secret_dir in sorted(glob.glob(os.path.join(_OCI_SECRET_DIRECTORY, "*"))):
+            for key, auths_key in ((".dockerconfigjson", "auths"), (".dockercfg", None)):
+                config_file = os.path.join(secret_dir, key)
+                if not os.path.exists(config_file):
+                    continue
+                with open(config_file) as f:
+                    config = json.load(f)
+                auths = config.get(auths_key, {}) if auths_key else config
+                for server, auth in auths.items():
+                    host = re.sub("^https?://", "", server).split("/")[0]
+                    if host not in (registry, "index." + registry):
+                        continue
+                    if auth.get("username"):
+                        return auth["username"], auth.get("password", "")
+                    if auth.get("auth"):
+                        username, _, password = base64.b64decode(auth["auth"]).decode("utf-8").partition(":")
+                        return username, password
+        return None, None
+
+    @staticmethod
+    def _verify_oci_digest(path, digest):
+        algorithm, _, expected = digest.partition(":")
+        hasher = hashlib.new(algorithm)
+        with open(path, "rb") as f:
+            for chunk in iter(lambda: f.read(1024 * 1024), b""):
+                hasher.update(chunk)
+        if hasher.hexdigest() != expected:
+            os.remove(path)
+            raise CorruptModelError("Digest mismatch of %s, expected %s but got %s:%s" %
+                               (path, digest, algorithm, hasher.hexdigest()))
+
+    @staticmethod
+    def _download_oci(uri, out_dir: str):
+        from oras.client import OrasClient
+
+        registry, target = Storage._parse_oci_uri(uri)
+        client = OrasClient(hostname=registry)
+        username, password = Storage._get_oci_credentials(registry)
+        if username:
+            client.set_basic_auth(username, password)
+        logging.info("Pulling OCI artifact: [%s], authenticated: [%s]", target, username is not None)
+        container = client.get_container(target)
+        manifest = client.get_manifest(container)
+        out_dir = os.path.realpath(out_dir)
+        count = 0
+        for layer in manifest.get("layers", []):
+            annotations = layer.get("annotations") or {}
+            # Like oras, only the layers with a title are files of the artifact, the title is their relative path
+            title = annotations.get(_OCI_TITLE_ANNOTATION)
+            if not title:
+                logging.info("Skipping layer %s without a title", layer["digest"])
+                continue
+            path = os.path.realpath(os.path.join(out_dir, title))
+            if os.path.commonpath([out_dir, path]) != out_dir:
+                raise RuntimeError("Layer %s of %s is outside of the output directory" % (title, target))
+            os.makedirs(os.path.dirname(path), exist_ok=True)
+            if annotations.get(_OCI_UNPACK_ANNOTATION) == "true":
+                # The directories are pushed as gzipped tarballs and unpacked under their title
+                archive = path + ".tar.gz"
+                client.download_blob(container, layer["digest"], archive)
+                Storage._verify_oci_digest(archive, layer["digest"])
+                with tarfile.open(archive, "r:gz") as tar:
+                    tar.extractall(os.path.dirname(path))
+                os.remove(archive)
+            else:
+                client.download_blob(container, layer["digest"], path)
+                Storage._verify_oci_digest(path, layer["digest"])
+            count = count + 1
+        if count == 0:
+            raise ModelNotFoundError("Failed to fetch model. No files found in the layers of %s." % target)
 
     @staticmethod
     def _download_azure_blob(uri, out_dir: str):  # pylint: disable=too-many-locals
@@ -481,7 +1097,7 @@ class Storage(object):  # pylint: disable=too-few-public-methods
     def _download_local(uri, out_dir=None):
         local_path = uri.replace(_LOCAL_PREFIX, "", 1)
         if not os.path.exists(local_path):
-            raise RuntimeError("Local path %s does not exist." % (uri))
+            raise ModelNotFoundError("Local path %s does not exist." % (uri))
 
         if out_dir is None:
             return local_path
@@ -509,6 +1125,69 @@ class Storage(object):  # pylint: disable=too-few-public-methods
 
         return out_dir
 
+    @staticmethod
+    def _presign(uri):
+        """Returns the files of the uri, with their relative path, size and presigned url, from the presign broker. The
+        broker authenticates the pod with the client certificate and the service account token bound to its audience."""
+        broker_url = os.getenv(_PRESIGN_BROKER_URL_ENV)
+        broker_dir = os.getenv(_PRESIGN_BROKER_DIR_ENV, _DEFAULT_PRESIGN_BROKER_DIR)
+        kwargs = {}
+        cert_file = os.path.join(broker_dir, "tls.crt")
+        if os.path.isfile(cert_file):
+            kwargs["cert"] = (cert_file, os.path.join(broker_dir, "tls.key"))
+        ca_file = os.path.join(broker_dir, "ca.crt")
+        if os.path.isfile(ca_file):
+            kwargs["verify"] = ca_file
+        headers = {}
+        token_file = os.path.join(broker_dir, "token")
+        if os.path.isfile(token_file):
+            with open(token_file) as f:
+                headers["Authorization"] = "Bearer " + f.read().strip()
+        response = requests.post(broker_url, json={"uri": uri}, headers=headers,
+                                 timeout=_PRESIGN_BROKER_TIMEOUT_SECONDS, **kwargs)
+        if response.status_code != 200:
+            raise Storage._status_error(response.status_code, "Presign broker %s returned a %s response code for %s: %s"
+                                        % (broker_url, response.status_code, uri, response.text))
+        files = response.json().get("files") or []
+        if len(files) == 0:
+            raise ModelNotFoundError("Failed to fetch model. No model found in %s." % uri)
+        return files
+
+    @staticmethod
+    def _download_presigned(uri, out_dir: str):
+        files = []
+        for presigned in Storage._presign(uri):
+            path = os.path.normpath(presigned["path"])
+            if os.path.isabs(path) or path == ".." or path.startswith("../"):
+                raise StorageError("Presign broker returned the path %s outside of the model directory" % path)
+            files.append((presigned["url"], int(presigned["size"]), os.path.join(out_dir, path)))
+
+        def download_file(url, target):
+            with requests.get(url, stream=True, timeout=_PRESIGN_BROKER_TIMEOUT_SECONDS) as response:
+                if response.status_code != 200:
+                    raise Storage._status_error(response.status_code, "Presigned url of %s returned a %s response "
+                                                "code." % (target, response.status_code))
+                with open(target, "wb") as out:
+                    shutil.copyfileobj(response.raw, out)
+            logging.info("Downloaded %s", target)
+
+        def download_range(url, start, end):
+            response = requests.get(url, headers={"Range": f"bytes={start}-{end}"},
+                                    timeout=_PRESIGN_BROKER_TIMEOUT_SECONDS)
+            if response.status_code != 206:
+                raise Storage._status_error(response.status_code, "Presigned url returned a %s response code for the "
+                                            "bytes %s-%s." % (response.status_code, start, end))
+            return response.content
+
+        Storage._download_files(files, download_file, download_range)
+
+        # Unpack compressed file, supports .tgz, tar.gz and zip file formats.
+        if len(files) == 1:
+            target = files[0][2]
+            mimetype, _ = mimetypes.guess_type(target)
+            if mimetype in ["application/x-tar", "application/zip"]:
+                Storage._unpack_archive_file(target, mimetype, out_dir)
+
     @staticmethod
     def _download_from_uri(uri, out_dir=None):
         url = urlparse(uri)
@@ -528,7 +1207,8 @@ class Storage(object):  # pylint: disable=too-few-public-methods
 
         with requests.get(uri, stream=True, headers=headers) as response:
             if response.status_code != 200:
-                raise RuntimeError("URI: %s returned a %s response code." % (uri, response.status_code))
+                raise Storage._status_error(response.status_code,
+                                            "URI: %s returned a %s response code." % (uri, response.status_code))
             zip_content_types = ('application/x-zip-compressed', 'application/zip', 'application/zip-compressed')
             if mimetype == 'application/zip' and not response.headers.get('Content-Type', '')\
                     .startswith(zip_content_types):
@@ -571,6 +1251,47 @@ class Storage(object):  # pylint: disable=too-few-public-methods
             archive.extractall(target_dir)
             archive.close()
         except (tarfile.TarError, zipfile.BadZipfile):
-            raise RuntimeError("Failed to unpack archive file. \
+            raise CorruptModelError("Failed to unpack archive file. \
 The file format is not valid.")
         os.remove(file_path)
+
+
+class _DownloadProgress(object):
+    """Logs the downloaded bytes of all the files in steps of 10 percent"""
+
+    def __init__(self, total: int):
+        self.total = total
+        self.downloaded = 0
+        self.reported = 0
+        self.lock = threading.Lock()
+
+    def update(self, size: int):
+        with self.lock:
+            self.downloaded += size
+            percent = self.downloaded * 100 // self.total if self.total else 100
+            if percent >= self.reported + 10:
+                self.reported = percent - percent % 10
+                logging.info("Downloaded %d%% of the model, %d of %d bytes", percent, self.downloaded, self.total)
+
+
+class _KerberosTicketRenewer(threading.Thread):
+    """Acquires the Kerberos ticket again from the keytab on an interval until it is stopped"""
+
+    def __init__(self, context, principal: str, interval: float):
+        super().__init__(name="kerberos-ticket-renewer", daemon=True)
+        self.context = context
+        self.principal = principal
+        self.interval = interval
+        self.stopped = threading.Event()
+
+    def run(self):
+        while not self.stopped.wait(self.interval):
+            try:
+                self.context.init_with_keytab()
+                logging.info("Renewed the Kerberos ticket of %s", self.principal)
+            except Exception:  # pylint: disable=broad-except
+                logging.exception("Failed to renew the Kerberos ticket of %s, retrying in %.0fs", self.principal,
+                                  self.interval)
+
+    def stop(self):
+        self.stopped.set()
@@ -12,9 +12,11 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import json
 import os
 import unittest.mock as mock
 
+import pytest
 from botocore.client import Config
 from botocore import UNSIGNED
 import kserve
@@ -23,6 +25,7 @@ import kserve
 def create_mock_obj(path):
     mock_obj = mock.MagicMock()
     mock_obj.key = path
+    mock_obj.size = 1
     mock_obj.is_dir = False
     return mock_obj
 
@@ -73,7 +76,7 @@ def test_parent_key(mock_storage):
 
     # then
     arg_list = get_call_args(mock_boto3_bucket.download_file.call_args_list)
-    assert arg_list == expected_call_args_list('bar', 'dest_path', paths)
+    assert sorted(arg_list) == sorted(expected_call_args_list('bar', 'dest_path', paths))
 
     mock_boto3_bucket.objects.filter.assert_called_with(Prefix='bar')
 
@@ -91,7 +94,7 @@ def test_no_key(mock_storage):
 
     # then
     arg_list = get_call_args(mock_boto3_bucket.download_file.call_args_list)
-    assert arg_list == expected_call_args_list('', 'dest_path', object_paths)
+    assert sorted(arg_list) == sorted(expected_call_args_list('', 'dest_path', object_paths))
 
     mock_boto3_bucket.objects.filter.assert_called_with(Prefix='')
 
@@ -166,3 +169,129 @@ def test_get_S3_config():
     with mock.patch.dict(os.environ, credentials_and_anon):
         config5 = kserve.Storage.get_S3_config()
     assert config5.signature_version == ANON_CONFIG.signature_version
+
+    with mock.patch.dict(os.environ, {"S3_USER_VIRTUAL_BUCKET": "false"}):
+        config6 = kserve.Storage.get_S3_config()
+    assert config6.s3 == {"addressing_style": "path"}
+
+
+SSE_CUSTOMER_KEY = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+
+@mock.patch('kserve.storage.boto3')
+def test_on_prem_options(mock_storage):
+
+    # given
+    object_key = 'path/to/model/name.pt'
+    env = {"AWS_ENDPOINT_URL": "https://minio.minio:9000", "S3_VERIFY_SSL": "0",
+           "AWS_SSE_CUSTOMER_KEY": SSE_CUSTOMER_KEY}
+
+    # when
+    mock_boto3_bucket = create_mock_boto3_bucket(mock_storage, [object_key])
+    with mock.patch.dict(os.environ, env):
+        kserve.Storage._download_s3(f's3://foo/{object_key}', 'dest_path')
+
+    # then
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["endpoint_url"] == "https://minio.minio:9000"
+    assert kwargs["verify"] is False
+    mock_boto3_bucket.download_file.assert_called_with(
+        object_key, 'dest_path/name.pt',
+        ExtraArgs={"SSECustomerAlgorithm": "AES256", "SSECustomerKey": b"0123456789abcdef0123456789abcdef"})
+
+
+def test_invalid_sse_customer_key():
+    with mock.patch.dict(os.environ, {"AWS_SSE_CUSTOMER_KEY": "c2hvcnQ="}):
+        with pytest.raises(ValueError):
+            kserve.Storage._get_s3_sse_customer_args()
+
+
+def test_storage_spec_on_prem_options():
+    storage_config = {"type": "s3", "endpoint_url": "https://ceph.rgw:8443", "force_path_style": "true",
+                      "verify_ssl": "0", "sse_customer_key": SSE_CUSTOMER_KEY}
+    with mock.patch.dict(os.environ, {"STORAGE_CONFIG": json.dumps(storage_config)}):
+        kserve.Storage._update_with_storage_spec()
+        assert os.environ["AWS_ENDPOINT_URL"] == "https://ceph.rgw:8443"
+        assert os.environ["S3_USER_VIRTUAL_BUCKET"] == "false"
+        assert os.environ["S3_VERIFY_SSL"] == "0"
+        assert os.environ["AWS_SSE_CUSTOMER_KEY"] == SSE_CUSTOMER_KEY
+
+
+@mock.patch('kserve.storage.boto3')
+def test_oss(mock_storage):
+
+    # given
+    object_key = 'path/to/model/name.pt'
+    env = {"OSS_REGION": "cn-hangzhou", "OSS_ACCESS_KEY_ID": "key", "OSS_ACCESS_KEY_SECRET": "secret"}
+
+    # when
+    mock_boto3_bucket = create_mock_boto3_bucket(mock_storage, [object_key])
+    with mock.patch.dict(os.environ, env):
+        kserve.Storage._download_oss(f'oss://foo/{object_key}', 'dest_path')
+
+    # then
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["endpoint_url"] == "https://oss-cn-hangzhou.aliyuncs.com"
+    assert kwargs["region_name"] == "cn-hangzhou"
+    assert kwargs["aws_access_key_id"] == "key"
+    assert kwargs["config"].s3 == {"addressing_style": "virtual"}
+    mock_storage.resource.return_value.Bucket.assert_called_with("foo")
+    mock_boto3_bucket.download_file.assert_called_with(object_key, 'dest_path/name.pt')
+
+
+@mock.patch('kserve.storage.requests')
+@mock.patch('kserve.storage.boto3')
+def test_cos_iam(mock_storage, mock_requests):
+
+    # given
+    object_key = 'path/to/model/name.pt'
+    env = {"COS_REGION": "us-south", "COS_API_KEY": "apikey"}
+    mock_requests.post.return_value.json.return_value = {"access_token": "token", "expires_in": 3600}
+
+    # when
+    create_mock_boto3_bucket(mock_storage, [object_key])
+    with mock.patch.dict(os.environ, env):
+        kserve.Storage._download_cos(f'cos://foo/{object_key}', 'dest_path')
+
+    # then the requests are not signed and carry the bearer token of the API key, requested once
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["endpoint_url"] == "https://s3.us-south.cloud-object-storage.appdomain.cloud"
+    assert kwargs["config"].signature_version == UNSIGNED
+    assert "aws_access_key_id" not in kwargs
+    register = mock_storage.resource.return_value.meta.client.meta.events.register
+    event, authorize = register.call_args[0]
+    assert event == "before-sign.s3"
+    requests = [mock.MagicMock(headers={}), mock.MagicMock(headers={})]
+    for request in requests:
+        authorize(request=request)
+        assert request.headers["Authorization"] == "Bearer token"
+    assert mock_requests.post.call_count == 1
+    assert mock_requests.post.call_args[1]["data"]["apikey"] == "apikey"
+
+
+@mock.patch('kserve.storage.boto3')
+def test_r2(mock_storage):
+
+    # given
+    object_key = 'path/to/model/name.pt'
+    env = {"R2_ACCOUNT_ID": "account", "R2_JURISDICTION": "eu", "R2_ACCESS_KEY_ID": "key",
+           "R2_SECRET_ACCESS_KEY": "secret"}
+
+    # when
+    create_mock_boto3_bucket(mock_storage, [object_key])
+    with mock.patch.dict(os.environ, env):
+        kserve.Storage._download_r2(f'r2://foo/{object_key}', 'dest_path')
+
+    # then
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["endpoint_url"] == "https://account.eu.r2.cloudflarestorage.com"
+    assert kwargs["region_name"] == "auto"
+    assert kwargs["config"].s3 == {"addressing_style": "path"}
+    assert kwargs["config"].signature_version == "s3v4"
+
+
+def test_object_store_region_required():
+    with mock.patch.dict(os.environ, {}, clear=True):
+        for download in (kserve.Storage._download_oss, kserve.Storage._download_cos, kserve.Storage._download_r2):
+            with pytest.raises(ValueError):
+                download('oss://foo/model', 'dest_path')
@@ -12,9 +12,14 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import base64
+import hashlib
 import io
+import json
 import os
+import sys
 import tempfile
+import threading
 import binascii
 import unittest.mock as mock
 import mimetypes
@@ -139,6 +144,7 @@ def test_mock_gcs(mock_storage):
     gcs_path = 'gs://foo/bar'
     mock_obj = mock.MagicMock()
     mock_obj.name = 'mock.object'
+    mock_obj.size = 1
     mock_storage.Client().bucket().list_blobs().__iter__.return_value = [mock_obj]
     assert kserve.Storage.download(gcs_path)
 
@@ -197,3 +203,268 @@ def test_unpack_zip_file():
     kserve.Storage._unpack_archive_file(tar_file, mimetype, out_dir)
     assert os.path.exists(os.path.join(out_dir, 'model.pth'))
     os.remove(os.path.join(out_dir, 'model.pth'))
+
+
+@mock.patch.dict(os.environ, {"HF_TOKEN": "hf_token", "HF_ALLOW_PATTERNS": "*.safetensors, *.json"})
+def test_storage_hf():
+    mock_hf = mock.MagicMock()
+    with mock.patch.dict(sys.modules, {"huggingface_hub": mock_hf}):
+        out_dir = kserve.Storage.download("hf://meta-llama/Llama-3-8b:main")
+    mock_hf.snapshot_download.assert_called_once_with(repo_id="meta-llama/Llama-3-8b",
+                                                      revision="main",
+                                                      local_dir=out_dir,
+                                                      allow_patterns=["*.safetensors", "*.json"],
+                                                      ignore_patterns=None,
+                                                      token="hf_token")
+
+
+def test_storage_hf_invalid_uri():
+    with pytest.raises(ValueError):
+        kserve.Storage._parse_hf_uri("hf://Llama-3-8b")
+
+
+def _oci_layer(title, content):
+    return {"digest": "sha256:" + hashlib.sha256(content).hexdigest(),
+            "annotations": {"org.opencontainers.image.title": title}}
+
+
+def _mock_oras(layers, contents):
+    mock_oras = mock.MagicMock()
+    client = mock_oras.OrasClient.return_value
+    client.get_manifest.return_value = {"layers": layers}
+
+    def download_blob(container, digest, outfile):
+        with open(outfile, "wb") as f:
+            f.write(contents[digest])
+    client.download_blob.side_effect = download_blob
+    return mock_oras, client
+
+
+def test_storage_oci():
+    model, config = b"model weights", b"{}"
+    layers = [_oci_layer("model.joblib", model), _oci_layer("config/model.json", config),
+              {"digest": "sha256:0000", "annotations": {}}]
+    contents = {layer["digest"]: content for layer, content in zip(layers, [model, config])}
+    mock_oras, client = _mock_oras(layers, contents)
+    with tempfile.TemporaryDirectory() as secret_dir, \
+            mock.patch("kserve.storage._OCI_SECRET_DIRECTORY", secret_dir), \
+            mock.patch.dict(sys.modules, {"oras.client": mock_oras}):
+        os.mkdir(os.path.join(secret_dir, "regcred"))
+        with open(os.path.join(secret_dir, "regcred", ".dockerconfigjson"), "w") as f:
+            json.dump({"auths": {"https://ghcr.io": {"auth": base64.b64encode(b"user:pass").decode()}}}, f)
+        out_dir = kserve.Storage.download("oci://ghcr.io/kserve/models/sklearn:v1")
+    mock_oras.OrasClient.assert_called_once_with(hostname="ghcr.io")
+    client.set_basic_auth.assert_called_once_with("user", "pass")
+    client.get_container.assert_called_once_with("ghcr.io/kserve/models/sklearn:v1")
+    assert Path(out_dir, "model.joblib").read_bytes() == model
+    assert Path(out_dir, "config", "model.json").read_bytes() == config
+
+
+def test_storage_oci_digest_mismatch():
+    layers = [_oci_layer("model.joblib", b"model weights")]
+    mock_oras, _ = _mock_oras(layers, {layers[0]["digest"]: b"tampered weights"})
+    with mock.patch.dict(sys.modules, {"oras.client": mock_oras}):
+        with pytest.raises(RuntimeError, match="Digest mismatch"):
+            kserve.Storage.download("oci://ghcr.io/kserve/models/sklearn@sha256:1234")
+
+
+def test_storage_oci_invalid_uri():
+    with pytest.raises(ValueError):
+        kserve.Storage._parse_oci_uri("oci://kserve/sklearn:v1")
+
+
+@mock.patch("kserve.storage._DOWNLOAD_PART_SIZE", 4)
+def test_storage_download_files_resume():
+    content = b"0123456789"
+    with tempfile.TemporaryDirectory() as out_dir:
+        target = os.path.join(out_dir, "model", "model.bin")
+        os.makedirs(os.path.dirname(target))
+        # The first part was downloaded before the storage initializer restarted
+        with open(target + ".kserve-partial", "wb") as f:
+            f.write(content[:4] + bytes(6))
+        with open(target + ".kserve-parts", "w") as f:
+            f.write("10 4\n0\n")
+        download_range = mock.MagicMock(side_effect=lambda key, start, end: content[start:end + 1])
+        download_file = mock.MagicMock()
+        with mock.patch.dict(os.environ, {"STORAGE_DOWNLOAD_WORKERS": "2"}):
+            kserve.Storage._download_files([("model.bin", 10, target)], download_file, download_range)
+        assert sorted(call.args for call in download_range.call_args_list) == [("model.bin", 4, 7),
+                                                                               ("model.bin", 8, 9)]
+        download_file.assert_not_called()
+        assert Path(target).read_bytes() == content
+        assert os.listdir(os.path.dirname(target)) == ["model.bin"]
+
+
+def test_storage_download_workers_invalid():
+    with mock.patch.dict(os.environ, {"STORAGE_DOWNLOAD_WORKERS": "0"}):
+        with pytest.raises(ValueError):
+            kserve.Storage._get_download_workers()
+
+
+@mock.patch(STORAGE_MODULE + ".Storage._download_s3")
+def test_storage_completion_marker(mock_download_s3):
+    with tempfile.TemporaryDirectory() as out_dir:
+        kserve.Storage.download("s3://foo/bar", out_dir)
+        assert Path(out_dir, ".kserve-download-complete").read_text() == "s3://foo/bar"
+        kserve.Storage.download("s3://foo/bar", out_dir)
+        mock_download_s3.assert_called_once_with("s3://foo/bar", out_dir)
+
+
+@pytest.mark.parametrize("error,expected_exit_code", [
+    (botocore.exceptions.ClientError({"Error": {"Code": "NoSuchBucket"}}, "ListObjects"), 66),
+    (botocore.exceptions.ClientError({"Error": {"Code": "AccessDenied"}}, "GetObject"), 77),
+    (botocore.exceptions.ClientError({"ResponseMetadata": {"HTTPStatusCode": 503}}, "GetObject"), 75),
+    (botocore.exceptions.EndpointConnectionError(endpoint_url="https://s3.amazonaws.com"), 75),
+    (kserve.storage.CorruptModelError("Failed to unpack archive file."), 65),
+    (ConnectionResetError(), 75),
+    (ValueError("Invalid OCI uri"), 1),
+])
+def test_storage_exit_code(error, expected_exit_code):
+    assert kserve.Storage.exit_code(error) == expected_exit_code
+
+
+@mock.patch('requests.get', return_value=MockHttpResponse(status_code=403))
+def test_http_uri_access_denied(_):
+    with tempfile.TemporaryDirectory() as out_dir:
+        with pytest.raises(kserve.storage.AccessDeniedError) as e:
+            kserve.Storage.download(HTTPS_URI_TARGZ, out_dir)
+        assert kserve.Storage.exit_code(e.value) == 77
+
+
+def test_unpack_corrupt_archive():
+    with tempfile.TemporaryDirectory() as out_dir:
+        tar_file = os.path.join(out_dir, "model.tgz")
+        Path(tar_file).write_bytes(b"not an archive")
+        with pytest.raises(kserve.storage.CorruptModelError):
+            kserve.Storage._unpack_archive_file(tar_file, "application/x-tar", out_dir)
+
+
+@mock.patch.dict(os.environ, {"STORAGE_PRESIGN_BROKER_URL": "https://presign-broker.kserve/presign"})
+@mock.patch("requests.get")
+@mock.patch("requests.post")
+def test_storage_presign_broker(mock_post, mock_get):
+    broker_response = mock.MagicMock(status_code=200)
+    broker_response.json.return_value = {"files": [
+        {"path": "model.joblib", "size": 5, "url": "https://bucket.s3/model.joblib?X-Amz-Signature=a"},
+        {"path": "meta/config.json", "size": 2, "url": "https://bucket.s3/meta/config.json?X-Amz-Signature=b"},
+    ]}
+    mock_post.return_value = broker_response
+    mock_get.side_effect = lambda url, **kwargs: MockHttpResponse(
+        status_code=200, raw=b"model" if "model.joblib" in url else b"{}")
+    with tempfile.TemporaryDirectory() as out_dir:
+        kserve.Storage.download("s3://models/sklearn", out_dir)
+        assert Path(out_dir, "model.joblib").read_bytes() == b"model"
+        assert Path(out_dir, "meta", "config.json").read_bytes() == b"{}"
+    # The storage uri is presigned by the broker rather than listed with the credentials of the storage
+    assert mock_post.call_args[0][0] == "https://presign-broker.kserve/presign"
+    assert mock_post.call_args[1]["json"] == {"uri": "s3://models/sklearn"}
+
+
+@mock.patch.dict(os.environ, {"STORAGE_PRESIGN_BROKER_URL": "https://presign-broker.kserve/presign"})
+@mock.patch("requests.post")
+def test_storage_presign_broker_errors(mock_post):
+    mock_post.return_value = mock.MagicMock(status_code=403, text="namespace not allowed")
+    with tempfile.TemporaryDirectory() as out_dir:
+        with pytest.raises(kserve.storage.AccessDeniedError):
+            kserve.Storage.download("s3://models/sklearn", out_dir)
+
+    # The files outside of the model directory are rejected
+    broker_response = mock.MagicMock(status_code=200)
+    broker_response.json.return_value = {"files": [{"path": "../etc/passwd", "size": 1, "url": "https://bucket.s3/x"}]}
+    mock_post.return_value = broker_response
+    with tempfile.TemporaryDirectory() as out_dir:
+        with pytest.raises(kserve.storage.StorageError):
+            kserve.Storage.download("s3://models/sklearn", out_dir)
+
+
+class MockSwiftClientException(Exception):
+    def __init__(self, message, http_status=None):
+        super().__init__(message)
+        self.http_status = http_status
+
+
+def _mock_swiftclient(objects, contents):
+    mock_swift = mock.MagicMock()
+    mock_swift.exceptions.ClientException = MockSwiftClientException
+    connection = mock_swift.client.Connection.return_value
+    connection.get_auth.return_value = ("https://swift.example.com/v1/AUTH_models", "token")
+    connection.get_container.return_value = ({}, objects)
+    connection.get_object.side_effect = lambda container, name, **kwargs: ({}, iter([contents[name]]))
+    modules = {"swiftclient": mock_swift, "swiftclient.client": mock_swift.client,
+               "swiftclient.exceptions": mock_swift.exceptions}
+    return mock_swift, connection, modules
+
+
+@mock.patch.dict(os.environ, {"OS_AUTH_URL": "https://keystone.example.com:5000/v3",
+                              "OS_APPLICATION_CREDENTIAL_ID": "id", "OS_APPLICATION_CREDENTIAL_SECRET": "secret",
+                              "OS_REGION_NAME": "RegionOne"})
+def test_storage_swift():
+    objects = [{"name": "sklearn/iris/", "bytes": 0, "content_type": "application/directory"},
+               {"name": "sklearn/iris/model.joblib", "bytes": 5, "content_type": "application/octet-stream"},
+               {"name": "sklearn/iris/meta/config.json", "bytes": 2, "content_type": "application/json"}]
+    contents = {"sklearn/iris/model.joblib": b"model", "sklearn/iris/meta/config.json": b"{}"}
+    mock_swift, connection, modules = _mock_swiftclient(objects, contents)
+    with tempfile.TemporaryDirectory() as out_dir, mock.patch.dict(sys.modules, modules):
+        kserve.Storage._download_swift("swift://models/sklearn/iris", out_dir)
+        assert Path(out_dir, "model.joblib").read_bytes() == b"model"
+        assert Path(out_dir, "meta", "config.json").read_bytes() == b"{}"
+    connection.get_container.assert_called_once_with("models", prefix="sklearn/iris", full_listing=True)
+    # The first connection authenticates with the application credential, the workers reuse its token
+    first = mock_swift.client.Connection.call_args_list[0][1]
+    assert first["authurl"] == "https://keystone.example.com:5000/v3"
+    assert first["os_options"] == {"region_name": "RegionOne", "auth_type": "v3applicationcredential",
+                                   "application_credential_id": "id", "application_credential_secret": "secret"}
+    worker = mock_swift.client.Connection.call_args_list[1][1]
+    assert worker["preauthurl"] == "https://swift.example.com/v1/AUTH_models"
+    assert worker["preauthtoken"] == "token"
+
+
+@mock.patch.dict(os.environ, {"OS_STORAGE_URL": "https://swift.example.com/v1/AUTH_models", "OS_AUTH_TOKEN": "token"})
+def test_storage_swift_errors():
+    _, connection, modules = _mock_swiftclient([], {})
+    with tempfile.TemporaryDirectory() as out_dir, mock.patch.dict(sys.modules, modules):
+        with pytest.raises(kserve.storage.ModelNotFoundError):
+            kserve.Storage._download_swift("swift://models/sklearn", out_dir)
+        connection.get_container.side_effect = MockSwiftClientException("Unauthorized", http_status=401)
+        with pytest.raises(kserve.storage.AccessDeniedError):
+            kserve.Storage._download_swift("swift://models/sklearn", out_dir)
+    with pytest.raises(ValueError):
+        kserve.Storage._parse_swift_uri("swift://")
+
+
+def test_kerberos_ticket_renewer():
+    context = mock.MagicMock()
+    renewer = kserve.storage._KerberosTicketRenewer(context, "kserve@EXAMPLE.COM", 0.01)
+    renewed = threading.Event()
+
+    def init_with_keytab():
+        if context.init_with_keytab.call_count == 1:
+            raise RuntimeError("KDC unreachable")
+        renewed.set()
+    context.init_with_keytab.side_effect = init_with_keytab
+    renewer.start()
+    # The failed renewals are retried on the next interval
+    assert renewed.wait(5)
+    renewer.stop()
+    renewer.join(5)
+    assert not renewer.is_alive()
+
+
+def test_storage_hdfs_kerberos():
+    mock_krbcontext, mock_hdfs = mock.MagicMock(), mock.MagicMock()
+    client = mock_hdfs.ext.kerberos.KerberosClient.return_value
+    client.status.return_value = {"type": "FILE"}
+    modules = {"krbcontext": mock_krbcontext, "krbcontext.context": mock_krbcontext.context, "hdfs": mock_hdfs,
+               "hdfs.ext": mock_hdfs.ext, "hdfs.ext.kerberos": mock_hdfs.ext.kerberos}
+    with tempfile.TemporaryDirectory() as secret_dir, tempfile.TemporaryDirectory() as out_dir, \
+            mock.patch.dict(os.environ, {"HDFS_SECRET_DIR": secret_dir}), mock.patch.dict(sys.modules, modules):
+        for name, value in {"HDFS_NAMENODE": "https://namenode:9871", "KERBEROS_PRINCIPAL": "kserve@EXAMPLE.COM",
+                            "KERBEROS_KEYTAB": "keytab", "KRB5_CONF": "[libdefaults]"}.items():
+            Path(secret_dir, name).write_text(value)
+        kserve.Storage._download_hdfs("hdfs://user/kserve/model.joblib", out_dir)
+        # The krb5.conf of the secret is used and the ticket is kept in a cache of the storage initializer
+        assert os.environ["KRB5_CONFIG"] == f"{secret_dir}/KRB5_CONF"
+        kwargs = mock_krbcontext.context.krbContext.call_args[1]
+        assert kwargs["keytab_file"] == f"{secret_dir}/KERBEROS_KEYTAB"
+        assert os.environ["KRB5CCNAME"] == "FILE:" + kwargs["ccache_file"]
+    client.download.assert_called_once_with("/user/kserve/model.joblib", out_dir, n_threads=1)
@@ -50,10 +50,18 @@ class TestV1alpha1InferenceStep(unittest.TestCase):
         # model = kserve.models.v1alpha1_inference_step.V1alpha1InferenceStep()  # noqa: E501
         if include_optional :
             return V1alpha1InferenceStep(
+                backoff = kserve.models.v1alpha1_step_backoff.V1alpha1StepBackoff(
+                    initial_delay_ms = 56, 
+                    max_delay_ms = 56, 
+                    multiplier = 56, ), 
+                circuit_breaker = kserve.models.v1alpha1_step_circuit_breaker.V1alpha1StepCircuitBreaker(
+                    failure_threshold = 56, 
+                    open_duration = 56, ), 
                 condition = '0', 
                 data = '0', 
                 name = '0', 
                 node_name = '0', 
+                retries = 56, 
                 service_name = '0', 
                 service_url = '0', 
                 weight = 56
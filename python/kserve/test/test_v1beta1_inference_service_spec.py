@@ -181,6 +181,32 @@ class TestV1beta1InferenceServiceSpec(unittest.TestCase):
                     volumes = [
                         None
                         ], ), 
+                overlays = {
+                    'key' : kserve.models.v1beta1_inference_service_overlay.V1beta1InferenceServiceOverlay(
+                        explainer = kserve.models.v1beta1_component_overlay.V1beta1ComponentOverlay(
+                            max_replicas = 56, 
+                            min_replicas = 56, 
+                            node_selector = {
+                                'key' : '0'
+                                }, 
+                            resources = None, 
+                            scale_target = 56, ), 
+                        max_replicas = 56, 
+                        min_replicas = 56, 
+                        node_selector = {
+                            'key' : '0'
+                            }, 
+                        resources = None, 
+                        scale_target = 56, 
+                        transformer = kserve.models.v1beta1_component_overlay.V1beta1ComponentOverlay(
+                            max_replicas = 56, 
+                            min_replicas = 56, 
+                            node_selector = {
+                                'key' : '0'
+                                }, 
+                            resources = None, 
+                            scale_target = 56, ), )
+                    }, 
                 predictor = kserve.models.v1beta1_predictor_spec.V1beta1PredictorSpec(
                     active_deadline_seconds = 56, 
                     affinity = None, 
@@ -379,6 +405,16 @@ class TestV1beta1InferenceServiceSpec(unittest.TestCase):
                         termination_message_policy = '0', 
                         tty = True, 
                         working_dir = '0', ), ), 
+                smoke_test = kserve.models.v1beta1_smoke_test_spec.V1beta1SmokeTestSpec(
+                    expected_response = kserve.models.v1beta1_smoke_test_response_matcher.V1beta1SmokeTestResponseMatcher(
+                        conditions = [
+                            '0'
+                            ], 
+                        pattern = '0', ), 
+                    expected_status = 56, 
+                    path = '0', 
+                    payload = '0', 
+                    timeout_seconds = 56, ), 
                 transformer = kserve.models.v1beta1_transformer_spec.V1beta1TransformerSpec(
                     active_deadline_seconds = 56, 
                     affinity = None, 